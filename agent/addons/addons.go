@@ -36,6 +36,7 @@ type IntrospectionConfig struct {
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	PresenceRecorder   presence.Recorder
+	SlowQueryReporter  introspection.SlowQueryReporter
 	Clock              clock.Clock
 	LocalHub           introspection.SimpleHub
 	CentralHub         introspection.StructuredHub
@@ -64,6 +65,7 @@ func StartIntrospection(cfg IntrospectionConfig) error {
 		MachineLock:        cfg.MachineLock,
 		PrometheusGatherer: cfg.PrometheusGatherer,
 		Presence:           cfg.PresenceRecorder,
+		SlowQueries:        cfg.SlowQueryReporter,
 		Clock:              cfg.Clock,
 		LocalHub:           cfg.LocalHub,
 		CentralHub:         cfg.CentralHub,
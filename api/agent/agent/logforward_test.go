@@ -0,0 +1,94 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent_test
+
+import (
+	"encoding/base64"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/agent/agent"
+	apitesting "github.com/juju/juju/api/base/testing"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type LogForwardSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&LogForwardSuite{})
+
+func (s *LogForwardSuite) logForwardCaller(c *gc.C, version int, attrs coretesting.Attrs) apitesting.BestVersionCaller {
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"logforward-enabled": true,
+		"logforward-type":    "loki",
+		"logforward-url":     "https://loki:3100/loki/api/v1/push",
+		"logforward-headers": `{"X-Scope-OrgID": "tenant"}`,
+	}.Merge(attrs))
+	return apitesting.BestVersionCaller{
+		BestVersion: version,
+		APICallerFunc: func(objType string, _ int, _, request string, _, result interface{}) error {
+			c.Check(objType, gc.Equals, "Agent")
+			switch request {
+			case "ModelConfig":
+				*(result.(*params.ModelConfigResult)) = params.ModelConfigResult{
+					Config: cfg.AllAttrs(),
+				}
+			case "LogForwardSecretHeaders":
+				*(result.(*params.SecretContentResult)) = params.SecretContentResult{
+					Content: params.SecretContentParams{
+						Data: map[string]string{
+							"authorization": base64.StdEncoding.EncodeToString([]byte("Bearer token")),
+						},
+					},
+				}
+			default:
+				c.Fatalf("unexpected request %q", request)
+			}
+			return nil
+		},
+	}
+}
+
+func (s *LogForwardSuite) TestLogForwardConfig(c *gc.C) {
+	st, err := agent.NewState(s.logForwardCaller(c, 4, nil))
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, ok, err := st.LogForwardConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(cfg.HTTP.Headers, jc.DeepEquals, map[string]string{
+		"X-Scope-OrgID": "tenant",
+	})
+}
+
+func (s *LogForwardSuite) TestLogForwardConfigWithHeadersSecret(c *gc.C) {
+	st, err := agent.NewState(s.logForwardCaller(c, 4, coretesting.Attrs{
+		"logforward-headers-secret": coresecrets.NewURI().String(),
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, ok, err := st.LogForwardConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(cfg.HTTP.Headers, jc.DeepEquals, map[string]string{
+		"X-Scope-OrgID": "tenant",
+		"authorization": "Bearer token",
+	})
+}
+
+func (s *LogForwardSuite) TestLogForwardConfigWithHeadersSecretNotSupported(c *gc.C) {
+	st, err := agent.NewState(s.logForwardCaller(c, 3, coretesting.Attrs{
+		"logforward-headers-secret": coresecrets.NewURI().String(),
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = st.LogForwardConfig()
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/logfwd/target"
 	"github.com/juju/juju/rpc/params"
 )
 
@@ -91,6 +93,41 @@ func (st *State) IsMaster() (bool, error) {
 	return results.Master, err
 }
 
+// LogForwardConfig returns the current log forward configuration,
+// including any headers held in the user secret referenced by the
+// model's logforward-headers-secret config.
+func (st *State) LogForwardConfig() (*target.RawConfig, bool, error) {
+	modelConfig, err := st.ModelConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	cfg, ok := modelConfig.LogFwdTarget()
+	if !ok || modelConfig.LogFwdHeadersSecret() == "" {
+		return cfg, ok, nil
+	}
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, false, errors.NotSupportedf("log forwarding headers secret on this controller")
+	}
+	var result params.SecretContentResult
+	if err := st.facade.FacadeCall("LogForwardSecretHeaders", nil, &result); err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, false, errors.Trace(result.Error)
+	}
+	headers, err := coresecrets.NewSecretValue(result.Content.Data).Values()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if cfg.HTTP.Headers == nil {
+		cfg.HTTP.Headers = make(map[string]string, len(headers))
+	}
+	for name, value := range headers {
+		cfg.HTTP.Headers[name] = value
+	}
+	return cfg, true, nil
+}
+
 type Entity struct {
 	st  *State
 	tag names.Tag
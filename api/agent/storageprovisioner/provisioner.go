@@ -114,6 +114,33 @@ func (st *State) watchStorageEntities(method string, scope names.Tag) (watcher.S
 	return w, nil
 }
 
+// WatchStorageSnapshots watches for changes to the lifecycles of storage
+// snapshots scoped to the entity with the specified tag.
+func (st *State) WatchStorageSnapshots(scope names.Tag) (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("storage snapshots on this controller")
+	}
+	return st.watchStorageEntities("WatchStorageSnapshots", scope)
+}
+
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the specified tag, including requests to resize them.
+func (st *State) WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("resizing storage on this controller")
+	}
+	return st.watchStorageEntities("WatchVolumeResizes", scope)
+}
+
+// WatchFilesystemResizes watches for changes to filesystems scoped to
+// the entity with the specified tag, including requests to resize them.
+func (st *State) WatchFilesystemResizes(scope names.Tag) (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("resizing storage on this controller")
+	}
+	return st.watchStorageEntities("WatchFilesystemResizes", scope)
+}
+
 // WatchVolumeAttachments watches for changes to volume attachments
 // scoped to the entity with the specified tag.
 func (st *State) WatchVolumeAttachments(scope names.Tag) (watcher.MachineStorageIdsWatcher, error) {
@@ -371,6 +398,107 @@ func (st *State) FilesystemAttachmentParams(ids []params.MachineStorageId) ([]pa
 	return results.Results, nil
 }
 
+// StorageSnapshotParams returns the parameters for taking the storage
+// snapshots with the specified IDs.
+func (st *State) StorageSnapshotParams(ids []string) ([]params.StorageSnapshotParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(ids)),
+	}
+	for i, id := range ids {
+		args.Entities[i].Tag = id
+	}
+	var results params.StorageSnapshotParamsResults
+	err := st.facade.FacadeCall("StorageSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// StartStorageSnapshots records that the storage snapshots with the
+// specified IDs are being taken. The result for a snapshot that was
+// started before, and so must not be taken again, is an AlreadyExists
+// error.
+func (st *State) StartStorageSnapshots(ids []string) ([]params.ErrorResult, error) {
+	return st.storageSnapshotsCall("StartStorageSnapshots", ids)
+}
+
+// RemoveStorageSnapshots removes the dying storage snapshots with the
+// specified IDs, once they have been deleted.
+func (st *State) RemoveStorageSnapshots(ids []string) ([]params.ErrorResult, error) {
+	return st.storageSnapshotsCall("RemoveStorageSnapshots", ids)
+}
+
+func (st *State) storageSnapshotsCall(method string, ids []string) ([]params.ErrorResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(ids)),
+	}
+	for i, id := range ids {
+		args.Entities[i].Tag = id
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall(method, args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// SetStorageSnapshotInfo records the outcome of taking storage snapshots.
+func (st *State) SetStorageSnapshotInfo(snapshots []params.StorageSnapshotInfo) ([]params.ErrorResult, error) {
+	args := params.StorageSnapshotInfos{Snapshots: snapshots}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetStorageSnapshotInfo", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// StorageResizeParams returns the parameters for resizing the volumes
+// or filesystems with the specified tags.
+func (st *State) StorageResizeParams(tags []names.Tag) ([]params.StorageResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.StorageResizeParamsResults
+	err := st.facade.FacadeCall("StorageResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// SetStorageResized records the outcome of resizing volumes or
+// filesystems.
+func (st *State) SetStorageResized(resized []params.StorageResized) ([]params.ErrorResult, error) {
+	args := params.StorageResizedArgs{Resized: resized}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetStorageResized", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(resized) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(resized), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // SetVolumeInfo records the details of newly provisioned volumes.
 func (st *State) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, error) {
 	args := params.Volumes{Volumes: volumes}
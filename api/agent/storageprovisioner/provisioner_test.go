@@ -1301,3 +1301,264 @@ func (s *provisionerSuite) TestLifeServerError(c *gc.C) {
 	c.Assert(results, gc.HasLen, 1)
 	c.Check(results[0].Error, gc.ErrorMatches, "MSG")
 }
+
+func (s *provisionerSuite) TestWatchStorageSnapshots(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 5)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchStorageSnapshots")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-123"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+			*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+				Results: []params.StringsWatchResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 5,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchStorageSnapshots(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *provisionerSuite) TestWatchStorageSnapshotsNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		}),
+		BestVersion: 4,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchStorageSnapshots(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "storage snapshots on this controller not supported")
+}
+
+func (s *provisionerSuite) TestStorageSnapshotParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(request, gc.Equals, "StorageSnapshotParams")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "0/1"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StorageSnapshotParamsResults{})
+		*(result.(*params.StorageSnapshotParamsResults)) = params.StorageSnapshotParamsResults{
+			Results: []params.StorageSnapshotParamsResult{{
+				Result: &params.StorageSnapshotParams{
+					Id:        "0/1",
+					VolumeTag: "volume-0-0",
+					VolumeId:  "vol-0",
+					Provider:  "loop",
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.StorageSnapshotParams([]string{"0/1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(results, jc.DeepEquals, []params.StorageSnapshotParamsResult{{
+		Result: &params.StorageSnapshotParams{
+			Id:        "0/1",
+			VolumeTag: "volume-0-0",
+			VolumeId:  "vol-0",
+			Provider:  "loop",
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestSetStorageSnapshotInfo(c *gc.C) {
+	var callCount int
+	snapshots := []params.StorageSnapshotInfo{
+		{Id: "0/1", SnapshotId: "snapshot-0-1", Size: 1024},
+		{Id: "2", Error: "boom"},
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(request, gc.Equals, "SetStorageSnapshotInfo")
+		c.Check(arg, jc.DeepEquals, params.StorageSnapshotInfos{Snapshots: snapshots})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetStorageSnapshotInfo(snapshots)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 2)
+}
+
+func (s *provisionerSuite) TestStartStorageSnapshots(c *gc.C) {
+	s.assertStorageSnapshotsCall(c, "StartStorageSnapshots", func(st *storageprovisioner.State) ([]params.ErrorResult, error) {
+		return st.StartStorageSnapshots([]string{"0/1", "2"})
+	})
+}
+
+func (s *provisionerSuite) TestRemoveStorageSnapshots(c *gc.C) {
+	s.assertStorageSnapshotsCall(c, "RemoveStorageSnapshots", func(st *storageprovisioner.State) ([]params.ErrorResult, error) {
+		return st.RemoveStorageSnapshots([]string{"0/1", "2"})
+	})
+}
+
+func (s *provisionerSuite) assertStorageSnapshotsCall(
+	c *gc.C, method string, call func(*storageprovisioner.State) ([]params.ErrorResult, error),
+) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(request, gc.Equals, method)
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "0/1"}, {Tag: "2"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := call(st)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, jc.DeepEquals, []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}})
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 6)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchVolumeResizes")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-123"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+			*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+				Results: []params.StringsWatchResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 6,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *provisionerSuite) TestWatchFilesystemResizesNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		}),
+		BestVersion: 5,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchFilesystemResizes(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "resizing storage on this controller not supported")
+}
+
+func (s *provisionerSuite) TestStorageResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(request, gc.Equals, "StorageResizeParams")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "volume-0-0"}, {Tag: "filesystem-1"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StorageResizeParamsResults{})
+		*(result.(*params.StorageResizeParamsResults)) = params.StorageResizeParamsResults{
+			Results: []params.StorageResizeParamsResult{{
+				Result: &params.StorageResizeParams{
+					Tag:           "volume-0-0",
+					ProviderId:    "vol-0",
+					Size:          1024,
+					RequestedSize: 2048,
+					Provider:      "loop",
+				},
+			}, {}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.StorageResizeParams([]names.Tag{
+		names.NewVolumeTag("0/0"),
+		names.NewFilesystemTag("1"),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(results, jc.DeepEquals, []params.StorageResizeParamsResult{{
+		Result: &params.StorageResizeParams{
+			Tag:           "volume-0-0",
+			ProviderId:    "vol-0",
+			Size:          1024,
+			RequestedSize: 2048,
+			Provider:      "loop",
+		},
+	}, {}})
+}
+
+func (s *provisionerSuite) TestSetStorageResized(c *gc.C) {
+	var callCount int
+	resized := []params.StorageResized{
+		{Tag: "volume-0-0", Size: 2048},
+		{Tag: "filesystem-1"},
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(request, gc.Equals, "SetStorageResized")
+		c.Check(arg, jc.DeepEquals, params.StorageResizedArgs{Resized: resized})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetStorageResized(resized)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 2)
+}
@@ -176,3 +176,28 @@ func (sa *StorageAccessor) RemoveStorageAttachment(storageTag names.StorageTag,
 	}
 	return nil
 }
+
+// SetStorageUsage records the usage of the specified storage instances,
+// which must be attached to the specified unit.
+func (sa *StorageAccessor) SetStorageUsage(unitTag names.UnitTag, usage map[names.StorageTag]params.StorageUsage) error {
+	if sa.facade.BestAPIVersion() < 20 {
+		// SetStorageUsage() was introduced in UniterAPIV20.
+		return errors.NotImplementedf("SetStorageUsage() (need V20+)")
+	}
+	args := params.StorageUsageArgs{
+		Args: make([]params.StorageUsageArg, 0, len(usage)),
+	}
+	for storageTag, u := range usage {
+		args.Args = append(args.Args, params.StorageUsageArg{
+			StorageTag: storageTag.String(),
+			UnitTag:    unitTag.String(),
+			Usage:      u,
+		})
+	}
+	var results params.ErrorResults
+	err := sa.facade.FacadeCall("SetStorageUsage", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
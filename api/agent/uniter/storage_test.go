@@ -256,3 +256,51 @@ func (s *storageSuite) TestRemoveStorageAttachment(c *gc.C) {
 	err := st.RemoveStorageAttachment(names.NewStorageTag("data/0"), names.NewUnitTag("mysql/0"))
 	c.Check(err, gc.ErrorMatches, "yoink")
 }
+
+func (s *storageSuite) TestSetStorageUsage(c *gc.C) {
+	usage := params.StorageUsage{
+		BytesUsed:   1024,
+		BytesTotal:  4096,
+		InodesUsed:  10,
+		InodesTotal: 100,
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Uniter")
+		c.Check(version, gc.Equals, 20)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetStorageUsage")
+		c.Check(arg, jc.DeepEquals, params.StorageUsageArgs{
+			Args: []params.StorageUsageArg{{
+				StorageTag: "storage-data-0",
+				UnitTag:    "unit-mysql-0",
+				Usage:      usage,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "yoink"},
+			}},
+		}
+		return nil
+	})
+
+	caller := testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 20}
+	st := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	err := st.SetStorageUsage(names.NewUnitTag("mysql/0"), map[names.StorageTag]params.StorageUsage{
+		names.NewStorageTag("data/0"): usage,
+	})
+	c.Check(err, gc.ErrorMatches, "yoink")
+}
+
+func (s *storageSuite) TestSetStorageUsageNotImplemented(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	caller := testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 19}
+	st := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	err := st.SetStorageUsage(names.NewUnitTag("mysql/0"), nil)
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+}
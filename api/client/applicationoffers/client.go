@@ -112,6 +112,7 @@ func offerParamsToDetails(offer params.ApplicationOfferAdminDetailsV5) (*crossmo
 		}
 	}
 	result := &crossmodel.ApplicationOfferDetails{
+		OfferUUID:              offer.OfferUUID,
 		ApplicationName:        offer.ApplicationName,
 		ApplicationDescription: offer.ApplicationDescription,
 		OfferName:              offer.OfferName,
@@ -164,6 +164,7 @@ func (s *crossmodelMockSuite) TestList(c *gc.C) {
 	results, err := client.ListOffers(filter)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, []*jujucrossmodel.ApplicationOfferDetails{{
+		OfferUUID:       offerName + "-uuid",
 		OfferURL:        url,
 		OfferName:       offerName,
 		Endpoints:       []charm.Relation{{Name: "endPointA"}},
@@ -0,0 +1,37 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// List returns the metadata of all the backups stored on the
+// controller, oldest first.
+func (c *Client) List() (*params.BackupsListResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("listing backups on this controller")
+	}
+	var result params.BackupsListResult
+	if err := c.facade.FacadeCall("List", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
+
+// Verify checks the stored backup with the given ID against the checksum
+// recorded when it was created, and returns its metadata.
+func (c *Client) Verify(id string) (*params.BackupsMetadataResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("verifying backups on this controller")
+	}
+	var result params.BackupsMetadataResult
+	args := params.BackupsVerifyArgs{ID: id}
+	if err := c.facade.FacadeCall("Verify", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
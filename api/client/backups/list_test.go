@@ -0,0 +1,64 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	apiserverbackups "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/rpc/params"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type listSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) TestList(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	meta := backupstesting.NewMetadata()
+	result := params.BackupsListResult{
+		List: []params.BackupsMetadataResult{
+			apiserverbackups.CreateResult(meta, "/path/to/backup"),
+		},
+	}
+	s.facade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("List", nil, gomock.Any()).SetArg(2, result)
+
+	client := s.newClient()
+	got, err := client.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.List, gc.HasLen, 1)
+	s.checkMetadataResult(c, &got.List[0], meta)
+}
+
+func (s *listSuite) TestListNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().BestAPIVersion().Return(3)
+
+	client := s.newClient()
+	_, err := client.List()
+	c.Assert(err, gc.ErrorMatches, "listing backups on this controller not supported")
+}
+
+func (s *listSuite) TestVerify(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	meta := backupstesting.NewMetadata()
+	result := apiserverbackups.CreateResult(meta, "/path/to/backup")
+	arg := params.BackupsVerifyArgs{ID: "/path/to/backup"}
+	s.facade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("Verify", arg, gomock.Any()).SetArg(2, result)
+
+	client := s.newClient()
+	got, err := client.Verify("/path/to/backup")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkMetadataResult(c, got, meta)
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// Restore asks the controller to start restoring itself from the
+// previously uploaded backup archive with the given ID. The restore
+// runs in the background on the controller; use RestoreStatus to
+// follow its progress.
+func (c *Client) Restore(id string) (*params.BackupsRestoreResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("restoring backups on this controller")
+	}
+	var result params.BackupsRestoreResult
+	args := params.BackupsRestoreArgs{ID: id}
+	if err := c.facade.FacadeCall("Restore", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
+
+// RestoreStatus returns the progress of the restore of the backup
+// archive with the given ID, including the steps started so far and,
+// once the restore is done, any error it failed with.
+func (c *Client) RestoreStatus(id string) (*params.BackupsRestoreResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("restoring backups on this controller")
+	}
+	var result params.BackupsRestoreResult
+	args := params.BackupsRestoreArgs{ID: id}
+	if err := c.facade.FacadeCall("RestoreStatus", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
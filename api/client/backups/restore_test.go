@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/httprequest.v1"
+
+	apiserverbackups "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/rpc/params"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type restoreSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&restoreSuite{})
+
+func (s *restoreSuite) TestUpload(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "PUT")
+		c.Check(r.URL.String(), gc.Equals, "/backups")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, params.ContentTypeRaw)
+		data, err := io.ReadAll(r.Body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(string(data), gc.Equals, "<archive>")
+
+		w.Header().Set("Content-Type", params.ContentTypeJSON)
+		err = json.NewEncoder(w).Encode(params.BackupsUploadResult{ID: "juju-backup-upload-1.tar.gz"})
+		c.Check(err, jc.ErrorIsNil)
+	}))
+	defer srv.Close()
+	httpClient := &httprequest.Client{BaseURL: srv.URL}
+
+	s.apiCaller.EXPECT().HTTPClient().Return(httpClient, nil)
+	s.apiCaller.EXPECT().Context().Return(context.TODO())
+
+	client := s.newClient()
+	id, err := client.Upload(strings.NewReader("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "juju-backup-upload-1.tar.gz")
+}
+
+func (s *restoreSuite) TestRestore(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	meta := backupstesting.NewMetadata()
+	result := params.BackupsRestoreResult{
+		Metadata: apiserverbackups.CreateResult(meta, "/path/to/backup"),
+		Steps:    []string{"restoring database"},
+	}
+	arg := params.BackupsRestoreArgs{ID: "/path/to/backup"}
+	s.facade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("Restore", arg, gomock.Any()).SetArg(2, result)
+
+	client := s.newClient()
+	got, err := client.Restore("/path/to/backup")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkMetadataResult(c, &got.Metadata, meta)
+	c.Check(got.Steps, jc.DeepEquals, []string{"restoring database"})
+}
+
+func (s *restoreSuite) TestRestoreNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.facade.EXPECT().BestAPIVersion().Return(3)
+
+	client := s.newClient()
+	_, err := client.Restore("/path/to/backup")
+	c.Assert(err, gc.ErrorMatches, "restoring backups on this controller not supported")
+}
+
+func (s *restoreSuite) TestRestoreStatus(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	result := params.BackupsRestoreResult{
+		Steps: []string{"restoring database"},
+		Done:  true,
+		Error: &params.Error{Message: "failed!"},
+	}
+	arg := params.BackupsRestoreArgs{ID: "/path/to/backup"}
+	s.facade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("RestoreStatus", arg, gomock.Any()).SetArg(2, result)
+
+	client := s.newClient()
+	got, err := client.RestoreStatus("/path/to/backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got, jc.DeepEquals, &result)
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Upload sends the backup archive read from the given reader to the
+// controller, ready for a subsequent restore. It returns the ID under
+// which the archive was stored.
+func (c *Client) Upload(archive io.ReadSeeker) (string, error) {
+	req, err := http.NewRequest("PUT", "/backups", archive)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot create upload request")
+	}
+	req.Header.Set("Content-Type", params.ContentTypeRaw)
+
+	httpClient, err := c.st.HTTPClient()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	var result params.BackupsUploadResult
+	if err := httpClient.Do(c.st.Context(), req, &result); err != nil {
+		return "", errors.Trace(apiservererrors.RestoreError(err))
+	}
+	return result.ID, nil
+}
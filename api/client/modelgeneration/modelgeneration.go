@@ -130,6 +130,87 @@ func (c *Client) TrackBranch(branchName string, entities []string, numUnits int)
 	return nil
 }
 
+// SetBranchRollout sets the policy by which the branch with the input name
+// is gradually rolled out to the units of its applications.
+func (c *Client) SetBranchRollout(branchName string, policy model.RolloutPolicy) error {
+	if c.facade.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("branch rollouts on this controller")
+	}
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	arg := params.BranchRolloutArg{
+		BranchName:    branchName,
+		Steps:         policy.Steps,
+		StepInterval:  policy.StepInterval,
+		WaitForActive: policy.WaitForActive,
+		AutoAbort:     policy.AutoAbort,
+	}
+	if !policy.Start.IsZero() {
+		arg.Start = &policy.Start
+	}
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("SetBranchRollout", arg, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
+// SetBranchCharm upgrades the application with the input name to the
+// input charm under the input branch, attaching the pending resources
+// with the input IDs, keyed by resource name. The charm must already have
+// been added to the model.
+func (c *Client) SetBranchCharm(
+	branchName, appName, charmURL string, origin params.CharmOrigin, resourceIDs map[string]string,
+) error {
+	if c.facade.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("charm upgrades under a branch on this controller")
+	}
+	arg := params.BranchCharmArg{
+		BranchName:      branchName,
+		ApplicationName: appName,
+		CharmURL:        charmURL,
+		CharmOrigin:     &origin,
+		ResourceIDs:     resourceIDs,
+	}
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("SetBranchCharm", arg, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
+// AttachBranchResource attaches the pending resource with the input ID to
+// the application with the input name under the input branch.
+func (c *Client) AttachBranchResource(branchName, appName, resourceName, pendingID string) error {
+	if c.facade.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("resource changes under a branch on this controller")
+	}
+	arg := params.BranchResourceArg{
+		BranchName:      branchName,
+		ApplicationName: appName,
+		ResourceName:    resourceName,
+		PendingID:       pendingID,
+	}
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("AttachBranchResource", arg, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
 // HasActiveBranch returns true if the model has an
 // "in-flight" branch with the input name.
 func (c *Client) HasActiveBranch(branchName string) (bool, error) {
@@ -184,6 +265,8 @@ func generationInfoFromResult(
 				ApplicationName: a.ApplicationName,
 				UnitProgress:    a.UnitProgress,
 				ConfigChanges:   a.ConfigChanges,
+				CharmURL:        a.CharmURL,
+				Resources:       a.Resources,
 			}
 			if detailed {
 				bApp.UnitDetail = &model.GenerationUnits{
@@ -197,11 +280,33 @@ func generationInfoFromResult(
 			Created:      formatTime(time.Unix(res.Created, 0)),
 			CreatedBy:    res.CreatedBy,
 			Applications: appDeltas,
+			Rollout:      generationRolloutFromResult(res.Rollout, formatTime),
 		}
 	}
 	return summaries
 }
 
+func generationRolloutFromResult(
+	res *params.BranchRollout, formatTime func(time.Time) string,
+) *model.GenerationRollout {
+	if res == nil {
+		return nil
+	}
+	rollout := &model.GenerationRollout{
+		Steps:         res.Steps,
+		StepInterval:  res.StepInterval,
+		WaitForActive: res.WaitForActive,
+		AutoAbort:     res.AutoAbort,
+		Status:        model.RolloutStatus(res.Status),
+		Step:          res.Step,
+		Message:       res.Message,
+	}
+	if res.Start != nil {
+		rollout.Start = formatTime(*res.Start)
+	}
+	return rollout
+}
+
 func generationCommitsFromResults(results params.BranchResults) model.GenerationCommits {
 	commits := make(model.GenerationCommits, len(results.Generations))
 	for i, gen := range results.Generations {
@@ -222,6 +327,8 @@ func generationCommitFromResult(result params.GenerationResult) model.Generation
 		app := model.GenerationApplication{
 			ApplicationName: a.ApplicationName,
 			ConfigChanges:   a.ConfigChanges,
+			CharmURL:        a.CharmURL,
+			Resources:       a.Resources,
 			UnitDetail:      &model.GenerationUnits{UnitsTracking: a.UnitsTracking},
 		}
 		appChanges[i] = app
@@ -6,6 +6,7 @@ package modelgeneration_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
 	gc "gopkg.in/check.v1"
@@ -124,6 +125,89 @@ func (s *modelGenerationSuite) TestHasActiveBranch(c *gc.C) {
 	c.Check(has, jc.IsTrue)
 }
 
+func (s *modelGenerationSuite) TestSetBranchRollout(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	start := time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
+	arg := params.BranchRolloutArg{
+		BranchName:    s.branchName,
+		Steps:         []int{10, 50, 100},
+		Start:         &start,
+		StepInterval:  time.Hour,
+		WaitForActive: true,
+		AutoAbort:     true,
+	}
+	s.fCaller.EXPECT().BestAPIVersion().Return(5)
+	s.fCaller.EXPECT().FacadeCall("SetBranchRollout", arg, gomock.Any()).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.SetBranchRollout(s.branchName, model.RolloutPolicy{
+		Steps:         []int{10, 50, 100},
+		Start:         start,
+		StepInterval:  time.Hour,
+		WaitForActive: true,
+		AutoAbort:     true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelGenerationSuite) TestSetBranchRolloutNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.fCaller.EXPECT().BestAPIVersion().Return(4)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.SetBranchRollout(s.branchName, model.RolloutPolicy{Steps: []int{100}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *modelGenerationSuite) TestSetBranchCharm(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	origin := params.CharmOrigin{Source: "charm-hub", Revision: intPtr(2)}
+	arg := params.BranchCharmArg{
+		BranchName:      s.branchName,
+		ApplicationName: "redis",
+		CharmURL:        "ch:redis-2",
+		CharmOrigin:     &origin,
+		ResourceIDs:     map[string]string{"store": "pending-id"},
+	}
+	s.fCaller.EXPECT().BestAPIVersion().Return(6)
+	s.fCaller.EXPECT().FacadeCall("SetBranchCharm", arg, gomock.Any()).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.SetBranchCharm(s.branchName, "redis", "ch:redis-2", origin, map[string]string{"store": "pending-id"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelGenerationSuite) TestSetBranchCharmNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.fCaller.EXPECT().BestAPIVersion().Return(5)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.SetBranchCharm(s.branchName, "redis", "ch:redis-2", params.CharmOrigin{}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *modelGenerationSuite) TestAttachBranchResource(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	arg := params.BranchResourceArg{
+		BranchName:      s.branchName,
+		ApplicationName: "redis",
+		ResourceName:    "store",
+		PendingID:       "pending-id",
+	}
+	resultSource := params.ErrorResult{Error: &params.Error{Message: "boom"}}
+	s.fCaller.EXPECT().BestAPIVersion().Return(6)
+	s.fCaller.EXPECT().FacadeCall("AttachBranchResource", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.AttachBranchResource(s.branchName, "redis", "store", "pending-id")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *modelGenerationSuite) TestBranchInfo(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
@@ -140,6 +224,11 @@ func (s *modelGenerationSuite) TestBranchInfo(c *gc.C) {
 				ConfigChanges:   map[string]interface{}{"databases": 8},
 			},
 		},
+		Rollout: &params.BranchRollout{
+			Steps:  []int{50, 100},
+			Status: "running",
+			Step:   1,
+		},
 	}}}
 	arg := params.BranchInfoArgs{
 		BranchNames: []string{s.branchName},
@@ -169,6 +258,15 @@ func (s *modelGenerationSuite) TestBranchInfo(c *gc.C) {
 				},
 				ConfigChanges: map[string]interface{}{"databases": 8},
 			}},
+			Rollout: &model.GenerationRollout{
+				Steps:  []int{50, 100},
+				Status: model.RolloutRunning,
+				Step:   1,
+			},
 		},
 	})
 }
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -280,3 +280,56 @@ func (c *Client) RevokeSecret(uri *secrets.URI, name string, apps []string) ([]e
 	}
 	return processErrors(results), nil
 }
+
+// MigrationFailure describes a secret revision which could not be migrated.
+type MigrationFailure struct {
+	URI      string
+	Revision int
+	Error    error
+}
+
+// MigrationProgress holds the progress of a secret migration.
+type MigrationProgress struct {
+	Migrated  int
+	Remaining int
+	Failed    []MigrationFailure
+	// Next is the cursor to carry on the migration from; it is empty
+	// once every revision has been attempted.
+	Next string
+}
+
+// MigrateSecrets attempts to migrate up to limit secret revisions from
+// one backend to another, starting after the cursor returned by the
+// previous call. A limit of 0 attempts all revisions.
+func (c *Client) MigrateSecrets(fromBackend, toBackend string, limit int, after string) (*MigrationProgress, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("secret migration")
+	}
+	arg := params.MigrateSecretsArg{
+		FromBackend: fromBackend,
+		ToBackend:   toBackend,
+		Limit:       limit,
+		After:       after,
+	}
+	var result params.MigrateSecretsResult
+	err := c.facade.FacadeCall("MigrateSecrets", arg, &result)
+	if err != nil {
+		return nil, params.TranslateWellKnownError(err)
+	}
+	progress := &MigrationProgress{
+		Migrated:  result.Migrated,
+		Remaining: result.Remaining,
+		Next:      result.Next,
+	}
+	for _, f := range result.Failed {
+		failure := MigrationFailure{
+			URI:      f.URI,
+			Revision: f.Revision,
+		}
+		if f.Error != nil {
+			failure.Error = params.TranslateWellKnownError(f.Error)
+		}
+		progress.Failed = append(progress.Failed, failure)
+	}
+	return progress, nil
+}
@@ -452,3 +452,48 @@ func (s *SecretsSuite) TestRevokeSecretByName(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.DeepEquals, []error{nil})
 }
+
+func (s *SecretsSuite) TestMigrateSecrets(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Secrets")
+		c.Assert(request, gc.Equals, "MigrateSecrets")
+		c.Assert(arg, gc.DeepEquals, params.MigrateSecretsArg{
+			FromBackend: "vault",
+			ToBackend:   "internal",
+			Limit:       10,
+			After:       "cursor",
+		})
+		*(result.(*params.MigrateSecretsResult)) = params.MigrateSecretsResult{
+			Migrated:  2,
+			Remaining: 5,
+			Next:      "next-cursor",
+			Failed: []params.SecretRevisionError{{
+				URI:      "secret:9m4e2mr0ui3e8a215n4g",
+				Revision: 1,
+				Error:    &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	caller := testing.BestVersionCaller{apiCaller, 3}
+	client := apisecrets.NewClient(caller)
+	result, err := client.MigrateSecrets("vault", "internal", 10, "cursor")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Migrated, gc.Equals, 2)
+	c.Assert(result.Remaining, gc.Equals, 5)
+	c.Assert(result.Next, gc.Equals, "next-cursor")
+	c.Assert(result.Failed, gc.HasLen, 1)
+	c.Assert(result.Failed[0].URI, gc.Equals, "secret:9m4e2mr0ui3e8a215n4g")
+	c.Assert(result.Failed[0].Revision, gc.Equals, 1)
+	c.Assert(result.Failed[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *SecretsSuite) TestMigrateSecretsNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	caller := testing.BestVersionCaller{apiCaller, 2}
+	client := apisecrets.NewClient(caller)
+	_, err := client.MigrateSecrets("vault", "internal", 0, "")
+	c.Assert(err, gc.ErrorMatches, "secret migration not supported")
+}
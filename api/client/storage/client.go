@@ -183,6 +183,11 @@ func (c *Client) ListFilesystems(machines []string) ([]params.FilesystemDetailsL
 // NOTE(axw) for old controllers, the results will only
 // contain errors.
 func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
+	for _, one := range storages {
+		if one.SnapshotId != "" && c.facade.BestAPIVersion() < 7 {
+			return nil, errors.NotSupportedf("adding storage from a snapshot on this controller")
+		}
+	}
 	out := params.AddStorageResults{}
 	in := params.StoragesAddParams{Storages: storages}
 	err := c.facade.FacadeCall("AddToUnit", in, &out)
@@ -284,13 +289,19 @@ func (c *Client) Detach(storageIds []string, force *bool, maxWait *time.Duration
 	return results.Results, nil
 }
 
-// Import imports storage into the model.
+// Import imports storage into the model. If an application is
+// specified, the storage may only be attached to units of that
+// application.
 func (c *Client) Import(
 	kind storage.StorageKind,
 	storagePool string,
 	storageProviderId string,
 	storageName string,
+	application string,
 ) (names.StorageTag, error) {
+	if application != "" && c.facade.BestAPIVersion() < 8 {
+		return names.StorageTag{}, errors.NotSupportedf("importing storage for an application on this controller")
+	}
 	var results params.ImportStorageResults
 	args := params.BulkImportStorageParams{
 		[]params.ImportStorageParams{{
@@ -298,6 +309,7 @@ func (c *Client) Import(
 			Kind:        params.StorageKind(kind),
 			Pool:        storagePool,
 			ProviderId:  storageProviderId,
+			Application: application,
 		}},
 	}
 	if err := c.facade.FacadeCall("Import", args, &results); err != nil {
@@ -314,3 +326,85 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// CreateSnapshots requests snapshots of the specified storage instances.
+func (c *Client) CreateSnapshots(tags []names.StorageTag) ([]params.StorageSnapshotDetailsResult, error) {
+	if c.facade.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("storage snapshots on this controller")
+	}
+	entities := make([]params.Entity, len(tags))
+	for i, tag := range tags {
+		entities[i] = params.Entity{Tag: tag.String()}
+	}
+	var results params.StorageSnapshotDetailsResults
+	if err := c.facade.FacadeCall("CreateStorageSnapshots", params.Entities{Entities: entities}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(tags), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ListSnapshots lists all storage snapshots in the model.
+func (c *Client) ListSnapshots() ([]params.StorageSnapshotDetails, error) {
+	if c.facade.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("storage snapshots on this controller")
+	}
+	var results params.StorageSnapshotDetailsResults
+	if err := c.facade.FacadeCall("ListStorageSnapshots", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots := make([]params.StorageSnapshotDetails, 0, len(results.Results))
+	for _, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Trace(result.Error)
+		}
+		snapshots = append(snapshots, *result.Result)
+	}
+	return snapshots, nil
+}
+
+// DestroySnapshots destroys the storage snapshots with the specified IDs.
+func (c *Client) DestroySnapshots(ids []string) ([]params.ErrorResult, error) {
+	if c.facade.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("storage snapshots on this controller")
+	}
+	entities := make([]params.Entity, len(ids))
+	for i, id := range ids {
+		entities[i] = params.Entity{Tag: id}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("DestroyStorageSnapshots", params.Entities{Entities: entities}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(ids), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ResizeStorage requests that the storage instance with the specified
+// tag be grown to the given size, in MiB.
+func (c *Client) ResizeStorage(tag names.StorageTag, size uint64) error {
+	if c.facade.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("resizing storage on this controller")
+	}
+	args := params.ResizeStorageArgs{
+		Storage: []params.ResizeStorageArg{{
+			StorageTag: tag.String(),
+			Size:       size,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -671,11 +671,50 @@ func (s *storageMockSuite) TestImport(c *gc.C) {
 	mockFacadeCaller.EXPECT().FacadeCall("Import", expectedArgs, result).SetArg(2, results).Return(nil)
 
 	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
-	storageTag, err := storageClient.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz")
+	storageTag, err := storageClient.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(storageTag, gc.Equals, names.NewStorageTag("qux/0"))
 }
 
+func (s *storageMockSuite) TestImportForApplication(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	expectedArgs := params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindFilesystem,
+		Pool:        "foo",
+		ProviderId:  "bar",
+		StorageName: "baz",
+		Application: "mysql",
+	}}}
+	result := new(params.ImportStorageResults)
+	results := params.ImportStorageResults{
+		Results: []params.ImportStorageResult{{
+			Result: &params.ImportStorageDetails{
+				StorageTag: "storage-baz-0",
+			},
+		}},
+	}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(8)
+	mockFacadeCaller.EXPECT().FacadeCall("Import", expectedArgs, result).SetArg(2, results).Return(nil)
+
+	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
+	storageTag, err := storageClient.Import(jujustorage.StorageKindFilesystem, "foo", "bar", "baz", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("baz/0"))
+}
+
+func (s *storageMockSuite) TestImportForApplicationNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(7)
+
+	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
+	_, err := storageClient.Import(jujustorage.StorageKindFilesystem, "foo", "bar", "baz", "mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *storageMockSuite) TestImportError(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -689,7 +728,7 @@ func (s *storageMockSuite) TestImportError(c *gc.C) {
 	mockFacadeCaller.EXPECT().FacadeCall("Import", gomock.AssignableToTypeOf(params.BulkImportStorageParams{}), result).SetArg(2, results).Return(nil)
 
 	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
-	_, err := storageClient.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz")
+	_, err := storageClient.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz", "")
 	c.Check(err, gc.ErrorMatches, "qux")
 }
 
@@ -705,7 +744,7 @@ func (s *storageMockSuite) TestImportArityMismatch(c *gc.C) {
 	mockFacadeCaller.EXPECT().FacadeCall("Import", gomock.AssignableToTypeOf(params.BulkImportStorageParams{}), result).SetArg(2, results).Return(nil)
 
 	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
-	_, err := storageClient.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz")
+	_, err := storageClient.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz", "")
 	c.Check(err, gc.ErrorMatches, `expected 1 result, got 2`)
 }
 
@@ -793,3 +832,136 @@ func (s *storageMockSuite) TestUpdatePoolFacadeCallError(c *gc.C) {
 	err := storageClient.UpdatePool("", "", nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
+
+func (s *storageMockSuite) TestAddToUnitFromSnapshotNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(6)
+
+	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
+	_, err := storageClient.AddToUnit([]params.StorageAddParams{{
+		UnitTag:     "unit-mysql-0",
+		StorageName: "data",
+		SnapshotId:  "0/1",
+	}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "storage-data-0"}}}
+	results := params.StorageSnapshotDetailsResults{
+		Results: []params.StorageSnapshotDetailsResult{{
+			Result: &params.StorageSnapshotDetails{Id: "0/1", StorageTag: "storage-data-0"},
+		}},
+	}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(7)
+	mockFacadeCaller.EXPECT().FacadeCall("CreateStorageSnapshots", args, gomock.Any()).SetArg(2, results).Return(nil)
+
+	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
+	found, err := storageClient.CreateSnapshots([]names.StorageTag{names.NewStorageTag("data/0")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, results.Results)
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(6)
+
+	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
+	_, err := storageClient.CreateSnapshots([]names.StorageTag{names.NewStorageTag("data/0")})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestDestroySnapshots(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "0/1"}, {Tag: "2"}}}
+	results := params.ErrorResults{
+		Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+	}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(7)
+	mockFacadeCaller.EXPECT().FacadeCall("DestroyStorageSnapshots", args, gomock.Any()).SetArg(2, results).Return(nil)
+
+	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
+	found, err := storageClient.DestroySnapshots([]string{"0/1", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, results.Results)
+}
+
+func (s *storageMockSuite) TestDestroySnapshotsNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(6)
+
+	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
+	_, err := storageClient.DestroySnapshots([]string{"0/1"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	results := params.StorageSnapshotDetailsResults{
+		Results: []params.StorageSnapshotDetailsResult{{
+			Result: &params.StorageSnapshotDetails{Id: "0/1", StorageTag: "storage-data-0"},
+		}, {
+			Result: &params.StorageSnapshotDetails{Id: "2", StorageTag: "storage-data-1"},
+		}},
+	}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(7)
+	mockFacadeCaller.EXPECT().FacadeCall("ListStorageSnapshots", nil, gomock.Any()).SetArg(2, results).Return(nil)
+
+	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
+	found, err := storageClient.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []params.StorageSnapshotDetails{
+		*results.Results[0].Result,
+		*results.Results[1].Result,
+	})
+}
+
+func (s *storageMockSuite) TestResizeStorage(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := params.ResizeStorageArgs{
+		Storage: []params.ResizeStorageArg{{StorageTag: "storage-data-0", Size: 2048}},
+	}
+	results := params.ErrorResults{
+		Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+	}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(8)
+	mockFacadeCaller.EXPECT().FacadeCall("ResizeStorage", args, gomock.Any()).SetArg(2, results).Return(nil)
+
+	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
+	err := storageClient.ResizeStorage(names.NewStorageTag("data/0"), 2048)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestResizeStorageNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(7)
+
+	storageClient := storage.NewClientFromCaller(mockFacadeCaller)
+	err := storageClient.ResizeStorage(names.NewStorageTag("data/0"), 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// AddGroup creates a new user group in the controller.
+func (c *Client) AddGroup(name string) error {
+	return c.groupCall(name, "AddUserGroups")
+}
+
+// RemoveGroup removes a user group, along with all of the access granted
+// to it.
+func (c *Client) RemoveGroup(name string) error {
+	return c.groupCall(name, "RemoveUserGroups")
+}
+
+func (c *Client) groupCall(name string, methodCall string) error {
+	if c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("user groups on this controller")
+	}
+	args := params.UserGroupNames{Names: []string{name}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(methodCall, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Groups returns information about the specified user groups. If no
+// groups are specified, the call returns all groups.
+func (c *Client) Groups(groupNames ...string) ([]params.UserGroupInfo, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("user groups on this controller")
+	}
+	args := params.UserGroupNames{Names: groupNames}
+	var results params.UserGroupInfoResults
+	if err := c.facade.FacadeCall("UserGroups", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(groupNames) > 0 && len(results.Results) != len(groupNames) {
+		return nil, errors.Errorf("expected %d results, got %d", len(groupNames), len(results.Results))
+	}
+	info := make([]params.UserGroupInfo, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Trace(result.Error)
+		}
+		if result.Result == nil {
+			return nil, errors.Errorf("unexpected nil result at position %d", i)
+		}
+		info[i] = *result.Result
+	}
+	return info, nil
+}
+
+// AddGroupMembers adds the specified users to a user group.
+func (c *Client) AddGroupMembers(group string, usernames ...string) error {
+	return c.modifyGroupMembers(group, params.AddUserGroupMembers, usernames)
+}
+
+// RemoveGroupMembers removes the specified users from a user group.
+func (c *Client) RemoveGroupMembers(group string, usernames ...string) error {
+	return c.modifyGroupMembers(group, params.RemoveUserGroupMembers, usernames)
+}
+
+func (c *Client) modifyGroupMembers(group string, action params.UserGroupAction, usernames []string) error {
+	if c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("user groups on this controller")
+	}
+	userTags := make([]string, len(usernames))
+	for i, username := range usernames {
+		if !names.IsValidUser(username) {
+			return errors.Errorf("%q is not a valid username", username)
+		}
+		userTags[i] = names.NewUserTag(username).String()
+	}
+	args := params.ModifyUserGroupMembersRequest{
+		Changes: []params.ModifyUserGroupMembers{{
+			Group:    group,
+			Action:   action,
+			UserTags: userTags,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ModifyUserGroupMembers", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// GrantGroupAccess grants a user group access to a controller, model,
+// application offer or cloud.
+func (c *Client) GrantGroupAccess(group, access string, target names.Tag) error {
+	return c.modifyGroupAccess(group, params.GrantUserGroupAccess, access, target)
+}
+
+// RevokeGroupAccess revokes access from a user group on a controller,
+// model, application offer or cloud.
+func (c *Client) RevokeGroupAccess(group, access string, target names.Tag) error {
+	return c.modifyGroupAccess(group, params.RevokeUserGroupAccess, access, target)
+}
+
+func (c *Client) modifyGroupAccess(group string, action params.UserGroupAction, access string, target names.Tag) error {
+	if c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("user groups on this controller")
+	}
+	args := params.ModifyUserGroupAccessRequest{
+		Changes: []params.ModifyUserGroupAccess{{
+			Group:     group,
+			Action:    action,
+			Access:    access,
+			TargetTag: target.String(),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ModifyUserGroupAccess", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
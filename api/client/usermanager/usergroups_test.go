@@ -0,0 +1,146 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/mocks"
+	"github.com/juju/juju/api/client/usermanager"
+	"github.com/juju/juju/rpc/params"
+)
+
+type userGroupsSuite struct{}
+
+var _ = gc.Suite(&userGroupsSuite{})
+
+func (s *userGroupsSuite) newClient(ctrl *gomock.Controller, version int) (*usermanager.Client, *mocks.MockFacadeCaller) {
+	mockFacadeCaller := mocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(version).AnyTimes()
+	return usermanager.NewClientFromCaller(mockFacadeCaller), mockFacadeCaller
+}
+
+func (s *userGroupsSuite) TestAddGroup(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := params.UserGroupNames{Names: []string{"engineers"}}
+	result := new(params.ErrorResults)
+	results := params.ErrorResults{Results: []params.ErrorResult{{}}}
+	client, caller := s.newClient(ctrl, 4)
+	caller.EXPECT().FacadeCall("AddUserGroups", args, result).SetArg(2, results).Return(nil)
+
+	err := client.AddGroup("engineers")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *userGroupsSuite) TestRemoveGroupError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := params.UserGroupNames{Names: []string{"engineers"}}
+	result := new(params.ErrorResults)
+	results := params.ErrorResults{Results: []params.ErrorResult{{
+		Error: &params.Error{Message: `group "engineers" not found`, Code: params.CodeNotFound},
+	}}}
+	client, caller := s.newClient(ctrl, 4)
+	caller.EXPECT().FacadeCall("RemoveUserGroups", args, result).SetArg(2, results).Return(nil)
+
+	err := client.RemoveGroup("engineers")
+	c.Assert(err, gc.ErrorMatches, `group "engineers" not found`)
+}
+
+func (s *userGroupsSuite) TestGroups(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	created := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	info := params.UserGroupInfo{
+		Name:        "engineers",
+		Members:     []string{"bob", "mary@external"},
+		CreatedBy:   "admin",
+		DateCreated: created,
+		Access: []params.UserGroupAccess{{
+			Group:  "engineers",
+			Target: "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Access: "write",
+		}},
+	}
+	args := params.UserGroupNames{}
+	result := new(params.UserGroupInfoResults)
+	results := params.UserGroupInfoResults{Results: []params.UserGroupInfoResult{{Result: &info}}}
+	client, caller := s.newClient(ctrl, 4)
+	caller.EXPECT().FacadeCall("UserGroups", args, result).SetArg(2, results).Return(nil)
+
+	groups, err := client.Groups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, jc.DeepEquals, []params.UserGroupInfo{info})
+}
+
+func (s *userGroupsSuite) TestAddGroupMembers(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := params.ModifyUserGroupMembersRequest{
+		Changes: []params.ModifyUserGroupMembers{{
+			Group:    "engineers",
+			Action:   params.AddUserGroupMembers,
+			UserTags: []string{"user-bob", "user-mary@external"},
+		}},
+	}
+	result := new(params.ErrorResults)
+	results := params.ErrorResults{Results: []params.ErrorResult{{}}}
+	client, caller := s.newClient(ctrl, 4)
+	caller.EXPECT().FacadeCall("ModifyUserGroupMembers", args, result).SetArg(2, results).Return(nil)
+
+	err := client.AddGroupMembers("engineers", "bob", "mary@external")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *userGroupsSuite) TestAddGroupMembersInvalidUser(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	client, _ := s.newClient(ctrl, 4)
+	err := client.AddGroupMembers("engineers", "not@a@user")
+	c.Assert(err, gc.ErrorMatches, `"not@a@user" is not a valid username`)
+}
+
+func (s *userGroupsSuite) TestRevokeGroupAccess(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := params.ModifyUserGroupAccessRequest{
+		Changes: []params.ModifyUserGroupAccess{{
+			Group:     "engineers",
+			Action:    params.RevokeUserGroupAccess,
+			Access:    "add-model",
+			TargetTag: "cloud-aws",
+		}},
+	}
+	result := new(params.ErrorResults)
+	results := params.ErrorResults{Results: []params.ErrorResult{{}}}
+	client, caller := s.newClient(ctrl, 4)
+	caller.EXPECT().FacadeCall("ModifyUserGroupAccess", args, result).SetArg(2, results).Return(nil)
+
+	err := client.RevokeGroupAccess("engineers", "add-model", names.NewCloudTag("aws"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *userGroupsSuite) TestGroupsNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	client, _ := s.newClient(ctrl, 3)
+	_, err := client.Groups()
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+	err = client.GrantGroupAccess("engineers", "read", names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"))
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
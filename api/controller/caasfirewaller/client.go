@@ -21,6 +21,7 @@ import (
 // Client allows access to the CAAS firewaller API endpoint.
 type Client struct {
 	facade base.FacadeCaller
+	*common.ModelWatcher
 	*charmscommon.CharmInfoClient
 	*charmscommon.ApplicationCharmInfoClient
 }
@@ -32,6 +33,7 @@ func NewClientLegacy(caller base.APICaller) *Client {
 	appCharmInfoClient := charmscommon.NewApplicationCharmInfoClient(facadeCaller)
 	return &Client{
 		facade:                     facadeCaller,
+		ModelWatcher:               common.NewModelWatcher(facadeCaller),
 		CharmInfoClient:            charmInfoClient,
 		ApplicationCharmInfoClient: appCharmInfoClient,
 	}
@@ -17,7 +17,9 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/watcher"
+	environsconfig "github.com/juju/juju/environs/config"
 	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type firewallerBaseSuite struct {
@@ -48,6 +50,27 @@ var _ = gc.Suite(&firewallerLegacySuite{
 	},
 })
 
+func (s *firewallerLegacySuite) TestModelConfig(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, s.objType)
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ModelConfig")
+		c.Assert(result, gc.FitsTypeOf, &params.ModelConfigResult{})
+		*(result.(*params.ModelConfigResult)) = params.ModelConfigResult{
+			Config: params.ModelConfig(coretesting.FakeConfig().Merge(coretesting.Attrs{
+				environsconfig.ExposedIngressAllowKey: "10.0.0.0/8",
+			})),
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClientLegacy(apiCaller)
+	cfg, err := client.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ExposedIngressAllow(), jc.DeepEquals, []string{"10.0.0.0/8"})
+}
+
 type firewallerSidecarSuite struct {
 	firewallerBaseSuite
 }
@@ -315,16 +315,61 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationDryRun runs the checks that would be made when migrating the
+// specified model, without starting the migration. All of the blockers
+// found are returned; if there are none, the migration is expected to
+// succeed.
+func (c *Client) MigrationDryRun(spec MigrationSpec) ([]params.MigrationBlocker, error) {
+	if c.BestAPIVersion() < 14 {
+		return nil, errors.NotSupportedf("migration dry runs on this controller")
+	}
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	response := params.MigrationDryRunResults{}
+	if err := c.facade.FacadeCall("MigrationDryRun", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Blockers, nil
+}
+
+func migrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -337,19 +382,147 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:       macsJSON,
 			},
 		}},
+	}, nil
+}
+
+// MigrationPlanSpec holds the details required to create a plan to
+// migrate a number of models to another controller.
+type MigrationPlanSpec struct {
+	ModelUUIDs            []string
+	TargetControllerUUID  string
+	TargetControllerAlias string
+	TargetAddrs           []string
+	TargetCACert          string
+	TargetUser            string
+	TargetPassword        string
+	TargetMacaroons       []macaroon.Slice
+
+	// Concurrency is the maximum number of migrations that may be
+	// running at any one time.
+	Concurrency int
+
+	// MaxAttempts is the number of times the migration of each
+	// model is attempted before it is considered to have failed.
+	MaxAttempts int
+
+	// WindowStart and WindowEnd optionally restrict the time during
+	// which migrations may be started. A zero time is unbounded.
+	WindowStart time.Time
+	WindowEnd   time.Time
+}
+
+// Validate performs sanity checks on the migration plan configuration
+// it holds.
+func (s *MigrationPlanSpec) Validate() error {
+	if len(s.ModelUUIDs) == 0 {
+		return errors.NotValidf("empty model list")
 	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
+	for _, uuid := range s.ModelUUIDs {
+		spec := s.migrationSpec(uuid)
+		if err := spec.Validate(); err != nil {
+			return errors.Trace(err)
+		}
 	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
+	if s.Concurrency < 1 {
+		return errors.NotValidf("concurrency %d", s.Concurrency)
+	}
+	if s.MaxAttempts < 1 {
+		return errors.NotValidf("max attempts %d", s.MaxAttempts)
+	}
+	return nil
+}
+
+func (s *MigrationPlanSpec) migrationSpec(modelUUID string) MigrationSpec {
+	return MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  s.TargetControllerUUID,
+		TargetControllerAlias: s.TargetControllerAlias,
+		TargetAddrs:           s.TargetAddrs,
+		TargetCACert:          s.TargetCACert,
+		TargetUser:            s.TargetUser,
+		TargetPassword:        s.TargetPassword,
+		TargetMacaroons:       s.TargetMacaroons,
+	}
+}
+
+// CreateMigrationPlan asks the controller to migrate the models in the
+// spec to another controller, returning the ID of the new migration
+// plan. The controller starts the migrations as the plan's concurrency
+// limit and time window allow, retrying those which fail.
+func (c *Client) CreateMigrationPlan(spec MigrationPlanSpec) (string, error) {
+	if c.BestAPIVersion() < 14 {
+		return "", errors.NotSupportedf("migration plans on this controller")
+	}
+	if err := spec.Validate(); err != nil {
+		return "", errors.Annotatef(err, "client-side validation failed")
+	}
+	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	if err != nil {
+		return "", errors.Annotatef(err, "client-side validation failed")
+	}
+
+	args := params.CreateMigrationPlanArgs{
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag:   names.NewControllerTag(spec.TargetControllerUUID).String(),
+			ControllerAlias: spec.TargetControllerAlias,
+			Addrs:           spec.TargetAddrs,
+			CACert:          spec.TargetCACert,
+			AuthTag:         names.NewUserTag(spec.TargetUser).String(),
+			Password:        spec.TargetPassword,
+			Macaroons:       macsJSON,
+		},
+		Concurrency: spec.Concurrency,
+		MaxAttempts: spec.MaxAttempts,
+	}
+	for _, uuid := range spec.ModelUUIDs {
+		args.ModelTags = append(args.ModelTags, names.NewModelTag(uuid).String())
+	}
+	if !spec.WindowStart.IsZero() {
+		args.WindowStart = &spec.WindowStart
+	}
+	if !spec.WindowEnd.IsZero() {
+		args.WindowEnd = &spec.WindowEnd
+	}
+
+	var result params.StringResult
+	if err := c.facade.FacadeCall("CreateMigrationPlan", args, &result); err != nil {
+		return "", errors.Trace(err)
 	}
-	result := response.Results[0]
 	if result.Error != nil {
 		return "", errors.Trace(result.Error)
 	}
-	return result.MigrationId, nil
+	return result.Result, nil
+}
+
+// MigrationPlans returns the controller's migration plans, along with
+// the progress of each model in them.
+func (c *Client) MigrationPlans() ([]params.MigrationPlan, error) {
+	if c.BestAPIVersion() < 14 {
+		return nil, errors.NotSupportedf("migration plans on this controller")
+	}
+	var result params.MigrationPlanResults
+	if err := c.facade.FacadeCall("MigrationPlans", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
+
+// CancelMigrationPlan stops the controller from starting any further
+// migrations for the plan with the given ID. Migrations already running
+// are left to finish.
+func (c *Client) CancelMigrationPlan(id string) error {
+	if c.BestAPIVersion() < 14 {
+		return errors.NotSupportedf("migration plans on this controller")
+	}
+	args := params.CancelMigrationPlanArgs{Id: id}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("CancelMigrationPlan", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -432,3 +605,16 @@ func (c *Client) DashboardConnectionInfo(factory ProxierFactory) (DashboardConne
 	rval.Proxier = proxier
 	return rval, nil
 }
+
+// AuditLog returns the audit log entries, recorded by the database audit
+// log sink, that match the query. The oldest entry is first.
+func (c *Client) AuditLog(query params.AuditLogQuery) ([]params.AuditLogEntry, error) {
+	if c.BestAPIVersion() < 13 {
+		return nil, errors.NotSupportedf("querying the audit log on this controller")
+	}
+	var result params.AuditLogResults
+	if err := c.facade.FacadeCall("AuditLog", query, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
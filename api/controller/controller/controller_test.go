@@ -432,3 +432,228 @@ func (s *Suite) TestDashboardConnectionInfo(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(connectionInfo.SSHTunnel, gc.NotNil)
 }
+
+func (s *Suite) TestAuditLog(c *gc.C) {
+	var stub jujutesting.Stub
+	when := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.AuditLogResults)) = params.AuditLogResults{
+				Entries: []params.AuditLogEntry{{
+					Controller:     "0",
+					ConversationID: "abc",
+					When:           when,
+					Who:            "bob",
+				}},
+			}
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	query := params.AuditLogQuery{User: "bob", Limit: 10}
+	entries, err := client.AuditLog(query)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []params.AuditLogEntry{{
+		Controller:     "0",
+		ConversationID: "abc",
+		When:           when,
+		Who:            "bob",
+	}})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.AuditLog", []interface{}{query}},
+	})
+}
+
+func (s *Suite) TestMigrationDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	blockers := []params.MigrationBlocker{{
+		Check:   "source",
+		Message: "machine 0 is dying",
+	}}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.MigrationDryRunResults)) = params.MigrationDryRunResults{
+				Results: []params.MigrationDryRunResult{{
+					Blockers: blockers,
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	result, err := client.MigrationDryRun(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, blockers)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationDryRun", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationDryRunNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationDryRun(makeSpec())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestCreateMigrationPlan(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.StringResult)) = params.StringResult{Result: "1"}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	start := time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
+	modelUUID := randomUUID()
+	id, err := client.CreateMigrationPlan(controller.MigrationPlanSpec{
+		ModelUUIDs:            []string{spec.ModelUUID, modelUUID},
+		TargetControllerUUID:  spec.TargetControllerUUID,
+		TargetControllerAlias: spec.TargetControllerAlias,
+		TargetAddrs:           spec.TargetAddrs,
+		TargetCACert:          spec.TargetCACert,
+		TargetUser:            spec.TargetUser,
+		TargetPassword:        spec.TargetPassword,
+		TargetMacaroons:       spec.TargetMacaroons,
+		Concurrency:           2,
+		MaxAttempts:           3,
+		WindowStart:           start,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "1")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.CreateMigrationPlan", []interface{}{params.CreateMigrationPlanArgs{
+			ModelTags: []string{
+				names.NewModelTag(spec.ModelUUID).String(),
+				names.NewModelTag(modelUUID).String(),
+			},
+			TargetInfo:  specToArgs(spec).Specs[0].TargetInfo,
+			Concurrency: 2,
+			MaxAttempts: 3,
+			WindowStart: &start,
+		}}},
+	})
+}
+
+func (s *Suite) TestCreateMigrationPlanValidation(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	_, err := client.CreateMigrationPlan(controller.MigrationPlanSpec{
+		ModelUUIDs:           []string{spec.ModelUUID},
+		TargetControllerUUID: spec.TargetControllerUUID,
+		TargetAddrs:          spec.TargetAddrs,
+		TargetUser:           spec.TargetUser,
+		TargetPassword:       spec.TargetPassword,
+		MaxAttempts:          1,
+	})
+	c.Assert(err, gc.ErrorMatches, "client-side validation failed: concurrency 0 not valid")
+}
+
+func (s *Suite) TestMigrationPlans(c *gc.C) {
+	var stub jujutesting.Stub
+	plans := []params.MigrationPlan{{
+		Id:          "1",
+		Concurrency: 2,
+		Models: []params.MigrationPlanModel{{
+			ModelTag: names.NewModelTag(randomUUID()).String(),
+			Status:   "running",
+			Attempts: 1,
+		}},
+	}}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.MigrationPlanResults)) = params.MigrationPlanResults{Results: plans}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	result, err := client.MigrationPlans()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, plans)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationPlans", []interface{}{nil}},
+	})
+}
+
+func (s *Suite) TestMigrationPlansNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationPlans()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestCancelMigrationPlan(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.ErrorResult)) = params.ErrorResult{
+				Error: &params.Error{Message: "migration plan \"2\" not found", Code: params.CodeNotFound},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.CancelMigrationPlan("2")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.CancelMigrationPlan", []interface{}{params.CancelMigrationPlanArgs{Id: "2"}}},
+	})
+}
+
+func (s *Suite) TestCancelMigrationPlanNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.CancelMigrationPlan("1")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestAuditLogNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.AuditLog(params.AuditLogQuery{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
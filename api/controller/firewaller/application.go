@@ -5,11 +5,13 @@ package firewaller
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 
 	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/rpc/params"
 )
@@ -58,3 +60,32 @@ func (s *Application) ExposeInfo() (bool, map[string]params.ExposedEndpoint, err
 	}
 	return result.Exposed, result.ExposedEndpoints, nil
 }
+
+// EgressRules returns the rules restricting the outbound traffic of the
+// application's units. An application with no rules may send traffic
+// anywhere.
+func (s *Application) EgressRules() (firewall.EgressRules, error) {
+	if s.st.facade.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("egress rules on this controller")
+	}
+	var results params.StringsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetEgressRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			return nil, errors.NewNotFound(result.Error, "")
+		}
+		return nil, result.Error
+	}
+	rules, err := firewall.ParseEgressRules(strings.Join(result.Result, " "))
+	return rules, errors.Trace(err)
+}
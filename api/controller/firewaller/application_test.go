@@ -7,9 +7,12 @@ import (
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/api/controller/firewaller"
+	coreconfig "github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
@@ -60,6 +63,15 @@ func (s *applicationSuite) TestWatch(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertOneChange()
 
+	// Changes to the egress rules are detected too.
+	err = s.application.UpdateApplicationConfig(coreconfig.ConfigAttributes{
+		"egress-allow": "10.0.0.0/8",
+	}, nil, environschema.Fields{
+		"egress-allow": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
 	// Destroy the application and check it's detected.
 	err = s.application.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
@@ -93,3 +105,23 @@ func (s *applicationSuite) TestExposeInfo(c *gc.C) {
 	c.Assert(isExposed, jc.IsFalse)
 	c.Assert(exposedEndpoints, gc.HasLen, 0)
 }
+
+func (s *applicationSuite) TestEgressRules(c *gc.C) {
+	rules, err := s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = s.application.UpdateApplicationConfig(coreconfig.ConfigAttributes{
+		"egress-allow": "10.0.0.0/8 0.0.0.0/0:443",
+	}, nil, environschema.Fields{
+		"egress-allow": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err = s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, firewall.EgressRules{
+		{DestinationCIDR: "0.0.0.0/0", PortRange: network.MustParsePortRange("443/tcp")},
+		{DestinationCIDR: "10.0.0.0/8"},
+	})
+}
@@ -50,12 +50,41 @@ func (c *Client) BestFacadeVersion() int {
 // Prechecks checks that the target controller is able to accept the
 // model being migrated.
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
+	args, err := migrationModelInfo(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
+}
+
+// DryRun runs the checks that the target controller makes before and
+// while importing the model being migrated, without importing it, and
+// returns every blocker found.
+func (c *Client) DryRun(model coremigration.ModelInfo) ([]params.MigrationBlocker, error) {
+	if c.caller.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("migration dry run on target controller")
+	}
+	args, err := migrationModelInfo(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result params.MigrationDryRunResult
+	if err := c.caller.FacadeCall("DryRun", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Blockers, nil
+}
+
+func migrationModelInfo(model coremigration.ModelInfo) (params.MigrationModelInfo, error) {
 	// The model description is marshalled into YAML (description package does
 	// not support JSON) to prevent potential issues with
 	// marshalling/unmarshalling on the target API controller.
 	serialised, err := description.Serialize(model.ModelDescription)
 	if err != nil {
-		return errors.Annotate(err, "failed to marshal model description")
+		return params.MigrationModelInfo{}, errors.Annotate(err, "failed to marshal model description")
 	}
 
 	// Pass all the known facade versions to the controller so that it
@@ -68,7 +97,7 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		versions[name] = version
 	}
 
-	args := params.MigrationModelInfo{
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
@@ -76,8 +105,7 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		ControllerAgentVersion: model.ControllerAgentVersion,
 		FacadeVersions:         versions,
 		ModelDescription:       serialised,
-	}
-	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
+	}, nil
 }
 
 // Import takes a serialized model and imports it into the target
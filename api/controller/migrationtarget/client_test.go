@@ -90,6 +90,41 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	c.Assert(arg, mc, expectedArg)
 }
 
+func (s *ClientSuite) TestDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationDryRunResult)) = params.MigrationDryRunResult{
+			Blockers: []params.MigrationBlocker{{Check: "import", Message: "bad model"}},
+		}
+		return nil
+	}), BestVersion: 4}
+	client := migrationtarget.NewClient(apiCaller)
+
+	blockers, err := client.DryRun(coremigration.ModelInfo{
+		UUID:             "uuid",
+		Owner:            names.NewUserTag("owner"),
+		Name:             "name",
+		AgentVersion:     version.MustParse("1.2.3"),
+		ModelDescription: description.NewModel(description.ModelArgs{}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, jc.DeepEquals, []params.MigrationBlocker{{Check: "import", Message: "bad model"}})
+	stub.CheckCallNames(c, "MigrationTarget.DryRun")
+	arg := stub.Calls()[0].Args[1].(params.MigrationModelInfo)
+	c.Assert(arg.UUID, gc.Equals, "uuid")
+	c.Assert(arg.ModelDescription, gc.Not(gc.HasLen), 0)
+}
+
+func (s *ClientSuite) TestDryRunNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.DryRun(coremigration.ModelInfo{
+		ModelDescription: description.NewModel(description.ModelArgs{}),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
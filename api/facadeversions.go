@@ -23,16 +23,16 @@ func SupportedFacadeVersions() facades.FacadeVersions {
 var facadeVersions = facades.FacadeVersions{
 	"Action":                       {7},
 	"ActionPruner":                 {1},
-	"Agent":                        {3},
+	"Agent":                        {3, 4},
 	"AgentLifeFlag":                {1},
 	"AgentTools":                   {1},
-	"AllModelWatcher":              {4},
-	"AllWatcher":                   {3},
+	"AllModelWatcher":              {4, 5},
+	"AllWatcher":                   {3, 4},
 	"Annotations":                  {2},
 	"Application":                  {15, 16, 17, 18, 19, 20},
 	"ApplicationOffers":            {4, 5},
 	"ApplicationScaler":            {1},
-	"Backups":                      {3},
+	"Backups":                      {3, 4},
 	"Block":                        {2},
 	"Bundle":                       {6},
 	"CAASAgent":                    {2},
@@ -40,7 +40,7 @@ var facadeVersions = facades.FacadeVersions{
 	"CAASApplication":              {1},
 	"CAASApplicationProvisioner":   {1},
 	"CAASModelConfigManager":       {1},
-	"CAASFirewaller":               {1},
+	"CAASFirewaller":               {1, 2},
 	"CAASFirewallerSidecar":        {1},
 	"CAASModelOperator":            {1},
 	"CAASOperator":                 {1},
@@ -53,7 +53,7 @@ var facadeVersions = facades.FacadeVersions{
 	"Cleaner":                      {2},
 	"Client":                       {6, 7, 8},
 	"Cloud":                        {7},
-	"Controller":                   {11, 12},
+	"Controller":                   {11, 12, 13, 14},
 	"CredentialManager":            {1},
 	"CredentialValidator":          {2},
 	"CrossController":              {1},
@@ -66,7 +66,7 @@ var facadeVersions = facades.FacadeVersions{
 	"ExternalControllerUpdater":    {1},
 	"FanConfigurer":                {1},
 	"FilesystemAttachmentsWatcher": {2},
-	"Firewaller":                   {7},
+	"Firewaller":                   {7, 8},
 	"HighAvailability":             {2},
 	"HostKeyReporter":              {1},
 	"ImageMetadata":                {3},
@@ -91,9 +91,9 @@ var facadeVersions = facades.FacadeVersions{
 	"MigrationMaster":              {3},
 	"MigrationMinion":              {1},
 	"MigrationStatusWatcher":       {1},
-	"MigrationTarget":              {1, 2, 3},
+	"MigrationTarget":              {1, 2, 3, 4},
 	"ModelConfig":                  {3},
-	"ModelGeneration":              {4},
+	"ModelGeneration":              {4, 5, 6},
 	"ModelManager":                 {9, 10},
 	"ModelSummaryWatcher":          {1},
 	"ModelUpgrader":                {1},
@@ -117,7 +117,7 @@ var facadeVersions = facades.FacadeVersions{
 	"SecretBackendsManager":        {1},
 	"SecretBackendsRotateWatcher":  {1},
 	"SecretsRevisionWatcher":       {1},
-	"Secrets":                      {1, 2},
+	"Secrets":                      {1, 2, 3},
 	"SecretsManager":               {1, 2},
 	"SecretsDrain":                 {1},
 	"UserSecretsDrain":             {1},
@@ -126,17 +126,17 @@ var facadeVersions = facades.FacadeVersions{
 	"Spaces":                       {6},
 	"SSHClient":                    {4},
 	"StatusHistory":                {2},
-	"Storage":                      {6},
-	"StorageProvisioner":           {4},
+	"Storage":                      {6, 7, 8},
+	"StorageProvisioner":           {4, 5, 6},
 	"StringsWatcher":               {1},
 	"Subnets":                      {5},
 	"Undertaker":                   {1},
 	"UnitAssigner":                 {1},
-	"Uniter":                       {18, 19},
+	"Uniter":                       {18, 19, 20},
 	"Upgrader":                     {1},
 	"UpgradeSeries":                {3, 4},
 	"UpgradeSteps":                 {2},
-	"UserManager":                  {3},
+	"UserManager":                  {3, 4},
 	"VolumeAttachmentsWatcher":     {2},
 	"VolumeAttachmentPlansWatcher": {1},
 }
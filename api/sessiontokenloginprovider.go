@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/juju/errors"
 	jujuhttp "github.com/juju/http/v2"
@@ -16,6 +17,11 @@ import (
 	"github.com/juju/juju/rpc/params"
 )
 
+// defaultDevicePollInterval is the interval between polls for the session
+// token while a device login is pending, if the controller doesn't
+// specify one.
+const defaultDevicePollInterval = 5 * time.Second
+
 var (
 	loginDeviceAPICall = func(caller base.APICaller, request interface{}, response interface{}) error {
 		return caller.APICall("Admin", 4, "", "LoginDevice", request, response)
@@ -102,6 +108,7 @@ func (p *sessionTokenLoginProvider) initiateDeviceLogin(ctx context.Context, cal
 	var deviceResult struct {
 		UserCode        string `json:"user-code"`
 		VerificationURI string `json:"verification-uri"`
+		Interval        int    `json:"interval"`
 	}
 
 	// The first call we make is to initiate the device login oauth2 flow. This will
@@ -123,10 +130,24 @@ func (p *sessionTokenLoginProvider) initiateDeviceLogin(ctx context.Context, cal
 		SessionToken string `json:"session-token"`
 	}
 	var sessionTokenResult loginResponse
-	// Then we make a blocking call to get the session token.
-	err = getDeviceSessionTokenAPICall(caller, &loginRequest{}, &sessionTokenResult)
-	if err != nil {
-		return errors.Trace(err)
+	// Then we poll for the session token until the user has logged in.
+	interval := time.Duration(deviceResult.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	for {
+		err = getDeviceSessionTokenAPICall(caller, &loginRequest{}, &sessionTokenResult)
+		if err == nil {
+			break
+		}
+		if !params.IsCodeDeviceLoginPending(err) {
+			return errors.Trace(err)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-time.After(interval):
+		}
 	}
 
 	p.sessionToken = sessionTokenResult.SessionToken
@@ -40,9 +40,11 @@ func (s *sessionTokenLoginProviderSuite) TestSessionTokenLogin(c *gc.C) {
 		lr := struct {
 			UserCode        string `json:"user-code"`
 			VerificationURI string `json:"verification-uri"`
+			Interval        int    `json:"interval"`
 		}{
 			UserCode:        userCode,
 			VerificationURI: verificationURI,
+			Interval:        1,
 		}
 
 		data, err := json.Marshal(lr)
@@ -53,7 +55,16 @@ func (s *sessionTokenLoginProviderSuite) TestSessionTokenLogin(c *gc.C) {
 		return json.Unmarshal(data, response)
 	})
 
+	polls := 0
 	s.PatchValue(api.GetDeviceSessionTokenAPICall, func(_ base.APICaller, request interface{}, response interface{}) error {
+		polls++
+		if polls == 1 {
+			// The user has yet to log in with the issuer.
+			return &params.Error{
+				Message: "device login pending",
+				Code:    params.CodeDeviceLoginPending,
+			}
+		}
 		lr := struct {
 			SessionToken string `json:"session-token"`
 		}{
@@ -124,6 +135,7 @@ func (s *sessionTokenLoginProviderSuite) TestSessionTokenLogin(c *gc.C) {
 
 	c.Check(output.String(), gc.Equals, "Please visit http://localhost:8080/test-verification and enter code 1234567 to log in.\n")
 	c.Check(obtainedSessionToken, gc.Equals, sessionToken)
+	c.Check(polls, gc.Equals, 2)
 	c.Check(err, jc.ErrorIsNil)
 }
 
@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/authentication/oidc"
 	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
@@ -50,6 +51,108 @@ func newAdminAPIV3(srv *Server, root *apiHandler, apiObserver observer.Observer)
 	}
 }
 
+// adminV4 extends the admin API with the OAuth 2.0 device login used
+// when the controller trusts an OpenID Connect issuer.
+type adminV4 struct {
+	*admin
+
+	// deviceLogin is the device login started by LoginDevice, which
+	// is completed by GetDeviceSessionToken.
+	deviceLogin *oidc.DeviceLogin
+}
+
+func newAdminAPIV4(srv *Server, root *apiHandler, apiObserver observer.Observer) interface{} {
+	return &adminV4{
+		admin: newAdminAPIV3(srv, root, apiObserver).(*admin),
+	}
+}
+
+// Admin returns an object that provides API access to methods that can be
+// called even when not authenticated.
+func (a *adminV4) Admin(id string) (*adminV4, error) {
+	if id != "" {
+		// Safeguard id for possible future use.
+		return nil, apiservererrors.ErrBadId
+	}
+	return a, nil
+}
+
+// LoginDevice starts a device login with the controller's OpenID Connect
+// issuer. The returned user code and verification URI are presented to
+// the user, who logs in with the issuer while GetDeviceSessionToken is
+// polled at the returned interval.
+func (a *adminV4) LoginDevice() (params.LoginDeviceResult, error) {
+	if a.srv.oidcAuthenticator == nil {
+		return params.LoginDeviceResult{}, errors.NotSupportedf("device login")
+	}
+	deviceLogin, err := a.srv.oidcAuthenticator.StartDeviceLogin(context.Background())
+	if err != nil {
+		return params.LoginDeviceResult{}, errors.Trace(err)
+	}
+
+	a.mu.Lock()
+	a.deviceLogin = deviceLogin
+	a.mu.Unlock()
+
+	return params.LoginDeviceResult{
+		UserCode:        deviceLogin.UserCode(),
+		VerificationURI: deviceLogin.VerificationURI(),
+		Interval:        int(deviceLogin.Interval() / time.Second),
+	}, nil
+}
+
+// GetDeviceSessionToken checks whether the user has completed the device
+// login started by LoginDevice, returning the session token to log in
+// with if so. It does not wait for the user: while the login is still
+// pending, an error with the CodeDeviceLoginPending code is returned and
+// the client should call again after the interval returned by LoginDevice.
+func (a *adminV4) GetDeviceSessionToken() (params.SessionTokenResult, error) {
+	if a.srv.oidcAuthenticator == nil {
+		return params.SessionTokenResult{}, errors.NotSupportedf("device login")
+	}
+
+	a.mu.Lock()
+	deviceLogin := a.deviceLogin
+	a.mu.Unlock()
+	if deviceLogin == nil {
+		return params.SessionTokenResult{}, errors.NotFoundf("device login")
+	}
+
+	sessionToken, err := a.srv.oidcAuthenticator.PollDeviceLogin(context.Background(), deviceLogin)
+	if errors.Is(err, apiservererrors.ErrDeviceLoginPending) {
+		return params.SessionTokenResult{}, errors.Trace(err)
+	}
+
+	// The device login is over, one way or the other.
+	a.mu.Lock()
+	if a.deviceLogin == deviceLogin {
+		a.deviceLogin = nil
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return params.SessionTokenResult{}, errors.Trace(err)
+	}
+	return params.SessionTokenResult{
+		SessionToken: sessionToken,
+	}, nil
+}
+
+// LoginWithSessionToken logs in with a session token obtained from a
+// device login. All subsequent requests on the connection will act as
+// the authenticated user.
+func (a *adminV4) LoginWithSessionToken(req params.SessionTokenLoginRequest) (params.LoginResult, error) {
+	if a.srv.oidcAuthenticator == nil {
+		return params.LoginResult{}, errors.NotSupportedf("session token login")
+	}
+	if req.SessionToken == "" {
+		// An empty token tells the client to start a device login.
+		return params.LoginResult{}, apiservererrors.ErrSessionTokenInvalid
+	}
+	return a.login(context.Background(), params.LoginRequest{
+		SessionToken: req.SessionToken,
+	}, 4)
+}
+
 // Admin returns an object that provides API access to methods that can be
 // called even when not authenticated.
 func (a *admin) Admin(id string) (*admin, error) {
@@ -283,6 +386,7 @@ func (a *admin) authenticate(ctx context.Context, req params.LoginRequest) (*aut
 			Credentials:   req.Credentials,
 			Nonce:         req.Nonce,
 			Token:         req.Token,
+			SessionToken:  req.SessionToken,
 			Macaroons:     req.Macaroons,
 			BakeryVersion: req.BakeryVersion,
 		}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/httpbakery"
+	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -32,8 +33,10 @@ import (
 	machineclient "github.com/juju/juju/api/client/machinemanager"
 	"github.com/juju/juju/api/client/modelconfig"
 	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/apiserver/authentication/oidc"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/stateauthenticator"
 	servertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/apiserver/testserver"
 	corecontroller "github.com/juju/juju/controller"
@@ -1535,6 +1538,167 @@ func (s *loginV3Suite) TestClientLoginToRootOldClient(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, ".*this version of Juju does not support login from old clients.*")
 }
 
+const oidcClientID = "juju-controller"
+
+// deviceLoginSuite tests the device login methods of Admin v4, with the
+// controller trusting a fake OpenID Connect issuer.
+type deviceLoginSuite struct {
+	baseSuite
+	issuer *servertesting.FakeOIDCIssuer
+}
+
+var _ = gc.Suite(&deviceLoginSuite{})
+
+func (s *deviceLoginSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	s.issuer = servertesting.NewFakeOIDCIssuer(c, oidcClientID)
+	s.AddCleanup(func(*gc.C) { s.issuer.Close() })
+
+	authenticator, err := oidc.NewAuthenticator(oidc.Config{
+		IssuerURL:     s.issuer.URL(),
+		ClientID:      oidcClientID,
+		UsernameClaim: "email",
+		GroupsClaim:   "groups",
+		GroupAccess: []corecontroller.OIDCGroupAccessEntry{{
+			Group:  "users",
+			Access: permission.LoginAccess,
+		}, {
+			Group:  "users",
+			Target: s.Model.ModelTag(),
+			Access: permission.ReadAccess,
+		}},
+		JujuAccess: &stateauthenticator.PermissionDelegator{State: s.State},
+		HTTPClient: s.issuer.Client(),
+		Clock:      clock.WallClock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { authenticator.Close() })
+	s.cfg.OIDCAuthenticator = authenticator
+}
+
+func (s *deviceLoginSuite) idToken(c *gc.C, audience string, claims map[string]interface{}) string {
+	return s.issuer.NewIDToken(c, s.issuer.URL(), audience, time.Now().Add(time.Hour), claims)
+}
+
+func (s *deviceLoginSuite) loginWithSessionToken(c *gc.C, st api.Connection, token string) (params.LoginResult, error) {
+	var result params.LoginResult
+	err := st.APICall("Admin", 4, "", "LoginWithSessionToken", params.SessionTokenLoginRequest{
+		SessionToken: token,
+	}, &result)
+	return result, err
+}
+
+func (s *deviceLoginSuite) TestDeviceLogin(c *gc.C) {
+	s.issuer.SetIDToken(s.idToken(c, oidcClientID, map[string]interface{}{
+		"email":  "fred@example.com",
+		"groups": []string{"users"},
+	}))
+	st := s.openAPIWithoutLogin(c, s.newServer(c).Info)
+
+	var device params.LoginDeviceResult
+	err := st.APICall("Admin", 4, "", "LoginDevice", nil, &device)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(device, jc.DeepEquals, params.LoginDeviceResult{
+		UserCode:        "ABCD-EFGH",
+		VerificationURI: s.issuer.URL() + "/verify",
+		Interval:        1,
+	})
+
+	var token params.SessionTokenResult
+	err = st.APICall("Admin", 4, "", "GetDeviceSessionToken", nil, &token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.SessionToken, gc.Not(gc.Equals), "")
+
+	result, err := s.loginWithSessionToken(c, st, token.SessionToken)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.UserInfo, gc.NotNil)
+	c.Assert(result.UserInfo.Identity, gc.Equals, "user-fred@example.com")
+	c.Assert(result.UserInfo.ControllerAccess, gc.Equals, "login")
+	c.Assert(result.UserInfo.ModelAccess, gc.Equals, "read")
+}
+
+func (s *deviceLoginSuite) TestGetDeviceSessionTokenPending(c *gc.C) {
+	s.issuer.SetTokenError("authorization_pending")
+	st := s.openAPIWithoutLogin(c, s.newServer(c).Info)
+
+	var device params.LoginDeviceResult
+	err := st.APICall("Admin", 4, "", "LoginDevice", nil, &device)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var token params.SessionTokenResult
+	err = st.APICall("Admin", 4, "", "GetDeviceSessionToken", nil, &token)
+	c.Assert(params.IsCodeDeviceLoginPending(err), jc.IsTrue, gc.Commentf("%v", err))
+}
+
+func (s *deviceLoginSuite) TestGetDeviceSessionTokenWithoutLoginDevice(c *gc.C) {
+	st := s.openAPIWithoutLogin(c, s.newServer(c).Info)
+
+	var token params.SessionTokenResult
+	err := st.APICall("Admin", 4, "", "GetDeviceSessionToken", nil, &token)
+	c.Assert(err, gc.ErrorMatches, `device login not found`)
+}
+
+func (s *deviceLoginSuite) TestLoginWithSessionTokenForUserWithoutAccess(c *gc.C) {
+	st := s.openAPIWithoutLogin(c, s.newServer(c).Info)
+
+	// The token is valid, but it was issued for a user who has been
+	// granted no access to the controller.
+	_, err := s.loginWithSessionToken(c, st, s.idToken(c, oidcClientID, map[string]interface{}{
+		"email": "mallory@example.com",
+	}))
+	assertPermissionDenied(c, err)
+}
+
+func (s *deviceLoginSuite) TestLoginWithSessionTokenForJujuUser(c *gc.C) {
+	s.Factory.MakeModelUser(c, &factory.ModelUserParams{
+		User:   "wally@example.com",
+		Access: permission.WriteAccess,
+	})
+	err := controller.ChangeControllerAccess(
+		s.State, s.Owner, names.NewUserTag("wally@example.com"),
+		params.GrantControllerAccess, permission.LoginAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.openAPIWithoutLogin(c, s.newServer(c).Info)
+
+	// Access granted within Juju is honoured for users who aren't in
+	// any of the issuer groups.
+	result, err := s.loginWithSessionToken(c, st, s.idToken(c, oidcClientID, map[string]interface{}{
+		"email": "wally@example.com",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.UserInfo.Identity, gc.Equals, "user-wally@example.com")
+	c.Assert(result.UserInfo.ModelAccess, gc.Equals, "write")
+}
+
+func (s *deviceLoginSuite) TestLoginWithSessionTokenForOtherClient(c *gc.C) {
+	st := s.openAPIWithoutLogin(c, s.newServer(c).Info)
+
+	_, err := s.loginWithSessionToken(c, st, s.idToken(c, "other-client", map[string]interface{}{
+		"email":  "fred@example.com",
+		"groups": []string{"users"},
+	}))
+	c.Assert(params.IsCodeSessionTokenInvalid(err), jc.IsTrue, gc.Commentf("%v", err))
+}
+
+func (s *deviceLoginSuite) TestLoginWithEmptySessionToken(c *gc.C) {
+	st := s.openAPIWithoutLogin(c, s.newServer(c).Info)
+
+	_, err := s.loginWithSessionToken(c, st, "")
+	c.Assert(params.IsCodeSessionTokenInvalid(err), jc.IsTrue, gc.Commentf("%v", err))
+}
+
+func (s *deviceLoginSuite) TestDeviceLoginNotSupported(c *gc.C) {
+	s.cfg.OIDCAuthenticator = nil
+	st := s.openAPIWithoutLogin(c, s.newServer(c).Info)
+
+	var device params.LoginDeviceResult
+	err := st.APICall("Admin", 4, "", "LoginDevice", nil, &device)
+	c.Assert(err, gc.ErrorMatches, `device login not supported`)
+
+	_, err = s.loginWithSessionToken(c, st, "token")
+	c.Assert(err, gc.ErrorMatches, `session token login not supported`)
+}
+
 // errorTransport implements http.RoundTripper by always
 // returning the given error from RoundTrip when it visits
 // the given URL (otherwise it uses the fallback transport.
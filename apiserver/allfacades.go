@@ -294,6 +294,7 @@ func AllFacades() *facade.Registry {
 // admin APIs with specific versions.
 var adminAPIFactories = map[int]adminAPIFactory{
 	3: newAdminAPIV3,
+	4: newAdminAPIV4,
 }
 
 // AdminFacadeDetails returns information on the Admin facade provided
@@ -319,12 +320,14 @@ func registerWatchers(registry *facade.Registry) {
 		return NewPinger(ctx)
 	}, reflect.TypeOf((*Pinger)(nil)).Elem())
 
-	registry.MustRegister("AllWatcher", 3, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllWatcher", 3, NewAllWatcherV3, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllWatcher", 4, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	// Note: AllModelWatcher uses the same infrastructure as AllWatcher
 	// but they are get under separate names as it possible the may
 	// diverge in the future (especially in terms of authorisation
 	// checks).
-	registry.MustRegister("AllModelWatcher", 4, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllModelWatcher", 4, NewAllWatcherV3, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllModelWatcher", 5, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	registry.MustRegister("NotifyWatcher", 1, newNotifyWatcher, reflect.TypeOf((*srvNotifyWatcher)(nil)))
 	registry.MustRegister("StringsWatcher", 1, newStringsWatcher, reflect.TypeOf((*srvStringsWatcher)(nil)))
 	registry.MustRegister("OfferStatusWatcher", 1, newOfferStatusWatcher, reflect.TypeOf((*srvOfferStatusWatcher)(nil)))
@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/authentication/jwt"
 	"github.com/juju/juju/apiserver/authentication/macaroon"
+	"github.com/juju/juju/apiserver/authentication/oidc"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/apihttp"
 	"github.com/juju/juju/apiserver/common/crossmodel"
@@ -80,6 +81,7 @@ type Server struct {
 
 	localMacaroonAuthenticator macaroon.LocalMacaroonAuthenticator
 	jwtAuthenticator           jwt.Authenticator
+	oidcAuthenticator          oidc.Authenticator
 
 	httpAuthenticators  []authentication.HTTPAuthenticator
 	loginAuthenticators []authentication.LoginAuthenticator
@@ -149,6 +151,11 @@ type ServerConfig struct {
 	// provider.
 	JWTAuthenticator jwt.Authenticator
 
+	// OIDCAuthenticator is the request authenticator used for validating
+	// OpenID Connect ID tokens when the controller has been bootstrapped
+	// with a trusted OpenID Connect issuer.
+	OIDCAuthenticator oidc.Authenticator
+
 	// MultiwatcherFactory is used by the API server to create
 	// multiwatchers. The real factory is managed by the multiwatcher
 	// worker.
@@ -345,6 +352,11 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		httpAuthenticators = append([]authentication.HTTPAuthenticator{cfg.JWTAuthenticator}, httpAuthenticators...)
 		loginAuthenticators = append([]authentication.LoginAuthenticator{cfg.JWTAuthenticator}, loginAuthenticators...)
 	}
+	// Likewise, only add the oidc authenticator if it's not nil.
+	if cfg.OIDCAuthenticator != nil {
+		httpAuthenticators = append([]authentication.HTTPAuthenticator{cfg.OIDCAuthenticator}, httpAuthenticators...)
+		loginAuthenticators = append([]authentication.LoginAuthenticator{cfg.OIDCAuthenticator}, loginAuthenticators...)
+	}
 
 	srv := &Server{
 		clock:                         cfg.Clock,
@@ -359,6 +371,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		mux:                           cfg.Mux,
 		localMacaroonAuthenticator:    cfg.LocalMacaroonAuthenticator,
 		jwtAuthenticator:              cfg.JWTAuthenticator,
+		oidcAuthenticator:             cfg.OIDCAuthenticator,
 		httpAuthenticators:            httpAuthenticators,
 		loginAuthenticators:           loginAuthenticators,
 		allowModelAccess:              cfg.AllowModelAccess,
@@ -438,6 +451,10 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		return nil, errors.Annotate(err, "unable to subscribe to restart message")
 	}
 
+	// The storage usage metrics are read from the controller's
+	// state for as long as the server is running.
+	srv.metricsCollector.SetStorageUsageSource(systemState)
+
 	ready := make(chan struct{})
 	srv.tomb.Go(func() error {
 		defer srv.apiServerLoggers.dispose()
@@ -445,6 +462,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		defer srv.shared.Close()
 		defer unsubscribe()
 		defer unsubscribeControllerConfig()
+		defer srv.metricsCollector.SetStorageUsageSource(nil)
 		return srv.loop(ready)
 	})
 
@@ -6,10 +6,12 @@ package apiserver
 import (
 	"fmt"
 	"runtime"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/juju/juju/apiserver/observer/metricobserver"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/version"
 )
 
@@ -39,6 +41,10 @@ const (
 
 	// MetricLabelVersion is the metric for the Juju Version of the controller
 	MetricLabelVersion = "version"
+
+	// MetricLabelStorage defines a storage ID constant for the storage
+	// usage Labels
+	MetricLabelStorage = "storage"
 )
 
 // MetricAPIConnectionsLabelNames defines a series of labels for the
@@ -76,6 +82,19 @@ var MetricTotalRequestsLabelNames = []string{
 	MetricLabelHost,
 }
 
+// MetricStorageUsageLabelNames defines a series of labels for the storage
+// usage metrics.
+var MetricStorageUsageLabelNames = []string{
+	MetricLabelModelUUID,
+	MetricLabelStorage,
+}
+
+// StorageUsageSource provides the last reported usage of the storage
+// in all models on the controller.
+type StorageUsageSource interface {
+	AllStorageUsageForController() ([]state.ModelStorageUsage, error)
+}
+
 // Collector is a prometheus.Collector that collects metrics based
 // on apiserver status.
 type Collector struct {
@@ -94,6 +113,17 @@ type Collector struct {
 	TotalRequests         *prometheus.CounterVec
 	TotalRequestErrors    *prometheus.CounterVec
 	TotalRequestsDuration *prometheus.SummaryVec
+
+	StorageBytesUsed   *prometheus.GaugeVec
+	StorageBytesTotal  *prometheus.GaugeVec
+	StorageInodesUsed  *prometheus.GaugeVec
+	StorageInodesTotal *prometheus.GaugeVec
+
+	// The storage usage gauges are reset and refilled from the
+	// source on every collection, so that removed storage and
+	// models are dropped and nothing is lost on restart.
+	mu                 sync.Mutex
+	storageUsageSource StorageUsageSource
 }
 
 // NewMetricsCollector returns a new Collector.
@@ -194,6 +224,31 @@ func NewMetricsCollector() *Collector {
 				0.99: 0.001,
 			},
 		}, MetricTotalRequestsLabelNames),
+
+		StorageBytesUsed: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "storage_bytes_used",
+			Help:      "Bytes used by storage instances, as reported by units",
+		}, MetricStorageUsageLabelNames),
+		StorageBytesTotal: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "storage_bytes_total",
+			Help:      "Total bytes of storage instances, as reported by units",
+		}, MetricStorageUsageLabelNames),
+		StorageInodesUsed: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "storage_inodes_used",
+			Help:      "Inodes used by storage instances, as reported by units",
+		}, MetricStorageUsageLabelNames),
+		StorageInodesTotal: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "storage_inodes_total",
+			Help:      "Total inodes of storage instances, as reported by units",
+		}, MetricStorageUsageLabelNames),
 		BuildInfo: buildInfo,
 	}
 }
@@ -210,6 +265,10 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.TotalRequests.Describe(ch)
 	c.TotalRequestErrors.Describe(ch)
 	c.TotalRequestsDuration.Describe(ch)
+	c.StorageBytesUsed.Describe(ch)
+	c.StorageBytesTotal.Describe(ch)
+	c.StorageInodesUsed.Describe(ch)
+	c.StorageInodesTotal.Describe(ch)
 	c.BuildInfo.Describe(ch)
 }
 
@@ -225,5 +284,42 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.TotalRequests.Collect(ch)
 	c.TotalRequestErrors.Collect(ch)
 	c.TotalRequestsDuration.Collect(ch)
+	c.collectStorageUsage(ch)
 	c.BuildInfo.Collect(ch)
 }
+
+// SetStorageUsageSource sets the source of the storage usage metrics.
+// If the source is nil, no storage usage metrics are collected.
+func (c *Collector) SetStorageUsageSource(source StorageUsageSource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.storageUsageSource = source
+}
+
+func (c *Collector) collectStorageUsage(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.StorageBytesUsed.Reset()
+	c.StorageBytesTotal.Reset()
+	c.StorageInodesUsed.Reset()
+	c.StorageInodesTotal.Reset()
+
+	if c.storageUsageSource != nil {
+		all, err := c.storageUsageSource.AllStorageUsageForController()
+		if err != nil {
+			logger.Debugf("cannot get storage usage: %v", err)
+		}
+		for _, usage := range all {
+			c.StorageBytesUsed.WithLabelValues(usage.ModelUUID, usage.StorageID).Set(float64(usage.BytesUsed))
+			c.StorageBytesTotal.WithLabelValues(usage.ModelUUID, usage.StorageID).Set(float64(usage.BytesTotal))
+			c.StorageInodesUsed.WithLabelValues(usage.ModelUUID, usage.StorageID).Set(float64(usage.InodesUsed))
+			c.StorageInodesTotal.WithLabelValues(usage.ModelUUID, usage.StorageID).Set(float64(usage.InodesTotal))
+		}
+	}
+
+	c.StorageBytesUsed.Collect(ch)
+	c.StorageBytesTotal.Collect(ch)
+	c.StorageInodesUsed.Collect(ch)
+	c.StorageInodesTotal.Collect(ch)
+}
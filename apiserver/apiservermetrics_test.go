@@ -6,13 +6,16 @@ package apiserver_test
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/version"
 )
 
@@ -38,7 +41,7 @@ func (s *apiservermetricsSuite) TestDescribe(c *gc.C) {
 	for desc := range ch {
 		descs = append(descs, desc)
 	}
-	c.Assert(descs, gc.HasLen, 11)
+	c.Assert(descs, gc.HasLen, 15)
 	c.Assert(descs[0].String(), gc.Matches, `.*fqName: "juju_apiserver_connections_total".*`)
 	c.Assert(descs[1].String(), gc.Matches, `.*fqName: "juju_apiserver_connections".*`)
 	c.Assert(descs[2].String(), gc.Matches, `.*fqName: "juju_apiserver_active_login_attempts".*`)
@@ -50,7 +53,11 @@ func (s *apiservermetricsSuite) TestDescribe(c *gc.C) {
 	c.Assert(descs[7].String(), gc.Matches, `.*fqName: "juju_apiserver_outbound_requests_total".*`)
 	c.Assert(descs[8].String(), gc.Matches, `.*fqName: "juju_apiserver_outbound_request_errors_total".*`)
 	c.Assert(descs[9].String(), gc.Matches, `.*fqName: "juju_apiserver_outbound_request_duration_seconds".*`)
-	build_info_description := descs[10].String()
+	c.Assert(descs[10].String(), gc.Matches, `.*fqName: "juju_apiserver_storage_bytes_used".*`)
+	c.Assert(descs[11].String(), gc.Matches, `.*fqName: "juju_apiserver_storage_bytes_total".*`)
+	c.Assert(descs[12].String(), gc.Matches, `.*fqName: "juju_apiserver_storage_inodes_used".*`)
+	c.Assert(descs[13].String(), gc.Matches, `.*fqName: "juju_apiserver_storage_inodes_total".*`)
+	build_info_description := descs[14].String()
 	c.Check(build_info_description, gc.Matches, `.*fqName: "juju_apiserver_build_info".*`)
 	// Ensure that the current version of the Juju controller is one of the const labels on the
 	//build_info metric.
@@ -73,6 +80,75 @@ func (s *apiservermetricsSuite) TestCollect(c *gc.C) {
 	c.Assert(metrics, gc.HasLen, 3)
 }
 
+type fakeStorageUsageSource struct {
+	usage []state.ModelStorageUsage
+}
+
+func (f *fakeStorageUsageSource) AllStorageUsageForController() ([]state.ModelStorageUsage, error) {
+	return f.usage, nil
+}
+
+func (s *apiservermetricsSuite) collectStorageUsage(c *gc.C) map[string]float64 {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		s.collector.Collect(ch)
+	}()
+	values := make(map[string]float64)
+	for metric := range ch {
+		desc := metric.Desc().String()
+		if !strings.Contains(desc, "juju_apiserver_storage_") {
+			continue
+		}
+		var m dto.Metric
+		err := metric.Write(&m)
+		c.Assert(err, jc.ErrorIsNil)
+		name := desc[strings.Index(desc, "juju_apiserver_storage_"):]
+		name = name[:strings.Index(name, `"`)]
+		for _, label := range m.GetLabel() {
+			name += " " + label.GetValue()
+		}
+		values[name] = m.GetGauge().GetValue()
+	}
+	return values
+}
+
+func (s *apiservermetricsSuite) TestCollectStorageUsage(c *gc.C) {
+	collector := s.collector.(*apiserver.Collector)
+	c.Assert(s.collectStorageUsage(c), gc.HasLen, 0)
+
+	source := &fakeStorageUsageSource{
+		usage: []state.ModelStorageUsage{{
+			ModelUUID: "deadbeef",
+			StorageID: "data/0",
+			StorageUsage: state.StorageUsage{
+				BytesUsed:   1024,
+				BytesTotal:  4096,
+				InodesUsed:  10,
+				InodesTotal: 100,
+			},
+		}},
+	}
+	collector.SetStorageUsageSource(source)
+	c.Assert(s.collectStorageUsage(c), jc.DeepEquals, map[string]float64{
+		"juju_apiserver_storage_bytes_used deadbeef data/0":   1024,
+		"juju_apiserver_storage_bytes_total deadbeef data/0":  4096,
+		"juju_apiserver_storage_inodes_used deadbeef data/0":  10,
+		"juju_apiserver_storage_inodes_total deadbeef data/0": 100,
+	})
+
+	// Usage of removed storage is no longer collected.
+	source.usage = nil
+	c.Assert(s.collectStorageUsage(c), gc.HasLen, 0)
+
+	source.usage = []state.ModelStorageUsage{{
+		ModelUUID: "deadbeef",
+		StorageID: "data/1",
+	}}
+	collector.SetStorageUsageSource(nil)
+	c.Assert(s.collectStorageUsage(c), gc.HasLen, 0)
+}
+
 func (s *apiservermetricsSuite) TestLabelNames(c *gc.C) {
 	// This is the prometheus label specs.
 	labelNameRE := regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
@@ -106,6 +182,11 @@ func (s *apiservermetricsSuite) TestLabelNames(c *gc.C) {
 			labels:  apiserver.MetricTotalRequestsLabelNames,
 			checker: jc.IsTrue,
 		},
+		{
+			name:    "storage usage label names",
+			labels:  apiserver.MetricStorageUsageLabelNames,
+			checker: jc.IsTrue,
+		},
 		{
 			name:    "invalid names",
 			labels:  []string{"model-uuid"},
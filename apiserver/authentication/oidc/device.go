@@ -0,0 +1,191 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"golang.org/x/oauth2"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+)
+
+const (
+	// deviceGrantType is the grant type used to exchange a device code
+	// for a token, as defined by RFC 8628.
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultPollInterval is the minimum interval between polls of the
+	// issuer if it doesn't specify one.
+	defaultPollInterval = 5 * time.Second
+
+	// slowDownInterval is added to the poll interval each time the
+	// issuer asks for polling to slow down.
+	slowDownInterval = 5 * time.Second
+)
+
+// deviceScopes are the scopes requested when starting a device login.
+var deviceScopes = []string{"openid", "profile", "email"}
+
+// DeviceLogin holds the state of a device login started with the issuer.
+type DeviceLogin struct {
+	response *oauth2.DeviceAuthResponse
+
+	mu       sync.Mutex
+	interval time.Duration
+	// nextPoll is the earliest time the issuer may be polled again.
+	nextPoll time.Time
+}
+
+// UserCode is the code the user enters at the verification URI.
+func (d *DeviceLogin) UserCode() string {
+	return d.response.UserCode
+}
+
+// VerificationURI is where the user logs in and enters the user code.
+func (d *DeviceLogin) VerificationURI() string {
+	return d.response.VerificationURI
+}
+
+// Interval is the minimum interval between polls of the device login.
+func (d *DeviceLogin) Interval() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.interval
+}
+
+func (a *OIDCAuthenticator) oauth2Config(provider providerMetadata) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID: a.config.ClientID,
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: provider.DeviceAuthorizationEndpoint,
+			TokenURL:      provider.TokenEndpoint,
+		},
+		Scopes: deviceScopes,
+	}
+}
+
+func (a *OIDCAuthenticator) oauth2Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, a.config.HTTPClient)
+}
+
+// StartDeviceLogin starts an OAuth 2.0 device authorization grant with
+// the issuer. The returned user code and verification URI are presented
+// to the user.
+func (a *OIDCAuthenticator) StartDeviceLogin(ctx context.Context) (*DeviceLogin, error) {
+	provider, _, err := a.discovered(ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "discovering issuer")
+	}
+	if provider.DeviceAuthorizationEndpoint == "" {
+		return nil, errors.NotSupportedf("device login with issuer %q", a.config.IssuerURL)
+	}
+	response, err := a.oauth2Config(provider).DeviceAuth(a.oauth2Context(ctx))
+	if err != nil {
+		return nil, errors.Annotate(err, "starting device login")
+	}
+	interval := time.Duration(response.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return &DeviceLogin{
+		response: response,
+		interval: interval,
+	}, nil
+}
+
+// tokenResponse holds the parts of the issuer's token response used to
+// complete a device login.
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// PollDeviceLogin asks the issuer once whether the user has completed
+// the device login, without waiting for them to do so. If they have, the
+// ID token issued for the user is validated and returned. Otherwise, or
+// if polled more often than the issuer allows, ErrDeviceLoginPending is
+// returned and the caller should poll again after the device login's
+// interval.
+func (a *OIDCAuthenticator) PollDeviceLogin(ctx context.Context, device *DeviceLogin) (string, error) {
+	now := a.config.Clock.Now()
+	if expiry := device.response.Expiry; !expiry.IsZero() && now.After(expiry) {
+		return "", errors.New("device login expired")
+	}
+
+	device.mu.Lock()
+	if now.Before(device.nextPoll) {
+		device.mu.Unlock()
+		return "", errors.Trace(apiservererrors.ErrDeviceLoginPending)
+	}
+	device.nextPoll = now.Add(device.interval)
+	device.mu.Unlock()
+
+	provider, _, err := a.discovered(ctx)
+	if err != nil {
+		return "", errors.Annotate(err, "discovering issuer")
+	}
+	response, err := a.requestToken(ctx, provider, device)
+	if err != nil {
+		return "", errors.Annotate(err, "completing device login")
+	}
+	switch response.Error {
+	case "":
+	case "authorization_pending":
+		return "", errors.Trace(apiservererrors.ErrDeviceLoginPending)
+	case "slow_down":
+		device.mu.Lock()
+		device.interval += slowDownInterval
+		device.nextPoll = now.Add(device.interval)
+		device.mu.Unlock()
+		return "", errors.Trace(apiservererrors.ErrDeviceLoginPending)
+	default:
+		return "", errors.Errorf("completing device login: %s %s", response.Error, response.ErrorDescription)
+	}
+
+	if response.IDToken == "" {
+		return "", errors.NotFoundf("ID token in token response")
+	}
+	if _, _, err := a.Parse(ctx, response.IDToken); err != nil {
+		return "", errors.Annotate(err, "validating ID token")
+	}
+	return response.IDToken, nil
+}
+
+// requestToken makes a single device access token request to the
+// issuer's token endpoint.
+func (a *OIDCAuthenticator) requestToken(ctx context.Context, provider providerMetadata, device *DeviceLogin) (tokenResponse, error) {
+	form := url.Values{
+		"grant_type":  {deviceGrantType},
+		"device_code": {device.response.DeviceCode},
+		"client_id":   {a.config.ClientID},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return tokenResponse{}, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := a.config.HTTPClient.Do(req)
+	if err != nil {
+		return tokenResponse{}, errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var response tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return tokenResponse{}, errors.Annotatef(err, "decoding token response %q", resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK && response.Error == "" {
+		return tokenResponse{}, errors.Errorf("unexpected token response %q", resp.Status)
+	}
+	return response, nil
+}
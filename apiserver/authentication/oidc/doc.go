@@ -0,0 +1,34 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package oidc provides an authentication and authorisation mechanism whereby
+// a Juju controller trusts an OpenID Connect issuer to authenticate human
+// users.
+//
+// This mechanism kicks in if the controller is bootstrapped with the
+// 'oidc-issuer-url' and 'oidc-client-id' controller config set.
+//
+// # Authentication
+//
+// Clients log in using the OAuth 2.0 device authorization grant. The
+// controller starts the device flow with the issuer on behalf of the client,
+// which presents the user code and verification URL to the user. Once the
+// user has logged in with the issuer, the controller obtains an ID token
+// which is handed back to the client as its session token. The client polls
+// the controller for the session token, and the controller polls the issuer
+// once for each request, so no API request waits on the user.
+//
+// The session token is then used to authenticate Juju login requests
+// against the API endpoint, and raw HTTP requests. ID tokens are validated
+// against the issuer's JWKS, and must name the controller's client ID in
+// their audience. The Juju user is taken from the 'oidc-username-claim'
+// claim, and is always an external user.
+//
+// # Authorisation
+//
+// As with the jwt package, the Juju permission model is not consulted for
+// users authenticated with an ID token. Instead, the groups found in the
+// 'oidc-groups-claim' claim are mapped to controller, model, cloud and offer
+// access using the 'oidc-group-access' controller config.
+
+package oidc
@@ -0,0 +1,564 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/permission"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.authentication.oidc")
+
+const (
+	// discoveryPath is the path, relative to the issuer URL, of the
+	// OpenID Connect discovery document.
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// externalDomain is the domain given to user names that do not
+	// have one, so that they are never mistaken for local users.
+	externalDomain = "external"
+
+	// acceptableSkew is the clock skew tolerated when validating the
+	// times in an ID token.
+	acceptableSkew = time.Minute
+
+	// discoveryTimeout bounds the time spent discovering the issuer, so
+	// that an unreachable issuer does not hold up logins for long.
+	discoveryTimeout = 10 * time.Second
+
+	// discoveryRetryDelay is how long a failure to discover the issuer
+	// is reported to logins before discovery is tried again.
+	discoveryRetryDelay = 30 * time.Second
+)
+
+// Config holds the configuration of an OpenID Connect authenticator.
+type Config struct {
+	// IssuerURL is the URL of the trusted issuer.
+	IssuerURL string
+
+	// ClientID is the client ID registered with the issuer for the
+	// controller.
+	ClientID string
+
+	// UsernameClaim is the ID token claim holding the user name.
+	UsernameClaim string
+
+	// GroupsClaim is the ID token claim holding the user's groups.
+	GroupsClaim string
+
+	// GroupAccess is the access granted to the members of each group.
+	GroupAccess []controller.OIDCGroupAccessEntry
+
+	// JujuAccess, if set, reports the access granted to users within
+	// Juju, directly or through Juju user groups. Users are given the
+	// greater of that and the access granted to their issuer groups.
+	JujuAccess authentication.PermissionDelegator
+
+	// HTTPClient is used to talk to the issuer.
+	HTTPClient *http.Client
+
+	// Clock is used to validate the times in an ID token.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config is not valid.
+func (c Config) Validate() error {
+	if c.IssuerURL == "" {
+		return errors.NotValidf("empty IssuerURL")
+	}
+	if c.ClientID == "" {
+		return errors.NotValidf("empty ClientID")
+	}
+	if c.UsernameClaim == "" {
+		return errors.NotValidf("empty UsernameClaim")
+	}
+	if c.GroupsClaim == "" {
+		return errors.NotValidf("empty GroupsClaim")
+	}
+	if c.HTTPClient == nil {
+		return errors.NotValidf("nil HTTPClient")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// NewConfig returns the authenticator config held in the controller
+// config. The returned config has no HTTPClient or Clock set.
+func NewConfig(controllerConfig controller.Config) (Config, error) {
+	groupAccess, err := controllerConfig.OIDCGroupAccess()
+	if err != nil {
+		return Config{}, errors.Trace(err)
+	}
+	return Config{
+		IssuerURL:     controllerConfig.OIDCIssuerURL(),
+		ClientID:      controllerConfig.OIDCClientID(),
+		UsernameClaim: controllerConfig.OIDCUsernameClaim(),
+		GroupsClaim:   controllerConfig.OIDCGroupsClaim(),
+		GroupAccess:   groupAccess,
+	}, nil
+}
+
+// providerMetadata holds the parts of the issuer's discovery document
+// used by the authenticator.
+type providerMetadata struct {
+	Issuer                      string `json:"issuer"`
+	JWKSURI                     string `json:"jwks_uri"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+// Authenticator authenticates requests using ID tokens, and runs device
+// logins with the issuer on behalf of clients.
+type Authenticator interface {
+	authentication.RequestAuthenticator
+
+	// StartDeviceLogin starts a device login with the issuer.
+	StartDeviceLogin(ctx context.Context) (*DeviceLogin, error)
+
+	// PollDeviceLogin checks once whether the device login has
+	// completed, returning the ID token issued for the user if so.
+	PollDeviceLogin(ctx context.Context, device *DeviceLogin) (string, error)
+
+	// Close releases the resources held by the authenticator.
+	Close()
+}
+
+// OIDCAuthenticator is an authenticator responsible for handling ID tokens
+// issued by an OpenID Connect issuer.
+type OIDCAuthenticator struct {
+	config Config
+
+	// discovery runs a single discovery of the issuer at a time, which
+	// all the logins waiting for the issuer share.
+	discovery singleflight.Group
+
+	// mu guards the fields below. It is never held while talking to
+	// the issuer.
+	mu       sync.Mutex
+	closed   bool
+	provider providerMetadata
+	// cache refreshes the issuer's keys in the background until
+	// stopCache is called.
+	cache     *jwk.Cache
+	stopCache context.CancelFunc
+	// discoveryErr is the error from the last failed discovery, which
+	// is reported until discovery is retried at retryDiscovery.
+	discoveryErr   error
+	retryDiscovery time.Time
+}
+
+// NewAuthenticator returns a new authenticator for the issuer described
+// by the config. The issuer is discovered when the authenticator is
+// first used, so that an unreachable issuer does not prevent the
+// controller from starting. Close must be called once the authenticator
+// is no longer needed.
+func NewAuthenticator(config Config) (*OIDCAuthenticator, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OIDCAuthenticator{
+		config: config,
+	}, nil
+}
+
+// Close stops the background refresh of the issuer's keys.
+func (a *OIDCAuthenticator) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closed = true
+	if a.stopCache != nil {
+		a.stopCache()
+	}
+}
+
+// Discover reads the issuer's discovery document, then sets up the key
+// cache and fetches the issuer's public keys. It does nothing if the
+// issuer has already been discovered.
+func (a *OIDCAuthenticator) Discover(ctx context.Context) error {
+	_, _, err := a.discovered(ctx)
+	return errors.Trace(err)
+}
+
+// discovered returns the issuer's metadata and key cache, discovering
+// the issuer first if that has yet to succeed. Concurrent callers share
+// a single discovery, and a failed discovery is reported without
+// contacting the issuer again until discoveryRetryDelay has passed.
+func (a *OIDCAuthenticator) discovered(ctx context.Context) (providerMetadata, *jwk.Cache, error) {
+	if provider, cache, done, err := a.discoveryResult(); done {
+		return provider, cache, errors.Trace(err)
+	}
+	result := a.discovery.DoChan("discover", func() (interface{}, error) {
+		// The discovery is shared, so it is not bound to the context
+		// of the caller which happens to start it.
+		ctx, cancel := context.WithTimeout(context.Background(), discoveryTimeout)
+		defer cancel()
+		return nil, a.discover(ctx)
+	})
+	select {
+	case <-ctx.Done():
+		return providerMetadata{}, nil, errors.Trace(ctx.Err())
+	case <-result:
+	}
+	provider, cache, _, err := a.discoveryResult()
+	return provider, cache, errors.Trace(err)
+}
+
+// discoveryResult returns the outcome of discovering the issuer, and
+// whether there is one to report.
+func (a *OIDCAuthenticator) discoveryResult() (providerMetadata, *jwk.Cache, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch {
+	case a.closed:
+		return providerMetadata{}, nil, true, errors.New("oidc authenticator closed")
+	case a.cache != nil:
+		return a.provider, a.cache, true, nil
+	case a.discoveryErr != nil:
+		return providerMetadata{}, nil, a.config.Clock.Now().Before(a.retryDiscovery), a.discoveryErr
+	}
+	return providerMetadata{}, nil, false, nil
+}
+
+// discover discovers the issuer, recording the outcome for the logins
+// waiting for it.
+func (a *OIDCAuthenticator) discover(ctx context.Context) error {
+	provider, cache, stopCache, err := a.fetchProvider(ctx)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err != nil {
+		a.discoveryErr = err
+		a.retryDiscovery = a.config.Clock.Now().Add(discoveryRetryDelay)
+		return errors.Trace(err)
+	}
+	if a.closed {
+		stopCache()
+		return nil
+	}
+	a.provider = provider
+	a.cache = cache
+	a.stopCache = stopCache
+	a.discoveryErr = nil
+	return nil
+}
+
+// fetchProvider reads the issuer's discovery document, and returns it
+// along with a cache of the issuer's keys and the function which stops
+// the cache.
+func (a *OIDCAuthenticator) fetchProvider(ctx context.Context) (providerMetadata, *jwk.Cache, context.CancelFunc, error) {
+	discoveryURL := strings.TrimSuffix(a.config.IssuerURL, "/") + discoveryPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return providerMetadata{}, nil, nil, errors.Trace(err)
+	}
+	resp, err := a.config.HTTPClient.Do(req)
+	if err != nil {
+		return providerMetadata{}, nil, nil, fmt.Errorf("fetching discovery document from %q: %w", discoveryURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return providerMetadata{}, nil, nil, errors.Errorf("fetching discovery document from %q: unexpected response %q", discoveryURL, resp.Status)
+	}
+
+	var provider providerMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&provider); err != nil {
+		return providerMetadata{}, nil, nil, errors.Annotatef(err, "decoding discovery document from %q", discoveryURL)
+	}
+	// The issuer in the discovery document must exactly match the
+	// issuer URL used to retrieve it.
+	if strings.TrimSuffix(provider.Issuer, "/") != strings.TrimSuffix(a.config.IssuerURL, "/") {
+		return providerMetadata{}, nil, nil, errors.Errorf("issuer %q in discovery document does not match %q", provider.Issuer, a.config.IssuerURL)
+	}
+	if provider.JWKSURI == "" {
+		return providerMetadata{}, nil, nil, errors.NotValidf("discovery document without jwks_uri")
+	}
+
+	// The cache is stopped again if the keys can't be fetched, so
+	// that failed attempts don't leave it running.
+	cacheCtx, stopCache := context.WithCancel(context.Background())
+	cache := jwk.NewCache(cacheCtx)
+	if err := cache.Register(provider.JWKSURI, jwk.WithHTTPClient(a.config.HTTPClient)); err != nil {
+		stopCache()
+		return providerMetadata{}, nil, nil, fmt.Errorf("registering jwk cache with url %q: %w", provider.JWKSURI, err)
+	}
+	if _, err := cache.Refresh(ctx, provider.JWKSURI); err != nil {
+		stopCache()
+		return providerMetadata{}, nil, nil, fmt.Errorf("refreshing jwk cache at %q: %w", provider.JWKSURI, err)
+	}
+	return provider, cache, stopCache, nil
+}
+
+// Authenticate implements HTTPAuthenticator. The session token is read
+// from the password of a basic auth header without a user name.
+func (a *OIDCAuthenticator) Authenticate(req *http.Request) (authentication.AuthInfo, error) {
+	user, token, ok := req.BasicAuth()
+	if !ok {
+		return authentication.AuthInfo{}, errors.NotFoundf("basic authorization header")
+	}
+	if user != "" || token == "" {
+		return authentication.AuthInfo{}, errors.NotFoundf("session token in authorization header")
+	}
+
+	authInfo, err := a.authInfo(req.Context(), token)
+	if err != nil {
+		return authentication.AuthInfo{}, fmt.Errorf("parsing session token: %w", err)
+	}
+	return authInfo, nil
+}
+
+// AuthenticateLoginRequest implements LoginAuthenticator.
+func (a *OIDCAuthenticator) AuthenticateLoginRequest(
+	ctx context.Context,
+	_, _ string,
+	authParams authentication.AuthParams,
+) (authentication.AuthInfo, error) {
+	if authParams.SessionToken == "" {
+		return authentication.AuthInfo{}, fmt.Errorf("session token %w", errors.NotSupported)
+	}
+
+	authInfo, err := a.authInfo(ctx, authParams.SessionToken)
+	if err != nil {
+		logger.Debugf("invalid session token: %v", err)
+		return authentication.AuthInfo{}, errors.Trace(apiservererrors.ErrSessionTokenInvalid)
+	}
+	return authInfo, nil
+}
+
+func (a *OIDCAuthenticator) authInfo(ctx context.Context, token string) (authentication.AuthInfo, error) {
+	idToken, entity, err := a.Parse(ctx, token)
+	if err != nil {
+		return authentication.AuthInfo{}, errors.Trace(err)
+	}
+	return authentication.AuthInfo{
+		Entity: entity,
+		Delegator: &PermissionDelegator{
+			User:        entity.User,
+			Groups:      groupsFromToken(idToken, a.config.GroupsClaim),
+			GroupAccess: a.config.GroupAccess,
+			JujuAccess:  a.config.JujuAccess,
+		},
+	}, nil
+}
+
+// Parse validates the ID token, returning the parsed token and the
+// entity it was issued for.
+func (a *OIDCAuthenticator) Parse(ctx context.Context, token string) (jwt.Token, TokenEntity, error) {
+	provider, cache, err := a.discovered(ctx)
+	if err != nil {
+		return nil, TokenEntity{}, errors.Annotate(err, "discovering issuer")
+	}
+	keySet, err := cache.Get(ctx, provider.JWKSURI)
+	if err != nil {
+		return nil, TokenEntity{}, errors.Annotate(err, "refreshing jwt key")
+	}
+
+	idToken, err := jwt.ParseString(
+		token,
+		jwt.WithKeySet(keySet),
+		jwt.WithValidate(true),
+		jwt.WithIssuer(provider.Issuer),
+		jwt.WithAudience(a.config.ClientID),
+		jwt.WithClock(jwt.ClockFunc(a.config.Clock.Now)),
+		jwt.WithAcceptableSkew(acceptableSkew),
+	)
+	if err != nil {
+		return nil, TokenEntity{}, errors.Trace(err)
+	}
+	entity, err := userFromToken(idToken, a.config.UsernameClaim)
+	if err != nil {
+		return nil, TokenEntity{}, errors.Trace(err)
+	}
+	return idToken, entity, nil
+}
+
+// TokenEntity represents the user an ID token was issued for and
+// conforms to state.Entity.
+type TokenEntity struct {
+	User names.UserTag
+}
+
+// Tag implements state.Entity.
+func (t TokenEntity) Tag() names.Tag {
+	return t.User
+}
+
+// userFromToken returns the external user named by the claim.
+func userFromToken(token jwt.Token, claim string) (TokenEntity, error) {
+	var value interface{}
+	if claim == jwt.SubjectKey {
+		value = token.Subject()
+	} else {
+		value, _ = token.Get(claim)
+	}
+	name, ok := value.(string)
+	if !ok || name == "" {
+		return TokenEntity{}, errors.NotFoundf("user name claim %q", claim)
+	}
+	if !strings.Contains(name, "@") {
+		name += "@" + externalDomain
+	}
+	if !names.IsValidUser(name) {
+		return TokenEntity{}, errors.NotValidf("user name %q", name)
+	}
+	user := names.NewUserTag(name)
+	if user.IsLocal() {
+		// Identity provider users must never be able to act as
+		// local users.
+		return TokenEntity{}, errors.NotValidf("local user %q", name)
+	}
+	return TokenEntity{User: user}, nil
+}
+
+// groupsFromToken returns the groups held in the claim, which may be a
+// list of strings or a single string.
+func groupsFromToken(token jwt.Token, claim string) []string {
+	value, ok := token.Get(claim)
+	if !ok {
+		return nil
+	}
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	case []interface{}:
+		groups := make([]string, 0, len(value))
+		for _, group := range value {
+			if group, ok := group.(string); ok {
+				groups = append(groups, group)
+			}
+		}
+		return groups
+	}
+	return nil
+}
+
+// PermissionDelegator is responsible for handling authorization questions
+// for a user authenticated with an ID token, using the access granted to
+// the user's issuer groups along with any granted within Juju. It
+// implements authentication.PermissionDelegator.
+type PermissionDelegator struct {
+	// User is the authenticated user.
+	User names.UserTag
+
+	// Groups are the issuer groups the user is a member of.
+	Groups []string
+
+	// GroupAccess is the access granted to the members of each group.
+	GroupAccess []controller.OIDCGroupAccessEntry
+
+	// JujuAccess, if set, reports the access granted within Juju.
+	JujuAccess authentication.PermissionDelegator
+}
+
+// SubjectPermissions implements PermissionDelegator. The highest access
+// granted to the user within Juju, or to any of the user's issuer
+// groups, is returned.
+func (p *PermissionDelegator) SubjectPermissions(
+	e authentication.Entity,
+	subject names.Tag,
+) (permission.Access, error) {
+	if e.Tag().Id() == common.EveryoneTagName {
+		// Issuer groups are only known for the authenticated user.
+		return p.jujuAccess(e, subject)
+	}
+	// We need to make very sure that the entity the request pertains to
+	// is the same entity this delegator was seeded with.
+	if p.User.String() != e.Tag().String() {
+		err := fmt.Errorf(
+			"%w to use token permissions for one entity on another",
+			apiservererrors.ErrPerm,
+		)
+		return permission.NoAccess, errors.WithType(err, authentication.ErrorEntityMissingPermission)
+	}
+
+	var greater func(a, b permission.Access) bool
+	switch subject.Kind() {
+	case names.ControllerTagKind:
+		greater = permission.Access.GreaterControllerAccessThan
+	case names.ModelTagKind:
+		greater = permission.Access.GreaterModelAccessThan
+	case names.CloudTagKind:
+		greater = func(a, b permission.Access) bool {
+			return a != b && a.EqualOrGreaterCloudAccessThan(b)
+		}
+	case names.ApplicationOfferTagKind:
+		greater = permission.Access.GreaterOfferAccessThan
+	default:
+		return permission.NoAccess, errors.NotValidf("%q as a target", subject)
+	}
+
+	groups := make(map[string]bool, len(p.Groups))
+	for _, group := range p.Groups {
+		groups[group] = true
+	}
+
+	access, err := p.jujuAccess(e, subject)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	for _, entry := range p.GroupAccess {
+		if !groups[entry.Group] || !entryMatches(entry, subject) {
+			continue
+		}
+		if greater(entry.Access, access) {
+			access = entry.Access
+		}
+	}
+	return access, nil
+}
+
+// jujuAccess returns the access granted to the entity within Juju.
+func (p *PermissionDelegator) jujuAccess(e authentication.Entity, subject names.Tag) (permission.Access, error) {
+	if p.JujuAccess == nil {
+		return permission.NoAccess, nil
+	}
+	access, err := p.JujuAccess.SubjectPermissions(e, subject)
+	if errors.Is(err, errors.NotFound) {
+		return permission.NoAccess, nil
+	}
+	return access, errors.Trace(err)
+}
+
+// entryMatches returns true if the group access entry applies to the
+// subject. An entry without a target applies to the controller.
+func entryMatches(entry controller.OIDCGroupAccessEntry, subject names.Tag) bool {
+	if entry.Target == nil {
+		return subject.Kind() == names.ControllerTagKind
+	}
+	return entry.Target.String() == subject.String()
+}
+
+// PermissionError implements PermissionDelegator.
+func (p *PermissionDelegator) PermissionError(
+	subject names.Tag,
+	perm permission.Access,
+) error {
+	return &apiservererrors.AccessRequiredError{
+		RequiredAccess: map[names.Tag]permission.Access{
+			subject: perm,
+		},
+	}
+}
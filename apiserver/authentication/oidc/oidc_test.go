@@ -0,0 +1,527 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/authentication/oidc"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/permission"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const clientID = "juju-controller"
+
+type oidcSuite struct {
+	issuer         *apitesting.FakeOIDCIssuer
+	clock          *testclock.Clock
+	authenticators []*oidc.OIDCAuthenticator
+}
+
+var _ = gc.Suite(&oidcSuite{})
+
+func (s *oidcSuite) SetUpTest(c *gc.C) {
+	s.issuer = apitesting.NewFakeOIDCIssuer(c, clientID)
+	s.clock = testclock.NewClock(time.Now())
+}
+
+func (s *oidcSuite) TearDownTest(_ *gc.C) {
+	for _, authenticator := range s.authenticators {
+		authenticator.Close()
+	}
+	s.authenticators = nil
+	s.issuer.Close()
+}
+
+func (s *oidcSuite) config() oidc.Config {
+	return oidc.Config{
+		IssuerURL:     s.issuer.URL(),
+		ClientID:      clientID,
+		UsernameClaim: "email",
+		GroupsClaim:   "groups",
+		GroupAccess: []controller.OIDCGroupAccessEntry{{
+			Group:  "admins",
+			Access: permission.SuperuserAccess,
+		}, {
+			Group:  "users",
+			Access: permission.LoginAccess,
+		}, {
+			Group:  "users",
+			Target: coretesting.ModelTag,
+			Access: permission.ReadAccess,
+		}, {
+			Group:  "developers",
+			Target: coretesting.ModelTag,
+			Access: permission.WriteAccess,
+		}},
+		HTTPClient: s.issuer.Client(),
+		Clock:      s.clock,
+	}
+}
+
+func (s *oidcSuite) newUndiscoveredAuthenticator(c *gc.C, cfg oidc.Config) *oidc.OIDCAuthenticator {
+	authenticator, err := oidc.NewAuthenticator(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	s.authenticators = append(s.authenticators, authenticator)
+	return authenticator
+}
+
+func (s *oidcSuite) newAuthenticator(c *gc.C) *oidc.OIDCAuthenticator {
+	authenticator := s.newUndiscoveredAuthenticator(c, s.config())
+	err := authenticator.Discover(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	return authenticator
+}
+
+func (s *oidcSuite) validToken(c *gc.C, claims map[string]interface{}) string {
+	return s.issuer.NewIDToken(c, s.issuer.URL(), clientID, s.clock.Now().Add(time.Hour), claims)
+}
+
+func (s *oidcSuite) TestConfigValidate(c *gc.C) {
+	cfg := s.config()
+	cfg.ClientID = ""
+	c.Assert(cfg.Validate(), gc.ErrorMatches, "empty ClientID not valid")
+
+	cfg = s.config()
+	cfg.HTTPClient = nil
+	c.Assert(cfg.Validate(), gc.ErrorMatches, "nil HTTPClient not valid")
+}
+
+func (s *oidcSuite) TestNewConfig(c *gc.C) {
+	controllerConfig, err := controller.NewConfig(coretesting.ControllerTag.Id(), coretesting.CACert, map[string]interface{}{
+		controller.OIDCIssuerURL:   "https://issuer.example.com",
+		controller.OIDCClientID:    clientID,
+		controller.OIDCGroupAccess: []interface{}{"admins=superuser"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := oidc.NewConfig(controllerConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, oidc.Config{
+		IssuerURL:     "https://issuer.example.com",
+		ClientID:      clientID,
+		UsernameClaim: "email",
+		GroupsClaim:   "groups",
+		GroupAccess: []controller.OIDCGroupAccessEntry{{
+			Group:  "admins",
+			Access: permission.SuperuserAccess,
+		}},
+	})
+}
+
+func (s *oidcSuite) TestDiscoverNotFound(c *gc.C) {
+	cfg := s.config()
+	cfg.IssuerURL = s.issuer.URL() + "/other"
+	authenticator := s.newUndiscoveredAuthenticator(c, cfg)
+	err := authenticator.Discover(context.Background())
+	c.Assert(err, gc.ErrorMatches, `fetching discovery document from ".*": unexpected response "404 Not Found"`)
+}
+
+func (s *oidcSuite) TestDiscoverOnFirstUse(c *gc.C) {
+	authenticator := s.newUndiscoveredAuthenticator(c, s.config())
+	c.Assert(s.issuer.DiscoveryCount(), gc.Equals, 0)
+
+	token := s.validToken(c, map[string]interface{}{
+		"email": "fred@example.com",
+	})
+	_, entity, err := authenticator.Parse(context.Background(), token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Tag(), gc.Equals, names.NewUserTag("fred@example.com"))
+
+	_, _, err = authenticator.Parse(context.Background(), token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.issuer.DiscoveryCount(), gc.Equals, 1)
+}
+
+func (s *oidcSuite) TestDiscoverRetriedAfterFailure(c *gc.C) {
+	authenticator := s.newUndiscoveredAuthenticator(c, s.config())
+	token := s.validToken(c, map[string]interface{}{
+		"email": "fred@example.com",
+	})
+
+	// The issuer is unavailable when the controller starts.
+	s.issuer.SetUnavailable(true)
+	_, _, err := authenticator.Parse(context.Background(), token)
+	c.Assert(err, gc.ErrorMatches, `discovering issuer: fetching discovery document from ".*": unexpected response "503 Service Unavailable"`)
+
+	// The failure is reported without contacting the issuer again
+	// until the retry delay has passed.
+	s.issuer.SetUnavailable(false)
+	_, _, err = authenticator.Parse(context.Background(), token)
+	c.Assert(err, gc.ErrorMatches, `discovering issuer: fetching discovery document from ".*": unexpected response "503 Service Unavailable"`)
+	c.Assert(s.issuer.DiscoveryCount(), gc.Equals, 1)
+
+	s.clock.Advance(30 * time.Second)
+	_, _, err = authenticator.Parse(context.Background(), token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.issuer.DiscoveryCount(), gc.Equals, 2)
+}
+
+func (s *oidcSuite) TestDiscoveryDoesNotHoldUpOthers(c *gc.C) {
+	authenticator := s.newUndiscoveredAuthenticator(c, s.config())
+	token := s.validToken(c, map[string]interface{}{
+		"email": "fred@example.com",
+	})
+	block := make(chan struct{})
+	s.issuer.SetBlock(block)
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := authenticator.Parse(context.Background(), token)
+		done <- err
+	}()
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if s.issuer.DiscoveryCount() == 1 {
+			break
+		}
+	}
+	c.Assert(s.issuer.DiscoveryCount(), gc.Equals, 1)
+
+	// Other logins share the discovery in progress, giving up when
+	// their own context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), coretesting.ShortWait)
+	defer cancel()
+	_, _, err := authenticator.Parse(ctx, token)
+	c.Assert(err, jc.ErrorIs, context.DeadlineExceeded)
+
+	close(block)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for discovery")
+	}
+	c.Assert(s.issuer.DiscoveryCount(), gc.Equals, 1)
+}
+
+func (s *oidcSuite) TestClose(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	authenticator.Close()
+
+	_, _, err := authenticator.Parse(context.Background(), s.validToken(c, map[string]interface{}{
+		"email": "fred@example.com",
+	}))
+	c.Assert(err, gc.ErrorMatches, `discovering issuer: oidc authenticator closed`)
+}
+
+func (s *oidcSuite) TestParse(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	token := s.validToken(c, map[string]interface{}{
+		"email": "fred@example.com",
+	})
+
+	_, entity, err := authenticator.Parse(context.Background(), token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Tag(), gc.Equals, names.NewUserTag("fred@example.com"))
+}
+
+func (s *oidcSuite) TestParseAddsExternalDomain(c *gc.C) {
+	cfg := s.config()
+	cfg.UsernameClaim = "sub"
+	authenticator := s.newUndiscoveredAuthenticator(c, cfg)
+	err := authenticator.Discover(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, entity, err := authenticator.Parse(context.Background(), s.validToken(c, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Tag(), gc.Equals, names.NewUserTag("1234@external"))
+}
+
+func (s *oidcSuite) TestParseRejectsLocalUser(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	token := s.validToken(c, map[string]interface{}{
+		"email": "admin@local",
+	})
+
+	_, _, err := authenticator.Parse(context.Background(), token)
+	c.Assert(err, gc.ErrorMatches, `local user "admin@local" not valid`)
+}
+
+func (s *oidcSuite) TestParseMissingUsernameClaim(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+
+	_, _, err := authenticator.Parse(context.Background(), s.validToken(c, nil))
+	c.Assert(err, gc.ErrorMatches, `user name claim "email" not found`)
+}
+
+func (s *oidcSuite) TestParseWrongIssuer(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	token := s.issuer.NewIDToken(c, "https://other.example.com", clientID, s.clock.Now().Add(time.Hour), map[string]interface{}{
+		"email": "fred@example.com",
+	})
+
+	_, _, err := authenticator.Parse(context.Background(), token)
+	c.Assert(err, gc.ErrorMatches, `.*"iss" not satisfied.*`)
+}
+
+func (s *oidcSuite) TestParseWrongAudience(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	token := s.issuer.NewIDToken(c, s.issuer.URL(), "other-client", s.clock.Now().Add(time.Hour), map[string]interface{}{
+		"email": "fred@example.com",
+	})
+
+	_, _, err := authenticator.Parse(context.Background(), token)
+	c.Assert(err, gc.ErrorMatches, `.*"aud" not satisfied.*`)
+}
+
+func (s *oidcSuite) TestParseExpired(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	token := s.validToken(c, map[string]interface{}{
+		"email": "fred@example.com",
+	})
+	s.clock.Advance(2 * time.Hour)
+
+	_, _, err := authenticator.Parse(context.Background(), token)
+	c.Assert(err, gc.ErrorMatches, `.*"exp" not satisfied.*`)
+}
+
+func (s *oidcSuite) TestParseUnknownKey(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	other := apitesting.NewFakeOIDCIssuer(c, clientID)
+	defer other.Close()
+	token := other.NewIDToken(c, s.issuer.URL(), clientID, s.clock.Now().Add(time.Hour), map[string]interface{}{
+		"email": "fred@example.com",
+	})
+
+	_, _, err := authenticator.Parse(context.Background(), token)
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *oidcSuite) TestAuthenticateLoginRequest(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	token := s.validToken(c, map[string]interface{}{
+		"email":  "fred@example.com",
+		"groups": []string{"users", "developers"},
+	})
+
+	authInfo, err := authenticator.AuthenticateLoginRequest(context.Background(), "", "", authentication.AuthParams{
+		SessionToken: token,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(authInfo.Entity.Tag(), gc.Equals, names.NewUserTag("fred@example.com"))
+
+	perm, err := authInfo.SubjectPermissions(coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perm, gc.Equals, permission.LoginAccess)
+
+	perm, err = authInfo.SubjectPermissions(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perm, gc.Equals, permission.WriteAccess)
+
+	perm, err = authInfo.SubjectPermissions(names.NewModelTag("aaaaaaaa-bbbb-4ccc-8ddd-eeeeeeeeeeee"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perm, gc.Equals, permission.NoAccess)
+}
+
+func (s *oidcSuite) TestAuthenticateLoginRequestNotSupported(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+
+	_, err := authenticator.AuthenticateLoginRequest(context.Background(), "", "", authentication.AuthParams{
+		Token: "jwt",
+	})
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
+
+func (s *oidcSuite) TestAuthenticateLoginRequestInvalidToken(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	token := s.validToken(c, map[string]interface{}{
+		"email": "fred@example.com",
+	})
+	s.clock.Advance(2 * time.Hour)
+
+	_, err := authenticator.AuthenticateLoginRequest(context.Background(), "", "", authentication.AuthParams{
+		SessionToken: token,
+	})
+	c.Assert(err, jc.ErrorIs, apiservererrors.ErrSessionTokenInvalid)
+}
+
+func (s *oidcSuite) TestAuthenticate(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	token := s.validToken(c, map[string]interface{}{
+		"email":  "fred@example.com",
+		"groups": "admins",
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	req.SetBasicAuth("", token)
+	authInfo, err := authenticator.Authenticate(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(authInfo.Entity.Tag(), gc.Equals, names.NewUserTag("fred@example.com"))
+
+	perm, err := authInfo.SubjectPermissions(coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perm, gc.Equals, permission.SuperuserAccess)
+}
+
+func (s *oidcSuite) TestAuthenticateNoSessionToken(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+
+	req, err := http.NewRequest(http.MethodGet, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = authenticator.Authenticate(req)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	req.SetBasicAuth("user-fred", "password")
+	_, err = authenticator.Authenticate(req)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *oidcSuite) TestPermissionDelegatorOtherEntity(c *gc.C) {
+	delegator := &oidc.PermissionDelegator{
+		User:        names.NewUserTag("fred@example.com"),
+		Groups:      []string{"admins"},
+		GroupAccess: s.config().GroupAccess,
+	}
+
+	_, err := delegator.SubjectPermissions(oidc.TokenEntity{
+		User: names.NewUserTag("mary@example.com"),
+	}, coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIs, apiservererrors.ErrPerm)
+	c.Assert(err, jc.ErrorIs, authentication.ErrorEntityMissingPermission)
+}
+
+func (s *oidcSuite) TestPermissionDelegatorMergesJujuAccess(c *gc.C) {
+	fred := names.NewUserTag("fred@example.com")
+	delegator := &oidc.PermissionDelegator{
+		User:        fred,
+		Groups:      []string{"users"},
+		GroupAccess: s.config().GroupAccess,
+		JujuAccess: fakeJujuAccess{
+			coretesting.ModelTag.String():      permission.AdminAccess,
+			coretesting.ControllerTag.String(): permission.NoAccess,
+		},
+	}
+	entity := oidc.TokenEntity{User: fred}
+
+	// Access granted within Juju is merged with the group access.
+	perm, err := delegator.SubjectPermissions(entity, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perm, gc.Equals, permission.AdminAccess)
+
+	perm, err = delegator.SubjectPermissions(entity, coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perm, gc.Equals, permission.LoginAccess)
+
+	// Access unknown to Juju is not an error.
+	perm, err = delegator.SubjectPermissions(entity, names.NewCloudTag("fluffy"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perm, gc.Equals, permission.NoAccess)
+
+	// Access granted to everyone comes from Juju alone.
+	everyone := oidc.TokenEntity{User: names.NewUserTag("everyone@external")}
+	perm, err = delegator.SubjectPermissions(everyone, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perm, gc.Equals, permission.AdminAccess)
+}
+
+// fakeJujuAccess reports the access granted within Juju, keyed by
+// subject.
+type fakeJujuAccess map[string]permission.Access
+
+func (f fakeJujuAccess) SubjectPermissions(_ authentication.Entity, subject names.Tag) (permission.Access, error) {
+	access, ok := f[subject.String()]
+	if !ok {
+		return permission.NoAccess, errors.NotFoundf("access to %s", subject)
+	}
+	return access, nil
+}
+
+func (f fakeJujuAccess) PermissionError(names.Tag, permission.Access) error {
+	return apiservererrors.ErrPerm
+}
+
+func (s *oidcSuite) TestDeviceLogin(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	idToken := s.validToken(c, map[string]interface{}{
+		"email": "fred@example.com",
+	})
+	s.issuer.SetIDToken(idToken)
+
+	device, err := authenticator.StartDeviceLogin(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(device.UserCode(), gc.Equals, "ABCD-EFGH")
+	c.Assert(device.VerificationURI(), gc.Equals, s.issuer.URL()+"/verify")
+	c.Assert(device.Interval(), gc.Equals, time.Second)
+
+	token, err := authenticator.PollDeviceLogin(context.Background(), device)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token, gc.Equals, idToken)
+}
+
+func (s *oidcSuite) TestDeviceLoginPending(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	s.issuer.SetTokenError("authorization_pending")
+
+	device, err := authenticator.StartDeviceLogin(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = authenticator.PollDeviceLogin(context.Background(), device)
+	c.Assert(err, jc.ErrorIs, apiservererrors.ErrDeviceLoginPending)
+	c.Assert(s.issuer.TokenRequests(), gc.Equals, 1)
+
+	// Polling again within the interval does not reach the issuer.
+	_, err = authenticator.PollDeviceLogin(context.Background(), device)
+	c.Assert(err, jc.ErrorIs, apiservererrors.ErrDeviceLoginPending)
+	c.Assert(s.issuer.TokenRequests(), gc.Equals, 1)
+
+	// The user completes the login.
+	s.issuer.SetTokenError("")
+	idToken := s.validToken(c, map[string]interface{}{
+		"email": "fred@example.com",
+	})
+	s.issuer.SetIDToken(idToken)
+	s.clock.Advance(device.Interval())
+	token, err := authenticator.PollDeviceLogin(context.Background(), device)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token, gc.Equals, idToken)
+	c.Assert(s.issuer.TokenRequests(), gc.Equals, 2)
+}
+
+func (s *oidcSuite) TestDeviceLoginSlowDown(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	s.issuer.SetTokenError("slow_down")
+
+	device, err := authenticator.StartDeviceLogin(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = authenticator.PollDeviceLogin(context.Background(), device)
+	c.Assert(err, jc.ErrorIs, apiservererrors.ErrDeviceLoginPending)
+	c.Assert(device.Interval(), gc.Equals, 6*time.Second)
+}
+
+func (s *oidcSuite) TestDeviceLoginExpired(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+
+	device, err := authenticator.StartDeviceLogin(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(2 * time.Minute)
+	_, err = authenticator.PollDeviceLogin(context.Background(), device)
+	c.Assert(err, gc.ErrorMatches, `device login expired`)
+	c.Assert(s.issuer.TokenRequests(), gc.Equals, 0)
+}
+
+func (s *oidcSuite) TestDeviceLoginDenied(c *gc.C) {
+	authenticator := s.newAuthenticator(c)
+	s.issuer.SetTokenError("access_denied")
+
+	device, err := authenticator.StartDeviceLogin(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = authenticator.PollDeviceLogin(context.Background(), device)
+	c.Assert(err, gc.ErrorMatches, `completing device login: access_denied.*`)
+}
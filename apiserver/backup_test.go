@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -74,7 +75,7 @@ func (s *backupsSuite) checkInvalidMethod(c *gc.C, method, url string) {
 
 func (s *backupsSuite) TestInvalidHTTPMethods(c *gc.C) {
 	url := s.backupURL
-	for _, method := range []string{"PUT", "POST", "DELETE", "OPTIONS"} {
+	for _, method := range []string{"POST", "DELETE", "OPTIONS"} {
 		c.Log("testing HTTP method: " + method)
 		s.checkInvalidMethod(c, method, url)
 	}
@@ -164,3 +165,47 @@ func (s *backupsSuite) TestErrorWhenGetFails(c *gc.C) {
 
 	s.assertErrorResponse(c, resp, http.StatusInternalServerError, "failed!")
 }
+
+func (s *backupsSuite) sendValidPut(c *gc.C) *http.Response {
+	s.fake.Filename = "/var/lib/juju/backups/juju-backup-upload-1234.tar.gz"
+	return s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "PUT",
+		URL:         s.backupURL,
+		ContentType: params.ContentTypeRaw,
+		Body:        strings.NewReader("<archive>"),
+	})
+}
+
+func (s *backupsSuite) TestUpload(c *gc.C) {
+	resp := s.sendValidPut(c)
+	defer resp.Body.Close()
+
+	c.Check(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Check(s.fake.Calls, gc.DeepEquals, []string{"Add"})
+	body, err := io.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	var result params.BackupsUploadResult
+	err = json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ID, gc.Equals, s.fake.Filename)
+}
+
+func (s *backupsSuite) TestUploadWrongContentType(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "PUT",
+		URL:         s.backupURL,
+		ContentType: params.ContentTypeJSON,
+		Body:        strings.NewReader("<archive>"),
+	})
+	defer resp.Body.Close()
+
+	s.assertErrorResponse(c, resp, http.StatusInternalServerError, `expected Content-Type "application/octet-stream", got "application/json"`)
+}
+
+func (s *backupsSuite) TestErrorWhenAddFails(c *gc.C) {
+	s.fake.Error = errors.New("failed!")
+	resp := s.sendValidPut(c)
+	defer resp.Body.Close()
+
+	s.assertErrorResponse(c, resp, http.StatusInternalServerError, "failed!")
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// EgressWatchingEntityFinder returns an EntityFinder whose applications,
+// when watched, also report changes to their application config, which
+// holds the rules restricting the application's outbound traffic. It's
+// used by the firewaller facades so that a worker watching an
+// application learns about both expose and egress changes.
+func EgressWatchingEntityFinder(st state.EntityFinder) state.EntityFinder {
+	return egressWatchingEntityFinder{st}
+}
+
+type egressWatchingEntityFinder struct {
+	state.EntityFinder
+}
+
+// FindEntity implements state.EntityFinder.
+func (st egressWatchingEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
+	entity, err := st.EntityFinder.FindEntity(tag)
+	if err != nil {
+		return nil, err
+	}
+	if app, ok := entity.(*state.Application); ok {
+		return egressWatchingApplication{app}, nil
+	}
+	return entity, nil
+}
+
+type egressWatchingApplication struct {
+	*state.Application
+}
+
+// Watch returns a watcher for observing changes to the application and
+// to its application config.
+func (a egressWatchingApplication) Watch() state.NotifyWatcher {
+	return common.NewMultiNotifyWatcher(a.Application.Watch(), a.Application.WatchApplicationConfig())
+}
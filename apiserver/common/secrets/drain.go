@@ -23,9 +23,10 @@ type SecretsDrainAPI struct {
 	resources         facade.Resources
 	leadershipChecker leadership.Checker
 
-	model           Model
-	secretsState    SecretsMetaState
-	secretsConsumer SecretsConsumer
+	model             Model
+	secretsState      SecretsMetaState
+	secretsConsumer   SecretsConsumer
+	adminConfigGetter BackendAdminConfigGetter
 }
 
 // NewSecretsDrainAPI returns a new SecretsDrainAPI.
@@ -37,6 +38,7 @@ func NewSecretsDrainAPI(
 	model Model,
 	secretsState SecretsMetaState,
 	secretsConsumer SecretsConsumer,
+	adminConfigGetter BackendAdminConfigGetter,
 ) (*SecretsDrainAPI, error) {
 	if !authorizer.AuthUnitAgent() && !authorizer.AuthApplicationAgent() && !authorizer.AuthController() {
 		return nil, apiservererrors.ErrPerm
@@ -48,6 +50,7 @@ func NewSecretsDrainAPI(
 		model:             model,
 		secretsState:      secretsState,
 		secretsConsumer:   secretsConsumer,
+		adminConfigGetter: adminConfigGetter,
 	}, nil
 }
 
@@ -107,7 +110,63 @@ func (s *SecretsDrainAPI) changeSecretBackendForOne(arg params.ChangeSecretBacke
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return s.secretsState.ChangeSecretBackend(toChangeSecretBackendParams(token, uri, arg))
+	changeParams := toChangeSecretBackendParams(token, uri, arg)
+	cfgInfo, err := s.adminConfigGetter()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	served, err := hasControllerServedBackend(cfgInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !served {
+		return s.secretsState.ChangeSecretBackend(changeParams)
+	}
+
+	// Agents cannot access backends served by the controller, so
+	// the controller saves the drained content to such a backend
+	// and removes it from one once the secret has moved.
+	oldValueRef, err := s.revisionValueRef(uri, arg.Revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cleanup := func() {}
+	if changeParams.ValueRef == nil {
+		adminConfigGetter := func() (*secretsprovider.ModelBackendConfigInfo, error) { return cfgInfo, nil }
+		valueRef, saveCleanup, err := SaveControllerServedContent(adminConfigGetter, uri, arg.Revision, changeParams.Data)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if valueRef != nil {
+			changeParams.ValueRef = valueRef
+			changeParams.Data = nil
+			cleanup = saveCleanup
+		}
+	}
+	if err := s.secretsState.ChangeSecretBackend(changeParams); err != nil {
+		cleanup()
+		return errors.Trace(err)
+	}
+	if oldValueRef == nil || (changeParams.ValueRef != nil && *oldValueRef == *changeParams.ValueRef) {
+		return nil
+	}
+	if err := deleteControllerServedContent(cfgInfo, oldValueRef); err != nil {
+		logger.Warningf("removing drained content for secret %q revision %d: %v", uri, arg.Revision, err)
+	}
+	return nil
+}
+
+func (s *SecretsDrainAPI) revisionValueRef(uri *coresecrets.URI, revision int) (*coresecrets.ValueRef, error) {
+	revs, err := s.secretsState.ListSecretRevisions(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rev := range revs {
+		if rev.Revision == revision {
+			return rev.ValueRef, nil
+		}
+	}
+	return nil, errors.NotFoundf("secret %q revision %d", uri, revision)
 }
 
 func toChangeSecretBackendParams(token leadership.Token, uri *coresecrets.URI, arg params.ChangeSecretBackendArg) state.ChangeSecretBackendParams {
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/secrets/provider/file"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -38,7 +39,8 @@ type secretsDrainSuite struct {
 	secretsConsumer           *mocks.MockSecretsConsumer
 	modelConfigChangesWatcher *mocks.MockNotifyWatcher
 
-	authTag names.Tag
+	authTag     names.Tag
+	adminConfig *provider.ModelBackendConfigInfo
 
 	facade *secrets.SecretsDrainAPI
 }
@@ -67,6 +69,14 @@ func (s *secretsDrainSuite) setup(c *gc.C) *gomock.Controller {
 	s.expectAuthUnitAgent()
 
 	s.PatchValue(&secrets.GetProvider, func(string) (provider.SecretBackendProvider, error) { return s.provider, nil })
+	s.adminConfig = &provider.ModelBackendConfigInfo{
+		ActiveID: "backend-id",
+		Configs: map[string]provider.ModelBackendConfig{
+			"backend-id": {
+				BackendConfig: provider.BackendConfig{BackendType: "some-backend"},
+			},
+		},
+	}
 
 	var err error
 	s.facade, err = secrets.NewSecretsDrainAPI(
@@ -77,6 +87,9 @@ func (s *secretsDrainSuite) setup(c *gc.C) *gomock.Controller {
 		s.model,
 		s.secretsMetaState,
 		s.secretsConsumer,
+		func() (*provider.ModelBackendConfigInfo, error) {
+			return s.adminConfig, nil
+		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return ctrl
@@ -329,6 +342,82 @@ func (s *secretsDrainSuite) TestChangeSecretBackend(c *gc.C) {
 	})
 }
 
+func (s *secretsDrainSuite) TestChangeSecretBackendControllerServed(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	fileProvider := file.NewProvider()
+	s.PatchValue(&secrets.GetProvider, func(backendType string) (provider.SecretBackendProvider, error) {
+		if backendType == file.BackendType {
+			return fileProvider, nil
+		}
+		return s.provider, nil
+	})
+	fileCfg := provider.ModelBackendConfig{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ModelName:      "fred",
+		BackendConfig: provider.BackendConfig{
+			BackendType: file.BackendType,
+			Config: map[string]interface{}{
+				"path":           c.MkDir(),
+				"encryption-key": "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=",
+			},
+		},
+	}
+	c.Assert(fileProvider.Initialise(&fileCfg), jc.ErrorIsNil)
+	s.adminConfig = &provider.ModelBackendConfigInfo{
+		ActiveID: "file-id",
+		Configs: map[string]provider.ModelBackendConfig{
+			"backend-id": {
+				BackendConfig: provider.BackendConfig{BackendType: "some-backend"},
+			},
+			"file-id": fileCfg,
+		},
+	}
+
+	s.expectSecretAccessQuery(2)
+	uri := coresecrets.NewURI()
+	s.leadership.EXPECT().LeadershipCheck("mariadb", "mariadb/0").Return(s.token)
+	s.token.EXPECT().Check().Return(nil)
+	s.secretsMetaState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{{
+		Revision: 1,
+	}}, nil)
+	valueRef := &coresecrets.ValueRef{
+		BackendID:  "file-id",
+		RevisionID: uri.ID + "-1",
+	}
+	s.secretsMetaState.EXPECT().ChangeSecretBackend(
+		state.ChangeSecretBackendParams{
+			Token:    s.token,
+			URI:      uri,
+			Revision: 1,
+			ValueRef: valueRef,
+		},
+	).Return(nil)
+
+	// The agent cannot save to the file backend, so it sends the content.
+	result, err := s.facade.ChangeSecretBackend(params.ChangeSecretBackendArgs{
+		Args: []params.ChangeSecretBackendArg{{
+			URI:      uri.String(),
+			Revision: 1,
+			Content: params.SecretContentParams{
+				Data: map[string]string{"foo": "bar"},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	})
+
+	val, ref, err := secrets.ResolveControllerServedContent(func() (*provider.ModelBackendConfigInfo, error) {
+		return s.adminConfig, nil
+	}, nil, valueRef)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ref, gc.IsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
 func (s *secretsDrainSuite) TestWatchSecretBackendChanged(c *gc.C) {
 	defer s.setup(c).Finish()
 
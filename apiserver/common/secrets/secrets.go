@@ -14,6 +14,7 @@ import (
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/leadership"
 	corelogger "github.com/juju/juju/core/logger"
 	coresecrets "github.com/juju/juju/core/secrets"
@@ -33,6 +34,7 @@ var (
 	GetProvider            = provider.Provider
 	GetSecretsState        = getSecretsState
 	GetSecretBackendsState = getSecretBackendsState
+	GetControllerConfig    = getControllerConfig
 )
 
 func getSecretsState(m Model) state.SecretsStore {
@@ -43,6 +45,10 @@ func getSecretBackendsState(m Model) state.SecretBackendsStorage {
 	return state.NewSecretBackends(m.State())
 }
 
+func getControllerConfig(m Model) (controller.Config, error) {
+	return m.State().ControllerConfig()
+}
+
 // BackendConfigGetter is a func used to get secret backend config.
 type BackendConfigGetter func(backendIDs []string, wantAll bool) (*provider.ModelBackendConfigInfo, error)
 
@@ -110,13 +116,17 @@ func AdminBackendConfigInfo(model Model) (*provider.ModelBackendConfigInfo, erro
 		if b.Name == backendName {
 			info.ActiveID = b.ID
 		}
+		backendCfg, err := adminConfig(model, b)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting config for secret backend %q", b.Name)
+		}
 		info.Configs[b.ID] = provider.ModelBackendConfig{
 			ControllerUUID: model.ControllerUUID(),
 			ModelUUID:      model.UUID(),
 			ModelName:      model.Name(),
 			BackendConfig: provider.BackendConfig{
 				BackendType: b.BackendType,
-				Config:      b.Config,
+				Config:      backendCfg,
 			},
 		}
 	}
@@ -126,6 +136,36 @@ func AdminBackendConfigInfo(model Model) (*provider.ModelBackendConfigInfo, erro
 	return &info, nil
 }
 
+// adminConfig returns the backend config merged with any config the
+// backend's provider takes from controller config. The result is only
+// to be used by the controller.
+func adminConfig(model Model, b *coresecrets.SecretBackend) (map[string]interface{}, error) {
+	p, err := GetProvider(b.BackendType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secretConfig, ok := p.(provider.ControllerSecretConfig)
+	if !ok {
+		return b.Config, nil
+	}
+	controllerCfg, err := GetControllerConfig(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	extra, err := secretConfig.ControllerSecretConfig(controllerCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg := make(map[string]interface{}, len(b.Config)+len(extra))
+	for k, v := range b.Config {
+		cfg[k] = v
+	}
+	for k, v := range extra {
+		cfg[k] = v
+	}
+	return cfg, nil
+}
+
 // DrainBackendConfigInfo returns the secret backend config for the drain worker to use.
 func DrainBackendConfigInfo(backendID string, model Model, authTag names.Tag, leadershipChecker leadership.Checker) (*provider.ModelBackendConfigInfo, error) {
 	adminModelCfg, err := AdminBackendConfigInfo(model)
@@ -490,6 +530,40 @@ func PingBackend(p provider.SecretBackendProvider, cfg provider.ConfigAttrs) err
 	return b.Ping()
 }
 
+// SecretContent returns the content of the specified secret revision,
+// reading it from the backend holding it if it is not stored in the
+// Juju database. It is used where the controller itself consumes a
+// secret.
+func SecretContent(
+	secretsState SecretsGetter, adminConfigGetter BackendAdminConfigGetter, uri *coresecrets.URI, revision int,
+) (coresecrets.SecretValue, error) {
+	val, valueRef, err := secretsState.GetSecretValue(uri, revision)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if valueRef == nil {
+		return val, nil
+	}
+	cfgInfo, err := adminConfigGetter()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, ok := cfgInfo.Configs[valueRef.BackendID]
+	if !ok {
+		return nil, errors.NotFoundf("secret backend %q", valueRef.BackendID)
+	}
+	p, err := GetProvider(cfg.BackendType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend, err := p.NewBackend(&cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	val, err = backend.GetContent(context.TODO(), valueRef.RevisionID)
+	return val, errors.Trace(err)
+}
+
 // GetSecretMetadata returns the secrets metadata for the given filter.
 func GetSecretMetadata(
 	ownerTag names.Tag, secretsState SecretsMetaState, leadershipChecker leadership.Checker,
@@ -574,6 +648,8 @@ func GetSecretMetadata(
 // RemoveSecretsForAgent removes the specified secrets for agent.
 // The secrets are only removed from the state and
 // the caller must have permission to manage the secret(secret owners remove secrets from the backend on uniter side).
+// Content held in backends served by the controller is not accessible to
+// the agent, so it is removed here.
 func RemoveSecretsForAgent(
 	removeState SecretsRemoveState, adminConfigGetter BackendAdminConfigGetter,
 	args params.DeleteSecretArgs,
@@ -584,7 +660,20 @@ func RemoveSecretsForAgent(
 		removeState, adminConfigGetter, args,
 		modelUUID,
 		canDelete,
-		func(provider.SecretBackendProvider, provider.ModelBackendConfig, provider.SecretRevisions) error {
+		func(p provider.SecretBackendProvider, cfg provider.ModelBackendConfig, revs provider.SecretRevisions) error {
+			if !provider.IsServedByController(p) {
+				return nil
+			}
+			backend, err := p.NewBackend(&cfg)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, revId := range revs.RevisionIDs() {
+				err = backend.DeleteContent(context.TODO(), revId)
+				if err != nil && !errors.Is(err, errors.NotFound) {
+					return errors.Trace(err)
+				}
+			}
 			return nil
 		},
 	)
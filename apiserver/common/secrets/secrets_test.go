@@ -16,11 +16,13 @@ import (
 	"github.com/juju/juju/apiserver/common/secrets"
 	"github.com/juju/juju/apiserver/common/secrets/mocks"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/leadership"
 	coresecrets "github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/secrets/provider"
 	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/secrets/provider/file"
 	"github.com/juju/juju/secrets/provider/juju"
 	"github.com/juju/juju/secrets/provider/kubernetes"
 	"github.com/juju/juju/secrets/provider/vault"
@@ -203,6 +205,56 @@ func (s *secretsSuite) assertAdminBackendConfigInfoDefault(
 	c.Assert(info, jc.DeepEquals, expected)
 }
 
+func (s *secretsSuite) assertAdminBackendConfigInfoFile(c *gc.C, controllerCfg controller.Config) (*provider.ModelBackendConfigInfo, error) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	model := mocks.NewMockModel(ctrl)
+	backendState := mocks.NewMockSecretBackendsStorage(ctrl)
+
+	s.PatchValue(&secrets.GetSecretBackendsState, func(secrets.Model) state.SecretBackendsStorage { return backendState })
+	s.PatchValue(&secrets.GetControllerConfig, func(secrets.Model) (controller.Config, error) { return controllerCfg, nil })
+
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{"secret-backend": "myfile"})
+	model.EXPECT().ControllerUUID().Return(coretesting.ControllerTag.Id()).AnyTimes()
+	model.EXPECT().UUID().Return(coretesting.ModelTag.Id()).AnyTimes()
+	model.EXPECT().Name().Return("fred").AnyTimes()
+	model.EXPECT().Config().Return(cfg, nil)
+	model.EXPECT().Type().Return(state.ModelTypeIAAS)
+
+	backendState.EXPECT().ListSecretBackends().Return([]*coresecrets.SecretBackend{{
+		ID:          "file-id",
+		Name:        "myfile",
+		BackendType: file.BackendType,
+		Config: map[string]interface{}{
+			"path": "/srv/secrets",
+		},
+	}}, nil)
+
+	return secrets.AdminBackendConfigInfo(model)
+}
+
+func (s *secretsSuite) TestAdminBackendConfigInfoFileBackend(c *gc.C) {
+	key := "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	info, err := s.assertAdminBackendConfigInfoFile(c, controller.Config{
+		controller.SecretBackendEncryptionKey: key,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ActiveID, gc.Equals, "file-id")
+	c.Assert(info.Configs["file-id"].BackendConfig, jc.DeepEquals, provider.BackendConfig{
+		BackendType: file.BackendType,
+		Config: map[string]interface{}{
+			"path":           "/srv/secrets",
+			"encryption-key": key,
+		},
+	})
+}
+
+func (s *secretsSuite) TestAdminBackendConfigInfoFileBackendMissingKey(c *gc.C) {
+	_, err := s.assertAdminBackendConfigInfoFile(c, controller.Config{})
+	c.Assert(err, gc.ErrorMatches, `getting config for secret backend "myfile": missing controller config "secret-backend-encryption-key" not valid`)
+}
+
 func (s *secretsSuite) TestBackendConfigInfoLeaderUnit(c *gc.C) {
 	s.assertBackendConfigInfoLeaderUnit(c, []string{"backend-id"})
 }
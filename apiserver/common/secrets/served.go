@@ -0,0 +1,135 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+)
+
+// controllerServedBackend returns a backend client for the specified
+// backend config if the backend is served by the controller.
+// It returns nil if agents access the backend directly.
+func controllerServedBackend(cfg provider.ModelBackendConfig) (provider.SecretsBackend, error) {
+	p, err := GetProvider(cfg.BackendType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !provider.IsServedByController(p) {
+		return nil, nil
+	}
+	backend, err := p.NewBackend(&cfg)
+	return backend, errors.Trace(err)
+}
+
+// ResolveControllerServedContent returns the content of a secret revision
+// to hand to an agent. Agents cannot access backends served by the
+// controller, so content held in such a backend is read here and returned
+// in place of its reference. Otherwise val and valueRef are returned as is.
+func ResolveControllerServedContent(
+	adminConfigGetter BackendAdminConfigGetter, val coresecrets.SecretValue, valueRef *coresecrets.ValueRef,
+) (coresecrets.SecretValue, *coresecrets.ValueRef, error) {
+	if valueRef == nil {
+		return val, nil, nil
+	}
+	cfgInfo, err := adminConfigGetter()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cfg, ok := cfgInfo.Configs[valueRef.BackendID]
+	if !ok {
+		return nil, nil, errors.NotFoundf("secret backend %q", valueRef.BackendID)
+	}
+	backend, err := controllerServedBackend(cfg)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if backend == nil {
+		return val, valueRef, nil
+	}
+	served, err := backend.GetContent(context.TODO(), valueRef.RevisionID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return served, nil, nil
+}
+
+// SaveControllerServedContent saves the content of the specified secret
+// revision to the model's active backend if that backend is served by the
+// controller. It returns a reference to the saved content, or nil if the
+// content is to be stored in the Juju database, along with a func to
+// remove the content again should recording the reference fail.
+func SaveControllerServedContent(
+	adminConfigGetter BackendAdminConfigGetter, uri *coresecrets.URI, revision int, data coresecrets.SecretData,
+) (*coresecrets.ValueRef, func(), error) {
+	noop := func() {}
+	if len(data) == 0 {
+		return nil, noop, nil
+	}
+	cfgInfo, err := adminConfigGetter()
+	if err != nil {
+		return nil, noop, errors.Trace(err)
+	}
+	cfg, ok := cfgInfo.Configs[cfgInfo.ActiveID]
+	if !ok {
+		return nil, noop, errors.NotFoundf("secret backend %q", cfgInfo.ActiveID)
+	}
+	backend, err := controllerServedBackend(cfg)
+	if err != nil || backend == nil {
+		return nil, noop, errors.Trace(err)
+	}
+	revisionID, err := backend.SaveContent(context.TODO(), uri, revision, coresecrets.NewSecretValue(data))
+	if err != nil {
+		return nil, noop, errors.Annotatef(err, "saving content for secret %q", uri)
+	}
+	cleanup := func() {
+		if err := backend.DeleteContent(context.TODO(), revisionID); err != nil && !errors.Is(err, errors.NotFound) {
+			logger.Warningf("removing content for secret %q revision %d: %v", uri, revision, err)
+		}
+	}
+	return &coresecrets.ValueRef{
+		BackendID:  cfgInfo.ActiveID,
+		RevisionID: revisionID,
+	}, cleanup, nil
+}
+
+// deleteControllerServedContent removes the content referenced by
+// valueRef if it is held in a backend served by the controller.
+func deleteControllerServedContent(cfgInfo *provider.ModelBackendConfigInfo, valueRef *coresecrets.ValueRef) error {
+	if valueRef == nil {
+		return nil
+	}
+	cfg, ok := cfgInfo.Configs[valueRef.BackendID]
+	if !ok {
+		return errors.NotFoundf("secret backend %q", valueRef.BackendID)
+	}
+	backend, err := controllerServedBackend(cfg)
+	if err != nil || backend == nil {
+		return errors.Trace(err)
+	}
+	err = backend.DeleteContent(context.TODO(), valueRef.RevisionID)
+	if errors.Is(err, errors.NotFound) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// hasControllerServedBackend returns true if any of the backends in
+// cfgInfo are served by the controller.
+func hasControllerServedBackend(cfgInfo *provider.ModelBackendConfigInfo) (bool, error) {
+	for _, cfg := range cfgInfo.Configs {
+		p, err := GetProvider(cfg.BackendType)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if provider.IsServedByController(p) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common/secrets"
+	"github.com/juju/juju/apiserver/common/secrets/mocks"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/secrets/provider/file"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func (s *secretsSuite) fileBackendConfig(c *gc.C, otherType string) *provider.ModelBackendConfigInfo {
+	fileCfg := provider.ModelBackendConfig{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ModelName:      "fred",
+		BackendConfig: provider.BackendConfig{
+			BackendType: file.BackendType,
+			Config: map[string]interface{}{
+				"path":           c.MkDir(),
+				"encryption-key": "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=",
+			},
+		},
+	}
+	c.Assert(file.NewProvider().Initialise(&fileCfg), jc.ErrorIsNil)
+	return &provider.ModelBackendConfigInfo{
+		ActiveID: "file-id",
+		Configs: map[string]provider.ModelBackendConfig{
+			"file-id": fileCfg,
+			"other-id": {
+				BackendConfig: provider.BackendConfig{BackendType: otherType},
+			},
+		},
+	}
+}
+
+func (s *secretsSuite) TestSaveAndResolveControllerServedContent(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	otherProvider := mocks.NewMockSecretBackendProvider(ctrl)
+	s.PatchValue(&secrets.GetProvider, func(backendType string) (provider.SecretBackendProvider, error) {
+		if backendType == file.BackendType {
+			return file.NewProvider(), nil
+		}
+		return otherProvider, nil
+	})
+	cfgInfo := s.fileBackendConfig(c, "some-backend")
+	adminConfigGetter := func() (*provider.ModelBackendConfigInfo, error) {
+		return cfgInfo, nil
+	}
+
+	uri := coresecrets.NewURI()
+	valueRef, cleanup, err := secrets.SaveControllerServedContent(adminConfigGetter, uri, 2, map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(valueRef, jc.DeepEquals, &coresecrets.ValueRef{
+		BackendID:  "file-id",
+		RevisionID: uri.ID + "-2",
+	})
+
+	val, ref, err := secrets.ResolveControllerServedContent(adminConfigGetter, nil, valueRef)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ref, gc.IsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "bar"})
+
+	// References to other backends are handed to the agent as is.
+	otherRef := &coresecrets.ValueRef{BackendID: "other-id", RevisionID: "rev-1"}
+	val, ref, err = secrets.ResolveControllerServedContent(adminConfigGetter, nil, otherRef)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ref, gc.Equals, otherRef)
+	c.Assert(val, gc.IsNil)
+
+	cleanup()
+	_, _, err = secrets.ResolveControllerServedContent(adminConfigGetter, nil, valueRef)
+	c.Assert(err, gc.ErrorMatches, `secret revision ".*" not found`)
+}
+
+func (s *secretsSuite) TestSaveControllerServedContentOtherBackend(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	otherProvider := mocks.NewMockSecretBackendProvider(ctrl)
+	s.PatchValue(&secrets.GetProvider, func(backendType string) (provider.SecretBackendProvider, error) {
+		if backendType == file.BackendType {
+			return file.NewProvider(), nil
+		}
+		return otherProvider, nil
+	})
+	cfgInfo := s.fileBackendConfig(c, "some-backend")
+	cfgInfo.ActiveID = "other-id"
+
+	valueRef, _, err := secrets.SaveControllerServedContent(func() (*provider.ModelBackendConfigInfo, error) {
+		return cfgInfo, nil
+	}, coresecrets.NewURI(), 1, map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(valueRef, gc.IsNil)
+}
+
+func (s *secretsSuite) TestSecretContentExternalBackend(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	secretsState := mocks.NewMockSecretsStore(ctrl)
+	p := mocks.NewMockSecretBackendProvider(ctrl)
+	backend := mocks.NewMockSecretsBackend(ctrl)
+	s.PatchValue(&secrets.GetProvider, func(string) (provider.SecretBackendProvider, error) { return p, nil })
+
+	uri := coresecrets.NewURI()
+	cfg := provider.ModelBackendConfig{
+		BackendConfig: provider.BackendConfig{BackendType: "some-backend"},
+	}
+	gomock.InOrder(
+		secretsState.EXPECT().GetSecretValue(uri, 2).Return(nil, &coresecrets.ValueRef{
+			BackendID:  "backend-id",
+			RevisionID: "rev-id",
+		}, nil),
+		p.EXPECT().NewBackend(&cfg).Return(backend, nil),
+		backend.EXPECT().GetContent(gomock.Any(), "rev-id").Return(
+			coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}), nil),
+	)
+
+	val, err := secrets.SecretContent(secretsState, func() (*provider.ModelBackendConfigInfo, error) {
+		return &provider.ModelBackendConfigInfo{
+			ActiveID: "backend-id",
+			Configs:  map[string]provider.ModelBackendConfig{"backend-id": cfg},
+		}, nil
+	}, uri, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+}
+
+func (s *secretsSuite) TestSecretContentInternal(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	secretsState := mocks.NewMockSecretsStore(ctrl)
+	uri := coresecrets.NewURI()
+	secretsState.EXPECT().GetSecretValue(uri, 1).Return(
+		coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}), nil, nil)
+
+	val, err := secrets.SecretContent(secretsState, func() (*provider.ModelBackendConfigInfo, error) {
+		c.Fatalf("unexpected call to get backend config")
+		return nil, nil
+	}, uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+}
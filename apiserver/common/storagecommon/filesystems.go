@@ -27,9 +27,11 @@ func FilesystemParams(
 
 	var pool string
 	var size uint64
+	var snapshotId string
 	if stateFilesystemParams, ok := f.Params(); ok {
 		pool = stateFilesystemParams.Pool
 		size = stateFilesystemParams.Size
+		snapshotId = stateFilesystemParams.SnapshotId
 	} else {
 		filesystemInfo, err := f.Info()
 		if err != nil {
@@ -55,6 +57,7 @@ func FilesystemParams(
 		string(providerType),
 		cfg.Attrs(),
 		filesystemTags,
+		snapshotId,
 		nil, // attachment params set by the caller
 	}
 
@@ -167,8 +167,9 @@ func volumeStorageAttachmentInfo(
 		return nil, errors.Trace(err)
 	}
 	return &storage.StorageAttachmentInfo{
-		storage.StorageKindBlock,
-		devicePath,
+		Kind:     storage.StorageKindBlock,
+		Location: devicePath,
+		Size:     volumeInfo.Size,
 	}, nil
 }
 
@@ -196,9 +197,14 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
+	var size uint64
+	if filesystemInfo, err := filesystem.Info(); err == nil {
+		size = filesystemInfo.Size
+	}
 	return &storage.StorageAttachmentInfo{
-		storage.StorageKindFilesystem,
-		filesystemAttachmentInfo.MountPoint,
+		Kind:     storage.StorageKindFilesystem,
+		Location: filesystemAttachmentInfo.MountPoint,
+		Size:     size,
 	}, nil
 }
 
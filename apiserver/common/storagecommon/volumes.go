@@ -28,9 +28,11 @@ func VolumeParams(
 
 	var pool string
 	var size uint64
+	var snapshotId string
 	if stateVolumeParams, ok := v.Params(); ok {
 		pool = stateVolumeParams.Pool
 		size = stateVolumeParams.Size
+		snapshotId = stateVolumeParams.SnapshotId
 	} else {
 		volumeInfo, err := v.Info()
 		if err != nil {
@@ -55,6 +57,7 @@ func VolumeParams(
 		string(providerType),
 		cfg.Attrs(),
 		volumeTags,
+		snapshotId,
 		nil, // attachment params set by the caller
 	}, nil
 }
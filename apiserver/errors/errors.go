@@ -22,17 +22,19 @@ var logger = loggo.GetLogger("juju.apiserver.common.errors")
 
 const (
 	// TODO(juju3): move to params
-	ErrBadId              = errors.ConstError("id not found")
-	ErrBadCreds           = errors.ConstError("invalid entity name or password")
-	ErrNoCreds            = errors.ConstError("no credentials provided")
-	ErrLoginExpired       = errors.ConstError("login expired")
-	ErrPerm               = errors.ConstError("permission denied")
-	ErrNotLoggedIn        = errors.ConstError("not logged in")
-	ErrUnknownWatcher     = errors.ConstError("unknown watcher id")
-	ErrStoppedWatcher     = errors.ConstError("watcher has been stopped")
-	ErrBadRequest         = errors.ConstError("invalid request")
-	ErrTryAgain           = errors.ConstError("try again")
-	ErrActionNotAvailable = errors.ConstError("action no longer available")
+	ErrBadId               = errors.ConstError("id not found")
+	ErrBadCreds            = errors.ConstError("invalid entity name or password")
+	ErrNoCreds             = errors.ConstError("no credentials provided")
+	ErrLoginExpired        = errors.ConstError("login expired")
+	ErrSessionTokenInvalid = errors.ConstError("session token invalid")
+	ErrDeviceLoginPending  = errors.ConstError("device login pending")
+	ErrPerm                = errors.ConstError("permission denied")
+	ErrNotLoggedIn         = errors.ConstError("not logged in")
+	ErrUnknownWatcher      = errors.ConstError("unknown watcher id")
+	ErrStoppedWatcher      = errors.ConstError("watcher has been stopped")
+	ErrBadRequest          = errors.ConstError("invalid request")
+	ErrTryAgain            = errors.ConstError("try again")
+	ErrActionNotAvailable  = errors.ConstError("action no longer available")
 )
 
 // OperationBlockedError returns an error which signifies that
@@ -61,6 +63,8 @@ var singletonErrorCodes = map[errors.ConstError]string{
 	ErrBadCreds:                                  params.CodeUnauthorized,
 	ErrNoCreds:                                   params.CodeNoCreds,
 	ErrLoginExpired:                              params.CodeLoginExpired,
+	ErrSessionTokenInvalid:                       params.CodeSessionTokenInvalid,
+	ErrDeviceLoginPending:                        params.CodeDeviceLoginPending,
 	ErrPerm:                                      params.CodeUnauthorized,
 	ErrNotLoggedIn:                               params.CodeUnauthorized,
 	ErrUnknownWatcher:                            params.CodeNotFound,
@@ -353,6 +357,8 @@ func RestoreError(err error) error {
 		return fmt.Errorf(msg+"%w", errors.Hide(DeadlineExceededError))
 	case params.IsCodeTryAgain(err):
 		return ErrTryAgain
+	case params.IsCodeDeviceLoginPending(err):
+		return ErrDeviceLoginPending
 	default:
 		// Handle all other codes here.
 		return params.TranslateWellKnownError(err)
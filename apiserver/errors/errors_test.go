@@ -143,6 +143,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeTryAgain,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeTryAgain,
+}, {
+	err:        apiservererrors.ErrDeviceLoginPending,
+	code:       params.CodeDeviceLoginPending,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeDeviceLoginPending,
 }, {
 	err:        leadership.ErrClaimDenied,
 	code:       params.CodeLeadershipClaimDenied,
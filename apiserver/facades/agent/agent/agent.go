@@ -12,17 +12,26 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/cloudspec"
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
+	coresecrets "github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
-// AgentAPI implements the version 3 of the API provided to an agent.
+// AgentAPIV3 implements version 3 of the API provided to an agent,
+// which does not include LogForwardSecretHeaders.
+type AgentAPIV3 struct {
+	AgentAPI
+}
+
+// AgentAPI implements version 4 of the API provided to an agent.
 type AgentAPI struct {
 	*common.PasswordChanger
 	*common.RebootFlagClearer
@@ -131,6 +140,52 @@ func (api *AgentAPI) IsMaster() (params.IsMasterResult, error) {
 	}
 }
 
+// LogForwardSecretHeaders returns the headers held in the user secret
+// referenced by the model's logforward-headers-secret config. Only the
+// controller, which runs the log forwarder, may read them.
+func (api *AgentAPI) LogForwardSecretHeaders() (params.SecretContentResult, error) {
+	if !api.auth.AuthController() {
+		return params.SecretContentResult{}, apiservererrors.ErrPerm
+	}
+	model, err := api.st.Model()
+	if err != nil {
+		return params.SecretContentResult{}, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return params.SecretContentResult{}, errors.Trace(err)
+	}
+	var result params.SecretContentResult
+	secretURI := cfg.LogFwdHeadersSecret()
+	if secretURI == "" {
+		return result, nil
+	}
+	uri, err := coresecrets.ParseURI(secretURI)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	secretsState := state.NewSecrets(api.st)
+	md, err := secretsState.GetSecret(uri)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	// Only user secrets may be used, not secrets owned by a charm.
+	if md.OwnerTag != model.ModelTag().String() {
+		return result, errors.NotValidf("log forwarding headers secret %q not owned by the model", uri)
+	}
+	val, err := commonsecrets.SecretContent(secretsState, func() (*provider.ModelBackendConfigInfo, error) {
+		return commonsecrets.AdminBackendConfigInfo(commonsecrets.SecretsModel(model))
+	}, uri, md.LatestRevision)
+	if err != nil {
+		return result, errors.Annotatef(err, "reading log forwarding headers secret %q", uri)
+	}
+	result.Content.Data = val.EncodedValues()
+	return result, nil
+}
+
+// LogForwardSecretHeaders isn't on the v3 API.
+func (*AgentAPIV3) LogForwardSecretHeaders(_, _ struct{}) {}
+
 func stateJobsToAPIParamsJobs(jobs []state.MachineJob) []model.MachineJob {
 	pjobs := make([]model.MachineJob, len(jobs))
 	for i, job := range jobs {
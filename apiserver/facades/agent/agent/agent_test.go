@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
+	coresecrets "github.com/juju/juju/core/secrets"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
@@ -327,3 +328,51 @@ func (s *agentSuite) TestWatchAuthError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 }
+
+type fakeToken struct{}
+
+func (fakeToken) Check() error {
+	return nil
+}
+
+func (s *agentSuite) TestLogForwardSecretHeaders(c *gc.C) {
+	uri := coresecrets.NewURI()
+	_, err := state.NewSecrets(s.State).CreateSecret(uri, state.CreateSecretParams{
+		UpdateSecretParams: state.UpdateSecretParams{
+			LeaderToken: fakeToken{},
+			Data:        map[string]string{"authorization": "QmVhcmVyIHRva2Vu"},
+		},
+		Owner: s.Model.ModelTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"logforward-headers-secret": uri.String(),
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := agent.NewAgentAPIV4(facadetest.Context{
+		State_:     s.State,
+		StatePool_: s.StatePool,
+		Resources_: s.resources,
+		Auth_: apiservertesting.FakeAuthorizer{
+			Tag:        s.machine0.Tag(),
+			Controller: true,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.LogForwardSecretHeaders()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Content.Data, jc.DeepEquals, map[string]string{"authorization": "QmVhcmVyIHRva2Vu"})
+}
+
+func (s *agentSuite) TestLogForwardSecretHeadersNotController(c *gc.C) {
+	api, err := agent.NewAgentAPIV4(facadetest.Context{
+		State_:     s.State,
+		StatePool_: s.StatePool,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.LogForwardSecretHeaders()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
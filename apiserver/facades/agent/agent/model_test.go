@@ -42,7 +42,7 @@ func (s *modelSuite) SetUpTest(c *gc.C) {
 	s.resources = common.NewResources()
 	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
 
-	s.api, err = agent.NewAgentAPIV3(facadetest.Context{
+	s.api, err = agent.NewAgentAPIV4(facadetest.Context{
 		State_:     s.State,
 		StatePool_: s.StatePool,
 		Resources_: s.resources,
@@ -11,6 +11,7 @@ import (
 	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/secrets/provider"
 	"github.com/juju/juju/state"
 )
 
@@ -35,6 +36,9 @@ func newSecretsDrainAPI(context facade.Context) (*commonsecrets.SecretsDrainAPI,
 		return nil, errors.Trace(err)
 	}
 	authTag := context.Auth().GetAuthTag()
+	secretBackendAdminConfigGetter := func() (*provider.ModelBackendConfigInfo, error) {
+		return commonsecrets.AdminBackendConfigInfo(commonsecrets.SecretsModel(model))
+	}
 	return commonsecrets.NewSecretsDrainAPI(
 		authTag,
 		context.Auth(),
@@ -43,5 +47,6 @@ func newSecretsDrainAPI(context facade.Context) (*commonsecrets.SecretsDrainAPI,
 		commonsecrets.SecretsModel(model),
 		state.NewSecrets(context.State()),
 		context.State(),
+		secretBackendAdminConfigGetter,
 	)
 }
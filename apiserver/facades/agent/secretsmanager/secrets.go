@@ -265,12 +265,18 @@ func (s *SecretsManagerAPI) createSecret(arg params.CreateSecretArg) (string, er
 	if arg.RotatePolicy.WillRotate() {
 		nextRotateTime = arg.RotatePolicy.NextRotateTime(s.clock.Now())
 	}
+	updateParams := fromUpsertParams(arg.UpsertSecretArg, token, nextRotateTime)
+	cleanup, err := s.saveControllerServedContent(uri, 1, &updateParams)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
 	md, err := s.secretsState.CreateSecret(uri, state.CreateSecretParams{
 		Version:            secrets.Version,
 		Owner:              secretOwner,
-		UpdateSecretParams: fromUpsertParams(arg.UpsertSecretArg, token, nextRotateTime),
+		UpdateSecretParams: updateParams,
 	})
 	if err != nil {
+		cleanup()
 		return "", errors.Trace(err)
 	}
 	err = s.secretsConsumer.GrantSecretAccess(uri, state.SecretAccessParams{
@@ -347,8 +353,31 @@ func (s *SecretsManagerAPI) updateSecret(arg params.UpdateSecretArg) error {
 	if !md.RotatePolicy.WillRotate() && arg.RotatePolicy.WillRotate() {
 		nextRotateTime = arg.RotatePolicy.NextRotateTime(s.clock.Now())
 	}
-	_, err = s.secretsState.UpdateSecret(uri, fromUpsertParams(arg.UpsertSecretArg, token, nextRotateTime))
-	return errors.Trace(err)
+	updateParams := fromUpsertParams(arg.UpsertSecretArg, token, nextRotateTime)
+	cleanup, err := s.saveControllerServedContent(uri, md.LatestRevision+1, &updateParams)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err = s.secretsState.UpdateSecret(uri, updateParams); err != nil {
+		cleanup()
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// saveControllerServedContent saves content sent by the agent to the
+// active backend if the backend is served by the controller, replacing
+// the content in p with a reference to it.
+func (s *SecretsManagerAPI) saveControllerServedContent(uri *coresecrets.URI, revision int, p *state.UpdateSecretParams) (func(), error) {
+	valueRef, cleanup, err := commonsecrets.SaveControllerServedContent(s.adminConfigGetter, uri, revision, p.Data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if valueRef != nil {
+		p.ValueRef = valueRef
+		p.Data = nil
+	}
+	return cleanup, nil
 }
 
 // RemoveSecrets removes the specified secrets.
@@ -561,6 +590,11 @@ func (s *SecretsManagerAPI) GetSecretRevisionContentInfo(arg params.SecretRevisi
 			result.Results[i].Error = apiservererrors.ServerError(err)
 			continue
 		}
+		val, valueRef, err = commonsecrets.ResolveControllerServedContent(s.adminConfigGetter, val, valueRef)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
 		contentParams := params.SecretContentParams{}
 		if valueRef != nil {
 			contentParams.ValueRef = &params.SecretValueRef{
@@ -747,6 +781,9 @@ func (s *SecretsManagerAPI) getSecretContent(arg params.GetSecretContentArg) (
 	}
 
 	val, valueRef, err := s.secretsState.GetSecretValue(uri, consumedRevision)
+	if err == nil {
+		val, valueRef, err = commonsecrets.ResolveControllerServedContent(s.adminConfigGetter, val, valueRef)
+	}
 	content := &secrets.ContentParams{SecretValue: val, ValueRef: valueRef}
 	if err != nil || content.ValueRef == nil {
 		return content, nil, false, errors.Trace(err)
@@ -461,6 +461,12 @@ func (w *hostFilesystemAttachmentsWatcher) modelVolumeAttachmentChanged(hostTag
 	return nil
 }
 
+// FilterStringsWatcher returns a strings watcher that reports the
+// changes from w for which filter returns true.
+func FilterStringsWatcher(w state.StringsWatcher, filter func(string) (bool, error)) state.StringsWatcher {
+	return newFilteredStringsWatcher(w, filter)
+}
+
 type filteredStringsWatcher struct {
 	stringsWatcherBase
 	w      state.StringsWatcher
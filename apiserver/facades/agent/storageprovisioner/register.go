@@ -21,6 +21,30 @@ func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("StorageProvisioner", 4, func(ctx facade.Context) (facade.Facade, error) {
 		return newFacadeV4(ctx)
 	}, reflect.TypeOf((*StorageProvisionerAPIv4)(nil)))
+	registry.MustRegister("StorageProvisioner", 5, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacadeV5(ctx) // adds storage snapshots.
+	}, reflect.TypeOf((*StorageProvisionerAPIv5)(nil)))
+	registry.MustRegister("StorageProvisioner", 6, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacadeV6(ctx) // adds storage resizes.
+	}, reflect.TypeOf((*StorageProvisionerAPIv6)(nil)))
+}
+
+// newFacadeV6 provides the signature required for facade registration.
+func newFacadeV6(ctx facade.Context) (*StorageProvisionerAPIv6, error) {
+	v5, err := newFacadeV5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProvisionerAPIv6(v5), nil
+}
+
+// newFacadeV5 provides the signature required for facade registration.
+func newFacadeV5(ctx facade.Context) (*StorageProvisionerAPIv5, error) {
+	v4, err := newFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProvisionerAPIv5(v4), nil
 }
 
 // newFacadeV4 provides the signature required for facade registration.
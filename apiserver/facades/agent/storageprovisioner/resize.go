@@ -0,0 +1,177 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner/internal/filesystemwatcher"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// StorageProvisionerAPIv6 provides the StorageProvisioner API v6 facade.
+type StorageProvisionerAPIv6 struct {
+	*StorageProvisionerAPIv5
+}
+
+// NewStorageProvisionerAPIv6 creates a new server-side StorageProvisioner v6 facade.
+func NewStorageProvisionerAPIv6(v5 *StorageProvisionerAPIv5) *StorageProvisionerAPIv6 {
+	return &StorageProvisionerAPIv6{v5}
+}
+
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entities with the specified tags, so that requests to resize them
+// may be observed.
+func (s *StorageProvisionerAPIv6) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args,
+		s.sb.WatchModelVolumeResizes,
+		s.sb.WatchMachineVolumeResizes,
+		s.watchUnitVolumeResizes)
+}
+
+// watchUnitVolumeResizes returns a strings watcher that reports changes
+// to model-scoped volumes attached to units of the specified
+// application. This is how the volumes backing storage in Kubernetes
+// models are managed.
+func (s *StorageProvisionerAPIv6) watchUnitVolumeResizes(app names.ApplicationTag) state.StringsWatcher {
+	return filesystemwatcher.FilterStringsWatcher(s.sb.WatchModelVolumeResizes(), func(id string) (bool, error) {
+		attachments, err := s.sb.VolumeAttachments(names.NewVolumeTag(id))
+		if errors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		for _, a := range attachments {
+			if a.Host().Kind() != names.UnitTagKind {
+				continue
+			}
+			unitApp, err := names.UnitApplication(a.Host().Id())
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			if unitApp == app.Id() {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// WatchFilesystemResizes watches for changes to filesystems scoped to
+// the entities with the specified tags, so that requests to resize them
+// may be observed.
+func (s *StorageProvisionerAPIv6) WatchFilesystemResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelFilesystemResizes, s.sb.WatchMachineFilesystemResizes, nil)
+}
+
+// StorageResizeParams returns the parameters for resizing the volumes
+// or filesystems with the specified tags. The result for a volume or
+// filesystem that has no pending resize is empty.
+func (s *StorageProvisionerAPIv6) StorageResizeParams(args params.Entities) (params.StorageResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.StorageResizeParamsResults{}, err
+	}
+	results := params.StorageResizeParamsResults{
+		Results: make([]params.StorageResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (*params.StorageResizeParams, error) {
+		tag, err := names.ParseTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return nil, apiservererrors.ErrPerm
+		}
+		var (
+			requestedSize uint64
+			ok            bool
+			result        = params.StorageResizeParams{Tag: tag.String()}
+			pool          string
+		)
+		switch tag := tag.(type) {
+		case names.VolumeTag:
+			volume, err := s.sb.Volume(tag)
+			if errors.IsNotFound(err) {
+				return nil, apiservererrors.ErrPerm
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if requestedSize, ok = volume.RequestedSize(); !ok {
+				return nil, nil
+			}
+			info, err := volume.Info()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result.ProviderId = info.VolumeId
+			result.Size = info.Size
+			pool = info.Pool
+		case names.FilesystemTag:
+			filesystem, err := s.sb.Filesystem(tag)
+			if errors.IsNotFound(err) {
+				return nil, apiservererrors.ErrPerm
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if requestedSize, ok = filesystem.RequestedSize(); !ok {
+				return nil, nil
+			}
+			info, err := filesystem.Info()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result.ProviderId = info.FilesystemId
+			result.Size = info.Size
+			pool = info.Pool
+		default:
+			return nil, apiservererrors.ErrPerm
+		}
+		result.RequestedSize = requestedSize
+		providerType, cfg, err := storagecommon.StoragePoolConfig(pool, s.poolManager, s.registry)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Provider = string(providerType)
+		result.Attributes = cfg.Attrs()
+		return &result, nil
+	}
+	for i, arg := range args.Entities {
+		result, err := one(arg)
+		results.Results[i].Result = result
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetStorageResized records the outcome of resizing volumes or
+// filesystems, clearing the resize requests.
+func (s *StorageProvisionerAPIv6) SetStorageResized(args params.StorageResizedArgs) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Resized)),
+	}
+	one := func(arg params.StorageResized) error {
+		tag, err := names.ParseTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return apiservererrors.ErrPerm
+		}
+		switch tag := tag.(type) {
+		case names.VolumeTag:
+			return s.sb.SetVolumeResized(tag, arg.Size)
+		case names.FilesystemTag:
+			return s.sb.SetFilesystemResized(tag, arg.Size)
+		}
+		return apiservererrors.ErrPerm
+	}
+	for i, arg := range args.Resized {
+		err := one(arg)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
@@ -47,6 +47,12 @@ type StorageBackend interface {
 	WatchUnitVolumeAttachments(tag names.ApplicationTag) state.StringsWatcher
 	WatchVolumeAttachment(names.Tag, names.VolumeTag) state.NotifyWatcher
 	WatchMachineAttachmentsPlans(names.MachineTag) state.StringsWatcher
+	WatchModelStorageSnapshots() state.StringsWatcher
+	WatchMachineStorageSnapshots(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
+	WatchModelFilesystemResizes() state.StringsWatcher
+	WatchMachineFilesystemResizes(names.MachineTag) state.StringsWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 	AllStorageInstances() ([]state.StorageInstance, error)
@@ -81,6 +87,15 @@ type StorageBackend interface {
 	CreateVolumeAttachmentPlan(names.Tag, names.VolumeTag, state.VolumeAttachmentPlanInfo) error
 	RemoveVolumeAttachmentPlan(names.Tag, names.VolumeTag, bool) error
 	SetVolumeAttachmentPlanBlockInfo(machineTag names.Tag, volumeTag names.VolumeTag, info state.BlockDeviceInfo) error
+
+	StorageSnapshot(string) (state.StorageSnapshot, error)
+	SetStorageSnapshotInfo(string, state.StorageSnapshotInfo) error
+	SetStorageSnapshotError(string, string) error
+	StartStorageSnapshot(string) error
+	RemoveStorageSnapshot(string) error
+
+	SetVolumeResized(names.VolumeTag, uint64) error
+	SetFilesystemResized(names.FilesystemTag, uint64) error
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -0,0 +1,219 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// StorageProvisionerAPIv5 provides the StorageProvisioner API v5 facade.
+type StorageProvisionerAPIv5 struct {
+	*StorageProvisionerAPIv4
+}
+
+// NewStorageProvisionerAPIv5 creates a new server-side StorageProvisioner v5 facade.
+func NewStorageProvisionerAPIv5(v4 *StorageProvisionerAPIv4) *StorageProvisionerAPIv5 {
+	return &StorageProvisionerAPIv5{v4}
+}
+
+// WatchStorageSnapshots watches for changes to the lifecycles of storage
+// snapshots scoped to the entities with the specified tags.
+func (s *StorageProvisionerAPIv5) WatchStorageSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelStorageSnapshots, s.sb.WatchMachineStorageSnapshots, nil)
+}
+
+// storageSnapshot returns the storage snapshot with the specified ID,
+// if the authenticated agent may access it.
+func (s *StorageProvisionerAPIv5) storageSnapshot(id string) (state.StorageSnapshot, error) {
+	canAccess, err := s.getScopeAuthFunc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Snapshots of machine-scoped storage have IDs prefixed with the
+	// machine ID, so access is checked before looking the snapshot up;
+	// a removed snapshot is reported as not found to the agent that
+	// took it.
+	var scope names.Tag = s.st.ModelTag()
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		if !names.IsValidMachine(id[:i]) {
+			return nil, apiservererrors.ErrPerm
+		}
+		scope = names.NewMachineTag(id[:i])
+	}
+	if !canAccess(scope) {
+		return nil, apiservererrors.ErrPerm
+	}
+	snapshot, err := s.sb.StorageSnapshot(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return snapshot, nil
+}
+
+// StorageSnapshotParams returns the parameters for taking or deleting
+// the storage snapshots with the specified IDs. The result for an alive
+// snapshot that has already been taken, or has failed, is empty.
+func (s *StorageProvisionerAPIv5) StorageSnapshotParams(args params.Entities) (params.StorageSnapshotParamsResults, error) {
+	modelCfg, err := s.st.ModelConfig()
+	if err != nil {
+		return params.StorageSnapshotParamsResults{}, err
+	}
+	controllerCfg, err := s.st.ControllerConfig()
+	if err != nil {
+		return params.StorageSnapshotParamsResults{}, err
+	}
+	results := params.StorageSnapshotParamsResults{
+		Results: make([]params.StorageSnapshotParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (*params.StorageSnapshotParams, error) {
+		snapshot, err := s.storageSnapshot(arg.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result := params.StorageSnapshotParams{
+			Id:   snapshot.Id(),
+			Life: life.Value(snapshot.Life().String()),
+		}
+		providerType, cfg, err := storagecommon.StoragePoolConfig(snapshot.Pool(), s.poolManager, s.registry)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Provider = string(providerType)
+		result.Attributes = cfg.Attrs()
+
+		if snapshot.Life() != state.Alive {
+			// The volume or filesystem may be gone by now, so only
+			// what's recorded on the snapshot is used to delete it.
+			if info, err := snapshot.Info(); err == nil {
+				result.SnapshotId = info.SnapshotId
+			}
+			if volumeTag, err := snapshot.Volume(); err == nil {
+				result.VolumeTag = volumeTag.String()
+			} else if filesystemTag, err := snapshot.Filesystem(); err == nil {
+				result.FilesystemTag = filesystemTag.String()
+			}
+			return &result, nil
+		}
+		if _, err := snapshot.Info(); err == nil || snapshot.Message() != "" {
+			return nil, nil
+		}
+		if volumeTag, err := snapshot.Volume(); err == nil {
+			volume, err := s.sb.Volume(volumeTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info, err := volume.Info()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result.VolumeTag = volumeTag.String()
+			result.VolumeId = info.VolumeId
+		} else if filesystemTag, err := snapshot.Filesystem(); err == nil {
+			filesystem, err := s.sb.Filesystem(filesystemTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info, err := filesystem.Info()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result.FilesystemTag = filesystemTag.String()
+			result.FilesystemId = info.FilesystemId
+		} else {
+			return nil, errors.Trace(err)
+		}
+		storageInstance, err := s.sb.StorageInstance(snapshot.StorageInstance())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Tags, err = storagecommon.StorageTags(
+			storageInstance, modelCfg.UUID(), controllerCfg.ControllerUUID(), modelCfg,
+		)
+		if err != nil {
+			return nil, errors.Annotate(err, "computing storage tags")
+		}
+		return &result, nil
+	}
+	for i, arg := range args.Entities {
+		result, err := one(arg)
+		results.Results[i].Result = result
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// StartStorageSnapshots records that the storage provisioner is taking
+// the storage snapshots with the specified IDs. The result for a
+// snapshot that was started before, and so must not be taken again, is
+// an AlreadyExists error.
+func (s *StorageProvisionerAPIv5) StartStorageSnapshots(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) error {
+		if _, err := s.storageSnapshot(arg.Tag); err != nil {
+			return errors.Trace(err)
+		}
+		return s.sb.StartStorageSnapshot(arg.Tag)
+	}
+	for i, arg := range args.Entities {
+		err := one(arg)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveStorageSnapshots removes the dying storage snapshots with the
+// specified IDs, once they have been deleted.
+func (s *StorageProvisionerAPIv5) RemoveStorageSnapshots(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) error {
+		if _, err := s.storageSnapshot(arg.Tag); errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		return s.sb.RemoveStorageSnapshot(arg.Tag)
+	}
+	for i, arg := range args.Entities {
+		err := one(arg)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetStorageSnapshotInfo records the outcome of taking storage snapshots.
+func (s *StorageProvisionerAPIv5) SetStorageSnapshotInfo(args params.StorageSnapshotInfos) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Snapshots)),
+	}
+	one := func(arg params.StorageSnapshotInfo) error {
+		if _, err := s.storageSnapshot(arg.Id); err != nil {
+			return errors.Trace(err)
+		}
+		if arg.Error != "" {
+			return s.sb.SetStorageSnapshotError(arg.Id, arg.Error)
+		}
+		return s.sb.SetStorageSnapshotInfo(arg.Id, state.StorageSnapshotInfo{
+			SnapshotId: arg.SnapshotId,
+			Size:       arg.Size,
+		})
+	}
+	for i, arg := range args.Snapshots {
+		err := one(arg)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
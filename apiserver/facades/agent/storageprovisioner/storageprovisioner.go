@@ -349,6 +349,9 @@ func (s *StorageProvisionerAPIv4) watchStorageEntities(
 		case names.ModelTag:
 			w = watchEnvironStorage()
 		case names.ApplicationTag:
+			if watchApplicationStorage == nil {
+				return "", nil, apiservererrors.ServerError(errors.NotSupportedf("watching storage for %v", tag))
+			}
 			w = watchApplicationStorage(tag)
 		default:
 			return "", nil, apiservererrors.ServerError(errors.NotSupportedf("watching storage for %v", tag))
@@ -23,18 +23,29 @@ func Register(registry facade.FacadeRegistry) {
 		return newUniterAPIv18(ctx)
 	}, reflect.TypeOf((*UniterAPIv18)(nil)))
 	registry.MustRegister("Uniter", 19, func(ctx facade.Context) (facade.Facade, error) {
+		return newUniterAPIv19(ctx)
+	}, reflect.TypeOf((*UniterAPIv19)(nil)))
+	registry.MustRegister("Uniter", 20, func(ctx facade.Context) (facade.Facade, error) {
 		return newUniterAPI(ctx)
 	}, reflect.TypeOf((*UniterAPI)(nil)))
 }
 
 func newUniterAPIv18(context facade.Context) (*UniterAPIv18, error) {
-	api, err := newUniterAPI(context)
+	api, err := newUniterAPIv19(context)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &UniterAPIv18{*api}, nil
 }
 
+func newUniterAPIv19(context facade.Context) (*UniterAPIv19, error) {
+	api, err := newUniterAPI(context)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIv19{*api}, nil
+}
+
 // newUniterAPI creates a new instance of the core Uniter API.
 func newUniterAPI(context facade.Context) (*UniterAPI, error) {
 	authorizer := context.Auth()
@@ -25,12 +25,14 @@ type storageInterface interface {
 	AddStorageForUnitOperation(names.UnitTag, string, state.StorageConstraints) (state.ModelOperation, error)
 	WatchStorageAttachments(names.UnitTag) state.StringsWatcher
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) state.NotifyWatcher
+	SetStorageUsage(names.StorageTag, state.StorageUsage) error
 }
 
 type storageVolumeInterface interface {
 	StorageInstanceVolume(names.StorageTag) (state.Volume, error)
 	BlockDevices(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	WatchVolumeAttachment(names.Tag, names.VolumeTag) state.NotifyWatcher
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
 	WatchBlockDevices(names.MachineTag) state.NotifyWatcher
 	VolumeAttachment(names.Tag, names.VolumeTag) (state.VolumeAttachment, error)
 	VolumeAttachmentPlan(names.Tag, names.VolumeTag) (state.VolumeAttachmentPlan, error)
@@ -40,6 +42,7 @@ type storageFilesystemInterface interface {
 	StorageInstanceFilesystem(names.StorageTag) (state.Filesystem, error)
 	FilesystemAttachment(names.Tag, names.FilesystemTag) (state.FilesystemAttachment, error)
 	WatchFilesystemAttachment(names.Tag, names.FilesystemTag) state.NotifyWatcher
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
 }
 
 var getStorageState = func(st *state.State) (storageAccess, error) {
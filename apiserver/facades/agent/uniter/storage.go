@@ -201,15 +201,51 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 		ownerTag = owner.String()
 	}
 	return params.StorageAttachment{
-		stateStorageAttachment.StorageInstance().String(),
-		ownerTag,
-		stateStorageAttachment.Unit().String(),
-		params.StorageKind(stateStorageInstance.Kind()),
-		info.Location,
-		life.Value(stateStorageAttachment.Life().String()),
+		StorageTag: stateStorageAttachment.StorageInstance().String(),
+		OwnerTag:   ownerTag,
+		UnitTag:    stateStorageAttachment.Unit().String(),
+		Kind:       params.StorageKind(stateStorageInstance.Kind()),
+		Location:   info.Location,
+		Life:       life.Value(stateStorageAttachment.Life().String()),
+		Size:       info.Size,
 	}, nil
 }
 
+// SetStorageUsage records the usage of storage attached to units, as
+// reported by the units.
+func (s *StorageAPI) SetStorageUsage(args params.StorageUsageArgs) (params.ErrorResults, error) {
+	canAccess, err := s.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := s.setOneStorageUsage(canAccess, arg)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (s *StorageAPI) setOneStorageUsage(canAccess common.AuthFunc, arg params.StorageUsageArg) error {
+	stateStorageAttachment, err := s.getOneStateStorageAttachment(canAccess, params.StorageAttachmentId{
+		StorageTag: arg.StorageTag,
+		UnitTag:    arg.UnitTag,
+	})
+	if err != nil {
+		return err
+	}
+	err = s.storage.SetStorageUsage(stateStorageAttachment.StorageInstance(), state.StorageUsage{
+		BytesUsed:   arg.Usage.BytesUsed,
+		BytesTotal:  arg.Usage.BytesTotal,
+		InodesUsed:  arg.Usage.InodesUsed,
+		InodesTotal: arg.Usage.InodesTotal,
+		Updated:     arg.Usage.Updated,
+	})
+	return errors.Trace(err)
+}
+
 // WatchUnitStorageAttachments creates watchers for a collection of units,
 // each of which can be used to watch for lifecycle changes to the corresponding
 // unit's storage attachments.
@@ -417,8 +453,11 @@ func watchStorageAttachment(
 		// We need to watch both the volume attachment, and the
 		// machine's block devices. A volume attachment's block
 		// device could change (most likely, become present).
+		// The volume itself is watched so that the unit learns
+		// when it has been resized.
 		watchers = []state.NotifyWatcher{
 			stVolume.WatchVolumeAttachment(hostTag, volume.VolumeTag()),
+			stVolume.WatchVolume(volume.VolumeTag()),
 		}
 
 		// TODO(caas) - we currently only support block devices on machines.
@@ -442,6 +481,7 @@ func watchStorageAttachment(
 		}
 		watchers = []state.NotifyWatcher{
 			stFile.WatchFilesystemAttachment(hostTag, filesystem.FilesystemTag()),
+			stFile.WatchFilesystem(filesystem.FilesystemTag()),
 		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
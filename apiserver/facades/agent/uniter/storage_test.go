@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
@@ -80,6 +82,10 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		changes: make(chan struct{}, 1),
 	}
 	volumeWatcher.changes <- struct{}{}
+	volumeResizeWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	volumeResizeWatcher.changes <- struct{}{}
 	blockDevicesWatcher := &mockNotifyWatcher{
 		changes: make(chan struct{}, 1),
 	}
@@ -109,6 +115,11 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 			c.Assert(v, gc.DeepEquals, volumeTag)
 			return volumeWatcher
 		},
+		watchVolume: func(v names.VolumeTag) state.NotifyWatcher {
+			calls = append(calls, "WatchVolume")
+			c.Assert(v, gc.DeepEquals, volumeTag)
+			return volumeResizeWatcher
+		},
 		watchBlockDevices: func(m names.MachineTag) state.NotifyWatcher {
 			calls = append(calls, "WatchBlockDevices")
 			c.Assert(m, gc.DeepEquals, machineTag)
@@ -134,6 +145,7 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		"StorageInstance",
 		"StorageInstanceVolume",
 		"WatchVolumeAttachment",
+		"WatchVolume",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
 	})
@@ -172,6 +184,10 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		changes: make(chan struct{}, 1),
 	}
 	filesystemWatcher.changes <- struct{}{}
+	filesystemResizeWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	filesystemResizeWatcher.changes <- struct{}{}
 	var calls []string
 	st := &mockStorageState{
 		assignedMachine: assignedMachine,
@@ -197,6 +213,11 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 			c.Assert(f, gc.DeepEquals, filesystemTag)
 			return filesystemWatcher
 		},
+		watchFilesystem: func(f names.FilesystemTag) state.NotifyWatcher {
+			calls = append(calls, "WatchFilesystem")
+			c.Assert(f, gc.DeepEquals, filesystemTag)
+			return filesystemResizeWatcher
+		},
 	}
 
 	storage, err := uniter.NewStorageAPI(st, st, resources, getCanAccess)
@@ -217,6 +238,7 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		"StorageInstance",
 		"StorageInstanceFilesystem",
 		"WatchFilesystemAttachment",
+		"WatchFilesystem",
 		"WatchStorageAttachment",
 	})
 }
@@ -330,6 +352,66 @@ func (u *mockUnit) StorageConstraints() (map[string]state.StorageConstraints, er
 	return u.storageConstraints, nil
 }
 
+func (s *storageSuite) TestSetStorageUsage(c *gc.C) {
+	getCanAccess := func() (common.AuthFunc, error) {
+		return func(tag names.Tag) bool {
+			return tag == names.NewUnitTag("mysql/0")
+		}, nil
+	}
+	updated := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	var recorded []state.StorageUsage
+	st := &mockStorageState{
+		storageAttachment: func(s names.StorageTag, u names.UnitTag) (state.StorageAttachment, error) {
+			if s.Id() != "data/0" {
+				return nil, errors.NotFoundf("storage attachment %s:%s", s.Id(), u.Id())
+			}
+			return &mockStorageAttachment{storage: s, unit: u}, nil
+		},
+		setStorageUsage: func(s names.StorageTag, usage state.StorageUsage) error {
+			c.Check(s, gc.Equals, names.NewStorageTag("data/0"))
+			recorded = append(recorded, usage)
+			return nil
+		},
+	}
+	storage, err := uniter.NewStorageAPI(st, st, common.NewResources(), getCanAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	usage := params.StorageUsage{
+		BytesUsed:   1024,
+		BytesTotal:  4096,
+		InodesUsed:  10,
+		InodesTotal: 100,
+		Updated:     updated,
+	}
+	result, err := storage.SetStorageUsage(params.StorageUsageArgs{
+		Args: []params.StorageUsageArg{{
+			StorageTag: "storage-data-0",
+			UnitTag:    "unit-mysql-0",
+			Usage:      usage,
+		}, {
+			StorageTag: "storage-data-1",
+			UnitTag:    "unit-mysql-0",
+			Usage:      usage,
+		}, {
+			StorageTag: "storage-data-0",
+			UnitTag:    "unit-mysql-1",
+			Usage:      usage,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `storage attachment data/1:mysql/0 not found`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `permission denied`)
+
+	c.Assert(recorded, jc.DeepEquals, []state.StorageUsage{{
+		BytesUsed:   1024,
+		BytesTotal:  4096,
+		InodesUsed:  10,
+		InodesTotal: 100,
+		Updated:     updated,
+	}})
+}
+
 type mockStorageState struct {
 	unitStorageConstraints map[string]state.StorageConstraints
 	assignedMachine        string
@@ -347,8 +429,12 @@ type mockStorageState struct {
 	watchStorageAttachment        func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment     func(names.Tag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.Tag, names.VolumeTag) state.NotifyWatcher
+	watchVolume                   func(names.VolumeTag) state.NotifyWatcher
+	watchFilesystem               func(names.FilesystemTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
 	addUnitStorageOperation       func(u names.UnitTag, name string, cons state.StorageConstraints) error
+	storageAttachment             func(names.StorageTag, names.UnitTag) (state.StorageAttachment, error)
+	setStorageUsage               func(names.StorageTag, state.StorageUsage) error
 }
 
 func (m *mockStorageState) VolumeAccess() uniter.StorageVolumeInterface {
@@ -401,6 +487,14 @@ func (m *mockStorageState) WatchVolumeAttachment(hostTag names.Tag, v names.Volu
 	return m.watchVolumeAttachment(hostTag, v)
 }
 
+func (m *mockStorageState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return m.watchVolume(v)
+}
+
+func (m *mockStorageState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return m.watchFilesystem(f)
+}
+
 func (m *mockStorageState) WatchBlockDevices(mtag names.MachineTag) state.NotifyWatcher {
 	return m.watchBlockDevices(mtag)
 }
@@ -413,6 +507,28 @@ func (m *mockStorageState) ApplyOperation(state.ModelOperation) error {
 	return nil
 }
 
+func (m *mockStorageState) StorageAttachment(s names.StorageTag, u names.UnitTag) (state.StorageAttachment, error) {
+	return m.storageAttachment(s, u)
+}
+
+func (m *mockStorageState) SetStorageUsage(s names.StorageTag, usage state.StorageUsage) error {
+	return m.setStorageUsage(s, usage)
+}
+
+type mockStorageAttachment struct {
+	state.StorageAttachment
+	storage names.StorageTag
+	unit    names.UnitTag
+}
+
+func (a *mockStorageAttachment) StorageInstance() names.StorageTag {
+	return a.storage
+}
+
+func (a *mockStorageAttachment) Unit() names.UnitTag {
+	return a.unit
+}
+
 type mockStringsWatcher struct {
 	state.StringsWatcher
 	changes chan []string
@@ -483,6 +599,7 @@ type watchStorageAttachmentSuite struct {
 	storageInstance          *fakeStorageInstance
 	volume                   *fakeVolume
 	volumeAttachmentWatcher  *apiservertesting.FakeNotifyWatcher
+	volumeWatcher            *apiservertesting.FakeNotifyWatcher
 	blockDevicesWatcher      *apiservertesting.FakeNotifyWatcher
 	storageAttachmentWatcher *apiservertesting.FakeNotifyWatcher
 }
@@ -500,6 +617,7 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 	}
 	s.volume = &fakeVolume{tag: names.NewVolumeTag("0")}
 	s.volumeAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
+	s.volumeWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.blockDevicesWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.storageAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.st = &fakeStorage{
@@ -512,6 +630,9 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		watchVolumeAttachment: func(names.Tag, names.VolumeTag) state.NotifyWatcher {
 			return s.volumeAttachmentWatcher
 		},
+		watchVolume: func(names.VolumeTag) state.NotifyWatcher {
+			return s.volumeWatcher
+		},
 		watchBlockDevices: func(names.MachineTag) state.NotifyWatcher {
 			return s.blockDevicesWatcher
 		},
@@ -527,6 +648,12 @@ func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeAttachment
 	})
 }
 
+func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeChanges(c *gc.C) {
+	s.testWatchBlockStorageAttachment(c, func() {
+		s.volumeWatcher.C <- struct{}{}
+	})
+}
+
 func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentStorageAttachmentChanges(c *gc.C) {
 	s.testWatchBlockStorageAttachment(c, func() {
 		s.storageAttachmentWatcher.C <- struct{}{}
@@ -545,6 +672,7 @@ func (s *watchStorageAttachmentSuite) testWatchBlockStorageAttachment(c *gc.C, c
 		"StorageInstance",
 		"StorageInstanceVolume",
 		"WatchVolumeAttachment",
+		"WatchVolume",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
 	)
@@ -38,7 +38,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v18) of the Uniter API.
+// UniterAPI implements the latest version (v20) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -78,13 +78,22 @@ type UniterAPI struct {
 	cloudSpecer     cloudspec.CloudSpecer
 }
 
+// UniterAPIv19 implements version 19 of the uniter API, which does not
+// include SetStorageUsage.
+type UniterAPIv19 struct {
+	UniterAPI
+}
+
 // UniterAPIv18 Implements version 18 of the uniter API, which includes methods
 // ModelUUID and OpenedApplicationPortRangesByEndpoint that were removed from
 // later versions.
 type UniterAPIv18 struct {
-	UniterAPI
+	UniterAPIv19
 }
 
+// SetStorageUsage isn't on the v19 API.
+func (*UniterAPIv19) SetStorageUsage(_, _ struct{}) {}
+
 // OpenedMachinePortRangesByEndpoint returns the port ranges opened by each
 // unit on the provided machines grouped by application endpoint.
 func (u *UniterAPI) OpenedMachinePortRangesByEndpoint(args params.Entities) (params.OpenPortRangesByEndpointResults, error) {
@@ -595,17 +604,23 @@ func (u *UniterAPI) charmModifiedVersion(tagStr string, canAccess func(names.Tag
 		return -1, err
 	}
 	var application *state.Application
+	var unitName string
 	switch entity := unitOrApplication.(type) {
 	case *state.Application:
 		application = entity
+		unitName, _ = u.authUnitOf(entity.Name())
 	case *state.Unit:
 		application, err = entity.Application()
 		if err != nil {
 			return -1, err
 		}
+		unitName = entity.Name()
 	default:
 		return -1, errors.BadRequestf("type %T does not have a CharmModifiedVersion", entity)
 	}
+	if unitName != "" {
+		return application.UnitCharmModifiedVersion(unitName)
+	}
 	return application.CharmModifiedVersion(), nil
 }
 
@@ -635,7 +650,12 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 
 				switch entity := unitOrApplication.(type) {
 				case *state.Application:
-					cURL, force = entity.CharmURL()
+					// A unit tracking a branch runs the branch's charm.
+					if unitName, ok := u.authUnitOf(entity.Name()); ok {
+						cURL, force, err = entity.UnitCharmURL(unitName)
+					} else {
+						cURL, force = entity.CharmURL()
+					}
 				case *state.Unit:
 					cURL = entity.CharmURL()
 					// The force value is not actually used on the uniter's unit api.
@@ -655,6 +675,54 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 	return result, nil
 }
 
+// Watch starts a NotifyWatcher for each given entity. When a unit watches
+// its own application, the watcher also fires on changes to the model's
+// branches, since the charm the unit runs depends on the branch it tracks.
+func (u *UniterAPI) Watch(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i] = u.watchAgentEntity(entity)
+			continue
+		}
+		if _, ok := u.authUnitOf(tag.Id()); !ok {
+			result.Results[i] = u.watchAgentEntity(entity)
+			continue
+		}
+		id, err := u.watchApplicationForUnit(tag)
+		result.Results[i].NotifyWatcherId = id
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) watchAgentEntity(entity params.Entity) params.NotifyWatchResult {
+	results, err := u.AgentEntityWatcher.Watch(params.Entities{Entities: []params.Entity{entity}})
+	if err != nil {
+		return params.NotifyWatchResult{Error: apiservererrors.ServerError(err)}
+	}
+	return results.Results[0]
+}
+
+func (u *UniterAPI) watchApplicationForUnit(tag names.ApplicationTag) (string, error) {
+	app, err := u.st.Application(tag.Id())
+	if err != nil {
+		return "", err
+	}
+	watch := common.NewMultiNotifyWatcher(app.Watch(), u.st.WatchApplicationBranches(app.Name()))
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
 // SetCharmURL sets the charm URL for each given unit. An error will
 // be returned if a unit is dead, or the charm URL is not known.
 func (u *UniterAPI) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResults, error) {
@@ -1593,6 +1661,20 @@ func (u *UniterAPI) getUnit(tag names.UnitTag) (*state.Unit, error) {
 	return u.st.Unit(tag.Id())
 }
 
+// authUnitOf returns the name of the authenticated unit, if it is a unit
+// of the application with the input name.
+func (u *UniterAPI) authUnitOf(appName string) (string, bool) {
+	tag, ok := u.auth.GetAuthTag().(names.UnitTag)
+	if !ok {
+		return "", false
+	}
+	unitAppName, err := names.UnitApplication(tag.Id())
+	if err != nil || unitAppName != appName {
+		return "", false
+	}
+	return tag.Id(), true
+}
+
 func (u *UniterAPI) getRelationUnit(canAccess common.AuthFunc, relTag string, unitTag names.UnitTag) (*state.RelationUnit, error) {
 	rel, unit, err := u.getRelationAndUnit(canAccess, relTag, unitTag)
 	if err != nil {
@@ -1030,6 +1030,33 @@ func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestCharmURLUnderBranch(c *gc.C) {
+	c.Assert(s.Model.AddBranch("canary", "admin"), jc.ErrorIsNil)
+	branch, err := s.Model.Branch("canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignUnit(s.wordpressUnit.Name()), jc.ErrorIsNil)
+	c.Assert(branch.Refresh(), jc.ErrorIsNil)
+
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name:     "wordpress",
+		Revision: "4",
+	})
+	c.Assert(branch.SetCharm("wordpress", newCharm, *s.wordpress.CharmOrigin()), jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "application-wordpress"}}}
+	urlResult, err := s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(urlResult, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{{Result: newCharm.URL()}},
+	})
+
+	versionResult, err := s.uniter.CharmModifiedVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versionResult, gc.DeepEquals, params.IntResults{
+		Results: []params.IntResult{{Result: s.wordpress.CharmModifiedVersion() + 1}},
+	})
+}
+
 func (s *uniterSuite) TestWatchConfigSettingsHash(c *gc.C) {
 	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
 	c.Assert(err, jc.ErrorIsNil)
@@ -3739,7 +3766,7 @@ func (s *uniterSuite) TestOpenedApplicationPortRangesByEndpoint(c *gc.C) {
 
 	uniterAPI := s.newUniterAPI(c, st, s.authorizer)
 
-	api := &uniter.UniterAPIv18{UniterAPI: *uniterAPI}
+	api := &uniter.UniterAPIv18{UniterAPIv19: uniter.UniterAPIv19{UniterAPI: *uniterAPI}}
 	result, err := api.OpenedApplicationPortRangesByEndpoint(arg)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.DeepEquals, params.ApplicationOpenedPortsResults{
@@ -357,7 +357,7 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		return AddTrustSchemaAndDefaults(environschema.Fields{}, schema.Defaults{})
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -585,6 +585,9 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkEgressSupported(model, appConfig); err != nil {
+		return errors.Trace(err)
+	}
 
 	// Parse storage tags in AttachStorage.
 	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
@@ -755,6 +758,9 @@ func parseCharmSettings(
 	if err != nil {
 		return nil, nil, nil, nil, errors.Trace(err)
 	}
+	if err := validateEgressConfig(appConfig); err != nil {
+		return nil, nil, nil, nil, errors.Trace(err)
+	}
 
 	// If there isn't a charm YAML, then we can just return the charmConfig as
 	// the settings and no need to attempt to parse an empty yaml.
@@ -922,6 +928,9 @@ func (api *APIBase) setConfig(app Application, generation, settingsYAML string,
 	if err != nil {
 		return errors.Annotate(err, "parsing settings for application")
 	}
+	if err := checkEgressSupported(api.model, appConfig); err != nil {
+		return errors.Trace(err)
+	}
 
 	var configChanged bool
 	if len(charmSettings) != 0 {
@@ -1138,6 +1147,9 @@ func (api *APIBase) applicationSetCharm(
 	if err != nil {
 		return errors.Annotate(err, "parsing config settings")
 	}
+	if err := checkEgressSupported(model, appConfig); err != nil {
+		return errors.Trace(err)
+	}
 	if err := appConfig.Validate(); err != nil {
 		return errors.Annotate(err, "validating config settings")
 	}
@@ -2864,6 +2864,78 @@ func (s *ApplicationSuite) TestSetEmptyConfigMasterBranch(c *gc.C) {
 	c.Assert(result.OneError(), jc.ErrorIsNil)
 }
 
+// egressProvider is an environ provider whose support for egress rules
+// can be chosen.
+type egressProvider struct {
+	environs.EnvironProvider
+	supported bool
+}
+
+func (p egressProvider) SupportsEgressRules() bool {
+	return p.supported
+}
+
+func (s *ApplicationSuite) expectEgressProvider(c *gc.C, supported bool) {
+	s.model.EXPECT().ModelConfig().Return(config.New(config.UseDefaults, coretesting.FakeConfig()))
+	s.PatchValue(&application.EnvironProvider, func(providerType string) (environs.EnvironProvider, error) {
+		c.Check(providerType, gc.Equals, "someprovider")
+		return egressProvider{supported: supported}, nil
+	})
+}
+
+func (s *ApplicationSuite) TestSetConfigEgressAllow(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectEgressProvider(c, true)
+	schemaFields, defaults, err := application.AddTrustSchemaAndDefaults(environschema.Fields{}, schema.Defaults{})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.expectDefaultApplication(ctrl)
+	app.EXPECT().UpdateApplicationConfig(coreconfig.ConfigAttributes{"egress-allow": "10.0.0.0/8:443/tcp"}, nil, schemaFields, defaults)
+	s.backend.EXPECT().Application("postgresql").Return(app, nil)
+
+	result, err := s.api.SetConfigs(params.ConfigSetArgs{
+		Args: []params.ConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"egress-allow": "10.0.0.0/8:443/tcp"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestSetConfigEgressAllowNotSupported(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectEgressProvider(c, false)
+	app := s.expectDefaultApplication(ctrl)
+	s.backend.EXPECT().Application("postgresql").Return(app, nil)
+
+	result, err := s.api.SetConfigs(params.ConfigSetArgs{
+		Args: []params.ConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"egress-allow": "10.0.0.0/8:443/tcp"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `egress-allow in "someprovider" models not supported`)
+}
+
+func (s *ApplicationSuite) TestSetConfigInvalidEgressAllow(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	app := s.expectDefaultApplication(ctrl)
+	s.backend.EXPECT().Application("postgresql").Return(app, nil)
+
+	result, err := s.api.SetConfigs(params.ConfigSetArgs{
+		Args: []params.ConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"egress-allow": "10.0.0.0/8 example.com"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `parsing settings for application: invalid egress-allow: egress rule "example.com", .* not valid`)
+}
+
 func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	s.modelType = state.ModelTypeCAAS
 	ctrl := s.setup(c)
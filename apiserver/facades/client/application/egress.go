@@ -0,0 +1,70 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/config"
+	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+)
+
+var (
+	// Overridden by tests.
+	EnvironProvider = environs.Provider
+)
+
+var egressFields = environschema.Fields{
+	firewall.EgressAllowKey: {
+		Description: "Space separated <cidr>[:<port-range>] destinations this application may send traffic to; empty allows all outbound traffic",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+var egressDefaults = schema.Defaults{
+	firewall.EgressAllowKey: "",
+}
+
+// validateEgressConfig returns an error if the application config holds
+// egress rules that can't be parsed.
+func validateEgressConfig(appConfig *config.Config) error {
+	value, ok := appConfig.Attributes()[firewall.EgressAllowKey].(string)
+	if !ok {
+		return nil
+	}
+	if _, err := firewall.ParseEgressRules(value); err != nil {
+		return errors.Annotatef(err, "invalid %s", firewall.EgressAllowKey)
+	}
+	return nil
+}
+
+// checkEgressSupported returns an error if the application config restricts
+// outbound traffic in a model whose provider can't enforce the
+// restriction. Kubernetes models enforce egress rules with network
+// policies.
+func checkEgressSupported(model Model, appConfig *config.Config) error {
+	if appConfig == nil || model.Type() == state.ModelTypeCAAS {
+		return nil
+	}
+	if value, _ := appConfig.Attributes()[firewall.EgressAllowKey].(string); value == "" {
+		return nil
+	}
+	modelConfig, err := model.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	provider, err := EnvironProvider(modelConfig.Type())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if p, ok := provider.(environs.EgressFirewallProvider); ok && p.SupportsEgressRules() {
+		return nil
+	}
+	return errors.NotSupportedf("%s in %q models", firewall.EgressAllowKey, modelConfig.Type())
+}
@@ -25,7 +25,9 @@ var trustDefaults = schema.Defaults{
 	TrustConfigOptionName: defaultTrustLevel,
 }
 
-// AddTrustSchemaAndDefaults adds trust schema fields and defaults to an existing set of schema fields and defaults.
+// AddTrustSchemaAndDefaults adds the schema fields and defaults common to all
+// applications, trust and egress-allow, to an existing set of schema fields
+// and defaults.
 func AddTrustSchemaAndDefaults(schema environschema.Fields, defaults schema.Defaults) (environschema.Fields, schema.Defaults, error) {
 	newSchema, err := addTrustSchema(schema)
 	newDefaults := addTrustDefaults(defaults)
@@ -37,6 +39,9 @@ func addTrustDefaults(defaults schema.Defaults) schema.Defaults {
 	for key, value := range trustDefaults {
 		newDefaults[key] = value
 	}
+	for key, value := range egressDefaults {
+		newDefaults[key] = value
+	}
 	for key, value := range defaults {
 		newDefaults[key] = value
 	}
@@ -48,11 +53,13 @@ func addTrustDefaults(defaults schema.Defaults) schema.Defaults {
 // schema fields.
 func addTrustSchema(extra environschema.Fields) (environschema.Fields, error) {
 	fields := make(environschema.Fields)
-	for name, field := range trustFields {
-		fields[name] = field
+	for _, common := range []environschema.Fields{trustFields, egressFields} {
+		for name, field := range common {
+			fields[name] = field
+		}
 	}
 	for name, field := range extra {
-		if _, ok := trustFields[name]; ok {
+		if _, ok := fields[name]; ok {
 			return nil, errors.Errorf("config field %q clashes with common config", name)
 		}
 		fields[name] = field
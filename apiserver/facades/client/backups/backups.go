@@ -5,6 +5,7 @@ package backups
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/mgo/v3"
 	"github.com/juju/names/v5"
 
@@ -21,6 +22,8 @@ import (
 	"github.com/juju/juju/state/backups"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.backups")
+
 // Backend exposes state.State functionality needed by the backups Facade.
 type Backend interface {
 	IsController() bool
@@ -45,6 +48,11 @@ type API struct {
 	machineID string
 }
 
+// APIv3 provides the Backups API facade for version 3.
+type APIv3 struct {
+	*API
+}
+
 // NewAPI creates a new instance of the Backups API facade.
 func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
@@ -115,6 +123,18 @@ func extractResourceValue(resources facade.Resources, key string) (string, error
 	return strRes.String(), nil
 }
 
+// Restore isn't on the v3 API.
+func (*APIv3) Restore(_, _ struct{}) {}
+
+// RestoreStatus isn't on the v3 API.
+func (*APIv3) RestoreStatus(_, _ struct{}) {}
+
+// List isn't on the v3 API.
+func (*APIv3) List(_, _ struct{}) {}
+
+// Verify isn't on the v3 API.
+func (*APIv3) Verify(_, _ struct{}) {}
+
 var newBackups = backups.NewBackups
 
 // CreateResult updates the result with the information in the
@@ -6,4 +6,6 @@ package backups
 var (
 	NewBackups     = &newBackups
 	WaitUntilReady = &waitUntilReady
+	StartRestore   = &startRestore
+	RestoreRunning = &restoreRunning
 )
@@ -0,0 +1,44 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// List is the API method that returns the metadata of all the backup
+// archives stored on the controller machine, oldest first.
+func (a *API) List() (params.BackupsListResult, error) {
+	var result params.BackupsListResult
+	backupsMethods := newBackups(a.paths)
+
+	metaList, err := backupsMethods.List()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.List = make([]params.BackupsMetadataResult, len(metaList))
+	for i, meta := range metaList {
+		result.List[i] = CreateResult(meta, meta.ID())
+	}
+	return result, nil
+}
+
+// Verify is the API method that checks a stored backup archive against
+// the checksum recorded when it was created.
+func (a *API) Verify(args params.BackupsVerifyArgs) (params.BackupsMetadataResult, error) {
+	var result params.BackupsMetadataResult
+	if args.ID == "" {
+		return result, errors.NotValidf("missing backup ID")
+	}
+	backupsMethods := newBackups(a.paths)
+
+	meta, err := backupsMethods.Verify(args.ID)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return CreateResult(meta, args.ID), nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/rpc/params"
+)
+
+func (s *backupsSuite) TestList(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	result, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List"})
+	c.Check(result.List, jc.DeepEquals, []params.BackupsMetadataResult{
+		backups.CreateResult(s.meta, s.meta.ID()),
+	})
+}
+
+func (s *backupsSuite) TestListError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.api.List()
+	c.Assert(err, gc.ErrorMatches, "failed!")
+}
+
+func (s *backupsSuite) TestVerify(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	result, err := s.api.Verify(params.BackupsVerifyArgs{ID: "test-filename"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Verify"})
+	c.Check(fake.IDArg, gc.Equals, "test-filename")
+	c.Check(result, jc.DeepEquals, backups.CreateResult(s.meta, "test-filename"))
+}
+
+func (s *backupsSuite) TestVerifyMissingID(c *gc.C) {
+	s.setBackups(c, s.meta, "")
+	_, err := s.api.Verify(params.BackupsVerifyArgs{})
+	c.Assert(err, gc.ErrorMatches, "missing backup ID not valid")
+}
+
+func (s *backupsSuite) TestVerifyError(c *gc.C) {
+	s.setBackups(c, nil, "checksum mismatch")
+	_, err := s.api.Verify(params.BackupsVerifyArgs{ID: "test-filename"})
+	c.Assert(err, gc.ErrorMatches, "checksum mismatch")
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/agent/tools"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	jujunames "github.com/juju/juju/juju/names"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state/backups"
+)
+
+// restoreUnitName is the name of the transient systemd unit in which
+// the restore runs.
+const restoreUnitName = "juju-restore-backup"
+
+// startRestore starts "jujud restore-backup" to restore the controller
+// from the backup archive with the given ID. It runs in a transient
+// systemd unit rather than as a child of the controller agent, since
+// stopping the agent's service would otherwise stop the restore too.
+var startRestore = func(dataDir, machineID, backupDir, id string) error {
+	jujud := filepath.Join(tools.ToolsDir(dataDir, names.NewMachineTag(machineID).String()), jujunames.Jujud)
+	cmd := exec.Command("systemd-run",
+		"--unit", restoreUnitName, "--collect", "--quiet",
+		jujud, "restore-backup",
+		"--data-dir", dataDir,
+		"--machine-id", machineID,
+		"--backup-dir", backupDir,
+		id,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Annotatef(err, "starting restore: %s", out)
+	}
+	return nil
+}
+
+// restoreRunning reports whether the transient unit running a restore
+// is still active.
+var restoreRunning = func() bool {
+	return exec.Command("systemctl", "is-active", "--quiet", restoreUnitName).Run() == nil
+}
+
+// Restore is the API method that starts restoring the controller from
+// a backup archive previously uploaded to the controller. The archive
+// must have been created by a controller running the same major and
+// minor version.
+//
+// The restore runs outside the controller agent, which it stops while
+// the database is replaced and starts again afterwards. The connection
+// used to start the restore is dropped when the agent stops; use
+// RestoreStatus on a new connection to follow the restore.
+func (a *API) Restore(args params.BackupsRestoreArgs) (params.BackupsRestoreResult, error) {
+	if args.ID == "" {
+		return params.BackupsRestoreResult{}, errors.NotValidf("missing backup ID")
+	}
+	if filepath.Base(args.ID) != args.ID {
+		return params.BackupsRestoreResult{}, errors.NotValidf("backup ID %q", args.ID)
+	}
+
+	current, err := backups.ReadRestoreStatus(a.paths.DataDir)
+	if err != nil && !errors.Is(err, errors.NotFound) {
+		return params.BackupsRestoreResult{}, errors.Trace(err)
+	}
+	if err == nil && !current.Done {
+		if restoreRunning() {
+			return params.BackupsRestoreResult{}, errors.Errorf("restore of %q already in progress", current.ID)
+		}
+		// The restore died without recording its outcome.
+		logger.Warningf("restore of %q did not finish", current.ID)
+	}
+
+	if _, err := os.Stat(filepath.Join(a.paths.BackupDir, args.ID)); os.IsNotExist(err) {
+		return params.BackupsRestoreResult{}, errors.NotFoundf("backup %q", args.ID)
+	} else if err != nil {
+		return params.BackupsRestoreResult{}, errors.Trace(err)
+	}
+
+	// Check before the agent is stopped that the restore can go ahead.
+	// The check is made again by the restore itself.
+	session := a.backend.MongoSession().Copy()
+	err = backups.CheckFreshController(sessionShim{session})
+	session.Close()
+	if err != nil {
+		return params.BackupsRestoreResult{}, errors.Trace(err)
+	}
+
+	status := &backups.RestoreStatus{ID: args.ID}
+	if err := backups.WriteRestoreStatus(a.paths.DataDir, status); err != nil {
+		return params.BackupsRestoreResult{}, errors.Annotate(err, "recording restore status")
+	}
+	if err := startRestore(a.paths.DataDir, a.machineID, a.paths.BackupDir, args.ID); err != nil {
+		status.Done = true
+		status.Error = err.Error()
+		if err := backups.WriteRestoreStatus(a.paths.DataDir, status); err != nil {
+			logger.Errorf("cannot record restore status: %v", err)
+		}
+		return params.BackupsRestoreResult{}, errors.Trace(err)
+	}
+	return restoreResult(status), nil
+}
+
+// RestoreStatus returns the progress of the restore of the backup
+// archive with the given ID, including the steps completed so far and,
+// once the restore has finished, whether it failed. The progress is
+// read from the controller machine's data directory, where the restore
+// records it.
+func (a *API) RestoreStatus(args params.BackupsRestoreArgs) (params.BackupsRestoreResult, error) {
+	status, err := backups.ReadRestoreStatus(a.paths.DataDir)
+	if errors.Is(err, errors.NotFound) || (err == nil && status.ID != args.ID) {
+		return params.BackupsRestoreResult{}, errors.NotFoundf("restore of %q", args.ID)
+	}
+	if err != nil {
+		return params.BackupsRestoreResult{}, errors.Trace(err)
+	}
+	if !status.Done && !restoreRunning() {
+		status.Done = true
+		status.Error = "restore stopped without recording its outcome"
+	}
+	return restoreResult(status), nil
+}
+
+func restoreResult(status *backups.RestoreStatus) params.BackupsRestoreResult {
+	result := params.BackupsRestoreResult{
+		Steps: status.Steps,
+		Done:  status.Done,
+	}
+	if status.Error != "" {
+		result.Error = apiservererrors.ServerError(errors.New(status.Error))
+	}
+	if status.Metadata != nil {
+		result.Metadata = CreateResult(status.Metadata, status.ID)
+	}
+	return result
+}
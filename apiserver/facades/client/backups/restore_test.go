@@ -0,0 +1,182 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	backupsAPI "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state/backups"
+)
+
+// startedRestore records the arguments a restore was started with.
+type startedRestore struct {
+	dataDir   string
+	machineID string
+	backupDir string
+	id        string
+}
+
+func (s *backupsSuite) patchRestore(running bool, startErr error) *[]startedRestore {
+	var started []startedRestore
+	s.PatchValue(backupsAPI.StartRestore, func(dataDir, machineID, backupDir, id string) error {
+		started = append(started, startedRestore{dataDir, machineID, backupDir, id})
+		return startErr
+	})
+	s.PatchValue(backupsAPI.RestoreRunning, func() bool { return running })
+	return &started
+}
+
+func (s *backupsSuite) addUploadedArchive(c *gc.C) string {
+	backupDir := backups.BackupDirToUse("")
+	file, err := os.CreateTemp(backupDir, backups.UploadFilenamePrefix+"*.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(file.Close(), jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { _ = os.Remove(file.Name()) })
+	return filepath.Base(file.Name())
+}
+
+func (s *backupsSuite) TestRestore(c *gc.C) {
+	started := s.patchRestore(true, nil)
+	id := s.addUploadedArchive(c)
+
+	result, err := s.api.Restore(params.BackupsRestoreArgs{ID: id})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Done, jc.IsFalse)
+	c.Check(*started, jc.DeepEquals, []startedRestore{{
+		dataDir:   s.DataDir(),
+		machineID: "0",
+		backupDir: backups.BackupDirToUse(""),
+		id:        id,
+	}})
+
+	result, err = s.api.RestoreStatus(params.BackupsRestoreArgs{ID: id})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Done, jc.IsFalse)
+}
+
+func (s *backupsSuite) TestRestoreStatusFromRestore(c *gc.C) {
+	s.patchRestore(false, nil)
+	err := backups.WriteRestoreStatus(s.DataDir(), &backups.RestoreStatus{
+		ID:       "test-filename",
+		Steps:    []string{"stopping controller agent", "restoring database"},
+		Done:     true,
+		Metadata: s.meta,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.RestoreStatus(params.BackupsRestoreArgs{ID: "test-filename"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Done, jc.IsTrue)
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.Steps, jc.DeepEquals, []string{"stopping controller agent", "restoring database"})
+	c.Check(result.Metadata.Version, gc.Equals, s.meta.Origin.Version)
+}
+
+func (s *backupsSuite) TestRestoreStatusFailed(c *gc.C) {
+	s.patchRestore(false, nil)
+	err := backups.WriteRestoreStatus(s.DataDir(), &backups.RestoreStatus{
+		ID:    "test-filename",
+		Steps: []string{"restoring database"},
+		Done:  true,
+		Error: "failed!",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.RestoreStatus(params.BackupsRestoreArgs{ID: "test-filename"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Done, jc.IsTrue)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals, "failed!")
+}
+
+func (s *backupsSuite) TestRestoreStatusRestoreDied(c *gc.C) {
+	s.patchRestore(false, nil)
+	err := backups.WriteRestoreStatus(s.DataDir(), &backups.RestoreStatus{ID: "test-filename"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.RestoreStatus(params.BackupsRestoreArgs{ID: "test-filename"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Done, jc.IsTrue)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals, "restore stopped without recording its outcome")
+}
+
+func (s *backupsSuite) TestRestoreMissingID(c *gc.C) {
+	s.patchRestore(false, nil)
+	_, err := s.api.Restore(params.BackupsRestoreArgs{})
+	c.Assert(err, gc.ErrorMatches, "missing backup ID not valid")
+}
+
+func (s *backupsSuite) TestRestoreInvalidID(c *gc.C) {
+	started := s.patchRestore(false, nil)
+	_, err := s.api.Restore(params.BackupsRestoreArgs{ID: "../passwd"})
+	c.Assert(err, gc.ErrorMatches, `backup ID "../passwd" not valid`)
+	c.Check(*started, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestRestoreMissingArchive(c *gc.C) {
+	started := s.patchRestore(false, nil)
+	_, err := s.api.Restore(params.BackupsRestoreArgs{ID: backups.UploadFilenamePrefix + "missing.tar.gz"})
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+	c.Check(*started, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestRestoreControllerInUse(c *gc.C) {
+	started := s.patchRestore(false, nil)
+	s.Factory.MakeApplication(c, nil)
+	id := s.addUploadedArchive(c)
+
+	_, err := s.api.Restore(params.BackupsRestoreArgs{ID: id})
+	c.Assert(err, gc.ErrorMatches, `controller is not freshly bootstrapped .*`)
+	c.Check(*started, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestRestoreStartFailed(c *gc.C) {
+	s.patchRestore(false, errors.New("no systemd"))
+	id := s.addUploadedArchive(c)
+
+	_, err := s.api.Restore(params.BackupsRestoreArgs{ID: id})
+	c.Assert(err, gc.ErrorMatches, "no systemd")
+
+	result, err := s.api.RestoreStatus(params.BackupsRestoreArgs{ID: id})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Done, jc.IsTrue)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals, "no systemd")
+}
+
+func (s *backupsSuite) TestRestoreStatusUnknown(c *gc.C) {
+	s.patchRestore(false, nil)
+	_, err := s.api.RestoreStatus(params.BackupsRestoreArgs{ID: "test-filename"})
+	c.Assert(err, gc.ErrorMatches, `restore of "test-filename" not found`)
+}
+
+func (s *backupsSuite) TestRestoreAlreadyRunning(c *gc.C) {
+	started := s.patchRestore(true, nil)
+	err := backups.WriteRestoreStatus(s.DataDir(), &backups.RestoreStatus{ID: "test-filename"})
+	c.Assert(err, jc.ErrorIsNil)
+	id := s.addUploadedArchive(c)
+
+	_, err = s.api.Restore(params.BackupsRestoreArgs{ID: id})
+	c.Assert(err, gc.ErrorMatches, `restore of "test-filename" already in progress`)
+	c.Check(*started, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestRestoreAfterRestoreDied(c *gc.C) {
+	started := s.patchRestore(false, nil)
+	err := backups.WriteRestoreStatus(s.DataDir(), &backups.RestoreStatus{ID: "test-filename"})
+	c.Assert(err, jc.ErrorIsNil)
+	id := s.addUploadedArchive(c)
+
+	_, err = s.api.Restore(params.BackupsRestoreArgs{ID: id})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*started, gc.HasLen, 1)
+}
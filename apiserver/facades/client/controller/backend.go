@@ -39,6 +39,7 @@ type Backend interface {
 	ModelExists(uuid string) (bool, error)
 	ControllerConfig() (jujucontroller.Config, error)
 	UpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error
+	QueryAuditLog(filter state.AuditLogFilter) ([]state.AuditLogEntry, error)
 }
 
 type Application interface {
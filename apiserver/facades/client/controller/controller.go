@@ -9,7 +9,8 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/juju/collections/set"
+	charmresource "github.com/juju/charm/v12/resource"
+	"github.com/juju/description/v7"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v5"
@@ -17,12 +18,11 @@ import (
 	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/api"
-	"github.com/juju/juju/api/client/usermanager"
-	controllerclient "github.com/juju/juju/api/controller/controller"
 	"github.com/juju/juju/api/controller/migrationtarget"
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/cloudspec"
+	"github.com/juju/juju/apiserver/common/credentialcommon"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/caas"
@@ -35,11 +35,13 @@ import (
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/docker"
 	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/state/storage"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -64,10 +66,21 @@ type ControllerAPI struct {
 	multiwatcherFactory multiwatcher.Factory
 }
 
-type ControllerAPIv11 struct {
+// ControllerAPIv13 provides the Controller API facade for version 13.
+type ControllerAPIv13 struct {
 	*ControllerAPI
 }
 
+// ControllerAPIv12 provides the Controller API facade for version 12.
+type ControllerAPIv12 struct {
+	*ControllerAPIv13
+}
+
+// ControllerAPIv11 provides the Controller API facade for version 11.
+type ControllerAPIv11 struct {
+	*ControllerAPIv12
+}
+
 // LatestAPI is used for testing purposes to create the latest
 // controller API.
 var LatestAPI = makeControllerAPI
@@ -467,6 +480,63 @@ func (c *ControllerAPIv11) ModelConfig() (params.ModelConfigResults, error) {
 	return result, nil
 }
 
+// AuditLog returns the audit log entries, written by the database audit
+// log sink of every controller, that match the query. Only controller
+// superusers may read the audit log.
+func (c *ControllerAPI) AuditLog(args params.AuditLogQuery) (params.AuditLogResults, error) {
+	result := params.AuditLogResults{}
+	if err := c.checkIsSuperUser(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	filter := state.AuditLogFilter{
+		User:      args.User,
+		ModelUUID: args.ModelUUID,
+		Facade:    args.Facade,
+		Method:    args.Method,
+		Limit:     args.Limit,
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	entries, err := c.state.QueryAuditLog(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			Controller:     entry.Controller,
+			ConversationID: entry.ConversationID,
+			ConnectionID:   entry.ConnectionID,
+			When:           entry.When,
+			Who:            entry.Who,
+			What:           entry.What,
+			ModelName:      entry.ModelName,
+			ModelUUID:      entry.ModelUUID,
+			RequestID:      entry.RequestID,
+			Facade:         entry.Facade,
+			Method:         entry.Method,
+			Version:        entry.Version,
+			Args:           entry.Args,
+		}
+		for _, e := range entry.Errors {
+			result.Entries[i].Errors = append(result.Entries[i].Errors, params.AuditLogError{
+				Message: e.Message,
+				Code:    e.Code,
+			})
+		}
+	}
+	return result, nil
+}
+
+// AuditLog isn't on the v12 API.
+func (c *ControllerAPIv12) AuditLog(_, _ struct{}) {}
+
 // HostedModelConfigs returns all the information that the client needs in
 // order to connect directly with the host model's provider and destroy it
 // directly.
@@ -628,41 +698,139 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, err := c.migrationModelState(spec.ModelTag)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Release()
 
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.statePool.Get(modelTag.Id())
+	// Check if the migration is likely to succeed.
+	systemState, err := c.statePool.SystemState()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	leaders, err := c.leadership.Leaders()
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+
+	if err := runMigrationPreChecks(
+		hostedState.State, systemState,
+		&targetInfo, c.presence,
+		leaders,
+	); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return mig.Id(), nil
+}
+
+// MigrationDryRun runs the checks that would be made before and during
+// the migration of one or more models to other controllers, without
+// starting the migrations. Rather than failing on the first issue found,
+// every blocker is reported.
+func (c *ControllerAPI) MigrationDryRun(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationDryRunResults, error,
+) {
+	out := params.MigrationDryRunResults{
+		Results: make([]params.MigrationDryRunResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		blockers, err := c.dryRunOneMigration(spec)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		} else {
+			result.Blockers = blockers
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) dryRunOneMigration(spec params.MigrationSpec) ([]params.MigrationBlocker, error) {
+	hostedState, err := c.migrationModelState(spec.ModelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	defer hostedState.Release()
 
-	// Construct target info.
-	specTarget := spec.TargetInfo
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	systemState, err := c.statePool.SystemState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	leaders, err := c.leadership.Leaders()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	blockers, err := runMigrationDryRun(
+		hostedState.State, systemState,
+		&targetInfo, c.presence,
+		leaders,
+	)
+	return blockers, errors.Trace(err)
+}
+
+// migrationModelState returns the state for the model with the
+// specified tag, ensuring that the model exists.
+func (c *ControllerAPI) migrationModelState(tag string) (*state.PooledState, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return nil, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return nil, errors.NotFoundf("model")
+	}
+
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	return hostedState, errors.Trace(err)
+}
+
+func makeTargetInfo(specTarget params.MigrationTargetInfo) (coremigration.TargetInfo, error) {
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return coremigration.TargetInfo{}, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return coremigration.TargetInfo{}, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return coremigration.TargetInfo{}, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	return coremigration.TargetInfo{
 		ControllerTag:   controllerTag,
 		ControllerAlias: specTarget.ControllerAlias,
 		Addrs:           specTarget.Addrs,
@@ -670,38 +838,149 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		AuthTag:         authTag,
 		Password:        specTarget.Password,
 		Macaroons:       macs,
+	}, nil
+}
+
+// CreateMigrationPlan records a plan to migrate a number of models to
+// another controller. The controller starts the migrations as the
+// plan's concurrency limit and time window allow, retrying those which
+// fail. The ID of the new plan is returned.
+func (c *ControllerAPI) CreateMigrationPlan(args params.CreateMigrationPlanArgs) (params.StringResult, error) {
+	if err := c.checkIsSuperUser(); err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	id, err := c.createMigrationPlan(args)
+	if err != nil {
+		return params.StringResult{Error: apiservererrors.ServerError(err)}, nil
 	}
+	return params.StringResult{Result: id}, nil
+}
 
-	// Check if the migration is likely to succeed.
-	systemState, err := c.statePool.SystemState()
+func (c *ControllerAPI) createMigrationPlan(args params.CreateMigrationPlanArgs) (string, error) {
+	modelUUIDs := make([]string, len(args.ModelTags))
+	for i, tag := range args.ModelTags {
+		modelTag, err := names.ParseModelTag(tag)
+		if err != nil {
+			return "", errors.Annotate(err, "model tag")
+		}
+		modelUUIDs[i] = modelTag.Id()
+	}
+	targetInfo, err := makeTargetInfo(args.TargetInfo)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	spec := state.MigrationPlanSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+		ModelUUIDs:  modelUUIDs,
+		Concurrency: args.Concurrency,
+		MaxAttempts: args.MaxAttempts,
+	}
+	if args.WindowStart != nil {
+		spec.WindowStart = *args.WindowStart
+	}
+	if args.WindowEnd != nil {
+		spec.WindowEnd = *args.WindowEnd
+	}
 
-	leaders, err := c.leadership.Leaders()
+	systemState, err := c.statePool.SystemState()
 	if err != nil {
 		return "", errors.Trace(err)
 	}
-
-	if err := runMigrationPreChecks(
-		hostedState.State, systemState,
-		&targetInfo, c.presence,
-		leaders,
-	); err != nil {
+	plan, err := systemState.CreateMigrationPlan(spec)
+	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return plan.Id(), nil
+}
 
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+// MigrationPlans returns the controller's migration plans, along with
+// the progress of each model in them.
+func (c *ControllerAPI) MigrationPlans() (params.MigrationPlanResults, error) {
+	if err := c.checkIsSuperUser(); err != nil {
+		return params.MigrationPlanResults{}, errors.Trace(err)
+	}
+	systemState, err := c.statePool.SystemState()
 	if err != nil {
-		return "", errors.Trace(err)
+		return params.MigrationPlanResults{}, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	plans, err := systemState.AllMigrationPlans()
+	if err != nil {
+		return params.MigrationPlanResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.MigrationPlan, len(plans))
+	for i, plan := range plans {
+		targetInfo, err := plan.TargetInfo()
+		if err != nil {
+			return params.MigrationPlanResults{}, errors.Trace(err)
+		}
+		result := params.MigrationPlan{
+			Id:                    plan.Id(),
+			InitiatedBy:           plan.InitiatedBy(),
+			Created:               plan.Created(),
+			TargetControllerTag:   targetInfo.ControllerTag.String(),
+			TargetControllerAlias: targetInfo.ControllerAlias,
+			Concurrency:           plan.Concurrency(),
+			MaxAttempts:           plan.MaxAttempts(),
+			Completed:             plan.Completed(),
+			Cancelled:             plan.Cancelled(),
+		}
+		if start := plan.WindowStart(); !start.IsZero() {
+			result.WindowStart = &start
+		}
+		if end := plan.WindowEnd(); !end.IsZero() {
+			result.WindowEnd = &end
+		}
+		for _, model := range plan.Models() {
+			modelResult := params.MigrationPlanModel{
+				ModelTag:    names.NewModelTag(model.ModelUUID).String(),
+				ModelName:   model.ModelName,
+				Status:      string(model.Status),
+				Attempts:    model.Attempts,
+				MigrationId: model.MigrationId,
+				Message:     model.Message,
+			}
+			if retryAfter := model.RetryAfter; !retryAfter.IsZero() {
+				modelResult.RetryAfter = &retryAfter
+			}
+			result.Models = append(result.Models, modelResult)
+		}
+		results[i] = result
+	}
+	return params.MigrationPlanResults{Results: results}, nil
 }
 
+// CancelMigrationPlan stops the controller from starting any further
+// migrations for the plan. Migrations already running are left to
+// finish.
+func (c *ControllerAPI) CancelMigrationPlan(args params.CancelMigrationPlanArgs) (params.ErrorResult, error) {
+	if err := c.checkIsSuperUser(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	systemState, err := c.statePool.SystemState()
+	if err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	plan, err := systemState.MigrationPlan(args.Id)
+	if err == nil {
+		err = plan.Cancel()
+	}
+	return params.ErrorResult{Error: apiservererrors.ServerError(err)}, nil
+}
+
+// MigrationDryRun isn't on the v13 API.
+func (c *ControllerAPIv13) MigrationDryRun(_, _ struct{}) {}
+
+// CreateMigrationPlan isn't on the v13 API.
+func (c *ControllerAPIv13) CreateMigrationPlan(_, _ struct{}) {}
+
+// MigrationPlans isn't on the v13 API.
+func (c *ControllerAPIv13) MigrationPlans(_, _ struct{}) {}
+
+// CancelMigrationPlan isn't on the v13 API.
+func (c *ControllerAPIv13) CancelMigrationPlan(_, _ struct{}) {}
+
 // ModifyControllerAccess changes the model access granted to users.
 func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -810,221 +1089,204 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 	return nil
 }
 
-// runMigrationPreChecks runs prechecks on the migration and updates
-// information in targetInfo as needed based on information
-// retrieved from the target controller.
+// runMigrationPreChecks runs the migration prechecks, using the
+// presence of the API server's connections. It is replaced in tests.
 var runMigrationPreChecks = func(
 	st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo,
 	presence facade.Presence, leaders map[string]string,
 ) error {
+	return migration.PrecheckMigration(
+		st, ctlrSt, targetInfo,
+		presence.ModelPresence(st.ModelUUID()),
+		presence.ModelPresence(ctlrSt.ModelUUID()),
+		leaders,
+	)
+}
+
+// The checks run by a migration dry run, used to categorise the
+// blockers found.
+const (
+	dryRunCheckSource     = "source"
+	dryRunCheckCredential = "credential"
+	dryRunCheckCharms     = "charms"
+	dryRunCheckExport     = "export"
+	dryRunCheckUsers      = "users"
+	dryRunCheckTarget     = "target"
+)
+
+// runMigrationDryRun runs the migration prechecks, along with checks
+// that the model can be exported, that its cloud credential is valid and
+// that its charms and resources are available to be transferred. The
+// target controller is asked to check that it could import the model.
+// Each check is run independently so that all blockers are reported.
+var runMigrationDryRun = func(
+	st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo,
+	presence facade.Presence, leaders map[string]string,
+) ([]params.MigrationBlocker, error) {
+	var blockers []params.MigrationBlocker
+	check := func(name string, err error) {
+		if err != nil {
+			blockers = append(blockers, params.MigrationBlocker{
+				Check:   name,
+				Message: err.Error(),
+			})
+		}
+	}
+
 	// Check model and source controller.
 	backend, err := migration.PrecheckShim(st, ctlrSt)
 	if err != nil {
-		return errors.Annotate(err, "creating backend")
+		return nil, errors.Annotate(err, "creating backend")
 	}
-	modelPresence := presence.ModelPresence(st.ModelUUID())
-	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
-
-	if err := migration.SourcePrecheck(
+	for _, err := range migration.SourcePrecheckAll(
 		backend,
-		modelPresence, controllerPresence,
+		presence.ModelPresence(st.ModelUUID()),
+		presence.ModelPresence(ctlrSt.ModelUUID()),
 		cloudspec.MakeCloudSpecGetterForModel(st),
-	); err != nil {
-		return errors.Annotate(err, "source prechecks failed")
+	) {
+		check(dryRunCheckSource, err)
 	}
+	check(dryRunCheckCredential, checkMigrationCredential(st))
+	check(dryRunCheckCharms, checkMigrationCharms(st))
 
-	// Check target controller.
-	modelInfo, srcUserList, err := makeModelInfo(st, ctlrSt, leaders)
-	if err != nil {
-		return errors.Trace(err)
+	modelInfo, srcUserList, err := migration.MakeModelInfo(st, ctlrSt, leaders)
+	if err == nil {
+		err = checkModelSerialization(modelInfo.ModelDescription)
 	}
-	targetConn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	check(dryRunCheckExport, err)
+	exported := err == nil
+
+	// Check target controller.
+	targetConn, err := api.Open(migration.TargetToAPIInfo(targetInfo), migration.ControllerDialOpts())
 	if err != nil {
-		return errors.Annotate(err, "connect to target controller")
+		check(dryRunCheckTarget, errors.Annotate(err, "connect to target controller"))
+		return blockers, nil
 	}
 	defer targetConn.Close()
-	dstUserList, err := getTargetControllerUsers(targetConn)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	if err = srcUserList.checkCompatibilityWith(dstUserList); err != nil {
-		return errors.Trace(err)
+	dstUserList, err := migration.TargetControllerUsers(targetConn)
+	if err == nil && exported {
+		err = srcUserList.CheckCompatibilityWith(dstUserList)
 	}
+	check(dryRunCheckUsers, err)
+
 	client := migrationtarget.NewClient(targetConn)
 	if targetInfo.CACert == "" {
-		targetInfo.CACert, err = client.CACert()
-		if err != nil {
-			if !params.IsCodeNotImplemented(err) {
-				return errors.Annotatef(err, "cannot retrieve CA certificate")
-			}
+		if _, err := client.CACert(); params.IsCodeNotImplemented(err) {
 			// If the call's not implemented, it indicates an earlier version
 			// of the controller, which we can't migrate to.
-			return errors.New("controller API version is too old")
+			check(dryRunCheckTarget, errors.New("controller API version is too old"))
+			return blockers, nil
+		} else if err != nil {
+			check(dryRunCheckTarget, errors.Annotatef(err, "cannot retrieve CA certificate"))
 		}
 	}
-	err = client.Prechecks(modelInfo)
-	return errors.Annotate(err, "target prechecks failed")
-}
-
-// userList encapsulates information about the users who have been granted
-// access to a model or the users known to a particular controller.
-type userList struct {
-	identityURL string
-	users       set.Strings
-}
-
-// checkCompatibilityWith ensures that the set of users granted access to
-// the model being migrated is present in the destination (migration target)
-// controller.
-func (src *userList) checkCompatibilityWith(dst userList) error {
-	srcUsers, dstUsers := src.users, dst.users
-
-	// If external users have access to this model we can only allow the
-	// migration to proceed if:
-	// - the local users from src exist in the dst, and
-	// - both controllers are configured with the same identity provider URL
-	srcExtUsers := filterSet(srcUsers, func(u string) bool {
-		return strings.Contains(u, "@")
-	})
-
-	if srcExtUsers.Size() != 0 {
-		localSrcUsers := srcUsers.Difference(srcExtUsers)
-
-		// In this case external user lookups will most likely not work.
-		// Display an appropriate error message depending on whether
-		// the local users are present in dst or not.
-		if src.identityURL != dst.identityURL {
-			missing := localSrcUsers.Difference(dstUsers)
-			if missing.Size() == 0 {
-				return errors.Errorf(`cannot initiate migration as external users have been granted access to the model
-and the two controllers have different identity provider configurations. To resolve
-this issue you can remove the following users from the current model:
-  - %s`,
-					strings.Join(srcExtUsers.Values(), "\n  - "),
-				)
-			}
-
-			return errors.Errorf(`cannot initiate migration as external users have been granted access to the model
-and the two controllers have different identity provider configurations. To resolve
-this issue you need to remove the following users from the current model:
-  - %s
-
-and add the following users to the destination controller or remove them from
-the current model:
-  - %s`,
-				strings.Join(srcExtUsers.Values(), "\n  - "),
-				strings.Join(localSrcUsers.Difference(dstUsers).Values(), "\n  - "),
-			)
-
-		}
-
-		// External user lookups will work out of the box. We only need
-		// to ensure that the local model users are present in dst
-		srcUsers = localSrcUsers
+	if !exported {
+		return blockers, nil
 	}
-
-	if missing := srcUsers.Difference(dstUsers); missing.Size() != 0 {
-		return errors.Errorf(`cannot initiate migration as the users granted access to the model do not exist
-on the destination controller. To resolve this issue you can add the following
-users to the destination controller or remove them from the current model:
-  - %s`, strings.Join(missing.Values(), "\n  - "))
+	targetBlockers, err := client.DryRun(modelInfo)
+	if errors.Is(err, errors.NotSupported) {
+		// Older target controllers can only report the first
+		// issue found by their prechecks.
+		check(dryRunCheckTarget, client.Prechecks(modelInfo))
+	} else if err != nil {
+		check(dryRunCheckTarget, err)
 	}
-
-	return nil
+	return append(blockers, targetBlockers...), nil
 }
 
-func makeModelInfo(st, ctlrSt *state.State, leaders map[string]string) (coremigration.ModelInfo, userList, error) {
-	var empty coremigration.ModelInfo
-	var ul userList
-
+// checkMigrationCredential checks that the cloud credential used by the
+// model is valid, and can still be used to reach the model's instances.
+func checkMigrationCredential(st *state.State) error {
 	model, err := st.Model()
 	if err != nil {
-		return empty, ul, errors.Trace(err)
+		return errors.Trace(err)
 	}
-
-	description, err := st.Export(leaders)
+	cloud, err := model.Cloud()
 	if err != nil {
-		return empty, ul, errors.Trace(err)
+		return errors.Trace(err)
 	}
-
-	users, err := model.Users()
+	// We don't want to check existing cloud instances for "manual" clouds.
+	results, err := credentialcommon.ValidateExistingModelCredential(
+		credentialcommon.NewPersistentBackend(st),
+		context.CallContext(st),
+		cloud.Type != "manual",
+		true,
+	)
 	if err != nil {
-		return empty, ul, errors.Trace(err)
-	}
-	ul.users = set.NewStrings()
-	for _, u := range users {
-		ul.users.Add(u.UserName)
+		return errors.Trace(err)
 	}
+	return errors.Trace(results.Combine())
+}
 
-	// Retrieve agent version for the model.
-	conf, err := model.ModelConfig()
+// checkMigrationCharms checks that the charm archives and uploaded
+// resources of every application can be read from the controller's
+// storage, so that they can be transferred to the target controller.
+func checkMigrationCharms(st *state.State) error {
+	applications, err := st.AllApplications()
 	if err != nil {
-		return empty, userList{}, errors.Trace(err)
+		return errors.Annotate(err, "retrieving applications")
 	}
-	agentVersion, _ := conf.AgentVersion()
-
-	// Retrieve agent version for the controller.
-	controllerModel, err := ctlrSt.Model()
-	if err != nil {
-		return empty, userList{}, errors.Trace(err)
+	store := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	resources := st.Resources()
+	var missing []string
+	for _, app := range applications {
+		ch, _, err := app.Charm()
+		if err != nil {
+			return errors.Annotatef(err, "retrieving charm for application %s", app.Name())
+		}
+		if ch.IsPlaceholder() || !ch.IsUploaded() {
+			missing = append(missing, fmt.Sprintf("charm %s for application %s", ch.URL(), app.Name()))
+		} else if err := checkStoredBlob(store, ch.StoragePath()); err != nil {
+			missing = append(missing, fmt.Sprintf("charm %s for application %s (%v)", ch.URL(), app.Name(), err))
+		}
+		appResources, err := resources.ListResources(app.Name())
+		if err != nil {
+			return errors.Annotatef(err, "retrieving resources for application %s", app.Name())
+		}
+		for _, res := range appResources.Resources {
+			if res.IsPlaceholder() {
+				// Placeholders for store resources can be fetched
+				// again by the target controller, but uploads cannot.
+				if res.Origin == charmresource.OriginUpload {
+					missing = append(missing, fmt.Sprintf("resource %s for application %s", res.Name, app.Name()))
+				}
+				continue
+			}
+			_, reader, err := resources.OpenResource(app.Name(), res.Name)
+			if err != nil {
+				missing = append(missing, fmt.Sprintf("resource %s for application %s (%v)", res.Name, app.Name(), err))
+				continue
+			}
+			_ = reader.Close()
+		}
 	}
-	controllerConfig, err := controllerModel.Config()
-	if err != nil {
-		return empty, userList{}, errors.Trace(err)
+	if len(missing) > 0 {
+		return errors.Errorf("not available to transfer:\n  - %s", strings.Join(missing, "\n  - "))
 	}
-	controllerVersion, _ := controllerConfig.AgentVersion()
-
-	coreConf, err := ctlrSt.ControllerConfig()
-	if err != nil {
-		return empty, userList{}, errors.Trace(err)
-	}
-	ul.identityURL = coreConf.IdentityURL()
-	return coremigration.ModelInfo{
-		UUID:                   model.UUID(),
-		Name:                   model.Name(),
-		Owner:                  model.Owner(),
-		AgentVersion:           agentVersion,
-		ControllerAgentVersion: controllerVersion,
-		ModelDescription:       description,
-	}, ul, nil
+	return nil
 }
 
-func getTargetControllerUsers(conn api.Connection) (userList, error) {
-	ul := userList{}
-
-	userClient := usermanager.NewClient(conn)
-	users, err := userClient.UserInfo(nil, usermanager.AllUsers)
-	if err != nil {
-		return ul, errors.Trace(err)
-	}
-
-	ul.users = set.NewStrings()
-	for _, u := range users {
-		ul.users.Add(u.Username)
-	}
-
-	ctrlClient := controllerclient.NewClient(conn)
-	ul.identityURL, err = ctrlClient.IdentityProviderURL()
+// checkStoredBlob checks that the blob at the path can be read from
+// the controller's storage.
+func checkStoredBlob(store storage.Storage, path string) error {
+	reader, _, err := store.Get(path)
 	if err != nil {
-		return ul, errors.Trace(err)
+		return errors.Trace(err)
 	}
-
-	return ul, nil
+	return errors.Trace(reader.Close())
 }
 
-func targetToAPIInfo(ti *coremigration.TargetInfo) *api.Info {
-	info := &api.Info{
-		Addrs:     ti.Addrs,
-		CACert:    ti.CACert,
-		Password:  ti.Password,
-		Macaroons: ti.Macaroons,
+// checkModelSerialization ensures that the exported model can be
+// serialized and read back, as it will be during the migration.
+func checkModelSerialization(model description.Model) error {
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return errors.Annotate(err, "serializing model")
 	}
-	// Only local users must be added to the api info.
-	// For external users, the tag needs to be left empty.
-	if ti.AuthTag.IsLocal() {
-		info.Tag = ti.AuthTag
+	if _, err := description.Deserialize(bytes); err != nil {
+		return errors.Annotate(err, "deserializing model")
 	}
-	return info
+	return nil
 }
 
 func grantControllerAccess(accessor ControllerAccess, targetUserTag, apiUser names.UserTag, access permission.Access) error {
@@ -1124,14 +1386,3 @@ func (o orderedBlockInfo) Less(i, j int) bool {
 func (o orderedBlockInfo) Swap(i, j int) {
 	o[i], o[j] = o[j], o[i]
 }
-
-func filterSet(s set.Strings, keep func(string) bool) set.Strings {
-	out := set.NewStrings()
-	for _, v := range s.Values() {
-		if keep(v) {
-			out.Add(v)
-		}
-	}
-
-	return out
-}
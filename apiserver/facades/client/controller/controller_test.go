@@ -30,6 +30,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	coremultiwatcher "github.com/juju/juju/core/multiwatcher"
@@ -684,6 +685,183 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestMigrationDryRun(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	blockers := []params.MigrationBlocker{{
+		Check:   "source",
+		Message: "machine 0 is dying",
+	}, {
+		Check:   "target",
+		Message: "model named \"foo\" already exists",
+	}}
+	controller.SetDryRunResult(s, blockers, nil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}, {
+			ModelTag: randomModelTag(), // Doesn't exist.
+		}},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Check(out.Results[0].ModelTag, gc.Equals, m.ModelTag().String())
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].Blockers, jc.DeepEquals, blockers)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	// A dry run never starts a migration.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestMigrationDryRunSpecError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.controller.MigrationDryRun(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			// TargetInfo missing
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "controller tag: .+ is not a valid tag")
+}
+
+func (s *controllerSuite) TestCheckMigrationCharmsArchiveMissing(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	// The factory records the charm as uploaded without storing
+	// its archive.
+	f.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+
+	err := controller.CheckMigrationCharms(st)
+	c.Assert(err, gc.ErrorMatches, `(?s)not available to transfer:
+  - charm .* for application mysql \(.*not found.*\)`)
+}
+
+func (s *controllerSuite) TestCreateMigrationPlan(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerTag := randomControllerTag()
+	start := time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
+	out, err := s.controller.CreateMigrationPlan(params.CreateMigrationPlanArgs{
+		ModelTags: []string{m.ModelTag().String()},
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag:   controllerTag,
+			ControllerAlias: "target",
+			Addrs:           []string{"1.1.1.1:1111"},
+			CACert:          "cert1",
+			AuthTag:         names.NewUserTag("admin1").String(),
+			Password:        "secret1",
+		},
+		Concurrency: 2,
+		MaxAttempts: 3,
+		WindowStart: &start,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Error, gc.IsNil)
+
+	plans, err := s.controller.MigrationPlans()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plans.Results, gc.HasLen, 1)
+	plan := plans.Results[0]
+	c.Check(plan.Id, gc.Equals, out.Result)
+	c.Check(plan.InitiatedBy, gc.Equals, "admin")
+	c.Check(plan.TargetControllerTag, gc.Equals, controllerTag)
+	c.Check(plan.TargetControllerAlias, gc.Equals, "target")
+	c.Check(plan.Concurrency, gc.Equals, 2)
+	c.Check(plan.MaxAttempts, gc.Equals, 3)
+	c.Assert(plan.WindowStart, gc.NotNil)
+	c.Check(plan.WindowStart.Equal(start), jc.IsTrue)
+	c.Check(plan.WindowEnd, gc.IsNil)
+	c.Check(plan.Completed, jc.IsFalse)
+	c.Check(plan.Models, jc.DeepEquals, []params.MigrationPlanModel{{
+		ModelTag:  m.ModelTag().String(),
+		ModelName: m.Name(),
+		Status:    "queued",
+	}})
+}
+
+func (s *controllerSuite) TestCreateMigrationPlanError(c *gc.C) {
+	out, err := s.controller.CreateMigrationPlan(params.CreateMigrationPlanArgs{
+		ModelTags: []string{randomModelTag()},
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag: randomControllerTag(),
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert1",
+			AuthTag:       names.NewUserTag("admin1").String(),
+			Password:      "secret1",
+		},
+		Concurrency: 1,
+		MaxAttempts: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out.Error, gc.ErrorMatches, `model ".+" not found`)
+}
+
+func (s *controllerSuite) TestCancelMigrationPlan(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.controller.CreateMigrationPlan(params.CreateMigrationPlanArgs{
+		ModelTags: []string{m.ModelTag().String()},
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag: randomControllerTag(),
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert1",
+			AuthTag:       names.NewUserTag("admin1").String(),
+			Password:      "secret1",
+		},
+		Concurrency: 1,
+		MaxAttempts: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Error, gc.IsNil)
+
+	result, err := s.controller.CancelMigrationPlan(params.CancelMigrationPlanArgs{Id: out.Result})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	plans, err := s.controller.MigrationPlans()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plans.Results, gc.HasLen, 1)
+	c.Check(plans.Results[0].Cancelled, jc.IsTrue)
+	c.Check(plans.Results[0].Completed, jc.IsTrue)
+	c.Check(plans.Results[0].Models[0].Status, gc.Equals, "cancelled")
+}
+
+func (s *controllerSuite) TestCancelMigrationPlanNotFound(c *gc.C) {
+	result, err := s.controller.CancelMigrationPlan(params.CancelMigrationPlanArgs{Id: "42"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.ErrorMatches, `migration plan "42" not found`)
+	c.Check(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -1244,3 +1422,62 @@ type noopLeadershipReader struct {
 func (noopLeadershipReader) Leaders() (map[string]string, error) {
 	return make(map[string]string), nil
 }
+
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	writer := state.NewAuditLogWriter(s.State, "0", time.Hour)
+	err := writer.SendRecords([]auditlog.Record{{
+		Conversation: &auditlog.Conversation{
+			Who:            "bob",
+			What:           "juju deploy mysql",
+			When:           "2023-05-01T10:00:00Z",
+			ModelName:      "default",
+			ModelUUID:      s.State.ModelUUID(),
+			ConversationID: "abc",
+			ConnectionID:   "1F",
+		},
+	}, {
+		Request: &auditlog.Request{
+			ConversationID: "abc",
+			ConnectionID:   "1F",
+			RequestID:      1,
+			When:           "2023-05-01T10:00:01Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        19,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(writer.Close(), jc.ErrorIsNil)
+
+	from := time.Date(2023, 5, 1, 10, 0, 1, 0, time.UTC)
+	result, err := s.controller.AuditLog(params.AuditLogQuery{User: "bob", From: &from})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, jc.DeepEquals, []params.AuditLogEntry{{
+		Controller:     "0",
+		ConversationID: "abc",
+		ConnectionID:   "1F",
+		When:           from,
+		Who:            "bob",
+		What:           "juju deploy mysql",
+		ModelName:      "default",
+		ModelUUID:      s.State.ModelUUID(),
+		RequestID:      1,
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        19,
+	}})
+}
+
+func (s *controllerSuite) TestAuditLogRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.LoginAccess,
+	})
+	s.context.Auth_ = apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.LatestAPI(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.AuditLog(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
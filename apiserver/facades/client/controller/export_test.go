@@ -6,6 +6,7 @@ package controller
 import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 )
 
@@ -19,10 +20,17 @@ func SetPreCheckResult(p patcher, err error) {
 	})
 }
 
+func SetDryRunResult(p patcher, blockers []params.MigrationBlocker, err error) {
+	p.PatchValue(&runMigrationDryRun, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence, map[string]string) ([]params.MigrationBlocker, error) {
+		return blockers, err
+	})
+}
+
 func NewControllerAPIForTest(backend Backend) *ControllerAPI {
 	return &ControllerAPI{state: backend}
 }
 
 var (
-	NewControllerAPIv11 = makeControllerAPIv11
+	NewControllerAPIv11  = makeControllerAPIv11
+	CheckMigrationCharms = checkMigrationCharms
 )
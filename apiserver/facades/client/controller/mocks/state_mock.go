@@ -236,6 +236,21 @@ func (mr *MockBackendMockRecorder) MongoVersion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MongoVersion", reflect.TypeOf((*MockBackend)(nil).MongoVersion))
 }
 
+// QueryAuditLog mocks base method.
+func (m *MockBackend) QueryAuditLog(arg0 state.AuditLogFilter) ([]state.AuditLogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAuditLog", arg0)
+	ret0, _ := ret[0].([]state.AuditLogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAuditLog indicates an expected call of QueryAuditLog.
+func (mr *MockBackendMockRecorder) QueryAuditLog(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAuditLog", reflect.TypeOf((*MockBackend)(nil).QueryAuditLog), arg0)
+}
+
 // RemoveAllBlocksForController mocks base method.
 func (m *MockBackend) RemoveAllBlocksForController() error {
 	m.ctrl.T.Helper()
@@ -23,11 +23,27 @@ func Register(registry facade.FacadeRegistry) {
 	}, reflect.TypeOf((*ControllerAPIv11)(nil)))
 
 	registry.MustRegister("Controller", 12, func(ctx facade.Context) (facade.Facade, error) {
-		api, err := makeControllerAPI(ctx)
+		api, err := makeControllerAPIv12(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("creating Controller facade v12: %w", err)
 		}
 		return api, nil
+	}, reflect.TypeOf((*ControllerAPIv12)(nil)))
+
+	registry.MustRegister("Controller", 13, func(ctx facade.Context) (facade.Facade, error) {
+		api, err := makeControllerAPIv13(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating Controller facade v13: %w", err)
+		}
+		return api, nil
+	}, reflect.TypeOf((*ControllerAPIv13)(nil)))
+
+	registry.MustRegister("Controller", 14, func(ctx facade.Context) (facade.Facade, error) {
+		api, err := makeControllerAPI(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating Controller facade v14: %w", err)
+		}
+		return api, nil
 	}, reflect.TypeOf((*ControllerAPI)(nil)))
 }
 
@@ -59,14 +75,38 @@ func makeControllerAPI(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// makeControllerAPIv13 creates a new ControllerAPIv13
+func makeControllerAPIv13(ctx facade.Context) (*ControllerAPIv13, error) {
+	controllerAPI, err := makeControllerAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ControllerAPIv13{
+		ControllerAPI: controllerAPI,
+	}, nil
+}
+
+// makeControllerAPIv12 creates a new ControllerAPIv12
+func makeControllerAPIv12(ctx facade.Context) (*ControllerAPIv12, error) {
+	controllerAPI, err := makeControllerAPIv13(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ControllerAPIv12{
+		ControllerAPIv13: controllerAPI,
+	}, nil
+}
+
 // makeControllerAPIv11 creates a new ControllerAPIv11
 func makeControllerAPIv11(ctx facade.Context) (*ControllerAPIv11, error) {
-	controllerAPI, err := makeControllerAPI(ctx)
+	controllerAPI, err := makeControllerAPIv12(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	return &ControllerAPIv11{
-		ControllerAPI: controllerAPI,
+		ControllerAPIv12: controllerAPI,
 	}, nil
 }
@@ -106,6 +106,21 @@ func (m *MockStorageInstance) EXPECT() *MockStorageInstanceMockRecorder {
 	return m.recorder
 }
 
+// Application mocks base method.
+func (m *MockStorageInstance) Application() (names.ApplicationTag, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Application")
+	ret0, _ := ret[0].(names.ApplicationTag)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Application indicates an expected call of Application.
+func (mr *MockStorageInstanceMockRecorder) Application() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Application", reflect.TypeOf((*MockStorageInstance)(nil).Application))
+}
+
 // Kind mocks base method.
 func (m *MockStorageInstance) Kind() state.StorageKind {
 	m.ctrl.T.Helper()
@@ -8,7 +8,9 @@ import (
 	"github.com/juju/names/v5"
 
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/state"
 )
 
 //go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/package_mock.go github.com/juju/juju/apiserver/facades/client/modelgeneration State,Model,Generation,Application,ModelCache
@@ -18,6 +20,7 @@ type State interface {
 	ControllerTag() names.ControllerTag
 	Model() (Model, error)
 	Application(string) (Application, error)
+	Charm(string) (*state.Charm, error)
 }
 
 // Model describes model state used by the model generation API.
@@ -50,6 +53,12 @@ type Generation interface {
 	Abort(string) error
 	Config() map[string]settings.ItemChanges
 	GenerationId() int
+	Rollout() (state.BranchRollout, bool)
+	SetRollout(model.RolloutPolicy) error
+	CharmURL(string) (string, bool)
+	Resources() map[string]map[string]string
+	SetCharm(string, *state.Charm, state.CharmOrigin) error
+	AttachResource(string, string, string) error
 }
 
 // Application describes application state used by the model generation API.
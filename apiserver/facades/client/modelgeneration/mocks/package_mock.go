@@ -15,7 +15,9 @@ import (
 	charm "github.com/juju/charm/v12"
 	modelgeneration "github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	cache "github.com/juju/juju/core/cache"
+	model "github.com/juju/juju/core/model"
 	settings "github.com/juju/juju/core/settings"
+	state "github.com/juju/juju/state"
 	names "github.com/juju/names/v5"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -58,6 +60,21 @@ func (mr *MockStateMockRecorder) Application(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Application", reflect.TypeOf((*MockState)(nil).Application), arg0)
 }
 
+// Charm mocks base method.
+func (m *MockState) Charm(arg0 string) (*state.Charm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Charm", arg0)
+	ret0, _ := ret[0].(*state.Charm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Charm indicates an expected call of Charm.
+func (mr *MockStateMockRecorder) Charm(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Charm", reflect.TypeOf((*MockState)(nil).Charm), arg0)
+}
+
 // ControllerTag mocks base method.
 func (m *MockState) ControllerTag() names.ControllerTag {
 	m.ctrl.T.Helper()
@@ -291,6 +308,20 @@ func (mr *MockGenerationMockRecorder) AssignedUnits() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignedUnits", reflect.TypeOf((*MockGeneration)(nil).AssignedUnits))
 }
 
+// AttachResource mocks base method.
+func (m *MockGeneration) AttachResource(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachResource", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachResource indicates an expected call of AttachResource.
+func (mr *MockGenerationMockRecorder) AttachResource(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachResource", reflect.TypeOf((*MockGeneration)(nil).AttachResource), arg0, arg1, arg2)
+}
+
 // BranchName mocks base method.
 func (m *MockGeneration) BranchName() string {
 	m.ctrl.T.Helper()
@@ -305,6 +336,21 @@ func (mr *MockGenerationMockRecorder) BranchName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchName", reflect.TypeOf((*MockGeneration)(nil).BranchName))
 }
 
+// CharmURL mocks base method.
+func (m *MockGeneration) CharmURL(arg0 string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CharmURL", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// CharmURL indicates an expected call of CharmURL.
+func (mr *MockGenerationMockRecorder) CharmURL(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CharmURL", reflect.TypeOf((*MockGeneration)(nil).CharmURL), arg0)
+}
+
 // Commit mocks base method.
 func (m *MockGeneration) Commit(arg0 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -404,6 +450,63 @@ func (mr *MockGenerationMockRecorder) GenerationId() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerationId", reflect.TypeOf((*MockGeneration)(nil).GenerationId))
 }
 
+// Resources mocks base method.
+func (m *MockGeneration) Resources() map[string]map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resources")
+	ret0, _ := ret[0].(map[string]map[string]string)
+	return ret0
+}
+
+// Resources indicates an expected call of Resources.
+func (mr *MockGenerationMockRecorder) Resources() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resources", reflect.TypeOf((*MockGeneration)(nil).Resources))
+}
+
+// Rollout mocks base method.
+func (m *MockGeneration) Rollout() (state.BranchRollout, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollout")
+	ret0, _ := ret[0].(state.BranchRollout)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Rollout indicates an expected call of Rollout.
+func (mr *MockGenerationMockRecorder) Rollout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollout", reflect.TypeOf((*MockGeneration)(nil).Rollout))
+}
+
+// SetCharm mocks base method.
+func (m *MockGeneration) SetCharm(arg0 string, arg1 *state.Charm, arg2 state.CharmOrigin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCharm", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCharm indicates an expected call of SetCharm.
+func (mr *MockGenerationMockRecorder) SetCharm(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCharm", reflect.TypeOf((*MockGeneration)(nil).SetCharm), arg0, arg1, arg2)
+}
+
+// SetRollout mocks base method.
+func (m *MockGeneration) SetRollout(arg0 model.RolloutPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRollout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRollout indicates an expected call of SetRollout.
+func (mr *MockGenerationMockRecorder) SetRollout(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRollout", reflect.TypeOf((*MockGeneration)(nil).SetRollout), arg0)
+}
+
 // MockApplication is a mock of Application interface.
 type MockApplication struct {
 	ctrl     *gomock.Controller
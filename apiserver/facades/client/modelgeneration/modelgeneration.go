@@ -11,8 +11,11 @@ import (
 	"github.com/juju/names/v5"
 
 	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/facades/client/charms"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/rpc/params"
@@ -27,6 +30,16 @@ type API struct {
 	modelCache ModelCache
 }
 
+// APIV5 provides the ModelGeneration API facade for version 5.
+type APIV5 struct {
+	*API
+}
+
+// APIV4 provides the ModelGeneration API facade for version 4.
+type APIV4 struct {
+	*APIV5
+}
+
 // NewModelGenerationAPI creates a new API endpoint for dealing with model generations.
 func NewModelGenerationAPI(
 	st State,
@@ -167,6 +180,117 @@ func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
 	return result, nil
 }
 
+// SetBranchRollout sets the policy by which the input branch is gradually
+// rolled out to the units of its applications. The rollout is carried out
+// by a controller worker, which commits the branch once every unit is
+// tracking it.
+func (api *API) SetBranchRollout(arg params.BranchRolloutArg) (params.ErrorResult, error) {
+	result := params.ErrorResult{}
+
+	if err := api.hasAdminAccess(); err != nil {
+		return result, err
+	}
+
+	branch, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		result.Error = apiservererrors.ServerError(err)
+		return result, nil
+	}
+
+	policy := model.RolloutPolicy{
+		Steps:         arg.Steps,
+		StepInterval:  arg.StepInterval,
+		WaitForActive: arg.WaitForActive,
+		AutoAbort:     arg.AutoAbort,
+	}
+	if arg.Start != nil {
+		policy.Start = *arg.Start
+	}
+	result.Error = apiservererrors.ServerError(branch.SetRollout(policy))
+	return result, nil
+}
+
+// SetBranchRollout isn't on the v4 API.
+func (api *APIV4) SetBranchRollout(_, _ struct{}) {}
+
+// SetBranchCharm upgrades an application to the input charm under the
+// input branch, along with any pending resources supplied. Only units
+// tracking the branch run the new charm until the branch is committed.
+func (api *API) SetBranchCharm(arg params.BranchCharmArg) (params.ErrorResult, error) {
+	result := params.ErrorResult{}
+
+	if err := api.hasAdminAccess(); err != nil {
+		return result, err
+	}
+
+	result.Error = apiservererrors.ServerError(api.setBranchCharm(arg))
+	return result, nil
+}
+
+func (api *API) setBranchCharm(arg params.BranchCharmArg) error {
+	if err := common.ValidateCharmOrigin(arg.CharmOrigin); err != nil {
+		return errors.Trace(err)
+	}
+	origin, err := charms.ConvertParamsOrigin(*arg.CharmOrigin)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stateOrigin, err := application.StateCharmOrigin(origin)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	branch, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, err := api.st.Charm(arg.CharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := branch.SetCharm(arg.ApplicationName, ch, *stateOrigin); err != nil {
+		return errors.Trace(err)
+	}
+
+	resourceNames := set.NewStrings()
+	for name := range arg.ResourceIDs {
+		resourceNames.Add(name)
+	}
+	for _, name := range resourceNames.SortedValues() {
+		if err := branch.AttachResource(arg.ApplicationName, name, arg.ResourceIDs[name]); err != nil {
+			return errors.Annotatef(err, "attaching resource %q", name)
+		}
+	}
+	return nil
+}
+
+// SetBranchCharm isn't on the v5 API.
+func (api *APIV5) SetBranchCharm(_, _ struct{}) {}
+
+// AttachBranchResource attaches a pending resource to an application under
+// the input branch. Only units tracking the branch use the resource until
+// the branch is committed.
+func (api *API) AttachBranchResource(arg params.BranchResourceArg) (params.ErrorResult, error) {
+	result := params.ErrorResult{}
+
+	if err := api.hasAdminAccess(); err != nil {
+		return result, err
+	}
+
+	branch, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		result.Error = apiservererrors.ServerError(err)
+		return result, nil
+	}
+
+	result.Error = apiservererrors.ServerError(
+		branch.AttachResource(arg.ApplicationName, arg.ResourceName, arg.PendingID))
+	return result, nil
+}
+
+// AttachBranchResource isn't on the v5 API.
+func (api *APIV5) AttachBranchResource(_, _ struct{}) {}
+
 // BranchInfo will return details of branch identified by the input argument,
 // including units on the branch and the configuration disjoint with the
 // master generation.
@@ -293,9 +417,8 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 		}
 		branchApp.ConfigChanges = deltas[appName].EffectiveChanges(defaults)
 
-		// TODO (manadart 2019-04-12): Charm URL.
-
-		// TODO (manadart 2019-04-12): Resources.
+		branchApp.CharmURL, _ = branch.CharmURL(appName)
+		branchApp.Resources = branch.Resources()[appName]
 
 		// Only include unit names if detailed info was requested.
 		if detailed {
@@ -312,9 +435,31 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 		Created:      branch.Created(),
 		CreatedBy:    branch.CreatedBy(),
 		Applications: apps,
+		Rollout:      branchRollout(branch),
 	}, nil
 }
 
+func branchRollout(branch Generation) *params.BranchRollout {
+	rollout, ok := branch.Rollout()
+	if !ok {
+		return nil
+	}
+	result := &params.BranchRollout{
+		Steps:         rollout.Steps,
+		StepInterval:  rollout.StepInterval,
+		WaitForActive: rollout.WaitForActive,
+		AutoAbort:     rollout.AutoAbort,
+		Status:        string(rollout.Status),
+		Step:          rollout.Step,
+		Message:       rollout.Message,
+	}
+	if !rollout.Start.IsZero() {
+		start := rollout.Start
+		result.Start = &start
+	}
+	return result
+}
+
 func (api *API) getGenerationCommit(branch Generation) (params.Generation, error) {
 	generation, err := api.oneBranchInfo(branch, true)
 	if err != nil {
@@ -328,6 +473,7 @@ func (api *API) getGenerationCommit(branch Generation) (params.Generation, error
 		Created:      branch.Created(),
 		CreatedBy:    branch.CreatedBy(),
 		Applications: generation.Applications,
+		Rollout:      generation.Rollout,
 	}, nil
 }
 
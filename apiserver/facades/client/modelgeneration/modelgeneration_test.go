@@ -4,6 +4,8 @@
 package modelgeneration_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
@@ -17,6 +19,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
 )
 
 type modelGenerationSuite struct {
@@ -146,6 +149,117 @@ func (s *modelGenerationSuite) TestAbortBranchSuccess(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
 }
 
+func (s *modelGenerationSuite) TestSetBranchRolloutSuccess(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	start := time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
+	s.mockGen.EXPECT().SetRollout(model.RolloutPolicy{
+		Steps:         []int{10, 50, 100},
+		Start:         start,
+		StepInterval:  time.Hour,
+		WaitForActive: true,
+		AutoAbort:     true,
+	}).Return(nil)
+	s.expectBranch()
+
+	result, err := s.api.SetBranchRollout(params.BranchRolloutArg{
+		BranchName:    s.newBranchName,
+		Steps:         []int{10, 50, 100},
+		Start:         &start,
+		StepInterval:  time.Hour,
+		WaitForActive: true,
+		AutoAbort:     true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
+}
+
+func (s *modelGenerationSuite) TestSetBranchRolloutError(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.mockGen.EXPECT().SetRollout(model.RolloutPolicy{
+		Steps: []int{50},
+	}).Return(errors.NotValidf("rollout steps [50] ending before 100"))
+	s.expectBranch()
+
+	result, err := s.api.SetBranchRollout(params.BranchRolloutArg{
+		BranchName: s.newBranchName,
+		Steps:      []int{50},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `rollout steps \[50\] ending before 100 not valid`)
+}
+
+func (s *modelGenerationSuite) TestSetBranchCharmSuccess(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockState.EXPECT().Charm("ch:redis-2").Return(nil, nil)
+	s.mockGen.EXPECT().SetCharm("redis", gomock.Nil(), gomock.Any()).DoAndReturn(
+		func(_ string, _ *state.Charm, origin state.CharmOrigin) error {
+			c.Check(origin.Source, gc.Equals, "charm-hub")
+			c.Check(origin.Revision, gc.DeepEquals, intPtr(2))
+			return nil
+		})
+	s.mockGen.EXPECT().AttachResource("redis", "store", "pending-id").Return(nil)
+
+	result, err := s.api.SetBranchCharm(params.BranchCharmArg{
+		BranchName:      s.newBranchName,
+		ApplicationName: "redis",
+		CharmURL:        "ch:redis-2",
+		CharmOrigin: &params.CharmOrigin{
+			Source:   "charm-hub",
+			ID:       "charm-id",
+			Hash:     "charm-hash",
+			Revision: intPtr(2),
+			Base:     params.Base{Name: "ubuntu", Channel: "22.04"},
+		},
+		ResourceIDs: map[string]string{"store": "pending-id"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
+}
+
+func (s *modelGenerationSuite) TestSetBranchCharmNoOrigin(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+
+	result, err := s.api.SetBranchCharm(params.BranchCharmArg{
+		BranchName:      s.newBranchName,
+		ApplicationName: "redis",
+		CharmURL:        "ch:redis-2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "charm origin source required")
+}
+
+func (s *modelGenerationSuite) TestAttachBranchResourceSuccess(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().AttachResource("redis", "store", "pending-id").Return(nil)
+
+	result, err := s.api.AttachBranchResource(params.BranchResourceArg{
+		BranchName:      s.newBranchName,
+		ApplicationName: "redis",
+		ResourceName:    "store",
+		PendingID:       "pending-id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
+}
+
+func (s *modelGenerationSuite) TestAttachBranchResourceError(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().AttachResource("redis", "store", "pending-id").Return(
+		errors.NotFoundf("pending resource \"redis/store\" (pending-id)"))
+
+	result, err := s.api.AttachBranchResource(params.BranchResourceArg{
+		BranchName:      s.newBranchName,
+		ApplicationName: "redis",
+		ResourceName:    "store",
+		PendingID:       "pending-id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `pending resource "redis/store" \(pending-id\) not found`)
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranchTrue(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectHasActiveBranch(nil)
@@ -185,6 +299,8 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	s.expectAssignedUnits(units[:2])
 	s.expectCreated()
 	s.expectCreatedBy()
+	s.expectRollout()
+	s.expectCharmChanges()
 
 	// Flex the code path based on whether we are getting all branches
 	// or a sub-set.
@@ -209,6 +325,12 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	c.Assert(gen.Created, gc.Equals, int64(666))
 	c.Assert(gen.CreatedBy, gc.Equals, s.apiUser)
 	c.Assert(gen.Applications, gc.HasLen, 1)
+	c.Check(gen.Rollout, gc.DeepEquals, &params.BranchRollout{
+		Steps:        []int{50, 100},
+		StepInterval: time.Hour,
+		Status:       "running",
+		Step:         1,
+	})
 
 	genApp := gen.Applications[0]
 	c.Check(genApp.ApplicationName, gc.Equals, "redis")
@@ -218,6 +340,8 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 		"databases": 16,
 		"port":      8000,
 	})
+	c.Check(genApp.CharmURL, gc.Equals, "ch:redis-2")
+	c.Check(genApp.Resources, gc.DeepEquals, map[string]string{"store": "pending-id"})
 
 	// Unit lists are only populated when detailed is true.
 	if detailed {
@@ -306,6 +430,25 @@ func (s *modelGenerationSuite) expectCreatedBy() {
 	s.mockGen.EXPECT().CreatedBy().Return(s.apiUser)
 }
 
+func (s *modelGenerationSuite) expectRollout() {
+	s.mockGen.EXPECT().Rollout().Return(state.BranchRollout{
+		RolloutPolicy: model.RolloutPolicy{
+			Steps:        []int{50, 100},
+			StepInterval: time.Hour,
+		},
+		Status:   model.RolloutRunning,
+		Step:     1,
+		LastStep: time.Now(),
+	}, true)
+}
+
+func (s *modelGenerationSuite) expectCharmChanges() {
+	s.mockGen.EXPECT().CharmURL("redis").Return("ch:redis-2", true)
+	s.mockGen.EXPECT().Resources().Return(map[string]map[string]string{
+		"redis": {"store": "pending-id"},
+	})
+}
+
 func (s *modelGenerationSuite) expectConfig() {
 	s.mockGen.EXPECT().Config().Return(map[string]settings.ItemChanges{"redis": {
 		settings.MakeAddition("password", "added-pass"),
@@ -324,3 +467,7 @@ func (s *modelGenerationSuite) setupMockApp(ctrl *gomock.Controller, units []str
 
 	s.mockState.EXPECT().Application("redis").Return(mockApp, nil)
 }
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -15,11 +15,35 @@ import (
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("ModelGeneration", 4, func(ctx facade.Context) (facade.Facade, error) {
 		return newModelGenerationFacadeV4(ctx)
+	}, reflect.TypeOf((*APIV4)(nil)))
+	registry.MustRegister("ModelGeneration", 5, func(ctx facade.Context) (facade.Facade, error) {
+		return newModelGenerationFacadeV5(ctx)
+	}, reflect.TypeOf((*APIV5)(nil)))
+	registry.MustRegister("ModelGeneration", 6, func(ctx facade.Context) (facade.Facade, error) {
+		return newModelGenerationFacadeV6(ctx)
 	}, reflect.TypeOf((*API)(nil)))
 }
 
 // newModelGenerationFacadeV4 provides the signature required for facade registration.
-func newModelGenerationFacadeV4(ctx facade.Context) (*API, error) {
+func newModelGenerationFacadeV4(ctx facade.Context) (*APIV4, error) {
+	api, err := newModelGenerationFacadeV5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV4{APIV5: api}, nil
+}
+
+// newModelGenerationFacadeV5 provides the signature required for facade registration.
+func newModelGenerationFacadeV5(ctx facade.Context) (*APIV5, error) {
+	api, err := newModelGenerationFacadeV6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV5{API: api}, nil
+}
+
+// newModelGenerationFacadeV6 provides the signature required for facade registration.
+func newModelGenerationFacadeV6(ctx facade.Context) (*API, error) {
 	authorizer := ctx.Auth()
 	st := &stateShim{State: ctx.State()}
 	m, err := st.Model()
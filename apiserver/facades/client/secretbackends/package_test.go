@@ -11,6 +11,7 @@ import (
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/controller"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -28,6 +29,7 @@ func NewTestAPI(
 	statePool StatePool,
 	authorizer facade.Authorizer,
 	clock clock.Clock,
+	controllerConfig controller.Config,
 ) (*SecretBackendsAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
@@ -40,5 +42,8 @@ func NewTestAPI(
 		statePool:      statePool,
 		backendState:   backendState,
 		secretState:    secretState,
+		controllerConfig: func() (controller.Config, error) {
+			return controllerConfig, nil
+		},
 	}, nil
 }
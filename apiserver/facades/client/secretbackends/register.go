@@ -27,11 +27,12 @@ func newSecretBackendsAPI(context facade.Context) (*SecretBackendsAPI, error) {
 	}
 
 	return &SecretBackendsAPI{
-		authorizer:     context.Auth(),
-		controllerUUID: context.State().ControllerUUID(),
-		clock:          clock.WallClock,
-		backendState:   state.NewSecretBackends(context.State()),
-		secretState:    state.NewSecrets(context.State()),
-		statePool:      &statePoolShim{context.StatePool()},
+		authorizer:       context.Auth(),
+		controllerUUID:   context.State().ControllerUUID(),
+		clock:            clock.WallClock,
+		backendState:     state.NewSecretBackends(context.State()),
+		secretState:      state.NewSecrets(context.State()),
+		statePool:        &statePoolShim{context.StatePool()},
+		controllerConfig: context.State().ControllerConfig,
 	}, nil
 }
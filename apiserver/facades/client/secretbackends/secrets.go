@@ -13,6 +13,7 @@ import (
 	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/rpc/params"
@@ -27,10 +28,11 @@ type SecretBackendsAPI struct {
 	authorizer     facade.Authorizer
 	controllerUUID string
 
-	clock        clock.Clock
-	backendState SecretsBackendState
-	secretState  SecretsState
-	statePool    StatePool
+	clock            clock.Clock
+	backendState     SecretsBackendState
+	secretState      SecretsState
+	statePool        StatePool
+	controllerConfig func() (controller.Config, error)
 }
 
 func (s *SecretBackendsAPI) checkCanAdmin() error {
@@ -93,6 +95,15 @@ func (s *SecretBackendsAPI) createBackend(id string, arg params.SecretBackend) e
 			return errors.Trace(err)
 		}
 	}
+	if secretConfig, ok := p.(provider.ControllerSecretConfig); ok {
+		controllerCfg, err := s.controllerConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := secretConfig.ControllerSecretConfig(controllerCfg); err != nil {
+			return errors.Annotatef(err, "invalid controller config for provider %q", arg.BackendType)
+		}
+	}
 	_, err = s.backendState.CreateSecretBackend(state.CreateSecretBackendParams{
 		ID:                  id,
 		Name:                arg.Name,
@@ -24,6 +24,7 @@ import (
 	facademocks "github.com/juju/juju/apiserver/facade/mocks"
 	"github.com/juju/juju/apiserver/facades/client/secretbackends"
 	"github.com/juju/juju/apiserver/facades/client/secretbackends/mocks"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/rpc/params"
@@ -40,6 +41,8 @@ type SecretsSuite struct {
 	backendState *mocks.MockSecretsBackendState
 	secretsState *mocks.MockSecretsState
 	statePool    *mocks.MockStatePool
+
+	controllerConfig controller.Config
 }
 
 var _ = gc.Suite(&SecretsSuite{})
@@ -57,6 +60,7 @@ func (s *SecretsSuite) setup(c *gc.C) *gomock.Controller {
 	s.statePool = mocks.NewMockStatePool(ctrl)
 
 	s.clock = testclock.NewClock(time.Now())
+	s.controllerConfig = coretesting.FakeControllerConfig()
 
 	return ctrl
 }
@@ -141,7 +145,7 @@ func (s *SecretsSuite) assertListSecretBackends(c *gc.C, modelType state.ModelTy
 		}, nil
 	})
 
-	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock)
+	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock, s.controllerConfig)
 	c.Assert(err, jc.ErrorIsNil)
 
 	uuid := coretesting.ModelTag.Id()
@@ -237,7 +241,7 @@ func (s *SecretsSuite) TestListSecretBackendsPermissionDeniedReveal(c *gc.C) {
 	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(
 		errors.WithType(apiservererrors.ErrPerm, authentication.ErrorEntityMissingPermission))
 
-	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock)
+	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock, s.controllerConfig)
 	c.Assert(err, jc.ErrorIsNil)
 
 	_, err = facade.ListSecretBackends(params.ListSecretBackendsArgs{Reveal: true})
@@ -251,7 +255,7 @@ func (s *SecretsSuite) TestAddSecretBackends(c *gc.C) {
 	s.expectAuthClient()
 	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
 
-	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock)
+	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock, s.controllerConfig)
 	c.Assert(err, jc.ErrorIsNil)
 
 	p := mocks.NewMockSecretBackendProvider(ctrl)
@@ -323,6 +327,54 @@ func (s *SecretsSuite) TestAddSecretBackends(c *gc.C) {
 	})
 }
 
+func (s *SecretsSuite) assertAddFileSecretBackend(c *gc.C, controllerCfg controller.Config) error {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectAuthClient()
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
+
+	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock, controllerCfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	path := c.MkDir()
+	if controllerCfg.SecretBackendEncryptionKey() != "" {
+		s.backendState.EXPECT().CreateSecretBackend(state.CreateSecretBackendParams{
+			Name:        "myfile",
+			BackendType: "file",
+			Config:      map[string]interface{}{"path": path},
+		}).Return("backend-id", nil)
+	}
+
+	results, err := facade.AddSecretBackends(params.AddSecretBackendArgs{
+		Args: []params.AddSecretBackendArg{{
+			SecretBackend: params.SecretBackend{
+				Name:        "myfile",
+				BackendType: "file",
+				Config:      map[string]interface{}{"path": path},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	if results.Results[0].Error == nil {
+		return nil
+	}
+	return results.Results[0].Error
+}
+
+func (s *SecretsSuite) TestAddFileSecretBackend(c *gc.C) {
+	cfg := coretesting.FakeControllerConfig()
+	cfg[controller.SecretBackendEncryptionKey] = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	err := s.assertAddFileSecretBackend(c, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) TestAddFileSecretBackendMissingEncryptionKey(c *gc.C) {
+	err := s.assertAddFileSecretBackend(c, coretesting.FakeControllerConfig())
+	c.Assert(err, gc.ErrorMatches, `invalid controller config for provider "file": missing controller config "secret-backend-encryption-key" not valid`)
+}
+
 func (s *SecretsSuite) TestAddSecretBackendsPermissionDenied(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -330,7 +382,7 @@ func (s *SecretsSuite) TestAddSecretBackendsPermissionDenied(c *gc.C) {
 	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(
 		errors.WithType(apiservererrors.ErrPerm, authentication.ErrorEntityMissingPermission))
 
-	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock)
+	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock, s.controllerConfig)
 	c.Assert(err, jc.ErrorIsNil)
 
 	_, err = facade.AddSecretBackends(params.AddSecretBackendArgs{})
@@ -343,7 +395,7 @@ func (s *SecretsSuite) TestRemoveSecretBackends(c *gc.C) {
 	s.expectAuthClient()
 	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
 
-	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock)
+	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock, s.controllerConfig)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.backendState.EXPECT().DeleteSecretBackend("myvault", true).Return(nil)
@@ -373,7 +425,7 @@ func (s *SecretsSuite) TestRemoveSecretBackendsPermissionDenied(c *gc.C) {
 	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(
 		errors.WithType(apiservererrors.ErrPerm, authentication.ErrorEntityMissingPermission))
 
-	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock)
+	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock, s.controllerConfig)
 	c.Assert(err, jc.ErrorIsNil)
 
 	_, err = facade.RemoveSecretBackends(params.RemoveSecretBackendArgs{})
@@ -387,7 +439,7 @@ func (s *SecretsSuite) TestUpdateSecretBackends(c *gc.C) {
 	s.expectAuthClient()
 	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
 
-	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock)
+	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock, s.controllerConfig)
 	c.Assert(err, jc.ErrorIsNil)
 
 	p := mocks.NewMockSecretBackendProvider(ctrl)
@@ -451,7 +503,7 @@ func (s *SecretsSuite) TestUpdateSecretBackendsPermissionDenied(c *gc.C) {
 	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(
 		errors.WithType(apiservererrors.ErrPerm, authentication.ErrorEntityMissingPermission))
 
-	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock)
+	facade, err := secretbackends.NewTestAPI(s.backendState, s.secretsState, s.statePool, s.authorizer, s.clock, s.controllerConfig)
 	c.Assert(err, jc.ErrorIsNil)
 
 	_, err = facade.UpdateSecretBackends(params.UpdateSecretBackendArgs{})
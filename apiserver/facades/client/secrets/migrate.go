@@ -0,0 +1,205 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// MigrateSecrets isn't on the v2 API.
+func (s *SecretsAPIV2) MigrateSecrets(_ struct{}) {}
+
+// MigrateSecrets copies the content of secret revisions stored in one
+// backend to another, verifies the copy, and then updates each revision
+// to refer to the new backend before removing the original content.
+//
+// Revisions are attempted in a fixed order, at most arg.Limit of them
+// per call, so that clients can report progress. The returned cursor is
+// passed as arg.After to carry on with the revisions after those already
+// attempted; revisions which failed are retried by starting again
+// without a cursor.
+//
+// Each revision is switched to its new content in a single transaction,
+// so it always refers to complete content in one backend or the other.
+// The migration as a whole is not atomic: until every revision has been
+// migrated, the model's secret content is split between both backends.
+func (s *SecretsAPI) MigrateSecrets(arg params.MigrateSecretsArg) (params.MigrateSecretsResult, error) {
+	var result params.MigrateSecretsResult
+	if err := s.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if arg.Limit < 0 {
+		return result, errors.NotValidf("negative limit %d", arg.Limit)
+	}
+	fromID, err := s.backendIDGetter(arg.FromBackend)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	toID, err := s.backendIDGetter(arg.ToBackend)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if fromID == toID {
+		return result, errors.NotValidf("migrating secrets from backend %q to itself", arg.FromBackend)
+	}
+	if err := s.getBackendInfo(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if toID != s.controllerUUID {
+		if _, ok := s.backends[toID]; !ok {
+			return result, errors.NotFoundf("secret backend %q", arg.ToBackend)
+		}
+	}
+
+	pending, err := s.revisionsInBackend(fromID)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var batch []secretRevision
+	for _, rev := range pending {
+		if rev.cursor() > arg.After {
+			batch = append(batch, rev)
+		}
+	}
+	if arg.Limit > 0 && len(batch) > arg.Limit {
+		batch = batch[:arg.Limit]
+		result.Next = batch[arg.Limit-1].cursor()
+	}
+	for _, rev := range batch {
+		if err := s.migrateRevision(rev.uri, rev.revision, toID); err != nil {
+			logger.Warningf("cannot migrate secret %s revision %d: %v", rev.uri, rev.revision, err)
+			result.Failed = append(result.Failed, params.SecretRevisionError{
+				URI:      rev.uri.String(),
+				Revision: rev.revision,
+				Error:    apiservererrors.ServerError(err),
+			})
+			continue
+		}
+		result.Migrated++
+	}
+	result.Remaining = len(pending) - result.Migrated
+	return result, nil
+}
+
+type secretRevision struct {
+	uri      *coresecrets.URI
+	revision int
+}
+
+// cursor returns a key for the revision which sorts in the order the
+// revisions are migrated.
+func (r secretRevision) cursor() string {
+	return fmt.Sprintf("%s/%010d", r.uri.ID, r.revision)
+}
+
+// revisionsInBackend returns all the model's secret revisions whose
+// content is stored in the specified backend, in migration order.
+func (s *SecretsAPI) revisionsInBackend(backendID string) ([]secretRevision, error) {
+	metadata, err := s.secretsState.ListSecrets(state.SecretsFilter{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []secretRevision
+	for _, md := range metadata {
+		revs, err := s.secretsState.ListSecretRevisions(md.URI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, rev := range revs {
+			revBackendID := s.controllerUUID
+			if rev.ValueRef != nil {
+				revBackendID = rev.ValueRef.BackendID
+			}
+			if revBackendID == backendID {
+				result = append(result, secretRevision{uri: md.URI, revision: rev.Revision})
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].cursor() < result[j].cursor()
+	})
+	return result, nil
+}
+
+func (s *SecretsAPI) migrateRevision(uri *coresecrets.URI, revision int, toID string) (errOut error) {
+	ctx := context.TODO()
+	val, oldRef, err := s.secretsState.GetSecretValue(uri, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if oldRef != nil {
+		oldBackend, ok := s.backends[oldRef.BackendID]
+		if !ok {
+			return errors.NotFoundf("secret backend %q", oldRef.BackendID)
+		}
+		if val, err = oldBackend.GetContent(ctx, oldRef.RevisionID); err != nil {
+			return errors.Annotate(err, "reading content")
+		}
+	}
+	checksum, err := val.Checksum()
+	if err != nil {
+		return errors.Annotate(err, "calculating checksum")
+	}
+
+	arg := state.ChangeSecretBackendParams{
+		Token:    successfulToken{},
+		URI:      uri,
+		Revision: revision,
+	}
+	if toID == s.controllerUUID {
+		arg.Data = val.EncodedValues()
+	} else {
+		newBackend := s.backends[toID]
+		revID, err := newBackend.SaveContent(ctx, uri, revision, val)
+		if err != nil {
+			return errors.Annotate(err, "saving content")
+		}
+		defer func() {
+			if errOut == nil {
+				return
+			}
+			if err := newBackend.DeleteContent(ctx, revID); err != nil && !errors.Is(err, errors.NotFound) {
+				logger.Errorf("cannot clean up content %q for secret %s: %v", revID, uri, err)
+			}
+		}()
+
+		// Read back what was saved before anything refers to it.
+		saved, err := newBackend.GetContent(ctx, revID)
+		if err != nil {
+			return errors.Annotate(err, "verifying content")
+		}
+		savedChecksum, err := saved.Checksum()
+		if err != nil {
+			return errors.Annotate(err, "verifying content")
+		}
+		if savedChecksum != checksum {
+			return errors.Errorf("verifying content: checksum mismatch")
+		}
+		arg.ValueRef = &coresecrets.ValueRef{
+			BackendID:  toID,
+			RevisionID: revID,
+		}
+	}
+	if err := s.secretsState.ChangeSecretBackend(arg); err != nil {
+		return errors.Annotate(err, "updating secret backend")
+	}
+
+	if oldRef != nil {
+		if err := s.backends[oldRef.BackendID].DeleteContent(ctx, oldRef.RevisionID); err != nil && !errors.Is(err, errors.NotFound) {
+			// The revision now refers to the new content, so this is
+			// not fatal; the old content is merely orphaned.
+			logger.Warningf("cannot delete migrated content %q for secret %s: %v", oldRef.RevisionID, uri, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	apisecrets "github.com/juju/juju/apiserver/facades/client/secrets"
+	"github.com/juju/juju/core/permission"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func backendIDGetter(name string) (string, error) {
+	switch name {
+	case "internal":
+		return coretesting.ControllerTag.Id(), nil
+	case "active":
+		return "backend-id", nil
+	case "other":
+		return "other-backend-id", nil
+	}
+	return "", errors.NotFoundf("secret backend %q", name)
+}
+
+func (s *SecretsSuite) newMigrateAPI(c *gc.C) *apisecrets.SecretsAPI {
+	facade, err := apisecrets.NewTestAPI(s.authTag, s.authorizer, s.secretsState, s.secretConsumer,
+		adminBackendConfigGetter, backendConfigGetterForUserSecretsWrite(c),
+		func(cfg *provider.ModelBackendConfig) (provider.SecretsBackend, error) {
+			if cfg.BackendType == "active-type" {
+				return s.backend, nil
+			}
+			return s.secretsBackend, nil
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	apisecrets.SetBackendIDGetter(facade, backendIDGetter)
+	return facade
+}
+
+func (s *SecretsSuite) expectModelAdmin() {
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
+}
+
+func (s *SecretsSuite) TestMigrateSecretsExternal(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAuthClient()
+	s.expectModelAdmin()
+
+	uri := coresecrets.NewURI()
+	s.secretsState.EXPECT().ListSecrets(state.SecretsFilter{}).Return([]*coresecrets.SecretMetadata{{URI: uri}}, nil)
+	s.secretsState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{{
+		Revision: 1,
+		ValueRef: &coresecrets.ValueRef{BackendID: "other-backend-id", RevisionID: "rev-1"},
+	}, {
+		Revision: 2,
+	}}, nil)
+
+	val := coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	s.secretsState.EXPECT().GetSecretValue(uri, 1).Return(nil, &coresecrets.ValueRef{
+		BackendID: "other-backend-id", RevisionID: "rev-1",
+	}, nil)
+	s.secretsBackend.EXPECT().GetContent(gomock.Any(), "rev-1").Return(val, nil)
+	s.backend.EXPECT().SaveContent(gomock.Any(), uri, 1, val).Return("new-rev-1", nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "new-rev-1").Return(val, nil)
+	s.secretsState.EXPECT().ChangeSecretBackend(gomock.Any()).DoAndReturn(func(arg state.ChangeSecretBackendParams) error {
+		c.Check(arg.URI, jc.DeepEquals, uri)
+		c.Check(arg.Revision, gc.Equals, 1)
+		c.Check(arg.ValueRef, jc.DeepEquals, &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "new-rev-1"})
+		c.Check(arg.Data, gc.HasLen, 0)
+		return nil
+	})
+	s.secretsBackend.EXPECT().DeleteContent(gomock.Any(), "rev-1").Return(nil)
+
+	result, err := s.newMigrateAPI(c).MigrateSecrets(params.MigrateSecretsArg{
+		FromBackend: "other",
+		ToBackend:   "active",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MigrateSecretsResult{Migrated: 1})
+}
+
+func (s *SecretsSuite) TestMigrateSecretsToInternal(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAuthClient()
+	s.expectModelAdmin()
+
+	uri := coresecrets.NewURI()
+	s.secretsState.EXPECT().ListSecrets(state.SecretsFilter{}).Return([]*coresecrets.SecretMetadata{{URI: uri}}, nil)
+	s.secretsState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{{
+		Revision: 1,
+		ValueRef: &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "rev-1"},
+	}}, nil)
+
+	val := coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	s.secretsState.EXPECT().GetSecretValue(uri, 1).Return(nil, &coresecrets.ValueRef{
+		BackendID: "backend-id", RevisionID: "rev-1",
+	}, nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "rev-1").Return(val, nil)
+	s.secretsState.EXPECT().ChangeSecretBackend(gomock.Any()).DoAndReturn(func(arg state.ChangeSecretBackendParams) error {
+		c.Check(arg.ValueRef, gc.IsNil)
+		c.Check(arg.Data, jc.DeepEquals, coresecrets.SecretData{"foo": "YmFy"})
+		return nil
+	})
+	s.backend.EXPECT().DeleteContent(gomock.Any(), "rev-1").Return(nil)
+
+	result, err := s.newMigrateAPI(c).MigrateSecrets(params.MigrateSecretsArg{
+		FromBackend: "active",
+		ToBackend:   "internal",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MigrateSecretsResult{Migrated: 1})
+}
+
+func (s *SecretsSuite) TestMigrateSecretsLimitAndFailure(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAuthClient()
+	s.expectModelAdmin()
+
+	uri := coresecrets.NewURI()
+	s.secretsState.EXPECT().ListSecrets(state.SecretsFilter{}).Return([]*coresecrets.SecretMetadata{{URI: uri}}, nil).Times(2)
+	s.secretsState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{
+		{Revision: 3}, {Revision: 1}, {Revision: 2},
+	}, nil)
+
+	val := coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	other := coresecrets.NewSecretValue(map[string]string{"foo": "YmF6"})
+	s.secretsState.EXPECT().GetSecretValue(uri, 1).Return(val, nil, nil)
+	s.backend.EXPECT().SaveContent(gomock.Any(), uri, 1, val).Return("new-rev-1", nil)
+	// The saved content doesn't match, so it is removed again.
+	s.backend.EXPECT().GetContent(gomock.Any(), "new-rev-1").Return(other, nil)
+	s.backend.EXPECT().DeleteContent(gomock.Any(), "new-rev-1").Return(nil)
+
+	s.secretsState.EXPECT().GetSecretValue(uri, 2).Return(val, nil, nil)
+	s.backend.EXPECT().SaveContent(gomock.Any(), uri, 2, val).Return("new-rev-2", nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "new-rev-2").Return(val, nil)
+	s.secretsState.EXPECT().ChangeSecretBackend(gomock.Any()).Return(nil)
+
+	api := s.newMigrateAPI(c)
+	result, err := api.MigrateSecrets(params.MigrateSecretsArg{
+		FromBackend: "internal",
+		ToBackend:   "active",
+		Limit:       2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Next, gc.Not(gc.Equals), "")
+	next := result.Next
+	result.Next = ""
+	// The failure counts towards the limit.
+	c.Assert(result, jc.DeepEquals, params.MigrateSecretsResult{
+		Migrated:  1,
+		Remaining: 2,
+		Failed: []params.SecretRevisionError{{
+			URI:      uri.String(),
+			Revision: 1,
+			Error:    &params.Error{Message: "verifying content: checksum mismatch"},
+		}},
+	})
+
+	// Carrying on from the cursor doesn't attempt the failed revision
+	// again.
+	s.expectModelAdmin()
+	s.secretsState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{
+		{Revision: 3}, {Revision: 1},
+	}, nil)
+	s.secretsState.EXPECT().GetSecretValue(uri, 3).Return(val, nil, nil)
+	s.backend.EXPECT().SaveContent(gomock.Any(), uri, 3, val).Return("new-rev-3", nil)
+	s.backend.EXPECT().GetContent(gomock.Any(), "new-rev-3").Return(val, nil)
+	s.secretsState.EXPECT().ChangeSecretBackend(gomock.Any()).Return(nil)
+
+	result, err = api.MigrateSecrets(params.MigrateSecretsArg{
+		FromBackend: "internal",
+		ToBackend:   "active",
+		Limit:       2,
+		After:       next,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MigrateSecretsResult{
+		Migrated:  1,
+		Remaining: 1,
+	})
+}
+
+func (s *SecretsSuite) TestMigrateSecretsSameBackend(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAuthClient()
+	s.expectModelAdmin()
+
+	_, err := s.newMigrateAPI(c).MigrateSecrets(params.MigrateSecretsArg{
+		FromBackend: "active",
+		ToBackend:   "active",
+	})
+	c.Assert(err, gc.ErrorMatches, `migrating secrets from backend "active" to itself not valid`)
+}
+
+func (s *SecretsSuite) TestMigrateSecretsUnknownBackend(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAuthClient()
+	s.expectModelAdmin()
+
+	_, err := s.newMigrateAPI(c).MigrateSecrets(params.MigrateSecretsArg{
+		FromBackend: "active",
+		ToBackend:   "missing",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestMigrateSecretsPermissionDenied(c *gc.C) {
+	defer s.setup(c).Finish()
+	s.expectAuthClient()
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(apiservererrors.ErrPerm)
+
+	_, err := s.newMigrateAPI(c).MigrateSecrets(params.MigrateSecretsArg{
+		FromBackend: "active",
+		ToBackend:   "other",
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -47,3 +47,8 @@ func NewTestAPI(
 		backendGetter:                          backendGetter,
 	}, nil
 }
+
+// SetBackendIDGetter sets the func used to look up secret backend IDs.
+func SetBackendIDGetter(api *SecretsAPI, getter func(name string) (string, error)) {
+	api.backendIDGetter = getter
+}
@@ -12,6 +12,8 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/secrets/provider/juju"
+	"github.com/juju/juju/secrets/provider/kubernetes"
 	"github.com/juju/juju/state"
 )
 
@@ -21,16 +23,27 @@ func Register(registry facade.FacadeRegistry) {
 		return newSecretsAPIV1(ctx)
 	}, reflect.TypeOf((*SecretsAPI)(nil)))
 	registry.MustRegister("Secrets", 2, func(ctx facade.Context) (facade.Facade, error) {
+		return newSecretsAPIV2(ctx)
+	}, reflect.TypeOf((*SecretsAPIV2)(nil)))
+	registry.MustRegister("Secrets", 3, func(ctx facade.Context) (facade.Facade, error) {
 		return newSecretsAPI(ctx)
 	}, reflect.TypeOf((*SecretsAPI)(nil)))
 }
 
 func newSecretsAPIV1(context facade.Context) (*SecretsAPIV1, error) {
+	api, err := newSecretsAPIV2(context)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SecretsAPIV1{SecretsAPIV2: api}, nil
+}
+
+func newSecretsAPIV2(context facade.Context) (*SecretsAPIV2, error) {
 	api, err := newSecretsAPI(context)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &SecretsAPIV1{SecretsAPI: api}, nil
+	return &SecretsAPIV2{SecretsAPI: api}, nil
 }
 
 // newSecretsAPI creates a SecretsAPI.
@@ -63,6 +76,19 @@ func newSecretsAPI(context facade.Context) (*SecretsAPI, error) {
 		}
 		return p.NewBackend(cfg)
 	}
+	backendIDGetter := func(name string) (string, error) {
+		switch {
+		case name == juju.BackendName:
+			return model.ControllerUUID(), nil
+		case name == kubernetes.BuiltInName(model.Name()) && model.Type() == state.ModelTypeCAAS:
+			return model.UUID(), nil
+		}
+		backend, err := state.NewSecretBackends(context.State()).GetSecretBackend(name)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return backend.ID, nil
+	}
 	return &SecretsAPI{
 		authorizer:                             context.Auth(),
 		authTag:                                context.Auth().GetAuthTag(),
@@ -75,5 +101,6 @@ func newSecretsAPI(context facade.Context) (*SecretsAPI, error) {
 		adminBackendConfigGetter:               adminBackendConfigGetter,
 		backendConfigGetterForUserSecretsWrite: backendConfigGetterForUserSecretsWrite,
 		backendGetter:                          backendGetter,
+		backendIDGetter:                        backendIDGetter,
 	}, nil
 }
@@ -43,11 +43,17 @@ type SecretsAPI struct {
 	adminBackendConfigGetter               func() (*provider.ModelBackendConfigInfo, error)
 	backendConfigGetterForUserSecretsWrite func(backendID string) (*provider.ModelBackendConfigInfo, error)
 	backendGetter                          func(*provider.ModelBackendConfig) (provider.SecretsBackend, error)
+	backendIDGetter                        func(name string) (string, error)
+}
+
+// SecretsAPIV2 is the backend for the Secrets facade v2.
+type SecretsAPIV2 struct {
+	*SecretsAPI
 }
 
 // SecretsAPIV1 is the backend for the Secrets facade v1.
 type SecretsAPIV1 struct {
-	*SecretsAPI
+	*SecretsAPIV2
 }
 
 func (s *SecretsAPI) checkCanRead() error {
@@ -22,6 +22,7 @@ type SecretsState interface {
 	ListSecretRevisions(uri *secrets.URI) ([]*secrets.SecretRevisionMetadata, error)
 	ListUnusedSecretRevisions(uri *secrets.URI) ([]int, error)
 	SecretGrants(uri *secrets.URI, role secrets.SecretRole) ([]secrets.AccessInfo, error)
+	ChangeSecretBackend(state.ChangeSecretBackendParams) error
 }
 
 // SecretsConsumer instances provide secret consumer apis.
@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	"github.com/juju/testing"
@@ -44,6 +46,7 @@ type baseStorageSuite struct {
 	filesystemTag        names.FilesystemTag
 	filesystem           *mockFilesystem
 	filesystemAttachment *mockFilesystemAttachment
+	storageSnapshot      *mockStorageSnapshot
 	stub                 testing.Stub
 
 	registry    jujustorage.StaticProviderRegistry
@@ -108,6 +111,11 @@ const (
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	createStorageSnapshotCall               = "createStorageSnapshot"
+	allStorageSnapshotsCall                 = "allStorageSnapshots"
+	destroyStorageSnapshotCall              = "destroyStorageSnapshot"
+	addStorageForUnitFromSnapshotCall       = "addStorageForUnitFromSnapshot"
+	resizeStorageCall                       = "resizeStorage"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -153,6 +161,13 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 		life:       state.Dead,
 	}
 	s.volume = &mockVolume{tag: s.volumeTag, storage: &s.storageTag}
+	s.storageSnapshot = &mockStorageSnapshot{
+		id:         "0/1",
+		storage:    s.storageTag,
+		filesystem: s.filesystemTag,
+		created:    time.Date(2023, 5, 4, 3, 2, 1, 0, time.UTC),
+		info:       &state.StorageSnapshotInfo{SnapshotId: "snapshot-0-1", Size: 2048},
+	}
 	s.volumeAttachment = &mockVolumeAttachment{
 		VolumeTag: s.volumeTag,
 		HostTag:   s.machineTag,
@@ -312,10 +327,33 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(releaseStorageInstanceCall, tag, destroyAttached, force)
 			return errors.New("cannae do it")
 		},
-		addExistingFilesystem: func(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
-			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
+		addExistingFilesystem: func(f state.FilesystemInfo, v *state.VolumeInfo, storageName, application string) (names.StorageTag, error) {
+			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName, application)
 			return s.storageTag, s.stub.NextErr()
 		},
+		createStorageSnapshot: func(tag names.StorageTag) (state.StorageSnapshot, error) {
+			s.stub.AddCall(createStorageSnapshotCall, tag)
+			if err := s.stub.NextErr(); err != nil {
+				return nil, err
+			}
+			return s.storageSnapshot, nil
+		},
+		allStorageSnapshots: func() ([]state.StorageSnapshot, error) {
+			s.stub.AddCall(allStorageSnapshotsCall)
+			return []state.StorageSnapshot{s.storageSnapshot}, s.stub.NextErr()
+		},
+		destroyStorageSnapshot: func(id string) error {
+			s.stub.AddCall(destroyStorageSnapshotCall, id)
+			return s.stub.NextErr()
+		},
+		addStorageForUnitFromSnapshot: func(u names.UnitTag, name string, snapshotId string) ([]names.StorageTag, error) {
+			s.stub.AddCall(addStorageForUnitFromSnapshotCall, u, name, snapshotId)
+			return []names.StorageTag{names.NewStorageTag("data/1")}, s.stub.NextErr()
+		},
+		resizeStorage: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageCall, tag, size)
+			return s.stub.NextErr()
+		},
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	jujustorage "github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
@@ -70,7 +71,13 @@ type mockStorageAccessor struct {
 	releaseStorageInstance              func(names.StorageTag, bool, bool) error
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag, bool) error
-	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string, string) (names.StorageTag, error)
+	createStorageSnapshot               func(names.StorageTag) (state.StorageSnapshot, error)
+	allStorageSnapshots                 func() ([]state.StorageSnapshot, error)
+	destroyStorageSnapshot              func(string) error
+	resizeStorage                       func(names.StorageTag, uint64) error
+	addStorageForUnitFromSnapshot       func(names.UnitTag, string, string) ([]names.StorageTag, error)
+	storageUsage                        func(names.StorageTag) (state.StorageUsage, error)
 }
 
 func (st *mockStorageAccessor) VolumeAccess() storage.StorageVolume {
@@ -158,6 +165,33 @@ func (st *mockStorageAccessor) AddStorageForUnit(u names.UnitTag, name string, c
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockStorageAccessor) AddStorageForUnitFromSnapshot(u names.UnitTag, name string, snapshotId string) ([]names.StorageTag, error) {
+	return st.addStorageForUnitFromSnapshot(u, name, snapshotId)
+}
+
+func (st *mockStorageAccessor) CreateStorageSnapshot(tag names.StorageTag) (state.StorageSnapshot, error) {
+	return st.createStorageSnapshot(tag)
+}
+
+func (st *mockStorageAccessor) AllStorageSnapshots() ([]state.StorageSnapshot, error) {
+	return st.allStorageSnapshots()
+}
+
+func (st *mockStorageAccessor) DestroyStorageSnapshot(id string) error {
+	return st.destroyStorageSnapshot(id)
+}
+
+func (st *mockStorageAccessor) ResizeStorage(tag names.StorageTag, size uint64) error {
+	return st.resizeStorage(tag, size)
+}
+
+func (st *mockStorageAccessor) StorageUsage(tag names.StorageTag) (state.StorageUsage, error) {
+	if st.storageUsage == nil {
+		return state.StorageUsage{}, errors.NotFoundf("usage of %s", names.ReadableString(tag))
+	}
+	return st.storageUsage(tag)
+}
+
 func (st *mockStorageAccessor) BlockDevices(m names.MachineTag) ([]state.BlockDeviceInfo, error) {
 	if st.blockDevices != nil {
 		return st.blockDevices(m)
@@ -185,8 +219,68 @@ func (st *mockStorageAccessor) UnitStorageAttachments(tag names.UnitTag) ([]stat
 	panic("should not be called")
 }
 
-func (st *mockStorageAccessor) AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, s string) (names.StorageTag, error) {
-	return st.addExistingFilesystem(f, v, s)
+func (st *mockStorageAccessor) AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, s, a string) (names.StorageTag, error) {
+	return st.addExistingFilesystem(f, v, s, a)
+}
+
+type mockStorageSnapshot struct {
+	state.StorageSnapshot
+	id         string
+	storage    names.StorageTag
+	filesystem names.FilesystemTag
+	created    time.Time
+	info       *state.StorageSnapshotInfo
+}
+
+func (m *mockStorageSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockStorageSnapshot) Life() state.Life {
+	return state.Alive
+}
+
+func (m *mockStorageSnapshot) StorageInstance() names.StorageTag {
+	return m.storage
+}
+
+func (m *mockStorageSnapshot) Kind() state.StorageKind {
+	return state.StorageKindFilesystem
+}
+
+func (m *mockStorageSnapshot) Volume() (names.VolumeTag, error) {
+	return names.VolumeTag{}, errors.NotFoundf("volume")
+}
+
+func (m *mockStorageSnapshot) Filesystem() (names.FilesystemTag, error) {
+	return m.filesystem, nil
+}
+
+func (m *mockStorageSnapshot) Machine() (names.MachineTag, bool) {
+	return names.NewMachineTag("0"), true
+}
+
+func (m *mockStorageSnapshot) Pool() string {
+	return "loop"
+}
+
+func (m *mockStorageSnapshot) Size() uint64 {
+	return 1024
+}
+
+func (m *mockStorageSnapshot) Created() time.Time {
+	return m.created
+}
+
+func (m *mockStorageSnapshot) Info() (state.StorageSnapshotInfo, error) {
+	if m.info == nil {
+		return state.StorageSnapshotInfo{}, errors.NotProvisionedf("storage snapshot %q", m.id)
+	}
+	return *m.info, nil
+}
+
+func (m *mockStorageSnapshot) Message() string {
+	return ""
 }
 
 type mockVolume struct {
@@ -436,11 +530,12 @@ func (u *mockUnit) AssignedMachineId() (string, error) {
 }
 
 type mockState struct {
-	modelTag        names.ModelTag
-	getBlockForType func(t state.BlockType) (state.Block, bool, error)
-	unitName        string
-	unitErr         string
-	assignedMachine string
+	modelTag         names.ModelTag
+	getBlockForType  func(t state.BlockType) (state.Block, bool, error)
+	unitName         string
+	unitErr          string
+	assignedMachine  string
+	modelConfigAttrs testing.Attrs
 }
 
 func (st *mockState) ControllerTag() names.ControllerTag {
@@ -455,6 +550,10 @@ func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, erro
 	return st.getBlockForType(t)
 }
 
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	return config.New(config.UseDefaults, testing.FakeConfig().Merge(st.modelConfigAttrs))
+}
+
 func (st *mockState) Unit(unitName string) (storage.Unit, error) {
 	if st.unitErr != "" {
 		return nil, errors.New(st.unitErr)
@@ -22,10 +22,34 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("Storage", 6, func(ctx facade.Context) (facade.Facade, error) {
-		return newStorageAPI(ctx) // modify Remove to support force and maxWait; add DetachStorage to support force and maxWait.
+		return newStorageAPIV6(ctx) // modify Remove to support force and maxWait; add DetachStorage to support force and maxWait.
+	}, reflect.TypeOf((*StorageAPIV6)(nil)))
+	registry.MustRegister("Storage", 7, func(ctx facade.Context) (facade.Facade, error) {
+		return newStorageAPIV7(ctx) // add storage snapshots.
+	}, reflect.TypeOf((*StorageAPIV7)(nil)))
+	registry.MustRegister("Storage", 8, func(ctx facade.Context) (facade.Facade, error) {
+		return newStorageAPI(ctx) // add ResizeStorage.
 	}, reflect.TypeOf((*StorageAPI)(nil)))
 }
 
+// newStorageAPIV6 returns a new storage API v6 facade.
+func newStorageAPIV6(ctx facade.Context) (*StorageAPIV6, error) {
+	api, err := newStorageAPIV7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &StorageAPIV6{api}, nil
+}
+
+// newStorageAPIV7 returns a new storage API v7 facade.
+func newStorageAPIV7(ctx facade.Context) (*StorageAPIV7, error) {
+	api, err := newStorageAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &StorageAPIV7{api}, nil
+}
+
 // newStorageAPI returns a new storage API facade.
 func newStorageAPI(ctx facade.Context) (*StorageAPI, error) {
 	st := ctx.State()
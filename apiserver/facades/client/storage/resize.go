@@ -0,0 +1,48 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/rpc/params"
+)
+
+// StorageAPIV7 implements the v7 Storage API.
+type StorageAPIV7 struct {
+	*StorageAPI
+}
+
+// ResizeStorage isn't on the v7 API.
+func (a *StorageAPIV7) ResizeStorage(_, _ struct{}) {}
+
+// ResizeStorage requests that the volumes or filesystems of the
+// specified storage instances be grown to the given sizes. The resize
+// is carried out asynchronously by the storage provisioner, after which
+// the units that the storage is attached to are notified.
+func (a *StorageAPI) ResizeStorage(args params.ResizeStorageArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		tag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if err := a.storageAccess.ResizeStorage(tag, arg.Size); err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
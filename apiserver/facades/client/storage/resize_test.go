@@ -0,0 +1,49 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+type storageResizeSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageResizeSuite{})
+
+func (s *storageResizeSuite) TestResizeStorage(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotValidf("size"))
+	results, err := s.api.ResizeStorage(params.ResizeStorageArgs{
+		Storage: []params.ResizeStorageArg{
+			{StorageTag: "storage-data-0", Size: 2048},
+			{StorageTag: "storage-data-1", Size: 1},
+			{StorageTag: "volume-0", Size: 2048},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "size not valid", Code: params.CodeNotValid}},
+			{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		},
+	})
+	s.stub.CheckCallNames(c, getBlockForTypeCall, resizeStorageCall, resizeStorageCall)
+	s.stub.CheckCall(c, 1, resizeStorageCall, names.NewStorageTag("data/0"), uint64(2048))
+	s.stub.CheckCall(c, 2, resizeStorageCall, names.NewStorageTag("data/1"), uint64(1))
+}
+
+func (s *storageResizeSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeStorageBlocked")
+	_, err := s.api.ResizeStorage(params.ResizeStorageArgs{
+		Storage: []params.ResizeStorageArg{{StorageTag: "storage-data-0", Size: 2048}},
+	})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+}
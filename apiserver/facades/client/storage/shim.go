@@ -9,6 +9,7 @@ import (
 	"github.com/juju/names/v5"
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -21,6 +22,9 @@ type storageAccess interface {
 	storageInterface
 	storageVolume
 	storageFile
+	storageSnapshot
+	storageResize
+	storageUsage
 }
 
 type storageInterface interface {
@@ -76,7 +80,7 @@ type storageVolume interface {
 	Volume(tag names.VolumeTag) (state.Volume, error)
 
 	// AddExistingFilesystem imports an existing filesystem into the model.
-	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
+	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName, application string) (names.StorageTag, error)
 }
 
 type storageFile interface {
@@ -95,7 +99,36 @@ type storageFile interface {
 	Filesystem(tag names.FilesystemTag) (state.Filesystem, error)
 
 	// AddExistingFilesystem imports an existing filesystem into the model.
-	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
+	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName, application string) (names.StorageTag, error)
+}
+
+type storageSnapshot interface {
+	// CreateStorageSnapshot requests a snapshot of the storage instance
+	// with the specified tag.
+	CreateStorageSnapshot(names.StorageTag) (state.StorageSnapshot, error)
+
+	// AllStorageSnapshots returns all storage snapshots in the model.
+	AllStorageSnapshots() ([]state.StorageSnapshot, error)
+
+	// DestroyStorageSnapshot ensures that the snapshot with the
+	// specified ID is deleted.
+	DestroyStorageSnapshot(id string) error
+
+	// AddStorageForUnitFromSnapshot adds storage to the unit, created
+	// from the snapshot with the specified ID.
+	AddStorageForUnitFromSnapshot(tag names.UnitTag, name string, snapshotId string) ([]names.StorageTag, error)
+}
+
+type storageResize interface {
+	// ResizeStorage requests that the storage instance with the
+	// specified tag be grown to the given size in MiB.
+	ResizeStorage(tag names.StorageTag, size uint64) error
+}
+
+type storageUsage interface {
+	// StorageUsage returns the last reported usage of the storage
+	// instance with the specified tag.
+	StorageUsage(tag names.StorageTag) (state.StorageUsage, error)
 }
 
 var getStorageAccessor = func(st *state.State) (storageAccess, error) {
@@ -111,6 +144,7 @@ type backend interface {
 	ModelTag() names.ModelTag
 	Unit(string) (Unit, error)
 	GetBlockForType(state.BlockType) (state.Block, bool, error)
+	ModelConfig() (*config.Config, error)
 }
 
 type Unit interface {
@@ -129,6 +163,14 @@ func (s stateShim) GetBlockForType(t state.BlockType) (state.Block, bool, error)
 	return s.State.GetBlockForType(t)
 }
 
+func (s stateShim) ModelConfig() (*config.Config, error) {
+	m, err := s.State.Model()
+	if err != nil {
+		return nil, err
+	}
+	return m.ModelConfig()
+}
+
 func (s stateShim) Unit(name string) (Unit, error) {
 	return s.State.Unit(name)
 }
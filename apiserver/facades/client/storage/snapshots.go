@@ -0,0 +1,126 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// StorageAPIV6 implements the v6 Storage API.
+type StorageAPIV6 struct {
+	*StorageAPIV7
+}
+
+// CreateStorageSnapshots isn't on the v6 API.
+func (a *StorageAPIV6) CreateStorageSnapshots(_, _ struct{}) {}
+
+// ListStorageSnapshots isn't on the v6 API.
+func (a *StorageAPIV6) ListStorageSnapshots(_, _ struct{}) {}
+
+// DestroyStorageSnapshots isn't on the v6 API.
+func (a *StorageAPIV6) DestroyStorageSnapshots(_, _ struct{}) {}
+
+// CreateStorageSnapshots requests point-in-time snapshots of the volumes
+// or filesystems of the specified storage instances. The snapshots are
+// taken asynchronously by the storage provisioner.
+func (a *StorageAPI) CreateStorageSnapshots(args params.Entities) (params.StorageSnapshotDetailsResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StorageSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StorageSnapshotDetailsResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.StorageSnapshotDetailsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		snapshot, err := a.storageAccess.CreateStorageSnapshot(tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		details := storageSnapshotDetails(snapshot)
+		results[i].Result = &details
+	}
+	return params.StorageSnapshotDetailsResults{Results: results}, nil
+}
+
+// ListStorageSnapshots returns the details of all storage snapshots in
+// the model.
+func (a *StorageAPI) ListStorageSnapshots() (params.StorageSnapshotDetailsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StorageSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	snapshots, err := a.storageAccess.AllStorageSnapshots()
+	if err != nil {
+		return params.StorageSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	results := make([]params.StorageSnapshotDetailsResult, len(snapshots))
+	for i, snapshot := range snapshots {
+		details := storageSnapshotDetails(snapshot)
+		results[i].Result = &details
+	}
+	return params.StorageSnapshotDetailsResults{Results: results}, nil
+}
+
+// DestroyStorageSnapshots destroys the storage snapshots with the
+// specified IDs. The snapshots are deleted by the storage provisioner,
+// and then removed from the model.
+func (a *StorageAPI) DestroyStorageSnapshots(args params.Entities) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		err := a.storageAccess.DestroyStorageSnapshot(arg.Tag)
+		results[i].Error = apiservererrors.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func storageSnapshotDetails(snapshot state.StorageSnapshot) params.StorageSnapshotDetails {
+	details := params.StorageSnapshotDetails{
+		Id:         snapshot.Id(),
+		StorageTag: snapshot.StorageInstance().String(),
+		Kind:       params.StorageKind(snapshot.Kind()),
+		Pool:       snapshot.Pool(),
+		Size:       snapshot.Size(),
+		Created:    snapshot.Created(),
+		Message:    snapshot.Message(),
+		Life:       life.Value(snapshot.Life().String()),
+	}
+	if volumeTag, err := snapshot.Volume(); err == nil {
+		details.VolumeTag = volumeTag.String()
+	}
+	if filesystemTag, err := snapshot.Filesystem(); err == nil {
+		details.FilesystemTag = filesystemTag.String()
+	}
+	if machineTag, ok := snapshot.Machine(); ok {
+		details.MachineTag = machineTag.String()
+	}
+	if info, err := snapshot.Info(); err == nil {
+		details.SnapshotId = info.SnapshotId
+		if info.Size > 0 {
+			details.Size = info.Size
+		}
+	}
+	return details
+}
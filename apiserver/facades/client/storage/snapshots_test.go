@@ -0,0 +1,126 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+type storageSnapshotSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageSnapshotSuite{})
+
+func (s *storageSnapshotSuite) expectedDetails() params.StorageSnapshotDetails {
+	return params.StorageSnapshotDetails{
+		Id:            "0/1",
+		StorageTag:    "storage-data-0",
+		Kind:          params.StorageKindFilesystem,
+		FilesystemTag: s.filesystemTag.String(),
+		MachineTag:    "machine-0",
+		Pool:          "loop",
+		Size:          2048,
+		Created:       time.Date(2023, 5, 4, 3, 2, 1, 0, time.UTC),
+		SnapshotId:    "snapshot-0-1",
+		Life:          life.Alive,
+	}
+}
+
+func (s *storageSnapshotSuite) TestCreateStorageSnapshots(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("boom"))
+	results, err := s.api.CreateStorageSnapshots(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "storage-data-0"},
+			{Tag: "storage-data-1"},
+			{Tag: "volume-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := s.expectedDetails()
+	c.Assert(results, jc.DeepEquals, params.StorageSnapshotDetailsResults{
+		Results: []params.StorageSnapshotDetailsResult{
+			{Result: &expected},
+			{Error: &params.Error{Message: "boom"}},
+			{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		},
+	})
+	s.stub.CheckCallNames(c, getBlockForTypeCall, createStorageSnapshotCall, createStorageSnapshotCall)
+	s.stub.CheckCall(c, 2, createStorageSnapshotCall, names.NewStorageTag("data/1"))
+}
+
+func (s *storageSnapshotSuite) TestCreateStorageSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateStorageSnapshotsBlocked")
+	_, err := s.api.CreateStorageSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: "storage-data-0"}},
+	})
+	s.assertBlocked(c, err, "TestCreateStorageSnapshotsBlocked")
+}
+
+func (s *storageSnapshotSuite) TestListStorageSnapshots(c *gc.C) {
+	results, err := s.api.ListStorageSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	expected := s.expectedDetails()
+	c.Assert(results, jc.DeepEquals, params.StorageSnapshotDetailsResults{
+		Results: []params.StorageSnapshotDetailsResult{{Result: &expected}},
+	})
+	s.stub.CheckCallNames(c, allStorageSnapshotsCall)
+}
+
+func (s *storageSnapshotSuite) TestListStorageSnapshotsError(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	_, err := s.api.ListStorageSnapshots()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageSnapshotSuite) TestDestroyStorageSnapshots(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("boom"))
+	results, err := s.api.DestroyStorageSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: "0/1"}, {Tag: "2"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "boom"}},
+		},
+	})
+	s.stub.CheckCallNames(c, getBlockForTypeCall, getBlockForTypeCall, destroyStorageSnapshotCall, destroyStorageSnapshotCall)
+	s.stub.CheckCall(c, 2, destroyStorageSnapshotCall, "0/1")
+	s.stub.CheckCall(c, 3, destroyStorageSnapshotCall, "2")
+}
+
+func (s *storageSnapshotSuite) TestDestroyStorageSnapshotsBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestDestroyStorageSnapshotsBlocked")
+	_, err := s.api.DestroyStorageSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: "0/1"}},
+	})
+	s.assertBlocked(c, err, "TestDestroyStorageSnapshotsBlocked")
+}
+
+func (s *storageSnapshotSuite) TestAddToUnitFromSnapshot(c *gc.C) {
+	results, err := s.api.AddToUnit(params.StoragesAddParams{
+		Storages: []params.StorageAddParams{{
+			UnitTag:     s.unitTag.String(),
+			StorageName: "data",
+			SnapshotId:  "0/1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.AddStorageResults{
+		Results: []params.AddStorageResult{{
+			Result: &params.AddStorageDetails{StorageTags: []string{"storage-data-1"}},
+		}},
+	})
+	s.stub.CheckCallNames(c, getBlockForTypeCall, addStorageForUnitFromSnapshotCall)
+	s.stub.CheckCall(c, 1, addStorageForUnitFromSnapshotCall, s.unitTag, "data", "0/1")
+}
@@ -27,7 +27,7 @@ import (
 
 type storageMetadataFunc func() (poolmanager.PoolManager, storage.ProviderRegistry, error)
 
-// StorageAPI implements the latest version (v6) of the Storage API.
+// StorageAPI implements the latest version (v7) of the Storage API.
 type StorageAPI struct {
 	backend         backend
 	storageAccess   storageAccess
@@ -78,6 +78,10 @@ func (a *StorageAPI) StorageDetails(entities params.Entities) (params.StorageDet
 	if err := a.checkCanRead(); err != nil {
 		return params.StorageDetailsResults{}, errors.Trace(err)
 	}
+	threshold, err := a.storageUsageWarningThreshold()
+	if err != nil {
+		return params.StorageDetailsResults{}, errors.Trace(err)
+	}
 	results := make([]params.StorageDetailsResult, len(entities.Entities))
 	for i, entity := range entities.Entities {
 		storageTag, err := names.ParseStorageTag(entity.Tag)
@@ -95,6 +99,10 @@ func (a *StorageAPI) StorageDetails(entities params.Entities) (params.StorageDet
 			results[i].Error = apiservererrors.ServerError(err)
 			continue
 		}
+		if details.Usage, err = a.storageUsage(storageTag, threshold); err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
 		results[i].Result = details
 	}
 	return params.StorageDetailsResults{Results: results}, nil
@@ -130,9 +138,16 @@ func (a *StorageAPI) listStorageDetails(filter params.StorageFilter) ([]params.S
 	if err != nil {
 		return nil, apiservererrors.ServerError(err)
 	}
+	threshold, err := a.storageUsageWarningThreshold()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	results := make([]params.StorageDetails, len(stateInstances))
 	for i, stateInstance := range stateInstances {
 		details, err := storagecommon.StorageDetails(a.storageAccess, a.unitAssignedMachine, stateInstance)
+		if err == nil {
+			details.Usage, err = a.storageUsage(stateInstance.StorageTag(), threshold)
+		}
 		if err != nil {
 			return nil, errors.Annotatef(
 				err, "getting details for %s",
@@ -144,6 +159,34 @@ func (a *StorageAPI) listStorageDetails(filter params.StorageFilter) ([]params.S
 	return results, nil
 }
 
+func (a *StorageAPI) storageUsageWarningThreshold() (int, error) {
+	cfg, err := a.backend.ModelConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return cfg.StorageUsageWarningThreshold(), nil
+}
+
+// storageUsage returns the last reported usage of the specified storage,
+// or nil if none has been reported. A warning is included if the space
+// or inodes used are at or above the given percentage threshold.
+func (a *StorageAPI) storageUsage(tag names.StorageTag, threshold int) (*params.StorageUsage, error) {
+	usage, err := a.storageAccess.StorageUsage(tag)
+	if errors.Is(err, errors.NotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.StorageUsage{
+		BytesUsed:   usage.BytesUsed,
+		BytesTotal:  usage.BytesTotal,
+		InodesUsed:  usage.InodesUsed,
+		InodesTotal: usage.InodesTotal,
+		Updated:     usage.Updated,
+		Warning:     usage.Warning(threshold),
+	}, nil
+}
+
 // ListPools returns a list of pools.
 // If filter is provided, returned list only contains pools that match
 // the filter.
@@ -546,9 +589,16 @@ func (a *StorageAPI) addToUnit(args params.StoragesAddParams) (params.AddStorage
 			continue
 		}
 
-		storageTags, err := a.storageAccess.AddStorageForUnit(
-			u, one.StorageName, paramsToState(one.Constraints),
-		)
+		var storageTags []names.StorageTag
+		if one.SnapshotId != "" {
+			storageTags, err = a.storageAccess.AddStorageForUnitFromSnapshot(
+				u, one.StorageName, one.SnapshotId,
+			)
+		} else {
+			storageTags, err = a.storageAccess.AddStorageForUnit(
+				u, one.StorageName, paramsToState(one.Constraints),
+			)
+		}
 		if err != nil {
 			result[i].Error = apiservererrors.ServerError(err)
 		}
@@ -818,7 +868,7 @@ func (a *StorageAPI) importFilesystem(
 		filesystemInfo.Size = info.Size
 	}
 
-	storageTag, err := a.storageAccess.AddExistingFilesystem(filesystemInfo, volumeInfo, arg.StorageName)
+	storageTag, err := a.storageAccess.AddExistingFilesystem(filesystemInfo, volumeInfo, arg.StorageName, arg.Application)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
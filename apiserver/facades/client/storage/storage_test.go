@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
@@ -308,6 +309,60 @@ func (s *storageSuite) TestShowStorage(c *gc.C) {
 	c.Assert(one.Result, jc.DeepEquals, &expected)
 }
 
+func (s *storageSuite) TestShowStorageUsage(c *gc.C) {
+	updated := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	s.storageAccessor.storageUsage = func(tag names.StorageTag) (state.StorageUsage, error) {
+		c.Assert(tag, gc.Equals, s.storageTag)
+		return state.StorageUsage{
+			BytesUsed:   950,
+			BytesTotal:  1000,
+			InodesUsed:  10,
+			InodesTotal: 100,
+			Updated:     updated,
+		}, nil
+	}
+	entity := params.Entity{Tag: s.storageTag.String()}
+
+	found, err := s.api.StorageDetails(
+		params.Entities{Entities: []params.Entity{entity}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Error, gc.IsNil)
+	c.Assert(found.Results[0].Result.Usage, jc.DeepEquals, &params.StorageUsage{
+		BytesUsed:   950,
+		BytesTotal:  1000,
+		InodesUsed:  10,
+		InodesTotal: 100,
+		Updated:     updated,
+		Warning:     "95% of space used, at or above the 90% warning threshold",
+	})
+}
+
+func (s *storageSuite) TestStorageListUsageWarningDisabled(c *gc.C) {
+	s.state.modelConfigAttrs = coretesting.Attrs{"storage-usage-warning-threshold": 0}
+	s.storageAccessor.storageUsage = func(tag names.StorageTag) (state.StorageUsage, error) {
+		return state.StorageUsage{
+			BytesUsed:   1000,
+			BytesTotal:  1000,
+			InodesUsed:  100,
+			InodesTotal: 100,
+		}, nil
+	}
+	found, err := s.api.ListStorageDetails(
+		params.StorageFilters{[]params.StorageFilter{{}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result[0].Usage, jc.DeepEquals, &params.StorageUsage{
+		BytesUsed:   1000,
+		BytesTotal:  1000,
+		InodesUsed:  100,
+		InodesTotal: 100,
+	})
+}
+
 func (s *storageSuite) TestShowStorageInvalidId(c *gc.C) {
 	storageTag := "foo"
 	entity := params.Entity{Tag: storageTag}
@@ -527,7 +582,7 @@ func (s *storageSuite) TestImportFilesystem(c *gc.C) {
 				Size:         123,
 			},
 			(*state.VolumeInfo)(nil),
-			"pgdata",
+			"pgdata", "",
 		}},
 	})
 }
@@ -552,6 +607,7 @@ func (s *storageSuite) TestImportFilesystemVolumeBacked(c *gc.C) {
 		Pool:        "radiance",
 		ProviderId:  "foo",
 		StorageName: "pgdata",
+		Application: "postgresql",
 	}}})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{{
@@ -581,7 +637,7 @@ func (s *storageSuite) TestImportFilesystemVolumeBacked(c *gc.C) {
 				Size:       123,
 				HardwareId: "hw",
 			},
-			"pgdata",
+			"pgdata", "postgresql",
 		}},
 	})
 }
@@ -0,0 +1,320 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/apiserver/authentication"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// UserManagerAPIV3 is the version 3 of the user manager facade,
+// without support for user groups.
+type UserManagerAPIV3 struct {
+	*UserManagerAPI
+}
+
+// AddUserGroups isn't on the v3 API.
+func (*UserManagerAPIV3) AddUserGroups(_, _ struct{}) {}
+
+// RemoveUserGroups isn't on the v3 API.
+func (*UserManagerAPIV3) RemoveUserGroups(_, _ struct{}) {}
+
+// UserGroups isn't on the v3 API.
+func (*UserManagerAPIV3) UserGroups(_, _ struct{}) {}
+
+// ModifyUserGroupMembers isn't on the v3 API.
+func (*UserManagerAPIV3) ModifyUserGroupMembers(_, _ struct{}) {}
+
+// ModifyUserGroupAccess isn't on the v3 API.
+func (*UserManagerAPIV3) ModifyUserGroupAccess(_, _ struct{}) {}
+
+// AddUserGroups creates the named user groups. Only controller
+// superusers may create groups.
+func (api *UserManagerAPI) AddUserGroups(args params.UserGroupNames) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if _, err := api.hasControllerAdminAccess(); err != nil {
+		return result, err
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Names))
+	for i, name := range args.Names {
+		if _, err := api.state.AddUserGroup(name, api.apiUser.Id()); err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(errors.Annotate(err, "failed to create group"))
+		}
+	}
+	return result, nil
+}
+
+// RemoveUserGroups removes the named user groups, along with all of the
+// access granted to them. Only controller superusers may remove groups.
+func (api *UserManagerAPI) RemoveUserGroups(args params.UserGroupNames) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if _, err := api.hasControllerAdminAccess(); err != nil {
+		return result, err
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Names))
+	for i, name := range args.Names {
+		if err := api.state.RemoveUserGroup(name); err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// UserGroups returns information on the named user groups, or all groups
+// if no names are supplied. Only controller superusers may list groups.
+func (api *UserManagerAPI) UserGroups(args params.UserGroupNames) (params.UserGroupInfoResults, error) {
+	var result params.UserGroupInfoResults
+	if _, err := api.hasControllerAdminAccess(); err != nil {
+		return result, err
+	}
+
+	if len(args.Names) == 0 {
+		groups, err := api.state.AllUserGroups()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.Results = make([]params.UserGroupInfoResult, len(groups))
+		for i, group := range groups {
+			result.Results[i] = api.userGroupInfo(group)
+		}
+		return result, nil
+	}
+
+	result.Results = make([]params.UserGroupInfoResult, len(args.Names))
+	for i, name := range args.Names {
+		group, err := api.state.UserGroup(name)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i] = api.userGroupInfo(group)
+	}
+	return result, nil
+}
+
+func (api *UserManagerAPI) userGroupInfo(group *state.UserGroup) params.UserGroupInfoResult {
+	access, err := api.userGroupAccess(group.Name())
+	if err != nil {
+		return params.UserGroupInfoResult{Error: apiservererrors.ServerError(err)}
+	}
+	members := group.Members()
+	info := &params.UserGroupInfo{
+		Name:        group.Name(),
+		Members:     make([]string, len(members)),
+		CreatedBy:   group.CreatedBy(),
+		DateCreated: group.DateCreated(),
+		Access:      access,
+	}
+	for i, member := range members {
+		info.Members[i] = member.Id()
+	}
+	return params.UserGroupInfoResult{Result: info}
+}
+
+func (api *UserManagerAPI) userGroupAccess(group string) ([]params.UserGroupAccess, error) {
+	perms, err := api.state.UserGroupPermissions(group)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.UserGroupAccess, len(perms))
+	for i, perm := range perms {
+		result[i] = params.UserGroupAccess{
+			Group:  perm.Group,
+			Target: perm.Target.String(),
+			Access: string(perm.Access),
+		}
+	}
+	return result, nil
+}
+
+// groupsForUser returns the names of the groups the user is a member
+// of, and the access granted through them.
+func (api *UserManagerAPI) groupsForUser(user names.UserTag) ([]string, []params.UserGroupAccess, error) {
+	groups, err := api.state.UserGroupsForUser(user)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var (
+		groupNames []string
+		access     []params.UserGroupAccess
+	)
+	for _, group := range groups {
+		groupNames = append(groupNames, group.Name())
+		groupAccess, err := api.userGroupAccess(group.Name())
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		access = append(access, groupAccess...)
+	}
+	return groupNames, access, nil
+}
+
+// ModifyUserGroupMembers adds users to, or removes users from, user
+// groups. Only controller superusers may change group membership.
+func (api *UserManagerAPI) ModifyUserGroupMembers(args params.ModifyUserGroupMembersRequest) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if _, err := api.hasControllerAdminAccess(); err != nil {
+		return result, err
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Changes))
+	for i, arg := range args.Changes {
+		if err := api.modifyUserGroupMembers(arg); err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func (api *UserManagerAPI) modifyUserGroupMembers(arg params.ModifyUserGroupMembers) error {
+	users := make([]names.UserTag, len(arg.UserTags))
+	for i, tag := range arg.UserTags {
+		user, err := names.ParseUserTag(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		users[i] = user
+	}
+	group, err := api.state.UserGroup(arg.Group)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch arg.Action {
+	case params.AddUserGroupMembers:
+		return errors.Annotatef(group.AddMembers(users...), "could not add users to group %q", arg.Group)
+	case params.RemoveUserGroupMembers:
+		return errors.Annotatef(group.RemoveMembers(users...), "could not remove users from group %q", arg.Group)
+	default:
+		return errors.Errorf("unknown action %q", arg.Action)
+	}
+}
+
+// ModifyUserGroupAccess grants access to, or revokes access from, user
+// groups. Controller superusers may change access on any target; other
+// users require admin access on the target.
+func (api *UserManagerAPI) ModifyUserGroupAccess(args params.ModifyUserGroupAccessRequest) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil && !errors.Is(err, authentication.ErrorEntityMissingPermission) {
+		return result, errors.Trace(err)
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Changes))
+	for i, arg := range args.Changes {
+		target, err := names.ParseTag(arg.TargetTag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(errors.Annotate(err, "could not parse target"))
+			continue
+		}
+		if !isSuperUser {
+			if err := api.authorizer.HasPermission(permission.AdminAccess, target); err != nil {
+				result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+				continue
+			}
+		}
+		access := permission.Access(arg.Access)
+		if err := api.modifyUserGroupAccess(arg.Group, target, arg.Action, access); err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func (api *UserManagerAPI) modifyUserGroupAccess(group string, target names.Tag, action params.UserGroupAction, access permission.Access) error {
+	current, err := api.state.UserGroupAccess(group, target)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "could not look up access for group")
+	}
+
+	switch action {
+	case params.GrantUserGroupAccess:
+		// Only set access if greater access is being granted.
+		if current != permission.NoAccess && equalOrGreaterAccess(target.Kind(), current, access) {
+			return errors.Errorf("group already has %q access or greater", access)
+		}
+		err := api.state.SetUserGroupAccess(group, target, access)
+		return errors.Annotatef(err, "could not grant %s access", target.Kind())
+
+	case params.RevokeUserGroupAccess:
+		if current == permission.NoAccess || !equalOrGreaterAccess(target.Kind(), current, access) {
+			return errors.Errorf("group does not have %q access", access)
+		}
+		lower, err := lowerAccess(target.Kind(), access)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if lower == permission.NoAccess {
+			err := api.state.RemoveUserGroupAccess(group, target)
+			return errors.Annotatef(err, "could not revoke %s access", target.Kind())
+		}
+		err = api.state.SetUserGroupAccess(group, target, lower)
+		return errors.Annotatef(err, "could not set %s access to %q", target.Kind(), lower)
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
+// equalOrGreaterAccess reports whether access a is equal to or greater
+// than access b, as ordered for the kind of target.
+func equalOrGreaterAccess(kind string, a, b permission.Access) bool {
+	switch kind {
+	case names.ControllerTagKind:
+		return a.EqualOrGreaterControllerAccessThan(b)
+	case names.ModelTagKind:
+		return a.EqualOrGreaterModelAccessThan(b)
+	case names.ApplicationOfferTagKind:
+		return a.EqualOrGreaterOfferAccessThan(b)
+	case names.CloudTagKind:
+		return a.EqualOrGreaterCloudAccessThan(b)
+	}
+	return false
+}
+
+// lowerAccess returns the access that is left after revoking the supplied
+// access from a target of the given kind. Revoking the lowest access
+// removes all access.
+func lowerAccess(kind string, access permission.Access) (permission.Access, error) {
+	var levels []permission.Access
+	switch kind {
+	case names.ControllerTagKind:
+		levels = []permission.Access{permission.LoginAccess, permission.SuperuserAccess}
+	case names.ModelTagKind:
+		levels = []permission.Access{permission.ReadAccess, permission.WriteAccess, permission.AdminAccess}
+	case names.ApplicationOfferTagKind:
+		levels = []permission.Access{permission.ReadAccess, permission.ConsumeAccess, permission.AdminAccess}
+	case names.CloudTagKind:
+		levels = []permission.Access{permission.AddModelAccess, permission.AdminAccess}
+	}
+	for i, level := range levels {
+		if level != access {
+			continue
+		}
+		if i == 0 {
+			return permission.NoAccess, nil
+		}
+		return levels[i-1], nil
+	}
+	return "", errors.Errorf("don't know how to revoke %q access", access)
+}
@@ -0,0 +1,197 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager_test
+
+import (
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/usermanager"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *userManagerSuite) addGroup(c *gc.C, name string, members ...string) {
+	result, err := s.usermanager.AddUserGroups(params.UserGroupNames{Names: []string{name}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	if len(members) == 0 {
+		return
+	}
+	userTags := make([]string, len(members))
+	for i, member := range members {
+		userTags[i] = names.NewUserTag(member).String()
+	}
+	result, err = s.usermanager.ModifyUserGroupMembers(params.ModifyUserGroupMembersRequest{
+		Changes: []params.ModifyUserGroupMembers{{
+			Group:    name,
+			Action:   params.AddUserGroupMembers,
+			UserTags: userTags,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+}
+
+func (s *userManagerSuite) modifyGroupAccess(c *gc.C, group string, action params.UserGroupAction, access permission.Access, target names.Tag) error {
+	result, err := s.usermanager.ModifyUserGroupAccess(params.ModifyUserGroupAccessRequest{
+		Changes: []params.ModifyUserGroupAccess{{
+			Group:     group,
+			Action:    action,
+			Access:    string(access),
+			TargetTag: target.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return result.OneError()
+}
+
+func (s *userManagerSuite) TestUserGroups(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	s.addGroup(c, "engineers", "bob", "mary@external")
+	s.addGroup(c, "testers")
+
+	modelTag := s.Model.ModelTag()
+	err := s.modifyGroupAccess(c, "engineers", params.GrantUserGroupAccess, permission.WriteAccess, modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.usermanager.UserGroups(params.UserGroupNames{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	info := result.Results[0].Result
+	c.Assert(info, gc.NotNil)
+	c.Assert(info.Name, gc.Equals, "engineers")
+	c.Assert(info.Members, jc.DeepEquals, []string{"bob", "mary@external"})
+	c.Assert(info.CreatedBy, gc.Equals, s.adminName)
+	c.Assert(info.Access, jc.DeepEquals, []params.UserGroupAccess{{
+		Group:  "engineers",
+		Target: modelTag.String(),
+		Access: "write",
+	}})
+	c.Assert(result.Results[1].Result.Name, gc.Equals, "testers")
+
+	result, err = s.usermanager.UserGroups(params.UserGroupNames{Names: []string{"missing"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `group "missing" not found`)
+}
+
+func (s *userManagerSuite) TestRemoveUserGroups(c *gc.C) {
+	s.addGroup(c, "engineers")
+
+	result, err := s.usermanager.RemoveUserGroups(params.UserGroupNames{Names: []string{"engineers", "engineers"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `group "engineers" not found`)
+}
+
+func (s *userManagerSuite) TestModifyUserGroupMembersRemove(c *gc.C) {
+	s.addGroup(c, "engineers", "mary@external")
+
+	result, err := s.usermanager.ModifyUserGroupMembers(params.ModifyUserGroupMembersRequest{
+		Changes: []params.ModifyUserGroupMembers{{
+			Group:    "engineers",
+			Action:   params.RemoveUserGroupMembers,
+			UserTags: []string{"user-mary@external"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	group, err := s.State.UserGroup("engineers")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), gc.HasLen, 0)
+}
+
+func (s *userManagerSuite) TestUserGroupsNotControllerAdmin(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      apiservertesting.FakeAuthorizer{Tag: bob.Tag()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = usermanager.AddUserGroups(params.UserGroupNames{Names: []string{"engineers"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = usermanager.UserGroups(params.UserGroupNames{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	s.addGroup(c, "engineers")
+	result, err := usermanager.ModifyUserGroupAccess(params.ModifyUserGroupAccessRequest{
+		Changes: []params.ModifyUserGroupAccess{{
+			Group:     "engineers",
+			Action:    params.GrantUserGroupAccess,
+			Access:    "read",
+			TargetTag: s.Model.ModelTag().String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
+}
+
+func (s *userManagerSuite) TestGrantUserGroupAccess(c *gc.C) {
+	s.addGroup(c, "engineers")
+	modelTag := s.Model.ModelTag()
+
+	err := s.modifyGroupAccess(c, "engineers", params.GrantUserGroupAccess, permission.WriteAccess, modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.modifyGroupAccess(c, "engineers", params.GrantUserGroupAccess, permission.ReadAccess, modelTag)
+	c.Assert(err, gc.ErrorMatches, `group already has "read" access or greater`)
+	err = s.modifyGroupAccess(c, "engineers", params.GrantUserGroupAccess, permission.AdminAccess, modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserGroupAccess("engineers", modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+}
+
+func (s *userManagerSuite) TestRevokeUserGroupAccess(c *gc.C) {
+	s.addGroup(c, "engineers")
+	modelTag := s.Model.ModelTag()
+	err := s.modifyGroupAccess(c, "engineers", params.GrantUserGroupAccess, permission.AdminAccess, modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Revoking admin access leaves write access.
+	err = s.modifyGroupAccess(c, "engineers", params.RevokeUserGroupAccess, permission.AdminAccess, modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.UserGroupAccess("engineers", modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	err = s.modifyGroupAccess(c, "engineers", params.RevokeUserGroupAccess, permission.AdminAccess, modelTag)
+	c.Assert(err, gc.ErrorMatches, `group does not have "admin" access`)
+
+	// Revoking read access removes all access.
+	err = s.modifyGroupAccess(c, "engineers", params.RevokeUserGroupAccess, permission.ReadAccess, modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserGroupAccess("engineers", modelTag)
+	c.Assert(err, gc.ErrorMatches, `.*not found`)
+}
+
+func (s *userManagerSuite) TestUserInfoGroups(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	s.addGroup(c, "engineers", "bob")
+	controllerTag := s.State.ControllerTag()
+	err := s.modifyGroupAccess(c, "engineers", params.GrantUserGroupAccess, permission.SuperuserAccess, controllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.usermanager.UserInfo(params.UserInfoRequest{
+		Entities: []params.Entity{{Tag: bob.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	info := results.Results[0].Result
+	c.Assert(info, gc.NotNil)
+	c.Assert(info.Access, gc.Equals, "superuser")
+	c.Assert(info.Groups, jc.DeepEquals, []string{"engineers"})
+	c.Assert(info.GroupAccess, jc.DeepEquals, []params.UserGroupAccess{{
+		Group:  "engineers",
+		Target: controllerTag.String(),
+		Access: "superuser",
+	}})
+}
@@ -224,6 +224,19 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 		}
 	}
 
+	var groupsForUser = func(userTag names.UserTag, result *params.UserInfoResult) {
+		// Lookup the groups the specified user is a member of, and
+		// the access granted through them.
+		groups, access, err := api.groupsForUser(userTag)
+		if err != nil {
+			result.Result = nil
+			result.Error = apiservererrors.ServerError(err)
+			return
+		}
+		result.Result.Groups = groups
+		result.Result.GroupAccess = access
+	}
+
 	var infoForUser = func(user *state.User) params.UserInfoResult {
 		var lastLogin *time.Time
 		userLastLogin, err := user.LastLogin()
@@ -250,6 +263,9 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 		} else {
 			accessForUser(user.UserTag(), &result)
 		}
+		if result.Result != nil {
+			groupsForUser(user.UserTag(), &result)
+		}
 		return result
 	}
 
@@ -287,6 +303,9 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 				},
 			}
 			accessForUser(userTag, &result)
+			if result.Result != nil {
+				groupsForUser(userTag, &result)
+			}
 			results.Results = append(results.Results, result)
 			continue
 		}
@@ -499,6 +499,8 @@ type mockStorage struct {
 	storageVolumes     map[names.StorageTag]names.VolumeTag
 	storageAttachments map[names.UnitTag]names.StorageTag
 	backingVolume      names.VolumeTag
+	storageInstances   []state.StorageInstance
+	volumeInfo         map[names.VolumeTag]state.VolumeInfo
 }
 
 func (m *mockStorage) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
@@ -509,6 +511,11 @@ func (m *mockStorage) StorageInstance(tag names.StorageTag) (state.StorageInstan
 	}, nil
 }
 
+func (m *mockStorage) AllStorageInstances() ([]state.StorageInstance, error) {
+	m.MethodCall(m, "AllStorageInstances")
+	return m.storageInstances, nil
+}
+
 func (m *mockStorage) AllFilesystems() ([]state.Filesystem, error) {
 	m.MethodCall(m, "AllFilesystems")
 	var result []state.Filesystem
@@ -564,11 +571,20 @@ func (m *mockStorage) SetFilesystemAttachmentInfo(host names.Tag, fsTag names.Fi
 
 func (m *mockStorage) Volume(volTag names.VolumeTag) (state.Volume, error) {
 	m.MethodCall(m, "Volume", volTag)
-	return &mockVolume{Stub: &m.Stub, tag: volTag}, nil
+	vol := &mockVolume{Stub: &m.Stub, tag: volTag}
+	if info, ok := m.volumeInfo[volTag]; ok {
+		vol.info = &info
+	}
+	return vol, nil
 }
 
 func (m *mockStorage) StorageInstanceVolume(tag names.StorageTag) (state.Volume, error) {
-	return &mockVolume{Stub: &m.Stub, tag: m.storageVolumes[tag]}, nil
+	volTag := m.storageVolumes[tag]
+	vol := &mockVolume{Stub: &m.Stub, tag: volTag}
+	if info, ok := m.volumeInfo[volTag]; ok {
+		vol.info = &info
+	}
+	return vol, nil
 }
 
 func (m *mockStorage) SetVolumeInfo(volTag names.VolumeTag, volInfo state.VolumeInfo) error {
@@ -581,10 +597,28 @@ func (m *mockStorage) SetVolumeAttachmentInfo(host names.Tag, volTag names.Volum
 	return nil
 }
 
+func (m *mockStorage) ReplaceStorage(storage, replacement names.StorageTag, unit names.UnitTag) error {
+	m.MethodCall(m, "ReplaceStorage", storage, replacement, unit)
+	return m.NextErr()
+}
+
 type mockStorageInstance struct {
 	state.StorageInstance
-	tag   names.StorageTag
-	owner names.Tag
+	tag         names.StorageTag
+	owner       names.Tag
+	application string
+}
+
+func (a *mockStorageInstance) Owner() (names.Tag, bool) {
+	return a.owner, a.owner != nil
+}
+
+func (a *mockStorageInstance) Application() (names.ApplicationTag, bool) {
+	return names.NewApplicationTag(a.application), a.application != ""
+}
+
+func (a *mockStorageInstance) StorageTag() names.StorageTag {
+	return a.tag
 }
 
 func (a *mockStorageInstance) Kind() state.StorageKind {
@@ -645,7 +679,8 @@ func (f *mockFilesystem) Info() (state.FilesystemInfo, error) {
 type mockVolume struct {
 	*testing.Stub
 	state.Volume
-	tag names.VolumeTag
+	tag  names.VolumeTag
+	info *state.VolumeInfo
 }
 
 func (v *mockVolume) Tag() names.Tag {
@@ -662,6 +697,9 @@ func (v *mockVolume) SetStatus(statusInfo status.StatusInfo) error {
 }
 
 func (v *mockVolume) Info() (state.VolumeInfo, error) {
+	if v.info != nil {
+		return *v.info, nil
+	}
 	return state.VolumeInfo{}, errors.NotProvisionedf("volume")
 }
 
@@ -557,6 +557,13 @@ func (a *API) applicationFilesystemParams(
 		sNames = append(sNames, name)
 	}
 	sort.Strings(sNames)
+	var importedVolumeIds map[importedStorageKey][]string
+	if len(sNames) > 0 {
+		importedVolumeIds, err = a.importedVolumeIds()
+		if err != nil {
+			return nil, errors.Annotate(err, "getting imported volumes")
+		}
+	}
 	for _, name := range sNames {
 		cons := storageConstraints[name]
 		fsParams, err := filesystemParams(
@@ -568,6 +575,10 @@ func (a *API) applicationFilesystemParams(
 		if err != nil {
 			return nil, errors.Annotatef(err, "getting filesystem %q parameters", name)
 		}
+		fsParams.ImportedVolumeIds = importedVolumeIds[importedStorageKey{
+			application: app.Name(),
+			storageName: name,
+		}]
 		for i := 0; i < int(cons.Count); i++ {
 			charmStorage := ch.Meta().Storage[name]
 			id := fmt.Sprintf("%s/%v", name, i)
@@ -588,6 +599,81 @@ func (a *API) applicationFilesystemParams(
 	return allFilesystemParams, nil
 }
 
+// importedStorageKey identifies imported storage by the application
+// it was imported for, and its storage name.
+type importedStorageKey struct {
+	application string
+	storageName string
+}
+
+// importedStorage describes an imported storage instance which is
+// not yet attached to a unit.
+type importedStorage struct {
+	importedStorageKey
+	storageTag names.StorageTag
+	volumeId   string
+}
+
+// importedStorage returns the storage instances that have been imported
+// for an application, and which are not owned by any unit, along with the
+// provider IDs of their provisioned backing volumes. Storage imported
+// without an application is not bound to the storage of new units.
+func (a *API) importedStorage() ([]importedStorage, error) {
+	storageInstances, err := a.storage.AllStorageInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []importedStorage
+	for _, si := range storageInstances {
+		if _, ok := si.Owner(); ok || si.Kind() != state.StorageKindFilesystem {
+			continue
+		}
+		appTag, ok := si.Application()
+		if !ok {
+			continue
+		}
+		vol, err := a.storage.StorageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := vol.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, importedStorage{
+			importedStorageKey: importedStorageKey{
+				application: appTag.Id(),
+				storageName: si.StorageName(),
+			},
+			storageTag: si.StorageTag(),
+			volumeId:   info.VolumeId,
+		})
+	}
+	return result, nil
+}
+
+// importedVolumeIds returns the provider IDs of the volumes backing
+// imported storage, keyed by application and storage name. These
+// volumes may be bound to the storage of the application's new units.
+func (a *API) importedVolumeIds() (map[importedStorageKey][]string, error) {
+	imported, err := a.importedStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[importedStorageKey][]string)
+	for _, one := range imported {
+		result[one.importedStorageKey] = append(result[one.importedStorageKey], one.volumeId)
+	}
+	for _, ids := range result {
+		sort.Strings(ids)
+	}
+	return result, nil
+}
+
 func filesystemParams(
 	app Application,
 	cons state.StorageConstraints,
@@ -823,6 +909,32 @@ func (a *API) updateUnitsFromCloud(app Application, unitUpdates []params.Applica
 	volumeUpdates := make(map[string]volumeInfo)
 	volumeStatus := make(map[string]status.StatusInfo)
 
+	// The cloud may bind a unit to the volume of storage imported for
+	// the application. Such storage is attached to the unit in place of
+	// the unit's own storage, rather than recording the same volume
+	// against the unit's storage.
+	var importedByVolumeId map[string]importedStorage
+	importedStorageForVolume := func(storageName, volumeId string) (importedStorage, bool, error) {
+		if volumeId == "" {
+			return importedStorage{}, false, nil
+		}
+		if importedByVolumeId == nil {
+			imported, err := a.importedStorage()
+			if err != nil {
+				return importedStorage{}, false, errors.Trace(err)
+			}
+			importedByVolumeId = make(map[string]importedStorage)
+			for _, one := range imported {
+				importedByVolumeId[one.volumeId] = one
+			}
+		}
+		one, ok := importedByVolumeId[volumeId]
+		if !ok || one.application != app.Name() || one.storageName != storageName {
+			return importedStorage{}, false, nil
+		}
+		return one, true, nil
+	}
+
 	processFilesystemParams := func(processedFilesystemIds set.Strings, unitTag names.UnitTag, unitParams params.ApplicationUnitParams) error {
 		// Once a unit is available in the cluster, we consider
 		// its filesystem(s) to be attached since the unit is
@@ -860,11 +972,31 @@ func (a *API) updateUnitsFromCloud(app Application, unitUpdates []params.Applica
 				if si.StorageName() != storageName {
 					continue
 				}
-				fs, err := a.storage.StorageInstanceFilesystem(sa.StorageInstance())
+				fsInfo := infos[0]
+				infos = infos[1:]
+
+				storageTag := sa.StorageInstance()
+				imported, ok, err := importedStorageForVolume(storageName, fsInfo.Volume.VolumeId)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				if ok {
+					logger.Infof("attaching imported %s to %s in place of %s", names.ReadableString(imported.storageTag), names.ReadableString(unitTag), names.ReadableString(storageTag))
+					if err := a.storage.ReplaceStorage(storageTag, imported.storageTag, unitTag); err != nil {
+						logger.Warningf("cannot attach imported storage %v to unit %v: %v", imported.storageTag.Id(), unitTag.Id(), err)
+						if len(infos) == 0 {
+							break
+						}
+						continue
+					}
+					delete(importedByVolumeId, imported.volumeId)
+					storageTag = imported.storageTag
+				}
+
+				fs, err := a.storage.StorageInstanceFilesystem(storageTag)
 				if err != nil {
 					return errors.Trace(err)
 				}
-				fsInfo := infos[0]
 				processedFilesystemIds.Add(fsInfo.FilesystemId)
 
 				// k8s reports provisioned info even when the volume is not ready.
@@ -888,7 +1020,7 @@ func (a *API) updateUnitsFromCloud(app Application, unitUpdates []params.Applica
 
 				// If the filesystem has a backing volume, get that info also.
 				if _, err := fs.Volume(); err == nil {
-					vol, err := a.storage.StorageInstanceVolume(sa.StorageInstance())
+					vol, err := a.storage.StorageInstanceVolume(storageTag)
 					if err != nil {
 						return errors.Trace(err)
 					}
@@ -909,7 +1041,6 @@ func (a *API) updateUnitsFromCloud(app Application, unitUpdates []params.Applica
 					}
 				}
 
-				infos = infos[1:]
 				if len(infos) == 0 {
 					break
 				}
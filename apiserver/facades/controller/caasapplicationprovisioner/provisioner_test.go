@@ -10,6 +10,7 @@ import (
 	charmresource "github.com/juju/charm/v12/resource"
 	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version/v2"
@@ -120,6 +121,58 @@ func (s *CAASApplicationProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	})
 }
 
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoImportedVolumes(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:  names.NewApplicationTag("gitlab"),
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{
+				Storage: map[string]charm.Storage{
+					"data": {Name: "data", Type: charm.StorageFilesystem, Location: "/data"},
+				},
+			},
+			url: "ch:gitlab",
+		},
+		storageConstraints: map[string]state.StorageConstraints{
+			"data": {Pool: "k8s-pool", Size: 1024, Count: 1},
+		},
+		scale: 1,
+	}
+	s.storage.storageInstances = []state.StorageInstance{
+		&mockStorageInstance{tag: names.NewStorageTag("data/0"), owner: names.NewUnitTag("gitlab/0"), application: "gitlab"},
+		&mockStorageInstance{tag: names.NewStorageTag("data/1"), application: "gitlab"},
+		&mockStorageInstance{tag: names.NewStorageTag("logs/2"), application: "gitlab"},
+		&mockStorageInstance{tag: names.NewStorageTag("data/3"), application: "gitlab"},
+		&mockStorageInstance{tag: names.NewStorageTag("data/4"), application: "mariadb"},
+		&mockStorageInstance{tag: names.NewStorageTag("data/5")},
+	}
+	s.storage.storageVolumes[names.NewStorageTag("data/0")] = names.NewVolumeTag("0")
+	s.storage.storageVolumes[names.NewStorageTag("data/1")] = names.NewVolumeTag("1")
+	s.storage.storageVolumes[names.NewStorageTag("logs/2")] = names.NewVolumeTag("2")
+	s.storage.storageVolumes[names.NewStorageTag("data/3")] = names.NewVolumeTag("3")
+	s.storage.storageVolumes[names.NewStorageTag("data/4")] = names.NewVolumeTag("4")
+	s.storage.storageVolumes[names.NewStorageTag("data/5")] = names.NewVolumeTag("5")
+	s.storage.volumeInfo = map[names.VolumeTag]state.VolumeInfo{
+		names.NewVolumeTag("0"): {VolumeId: "pv-0"},
+		names.NewVolumeTag("1"): {VolumeId: "pv-1"},
+		names.NewVolumeTag("2"): {VolumeId: "pv-2"},
+		// Volume 3 is not provisioned.
+		// Volume 4 was imported for another application.
+		names.NewVolumeTag("4"): {VolumeId: "pv-4"},
+		// Volume 5 was imported without an application.
+		names.NewVolumeTag("5"): {VolumeId: "pv-5"},
+	}
+
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Filesystems, gc.HasLen, 1)
+	fs := result.Results[0].Filesystems[0]
+	c.Assert(fs.StorageName, gc.Equals, "data")
+	c.Assert(fs.ImportedVolumeIds, jc.DeepEquals, []string{"pv-1"})
+}
+
 func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoPendingCharmError(c *gc.C) {
 	s.st.app = &mockApplication{
 		life:         state.Alive,
@@ -419,59 +472,59 @@ func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithStorage
 	s.st.app.units[2].CheckCallNames(c)
 
 	s.storage.CheckCallNames(c,
-		"UnitStorageAttachments", "StorageInstance", "UnitStorageAttachments", "StorageInstance",
-		"AllFilesystems", "Volume", "SetVolumeInfo", "SetVolumeAttachmentInfo", "Volume", "SetStatus",
+		"UnitStorageAttachments", "StorageInstance", "AllStorageInstances",
+		"UnitStorageAttachments", "StorageInstance", "AllFilesystems", "Volume", "SetVolumeInfo", "SetVolumeAttachmentInfo", "Volume", "SetStatus",
 		"Volume", "SetStatus", "Filesystem", "SetFilesystemInfo", "SetFilesystemAttachmentInfo",
 		"Filesystem", "SetStatus", "Filesystem", "SetStatus")
 	s.storage.CheckCall(c, 0, "UnitStorageAttachments", names.NewUnitTag("gitlab/0"))
 	s.storage.CheckCall(c, 1, "StorageInstance", names.NewStorageTag("data/0"))
-	s.storage.CheckCall(c, 2, "UnitStorageAttachments", names.NewUnitTag("gitlab/1"))
-	s.storage.CheckCall(c, 3, "StorageInstance", names.NewStorageTag("data/1"))
+	s.storage.CheckCall(c, 3, "UnitStorageAttachments", names.NewUnitTag("gitlab/1"))
+	s.storage.CheckCall(c, 4, "StorageInstance", names.NewStorageTag("data/1"))
 
-	s.storage.CheckCall(c, 6, "SetVolumeInfo",
+	s.storage.CheckCall(c, 7, "SetVolumeInfo",
 		names.NewVolumeTag("1"),
 		state.VolumeInfo{
 			Size:       200,
 			VolumeId:   "vol-id2",
 			Persistent: true,
 		})
-	s.storage.CheckCall(c, 7, "SetVolumeAttachmentInfo",
+	s.storage.CheckCall(c, 8, "SetVolumeAttachmentInfo",
 		names.NewUnitTag("gitlab/1"), names.NewVolumeTag("1"),
 		state.VolumeAttachmentInfo{
 			ReadOnly: true,
 		})
-	s.storage.CheckCall(c, 9, "SetStatus",
+	s.storage.CheckCall(c, 10, "SetStatus",
 		status.StatusInfo{
 			Status:  status.Pending,
 			Message: "vol not ready",
 			Since:   &now,
 		})
-	s.storage.CheckCall(c, 11, "SetStatus",
+	s.storage.CheckCall(c, 12, "SetStatus",
 		status.StatusInfo{
 			Status:  status.Attached,
 			Message: "vol ready",
 			Since:   &now,
 		})
 
-	s.storage.CheckCall(c, 13, "SetFilesystemInfo",
+	s.storage.CheckCall(c, 14, "SetFilesystemInfo",
 		names.NewFilesystemTag("gitlab/1/0"),
 		state.FilesystemInfo{
 			Size:         200,
 			FilesystemId: "fs-id2",
 		})
-	s.storage.CheckCall(c, 14, "SetFilesystemAttachmentInfo",
+	s.storage.CheckCall(c, 15, "SetFilesystemAttachmentInfo",
 		names.NewUnitTag("gitlab/1"), names.NewFilesystemTag("gitlab/1/0"),
 		state.FilesystemAttachmentInfo{
 			MountPoint: "/path/to/there",
 			ReadOnly:   true,
 		})
-	s.storage.CheckCall(c, 16, "SetStatus",
+	s.storage.CheckCall(c, 17, "SetStatus",
 		status.StatusInfo{
 			Status:  status.Pending,
 			Message: "not ready",
 			Since:   &now,
 		})
-	s.storage.CheckCall(c, 18, "SetStatus",
+	s.storage.CheckCall(c, 19, "SetStatus",
 		status.StatusInfo{
 			Status:  status.Attached,
 			Message: "ready",
@@ -482,6 +535,109 @@ func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithStorage
 	s.st.model.CheckCall(c, 0, "Containers", []string{"gitlab-0", "gitlab-1"})
 }
 
+func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsImportedStorage(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:  names.NewApplicationTag("gitlab"),
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{
+				Deployment: &charm.Deployment{
+					DeploymentType: charm.DeploymentStateful,
+				},
+			},
+			url: "ch:gitlab",
+		},
+		units: []*mockUnit{
+			{
+				tag: names.NewUnitTag("gitlab/0"),
+				containerInfo: &mockCloudContainer{
+					unit:       "gitlab/0",
+					providerId: "gitlab-0",
+				},
+			},
+			{
+				tag: names.NewUnitTag("gitlab/1"),
+				containerInfo: &mockCloudContainer{
+					unit:       "gitlab/1",
+					providerId: "gitlab-1",
+				},
+			},
+		},
+	}
+	s.storage.storageInstances = []state.StorageInstance{
+		&mockStorageInstance{tag: names.NewStorageTag("data/7"), application: "gitlab"},
+		&mockStorageInstance{tag: names.NewStorageTag("data/8"), application: "gitlab"},
+	}
+	s.storage.storageFilesystems[names.NewStorageTag("data/0")] = names.NewFilesystemTag("gitlab/0/0")
+	s.storage.storageFilesystems[names.NewStorageTag("data/1")] = names.NewFilesystemTag("gitlab/1/0")
+	s.storage.storageFilesystems[names.NewStorageTag("data/7")] = names.NewFilesystemTag("7")
+	s.storage.storageVolumes[names.NewStorageTag("data/0")] = names.NewVolumeTag("0")
+	s.storage.storageVolumes[names.NewStorageTag("data/1")] = names.NewVolumeTag("1")
+	s.storage.storageVolumes[names.NewStorageTag("data/7")] = names.NewVolumeTag("7")
+	s.storage.storageVolumes[names.NewStorageTag("data/8")] = names.NewVolumeTag("8")
+	s.storage.volumeInfo = map[names.VolumeTag]state.VolumeInfo{
+		names.NewVolumeTag("7"): {VolumeId: "pv-imported", Size: 100, Persistent: true},
+		names.NewVolumeTag("8"): {VolumeId: "pv-imported2", Size: 100, Persistent: true},
+	}
+	s.storage.backingVolume = names.NewVolumeTag("7")
+	s.storage.storageAttachments[names.NewUnitTag("gitlab/0")] = names.NewStorageTag("data/0")
+	s.storage.storageAttachments[names.NewUnitTag("gitlab/1")] = names.NewStorageTag("data/1")
+	// Attaching the second imported storage fails.
+	s.storage.SetErrors(nil, errors.New("boom"))
+
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "gitlab-0", Status: "running", Stateful: true,
+			FilesystemInfo: []params.KubernetesFilesystemInfo{
+				{StorageName: "data", FilesystemId: "fs-id", Size: 100, MountPoint: "/path/to/here",
+					Status: "attached",
+					Volume: params.KubernetesVolumeInfo{
+						VolumeId: "pv-imported", Size: 100, Persistent: true,
+						Status: "attached",
+					}},
+			},
+		},
+		{ProviderId: "gitlab-1", Status: "running", Stateful: true,
+			FilesystemInfo: []params.KubernetesFilesystemInfo{
+				{StorageName: "data", FilesystemId: "fs-id2", Size: 100, MountPoint: "/path/to/there",
+					Status: "attached",
+					Volume: params.KubernetesVolumeInfo{
+						VolumeId: "pv-imported2", Size: 100, Persistent: true,
+						Status: "attached",
+					}},
+			},
+		},
+	}
+	results, err := s.api.UpdateApplicationsUnits(params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{{
+			ApplicationTag: "application-gitlab",
+			Units:          units,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	// The imported storage replaces the unit's own storage, and the
+	// observed volume is not recorded against the unit's own volume.
+	s.storage.CheckCallNames(c,
+		"UnitStorageAttachments", "StorageInstance", "AllStorageInstances", "ReplaceStorage",
+		"UnitStorageAttachments", "StorageInstance", "ReplaceStorage",
+		"AllFilesystems", "Volume", "SetVolumeAttachmentInfo", "Volume", "SetStatus",
+		"Filesystem", "SetFilesystemInfo", "SetFilesystemAttachmentInfo", "Filesystem", "SetStatus")
+	s.storage.CheckCall(c, 3, "ReplaceStorage",
+		names.NewStorageTag("data/0"), names.NewStorageTag("data/7"), names.NewUnitTag("gitlab/0"))
+	s.storage.CheckCall(c, 6, "ReplaceStorage",
+		names.NewStorageTag("data/1"), names.NewStorageTag("data/8"), names.NewUnitTag("gitlab/1"))
+	s.storage.CheckCall(c, 9, "SetVolumeAttachmentInfo",
+		names.NewUnitTag("gitlab/0"), names.NewVolumeTag("7"),
+		state.VolumeAttachmentInfo{})
+	s.storage.CheckCall(c, 13, "SetFilesystemInfo",
+		names.NewFilesystemTag("7"),
+		state.FilesystemInfo{
+			Size:         100,
+			FilesystemId: "fs-id",
+		})
+}
+
 func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithoutStorage(c *gc.C) {
 	s.st.app = &mockApplication{
 		tag:  names.NewApplicationTag("gitlab"),
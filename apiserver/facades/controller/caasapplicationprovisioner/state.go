@@ -145,6 +145,7 @@ func (a *applicationShim) AllUnits() ([]Unit, error) {
 // functionality required by the CAAS app provisioner facade.
 type StorageBackend interface {
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
+	AllStorageInstances() ([]state.StorageInstance, error)
 	Filesystem(names.FilesystemTag) (state.Filesystem, error)
 	StorageInstanceFilesystem(names.StorageTag) (state.Filesystem, error)
 	UnitStorageAttachments(unit names.UnitTag) ([]state.StorageAttachment, error)
@@ -155,6 +156,11 @@ type StorageBackend interface {
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.Tag, names.VolumeTag, state.VolumeAttachmentInfo) error
 
+	// ReplaceStorage attaches imported storage to a unit in place of
+	// the unit's own storage, when the cloud binds the imported volume
+	// to the unit.
+	ReplaceStorage(storage, replacement names.StorageTag, unit names.UnitTag) error
+
 	// These are for cleanup up orphaned filesystems when pods are recreated.
 	// TODO(caas) - record unit id on the filesystem so we can query by unit
 	AllFilesystems() ([]state.Filesystem, error)
@@ -11,26 +11,43 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	charmscommon "github.com/juju/juju/apiserver/common/charms"
+	"github.com/juju/juju/apiserver/common/firewall"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
+// Facade provides access to the CAASFirewaller API facade.
 type Facade struct {
 	*common.LifeGetter
 	*common.AgentEntityWatcher
+	*common.ModelWatcher
 	resources       facade.Resources
 	state           CAASFirewallerState
 	charmInfoAPI    *charmscommon.CharmInfoAPI
 	appCharmInfoAPI *charmscommon.ApplicationCharmInfoAPI
 }
 
+// FacadeV1 provides v1 of the CAASFirewaller API facade, which has no
+// access to the model config.
+type FacadeV1 struct {
+	*Facade
+}
+
+// ModelConfig isn't on the v1 API.
+func (*FacadeV1) ModelConfig(_, _ struct{}) {}
+
+// WatchForModelConfigChanges isn't on the v1 API.
+func (*FacadeV1) WatchForModelConfigChanges(_, _ struct{}) {}
+
 func newFacadeLegacy(
 	resources facade.Resources,
 	authorizer facade.Authorizer,
 	st CAASFirewallerState,
+	model state.ModelAccessor,
 	charmInfoAPI *charmscommon.CharmInfoAPI,
 	appCharmInfoAPI *charmscommon.ApplicationCharmInfoAPI,
 ) (*Facade, error) {
@@ -46,10 +63,13 @@ func newFacadeLegacy(
 			),
 		),
 		AgentEntityWatcher: common.NewAgentEntityWatcher(
-			st,
+			firewall.EgressWatchingEntityFinder(st),
 			resources,
 			accessApplication,
 		),
+		// ModelConfig() and WatchForModelConfigChanges() are used to
+		// apply the model's exposed ingress allowlists.
+		ModelWatcher:    common.NewModelWatcher(model, resources, authorizer),
 		resources:       resources,
 		state:           st,
 		charmInfoAPI:    charmInfoAPI,
@@ -141,6 +161,12 @@ type FacadeSidecar struct {
 	accessModel common.GetAuthFunc
 }
 
+// ModelConfig isn't on the sidecar API.
+func (*FacadeSidecar) ModelConfig(_, _ struct{}) {}
+
+// WatchForModelConfigChanges isn't on the sidecar API.
+func (*FacadeSidecar) WatchForModelConfigChanges(_, _ struct{}) {}
+
 func newFacadeSidecar(
 	resources facade.Resources,
 	authorizer facade.Authorizer,
@@ -163,7 +189,7 @@ func newFacadeSidecar(
 				),
 			),
 			AgentEntityWatcher: common.NewAgentEntityWatcher(
-				st,
+				firewall.EgressWatchingEntityFinder(st),
 				resources,
 				accessApplication,
 			),
@@ -17,6 +17,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
+	environsconfig "github.com/juju/juju/environs/config"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -30,6 +31,7 @@ type firewallerBaseSuite struct {
 	applicationsChanges chan []string
 	openPortsChanges    chan []string
 	appExposedChanges   chan struct{}
+	modelConfigChanges  chan struct{}
 
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
@@ -60,6 +62,7 @@ var _ = gc.Suite(&firewallerLegacySuite{
 				resources,
 				authorizer,
 				st,
+				&st.model,
 				commonCharmsAPI,
 				appCharmInfoAPI,
 			)
@@ -95,6 +98,27 @@ var _ = gc.Suite(&firewallerSidecarSuite{
 	},
 })
 
+func (s *firewallerLegacySuite) TestModelConfig(c *gc.C) {
+	facade, ok := s.facade.(*caasfirewaller.Facade)
+	c.Assert(ok, jc.IsTrue)
+
+	result, err := facade.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config[environsconfig.ExposedIngressAllowKey], gc.Equals, "10.0.0.0/8")
+}
+
+func (s *firewallerLegacySuite) TestWatchForModelConfigChanges(c *gc.C) {
+	facade, ok := s.facade.(*caasfirewaller.Facade)
+	c.Assert(ok, jc.IsTrue)
+	s.modelConfigChanges <- struct{}{}
+
+	result, err := facade.WatchForModelConfigChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Get("1"), gc.Equals, s.st.model.configWatcher)
+}
+
 func (s *firewallerSidecarSuite) SetUpTest(c *gc.C) {
 	s.firewallerBaseSuite.SetUpTest(c)
 
@@ -190,7 +214,12 @@ func (s *firewallerBaseSuite) SetUpTest(c *gc.C) {
 	s.applicationsChanges = make(chan []string, 1)
 	s.appExposedChanges = make(chan struct{}, 1)
 	s.openPortsChanges = make(chan []string, 1)
+	s.modelConfigChanges = make(chan struct{}, 1)
 	appExposedWatcher := statetesting.NewMockNotifyWatcher(s.appExposedChanges)
+	modelConfig, err := environsconfig.New(environsconfig.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		environsconfig.ExposedIngressAllowKey: "10.0.0.0/8",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
 	s.st = &mockState{
 		application: mockApplication{
 			life:    state.Alive,
@@ -206,8 +235,13 @@ func (s *firewallerBaseSuite) SetUpTest(c *gc.C) {
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		openPortsWatcher:    statetesting.NewMockStringsWatcher(s.openPortsChanges),
 		appExposedWatcher:   appExposedWatcher,
+		model: mockModel{
+			config:        modelConfig,
+			configWatcher: statetesting.NewMockNotifyWatcher(s.modelConfigChanges),
+		},
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.model.configWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.openPortsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.appExposedWatcher) })
 
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/network"
+	environsconfig "github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -22,6 +23,7 @@ type mockState struct {
 	applicationsWatcher *statetesting.MockStringsWatcher
 	openPortsWatcher    *statetesting.MockStringsWatcher
 	appExposedWatcher   *statetesting.MockNotifyWatcher
+	model               mockModel
 }
 
 func (st *mockState) WatchApplications() state.StringsWatcher {
@@ -66,6 +68,25 @@ func (st *mockState) Model() (*state.Model, error) {
 	return nil, nil
 }
 
+type mockModel struct {
+	testing.Stub
+	config        *environsconfig.Config
+	configWatcher *statetesting.MockNotifyWatcher
+}
+
+func (m *mockModel) ModelConfig() (*environsconfig.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.config, nil
+}
+
+func (m *mockModel) WatchForModelConfigChanges() state.NotifyWatcher {
+	m.MethodCall(m, "WatchForModelConfigChanges")
+	return m.configWatcher
+}
+
 type mockApplication struct {
 	testing.Stub
 	state.Entity // Pull in Tag method (which tests don't use)
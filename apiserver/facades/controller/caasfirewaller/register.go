@@ -15,6 +15,9 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("CAASFirewaller", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return newStateFacadeLegacyV1(ctx)
+	}, reflect.TypeOf((*FacadeV1)(nil)))
+	registry.MustRegister("CAASFirewaller", 2, func(ctx facade.Context) (facade.Facade, error) {
 		return newStateFacadeLegacy(ctx)
 	}, reflect.TypeOf((*Facade)(nil)))
 
@@ -23,6 +26,15 @@ func Register(registry facade.FacadeRegistry) {
 	}, reflect.TypeOf((*FacadeSidecar)(nil)))
 }
 
+// newStateFacadeLegacyV1 provides the signature required for facade registration.
+func newStateFacadeLegacyV1(ctx facade.Context) (*FacadeV1, error) {
+	api, err := newStateFacadeLegacy(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{api}, nil
+}
+
 // newStateFacadeLegacy provides the signature required for facade registration.
 func newStateFacadeLegacy(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -37,11 +49,16 @@ func newStateFacadeLegacy(ctx facade.Context) (*Facade, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	model, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	return newFacadeLegacy(
 		resources,
 		authorizer,
 		&stateShim{ctx.State()},
+		model,
 		charmInfoAPI,
 		appCharmInfoAPI,
 	)
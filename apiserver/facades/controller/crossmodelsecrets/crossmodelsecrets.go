@@ -15,6 +15,7 @@ import (
 	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/apiserver/common/crossmodel"
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	corelogger "github.com/juju/juju/core/logger"
@@ -25,6 +26,7 @@ import (
 )
 
 type backendConfigGetter func(modelUUID string, sameController bool, backendID string, consumer names.Tag) (*provider.ModelBackendConfigInfo, error)
+type adminBackendConfigGetter func(modelUUID string) (*provider.ModelBackendConfigInfo, error)
 type secretStateGetter func(modelUUID string) (SecretsState, SecretsConsumer, func() bool, error)
 
 // CrossModelSecretsAPI provides access to the CrossModelSecrets API facade.
@@ -39,6 +41,7 @@ type CrossModelSecretsAPI struct {
 
 	secretsStateGetter  secretStateGetter
 	backendConfigGetter backendConfigGetter
+	adminConfigGetter   adminBackendConfigGetter
 	crossModelState     CrossModelState
 	stateBackend        StateBackend
 }
@@ -51,6 +54,7 @@ func NewCrossModelSecretsAPI(
 	modelUUID string,
 	secretsStateGetter secretStateGetter,
 	backendConfigGetter backendConfigGetter,
+	adminConfigGetter adminBackendConfigGetter,
 	crossModelState CrossModelState,
 	stateBackend StateBackend,
 ) (*CrossModelSecretsAPI, error) {
@@ -62,6 +66,7 @@ func NewCrossModelSecretsAPI(
 		modelUUID:           modelUUID,
 		secretsStateGetter:  secretsStateGetter,
 		backendConfigGetter: backendConfigGetter,
+		adminConfigGetter:   adminConfigGetter,
 		crossModelState:     crossModelState,
 		stateBackend:        stateBackend,
 	}, nil
@@ -224,6 +229,11 @@ func (s *CrossModelSecretsAPI) getSecretContent(arg params.GetRemoteSecretConten
 	}
 
 	val, valueRef, err := secretState.GetSecretValue(uri, wantRevision)
+	if err == nil {
+		val, valueRef, err = commonsecrets.ResolveControllerServedContent(func() (*provider.ModelBackendConfigInfo, error) {
+			return s.adminConfigGetter(uri.SourceUUID)
+		}, val, valueRef)
+	}
 	content := &secrets.ContentParams{SecretValue: val, ValueRef: valueRef}
 	if err != nil || content.ValueRef == nil {
 		return content, nil, latestRevision, errors.Trace(err)
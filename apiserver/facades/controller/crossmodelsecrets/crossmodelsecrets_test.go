@@ -25,6 +25,7 @@ import (
 	coresecrets "github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/secrets/provider"
+	_ "github.com/juju/juju/secrets/provider/all"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -125,6 +126,22 @@ func (s *CrossModelSecretsSuite) setup(c *gc.C) *gomock.Controller {
 			},
 		}, nil
 	}
+	adminConfigGetter := func(modelUUID string) (*provider.ModelBackendConfigInfo, error) {
+		return &provider.ModelBackendConfigInfo{
+			ActiveID: "active-id",
+			Configs: map[string]provider.ModelBackendConfig{
+				"backend-id": {
+					ControllerUUID: coretesting.ControllerTag.Id(),
+					ModelUUID:      modelUUID,
+					ModelName:      "fred",
+					BackendConfig: provider.BackendConfig{
+						BackendType: "vault",
+						Config:      map[string]interface{}{"foo": "admin"},
+					},
+				},
+			},
+		}, nil
+	}
 	var err error
 	s.facade, err = crossmodelsecrets.NewCrossModelSecretsAPI(
 		s.resources,
@@ -133,6 +150,7 @@ func (s *CrossModelSecretsSuite) setup(c *gc.C) *gomock.Controller {
 		coretesting.ModelTag.Id(),
 		secretsStateGetter,
 		backendConfigGetter,
+		adminConfigGetter,
 		s.crossModelState,
 		s.stateBackend,
 	)
@@ -18,6 +18,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/network"
+	corefirewall "github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
@@ -50,7 +51,12 @@ type FirewallerAPI struct {
 	appEndpointBindings map[string]map[string]string
 }
 
-// NewStateFirewallerAPI creates a new server-side FirewallerAPIV7 facade.
+// FirewallerAPIV7 provides access to the Firewaller API facade, version 7.
+type FirewallerAPIV7 struct {
+	*FirewallerAPI
+}
+
+// NewStateFirewallerAPI creates a new server-side FirewallerAPIV8 facade.
 func NewStateFirewallerAPI(
 	st State,
 	resources facade.Resources,
@@ -82,9 +88,10 @@ func NewStateFirewallerAPI(
 		resources,
 		authorizer,
 	)
-	// Watch() is supported for applications only.
+	// Watch() is supported for applications only, and also reports
+	// changes to the egress rules in their application config.
 	entityWatcher := common.NewAgentEntityWatcher(
-		st,
+		firewall.EgressWatchingEntityFinder(st),
 		resources,
 		accessApplication,
 	)
@@ -607,6 +614,84 @@ func (f *FirewallerAPI) GetExposeInfo(args params.Entities) (params.ExposeInfoRe
 	return result, nil
 }
 
+// GetEgressRules returns the rules restricting the outbound traffic of
+// the specified applications, as held in their application config, along
+// with the essential rules that keep their units' agents working. An
+// application with no rules may send traffic anywhere.
+func (f *FirewallerAPI) GetEgressRules(args params.Entities) (params.StringsResults, error) {
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+
+	var (
+		controllerAddrs []string
+		fetchedAddrs    bool
+	)
+	controllerAddresses := func() ([]string, error) {
+		if fetchedAddrs {
+			return controllerAddrs, nil
+		}
+		infos, err := f.ControllerAPIInfoForModels(params.Entities{
+			Entities: []params.Entity{{Tag: names.NewModelTag(f.st.ModelUUID()).String()}},
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(infos.Results) != 1 {
+			return nil, errors.Errorf("expected 1 result, got %d", len(infos.Results))
+		}
+		if err := infos.Results[0].Error; err != nil {
+			return nil, errors.Trace(err)
+		}
+		controllerAddrs, fetchedAddrs = infos.Results[0].Addresses, true
+		return controllerAddrs, nil
+	}
+
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		appConfig, err := application.ApplicationConfig()
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		rules, err := corefirewall.ParseEgressRules(appConfig.GetString(corefirewall.EgressAllowKey, ""))
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if len(rules) > 0 {
+			addrs, err := controllerAddresses()
+			if err == nil {
+				rules, err = rules.WithEssentials(addrs)
+			}
+			if err != nil {
+				result.Results[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
+		}
+		for _, rule := range rules {
+			result.Results[i].Result = append(result.Results[i].Result, rule.String())
+		}
+	}
+	return result, nil
+}
+
+// GetEgressRules isn't on the v7 API.
+func (f *FirewallerAPIV7) GetEgressRules(_, _ struct{}) {}
+
 // SpaceInfos returns a comprehensive representation of either all spaces or
 // a filtered subset of the known spaces and their associated subnet details.
 func (f *FirewallerAPI) SpaceInfos(args params.SpaceInfosParams) (params.SpaceInfos, error) {
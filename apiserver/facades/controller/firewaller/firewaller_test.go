@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/cloudspec"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/facades/controller/firewaller/mocks"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreconfig "github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
@@ -31,7 +33,8 @@ type firewallerSuite struct {
 	firewaller *firewaller.FirewallerAPI
 	subnet     *state.Subnet
 
-	ctrl *gomock.Controller
+	ctrl                *gomock.Controller
+	controllerConfigAPI *mocks.MockControllerConfigAPI
 }
 
 var _ = gc.Suite(&firewallerSuite{})
@@ -53,14 +56,14 @@ func (s *firewallerSuite) SetUpTest(c *gc.C) {
 	)
 
 	s.ctrl = gomock.NewController(c)
-	controllerConfigAPI := mocks.NewMockControllerConfigAPI(s.ctrl)
+	s.controllerConfigAPI = mocks.NewMockControllerConfigAPI(s.ctrl)
 	// Create a firewaller API for the machine.
 	firewallerAPI, err := firewaller.NewStateFirewallerAPI(
 		firewaller.StateShim(s.State, s.Model),
 		s.resources,
 		s.authorizer,
 		cloudSpecAPI,
-		controllerConfigAPI,
+		s.controllerConfigAPI,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.firewaller = firewallerAPI
@@ -273,6 +276,46 @@ func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
 	})
 }
 
+func (s *firewallerSuite) TestGetEgressRules(c *gc.C) {
+	defer s.ctrl.Finish()
+
+	err := s.application.UpdateApplicationConfig(coreconfig.ConfigAttributes{
+		"egress-allow": "10.0.0.0/8 0.0.0.0/0:443",
+	}, nil, environschema.Fields{
+		"egress-allow": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.controllerConfigAPI.EXPECT().ControllerAPIInfoForModels(params.Entities{
+		Entities: []params.Entity{{Tag: s.Model.ModelTag().String()}},
+	}).Return(params.ControllerAPIInfoResults{
+		Results: []params.ControllerAPIInfoResult{{Addresses: []string{"10.1.0.1:17070"}}},
+	}, nil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+	result, err := s.firewaller.GetEgressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			// The essential rules for DNS, the metadata service and
+			// the controller API are always allowed.
+			{Result: []string{
+				"0.0.0.0/0:53/tcp", "0.0.0.0/0:443/tcp", "0.0.0.0/0:53/udp",
+				"10.0.0.0/8", "10.1.0.1/32:17070/tcp", "169.254.169.254/32",
+				"::/0:53/tcp", "::/0:53/udp",
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
 	defer s.ctrl.Finish()
 
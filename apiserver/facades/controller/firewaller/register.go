@@ -18,11 +18,23 @@ import (
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("Firewaller", 7, func(ctx facade.Context) (facade.Facade, error) {
 		return newFirewallerAPIV7(ctx)
+	}, reflect.TypeOf((*FirewallerAPIV7)(nil)))
+	registry.MustRegister("Firewaller", 8, func(ctx facade.Context) (facade.Facade, error) {
+		return newFirewallerAPIV8(ctx)
 	}, reflect.TypeOf((*FirewallerAPI)(nil)))
 }
 
 // newFirewallerAPIV7 creates a new server-side FirewallerAPIv7 facade.
-func newFirewallerAPIV7(context facade.Context) (*FirewallerAPI, error) {
+func newFirewallerAPIV7(context facade.Context) (*FirewallerAPIV7, error) {
+	api, err := newFirewallerAPIV8(context)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FirewallerAPIV7{api}, nil
+}
+
+// newFirewallerAPIV8 creates a new server-side FirewallerAPIv8 facade.
+func newFirewallerAPIV8(context facade.Context) (*FirewallerAPI, error) {
 	st := context.State()
 	m, err := st.Model()
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/juju/description/v7"
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 
@@ -42,7 +43,7 @@ type API struct {
 
 // APIV1 implements the V1 version of the API facade.
 type APIV1 struct {
-	*API
+	*APIV3
 }
 
 // APIV2 implements the V2 version of the API facade.
@@ -50,6 +51,11 @@ type APIV2 struct {
 	*APIV1
 }
 
+// APIV3 implements the V3 version of the API facade.
+type APIV3 struct {
+	*API
+}
+
 // NewAPI returns a new APIV1. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(
@@ -87,43 +93,121 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
+	if err := api.checkFacadeVersions(model); err != nil {
+		return errors.Trace(err)
+	}
+	backend, modelInfo, presence, err := api.precheckArgs(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return migration.TargetPrecheck(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		presence,
+	)
+}
+
+// The checks run by a migration dry run on the target controller, used
+// to categorise the blockers found.
+const (
+	dryRunCheckTarget = "target"
+	dryRunCheckImport = "import"
+)
+
+// DryRun runs the checks that the target controller makes before and
+// while importing a model, without importing it. Rather than failing on
+// the first issue found, every blocker is reported.
+func (api *API) DryRun(model params.MigrationModelInfo) (params.MigrationDryRunResult, error) {
+	result := params.MigrationDryRunResult{
+		ModelTag: names.NewModelTag(model.UUID).String(),
+	}
+	addBlocker := func(check string, err error) {
+		result.Blockers = append(result.Blockers, params.MigrationBlocker{
+			Check:   check,
+			Message: err.Error(),
+		})
+	}
+
+	if err := api.checkFacadeVersions(model); err != nil {
+		addBlocker(dryRunCheckTarget, err)
+	}
+	backend, modelInfo, presence, err := api.precheckArgs(model)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, err := range migration.TargetPrecheckAll(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		presence,
+	) {
+		addBlocker(dryRunCheckTarget, err)
+	}
+
+	desc, err := description.Deserialize(model.ModelDescription)
+	if err != nil {
+		addBlocker(dryRunCheckImport, errors.Annotate(err, "deserializing model"))
+		return result, nil
+	}
+	problems, err := state.NewController(api.pool).ValidateImport(desc)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, err := range problems {
+		addBlocker(dryRunCheckImport, err)
+	}
+	return result, nil
+}
+
+// checkFacadeVersions ensures that the source controller supports the
+// facades required to migrate to this controller.
+func (api *API) checkFacadeVersions(model params.MigrationModelInfo) error {
 	// If there are no required migration facade versions, then we
 	// don't need to check anything.
-	if len(api.requiredMigrationFacadeVersions) > 0 {
-		// Ensure that when attempting to migrate a model, the source
-		// controller has the required facades for the migration.
-		sourceFacadeVersions := facades.FacadeVersions{}
-		for name, versions := range model.FacadeVersions {
-			sourceFacadeVersions[name] = versions
-		}
-		if !facades.CompleteIntersection(api.requiredMigrationFacadeVersions, sourceFacadeVersions) {
-			majorMinor := fmt.Sprintf("%d.%d",
-				model.ControllerAgentVersion.Major,
-				model.ControllerAgentVersion.Minor,
-			)
-
-			// If the patch is zero, then we don't need to mention it.
-			var patchMessage string
-			if model.ControllerAgentVersion.Patch > 0 {
-				patchMessage = fmt.Sprintf(", that is greater than %s.%d", majorMinor, model.ControllerAgentVersion.Patch)
-			}
-
-			return errors.Errorf(`
+	if len(api.requiredMigrationFacadeVersions) == 0 {
+		return nil
+	}
+	// Ensure that when attempting to migrate a model, the source
+	// controller has the required facades for the migration.
+	sourceFacadeVersions := facades.FacadeVersions{}
+	for name, versions := range model.FacadeVersions {
+		sourceFacadeVersions[name] = versions
+	}
+	if facades.CompleteIntersection(api.requiredMigrationFacadeVersions, sourceFacadeVersions) {
+		return nil
+	}
+	majorMinor := fmt.Sprintf("%d.%d",
+		model.ControllerAgentVersion.Major,
+		model.ControllerAgentVersion.Minor,
+	)
+
+	// If the patch is zero, then we don't need to mention it.
+	var patchMessage string
+	if model.ControllerAgentVersion.Patch > 0 {
+		patchMessage = fmt.Sprintf(", that is greater than %s.%d", majorMinor, model.ControllerAgentVersion.Patch)
+	}
+
+	return errors.Errorf(`
 Source controller does not support required facades for performing migration.
 Upgrade the controller to a newer version of %s%s or migrate to a controller
 with an earlier version of the target controller and try again.
 
 `[1:], majorMinor, patchMessage)
-		}
-	}
+}
 
+// precheckArgs returns the arguments needed to run the target
+// prechecks for the model.
+func (api *API) precheckArgs(model params.MigrationModelInfo) (
+	migration.PrecheckBackend, coremigration.ModelInfo, migration.ModelPresence, error,
+) {
 	ownerTag, err := names.ParseUserTag(model.OwnerTag)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, coremigration.ModelInfo{}, nil, errors.Trace(err)
 	}
 	controllerState, err := api.pool.SystemState()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, coremigration.ModelInfo{}, nil, errors.Trace(err)
 	}
 	// NOTE (thumper): it isn't clear to me why api.state would be different
 	// from the controllerState as I had thought that the Precheck call was
@@ -131,20 +215,16 @@ with an earlier version of the target controller and try again.
 	// controllerState.
 	backend, err := migration.PrecheckShim(api.state, controllerState)
 	if err != nil {
-		return errors.Annotate(err, "creating backend")
+		return nil, coremigration.ModelInfo{}, nil, errors.Annotate(err, "creating backend")
 	}
-	return migration.TargetPrecheck(
-		backend,
-		migration.PoolShim(api.pool),
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
-		api.presence.ModelPresence(controllerState.ModelUUID()),
-	)
+	modelInfo := coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+	}
+	return backend, modelInfo, api.presence.ModelPresence(controllerState.ModelUUID()), nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
@@ -392,3 +472,6 @@ func (api *API) CACert() (params.BytesResult, error) {
 	caCert, _ := cfg.CACert()
 	return params.BytesResult{Result: []byte(caCert)}, nil
 }
+
+// DryRun isn't on the v3 API.
+func (api *APIV3) DryRun(_, _ struct{}) {}
@@ -81,7 +81,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 }
 
 func (s *Suite) TestFacadeRegistered(c *gc.C) {
-	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 4)
 	c.Assert(err, jc.ErrorIsNil)
 
 	api, err := aFactory(&facadetest.Context{
@@ -93,6 +93,19 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
+func (s *Suite) TestFacadeRegisteredV3(c *gc.C) {
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := aFactory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV3))
+}
+
 func (s *Suite) TestFacadeRegisteredV2(c *gc.C) {
 	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 2)
 	c.Assert(err, jc.ErrorIsNil)
@@ -192,6 +205,78 @@ with an earlier version of the target controller and try again.
 `[1:])
 }
 
+func (s *Suite) TestDryRun(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	args := params.MigrationModelInfo{
+		UUID:                   uuid,
+		Name:                   "some-model",
+		OwnerTag:               s.Owner.String(),
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+		ModelDescription:       bytes,
+	}
+	result, err := api.DryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.ModelTag, gc.Equals, names.NewModelTag(uuid).String())
+	c.Assert(result.Blockers, gc.HasLen, 0)
+
+	// Nothing is imported.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exists, jc.IsFalse)
+}
+
+func (s *Suite) TestDryRunReportsAllBlockers(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+
+	api := s.mustNewAPIWithFacadeVersions(c, facades.FacadeVersions{
+		"MigrationTarget": []int{1},
+	})
+	// The model being migrated already exists on this controller.
+	model, err := s.State.Export(s.leaders)
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.MigrationModelInfo{
+		UUID:                   s.State.ModelUUID(),
+		Name:                   "some-model",
+		OwnerTag:               s.Owner.String(),
+		AgentVersion:           controllerVersion,
+		ControllerAgentVersion: controllerVersion,
+		ModelDescription:       bytes,
+	}
+	result, err := api.DryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var checks []string
+	for _, blocker := range result.Blockers {
+		checks = append(checks, blocker.Check)
+	}
+	c.Assert(checks, jc.DeepEquals, []string{"target", "target", "import"})
+	c.Check(result.Blockers[0].Message, gc.Matches, "(?s)Source controller does not support required facades.*")
+	c.Check(result.Blockers[1].Message, gc.Matches, `model with same UUID already exists \(.*\)`)
+	c.Check(result.Blockers[2].Message, gc.Matches, "model .* already exists")
+}
+
+func (s *Suite) TestDryRunInvalidModelDescription(c *gc.C) {
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:                   utils.MustNewUUID().String(),
+		Name:                   "some-model",
+		OwnerTag:               s.Owner.String(),
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+		ModelDescription:       []byte("not a model"),
+	}
+	result, err := api.DryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Blockers, gc.HasLen, 1)
+	c.Assert(result.Blockers[0].Check, gc.Equals, "import")
+	c.Assert(result.Blockers[0].Message, gc.Matches, "deserializing model: .*")
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -25,6 +25,9 @@ func Register(requiredMigrationFacadeVersions facades.FacadeVersions) func(regis
 			return newFacadeV2(ctx)
 		}, reflect.TypeOf((*APIV2)(nil)))
 		registry.MustRegister("MigrationTarget", 3, func(ctx facade.Context) (facade.Facade, error) {
+			return newFacadeV3(ctx, requiredMigrationFacadeVersions)
+		}, reflect.TypeOf((*APIV3)(nil)))
+		registry.MustRegister("MigrationTarget", 4, func(ctx facade.Context) (facade.Facade, error) {
 			return newFacade(ctx, requiredMigrationFacadeVersions)
 		}, reflect.TypeOf((*API)(nil)))
 	}
@@ -41,7 +44,7 @@ func newFacadeV1(ctx facade.Context) (*APIV1, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &APIV1{API: api}, nil
+	return &APIV1{APIV3: &APIV3{API: api}}, nil
 }
 
 // newFacadeV2 is used for APIV2 registration.
@@ -55,7 +58,16 @@ func newFacadeV2(ctx facade.Context) (*APIV2, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &APIV2{APIV1: &APIV1{API: api}}, nil
+	return &APIV2{APIV1: &APIV1{APIV3: &APIV3{API: api}}}, nil
+}
+
+// newFacadeV3 is used for APIV3 registration.
+func newFacadeV3(ctx facade.Context, facadeVersions facades.FacadeVersions) (*APIV3, error) {
+	api, err := newFacade(ctx, facadeVersions)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV3{API: api}, nil
 }
 
 // newFacade is used for API registration.
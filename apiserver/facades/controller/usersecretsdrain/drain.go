@@ -25,6 +25,7 @@ type SecretsDrainAPI struct {
 
 	drainConfigGetter   commonsecrets.BackendDrainConfigGetter
 	backendConfigGetter commonsecrets.BackendConfigGetter
+	adminConfigGetter   commonsecrets.BackendAdminConfigGetter
 }
 
 // GetSecretBackendConfigs gets the config needed to create a client to secret backends for the drain worker.
@@ -122,6 +123,10 @@ func (s *SecretsDrainAPI) getSecretContent(arg params.GetSecretContentArg) (
 	if err != nil {
 		return nil, nil, false, errors.Trace(err)
 	}
+	val, valueRef, err = commonsecrets.ResolveControllerServedContent(s.adminConfigGetter, val, valueRef)
+	if err != nil {
+		return nil, nil, false, errors.Trace(err)
+	}
 	content := &secrets.ContentParams{SecretValue: val, ValueRef: valueRef}
 	if content.ValueRef == nil {
 		// Internal secret.
@@ -168,6 +173,11 @@ func (s *SecretsDrainAPI) GetSecretRevisionContentInfo(arg params.SecretRevision
 			result.Results[i].Error = apiservererrors.ServerError(err)
 			continue
 		}
+		val, valueRef, err = commonsecrets.ResolveControllerServedContent(s.adminConfigGetter, val, valueRef)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
 		contentParams := params.SecretContentParams{}
 		if valueRef != nil {
 			contentParams.ValueRef = &params.SecretValueRef{
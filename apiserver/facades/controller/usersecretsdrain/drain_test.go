@@ -10,6 +10,8 @@ import (
 	"go.uber.org/mock/gomock"
 	gc "gopkg.in/check.v1"
 
+	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
+	secretsmocks "github.com/juju/juju/apiserver/common/secrets/mocks"
 	facademocks "github.com/juju/juju/apiserver/facade/mocks"
 	"github.com/juju/juju/apiserver/facades/controller/usersecretsdrain"
 	"github.com/juju/juju/apiserver/facades/controller/usersecretsdrain/mocks"
@@ -74,8 +76,15 @@ func (s *drainSuite) setup(c *gc.C) *gomock.Controller {
 		}, nil
 	}
 
+	adminConfigGetter := func() (*provider.ModelBackendConfigInfo, error) {
+		return drainConfigGetter("")
+	}
+	s.PatchValue(&commonsecrets.GetProvider, func(string) (provider.SecretBackendProvider, error) {
+		return secretsmocks.NewMockSecretBackendProvider(ctrl), nil
+	})
+
 	var err error
-	s.facade, err = usersecretsdrain.NewTestAPI(s.authorizer, s.secretsState, backendConfigGetter, drainConfigGetter)
+	s.facade, err = usersecretsdrain.NewTestAPI(s.authorizer, s.secretsState, backendConfigGetter, drainConfigGetter, adminConfigGetter)
 	c.Assert(err, jc.ErrorIsNil)
 
 	return ctrl
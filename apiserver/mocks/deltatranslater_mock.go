@@ -194,6 +194,34 @@ func (mr *MockDeltaTranslaterMockRecorder) TranslateRemoteApplication(arg0 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateRemoteApplication", reflect.TypeOf((*MockDeltaTranslater)(nil).TranslateRemoteApplication), arg0)
 }
 
+// TranslateSecret mocks base method.
+func (m *MockDeltaTranslater) TranslateSecret(arg0 multiwatcher.EntityInfo) params.EntityInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TranslateSecret", arg0)
+	ret0, _ := ret[0].(params.EntityInfo)
+	return ret0
+}
+
+// TranslateSecret indicates an expected call of TranslateSecret.
+func (mr *MockDeltaTranslaterMockRecorder) TranslateSecret(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateSecret", reflect.TypeOf((*MockDeltaTranslater)(nil).TranslateSecret), arg0)
+}
+
+// TranslateStorageInstance mocks base method.
+func (m *MockDeltaTranslater) TranslateStorageInstance(arg0 multiwatcher.EntityInfo) params.EntityInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TranslateStorageInstance", arg0)
+	ret0, _ := ret[0].(params.EntityInfo)
+	return ret0
+}
+
+// TranslateStorageInstance indicates an expected call of TranslateStorageInstance.
+func (mr *MockDeltaTranslaterMockRecorder) TranslateStorageInstance(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateStorageInstance", reflect.TypeOf((*MockDeltaTranslater)(nil).TranslateStorageInstance), arg0)
+}
+
 // TranslateUnit mocks base method.
 func (m *MockDeltaTranslater) TranslateUnit(arg0 multiwatcher.EntityInfo) params.EntityInfo {
 	m.ctrl.T.Helper()
@@ -58,7 +58,15 @@ func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 			}
 		}
 		if permission.IsEmptyUserAccess(controllerAccess) {
-			return nil, errors.NotFoundf("model or controller user")
+			// The user may still have been granted access through
+			// one of their groups.
+			hasAccess, err := hasGroupAccess(f.st, utag, model.ModelTag())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !hasAccess {
+				return nil, errors.NotFoundf("model or controller user")
+			}
 		}
 	}
 
@@ -77,6 +85,21 @@ func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 	return u, nil
 }
 
+// hasGroupAccess returns true if any of the user's groups have been
+// granted access to the model or the controller.
+func hasGroupAccess(st *state.State, user names.UserTag, modelTag names.ModelTag) (bool, error) {
+	for _, target := range []names.Tag{modelTag, st.ControllerTag()} {
+		access, err := st.UserPermission(user, target)
+		if err != nil && !errors.Is(err, errors.NotFound) {
+			return false, errors.Trace(err)
+		}
+		if access != permission.NoAccess {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // modelUserEntity encapsulates a model user
 // and, if the user is local, the local state user
 // as well. This enables us to implement FindEntity
@@ -0,0 +1,209 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	gc "gopkg.in/check.v1"
+)
+
+// FakeOIDCIssuer is a minimal OpenID Connect issuer, supporting
+// discovery, key retrieval and the device authorization grant.
+type FakeOIDCIssuer struct {
+	srv        *httptest.Server
+	clientID   string
+	keySet     jwk.Set
+	signingKey jwk.Key
+
+	mu sync.Mutex
+	// discoveries counts the requests for the discovery document.
+	discoveries int
+	// unavailable, if set, fails requests for the discovery document.
+	unavailable bool
+	// block, if set, holds up requests for the discovery document
+	// until it is closed.
+	block chan struct{}
+	// tokenRequests counts the requests to the token endpoint.
+	tokenRequests int
+	// tokenError, if set, is returned by the token endpoint.
+	tokenError string
+	// idToken is returned by the token endpoint.
+	idToken string
+}
+
+// NewFakeOIDCIssuer starts a fake issuer accepting device logins
+// for the given client ID. The issuer must be closed when done with.
+func NewFakeOIDCIssuer(c *gc.C, clientID string) *FakeOIDCIssuer {
+	keySet, signingKey, err := NewJWKSet()
+	c.Assert(err, jc.ErrorIsNil)
+	pubKey, ok := keySet.Key(0)
+	c.Assert(ok, jc.IsTrue)
+	err = signingKey.Set(jwk.KeyIDKey, pubKey.KeyID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	issuer := &FakeOIDCIssuer{
+		clientID:   clientID,
+		keySet:     keySet,
+		signingKey: signingKey,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("/jwks", issuer.jwks)
+	mux.HandleFunc("/device", issuer.device)
+	mux.HandleFunc("/token", issuer.token)
+	issuer.srv = httptest.NewServer(mux)
+	return issuer
+}
+
+// URL returns the issuer URL.
+func (i *FakeOIDCIssuer) URL() string {
+	return i.srv.URL
+}
+
+// Client returns an HTTP client for talking to the issuer.
+func (i *FakeOIDCIssuer) Client() *http.Client {
+	return i.srv.Client()
+}
+
+// Close stops the issuer.
+func (i *FakeOIDCIssuer) Close() {
+	i.srv.Close()
+}
+
+// DiscoveryCount returns the number of requests made for the
+// discovery document.
+func (i *FakeOIDCIssuer) DiscoveryCount() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.discoveries
+}
+
+// TokenRequests returns the number of requests made to the token
+// endpoint.
+func (i *FakeOIDCIssuer) TokenRequests() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.tokenRequests
+}
+
+// SetUnavailable sets whether requests for the discovery document fail.
+func (i *FakeOIDCIssuer) SetUnavailable(unavailable bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.unavailable = unavailable
+}
+
+// SetBlock holds up requests for the discovery document until the
+// given channel is closed.
+func (i *FakeOIDCIssuer) SetBlock(block chan struct{}) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.block = block
+}
+
+// SetTokenError sets the error returned by the token endpoint. An
+// empty error completes the device login with the ID token set by
+// SetIDToken.
+func (i *FakeOIDCIssuer) SetTokenError(tokenError string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.tokenError = tokenError
+}
+
+// SetIDToken sets the ID token issued when a device login completes.
+func (i *FakeOIDCIssuer) SetIDToken(idToken string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.idToken = idToken
+}
+
+// NewIDToken returns an ID token signed by the issuer, holding the
+// standard claims plus the extra claims specified.
+func (i *FakeOIDCIssuer) NewIDToken(c *gc.C, issuer, audience string, expiry time.Time, claims map[string]interface{}) string {
+	builder := jwt.NewBuilder().
+		Issuer(issuer).
+		Audience([]string{audience}).
+		Subject("1234").
+		IssuedAt(expiry.Add(-time.Hour)).
+		Expiration(expiry)
+	for k, v := range claims {
+		builder = builder.Claim(k, v)
+	}
+	token, err := builder.Build()
+	c.Assert(err, jc.ErrorIsNil)
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, i.signingKey))
+	c.Assert(err, jc.ErrorIsNil)
+	return string(signed)
+}
+
+func (i *FakeOIDCIssuer) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (i *FakeOIDCIssuer) discovery(w http.ResponseWriter, _ *http.Request) {
+	i.mu.Lock()
+	i.discoveries++
+	unavailable := i.unavailable
+	block := i.block
+	i.mu.Unlock()
+	if block != nil {
+		<-block
+	}
+	if unavailable {
+		i.writeJSON(w, http.StatusServiceUnavailable, nil)
+		return
+	}
+	i.writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                        i.srv.URL,
+		"jwks_uri":                      i.srv.URL + "/jwks",
+		"token_endpoint":                i.srv.URL + "/token",
+		"device_authorization_endpoint": i.srv.URL + "/device",
+	})
+}
+
+func (i *FakeOIDCIssuer) jwks(w http.ResponseWriter, _ *http.Request) {
+	i.writeJSON(w, http.StatusOK, i.keySet)
+}
+
+func (i *FakeOIDCIssuer) device(w http.ResponseWriter, _ *http.Request) {
+	i.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"device_code":      "device-code",
+		"user_code":        "ABCD-EFGH",
+		"verification_uri": i.srv.URL + "/verify",
+		"expires_in":       60,
+		"interval":         1,
+	})
+}
+
+func (i *FakeOIDCIssuer) token(w http.ResponseWriter, r *http.Request) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.tokenRequests++
+	if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:device_code" ||
+		r.FormValue("client_id") != i.clientID ||
+		r.FormValue("device_code") != "device-code" {
+		i.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	if i.tokenError != "" {
+		i.writeJSON(w, http.StatusBadRequest, map[string]string{"error": i.tokenError})
+		return
+	}
+	i.writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"id_token":     i.idToken,
+	})
+}
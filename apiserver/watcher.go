@@ -95,6 +95,13 @@ func NewAllWatcher(context facade.Context) (facade.Facade, error) {
 	return newAllWatcher(context, newAllWatcherDeltaTranslater())
 }
 
+// NewAllWatcherV3 returns a new API server endpoint for interacting
+// with a watcher created by the WatchAll and WatchAllModels API calls,
+// for clients that don't know about storage instances and secrets.
+func NewAllWatcherV3(context facade.Context) (facade.Facade, error) {
+	return newAllWatcher(context, &allWatcherDeltaTranslaterV3{})
+}
+
 // Next will return the current state of everything on the first call
 // and subsequent calls will
 func (aw *SrvAllWatcher) Next() (params.AllWatcherNextResults, error) {
@@ -126,6 +133,8 @@ type DeltaTranslater interface {
 	TranslateBlock(multiwatcher.EntityInfo) params.EntityInfo
 	TranslateAction(multiwatcher.EntityInfo) params.EntityInfo
 	TranslateApplicationOffer(multiwatcher.EntityInfo) params.EntityInfo
+	TranslateStorageInstance(multiwatcher.EntityInfo) params.EntityInfo
+	TranslateSecret(multiwatcher.EntityInfo) params.EntityInfo
 }
 
 func translate(dt DeltaTranslater, deltas []multiwatcher.Delta) []params.Delta {
@@ -159,6 +168,10 @@ func translate(dt DeltaTranslater, deltas []multiwatcher.Delta) []params.Delta {
 			converted = dt.TranslateAction(delta.Entity)
 		case multiwatcher.ApplicationOfferKind:
 			converted = dt.TranslateApplicationOffer(delta.Entity)
+		case multiwatcher.StorageInstanceKind:
+			converted = dt.TranslateStorageInstance(delta.Entity)
+		case multiwatcher.SecretKind:
+			converted = dt.TranslateSecret(delta.Entity)
 		default:
 			// converted stays nil
 		}
@@ -446,12 +459,17 @@ func (aw allWatcherDeltaTranslater) TranslateRelation(info multiwatcher.EntityIn
 		logger.Criticalf("consistency error: %s", pretty.Sprint(info))
 		return nil
 	}
-	return &params.RelationInfo{
+	result := &params.RelationInfo{
 		ModelUUID: orig.ModelUUID,
 		Key:       orig.Key,
 		Id:        orig.ID,
 		Endpoints: aw.translateEndpoints(orig.Endpoints),
 	}
+	if orig.Status.Current != "" {
+		relationStatus := aw.translateStatus(orig.Status)
+		result.Status = &relationStatus
+	}
+	return result
 }
 
 func (aw allWatcherDeltaTranslater) translateEndpoints(eps []multiwatcher.Endpoint) []params.Endpoint {
@@ -523,6 +541,59 @@ func (aw allWatcherDeltaTranslater) TranslateBranch(info multiwatcher.EntityInfo
 	}
 }
 
+func (aw allWatcherDeltaTranslater) TranslateStorageInstance(info multiwatcher.EntityInfo) params.EntityInfo {
+	orig, ok := info.(*multiwatcher.StorageInstanceInfo)
+	if !ok {
+		logger.Criticalf("consistency error: %s", pretty.Sprint(info))
+		return nil
+	}
+	return &params.StorageInstanceInfo{
+		ModelUUID:   orig.ModelUUID,
+		Id:          orig.ID,
+		Kind:        orig.Kind,
+		Owner:       orig.Owner,
+		StorageName: orig.StorageName,
+		Pool:        orig.Pool,
+		Life:        orig.Life,
+		Attachments: orig.Attachments,
+	}
+}
+
+func (aw allWatcherDeltaTranslater) TranslateSecret(info multiwatcher.EntityInfo) params.EntityInfo {
+	orig, ok := info.(*multiwatcher.SecretInfo)
+	if !ok {
+		logger.Criticalf("consistency error: %s", pretty.Sprint(info))
+		return nil
+	}
+	return &params.SecretInfo{
+		ModelUUID:      orig.ModelUUID,
+		Id:             orig.ID,
+		Owner:          orig.Owner,
+		Label:          orig.Label,
+		Description:    orig.Description,
+		LatestRevision: orig.LatestRevision,
+		RotatePolicy:   orig.RotatePolicy,
+		UpdateTime:     orig.UpdateTime,
+		Consumers:      orig.Consumers,
+	}
+}
+
+// allWatcherDeltaTranslaterV3 drops the entities that older clients
+// don't know how to decode.
+type allWatcherDeltaTranslaterV3 struct {
+	allWatcherDeltaTranslater
+}
+
+// TranslateStorageInstance implements DeltaTranslater.
+func (aw allWatcherDeltaTranslaterV3) TranslateStorageInstance(multiwatcher.EntityInfo) params.EntityInfo {
+	return nil
+}
+
+// TranslateSecret implements DeltaTranslater.
+func (aw allWatcherDeltaTranslaterV3) TranslateSecret(multiwatcher.EntityInfo) params.EntityInfo {
+	return nil
+}
+
 func (aw allWatcherDeltaTranslater) translateBranchConfig(config map[string][]multiwatcher.ItemChange) map[string][]params.ItemChange {
 	if config == nil {
 		return nil
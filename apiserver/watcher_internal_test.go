@@ -72,6 +72,30 @@ func (s *allWatcherSuite) TestTranslateAction(c *gc.C) {
 	})
 }
 
+func (s *allWatcherSuite) TestTranslateRelation(c *gc.C) {
+	t := newAllWatcherDeltaTranslater()
+	input := &multiwatcher.RelationInfo{
+		ModelUUID: testing.ModelTag.Id(),
+		Key:       "wordpress:db mysql:server",
+		ID:        3,
+	}
+	output := t.TranslateRelation(input)
+	c.Assert(output, jc.DeepEquals, &params.RelationInfo{
+		ModelUUID: input.ModelUUID,
+		Key:       input.Key,
+		Id:        input.ID,
+	})
+
+	input.Status = multiwatcher.StatusInfo{Current: status.Joined}
+	output = t.TranslateRelation(input)
+	c.Assert(output, jc.DeepEquals, &params.RelationInfo{
+		ModelUUID: input.ModelUUID,
+		Key:       input.Key,
+		Id:        input.ID,
+		Status:    &params.StatusInfo{Current: status.Joined},
+	})
+}
+
 func newDelta(info multiwatcher.EntityInfo) multiwatcher.Delta {
 	return multiwatcher.Delta{Entity: info}
 }
@@ -95,6 +119,8 @@ func (s *allWatcherSuite) TestTranslate(c *gc.C) {
 		dt.EXPECT().TranslateBlock(gomock.Any()).Return(nil),
 		dt.EXPECT().TranslateAction(gomock.Any()).Return(nil),
 		dt.EXPECT().TranslateApplicationOffer(gomock.Any()).Return(nil),
+		dt.EXPECT().TranslateStorageInstance(gomock.Any()).Return(nil),
+		dt.EXPECT().TranslateSecret(gomock.Any()).Return(nil),
 	)
 
 	deltas := []multiwatcher.Delta{
@@ -110,10 +136,63 @@ func (s *allWatcherSuite) TestTranslate(c *gc.C) {
 		newDelta(&multiwatcher.BlockInfo{}),
 		newDelta(&multiwatcher.ActionInfo{}),
 		newDelta(&multiwatcher.ApplicationOfferInfo{}),
+		newDelta(&multiwatcher.StorageInstanceInfo{}),
+		newDelta(&multiwatcher.SecretInfo{}),
 	}
 	_ = translate(dt, deltas)
 }
 
+func (s *allWatcherSuite) TestTranslateStorageInstance(c *gc.C) {
+	translator := newAllWatcherDeltaTranslater()
+	entityInfo := translator.TranslateStorageInstance(&multiwatcher.StorageInstanceInfo{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		ID:          "data/0",
+		Kind:        "block",
+		Owner:       "unit-mysql-0",
+		StorageName: "data",
+		Life:        life.Alive,
+		Attachments: map[string]life.Value{"mysql/0": life.Alive},
+	})
+	c.Assert(entityInfo, jc.DeepEquals, &params.StorageInstanceInfo{
+		ModelUUID:   coretesting.ModelTag.Id(),
+		Id:          "data/0",
+		Kind:        "block",
+		Owner:       "unit-mysql-0",
+		StorageName: "data",
+		Life:        life.Alive,
+		Attachments: map[string]life.Value{"mysql/0": life.Alive},
+	})
+}
+
+func (s *allWatcherSuite) TestTranslateSecret(c *gc.C) {
+	translator := newAllWatcherDeltaTranslater()
+	entityInfo := translator.TranslateSecret(&multiwatcher.SecretInfo{
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ID:             "cj4v5vm78ohs79o84r4g",
+		Owner:          "application-mysql",
+		LatestRevision: 2,
+		Consumers:      map[string]int{"unit-wordpress-0": 1},
+	})
+	c.Assert(entityInfo, jc.DeepEquals, &params.SecretInfo{
+		ModelUUID:      coretesting.ModelTag.Id(),
+		Id:             "cj4v5vm78ohs79o84r4g",
+		Owner:          "application-mysql",
+		LatestRevision: 2,
+		Consumers:      map[string]int{"unit-wordpress-0": 1},
+	})
+}
+
+func (s *allWatcherSuite) TestTranslateV3DropsNewEntities(c *gc.C) {
+	deltas := []multiwatcher.Delta{
+		newDelta(&multiwatcher.ApplicationOfferInfo{OfferName: "hosted-mysql"}),
+		newDelta(&multiwatcher.StorageInstanceInfo{ID: "data/0"}),
+		newDelta(&multiwatcher.SecretInfo{ID: "cj4v5vm78ohs79o84r4g"}),
+	}
+	result := translate(&allWatcherDeltaTranslaterV3{}, deltas)
+	c.Assert(result, gc.HasLen, 1)
+	c.Assert(result[0].Entity, gc.FitsTypeOf, &params.ApplicationOfferInfo{})
+}
+
 func (s *allWatcherSuite) TestTranslateModelEmpty(c *gc.C) {
 	translator := newAllWatcherDeltaTranslater()
 	entityInfo := translator.TranslateModel(&multiwatcher.ModelInfo{
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/status"
@@ -361,12 +362,18 @@ type ServiceManager interface {
 	// DeleteService deletes the specified service with all related resources.
 	DeleteService(appName string) error
 
-	// ExposeService sets up external access to the specified service.
-	ExposeService(appName string, resourceTags map[string]string, config config.ConfigAttributes) error
+	// ExposeService sets up external access to the specified service,
+	// restricted to the given source CIDRs. No CIDRs, or CIDRs covering
+	// all IPv4 and IPv6 addresses, lift any restriction.
+	ExposeService(appName string, resourceTags map[string]string, config config.ConfigAttributes, ingressCIDRs []string) error
 
 	// UnexposeService removes external access to the specified service.
 	UnexposeService(appName string) error
 
+	// EnsureEgressPolicy restricts the outbound traffic of the specified
+	// application to the given rules. No rules lift any restriction.
+	EnsureEgressPolicy(appName string, rules firewall.EgressRules) error
+
 	// GetService returns the service for the specified application.
 	GetService(appName string, mode DeploymentMode, includeClusterIP bool) (*Service, error)
 
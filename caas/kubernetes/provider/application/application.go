@@ -309,6 +309,9 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 		); err != nil {
 			return errors.Trace(err)
 		}
+		if err = a.bindImportedVolumes(statefulset.Spec.VolumeClaimTemplates, config.Filesystems); err != nil {
+			return errors.Annotatef(err, "binding imported volumes for %q", a.name)
+		}
 
 		applier.Apply(&statefulset)
 	case caas.DeploymentStateless:
@@ -1895,6 +1898,84 @@ func (a *app) getStorageUniqPrefix(getMeta func() (annotationGetter, error)) (st
 	return a.randomPrefix()
 }
 
+// bindImportedVolumes pre-binds any imported persistent volumes to the
+// claims the statefulset will create for its next unused ordinals, so that
+// new units pick up the imported data rather than provisioning new volumes.
+func (a *app) bindImportedVolumes(
+	templates []corev1.PersistentVolumeClaim,
+	filesystems []jujustorage.KubernetesFilesystemParams,
+) error {
+	templatesByStorage := make(map[string]corev1.PersistentVolumeClaim)
+	for _, t := range templates {
+		templatesByStorage[utils.StorageNameFromLabels(t.Labels)] = t
+	}
+
+	var reserved set.Strings
+	for _, fs := range filesystems {
+		template, ok := templatesByStorage[fs.StorageName]
+		if !ok || len(fs.ImportedVolumeIds) == 0 {
+			continue
+		}
+		if reserved == nil {
+			pvs, err := a.client.CoreV1().PersistentVolumes().List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				return errors.Annotate(err, "listing persistent volumes")
+			}
+			reserved = set.NewStrings()
+			for _, pv := range pvs.Items {
+				if ref := pv.Spec.ClaimRef; ref != nil && ref.Namespace == a.namespace {
+					reserved.Add(ref.Name)
+				}
+			}
+		}
+
+		ordinal := 0
+		for _, volumeId := range fs.ImportedVolumeIds {
+			pv, err := a.client.CoreV1().PersistentVolumes().Get(context.Background(), volumeId, metav1.GetOptions{})
+			if k8serrors.IsNotFound(err) {
+				logger.Warningf("imported persistent volume %q not found", volumeId)
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			if pv.Spec.ClaimRef != nil {
+				// Already bound or reserved for a claim.
+				continue
+			}
+
+			var claimName string
+			for ; ; ordinal++ {
+				claimName = fmt.Sprintf("%s-%s-%d", template.Name, a.name, ordinal)
+				if reserved.Contains(claimName) {
+					continue
+				}
+				_, err := a.client.CoreV1().PersistentVolumeClaims(a.namespace).Get(context.Background(), claimName, metav1.GetOptions{})
+				if k8serrors.IsNotFound(err) {
+					break
+				} else if err != nil {
+					return errors.Trace(err)
+				}
+			}
+			reserved.Add(claimName)
+
+			pv.Spec.ClaimRef = &corev1.ObjectReference{
+				Kind:       "PersistentVolumeClaim",
+				APIVersion: "v1",
+				Namespace:  a.namespace,
+				Name:       claimName,
+			}
+			if template.Spec.StorageClassName != nil {
+				pv.Spec.StorageClassName = *template.Spec.StorageClassName
+			}
+			logger.Infof("binding imported persistent volume %q to claim %q", volumeId, claimName)
+			if _, err := a.client.CoreV1().PersistentVolumes().Update(context.Background(), pv, metav1.UpdateOptions{}); err != nil {
+				return errors.Annotatef(err, "updating persistent volume %q", volumeId)
+			}
+		}
+	}
+	return nil
+}
+
 type handleVolumeFunc func(vol corev1.Volume, mountPath string, readOnly bool) (*corev1.VolumeMount, error)
 type handlePVCFunc func(pvc corev1.PersistentVolumeClaim, mountPath string, readOnly bool) (*corev1.VolumeMount, error)
 type handleVolumeMountFunc func(string, corev1.VolumeMount) error
@@ -2917,6 +2917,55 @@ func (s *applicationSuite) TestPVCNames(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestBindImportedVolumes(c *gc.C) {
+	_, err := s.client.CoreV1().PersistentVolumeClaims("test").Create(context.TODO(), &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitlab-database-appuuid-gitlab-0", Namespace: "test"},
+	}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, pv := range []*corev1.PersistentVolume{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-reserved"},
+		Spec: corev1.PersistentVolumeSpec{
+			ClaimRef: &corev1.ObjectReference{Namespace: "test", Name: "gitlab-database-appuuid-gitlab-1"},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-2"},
+	}} {
+		_, err := s.client.CoreV1().PersistentVolumes().Create(context.TODO(), pv, metav1.CreateOptions{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	templates := []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "gitlab-database-appuuid",
+			Labels: map[string]string{"storage.juju.is/name": "database"},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: pointer.StringPtr("test-workload-storage"),
+		},
+	}}
+	err = application.BindImportedVolumes(s.client, "test", "gitlab", templates, []storage.KubernetesFilesystemParams{{
+		StorageName:       "database",
+		ImportedVolumeIds: []string{"pv-1", "pv-missing", "pv-reserved", "pv-2"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for pvName, claimName := range map[string]string{
+		"pv-1":        "gitlab-database-appuuid-gitlab-2",
+		"pv-2":        "gitlab-database-appuuid-gitlab-3",
+		"pv-reserved": "gitlab-database-appuuid-gitlab-1",
+	} {
+		pv, err := s.client.CoreV1().PersistentVolumes().Get(context.TODO(), pvName, metav1.GetOptions{})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(pv.Spec.ClaimRef.Namespace, gc.Equals, "test")
+		c.Check(pv.Spec.ClaimRef.Name, gc.Equals, claimName)
+		if pvName != "pv-reserved" {
+			c.Check(pv.Spec.StorageClassName, gc.Equals, "test-workload-storage")
+		}
+	}
+}
+
 func (s *applicationSuite) TestLimits(c *gc.C) {
 	limits := corev1.ResourceList{
 		corev1.ResourceCPU:    *k8sresource.NewMilliQuantity(1000, k8sresource.DecimalSI),
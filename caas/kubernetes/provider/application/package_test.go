@@ -8,12 +8,14 @@ import (
 
 	"github.com/juju/clock"
 	gc "gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider/resources"
 	k8sutils "github.com/juju/juju/caas/kubernetes/provider/utils"
 	k8swatcher "github.com/juju/juju/caas/kubernetes/provider/watcher"
+	jujustorage "github.com/juju/juju/storage"
 )
 
 func Test(t *testing.T) {
@@ -60,3 +62,16 @@ func PVCNames(client kubernetes.Interface, namespace, appName, storagePrefix str
 	}
 	return a.pvcNames(storagePrefix)
 }
+
+func BindImportedVolumes(
+	client kubernetes.Interface, namespace, appName string,
+	templates []corev1.PersistentVolumeClaim,
+	filesystems []jujustorage.KubernetesFilesystemParams,
+) error {
+	a := &app{
+		name:      appName,
+		namespace: namespace,
+		client:    client,
+	}
+	return a.bindImportedVolumes(templates, filesystems)
+}
@@ -56,6 +56,7 @@ import (
 	"github.com/juju/juju/core/assumes"
 	coreconfig "github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/paths"
 	coreresources "github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/status"
@@ -1902,7 +1903,9 @@ func (k *kubernetesClient) findDefaultIngressClassResource() (*string, error) {
 }
 
 // ExposeService sets up external access to the specified application.
-func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string]string, config coreconfig.ConfigAttributes) error {
+// Access through the ingress resource, and through the application's
+// service if it is a load balancer, is restricted to ingressCIDRs.
+func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string]string, config coreconfig.ConfigAttributes, ingressCIDRs []string) error {
 	if k.namespace == "" {
 		return errNoNamespace
 	}
@@ -1932,6 +1935,10 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	if len(svc.Spec.Ports) == 0 {
 		return errors.Errorf("cannot create ingress rule for service %q without a port", svc.Name)
 	}
+	sourceRanges := ingressSourceRanges(ingressCIDRs)
+	if err := k.ensureLoadBalancerSourceRanges(svc, sourceRanges); err != nil {
+		return errors.Trace(err)
+	}
 	spec := &networkingv1.Ingress{
 		ObjectMeta: v1.ObjectMeta{
 			Name:   deploymentName,
@@ -1945,6 +1952,9 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 		},
 		Spec: networkingv1.IngressSpec{},
 	}
+	if len(sourceRanges) > 0 {
+		spec.Annotations[ingressWhitelistSourceRangeKey] = strings.Join(sourceRanges, ",")
+	}
 
 	ingressClass := config.GetString(ingressClassKey, defaultIngressClass)
 	if ingressClass == defaultIngressClass {
@@ -1987,6 +1997,36 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	return errors.Trace(err)
 }
 
+// ingressWhitelistSourceRangeKey is the ingress annotation restricting
+// the source addresses allowed to reach the ingress.
+const ingressWhitelistSourceRangeKey = "ingress.kubernetes.io/whitelist-source-range"
+
+// ingressSourceRanges returns the source ranges used to restrict access
+// to an exposed application to the given CIDRs, or nil if they don't
+// restrict access.
+func ingressSourceRanges(cidrs []string) []string {
+	all := set.NewStrings(cidrs...)
+	if all.Contains(firewall.AllNetworksIPV4CIDR) && all.Contains(firewall.AllNetworksIPV6CIDR) {
+		return nil
+	}
+	return all.SortedValues()
+}
+
+// ensureLoadBalancerSourceRanges restricts access to svc to the given
+// source ranges if it is a load balancer.
+func (k *kubernetesClient) ensureLoadBalancerSourceRanges(svc *core.Service, sourceRanges []string) error {
+	if svc.Spec.Type != core.ServiceTypeLoadBalancer {
+		return nil
+	}
+	if strings.Join(svc.Spec.LoadBalancerSourceRanges, ",") == strings.Join(sourceRanges, ",") {
+		return nil
+	}
+	logger.Debugf("setting load balancer source ranges for %s to %v", svc.Name, sourceRanges)
+	svc.Spec.LoadBalancerSourceRanges = sourceRanges
+	_, err := k.client().CoreV1().Services(k.namespace).Update(context.TODO(), svc, v1.UpdateOptions{})
+	return errors.Trace(err)
+}
+
 // UnexposeService removes external access to the specified service.
 func (k *kubernetesClient) UnexposeService(appName string) error {
 	logger.Debugf("deleting ingress resource for %s", appName)
@@ -8204,7 +8204,7 @@ func (s *K8sBrokerSuite) TestExposeServiceIngressClassProvided(c *gc.C) {
 	err := s.broker.ExposeService("gitlab", nil, config.ConfigAttributes{
 		"kubernetes-ingress-class": "foo",
 		"juju-external-hostname":   "172.0.0.1.xip.io",
-	})
+	}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -8288,7 +8288,7 @@ func (s *K8sBrokerSuite) TestExposeServiceGetDefaultIngressClassFromResource(c *
 
 	err := s.broker.ExposeService("gitlab", nil, config.ConfigAttributes{
 		"juju-external-hostname": "172.0.0.1.xip.io",
-	})
+	}, []string{"0.0.0.0/0", "::/0"})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -8362,7 +8362,86 @@ func (s *K8sBrokerSuite) TestExposeServiceGetDefaultIngressClass(c *gc.C) {
 
 	err := s.broker.ExposeService("gitlab", nil, config.ConfigAttributes{
 		"juju-external-hostname": "172.0.0.1.xip.io",
-	})
+	}, []string{"0.0.0.0/0", "::/0"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceIngressCIDRs(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc1 := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "gitlab",
+			Namespace: "test",
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "juju", "app.kubernetes.io/name": "gitlab"},
+			Annotations: map[string]string{
+				"controller.juju.is/id": testing.ControllerTag.Id(),
+			}},
+		Spec: core.ServiceSpec{
+			Selector: k8sutils.LabelForKeyValue("app", "gitlab"),
+			Type:     core.ServiceTypeLoadBalancer,
+			Ports: []core.ServicePort{
+				{
+					Protocol:   core.ProtocolTCP,
+					Port:       80,
+					TargetPort: intstr.IntOrString{IntVal: 9376},
+				},
+			},
+		},
+	}
+	svc2 := *svc1
+	svc2.Spec.LoadBalancerSourceRanges = []string{"10.0.0.0/8", "192.168.0.0/16"}
+
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "gitlab",
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "juju", "app.kubernetes.io/name": "gitlab"},
+			Annotations: map[string]string{
+				"ingress.kubernetes.io/rewrite-target":         "",
+				"ingress.kubernetes.io/ssl-redirect":           "false",
+				"kubernetes.io/ingress.allow-http":             "false",
+				"ingress.kubernetes.io/ssl-passthrough":        "false",
+				"ingress.kubernetes.io/whitelist-source-range": "10.0.0.0/8,192.168.0.0/16",
+				"kubernetes.io/ingress.class":                  "foo",
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{
+				Host: "172.0.0.1.xip.io",
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: "gitlab",
+									Port: networkingv1.ServiceBackendPort{
+										Number: int32(9376),
+									},
+								},
+							},
+						}}},
+				}}},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-gitlab", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get(gomock.Any(), "gitlab", v1.GetOptions{}).
+			Return(svc1, nil),
+		s.mockServices.EXPECT().Update(gomock.Any(), &svc2, v1.UpdateOptions{}).
+			Return(&svc2, nil),
+		s.mockIngressV1.EXPECT().Create(gomock.Any(), ingress, v1.CreateOptions{}).Return(nil, nil),
+	)
+
+	err := s.broker.ExposeService("gitlab", nil, config.ConfigAttributes{
+		"kubernetes-ingress-class": "foo",
+		"juju-external-hostname":   "172.0.0.1.xip.io",
+	}, []string{"192.168.0.0/16", "10.0.0.0/8"})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
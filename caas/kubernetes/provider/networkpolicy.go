@@ -0,0 +1,132 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+)
+
+func egressPolicyName(appName string) string {
+	return appName + "-egress"
+}
+
+// EnsureEgressPolicy restricts the outbound traffic of the application's
+// pods to the given rules with a NetworkPolicy. Restricted pods can always
+// reach DNS, the metadata service and the controller. No rules removes
+// the policy, leaving the outbound traffic unrestricted.
+func (k *kubernetesClient) EnsureEgressPolicy(appName string, rules firewall.EgressRules) error {
+	if k.namespace == "" {
+		return errNoNamespace
+	}
+	api := k.client().NetworkingV1().NetworkPolicies(k.namespace)
+	name := egressPolicyName(appName)
+	if len(rules) == 0 {
+		logger.Debugf("deleting egress network policy for %s", appName)
+		err := api.Delete(context.TODO(), name, metav1.DeleteOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	rules, err := rules.WithEssentials(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The broker doesn't know the addresses of controllers outside the
+	// cluster, so their default API port is allowed to any destination.
+	apiPort := network.PortRange{Protocol: "tcp", FromPort: controller.DefaultAPIPort, ToPort: controller.DefaultAPIPort}
+	rules = append(rules,
+		firewall.EgressRule{DestinationCIDR: firewall.AllNetworksIPV4CIDR, PortRange: apiPort},
+		firewall.EgressRule{DestinationCIDR: firewall.AllNetworksIPV6CIDR, PortRange: apiPort},
+	).UniqueRules()
+
+	logger.Debugf("creating/updating egress network policy for %s", appName)
+	spec := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: utils.LabelsForApp(appName, k.IsLegacyLabels()),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: utils.SelectorLabelsForApp(appName, k.IsLegacyLabels()),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      append(egressPolicyRules(appName, rules), essentialEgressPolicyRules()...),
+		},
+	}
+	_, err = api.Create(context.TODO(), spec, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		_, err = api.Update(context.TODO(), spec, metav1.UpdateOptions{})
+	}
+	return errors.Trace(err)
+}
+
+// egressPolicyRules returns the NetworkPolicy rules for the given egress
+// rules.
+func egressPolicyRules(appName string, rules firewall.EgressRules) []networkingv1.NetworkPolicyEgressRule {
+	var result []networkingv1.NetworkPolicyEgressRule
+	for _, rule := range rules {
+		policyRule := networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{
+				IPBlock: &networkingv1.IPBlock{CIDR: rule.DestinationCIDR},
+			}},
+		}
+		if !rule.AllPorts() {
+			protocol := core.Protocol(strings.ToUpper(rule.PortRange.Protocol))
+			if protocol != core.ProtocolTCP && protocol != core.ProtocolUDP {
+				// NetworkPolicies can't select ICMP traffic.
+				logger.Warningf("ignoring egress rule %q for %s: protocol not supported by network policies", rule, appName)
+				continue
+			}
+			port := networkingv1.NetworkPolicyPort{
+				Protocol: &protocol,
+				Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: int32(rule.PortRange.FromPort)},
+			}
+			if rule.PortRange.ToPort != rule.PortRange.FromPort {
+				endPort := int32(rule.PortRange.ToPort)
+				port.EndPort = &endPort
+			}
+			policyRule.Ports = []networkingv1.NetworkPolicyPort{port}
+		}
+		result = append(result, policyRule)
+	}
+	return result
+}
+
+// essentialEgressPolicyRules returns the NetworkPolicy rules allowing the
+// traffic within the cluster that restricted pods always need: DNS, which
+// is served by pods in another namespace, and the controller's pods.
+func essentialEgressPolicyRules() []networkingv1.NetworkPolicyEgressRule {
+	udp, tcp := core.ProtocolUDP, core.ProtocolTCP
+	dnsPort := intstr.FromInt(53)
+	return []networkingv1.NetworkPolicyEgressRule{{
+		To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: &udp, Port: &dnsPort},
+			{Protocol: &tcp, Port: &dnsPort},
+		},
+	}, {
+		To: []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: utils.SelectorLabelsForApp(constants.JujuControllerStackName, false),
+			},
+		}},
+	}}
+}
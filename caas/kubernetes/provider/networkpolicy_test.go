@@ -0,0 +1,122 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"context"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+)
+
+var _ = gc.Suite(&networkPolicySuite{})
+
+type networkPolicySuite struct {
+	fakeClientSuite
+}
+
+func (s *networkPolicySuite) TestEnsureEgressPolicy(c *gc.C) {
+	err := s.broker.EnsureEgressPolicy("gitlab", firewall.EgressRules{
+		{DestinationCIDR: "10.0.0.0/8"},
+		{DestinationCIDR: "0.0.0.0/0", PortRange: network.MustParsePortRange("8000-8080/udp")},
+		{DestinationCIDR: "0.0.0.0/0", PortRange: network.MustParsePortRange("icmp")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	policies := s.k8sClient.NetworkingV1().NetworkPolicies(s.getNamespace())
+	policy, err := policies.Get(context.Background(), "gitlab-egress", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.Labels, jc.DeepEquals, map[string]string{
+		"app.kubernetes.io/managed-by": "juju",
+		"app.kubernetes.io/name":       "gitlab",
+	})
+	c.Assert(policy.Spec.PodSelector, jc.DeepEquals, v1.LabelSelector{
+		MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+	})
+	c.Assert(policy.Spec.PolicyTypes, jc.DeepEquals, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress})
+	c.Assert(policy.Spec.Egress, jc.SameContents, append([]networkingv1.NetworkPolicyEgressRule{
+		egressToCIDR("10.0.0.0/8", nil),
+		egressToCIDR("0.0.0.0/0", egressPort(core.ProtocolUDP, 8000, 8080)),
+	}, essentialEgress()...))
+
+	// Setting the rules again updates the existing policy.
+	err = s.broker.EnsureEgressPolicy("gitlab", firewall.EgressRules{
+		{DestinationCIDR: "192.168.0.0/16"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err = policies.Get(context.Background(), "gitlab-egress", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.Spec.Egress, jc.SameContents, append([]networkingv1.NetworkPolicyEgressRule{
+		egressToCIDR("192.168.0.0/16", nil),
+	}, essentialEgress()...))
+
+	// No rules removes the policy.
+	err = s.broker.EnsureEgressPolicy("gitlab", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = policies.Get(context.Background(), "gitlab-egress", v1.GetOptions{})
+	c.Assert(k8serrors.IsNotFound(err), jc.IsTrue)
+
+	// Removing a policy that doesn't exist is fine.
+	err = s.broker.EnsureEgressPolicy("gitlab", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func egressPort(protocol core.Protocol, port, endPort int32) *networkingv1.NetworkPolicyPort {
+	result := &networkingv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: port},
+	}
+	if endPort != port {
+		result.EndPort = &endPort
+	}
+	return result
+}
+
+func egressToCIDR(cidr string, port *networkingv1.NetworkPolicyPort) networkingv1.NetworkPolicyEgressRule {
+	rule := networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: cidr}}},
+	}
+	if port != nil {
+		rule.Ports = []networkingv1.NetworkPolicyPort{*port}
+	}
+	return rule
+}
+
+// essentialEgress returns the rules that are always part of an egress
+// policy, for DNS, the metadata service and the controller.
+func essentialEgress() []networkingv1.NetworkPolicyEgressRule {
+	dnsPort := intstr.FromInt(53)
+	udp, tcp := core.ProtocolUDP, core.ProtocolTCP
+	return []networkingv1.NetworkPolicyEgressRule{
+		egressToCIDR("0.0.0.0/0", egressPort(core.ProtocolUDP, 53, 53)),
+		egressToCIDR("0.0.0.0/0", egressPort(core.ProtocolTCP, 53, 53)),
+		egressToCIDR("::/0", egressPort(core.ProtocolUDP, 53, 53)),
+		egressToCIDR("::/0", egressPort(core.ProtocolTCP, 53, 53)),
+		egressToCIDR("0.0.0.0/0", egressPort(core.ProtocolTCP, 17070, 17070)),
+		egressToCIDR("::/0", egressPort(core.ProtocolTCP, 17070, 17070)),
+		egressToCIDR("169.254.169.254/32", nil),
+		{
+			To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &v1.LabelSelector{}}},
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &udp, Port: &dnsPort},
+				{Protocol: &tcp, Port: &dnsPort},
+			},
+		}, {
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &v1.LabelSelector{},
+				PodSelector: &v1.LabelSelector{
+					MatchLabels: map[string]string{"app.kubernetes.io/name": "controller"},
+				},
+			}},
+		},
+	}
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/juju/errors"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/caas/kubernetes/provider/storage"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	k8sannotations "github.com/juju/juju/core/annotations"
 	jujucontext "github.com/juju/juju/environs/context"
 	jujustorage "github.com/juju/juju/storage"
 )
@@ -103,7 +106,11 @@ type volumeSource struct {
 	client *kubernetesClient
 }
 
-var _ jujustorage.VolumeSource = (*volumeSource)(nil)
+var (
+	_ jujustorage.VolumeSource   = (*volumeSource)(nil)
+	_ jujustorage.VolumeImporter = (*volumeSource)(nil)
+	_ jujustorage.VolumeResizer  = (*volumeSource)(nil)
+)
 
 // CreateVolumes is specified on the jujustorage.VolumeSource interface.
 func (v *volumeSource) CreateVolumes(ctx jujucontext.ProviderCallContext, params []jujustorage.VolumeParams) (_ []jujustorage.CreateVolumesResult, err error) {
@@ -207,6 +214,82 @@ func (v *volumeSource) DetachVolumes(ctx jujucontext.ProviderCallContext, attach
 	return make([]error, len(attachParams)), nil
 }
 
+// ImportVolume is specified on the jujustorage.VolumeImporter interface.
+// The imported persistent volume is retained when released, and any stale
+// claim reference is removed so that it may be bound to the claim of a
+// new unit.
+func (v *volumeSource) ImportVolume(ctx jujucontext.ProviderCallContext, volumeId string, resourceTags map[string]string) (jujustorage.VolumeInfo, error) {
+	pVolumes := v.client.client().CoreV1().PersistentVolumes()
+	vol, err := pVolumes.Get(context.TODO(), volumeId, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return jujustorage.VolumeInfo{}, errors.NotFoundf("persistent volume %q", volumeId)
+	} else if err != nil {
+		return jujustorage.VolumeInfo{}, errors.Trace(err)
+	}
+	if vol.Status.Phase == core.VolumeBound && vol.Spec.ClaimRef != nil {
+		return jujustorage.VolumeInfo{}, errors.Errorf(
+			"persistent volume %q is bound to claim %s/%s",
+			volumeId, vol.Spec.ClaimRef.Namespace, vol.Spec.ClaimRef.Name,
+		)
+	}
+	vol.Spec.PersistentVolumeReclaimPolicy = core.PersistentVolumeReclaimRetain
+	vol.Spec.ClaimRef = nil
+	vol.Annotations = k8sannotations.New(vol.Annotations).
+		Merge(utils.ResourceTagsToAnnotations(resourceTags, v.client.IsLegacyLabels())).
+		ToMap()
+	vol, err = pVolumes.Update(context.TODO(), vol, v1.UpdateOptions{})
+	if err != nil {
+		return jujustorage.VolumeInfo{}, errors.Annotatef(err, "updating persistent volume %q", volumeId)
+	}
+	size := vol.Spec.Capacity[core.ResourceStorage]
+	return jujustorage.VolumeInfo{
+		VolumeId:   vol.Name,
+		Size:       uint64(size.Value()) / (1024 * 1024),
+		Persistent: true,
+	}, nil
+}
+
+// ResizeVolumes is specified on the jujustorage.VolumeResizer interface.
+// Persistent volumes are grown by expanding the claims bound to them,
+// which requires that the claim's storage class allows volume expansion.
+func (v *volumeSource) ResizeVolumes(ctx jujucontext.ProviderCallContext, args []jujustorage.VolumeResizeParams) ([]jujustorage.ResizeResult, error) {
+	results := make([]jujustorage.ResizeResult, len(args))
+	for i, arg := range args {
+		if err := v.resizeVolume(arg); err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %v", arg.VolumeId)
+			continue
+		}
+		results[i].Size = arg.Size
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeVolume(arg jujustorage.VolumeResizeParams) error {
+	vol, err := v.client.client().CoreV1().PersistentVolumes().Get(context.TODO(), arg.VolumeId, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NotFoundf("persistent volume %q", arg.VolumeId)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	claimRef := vol.Spec.ClaimRef
+	if claimRef == nil {
+		return errors.NotSupportedf("resizing unclaimed persistent volume %q", arg.VolumeId)
+	}
+	pClaims := v.client.client().CoreV1().PersistentVolumeClaims(claimRef.Namespace)
+	pvc, err := pClaims.Get(context.TODO(), claimRef.Name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NotFoundf("persistent volume claim %q", claimRef.Name)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = core.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[core.ResourceStorage] = resource.MustParse(fmt.Sprintf("%dMi", arg.Size))
+	_, err = pClaims.Update(context.TODO(), pvc, v1.UpdateOptions{})
+	return errors.Trace(err)
+}
+
 func foreachVolume(volumeIds []string, f func(string) error) []error {
 	results := make([]error, len(volumeIds))
 	var wg sync.WaitGroup
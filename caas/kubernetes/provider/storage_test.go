@@ -172,6 +172,123 @@ func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
 	}})
 }
 
+func (s *storageSuite) TestImportVolume(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pv := &core.PersistentVolume{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "pv-1",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: core.PersistentVolumeSpec{
+			PersistentVolumeReclaimPolicy: core.PersistentVolumeReclaimDelete,
+			ClaimRef:                      &core.ObjectReference{Namespace: "old", Name: "old-pvc"},
+			Capacity:                      core.ResourceList{core.ResourceStorage: resource.MustParse("2Gi")},
+		},
+		Status: core.PersistentVolumeStatus{Phase: core.VolumeReleased},
+	}
+	expected := pv.DeepCopy()
+	expected.Annotations = map[string]string{
+		"foo":                   "bar",
+		"model.juju.is/id":      "deadbeef",
+		"controller.juju.is/id": "badf00d",
+	}
+	expected.Spec.PersistentVolumeReclaimPolicy = core.PersistentVolumeReclaimRetain
+	expected.Spec.ClaimRef = nil
+	gomock.InOrder(
+		s.mockPersistentVolumes.EXPECT().Get(gomock.Any(), "pv-1", v1.GetOptions{}).
+			Return(pv, nil),
+		s.mockPersistentVolumes.EXPECT().Update(gomock.Any(), expected, v1.UpdateOptions{}).
+			Return(expected, nil),
+	)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	importer, ok := vs.(storage.VolumeImporter)
+	c.Assert(ok, jc.IsTrue)
+
+	info, err := importer.ImportVolume(&context.CloudCallContext{}, "pv-1", map[string]string{
+		"juju-model-uuid":      "deadbeef",
+		"juju-controller-uuid": "badf00d",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   "pv-1",
+		Size:       2048,
+		Persistent: true,
+	})
+}
+
+func (s *storageSuite) TestImportVolumeBound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockPersistentVolumes.EXPECT().Get(gomock.Any(), "pv-1", v1.GetOptions{}).
+		Return(&core.PersistentVolume{
+			ObjectMeta: v1.ObjectMeta{Name: "pv-1"},
+			Spec: core.PersistentVolumeSpec{
+				ClaimRef: &core.ObjectReference{Namespace: "test", Name: "data-pvc"},
+			},
+			Status: core.PersistentVolumeStatus{Phase: core.VolumeBound},
+		}, nil)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = vs.(storage.VolumeImporter).ImportVolume(&context.CloudCallContext{}, "pv-1", nil)
+	c.Assert(err, gc.ErrorMatches, `persistent volume "pv-1" is bound to claim test/data-pvc`)
+}
+
+func (s *storageSuite) TestResizeVolumes(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pvc := &core.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: "vol-1-pvc"},
+		Spec: core.PersistentVolumeClaimSpec{
+			Resources: core.VolumeResourceRequirements{
+				Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	expected := pvc.DeepCopy()
+	expected.Spec.Resources.Requests[core.ResourceStorage] = resource.MustParse("2048Mi")
+	gomock.InOrder(
+		s.mockPersistentVolumes.EXPECT().Get(gomock.Any(), "vol-1", v1.GetOptions{}).
+			Return(&core.PersistentVolume{
+				Spec: core.PersistentVolumeSpec{
+					ClaimRef: &core.ObjectReference{Namespace: "test", Name: "vol-1-pvc"},
+				}}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get(gomock.Any(), "vol-1-pvc", v1.GetOptions{}).
+			Return(pvc, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Update(gomock.Any(), expected, v1.UpdateOptions{}).
+			Return(expected, nil),
+		s.mockPersistentVolumes.EXPECT().Get(gomock.Any(), "vol-2", v1.GetOptions{}).
+			Return(&core.PersistentVolume{}, nil),
+	)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	resizer, ok := vs.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+
+	results, err := resizer.ResizeVolumes(&context.CloudCallContext{}, []storage.VolumeResizeParams{{
+		VolumeId: "vol-1",
+		Size:     2048,
+	}, {
+		VolumeId: "vol-2",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results[0], jc.DeepEquals, storage.ResizeResult{Size: 2048})
+	c.Check(results[1].Error, gc.ErrorMatches, `resizing volume vol-2: resizing unclaimed persistent volume "vol-2" not supported`)
+}
+
 func (s *storageSuite) TestValidateStorageProvider(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
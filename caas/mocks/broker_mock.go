@@ -16,6 +16,7 @@ import (
 	caas "github.com/juju/juju/caas"
 	config "github.com/juju/juju/core/config"
 	constraints "github.com/juju/juju/core/constraints"
+	firewall "github.com/juju/juju/core/network/firewall"
 	secrets "github.com/juju/juju/core/secrets"
 	watcher "github.com/juju/juju/core/watcher"
 	docker "github.com/juju/juju/docker"
@@ -251,6 +252,20 @@ func (mr *MockBrokerMockRecorder) DestroyController(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyController", reflect.TypeOf((*MockBroker)(nil).DestroyController), arg0, arg1)
 }
 
+// EnsureEgressPolicy mocks base method.
+func (m *MockBroker) EnsureEgressPolicy(arg0 string, arg1 firewall.EgressRules) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureEgressPolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureEgressPolicy indicates an expected call of EnsureEgressPolicy.
+func (mr *MockBrokerMockRecorder) EnsureEgressPolicy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureEgressPolicy", reflect.TypeOf((*MockBroker)(nil).EnsureEgressPolicy), arg0, arg1)
+}
+
 // EnsureImageRepoSecret mocks base method.
 func (m *MockBroker) EnsureImageRepoSecret(arg0 docker.ImageRepoDetails) error {
 	m.ctrl.T.Helper()
@@ -323,17 +338,17 @@ func (mr *MockBrokerMockRecorder) EnsureService(arg0, arg1, arg2, arg3, arg4 any
 }
 
 // ExposeService mocks base method.
-func (m *MockBroker) ExposeService(arg0 string, arg1 map[string]string, arg2 config.ConfigAttributes) error {
+func (m *MockBroker) ExposeService(arg0 string, arg1 map[string]string, arg2 config.ConfigAttributes, arg3 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExposeService", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "ExposeService", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ExposeService indicates an expected call of ExposeService.
-func (mr *MockBrokerMockRecorder) ExposeService(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockBrokerMockRecorder) ExposeService(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExposeService", reflect.TypeOf((*MockBroker)(nil).ExposeService), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExposeService", reflect.TypeOf((*MockBroker)(nil).ExposeService), arg0, arg1, arg2, arg3)
 }
 
 // GetJujuSecret mocks base method.
@@ -29,6 +29,7 @@ func NewRefreshCommandForTest(
 	newSpacesClient func(base.APICallCloser) SpacesAPI,
 	newModelConfigClient func(base.APICallCloser) ModelConfigClient,
 	newCharmHubClient func(string) (store.DownloadBundleClient, error),
+	newBranchClient func(base.APICallCloser) BranchCharmClient,
 ) cmd.Command {
 	cmd := &refreshCommand{
 		DeployResources:       deployResources,
@@ -41,6 +42,7 @@ func NewRefreshCommandForTest(
 		NewRefresherFactory:   refresher.NewRefresherFactory,
 		ModelConfigClient:     newModelConfigClient,
 		NewCharmHubClient:     newCharmHubClient,
+		NewBranchClient:       newBranchClient,
 	}
 	cmd.SetClientStore(store)
 	cmd.SetAPIOpen(apiOpen)
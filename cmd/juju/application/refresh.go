@@ -20,6 +20,7 @@ import (
 	apicharms "github.com/juju/juju/api/client/charms"
 	apiclient "github.com/juju/juju/api/client/client"
 	"github.com/juju/juju/api/client/modelconfig"
+	"github.com/juju/juju/api/client/modelgeneration"
 	"github.com/juju/juju/api/client/resources"
 	"github.com/juju/juju/api/client/spaces"
 	commoncharm "github.com/juju/juju/api/common/charm"
@@ -35,6 +36,7 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 	corebase "github.com/juju/juju/core/base"
 	corecharm "github.com/juju/juju/core/charm"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/storage"
@@ -77,6 +79,9 @@ func newRefreshCommand() *refreshCommand {
 			)
 		},
 		NewRefresherFactory: refresher.NewRefresherFactory,
+		NewBranchClient: func(conn base.APICallCloser) BranchCharmClient {
+			return modelgeneration.NewClient(conn)
+		},
 	}
 }
 
@@ -99,6 +104,12 @@ type CharmRefreshClient interface {
 	SetCharm(string, application.SetCharmConfig) error
 }
 
+// BranchCharmClient defines a subset of the model generation facade, as
+// required by the refresh command to upgrade a charm under a branch.
+type BranchCharmClient interface {
+	SetBranchCharm(branchName, appName, charmURL string, origin params.CharmOrigin, resourceIDs map[string]string) error
+}
+
 // NewCharmAdderFunc is the type of a function used to construct
 // a new CharmAdder.
 type NewCharmAdderFunc func(
@@ -122,6 +133,7 @@ type refreshCommand struct {
 	ModelConfigClient     func(base.APICallCloser) ModelConfigClient
 	NewCharmHubClient     func(string) (store.DownloadBundleClient, error)
 	NewRefresherFactory   func(refresher.RefresherDependencies) refresher.RefresherFactory
+	NewBranchClient       func(base.APICallCloser) BranchCharmClient
 
 	ApplicationName string
 	// Force should be ubiquitous and we should eventually deprecate both
@@ -156,6 +168,11 @@ type refreshCommand struct {
 	// That is, hooks run by the charm can access cloud credentials and other
 	// trusted access credentials.
 	Trust *bool
+
+	// BranchName is the name of the branch under which the charm is
+	// refreshed. Only units tracking the branch run the new charm until
+	// the branch is committed.
+	BranchName string
 }
 
 const refreshDoc = `
@@ -235,6 +252,12 @@ cause unexpected behavior.
 --force option for LXD Profiles is not generally recommended when upgrading an
 application; overriding profiles on the container may cause unexpected
 behavior.
+
+The --branch option refreshes the charm, and any resources, under an existing
+branch. Only units tracking the branch run the new charm until the branch is
+committed, at which point the rest of the application's units are refreshed.
+This allows a new revision to be tried on a few units first. --branch cannot
+be combined with --config, --storage, --bind, --trust or --force-units.
 `
 
 const refreshExamples = `
@@ -249,6 +272,10 @@ To refresh the application config from a file for application foo:
 To refresh the resources for application foo:
 
 	juju refresh foo --resource bar=/some/file.tgz --resource baz=./docs/cfg.xml
+
+To refresh application foo only on the units tracking branch canary:
+
+	juju refresh foo --branch canary
 `
 
 const upgradedApplicationHasUnitsMessage = `
@@ -286,6 +313,7 @@ func (c *refreshCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.ConfigOptions, "config", "Either a path to yaml-formatted application config file or a key=value pair ")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
 	f.Var(newOptBoolValue(&c.Trust), "trust", "Allows charm to run hooks that require access credentials")
+	f.StringVar(&c.BranchName, "branch", "", "Refresh only the units tracking this branch, until it is committed")
 }
 
 type optBoolValue struct {
@@ -340,9 +368,38 @@ func (c *refreshCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.BranchName != "" {
+		if err := c.validateBranchFlags(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
+// validateBranchFlags checks that the options supplied along with --branch
+// can be applied under a branch.
+func (c *refreshCommand) validateBranchFlags() error {
+	if c.BranchName == model.GenerationMaster {
+		return errors.Errorf("cannot refresh under the %q branch, omit --branch instead", model.GenerationMaster)
+	}
+	var flag string
+	switch {
+	case c.ConfigOptions.String() != "":
+		flag = "--config"
+	case len(c.Storage) > 0:
+		flag = "--storage"
+	case c.BindToSpaces != "":
+		flag = "--bind"
+	case c.Trust != nil:
+		flag = "--trust"
+	case c.ForceUnits:
+		flag = "--force-units"
+	default:
+		return nil
+	}
+	return errors.Errorf("--branch and %s are mutually exclusive", flag)
+}
+
 // Run connects to the specified environment and starts the charm
 // upgrade process.
 func (c *refreshCommand) Run(ctx *cmd.Context) error {
@@ -490,6 +547,10 @@ func (c *refreshCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	if c.BranchName != "" {
+		return c.setBranchCharm(ctx, apiRoot, chID, resourceIDs)
+	}
+
 	// Print out the updated endpoint binding plan.
 	charmsClient := c.NewCharmClient(apiRoot)
 	charmInfo, err := charmsClient.CharmInfo(curl.String())
@@ -539,6 +600,20 @@ func (c *refreshCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// setBranchCharm upgrades the application to the input charm and resources
+// under the branch supplied with --branch.
+func (c *refreshCommand) setBranchCharm(
+	ctx *cmd.Context, apiRoot base.APICallCloser, chID application.CharmID, resourceIDs map[string]string,
+) error {
+	err := c.NewBranchClient(apiRoot).SetBranchCharm(
+		c.BranchName, c.ApplicationName, chID.URL, chID.Origin.ParamsCharmOrigin(), resourceIDs)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Charm of %q refreshed under branch %q", c.ApplicationName, c.BranchName)
+	return nil
+}
+
 func (c *refreshCommand) validateEndpointNames(newCharmEndpoints set.Strings, oldEndpointsMap, userBindings map[string]string) error {
 	for epName := range userBindings {
 		if _, exists := oldEndpointsMap[epName]; exists || epName == "" {
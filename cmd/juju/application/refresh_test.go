@@ -67,6 +67,7 @@ type BaseRefreshSuite struct {
 	charmAdder           mockCharmAdder
 	charmClient          mockCharmClient
 	charmAPIClient       mockCharmRefreshClient
+	branchClient         mockBranchCharmClient
 	modelConfigGetter    mockModelConfigGetter
 	resourceLister       mockResourceLister
 	spacesClient         mockSpacesClient
@@ -169,6 +170,7 @@ func (s *BaseRefreshSuite) setup(c *gc.C, b corebase.Base, currentCharmURL, late
 			Base:         s.testBase,
 		},
 	}
+	s.branchClient = mockBranchCharmClient{}
 	s.modelConfigGetter = newMockModelConfigGetter()
 	s.resourceLister = mockResourceLister{}
 	s.spacesClient = mockSpacesClient{
@@ -249,6 +251,10 @@ func (s *BaseRefreshSuite) refreshCommand() cmd.Command {
 			s.AddCall("NewCharmHubClient", curl)
 			return &s.downloadBundleClient, nil
 		},
+		func(conn base.APICallCloser) BranchCharmClient {
+			s.AddCall("NewBranchClient", conn)
+			return &s.branchClient
+		},
 	)
 	return cmd
 }
@@ -663,6 +669,54 @@ func (s *RefreshSuite) TestUpgradeWithChannel(c *gc.C) {
 	})
 }
 
+func (s *RefreshSuite) TestUpgradeWithBranch(c *gc.C) {
+	ctx, err := s.runRefresh(c, "foo", "--branch", "canary")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURLOrigin", "Get")
+	s.branchClient.CheckCallNames(c, "SetBranchCharm")
+	origin := commoncharm.Origin{
+		ID:           "testing",
+		Source:       "charm-hub",
+		Risk:         "stable",
+		Architecture: arch.DefaultArchitecture,
+		Base:         s.testBase,
+	}
+	s.branchClient.CheckCall(c, 0, "SetBranchCharm",
+		"canary", "foo", s.resolvedCharmURL.String(), origin.ParamsCharmOrigin(), map[string]string(nil))
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Charm of "foo" refreshed under branch "canary"`)
+}
+
+func (s *RefreshSuite) TestUpgradeWithBranchInvalidFlags(c *gc.C) {
+	tests := []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--branch", model.GenerationMaster},
+		err:  `cannot refresh under the "master" branch, omit --branch instead`,
+	}, {
+		args: []string{"--branch", "canary", "--config", "foo=bar"},
+		err:  "--branch and --config are mutually exclusive",
+	}, {
+		args: []string{"--branch", "canary", "--storage", "bar=baz"},
+		err:  "--branch and --storage are mutually exclusive",
+	}, {
+		args: []string{"--branch", "canary", "--bind", "sp1"},
+		err:  "--branch and --bind are mutually exclusive",
+	}, {
+		args: []string{"--branch", "canary", "--trust"},
+		err:  "--branch and --trust are mutually exclusive",
+	}, {
+		args: []string{"--branch", "canary", "--force-units"},
+		err:  "--branch and --force-units are mutually exclusive",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runRefresh(c, append([]string{"foo"}, test.args...)...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *RefreshSuite) TestUpgradeWithChannelNoNewCharmURL(c *gc.C) {
 	// Test setting a new charm channel, without an actual
 	// charm upgrade needed.
@@ -1357,6 +1411,17 @@ func (m *mockCharmRefreshClient) Get(_, applicationName string) (*params.Applica
 	}, m.NextErr()
 }
 
+type mockBranchCharmClient struct {
+	testing.Stub
+}
+
+func (m *mockBranchCharmClient) SetBranchCharm(
+	branchName, appName, charmURL string, origin params.CharmOrigin, resourceIDs map[string]string,
+) error {
+	m.MethodCall(m, "SetBranchCharm", branchName, appName, charmURL, origin, resourceIDs)
+	return m.NextErr()
+}
+
 func newMockModelConfigGetter() mockModelConfigGetter {
 	return mockModelConfigGetter{cfg: coretesting.FakeConfig()}
 }
@@ -0,0 +1,143 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api/client/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/network/firewall"
+)
+
+const (
+	setEgressSummary = `Restricts the outbound traffic of a deployed application.`
+	setEgressDetails = `Sets the destinations that the units of an application may send traffic
+to. Each rule is a CIDR, optionally followed by a colon and a port range
+in the form <port>[-<port>][/<protocol>], where the protocol defaults
+to tcp. A rule with no port range allows all traffic to the CIDR.
+
+The rules replace any previously set for the application. Once rules are
+set, outbound traffic that doesn't match any of them is blocked; use
+--reset to allow all outbound traffic again.
+
+Traffic to DNS servers, the cloud's metadata service and the controller
+API is always allowed, so that the units' agents keep working.
+
+The rules are held in the application's egress-allow config value, and
+are enforced through security groups on OpenStack models using the
+"instance" firewall-mode, and through a NetworkPolicy on Kubernetes. A
+Kubernetes NetworkPolicy is only enforced when the cluster's network
+plugin supports it. Setting rules is rejected on other clouds, such as
+LXD, which can't enforce them.
+
+Units that share a machine share its security group, so a machine's
+outbound traffic is allowed to the destinations of all of its units'
+applications, and is unrestricted if any of them has no rules.
+`
+
+	setEgressExamples = `
+    juju set-egress mediawiki 10.0.0.0/8 0.0.0.0/0:443
+    juju set-egress mediawiki 192.168.1.0/24:5432 0.0.0.0/0:53/udp
+    juju set-egress mediawiki --reset
+`
+)
+
+type setEgressCommand struct {
+	modelcmd.ModelCommandBase
+	api ApplicationAPI
+
+	applicationName string
+	rules           firewall.EgressRules
+	reset           bool
+}
+
+// NewSetEgressCommand returns a command that sets the egress rules of
+// an application.
+func NewSetEgressCommand() cmd.Command {
+	return modelcmd.Wrap(&setEgressCommand{})
+}
+
+// Info is part of the cmd.Command interface.
+func (c *setEgressCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "set-egress",
+		Args:     "<application name> [<cidr>[:<port-range>] ...]",
+		Purpose:  setEgressSummary,
+		Doc:      setEgressDetails,
+		Examples: setEgressExamples,
+		SeeAlso: []string{
+			"config",
+			"expose",
+		},
+	})
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *setEgressCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.reset, "reset", false, "Remove the egress rules, allowing all outbound traffic")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *setEgressCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.NotValidf("application name %q", c.applicationName)
+	}
+	args = args[1:]
+	if c.reset {
+		if len(args) > 0 {
+			return errors.New("cannot specify egress rules with --reset")
+		}
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("no egress rules specified; use --reset to allow all outbound traffic")
+	}
+	for _, arg := range args {
+		rule, err := firewall.ParseEgressRule(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.rules = append(c.rules, rule)
+	}
+	c.rules = c.rules.UniqueRules()
+	return nil
+}
+
+// getAPI either uses the fake API set at test time or that is nil, gets a real
+// API and sets that as the API.
+func (c *setEgressCommand) getAPI() (ApplicationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *setEgressCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	err = client.SetConfig("", c.applicationName, "",
+		map[string]string{firewall.EgressAllowKey: c.rules.String()},
+	)
+	return errors.Trace(block.ProcessBlockedError(err, block.BlockChange))
+}
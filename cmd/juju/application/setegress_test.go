@@ -0,0 +1,93 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application/mocks"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type SetEgressSuite struct {
+	applicationAPI *mocks.MockApplicationAPI
+	store          *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&SetEgressSuite{})
+
+func (s *SetEgressSuite) SetUpTest(c *gc.C) {
+	s.store = jujuclienttesting.MinimalStore()
+}
+
+func (s *SetEgressSuite) setupMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.applicationAPI = mocks.NewMockApplicationAPI(ctrl)
+	return ctrl
+}
+
+func (s *SetEgressSuite) runSetEgress(c *gc.C, args ...string) error {
+	setEgressCmd := modelcmd.Wrap(&setEgressCommand{api: s.applicationAPI})
+	setEgressCmd.SetClientStore(s.store)
+	_, err := cmdtesting.RunCommand(c, setEgressCmd, args...)
+	return err
+}
+
+func (s *SetEgressSuite) TestSetEgress(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.applicationAPI.EXPECT().SetConfig("", "gitlab", "", map[string]string{
+		"egress-allow": "0.0.0.0/0:443/tcp 10.0.0.0/8 2001:db8::/32:53/udp",
+	})
+	s.applicationAPI.EXPECT().Close()
+
+	err := s.runSetEgress(c, "gitlab", "10.0.0.0/8", "2001:db8::/32:53/udp", "0.0.0.0/0:443", "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SetEgressSuite) TestSetEgressReset(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.applicationAPI.EXPECT().SetConfig("", "gitlab", "", map[string]string{"egress-allow": ""})
+	s.applicationAPI.EXPECT().Close()
+
+	err := s.runSetEgress(c, "gitlab", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SetEgressSuite) TestInitErrors(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"gitlab"},
+		err:  "no egress rules specified; use --reset to allow all outbound traffic",
+	}, {
+		args: []string{"gitlab", "--reset", "10.0.0.0/8"},
+		err:  "cannot specify egress rules with --reset",
+	}, {
+		args: []string{"gitlab", "example.com:443"},
+		err:  `egress rule "example.com:443", expected <cidr>\[:<port-range>\] not valid`,
+	}, {
+		args: []string{"gitlab", "10.0.0.0/8:http"},
+		err:  `egress rule "10.0.0.0/8:http": invalid port "http".*`,
+	}, {
+		args: []string{"Gitlab", "10.0.0.0/8"},
+		err:  `application name "Gitlab" not valid`,
+	}} {
+		c.Logf("args: %v", test.args)
+		err := s.runSetEgress(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
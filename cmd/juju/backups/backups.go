@@ -27,6 +27,17 @@ type APIClient interface {
 	Create(notes string, noDownload bool) (*params.BackupsMetadataResult, error)
 	// Download pulls the backup archive file.
 	Download(filename string) (io.ReadCloser, error)
+	// Upload pushes a backup archive file to the controller.
+	Upload(archive io.ReadSeeker) (string, error)
+	// Restore starts restoring the controller from an uploaded backup
+	// archive.
+	Restore(id string) (*params.BackupsRestoreResult, error)
+	// RestoreStatus returns the progress of a restore.
+	RestoreStatus(id string) (*params.BackupsRestoreResult, error)
+	// List returns the metadata of the backups stored on the controller.
+	List() (*params.BackupsListResult, error)
+	// Verify checks a stored backup against its recorded checksum.
+	Verify(id string) (*params.BackupsMetadataResult, error)
 }
 
 // CommandBase is the base type for backups sub-commands.
@@ -26,7 +26,9 @@
 // might be slightly outdated by the time all state-related files are gathered,
 // though the risk is minimal.
 
-// In terms of the restore process, please see "[juju-restore tool]".
-// [juju-restore tool]: https://github.com/juju/juju-restore
+// A backup archive can be restored into a freshly bootstrapped controller
+// with "juju restore-backup". The archive is uploaded to the controller,
+// checked against the controller version, and then the database and
+// state-related files are restored in place.
 
 package backups
@@ -19,6 +19,10 @@ var (
 	NewGetAPI    = &getAPI
 )
 
+var RestorePollInterval = &restorePollInterval
+
+var RestoreUnavailableTimeout = &restoreUnavailableTimeout
+
 type CreateCommand struct {
 	*createCommand
 }
@@ -27,6 +31,10 @@ type DownloadCommand struct {
 	*downloadCommand
 }
 
+type RestoreCommand struct {
+	*restoreCommand
+}
+
 func NewCreateCommandForTest(store jujuclient.ClientStore) (cmd.Command, *CreateCommand) {
 	c := &createCommand{}
 	c.SetClientStore(store)
@@ -38,3 +46,21 @@ func NewDownloadCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Down
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c), &DownloadCommand{c}
 }
+
+func NewRestoreCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RestoreCommand) {
+	c := &restoreCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &RestoreCommand{c}
+}
+
+func NewListCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewVerifyCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &verifyCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/version/v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state/backups"
+)
+
+const listDoc = `
+backups lists the backup archives stored on the controller.
+
+Archives are stored on the controller when they are created with
+'juju create-backup --no-download', or when they are kept by the
+controller's backup retention policy. The retention policy is set
+with the "backup-retention-count" and "backup-retention-period"
+controller config keys.
+`
+
+const listExamples = `
+    juju backups
+    juju backups --format yaml
+`
+
+// NewListCommand returns a command used to list stored backups.
+func NewListCommand() cmd.Command {
+	return modelcmd.Wrap(&listCommand{})
+}
+
+// listCommand is the sub-command for listing stored backup archives.
+type listCommand struct {
+	CommandBase
+	out cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *listCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "backups",
+		Aliases:  []string{"list-backups"},
+		Purpose:  "List the backup archives stored on the controller.",
+		Doc:      listDoc,
+		Examples: listExamples,
+		SeeAlso: []string{
+			"create-backup",
+			"download-backup",
+			"verify-backup",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatBackupsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listCommand) Init(args []string) error {
+	if err := c.CommandBase.Init(args); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+type backupDetails struct {
+	ID          string    `json:"id" yaml:"id"`
+	Started     time.Time `json:"started" yaml:"started"`
+	Finished    time.Time `json:"finished,omitempty" yaml:"finished,omitempty"`
+	Size        int64     `json:"size" yaml:"size"`
+	Checksum    string    `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+	JujuVersion string    `json:"juju-version,omitempty" yaml:"juju-version,omitempty"`
+	Notes       string    `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.List) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No backups are stored on the controller.")
+		return nil
+	}
+
+	details := make([]backupDetails, len(result.List))
+	for i, meta := range result.List {
+		details[i] = newBackupDetails(meta)
+	}
+	return c.out.Write(ctx, details)
+}
+
+func newBackupDetails(meta params.BackupsMetadataResult) backupDetails {
+	details := backupDetails{
+		ID:       meta.ID,
+		Started:  meta.Started,
+		Finished: meta.Finished,
+		Size:     meta.Size,
+		Checksum: meta.Checksum,
+		Notes:    meta.Notes,
+	}
+	// Archives without stored metadata report an unknown version.
+	if meta.Version != backups.UnknownVersion && meta.Version != version.Zero {
+		details.JujuVersion = meta.Version.String()
+	}
+	return details
+}
+
+func formatBackupsTabular(writer io.Writer, value interface{}) error {
+	details, ok := value.([]backupDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", details, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.SetColumnAlignRight(2)
+
+	w.Println("ID", "Finished", "Size", "Version", "Notes")
+	for _, d := range details {
+		finished := "-"
+		if !d.Finished.IsZero() {
+			finished = d.Finished.Format(time.RFC3339)
+		}
+		version := d.JujuVersion
+		if version == "" {
+			version = "-"
+		}
+		w.Println(d.ID, finished, humanize.IBytes(uint64(d.Size)), version, d.Notes)
+	}
+	return tw.Flush()
+}
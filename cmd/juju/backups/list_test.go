@@ -0,0 +1,120 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+)
+
+type listSuite struct {
+	BaseBackupsSuite
+	command cmd.Command
+}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.command = backups.NewListCommandForTest(s.store)
+
+	s.metaresult.ID = "/var/lib/juju/backups/juju-backup-20240101-120000.tar.gz"
+	s.metaresult.Finished = time.Date(2024, 1, 1, 12, 0, 5, 0, time.UTC)
+	s.metaresult.Size = 2048
+	s.metaresult.Version = version.MustParse("3.5.0")
+	s.metaresult.Notes = "nightly"
+}
+
+func (s *listSuite) TestTabular(c *gc.C) {
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.command)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "List")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+ID                                                        Finished                 Size  Version  Notes
+/var/lib/juju/backups/juju-backup-20240101-120000.tar.gz  2024-01-01T12:00:05Z  2.0 KiB  3.5.0    nightly
+`[1:])
+}
+
+func (s *listSuite) TestYAML(c *gc.C) {
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.command, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: /var/lib/juju/backups/juju-backup-20240101-120000.tar.gz
+  started: 0001-01-01T00:00:00Z
+  finished: 2024-01-01T12:00:05Z
+  size: 2048
+  juju-version: 3.5.0
+  notes: nightly
+`[1:])
+}
+
+func (s *listSuite) TestEmpty(c *gc.C) {
+	client := &fakeAPIClient{}
+	s.patchAPIClient(client)
+	ctx, err := cmdtesting.RunCommand(c, s.command)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No backups are stored on the controller.\n")
+}
+
+func (s *listSuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.command)
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
+
+type verifySuite struct {
+	BaseBackupsSuite
+	command cmd.Command
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.command = backups.NewVerifyCommandForTest(s.store)
+}
+
+func (s *verifySuite) TestOkay(c *gc.C) {
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.command, "/path/to/backup")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Verify")
+	client.CheckArgs(c, "/path/to/backup")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Backup \"/path/to/backup\" verified\n")
+}
+
+func (s *verifySuite) TestVerbose(c *gc.C) {
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.createCommandForGlobalOptionTesting(s.command), "verify-backup", "/path/to/backup", "--verbose")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, MetaResultString)
+}
+
+func (s *verifySuite) TestMissingID(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.command)
+	c.Assert(err, gc.ErrorMatches, "missing backup ID")
+}
+
+func (s *verifySuite) TestError(c *gc.C) {
+	s.setFailure("checksum mismatch")
+	_, err := cmdtesting.RunCommand(c, s.command, "/path/to/backup")
+	c.Check(err, gc.ErrorMatches, `verifying backup "/path/to/backup": checksum mismatch`)
+}
@@ -169,6 +169,9 @@ type fakeAPIClient struct {
 	args  []string
 	idArg string
 	notes string
+
+	restoreErr        string
+	restoreStatusErrs []error
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -206,6 +209,74 @@ func (c *fakeAPIClient) Download(id string) (io.ReadCloser, error) {
 	return c.archive, nil
 }
 
+func (c *fakeAPIClient) Upload(archive io.ReadSeeker) (string, error) {
+	c.calls = append(c.calls, "Upload")
+	if c.err != nil {
+		return "", c.err
+	}
+	data, err := io.ReadAll(archive)
+	if err != nil {
+		return "", err
+	}
+	c.args = append(c.args, string(data))
+	return c.metaresult.Filename, nil
+}
+
+func (c *fakeAPIClient) Restore(id string) (*params.BackupsRestoreResult, error) {
+	c.calls = append(c.calls, "Restore")
+	c.args = append(c.args, id)
+	c.idArg = id
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &params.BackupsRestoreResult{
+		Steps: []string{"restoring database"},
+	}, nil
+}
+
+func (c *fakeAPIClient) RestoreStatus(id string) (*params.BackupsRestoreResult, error) {
+	c.calls = append(c.calls, "RestoreStatus")
+	if c.err != nil {
+		return nil, c.err
+	}
+	if len(c.restoreStatusErrs) > 0 {
+		err := c.restoreStatusErrs[0]
+		c.restoreStatusErrs = c.restoreStatusErrs[1:]
+		return nil, err
+	}
+	result := &params.BackupsRestoreResult{
+		Metadata: *c.metaresult,
+		Steps:    []string{"restoring database", "re-seeding agent config"},
+		Done:     true,
+	}
+	if c.restoreErr != "" {
+		result.Error = &params.Error{Message: c.restoreErr}
+	}
+	return result, nil
+}
+
+func (c *fakeAPIClient) List() (*params.BackupsListResult, error) {
+	c.calls = append(c.calls, "List")
+	if c.err != nil {
+		return nil, c.err
+	}
+	var result params.BackupsListResult
+	if c.metaresult != nil {
+		result.List = append(result.List, *c.metaresult)
+	}
+	return &result, nil
+}
+
+func (c *fakeAPIClient) Verify(id string) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "Verify")
+	c.args = append(c.args, id)
+	c.idArg = id
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
 func (c *fakeAPIClient) Close() error {
 	return nil
 }
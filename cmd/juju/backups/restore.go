@@ -0,0 +1,193 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/rpc/params"
+)
+
+const restoreDoc = `
+restore-backup rebuilds a controller from a backup archive created
+with 'juju create-backup'.
+
+The archive is uploaded to the controller and restored in place. The
+controller must have been freshly bootstrapped, and must be running the
+same major and minor Juju version as the controller that created the
+backup. The controller's database and state-related files are replaced
+with those in the archive, and the agent configuration of the
+controller machine is re-seeded so that it keeps its current addresses.
+
+The restore runs on the controller machine, outside the controller
+agent. The agent is stopped while the database is replaced, so the
+controller is unavailable for the duration of the restore, and it is
+started again once the restore has finished, whether or not it
+succeeded. Uploaded archives are removed from the controller once they
+have been restored.
+
+Progress is reported as each restore step starts, reconnecting to the
+controller as needed. If the restore fails, the step it failed in is
+reported. The restored controller uses the users and credentials held
+in the backup, so once the restore has finished, log in again with the
+credentials of the controller that was backed up if they differ.
+
+Use --verbose to see the metadata of the restored backup.
+`
+
+const restoreExamples = `
+    juju restore-backup juju-backup-20240101-120000.tar.gz
+`
+
+// restorePollInterval is how often the progress of a restore is
+// checked.
+var restorePollInterval = time.Second
+
+// restoreUnavailableTimeout is how long the controller may be
+// unreachable while a restore runs before giving up on waiting for
+// the restore to finish.
+var restoreUnavailableTimeout = 30 * time.Minute
+
+// NewRestoreCommand returns a command used to restore backups.
+func NewRestoreCommand() cmd.Command {
+	return modelcmd.Wrap(&restoreCommand{})
+}
+
+// restoreCommand is the sub-command for restoring a backup archive.
+type restoreCommand struct {
+	CommandBase
+	// Filename is the local backup archive to restore.
+	Filename string
+}
+
+// Info implements Command.Info.
+func (c *restoreCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "restore-backup",
+		Args:     "<local backup archive>",
+		Purpose:  "Restore a controller from a backup archive.",
+		Doc:      restoreDoc,
+		Examples: restoreExamples,
+		SeeAlso: []string{
+			"create-backup",
+			"download-backup",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements Command.Init.
+func (c *restoreCommand) Init(args []string) error {
+	if err := c.CommandBase.Init(args); err != nil {
+		return errors.Trace(err)
+	}
+	if len(args) == 0 {
+		return errors.New("missing filename")
+	}
+	filename, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.Filename = filename
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *restoreCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+
+	archive, err := c.Filesystem().Open(ctx.AbsPath(c.Filename))
+	if err != nil {
+		return errors.Annotate(err, "while opening local archive file")
+	}
+	defer func() { _ = archive.Close() }()
+
+	id, result, err := c.startRestore(ctx, archive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// The controller agent is stopped while the restore runs, and the
+	// restored controller has a different database, so each check on
+	// the restore's progress is made over a new connection.
+	var reported int
+	unavailableSince := time.Now()
+	for {
+		for _, step := range result.Steps[reported:] {
+			ctx.Infof("  %s", step)
+		}
+		reported = len(result.Steps)
+		if result.Done {
+			break
+		}
+		<-time.After(restorePollInterval)
+		status, err := c.restoreStatus(id)
+		if err != nil {
+			if time.Since(unavailableSince) > restoreUnavailableTimeout {
+				return errors.Annotate(err, "while waiting for restore")
+			}
+			// The controller is unavailable while its agent is
+			// stopped, so keep trying.
+			continue
+		}
+		result = status
+		unavailableSince = time.Now()
+	}
+	if result.Error != nil {
+		return errors.Annotate(result.Error, "while restoring backup archive")
+	}
+
+	if c.verbose {
+		ctx.Verbosef(c.metadata(&result.Metadata))
+	}
+	ctx.Infof("Restore complete; the controller agent has been restarted with the restored state.")
+	return nil
+}
+
+// startRestore uploads the archive and starts restoring the
+// controller from it.
+func (c *restoreCommand) startRestore(ctx *cmd.Context, archive io.ReadSeeker) (string, *params.BackupsRestoreResult, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx.Infof("Uploading backup archive %q", c.Filename)
+	id, err := client.Upload(archive)
+	if err != nil {
+		return "", nil, errors.Annotate(err, "while uploading backup archive")
+	}
+
+	ctx.Infof("Restoring controller from backup archive %q", id)
+	result, err := client.Restore(id)
+	if err != nil {
+		return "", nil, errors.Annotate(err, "while restoring backup archive")
+	}
+	return id, result, nil
+}
+
+// restoreStatus returns the progress of the restore of the archive
+// with the given ID.
+func (c *restoreCommand) restoreStatus(id string) (*params.BackupsRestoreResult, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+	return client.RestoreStatus(id)
+}
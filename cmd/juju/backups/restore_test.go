@@ -0,0 +1,114 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+)
+
+type restoreSuite struct {
+	BaseBackupsSuite
+	wrappedCommand cmd.Command
+	command        *backups.RestoreCommand
+	archive        string
+}
+
+var _ = gc.Suite(&restoreSuite{})
+
+func (s *restoreSuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.wrappedCommand, s.command = backups.NewRestoreCommandForTest(s.store)
+	s.PatchValue(backups.RestorePollInterval, time.Duration(0))
+
+	s.archive = filepath.Join(c.MkDir(), "juju-backup.tar.gz")
+	err := os.WriteFile(s.archive, []byte(s.data), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) TestOkay(c *gc.C) {
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Upload", "Restore", "RestoreStatus")
+	client.CheckArgs(c, s.data, s.metaresult.Filename)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Uploading backup archive "`[1:]+s.archive+`"
+Restoring controller from backup archive "backup-filename"
+  restoring database
+  re-seeding agent config
+Restore complete; the controller agent has been restarted with the restored state.
+`)
+}
+
+func (s *restoreSuite) TestControllerUnavailable(c *gc.C) {
+	client := s.setSuccess()
+	client.restoreStatusErrs = []error{
+		errors.New("connection refused"),
+		errors.New("connection refused"),
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Upload", "Restore", "RestoreStatus", "RestoreStatus", "RestoreStatus")
+	c.Check(cmdtesting.Stderr(ctx), jc.HasSuffix, `
+Restore complete; the controller agent has been restarted with the restored state.
+`)
+}
+
+func (s *restoreSuite) TestControllerUnavailableTimeout(c *gc.C) {
+	s.PatchValue(backups.RestoreUnavailableTimeout, time.Duration(0))
+	client := s.setSuccess()
+	client.restoreStatusErrs = []error{errors.New("connection refused")}
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.archive)
+	c.Assert(err, gc.ErrorMatches, "while waiting for restore: connection refused")
+	client.CheckCalls(c, "Upload", "Restore", "RestoreStatus")
+}
+
+func (s *restoreSuite) TestRestoreFailed(c *gc.C) {
+	client := s.setSuccess()
+	client.restoreErr = "failed!"
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.archive)
+	c.Assert(err, gc.ErrorMatches, "while restoring backup archive: failed!")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Uploading backup archive "`[1:]+s.archive+`"
+Restoring controller from backup archive "backup-filename"
+  restoring database
+  re-seeding agent config
+`)
+}
+
+func (s *restoreSuite) TestMissingFilename(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Assert(err, gc.ErrorMatches, "missing filename")
+}
+
+func (s *restoreSuite) TestTooManyArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.archive, "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *restoreSuite) TestMissingArchive(c *gc.C) {
+	client := s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, filepath.Join(c.MkDir(), "missing.tar.gz"))
+	c.Assert(err, gc.ErrorMatches, "while opening local archive file: .*")
+	client.CheckCalls(c)
+}
+
+func (s *restoreSuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.archive)
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
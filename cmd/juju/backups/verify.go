@@ -0,0 +1,95 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const verifyDoc = `
+verify-backup checks a backup archive stored on the controller against
+the size and checksum recorded when the backup was created.
+
+The command fails if the archive has been modified or truncated since it
+was created, or if no checksum was recorded for it (for example, for
+archives uploaded with 'juju restore-backup').
+
+Use --verbose to see the metadata of the verified backup.
+`
+
+const verifyExamples = `
+    juju verify-backup /full/path/to/backup/on/controller
+`
+
+// NewVerifyCommand returns a command used to verify stored backups.
+func NewVerifyCommand() cmd.Command {
+	return modelcmd.Wrap(&verifyCommand{})
+}
+
+// verifyCommand is the sub-command for verifying a stored backup archive.
+type verifyCommand struct {
+	CommandBase
+	// ID is the stored backup to verify.
+	ID string
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "verify-backup",
+		Args:     "/full/path/to/backup/on/controller",
+		Purpose:  "Verify a backup archive stored on the controller.",
+		Doc:      verifyDoc,
+		Examples: verifyExamples,
+		SeeAlso: []string{
+			"backups",
+			"create-backup",
+		},
+	})
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	if err := c.CommandBase.Init(args); err != nil {
+		return errors.Trace(err)
+	}
+	if len(args) == 0 {
+		return errors.New("missing backup ID")
+	}
+	id, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.ID = id
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Verify(c.ID)
+	if err != nil {
+		return errors.Annotatef(err, "verifying backup %q", c.ID)
+	}
+
+	if c.verbose {
+		fmt.Fprintln(ctx.Stdout, c.metadata(result))
+	}
+	ctx.Infof("Backup %q verified", c.ID)
+	return nil
+}
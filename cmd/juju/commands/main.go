@@ -411,6 +411,9 @@ func registerCommands(r commandRegistry) {
 	// Manage backups.
 	r.Register(backups.NewCreateCommand())
 	r.Register(backups.NewDownloadCommand())
+	r.Register(backups.NewRestoreCommand())
+	r.Register(backups.NewListCommand())
+	r.Register(backups.NewVerifyCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -429,6 +432,11 @@ func registerCommands(r commandRegistry) {
 	r.Register(user.NewLogoutCommand())
 	r.Register(user.NewRemoveCommand())
 	r.Register(user.NewWhoAmICommand())
+	r.Register(user.NewAddGroupCommand())
+	r.Register(user.NewRemoveGroupCommand())
+	r.Register(user.NewAddToGroupCommand())
+	r.Register(user.NewRemoveFromGroupCommand())
+	r.Register(user.NewListGroupsCommand())
 
 	// Manage machines
 	r.Register(machine.NewAddCommand())
@@ -453,11 +461,15 @@ func registerCommands(r commandRegistry) {
 		r.Register(model.NewBranchCommand())
 		r.Register(model.NewDiffCommand())
 		r.Register(model.NewAbortCommand())
+		r.Register(model.NewRolloutCommand())
 		r.Register(model.NewCommitsCommand())
 		r.Register(model.NewShowCommitCommand())
 	}
 
 	r.Register(newMigrateCommand())
+	r.Register(newMigrateModelsCommand())
+	r.Register(newMigrationPlansCommand())
+	r.Register(newCancelMigrationPlanCommand())
 	r.Register(model.NewExportBundleCommand())
 
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -506,6 +518,10 @@ func registerCommands(r commandRegistry) {
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
+	r.Register(storage.NewSnapshotCommand())
+	r.Register(storage.NewSnapshotListCommand())
+	r.Register(storage.NewSnapshotRemoveCommand())
+	r.Register(storage.NewResizeCommand())
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
@@ -530,6 +546,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -564,6 +581,9 @@ func registerCommands(r commandRegistry) {
 	// Manage Application Credential Access
 	r.Register(application.NewTrustCommand())
 
+	// Manage Application Egress
+	r.Register(application.NewSetEgressCommand())
+
 	// Juju Dashboard commands.
 	r.Register(dashboard.NewDashboardCommand())
 
@@ -585,6 +605,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(secrets.NewRemoveSecretCommand())
 	r.Register(secrets.NewGrantSecretCommand())
 	r.Register(secrets.NewRevokeSecretCommand())
+	r.Register(secrets.NewMigrateSecretsCommand())
 
 	// Secret backends.
 	r.Register(secretbackends.NewListSecretBackendsCommand())
@@ -309,6 +309,7 @@ var commandNames = []string{
 	"actions",
 	"add-cloud",
 	"add-credential",
+	"add-group",
 	"add-k8s",
 	"add-machine",
 	"add-model",
@@ -317,15 +318,19 @@ var commandNames = []string{
 	"add-ssh-key",
 	"add-secret",
 	"add-storage",
+	"add-to-group",
 	"add-unit",
 	"add-user",
 	"agree",
 	"agreements",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
+	"backups",
 	"bind",
 	"bootstrap",
+	"cancel-migration-plan",
 	"cancel-task",
 	"change-user-password",
 	"charm-resources",
@@ -371,6 +376,7 @@ var commandNames = []string{
 	"grant",
 	"grant-secret",
 	"grant-cloud",
+	"groups",
 	"help",
 	"help-tool",
 	"import-filesystem",
@@ -380,12 +386,14 @@ var commandNames = []string{
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
+	"list-backups",
 	"list-charm-resources",
 	"list-clouds",
 	"list-controllers",
 	"list-credentials",
 	"list-disabled-commands",
 	"list-firewall-rules",
+	"list-groups",
 	"list-machines",
 	"list-models",
 	"list-offers",
@@ -399,6 +407,7 @@ var commandNames = []string{
 	"list-ssh-keys",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"login",
@@ -406,6 +415,9 @@ var commandNames = []string{
 	"machines",
 	"metrics",
 	"migrate",
+	"migrate-models",
+	"migrate-secrets",
+	"migration-plans",
 	"model-config",
 	"model-default",
 	"model-defaults",
@@ -423,6 +435,8 @@ var commandNames = []string{
 	"remove-application",
 	"remove-cloud",
 	"remove-credential",
+	"remove-from-group",
+	"remove-group",
 	"remove-k8s",
 	"remove-machine",
 	"remove-offer",
@@ -434,12 +448,15 @@ var commandNames = []string{
 	"remove-ssh-key",
 	"remove-storage",
 	"remove-storage-pool",
+	"remove-storage-snapshot",
 	"remove-unit",
 	"remove-user",
 	"rename-space",
+	"resize-storage",
 	"resolved",
 	"resolve",
 	"resources",
+	"restore-backup",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
@@ -455,6 +472,7 @@ var commandNames = []string{
 	"set-constraints",
 	"set-default-credentials",
 	"set-default-region",
+	"set-egress",
 	"set-firewall-rule",
 	"set-meter-status",
 	"set-model-constraints",
@@ -476,12 +494,14 @@ var commandNames = []string{
 	"show-task",
 	"show-unit",
 	"show-user",
+	"snapshot-storage",
 	"spaces",
 	"ssh",
 	"ssh-keys",
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
 	"suspend-relation",
 	"switch",
@@ -501,6 +521,7 @@ var commandNames = []string{
 	"upgrade-model",
 	"upgrade-machine",
 	"users",
+	"verify-backup",
 	"version",
 	"wait-for",
 	"whoami",
@@ -4,12 +4,14 @@
 package commands
 
 import (
+	"io"
 	"strings"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/httpbakery"
 	"github.com/juju/cmd/v3"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
 	"gopkg.in/macaroon.v2"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/juju/juju/api/controller/controller"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/rpc/params"
 )
@@ -34,7 +37,9 @@ func newMigrateCommand() modelcmd.ModelCommand {
 // migrateCommand initiates a model migration.
 type migrateCommand struct {
 	modelcmd.ModelCommandBase
+	out              cmd.Output
 	targetController string
+	dryRun           bool
 
 	// Overridden by tests
 	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
@@ -45,6 +50,7 @@ type migrateCommand struct {
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationDryRun(spec controller.MigrationSpec) ([]params.MigrationBlocker, error)
 	IdentityProviderURL() (string, error)
 	Close() error
 }
@@ -77,10 +83,28 @@ Once the migration is complete, the model's machine and unit agents
 will be connected to the new controller. The model will no longer be
 available at the source controller.
 
+A migration always moves a whole model, with all of its applications,
+machines, storage and offers. Moving only some of a model's applications
+into another model is not supported. To split a model, deploy the
+applications to be moved into the other model, relate them to the
+applications left behind through offers (see 'offer' and 'consume'),
+and then remove them from the original model.
+
 If the migration fails for some reason, the model is returned to its
 original state where it is managed by the original
 controller.
 
+With --dry-run, no migration is started. Instead, all of the checks
+made before and during a migration are run against the model and the
+target controller, and every issue that would block the migration is
+reported. The command exits with an error if any blockers are found.
+
+Examples:
+
+    juju migrate mymodel target-controller
+    juju migrate --dry-run mymodel target-controller
+    juju migrate --dry-run --format yaml mymodel target-controller
+
 `
 
 // Info implements cmd.Command.
@@ -98,6 +122,17 @@ func (c *migrateCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model can be migrated, without migrating it")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrationBlockersTabular,
+	})
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -133,6 +168,11 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
+	if c.dryRun {
+		// The users are checked by the controller as part of
+		// the dry run, along with everything else.
+		return c.runDryRun(ctx, spec)
+	}
 	if err := c.checkMigrationFeasibility(spec); err != nil {
 		return errors.Trace(err)
 	}
@@ -153,15 +193,77 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// migrationBlocker holds an issue, found by a migration dry run,
+// for display.
+type migrationBlocker struct {
+	Check   string `yaml:"check" json:"check"`
+	Message string `yaml:"message" json:"message"`
+}
+
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, spec *controller.MigrationSpec) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	results, err := api.MigrationDryRun(*spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 {
+		ctx.Infof("No blockers found; model can be migrated to %q", c.targetController)
+		return nil
+	}
+	blockers := make([]migrationBlocker, len(results))
+	for i, result := range results {
+		blockers[i] = migrationBlocker{
+			Check:   result.Check,
+			Message: result.Message,
+		}
+	}
+	if err := c.out.Write(ctx, blockers); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.ErrSilent
+}
+
+func formatMigrationBlockersTabular(writer io.Writer, value interface{}) error {
+	blockers, ok := value.([]migrationBlocker)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", blockers, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Check", "Message")
+	for _, blocker := range blockers {
+		w.Println(blocker.Check, blocker.Message)
+	}
+	return tw.Flush()
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
-	store := c.ClientStore()
+	return migrationTargetSpec(c.ClientStore(), c.targetController, c.getTargetControllerMacaroons)
+}
 
-	controllerInfo, err := store.ControllerByName(c.targetController)
+// migrationTargetSpec returns a migration spec holding the details of
+// the target controller, as recorded in the client store. If there is
+// no password for the target controller, getMacaroons is used to
+// obtain macaroons to authenticate with instead.
+func migrationTargetSpec(
+	store jujuclient.ClientStore,
+	targetController string,
+	getMacaroons func() ([]macaroon.Slice, error),
+) (*controller.MigrationSpec, error) {
+	controllerInfo, err := store.ControllerByName(targetController)
 	if err != nil {
 		return nil, err
 	}
 
-	accountInfo, err := store.AccountDetails(c.targetController)
+	accountInfo, err := store.AccountDetails(targetController)
 	if err != nil {
 		return nil, err
 	}
@@ -169,7 +271,7 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	var macs []macaroon.Slice
 	if accountInfo.Password == "" {
 		var err error
-		macs, err = c.getTargetControllerMacaroons()
+		macs, err = getMacaroons()
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -177,7 +279,7 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 
 	return &controller.MigrationSpec{
 		TargetControllerUUID:  controllerInfo.ControllerUUID,
-		TargetControllerAlias: c.targetController,
+		TargetControllerAlias: targetController,
 		TargetAddrs:           controllerInfo.APIEndpoints,
 		TargetCACert:          controllerInfo.CACert,
 		TargetUser:            accountInfo.User,
@@ -229,17 +331,26 @@ func (c *migrateCommand) getTargetControllerUserAPI() (userListAPI, error) {
 }
 
 func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error) {
-	jar, err := c.CommandBase.CookieJar(c.ClientStore(), c.targetController)
+	return targetControllerMacaroons(&c.CommandBase, c.ClientStore(), c.newAPIRoot, c.targetController)
+}
+
+// targetControllerMacaroons connects to the target controller, ensuring
+// up-to-date macaroons, and returns the macaroons in the cookie jar for
+// the controller.
+func targetControllerMacaroons(
+	base *modelcmd.CommandBase,
+	store jujuclient.ClientStore,
+	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error),
+	targetController string,
+) ([]macaroon.Slice, error) {
+	jar, err := base.CookieJar(store, targetController)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	// Connect to the target controller, ensuring up-to-date macaroons,
-	// and return the macaroons in the cookie jar for the controller.
-	//
 	// TODO(axw,mjs) add a controller API that returns a macaroon that
 	// may be used for the sole purpose of migration.
-	api, err := c.newAPIRoot(c.ClientStore(), c.targetController, "")
+	api, err := newAPIRoot(store, targetController, "")
 	if err != nil {
 		return nil, errors.Annotate(err, "connecting to target controller")
 	}
@@ -288,6 +288,52 @@ func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestDryRunNoBlockers(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No blockers found; model can be migrated to \"target\"\n")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunBlockers(c *gc.C) {
+	s.api.blockers = []params.MigrationBlocker{{
+		Check:   "source",
+		Message: "machine 0 is dying",
+	}, {
+		Check:   "credential",
+		Message: "credential \"cred\" not valid",
+	}}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Check       Message
+source      machine 0 is dying
+credential  credential "cred" not valid
+`[1:])
+}
+
+func (s *MigrateSuite) TestDryRunBlockersYAML(c *gc.C) {
+	s.api.blockers = []params.MigrationBlocker{{
+		Check:   "target",
+		Message: "model named \"model\" already exists",
+	}}
+	ctx, err := s.makeAndRun(c, "--dry-run", "--format", "yaml", "model", "target")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- check: target
+  message: model named "model" already exists
+`[1:])
+}
+
 func (s *MigrateSuite) TestModelDoesntExist(c *gc.C) {
 	cmd := s.makeCommand()
 	_, err := cmdtesting.RunCommand(c, cmd, "wat", "target")
@@ -441,6 +487,7 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 type fakeMigrateAPI struct {
 	specSeen    *controller.MigrationSpec
 	identityURL string
+	blockers    []params.MigrationBlocker
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -448,6 +495,11 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) MigrationDryRun(spec controller.MigrationSpec) ([]params.MigrationBlocker, error) {
+	a.specSeen = &spec
+	return a.blockers, nil
+}
+
 func (a *fakeMigrateAPI) IdentityProviderURL() (string, error) {
 	return a.identityURL, nil
 }
@@ -0,0 +1,458 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+	"gopkg.in/macaroon.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/controller/controller"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc/params"
+)
+
+type migrationPlanAPI interface {
+	CreateMigrationPlan(spec controller.MigrationPlanSpec) (string, error)
+	MigrationPlans() ([]params.MigrationPlan, error)
+	CancelMigrationPlan(id string) error
+	Close() error
+}
+
+func newMigrateModelsCommand() modelcmd.ControllerCommand {
+	var cmd migrateModelsCommand
+	cmd.newAPIRoot = cmd.CommandBase.NewAPIRoot
+	return modelcmd.WrapController(&cmd)
+}
+
+// migrateModelsCommand creates a plan to migrate a number of models.
+type migrateModelsCommand struct {
+	modelcmd.ControllerCommandBase
+	targetController string
+	modelNames       []string
+	concurrency      int
+	maxAttempts      int
+	windowStart      string
+	windowEnd        string
+
+	// Overridden by tests
+	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
+	api        migrationPlanAPI
+}
+
+const migrateModelsDoc = `
+The 'migrate-models' command asks the controller to migrate a number
+of workload models to another controller. Rather than starting every
+migration at once, the controller works through the models in the
+order given, running no more than --concurrency migrations at a time.
+A migration which fails is retried until it has been attempted
+--max-attempts times.
+
+The migrations can be restricted to a time window using --start and
+--end, which take RFC 3339 times. No migrations are started before the
+window opens; once it closes, no further migrations are started and
+any models still waiting are reported as failed.
+
+As with the 'migrate' command, the target controller must be in the
+juju client's local configuration cache.
+
+The progress of the migrations can be followed using the
+'migration-plans' command, and the plan stopped using the
+'cancel-migration-plan' command.
+
+Examples:
+
+    juju migrate-models target-controller model1 model2 model3
+    juju migrate-models --concurrency 2 --max-attempts 5 target-controller model1 model2
+    juju migrate-models --start 2023-06-01T01:00:00Z --end 2023-06-01T05:00:00Z \
+        target-controller model1 model2
+
+`
+
+// Info implements cmd.Command.
+func (c *migrateModelsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "migrate-models",
+		Args:    "<target-controller-name> <model-name> ...",
+		Purpose: "Migrate a number of workload models to another controller.",
+		Doc:     migrateModelsDoc,
+		SeeAlso: []string{
+			"migrate",
+			"migration-plans",
+			"cancel-migration-plan",
+		},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *migrateModelsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.IntVar(&c.concurrency, "concurrency", 1, "Maximum number of migrations to run at once")
+	f.IntVar(&c.maxAttempts, "max-attempts", 3, "Number of times to attempt the migration of each model")
+	f.StringVar(&c.windowStart, "start", "", "Time (RFC 3339) before which no migrations are started")
+	f.StringVar(&c.windowEnd, "end", "", "Time (RFC 3339) after which no migrations are started")
+}
+
+// Init implements cmd.Command.
+func (c *migrateModelsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("target controller not specified")
+	}
+	if len(args) < 2 {
+		return errors.New("no models specified")
+	}
+	c.targetController = args[0]
+	c.modelNames = args[1:]
+	if c.concurrency < 1 {
+		return errors.NotValidf("concurrency %d", c.concurrency)
+	}
+	if c.maxAttempts < 1 {
+		return errors.NotValidf("max attempts %d", c.maxAttempts)
+	}
+	for _, value := range []string{c.windowStart, c.windowEnd} {
+		if _, err := parseWindowTime(value); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func parseWindowTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.NotValidf("time %q (expected RFC 3339 format)", value)
+	}
+	return t, nil
+}
+
+// Run implements cmd.Command.
+func (c *migrateModelsCommand) Run(ctx *cmd.Context) error {
+	target, err := migrationTargetSpec(c.ClientStore(), c.targetController, c.getTargetControllerMacaroons)
+	if err != nil {
+		return err
+	}
+	uuids, err := c.ModelUUIDs(c.modelNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Init has already checked the times.
+	windowStart, _ := parseWindowTime(c.windowStart)
+	windowEnd, _ := parseWindowTime(c.windowEnd)
+
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	id, err := api.CreateMigrationPlan(controller.MigrationPlanSpec{
+		ModelUUIDs:            uuids,
+		TargetControllerUUID:  target.TargetControllerUUID,
+		TargetControllerAlias: target.TargetControllerAlias,
+		TargetAddrs:           target.TargetAddrs,
+		TargetCACert:          target.TargetCACert,
+		TargetUser:            target.TargetUser,
+		TargetPassword:        target.TargetPassword,
+		TargetMacaroons:       target.TargetMacaroons,
+		Concurrency:           c.concurrency,
+		MaxAttempts:           c.maxAttempts,
+		WindowStart:           windowStart,
+		WindowEnd:             windowEnd,
+	})
+	if err != nil {
+		return err
+	}
+	ctx.Infof("Migration plan %q created for %d models", id, len(uuids))
+	return nil
+}
+
+func (c *migrateModelsCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error) {
+	return targetControllerMacaroons(&c.CommandBase, c.ClientStore(), c.newAPIRoot, c.targetController)
+}
+
+func (c *migrateModelsCommand) getAPI() (migrationPlanAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+func newMigrationPlansCommand() modelcmd.ControllerCommand {
+	return modelcmd.WrapController(&migrationPlansCommand{})
+}
+
+// migrationPlansCommand shows the progress of migration plans.
+type migrationPlansCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	// Overridden by tests
+	api migrationPlanAPI
+}
+
+const migrationPlansDoc = `
+The 'migration-plans' command shows the migration plans created using
+the 'migrate-models' command, along with the progress of each model in
+them.
+
+Examples:
+
+    juju migration-plans
+    juju migration-plans --format yaml
+
+`
+
+// Info implements cmd.Command.
+func (c *migrationPlansCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "migration-plans",
+		Purpose: "Show the progress of plans to migrate many models.",
+		Doc:     migrationPlansDoc,
+		SeeAlso: []string{
+			"migrate-models",
+			"cancel-migration-plan",
+		},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *migrationPlansCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrationPlansTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *migrationPlansCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// migrationPlan holds the details of a migration plan for display.
+type migrationPlan struct {
+	Id          string               `yaml:"id" json:"id"`
+	InitiatedBy string               `yaml:"initiated-by" json:"initiated-by"`
+	Created     time.Time            `yaml:"created" json:"created"`
+	Target      string               `yaml:"target-controller" json:"target-controller"`
+	Concurrency int                  `yaml:"concurrency" json:"concurrency"`
+	MaxAttempts int                  `yaml:"max-attempts" json:"max-attempts"`
+	WindowStart *time.Time           `yaml:"window-start,omitempty" json:"window-start,omitempty"`
+	WindowEnd   *time.Time           `yaml:"window-end,omitempty" json:"window-end,omitempty"`
+	Progress    string               `yaml:"progress" json:"progress"`
+	Cancelled   bool                 `yaml:"cancelled,omitempty" json:"cancelled,omitempty"`
+	Models      []migrationPlanModel `yaml:"models" json:"models"`
+}
+
+// migrationPlanModel holds the progress of a model in a migration
+// plan for display.
+type migrationPlanModel struct {
+	Model       string     `yaml:"model" json:"model"`
+	Status      string     `yaml:"status" json:"status"`
+	Attempts    int        `yaml:"attempts" json:"attempts"`
+	MigrationId string     `yaml:"migration-id,omitempty" json:"migration-id,omitempty"`
+	Message     string     `yaml:"message,omitempty" json:"message,omitempty"`
+	RetryAfter  *time.Time `yaml:"retry-after,omitempty" json:"retry-after,omitempty"`
+}
+
+// Run implements cmd.Command.
+func (c *migrationPlansCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	results, err := api.MigrationPlans()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No migration plans found.")
+		return nil
+	}
+
+	plans := make([]migrationPlan, len(results))
+	for i, result := range results {
+		plan := migrationPlan{
+			Id:          result.Id,
+			InitiatedBy: result.InitiatedBy,
+			Created:     result.Created,
+			Target:      result.TargetControllerAlias,
+			Concurrency: result.Concurrency,
+			MaxAttempts: result.MaxAttempts,
+			WindowStart: result.WindowStart,
+			WindowEnd:   result.WindowEnd,
+			Progress:    migrationPlanProgress(result.Models),
+			Cancelled:   result.Cancelled,
+		}
+		if plan.Target == "" {
+			if tag, err := names.ParseControllerTag(result.TargetControllerTag); err == nil {
+				plan.Target = tag.Id()
+			}
+		}
+		for _, model := range result.Models {
+			name := model.ModelName
+			if name == "" {
+				if tag, err := names.ParseModelTag(model.ModelTag); err == nil {
+					name = tag.Id()
+				}
+			}
+			plan.Models = append(plan.Models, migrationPlanModel{
+				Model:       name,
+				Status:      model.Status,
+				Attempts:    model.Attempts,
+				MigrationId: model.MigrationId,
+				Message:     model.Message,
+				RetryAfter:  model.RetryAfter,
+			})
+		}
+		plans[i] = plan
+	}
+	return c.out.Write(ctx, plans)
+}
+
+func (c *migrationPlansCommand) getAPI() (migrationPlanAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// migrationPlanProgress summarises the progress of the models in a
+// migration plan, e.g. "2/5 succeeded, 1 failed, 1 running, 1 queued".
+// Models left unmigrated by cancelling the plan are counted as cancelled.
+func migrationPlanProgress(models []params.MigrationPlanModel) string {
+	counts := make(map[string]int)
+	for _, model := range models {
+		counts[model.Status]++
+	}
+	parts := []string{fmt.Sprintf("%d/%d succeeded", counts["succeeded"], len(models))}
+	for _, status := range []string{"failed", "cancelled", "running", "queued"} {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatMigrationPlansTabular(writer io.Writer, value interface{}) error {
+	plans, ok := value.([]migrationPlan)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", plans, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Plan", "Target", "Created", "Concurrency", "Window", "Progress")
+	for _, plan := range plans {
+		w.Println(
+			plan.Id, plan.Target, common.FormatTime(&plan.Created, true),
+			plan.Concurrency, formatMigrationWindow(plan.WindowStart, plan.WindowEnd), plan.Progress,
+		)
+	}
+	w.Println()
+	w.Println("Plan", "Model", "Status", "Attempts", "Message")
+	for _, plan := range plans {
+		for _, model := range plan.Models {
+			w.Println(plan.Id, model.Model, model.Status, model.Attempts, model.Message)
+		}
+	}
+	return tw.Flush()
+}
+
+func formatMigrationWindow(start, end *time.Time) string {
+	switch {
+	case start != nil && end != nil:
+		return fmt.Sprintf("%s to %s", common.FormatTime(start, true), common.FormatTime(end, true))
+	case start != nil:
+		return "from " + common.FormatTime(start, true)
+	case end != nil:
+		return "until " + common.FormatTime(end, true)
+	}
+	return "-"
+}
+
+func newCancelMigrationPlanCommand() modelcmd.ControllerCommand {
+	return modelcmd.WrapController(&cancelMigrationPlanCommand{})
+}
+
+// cancelMigrationPlanCommand stops a migration plan.
+type cancelMigrationPlanCommand struct {
+	modelcmd.ControllerCommandBase
+	planId string
+
+	// Overridden by tests
+	api migrationPlanAPI
+}
+
+const cancelMigrationPlanDoc = `
+The 'cancel-migration-plan' command stops the controller from starting
+any further migrations for a plan created using the 'migrate-models'
+command. Models still waiting to be migrated are reported as cancelled.
+Migrations already running are left to finish, and are not retried if
+they fail.
+
+Examples:
+
+    juju cancel-migration-plan 1
+
+`
+
+// Info implements cmd.Command.
+func (c *cancelMigrationPlanCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "cancel-migration-plan",
+		Args:    "<plan-id>",
+		Purpose: "Stop a plan to migrate many models.",
+		Doc:     cancelMigrationPlanDoc,
+		SeeAlso: []string{
+			"migrate-models",
+			"migration-plans",
+		},
+	})
+}
+
+// Init implements cmd.Command.
+func (c *cancelMigrationPlanCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("migration plan not specified")
+	}
+	c.planId = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *cancelMigrationPlanCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	if err := api.CancelMigrationPlan(c.planId); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Migration plan %q cancelled", c.planId)
+	return nil
+}
+
+func (c *cancelMigrationPlanCommand) getAPI() (migrationPlanAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
@@ -0,0 +1,312 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/controller/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/testing"
+)
+
+type MigrationPlanSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeMigrationPlanAPI
+	modelAPI *fakeModelAPI
+	store    *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&MigrationPlanSuite{})
+
+func (s *MigrationPlanSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	err := s.store.AddController("source", jujuclient.ControllerDetails{
+		ControllerUUID: "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("source")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("source", jujuclient.AccountDetails{
+		User: "sourceuser",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.store.AddController("target", jujuclient.ControllerDetails{
+		ControllerUUID: targetControllerUUID,
+		APIEndpoints:   []string{"1.2.3.4:5"},
+		CACert:         "cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("target", jujuclient.AccountDetails{
+		User:     "targetuser",
+		Password: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeMigrationPlanAPI{}
+	s.modelAPI = &fakeModelAPI{
+		models: []base.UserModel{{
+			Name:  "model",
+			UUID:  modelUUID,
+			Type:  model.IAAS,
+			Owner: "sourceuser",
+		}, {
+			Name:  "other",
+			UUID:  "other-uuid",
+			Type:  model.IAAS,
+			Owner: "sourceuser",
+		}},
+	}
+}
+
+func (s *MigrationPlanSuite) makeMigrateModelsCommand() modelcmd.ControllerCommand {
+	cmd := newMigrateModelsCommand()
+	cmd.SetClientStore(s.store)
+	cmd.SetModelAPI(s.modelAPI)
+	inner := modelcmd.InnerCommand(cmd).(*migrateModelsCommand)
+	inner.api = s.api
+	return cmd
+}
+
+func (s *MigrationPlanSuite) makeMigrationPlansCommand() modelcmd.ControllerCommand {
+	cmd := newMigrationPlansCommand()
+	cmd.SetClientStore(s.store)
+	inner := modelcmd.InnerCommand(cmd).(*migrationPlansCommand)
+	inner.api = s.api
+	return cmd
+}
+
+func (s *MigrationPlanSuite) makeCancelMigrationPlanCommand() modelcmd.ControllerCommand {
+	cmd := newCancelMigrationPlanCommand()
+	cmd.SetClientStore(s.store)
+	inner := modelcmd.InnerCommand(cmd).(*cancelMigrationPlanCommand)
+	inner.api = s.api
+	return cmd
+}
+
+func (s *MigrationPlanSuite) TestMigrateModelsInit(c *gc.C) {
+	tests := []struct {
+		args []string
+		err  string
+	}{{
+		err: "target controller not specified",
+	}, {
+		args: []string{"target"},
+		err:  "no models specified",
+	}, {
+		args: []string{"--concurrency", "0", "target", "model"},
+		err:  "concurrency 0 not valid",
+	}, {
+		args: []string{"--max-attempts", "0", "target", "model"},
+		err:  "max attempts 0 not valid",
+	}, {
+		args: []string{"--start", "tomorrow", "target", "model"},
+		err:  `time "tomorrow" \(expected RFC 3339 format\) not valid`,
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, s.makeMigrateModelsCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MigrationPlanSuite) TestMigrateModels(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeMigrateModelsCommand(),
+		"--concurrency", "2", "--start", "2023-06-01T01:00:00Z",
+		"target", "model", "other",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration plan \"1\" created for 2 models\n")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationPlanSpec{
+		ModelUUIDs:            []string{modelUUID, "other-uuid"},
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+		Concurrency:           2,
+		MaxAttempts:           3,
+		WindowStart:           time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *MigrationPlanSuite) TestMigrateModelsUnknownModel(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeMigrateModelsCommand(), "target", "model", "wat")
+	c.Check(err, gc.ErrorMatches, "model .+ not found")
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrationPlanSuite) TestMigrationPlansNone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeMigrationPlansCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No migration plans found.\n")
+}
+
+func (s *MigrationPlanSuite) setPlans() {
+	start := time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
+	s.api.plans = []params.MigrationPlan{{
+		Id:                    "1",
+		InitiatedBy:           "admin",
+		Created:               time.Date(2023, 5, 31, 12, 0, 0, 0, time.UTC),
+		TargetControllerTag:   names.NewControllerTag(targetControllerUUID).String(),
+		TargetControllerAlias: "target",
+		Concurrency:           2,
+		MaxAttempts:           3,
+		WindowStart:           &start,
+		Models: []params.MigrationPlanModel{{
+			ModelTag:    names.NewModelTag(modelUUID).String(),
+			ModelName:   "model",
+			Status:      "succeeded",
+			Attempts:    1,
+			MigrationId: modelUUID + ":0",
+		}, {
+			ModelTag:    names.NewModelTag("other-uuid").String(),
+			ModelName:   "other",
+			Status:      "running",
+			Attempts:    2,
+			MigrationId: "other-uuid:1",
+			Message:     "aborted: boom",
+		}, {
+			ModelTag:  names.NewModelTag("third-uuid").String(),
+			ModelName: "third",
+			Status:    "queued",
+		}},
+	}}
+}
+
+func (s *MigrationPlanSuite) TestMigrationPlansTabular(c *gc.C) {
+	s.setPlans()
+	ctx, err := cmdtesting.RunCommand(c, s.makeMigrationPlansCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Plan  Target  Created               Concurrency  Window                     Progress
+1     target  2023-05-31 12:00:00Z  2            from 2023-06-01 01:00:00Z  1/3 succeeded, 1 running, 1 queued
+
+Plan  Model  Status     Attempts  Message
+1     model  succeeded  1         
+1     other  running    2         aborted: boom
+1     third  queued     0         
+`[1:])
+}
+
+func (s *MigrationPlanSuite) TestMigrationPlansYAML(c *gc.C) {
+	s.setPlans()
+	s.api.plans[0].Models = s.api.plans[0].Models[:1]
+	ctx, err := cmdtesting.RunCommand(c, s.makeMigrationPlansCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: "1"
+  initiated-by: admin
+  created: 2023-05-31T12:00:00Z
+  target-controller: target
+  concurrency: 2
+  max-attempts: 3
+  window-start: 2023-06-01T01:00:00Z
+  progress: 1/1 succeeded
+  models:
+  - model: model
+    status: succeeded
+    attempts: 1
+    migration-id: deadbeef-0bad-400d-8000-4b1d0d06f00d:0
+`[1:])
+}
+
+func (s *MigrationPlanSuite) TestMigrationPlansCancelledYAML(c *gc.C) {
+	s.setPlans()
+	retryAfter := time.Date(2023, 6, 1, 2, 0, 0, 0, time.UTC)
+	s.api.plans[0].Cancelled = true
+	s.api.plans[0].Models = s.api.plans[0].Models[1:]
+	s.api.plans[0].Models[0].RetryAfter = &retryAfter
+	s.api.plans[0].Models[1].Status = "cancelled"
+	ctx, err := cmdtesting.RunCommand(c, s.makeMigrationPlansCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: "1"
+  initiated-by: admin
+  created: 2023-05-31T12:00:00Z
+  target-controller: target
+  concurrency: 2
+  max-attempts: 3
+  window-start: 2023-06-01T01:00:00Z
+  progress: 0/2 succeeded, 1 cancelled, 1 running
+  cancelled: true
+  models:
+  - model: other
+    status: running
+    attempts: 2
+    migration-id: other-uuid:1
+    message: 'aborted: boom'
+    retry-after: 2023-06-01T02:00:00Z
+  - model: third
+    status: cancelled
+    attempts: 0
+`[1:])
+}
+
+func (s *MigrationPlanSuite) TestMigrationPlansError(c *gc.C) {
+	s.api.err = errors.NotSupportedf("migration plans on this controller")
+	_, err := cmdtesting.RunCommand(c, s.makeMigrationPlansCommand())
+	c.Check(err, gc.ErrorMatches, "migration plans on this controller not supported")
+}
+
+type fakeMigrationPlanAPI struct {
+	specSeen  *controller.MigrationPlanSpec
+	plans     []params.MigrationPlan
+	cancelled string
+	err       error
+}
+
+func (a *fakeMigrationPlanAPI) CreateMigrationPlan(spec controller.MigrationPlanSpec) (string, error) {
+	a.specSeen = &spec
+	return "1", a.err
+}
+
+func (a *fakeMigrationPlanAPI) MigrationPlans() ([]params.MigrationPlan, error) {
+	return a.plans, a.err
+}
+
+func (a *fakeMigrationPlanAPI) CancelMigrationPlan(id string) error {
+	a.cancelled = id
+	return a.err
+}
+
+func (a *fakeMigrationPlanAPI) Close() error {
+	return nil
+}
+
+func (s *MigrationPlanSuite) TestCancelMigrationPlanInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeCancelMigrationPlanCommand())
+	c.Check(err, gc.ErrorMatches, "migration plan not specified")
+	_, err = cmdtesting.RunCommand(c, s.makeCancelMigrationPlanCommand(), "1", "2")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["2"\]`)
+}
+
+func (s *MigrationPlanSuite) TestCancelMigrationPlan(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeCancelMigrationPlanCommand(), "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.cancelled, gc.Equals, "1")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration plan \"1\" cancelled\n")
+}
+
+func (s *MigrationPlanSuite) TestCancelMigrationPlanError(c *gc.C) {
+	s.api.err = errors.NotFoundf("migration plan %q", "1")
+	_, err := cmdtesting.RunCommand(c, s.makeCancelMigrationPlanCommand(), "1")
+	c.Check(err, gc.ErrorMatches, `migration plan "1" not found`)
+}
@@ -0,0 +1,273 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/v3"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/rpc/params"
+)
+
+const auditLogDoc = `
+audit-log shows the API conversations and requests recorded in the
+controller's audit log, oldest first.
+
+Entries can only be queried once they have been written to the
+controller database, which happens when "database" is one of the sinks
+in the "audit-log-sinks" controller config key. Entries written by
+every controller in an HA cluster are shown, and are kept for the
+period given by "audit-log-database-retention".
+
+The --from and --to options take either a time in RFC3339 format, or a
+duration such as 2h30m, meaning that long ago.
+
+Only controller superusers may read the audit log.
+`
+
+const auditLogExamples = `
+    juju audit-log
+    juju audit-log --user bob --from 24h
+    juju audit-log --model default --facade Application --method Deploy
+    juju audit-log --from 2023-05-01T00:00:00Z --to 2023-05-02T00:00:00Z --format yaml
+`
+
+// defaultAuditLogLimit is the number of entries shown when --limit isn't
+// specified.
+const defaultAuditLogLimit = 100
+
+// NewAuditLogCommand returns a command that queries the audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{
+		clock: clock.WallClock,
+	})
+}
+
+type auditLogAPI interface {
+	Close() error
+	AuditLog(params.AuditLogQuery) ([]params.AuditLogEntry, error)
+}
+
+// auditLogCommand shows the entries recorded in the audit log.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out   cmd.Output
+	api   auditLogAPI
+	clock clock.Clock
+
+	user   string
+	model  string
+	facade string
+	method string
+	from   string
+	to     string
+	limit  int
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "audit-log",
+		Purpose:  "Show the API requests recorded in the audit log.",
+		Doc:      auditLogDoc,
+		Examples: auditLogExamples,
+		SeeAlso: []string{
+			"controller-config",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Only show requests made by this user")
+	f.StringVar(&c.model, "model", "", "Only show requests made to this model")
+	f.StringVar(&c.facade, "facade", "", "Only show requests made to this facade")
+	f.StringVar(&c.method, "method", "", "Only show requests made to this method")
+	f.StringVar(&c.from, "from", "", "Only show entries recorded at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show entries recorded at or before this time")
+	f.IntVar(&c.limit, "limit", defaultAuditLogLimit, "The maximum number of entries shown; the most recent are kept")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.limit <= 0 {
+		return errors.NotValidf("limit %d", c.limit)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+type auditLogEntry struct {
+	Controller     string          `json:"controller" yaml:"controller"`
+	ConversationID string          `json:"conversation-id" yaml:"conversation-id"`
+	ConnectionID   string          `json:"connection-id" yaml:"connection-id"`
+	When           time.Time       `json:"when" yaml:"when"`
+	User           string          `json:"user" yaml:"user"`
+	Command        string          `json:"command,omitempty" yaml:"command,omitempty"`
+	ModelName      string          `json:"model-name" yaml:"model-name"`
+	ModelUUID      string          `json:"model-uuid" yaml:"model-uuid"`
+	RequestID      uint64          `json:"request-id,omitempty" yaml:"request-id,omitempty"`
+	Facade         string          `json:"facade,omitempty" yaml:"facade,omitempty"`
+	Method         string          `json:"method,omitempty" yaml:"method,omitempty"`
+	Version        int             `json:"version,omitempty" yaml:"version,omitempty"`
+	Args           string          `json:"args,omitempty" yaml:"args,omitempty"`
+	Errors         []auditLogError `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+type auditLogError struct {
+	Message string `json:"message" yaml:"message"`
+	Code    string `json:"code,omitempty" yaml:"code,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	query, err := c.query()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.AuditLog(query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No audit log entries found.\n" +
+			`Entries are only recorded when "database" is one of the controller's audit-log-sinks.`)
+		return nil
+	}
+
+	details := make([]auditLogEntry, len(entries))
+	for i, e := range entries {
+		details[i] = auditLogEntry{
+			Controller:     e.Controller,
+			ConversationID: e.ConversationID,
+			ConnectionID:   e.ConnectionID,
+			When:           e.When,
+			User:           e.Who,
+			Command:        e.What,
+			ModelName:      e.ModelName,
+			ModelUUID:      e.ModelUUID,
+			RequestID:      e.RequestID,
+			Facade:         e.Facade,
+			Method:         e.Method,
+			Version:        e.Version,
+			Args:           e.Args,
+		}
+		for _, err := range e.Errors {
+			details[i].Errors = append(details[i].Errors, auditLogError{
+				Message: err.Message,
+				Code:    err.Code,
+			})
+		}
+	}
+	return c.out.Write(ctx, details)
+}
+
+// query returns the audit log query given by the command's options.
+func (c *auditLogCommand) query() (params.AuditLogQuery, error) {
+	query := params.AuditLogQuery{
+		User:   c.user,
+		Facade: c.facade,
+		Method: c.method,
+		Limit:  c.limit,
+	}
+	if c.model != "" {
+		query.ModelUUID = c.model
+		if !utils.IsValidUUIDString(c.model) {
+			uuids, err := c.ModelUUIDs([]string{c.model})
+			if err != nil {
+				return query, errors.Trace(err)
+			}
+			query.ModelUUID = uuids[0]
+		}
+	}
+	var err error
+	if query.From, err = c.parseTime("from", c.from); err != nil {
+		return query, errors.Trace(err)
+	}
+	if query.To, err = c.parseTime("to", c.to); err != nil {
+		return query, errors.Trace(err)
+	}
+	if query.From != nil && query.To != nil && query.To.Before(*query.From) {
+		return query, errors.New("--to time is before --from time")
+	}
+	return query, nil
+}
+
+// parseTime parses the value of the named time option, which is either
+// an RFC3339 time or a duration before now.
+func (c *auditLogCommand) parseTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		t = t.UTC()
+		return &t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return nil, errors.Errorf("--%s value %q should be a time in RFC3339 format or a duration", name, value)
+	}
+	t := c.clock.Now().UTC().Add(-d)
+	return &t, nil
+}
+
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Time", "Controller", "User", "Model", "Call", "Errors")
+	for _, e := range entries {
+		// Conversations show the command that started them, and
+		// requests the API call made.
+		call := e.Command
+		if e.Facade != "" {
+			call = fmt.Sprintf("%s.%s v%d", e.Facade, e.Method, e.Version)
+		}
+		errs := "-"
+		if len(e.Errors) > 0 {
+			messages := make([]string, len(e.Errors))
+			for i, err := range e.Errors {
+				messages[i] = err.Message
+			}
+			errs = strings.Join(messages, "; ")
+		}
+		w.Println(e.When.Format(time.RFC3339), e.Controller, e.User, e.ModelName, call, errs)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc/params"
+)
+
+type auditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	store *jujuclient.MemStore
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeAuditLogAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+	s.store.Models["fake"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/default": {ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d", ModelType: model.IAAS},
+		},
+	}
+	s.store.Accounts["fake"] = jujuclient.AccountDetails{User: "admin"}
+	s.clock = testclock.NewClock(time.Date(2023, 5, 2, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *auditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store, s.clock)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "admin/default",
+		"--facade", "Application",
+		"--method", "Deploy",
+		"--from", "2h",
+		"--to", "2023-05-02T11:30:00+01:00",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	from := time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 5, 2, 10, 30, 0, 0, time.UTC)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"AuditLog", []interface{}{params.AuditLogQuery{
+			User:      "bob",
+			ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Facade:    "Application",
+			Method:    "Deploy",
+			From:      &from,
+			To:        &to,
+			Limit:     5,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *auditLogSuite) TestDefaultQuery(c *gc.C) {
+	_, err := s.run(c, "--model", "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "AuditLog", params.AuditLogQuery{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Limit:     100,
+	})
+}
+
+func (s *auditLogSuite) TestTabular(c *gc.C) {
+	s.api.entries = []params.AuditLogEntry{{
+		Controller: "0",
+		When:       time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC),
+		Who:        "bob",
+		What:       "juju deploy mysql",
+		ModelName:  "default",
+	}, {
+		Controller: "0",
+		When:       time.Date(2023, 5, 2, 10, 0, 1, 0, time.UTC),
+		Who:        "bob",
+		What:       "juju deploy mysql",
+		ModelName:  "default",
+		RequestID:  1,
+		Facade:     "Application",
+		Method:     "Deploy",
+		Version:    19,
+		Errors:     []params.AuditLogError{{Message: "no way"}},
+	}}
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  Controller  User  Model    Call                    Errors
+2023-05-02T10:00:00Z  0           bob   default  juju deploy mysql       -
+2023-05-02T10:00:01Z  0           bob   default  Application.Deploy v19  no way
+`[1:])
+}
+
+func (s *auditLogSuite) TestYAML(c *gc.C) {
+	s.api.entries = []params.AuditLogEntry{{
+		Controller:     "1",
+		ConversationID: "abc",
+		ConnectionID:   "1F",
+		When:           time.Date(2023, 5, 2, 10, 0, 1, 0, time.UTC),
+		Who:            "bob",
+		ModelName:      "default",
+		ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		RequestID:      1,
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        19,
+		Errors:         []params.AuditLogError{{Message: "no way", Code: "unauthorized access"}},
+	}}
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- controller: "1"
+  conversation-id: abc
+  connection-id: 1F
+  when: 2023-05-02T10:00:01Z
+  user: bob
+  model-name: default
+  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  request-id: 1
+  facade: Application
+  method: Deploy
+  version: 19
+  errors:
+  - message: no way
+    code: unauthorized access
+`[1:])
+}
+
+func (s *auditLogSuite) TestNoEntries(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, `No audit log entries found.\n.*"database".*\n`)
+}
+
+func (s *auditLogSuite) TestInvalidArgs(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--limit", "0"},
+		err:  "limit 0 not valid",
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `--from value "yesterday" should be a time in RFC3339 format or a duration`,
+	}, {
+		args: []string{"--from", "1h", "--to", "2h"},
+		err:  "--to time is before --from time",
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("args: %v", test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+type fakeAuditLogAPI struct {
+	jujutesting.Stub
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.AddCall("Close")
+	return f.NextErr()
+}
+
+func (f *fakeAuditLogAPI) AuditLog(query params.AuditLogQuery) ([]params.AuditLogEntry, error) {
+	f.AddCall("AuditLog", query)
+	return f.entries, f.NextErr()
+}
@@ -192,3 +192,11 @@ func NewData(api destroyControllerAPI, ctrUUID string) (environmentStatus, error
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewAuditLogCommandForTest returns an audit-log command with the API
+// and clock provided as specified.
+func NewAuditLogCommandForTest(api auditLogAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &auditLogCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -277,17 +277,17 @@ func (c *registerCommand) publicControllerDetails(ctx *cmd.Context, host, contro
 
 	// we set up a login provider that will first try to log in using
 	// oauth device flow, failing that it will try to log in using
-	// user-pass or macaroons.
+	// user-pass or macaroons. The account isn't stored until the user
+	// is known, so the session token is kept until then.
+	var sessionToken string
 	dialOpts.LoginProvider = loginprovider.NewTryInOrderLoginProvider(
 		loggo.GetLogger("juju.cmd.loginprovider"),
 		api.NewSessionTokenLoginProvider(
 			"",
 			ctx.Stderr,
-			func(sessionToken string) error {
-				return c.store.UpdateAccount(controllerName, jujuclient.AccountDetails{
-					Type:         jujuclient.OAuth2DeviceFlowAccountDetailsType,
-					SessionToken: sessionToken,
-				})
+			func(token string) error {
+				sessionToken = token
+				return nil
 			},
 		),
 		api.NewLegacyLoginProvider(names.UserTag{}, "", "", nil, bclient, cookieURL),
@@ -311,13 +311,18 @@ func (c *registerCommand) publicControllerDetails(ctx *cmd.Context, host, contro
 			logger.Errorf("failed to clear macaroon: %v", err)
 		}
 	}
+	accountDetails := jujuclient.AccountDetails{
+		User:            user.Id(),
+		LastKnownAccess: conn.ControllerAccess(),
+	}
+	if sessionToken != "" {
+		accountDetails.Type = jujuclient.OAuth2DeviceFlowAccountDetailsType
+		accountDetails.SessionToken = sessionToken
+	}
 	return jujuclient.ControllerDetails{
-			APIEndpoints:   []string{apiAddr},
-			ControllerUUID: conn.ControllerTag().Id(),
-		}, jujuclient.AccountDetails{
-			User:            user.Id(),
-			LastKnownAccess: conn.ControllerAccess(),
-		}, nil
+		APIEndpoints:   []string{apiAddr},
+		ControllerUUID: conn.ControllerTag().Id(),
+	}, accountDetails, nil
 }
 
 func getProxier(proxyConfig params.Proxy) (*jujuclient.ProxyConfWrapper, error) {
@@ -15,8 +15,11 @@ import (
 )
 
 type firewallRule struct {
-	KnownService   firewall.WellKnownServiceType `yaml:"known-service" json:"known-service"`
-	WhitelistCIDRS []string                      `yaml:"allowlist-subnets,omitempty" json:"allowlist-subnets,omitempty"`
+	KnownService firewall.WellKnownServiceType `yaml:"known-service" json:"known-service"`
+	// Endpoint is the <application>:<endpoint> an exposed application
+	// rule applies to, or empty if it applies to all of them.
+	Endpoint       string   `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
+	WhitelistCIDRS []string `yaml:"allowlist-subnets,omitempty" json:"allowlist-subnets,omitempty"`
 }
 
 type firewallRules []firewallRule
@@ -24,7 +27,10 @@ type firewallRules []firewallRule
 func (o firewallRules) Len() int      { return len(o) }
 func (o firewallRules) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
 func (o firewallRules) Less(i, j int) bool {
-	return o[i].KnownService < o[j].KnownService
+	if o[i].KnownService != o[j].KnownService {
+		return o[i].KnownService < o[j].KnownService
+	}
+	return o[i].Endpoint < o[j].Endpoint
 }
 
 func formatListTabular(writer io.Writer, value interface{}) error {
@@ -45,7 +51,12 @@ func formatFirewallRulesTabular(writer io.Writer, rules firewallRules) {
 
 	w.Println("Service", "Allowlist subnets")
 	for _, rule := range rules {
-		w.Println(rule.KnownService, strings.Join(rule.WhitelistCIDRS, ","))
+		// Exposed application endpoint rules are shown by endpoint.
+		service := string(rule.KnownService)
+		if rule.Endpoint != "" {
+			service = rule.Endpoint
+		}
+		w.Println(service, strings.Join(rule.WhitelistCIDRS, ","))
 	}
 	tw.Flush()
 }
@@ -5,6 +5,7 @@ package firewall
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/juju/cmd/v3"
 	"github.com/juju/errors"
@@ -22,7 +23,8 @@ Prints the firewall rules.`[1:]
 
 var listRulesHelpDetails = `
 Lists the firewall rules which control ingress to well known services
-within a Juju model.
+within a Juju model, and to the ports opened by exposed applications and
+their endpoints.
 
 DEPRECATION WARNING: %v
 
@@ -113,6 +115,22 @@ func (c *listFirewallRulesCommand) Run(ctx *cmd.Context) error {
 	}, {
 		KnownService:   firewall.JujuApplicationOfferRule,
 		WhitelistCIDRS: cfg.SAASIngressAllow(),
+	}, {
+		KnownService:   firewall.ExposedApplicationRule,
+		WhitelistCIDRS: cfg.ExposedIngressAllow(),
 	}}
+	endpointRules := cfg.ExposedEndpointIngressAllow()
+	endpoints := make([]string, 0, len(endpointRules))
+	for endpoint := range endpointRules {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		rules = append(rules, firewallRule{
+			KnownService:   firewall.ExposedApplicationRule,
+			Endpoint:       endpoint,
+			WhitelistCIDRS: endpointRules[endpoint],
+		})
+	}
 	return c.out.Write(ctx, rules)
 }
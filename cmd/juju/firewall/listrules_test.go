@@ -43,6 +43,7 @@ func (s *ListSuite) TestListTabular(c *gc.C) {
 		[]string{"--format", "tabular"},
 		`
 Service                 Allowlist subnets
+exposed                 0.0.0.0/0,::/0
 juju-application-offer  0.0.0.0/0
 ssh                     192.168.1.0/16,10.0.0.0/8
 `[1:],
@@ -62,6 +63,10 @@ func (s *ListSuite) TestListYAML(c *gc.C) {
 - known-service: juju-application-offer
   allowlist-subnets:
   - 0.0.0.0/0
+- known-service: exposed
+  allowlist-subnets:
+  - 0.0.0.0/0
+  - ::/0
 `[1:],
 		"",
 	)
@@ -74,6 +79,7 @@ func (s *ListSuite) TestListEmpty(c *gc.C) {
 		[]string{"--format", "tabular"},
 		`
 Service                 Allowlist subnets
+exposed                 0.0.0.0/0,::/0
 juju-application-offer  0.0.0.0/0
 ssh                     
 `[1:],
@@ -82,6 +88,50 @@ ssh
 
 }
 
+func (s *ListSuite) TestListExposedRules(c *gc.C) {
+	s.mockAPI.exposedRules = "10.0.0.0/8"
+	s.mockAPI.endpointRules = "wordpress:website=192.168.0.0/16 mysql:db=10.1.0.0/16,10.2.0.0/16"
+	s.assertValidList(
+		c,
+		[]string{"--format", "tabular"},
+		`
+Service                 Allowlist subnets
+exposed                 10.0.0.0/8
+mysql:db                10.1.0.0/16,10.2.0.0/16
+wordpress:website       192.168.0.0/16
+juju-application-offer  0.0.0.0/0
+ssh                     192.168.1.0/16,10.0.0.0/8
+`[1:],
+		"",
+	)
+	s.assertValidList(
+		c,
+		[]string{"--format", "yaml"},
+		`
+- known-service: ssh
+  allowlist-subnets:
+  - 192.168.1.0/16
+  - 10.0.0.0/8
+- known-service: juju-application-offer
+  allowlist-subnets:
+  - 0.0.0.0/0
+- known-service: exposed
+  allowlist-subnets:
+  - 10.0.0.0/8
+- known-service: exposed
+  endpoint: mysql:db
+  allowlist-subnets:
+  - 10.1.0.0/16
+  - 10.2.0.0/16
+- known-service: exposed
+  endpoint: wordpress:website
+  allowlist-subnets:
+  - 192.168.0.0/16
+`[1:],
+		"",
+	)
+}
+
 func (s *ListSuite) runList(c *gc.C, args []string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, firewall.NewListRulesCommandForTest(s.mockAPI), args...)
 }
@@ -98,8 +148,10 @@ func (s *ListSuite) assertValidList(c *gc.C, args []string, expectedValid, expec
 }
 
 type mockListAPI struct {
-	rules string
-	err   error
+	rules         string
+	exposedRules  string
+	endpointRules string
+	err           error
 }
 
 func (s *mockListAPI) Close() error {
@@ -110,8 +162,13 @@ func (s *mockListAPI) ModelGet() (map[string]interface{}, error) {
 	if s.err != nil {
 		return nil, s.err
 	}
-	return testing.FakeConfig().Merge(testing.Attrs{
-		config.SSHAllowKey:         s.rules,
-		config.SAASIngressAllowKey: "0.0.0.0/0",
-	}), nil
+	attrs := testing.FakeConfig().Merge(testing.Attrs{
+		config.SSHAllowKey:                    s.rules,
+		config.SAASIngressAllowKey:            "0.0.0.0/0",
+		config.ExposedEndpointIngressAllowKey: s.endpointRules,
+	})
+	if s.exposedRules != "" {
+		attrs[config.ExposedIngressAllowKey] = s.exposedRules
+	}
+	return attrs, nil
 }
@@ -30,12 +30,35 @@ and a allowlist of allowed ingress subnets.
 The currently supported services are:
 - ssh
 - juju-application-offer
+- exposed
+
+The "exposed" rule restricts ingress to the ports opened by every
+exposed application in the model. Ingress is only allowed from the
+addresses that are both in the allowlist and in the CIDRs or spaces
+that the application is exposed to.
+
+A rule for an application endpoint, given as <application>:<endpoint>,
+replaces the "exposed" rule for the ports opened for that endpoint.
+Ports the application opens for all endpoints remain subject to the
+"exposed" rule. Use --remove to delete an application endpoint rule.
+
+In Kubernetes models, an exposed application is reached through a
+single ingress resource serving all of its endpoints. Its ingress, and
+its load balancer if it has one, are restricted by the "exposed" rule
+or, when any of its endpoints have a rule, by the addresses allowed by
+all of those rules.
+
+The rules are stored in the "exposed-ingress-allow" and
+"exposed-endpoint-ingress-allow" model config settings.
 
 DEPRECATION WARNING: %v
 `
 
 const setRuleHelpExamples = `
     juju set-firewall-rule ssh --allowlist 192.168.1.0/16
+    juju set-firewall-rule exposed --allowlist 10.0.0.0/8,192.168.0.0/16
+    juju set-firewall-rule mysql:db --allowlist 10.1.0.0/16
+    juju set-firewall-rule mysql:db --remove
 `
 
 // NewSetFirewallRuleCommand returns a command to set firewall rules.
@@ -56,8 +79,10 @@ type setFirewallRuleCommand struct {
 	modelcmd.ModelCommandBase
 	modelcmd.IAASOnlyCommand
 	service   firewall.WellKnownServiceType
+	endpoint  string
 	allowlist string
 	whitelist string
+	remove    bool
 
 	newAPIFunc func() (SetFirewallRuleAPI, error)
 }
@@ -66,7 +91,7 @@ type setFirewallRuleCommand struct {
 func (c *setFirewallRuleCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:     "set-firewall-rule",
-		Args:     "<service-name>, --allowlist <cidr>[,<cidr>...]",
+		Args:     "<service-name>|<application>:<endpoint>, --allowlist <cidr>[,<cidr>...]",
 		Purpose:  setRuleHelpSummary,
 		Doc:      fmt.Sprintf(setRuleHelpDetails, deprecationWarning),
 		Examples: setRuleHelpExamples,
@@ -80,27 +105,44 @@ func (c *setFirewallRuleCommand) Info() *cmd.Info {
 func (c *setFirewallRuleCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.allowlist, "allowlist", "", "list of subnets to allowlist")
 	f.StringVar(&c.whitelist, "whitelist", "", "")
+	f.BoolVar(&c.remove, "remove", false, "remove the rule for an application endpoint")
 }
 
 // Init implements cmd.Command.
 func (c *setFirewallRuleCommand) Init(args []string) (err error) {
-	if len(args) == 1 {
-		c.service = firewall.WellKnownServiceType(args[0])
-		if c.allowlist == "" && c.whitelist == "" {
-			return errors.New("no allowlist subnets specified")
-		}
-		if c.allowlist != "" && c.whitelist != "" {
-			return errors.New("cannot specify both whitelist and allowlist")
+	if len(args) == 0 {
+		return errors.New("no well known service specified")
+	}
+	if strings.Contains(args[0], ":") {
+		if err := firewall.ValidateEndpointKey(args[0]); err != nil {
+			return errors.Trace(err)
 		}
-		if args[0] != "ssh" && args[0] != "juju-application-offer" {
-			return errors.NotSupportedf("service %q", args[0])
+		c.endpoint = args[0]
+	} else {
+		c.service = firewall.WellKnownServiceType(args[0])
+	}
+	if c.remove {
+		if c.endpoint == "" {
+			return errors.New("--remove can only be used with an application endpoint")
 		}
-		if err := c.validateCIDRS(c.allowlist + c.whitelist); err != nil {
-			return errors.Trace(err)
+		if c.allowlist != "" || c.whitelist != "" {
+			return errors.New("cannot specify both --remove and allowlist subnets")
 		}
+		return cmd.CheckEmpty(args[1:])
 	}
-	if len(args) == 0 {
-		return errors.New("no well known service specified")
+	if c.allowlist == "" && c.whitelist == "" {
+		return errors.New("no allowlist subnets specified")
+	}
+	if c.allowlist != "" && c.whitelist != "" {
+		return errors.New("cannot specify both whitelist and allowlist")
+	}
+	switch c.service {
+	case "", firewall.SSHRule, firewall.JujuApplicationOfferRule, firewall.ExposedApplicationRule:
+	default:
+		return errors.NotSupportedf("service %q", args[0])
+	}
+	if err := c.validateCIDRS(c.allowlist + c.whitelist); err != nil {
+		return errors.Trace(err)
 	}
 	return cmd.CheckEmpty(args[1:])
 }
@@ -119,13 +161,15 @@ func (c *setFirewallRuleCommand) validateCIDRS(value string) error {
 // SetFirewallRuleAPI defines the API methods that the set firewall rules command uses.
 type SetFirewallRuleAPI interface {
 	Close() error
+	ModelGet() (map[string]interface{}, error)
 	ModelSet(config map[string]interface{}) error
 }
 
 var deprecationWarning = `
-Firewall rules have been moved to model-config settings "ssh-allow" and
-"saas-ingress-allow". This command is deprecated in favour of
-reading/writing directly to these settings.
+The ssh and juju-application-offer firewall rules have been moved to
+model-config settings "ssh-allow" and "saas-ingress-allow". Setting
+them with this command is deprecated in favour of reading/writing
+directly to these settings.
 `[1:]
 
 func (c *setFirewallRuleCommand) Run(ctx *cmd.Context) error {
@@ -133,7 +177,9 @@ func (c *setFirewallRuleCommand) Run(ctx *cmd.Context) error {
 		c.allowlist = c.whitelist
 		ctx.Warningf("--whitelist is deprecated in favour of --allowlist")
 	}
-	ctx.Warningf(deprecationWarning)
+	if c.service == firewall.SSHRule || c.service == firewall.JujuApplicationOfferRule {
+		ctx.Warningf(deprecationWarning)
+	}
 
 	client, err := c.newAPIFunc()
 	if err != nil {
@@ -141,13 +187,48 @@ func (c *setFirewallRuleCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.endpoint != "" {
+		return errors.Trace(c.setEndpointRule(client))
+	}
 	switch c.service {
 	case firewall.SSHRule:
 		err = client.ModelSet(map[string]interface{}{config.SSHAllowKey: c.allowlist})
 	case firewall.JujuApplicationOfferRule:
 		err = client.ModelSet(map[string]interface{}{config.SAASIngressAllowKey: c.allowlist})
+	case firewall.ExposedApplicationRule:
+		err = client.ModelSet(map[string]interface{}{config.ExposedIngressAllowKey: c.allowlist})
 	default:
 		return errors.NotSupportedf("service %v", c.service)
 	}
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
+
+// setEndpointRule sets, or removes, the endpoint's entry in the model's
+// exposed endpoint ingress allowlist.
+func (c *setFirewallRuleCommand) setEndpointRule(client SetFirewallRuleAPI) error {
+	attrs, err := client.ModelGet()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, _ := attrs[config.ExposedEndpointIngressAllowKey].(string)
+	allowlist, err := firewall.ParseEndpointIngressAllowlist(current)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.remove {
+		if _, ok := allowlist[c.endpoint]; !ok {
+			return errors.NotFoundf("firewall rule for endpoint %q", c.endpoint)
+		}
+		delete(allowlist, c.endpoint)
+	} else {
+		cidrs := strings.Split(c.allowlist, ",")
+		for i, cidr := range cidrs {
+			cidrs[i] = strings.TrimSpace(cidr)
+		}
+		allowlist[c.endpoint] = cidrs
+	}
+	err = client.ModelSet(map[string]interface{}{
+		config.ExposedEndpointIngressAllowKey: allowlist.String(),
+	})
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
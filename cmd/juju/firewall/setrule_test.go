@@ -49,6 +49,58 @@ func (s *SetRuleSuite) TestSetRuleSAAS(c *gc.C) {
 	c.Assert(s.mockAPI.saasRule, gc.Equals, "10.2.1.0/8,192.168.1.0/8")
 }
 
+func (s *SetRuleSuite) TestSetRuleExposed(c *gc.C) {
+	_, err := s.runSetRule(c, "--allowlist", "10.0.0.0/8,192.168.0.0/16", "exposed")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.exposedRule, gc.Equals, "10.0.0.0/8,192.168.0.0/16")
+}
+
+func (s *SetRuleSuite) TestSetRuleEndpoint(c *gc.C) {
+	s.mockAPI.endpointRule = "wordpress:website=192.168.0.0/16"
+	_, err := s.runSetRule(c, "--allowlist", "10.1.0.0/16, 10.2.0.0/16", "mysql:db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.endpointRule, gc.Equals, "mysql:db=10.1.0.0/16,10.2.0.0/16 wordpress:website=192.168.0.0/16")
+
+	_, err = s.runSetRule(c, "--allowlist", "10.3.0.0/16", "mysql:db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.endpointRule, gc.Equals, "mysql:db=10.3.0.0/16 wordpress:website=192.168.0.0/16")
+}
+
+func (s *SetRuleSuite) TestRemoveRuleEndpoint(c *gc.C) {
+	s.mockAPI.endpointRule = "mysql:db=10.1.0.0/16 wordpress:website=192.168.0.0/16"
+	_, err := s.runSetRule(c, "--remove", "mysql:db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.endpointRule, gc.Equals, "wordpress:website=192.168.0.0/16")
+
+	_, err = s.runSetRule(c, "--remove", "mysql:db")
+	c.Assert(err, gc.ErrorMatches, `firewall rule for endpoint "mysql:db" not found`)
+}
+
+func (s *SetRuleSuite) TestInitInvalid(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"http", "--allowlist", "10.0.0.0/8"},
+		err:  `service "http" not supported`,
+	}, {
+		args: []string{"mysql:", "--allowlist", "10.0.0.0/8"},
+		err:  `application endpoint "mysql:", expected <application>:<endpoint> not valid`,
+	}, {
+		args: []string{"exposed", "--remove"},
+		err:  "--remove can only be used with an application endpoint",
+	}, {
+		args: []string{"mysql:db", "--remove", "--allowlist", "10.0.0.0/8"},
+		err:  "cannot specify both --remove and allowlist subnets",
+	}, {
+		args: []string{"mysql:db", "--allowlist", "10.0.0.0"},
+		err:  "10.0.0.0 not valid",
+	}} {
+		_, err := s.runSetRule(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *SetRuleSuite) TestWhitelistAndAllowlist(c *gc.C) {
 	_, err := s.runSetRule(c, "ssh", "--allowlist", "192.168.0.0/24", "--whitelist", "192.168.1.0/24")
 	c.Assert(err, gc.ErrorMatches, "cannot specify both whitelist and allowlist")
@@ -65,15 +117,23 @@ func (s *SetRuleSuite) runSetRule(c *gc.C, args ...string) (*cmd.Context, error)
 }
 
 type mockSetRuleAPI struct {
-	sshRule  string
-	saasRule string
-	err      error
+	sshRule      string
+	saasRule     string
+	exposedRule  string
+	endpointRule string
+	err          error
 }
 
 func (s *mockSetRuleAPI) Close() error {
 	return nil
 }
 
+func (s *mockSetRuleAPI) ModelGet() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		config.ExposedEndpointIngressAllowKey: s.endpointRule,
+	}, nil
+}
+
 func (s *mockSetRuleAPI) ModelSet(cfg map[string]interface{}) error {
 	if s.err != nil {
 		return s.err
@@ -86,6 +146,14 @@ func (s *mockSetRuleAPI) ModelSet(cfg map[string]interface{}) error {
 	if ok {
 		s.saasRule = saasRule
 	}
+	exposedRule, ok := cfg[config.ExposedIngressAllowKey].(string)
+	if ok {
+		s.exposedRule = exposedRule
+	}
+	endpointRule, ok := cfg[config.ExposedEndpointIngressAllowKey].(string)
+	if ok {
+		s.endpointRule = endpointRule
+	}
 
 	return nil
 }
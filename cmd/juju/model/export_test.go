@@ -126,6 +126,35 @@ func NewRevokeCommandForTest(modelsApi RevokeModelAPI, offersAPI RevokeOfferAPI,
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
 
+// NewGrantGroupCommandForTest returns a grant command for user groups with
+// the apis provided as specified.
+func NewGrantGroupCommandForTest(groupAPI GroupAccessAPI, offerDetailsAPI OfferDetailsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &grantCommand{}
+	cmd.groupAPI = groupAPI
+	cmd.offerDetailsAPI = offerDetailsAPI
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewRevokeGroupCommandForTest returns a revoke command for user groups
+// with the apis provided as specified.
+func NewRevokeGroupCommandForTest(groupAPI GroupAccessAPI, offerDetailsAPI OfferDetailsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &revokeCommand{}
+	cmd.groupAPI = groupAPI
+	cmd.offerDetailsAPI = offerDetailsAPI
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewGrantCloudGroupCommandForTest returns a grant-cloud command for user
+// groups with the api provided as specified.
+func NewGrantCloudGroupCommandForTest(groupAPI GroupAccessAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &grantCloudCommand{}
+	cmd.groupAPI = groupAPI
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
 type GrantCloudCommand struct {
 	*grantCloudCommand
 }
@@ -242,3 +271,11 @@ func NewShowCommitCommandForTest(api ShowCommitCommandAPI, store jujuclient.Clie
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewRolloutCommandForTest(api RolloutCommandAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &rolloutCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd/v3"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
 
 	"github.com/juju/juju/api/client/applicationoffers"
@@ -48,6 +49,10 @@ By default, the controller is the current controller.
 Users with read access are limited in what they can do with models:
 ` + "`juju models`, `juju machines`, and `juju status`" + `.
 
+With the --group option, access is granted to a user group instead of a
+user. Each member of the group has the greatest of the access granted to
+them directly and the access granted to any of their groups.
+
 `[1:] + validAccessLevels
 
 const usageGrantExamples = `
@@ -75,6 +80,10 @@ Grant user 'sam' 'read' access to application offers 'fred/prod.hosted-mysql' an
 
     juju grant sam read fred/prod.hosted-mysql mary/test.hosted-mysql
 
+Grant user group 'engineers' 'write' access to model 'mymodel':
+
+    juju grant --group engineers write mymodel
+
 `
 
 var usageRevokeSummary = `
@@ -87,6 +96,9 @@ Revoking write access, from a user who has that permission, will leave
 that user with read access. Revoking read access, however, also revokes
 write access.
 
+With the --group option, access is revoked from a user group instead of a
+user.
+
 `[1:] + validAccessLevels
 
 const usageRevokeExamples = `
@@ -105,10 +117,15 @@ Revoke 'read' (and 'write') access from user 'joe' for application offer 'fred/p
 Revoke 'consume' access from user 'sam' for models 'fred/prod.hosted-mysql' and 'mary/test.hosted-mysql':
 
     juju revoke sam consume fred/prod.hosted-mysql mary/test.hosted-mysql
+
+Revoke 'write' access from user group 'engineers' for model 'mymodel':
+
+    juju revoke --group engineers write mymodel
 `
 
 type accessCommand struct {
 	modelcmd.ControllerCommandBase
+	groupAccessBase
 
 	User       string
 	ModelNames []string
@@ -116,6 +133,12 @@ type accessCommand struct {
 	Access     string
 }
 
+// SetFlags implements cmd.Command.
+func (c *accessCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.Group, "group", false, groupFlagUsage)
+}
+
 // Init implements cmd.Command.
 func (c *accessCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -189,13 +212,14 @@ type grantCommand struct {
 func (c *grantCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:     "grant",
-		Args:     "<user name> <permission> [<model name> ... | <offer url> ...]",
+		Args:     "<user name>|--group <group name> <permission> [<model name> ... | <offer url> ...]",
 		Purpose:  usageGrantSummary,
 		Doc:      usageGrantDetails,
 		Examples: usageGrantExamples,
 		SeeAlso: []string{
 			"revoke",
 			"add-user",
+			"add-group",
 			"grant-cloud",
 		},
 	})
@@ -243,6 +267,9 @@ type GrantOfferAPI interface {
 
 // Run implements cmd.Command.
 func (c *grantCommand) Run(ctx *cmd.Context) error {
+	if c.Group {
+		return c.runForGroup(GroupAccessAPI.GrantGroupAccess)
+	}
 	if len(c.ModelNames) > 0 {
 		return c.runForModel()
 	}
@@ -310,7 +337,7 @@ type revokeCommand struct {
 func (c *revokeCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:     "revoke",
-		Args:     "<user name> <permission> [<model name> ... | <offer url> ...]",
+		Args:     "<user name>|--group <group name> <permission> [<model name> ... | <offer url> ...]",
 		Purpose:  usageRevokeSummary,
 		Doc:      usageRevokeDetails,
 		Examples: usageRevokeExamples,
@@ -362,6 +389,9 @@ type RevokeOfferAPI interface {
 
 // Run implements cmd.Command.
 func (c *revokeCommand) Run(ctx *cmd.Context) error {
+	if c.Group {
+		return c.runForGroup(GroupAccessAPI.RevokeGroupAccess)
+	}
 	if len(c.ModelNames) > 0 {
 		return c.runForModel()
 	}
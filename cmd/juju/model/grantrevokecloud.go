@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd/v3"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
 
 	"github.com/juju/juju/api/client/cloud"
@@ -24,12 +25,20 @@ Valid access levels are:
 var usageGrantCloudSummary = `
 Grants access level to a Juju user for a cloud.`[1:]
 
-var usageGrantCloudDetails = validCloudAccessLevels
+var usageGrantCloudDetails = `
+With the --group option, access is granted to a user group instead of a
+user.
+
+`[1:] + validCloudAccessLevels
 
 const usageGrantCloudExamples = `
 Grant user 'joe' 'add-model' access to cloud 'fluffy':
 
     juju grant-cloud joe add-model fluffy
+
+Grant user group 'engineers' 'add-model' access to cloud 'fluffy':
+
+    juju grant-cloud --group engineers add-model fluffy
 `
 
 var usageRevokeCloudSummary = `
@@ -40,6 +49,9 @@ Revoking admin access, from a user who has that permission, will leave
 that user with add-model access. Revoking add-model access, however, also revokes
 admin access.
 
+With the --group option, access is revoked from a user group instead of a
+user.
+
 `[1:] + validCloudAccessLevels
 
 const usageRevokeCloudExamples = `
@@ -55,12 +67,19 @@ Revoke 'admin' access from user 'sam' for clouds 'fluffy' and 'rainy':
 
 type accessCloudCommand struct {
 	modelcmd.ControllerCommandBase
+	groupAccessBase
 
 	User   string
 	Clouds []string
 	Access string
 }
 
+// SetFlags implements cmd.Command.
+func (c *accessCloudCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.Group, "group", false, groupFlagUsage)
+}
+
 // Init implements cmd.Command.
 func (c *accessCloudCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -107,7 +126,7 @@ type grantCloudCommand struct {
 func (c *grantCloudCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:     "grant-cloud",
-		Args:     "<user name> <permission> <cloud name> ...",
+		Args:     "<user name>|--group <group name> <permission> <cloud name> ...",
 		Purpose:  usageGrantCloudSummary,
 		Doc:      usageGrantCloudDetails,
 		Examples: usageGrantCloudExamples,
@@ -138,6 +157,9 @@ type GrantCloudAPI interface {
 
 // Run implements cmd.Command.
 func (c *grantCloudCommand) Run(ctx *cmd.Context) error {
+	if c.Group {
+		return c.runForGroup(GroupAccessAPI.GrantGroupAccess)
+	}
 	client, err := c.getCloudsAPI()
 	if err != nil {
 		return err
@@ -162,7 +184,7 @@ type revokeCloudCommand struct {
 func (c *revokeCloudCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:     "revoke-cloud",
-		Args:     "<user name> <permission> <cloud name> ...",
+		Args:     "<user name>|--group <group name> <permission> <cloud name> ...",
 		Purpose:  usageRevokeCloudSummary,
 		Doc:      usageRevokeCloudDetails,
 		Examples: usageRevokeCloudExamples,
@@ -191,6 +213,9 @@ type RevokeCloudAPI interface {
 
 // Run implements cmd.Command.
 func (c *revokeCloudCommand) Run(ctx *cmd.Context) error {
+	if c.Group {
+		return c.runForGroup(GroupAccessAPI.RevokeGroupAccess)
+	}
 	client, err := c.getCloudAPI()
 	if err != nil {
 		return err
@@ -0,0 +1,150 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api/client/applicationoffers"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+const groupFlagUsage = "Treat the user name argument as a user group name"
+
+// GroupAccessAPI defines the API functions used by the grant and revoke
+// commands when changing the access of a user group.
+type GroupAccessAPI interface {
+	Close() error
+	GrantGroupAccess(group, access string, target names.Tag) error
+	RevokeGroupAccess(group, access string, target names.Tag) error
+}
+
+// OfferDetailsAPI defines the API functions used to look up the
+// application offers that a user group is granted access to.
+type OfferDetailsAPI interface {
+	Close() error
+	ApplicationOffer(url string) (*crossmodel.ApplicationOfferDetails, error)
+}
+
+// modifyGroupAccessFunc is the GroupAccessAPI method used to change the
+// access of a user group on a single target.
+type modifyGroupAccessFunc func(api GroupAccessAPI, group, access string, target names.Tag) error
+
+// groupAccessBase is the common code for changing the access of user
+// groups, rather than users.
+type groupAccessBase struct {
+	Group bool
+
+	groupAPI        GroupAccessAPI
+	offerDetailsAPI OfferDetailsAPI
+}
+
+func (c *groupAccessBase) getGroupAPI(base *modelcmd.ControllerCommandBase) (GroupAccessAPI, error) {
+	if c.groupAPI != nil {
+		return c.groupAPI, nil
+	}
+	return base.NewUserManagerAPIClient()
+}
+
+func (c *groupAccessBase) getOfferDetailsAPI(base *modelcmd.ControllerCommandBase) (OfferDetailsAPI, error) {
+	if c.offerDetailsAPI != nil {
+		return c.offerDetailsAPI, nil
+	}
+	root, err := base.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationoffers.NewClient(root), nil
+}
+
+// runForGroup changes the access of the group on each of the targets.
+func (c *groupAccessBase) runForGroup(
+	base *modelcmd.ControllerCommandBase, modify modifyGroupAccessFunc, group, access string, targets []names.Tag,
+) error {
+	client, err := c.getGroupAPI(base)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	for _, target := range targets {
+		if err := modify(client, group, access, target); err != nil {
+			return block.ProcessBlockedError(
+				errors.Annotatef(err, "%s", names.ReadableString(target)), block.BlockChange)
+		}
+	}
+	return nil
+}
+
+// groupTargets returns the tags of the models, offers or controller
+// named on the command line.
+func (c *accessCommand) groupTargets() ([]names.Tag, error) {
+	if len(c.ModelNames) > 0 {
+		uuids, err := c.ModelUUIDs(c.ModelNames)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		targets := make([]names.Tag, len(uuids))
+		for i, uuid := range uuids {
+			targets[i] = names.NewModelTag(uuid)
+		}
+		return targets, nil
+	}
+
+	if len(c.OfferURLs) > 0 {
+		client, err := c.getOfferDetailsAPI(&c.ControllerCommandBase)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer client.Close()
+
+		targets := make([]names.Tag, len(c.OfferURLs))
+		for i, url := range c.OfferURLs {
+			offer, err := client.ApplicationOffer(url.String())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			targets[i] = names.NewApplicationOfferTag(offer.OfferUUID)
+		}
+		return targets, nil
+	}
+
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	details, err := c.ClientStore().ControllerByName(controllerName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []names.Tag{names.NewControllerTag(details.ControllerUUID)}, nil
+}
+
+// runForGroup changes the access of the user group on the models, offers
+// or controller named on the command line.
+func (c *accessCommand) runForGroup(modify modifyGroupAccessFunc) error {
+	if len(c.OfferURLs) > 0 {
+		if err := setUnsetUsers(c, c.OfferURLs); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	targets, err := c.groupTargets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.groupAccessBase.runForGroup(&c.ControllerCommandBase, modify, c.User, c.Access, targets)
+}
+
+// runForGroup changes the access of the user group on the clouds named on
+// the command line.
+func (c *accessCloudCommand) runForGroup(modify modifyGroupAccessFunc) error {
+	targets := make([]names.Tag, len(c.Clouds))
+	for i, cloud := range c.Clouds {
+		targets[i] = names.NewCloudTag(cloud)
+	}
+	return c.groupAccessBase.runForGroup(&c.ControllerCommandBase, modify, c.User, c.Access, targets)
+}
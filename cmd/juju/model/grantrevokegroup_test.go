@@ -0,0 +1,145 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/core/crossmodel"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type grantRevokeGroupSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	groupAPI *fakeGroupAccessAPI
+	offerAPI *fakeOfferDetailsAPI
+	store    *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&grantRevokeGroupSuite{})
+
+func (s *grantRevokeGroupSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.groupAPI = &fakeGroupAccessAPI{}
+	s.offerAPI = &fakeOfferDetailsAPI{uuids: map[string]string{
+		"bob/foo.hosted-mysql": "hosted-mysql-uuid",
+	}}
+
+	controllerName := "test-master"
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = controllerName
+	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{
+		ControllerUUID: testing.ControllerTag.Id(),
+	}
+	s.store.Accounts[controllerName] = jujuclient.AccountDetails{
+		User: "bob",
+	}
+	s.store.Models = map[string]*jujuclient.ControllerModels{
+		controllerName: {
+			Models: map[string]jujuclient.ModelDetails{
+				"bob/foo": {ModelUUID: fooModelUUID, ModelType: coremodel.IAAS},
+				"bob/bar": {ModelUUID: barModelUUID, ModelType: coremodel.IAAS},
+			},
+		},
+	}
+}
+
+func (s *grantRevokeGroupSuite) TestGrantModels(c *gc.C) {
+	command := model.NewGrantGroupCommandForTest(s.groupAPI, s.offerAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--group", "engineers", "write", "foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.groupAPI.calls, jc.DeepEquals, []groupAccessCall{
+		{"grant", "engineers", "write", names.NewModelTag(fooModelUUID)},
+		{"grant", "engineers", "write", names.NewModelTag(barModelUUID)},
+	})
+}
+
+func (s *grantRevokeGroupSuite) TestGrantOffer(c *gc.C) {
+	command := model.NewGrantGroupCommandForTest(s.groupAPI, s.offerAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--group", "engineers", "consume", "foo.hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.groupAPI.calls, jc.DeepEquals, []groupAccessCall{
+		{"grant", "engineers", "consume", names.NewApplicationOfferTag("hosted-mysql-uuid")},
+	})
+}
+
+func (s *grantRevokeGroupSuite) TestGrantController(c *gc.C) {
+	command := model.NewGrantGroupCommandForTest(s.groupAPI, s.offerAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--group", "admins", "superuser")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.groupAPI.calls, jc.DeepEquals, []groupAccessCall{
+		{"grant", "admins", "superuser", testing.ControllerTag},
+	})
+}
+
+func (s *grantRevokeGroupSuite) TestRevokeModel(c *gc.C) {
+	command := model.NewRevokeGroupCommandForTest(s.groupAPI, s.offerAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--group", "engineers", "read", "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.groupAPI.calls, jc.DeepEquals, []groupAccessCall{
+		{"revoke", "engineers", "read", names.NewModelTag(fooModelUUID)},
+	})
+}
+
+func (s *grantRevokeGroupSuite) TestRevokeModelError(c *gc.C) {
+	s.groupAPI.err = errors.New(`group does not have "admin" access`)
+	command := model.NewRevokeGroupCommandForTest(s.groupAPI, s.offerAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--group", "engineers", "admin", "foo")
+	c.Assert(err, gc.ErrorMatches, `model `+fooModelUUID+`: group does not have "admin" access`)
+}
+
+func (s *grantRevokeGroupSuite) TestGrantCloud(c *gc.C) {
+	command := model.NewGrantCloudGroupCommandForTest(s.groupAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--group", "engineers", "add-model", "fluffy", "rainy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.groupAPI.calls, jc.DeepEquals, []groupAccessCall{
+		{"grant", "engineers", "add-model", names.NewCloudTag("fluffy")},
+		{"grant", "engineers", "add-model", names.NewCloudTag("rainy")},
+	})
+}
+
+type groupAccessCall struct {
+	action string
+	group  string
+	access string
+	target names.Tag
+}
+
+type fakeGroupAccessAPI struct {
+	calls []groupAccessCall
+	err   error
+}
+
+func (f *fakeGroupAccessAPI) Close() error { return nil }
+
+func (f *fakeGroupAccessAPI) GrantGroupAccess(group, access string, target names.Tag) error {
+	f.calls = append(f.calls, groupAccessCall{"grant", group, access, target})
+	return f.err
+}
+
+func (f *fakeGroupAccessAPI) RevokeGroupAccess(group, access string, target names.Tag) error {
+	f.calls = append(f.calls, groupAccessCall{"revoke", group, access, target})
+	return f.err
+}
+
+type fakeOfferDetailsAPI struct {
+	uuids map[string]string
+}
+
+func (f *fakeOfferDetailsAPI) Close() error { return nil }
+
+func (f *fakeOfferDetailsAPI) ApplicationOffer(url string) (*crossmodel.ApplicationOfferDetails, error) {
+	uuid, ok := f.uuids[url]
+	if !ok {
+		return nil, errors.NotFoundf("offer %q", url)
+	}
+	return &crossmodel.ApplicationOfferDetails{OfferURL: url, OfferUUID: uuid}, nil
+}
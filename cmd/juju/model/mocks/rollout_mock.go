@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/cmd/juju/model (interfaces: RolloutCommandAPI)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination ./mocks/rollout_mock.go github.com/juju/juju/cmd/juju/model RolloutCommandAPI
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	model "github.com/juju/juju/core/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRolloutCommandAPI is a mock of RolloutCommandAPI interface.
+type MockRolloutCommandAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRolloutCommandAPIMockRecorder
+}
+
+// MockRolloutCommandAPIMockRecorder is the mock recorder for MockRolloutCommandAPI.
+type MockRolloutCommandAPIMockRecorder struct {
+	mock *MockRolloutCommandAPI
+}
+
+// NewMockRolloutCommandAPI creates a new mock instance.
+func NewMockRolloutCommandAPI(ctrl *gomock.Controller) *MockRolloutCommandAPI {
+	mock := &MockRolloutCommandAPI{ctrl: ctrl}
+	mock.recorder = &MockRolloutCommandAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRolloutCommandAPI) EXPECT() *MockRolloutCommandAPIMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRolloutCommandAPI) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRolloutCommandAPIMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRolloutCommandAPI)(nil).Close))
+}
+
+// SetBranchRollout mocks base method.
+func (m *MockRolloutCommandAPI) SetBranchRollout(arg0 string, arg1 model.RolloutPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBranchRollout", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBranchRollout indicates an expected call of SetBranchRollout.
+func (mr *MockRolloutCommandAPIMockRecorder) SetBranchRollout(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBranchRollout", reflect.TypeOf((*MockRolloutCommandAPI)(nil).SetBranchRollout), arg0, arg1)
+}
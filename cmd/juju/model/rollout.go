@@ -0,0 +1,175 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/client/modelgeneration"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+)
+
+const (
+	rolloutSummary = "Rolls a branch out to the model gradually."
+	rolloutDoc     = `
+Rolling out a branch has the controller move the units of the applications
+changed under the branch onto it in steps, rather than all at once. Each
+step is a percentage of every application's units which track the branch
+once the step is taken. Once the final step of 100 percent is reached, the
+branch is committed to the model.
+
+The rollout can be scheduled to begin later with --start, which takes an
+RFC 3339 time. Steps are taken no more often than --interval. With
+--wait-for-active, a step is only taken once all units tracking the branch
+report an active workload status. With --auto-abort, the branch is aborted
+and its units return to the model's configuration as soon as a unit
+tracking the branch goes into an error state.
+
+Setting a rollout on a branch replaces any rollout previously set on it.
+
+Examples:
+    juju rollout upgrade-postgresql --steps 10,50,100
+    juju rollout upgrade-postgresql --steps 25,100 --interval 1h --auto-abort
+    juju rollout upgrade-postgresql --steps 100 --start 2023-06-01T01:00:00Z
+
+See also:
+    add-branch
+    branch
+    commit
+    abort
+`
+)
+
+// NewRolloutCommand wraps rolloutCommand with sane model settings.
+func NewRolloutCommand() cmd.Command {
+	return modelcmd.Wrap(&rolloutCommand{})
+}
+
+// rolloutCommand supplies the "rollout" CLI command used to roll the
+// changes made under a branch out to the model gradually.
+type rolloutCommand struct {
+	modelcmd.ModelCommandBase
+
+	api RolloutCommandAPI
+
+	branchName string
+	steps      string
+	start      string
+	interval   time.Duration
+	waitActive bool
+	autoAbort  bool
+
+	policy model.RolloutPolicy
+}
+
+// RolloutCommandAPI defines an API interface to be used during testing.
+//
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination ./mocks/rollout_mock.go github.com/juju/juju/cmd/juju/model RolloutCommandAPI
+type RolloutCommandAPI interface {
+	Close() error
+
+	// SetBranchRollout sets the policy by which the branch with the
+	// input name is gradually rolled out to the units of its applications.
+	SetBranchRollout(branchName string, policy model.RolloutPolicy) error
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *rolloutCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "rollout",
+		Args:    "<branch name>",
+		Purpose: rolloutSummary,
+		Doc:     rolloutDoc,
+	}
+	return jujucmd.Info(info)
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *rolloutCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.steps, "steps", "", "Comma separated percentages of units tracking the branch after each step")
+	f.StringVar(&c.start, "start", "", "Time (RFC 3339) before which the rollout does not begin")
+	f.DurationVar(&c.interval, "interval", 0, "Minimum time between steps")
+	f.BoolVar(&c.waitActive, "wait-for-active", false, "Only take a step once all units tracking the branch are active")
+	f.BoolVar(&c.autoAbort, "auto-abort", false, "Abort the branch when a unit tracking it goes into error")
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *rolloutCommand) Init(args []string) error {
+	if len(args) != 1 {
+		return errors.Errorf("must specify a branch name to roll out")
+	}
+	c.branchName = args[0]
+	if c.branchName == model.GenerationMaster {
+		return errors.Errorf("cannot roll out %q", model.GenerationMaster)
+	}
+
+	if c.steps == "" {
+		return errors.Errorf("must specify rollout steps")
+	}
+	for _, value := range strings.Split(c.steps, ",") {
+		step, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return errors.NotValidf("rollout step %q", value)
+		}
+		c.policy.Steps = append(c.policy.Steps, step)
+	}
+	if c.start != "" {
+		start, err := time.Parse(time.RFC3339, c.start)
+		if err != nil {
+			return errors.NotValidf("time %q (expected RFC 3339 format)", c.start)
+		}
+		c.policy.Start = start.UTC()
+	}
+	c.policy.StepInterval = c.interval
+	c.policy.WaitForActive = c.waitActive
+	c.policy.AutoAbort = c.autoAbort
+	return errors.Trace(c.policy.Validate())
+}
+
+// getAPI returns the API. This allows passing in a test RolloutCommandAPI
+// implementation.
+func (c *rolloutCommand) getAPI() (RolloutCommandAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	client := modelgeneration.NewClient(api)
+	return client, nil
+}
+
+// Run implements the meaty part of the cmd.Command interface.
+func (c *rolloutCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := client.SetBranchRollout(c.branchName, c.policy); err != nil {
+		return err
+	}
+	ctx.Infof("Rollout of branch %q set: %s", c.branchName, formatRolloutSteps(c.policy.Steps))
+	return nil
+}
+
+func formatRolloutSteps(steps []int) string {
+	values := make([]string, len(steps))
+	for i, step := range steps {
+		values[i] = fmt.Sprintf("%d%%", step)
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/model/mocks"
+	coremodel "github.com/juju/juju/core/model"
+)
+
+type rolloutSuite struct {
+	generationBaseSuite
+}
+
+var _ = gc.Suite(&rolloutSuite{})
+
+func (s *rolloutSuite) TestInit(c *gc.C) {
+	err := s.runInit(s.branchName, "--steps", "10,50,100", "--start", "2023-06-01T01:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *rolloutSuite) TestInitFail(c *gc.C) {
+	tests := []struct {
+		args []string
+		err  string
+	}{{
+		err: "must specify a branch name to roll out",
+	}, {
+		args: []string{coremodel.GenerationMaster, "--steps", "100"},
+		err:  `cannot roll out "master"`,
+	}, {
+		args: []string{s.branchName},
+		err:  "must specify rollout steps",
+	}, {
+		args: []string{s.branchName, "--steps", "10,lots"},
+		err:  `rollout step "lots" not valid`,
+	}, {
+		args: []string{s.branchName, "--steps", "50,10,100"},
+		err:  `rollout steps \[50 10 100\] not valid`,
+	}, {
+		args: []string{s.branchName, "--steps", "10,50"},
+		err:  `rollout steps \[10 50\] ending before 100 not valid`,
+	}, {
+		args: []string{s.branchName, "--steps", "100", "--start", "tomorrow"},
+		err:  `time "tomorrow" \(expected RFC 3339 format\) not valid`,
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.args)
+		c.Check(s.runInit(test.args...), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *rolloutSuite) TestRunCommand(c *gc.C) {
+	ctrl, api := setUpRolloutMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().SetBranchRollout(s.branchName, coremodel.RolloutPolicy{
+		Steps:         []int{10, 50, 100},
+		Start:         time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC),
+		StepInterval:  time.Hour,
+		WaitForActive: true,
+		AutoAbort:     true,
+	}).Return(nil)
+
+	ctx, err := s.runCommand(c, api,
+		"--steps", "10,50,100", "--start", "2023-06-01T01:00:00Z",
+		"--interval", "1h", "--wait-for-active", "--auto-abort",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Rollout of branch \"new-branch\" set: 10%, 50%, 100%\n")
+}
+
+func (s *rolloutSuite) TestRunCommandFail(c *gc.C) {
+	ctrl, api := setUpRolloutMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().SetBranchRollout(s.branchName, gomock.Any()).Return(errors.Errorf("fail"))
+
+	_, err := s.runCommand(c, api, "--steps", "100")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *rolloutSuite) runInit(args ...string) error {
+	return cmdtesting.InitCommand(model.NewRolloutCommandForTest(nil, s.store), args)
+}
+
+func (s *rolloutSuite) runCommand(c *gc.C, api model.RolloutCommandAPI, args ...string) (*cmd.Context, error) {
+	args = append([]string{s.branchName}, args...)
+	return cmdtesting.RunCommand(c, model.NewRolloutCommandForTest(api, s.store), args...)
+}
+
+func setUpRolloutMocks(c *gc.C) (*gomock.Controller, *mocks.MockRolloutCommandAPI) {
+	ctrl := gomock.NewController(c)
+	api := mocks.NewMockRolloutCommandAPI(ctrl)
+	api.EXPECT().Close()
+	return ctrl, api
+}
@@ -47,6 +47,16 @@ func NewUploadCommandForTest(newClient func() (UploadClient, error), filesystem
 	return cmd
 }
 
+func NewUploadBranchCommandForTest(
+	newClient func() (UploadClient, error),
+	newBranchClient func() (BranchResourceClient, error),
+	filesystem modelcmd.Filesystem,
+) *UploadCommand {
+	cmd := NewUploadCommandForTest(newClient, filesystem)
+	cmd.newBranchClient = newBranchClient
+	return cmd
+}
+
 func NewListCommandForTest(newClient func() (ListClient, error)) *ListCommand {
 	cmd := &ListCommand{newClient: newClient}
 	cmd.SetClientStore(jujuclienttesting.MinimalStore())
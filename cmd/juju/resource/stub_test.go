@@ -44,6 +44,24 @@ func (s *stubAPIClient) Upload(application, name, filename, pendingID string, re
 	return nil
 }
 
+func (s *stubAPIClient) UploadPendingResource(application string, res charmresource.Resource, filename string, resource io.ReadSeeker) (string, error) {
+	s.stub.AddCall("UploadPendingResource", application, res, filename, resource)
+	if err := s.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	return "pending-id", nil
+}
+
+func (s *stubAPIClient) AttachBranchResource(branchName, application, name, pendingID string) error {
+	s.stub.AddCall("AttachBranchResource", branchName, application, name, pendingID)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (s *stubAPIClient) ListResources(applications []string) ([]resources.ApplicationResources, error) {
 	s.stub.AddCall("ListResources", applications)
 	if err := s.stub.NextErr(); err != nil {
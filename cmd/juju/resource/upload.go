@@ -9,12 +9,15 @@ import (
 	charmresource "github.com/juju/charm/v12/resource"
 	"github.com/juju/cmd/v3"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
 
+	"github.com/juju/juju/api/client/modelgeneration"
 	"github.com/juju/juju/api/client/resources"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
 	coreresources "github.com/juju/juju/core/resources"
 )
 
@@ -23,6 +26,10 @@ type UploadClient interface {
 	// Upload sends the resource to Juju.
 	Upload(application, name, filename, pendingID string, resource io.ReadSeeker) error
 
+	// UploadPendingResource sends the resource to Juju without making it
+	// available to the application, returning its pending ID.
+	UploadPendingResource(application string, res charmresource.Resource, filename string, resource io.ReadSeeker) (string, error)
+
 	// ListResources returns info about resources for applications in the model.
 	ListResources(applications []string) ([]coreresources.ApplicationResources, error)
 
@@ -30,14 +37,27 @@ type UploadClient interface {
 	Close() error
 }
 
+// BranchResourceClient has the API client methods needed by UploadCommand
+// to attach a resource under a branch.
+type BranchResourceClient interface {
+	// AttachBranchResource attaches a pending resource to an application
+	// under a branch.
+	AttachBranchResource(branchName, application, name, pendingID string) error
+
+	// Close closes the client.
+	Close() error
+}
+
 // UploadCommand implements the upload command.
 type UploadCommand struct {
 	modelcmd.ModelCommandBase
 
-	newClient func() (UploadClient, error)
+	newClient       func() (UploadClient, error)
+	newBranchClient func() (BranchResourceClient, error)
 
 	application   string
 	resourceValue resourceValue
+	branchName    string
 }
 
 // NewUploadCommand returns a new command that lists resources defined
@@ -51,6 +71,13 @@ func NewUploadCommand() modelcmd.ModelCommand {
 		}
 		return resources.NewClient(apiRoot)
 	}
+	c.newBranchClient = func() (BranchResourceClient, error) {
+		apiRoot, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelgeneration.NewClient(apiRoot), nil
+	}
 	return modelcmd.Wrap(c)
 }
 
@@ -86,6 +113,9 @@ Note: If you choose (2b): You will need to specify:
 (i) the local path to the private OCI image as well as
 (ii) the username/password required to access the private OCI image.
 
+With --branch, the resource is attached under an existing branch. Only units
+tracking the branch use the new resource until the branch is committed.
+
 `
 	attachExample = `
     juju attach-resource mysql resource-name=foo
@@ -93,6 +123,8 @@ Note: If you choose (2b): You will need to specify:
     juju attach-resource ubuntu-k8s ubuntu_image=ubuntu
 
     juju attach-resource redis-k8s redis-image=redis
+
+    juju attach-resource mysql resource-name=foo --branch canary
 `
 )
 
@@ -110,6 +142,12 @@ func (c *UploadCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.SetFlags.
+func (c *UploadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.branchName, "branch", "", "Attach the resource only for units tracking this branch, until it is committed")
+}
+
 // Init implements cmd.Command.Init. It will return an error satisfying
 // errors.BadRequest if you give it an incorrect number of arguments.
 func (c *UploadCommand) Init(args []string) error {
@@ -124,6 +162,9 @@ func (c *UploadCommand) Init(args []string) error {
 	if !names.IsValidApplication(c.application) {
 		return errors.NotValidf("application %q", c.application)
 	}
+	if c.branchName == model.GenerationMaster {
+		return errors.BadRequestf("cannot attach a resource under the %q branch, omit --branch instead", model.GenerationMaster)
+	}
 
 	if err := c.addResourceValue(args[1]); err != nil {
 		return errors.Trace(err)
@@ -162,10 +203,19 @@ func (c *UploadCommand) Run(*cmd.Context) error {
 		return errors.Trace(err)
 	}
 	resourceMeta := result[0]
+	var meta *charmresource.Meta
 	for _, r := range resourceMeta.Resources {
 		if r.Name == c.resourceValue.name {
 			c.resourceValue.resourceType = r.Type
+			meta = &r.Meta
+		}
+	}
+
+	if c.branchName != "" {
+		if meta == nil {
+			return errors.NotFoundf("resource %q of application %q", c.resourceValue.name, c.application)
 		}
+		return errors.Trace(c.attachUnderBranch(*meta, apiclient))
 	}
 
 	if err := c.upload(c.resourceValue, apiclient); err != nil {
@@ -174,6 +224,38 @@ func (c *UploadCommand) Run(*cmd.Context) error {
 	return nil
 }
 
+// attachUnderBranch uploads the resource as pending, then attaches it to
+// the application under the branch supplied with --branch.
+func (c *UploadCommand) attachUnderBranch(meta charmresource.Meta, client UploadClient) error {
+	rf := c.resourceValue
+	f, err := OpenResource(rf.value, rf.resourceType, c.Filesystem().Open)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+
+	res := charmresource.Resource{
+		Meta:   meta,
+		Origin: charmresource.OriginUpload,
+	}
+	pendingID, err := client.UploadPendingResource(rf.application, res, rf.value, f)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return errors.Annotatef(err, "failed to upload resource %q", rf.name)
+	}
+
+	branchClient, err := c.newBranchClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer branchClient.Close()
+
+	err = branchClient.AttachBranchResource(c.branchName, rf.application, rf.name, pendingID)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return errors.Annotatef(err, "failed to attach resource %q under branch %q", rf.name, c.branchName)
+	}
+	return nil
+}
+
 // upload opens the given file and calls the apiclient to upload it to the given
 // application with the given name.
 func (c *UploadCommand) upload(rf resourceValue, client UploadClient) error {
@@ -7,6 +7,7 @@ import (
 	"bytes"
 
 	charmresource "github.com/juju/charm/v12/resource"
+	"github.com/juju/cmd/v3/cmdtesting"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -155,6 +156,58 @@ func (s *UploadSuite) TestUploadFileChangeBlocked(c *gc.C) {
 	s.stub.CheckCall(c, 3, "Upload", "svc", "foo", "bar", "", file)
 }
 
+func (s *UploadSuite) TestInitMasterBranch(c *gc.C) {
+	u := resourcecmd.NewUploadCommandForTest(nil, s.stubDeps)
+	err := cmdtesting.InitCommand(u, []string{"foo", "bar=baz", "--branch", "master"})
+	c.Assert(err, gc.ErrorMatches, `cannot attach a resource under the "master" branch, omit --branch instead`)
+}
+
+func (s *UploadSuite) TestUploadFileResourceUnderBranch(c *gc.C) {
+	file := &stubFile{stub: s.stub}
+	s.stubDeps.file = file
+	meta := charmresource.Meta{
+		Name: "foo",
+		Type: charmresource.TypeFile,
+		Path: "foo.tgz",
+	}
+	s.stubDeps.client.(*stubAPIClient).resources = resources.ApplicationResources{
+		Resources: []resources.Resource{{Resource: charmresource.Resource{Meta: meta}}},
+	}
+	u := resourcecmd.NewUploadBranchCommandForTest(s.stubDeps.NewClient, s.stubDeps.NewBranchClient, s.stubDeps)
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo=bar", "--branch", "canary"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"ListResources",
+		"OpenResource",
+		"UploadPendingResource",
+		"NewBranchClient",
+		"AttachBranchResource",
+		"Close",
+		"FileClose",
+		"Close",
+	)
+	s.stub.CheckCall(c, 3, "UploadPendingResource", "svc", charmresource.Resource{
+		Meta:   meta,
+		Origin: charmresource.OriginUpload,
+	}, "bar", file)
+	s.stub.CheckCall(c, 5, "AttachBranchResource", "canary", "svc", "foo", "pending-id")
+}
+
+func (s *UploadSuite) TestUploadUnknownResourceUnderBranch(c *gc.C) {
+	u := resourcecmd.NewUploadBranchCommandForTest(s.stubDeps.NewClient, s.stubDeps.NewBranchClient, s.stubDeps)
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo=bar", "--branch", "canary"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Run(nil)
+	c.Assert(err, gc.ErrorMatches, `resource "foo" of application "svc" not found`)
+	s.stub.CheckCallNames(c, "NewClient", "ListResources", "Close")
+}
+
 type rsc struct {
 	*bytes.Buffer
 }
@@ -215,6 +268,15 @@ func (s *stubUploadDeps) NewClient() (resourcecmd.UploadClient, error) {
 	return s.client, nil
 }
 
+func (s *stubUploadDeps) NewBranchClient() (resourcecmd.BranchResourceClient, error) {
+	s.stub.AddCall("NewBranchClient")
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.client.(resourcecmd.BranchResourceClient), nil
+}
+
 func (s *stubUploadDeps) Open(path string) (modelcmd.ReadSeekCloser, error) {
 	s.stub.AddCall("OpenResource", path)
 	if err := s.stub.NextErr(); err != nil {
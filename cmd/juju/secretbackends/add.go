@@ -49,12 +49,25 @@ Any key values override file content if both are specified.
 To rotate the backend access credential/token (if specified), use
 the "token-rotate" config and supply a duration.
 
+A "vault" backend stores secret content in a KV version 1 secrets
+engine, so any server implementing the HashiCorp Vault KV version 1
+API may be used.
+
+A "file" backend stores secret content, encrypted, in the directory
+given by the "path" config. The controller reads and writes the
+directory itself, so for a highly available controller the path must
+be on storage shared by all controller machines; Juju cannot check
+this. The content is encrypted with the key held in the
+"secret-backend-encryption-key" controller config, which must be set
+before a file backend is added and cannot be changed afterwards.
+
 `
 
 const addSecretBackendsExamples = `
     juju add-secret-backend myvault vault --config /path/to/cfg.yaml
     juju add-secret-backend myvault vault token-rotate=10m --config /path/to/cfg.yaml
     juju add-secret-backend myvault vault endpoint=https://vault.io:8200 token=s.1wshwhw
+    juju add-secret-backend myfiles file path=/srv/juju-secrets
 `
 
 // AddSecretBackendsAPI is the secrets client API.
@@ -0,0 +1,153 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	apisecrets "github.com/juju/juju/api/client/secrets"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const defaultMigrateBatchSize = 100
+
+type migrateSecretsCommand struct {
+	modelcmd.ModelCommandBase
+
+	secretsAPIFunc func() (MigrateSecretsAPI, error)
+
+	fromBackend string
+	toBackend   string
+	batchSize   int
+}
+
+// MigrateSecretsAPI is the secrets client API.
+type MigrateSecretsAPI interface {
+	MigrateSecrets(fromBackend, toBackend string, limit int, after string) (*apisecrets.MigrationProgress, error)
+	Close() error
+}
+
+// NewMigrateSecretsCommand returns a command to migrate secret content
+// between backends.
+func NewMigrateSecretsCommand() cmd.Command {
+	c := &migrateSecretsCommand{}
+	c.secretsAPIFunc = c.secretsAPI
+	return modelcmd.Wrap(c)
+}
+
+func (c *migrateSecretsCommand) secretsAPI() (MigrateSecretsAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apisecrets.NewClient(root), nil
+}
+
+const (
+	migrateSecretsDoc = `
+Move the content of every secret revision in the model from one secret
+backend to another.
+
+Each revision is copied to the target backend and read back to verify it,
+then updated to refer to its new location, and finally removed from the
+source backend. This includes revisions of application owned, unit owned
+and user secrets.
+
+The migration is performed in batches and progress is reported after each
+batch. Every revision is attempted once; those which fail are reported at
+the end. If the command is interrupted, or some revisions fail to migrate,
+running it again carries on with the revisions still in the source backend.
+
+Each revision is switched to its new location atomically, so it can always
+be read. The migration as a whole is not atomic: until it completes, some
+revisions are stored in each backend, and both must remain available.
+
+Changing the model's secret-backend config only affects where new
+revisions are stored; use this command to move existing content.
+`
+	migrateSecretsExamples = `
+    juju migrate-secrets --from internal --to myvault
+    juju migrate-secrets --from myvault --to myfiles --batch-size 20
+`
+)
+
+// Info implements cmd.Command.
+func (c *migrateSecretsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "migrate-secrets",
+		Purpose:  "Move existing secret content between secret backends.",
+		Doc:      migrateSecretsDoc,
+		Examples: migrateSecretsExamples,
+		SeeAlso: []string{
+			"secret-backends",
+			"model-config",
+		},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *migrateSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.fromBackend, "from", "", "the backend to move secret content from")
+	f.StringVar(&c.toBackend, "to", "", "the backend to move secret content to")
+	f.IntVar(&c.batchSize, "batch-size", defaultMigrateBatchSize, "the number of revisions to move at a time")
+}
+
+// Init implements cmd.Command.
+func (c *migrateSecretsCommand) Init(args []string) error {
+	if c.fromBackend == "" {
+		return errors.New("missing --from backend")
+	}
+	if c.toBackend == "" {
+		return errors.New("missing --to backend")
+	}
+	if c.fromBackend == c.toBackend {
+		return errors.New("--from and --to backends must be different")
+	}
+	if c.batchSize <= 0 {
+		return errors.NotValidf("batch size %d", c.batchSize)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *migrateSecretsCommand) Run(ctx *cmd.Context) error {
+	secretsAPI, err := c.secretsAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer secretsAPI.Close()
+
+	var (
+		total  int
+		after  string
+		failed []apisecrets.MigrationFailure
+	)
+	for {
+		progress, err := secretsAPI.MigrateSecrets(c.fromBackend, c.toBackend, c.batchSize, after)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		total += progress.Migrated
+		failed = append(failed, progress.Failed...)
+		ctx.Infof("migrated %d secret revisions, %d remaining", total, progress.Remaining)
+		// Stop once every revision has been attempted, or if the
+		// cursor doesn't move on, rather than repeat the same batch.
+		if progress.Next == "" || progress.Next == after {
+			break
+		}
+		after = progress.Next
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	for _, f := range failed {
+		fmt.Fprintf(ctx.Stderr, "cannot migrate secret %s revision %d: %v\n", f.URI, f.Revision, f.Error)
+	}
+	return errors.Errorf("%d secret revisions could not be migrated; run the command again to retry", len(failed))
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	apisecrets "github.com/juju/juju/api/client/secrets"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/cmd/juju/secrets/mocks"
+	"github.com/juju/juju/jujuclient"
+)
+
+type migrateSuite struct {
+	jujutesting.IsolationSuite
+	store      *jujuclient.MemStore
+	secretsAPI *mocks.MockMigrateSecretsAPI
+}
+
+var _ = gc.Suite(&migrateSuite{})
+
+func (s *migrateSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	store := jujuclient.NewMemStore()
+	store.Controllers["mycontroller"] = jujuclient.ControllerDetails{}
+	store.CurrentControllerName = "mycontroller"
+	s.store = store
+}
+
+func (s *migrateSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.secretsAPI = mocks.NewMockMigrateSecretsAPI(ctrl)
+	return ctrl
+}
+
+func (s *migrateSuite) TestInit(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--to", "myvault"},
+		err:  "missing --from backend",
+	}, {
+		args: []string{"--from", "internal"},
+		err:  "missing --to backend",
+	}, {
+		args: []string{"--from", "internal", "--to", "internal"},
+		err:  "--from and --to backends must be different",
+	}, {
+		args: []string{"--from", "internal", "--to", "myvault", "--batch-size", "0"},
+		err:  "batch size 0 not valid",
+	}, {
+		args: []string{"--from", "internal", "--to", "myvault", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, secrets.NewMigrateCommandForTest(s.store, s.secretsAPI), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *migrateSuite) TestMigrate(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	gomock.InOrder(
+		s.secretsAPI.EXPECT().MigrateSecrets("internal", "myvault", 2, "").Return(&apisecrets.MigrationProgress{
+			Migrated: 2, Remaining: 1, Next: "cursor-1",
+		}, nil),
+		s.secretsAPI.EXPECT().MigrateSecrets("internal", "myvault", 2, "cursor-1").Return(&apisecrets.MigrationProgress{
+			Migrated: 1, Remaining: 0,
+		}, nil),
+	)
+	s.secretsAPI.EXPECT().Close().Return(nil)
+
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewMigrateCommandForTest(s.store, s.secretsAPI),
+		"--from", "internal", "--to", "myvault", "--batch-size", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+migrated 2 secret revisions, 1 remaining
+migrated 3 secret revisions, 0 remaining
+`[1:])
+}
+
+func (s *migrateSuite) TestMigrateFailures(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	// Failed revisions are not attempted again, so a batch of
+	// failures still moves on to the rest.
+	gomock.InOrder(
+		s.secretsAPI.EXPECT().MigrateSecrets("internal", "myvault", 2, "").Return(&apisecrets.MigrationProgress{
+			Migrated: 0, Remaining: 5, Next: "cursor-1",
+			Failed: []apisecrets.MigrationFailure{{
+				URI: "secret:9m4e2mr0ui3e8a215n4g", Revision: 1, Error: errors.New("boom"),
+			}, {
+				URI: "secret:9m4e2mr0ui3e8a215n4g", Revision: 2, Error: errors.New("boom"),
+			}},
+		}, nil),
+		s.secretsAPI.EXPECT().MigrateSecrets("internal", "myvault", 2, "cursor-1").Return(&apisecrets.MigrationProgress{
+			Migrated: 2, Remaining: 3, Next: "cursor-2",
+		}, nil),
+		s.secretsAPI.EXPECT().MigrateSecrets("internal", "myvault", 2, "cursor-2").Return(&apisecrets.MigrationProgress{
+			Migrated: 0, Remaining: 3,
+			Failed: []apisecrets.MigrationFailure{{
+				URI: "secret:9m4e2mr0ui3e8a215n4g", Revision: 5, Error: errors.New("bang"),
+			}},
+		}, nil),
+	)
+	s.secretsAPI.EXPECT().Close().Return(nil)
+
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewMigrateCommandForTest(s.store, s.secretsAPI),
+		"--from", "internal", "--to", "myvault", "--batch-size", "2")
+	c.Assert(err, gc.ErrorMatches, "3 secret revisions could not be migrated; run the command again to retry")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+migrated 0 secret revisions, 5 remaining
+migrated 2 secret revisions, 3 remaining
+migrated 2 secret revisions, 3 remaining
+cannot migrate secret secret:9m4e2mr0ui3e8a215n4g revision 1: boom
+cannot migrate secret secret:9m4e2mr0ui3e8a215n4g revision 2: boom
+cannot migrate secret secret:9m4e2mr0ui3e8a215n4g revision 5: bang
+`[1:])
+}
+
+func (s *migrateSuite) TestMigrateStopsWithoutProgress(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.secretsAPI.EXPECT().MigrateSecrets("internal", "myvault", 2, "").Return(&apisecrets.MigrationProgress{
+		Migrated: 2, Remaining: 3, Next: "cursor-1",
+	}, nil)
+	// The cursor doesn't move on, so the batch isn't asked for again.
+	s.secretsAPI.EXPECT().MigrateSecrets("internal", "myvault", 2, "cursor-1").Return(&apisecrets.MigrationProgress{
+		Migrated: 0, Remaining: 3, Next: "cursor-1",
+	}, nil)
+	s.secretsAPI.EXPECT().Close().Return(nil)
+
+	_, err := cmdtesting.RunCommand(c, secrets.NewMigrateCommandForTest(s.store, s.secretsAPI),
+		"--from", "internal", "--to", "myvault", "--batch-size", "2")
+	c.Assert(err, jc.ErrorIsNil)
+}
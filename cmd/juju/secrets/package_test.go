@@ -11,7 +11,7 @@ import (
 	"github.com/juju/juju/jujuclient"
 )
 
-//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/secretsapi.go github.com/juju/juju/cmd/juju/secrets ListSecretsAPI,AddSecretsAPI,GrantRevokeSecretsAPI,UpdateSecretsAPI,RemoveSecretsAPI
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/secretsapi.go github.com/juju/juju/cmd/juju/secrets ListSecretsAPI,AddSecretsAPI,GrantRevokeSecretsAPI,UpdateSecretsAPI,RemoveSecretsAPI,MigrateSecretsAPI
 
 func TestPackage(t *stdtesting.T) {
 	gc.TestingT(t)
@@ -44,6 +44,15 @@ func NewRemoveCommandForTest(store jujuclient.ClientStore, api RemoveSecretsAPI)
 	return c
 }
 
+// NewMigrateCommandForTest returns a secrets command for testing.
+func NewMigrateCommandForTest(store jujuclient.ClientStore, api MigrateSecretsAPI) *migrateSecretsCommand {
+	c := &migrateSecretsCommand{
+		secretsAPIFunc: func() (MigrateSecretsAPI, error) { return api, nil },
+	}
+	c.SetClientStore(store)
+	return c
+}
+
 // NewGrantCommandForTest returns a secrets command for testing.
 func NewGrantCommandForTest(store jujuclient.ClientStore, api GrantRevokeSecretsAPI) *grantSecretCommand {
 	c := &grantSecretCommand{
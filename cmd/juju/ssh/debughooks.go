@@ -244,6 +244,11 @@ func (c *debugHooksCommand) getValidHooks(ch charm.Charm) (set.Strings, error) {
 		hook := fmt.Sprintf("juju-info-%s", hook)
 		validHooks.Add(hook)
 	}
+	// The storage-resized hook is run by the unit agent, but isn't
+	// known to the charm package.
+	for name := range ch.Meta().Storage {
+		validHooks.Add(fmt.Sprintf("%s-storage-resized", name))
+	}
 	return validHooks.Union(ch.Meta().Hooks()), nil
 }
 
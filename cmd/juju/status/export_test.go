@@ -17,3 +17,8 @@ func NewTestStatusCommand(statusapi statusAPI, clock Clock) cmd.Command {
 	return modelcmd.Wrap(
 		&statusCommand{statusAPI: statusapi, clock: clock})
 }
+
+func NewTestStatusWatchCommand(statusapi statusAPI, watcher statusWatcher, clock Clock) cmd.Command {
+	return modelcmd.Wrap(
+		&statusCommand{statusAPI: statusapi, statusWatcher: watcher, clock: clock})
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/naturalsort"
+)
+
+var csvHeaders = []string{
+	"kind", "name", "application", "machine", "status", "agent-status",
+	"address", "ports", "instance-id", "base", "leader", "message",
+}
+
+// FormatCSV writes one comma separated record for each unit (including
+// subordinates) and each machine (including containers) in the model.
+// The first column records whether the row describes a unit or a
+// machine, so that consumers can filter on it.
+func FormatCSV(writer io.Writer, value interface{}) error {
+	fs, valueConverted := value.(formattedStatus)
+	if !valueConverted {
+		return errors.Errorf("expected value of type %T, got %T", fs, value)
+	}
+
+	w := csv.NewWriter(writer)
+	if err := w.Write(csvHeaders); err != nil {
+		return errors.Trace(err)
+	}
+
+	var records [][]string
+	unitRecord := func(name string, u unitStatus) {
+		appName, _ := names.UnitApplication(name)
+		address := u.PublicAddress
+		if address == "" {
+			address = u.Address
+		}
+		records = append(records, []string{
+			"unit",
+			name,
+			appName,
+			u.Machine,
+			string(u.WorkloadStatusInfo.Current),
+			string(u.JujuStatusInfo.Current),
+			address,
+			strings.Join(u.OpenedPorts, " "),
+			"",
+			baseString(fs.Applications[appName].Base),
+			fmt.Sprint(u.Leader),
+			u.WorkloadStatusInfo.Message,
+		})
+	}
+	for _, appName := range naturalsort.Sort(stringKeysFromMap(fs.Applications)) {
+		app := fs.Applications[appName]
+		for _, uName := range naturalsort.Sort(stringKeysFromMap(app.Units)) {
+			u := app.Units[uName]
+			unitRecord(uName, u)
+			recurseUnits(u, 0, func(name string, sub unitStatus, _ int) {
+				unitRecord(name, sub)
+			})
+		}
+	}
+
+	var machineRecords func(machines map[string]machineStatus)
+	machineRecords = func(machines map[string]machineStatus) {
+		for _, id := range naturalsort.Sort(stringKeysFromMap(machines)) {
+			m := machines[id]
+			message := m.MachineStatus.Message
+			if m.Err != nil {
+				message = m.Err.Error()
+			}
+			records = append(records, []string{
+				"machine",
+				id,
+				"",
+				"",
+				string(m.MachineStatus.Current),
+				string(m.JujuStatus.Current),
+				m.DNSName,
+				"",
+				m.machineName(),
+				baseString(m.Base),
+				"",
+				message,
+			})
+			machineRecords(m.Containers)
+		}
+	}
+	machineRecords(fs.Machines)
+
+	if err := w.WriteAll(records); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// baseString returns the base in the <name>@<channel> form used
+// throughout the CLI, or an empty string if no base is set.
+func baseString(b *formattedBase) string {
+	if b == nil || b.Name == "" {
+		return ""
+	}
+	return b.Name + "@" + b.Channel
+}
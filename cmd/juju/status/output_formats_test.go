@@ -0,0 +1,178 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+)
+
+type OutputFormatsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&OutputFormatsSuite{})
+
+func (s *OutputFormatsSuite) formattedStatus() formattedStatus {
+	return formattedStatus{
+		Model: modelStatus{
+			Name:       "default",
+			Controller: "ctrl",
+			Cloud:      "aws",
+			Version:    "3.3.0",
+			Status:     statusInfoContents{Current: status.Available},
+		},
+		Applications: map[string]applicationStatus{
+			"mysql": {
+				CharmName:  "mysql",
+				CharmRev:   42,
+				Base:       &formattedBase{Name: "ubuntu", Channel: "22.04"},
+				Exposed:    true,
+				StatusInfo: statusInfoContents{Current: status.Active},
+				Units: map[string]unitStatus{
+					"mysql/0": {
+						Leader:             true,
+						Machine:            "0",
+						PublicAddress:      "10.0.0.1",
+						OpenedPorts:        []string{"3306/tcp"},
+						WorkloadStatusInfo: statusInfoContents{Current: status.Active, Message: "ready, primary"},
+						JujuStatusInfo:     statusInfoContents{Current: status.Idle},
+						Subordinates: map[string]unitStatus{
+							"logging/0": {
+								PublicAddress:      "10.0.0.1",
+								WorkloadStatusInfo: statusInfoContents{Current: status.Active},
+								JujuStatusInfo:     statusInfoContents{Current: status.Idle},
+							},
+						},
+					},
+				},
+			},
+		},
+		Machines: map[string]machineStatus{
+			"0": {
+				Id:            "0",
+				DNSName:       "10.0.0.1",
+				InstanceId:    "i-123",
+				Hardware:      "availability-zone=us-east-1a",
+				Base:          &formattedBase{Name: "ubuntu", Channel: "22.04"},
+				JujuStatus:    statusInfoContents{Current: status.Started},
+				MachineStatus: statusInfoContents{Current: status.Running, Message: "running | ok"},
+				Containers: map[string]machineStatus{
+					"0/lxd/0": {
+						Id:            "0/lxd/0",
+						JujuStatus:    statusInfoContents{Current: status.Pending},
+						MachineStatus: statusInfoContents{Current: status.Pending},
+					},
+				},
+			},
+		},
+		Relations: []relationStatus{{
+			Provider:  "mysql:juju-info",
+			Requirer:  "logging:info",
+			Interface: "juju-info",
+			Type:      "subordinate",
+		}},
+	}
+}
+
+func (s *OutputFormatsSuite) TestFormatCSV(c *gc.C) {
+	out := &bytes.Buffer{}
+	err := FormatCSV(out, s.formattedStatus())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+kind,name,application,machine,status,agent-status,address,ports,instance-id,base,leader,message
+unit,mysql/0,mysql,0,active,idle,10.0.0.1,3306/tcp,,ubuntu@22.04,true,"ready, primary"
+unit,logging/0,logging,,active,idle,10.0.0.1,,,,false,
+machine,0,,,running,started,10.0.0.1,,i-123,ubuntu@22.04,,running | ok
+machine,0/lxd/0,,,pending,pending,,,,,,
+`[1:])
+}
+
+func (s *OutputFormatsSuite) TestFormatMarkdown(c *gc.C) {
+	out := &bytes.Buffer{}
+	err := FormatMarkdown(out, s.formattedStatus())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+### Model
+
+| Model | Controller | Cloud/Region | Version | Status | Message |
+| --- | --- | --- | --- | --- | --- |
+| default | ctrl | aws | 3.3.0 | available |  |
+
+### Applications
+
+| App | Version | Status | Scale | Charm | Channel | Rev | Exposed | Message |
+| --- | --- | --- | --- | --- | --- | --- | --- | --- |
+| mysql |  | active | 1 | mysql |  | 42 | yes |  |
+
+### Units
+
+| Unit | Workload | Agent | Machine | Public address | Ports | Message |
+| --- | --- | --- | --- | --- | --- | --- |
+| mysql/0* | active | idle | 0 | 10.0.0.1 | 3306/tcp | ready, primary |
+| logging/0 | active | idle |  | 10.0.0.1 |  |  |
+
+### Machines
+
+| Machine | State | Address | Inst id | Base | AZ | Message |
+| --- | --- | --- | --- | --- | --- | --- |
+| 0 | started | 10.0.0.1 | i-123 | ubuntu@22.04 | us-east-1a | running \| ok |
+| 0/lxd/0 | pending |  |  |  |  |  |
+
+### Integrations
+
+| Provider | Requirer | Interface | Type | Message |
+| --- | --- | --- | --- | --- |
+| mysql:juju-info | logging:info | juju-info | subordinate |  |
+`[1:])
+}
+
+func (s *OutputFormatsSuite) TestFormatTemplate(c *gc.C) {
+	tmpl, err := parseStatusTemplate(
+		`{{.Model.Name}}{{range $name, $app := .Applications}} {{$name}}={{$app.StatusInfo.Current}}{{end}} {{json .Model.Cloud}}`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out := &bytes.Buffer{}
+	err = FormatTemplate(out, tmpl, s.formattedStatus())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `default mysql=active "aws"`)
+}
+
+func (s *OutputFormatsSuite) TestFormatTemplateExecuteError(c *gc.C) {
+	tmpl, err := parseStatusTemplate(`{{.Missing}}`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = FormatTemplate(&bytes.Buffer{}, tmpl, s.formattedStatus())
+	c.Assert(err, gc.ErrorMatches, `executing template: .*can't evaluate field Missing.*`)
+}
+
+// initTemplateCommand initialises the status command without the model
+// command wrapper, which would need a controller to be registered.
+func initTemplateCommand(args ...string) (*statusCommand, error) {
+	com := &statusCommand{}
+	return com, cmdtesting.InitCommand(com, args)
+}
+
+func (s *OutputFormatsSuite) TestInitTemplate(c *gc.C) {
+	com, err := initTemplateCommand("--format", "template", "--template", "{{.Model.Name}}")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(com.parsedTemplate, gc.NotNil)
+}
+
+func (s *OutputFormatsSuite) TestInitTemplateErrors(c *gc.C) {
+	_, err := initTemplateCommand("--format", "template")
+	c.Assert(err, gc.ErrorMatches, "--format=template requires --template")
+
+	_, err = initTemplateCommand("--format", "template", "--template", "{{.Model.Name")
+	c.Assert(err, gc.ErrorMatches, "invalid template: .*")
+
+	_, err = initTemplateCommand("--template", "{{.Model.Name}}")
+	c.Assert(err, gc.ErrorMatches, "--template can only be used with --format=template")
+}
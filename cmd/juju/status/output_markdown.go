@@ -0,0 +1,147 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+
+	"github.com/juju/juju/core/instance"
+)
+
+// FormatMarkdown writes the status as a series of Markdown tables,
+// suitable for pasting into tickets and documents. The sections mirror
+// those of the tabular format.
+func FormatMarkdown(writer io.Writer, value interface{}) error {
+	fs, valueConverted := value.(formattedStatus)
+	if !valueConverted {
+		return errors.Errorf("expected value of type %T, got %T", fs, value)
+	}
+	w := &markdownWriter{writer: writer}
+
+	cloudRegion := fs.Model.Cloud
+	if fs.Model.CloudRegion != "" {
+		cloudRegion += "/" + fs.Model.CloudRegion
+	}
+	w.section("Model", "Model", "Controller", "Cloud/Region", "Version", "Status", "Message")
+	w.row(fs.Model.Name, fs.Model.Controller, cloudRegion, fs.Model.Version,
+		string(fs.Model.Status.Current), fs.Model.Status.Message)
+
+	if len(fs.Applications) > 0 {
+		w.section("Applications", "App", "Version", "Status", "Scale", "Charm", "Channel", "Rev", "Exposed", "Message")
+		for _, appName := range naturalsort.Sort(stringKeysFromMap(fs.Applications)) {
+			app := fs.Applications[appName]
+			scale, _ := fs.applicationScale(appName)
+			exposed := "no"
+			if app.Exposed {
+				exposed = "yes"
+			}
+			w.row(appName, strings.Split(app.Version, "\n")[0], string(app.StatusInfo.Current), scale,
+				app.CharmName, app.CharmChannel, fmt.Sprint(app.CharmRev), exposed, app.StatusInfo.Message)
+		}
+
+		w.section("Units", "Unit", "Workload", "Agent", "Machine", "Public address", "Ports", "Message")
+		unitRow := func(name string, u unitStatus) {
+			if u.Leader {
+				name += "*"
+			}
+			w.row(name, string(u.WorkloadStatusInfo.Current), string(u.JujuStatusInfo.Current),
+				u.Machine, u.PublicAddress, strings.Join(u.OpenedPorts, " "), u.WorkloadStatusInfo.Message)
+		}
+		for _, appName := range naturalsort.Sort(stringKeysFromMap(fs.Applications)) {
+			units := fs.Applications[appName].Units
+			for _, uName := range naturalsort.Sort(stringKeysFromMap(units)) {
+				u := units[uName]
+				unitRow(uName, u)
+				recurseUnits(u, 0, func(name string, sub unitStatus, _ int) {
+					unitRow(name, sub)
+				})
+			}
+		}
+	}
+
+	if len(fs.Machines) > 0 {
+		w.section("Machines", "Machine", "State", "Address", "Inst id", "Base", "AZ", "Message")
+		var machineRows func(machines map[string]machineStatus)
+		machineRows = func(machines map[string]machineStatus) {
+			for _, id := range naturalsort.Sort(stringKeysFromMap(machines)) {
+				m := machines[id]
+				az := ""
+				if hw, err := instance.ParseHardware(m.Hardware); err == nil && hw.AvailabilityZone != nil {
+					az = *hw.AvailabilityZone
+				}
+				status, message := getStatusAndMessageFromMachineStatus(m)
+				w.row(id, string(status), m.DNSName, m.machineName(), baseString(m.Base), az, message)
+				machineRows(m.Containers)
+			}
+		}
+		machineRows(fs.Machines)
+	}
+
+	if len(fs.Relations) > 0 {
+		relations := append([]relationStatus(nil), fs.Relations...)
+		sort.Slice(relations, func(i, j int) bool {
+			a, b := relations[i], relations[j]
+			if a.Provider == b.Provider {
+				return a.Requirer < b.Requirer
+			}
+			return a.Provider < b.Provider
+		})
+		w.section("Integrations", "Provider", "Requirer", "Interface", "Type", "Message")
+		for _, r := range relations {
+			w.row(r.Provider, r.Requirer, r.Interface, r.Type, r.Message)
+		}
+	}
+	return errors.Trace(w.err)
+}
+
+// markdownWriter writes Markdown tables, remembering the first write
+// error so that callers only need to check it once.
+type markdownWriter struct {
+	writer  io.Writer
+	started bool
+	err     error
+}
+
+func (w *markdownWriter) printf(format string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = fmt.Fprintf(w.writer, format, args...)
+}
+
+// section starts a new table with the given title and column headers.
+func (w *markdownWriter) section(title string, headers ...string) {
+	if w.started {
+		w.printf("\n")
+	}
+	w.started = true
+	w.printf("### %s\n\n", title)
+	w.row(headers...)
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	w.row(separators...)
+}
+
+func (w *markdownWriter) row(values ...string) {
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = markdownEscaper.Replace(v)
+	}
+	w.printf("| %s |\n", strings.Join(escaped, " | "))
+}
+
+// markdownEscaper stops cell values from breaking the table layout.
+var markdownEscaper = strings.NewReplacer(
+	"|", `\|`,
+	"\r\n", " ",
+	"\n", " ",
+)
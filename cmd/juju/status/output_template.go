@@ -0,0 +1,50 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/juju/errors"
+)
+
+// templateFuncs are the functions available to status templates in
+// addition to the text/template builtins.
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return string(data), nil
+	},
+}
+
+// parseStatusTemplate parses text as a Go template to be executed
+// against the formatted status.
+func parseStatusTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("status").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid template")
+	}
+	return tmpl, nil
+}
+
+// FormatTemplate executes the given template against the formatted
+// status. The template sees the same structure as the yaml and json
+// formats, using the Go field names.
+func FormatTemplate(writer io.Writer, tmpl *template.Template, value interface{}) error {
+	fs, valueConverted := value.(formattedStatus)
+	if !valueConverted {
+		return errors.Errorf("expected value of type %T, got %T", fs, value)
+	}
+	if err := tmpl.Execute(writer, fs); err != nil {
+		return errors.Annotate(err, "executing template")
+	}
+	return nil
+}
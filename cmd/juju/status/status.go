@@ -9,6 +9,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/juju/clock"
@@ -17,7 +18,6 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
-	"github.com/juju/viddy"
 
 	"github.com/juju/juju/api/client/client"
 	jujucmd "github.com/juju/juju/cmd"
@@ -72,8 +72,19 @@ type statusCommand struct {
 	// storage indicates if 'storage' section is displayed
 	storage bool
 
-	// watch indicates the time to wait between consecutive status queries
+	// watch indicates the minimum time to wait between consecutive
+	// redraws when watching the model for changes.
 	watch time.Duration
+
+	// highlightChanges marks the lines that changed since the previous
+	// redraw when watching.
+	highlightChanges bool
+
+	statusWatcher statusWatcher
+
+	// template holds the Go template used by the 'template' format.
+	template       string
+	parsedTemplate *template.Template
 }
 
 var usageSummary = `
@@ -122,6 +133,21 @@ The '--format' option allows you to specify how the status report is formatted.
   --format=yaml
                     Provide information in a JSON or YAML formats for 
                     programmatic use.
+
+  --format=csv
+                    Reports one comma separated row for each unit and
+                    machine. The first column is either "unit" or "machine".
+
+  --format=markdown
+                    Reports the model, applications, units, machines and
+                    integrations as Markdown tables.
+
+  --format=template
+                    Executes the Go template given with '--template' against
+                    the status. Fields are referenced by their Go names, for
+                    example {{.Model.Name}} or {{range $name, $app :=
+                    .Applications}}. The functions 'join' and 'json' are
+                    available in addition to the template builtins.
 `
 
 const usageExamples = `
@@ -145,10 +171,23 @@ Provide output as valid JSON:
 
     juju status --format=json
 
-Watch the status every five seconds:
+Provide unit and machine information as CSV:
+
+    juju status --format=csv
+
+Report the status of each application using a Go template:
+
+    juju status --format=template --template='{{range $name, $app := .Applications}}{{$name}}: {{$app.StatusInfo.Current}}{{"\n"}}{{end}}'
+
+Watch the status, redrawing when the model changes but at most every
+five seconds:
 
     juju status --watch 5s
 
+Watch the status and mark the lines that changed since the last redraw:
+
+    juju status --watch 2s --highlight-changes
+
 Show only applications/units in active status:
 
     juju status active
@@ -187,7 +226,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
 
-	f.DurationVar(&c.watch, "watch", 0, "Watch the status every period of time")
+	f.DurationVar(&c.watch, "watch", 0, "Watch the status, redrawing when the model changes at most once every period of time")
+	f.BoolVar(&c.highlightChanges, "highlight-changes", false, "Mark the lines that changed since the previous redraw when using --watch")
+	f.StringVar(&c.template, "template", "", "Go template used with --format=template")
 
 	c.checkProvidedIgnoredFlagF = func() set.Strings {
 		ignoredFlagForNonTabularFormat := set.NewStrings(
@@ -208,13 +249,16 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	defaultFormat := "tabular"
 
 	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
-		"yaml":    c.formatYaml,
-		"json":    c.formatJson,
-		"short":   c.formatOneline,
-		"oneline": c.formatOneline,
-		"line":    c.formatOneline,
-		"tabular": c.FormatTabular,
-		"summary": c.formatSummary,
+		"yaml":     c.formatYaml,
+		"json":     c.formatJson,
+		"short":    c.formatOneline,
+		"oneline":  c.formatOneline,
+		"line":     c.formatOneline,
+		"tabular":  c.FormatTabular,
+		"summary":  c.formatSummary,
+		"csv":      FormatCSV,
+		"markdown": FormatMarkdown,
+		"template": c.formatTemplate,
 	})
 }
 
@@ -239,6 +283,26 @@ func (c *statusCommand) Init(args []string) error {
 		return errors.Errorf("cannot mix --no-color and --color")
 	}
 
+	if c.watch < 0 {
+		return errors.NotValidf("negative --watch period")
+	}
+	if c.highlightChanges && c.watch == 0 {
+		return errors.New("--highlight-changes can only be used with --watch")
+	}
+
+	if c.out.Name() == "template" {
+		if c.template == "" {
+			return errors.New("--format=template requires --template")
+		}
+		tmpl, err := parseStatusTemplate(c.template)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.parsedTemplate = tmpl
+	} else if c.template != "" {
+		return errors.New("--template can only be used with --format=template")
+	}
+
 	return nil
 }
 
@@ -383,55 +447,13 @@ func (c *statusCommand) runStatus(ctx *cmd.Context) error {
 	return nil
 }
 
-// statusCommandForViddy returns the full juju command including all args
-// except the '--watch' flag.
-func (c *statusCommand) statusCommandForViddy(args []string) []string {
-	var jujuStatusArgsWithoutWatchFlag []string
-
-	for i := range args {
-		// In order to support gnu flags, we must first check if the
-		// watch flag is using gnu style. In that case, we must remove
-		// the entire arg, since it's one entire string (e.g.
-		// --watch=1s).
-		if strings.HasPrefix(args[i], "--watch=") {
-			jujuStatusArgsWithoutWatchFlag = append(args[:i], args[i+1:]...)
-			break
-		}
-		// If the flag is not using gnu style, we must remove both the
-		// flag and the argument (e.g --watch 1s)
-		if args[i] == "--watch" {
-			jujuStatusArgsWithoutWatchFlag = append(args[:i], args[i+2:]...)
-			break
-		}
-	}
-
-	if !c.noColor {
-		jujuStatusArgsWithoutWatchFlag = append(jujuStatusArgsWithoutWatchFlag, "--color")
-	}
-	return jujuStatusArgsWithoutWatchFlag
-}
-
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	defer c.close()
 
 	if c.watch != 0 {
-		jujuStatusArgs := c.statusCommandForViddy(os.Args)
-
-		viddyArgs := append([]string{"--no-title", "--interval", c.watch.String()}, jujuStatusArgs...)
-
-		// Define tview styles and launch preconfiged Viddy watcher
-		app := viddy.NewPreconfigedViddy(viddyArgs)
-		if err := app.Run(); err != nil {
-			return errors.Annotate(err, "unable to run Viddy (watcher for status command)")
-		}
-	} else {
-		err := c.runStatus(ctx)
-		if err != nil {
-			return err
-		}
+		return c.watchStatus(ctx)
 	}
-
-	return nil
+	return c.runStatus(ctx)
 }
 
 func (c *statusCommand) formatYaml(writer io.Writer, value interface{}) error {
@@ -496,6 +518,10 @@ func (c *statusCommand) formatJson(writer io.Writer, value interface{}) error {
 	return cmd.FormatJson(writer, value)
 }
 
+func (c *statusCommand) formatTemplate(writer io.Writer, value interface{}) error {
+	return FormatTemplate(writer, c.parsedTemplate, value)
+}
+
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
 	if c.noColor {
 		if _, ok := os.LookupEnv("NO_COLOR"); !ok {
@@ -6703,16 +6703,3 @@ controller  kontroll    dummy/dummy-region  1.2.3    unsupported  15:04:05+07:00
 	_, _, stderr = runStatus(c, "--no-color", "cannot", "match", "me")
 	c.Check(string(stderr), gc.Equals, "Nothing matched specified filters.\n")
 }
-
-func (s *StatusSuite) TestStatusArgsWithoutWatch(c *gc.C) {
-	cmd, err := initStatusCommand()
-	c.Assert(err, jc.ErrorIsNil)
-
-	statusArgsGNUStyle := []string{"juju", "status", "--watch=1s", "--relations"}
-	expectedArgsGNUStyle := []string{"juju", "status", "--relations", "--color"}
-	c.Check(cmd.statusCommandForViddy(statusArgsGNUStyle), jc.SameContents, expectedArgsGNUStyle)
-
-	statusArgsUnixStyle := []string{"juju", "status", "--watch", "1s", "--relations"}
-	expectedArgsUnixStyle := []string{"juju", "status", "--relations", "--color"}
-	c.Check(cmd.statusCommandForViddy(statusArgsUnixStyle), jc.SameContents, expectedArgsUnixStyle)
-}
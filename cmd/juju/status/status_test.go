@@ -121,6 +121,62 @@ func (s *MinimalStatusSuite) TestRetryCount(c *gc.C) {
 	c.Assert(s.clock.waits, jc.DeepEquals, []time.Duration{delay, delay, delay, delay, delay})
 }
 
+func (s *MinimalStatusSuite) runWatch(c *gc.C, watcher *fakeStatusWatcher, args ...string) (*cmd.Context, error) {
+	statusCmd := status.NewTestStatusWatchCommand(s.statusapi, watcher, s.clock)
+	return cmdtesting.RunCommand(c, statusCmd, args...)
+}
+
+func (s *MinimalStatusSuite) TestWatchRedrawsOnChange(c *gc.C) {
+	s.statusapi.called = make(chan struct{}, 2)
+	watcher := &fakeStatusWatcher{
+		statusapi: s.statusapi,
+		changes:   []*params.FullStatus{{Model: params.ModelStatusInfo{Name: "test", CloudTag: "cloud-bar"}}},
+	}
+
+	context, err := s.runWatch(c, watcher, "--no-color", "--watch", "2s")
+	c.Assert(err, gc.ErrorMatches, "watching model changes: watcher stopped")
+	c.Assert(watcher.stopped, jc.IsTrue)
+	c.Assert(s.clock.waits, jc.DeepEquals, []time.Duration{2 * time.Second, 2 * time.Second})
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+test   test        foo           
+
+Model "admin/test" is empty.
+
+Model  Controller  Cloud/Region  Version
+test   test        bar           
+
+Model "admin/test" is empty.
+`[1:])
+}
+
+func (s *MinimalStatusSuite) TestWatchHighlightChanges(c *gc.C) {
+	s.statusapi.called = make(chan struct{}, 2)
+	watcher := &fakeStatusWatcher{
+		statusapi: s.statusapi,
+		changes:   []*params.FullStatus{{Model: params.ModelStatusInfo{Name: "test", CloudTag: "cloud-bar"}}},
+	}
+
+	context, err := s.runWatch(c, watcher, "--no-color", "--watch", "2s", "--highlight-changes")
+	c.Assert(err, gc.ErrorMatches, "watching model changes: watcher stopped")
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+  Model  Controller  Cloud/Region  Version
+  test   test        foo           
+
+  Model "admin/test" is empty.
+
+  Model  Controller  Cloud/Region  Version
++ test   test        bar           
+
+  Model "admin/test" is empty.
+`[1:])
+}
+
+func (s *MinimalStatusSuite) TestHighlightChangesRequiresWatch(c *gc.C) {
+	_, err := s.runStatus(c, "--highlight-changes")
+	c.Assert(err, gc.ErrorMatches, "--highlight-changes can only be used with --watch")
+}
+
 func (s *MinimalStatusSuite) TestRetryCountOfZero(c *gc.C) {
 	s.statusapi.errors = []error{
 		errors.New("error 1"),
@@ -138,6 +194,9 @@ type fakeStatusAPI struct {
 	expectIncludeStorage bool
 	result               *params.FullStatus
 	errors               []error
+
+	// called, if set, receives a value after each Status call.
+	called chan struct{}
 }
 
 func (f *fakeStatusAPI) Status(args *client.StatusArgs) (*params.FullStatus, error) {
@@ -152,6 +211,9 @@ func (f *fakeStatusAPI) Status(args *client.StatusArgs) (*params.FullStatus, err
 			return nil, err
 		}
 	}
+	if f.called != nil {
+		defer func() { f.called <- struct{}{} }()
+	}
 	return f.result, nil
 }
 
@@ -159,6 +221,35 @@ func (*fakeStatusAPI) Close() error {
 	return nil
 }
 
+// fakeStatusWatcher reports the initial model state, then one change for
+// each of the given status results, each after the previous status has
+// been drawn. Once all changes are reported it fails with "watcher
+// stopped" after the last status has been drawn.
+type fakeStatusWatcher struct {
+	statusapi *fakeStatusAPI
+	changes   []*params.FullStatus
+	started   bool
+	stopped   bool
+}
+
+func (w *fakeStatusWatcher) Next() ([]params.Delta, error) {
+	if !w.started {
+		w.started = true
+		return []params.Delta{}, nil
+	}
+	<-w.statusapi.called
+	if len(w.changes) == 0 {
+		return nil, errors.New("watcher stopped")
+	}
+	w.statusapi.result, w.changes = w.changes[0], w.changes[1:]
+	return []params.Delta{}, nil
+}
+
+func (w *fakeStatusWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
 type timeRecorder struct {
 	waits  []time.Duration
 	result chan time.Time
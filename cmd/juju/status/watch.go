@@ -0,0 +1,167 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/rpc/params"
+)
+
+// clearScreen moves the cursor to the top left of the terminal and
+// clears it, so that each frame replaces the previous one.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// statusWatcher is the part of the model AllWatcher used to know when
+// the status needs to be redrawn.
+type statusWatcher interface {
+	Next() ([]params.Delta, error)
+	Stop() error
+}
+
+// allWatcherAPI is implemented by status API clients that can watch
+// the model for changes.
+type allWatcherAPI interface {
+	WatchAll() (*api.AllWatcher, error)
+}
+
+func (c *statusCommand) newStatusWatcher() (statusWatcher, error) {
+	if c.statusWatcher != nil {
+		return c.statusWatcher, nil
+	}
+	apiclient, err := newAPIClientForStatus(c)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	watchAPI, ok := apiclient.(allWatcherAPI)
+	if !ok {
+		return nil, errors.NotSupportedf("watching the model for changes")
+	}
+	w, err := watchAPI.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.statusWatcher = w
+	return w, nil
+}
+
+// watchStatus redraws the status each time the model changes, but no
+// more often than the watch period. Deltas received from the model
+// AllWatcher only trigger a redraw; the status itself is still built
+// from a single FullStatus call so that all formats keep working.
+func (c *statusCommand) watchStatus(ctx *cmd.Context) error {
+	watcher, err := c.newStatusWatcher()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = watcher.Stop() }()
+
+	terminal := isTerminal(ctx.Stdout)
+	if terminal && !c.noColor {
+		c.color = true
+	}
+
+	changes := make(chan struct{}, 1)
+	watchErr := make(chan error, 1)
+	go func() {
+		// The first set of deltas describes the whole model, which
+		// the first frame already shows.
+		initial := true
+		for {
+			if _, err := watcher.Next(); err != nil {
+				watchErr <- err
+				return
+			}
+			if initial {
+				initial = false
+				continue
+			}
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	interrupt := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupt)
+	defer ctx.StopInterruptNotify(interrupt)
+
+	var previous []string
+	for {
+		frame, err := c.renderFrame(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.drawFrame(ctx.Stdout, terminal, previous, frame)
+		previous = frame
+
+		select {
+		case <-c.clock.After(c.watch):
+		case <-interrupt:
+			return nil
+		case err := <-watchErr:
+			return errors.Annotate(err, "watching model changes")
+		}
+		select {
+		case <-changes:
+		case <-interrupt:
+			return nil
+		case err := <-watchErr:
+			return errors.Annotate(err, "watching model changes")
+		}
+	}
+}
+
+// renderFrame runs the status command, capturing its output as lines.
+func (c *statusCommand) renderFrame(ctx *cmd.Context) ([]string, error) {
+	var buf bytes.Buffer
+	frameCtx := *ctx
+	frameCtx.Stdout = &buf
+	frameCtx.Stderr = &buf
+	if err := c.runStatus(&frameCtx); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"), nil
+}
+
+// drawFrame writes the frame, replacing the previous one on a terminal.
+// When highlighting changes, lines that were not in the previous frame
+// are marked with a "+" in the left margin.
+func (c *statusCommand) drawFrame(writer io.Writer, terminal bool, previous, frame []string) {
+	if terminal {
+		fmt.Fprint(writer, clearScreen)
+	} else if previous != nil {
+		fmt.Fprintln(writer)
+	}
+	if !c.highlightChanges {
+		fmt.Fprintln(writer, strings.Join(frame, "\n"))
+		return
+	}
+	seen := set.NewStrings(previous...)
+	marker := "+"
+	if c.color {
+		marker = colorVal(output.GoodHighlight, marker)
+	}
+	for _, line := range frame {
+		switch {
+		case previous != nil && !seen.Contains(line):
+			fmt.Fprintf(writer, "%s %s\n", marker, line)
+		case line == "":
+			fmt.Fprintln(writer)
+		default:
+			fmt.Fprintf(writer, "  %s\n", line)
+		}
+	}
+}
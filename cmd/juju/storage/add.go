@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd/v3"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
 
 	jujucmd "github.com/juju/juju/cmd"
@@ -73,6 +74,13 @@ by the charm, which can specify a maximum number of storage instances per unit.
 positive number, followed by a size suffix.  Valid suffixes include M, G, T,
 and P.  Defaults to "1024M", or the which can specify a minimum size required 
 by the charm.
+
+Storage may instead be created from a snapshot taken with 'juju snapshot-storage',
+using --from-snapshot. The storage is then created in the pool of the storage
+that the snapshot was taken of, and at least as large, so only the storage name
+may be given:
+
+	juju add-storage <unit> <storage-name> --from-snapshot <snapshot-id>
 `
 
 	addCommandExamples = `
@@ -88,6 +96,10 @@ Add a storage instance from the (AWS-specific) ebs-ssd storage pool for "brick"
 
     juju add-storage gluster/0 brick=ebs-ssd
 
+Add "pgdata" storage to unit postgresql/1, created from snapshot 0/3:
+
+    juju add-storage postgresql/1 pgdata --from-snapshot 0/3
+
 
 Further reading:
 
@@ -107,6 +119,16 @@ type addCommand struct {
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
 	newAPIFunc  func() (StorageAddAPI, error)
+
+	// fromSnapshot is the ID of the storage snapshot to create the
+	// storage from, if any.
+	fromSnapshot string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.fromSnapshot, "from-snapshot", "", "Create the storage from the snapshot with this ID")
 }
 
 // Init implements Command.Init.
@@ -121,6 +143,16 @@ func (c *addCommand) Init(args []string) (err error) {
 	}
 	c.unitTag = names.NewUnitTag(u)
 
+	if c.fromSnapshot != "" {
+		if len(args) > 2 {
+			return errors.New("add-storage --from-snapshot accepts a single storage name")
+		}
+		if strings.Contains(args[1], "=") {
+			return errors.New("storage constraints cannot be specified with --from-snapshot")
+		}
+		c.storageCons = map[string]storage.Constraints{args[1]: {}}
+		return nil
+	}
 	c.storageCons, err = storage.ParseConstraintsMap(args[1:], false)
 	return
 }
@@ -137,6 +169,7 @@ func (c *addCommand) Info() *cmd.Info {
 			"import-filesystem",
 			"storage",
 			"storage-pools",
+			"snapshot-storage",
 		},
 	})
 }
@@ -209,6 +242,14 @@ type StorageAddAPI interface {
 func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 	all := make([]params.StorageAddParams, 0, len(c.storageCons))
 	for one, sc := range c.storageCons {
+		if c.fromSnapshot != "" {
+			all = append(all, params.StorageAddParams{
+				UnitTag:     c.unitTag.String(),
+				StorageName: one,
+				SnapshotId:  c.fromSnapshot,
+			})
+			continue
+		}
 		cons := sc
 		all = append(all, params.StorageAddParams{
 			UnitTag:     c.unitTag.String(),
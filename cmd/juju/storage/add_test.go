@@ -229,6 +229,29 @@ func (s *addSuite) TestCollapseUnitErrors(c *gc.C) {
 	s.assertAddErrorOutput(c, "cmd: error out silently", expectedErr+"\n")
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
+		c.Assert(storages, jc.DeepEquals, []params.StorageAddParams{{
+			UnitTag:     "unit-tst-123",
+			StorageName: "data",
+			SnapshotId:  "0/3",
+		}})
+		return []params.AddStorageResult{{
+			Result: &params.AddStorageDetails{StorageTags: []string{"storage-data-1"}},
+		}}, nil
+	}
+	context, err := s.runAdd(c, "tst/123", "data", "--from-snapshot", "0/3")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExpectedOutput(c, context, "added storage data/1 to tst/123\n")
+}
+
+func (s *addSuite) TestAddFromSnapshotInvalidArgs(c *gc.C) {
+	_, err := s.runAdd(c, "tst/123", "data=loop", "--from-snapshot", "0/3")
+	c.Assert(err, gc.ErrorMatches, "storage constraints cannot be specified with --from-snapshot")
+	_, err = s.runAdd(c, "tst/123", "data", "logs", "--from-snapshot", "0/3")
+	c.Assert(err, gc.ErrorMatches, "add-storage --from-snapshot accepts a single storage name")
+}
+
 func (s *addSuite) TestUnauthorizedMentionsJujuGrant(c *gc.C) {
 	s.args = []string{"tst/123", "data"}
 	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
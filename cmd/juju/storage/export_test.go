@@ -71,6 +71,38 @@ func NewAddCommandForTest(api StorageAddAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.Wrap(cmd)
 }
 
+func NewSnapshotCommandForTest(api StorageSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotCommand{newAPIFunc: func() (StorageSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewResizeCommandForTest(api StorageResizeAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeCommand{newAPIFunc: func() (StorageResizeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSnapshotRemoveCommandForTest(api StorageSnapshotRemoveAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotRemoveCommand{newAPIFunc: func() (StorageSnapshotRemoveAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSnapshotListCommandForTest(api StorageSnapshotListAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotListCommand{newAPIFunc: func() (StorageSnapshotListAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewRemoveStorageCommandForTest(new NewStorageRemoverCloserFunc, store jujuclient.ClientStore) cmd.Command {
 	cmd := &removeStorageCommand{}
 	cmd.SetClientStore(store)
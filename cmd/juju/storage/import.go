@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd/v3"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
 
 	apistorage "github.com/juju/juju/api/client/storage"
@@ -64,7 +65,9 @@ To import a filesystem, you must specify three things:
    corresponding to the storage name used by a charm
 
 Once a filesystem is imported, Juju will create an associated storage
-instance using the given storage name.
+instance using the given storage name. If an application is specified
+with --application, the storage may only be attached to units of that
+application.
 `
 	importFilesystemCommandExamples = `
 Import an existing filesystem backed by an EBS volume,
@@ -74,6 +77,13 @@ the volume and filesystem contained within.
 
     juju import-filesystem ebs vol-123456 pgdata
 
+Import an existing Kubernetes persistent volume for the
+"postgresql" application, and assign it the "pgdata" storage
+name. The persistent volume must not be bound to a claim; it
+will be bound to the claim of the next unit of "postgresql".
+
+    juju import-filesystem kubernetes pv-data pgdata --application postgresql
+
 `
 
 	importFilesystemCommandAgs = `
@@ -84,12 +94,18 @@ the volume and filesystem contained within.
 // importFilesystemCommand imports filesystems into the model.
 type importFilesystemCommand struct {
 	StorageCommandBase
-	modelcmd.IAASOnlyCommand
 	newAPIFunc NewStorageImporterFunc
 
 	storagePool       string
 	storageProviderId string
 	storageName       string
+	application       string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *importFilesystemCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.application, "application", "", "Only attach the storage to units of this application")
 }
 
 // Init implements Command.Init.
@@ -112,6 +128,9 @@ func (c *importFilesystemCommand) Init(args []string) error {
 	if !validStorageName {
 		return errors.Errorf("%q is not a valid storage name", c.storageName)
 	}
+	if c.application != "" && !names.IsValidApplication(c.application) {
+		return errors.Errorf("%q is not a valid application name", c.application)
+	}
 	return nil
 }
 
@@ -141,7 +160,7 @@ func (c *importFilesystemCommand) Run(ctx *cmd.Context) (err error) {
 	)
 	storageTag, err := api.ImportStorage(
 		storage.StorageKindFilesystem,
-		c.storagePool, c.storageProviderId, c.storageName,
+		c.storagePool, c.storageProviderId, c.storageName, c.application,
 	)
 	if err != nil {
 		return err
@@ -156,7 +175,7 @@ type StorageImporter interface {
 
 	ImportStorage(
 		kind storage.StorageKind,
-		storagePool, storageProviderId, storageName string,
+		storagePool, storageProviderId, storageName, application string,
 	) (names.StorageTag, error)
 }
 
@@ -165,7 +184,7 @@ type apiStorageImporter struct {
 }
 
 func (a apiStorageImporter) ImportStorage(
-	kind storage.StorageKind, storagePool, storageProviderId, storageName string,
+	kind storage.StorageKind, storagePool, storageProviderId, storageName, application string,
 ) (names.StorageTag, error) {
-	return a.Import(kind, storagePool, storageProviderId, storageName)
+	return a.Import(kind, storagePool, storageProviderId, storageName, application)
 }
@@ -42,6 +42,9 @@ var initErrorTests = []struct {
 }, {
 	args:        []string{"foo", "abc123", "123"},
 	expectedErr: `"123" is not a valid storage name`,
+}, {
+	args:        []string{"foo", "abc123", "bar", "--application", "Baz!"},
+	expectedErr: `"Baz!" is not a valid application name`,
 }}
 
 func (s *ImportFilesystemSuite) TestInitErrors(c *gc.C) {
@@ -65,7 +68,20 @@ imported storage baz/0
 	s.importer.CheckCalls(c, []testing.StubCall{
 		{"ImportStorage", []interface{}{
 			jujustorage.StorageKindFilesystem,
-			"foo", "bar", "baz",
+			"foo", "bar", "baz", "",
+		}},
+		{"Close", nil},
+	})
+}
+
+func (s *ImportFilesystemSuite) TestImportForApplication(c *gc.C) {
+	_, err := s.run(c, "foo", "bar", "baz", "--application", "qux")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.importer.CheckCalls(c, []testing.StubCall{
+		{"ImportStorage", []interface{}{
+			jujustorage.StorageKindFilesystem,
+			"foo", "bar", "baz", "qux",
 		}},
 		{"Close", nil},
 	})
@@ -101,8 +117,8 @@ func (m *mockStorageImporter) Close() error {
 
 func (m *mockStorageImporter) ImportStorage(
 	k jujustorage.StorageKind,
-	pool, providerId, storageName string,
+	pool, providerId, storageName, application string,
 ) (names.StorageTag, error) {
-	m.MethodCall(m, "ImportStorage", k, pool, providerId, storageName)
+	m.MethodCall(m, "ImportStorage", k, pool, providerId, storageName, application)
 	return names.NewStorageTag(storageName + "/0"), m.NextErr()
 }
@@ -68,6 +68,21 @@ transcode/1   shared-fs/0   filesystem  1.0 GiB  attached
 `[1:])
 }
 
+func (s *ListSuite) TestListUsage(c *gc.C) {
+	s.mockAPI.withUsage = true
+	s.assertValidList(
+		c,
+		nil,
+		`
+Unit          Storage ID    Type        Pool      Size     Used  Inodes  Status    Message
+              persistent/1  filesystem                                   detached  
+postgresql/0  db-dir/1100   block                 3.0 MiB  33%           attached  
+transcode/0   db-dir/1000   block                                        pending   creating volume
+transcode/0   shared-fs/0   filesystem  radiance  1.0 GiB  95%   1%      attached  95% of space used, at or above the 90% warning threshold
+transcode/1   shared-fs/0   filesystem  radiance  1.0 GiB  95%   1%      attached  95% of space used, at or above the 90% warning threshold
+`[1:])
+}
+
 func (s *ListSuite) TestListYAML(c *gc.C) {
 	now := time.Now()
 	s.mockAPI.time = now
@@ -324,6 +339,7 @@ type mockListAPI struct {
 	listFilesystems func([]string) ([]params.FilesystemDetailsListResult, error)
 	listVolumes     func([]string) ([]params.VolumeDetailsListResult, error)
 	omitPool        bool
+	withUsage       bool
 	time            time.Time
 }
 
@@ -398,6 +414,19 @@ func (s *mockListAPI) ListStorageDetails() ([]params.StorageDetails, error) {
 		},
 		Persistent: true,
 	}}
+	if s.withUsage {
+		results[2].Usage = &params.StorageUsage{
+			BytesUsed:   1020054733,
+			BytesTotal:  1073741824,
+			InodesUsed:  1000,
+			InodesTotal: 65536,
+			Warning:     "95% of space used, at or above the 90% warning threshold",
+		}
+		results[1].Usage = &params.StorageUsage{
+			BytesUsed:  1048576,
+			BytesTotal: 3145728,
+		}
+	}
 	return results, nil
 }
 
@@ -4,6 +4,7 @@
 package storage
 
 import (
+	"fmt"
 	"io"
 	"sort"
 	"strconv"
@@ -22,6 +23,13 @@ func formatStorageInstancesListTabular(writer io.Writer, s CombinedStorage) erro
 
 	storagePool, storageSize := getStoragePoolAndSize(s)
 	units, byUnit := sortStorageInstancesByUnitId(s)
+	hasUsage := false
+	for _, info := range s.StorageInstances {
+		if info.Usage != nil {
+			hasUsage = true
+			break
+		}
+	}
 
 	w.Print("Unit", "Storage ID", "Type")
 	if len(storagePool) > 0 {
@@ -30,7 +38,13 @@ func formatStorageInstancesListTabular(writer io.Writer, s CombinedStorage) erro
 		// We omit the column in that case.
 		w.Print("Pool")
 	}
-	w.Println("Size", "Status", "Message")
+	w.Print("Size")
+	if hasUsage {
+		// Usage is only shown once a unit
+		// has reported it.
+		w.Print("Used", "Inodes")
+	}
+	w.Println("Status", "Message")
 
 	for _, unit := range units {
 		// Then sort by storage IDs
@@ -50,8 +64,24 @@ func formatStorageInstancesListTabular(writer io.Writer, s CombinedStorage) erro
 				w.Print(storagePool[info.storageId])
 			}
 			w.Print(humanizeStorageSize(storageSize[storageId]))
+			message := info.status.Message
+			if hasUsage {
+				if info.usage != nil {
+					w.Print(
+						usagePercent(info.usage.BytesUsed, info.usage.BytesTotal),
+						usagePercent(info.usage.InodesUsed, info.usage.InodesTotal),
+					)
+					if info.usage.Warning != "" && message != "" {
+						message += "; " + info.usage.Warning
+					} else if info.usage.Warning != "" {
+						message = info.usage.Warning
+					}
+				} else {
+					w.Print("", "")
+				}
+			}
 			w.PrintStatus(info.status.Current)
-			w.Println(info.status.Message)
+			w.Println(message)
 		}
 	}
 	return tw.Flush()
@@ -70,6 +100,7 @@ func sortStorageInstancesByUnitId(s CombinedStorage) ([]string, map[string]map[s
 				storageId: storageId,
 				kind:      storageInfo.Kind,
 				status:    storageInfo.Status,
+				usage:     storageInfo.Usage,
 			}
 			continue
 		}
@@ -84,6 +115,7 @@ func sortStorageInstancesByUnitId(s CombinedStorage) ([]string, map[string]map[s
 				unitId:    unitId,
 				kind:      storageInfo.Kind,
 				status:    storageInfo.Status,
+				usage:     storageInfo.Usage,
 			}
 		}
 	}
@@ -184,11 +216,21 @@ func humanizeStorageSize(size uint64) string {
 	return sizeStr
 }
 
+// usagePercent returns the percentage of the total used, or an empty
+// string if the total is not known.
+func usagePercent(used, total uint64) string {
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d%%", used*100/total)
+}
+
 type storageAttachmentInfo struct {
 	storageId string
 	unitId    string
 	kind      string
 	status    EntityStatus
+	usage     *StorageUsage
 }
 
 // slashSeparatedIds represents a list of slash separated ids.
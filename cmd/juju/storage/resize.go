@@ -0,0 +1,120 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/utils/v3"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/rpc/params"
+)
+
+// NewResizeCommand returns a command used to resize storage.
+func NewResizeCommand() cmd.Command {
+	cmd := &resizeCommand{}
+	cmd.newAPIFunc = func() (StorageResizeAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	resizeCommandDoc = `
+Grows the volume or filesystem backing a storage instance, while it
+remains attached. Specify the storage ID, as output by "juju storage",
+and the new size. The size is a number with an optional multiplier
+suffix (M, G, T, P, E, Z or Y); if no suffix is given, M is implied.
+Storage may only be grown, not shrunk.
+
+The storage is resized asynchronously by the storage provider. Once it
+has been resized, the "<storage>-storage-resized" hook is run on the unit
+the storage is attached to, so that the charm can grow any filesystem it
+manages on the storage.
+
+Only some storage providers support resizing storage.
+`
+
+	resizeCommandExamples = `
+Grow the pgdata/0 storage to 100GiB:
+
+    juju resize-storage pgdata/0 100G
+`
+
+	resizeCommandArgs = `<storage> <size>`
+)
+
+// resizeCommand requests that a storage instance be grown.
+type resizeCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageResizeAPI, error)
+	storageId  string
+	size       uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotatef(err, "parsing size %q", args[1])
+	}
+	if size == 0 {
+		return errors.NotValidf("size %q", args[1])
+	}
+	c.storageId = args[0]
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "resize-storage",
+		Purpose:  "Grows storage.",
+		Doc:      resizeCommandDoc,
+		Args:     resizeCommandArgs,
+		Examples: resizeCommandExamples,
+		SeeAlso: []string{
+			"storage",
+			"show-storage",
+		},
+	})
+}
+
+// Run implements Command.Run.
+func (c *resizeCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	err = api.ResizeStorage(names.NewStorageTag(c.storageId), c.size)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return err
+	}
+	ctx.Infof("resizing %s to %s", c.storageId, humanize.IBytes(c.size*humanize.MiByte))
+	return nil
+}
+
+// StorageResizeAPI defines the API methods that the resize-storage
+// command uses.
+type StorageResizeAPI interface {
+	Close() error
+	ResizeStorage(names.StorageTag, uint64) error
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type resizeSuite struct {
+	SubStorageSuite
+	mockAPI *mockResizeAPI
+}
+
+var _ = gc.Suite(&resizeSuite{})
+
+func (s *resizeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockResizeAPI{}
+}
+
+func (s *resizeSuite) runResize(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewResizeCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *resizeSuite) TestResizeArgs(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"pgdata/0"},
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"foo", "10G"},
+		err:  `storage ID "foo" not valid`,
+	}, {
+		args: []string{"pgdata/0", "big"},
+		err:  `parsing size "big": .*`,
+	}, {
+		args: []string{"pgdata/0", "0"},
+		err:  `size "0" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runResize(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *resizeSuite) TestResize(c *gc.C) {
+	s.mockAPI.resizeStorageFunc = func(tag names.StorageTag, size uint64) error {
+		c.Assert(tag, gc.Equals, names.NewStorageTag("pgdata/0"))
+		c.Assert(size, gc.Equals, uint64(10240))
+		return nil
+	}
+	ctx, err := s.runResize(c, "pgdata/0", "10G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resizing pgdata/0 to 10 GiB\n")
+}
+
+func (s *resizeSuite) TestResizeError(c *gc.C) {
+	s.mockAPI.resizeStorageFunc = func(names.StorageTag, uint64) error {
+		return errors.NotSupportedf("resizing storage on this controller")
+	}
+	_, err := s.runResize(c, "pgdata/0", "1G")
+	c.Assert(err, gc.ErrorMatches, "resizing storage on this controller not supported")
+}
+
+type mockResizeAPI struct {
+	resizeStorageFunc func(names.StorageTag, uint64) error
+}
+
+func (*mockResizeAPI) Close() error {
+	return nil
+}
+
+func (m *mockResizeAPI) ResizeStorage(tag names.StorageTag, size uint64) error {
+	return m.resizeStorageFunc(tag, size)
+}
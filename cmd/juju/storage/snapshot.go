@@ -0,0 +1,396 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+// NewSnapshotCommand returns a command used to snapshot storage.
+func NewSnapshotCommand() cmd.Command {
+	cmd := &snapshotCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	snapshotCommandDoc = `
+Takes point-in-time snapshots of storage. Specify one or more storage IDs,
+as output by "juju storage". The snapshots are taken asynchronously by the
+storage provider; use "juju storage-snapshots" to see when they complete.
+
+New storage may be created from a snapshot with 'juju add-storage
+--from-snapshot'. Snapshots of storage that is local to a machine may only
+be used by units on that machine.
+
+Only some storage providers support snapshots.
+`
+
+	snapshotCommandExamples = `
+Take a snapshot of the pgdata/0 storage before refreshing the charm:
+
+    juju snapshot-storage pgdata/0
+`
+
+	snapshotCommandArgs = `<storage> [<storage> ...]`
+)
+
+// snapshotCommand requests snapshots of storage instances.
+type snapshotCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (StorageSnapshotAPI, error)
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *snapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("snapshot-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "snapshot-storage",
+		Purpose:  "Takes snapshots of storage.",
+		Doc:      snapshotCommandDoc,
+		Args:     snapshotCommandArgs,
+		Examples: snapshotCommandExamples,
+		SeeAlso: []string{
+			"storage",
+			"storage-snapshots",
+			"remove-storage-snapshot",
+			"add-storage",
+		},
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	tags := make([]names.StorageTag, len(c.storageIds))
+	for i, id := range c.storageIds {
+		tags[i] = names.NewStorageTag(id)
+	}
+	results, err := api.CreateSnapshots(tags)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "snapshot storage")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("snapshot %s of %s requested", result.Result.Id, c.storageIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotAPI defines the API methods that the snapshot-storage
+// command uses.
+type StorageSnapshotAPI interface {
+	Close() error
+	CreateSnapshots([]names.StorageTag) ([]params.StorageSnapshotDetailsResult, error)
+}
+
+const (
+	snapshotRemoveCommandDoc = `
+Removes storage snapshots. Specify one or more snapshot IDs, as output by
+"juju storage-snapshots". The snapshots are deleted asynchronously by the
+storage provider, and are listed as "deleting" until they are gone.
+
+Snapshots are also removed when the storage they were taken of is removed.
+`
+
+	snapshotRemoveCommandExamples = `
+    juju remove-storage-snapshot 0/3 4
+`
+
+	snapshotRemoveCommandArgs = `<snapshot> [<snapshot> ...]`
+)
+
+// NewSnapshotRemoveCommand returns a command used to remove storage
+// snapshots.
+func NewSnapshotRemoveCommand() cmd.Command {
+	cmd := &snapshotRemoveCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotRemoveAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// snapshotRemoveCommand removes storage snapshots.
+type snapshotRemoveCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc  func() (StorageSnapshotRemoveAPI, error)
+	snapshotIds []string
+}
+
+// Init implements Command.Init.
+func (c *snapshotRemoveCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("remove-storage-snapshot requires at least one snapshot ID")
+	}
+	c.snapshotIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotRemoveCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "remove-storage-snapshot",
+		Purpose:  "Removes storage snapshots.",
+		Doc:      snapshotRemoveCommandDoc,
+		Args:     snapshotRemoveCommandArgs,
+		Examples: snapshotRemoveCommandExamples,
+		SeeAlso: []string{
+			"snapshot-storage",
+			"storage-snapshots",
+		},
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotRemoveCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.DestroySnapshots(c.snapshotIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "remove storage snapshots")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to remove snapshot %s: %s", c.snapshotIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("removing snapshot %s", c.snapshotIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotRemoveAPI defines the API methods that the
+// remove-storage-snapshot command uses.
+type StorageSnapshotRemoveAPI interface {
+	Close() error
+	DestroySnapshots([]string) ([]params.ErrorResult, error)
+}
+
+const (
+	snapshotListCommandDoc = `
+Lists the snapshots of storage in the model, as taken with
+"juju snapshot-storage".
+`
+
+	snapshotListCommandExamples = `
+    juju storage-snapshots
+    juju storage-snapshots --format yaml
+`
+)
+
+// NewSnapshotListCommand returns a command used to list storage snapshots.
+func NewSnapshotListCommand() cmd.Command {
+	cmd := &snapshotListCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotListAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// snapshotListCommand lists storage snapshots.
+type snapshotListCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (StorageSnapshotListAPI, error)
+	out        cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *snapshotListCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "storage-snapshots",
+		Purpose:  "Lists storage snapshots.",
+		Doc:      snapshotListCommandDoc,
+		Aliases:  []string{"list-storage-snapshots"},
+		Examples: snapshotListCommandExamples,
+		SeeAlso: []string{
+			"snapshot-storage",
+			"remove-storage-snapshot",
+			"add-storage",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *snapshotListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotListCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	snapshots, err := api.ListSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No storage snapshots to display.")
+		return nil
+	}
+	return c.out.Write(ctx, formatSnapshotInfo(snapshots))
+}
+
+// StorageSnapshotListAPI defines the API methods that the
+// storage-snapshots command uses.
+type StorageSnapshotListAPI interface {
+	Close() error
+	ListSnapshots() ([]params.StorageSnapshotDetails, error)
+}
+
+// SnapshotInfo defines the serialization behaviour of storage snapshot
+// information.
+type SnapshotInfo struct {
+	Storage    string    `yaml:"storage" json:"storage"`
+	Kind       string    `yaml:"kind" json:"kind"`
+	Volume     string    `yaml:"volume,omitempty" json:"volume,omitempty"`
+	Filesystem string    `yaml:"filesystem,omitempty" json:"filesystem,omitempty"`
+	Machine    string    `yaml:"machine,omitempty" json:"machine,omitempty"`
+	Pool       string    `yaml:"pool" json:"pool"`
+	Size       uint64    `yaml:"size" json:"size"`
+	Created    time.Time `yaml:"created" json:"created"`
+	Status     string    `yaml:"status" json:"status"`
+	Message    string    `yaml:"message,omitempty" json:"message,omitempty"`
+	ProviderId string    `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+}
+
+// formatSnapshotInfo returns a mapping from snapshot ID to snapshot
+// details.
+func formatSnapshotInfo(all []params.StorageSnapshotDetails) map[string]SnapshotInfo {
+	output := make(map[string]SnapshotInfo)
+	for _, one := range all {
+		info := SnapshotInfo{
+			Kind:       one.Kind.String(),
+			Pool:       one.Pool,
+			Size:       one.Size,
+			Created:    one.Created,
+			Message:    one.Message,
+			ProviderId: one.SnapshotId,
+		}
+		if tag, err := names.ParseStorageTag(one.StorageTag); err == nil {
+			info.Storage = tag.Id()
+		}
+		if tag, err := names.ParseVolumeTag(one.VolumeTag); err == nil {
+			info.Volume = tag.Id()
+		}
+		if tag, err := names.ParseFilesystemTag(one.FilesystemTag); err == nil {
+			info.Filesystem = tag.Id()
+		}
+		if tag, err := names.ParseMachineTag(one.MachineTag); err == nil {
+			info.Machine = tag.Id()
+		}
+		switch {
+		case life.IsNotAlive(one.Life):
+			info.Status = "deleting"
+		case one.SnapshotId != "":
+			info.Status = "taken"
+		case one.Message != "":
+			info.Status = "failed"
+		default:
+			info.Status = "pending"
+		}
+		output[one.Id] = info
+	}
+	return output
+}
+
+// formatSnapshotListTabular writes a tabular summary of storage
+// snapshots.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("Snapshot", "Storage", "Kind", "Pool", "Size", "Created", "Status", "Message")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		snapshot := snapshots[id]
+		size := humanize.IBytes(snapshot.Size * humanize.MiByte)
+		created := snapshot.Created.UTC().Format(time.RFC3339)
+		print(id, snapshot.Storage, snapshot.Kind, snapshot.Pool, size, created, snapshot.Status, snapshot.Message)
+	}
+	return tw.Flush()
+}
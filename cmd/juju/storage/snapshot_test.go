@@ -0,0 +1,271 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+type snapshotSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotAPI{}
+}
+
+func (s *snapshotSuite) runSnapshot(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewSnapshotCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *snapshotSuite) TestSnapshotNoArgs(c *gc.C) {
+	_, err := s.runSnapshot(c)
+	c.Assert(err, gc.ErrorMatches, "snapshot-storage requires at least one storage ID")
+}
+
+func (s *snapshotSuite) TestSnapshotInvalidStorage(c *gc.C) {
+	_, err := s.runSnapshot(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *snapshotSuite) TestSnapshot(c *gc.C) {
+	s.mockAPI.createSnapshotsFunc = func(tags []names.StorageTag) ([]params.StorageSnapshotDetailsResult, error) {
+		c.Assert(tags, jc.DeepEquals, []names.StorageTag{
+			names.NewStorageTag("pgdata/0"),
+			names.NewStorageTag("pgdata/1"),
+		})
+		return []params.StorageSnapshotDetailsResult{
+			{Result: &params.StorageSnapshotDetails{Id: "0/3"}},
+			{Result: &params.StorageSnapshotDetails{Id: "4"}},
+		}, nil
+	}
+	ctx, err := s.runSnapshot(c, "pgdata/0", "pgdata/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+snapshot 0/3 of pgdata/0 requested
+snapshot 4 of pgdata/1 requested
+`[1:])
+}
+
+func (s *snapshotSuite) TestSnapshotFailure(c *gc.C) {
+	s.mockAPI.createSnapshotsFunc = func(tags []names.StorageTag) ([]params.StorageSnapshotDetailsResult, error) {
+		return []params.StorageSnapshotDetailsResult{
+			{Result: &params.StorageSnapshotDetails{Id: "0/3"}},
+			{Error: apiservererrors.ServerError(errors.NotSupportedf("snapshots of storage scoped to a unit"))},
+		}, nil
+	}
+	ctx, err := s.runSnapshot(c, "pgdata/0", "pgdata/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+snapshot 0/3 of pgdata/0 requested
+failed to snapshot pgdata/1: snapshots of storage scoped to a unit not supported
+`[1:])
+}
+
+func (s *snapshotSuite) TestSnapshotError(c *gc.C) {
+	s.mockAPI.createSnapshotsFunc = func(tags []names.StorageTag) ([]params.StorageSnapshotDetailsResult, error) {
+		return nil, errors.NotSupportedf("storage snapshots on this controller")
+	}
+	_, err := s.runSnapshot(c, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "storage snapshots on this controller not supported")
+}
+
+type snapshotRemoveSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&snapshotRemoveSuite{})
+
+func (s *snapshotRemoveSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotAPI{}
+}
+
+func (s *snapshotRemoveSuite) runRemove(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewSnapshotRemoveCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *snapshotRemoveSuite) TestRemoveNoArgs(c *gc.C) {
+	_, err := s.runRemove(c)
+	c.Assert(err, gc.ErrorMatches, "remove-storage-snapshot requires at least one snapshot ID")
+}
+
+func (s *snapshotRemoveSuite) TestRemove(c *gc.C) {
+	s.mockAPI.destroySnapshotsFunc = func(ids []string) ([]params.ErrorResult, error) {
+		c.Assert(ids, jc.DeepEquals, []string{"0/3", "4"})
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+	ctx, err := s.runRemove(c, "0/3", "4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing snapshot 0/3
+removing snapshot 4
+`[1:])
+}
+
+func (s *snapshotRemoveSuite) TestRemoveFailure(c *gc.C) {
+	s.mockAPI.destroySnapshotsFunc = func(ids []string) ([]params.ErrorResult, error) {
+		return []params.ErrorResult{
+			{},
+			{Error: apiservererrors.ServerError(errors.NotFoundf("storage snapshot %q", "4"))},
+		}, nil
+	}
+	ctx, err := s.runRemove(c, "0/3", "4")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing snapshot 0/3
+failed to remove snapshot 4: storage snapshot "4" not found
+`[1:])
+}
+
+type snapshotListSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&snapshotListSuite{})
+
+func (s *snapshotListSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	created := time.Date(2023, 5, 4, 3, 2, 1, 0, time.UTC)
+	s.mockAPI = &mockSnapshotAPI{
+		listSnapshotsFunc: func() ([]params.StorageSnapshotDetails, error) {
+			return []params.StorageSnapshotDetails{{
+				Id:         "0/3",
+				StorageTag: "storage-pgdata-0",
+				Kind:       params.StorageKindBlock,
+				VolumeTag:  "volume-0-1",
+				MachineTag: "machine-0",
+				Pool:       "loop",
+				Size:       1024,
+				Created:    created,
+				SnapshotId: "snapshot-0-1",
+				Life:       life.Alive,
+			}, {
+				Id:         "5",
+				StorageTag: "storage-pgdata-0",
+				Kind:       params.StorageKindBlock,
+				VolumeTag:  "volume-0-1",
+				MachineTag: "machine-0",
+				Pool:       "loop",
+				Size:       1024,
+				Created:    created,
+				SnapshotId: "snapshot-0-5",
+				Life:       life.Dying,
+			}, {
+				Id:            "4",
+				StorageTag:    "storage-pgdata-1",
+				Kind:          params.StorageKindFilesystem,
+				FilesystemTag: "filesystem-2",
+				Pool:          "lxd",
+				Size:          2048,
+				Created:       created,
+				Message:       "no space left",
+				Life:          life.Alive,
+			}}, nil
+		},
+	}
+}
+
+func (s *snapshotListSuite) runList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewSnapshotListCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *snapshotListSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.runList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Snapshot  Storage   Kind        Pool  Size     Created               Status    Message
+0/3       pgdata/0  block       loop  1.0 GiB  2023-05-04T03:02:01Z  taken     
+4         pgdata/1  filesystem  lxd   2.0 GiB  2023-05-04T03:02:01Z  failed    no space left
+5         pgdata/0  block       loop  1.0 GiB  2023-05-04T03:02:01Z  deleting  
+`[1:])
+}
+
+func (s *snapshotListSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.runList(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+0/3:
+  storage: pgdata/0
+  kind: block
+  volume: 0/1
+  machine: "0"
+  pool: loop
+  size: 1024
+  created: 2023-05-04T03:02:01Z
+  status: taken
+  provider-id: snapshot-0-1
+"4":
+  storage: pgdata/1
+  kind: filesystem
+  filesystem: "2"
+  pool: lxd
+  size: 2048
+  created: 2023-05-04T03:02:01Z
+  status: failed
+  message: no space left
+"5":
+  storage: pgdata/0
+  kind: block
+  volume: 0/1
+  machine: "0"
+  pool: loop
+  size: 1024
+  created: 2023-05-04T03:02:01Z
+  status: deleting
+  provider-id: snapshot-0-5
+`[1:])
+}
+
+func (s *snapshotListSuite) TestListEmpty(c *gc.C) {
+	s.mockAPI.listSnapshotsFunc = func() ([]params.StorageSnapshotDetails, error) {
+		return nil, nil
+	}
+	ctx, err := s.runList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No storage snapshots to display.\n")
+}
+
+type mockSnapshotAPI struct {
+	createSnapshotsFunc  func([]names.StorageTag) ([]params.StorageSnapshotDetailsResult, error)
+	destroySnapshotsFunc func([]string) ([]params.ErrorResult, error)
+	listSnapshotsFunc    func() ([]params.StorageSnapshotDetails, error)
+}
+
+func (*mockSnapshotAPI) Close() error {
+	return nil
+}
+
+func (m *mockSnapshotAPI) CreateSnapshots(tags []names.StorageTag) ([]params.StorageSnapshotDetailsResult, error) {
+	return m.createSnapshotsFunc(tags)
+}
+
+func (m *mockSnapshotAPI) DestroySnapshots(ids []string) ([]params.ErrorResult, error) {
+	return m.destroySnapshotsFunc(ids)
+}
+
+func (m *mockSnapshotAPI) ListSnapshots() ([]params.StorageSnapshotDetails, error) {
+	return m.listSnapshotsFunc()
+}
@@ -36,6 +36,21 @@ type StorageInfo struct {
 	Status      EntityStatus        `yaml:"status" json:"status"`
 	Persistent  bool                `yaml:"persistent" json:"persistent"`
 	Attachments *StorageAttachments `yaml:"attachments,omitempty" json:"attachments,omitempty"`
+	Usage       *StorageUsage       `yaml:"usage,omitempty" json:"usage,omitempty"`
+}
+
+// StorageUsage contains the space and inodes used by a storage instance,
+// as last reported by a unit it is attached to.
+type StorageUsage struct {
+	BytesUsed   uint64 `yaml:"bytes-used" json:"bytes-used"`
+	BytesTotal  uint64 `yaml:"bytes-total" json:"bytes-total"`
+	InodesUsed  uint64 `yaml:"inodes-used" json:"inodes-used"`
+	InodesTotal uint64 `yaml:"inodes-total" json:"inodes-total"`
+	Updated     string `yaml:"updated,omitempty" json:"updated,omitempty"`
+
+	// Warning is set when the usage is at or above the model's
+	// storage-usage-warning-threshold.
+	Warning string `yaml:"warning,omitempty" json:"warning,omitempty"`
 }
 
 // StorageAttachments contains details about all attachments to a storage
@@ -121,5 +136,18 @@ func createStorageInfo(details params.StorageDetails) (names.StorageTag, Storage
 		info.Attachments = &StorageAttachments{unitStorageAttachments}
 	}
 
+	if usage := details.Usage; usage != nil {
+		info.Usage = &StorageUsage{
+			BytesUsed:   usage.BytesUsed,
+			BytesTotal:  usage.BytesTotal,
+			InodesUsed:  usage.InodesUsed,
+			InodesTotal: usage.InodesTotal,
+			Warning:     usage.Warning,
+		}
+		if !usage.Updated.IsZero() {
+			info.Usage.Updated = common.FormatTime(&usage.Updated, false)
+		}
+	}
+
 	return storageTag, info, nil
 }
@@ -119,6 +119,46 @@ func NewListCommandForTest(api UserInfoAPI, modelAPI modelUsersAPI, store jujucl
 	return modelcmd.WrapController(c)
 }
 
+// NewAddGroupCommandForTest returns an add-group command with the api
+// provided as specified.
+func NewAddGroupCommandForTest(api GroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addGroupCommand{groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveGroupCommandForTest returns a remove-group command with the api
+// provided as specified.
+func NewRemoveGroupCommandForTest(api GroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeGroupCommand{groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewAddToGroupCommandForTest returns an add-to-group command with the api
+// provided as specified.
+func NewAddToGroupCommandForTest(api GroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addToGroupCommand{groupMembersCommandBase{groupCommandBase: groupCommandBase{api: api}}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveFromGroupCommandForTest returns a remove-from-group command with
+// the api provided as specified.
+func NewRemoveFromGroupCommandForTest(api GroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeFromGroupCommand{groupMembersCommandBase{groupCommandBase: groupCommandBase{api: api}}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewListGroupsCommandForTest returns a groups command with the api
+// provided as specified.
+func NewListGroupsCommandForTest(api ListGroupsAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &listGroupsCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewWhoAmICommandForTest returns a whoAMI command with a mock store.
 func NewWhoAmICommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c := &whoAmICommand{store: store}
@@ -0,0 +1,296 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageAddGroupSummary = `
+Adds a user group to a controller.`[1:]
+
+var usageAddGroupDetails = `
+A user group is a named set of users. Access to models, application
+offers, clouds and the controller may be granted to a group, using the
+--group option of the grant commands; each member of the group then has
+that access in addition to any access granted to them directly.
+
+Group names follow the same rules as local user names.
+
+`[1:]
+
+const usageAddGroupExamples = `
+    juju add-group engineers
+`
+
+var usageRemoveGroupSummary = `
+Removes a user group from a controller.`[1:]
+
+var usageRemoveGroupDetails = `
+Removing a group revokes all of the access granted to it. The members of
+the group are not affected, other than losing the access they had through
+the group.
+
+`[1:]
+
+const usageRemoveGroupExamples = `
+    juju remove-group engineers
+`
+
+var usageAddToGroupSummary = `
+Adds users to a user group.`[1:]
+
+var usageAddToGroupDetails = `
+Local users must already exist. External users, such as those
+authenticated by an identity provider, may be added before they first
+log in.
+
+`[1:]
+
+const usageAddToGroupExamples = `
+    juju add-to-group engineers bob
+    juju add-to-group engineers mary@external jim
+`
+
+var usageRemoveFromGroupSummary = `
+Removes users from a user group.`[1:]
+
+var usageRemoveFromGroupDetails = `
+The users lose any access they had through membership of the group.
+
+`[1:]
+
+const usageRemoveFromGroupExamples = `
+    juju remove-from-group engineers bob
+`
+
+// GroupAPI defines the usermanager API methods that the group commands
+// use.
+type GroupAPI interface {
+	AddGroup(name string) error
+	RemoveGroup(name string) error
+	AddGroupMembers(group string, usernames ...string) error
+	RemoveGroupMembers(group string, usernames ...string) error
+	Close() error
+}
+
+// groupCommandBase is the common code for the commands that change user
+// groups.
+type groupCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api   GroupAPI
+	Group string
+}
+
+func (c *groupCommandBase) getGroupAPI() (GroupAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewUserManagerAPIClient()
+}
+
+// NewAddGroupCommand returns a command to add a user group.
+func NewAddGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addGroupCommand{})
+}
+
+// addGroupCommand adds a user group to a controller.
+type addGroupCommand struct {
+	groupCommandBase
+}
+
+// Info implements Command.Info.
+func (c *addGroupCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "add-group",
+		Args:     "<group name>",
+		Purpose:  usageAddGroupSummary,
+		Doc:      usageAddGroupDetails,
+		Examples: usageAddGroupExamples,
+		SeeAlso: []string{
+			"groups",
+			"add-to-group",
+			"remove-group",
+			"grant",
+		},
+	})
+}
+
+// Init implements Command.Init.
+func (c *addGroupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	c.Group = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *addGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getGroupAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.AddGroup(c.Group); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Group %q added", c.Group)
+	return nil
+}
+
+// NewRemoveGroupCommand returns a command to remove a user group.
+func NewRemoveGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&removeGroupCommand{})
+}
+
+// removeGroupCommand removes a user group from a controller.
+type removeGroupCommand struct {
+	groupCommandBase
+}
+
+// Info implements Command.Info.
+func (c *removeGroupCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "remove-group",
+		Args:     "<group name>",
+		Purpose:  usageRemoveGroupSummary,
+		Doc:      usageRemoveGroupDetails,
+		Examples: usageRemoveGroupExamples,
+		SeeAlso: []string{
+			"groups",
+			"add-group",
+		},
+	})
+}
+
+// Init implements Command.Init.
+func (c *removeGroupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	c.Group = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *removeGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getGroupAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.RemoveGroup(c.Group); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Group %q removed", c.Group)
+	return nil
+}
+
+// groupMembersCommandBase is the common code for the commands that change
+// the members of a user group.
+type groupMembersCommandBase struct {
+	groupCommandBase
+	Users []string
+}
+
+// Init implements Command.Init.
+func (c *groupMembersCommandBase) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	if len(args) == 1 {
+		return errors.New("no users supplied")
+	}
+	c.Group = args[0]
+	c.Users = args[1:]
+	return nil
+}
+
+// NewAddToGroupCommand returns a command to add users to a user group.
+func NewAddToGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addToGroupCommand{})
+}
+
+// addToGroupCommand adds users to a user group.
+type addToGroupCommand struct {
+	groupMembersCommandBase
+}
+
+// Info implements Command.Info.
+func (c *addToGroupCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "add-to-group",
+		Args:     "<group name> <user name> ...",
+		Purpose:  usageAddToGroupSummary,
+		Doc:      usageAddToGroupDetails,
+		Examples: usageAddToGroupExamples,
+		SeeAlso: []string{
+			"groups",
+			"add-group",
+			"remove-from-group",
+		},
+	})
+}
+
+// Run implements Command.Run.
+func (c *addToGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getGroupAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.AddGroupMembers(c.Group, c.Users...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
+
+// NewRemoveFromGroupCommand returns a command to remove users from a user
+// group.
+func NewRemoveFromGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&removeFromGroupCommand{})
+}
+
+// removeFromGroupCommand removes users from a user group.
+type removeFromGroupCommand struct {
+	groupMembersCommandBase
+}
+
+// Info implements Command.Info.
+func (c *removeFromGroupCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "remove-from-group",
+		Args:     "<group name> <user name> ...",
+		Purpose:  usageRemoveFromGroupSummary,
+		Doc:      usageRemoveFromGroupDetails,
+		Examples: usageRemoveFromGroupExamples,
+		SeeAlso: []string{
+			"groups",
+			"add-to-group",
+		},
+	})
+}
+
+// Run implements Command.Run.
+func (c *removeFromGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getGroupAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.RemoveGroupMembers(c.Group, c.Users...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
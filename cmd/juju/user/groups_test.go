@@ -0,0 +1,177 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/v3/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/testing"
+)
+
+type GroupCommandsSuite struct {
+	BaseSuite
+	mock *mockGroupAPI
+}
+
+var _ = gc.Suite(&GroupCommandsSuite{})
+
+func (s *GroupCommandsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mock = &mockGroupAPI{}
+}
+
+func (s *GroupCommandsSuite) TestAddGroup(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, user.NewAddGroupCommandForTest(s.mock, s.store), "engineers")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.added, gc.Equals, "engineers")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Group \"engineers\" added\n")
+}
+
+func (s *GroupCommandsSuite) TestAddGroupInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, user.NewAddGroupCommandForTest(s.mock, s.store))
+	c.Assert(err, gc.ErrorMatches, "no group name supplied")
+	_, err = cmdtesting.RunCommand(c, user.NewAddGroupCommandForTest(s.mock, s.store), "engineers", "testers")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["testers"\]`)
+}
+
+func (s *GroupCommandsSuite) TestAddGroupBlocked(c *gc.C) {
+	s.mock.err = apiservererrors.OperationBlockedError("TestAddGroupBlocked")
+	_, err := cmdtesting.RunCommand(c, user.NewAddGroupCommandForTest(s.mock, s.store), "engineers")
+	testing.AssertOperationWasBlocked(c, err, ".*TestAddGroupBlocked.*")
+}
+
+func (s *GroupCommandsSuite) TestRemoveGroup(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, user.NewRemoveGroupCommandForTest(s.mock, s.store), "engineers")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.removed, gc.Equals, "engineers")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Group \"engineers\" removed\n")
+}
+
+func (s *GroupCommandsSuite) TestAddToGroup(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, user.NewAddToGroupCommandForTest(s.mock, s.store), "engineers", "bob", "mary@external")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.group, gc.Equals, "engineers")
+	c.Assert(s.mock.addedMembers, jc.DeepEquals, []string{"bob", "mary@external"})
+}
+
+func (s *GroupCommandsSuite) TestAddToGroupInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, user.NewAddToGroupCommandForTest(s.mock, s.store))
+	c.Assert(err, gc.ErrorMatches, "no group name supplied")
+	_, err = cmdtesting.RunCommand(c, user.NewAddToGroupCommandForTest(s.mock, s.store), "engineers")
+	c.Assert(err, gc.ErrorMatches, "no users supplied")
+}
+
+func (s *GroupCommandsSuite) TestRemoveFromGroup(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, user.NewRemoveFromGroupCommandForTest(s.mock, s.store), "engineers", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.group, gc.Equals, "engineers")
+	c.Assert(s.mock.removedMembers, jc.DeepEquals, []string{"bob"})
+}
+
+func (s *GroupCommandsSuite) TestListGroups(c *gc.C) {
+	clock := testclock.NewClock(time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC))
+	command := user.NewListGroupsCommandForTest(s.mock, s.store, clock)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Name       Members            Access\n"+
+		"engineers  bob,mary@external  write on model deadbeef-0bad-400d-8000-4b1d0d06f00d, add-model on cloud fluffy\n"+
+		"testers                       \n")
+}
+
+func (s *GroupCommandsSuite) TestListGroupsYAML(c *gc.C) {
+	clock := testclock.NewClock(time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC))
+	command := user.NewListGroupsCommandForTest(s.mock, s.store, clock)
+	ctx, err := cmdtesting.RunCommand(c, command, "engineers", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.listed, jc.DeepEquals, []string{"engineers"})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- name: engineers
+  members:
+  - bob
+  - mary@external
+  created-by: admin
+  date-created: "2023-05-01"
+  access:
+  - group: engineers
+    target: model deadbeef-0bad-400d-8000-4b1d0d06f00d
+    access: write
+  - group: engineers
+    target: cloud fluffy
+    access: add-model
+`[1:])
+}
+
+type mockGroupAPI struct {
+	added          string
+	removed        string
+	group          string
+	addedMembers   []string
+	removedMembers []string
+	listed         []string
+	err            error
+}
+
+func (*mockGroupAPI) Close() error {
+	return nil
+}
+
+func (m *mockGroupAPI) AddGroup(name string) error {
+	m.added = name
+	return m.err
+}
+
+func (m *mockGroupAPI) RemoveGroup(name string) error {
+	m.removed = name
+	return m.err
+}
+
+func (m *mockGroupAPI) AddGroupMembers(group string, usernames ...string) error {
+	m.group = group
+	m.addedMembers = usernames
+	return m.err
+}
+
+func (m *mockGroupAPI) RemoveGroupMembers(group string, usernames ...string) error {
+	m.group = group
+	m.removedMembers = usernames
+	return m.err
+}
+
+func (m *mockGroupAPI) Groups(groupNames ...string) ([]params.UserGroupInfo, error) {
+	m.listed = groupNames
+	created := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	engineers := params.UserGroupInfo{
+		Name:        "engineers",
+		Members:     []string{"bob", "mary@external"},
+		CreatedBy:   "admin",
+		DateCreated: created,
+		Access: []params.UserGroupAccess{{
+			Group:  "engineers",
+			Target: "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Access: "write",
+		}, {
+			Group:  "engineers",
+			Target: "cloud-fluffy",
+			Access: "add-model",
+		}},
+	}
+	if len(groupNames) > 0 {
+		return []params.UserGroupInfo{engineers}, m.err
+	}
+	testers := params.UserGroupInfo{
+		Name:        "testers",
+		CreatedBy:   "admin",
+		DateCreated: created,
+	}
+	return []params.UserGroupInfo{engineers, testers}, m.err
+}
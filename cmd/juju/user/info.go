@@ -24,6 +24,9 @@ Show information about a user.`[1:]
 var helpDetails = `
 By default, the YAML format is used and the user name is the current
 user.
+
+Any user groups the user is a member of are shown, along with the access
+granted through each group.
 `[1:]
 
 const helpExamples = `
@@ -74,6 +77,11 @@ type UserInfo struct {
 	DateCreated    string `yaml:"date-created,omitempty" json:"date-created,omitempty"`
 	LastConnection string `yaml:"last-connection,omitempty" json:"last-connection,omitempty"`
 	Disabled       bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+
+	// Groups and GroupAccess show the user groups the user is a member
+	// of, and the access granted through each of them.
+	Groups      []string      `yaml:"groups,omitempty" json:"groups,omitempty"`
+	GroupAccess []GroupAccess `yaml:"group-access,omitempty" json:"group-access,omitempty"`
 }
 
 // Info implements Command.Info.
@@ -149,6 +157,8 @@ func (c *infoCommandBase) apiUsersToUserInfoSlice(users []params.UserInfo) []Use
 			DisplayName: info.DisplayName,
 			Access:      info.Access,
 			Disabled:    info.Disabled,
+			Groups:      info.Groups,
+			GroupAccess: groupAccessFromParams(info.GroupAccess),
 		}
 		// TODO(wallyworld) record login information about external users.
 		if names.NewUserTag(info.Username).IsLocal() {
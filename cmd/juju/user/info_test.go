@@ -62,12 +62,37 @@ func (*fakeUserInfoAPI) UserInfo(usernames []string, all usermanager.IncludeDisa
 		info.Username = "fred@external"
 		info.DisplayName = "Fred External"
 		info.Access = "add-model"
+	case "grouped":
+		info.Username = "grouped"
+		info.Access = "login"
+		info.Groups = []string{"engineers"}
+		info.GroupAccess = []params.UserGroupAccess{{
+			Group:  "engineers",
+			Target: "cloud-fluffy",
+			Access: "add-model",
+		}}
 	default:
 		return nil, apiservererrors.ErrPerm
 	}
 	return []params.UserInfo{info}, nil
 }
 
+func (s *UserInfoCommandSuite) TestUserInfoWithGroups(c *gc.C) {
+	context, err := cmdtesting.RunCommand(c, s.NewShowUserCommand(), "grouped")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `user-name: grouped
+access: login
+date-created: "1981-02-27"
+last-connection: "2014-01-01"
+groups:
+- engineers
+group-access:
+- group: engineers
+  target: cloud fluffy
+  access: add-model
+`)
+}
+
 func (s *UserInfoCommandSuite) TestUserInfo(c *gc.C) {
 	context, err := cmdtesting.RunCommand(c, s.NewShowUserCommand())
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,184 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/rpc/params"
+)
+
+var usageListGroupsSummary = `
+Lists the user groups of a controller.`[1:]
+
+var usageListGroupsDetails = `
+Shows the members of each user group, and the access granted to it.
+`[1:]
+
+const usageListGroupsExamples = `
+    juju groups
+    juju groups engineers --format yaml
+`
+
+// ListGroupsAPI defines the usermanager API methods that the groups
+// command uses.
+type ListGroupsAPI interface {
+	Groups(groupNames ...string) ([]params.UserGroupInfo, error)
+	Close() error
+}
+
+// NewListGroupsCommand returns a command to list user groups.
+func NewListGroupsCommand() cmd.Command {
+	return modelcmd.WrapController(&listGroupsCommand{
+		clock: clock.WallClock,
+	})
+}
+
+// listGroupsCommand lists the user groups of a controller.
+type listGroupsCommand struct {
+	modelcmd.ControllerCommandBase
+	api       ListGroupsAPI
+	clock     clock.Clock
+	exactTime bool
+	out       cmd.Output
+
+	Groups []string
+}
+
+// GroupInfo defines the serialization behaviour of the user group
+// information.
+type GroupInfo struct {
+	Name        string        `yaml:"name" json:"name"`
+	Members     []string      `yaml:"members,omitempty" json:"members,omitempty"`
+	CreatedBy   string        `yaml:"created-by" json:"created-by"`
+	DateCreated string        `yaml:"date-created" json:"date-created"`
+	Access      []GroupAccess `yaml:"access,omitempty" json:"access,omitempty"`
+}
+
+// GroupAccess defines the serialization behaviour of the access granted
+// to a user group.
+type GroupAccess struct {
+	Group  string `yaml:"group" json:"group"`
+	Target string `yaml:"target" json:"target"`
+	Access string `yaml:"access" json:"access"`
+}
+
+// Info implements Command.Info.
+func (c *listGroupsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "groups",
+		Args:     "[<group name> ...]",
+		Purpose:  usageListGroupsSummary,
+		Doc:      usageListGroupsDetails,
+		Aliases:  []string{"list-groups"},
+		Examples: usageListGroupsExamples,
+		SeeAlso: []string{
+			"add-group",
+			"add-to-group",
+			"show-user",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listGroupsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.exactTime, "exact-time", false, "Use full timestamp for creation times")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatGroupsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listGroupsCommand) Init(args []string) error {
+	c.Groups = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listGroupsCommand) Run(ctx *cmd.Context) error {
+	api := c.api
+	if api == nil {
+		var err error
+		api, err = c.NewUserManagerAPIClient()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer api.Close()
+	}
+
+	result, err := api.Groups(c.Groups...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result) == 0 {
+		ctx.Infof("No groups to display.")
+		return nil
+	}
+
+	now := c.clock.Now()
+	groups := make([]GroupInfo, len(result))
+	for i, info := range result {
+		groups[i] = GroupInfo{
+			Name:      info.Name,
+			Members:   info.Members,
+			CreatedBy: info.CreatedBy,
+			Access:    groupAccessFromParams(info.Access),
+		}
+		if c.exactTime {
+			groups[i].DateCreated = info.DateCreated.String()
+		} else {
+			groups[i].DateCreated = common.UserFriendlyDuration(info.DateCreated, now)
+		}
+	}
+	return c.out.Write(ctx, groups)
+}
+
+func groupAccessFromParams(access []params.UserGroupAccess) []GroupAccess {
+	var result []GroupAccess
+	for _, a := range access {
+		target := a.Target
+		if tag, err := names.ParseTag(a.Target); err == nil {
+			target = names.ReadableString(tag)
+		}
+		result = append(result, GroupAccess{
+			Group:  a.Group,
+			Target: target,
+			Access: a.Access,
+		})
+	}
+	return result
+}
+
+func formatGroupsTabular(writer io.Writer, value interface{}) error {
+	groups, ok := value.([]GroupInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", groups, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Name", "Members", "Access")
+	for _, group := range groups {
+		access := make([]string, len(group.Access))
+		for i, a := range group.Access {
+			access[i] = fmt.Sprintf("%s on %s", a.Access, a.Target)
+		}
+		w.Println(group.Name, strings.Join(group.Members, ","), strings.Join(access, ", "))
+	}
+	return tw.Flush()
+}
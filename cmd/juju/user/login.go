@@ -56,6 +56,12 @@ except verify that fact.
 If the -u option is provided, the juju login command will attempt to log
 into the controller as that user.
 
+If the --oidc option is provided, the juju login command will log into
+the controller using the OpenID Connect issuer the controller has been
+configured to trust. A verification URL and user code are displayed, which
+are used to log in with the issuer in a web browser. The resulting session
+token is stored and used for subsequent commands.
+
 After login, a token ("macaroon") will become active. It has an expiration
 time of 24 hours. Upon expiration, no further Juju commands can be issued
 and the user will be prompted to log in again.
@@ -73,6 +79,7 @@ const loginExamples = `
     juju login somepubliccontroller
     juju login jimm.jujucharms.com
     juju login -u bob
+    juju login --oidc
 `
 
 // Functions defined as variables so they can be overridden in tests.
@@ -103,6 +110,7 @@ type loginCommand struct {
 	noPrompt         bool
 	noPromptPassword string
 	trust            bool
+	oidc             bool
 	pollster         *interact.Pollster
 
 	// controllerName holds the name of the current controller.
@@ -142,6 +150,7 @@ func (c *loginCommand) SetFlags(fset *gnuflag.FlagSet) {
 	fset.StringVar(&c.username, "user", "", "")
 	fset.BoolVar(&c.noPrompt, "no-prompt", false, "don't prompt for password just read a line from stdin")
 	fset.BoolVar(&c.trust, "trust", false, "automatically trust controller CA certificate")
+	fset.BoolVar(&c.oidc, "oidc", false, "log in using the controller's OpenID Connect issuer")
 }
 
 // Init implements Command.Init.
@@ -151,6 +160,9 @@ func (c *loginCommand) Init(args []string) error {
 		return errors.Trace(err)
 	}
 	c.domain = domain
+	if c.oidc && c.username != "" {
+		return errors.New("--user cannot be used with --oidc")
+	}
 	return nil
 }
 
@@ -501,6 +513,9 @@ Run "juju logout" first before attempting to log in as a different user.`,
 		return dial(accountDetails)
 	}
 
+	if c.oidc {
+		return c.sessionTokenLogin(accountDetails, safeDial)
+	}
 	if accountDetails != nil && accountDetails.Password != "" {
 		// We've been provided some account details that
 		// contain a password, so try that first.
@@ -559,6 +574,36 @@ Run "juju logout" first before attempting to log in as a different user.`,
 	return conn, accountDetails, errors.Trace(err)
 }
 
+// sessionTokenLogin logs in with a session token obtained from a device
+// login with the controller's OpenID Connect issuer. An existing session
+// token is reused if it is still valid.
+func (c *loginCommand) sessionTokenLogin(
+	accountDetails *jujuclient.AccountDetails,
+	dial func(*jujuclient.AccountDetails) (api.Connection, error),
+) (api.Connection, *jujuclient.AccountDetails, error) {
+	// The user is only known once the login completes; until then
+	// a placeholder is stored alongside the session token.
+	details := &jujuclient.AccountDetails{
+		Type: jujuclient.OAuth2DeviceFlowAccountDetailsType,
+		User: "user",
+	}
+	if accountDetails != nil && accountDetails.Type == jujuclient.OAuth2DeviceFlowAccountDetailsType {
+		details.User = accountDetails.User
+		details.SessionToken = accountDetails.SessionToken
+	}
+	conn, err := dial(details)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	user, ok := conn.AuthTag().(names.UserTag)
+	if !ok {
+		conn.Close()
+		return nil, nil, errors.Errorf("logged in as %v, not a user", conn.AuthTag())
+	}
+	details.User = user.Id()
+	return conn, details, nil
+}
+
 const badCred = "invalid entity name or password"
 
 const noModelsMessage = `
@@ -69,6 +69,9 @@ func (s *LoginCommandSuite) TestInitError(c *gc.C) {
 	}, {
 		args:   []string{"foobar", "extra"},
 		stderr: `ERROR unrecognized args: \["extra"\]\n`,
+	}, {
+		args:   []string{"--oidc", "-u", "bob"},
+		stderr: `ERROR --user cannot be used with --oidc\n`,
 	}} {
 		c.Logf("test %d", i)
 		stdout, stderr, code := runLogin(c, "", test.args...)
@@ -239,6 +242,26 @@ There are no models available(.|\n)*`[1:])
 	c.Assert(s.apiConnectionParams.AccountDetails, jc.DeepEquals, &jujuclient.AccountDetails{})
 }
 
+func (s *LoginCommandSuite) TestLoginWithOIDC(c *gc.C) {
+	err := s.store.RemoveAccount("testing")
+	c.Assert(err, jc.ErrorIsNil)
+	stdout, stderr, code := runLogin(c, "", "--oidc")
+	c.Check(stderr, gc.Matches, `
+Welcome, user@external. You are now logged into "testing".
+
+There are no models available(.|\n)*`[1:])
+	c.Check(stdout, gc.Equals, ``)
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(s.apiConnectionParams.AccountDetails, jc.DeepEquals, &jujuclient.AccountDetails{
+		Type: jujuclient.OAuth2DeviceFlowAccountDetailsType,
+		User: "user",
+	})
+	details, err := s.store.AccountDetails("testing")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.Type, gc.Equals, jujuclient.OAuth2DeviceFlowAccountDetailsType)
+	c.Assert(details.User, gc.Equals, "user@external")
+}
+
 func (s *LoginCommandSuite) TestLoginWithMacaroonsNotSupported(c *gc.C) {
 	err := s.store.RemoveAccount("testing")
 	c.Assert(err, jc.ErrorIsNil)
@@ -63,6 +63,11 @@ Waits for all the application units to start with ubuntu and to be created
 and available.
 
     juju wait-for application ubuntu --query='forEach(units, unit => unit.life=="alive" && unit.status=="available" && startsWith(unit.name, "ubuntu"))'
+
+Waits for at least 3 units to be active, and for the application status to
+have been active for 5 minutes.
+
+    juju wait-for application ubuntu --query='count(units, unit => unit.workload-status=="active") >= 3 && status=="active" && since(status-since) > 5m'
 `
 
 // applicationCommand defines a command for waiting for applications.
@@ -91,6 +96,8 @@ func (c *applicationCommand) Info() *cmd.Info {
 			"wait-for model",
 			"wait-for machine",
 			"wait-for unit",
+			"wait-for relation",
+			"wait-for offer",
 		},
 	})
 }
@@ -254,7 +261,7 @@ func MakeApplicationScope(ctx ScopeContext,
 // GetIdents returns the identifiers with in a given scope.
 func (m ApplicationScope) GetIdents() []string {
 	idents := set.NewStrings(getIdents(m.ApplicationInfo)...)
-	return set.NewStrings("units", "machines").Union(idents).SortedValues()
+	return set.NewStrings("units", "machines", "status-since").Union(idents).SortedValues()
 }
 
 // GetIdentValue returns the value of the identifier in a given scope.
@@ -276,6 +283,8 @@ func (m ApplicationScope) GetIdentValue(name string) (query.Box, error) {
 		return query.NewBool(m.ApplicationInfo.Subordinate), nil
 	case "status":
 		return query.NewString(string(m.ApplicationInfo.Status.Current)), nil
+	case "status-since":
+		return query.NewString(formatSince(m.ApplicationInfo.Status.Since)), nil
 	case "workload-version":
 		return query.NewString(m.ApplicationInfo.WorkloadVersion), nil
 	case "units":
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd/v3"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
@@ -194,7 +195,8 @@ func MakeMachineScope(ctx ScopeContext, info *params.MachineInfo) MachineScope {
 
 // GetIdents returns the identifiers with in a given scope.
 func (m MachineScope) GetIdents() []string {
-	return getIdents(m.MachineInfo)
+	idents := set.NewStrings(getIdents(m.MachineInfo)...)
+	return set.NewStrings("status-since", "instance-status-since").Union(idents).SortedValues()
 }
 
 // GetIdentValue returns the value of the identifier in a given scope.
@@ -208,8 +210,12 @@ func (m MachineScope) GetIdentValue(name string) (query.Box, error) {
 		return query.NewString(string(m.MachineInfo.Life)), nil
 	case "status", "agent-status":
 		return query.NewString(string(m.MachineInfo.AgentStatus.Current)), nil
+	case "status-since", "agent-status-since":
+		return query.NewString(formatSince(m.MachineInfo.AgentStatus.Since)), nil
 	case "instance-status":
 		return query.NewString(string(m.MachineInfo.InstanceStatus.Current)), nil
+	case "instance-status-since":
+		return query.NewString(formatSince(m.MachineInfo.InstanceStatus.Since)), nil
 	case "base":
 		return query.NewString(m.MachineInfo.Base), nil
 	case "container-type":
@@ -105,6 +105,10 @@ func (c *modelCommand) Info() *cmd.Info {
 			"wait-for application",
 			"wait-for machine",
 			"wait-for unit",
+			"wait-for relation",
+			"wait-for offer",
+			"wait-for secret",
+			"wait-for storage",
 		},
 	})
 }
@@ -300,7 +304,7 @@ func MakeModelScope(ctx ScopeContext,
 // GetIdents returns the identifiers with in a given scope.
 func (m ModelScope) GetIdents() []string {
 	idents := set.NewStrings(getIdents(m.ModelInfo)...)
-	return set.NewStrings("applications", "machines", "units").Union(idents).SortedValues()
+	return set.NewStrings("applications", "machines", "units", "status-since").Union(idents).SortedValues()
 }
 
 // GetIdentValue returns the value of the identifier in a given scope.
@@ -318,6 +322,9 @@ func (m ModelScope) GetIdentValue(name string) (query.Box, error) {
 	case "status":
 		m.ctx.RecordIdent(name)
 		return query.NewString(string(m.ModelInfo.Status.Current)), nil
+	case "status-since":
+		m.ctx.RecordIdent(name)
+		return query.NewString(formatSince(m.ModelInfo.Status.Since)), nil
 	case "config":
 		m.ctx.RecordIdent(name)
 		return query.NewMapStringInterface(m.ModelInfo.Config), nil
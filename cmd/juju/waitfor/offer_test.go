@@ -0,0 +1,65 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/rpc/params"
+)
+
+type offerScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&offerScopeSuite{})
+
+func (s *offerScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field     string
+		OfferInfo *params.ApplicationOfferInfo
+		Expected  query.Box
+	}{{
+		Field:     "name",
+		OfferInfo: &params.ApplicationOfferInfo{OfferName: "hosted-mysql"},
+		Expected:  query.NewString("hosted-mysql"),
+	}, {
+		Field:     "offer-uuid",
+		OfferInfo: &params.ApplicationOfferInfo{OfferUUID: "deadbeef"},
+		Expected:  query.NewString("deadbeef"),
+	}, {
+		Field:     "application",
+		OfferInfo: &params.ApplicationOfferInfo{ApplicationName: "mysql"},
+		Expected:  query.NewString("mysql"),
+	}, {
+		Field:     "charm",
+		OfferInfo: &params.ApplicationOfferInfo{CharmName: "mysql"},
+		Expected:  query.NewString("mysql"),
+	}, {
+		Field:     "total-connected-count",
+		OfferInfo: &params.ApplicationOfferInfo{TotalConnectedCount: 2},
+		Expected:  query.NewInteger(2),
+	}, {
+		Field:     "active-connected-count",
+		OfferInfo: &params.ApplicationOfferInfo{ActiveConnectedCount: 1},
+		Expected:  query.NewInteger(1),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := MakeOfferScope(MakeScopeContext(), test.OfferInfo)
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *offerScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := MakeOfferScope(MakeScopeContext(), &params.ApplicationOfferInfo{})
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `.*"bad" on ApplicationOfferInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
@@ -108,6 +108,15 @@ func (p *astSuite) TestFloatString(c *gc.C) {
 	c.Assert(exp.String(), gc.DeepEquals, "1.123")
 }
 
+func (p *astSuite) TestDurationString(c *gc.C) {
+	exp := &Duration{
+		Token: Token{
+			Literal: "5m",
+		},
+	}
+	c.Assert(exp.String(), gc.DeepEquals, "5m")
+}
+
 func (p *astSuite) TestBoolString(c *gc.C) {
 	exp := &Bool{
 		Token: Token{
@@ -5,6 +5,7 @@ package query
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/juju/collections/set"
 )
@@ -149,6 +150,47 @@ func (o *BoxFloat) ForEach(fn func(any) bool) {
 	fn(o.value)
 }
 
+// BoxDuration defines an ordered duration.
+type BoxDuration struct {
+	value time.Duration
+}
+
+// NewDuration creates a new Box value
+func NewDuration(value time.Duration) *BoxDuration {
+	return &BoxDuration{value: value}
+}
+
+// Less checks if a BoxDuration is less than another BoxDuration.
+func (o *BoxDuration) Less(other Ord) bool {
+	if i, ok := other.(*BoxDuration); ok {
+		return o.value < i.value
+	}
+	return false
+}
+
+// Equal checks if an BoxDuration is equal to another BoxDuration.
+func (o *BoxDuration) Equal(other Ord) bool {
+	if i, ok := other.(*BoxDuration); ok {
+		return o.value == i.value
+	}
+	return false
+}
+
+// IsZero returns if the underlying value is zero.
+func (o *BoxDuration) IsZero() bool {
+	return o.value <= 0
+}
+
+// Value defines the shadow type value of the Box.
+func (o *BoxDuration) Value() any {
+	return o.value
+}
+
+// ForEach iterates over each value in the box.
+func (o *BoxDuration) ForEach(fn func(any) bool) {
+	fn(o.value)
+}
+
 // BoxString defines an ordered string.
 type BoxString struct {
 	value string
@@ -447,6 +489,8 @@ func shadowType(box Box) string {
 		return "int64"
 	case *BoxFloat:
 		return "float64"
+	case *BoxDuration:
+		return "duration"
 	case *BoxString:
 		return "string"
 	case *BoxMapInterfaceInterface:
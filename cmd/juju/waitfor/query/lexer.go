@@ -89,6 +89,12 @@ func (l *Lexer) NextToken() Token {
 					Literal: string(l.char) + string(peek),
 				}
 				l.ReadNext()
+			} else if peek == '~' {
+				tok = Token{
+					Type:    MATCH,
+					Literal: string(l.char) + string(peek),
+				}
+				l.ReadNext()
 			} else {
 				tok = MakeToken(ASSIGN, l.char)
 			}
@@ -168,9 +174,15 @@ func (l *Lexer) readRunesToken() Token {
 		return tok
 	case isDigit(l.char):
 		literal := l.readNumber()
-		if strings.Contains(literal, ".") {
+		switch {
+		case isLetter(l.char):
+			// A number directly followed by a unit is a duration, for
+			// example 5m or 1h30m.
+			literal += l.readDurationUnits()
+			tok.Type = DURATION
+		case strings.Contains(literal, "."):
 			tok.Type = FLOAT
-		} else {
+		default:
 			tok.Type = INT
 		}
 		tok.Literal = literal
@@ -252,6 +264,17 @@ func (l *Lexer) readNumber() string {
 	return string(ret)
 }
 
+// readDurationUnits returns the remainder of a duration literal, after the
+// leading number has been read.
+func (l *Lexer) readDurationUnits() string {
+	var ret []rune
+	for isLetter(l.char) || isDigit(l.char) || l.char == '.' {
+		ret = append(ret, l.char)
+		l.ReadNext()
+	}
+	return string(ret)
+}
+
 func (l *Lexer) getPosition() Position {
 	return Position{
 		Offset: l.position,
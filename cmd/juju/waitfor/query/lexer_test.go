@@ -121,6 +121,15 @@ func (p *lexerSuite) TestReadNextComplexTypes(c *gc.C) {
 			Type:    NEQ,
 			Literal: "!=",
 		}},
+	}, {
+		Input: "=~",
+		Expected: []Token{{
+			Type: -1,
+		}, {
+			Pos:     Position{Offset: 0, Line: 1, Column: 1},
+			Type:    MATCH,
+			Literal: "=~",
+		}},
 	}, {
 		Input: "&&",
 		Expected: []Token{{
@@ -349,6 +358,24 @@ func (p *lexerSuite) TestReadNextNumber(c *gc.C) {
 			Type:    FLOAT,
 			Literal: "0.000002",
 		}},
+	}, {
+		Input: `5m`,
+		Expected: []Token{{
+			Type: -1,
+		}, {
+			Pos:     Position{Offset: 0, Line: 1, Column: 1},
+			Type:    DURATION,
+			Literal: "5m",
+		}},
+	}, {
+		Input: `1h30.5m`,
+		Expected: []Token{{
+			Type: -1,
+		}, {
+			Pos:     Position{Offset: 0, Line: 1, Column: 1},
+			Type:    DURATION,
+			Literal: "1h30.5m",
+		}},
 	}}
 
 	for _, test := range tests {
@@ -5,6 +5,7 @@ package query
 
 import (
 	"strconv"
+	"time"
 )
 
 const (
@@ -23,6 +24,7 @@ var precedence = map[TokenType]int{
 	CONDAND:  PCONDAND,
 	EQ:       EQUALS,
 	NEQ:      EQUALS,
+	MATCH:    EQUALS,
 	LPAREN:   CALL,
 	LAMBDA:   CALL,
 	LT:       LESSGREATER,
@@ -57,6 +59,7 @@ func NewParser(lex *Lexer) *Parser {
 		UNDERSCORE: p.parseIdentifier,
 		INT:        p.parseInteger,
 		FLOAT:      p.parseFloat,
+		DURATION:   p.parseDuration,
 		STRING:     p.parseString,
 		LPAREN:     p.parseGroup,
 		BOOL:       p.parseBool,
@@ -64,6 +67,7 @@ func NewParser(lex *Lexer) *Parser {
 	p.infix = map[TokenType]InfixFunc{
 		EQ:       p.parseInfixExpression,
 		NEQ:      p.parseInfixExpression,
+		MATCH:    p.parseInfixExpression,
 		CONDAND:  p.parseInfixExpression,
 		CONDOR:   p.parseInfixExpression,
 		LT:       p.parseInfixExpression,
@@ -142,6 +146,17 @@ func (p *Parser) parseFloat() (Expression, error) {
 	}, nil
 }
 
+func (p *Parser) parseDuration() (Expression, error) {
+	value, err := time.ParseDuration(p.currentToken.Literal)
+	if err != nil {
+		return nil, ErrSyntaxError(p.currentToken.Pos, p.currentToken.Type, DURATION)
+	}
+	return &Duration{
+		Token: p.currentToken,
+		Value: value,
+	}, nil
+}
+
 func (p *Parser) parseExpressionStatement() (Expression, error) {
 	stmt := &ExpressionStatement{
 		Token: p.currentToken,
@@ -4,6 +4,8 @@
 package query
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 )
@@ -165,6 +167,41 @@ func (p *parserSuite) TestParserFloat(c *gc.C) {
 	})
 }
 
+func (p *parserSuite) TestParserDuration(c *gc.C) {
+	query := `1h30m`
+
+	lex := NewLexer(query)
+	parser := NewParser(lex)
+	exp, err := parser.Run()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exp, gc.DeepEquals, &QueryExpression{
+		Expressions: []Expression{
+			&ExpressionStatement{
+				Expression: &Duration{
+					Token: Token{
+						Pos:     Position{Line: 1, Column: 1, Offset: 0},
+						Type:    DURATION,
+						Literal: "1h30m",
+					},
+					Value: 90 * time.Minute,
+				},
+				Token: Token{
+					Pos:     Position{Line: 1, Column: 1, Offset: 0},
+					Type:    DURATION,
+					Literal: "1h30m",
+				},
+			},
+		},
+	})
+}
+
+func (p *parserSuite) TestParserInvalidDuration(c *gc.C) {
+	lex := NewLexer(`5mins`)
+	parser := NewParser(lex)
+	_, err := parser.Run()
+	c.Assert(err, jc.Satisfies, IsSyntaxError)
+}
+
 func (p *parserSuite) TestParserBool(c *gc.C) {
 	query := `true false`
 
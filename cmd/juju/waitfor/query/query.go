@@ -6,6 +6,8 @@ package query
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -234,6 +236,8 @@ func (q Query) run(e Expression, fnScope FuncScope, scope Scope) (any, error) {
 			return lessThan(right, left), nil
 		case GE:
 			return lessThanOrEqual(right, left), nil
+		case MATCH:
+			return matches(node, left, right)
 		}
 
 		// Everything onwards expects to work on logical operators.
@@ -285,6 +289,9 @@ func (q Query) run(e Expression, fnScope FuncScope, scope Scope) (any, error) {
 	case *Float:
 		return &BoxFloat{value: node.Value}, nil
 
+	case *Duration:
+		return &BoxDuration{value: node.Value}, nil
+
 	case *String:
 		return &BoxString{value: node.Token.Literal}, nil
 
@@ -350,6 +357,24 @@ func lessThanOrEqual(left, right any) bool {
 	return a.Less(b) || a.Equal(b)
 }
 
+// matches checks if the left string matches the regular expression on the
+// right.
+func matches(node *InfixExpression, left, right any) (bool, error) {
+	value, ok := left.(*BoxString)
+	if !ok {
+		return false, RuntimeErrorf("%v expected string to match, got %T", node.Left.Pos(), left)
+	}
+	pattern, ok := right.(*BoxString)
+	if !ok {
+		return false, RuntimeErrorf("%v expected string regular expression, got %T", node.Right.Pos(), right)
+	}
+	re, err := regexp.Compile(pattern.value)
+	if err != nil {
+		return false, RuntimeErrorf("%v invalid regular expression %q: %v", node.Right.Pos(), pattern.value, err)
+	}
+	return re.MatchString(value.value), nil
+}
+
 func ConvertRawResult(value any) (Box, error) {
 	if box, ok := value.(Box); ok {
 		return box, nil
@@ -369,6 +394,8 @@ func ConvertRawResult(value any) (Box, error) {
 		return NewBool(t), nil
 	case float64:
 		return NewFloat(t), nil
+	case time.Duration:
+		return NewDuration(t), nil
 	case map[any]any:
 		return NewMapInterfaceInterface(t), nil
 	case map[string]any:
@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -18,12 +19,14 @@ import (
 type GlobalFuncScope struct {
 	scope Scope
 	funcs map[string]any
+	now   func() time.Time
 }
 
 // NewGlobalFuncScope creates a new scope for executing functions.
 func NewGlobalFuncScope(scope Scope) *GlobalFuncScope {
-	return &GlobalFuncScope{
+	s := &GlobalFuncScope{
 		scope: scope,
+		now:   time.Now,
 		funcs: map[string]any{
 			"len": func(v any) (int, error) {
 				val := reflect.ValueOf(v)
@@ -130,6 +133,116 @@ func NewGlobalFuncScope(scope Scope) *GlobalFuncScope {
 			},
 		},
 	}
+	s.funcs["all"] = s.all
+	s.funcs["any"] = s.any
+	s.funcs["count"] = s.count
+	s.funcs["since"] = s.since
+	return s
+}
+
+// all returns true if the lambda is true for every value. Unlike a
+// mathematical all, an empty set of values is false, so that waiting on
+// entities that don't exist yet doesn't succeed.
+func (s *GlobalFuncScope) all(values, expr any) (bool, error) {
+	var (
+		called bool
+		result = true
+	)
+	err := s.callLambda(values, expr, func(matched bool) bool {
+		called = true
+		result = matched
+		return result
+	})
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return called && result, nil
+}
+
+// any returns true if the lambda is true for at least one value.
+func (s *GlobalFuncScope) any(values, expr any) (bool, error) {
+	var result bool
+	err := s.callLambda(values, expr, func(matched bool) bool {
+		result = matched
+		return !result
+	})
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// count returns the number of values for which the lambda is true.
+func (s *GlobalFuncScope) count(values, expr any) (int, error) {
+	var num int
+	err := s.callLambda(values, expr, func(matched bool) bool {
+		if matched {
+			num++
+		}
+		return true
+	})
+	if err != nil {
+		return -1, errors.Trace(err)
+	}
+	return num, nil
+}
+
+// since returns the duration elapsed since the given RFC3339 time. An
+// empty time, for an entity that hasn't reported one yet, is a zero
+// duration so that comparisons keep waiting. The result changes as time
+// passes, so queries are re-evaluated periodically and not only when the
+// model changes.
+func (s *GlobalFuncScope) since(v any) (time.Duration, error) {
+	value, ok := v.(string)
+	if !ok {
+		return 0, RuntimeErrorf("requires string to be passed to since, got %T", v)
+	}
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, RuntimeErrorf("invalid time %q passed to since", value)
+	}
+	return s.now().Sub(t), nil
+}
+
+// callLambda calls the lambda for each scope within values, passing
+// whether the lambda result was truthy to fn. Iteration stops when fn
+// returns false.
+func (s *GlobalFuncScope) callLambda(values, expr any, fn func(bool) bool) error {
+	scopes, ok := values.(Box)
+	if !ok {
+		return RuntimeErrorf("unexpected lambda values %T", values)
+	}
+	lambda, ok := expr.(*BoxLambda)
+	if !ok {
+		return RuntimeErrorf("unexpected lambda %T", expr)
+	}
+
+	var err error
+	ForEach(scopes, func(value any) bool {
+		nestedScope, ok := value.(Scope)
+		if !ok {
+			err = RuntimeErrorf("unexpected scope type %T", value)
+			return false
+		}
+
+		namedScope := MakeNestedScope(s.scope)
+		namedScope.SetScope(lambda.ArgName(), nestedScope)
+
+		var results []Box
+		results, err = lambda.Call(namedScope)
+		if err != nil {
+			return false
+		}
+		var lambdaResult bool
+		for _, result := range results {
+			lambdaResult = !result.IsZero()
+		}
+		return fn(lambdaResult)
+	})
+	return errors.Trace(err)
 }
 
 // Add a function to the global scope.
@@ -0,0 +1,129 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package query
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type scopeSuite struct{}
+
+var _ = gc.Suite(&scopeSuite{})
+
+func (s *scopeSuite) TestAggregates(c *gc.C) {
+	scope := testScope{
+		"units": scopesBox{
+			testScope{"status": NewString("active")},
+			testScope{"status": NewString("blocked")},
+			testScope{"status": NewString("active")},
+		},
+		"none": scopesBox{},
+	}
+
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{query: `all(units, u => u.status == "active")`, expected: false},
+		{query: `all(units, u => u.status =~ "^(active|blocked)$")`, expected: true},
+		{query: `all(none, u => true)`, expected: false},
+		{query: `any(units, u => u.status == "blocked")`, expected: true},
+		{query: `any(units, u => u.status == "error")`, expected: false},
+		{query: `any(none, u => true)`, expected: false},
+		{query: `count(units, u => u.status == "active") == 2`, expected: true},
+		{query: `count(none, u => true) == 0`, expected: true},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.query)
+
+		q, err := Parse(test.query)
+		c.Assert(err, jc.ErrorIsNil)
+		result, err := q.BuiltinsRun(scope)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, test.expected)
+	}
+}
+
+func (s *scopeSuite) TestSince(c *gc.C) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	scope := testScope{
+		"status-since": NewString(now.Add(-3 * time.Minute).Format(time.RFC3339)),
+		"never":        NewString(""),
+	}
+
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{query: `since(status-since) > 2m`, expected: true},
+		{query: `since(status-since) > 5m`, expected: false},
+		{query: `since(status-since) == 3m`, expected: true},
+		{query: `since(never) > 0s`, expected: false},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.query)
+
+		q, err := Parse(test.query)
+		c.Assert(err, jc.ErrorIsNil)
+
+		fnScope := NewGlobalFuncScope(scope)
+		fnScope.now = func() time.Time { return now }
+		result, err := q.Run(fnScope, scope)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, test.expected)
+	}
+}
+
+func (s *scopeSuite) TestSinceInvalidTime(c *gc.C) {
+	q, err := Parse(`since(status-since) > 2m`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = q.BuiltinsRun(testScope{"status-since": NewString("yesterday")})
+	c.Assert(err, gc.ErrorMatches, `invalid time "yesterday" passed to since`)
+}
+
+func (s *scopeSuite) TestMatchInvalidRegexp(c *gc.C) {
+	q, err := Parse(`status =~ "("`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = q.BuiltinsRun(testScope{"status": NewString("active")})
+	c.Assert(err, gc.ErrorMatches, `.* invalid regular expression "\(": .*`)
+}
+
+// testScope is a Scope backed by a map of identifiers.
+type testScope map[string]Box
+
+func (s testScope) GetIdents() []string {
+	var idents []string
+	for name := range s {
+		idents = append(idents, name)
+	}
+	return idents
+}
+
+func (s testScope) GetIdentValue(name string) (Box, error) {
+	if box, ok := s[name]; ok {
+		return box, nil
+	}
+	return nil, ErrInvalidIdentifier(name, s)
+}
+
+// scopesBox is an iterable Box of scopes, for use with lambdas.
+type scopesBox []Scope
+
+func (o scopesBox) Less(other Ord) bool  { return false }
+func (o scopesBox) Equal(other Ord) bool { return false }
+func (o scopesBox) IsZero() bool         { return len(o) == 0 }
+func (o scopesBox) Value() any           { return o }
+
+func (o scopesBox) ForEach(fn func(any) bool) {
+	for _, scope := range o {
+		if !fn(scope) {
+			return
+		}
+	}
+}
@@ -44,6 +44,9 @@ const (
 	LAMBDA     // =>
 	UNDERSCORE // _
 	PERIOD     // .
+
+	DURATION // duration literal
+	MATCH    // =~
 )
 
 func (t TokenType) String() string {
@@ -56,6 +59,8 @@ func (t TokenType) String() string {
 		return "INT"
 	case FLOAT:
 		return "FLOAT"
+	case DURATION:
+		return "DURATION"
 	case ASSIGN:
 		return "="
 	case BANG:
@@ -64,6 +69,8 @@ func (t TokenType) String() string {
 		return "=="
 	case NEQ:
 		return "!="
+	case MATCH:
+		return "=~"
 	case LT:
 		return "<"
 	case LE:
@@ -0,0 +1,317 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+	"gopkg.in/yaml.v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/waitfor/api"
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/rpc/params"
+)
+
+func newRelationCommand() cmd.Command {
+	cmd := &relationCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelAllWatchShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const relationCommandDoc = `
+The wait-for relation command waits for a relation to reach a goal state.
+The goal state can be defined programmatically using the query DSL
+(domain specific language). The default query for a relation just waits for
+the relation to be joined.
+
+A relation can be identified either by its integer id, or by the two
+application endpoints it connects. Endpoints are written as
+<application>[:<endpoint>] and can be given in any order.
+
+The wait-for command is an optimized alternative to the status command for
+determining programmatically if a goal state has been reached. The wait-for
+command streams delta changes from the underlying database, unlike the status
+command which performs a full query of the database.
+
+Multiple expressions can be combined to define a complex goal state.
+`
+
+const relationCommandExamples = `
+Waits for the relation between mysql and wordpress to be joined.
+
+    juju wait-for relation mysql wordpress:db
+
+Waits for relation 3 to be joined for at least a minute.
+
+    juju wait-for relation 3 --query='status=="joined" && since(status-since) >= 1m'
+`
+
+// relationCommand defines a command for waiting for relations.
+type relationCommand struct {
+	waitForCommandBase
+
+	id        int
+	endpoints []relationEndpoint
+	query     string
+	timeout   time.Duration
+	summary   bool
+
+	relationInfo *params.RelationInfo
+}
+
+// relationEndpoint describes one side of a relation, as supplied on the
+// command line.
+type relationEndpoint struct {
+	application string
+	name        string
+}
+
+// match returns true if the endpoint specification matches the
+// supplied relation endpoint.
+func (e relationEndpoint) match(endpoint params.Endpoint) bool {
+	if e.application != endpoint.ApplicationName {
+		return false
+	}
+	return e.name == "" || e.name == endpoint.Relation.Name
+}
+
+func (e relationEndpoint) String() string {
+	if e.name == "" {
+		return e.application
+	}
+	return fmt.Sprintf("%s:%s", e.application, e.name)
+}
+
+// Info implements Command.Info.
+func (c *relationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "relation",
+		Args:     "<id> | <application>[:<endpoint>] <application>[:<endpoint>]",
+		Purpose:  "Wait for a relation to reach a specified state.",
+		Doc:      relationCommandDoc,
+		Examples: relationCommandExamples,
+		SeeAlso: []string{
+			"wait-for model",
+			"wait-for application",
+			"wait-for offer",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *relationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `status=="joined"`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the relation query on exit")
+}
+
+// Init implements Command.Init.
+func (c *relationCommand) Init(args []string) (err error) {
+	switch len(args) {
+	case 0:
+		return errors.New("relation id or endpoints must be supplied when waiting for a relation")
+	case 1:
+		id, err := strconv.Atoi(args[0])
+		if err != nil || id < 0 {
+			return errors.Errorf("%q is not a valid relation id", args[0])
+		}
+		c.id = id
+	case 2:
+		for _, arg := range args {
+			endpoint, err := parseRelationEndpoint(arg)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			c.endpoints = append(c.endpoints, endpoint)
+		}
+	default:
+		return errors.New("a relation id or two endpoints can be supplied as arguments to this command")
+	}
+	return nil
+}
+
+func parseRelationEndpoint(arg string) (relationEndpoint, error) {
+	application, name, hasName := strings.Cut(arg, ":")
+	if !names.IsValidApplication(application) {
+		return relationEndpoint{}, errors.Errorf("%q is not a valid application name", application)
+	}
+	if hasName && !names.IsValidRelation(arg) {
+		return relationEndpoint{}, errors.Errorf("%q is not a valid endpoint", arg)
+	}
+	return relationEndpoint{
+		application: application,
+		name:        name,
+	}, nil
+}
+
+// name returns the human readable name of the relation being waited for.
+func (c *relationCommand) name() string {
+	if len(c.endpoints) == 0 {
+		return strconv.Itoa(c.id)
+	}
+	return fmt.Sprintf("%s %s", c.endpoints[0], c.endpoints[1])
+}
+
+// matches returns true if the relation is the one being waited for.
+func (c *relationCommand) matches(info *params.RelationInfo) bool {
+	if len(c.endpoints) == 0 {
+		return info.Id == c.id
+	}
+	if len(info.Endpoints) != 2 {
+		return false
+	}
+	a, b := info.Endpoints[0], info.Endpoints[1]
+	return (c.endpoints[0].match(a) && c.endpoints[1].match(b)) ||
+		(c.endpoints[0].match(b) && c.endpoints[1].match(a))
+}
+
+func (c *relationCommand) Run(ctx *cmd.Context) (err error) {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		if err != nil || !c.summary || c.relationInfo == nil {
+			return
+		}
+
+		ctx.Infof("relation %q is %s", c.relationInfo.Key, relationStatus(c.relationInfo).Current)
+		outputRelationSummary(ctx.Stdout, scopedContext, c.relationInfo)
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	err = strategy.Run(ctx, c.name(), c.query, c.waitFor(c.query, scopedContext, ctx), emptyNotify)
+	return errors.Trace(err)
+}
+
+func (c *relationCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
+	return func(name string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Verbosef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.RelationInfo:
+				if !c.matches(entityInfo) {
+					break
+				}
+
+				if delta.Removed {
+					return false, errors.Errorf("relation %v removed", name)
+				}
+
+				c.relationInfo = entityInfo
+			}
+		}
+
+		if c.relationInfo == nil {
+			logger.Infof("relation %q not found, waiting...", name)
+			return false, nil
+		}
+
+		scope := MakeRelationScope(ctx, c.relationInfo)
+		if done, err := runQuery(input, q, scope); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+
+		logger.Infof("relation %q found, waiting...", name)
+		return false, nil
+	}
+}
+
+// RelationScope allows the query to introspect a relation entity.
+type RelationScope struct {
+	ctx          ScopeContext
+	RelationInfo *params.RelationInfo
+}
+
+// MakeRelationScope creates a RelationScope from a RelationInfo.
+func MakeRelationScope(ctx ScopeContext, info *params.RelationInfo) RelationScope {
+	return RelationScope{
+		ctx:          ctx,
+		RelationInfo: info,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m RelationScope) GetIdents() []string {
+	idents := set.NewStrings(getIdents(m.RelationInfo)...)
+	return set.NewStrings("status", "message", "status-since", "interface").Union(idents).SortedValues()
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m RelationScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "id":
+		return query.NewInteger(int64(m.RelationInfo.Id)), nil
+	case "key":
+		return query.NewString(m.RelationInfo.Key), nil
+	case "status":
+		return query.NewString(string(relationStatus(m.RelationInfo).Current)), nil
+	case "message":
+		return query.NewString(relationStatus(m.RelationInfo).Message), nil
+	case "status-since":
+		return query.NewString(formatSince(relationStatus(m.RelationInfo).Since)), nil
+	case "interface":
+		var iface string
+		if len(m.RelationInfo.Endpoints) > 0 {
+			iface = m.RelationInfo.Endpoints[0].Relation.Interface
+		}
+		return query.NewString(iface), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on RelationInfo", name)
+}
+
+// relationStatus returns the status of the relation, which is empty
+// until the relation's status has been set.
+func relationStatus(info *params.RelationInfo) params.StatusInfo {
+	if info.Status == nil {
+		return params.StatusInfo{}
+	}
+	return *info.Status
+}
+
+func outputRelationSummary(writer io.Writer, scopedContext ScopeContext, relationInfo *params.RelationInfo) {
+	result := struct {
+		Elements map[string]interface{} `yaml:"properties"`
+	}{
+		Elements: make(map[string]interface{}),
+	}
+
+	idents := scopedContext.RecordedIdents()
+	for _, ident := range idents {
+		scope := MakeRelationScope(scopedContext, relationInfo)
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Elements[ident] = box.Value()
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+)
+
+type relationScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&relationScopeSuite{})
+
+func (s *relationScopeSuite) TestGetIdentValue(c *gc.C) {
+	since := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		Field        string
+		RelationInfo *params.RelationInfo
+		Expected     query.Box
+	}{{
+		Field:        "id",
+		RelationInfo: &params.RelationInfo{Id: 3},
+		Expected:     query.NewInteger(3),
+	}, {
+		Field:        "key",
+		RelationInfo: &params.RelationInfo{Key: "wordpress:db mysql:server"},
+		Expected:     query.NewString("wordpress:db mysql:server"),
+	}, {
+		Field: "status",
+		RelationInfo: &params.RelationInfo{Status: &params.StatusInfo{
+			Current: status.Joined,
+		}},
+		Expected: query.NewString("joined"),
+	}, {
+		Field: "message",
+		RelationInfo: &params.RelationInfo{Status: &params.StatusInfo{
+			Message: "waiting",
+		}},
+		Expected: query.NewString("waiting"),
+	}, {
+		Field: "status-since",
+		RelationInfo: &params.RelationInfo{Status: &params.StatusInfo{
+			Since: &since,
+		}},
+		Expected: query.NewString("2023-05-01T12:00:00Z"),
+	}, {
+		Field: "interface",
+		RelationInfo: &params.RelationInfo{Endpoints: []params.Endpoint{{
+			ApplicationName: "mysql",
+			Relation:        params.CharmRelation{Name: "server", Interface: "mysql"},
+		}}},
+		Expected: query.NewString("mysql"),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := MakeRelationScope(MakeScopeContext(), test.RelationInfo)
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *relationScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := MakeRelationScope(MakeScopeContext(), &params.RelationInfo{})
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `.*"bad" on RelationInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *relationScopeSuite) TestInit(c *gc.C) {
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{args: nil, err: "relation id or endpoints must be supplied when waiting for a relation"},
+		{args: []string{"3"}},
+		{args: []string{"-1"}, err: `"-1" is not a valid relation id`},
+		{args: []string{"mysql"}, err: `"mysql" is not a valid relation id`},
+		{args: []string{"mysql", "wordpress:db"}},
+		{args: []string{"mysql", "wordpress:"}, err: `"wordpress:" is not a valid endpoint`},
+		{args: []string{"Mysql", "wordpress"}, err: `"Mysql" is not a valid application name`},
+		{args: []string{"a", "b", "c"}, err: "a relation id or two endpoints can be supplied as arguments to this command"},
+	}
+	for i, test := range tests {
+		c.Logf("%d: Init %v", i, test.args)
+		err := (&relationCommand{}).Init(test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *relationScopeSuite) TestMatches(c *gc.C) {
+	info := &params.RelationInfo{
+		Id: 3,
+		Endpoints: []params.Endpoint{{
+			ApplicationName: "wordpress",
+			Relation:        params.CharmRelation{Name: "db"},
+		}, {
+			ApplicationName: "mysql",
+			Relation:        params.CharmRelation{Name: "server"},
+		}},
+	}
+	tests := []struct {
+		args     []string
+		expected bool
+	}{
+		{args: []string{"3"}, expected: true},
+		{args: []string{"4"}, expected: false},
+		{args: []string{"mysql", "wordpress"}, expected: true},
+		{args: []string{"wordpress:db", "mysql:server"}, expected: true},
+		{args: []string{"mysql:db", "wordpress"}, expected: false},
+		{args: []string{"mysql", "mysql"}, expected: false},
+	}
+	for i, test := range tests {
+		c.Logf("%d: matches %v", i, test.args)
+		cmd := &relationCommand{}
+		c.Assert(cmd.Init(test.args), jc.ErrorIsNil)
+		c.Check(cmd.matches(info), gc.Equals, test.expected)
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+	"gopkg.in/yaml.v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/waitfor/api"
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+)
+
+func newSecretCommand() cmd.Command {
+	cmd := &secretCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelAllWatchShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const secretCommandDoc = `
+The wait-for secret command waits for a secret to reach a goal state.
+The goal state can be defined programmatically using the query DSL
+(domain specific language). The default query for a secret waits for every
+consumer of the secret to be tracking the latest revision.
+
+The consumers of a secret can be queried using the consumers collection,
+where each consumer has a name and a current-revision.
+
+The wait-for command is an optimized alternative to the status command for
+determining programmatically if a goal state has been reached. The wait-for
+command streams delta changes from the underlying database, unlike the status
+command which performs a full query of the database.
+
+Multiple expressions can be combined to define a complex goal state.
+`
+
+const secretCommandExamples = `
+Waits for all consumers of a secret to see the latest revision.
+
+    juju wait-for secret cj4v5vm78ohs79o84r4g
+
+Waits for a secret to have at least three revisions.
+
+    juju wait-for secret secret:cj4v5vm78ohs79o84r4g --query='latest-revision >= 3'
+
+Waits for the wordpress application to consume a secret.
+
+    juju wait-for secret cj4v5vm78ohs79o84r4g --query='any(consumers, c => c.name=="wordpress")'
+`
+
+// secretCommand defines a command for waiting for secrets.
+type secretCommand struct {
+	waitForCommandBase
+
+	uri     *coresecrets.URI
+	query   string
+	timeout time.Duration
+	summary bool
+
+	secretInfo *params.SecretInfo
+}
+
+// Info implements Command.Info.
+func (c *secretCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "secret",
+		Args:     "[<uri>|<id>]",
+		Purpose:  "Wait for a secret to reach a specified state.",
+		Doc:      secretCommandDoc,
+		Examples: secretCommandExamples,
+		SeeAlso: []string{
+			"wait-for model",
+			"wait-for application",
+			"secrets",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *secretCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `all(consumers, consumer => consumer.current-revision == latest-revision)`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the secret query on exit")
+}
+
+// Init implements Command.Init.
+func (c *secretCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("secret URI or id must be supplied when waiting for a secret")
+	}
+	if len(args) != 1 {
+		return errors.New("only one secret URI or id can be supplied as an argument to this command")
+	}
+	if c.uri, err = coresecrets.ParseURI(args[0]); err != nil {
+		return errors.Annotatef(err, "%q is not a valid secret", args[0])
+	}
+
+	return nil
+}
+
+func (c *secretCommand) Run(ctx *cmd.Context) (err error) {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		if err != nil || !c.summary || c.secretInfo == nil {
+			return
+		}
+
+		ctx.Infof("secret %q is at revision %d", c.uri.String(), c.secretInfo.LatestRevision)
+		outputSecretSummary(ctx.Stdout, scopedContext, c.secretInfo)
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	err = strategy.Run(ctx, c.uri.ID, c.query, c.waitFor(c.query, scopedContext, ctx), emptyNotify)
+	return errors.Trace(err)
+}
+
+func (c *secretCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
+	return func(id string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Verbosef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.SecretInfo:
+				if entityInfo.Id != id {
+					break
+				}
+
+				if delta.Removed {
+					return false, errors.Errorf("secret %v removed", id)
+				}
+
+				c.secretInfo = entityInfo
+			}
+		}
+
+		if c.secretInfo == nil {
+			logger.Infof("secret %q not found, waiting...", id)
+			return false, nil
+		}
+
+		scope := MakeSecretScope(ctx, c.secretInfo)
+		if done, err := runQuery(input, q, scope); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+
+		logger.Infof("secret %q found, waiting...", id)
+		return false, nil
+	}
+}
+
+// SecretScope allows the query to introspect a secret entity.
+type SecretScope struct {
+	ctx        ScopeContext
+	SecretInfo *params.SecretInfo
+}
+
+// MakeSecretScope creates a SecretScope from a SecretInfo.
+func MakeSecretScope(ctx ScopeContext, info *params.SecretInfo) SecretScope {
+	return SecretScope{
+		ctx:        ctx,
+		SecretInfo: info,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m SecretScope) GetIdents() []string {
+	idents := set.NewStrings(getIdents(m.SecretInfo)...)
+	return set.NewStrings("uri", "update-time", "consumers").Union(idents).SortedValues()
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m SecretScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "id":
+		return query.NewString(m.SecretInfo.Id), nil
+	case "uri":
+		uri := coresecrets.URI{ID: m.SecretInfo.Id}
+		return query.NewString(uri.String()), nil
+	case "owner":
+		return query.NewString(tagToId(m.SecretInfo.Owner)), nil
+	case "label":
+		return query.NewString(m.SecretInfo.Label), nil
+	case "description":
+		return query.NewString(m.SecretInfo.Description), nil
+	case "latest-revision":
+		return query.NewInteger(int64(m.SecretInfo.LatestRevision)), nil
+	case "rotate-policy":
+		return query.NewString(m.SecretInfo.RotatePolicy), nil
+	case "update-time":
+		return query.NewString(formatSince(&m.SecretInfo.UpdateTime)), nil
+	case "consumers":
+		scopes := make(map[string]query.Scope)
+		for consumer, revision := range m.SecretInfo.Consumers {
+			consumerName := tagToId(consumer)
+			scopes[consumerName] = MakeSecretConsumerScope(m.ctx.Child(name, consumerName), consumerName, revision)
+		}
+		return NewScopedBox(scopes), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on SecretInfo", name)
+}
+
+// SecretConsumerScope allows the query to introspect a consumer of
+// a secret.
+type SecretConsumerScope struct {
+	ctx             ScopeContext
+	Name            string
+	CurrentRevision int
+}
+
+// MakeSecretConsumerScope creates a SecretConsumerScope for the named
+// consumer.
+func MakeSecretConsumerScope(ctx ScopeContext, name string, revision int) SecretConsumerScope {
+	return SecretConsumerScope{
+		ctx:             ctx,
+		Name:            name,
+		CurrentRevision: revision,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m SecretConsumerScope) GetIdents() []string {
+	return []string{"current-revision", "name"}
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m SecretConsumerScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "name":
+		return query.NewString(m.Name), nil
+	case "current-revision":
+		return query.NewInteger(int64(m.CurrentRevision)), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on secret consumer", name)
+}
+
+// tagToId returns the id of the supplied tag, or the value unchanged if it
+// isn't a valid tag.
+func tagToId(value string) string {
+	if tag, err := names.ParseTag(value); err == nil {
+		return tag.Id()
+	}
+	return value
+}
+
+func outputSecretSummary(writer io.Writer, scopedContext ScopeContext, secretInfo *params.SecretInfo) {
+	result := struct {
+		Elements map[string]interface{} `yaml:"properties"`
+	}{
+		Elements: make(map[string]interface{}),
+	}
+
+	idents := scopedContext.RecordedIdents()
+	for _, ident := range idents {
+		if ident == "consumers" {
+			continue
+		}
+		scope := MakeSecretScope(scopedContext, secretInfo)
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Elements[ident] = box.Value()
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/rpc/params"
+)
+
+type secretScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&secretScopeSuite{})
+
+func (s *secretScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field      string
+		SecretInfo *params.SecretInfo
+		Expected   query.Box
+	}{{
+		Field:      "id",
+		SecretInfo: &params.SecretInfo{Id: "cj4v5vm78ohs79o84r4g"},
+		Expected:   query.NewString("cj4v5vm78ohs79o84r4g"),
+	}, {
+		Field:      "uri",
+		SecretInfo: &params.SecretInfo{Id: "cj4v5vm78ohs79o84r4g"},
+		Expected:   query.NewString("secret:cj4v5vm78ohs79o84r4g"),
+	}, {
+		Field:      "owner",
+		SecretInfo: &params.SecretInfo{Owner: "application-mysql"},
+		Expected:   query.NewString("mysql"),
+	}, {
+		Field:      "label",
+		SecretInfo: &params.SecretInfo{Label: "password"},
+		Expected:   query.NewString("password"),
+	}, {
+		Field:      "description",
+		SecretInfo: &params.SecretInfo{Description: "db password"},
+		Expected:   query.NewString("db password"),
+	}, {
+		Field:      "latest-revision",
+		SecretInfo: &params.SecretInfo{LatestRevision: 2},
+		Expected:   query.NewInteger(2),
+	}, {
+		Field:      "rotate-policy",
+		SecretInfo: &params.SecretInfo{RotatePolicy: "daily"},
+		Expected:   query.NewString("daily"),
+	}, {
+		Field:      "update-time",
+		SecretInfo: &params.SecretInfo{UpdateTime: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)},
+		Expected:   query.NewString("2023-05-01T12:00:00Z"),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := MakeSecretScope(MakeScopeContext(), test.SecretInfo)
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *secretScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := MakeSecretScope(MakeScopeContext(), &params.SecretInfo{})
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `.*"bad" on SecretInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *secretScopeSuite) TestConsumersQuery(c *gc.C) {
+	info := &params.SecretInfo{
+		LatestRevision: 2,
+		Consumers: map[string]int{
+			"application-wordpress": 2,
+			"unit-mediawiki-0":      1,
+		},
+	}
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{query: `all(consumers, c => c.current-revision == latest-revision)`, expected: false},
+		{query: `any(consumers, c => c.name == "wordpress" && c.current-revision == 2)`, expected: true},
+		{query: `count(consumers, c => c.name == "mediawiki/0") == 1`, expected: true},
+	}
+	for i, test := range tests {
+		c.Logf("%d: %s", i, test.query)
+		q, err := query.Parse(test.query)
+		c.Assert(err, jc.ErrorIsNil)
+		result, err := q.BuiltinsRun(MakeSecretScope(MakeScopeContext(), info))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, test.expected)
+	}
+}
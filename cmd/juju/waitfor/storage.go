@@ -0,0 +1,301 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+	"gopkg.in/yaml.v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/waitfor/api"
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+func newStorageCommand() cmd.Command {
+	cmd := &storageCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelAllWatchShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const storageCommandDoc = `
+The wait-for storage command waits for a storage instance to reach a goal
+state. The goal state can be defined programmatically using the query DSL
+(domain specific language). The default query for a storage instance waits
+for the storage to be alive and attached to at least one unit.
+
+The attachments of a storage instance can be queried using the attachments
+collection, where each attachment has a unit and a life.
+
+The wait-for command is an optimized alternative to the status command for
+determining programmatically if a goal state has been reached. The wait-for
+command streams delta changes from the underlying database, unlike the status
+command which performs a full query of the database.
+
+Multiple expressions can be combined to define a complex goal state.
+`
+
+const storageCommandExamples = `
+Waits for the data/0 storage instance to be attached.
+
+    juju wait-for storage data/0
+
+Waits for the data/0 storage instance to be attached to the postgresql/0 unit.
+
+    juju wait-for storage data/0 --query='any(attachments, a => a.unit=="postgresql/0" && a.life=="alive")'
+
+Waits for the data/0 storage instance to be removed.
+
+    juju wait-for storage data/0 --query='life=="dead"'
+`
+
+// storageCommand defines a command for waiting for storage instances.
+type storageCommand struct {
+	waitForCommandBase
+
+	id      string
+	query   string
+	timeout time.Duration
+	summary bool
+
+	storageInfo *params.StorageInstanceInfo
+}
+
+// Info implements Command.Info.
+func (c *storageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "storage",
+		Args:     "[<id>]",
+		Purpose:  "Wait for a storage instance to reach a specified state.",
+		Doc:      storageCommandDoc,
+		Examples: storageCommandExamples,
+		SeeAlso: []string{
+			"wait-for model",
+			"wait-for unit",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *storageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `life=="alive" && attachment-count > 0`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the storage query on exit")
+}
+
+// Init implements Command.Init.
+func (c *storageCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("storage id must be supplied when waiting for storage")
+	}
+	if len(args) != 1 {
+		return errors.New("only one storage id can be supplied as an argument to this command")
+	}
+	if ok := names.IsValidStorage(args[0]); !ok {
+		return errors.Errorf("%q is not valid storage id", args[0])
+	}
+	c.id = args[0]
+
+	return nil
+}
+
+func (c *storageCommand) Run(ctx *cmd.Context) (err error) {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		if err != nil || !c.summary || c.storageInfo == nil {
+			return
+		}
+
+		switch c.storageInfo.Life {
+		case life.Dead:
+			ctx.Infof("storage %q has been removed", c.id)
+		case life.Dying:
+			ctx.Infof("storage %q is being removed", c.id)
+		default:
+			ctx.Infof("storage %q is alive", c.id)
+			outputStorageSummary(ctx.Stdout, scopedContext, c.storageInfo)
+		}
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	err = strategy.Run(ctx, c.id, c.query, c.waitFor(c.query, scopedContext, ctx), emptyNotify)
+	return errors.Trace(err)
+}
+
+func (c *storageCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
+	return func(id string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Verbosef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.StorageInstanceInfo:
+				if entityInfo.Id != id {
+					break
+				}
+
+				// A removed storage instance is reported as dead, so that
+				// a query can wait for the storage to go away.
+				if delta.Removed {
+					removed := *entityInfo
+					removed.Life = life.Dead
+					removed.Attachments = nil
+					entityInfo = &removed
+				}
+
+				c.storageInfo = entityInfo
+			}
+		}
+
+		if c.storageInfo == nil {
+			logger.Infof("storage %q not found, waiting...", id)
+			return false, nil
+		}
+
+		scope := MakeStorageScope(ctx, c.storageInfo)
+		if done, err := runQuery(input, q, scope); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+
+		logger.Infof("storage %q found, waiting...", id)
+		return false, nil
+	}
+}
+
+// StorageScope allows the query to introspect a storage instance entity.
+type StorageScope struct {
+	ctx         ScopeContext
+	StorageInfo *params.StorageInstanceInfo
+}
+
+// MakeStorageScope creates a StorageScope from a StorageInstanceInfo.
+func MakeStorageScope(ctx ScopeContext, info *params.StorageInstanceInfo) StorageScope {
+	return StorageScope{
+		ctx:         ctx,
+		StorageInfo: info,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m StorageScope) GetIdents() []string {
+	idents := set.NewStrings(getIdents(m.StorageInfo)...)
+	return set.NewStrings("attachment-count", "attachments").Union(idents).SortedValues()
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m StorageScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "id":
+		return query.NewString(m.StorageInfo.Id), nil
+	case "kind":
+		return query.NewString(m.StorageInfo.Kind), nil
+	case "owner":
+		return query.NewString(tagToId(m.StorageInfo.Owner)), nil
+	case "storage-name":
+		return query.NewString(m.StorageInfo.StorageName), nil
+	case "pool":
+		return query.NewString(m.StorageInfo.Pool), nil
+	case "life":
+		return query.NewString(string(m.StorageInfo.Life)), nil
+	case "attachment-count":
+		var count int64
+		for _, attachmentLife := range m.StorageInfo.Attachments {
+			if attachmentLife == life.Alive {
+				count++
+			}
+		}
+		return query.NewInteger(count), nil
+	case "attachments":
+		scopes := make(map[string]query.Scope)
+		for unit, attachmentLife := range m.StorageInfo.Attachments {
+			scopes[unit] = MakeStorageAttachmentScope(m.ctx.Child(name, unit), unit, attachmentLife)
+		}
+		return NewScopedBox(scopes), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on StorageInstanceInfo", name)
+}
+
+// StorageAttachmentScope allows the query to introspect the attachment of
+// a storage instance to a unit.
+type StorageAttachmentScope struct {
+	ctx  ScopeContext
+	Unit string
+	Life life.Value
+}
+
+// MakeStorageAttachmentScope creates a StorageAttachmentScope for the
+// attachment to the given unit.
+func MakeStorageAttachmentScope(ctx ScopeContext, unit string, attachmentLife life.Value) StorageAttachmentScope {
+	return StorageAttachmentScope{
+		ctx:  ctx,
+		Unit: unit,
+		Life: attachmentLife,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m StorageAttachmentScope) GetIdents() []string {
+	return []string{"life", "unit"}
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m StorageAttachmentScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "unit":
+		return query.NewString(m.Unit), nil
+	case "life":
+		return query.NewString(string(m.Life)), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on storage attachment", name)
+}
+
+func outputStorageSummary(writer io.Writer, scopedContext ScopeContext, storageInfo *params.StorageInstanceInfo) {
+	result := struct {
+		Elements map[string]interface{} `yaml:"properties"`
+	}{
+		Elements: make(map[string]interface{}),
+	}
+
+	idents := scopedContext.RecordedIdents()
+	for _, ident := range idents {
+		if ident == "attachments" {
+			continue
+		}
+		scope := MakeStorageScope(scopedContext, storageInfo)
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Elements[ident] = box.Value()
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+type storageScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&storageScopeSuite{})
+
+func (s *storageScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field       string
+		StorageInfo *params.StorageInstanceInfo
+		Expected    query.Box
+	}{{
+		Field:       "id",
+		StorageInfo: &params.StorageInstanceInfo{Id: "data/0"},
+		Expected:    query.NewString("data/0"),
+	}, {
+		Field:       "kind",
+		StorageInfo: &params.StorageInstanceInfo{Kind: "block"},
+		Expected:    query.NewString("block"),
+	}, {
+		Field:       "owner",
+		StorageInfo: &params.StorageInstanceInfo{Owner: "unit-postgresql-0"},
+		Expected:    query.NewString("postgresql/0"),
+	}, {
+		Field:       "storage-name",
+		StorageInfo: &params.StorageInstanceInfo{StorageName: "data"},
+		Expected:    query.NewString("data"),
+	}, {
+		Field:       "pool",
+		StorageInfo: &params.StorageInstanceInfo{Pool: "loop"},
+		Expected:    query.NewString("loop"),
+	}, {
+		Field:       "life",
+		StorageInfo: &params.StorageInstanceInfo{Life: life.Alive},
+		Expected:    query.NewString("alive"),
+	}, {
+		Field: "attachment-count",
+		StorageInfo: &params.StorageInstanceInfo{Attachments: map[string]life.Value{
+			"postgresql/0": life.Alive,
+			"postgresql/1": life.Dying,
+		}},
+		Expected: query.NewInteger(1),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := MakeStorageScope(MakeScopeContext(), test.StorageInfo)
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *storageScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := MakeStorageScope(MakeScopeContext(), &params.StorageInstanceInfo{})
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `.*"bad" on StorageInstanceInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *storageScopeSuite) TestAttachmentsQuery(c *gc.C) {
+	scope := MakeStorageScope(MakeScopeContext(), &params.StorageInstanceInfo{
+		Attachments: map[string]life.Value{
+			"postgresql/0": life.Alive,
+		},
+	})
+
+	q, err := query.Parse(`any(attachments, a => a.unit=="postgresql/0" && a.life=="alive")`)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := q.BuiltinsRun(scope)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.IsTrue)
+}
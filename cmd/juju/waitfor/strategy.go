@@ -36,11 +36,18 @@ type Callback = func(EventType)
 // can be changed depending on the callee.
 type StrategyFunc func(string, []params.Delta, query.Query) (bool, error)
 
+// reevaluateInterval is how often the query is run again when there
+// are no changes, so that time based queries can reach their goal.
+const reevaluateInterval = 5 * time.Second
+
 // Strategy defines a series of instructions to run for a given wait for
 // plan.
 type Strategy struct {
-	ClientFn    func() (api.WatchAllAPI, error)
-	Timeout     time.Duration
+	ClientFn func() (api.WatchAllAPI, error)
+	Timeout  time.Duration
+	// Clock is used to time out and re-evaluate the query.
+	// It defaults to the wall clock.
+	Clock       clock.Clock
 	subscribers []Callback
 }
 
@@ -62,7 +69,7 @@ func (s *Strategy) Run(
 	}
 
 	return retry.Call(retry.CallArgs{
-		Clock:       clock.WallClock,
+		Clock:       s.clock(),
 		Delay:       5 * time.Second,
 		MaxDuration: s.Timeout,
 		Stop:        ctx.Done(),
@@ -97,24 +104,41 @@ func (s *Strategy) run(q query.Query, name string, input string, fn StrategyFunc
 		_ = watcher.Stop()
 	}()
 
-	timeout := make(chan struct{})
-	go func() {
-		select {
-		case <-time.After(s.Timeout):
-			close(timeout)
-			_ = watcher.Stop()
-		}
-	}()
+	type next struct {
+		deltas []params.Delta
+		err    error
+	}
+	// Next blocks until there are changes, so it's called in the
+	// background so that the query can be re-evaluated as time passes.
+	// Any call still in progress returns once the watcher is stopped.
+	watchNext := func() <-chan next {
+		ch := make(chan next, 1)
+		go func() {
+			deltas, err := watcher.Next()
+			ch <- next{deltas: deltas, err: err}
+		}()
+		return ch
+	}
 
+	clk := s.clock()
+	timeout := clk.After(s.Timeout)
+	changes := watchNext()
+	var reevaluate <-chan time.Time
 	for {
-		deltas, err := watcher.Next()
-		if err != nil {
-			select {
-			case <-timeout:
-				return errors.Errorf("timed out waiting for %q to reach goal state", name)
-			default:
-				return errors.Trace(err)
+		var deltas []params.Delta
+		select {
+		case n := <-changes:
+			if n.err != nil {
+				return errors.Trace(n.err)
 			}
+			deltas = n.deltas
+			changes = nil
+		case <-reevaluate:
+			// Queries using since depend on the current time as well
+			// as the deltas, so they're run again even without any
+			// changes.
+		case <-timeout:
+			return errors.Errorf("timed out waiting for %q to reach goal state", name)
 		}
 
 		done, err := fn(name, deltas, q)
@@ -123,7 +147,18 @@ func (s *Strategy) run(q query.Query, name string, input string, fn StrategyFunc
 		} else if done {
 			return nil
 		}
+		if changes == nil {
+			changes = watchNext()
+		}
+		reevaluate = clk.After(reevaluateInterval)
+	}
+}
+
+func (s *Strategy) clock() clock.Clock {
+	if s.Clock == nil {
+		return clock.WallClock
 	}
+	return s.Clock
 }
 
 func (s *Strategy) dispatch(event EventType) {
@@ -213,6 +248,8 @@ func MakeScopeContext() ScopeContext {
 		idents: set.NewStrings(),
 		children: map[string]map[string]ScopeContext{
 			"applications": make(map[string]ScopeContext),
+			"attachments":  make(map[string]ScopeContext),
+			"consumers":    make(map[string]ScopeContext),
 			"machines":     make(map[string]ScopeContext),
 			"units":        make(map[string]ScopeContext),
 		},
@@ -7,6 +7,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
@@ -16,6 +18,7 @@ import (
 	"github.com/juju/juju/cmd/juju/waitfor/api/mocks"
 	"github.com/juju/juju/cmd/juju/waitfor/query"
 	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type strategySuite struct {
@@ -95,6 +98,65 @@ func (s *strategySuite) TestRunWithCallback(c *gc.C) {
 	c.Assert(eventType, gc.Equals, WatchAllStarted)
 }
 
+func (s *strategySuite) TestRunReevaluatesWithoutChanges(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	expected := []params.Delta{{
+		Entity: &MockEntityInfo{
+			Name: "meshuggah",
+		},
+	}}
+
+	stopped := make(chan struct{})
+	allWatcher := mocks.NewMockAllWatcher(ctrl)
+	gomock.InOrder(
+		allWatcher.EXPECT().Next().Return(expected, nil),
+		// No more changes arrive until the watcher is stopped.
+		allWatcher.EXPECT().Next().DoAndReturn(func() ([]params.Delta, error) {
+			<-stopped
+			return nil, errors.New("watcher was stopped")
+		}),
+	)
+	allWatcher.EXPECT().Stop().DoAndReturn(func() error {
+		close(stopped)
+		return nil
+	})
+
+	client := mocks.NewMockWatchAllAPI(ctrl)
+	client.EXPECT().WatchAll().Return(allWatcher, nil)
+
+	clock := testclock.NewClock(time.Now())
+	strategy := Strategy{
+		ClientFn: func() (api.WatchAllAPI, error) {
+			return client, nil
+		},
+		Timeout: time.Hour,
+		Clock:   clock,
+	}
+
+	var calls [][]params.Delta
+	done := make(chan error, 1)
+	go func() {
+		done <- strategy.Run(context.Background(), "generic", `life=="active"`, func(_ string, d []params.Delta, _ query.Query) (bool, error) {
+			calls = append(calls, d)
+			// The goal is only reached as time passes.
+			return len(calls) == 2, nil
+		}, emptyNotify)
+	}()
+
+	// Wait for the timeout and the re-evaluation timers.
+	err := clock.WaitAdvance(reevaluateInterval, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for strategy")
+	}
+	c.Assert(calls, gc.DeepEquals, [][]params.Delta{expected, nil})
+}
+
 func (s *strategySuite) TestRunWithInvalidQuery(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
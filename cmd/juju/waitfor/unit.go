@@ -88,6 +88,7 @@ func (c *unitCommand) Info() *cmd.Info {
 			"wait-for model",
 			"wait-for application",
 			"wait-for machine",
+			"wait-for storage",
 		},
 	})
 }
@@ -223,7 +224,7 @@ func MakeUnitScope(ctx ScopeContext, info *params.UnitInfo, machineInfos map[str
 // GetIdents returns the identifiers with in a given scope.
 func (m UnitScope) GetIdents() []string {
 	idents := set.NewStrings(getIdents(m.UnitInfo)...)
-	return set.NewStrings("machines").Union(idents).SortedValues()
+	return set.NewStrings("machines", "workload-since", "agent-since").Union(idents).SortedValues()
 }
 
 // GetIdentValue returns the value of the identifier in a given scope.
@@ -260,8 +261,12 @@ func (m UnitScope) GetIdentValue(name string) (query.Box, error) {
 		return query.NewString(string(m.UnitInfo.WorkloadStatus.Current)), nil
 	case "workload-message":
 		return query.NewString(m.UnitInfo.WorkloadStatus.Message), nil
+	case "workload-since":
+		return query.NewString(formatSince(m.UnitInfo.WorkloadStatus.Since)), nil
 	case "agent-status":
 		return query.NewString(string(m.UnitInfo.AgentStatus.Current)), nil
+	case "agent-since":
+		return query.NewString(formatSince(m.UnitInfo.AgentStatus.Since)), nil
 	case "machines":
 		scopes := make(map[string]query.Scope)
 		for k, machine := range m.MachineInfos {
@@ -14,9 +14,10 @@ type Logger interface {
 }
 
 var waitForDoc = `
-The wait-for set of commands (model, application, machine and unit) defines 
-a way to wait for a goal state to be reached. The goal state can be defined
-programmatically using the query DSL (domain specific language).
+The wait-for set of commands (model, application, machine, unit, relation,
+offer, secret and storage) defines a way to wait for a goal state to be
+reached. The goal state can be defined programmatically using the query DSL
+(domain specific language).
 
 The wait-for command is an optimized alternative to the status command for 
 determining programmatically if a goal state has been reached. The wait-for
@@ -32,10 +33,24 @@ Built-in functions are provided to help define the goal state. The built-in
 functions are defined in the query package. Examples of built-in functions
 include len, print, forEach (lambda), startsWith and endsWith.
 
+The aggregate functions all, any and count take a lambda, like forEach, and
+report whether every entity, at least one entity, or how many entities match.
+As with forEach, all is false when there are no entities.
+
+Strings can be matched against a regular expression with the =~ operator.
+
+Durations such as 30s, 5m or 1h30m can be compared with the result of the
+since function, which returns the time elapsed since a "-since" identifier,
+for example since(status-since) > 5m.
+
 See also:
     wait-for model
     wait-for application
     wait-for machine
+    wait-for offer
+    wait-for relation
+    wait-for secret
+    wait-for storage
     wait-for unit
 `
 
@@ -51,6 +66,11 @@ Waits for the mysql application to be active or idle.
 Waits for the model units to all start with ubuntu.
 
     juju wait-for model default --query='forEach(units, unit => startsWith(unit.name, "ubuntu"))'
+
+Waits for all the units of the mysql application to have been active for at
+least 2 minutes.
+
+    juju wait-for application mysql --query='all(units, unit => unit.workload-status=="active" && since(unit.workload-since) >= 2m)'
 `
 
 // NewWaitForCommand creates the wait-for supercommand and registers the
@@ -67,6 +87,10 @@ func NewWaitForCommand() cmd.Command {
 	waitFor.Register(newApplicationCommand())
 	waitFor.Register(newMachineCommand())
 	waitFor.Register(newModelCommand())
+	waitFor.Register(newOfferCommand())
+	waitFor.Register(newRelationCommand())
+	waitFor.Register(newSecretCommand())
+	waitFor.Register(newStorageCommand())
 	waitFor.Register(newUnitCommand())
 	return waitFor
 }
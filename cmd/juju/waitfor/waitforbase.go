@@ -6,6 +6,7 @@ package waitfor
 import (
 	"reflect"
 	"strings"
+	"time"
 
 	apiclient "github.com/juju/juju/api/client/client"
 	"github.com/juju/juju/cmd/juju/waitfor/api"
@@ -37,6 +38,15 @@ func runQuery(input string, q query.Query, scope query.Scope) (bool, error) {
 	return res, nil
 }
 
+// formatSince returns the time in the RFC3339 form expected by the since
+// query function, or an empty string if there is no time.
+func formatSince(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 func getIdents(q interface{}) []string {
 	var res []string
 
@@ -85,6 +85,7 @@ import (
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/modelworkermanager"
 	psworker "github.com/juju/juju/worker/pubsub"
+	"github.com/juju/juju/worker/querylogger"
 	"github.com/juju/juju/worker/upgradedatabase"
 	"github.com/juju/juju/worker/upgradesteps"
 	"github.com/juju/juju/wrench"
@@ -582,6 +583,9 @@ func (a *MachineAgent) makeEngineCreator(
 		// which is set to the current StatePool managed by the state
 		// tracker in controller agents.
 		var statePoolReporter statePoolIntrospectionReporter
+		// slowQueryReporter is an introspection.SlowQueryReporter, which
+		// is set to the query logger worker in controller agents.
+		var slowQueryReporter slowQueryIntrospectionReporter
 		registerIntrospectionHandlers := func(handle func(path string, h http.Handler)) {
 			handle("/metrics/", promhttp.HandlerFor(a.prometheusRegistry, promhttp.HandlerOpts{}))
 		}
@@ -622,6 +626,7 @@ func (a *MachineAgent) makeEngineCreator(
 			TransactionPruneInterval:          time.Hour,
 			MachineLock:                       a.machineLock,
 			SetStatePool:                      statePoolReporter.Set,
+			SetSlowQueryReporter:              slowQueryReporter.Set,
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
 			NewModelWorker:                    a.startModelWorkers,
 			MuxShutdownWait:                   1 * time.Minute,
@@ -653,6 +658,7 @@ func (a *MachineAgent) makeEngineCreator(
 			MachineLock:        a.machineLock,
 			PrometheusGatherer: a.prometheusRegistry,
 			PresenceRecorder:   presenceRecorder,
+			SlowQueryReporter:  &slowQueryReporter,
 			WorkerFunc:         introspection.NewWorker,
 			Clock:              clock.WallClock,
 			LocalHub:           localHub,
@@ -1310,3 +1316,26 @@ func (h *statePoolIntrospectionReporter) IntrospectionReport() string {
 	}
 	return h.pool.IntrospectionReport()
 }
+
+// slowQueryIntrospectionReporter wraps a (possibly nil) slow query
+// reporter, calling its SlowQueryReport method or returning a message
+// if it is nil.
+type slowQueryIntrospectionReporter struct {
+	mu       sync.Mutex
+	reporter querylogger.Reporter
+}
+
+func (h *slowQueryIntrospectionReporter) Set(reporter querylogger.Reporter) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.reporter = reporter
+}
+
+func (h *slowQueryIntrospectionReporter) SlowQueryReport(n int) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.reporter == nil {
+		return "agent has no query logger set\n"
+	}
+	return h.reporter.SlowQueryReport(n)
+}
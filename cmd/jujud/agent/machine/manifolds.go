@@ -31,8 +31,10 @@ import (
 	"github.com/juju/juju/core/instance"
 	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/core/machinelock"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/paths"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/upgrades"
 	proxyconfig "github.com/juju/juju/utils/proxy"
@@ -46,6 +48,8 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupretention"
+	"github.com/juju/juju/worker/branchrollout"
 	"github.com/juju/juju/worker/caasunitsmanager"
 	"github.com/juju/juju/worker/caasupgrader"
 	"github.com/juju/juju/worker/centralhub"
@@ -77,6 +81,7 @@ import (
 	"github.com/juju/juju/worker/machiner"
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationminion"
+	"github.com/juju/juju/worker/migrationplanner"
 	"github.com/juju/juju/worker/modelcache"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/multiwatcher"
@@ -219,6 +224,12 @@ type ManifoldsConfig struct {
 	// worker running outside of the dependency engine.
 	SetStatePool func(*state.StatePool)
 
+	// SetSlowQueryReporter is used by the query logger worker for
+	// informing the agent of the slow query reporter it creates, so we
+	// can pass it to the introspection worker running outside of the
+	// dependency engine.
+	SetSlowQueryReporter func(querylogger.Reporter)
+
 	// RegisterIntrospectionHTTPHandlers is a function that calls the
 	// supplied function to register introspection HTTP handlers. The
 	// function will be passed a path and a handler; the function may
@@ -696,9 +707,12 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 		})),
 
 		queryLoggerName: ifController(querylogger.Manifold(querylogger.ManifoldConfig{
-			LogDir: agentConfig.LogDir(),
-			Clock:  config.Clock,
-			Logger: loggo.GetLogger("juju.worker.querylogger"),
+			LogDir:               agentConfig.LogDir(),
+			Clock:                config.Clock,
+			Logger:               loggo.GetLogger("juju.worker.querylogger"),
+			PrometheusRegisterer: config.PrometheusRegisterer,
+			NewMetricsCollector:  querylogger.NewMetricsCollector,
+			SetReporter:          config.SetSlowQueryReporter,
 		})),
 
 		fileNotifyWatcherName: ifController(filenotifywatcher.Manifold(filenotifywatcher.ManifoldConfig{
@@ -722,6 +736,15 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker: auditconfigupdater.New,
 		})),
 
+		// The backup retention worker removes stored backups
+		// which fall outside the controller's retention policy.
+		backupRetentionName: ifController(backupretention.Manifold(backupretention.ManifoldConfig{
+			StateName: stateName,
+			Clock:     config.Clock,
+			Logger:    loggo.GetLogger("juju.worker.backupretention"),
+			NewWorker: backupretention.NewWorker,
+		})),
+
 		// The lease expiry worker constantly deletes
 		// leases with an expiry time in the past.
 		leaseExpiryName: ifController(leaseexpiry.Manifold(leaseexpiry.ManifoldConfig{
@@ -768,6 +791,28 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Logger:        loggo.GetLogger("juju.worker.credentialvalidator"),
 		}),
 
+		// The migration planner starts the migrations called for by
+		// migration plans, within each plan's limits, and retries
+		// those which fail.
+		migrationPlannerName: ifPrimaryController(migrationplanner.Manifold(migrationplanner.ManifoldConfig{
+			StateName:         stateName,
+			LeaseManagerName:  leaseManagerName,
+			Clock:             config.Clock,
+			Logger:            loggo.GetLogger("juju.worker.migrationplanner"),
+			PrecheckMigration: precheckPlannedMigration(config.PresenceRecorder),
+			NewWorker:         migrationplanner.NewWorker,
+		})),
+
+		// The branch rollout worker moves units onto branches with a
+		// rollout policy step by step, committing or aborting the
+		// branches as their policies direct.
+		branchRolloutName: ifPrimaryController(branchrollout.Manifold(branchrollout.ManifoldConfig{
+			StateName: stateName,
+			Clock:     config.Clock,
+			Logger:    loggo.GetLogger("juju.worker.branchrollout"),
+			NewWorker: branchrollout.NewWorker,
+		})),
+
 		secretBackendRotateName: ifNotMigrating(ifPrimaryController(secretbackendrotate.Manifold(
 			secretbackendrotate.ManifoldConfig{
 				APICallerName: apiCallerName,
@@ -1055,6 +1100,21 @@ func clockManifold(clock clock.Clock) dependency.Manifold {
 	}
 }
 
+// precheckPlannedMigration returns a function which runs the same
+// prechecks for the migrations started by migration plans as the
+// controller facade runs for migrations initiated by clients.
+func precheckPlannedMigration(recorder presence.Recorder) migrationplanner.PrecheckMigrationFunc {
+	return func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, leaders map[string]string) error {
+		connections := recorder.Connections()
+		return migration.PrecheckMigration(
+			st, ctlrSt, targetInfo,
+			connections.ForModel(st.ModelUUID()),
+			connections.ForModel(ctlrSt.ModelUUID()),
+			leaders,
+		)
+	}
+}
+
 var ifFullyUpgraded = engine.Housing{
 	Flags: []string{
 		upgradeStepsFlagName,
@@ -1169,6 +1229,9 @@ const (
 	changeStreamName              = "change-stream"
 	certificateUpdaterName        = "certificate-updater"
 	auditConfigUpdaterName        = "audit-config-updater"
+	backupRetentionName           = "backup-retention"
+	migrationPlannerName          = "migration-planner"
+	branchRolloutName             = "branch-rollout"
 	leaseExpiryName               = "lease-expiry"
 	leaseManagerName              = "lease-manager"
 	stateConverterName            = "state-converter"
@@ -65,6 +65,8 @@ func (s *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"backup-retention",
+			"branch-rollout",
 			"broker-tracker",
 			"central-hub",
 			"certificate-updater",
@@ -98,6 +100,7 @@ func (s *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"machiner",
 			"migration-fortress",
 			"migration-minion",
+			"migration-planner",
 			"migration-inactive-flag",
 			"model-cache",
 			"model-cache-initialized-flag",
@@ -147,6 +150,8 @@ func (s *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"backup-retention",
+			"branch-rollout",
 			"caas-units-manager",
 			"central-hub",
 			"certificate-watcher",
@@ -168,6 +173,7 @@ func (s *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"logging-config-updater",
 			"migration-fortress",
 			"migration-minion",
+			"migration-planner",
 			"migration-inactive-flag",
 			"model-cache",
 			"model-cache-initialized-flag",
@@ -226,6 +232,8 @@ func (s *ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"api-config-watcher",
 		"api-server",
 		"audit-config-updater",
+		"backup-retention",
+		"branch-rollout",
 		"certificate-updater",
 		"certificate-watcher",
 		"central-hub",
@@ -262,6 +270,7 @@ func (s *ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-minion",
+		"migration-planner",
 		"upgrade-check-flag",
 		"upgrade-check-gate",
 		"upgrade-database-flag",
@@ -296,6 +305,7 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 	controllerWorkers := set.NewStrings(
 		"certificate-watcher",
 		"audit-config-updater",
+		"backup-retention",
 		"is-primary-controller-flag",
 		"model-cache-initialized-flag",
 		"model-cache-initialized-gate",
@@ -313,7 +323,9 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 
 	// Explicitly guarded by ifPrimaryController.
 	primaryControllerWorkers := set.NewStrings(
+		"branch-rollout",
 		"external-controller-updater",
+		"migration-planner",
 		"secret-backend-rotate",
 	)
 
@@ -499,6 +511,23 @@ var expectedMachineManifoldsWithDependenciesIAAS = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"backup-retention": {
+		"agent",
+		"is-controller-flag",
+		"state",
+		"state-config-watcher",
+	},
+
+	"branch-rollout": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"state",
+		"state-config-watcher",
+	},
+
 	"broker-tracker": {
 		"agent",
 		"api-caller",
@@ -819,6 +848,20 @@ var expectedMachineManifoldsWithDependenciesIAAS = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"migration-planner": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"db-accessor",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"lease-manager",
+		"query-logger",
+		"state",
+		"state-config-watcher",
+	},
+
 	"model-cache": {
 		"agent",
 		"central-hub",
@@ -1122,6 +1165,23 @@ var expectedMachineManifoldsWithDependenciesCAAS = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"backup-retention": {
+		"agent",
+		"is-controller-flag",
+		"state",
+		"state-config-watcher",
+	},
+
+	"branch-rollout": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"state",
+		"state-config-watcher",
+	},
+
 	"central-hub": {"agent", "state-config-watcher"},
 
 	"certificate-watcher": {
@@ -1296,6 +1356,20 @@ var expectedMachineManifoldsWithDependenciesCAAS = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"migration-planner": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"db-accessor",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"lease-manager",
+		"query-logger",
+		"state",
+		"state-config-watcher",
+	},
+
 	"model-cache": {
 		"agent",
 		"central-hub",
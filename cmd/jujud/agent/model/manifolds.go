@@ -314,7 +314,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
+				OpenFn: sinks.Open,
 			}},
 			Logger: config.LoggingContext.GetLogger("juju.worker.logforwarder"),
 		})),
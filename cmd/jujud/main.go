@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/config"
 	"github.com/juju/juju/cmd/jujud/dumplogs"
 	"github.com/juju/juju/cmd/jujud/introspect"
+	"github.com/juju/juju/cmd/jujud/restorebackup"
 	"github.com/juju/juju/cmd/jujud/run"
 	"github.com/juju/juju/core/arch"
 	"github.com/juju/juju/core/machinelock"
@@ -281,6 +282,7 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 	jujud.Register(caasOperatorAgent)
 
 	jujud.Register(jujudagentcmd.NewCheckConnectionCommand(agentConf, jujudagentcmd.ConnectAsAgent))
+	jujud.Register(restorebackup.NewCommand())
 
 	code = cmd.Main(jujud, ctx, args[1:])
 	return code, nil
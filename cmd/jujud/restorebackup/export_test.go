@@ -0,0 +1,35 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package restorebackup
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/jujud/agent/agentconf"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/backups"
+)
+
+// NewCommandForTest returns a restore-backup command using the given
+// backups, agent service and database session.
+func NewCommandForTest(
+	newBackups func(*backups.Paths) backups.Backups,
+	agentService AgentService,
+	session backups.DBSession,
+) cmd.Command {
+	return &restoreBackupCommand{
+		agentConfig: agentconf.NewAgentConf(""),
+		newBackups:  newBackups,
+		newAgentService: func(name string) (AgentService, error) {
+			if name != "jujud-machine-0" {
+				return nil, errors.NotFoundf("service %q", name)
+			}
+			return agentService, nil
+		},
+		openSession: func(*mongo.MongoInfo) (backups.DBSession, func(), error) {
+			return session, func() {}, nil
+		},
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package restorebackup provides the jujud command which restores a
+// controller from a backup archive. It is started by the Backups
+// facade, outside the controller agent's service, so that it can stop
+// the agent while the restore runs and start it again afterwards.
+package restorebackup
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/loggo"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/names/v5"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/jujud/agent/agentconf"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/state/backups"
+	jujuversion "github.com/juju/juju/version"
+)
+
+var logger = loggo.GetLogger("juju.cmd.jujud.restorebackup")
+
+// AgentService is the init system service running the controller agent.
+type AgentService interface {
+	Start() error
+	Stop() error
+}
+
+// NewCommand returns a new Command instance which implements the
+// "jujud restore-backup" command.
+func NewCommand() cmd.Command {
+	return &restoreBackupCommand{
+		agentConfig: agentconf.NewAgentConf(""),
+		newBackups:  backups.NewBackups,
+		newAgentService: func(name string) (AgentService, error) {
+			return service.NewServiceReference(name)
+		},
+		openSession: openSession,
+	}
+}
+
+type restoreBackupCommand struct {
+	cmd.CommandBase
+	agentConfig agentconf.AgentConf
+	machineID   string
+	backupDir   string
+	id          string
+
+	newBackups      func(*backups.Paths) backups.Backups
+	newAgentService func(name string) (AgentService, error)
+	openSession     func(*mongo.MongoInfo) (backups.DBSession, func(), error)
+}
+
+// Info implements cmd.Command.
+func (c *restoreBackupCommand) Info() *cmd.Info {
+	doc := `
+Restores the controller from a backup archive held in the backup
+directory. The controller agent is stopped while the restore runs, and
+is started again once it has finished, whether or not the restore
+succeeded.
+
+Progress is recorded in the agent's data directory, where the Backups
+facade reads it once the agent is running again. This command is run
+by the controller when a restore is requested with
+'juju restore-backup'; it is not normally run by hand.
+`[1:]
+	return jujucmd.Info(&cmd.Info{
+		Name:    "restore-backup",
+		Args:    "<backup ID>",
+		Purpose: "restore the controller from a backup archive",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *restoreBackupCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.agentConfig.AddFlags(f)
+	f.StringVar(&c.machineID, "machine-id", "", "id of the controller machine on this host")
+	f.StringVar(&c.backupDir, "backup-dir", "", "directory holding the backup archive")
+}
+
+// Init implements cmd.Command.
+func (c *restoreBackupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing backup ID")
+	}
+	c.id, args = args[0], args[1:]
+	if err := c.agentConfig.CheckArgs(args); err != nil {
+		return errors.Trace(err)
+	}
+	if !names.IsValidMachine(c.machineID) {
+		return errors.New("--machine-id option expects a non-negative integer")
+	}
+	if c.backupDir == "" {
+		return errors.New("missing --backup-dir")
+	}
+	return errors.Trace(c.agentConfig.ReadConfig(names.NewMachineTag(c.machineID).String()))
+}
+
+// Run implements cmd.Command.
+func (c *restoreBackupCommand) Run(ctx *cmd.Context) (err error) {
+	dataDir := c.agentConfig.DataDir()
+	status := &backups.RestoreStatus{ID: c.id}
+	progress := func(step string) {
+		logger.Infof("restore of %q: %s", c.id, step)
+		ctx.Infof("%s", step)
+		status.Steps = append(status.Steps, step)
+		if err := backups.WriteRestoreStatus(dataDir, status); err != nil {
+			logger.Errorf("cannot record restore progress: %v", err)
+		}
+	}
+	defer func() {
+		status.Done = true
+		if err != nil {
+			logger.Errorf("restore of %q failed: %v", c.id, err)
+			status.Error = err.Error()
+		}
+		if err := backups.WriteRestoreStatus(dataDir, status); err != nil {
+			logger.Errorf("cannot record restore result: %v", err)
+		}
+	}()
+
+	agentConfig := c.agentConfig.CurrentConfig()
+	mgoInfo, ok := agentConfig.MongoInfo()
+	if !ok {
+		return errors.New("no database connection info available (is this a controller host?)")
+	}
+	session, closeSession, err := c.openSession(mgoInfo)
+	if err != nil {
+		return errors.Annotate(err, "connecting to database")
+	}
+	defer closeSession()
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// The agent's workers use the database being replaced, so the
+	// agent is stopped for the duration of the restore. It is always
+	// started again, so that a failed restore leaves a running
+	// controller behind.
+	agentService, err := c.newAgentService("jujud-" + names.NewMachineTag(c.machineID).String())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	progress("stopping controller agent")
+	if err := agentService.Stop(); err != nil {
+		return errors.Annotate(err, "stopping controller agent")
+	}
+	defer func() {
+		progress("starting controller agent")
+		if startErr := agentService.Start(); startErr != nil {
+			startErr = errors.Annotate(startErr, "starting controller agent")
+			if err == nil {
+				err = startErr
+			} else {
+				logger.Errorf("%v", startErr)
+			}
+		}
+	}()
+
+	paths := &backups.Paths{
+		BackupDir: c.backupDir,
+		DataDir:   dataDir,
+		LogsDir:   agentConfig.LogDir(),
+	}
+	status.Metadata, err = c.newBackups(paths).Restore(c.id, backups.RestoreArgs{
+		DBInfo:            dbInfo,
+		Session:           session,
+		MachineID:         c.machineID,
+		ControllerVersion: jujuversion.Current,
+		Progress:          progress,
+	})
+	return errors.Trace(err)
+}
+
+func openSession(info *mongo.MongoInfo) (backups.DBSession, func(), error) {
+	session, err := mongo.DialWithInfo(*info, mongo.DefaultDialOpts())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return sessionShim{session}, session.Close, nil
+}
+
+type sessionShim struct {
+	*mgo.Session
+}
+
+func (s sessionShim) DB(name string) backups.Database {
+	return s.Session.DB(name)
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package restorebackup_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/cmd/jujud/restorebackup"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	coretesting "github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type restoreBackupSuite struct {
+	testing.IsolationSuite
+
+	dataDir   string
+	backupDir string
+	service   *fakeService
+	backups   *backupstesting.FakeBackups
+}
+
+var _ = gc.Suite(&restoreBackupSuite{})
+
+func (s *restoreBackupSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dataDir = c.MkDir()
+	s.backupDir = c.MkDir()
+	s.service = &fakeService{}
+	s.backups = &backupstesting.FakeBackups{
+		Meta: backupstesting.NewMetadataStarted(),
+	}
+
+	conf, err := agent.NewStateMachineConfig(agent.AgentConfigParams{
+		Paths:             agent.Paths{DataDir: s.dataDir},
+		Tag:               names.NewMachineTag("0"),
+		UpgradedToVersion: jujuversion.Current,
+		Password:          "sekrit",
+		CACert:            coretesting.CACert,
+		APIAddresses:      []string{"10.0.0.1:17070"},
+		Nonce:             "a nonce",
+		Controller:        coretesting.ControllerTag,
+		Model:             coretesting.ModelTag,
+		JujuDBSnapChannel: controller.DefaultJujuDBSnapChannel,
+	}, controller.StateServingInfo{
+		Cert:         coretesting.ServerCert,
+		PrivateKey:   coretesting.ServerKey,
+		CAPrivateKey: coretesting.CAKey,
+		StatePort:    37017,
+		APIPort:      17070,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.Write(), jc.ErrorIsNil)
+}
+
+// fakeService records the calls made to the controller agent service.
+type fakeService struct {
+	calls   []string
+	stopErr error
+}
+
+func (f *fakeService) Start() error {
+	f.calls = append(f.calls, "Start")
+	return nil
+}
+
+func (f *fakeService) Stop() error {
+	f.calls = append(f.calls, "Stop")
+	return f.stopErr
+}
+
+// fakeSession reports a single database of a fixed size.
+type fakeSession struct{}
+
+func (fakeSession) DatabaseNames() ([]string, error) {
+	return []string{"juju"}, nil
+}
+
+func (f fakeSession) DB(string) backups.Database {
+	return f
+}
+
+func (fakeSession) Run(cmd interface{}, result interface{}) error {
+	data, err := bson.Marshal(bson.M{"dataSize": float64(1)})
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, result)
+}
+
+func (s *restoreBackupSuite) run(c *gc.C, args ...string) error {
+	command := restorebackup.NewCommandForTest(func(paths *backups.Paths) backups.Backups {
+		c.Check(paths.BackupDir, gc.Equals, s.backupDir)
+		c.Check(paths.DataDir, gc.Equals, s.dataDir)
+		return s.backups
+	}, s.service, fakeSession{})
+	if args == nil {
+		args = []string{
+			"--data-dir", s.dataDir,
+			"--machine-id", "0",
+			"--backup-dir", s.backupDir,
+			"backup-id",
+		}
+	}
+	_, err := cmdtesting.RunCommand(c, command, args...)
+	return err
+}
+
+func (s *restoreBackupSuite) TestRestore(c *gc.C) {
+	s.backups.RestoreHook = func() {
+		// The agent must not be running while the database is restored.
+		c.Check(s.service.calls, jc.DeepEquals, []string{"Stop"})
+	}
+
+	err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backups.Calls, jc.DeepEquals, []string{"Restore"})
+	c.Check(s.backups.IDArg, gc.Equals, "backup-id")
+	c.Check(s.backups.DBInfoArg, gc.NotNil)
+	c.Check(s.service.calls, jc.DeepEquals, []string{"Stop", "Start"})
+
+	status, err := backups.ReadRestoreStatus(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.ID, gc.Equals, "backup-id")
+	c.Check(status.Done, jc.IsTrue)
+	c.Check(status.Error, gc.Equals, "")
+	c.Check(status.Steps, jc.DeepEquals, []string{
+		"stopping controller agent",
+		"restoring",
+		"starting controller agent",
+	})
+	c.Assert(status.Metadata, gc.NotNil)
+	c.Check(status.Metadata.Origin, jc.DeepEquals, s.backups.Meta.Origin)
+}
+
+func (s *restoreBackupSuite) TestRestoreFailedStartsAgent(c *gc.C) {
+	s.backups.Error = errors.New("failed!")
+
+	err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "failed!")
+	c.Check(s.service.calls, jc.DeepEquals, []string{"Stop", "Start"})
+
+	status, err := backups.ReadRestoreStatus(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Done, jc.IsTrue)
+	c.Check(status.Error, gc.Equals, "failed!")
+	c.Check(status.Steps, jc.DeepEquals, []string{
+		"stopping controller agent",
+		"restoring",
+		"starting controller agent",
+	})
+}
+
+func (s *restoreBackupSuite) TestStopAgentFailed(c *gc.C) {
+	s.service.stopErr = errors.New("boom")
+
+	err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "stopping controller agent: boom")
+	c.Check(s.backups.Calls, gc.HasLen, 0)
+
+	status, err := backups.ReadRestoreStatus(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Done, jc.IsTrue)
+	c.Check(status.Error, gc.Equals, "stopping controller agent: boom")
+}
+
+func (s *restoreBackupSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--data-dir", s.dataDir},
+		err:  "missing backup ID",
+	}, {
+		args: []string{"--data-dir", s.dataDir, "--backup-dir", s.backupDir, "backup-id"},
+		err:  "--machine-id option expects a non-negative integer",
+	}, {
+		args: []string{"--data-dir", s.dataDir, "--machine-id", "0", "backup-id"},
+		err:  "missing --backup-dir",
+	}, {
+		args: []string{"--data-dir", s.dataDir, "--machine-id", "0", "--backup-dir", s.backupDir, "backup-id", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	c.Check(s.service.calls, gc.HasLen, 0)
+}
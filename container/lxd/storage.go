@@ -4,6 +4,7 @@
 package lxd
 
 import (
+	lxd "github.com/canonical/lxd/client"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/juju/errors"
 )
@@ -30,6 +31,50 @@ func (s *Server) CreateVolume(pool, name string, cfg map[string]string) error {
 	return errors.Annotatef(s.CreateStoragePoolVolume(pool, req), "creating storage pool volume %q", name)
 }
 
+// CreateVolumeSnapshot takes a snapshot with the given name of the custom
+// volume in the given storage pool.
+func (s *Server) CreateVolumeSnapshot(pool, volume, snapshot string) error {
+	req := api.StorageVolumeSnapshotsPost{Name: snapshot}
+	op, err := s.CreateStoragePoolVolumeSnapshot(pool, "custom", volume, req)
+	if err == nil {
+		err = op.Wait()
+	}
+	return errors.Annotatef(err, "creating snapshot %q of storage pool volume %q", snapshot, volume)
+}
+
+// DeleteVolumeSnapshot deletes the snapshot with the given name of the
+// custom volume in the given storage pool. A snapshot that no longer
+// exists, say because it was deleted along with the volume, is ignored.
+func (s *Server) DeleteVolumeSnapshot(pool, volume, snapshot string) error {
+	op, err := s.DeleteStoragePoolVolumeSnapshot(pool, "custom", volume, snapshot)
+	if err == nil {
+		err = op.Wait()
+	}
+	if err != nil && !IsLXDNotFound(err) {
+		return errors.Annotatef(err, "deleting snapshot %q of storage pool volume %q", snapshot, volume)
+	}
+	return nil
+}
+
+// CreateVolumeFromSnapshot creates a custom volume in the given storage
+// pool as a copy of a snapshot of another volume in the same pool.
+func (s *Server) CreateVolumeFromSnapshot(pool, name, volume, snapshot string, cfg map[string]string) error {
+	source := api.StorageVolume{
+		Name:             volume + "/" + snapshot,
+		Type:             "custom",
+		StorageVolumePut: api.StorageVolumePut{Config: cfg},
+	}
+	args := &lxd.StoragePoolVolumeCopyArgs{
+		Name:       name,
+		VolumeOnly: true,
+	}
+	op, err := s.CopyStoragePoolVolume(pool, s.InstanceServer, pool, source, args)
+	if err == nil {
+		err = op.Wait()
+	}
+	return errors.Annotatef(err, "creating storage pool volume %q from snapshot %q", name, source.Name)
+}
+
 // EnsureDefaultStorage ensures that the input profile is configured with a
 // disk device, creating a new storage pool and a device if required.
 func (s *Server) EnsureDefaultStorage(profile *api.Profile, eTag string) error {
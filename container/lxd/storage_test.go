@@ -4,6 +4,9 @@
 package lxd_test
 
 import (
+	"net/http"
+
+	lxdclient "github.com/canonical/lxd/client"
 	lxdapi "github.com/canonical/lxd/shared/api"
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
@@ -102,6 +105,73 @@ func (s *storageSuite) TestCreateVolume(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *storageSuite) TestCreateVolumeSnapshot(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "storage")
+
+	op := lxdtesting.NewMockOperation(ctrl)
+	op.EXPECT().Wait().Return(nil)
+	req := lxdapi.StorageVolumeSnapshotsPost{Name: "snapshot"}
+	cSvr.EXPECT().CreateStoragePoolVolumeSnapshot("default-pool", "custom", "volume", req).Return(op, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.CreateVolumeSnapshot("default-pool", "volume", "snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestDeleteVolumeSnapshot(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "storage")
+
+	op := lxdtesting.NewMockOperation(ctrl)
+	op.EXPECT().Wait().Return(nil)
+	cSvr.EXPECT().DeleteStoragePoolVolumeSnapshot("default-pool", "custom", "volume", "snapshot").Return(op, nil)
+	// Snapshots deleted along with their volume are ignored.
+	cSvr.EXPECT().DeleteStoragePoolVolumeSnapshot("default-pool", "custom", "gone", "snapshot").Return(
+		nil, lxdapi.StatusErrorf(http.StatusNotFound, "not found"))
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.DeleteVolumeSnapshot("default-pool", "volume", "snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuSvr.DeleteVolumeSnapshot("default-pool", "gone", "snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestCreateVolumeFromSnapshot(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "storage")
+
+	cfg := map[string]string{"size": "1024MB"}
+
+	op := lxdtesting.NewMockRemoteOperation(ctrl)
+	op.EXPECT().Wait().Return(nil)
+	source := lxdapi.StorageVolume{
+		Name: "volume/snapshot",
+		Type: "custom",
+		StorageVolumePut: lxdapi.StorageVolumePut{
+			Config: cfg,
+		},
+	}
+	args := &lxdclient.StoragePoolVolumeCopyArgs{
+		Name:       "new-volume",
+		VolumeOnly: true,
+	}
+	cSvr.EXPECT().CopyStoragePoolVolume("default-pool", cSvr, "default-pool", source, args).Return(op, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.CreateVolumeFromSnapshot("default-pool", "new-volume", "volume", "snapshot", cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *storageSuite) TestEnsureDefaultStorageDevicePresent(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
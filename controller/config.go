@@ -4,7 +4,9 @@
 package controller
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net"
 	"net/url"
 	"regexp"
 	"time"
@@ -18,6 +20,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/pki"
 )
 
@@ -92,6 +95,23 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogSinks is the list of places audit log entries are
+	// written to: any of "file", "syslog", "http" and "database".
+	AuditLogSinks = "audit-log-sinks"
+
+	// AuditLogSyslogHost is the address ("<host>:<port>") of the
+	// syslog server audit log entries are forwarded to by the syslog
+	// sink.
+	AuditLogSyslogHost = "audit-log-syslog-host"
+
+	// AuditLogHTTPURL is the URL audit log entries are POSTed to by
+	// the http sink.
+	AuditLogHTTPURL = "audit-log-http-url"
+
+	// AuditLogDatabaseRetention is how long audit log entries written
+	// by the database sink are kept.
+	AuditLogDatabaseRetention = "audit-log-database-retention"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -115,6 +135,34 @@ const (
 	// permissions model.
 	LoginTokenRefreshURL = "login-token-refresh-url"
 
+	// OIDCIssuerURL is the URL of an OpenID Connect issuer trusted to
+	// authenticate human users. The issuer's discovery document is read
+	// from <issuer>/.well-known/openid-configuration.
+	OIDCIssuerURL = "oidc-issuer-url"
+
+	// OIDCClientID is the client ID registered with the OpenID Connect
+	// issuer for this controller. ID tokens must name it in their audience.
+	OIDCClientID = "oidc-client-id"
+
+	// OIDCUsernameClaim is the ID token claim holding the name of the
+	// Juju user. The user is always treated as an external user.
+	OIDCUsernameClaim = "oidc-username-claim"
+
+	// OIDCGroupsClaim is the ID token claim holding the groups the user
+	// is a member of.
+	OIDCGroupsClaim = "oidc-groups-claim"
+
+	// OIDCGroupAccess maps OpenID Connect groups to the access their
+	// members have, as a list of "<group>=<access>[@<target>]" entries.
+	// The target is a controller, model, cloud or offer tag and defaults
+	// to the controller.
+	OIDCGroupAccess = "oidc-group-access"
+
+	// SecretBackendEncryptionKey is the base64 encoded 32 byte key used
+	// to encrypt the content stored in file secret backends. It can be
+	// set once, and cannot be changed while any content depends on it.
+	SecretBackendEncryptionKey = "secret-backend-encryption-key"
+
 	// IdentityURL sets the URL of the identity manager.
 	// Use this when users should be managed externally rather than
 	// created locally on the controller.
@@ -231,6 +279,16 @@ const (
 	// executing a model migration.
 	MigrationMinionWaitMax = "migration-agent-wait-time"
 
+	// BackupRetentionCount is the number of backup archives to keep on each
+	// controller machine. Older archives are removed by the backup retention
+	// worker. A value of 0 means that archives are not pruned by count.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupRetentionPeriod is how long backup archives are kept on each
+	// controller machine before they are removed by the backup retention
+	// worker. A value of 0 means that archives are not pruned by age.
+	BackupRetentionPeriod = "backup-retention-period"
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -303,6 +361,10 @@ const (
 	// roll the audit log file.
 	DefaultAuditLogMaxSizeMB = 300
 
+	// DefaultAuditLogDatabaseRetention is the default time audit log
+	// entries are kept in the controller database.
+	DefaultAuditLogDatabaseRetention = 30 * 24 * time.Hour
+
 	// DefaultAuditLogMaxBackups is the default number of files to
 	// keep.
 	DefaultAuditLogMaxBackups = 10
@@ -387,6 +449,26 @@ const (
 	// migration minion will wait for the migration to complete.
 	DefaultMigrationMinionWaitMax = 15 * time.Minute
 
+	// DefaultBackupRetentionCount is the default number of backup archives
+	// to keep, where 0 means that all archives are kept.
+	DefaultBackupRetentionCount = 0
+
+	// DefaultBackupRetentionPeriod is the default time to keep backup
+	// archives for, where 0 means that archives are kept forever.
+	DefaultBackupRetentionPeriod = time.Duration(0)
+
+	// DefaultOIDCUsernameClaim is the default ID token claim used as the
+	// Juju user name.
+	DefaultOIDCUsernameClaim = "email"
+
+	// DefaultOIDCGroupsClaim is the default ID token claim holding the
+	// user's groups.
+	DefaultOIDCGroupsClaim = "groups"
+
+	// SecretBackendEncryptionKeySize is the size in bytes of the
+	// decoded secret backend encryption key.
+	SecretBackendEncryptionKeySize = 32
+
 	// DefaultQueryTracingEnabled is the default value for if query tracing
 	// is enabled.
 	DefaultQueryTracingEnabled = false
@@ -418,6 +500,12 @@ var (
 		ControllerName,
 		ControllerUUIDKey,
 		LoginTokenRefreshURL,
+		OIDCIssuerURL,
+		OIDCClientID,
+		OIDCUsernameClaim,
+		OIDCGroupsClaim,
+		OIDCGroupAccess,
+		SecretBackendEncryptionKey,
 		IdentityPublicKey,
 		IdentityURL,
 		SetNUMAControlPolicyKey,
@@ -443,6 +531,10 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogSinks,
+		AuditLogSyslogHost,
+		AuditLogHTTPURL,
+		AuditLogDatabaseRetention,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
@@ -450,6 +542,8 @@ var (
 		MaxCharmStateSize,
 		MaxAgentStateSize,
 		MigrationMinionWaitMax,
+		BackupRetentionCount,
+		BackupRetentionPeriod,
 		ApplicationResourceDownloadLimit,
 		ControllerResourceDownloadLimit,
 		QueryTracingEnabled,
@@ -481,9 +575,15 @@ var (
 		ApplicationResourceDownloadLimit,
 		AuditingEnabled,
 		AuditLogCaptureArgs,
+		AuditLogDatabaseRetention,
 		AuditLogExcludeMethods,
+		AuditLogHTTPURL,
 		AuditLogMaxBackups,
 		AuditLogMaxSize,
+		AuditLogSinks,
+		AuditLogSyslogHost,
+		BackupRetentionCount,
+		BackupRetentionPeriod,
 		CAASImageRepo,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
@@ -507,6 +607,7 @@ var (
 		PublicDNSAddress,
 		QueryTracingEnabled,
 		QueryTracingThreshold,
+		SecretBackendEncryptionKey,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -517,6 +618,11 @@ var (
 		ReadOnlyMethodsWildcard,
 	}
 
+	// DefaultAuditLogSinks is the default list of audit log sinks.
+	DefaultAuditLogSinks = []string{
+		auditlog.FileSink,
+	}
+
 	methodNameRE = regexp.MustCompile(`[[:alpha:]][[:alnum:]]*\.[[:alpha:]][[:alnum:]]*`)
 )
 
@@ -772,6 +878,36 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogSinks returns the names of the places audit log entries are
+// written to.
+func (c Config) AuditLogSinks() []string {
+	if value, ok := c[AuditLogSinks].([]interface{}); ok {
+		sinks := make([]string, len(value))
+		for i, item := range value {
+			sinks[i] = item.(string)
+		}
+		return sinks
+	}
+	return DefaultAuditLogSinks
+}
+
+// AuditLogSyslogHost returns the address of the syslog server audit log
+// entries are forwarded to.
+func (c Config) AuditLogSyslogHost() string {
+	return c.asString(AuditLogSyslogHost)
+}
+
+// AuditLogHTTPURL returns the URL audit log entries are POSTed to.
+func (c Config) AuditLogHTTPURL() string {
+	return c.asString(AuditLogHTTPURL)
+}
+
+// AuditLogDatabaseRetention returns how long audit log entries are kept
+// in the controller database.
+func (c Config) AuditLogDatabaseRetention() time.Duration {
+	return c.durationOrDefault(AuditLogDatabaseRetention, DefaultAuditLogDatabaseRetention)
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -845,6 +981,59 @@ func (c Config) LoginTokenRefreshURL() string {
 	return c.asString(LoginTokenRefreshURL)
 }
 
+// OIDCIssuerURL returns the URL of the trusted OpenID Connect issuer,
+// or the empty string if OpenID Connect login is not enabled.
+func (c Config) OIDCIssuerURL() string {
+	return c.asString(OIDCIssuerURL)
+}
+
+// OIDCClientID returns the client ID registered with the OpenID Connect
+// issuer for this controller.
+func (c Config) OIDCClientID() string {
+	return c.asString(OIDCClientID)
+}
+
+// OIDCUsernameClaim returns the ID token claim used as the Juju user name.
+func (c Config) OIDCUsernameClaim() string {
+	if v := c.asString(OIDCUsernameClaim); v != "" {
+		return v
+	}
+	return DefaultOIDCUsernameClaim
+}
+
+// OIDCGroupsClaim returns the ID token claim holding the user's groups.
+func (c Config) OIDCGroupsClaim() string {
+	if v := c.asString(OIDCGroupsClaim); v != "" {
+		return v
+	}
+	return DefaultOIDCGroupsClaim
+}
+
+// OIDCGroupAccess returns the access granted to the members of each
+// OpenID Connect group.
+func (c Config) OIDCGroupAccess() ([]OIDCGroupAccessEntry, error) {
+	value, ok := c[OIDCGroupAccess].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+	entries := make([]OIDCGroupAccessEntry, len(value))
+	for i, item := range value {
+		entry, err := ParseOIDCGroupAccess(item.(string))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+// SecretBackendEncryptionKey returns the base64 encoded key used to
+// encrypt the content of file secret backends, or the empty string if
+// it has not been set.
+func (c Config) SecretBackendEncryptionKey() string {
+	return c.asString(SecretBackendEncryptionKey)
+}
+
 // MongoMemoryProfile returns the selected profile or low.
 func (c Config) MongoMemoryProfile() string {
 	if profile, ok := c[MongoMemoryProfile]; ok {
@@ -1001,6 +1190,18 @@ func (c Config) MigrationMinionWaitMax() time.Duration {
 	return c.durationOrDefault(MigrationMinionWaitMax, DefaultMigrationMinionWaitMax)
 }
 
+// BackupRetentionCount returns the number of backup archives to keep on
+// each controller machine. A value of zero indicates no limit.
+func (c Config) BackupRetentionCount() int {
+	return c.intOrDefault(BackupRetentionCount, DefaultBackupRetentionCount)
+}
+
+// BackupRetentionPeriod returns how long backup archives are kept on each
+// controller machine. A value of zero indicates no limit.
+func (c Config) BackupRetentionPeriod() time.Duration {
+	return c.durationOrDefault(BackupRetentionPeriod, DefaultBackupRetentionPeriod)
+}
+
 // QueryTracingEnabled returns whether query tracing is enabled.
 func (c Config) QueryTracingEnabled() bool {
 	return c.boolOrDefault(QueryTracingEnabled, DefaultQueryTracingEnabled)
@@ -1046,6 +1247,33 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[OIDCIssuerURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid OIDC issuer URL")
+		}
+		if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return errors.NotValidf("OIDC issuer URL %q", v)
+		}
+		if c.OIDCClientID() == "" {
+			return errors.Errorf("%s must be set when %s is set", OIDCClientID, OIDCIssuerURL)
+		}
+	}
+
+	if _, err := c.OIDCGroupAccess(); err != nil {
+		return errors.Annotatef(err, "invalid %s", OIDCGroupAccess)
+	}
+
+	if v := c.SecretBackendEncryptionKey(); v != "" {
+		key, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return errors.NotValidf("%s encoding", SecretBackendEncryptionKey)
+		}
+		if len(key) != SecretBackendEncryptionKeySize {
+			return errors.NotValidf("%s length %d (expected %d)", SecretBackendEncryptionKey, len(key), SecretBackendEncryptionKeySize)
+		}
+	}
+
 	caCert, caCertOK := c.CACert()
 	if !caCertOK {
 		return errors.Errorf("missing CA certificate")
@@ -1193,6 +1421,10 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.validateAuditLogSinks(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if v, ok := c[ControllerAPIPort].(int); ok {
 		// TODO: change the validation so 0 is invalid and --reset is used.
 		// However that doesn't exist yet.
@@ -1253,6 +1485,14 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[BackupRetentionCount].(int); ok && v < 0 {
+		return errors.Errorf("%s value %d must not be negative", BackupRetentionCount, v)
+	}
+
+	if d, ok := c[BackupRetentionPeriod].(time.Duration); ok && d < 0 {
+		return errors.Errorf("%s value %q must not be negative", BackupRetentionPeriod, d)
+	}
+
 	if d, ok := c[QueryTracingThreshold].(time.Duration); ok {
 		if d < 0 {
 			return errors.Errorf("%s value %q must be a positive duration", QueryTracingThreshold, d)
@@ -1291,6 +1531,37 @@ func (c Config) validateSpaceConfig(key, topic string) error {
 	return nil
 }
 
+func (c Config) validateAuditLogSinks() error {
+	if v, ok := c[AuditLogSinks].([]interface{}); ok {
+		for _, name := range v {
+			if !auditlog.ValidSink(name.(string)) {
+				return errors.Errorf(
+					`invalid audit log sinks: should be a list of "file", "syslog", "http" or "database", got %q`, name)
+			}
+		}
+	}
+	sinks := set.NewStrings(c.AuditLogSinks()...)
+	if host := c.AuditLogSyslogHost(); host != "" {
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			return errors.NotValidf("audit log syslog host %q", host)
+		}
+	} else if sinks.Contains(auditlog.SyslogSink) {
+		return errors.Errorf("%s must be set when the syslog audit log sink is used", AuditLogSyslogHost)
+	}
+	if v := c.AuditLogHTTPURL(); v != "" {
+		u, err := url.Parse(v)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return errors.NotValidf("audit log HTTP URL %q", v)
+		}
+	} else if sinks.Contains(auditlog.HTTPSink) {
+		return errors.Errorf("%s must be set when the http audit log sink is used", AuditLogHTTPURL)
+	}
+	if d, ok := c[AuditLogDatabaseRetention].(time.Duration); ok && d <= 0 {
+		return errors.Errorf("%s value %q must be a positive duration", AuditLogDatabaseRetention, d)
+	}
+	return nil
+}
+
 // AsSpaceConstraints checks to see whether config has spaces names populated
 // for management and/or HA (Mongo).
 // Non-empty values are merged with any input spaces and returned as a new
@@ -12,12 +12,14 @@ import (
 
 	"github.com/juju/collections/set"
 	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
 	"github.com/juju/romulus"
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/docker"
 	"github.com/juju/juju/docker/registry"
 	"github.com/juju/juju/docker/registry/mocks"
@@ -337,6 +339,103 @@ var newConfigTests = []struct {
 		controller.MigrationMinionWaitMax: "15",
 	},
 	expectError: `migration-agent-wait-time: conversion to duration: time: missing unit in duration "15"`,
+}, {
+	about: "backup-retention-count cannot be negative",
+	config: controller.Config{
+		controller.BackupRetentionCount: "-1",
+	},
+	expectError: `backup-retention-count value -1 must not be negative`,
+}, {
+	about: "backup-retention-period not a duration",
+	config: controller.Config{
+		controller.BackupRetentionPeriod: "7",
+	},
+	expectError: `backup-retention-period: conversion to duration: time: missing unit in duration "7"`,
+}, {
+	about: "backup-retention-period cannot be negative",
+	config: controller.Config{
+		controller.BackupRetentionPeriod: "-24h",
+	},
+	expectError: `backup-retention-period value "-24h0m0s" must not be negative`,
+}, {
+	about: "unknown audit log sink",
+	config: controller.Config{
+		controller.AuditLogSinks: []interface{}{"file", "tape"},
+	},
+	expectError: `invalid audit log sinks: should be a list of "file", "syslog", "http" or "database", got "tape"`,
+}, {
+	about: "syslog audit log sink without a host",
+	config: controller.Config{
+		controller.AuditLogSinks: []interface{}{"syslog"},
+	},
+	expectError: `audit-log-syslog-host must be set when the syslog audit log sink is used`,
+}, {
+	about: "audit log syslog host without a port",
+	config: controller.Config{
+		controller.AuditLogSyslogHost: "syslog.example.com",
+	},
+	expectError: `audit log syslog host "syslog.example.com" not valid`,
+}, {
+	about: "http audit log sink without a URL",
+	config: controller.Config{
+		controller.AuditLogSinks: []interface{}{"file", "http"},
+	},
+	expectError: `audit-log-http-url must be set when the http audit log sink is used`,
+}, {
+	about: "audit log HTTP URL not valid",
+	config: controller.Config{
+		controller.AuditLogHTTPURL: "ftp://audit.example.com",
+	},
+	expectError: `audit log HTTP URL "ftp://audit.example.com" not valid`,
+}, {
+	about: "audit-log-database-retention cannot be negative",
+	config: controller.Config{
+		controller.AuditLogDatabaseRetention: "-24h",
+	},
+	expectError: `audit-log-database-retention value "-24h0m0s" must be a positive duration`,
+}, {
+	about: "oidc-issuer-url not valid",
+	config: controller.Config{
+		controller.OIDCIssuerURL: "ftp://issuer.example.com",
+		controller.OIDCClientID:  "juju",
+	},
+	expectError: `OIDC issuer URL "ftp://issuer.example.com" not valid`,
+}, {
+	about: "oidc-issuer-url without oidc-client-id",
+	config: controller.Config{
+		controller.OIDCIssuerURL: "https://issuer.example.com",
+	},
+	expectError: `oidc-client-id must be set when oidc-issuer-url is set`,
+}, {
+	about: "oidc-group-access with invalid access",
+	config: controller.Config{
+		controller.OIDCGroupAccess: []interface{}{"admins=write"},
+	},
+	expectError: `invalid oidc-group-access: group access "admins=write": "write" controller access not valid`,
+}, {
+	about: "oidc-group-access with invalid target",
+	config: controller.Config{
+		controller.OIDCGroupAccess: []interface{}{"devs=write@machine-0"},
+	},
+	expectError: `invalid oidc-group-access: machine target in group access "devs=write@machine-0" not valid`,
+}, {
+	about: "oidc-group-access without group",
+	config: controller.Config{
+		controller.OIDCGroupAccess: []interface{}{"=login"},
+	},
+	expectError: `invalid oidc-group-access: group access "=login" not valid`,
+}, {
+	about: "secret-backend-encryption-key not base64",
+	config: controller.Config{
+		controller.SecretBackendEncryptionKey: "not base64!",
+	},
+	expectError: `secret-backend-encryption-key encoding not valid`,
+}, {
+	about: "secret-backend-encryption-key too short",
+	config: controller.Config{
+		controller.SecretBackendEncryptionKey: "c2hvcnQ=",
+	},
+	expectError: `secret-backend-encryption-key length 5 \(expected 32\) not valid`,
 }, {
 	about: "application-resource-download-limit cannot be negative",
 	config: controller.Config{
@@ -899,6 +998,121 @@ func (s *ConfigSuite) TestMigrationMinionWaitMax(c *gc.C) {
 	c.Assert(cfg.MigrationMinionWaitMax(), gc.Equals, 500*time.Millisecond)
 }
 
+func (s *ConfigSuite) TestBackupRetention(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, controller.DefaultBackupRetentionCount)
+	c.Assert(cfg.BackupRetentionPeriod(), gc.Equals, controller.DefaultBackupRetentionPeriod)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.BackupRetentionCount:  "5",
+			controller.BackupRetentionPeriod: "168h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 5)
+	c.Assert(cfg.BackupRetentionPeriod(), gc.Equals, 168*time.Hour)
+}
+
+func (s *ConfigSuite) TestAuditLogSinks(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(cfg.AuditLogSinks(), jc.DeepEquals, []string{"file"})
+	c.Assert(cfg.AuditLogSyslogHost(), gc.Equals, "")
+	c.Assert(cfg.AuditLogHTTPURL(), gc.Equals, "")
+	c.Assert(cfg.AuditLogDatabaseRetention(), gc.Equals, controller.DefaultAuditLogDatabaseRetention)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.AuditLogSinks:             []interface{}{"file", "syslog", "http", "database"},
+			controller.AuditLogSyslogHost:        "syslog.example.com:601",
+			controller.AuditLogHTTPURL:           "https://audit.example.com/records",
+			controller.AuditLogDatabaseRetention: "168h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSinks(), jc.DeepEquals, []string{"file", "syslog", "http", "database"})
+	c.Assert(cfg.AuditLogSyslogHost(), gc.Equals, "syslog.example.com:601")
+	c.Assert(cfg.AuditLogHTTPURL(), gc.Equals, "https://audit.example.com/records")
+	c.Assert(cfg.AuditLogDatabaseRetention(), gc.Equals, 168*time.Hour)
+}
+
+func (s *ConfigSuite) TestOIDC(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(cfg.OIDCIssuerURL(), gc.Equals, "")
+	c.Assert(cfg.OIDCUsernameClaim(), gc.Equals, controller.DefaultOIDCUsernameClaim)
+	c.Assert(cfg.OIDCGroupsClaim(), gc.Equals, controller.DefaultOIDCGroupsClaim)
+	access, err := cfg.OIDCGroupAccess()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.HasLen, 0)
+
+	modelTag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.OIDCIssuerURL:     "https://issuer.example.com",
+			controller.OIDCClientID:      "juju",
+			controller.OIDCUsernameClaim: "preferred_username",
+			controller.OIDCGroupsClaim:   "roles",
+			controller.OIDCGroupAccess: []interface{}{
+				"admins=superuser",
+				"org:devs=write@" + modelTag.String(),
+			},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OIDCIssuerURL(), gc.Equals, "https://issuer.example.com")
+	c.Assert(cfg.OIDCClientID(), gc.Equals, "juju")
+	c.Assert(cfg.OIDCUsernameClaim(), gc.Equals, "preferred_username")
+	c.Assert(cfg.OIDCGroupsClaim(), gc.Equals, "roles")
+	access, err = cfg.OIDCGroupAccess()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, jc.DeepEquals, []controller.OIDCGroupAccessEntry{{
+		Group:  "admins",
+		Access: permission.SuperuserAccess,
+	}, {
+		Group:  "org:devs",
+		Target: modelTag,
+		Access: permission.WriteAccess,
+	}})
+	c.Assert(access[1].String(), gc.Equals, "org:devs=write@"+modelTag.String())
+}
+
+func (s *ConfigSuite) TestSecretBackendEncryptionKey(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SecretBackendEncryptionKey(), gc.Equals, "")
+
+	key := "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.SecretBackendEncryptionKey: key,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SecretBackendEncryptionKey(), gc.Equals, key)
+}
+
 func (s *ConfigSuite) TestQueryTraceEnabled(c *gc.C) {
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -19,12 +19,22 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:                  schema.String(),
 	AuditLogMaxBackups:               schema.ForceInt(),
 	AuditLogExcludeMethods:           schema.List(schema.String()),
+	AuditLogSinks:                    schema.List(schema.String()),
+	AuditLogSyslogHost:               schema.String(),
+	AuditLogHTTPURL:                  schema.String(),
+	AuditLogDatabaseRetention:        schema.TimeDuration(),
 	APIPort:                          schema.ForceInt(),
 	APIPortOpenDelay:                 schema.TimeDuration(),
 	ControllerAPIPort:                schema.ForceInt(),
 	ControllerName:                   schema.NonEmptyString(ControllerName),
 	StatePort:                        schema.ForceInt(),
 	LoginTokenRefreshURL:             schema.String(),
+	OIDCIssuerURL:                    schema.String(),
+	OIDCClientID:                     schema.String(),
+	OIDCUsernameClaim:                schema.String(),
+	OIDCGroupsClaim:                  schema.String(),
+	OIDCGroupAccess:                  schema.List(schema.String()),
+	SecretBackendEncryptionKey:       schema.String(),
 	IdentityURL:                      schema.String(),
 	IdentityPublicKey:                schema.String(),
 	SetNUMAControlPolicyKey:          schema.Bool(),
@@ -54,6 +64,8 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxCharmStateSize:                schema.ForceInt(),
 	MaxAgentStateSize:                schema.ForceInt(),
 	MigrationMinionWaitMax:           schema.TimeDuration(),
+	BackupRetentionCount:             schema.ForceInt(),
+	BackupRetentionPeriod:            schema.TimeDuration(),
 	ApplicationResourceDownloadLimit: schema.ForceInt(),
 	ControllerResourceDownloadLimit:  schema.ForceInt(),
 	QueryTracingEnabled:              schema.Bool(),
@@ -71,8 +83,18 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:                  fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
 	AuditLogMaxBackups:               DefaultAuditLogMaxBackups,
 	AuditLogExcludeMethods:           DefaultAuditLogExcludeMethods,
+	AuditLogSinks:                    DefaultAuditLogSinks,
+	AuditLogSyslogHost:               schema.Omit,
+	AuditLogHTTPURL:                  schema.Omit,
+	AuditLogDatabaseRetention:        DefaultAuditLogDatabaseRetention,
 	StatePort:                        DefaultStatePort,
 	LoginTokenRefreshURL:             schema.Omit,
+	OIDCIssuerURL:                    schema.Omit,
+	OIDCClientID:                     schema.Omit,
+	OIDCUsernameClaim:                schema.Omit,
+	OIDCGroupsClaim:                  schema.Omit,
+	OIDCGroupAccess:                  schema.Omit,
+	SecretBackendEncryptionKey:       schema.Omit,
 	IdentityURL:                      schema.Omit,
 	IdentityPublicKey:                schema.Omit,
 	SetNUMAControlPolicyKey:          DefaultNUMAControlPolicy,
@@ -102,6 +124,8 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxCharmStateSize:                DefaultMaxCharmStateSize,
 	MaxAgentStateSize:                DefaultMaxAgentStateSize,
 	MigrationMinionWaitMax:           DefaultMigrationMinionWaitMax,
+	BackupRetentionCount:             schema.Omit,
+	BackupRetentionPeriod:            schema.Omit,
 	ApplicationResourceDownloadLimit: schema.Omit,
 	ControllerResourceDownloadLimit:  schema.Omit,
 	QueryTracingEnabled:              DefaultQueryTracingEnabled,
@@ -148,6 +172,23 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.Tlist,
 		Description: "The list of Facade.Method names that aren't interesting for audit logging purposes.",
 	},
+	AuditLogSinks: {
+		Type: environschema.Tlist,
+		Description: `The list of places audit log entries are written to: any of
+"file", "syslog", "http" and "database"`,
+	},
+	AuditLogSyslogHost: {
+		Type:        environschema.Tstring,
+		Description: `The address ("<host>:<port>") of the syslog server used by the syslog audit log sink`,
+	},
+	AuditLogHTTPURL: {
+		Type:        environschema.Tstring,
+		Description: "The URL audit log entries are POSTed to by the http audit log sink",
+	},
+	AuditLogDatabaseRetention: {
+		Type:        environschema.Tstring,
+		Description: "How long audit log entries written by the database audit log sink are kept",
+	},
 	APIPort: {
 		Type:        environschema.Tint,
 		Description: "The port used for api connections",
@@ -179,6 +220,31 @@ set, the api-port isn't opened until the controllers have started properly.`,
 		Type:        environschema.Tstring,
 		Description: `The url of the jwt well known endpoint`,
 	},
+	OIDCIssuerURL: {
+		Type:        environschema.Tstring,
+		Description: `The url of an OpenID Connect issuer trusted to authenticate users`,
+	},
+	OIDCClientID: {
+		Type:        environschema.Tstring,
+		Description: `The client id registered with the OpenID Connect issuer for this controller`,
+	},
+	OIDCUsernameClaim: {
+		Type:        environschema.Tstring,
+		Description: `The ID token claim used as the Juju user name`,
+	},
+	OIDCGroupsClaim: {
+		Type:        environschema.Tstring,
+		Description: `The ID token claim holding the groups of the user`,
+	},
+	OIDCGroupAccess: {
+		Type:        environschema.Tlist,
+		Description: `A list of "<group>=<access>[@<target>]" entries granting access to the members of OpenID Connect groups`,
+	},
+	SecretBackendEncryptionKey: {
+		Type: environschema.Tstring,
+		Description: `The base64 encoded 32 byte key used to encrypt the content of file secret backends. ` +
+			`It can be set once and must be kept safe: without it, the content cannot be read`,
+	},
 	IdentityURL: {
 		Type:        environschema.Tstring,
 		Description: `The url of the identity manager`,
@@ -298,6 +364,14 @@ Use "caas-image-repo" instead.`,
 		Type:        environschema.Tstring,
 		Description: `The maximum during model migrations that the migration worker will wait for agents to report on phases of the migration`,
 	},
+	BackupRetentionCount: {
+		Type:        environschema.Tint,
+		Description: `The number of backup archives to keep on each controller machine; 0 keeps all archives`,
+	},
+	BackupRetentionPeriod: {
+		Type:        environschema.Tstring,
+		Description: `How long to keep backup archives on each controller machine; 0 keeps archives forever`,
+	},
 	QueryTracingEnabled: {
 		Type:        environschema.Tbool,
 		Description: `Enable query tracing for the dqlite driver`,
@@ -0,0 +1,81 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/core/permission"
+)
+
+// OIDCGroupAccessEntry describes the access granted to the members of an
+// OpenID Connect group.
+type OIDCGroupAccessEntry struct {
+	// Group is the name of the group, as found in the groups claim.
+	Group string
+
+	// Target is the entity that access is granted on. A nil target
+	// refers to the controller.
+	Target names.Tag
+
+	// Access is the access granted on the target.
+	Access permission.Access
+}
+
+// String returns the entry in the form accepted by ParseOIDCGroupAccess.
+func (e OIDCGroupAccessEntry) String() string {
+	if e.Target == nil {
+		return e.Group + "=" + string(e.Access)
+	}
+	return e.Group + "=" + string(e.Access) + "@" + e.Target.String()
+}
+
+// ParseOIDCGroupAccess parses a group access entry of the form
+// "<group>=<access>[@<target>]", where the target is a controller, model,
+// cloud or application offer tag. If the target is omitted, the access is
+// granted on the controller.
+func ParseOIDCGroupAccess(value string) (OIDCGroupAccessEntry, error) {
+	i := strings.LastIndex(value, "=")
+	if i <= 0 {
+		return OIDCGroupAccessEntry{}, errors.NotValidf("group access %q", value)
+	}
+	entry := OIDCGroupAccessEntry{
+		Group: value[:i],
+	}
+
+	access, target, _ := strings.Cut(value[i+1:], "@")
+	entry.Access = permission.Access(access)
+	if target != "" && target != names.ControllerTagKind {
+		tag, err := names.ParseTag(target)
+		if err != nil {
+			return OIDCGroupAccessEntry{}, errors.NotValidf("target %q in group access %q", target, value)
+		}
+		entry.Target = tag
+	}
+
+	var validate func(permission.Access) error
+	kind := names.ControllerTagKind
+	if entry.Target != nil {
+		kind = entry.Target.Kind()
+	}
+	switch kind {
+	case names.ControllerTagKind:
+		validate = permission.ValidateControllerAccess
+	case names.ModelTagKind:
+		validate = permission.ValidateModelAccess
+	case names.CloudTagKind:
+		validate = permission.ValidateCloudAccess
+	case names.ApplicationOfferTagKind:
+		validate = permission.ValidateOfferAccess
+	default:
+		return OIDCGroupAccessEntry{}, errors.NotValidf("%s target in group access %q", kind, value)
+	}
+	if err := validate(entry.Access); err != nil {
+		return OIDCGroupAccessEntry{}, errors.Annotatef(err, "group access %q", value)
+	}
+	return entry, nil
+}
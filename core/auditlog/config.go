@@ -4,6 +4,8 @@
 package auditlog
 
 import (
+	"time"
+
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 )
@@ -29,14 +31,61 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
+	// Sinks names the places audit entries are written to (see
+	// FileSink, SyslogSink, HTTPSink and DatabaseSink). If empty, only
+	// the file sink is used.
+	Sinks []string
+
+	// SyslogHost is the address of the syslog server used by the
+	// syslog sink.
+	SyslogHost string
+
+	// HTTPURL is the URL records are POSTed to by the http sink.
+	HTTPURL string
+
+	// DatabaseRetention is how long entries written by the database
+	// sink are kept.
+	DatabaseRetention time.Duration
+
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
 }
 
+// SinksChanged returns whether the sinks of the other config, or the
+// settings of those sinks, differ from those of this config.
+func (cfg Config) SinksChanged(other Config) bool {
+	sinks := cfg.sinkSet()
+	if !sinks.Difference(other.sinkSet()).IsEmpty() || !other.sinkSet().Difference(sinks).IsEmpty() {
+		return true
+	}
+	return (sinks.Contains(SyslogSink) && cfg.SyslogHost != other.SyslogHost) ||
+		(sinks.Contains(HTTPSink) && cfg.HTTPURL != other.HTTPURL) ||
+		(sinks.Contains(DatabaseSink) && cfg.DatabaseRetention != other.DatabaseRetention)
+}
+
+// sinkSet returns the names of the sinks used by the config.
+func (cfg Config) sinkSet() set.Strings {
+	if len(cfg.Sinks) == 0 {
+		return set.NewStrings(FileSink)
+	}
+	return set.NewStrings(cfg.Sinks...)
+}
+
 // Validate checks the audit logging configuration.
 func (cfg Config) Validate() error {
 	if cfg.Enabled && cfg.Target == nil {
 		return errors.NewNotValid(nil, "logging enabled but no target provided")
 	}
+	for _, sink := range cfg.Sinks {
+		if !ValidSink(sink) {
+			return errors.NotValidf("audit log sink %q", sink)
+		}
+		if sink == SyslogSink && cfg.SyslogHost == "" {
+			return errors.NewNotValid(nil, "syslog sink enabled but no syslog host provided")
+		}
+		if sink == HTTPSink && cfg.HTTPURL == "" {
+			return errors.NewNotValid(nil, "http sink enabled but no URL provided")
+		}
+	}
 	return nil
 }
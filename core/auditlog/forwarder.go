@@ -0,0 +1,130 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// forwardBufferSize is the number of records a forwarding sink
+	// holds while waiting to send them. Records added once the buffer
+	// is full are dropped.
+	forwardBufferSize = 1000
+
+	// forwardBatchSize is the maximum number of records sent to a
+	// remote sink at once.
+	forwardBatchSize = 100
+)
+
+// RecordSender sends batches of records to an audit log sink.
+type RecordSender interface {
+	SendRecords([]Record) error
+	Close() error
+}
+
+// forwarder is an AuditLog that sends records to a sink in the
+// background, so that API requests aren't held up by a slow or
+// unreachable sink.
+type forwarder struct {
+	name   string
+	sender RecordSender
+
+	mu      sync.Mutex
+	closed  bool
+	dropped int
+	records chan Record
+	done    chan struct{}
+}
+
+// NewForwarder returns an audit entry sink which passes records to the
+// sender in batches, from a background goroutine. Records are dropped,
+// with a warning, if the sender can't keep up. The name identifies the
+// sink in log messages.
+func NewForwarder(name string, sender RecordSender) AuditLog {
+	f := &forwarder{
+		name:    name,
+		sender:  sender,
+		records: make(chan Record, forwardBufferSize),
+		done:    make(chan struct{}),
+	}
+	go f.loop()
+	return f
+}
+
+// AddConversation implements AuditLog.
+func (f *forwarder) AddConversation(c Conversation) error {
+	f.add(Record{Conversation: &c})
+	return nil
+}
+
+// AddRequest implements AuditLog.
+func (f *forwarder) AddRequest(r Request) error {
+	f.add(Record{Request: &r})
+	return nil
+}
+
+// AddResponse implements AuditLog.
+func (f *forwarder) AddResponse(r ResponseErrors) error {
+	f.add(Record{Errors: &r})
+	return nil
+}
+
+// Close implements AuditLog. Records that have already been added are
+// sent before the sink is closed; records added after Close are
+// discarded, since API connections may still hold on to the sink after
+// the audit configuration has changed.
+func (f *forwarder) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	close(f.records)
+	f.mu.Unlock()
+
+	<-f.done
+	return errors.Trace(f.sender.Close())
+}
+
+func (f *forwarder) add(r Record) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return
+	}
+	select {
+	case f.records <- r:
+	default:
+		f.dropped++
+		if f.dropped%forwardBufferSize == 1 {
+			logger.Warningf("%s audit log sink can't keep up, %d records dropped", f.name, f.dropped)
+		}
+	}
+}
+
+func (f *forwarder) loop() {
+	defer close(f.done)
+	for r := range f.records {
+		batch := []Record{r}
+	fill:
+		for len(batch) < forwardBatchSize {
+			select {
+			case r, ok := <-f.records:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, r)
+			default:
+				break fill
+			}
+		}
+		if err := f.sender.SendRecords(batch); err != nil {
+			logger.Warningf("sending %d records to %s audit log sink: %v", len(batch), f.name, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const httpRequestTimeout = 30 * time.Second
+
+// HTTPDoer sends an HTTP request and returns the response.
+type HTTPDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// NewHTTP returns an audit entry sink which forwards records to the
+// given URL. Each batch of records is POSTed as newline-delimited JSON,
+// in the same format as the audit log file.
+func NewHTTP(url string) AuditLog {
+	return NewHTTPForDoer(url, &http.Client{Timeout: httpRequestTimeout})
+}
+
+// NewHTTPForDoer returns an audit entry sink which forwards records to
+// the given URL using the doer.
+func NewHTTPForDoer(url string, doer HTTPDoer) AuditLog {
+	s := &httpSink{
+		url:  url,
+		doer: doer,
+	}
+	return NewForwarder("http", s)
+}
+
+type httpSink struct {
+	url  string
+	doer HTTPDoer
+}
+
+// SendRecords implements RecordSender.
+func (s *httpSink) SendRecords(records []Record) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, r := range records {
+		if err := encoder.Encode(r); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, &body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := s.doer.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("%s returned %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+// Close implements RecordSender.
+func (s *httpSink) Close() error {
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/errors"
+)
+
+const (
+	// FileSink writes audit records to a rotating file on each
+	// controller machine.
+	FileSink = "file"
+
+	// SyslogSink forwards audit records to a syslog server.
+	SyslogSink = "syslog"
+
+	// HTTPSink POSTs audit records to an HTTP endpoint.
+	HTTPSink = "http"
+
+	// DatabaseSink stores audit records in the controller database,
+	// where they can be queried across all controllers.
+	DatabaseSink = "database"
+)
+
+// ValidSink returns whether name is a known audit log sink.
+func ValidSink(name string) bool {
+	switch name {
+	case FileSink, SyslogSink, HTTPSink, DatabaseSink:
+		return true
+	}
+	return false
+}
+
+type multiLog []AuditLog
+
+// NewMultiLog returns an AuditLog that writes entries to all of the
+// given logs. An error writing to one log doesn't stop the entry
+// being written to the others.
+func NewMultiLog(logs ...AuditLog) AuditLog {
+	if len(logs) == 1 {
+		return logs[0]
+	}
+	return multiLog(logs)
+}
+
+// AddConversation implements AuditLog.
+func (m multiLog) AddConversation(c Conversation) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddConversation(c)
+	})
+}
+
+// AddRequest implements AuditLog.
+func (m multiLog) AddRequest(r Request) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddRequest(r)
+	})
+}
+
+// AddResponse implements AuditLog.
+func (m multiLog) AddResponse(r ResponseErrors) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddResponse(r)
+	})
+}
+
+// Close implements AuditLog.
+func (m multiLog) Close() error {
+	return m.each(func(log AuditLog) error {
+		return log.Close()
+	})
+}
+
+// each calls f for every log, returning the first error.
+func (m multiLog) each(f func(AuditLog) error) error {
+	var result error
+	for _, log := range m {
+		if err := f(log); err != nil && result == nil {
+			result = errors.Trace(err)
+		}
+	}
+	return result
+}
@@ -0,0 +1,247 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/rfc/v2/rfc5424"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type SinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinksSuite{})
+
+var (
+	sinkConversation = auditlog.Conversation{
+		Who:            "deerhoof",
+		What:           "juju deploy prometheus",
+		When:           "2017-11-27T13:21:24Z",
+		ModelName:      "admin/default",
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+	}
+	sinkRequest = auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		When:           "2017-11-27T13:21:25Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        4,
+	}
+)
+
+func (s *SinksSuite) TestMultiLog(c *gc.C) {
+	var first, second fakeLog
+	second.stub.SetErrors(errors.New("boom"), errors.New("bang"), errors.New("crash"))
+	log := auditlog.NewMultiLog(&first, &second)
+
+	err := log.AddConversation(sinkConversation)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	err = log.AddRequest(sinkRequest)
+	c.Assert(err, gc.ErrorMatches, "bang")
+	err = log.Close()
+	c.Assert(err, gc.ErrorMatches, "crash")
+
+	for _, l := range []*fakeLog{&first, &second} {
+		l.stub.CheckCalls(c, []testing.StubCall{
+			{"AddConversation", []interface{}{sinkConversation}},
+			{"AddRequest", []interface{}{sinkRequest}},
+			{"Close", nil},
+		})
+	}
+}
+
+func (s *SinksSuite) TestMultiLogSingle(c *gc.C) {
+	var only fakeLog
+	c.Assert(auditlog.NewMultiLog(&only), gc.Equals, &only)
+}
+
+func (s *SinksSuite) TestForwarderSendsOnClose(c *gc.C) {
+	var sender fakeSender
+	log := auditlog.NewForwarder("fake", &sender)
+	err := log.AddConversation(sinkConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddRequest(sinkRequest)
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(sender.records, jc.DeepEquals, []auditlog.Record{
+		{Conversation: &sinkConversation},
+		{Request: &sinkRequest},
+	})
+	c.Assert(sender.closed, jc.IsTrue)
+
+	// Records added after the sink has been closed are discarded.
+	err = log.AddRequest(sinkRequest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sender.records, gc.HasLen, 2)
+}
+
+func (s *SinksSuite) TestHTTP(c *gc.C) {
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, http.MethodPost)
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+		body, err := io.ReadAll(r.Body)
+		c.Check(err, jc.ErrorIsNil)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	log := auditlog.NewHTTP(server.URL)
+	err := log.AddConversation(sinkConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddRequest(sinkRequest)
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	mu.Lock()
+	defer mu.Unlock()
+	var records []auditlog.Record
+	for _, line := range strings.Split(strings.TrimSpace(strings.Join(bodies, "")), "\n") {
+		var record auditlog.Record
+		err := json.Unmarshal([]byte(line), &record)
+		c.Assert(err, jc.ErrorIsNil)
+		records = append(records, record)
+	}
+	c.Assert(records, jc.DeepEquals, []auditlog.Record{
+		{Conversation: &sinkConversation},
+		{Request: &sinkRequest},
+	})
+}
+
+func (s *SinksSuite) TestSyslog(c *gc.C) {
+	var sender fakeSyslogSender
+	var dialed []string
+	dial := func(host string) (auditlog.SyslogSender, error) {
+		dialed = append(dialed, host)
+		return &sender, nil
+	}
+	log := auditlog.NewSyslogForDialer("syslog.example.com:601", dial)
+	err := log.AddConversation(sinkConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddRequest(sinkRequest)
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(dialed, jc.DeepEquals, []string{"syslog.example.com:601"})
+	c.Assert(sender.closed, jc.IsTrue)
+	c.Assert(sender.messages, gc.HasLen, 2)
+
+	msg := sender.messages[0]
+	c.Check(msg.AppName, gc.Equals, rfc5424.AppName("juju-audit"))
+	c.Check(msg.MsgID, gc.Equals, rfc5424.MsgID("conversation"))
+	c.Check(msg.Priority.Facility, gc.Equals, rfc5424.FacilityAuthpriv)
+	c.Check(msg.Timestamp.UTC().Format("2006-01-02T15:04:05Z"), gc.Equals, sinkConversation.When)
+	var record auditlog.Record
+	err = json.Unmarshal([]byte(msg.Msg), &record)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(record, jc.DeepEquals, auditlog.Record{Conversation: &sinkConversation})
+	c.Check(sender.messages[1].MsgID, gc.Equals, rfc5424.MsgID("request"))
+}
+
+func (s *SinksSuite) TestConfigValidate(c *gc.C) {
+	target := &fakeLog{}
+	for _, test := range []struct {
+		cfg auditlog.Config
+		err string
+	}{{
+		cfg: auditlog.Config{Enabled: true, Target: target, Sinks: []string{"file", "database"}},
+	}, {
+		cfg: auditlog.Config{Enabled: true, Target: target, Sinks: []string{"tape"}},
+		err: `audit log sink "tape" not valid`,
+	}, {
+		cfg: auditlog.Config{Enabled: true, Target: target, Sinks: []string{"syslog"}},
+		err: "syslog sink enabled but no syslog host provided",
+	}, {
+		cfg: auditlog.Config{Enabled: true, Target: target, Sinks: []string{"http"}},
+		err: "http sink enabled but no URL provided",
+	}, {
+		cfg: auditlog.Config{Enabled: true, Sinks: []string{"file"}},
+		err: "logging enabled but no target provided",
+	}} {
+		err := test.cfg.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *SinksSuite) TestConfigSinksChanged(c *gc.C) {
+	cfg := auditlog.Config{
+		Sinks:      []string{"file", "syslog"},
+		SyslogHost: "syslog.example.com:601",
+	}
+	other := cfg
+	other.Sinks = []string{"syslog", "file"}
+	c.Check(cfg.SinksChanged(other), jc.IsFalse)
+	other.MaxBackups = 3
+	c.Check(cfg.SinksChanged(other), jc.IsFalse)
+	other.SyslogHost = "syslog.example.com:602"
+	c.Check(cfg.SinksChanged(other), jc.IsTrue)
+	other = cfg
+	other.Sinks = []string{"file"}
+	c.Check(cfg.SinksChanged(other), jc.IsTrue)
+
+	// No sinks means just the file sink, and settings of sinks that
+	// aren't used don't matter.
+	other.SyslogHost = ""
+	other.HTTPURL = "https://audit.example.com"
+	c.Check(auditlog.Config{}.SinksChanged(other), jc.IsFalse)
+}
+
+type fakeSender struct {
+	records []auditlog.Record
+	closed  bool
+}
+
+func (s *fakeSender) SendRecords(records []auditlog.Record) error {
+	s.records = append(s.records, records...)
+	return nil
+}
+
+func (s *fakeSender) Close() error {
+	s.closed = true
+	return nil
+}
+
+type fakeSyslogSender struct {
+	messages []rfc5424.Message
+	closed   bool
+}
+
+func (s *fakeSyslogSender) Send(msg rfc5424.Message) error {
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func (s *fakeSyslogSender) Close() error {
+	s.closed = true
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/rfc/v2/rfc5424"
+)
+
+const (
+	syslogAppName     = "juju-audit"
+	syslogSendTimeout = 10 * time.Second
+)
+
+// SyslogSender sends messages to a syslog server.
+type SyslogSender interface {
+	Send(rfc5424.Message) error
+	Close() error
+}
+
+// SyslogDialer opens a connection to the syslog server at the given
+// address.
+type SyslogDialer func(host string) (SyslogSender, error)
+
+// NewSyslog returns an audit entry sink which forwards records, as JSON
+// messages, to the syslog server at host ("<address>:<port>") over TCP.
+func NewSyslog(host string) AuditLog {
+	return NewSyslogForDialer(host, dialSyslog)
+}
+
+// NewSyslogForDialer returns an audit entry sink which forwards records
+// to a syslog server connected to with the given dialer. The connection
+// is opened when the first records are sent, and reopened after a
+// failure.
+func NewSyslogForDialer(host string, dial SyslogDialer) AuditLog {
+	hostname, err := os.Hostname()
+	if err != nil {
+		logger.Warningf("unable to get hostname for syslog audit log sink: %v", err)
+	}
+	s := &syslogSink{
+		host:     host,
+		hostname: hostname,
+		dial:     dial,
+	}
+	return NewForwarder("syslog", s)
+}
+
+func dialSyslog(host string) (SyslogSender, error) {
+	client, err := rfc5424.Open(host, rfc5424.ClientConfig{
+		SendTimeout: syslogSendTimeout,
+	}, nil)
+	return client, errors.Trace(err)
+}
+
+type syslogSink struct {
+	host     string
+	hostname string
+	dial     SyslogDialer
+	sender   SyslogSender
+}
+
+// SendRecords implements RecordSender.
+func (s *syslogSink) SendRecords(records []Record) error {
+	if s.sender == nil {
+		sender, err := s.dial(s.host)
+		if err != nil {
+			return errors.Annotatef(err, "connecting to %s", s.host)
+		}
+		s.sender = sender
+	}
+	for _, r := range records {
+		msg, err := s.message(r)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := s.sender.Send(msg); err != nil {
+			// Reconnect when the next batch is sent.
+			_ = s.sender.Close()
+			s.sender = nil
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Close implements RecordSender.
+func (s *syslogSink) Close() error {
+	if s.sender == nil {
+		return nil
+	}
+	return errors.Trace(s.sender.Close())
+}
+
+func (s *syslogSink) message(r Record) (rfc5424.Message, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return rfc5424.Message{}, errors.Trace(err)
+	}
+	kind, when := r.kind()
+	timestamp, err := time.Parse(time.RFC3339, when)
+	if err != nil {
+		timestamp = time.Now()
+	}
+	return rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityInformational,
+				Facility: rfc5424.FacilityAuthpriv,
+			},
+			Timestamp: rfc5424.Timestamp{Time: timestamp},
+			Hostname:  rfc5424.Hostname{Hostname: s.hostname},
+			AppName:   syslogAppName,
+			MsgID:     rfc5424.MsgID(kind),
+		},
+		Msg: string(data),
+	}, nil
+}
+
+// kind returns the type of entry held in the record, and when it was
+// recorded.
+func (r Record) kind() (string, string) {
+	switch {
+	case r.Conversation != nil:
+		return "conversation", r.Conversation.When
+	case r.Request != nil:
+		return "request", r.Request.When
+	case r.Errors != nil:
+		return "errors", r.Errors.When
+	}
+	return "", ""
+}
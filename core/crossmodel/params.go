@@ -17,6 +17,9 @@ import (
 // user making the API call, and whether the call is "find" or "list",
 // not all fields will be populated.
 type ApplicationOfferDetails struct {
+	// OfferUUID is the UUID of the offer.
+	OfferUUID string
+
 	// OfferName is the name of the offer
 	OfferName string
 
@@ -57,6 +57,14 @@ type GenerationApplication struct {
 	// TODO (manadart 2018-02-22) This data-type will evolve as more aspects
 	// of the application are made generational.
 	ConfigChanges map[string]interface{} `yaml:"config"`
+
+	// CharmURL is the URL of the charm that the application is upgraded
+	// to under the generation, if it is.
+	CharmURL string `yaml:"charm-url,omitempty"`
+
+	// Resources are the IDs of the pending resources attached to the
+	// application under the generation, keyed by resource name.
+	Resources map[string]string `yaml:"resources,omitempty"`
 }
 
 // Generation represents detail of a model generation including config changes.
@@ -70,6 +78,105 @@ type Generation struct {
 	// Applications is a collection of applications with changes in this
 	// generation including advanced units and modified configuration.
 	Applications []GenerationApplication `yaml:"applications"`
+
+	// Rollout describes the gradual rollout of the generation,
+	// if one has been set up.
+	Rollout *GenerationRollout `yaml:"rollout,omitempty"`
+}
+
+// RolloutPolicy describes how a branch is gradually rolled out to the
+// units of the applications changed under it.
+type RolloutPolicy struct {
+	// Steps holds the percentages of each application's units that
+	// should be tracking the branch after each step. They must increase,
+	// and the last must be 100. Once every unit is tracking the branch,
+	// the branch is committed.
+	Steps []int
+
+	// Start, if set, is when the rollout begins.
+	Start time.Time
+
+	// StepInterval is the minimum time between steps.
+	StepInterval time.Duration
+
+	// WaitForActive indicates that a step is only taken once every unit
+	// tracking the branch reports an active workload status.
+	WaitForActive bool
+
+	// AutoAbort indicates that the rollout is abandoned, and the branch
+	// aborted, if any unit tracking the branch goes into error.
+	AutoAbort bool
+}
+
+// Validate returns an error if the policy cannot drive a rollout.
+func (p RolloutPolicy) Validate() error {
+	if len(p.Steps) == 0 {
+		return errors.NotValidf("empty rollout steps")
+	}
+	last := 0
+	for _, step := range p.Steps {
+		if step <= last || step > 100 {
+			return errors.NotValidf("rollout steps %v", p.Steps)
+		}
+		last = step
+	}
+	if last != 100 {
+		return errors.NotValidf("rollout steps %v ending before 100", p.Steps)
+	}
+	if p.StepInterval < 0 {
+		return errors.NotValidf("negative rollout step interval")
+	}
+	return nil
+}
+
+// RolloutStatus describes the progress of a branch rollout.
+type RolloutStatus string
+
+const (
+	// RolloutPending indicates that a rollout has not yet started.
+	RolloutPending RolloutStatus = "pending"
+
+	// RolloutRunning indicates that units are being moved onto the
+	// branch step by step.
+	RolloutRunning RolloutStatus = "running"
+
+	// RolloutCompleted indicates that the branch was committed.
+	RolloutCompleted RolloutStatus = "completed"
+
+	// RolloutAborted indicates that the branch was aborted.
+	RolloutAborted RolloutStatus = "aborted"
+)
+
+// IsFinal returns whether the rollout has come to an end.
+func (s RolloutStatus) IsFinal() bool {
+	return s == RolloutCompleted || s == RolloutAborted
+}
+
+// GenerationRollout represents the rollout policy and progress
+// of a generation.
+type GenerationRollout struct {
+	// Steps are the percentages of units tracking the branch after
+	// each step.
+	Steps []int `yaml:"steps"`
+
+	// Start is the formatted time at which the rollout begins.
+	Start string `yaml:"start,omitempty"`
+
+	// StepInterval is the minimum time between steps.
+	StepInterval time.Duration `yaml:"step-interval,omitempty"`
+
+	// WaitForActive and AutoAbort reflect the rollout policy.
+	WaitForActive bool `yaml:"wait-for-active,omitempty"`
+	AutoAbort     bool `yaml:"auto-abort,omitempty"`
+
+	// Status is the progress of the rollout.
+	Status RolloutStatus `yaml:"status"`
+
+	// Step is the number of steps taken.
+	Step int `yaml:"step"`
+
+	// Message explains the rollout status, if needed.
+	Message string `yaml:"message,omitempty"`
 }
 
 // GenerationCommit represents a model generation's commit details.
@@ -0,0 +1,61 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+)
+
+type GenerationSuite struct{}
+
+var _ = gc.Suite(&GenerationSuite{})
+
+func (s *GenerationSuite) TestRolloutPolicyValidate(c *gc.C) {
+	tests := []struct {
+		policy model.RolloutPolicy
+		err    string
+	}{{
+		policy: model.RolloutPolicy{Steps: []int{100}},
+	}, {
+		policy: model.RolloutPolicy{Steps: []int{10, 50, 100}, StepInterval: time.Hour},
+	}, {
+		err: "empty rollout steps not valid",
+	}, {
+		policy: model.RolloutPolicy{Steps: []int{50, 10, 100}},
+		err:    `rollout steps \[50 10 100\] not valid`,
+	}, {
+		policy: model.RolloutPolicy{Steps: []int{0, 100}},
+		err:    `rollout steps \[0 100\] not valid`,
+	}, {
+		policy: model.RolloutPolicy{Steps: []int{50, 150}},
+		err:    `rollout steps \[50 150\] not valid`,
+	}, {
+		policy: model.RolloutPolicy{Steps: []int{10, 50}},
+		err:    `rollout steps \[10 50\] ending before 100 not valid`,
+	}, {
+		policy: model.RolloutPolicy{Steps: []int{100}, StepInterval: -time.Second},
+		err:    "negative rollout step interval not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.policy)
+		err := test.policy.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *GenerationSuite) TestRolloutStatusIsFinal(c *gc.C) {
+	c.Check(model.RolloutPending.IsFinal(), jc.IsFalse)
+	c.Check(model.RolloutRunning.IsFinal(), jc.IsFalse)
+	c.Check(model.RolloutCompleted.IsFinal(), jc.IsTrue)
+	c.Check(model.RolloutAborted.IsFinal(), jc.IsTrue)
+}
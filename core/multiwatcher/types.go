@@ -28,6 +28,8 @@ const (
 	ModelKind             = "model"
 	RelationKind          = "relation"
 	RemoteApplicationKind = "remoteApplication"
+	SecretKind            = "secret"
+	StorageInstanceKind   = "storageInstance"
 	UnitKind              = "unit"
 )
 
@@ -456,6 +458,7 @@ type RelationInfo struct {
 	Key       string
 	ID        int
 	Endpoints []Endpoint
+	Status    StatusInfo
 }
 
 // Endpoint holds an application-relation pair.
@@ -666,3 +669,80 @@ func (i *BranchInfo) Clone() EntityInfo {
 	}
 	return &clone
 }
+
+// StorageInstanceInfo holds data about a storage instance
+// that is tracked by multiwatcherStore.
+type StorageInstanceInfo struct {
+	ModelUUID   string
+	ID          string
+	Kind        string
+	Owner       string
+	StorageName string
+	Pool        string
+	Life        life.Value
+
+	// Attachments holds the life of each attachment of the
+	// storage instance, keyed on the name of the attached unit.
+	Attachments map[string]life.Value
+}
+
+// EntityID returns a unique identifier for a storage instance
+// across models.
+func (i *StorageInstanceInfo) EntityID() EntityID {
+	return EntityID{
+		Kind:      StorageInstanceKind,
+		ModelUUID: i.ModelUUID,
+		ID:        i.ID,
+	}
+}
+
+// Clone returns a clone of the EntityInfo.
+func (i *StorageInstanceInfo) Clone() EntityInfo {
+	clone := *i
+	if i.Attachments != nil {
+		clone.Attachments = map[string]life.Value{}
+		for k, v := range i.Attachments {
+			clone.Attachments[k] = v
+		}
+	}
+	return &clone
+}
+
+// SecretInfo holds data about a secret owned by a model
+// that is tracked by multiwatcherStore. The secret content
+// is never tracked.
+type SecretInfo struct {
+	ModelUUID      string
+	ID             string
+	Owner          string
+	Label          string
+	Description    string
+	LatestRevision int
+	RotatePolicy   string
+	UpdateTime     time.Time
+
+	// Consumers holds the revision each consumer in the
+	// model is currently using, keyed on the consumer tag.
+	Consumers map[string]int
+}
+
+// EntityID returns a unique identifier for a secret across models.
+func (i *SecretInfo) EntityID() EntityID {
+	return EntityID{
+		Kind:      SecretKind,
+		ModelUUID: i.ModelUUID,
+		ID:        i.ID,
+	}
+}
+
+// Clone returns a clone of the EntityInfo.
+func (i *SecretInfo) Clone() EntityInfo {
+	clone := *i
+	if i.Consumers != nil {
+		clone.Consumers = map[string]int{}
+		for k, v := range i.Consumers {
+			clone.Consumers[k] = v
+		}
+	}
+	return &clone
+}
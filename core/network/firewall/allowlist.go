@@ -0,0 +1,109 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+)
+
+// IntersectCIDRs returns the CIDRs describing the addresses that are
+// in both one of cidrs and one of allowed. Invalid CIDRs are ignored.
+// The result is sorted.
+func IntersectCIDRs(cidrs, allowed []string) []string {
+	result := set.NewStrings()
+	for _, cidr := range cidrs {
+		_, cidrNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		for _, allow := range allowed {
+			_, allowNet, err := net.ParseCIDR(allow)
+			if err != nil {
+				continue
+			}
+			// Two CIDRs either don't overlap, or one contains the
+			// other; the smaller one is the intersection.
+			cidrOnes, cidrBits := cidrNet.Mask.Size()
+			allowOnes, allowBits := allowNet.Mask.Size()
+			if cidrBits != allowBits {
+				continue
+			}
+			if cidrOnes >= allowOnes && allowNet.Contains(cidrNet.IP) {
+				result.Add(cidrNet.String())
+			} else if allowOnes > cidrOnes && cidrNet.Contains(allowNet.IP) {
+				result.Add(allowNet.String())
+			}
+		}
+	}
+	return result.SortedValues()
+}
+
+// EndpointIngressAllowlist holds the CIDRs allowed to reach the ports
+// opened for exposed application endpoints, keyed by
+// "<application>:<endpoint>".
+type EndpointIngressAllowlist map[string][]string
+
+// EndpointKey returns the key of an application endpoint in an
+// EndpointIngressAllowlist.
+func EndpointKey(application, endpoint string) string {
+	return application + ":" + endpoint
+}
+
+// ParseEndpointIngressAllowlist parses a space separated list of
+// "<application>:<endpoint>=<cidr>[,<cidr>...]" entries. An entry with
+// no CIDRs allows no ingress to the endpoint.
+func ParseEndpointIngressAllowlist(value string) (EndpointIngressAllowlist, error) {
+	result := make(EndpointIngressAllowlist)
+	for _, entry := range strings.Fields(value) {
+		key, cidrs, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, errors.NotValidf("endpoint ingress rule %q, expected <application>:<endpoint>=<cidr>[,<cidr>...]", entry)
+		}
+		if err := ValidateEndpointKey(key); err != nil {
+			return nil, errors.Trace(err)
+		}
+		allowed := []string{}
+		if cidrs != "" {
+			allowed = strings.Split(cidrs, ",")
+		}
+		for _, cidr := range allowed {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, errors.NotValidf("cidr %q for endpoint %q", cidr, key)
+			}
+		}
+		result[key] = allowed
+	}
+	return result, nil
+}
+
+// ValidateEndpointKey returns an error if key doesn't name an
+// application endpoint in the form "<application>:<endpoint>".
+func ValidateEndpointKey(key string) error {
+	application, endpoint, ok := strings.Cut(key, ":")
+	if !ok || endpoint == "" || !names.IsValidApplication(application) {
+		return errors.NotValidf("application endpoint %q, expected <application>:<endpoint>", key)
+	}
+	return nil
+}
+
+// String returns the allowlist in the form read by
+// ParseEndpointIngressAllowlist.
+func (l EndpointIngressAllowlist) String() string {
+	keys := make([]string, 0, len(l))
+	for key := range l {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]string, len(keys))
+	for i, key := range keys {
+		entries[i] = key + "=" + strings.Join(l[key], ",")
+	}
+	return strings.Join(entries, " ")
+}
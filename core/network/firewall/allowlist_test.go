@@ -0,0 +1,86 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+var _ = gc.Suite(&AllowlistSuite{})
+
+type AllowlistSuite struct {
+	testing.IsolationSuite
+}
+
+func (AllowlistSuite) TestIntersectCIDRs(c *gc.C) {
+	for i, test := range []struct {
+		cidrs, allowed, expected []string
+	}{{
+		cidrs:    []string{"0.0.0.0/0", "::/0"},
+		allowed:  []string{"0.0.0.0/0", "::/0"},
+		expected: []string{"0.0.0.0/0", "::/0"},
+	}, {
+		cidrs:    []string{"0.0.0.0/0", "::/0"},
+		allowed:  []string{"10.0.0.0/8", "192.168.1.0/24"},
+		expected: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	}, {
+		cidrs:    []string{"10.1.0.0/16", "172.16.0.0/12"},
+		allowed:  []string{"10.0.0.0/8"},
+		expected: []string{"10.1.0.0/16"},
+	}, {
+		cidrs:    []string{"10.1.0.0/16"},
+		allowed:  []string{"2001:db8::/32", "192.168.0.0/16"},
+		expected: []string{},
+	}, {
+		cidrs:    []string{"10.1.0.0/16"},
+		allowed:  []string{},
+		expected: []string{},
+	}, {
+		cidrs:    []string{"10.1.2.3/16", "bogus"},
+		allowed:  []string{"10.1.0.0/24", "bogus"},
+		expected: []string{"10.1.0.0/24"},
+	}} {
+		c.Logf("test %d: %v and %v", i, test.cidrs, test.allowed)
+		c.Check(IntersectCIDRs(test.cidrs, test.allowed), jc.DeepEquals, test.expected)
+	}
+}
+
+func (AllowlistSuite) TestParseEndpointIngressAllowlist(c *gc.C) {
+	allowlist, err := ParseEndpointIngressAllowlist(" mysql:db=10.0.0.0/8,192.168.0.0/16  wordpress:website= ")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowlist, jc.DeepEquals, EndpointIngressAllowlist{
+		"mysql:db":          {"10.0.0.0/8", "192.168.0.0/16"},
+		"wordpress:website": {},
+	})
+	c.Assert(allowlist.String(), gc.Equals, "mysql:db=10.0.0.0/8,192.168.0.0/16 wordpress:website=")
+
+	allowlist, err = ParseEndpointIngressAllowlist("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowlist, gc.HasLen, 0)
+	c.Assert(allowlist.String(), gc.Equals, "")
+}
+
+func (AllowlistSuite) TestParseEndpointIngressAllowlistErrors(c *gc.C) {
+	for _, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "mysql:db",
+		err:   `endpoint ingress rule "mysql:db", expected <application>:<endpoint>=<cidr>\[,<cidr>...\] not valid`,
+	}, {
+		value: "mysql=10.0.0.0/8",
+		err:   `application endpoint "mysql", expected <application>:<endpoint> not valid`,
+	}, {
+		value: "-mysql:db=10.0.0.0/8",
+		err:   `application endpoint "-mysql:db", expected <application>:<endpoint> not valid`,
+	}, {
+		value: "mysql:db=10.0.0.0",
+		err:   `cidr "10.0.0.0" for endpoint "mysql:db" not valid`,
+	}} {
+		_, err := ParseEndpointIngressAllowlist(test.value)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/network"
+)
+
+// EgressAllowKey is the application config key holding the rules that
+// restrict the outbound traffic of an application's units. When it is
+// empty, outbound traffic is not restricted.
+const EgressAllowKey = "egress-allow"
+
+// MetadataServiceCIDR is the destination of the link-local metadata
+// service clouds provide to their instances.
+const MetadataServiceCIDR = "169.254.169.254/32"
+
+// EgressRule represents a rule for allowing outbound traffic to a
+// destination CIDR, optionally on a particular port range.
+type EgressRule struct {
+	// The CIDR describing the destination of the outgoing traffic.
+	DestinationCIDR string
+
+	// The destination port range for the outgoing traffic. If it is
+	// the zero value, all traffic to the destination is allowed.
+	PortRange network.PortRange
+}
+
+// AllPorts returns true if the rule allows all traffic to its
+// destination.
+func (r EgressRule) AllPorts() bool {
+	return r.PortRange == network.PortRange{}
+}
+
+// Validate ensures that the egress rule contains a valid destination.
+func (r EgressRule) Validate() error {
+	if _, _, err := net.ParseCIDR(r.DestinationCIDR); err != nil {
+		return errors.NotValidf("egress rule destination %q", r.DestinationCIDR)
+	}
+	if r.AllPorts() {
+		return nil
+	}
+	return errors.Annotatef(r.PortRange.Validate(), "invalid port range for egress rule")
+}
+
+// String returns the rule in the form read by ParseEgressRule.
+func (r EgressRule) String() string {
+	if r.AllPorts() {
+		return r.DestinationCIDR
+	}
+	return r.DestinationCIDR + ":" + r.PortRange.String()
+}
+
+// ParseEgressRule parses an egress rule in the form
+// "<cidr>[:<port-range>]", where the port range is in the form read by
+// network.ParsePortRange. Examples: "10.0.0.0/8", "0.0.0.0/0:443/tcp",
+// "192.168.1.0/24:8000-8099/udp", "2001:db8::/32:53/udp".
+func ParseEgressRule(value string) (EgressRule, error) {
+	// The port range follows the first colon after the CIDR's prefix
+	// length, as IPv6 addresses contain colons themselves.
+	slash := strings.Index(value, "/")
+	if slash < 0 {
+		return EgressRule{}, errors.NotValidf("egress rule %q, expected <cidr>[:<port-range>]", value)
+	}
+	cidr, portRange := value, ""
+	if colon := strings.Index(value[slash:], ":"); colon >= 0 {
+		cidr, portRange = value[:slash+colon], value[slash+colon+1:]
+	}
+	rule := EgressRule{DestinationCIDR: cidr}
+	if _, _, err := net.ParseCIDR(cidr); err != nil {
+		return EgressRule{}, errors.NotValidf("cidr %q in egress rule %q", cidr, value)
+	}
+	if portRange == "" {
+		return rule, nil
+	}
+	var err error
+	if rule.PortRange, err = network.ParsePortRange(portRange); err != nil {
+		return EgressRule{}, errors.Annotatef(err, "egress rule %q", value)
+	}
+	return rule, nil
+}
+
+// EgressRules represents a collection of EgressRule instances.
+type EgressRules []EgressRule
+
+// ParseEgressRules parses a space separated list of egress rules, as
+// held in an application's config, and returns them sorted with any
+// duplicates removed.
+func ParseEgressRules(value string) (EgressRules, error) {
+	var rules EgressRules
+	for _, field := range strings.Fields(value) {
+		rule, err := ParseEgressRule(field)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules.UniqueRules(), nil
+}
+
+// Sort the rule list by destination and then by port range.
+func (rules EgressRules) Sort() {
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].DestinationCIDR != rules[j].DestinationCIDR {
+			return rules[i].DestinationCIDR < rules[j].DestinationCIDR
+		}
+		return rules[i].PortRange.LessThan(rules[j].PortRange)
+	})
+}
+
+// UniqueRules returns a sorted copy of the egress rule list after
+// removing any duplicate entries.
+func (rules EgressRules) UniqueRules() EgressRules {
+	seen := make(map[EgressRule]bool)
+	var uniqueRules EgressRules
+	for _, rule := range rules {
+		if seen[rule] {
+			continue
+		}
+		seen[rule] = true
+		uniqueRules = append(uniqueRules, rule)
+	}
+	uniqueRules.Sort()
+	return uniqueRules
+}
+
+// EqualTo returns true if this rule list holds the same rules as the
+// provided rule list.
+func (rules EgressRules) EqualTo(other EgressRules) bool {
+	this, that := rules.UniqueRules(), other.UniqueRules()
+	if len(this) != len(that) {
+		return false
+	}
+	for i, rule := range this {
+		if rule != that[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the rules in the form read by ParseEgressRules.
+func (rules EgressRules) String() string {
+	values := make([]string, len(rules))
+	for i, rule := range rules {
+		values[i] = rule.String()
+	}
+	return strings.Join(values, " ")
+}
+
+// EssentialEgressRules returns the egress rules that restricted units
+// always need for their agents to keep working: DNS, the cloud's metadata
+// service and the controller API at the given "host:port" addresses.
+// The API port of controller addresses that aren't IP addresses is
+// allowed to any destination.
+func EssentialEgressRules(controllerAddrs []string) (EgressRules, error) {
+	var rules EgressRules
+	for _, cidr := range []string{AllNetworksIPV4CIDR, AllNetworksIPV6CIDR} {
+		rules = append(rules,
+			EgressRule{DestinationCIDR: cidr, PortRange: network.MustParsePortRange("53/udp")},
+			EgressRule{DestinationCIDR: cidr, PortRange: network.MustParsePortRange("53/tcp")},
+		)
+	}
+	rules = append(rules, EgressRule{DestinationCIDR: MetadataServiceCIDR})
+
+	for _, addr := range controllerAddrs {
+		host, portStr, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, errors.NotValidf("controller address %q", addr)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, errors.NotValidf("port in controller address %q", addr)
+		}
+		portRange := network.PortRange{Protocol: "tcp", FromPort: port, ToPort: port}
+		cidrs := []string{AllNetworksIPV4CIDR, AllNetworksIPV6CIDR}
+		if ip := net.ParseIP(host); ip != nil {
+			if ip.To4() != nil {
+				cidrs = []string{ip.String() + "/32"}
+			} else {
+				cidrs = []string{ip.String() + "/128"}
+			}
+		}
+		for _, cidr := range cidrs {
+			rules = append(rules, EgressRule{DestinationCIDR: cidr, PortRange: portRange})
+		}
+	}
+	return rules.UniqueRules(), nil
+}
+
+// WithEssentials returns the rules along with the essential egress rules
+// for reaching the controller at the given "host:port" addresses; see
+// EssentialEgressRules. No rules leave outbound traffic unrestricted, so
+// nothing is added to them.
+func (rules EgressRules) WithEssentials(controllerAddrs []string) (EgressRules, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	essentials, err := EssentialEgressRules(controllerAddrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(append(EgressRules{}, rules...), essentials...).UniqueRules(), nil
+}
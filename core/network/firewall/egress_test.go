@@ -0,0 +1,123 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/network"
+)
+
+var _ = gc.Suite(&EgressRuleSuite{})
+
+type EgressRuleSuite struct {
+	testing.IsolationSuite
+}
+
+func (EgressRuleSuite) TestParseEgressRule(c *gc.C) {
+	for _, test := range []struct {
+		value string
+		rule  EgressRule
+		str   string
+		err   string
+	}{{
+		value: "10.0.0.0/8",
+		rule:  EgressRule{DestinationCIDR: "10.0.0.0/8"},
+	}, {
+		value: "0.0.0.0/0:443",
+		rule:  EgressRule{DestinationCIDR: "0.0.0.0/0", PortRange: network.MustParsePortRange("443/tcp")},
+		str:   "0.0.0.0/0:443/tcp",
+	}, {
+		value: "192.168.1.0/24:8000-8099/udp",
+		rule:  EgressRule{DestinationCIDR: "192.168.1.0/24", PortRange: network.MustParsePortRange("8000-8099/udp")},
+	}, {
+		value: "2001:db8::/32:53/udp",
+		rule:  EgressRule{DestinationCIDR: "2001:db8::/32", PortRange: network.MustParsePortRange("53/udp")},
+	}, {
+		value: "2001:db8::/32",
+		rule:  EgressRule{DestinationCIDR: "2001:db8::/32"},
+	}, {
+		value: "10.0.0.1",
+		err:   `egress rule "10.0.0.1", expected <cidr>\[:<port-range>\] not valid`,
+	}, {
+		value: "10.0.0.0/33:80",
+		err:   `cidr "10.0.0.0/33" in egress rule "10.0.0.0/33:80" not valid`,
+	}, {
+		value: "10.0.0.0/8:80/gopher",
+		err:   `egress rule "10.0.0.0/8:80/gopher": invalid protocol "gopher".*`,
+	}} {
+		c.Logf("value: %q", test.value)
+		rule, err := ParseEgressRule(test.value)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(rule, gc.Equals, test.rule)
+		if test.str == "" {
+			test.str = test.value
+		}
+		c.Check(rule.String(), gc.Equals, test.str)
+	}
+}
+
+func (EgressRuleSuite) TestParseEgressRules(c *gc.C) {
+	rules, err := ParseEgressRules("  10.0.0.0/8:443/tcp 0.0.0.0/0:53/udp\n10.0.0.0/8:443/tcp ")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, EgressRules{
+		{DestinationCIDR: "0.0.0.0/0", PortRange: network.MustParsePortRange("53/udp")},
+		{DestinationCIDR: "10.0.0.0/8", PortRange: network.MustParsePortRange("443/tcp")},
+	})
+	c.Assert(rules.String(), gc.Equals, "0.0.0.0/0:53/udp 10.0.0.0/8:443/tcp")
+
+	rules, err = ParseEgressRules("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	_, err = ParseEgressRules("10.0.0.0/8 bad")
+	c.Assert(err, gc.ErrorMatches, `egress rule "bad", expected .* not valid`)
+}
+
+func (EgressRuleSuite) TestEqualTo(c *gc.C) {
+	a, err := ParseEgressRules("10.0.0.0/8 0.0.0.0/0:443")
+	c.Assert(err, jc.ErrorIsNil)
+	b, err := ParseEgressRules("0.0.0.0/0:443/tcp 10.0.0.0/8 10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.EqualTo(b), jc.IsTrue)
+	c.Assert(a.EqualTo(b[:1]), jc.IsFalse)
+	c.Assert(EgressRules(nil).EqualTo(EgressRules{}), jc.IsTrue)
+}
+
+func (EgressRuleSuite) TestValidate(c *gc.C) {
+	c.Assert(EgressRule{DestinationCIDR: "10.0.0.0/8"}.Validate(), jc.ErrorIsNil)
+	c.Assert(EgressRule{DestinationCIDR: "10.0.0.0"}.Validate(), gc.ErrorMatches, `egress rule destination "10.0.0.0" not valid`)
+	rule := EgressRule{
+		DestinationCIDR: "10.0.0.0/8",
+		PortRange:       network.PortRange{Protocol: "tcp", FromPort: 90, ToPort: 80},
+	}
+	c.Assert(rule.Validate(), gc.ErrorMatches, "invalid port range for egress rule: .*")
+}
+
+func (EgressRuleSuite) TestEssentialEgressRules(c *gc.C) {
+	rules, err := EssentialEgressRules([]string{"10.0.0.1:17070", "[2001:db8::1]:17070", "controller.example.com:17070"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules.String(), gc.Equals, "0.0.0.0/0:53/tcp 0.0.0.0/0:17070/tcp 0.0.0.0/0:53/udp "+
+		"10.0.0.1/32:17070/tcp 169.254.169.254/32 2001:db8::1/128:17070/tcp ::/0:53/tcp ::/0:17070/tcp ::/0:53/udp")
+
+	_, err = EssentialEgressRules([]string{"10.0.0.1"})
+	c.Assert(err, gc.ErrorMatches, `controller address "10.0.0.1" not valid`)
+}
+
+func (EgressRuleSuite) TestWithEssentials(c *gc.C) {
+	rules, err := EgressRules(nil).WithEssentials([]string{"10.0.0.1:17070"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	rules, err = EgressRules{{DestinationCIDR: "192.168.0.0/16"}}.WithEssentials([]string{"10.0.0.1:17070"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules.String(), gc.Equals, "0.0.0.0/0:53/tcp 0.0.0.0/0:53/udp 10.0.0.1/32:17070/tcp "+
+		"169.254.169.254/32 192.168.0.0/16 ::/0:53/tcp ::/0:53/udp")
+}
@@ -14,6 +14,10 @@ const (
 
 	// JujuApplicationOfferRule is a rule for connections to a Juju offer.
 	JujuApplicationOfferRule = WellKnownServiceType("juju-application-offer")
+
+	// ExposedApplicationRule is a rule for connections to the ports
+	// opened by exposed applications.
+	ExposedApplicationRule = WellKnownServiceType("exposed")
 )
 
 // WellKnownService defines a service for which firewall rules may be applied.
@@ -21,7 +25,7 @@ type WellKnownServiceType string
 
 func (v WellKnownServiceType) Validate() error {
 	switch v {
-	case SSHRule, JujuControllerRule, JujuApplicationOfferRule:
+	case SSHRule, JujuControllerRule, JujuApplicationOfferRule, ExposedApplicationRule:
 		return nil
 	}
 	return errors.NotValidf("well known service type %q", v)
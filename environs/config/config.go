@@ -4,6 +4,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/url"
@@ -29,10 +30,13 @@ import (
 	corebase "github.com/juju/juju/core/base"
 	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+	coresecrets "github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -201,6 +205,10 @@ const (
 	// StorageDefaultFilesystemSourceKey is the key for the default filesystem storage source.
 	StorageDefaultFilesystemSourceKey = "storage-default-filesystem-source"
 
+	// StorageUsageWarningThresholdKey is the key for the percentage of
+	// space or inodes used at which storage usage is flagged as a warning.
+	StorageUsageWarningThresholdKey = "storage-usage-warning-threshold"
+
 	// ResourceTagsKey is an optional list or space-separated string
 	// of k=v pairs, defining the tags for ResourceTags.
 	ResourceTagsKey = "resource-tags"
@@ -219,6 +227,33 @@ const (
 	// forwarding.
 	LogFwdSyslogClientCert = "syslog-client-cert"
 
+	// LogFwdType sets the kind of log forwarding target, one of syslog,
+	// otlp or loki. It defaults to syslog.
+	LogFwdType = "logforward-type"
+
+	// LogFwdURL sets the URL of the OTLP or Loki endpoint that logs are
+	// pushed to.
+	LogFwdURL = "logforward-url"
+
+	// LogFwdCACert sets the certificate of the CA that signed the OTLP
+	// or Loki endpoint certificate.
+	LogFwdCACert = "logforward-ca-cert"
+
+	// LogFwdHeaders sets additional headers sent to the OTLP or Loki
+	// endpoint, as a JSON object mapping header names to values.
+	// Headers carrying credentials are not allowed; they are held
+	// in the secret referenced by LogFwdHeadersSecret instead.
+	LogFwdHeaders = "logforward-headers"
+
+	// LogFwdHeadersSecret sets the URI of a user secret whose content
+	// holds headers, such as authorization headers, sent to the OTLP
+	// or Loki endpoint.
+	LogFwdHeadersSecret = "logforward-headers-secret"
+
+	// LogFwdBatchSize sets the maximum number of log records sent to the
+	// OTLP or Loki endpoint in a single request.
+	LogFwdBatchSize = "logforward-batch-size"
+
 	// LogFwdSyslogClientKey sets the client key for syslog
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
@@ -298,6 +333,15 @@ const (
 	// specifying what ingress can be applied to offers in this model
 	SAASIngressAllowKey = "saas-ingress-allow"
 
+	// ExposedIngressAllowKey is a comma separated list of CIDRs allowed
+	// to reach the ports opened by exposed applications in this model.
+	ExposedIngressAllowKey = "exposed-ingress-allow"
+
+	// ExposedEndpointIngressAllowKey is a space separated list of
+	// <application>:<endpoint>=<cidr>[,<cidr>...] entries, overriding
+	// exposed-ingress-allow for the ports opened for those endpoints.
+	ExposedEndpointIngressAllowKey = "exposed-endpoint-ingress-allow"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -636,8 +680,13 @@ var defaultConfigValues = map[string]interface{}{
 	SecretBackendKey: DefaultSecretBackend,
 
 	// Model firewall settings
-	SSHAllowKey:         "0.0.0.0/0,::/0",
-	SAASIngressAllowKey: "0.0.0.0/0,::/0",
+	SSHAllowKey:                    "0.0.0.0/0,::/0",
+	SAASIngressAllowKey:            "0.0.0.0/0,::/0",
+	ExposedIngressAllowKey:         "0.0.0.0/0,::/0",
+	ExposedEndpointIngressAllowKey: "",
+
+	// Storage settings.
+	StorageUsageWarningThresholdKey: DefaultStorageUsageWarningThreshold,
 }
 
 // defaultLoggingConfig is the default value for logging-config if it is otherwise not set.
@@ -745,9 +794,19 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
-	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
+	if _, err := cfg.logFwdHeaders(); err != nil {
+		return errors.Annotatef(err, "invalid %s", LogFwdHeaders)
+	}
+
+	if v, ok := cfg.defined[LogFwdHeadersSecret].(string); ok && v != "" {
+		if _, err := coresecrets.ParseURI(v); err != nil {
+			return errors.Annotatef(err, "invalid %s", LogFwdHeadersSecret)
+		}
+	}
+
+	if lfCfg, ok := cfg.LogFwdTarget(); ok {
 		if err := lfCfg.Validate(); err != nil {
-			return errors.Annotate(err, "invalid syslog forwarding config")
+			return errors.Trace(err)
 		}
 	}
 
@@ -900,6 +959,14 @@ func Validate(cfg, old *Config) error {
 		return errors.Trace(err)
 	}
 
+	if err := cfg.validateCIDRs(cfg.ExposedIngressAllow(), true); err != nil {
+		return errors.Trace(err)
+	}
+
+	if _, err := firewall.ParseEndpointIngressAllowlist(cfg.asString(ExposedEndpointIngressAllowKey)); err != nil {
+		return errors.Trace(err)
+	}
+
 	if err := cfg.validateLoggingOutput(); err != nil {
 		return errors.Trace(err)
 	}
@@ -912,6 +979,10 @@ func Validate(cfg, old *Config) error {
 		return errors.Trace(err)
 	}
 
+	if err := cfg.validateStorageUsageWarningThreshold(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if old != nil {
 		// Check the immutable config values.  These can't change
 		for _, attr := range immutableAttributes {
@@ -1333,6 +1404,79 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdTarget returns the log forwarding target config, which holds
+// the config of either the syslog or the OTLP/Loki HTTP target.
+func (c *Config) LogFwdTarget() (*target.RawConfig, bool) {
+	var lfCfg target.RawConfig
+
+	syslogCfg, partial := c.LogFwdSyslog()
+	if partial {
+		lfCfg.Enabled = syslogCfg.Enabled
+		lfCfg.Syslog = *syslogCfg
+	}
+
+	if s, ok := c.defined[LogFwdType]; ok && s != "" {
+		partial = true
+		lfCfg.Type = target.Type(s.(string))
+	}
+
+	if s, ok := c.defined[LogFwdURL]; ok && s != "" {
+		partial = true
+		lfCfg.HTTP.URL = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdCACert]; ok && s != "" {
+		partial = true
+		lfCfg.HTTP.CACert = s.(string)
+	}
+
+	// Headers have already been validated.
+	if headers, _ := c.logFwdHeaders(); len(headers) > 0 {
+		partial = true
+		lfCfg.HTTP.Headers = headers
+	}
+
+	if s, ok := c.defined[LogFwdBatchSize]; ok {
+		partial = true
+		lfCfg.HTTP.BatchSize = s.(int)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogFwdHeadersSecret returns the URI of the user secret holding the
+// headers sent to the OTLP or Loki log forwarding endpoint, or "" if
+// there is none.
+func (c *Config) LogFwdHeadersSecret() string {
+	return c.asString(LogFwdHeadersSecret)
+}
+
+// credentialHeaders holds the (lower case) names of headers which
+// carry credentials and so must not be stored in model config.
+var credentialHeaders = set.NewStrings("authorization", "proxy-authorization", "cookie")
+
+// logFwdHeaders returns the headers sent to the OTLP or Loki endpoint,
+// parsed from the JSON object held in model config.
+func (c *Config) logFwdHeaders() (map[string]string, error) {
+	v := c.asString(LogFwdHeaders)
+	if v == "" {
+		return nil, nil
+	}
+	var headers map[string]string
+	if err := json.Unmarshal([]byte(v), &headers); err != nil {
+		return nil, errors.Errorf("expected a JSON object of header names to values: %v", err)
+	}
+	for name := range headers {
+		if credentialHeaders.Contains(strings.ToLower(name)) {
+			return nil, errors.Errorf("header %q carries credentials, use %s instead", name, LogFwdHeadersSecret)
+		}
+	}
+	return headers, nil
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1649,6 +1793,28 @@ func (c *Config) SAASIngressAllow() []string {
 	return strings.Split(allowList, ",")
 }
 
+// ExposedIngressAllow returns a slice of CIDRs allowed to reach the
+// ports opened by exposed applications in this model.
+func (c *Config) ExposedIngressAllow() []string {
+	allowList, ok := c.defined[ExposedIngressAllowKey].(string)
+	if !ok {
+		return []string{"0.0.0.0/0", "::/0"}
+	}
+	if allowList == "" {
+		return []string{}
+	}
+	return strings.Split(allowList, ",")
+}
+
+// ExposedEndpointIngressAllow returns the CIDRs allowed to reach the
+// ports opened for exposed application endpoints, where they override
+// ExposedIngressAllow.
+func (c *Config) ExposedEndpointIngressAllow() firewall.EndpointIngressAllowlist {
+	// The value is checked when the config is validated.
+	allowlist, _ := firewall.ParseEndpointIngressAllowlist(c.asString(ExposedEndpointIngressAllowKey))
+	return allowlist
+}
+
 func (c *Config) validateCIDRs(cidrs []string, allowEmpty bool) error {
 	if len(cidrs) == 0 && !allowEmpty {
 		return errors.NotValidf("empty cidrs")
@@ -1723,6 +1889,31 @@ func (c *Config) StorageDefaultFilesystemSource() (string, bool) {
 	return bs, bs != ""
 }
 
+// DefaultStorageUsageWarningThreshold is the default percentage of
+// space or inodes used at which storage usage is flagged as a warning.
+const DefaultStorageUsageWarningThreshold = 90
+
+// StorageUsageWarningThreshold returns the percentage of space or inodes
+// used at which storage usage is flagged as a warning. Zero disables the
+// warning.
+func (c *Config) StorageUsageWarningThreshold() int {
+	value, ok := c.defined[StorageUsageWarningThresholdKey].(int)
+	if !ok {
+		return DefaultStorageUsageWarningThreshold
+	}
+	return value
+}
+
+// validateStorageUsageWarningThreshold ensures the threshold is a
+// percentage.
+func (c *Config) validateStorageUsageWarningThreshold() error {
+	value, ok := c.defined[StorageUsageWarningThresholdKey].(int)
+	if ok && (value < 0 || value > 100) {
+		return errors.Errorf("%s: must be between 0 and 100", StorageUsageWarningThresholdKey)
+	}
+	return nil
+}
+
 // ResourceTags returns a set of tags to set on environment resources
 // that Juju creates and manages, if the provider supports them. These
 // tags have no special meaning to Juju, but may be used for existing
@@ -1905,16 +2096,25 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdType:             schema.Omit,
+	LogFwdURL:              schema.Omit,
+	LogFwdCACert:           schema.Omit,
+	LogFwdHeaders:          schema.Omit,
+	LogFwdHeadersSecret:    schema.Omit,
+	LogFwdBatchSize:        schema.Omit,
 	LoggingOutputKey:       schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey:      schema.Omit,
 	StorageDefaultFilesystemSourceKey: schema.Omit,
+	StorageUsageWarningThresholdKey:   schema.Omit,
 
-	"firewall-mode":     schema.Omit,
-	SSHAllowKey:         schema.Omit,
-	SAASIngressAllowKey: schema.Omit,
+	"firewall-mode":                schema.Omit,
+	SSHAllowKey:                    schema.Omit,
+	SAASIngressAllowKey:            schema.Omit,
+	ExposedIngressAllowKey:         schema.Omit,
+	ExposedEndpointIngressAllowKey: schema.Omit,
 
 	"logging-config":                schema.Omit,
 	ProvisionerHarvestModeKey:       schema.Omit,
@@ -2412,6 +2612,37 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdType: {
+		Description: `The kind of log forwarding target, one of syslog, otlp or loki (default syslog).`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"syslog", "otlp", "loki"},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdURL: {
+		Description: `The URL of the OTLP or Loki endpoint that logs are pushed to.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdCACert: {
+		Description: `The certificate of the CA that signed the OTLP or Loki endpoint certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHeaders: {
+		Description: `A JSON object of headers sent with every request to the OTLP or Loki endpoint, for example {"X-Scope-OrgID": "tenant"}. Use logforward-headers-secret for headers carrying credentials.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHeadersSecret: {
+		Description: `The URI of a user secret whose content holds headers, such as authorization, sent with every request to the OTLP or Loki endpoint.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdBatchSize: {
+		Description: `The maximum number of log records sent to the OTLP or Loki endpoint in a single request (default 100).`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -2427,6 +2658,13 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	StorageUsageWarningThresholdKey: {
+		Description: `The percentage of space or inodes used at which the usage of a
+storage instance is flagged as a warning in the status of its filesystem
+or volume. Zero disables the warning.`,
+		Type:  environschema.Tint,
+		Group: environschema.EnvironGroup,
+	},
 	TestModeKey: {
 		Description: `Whether the model is intended for testing.
 If true, accessing the charm store does not affect statistical
@@ -2461,6 +2699,27 @@ CIDRs specifying what ingress can be applied to offers in this model.`,
 		Type:  environschema.Tstring,
 		Group: environschema.EnvironGroup,
 	},
+	ExposedIngressAllowKey: {
+		Description: `Exposed application ingress allowlist is a comma-separated list of
+CIDRs allowed to reach the ports opened by exposed applications in this
+model. Ingress is limited to the addresses both in the allowlist and in
+the CIDRs and spaces the application is exposed to. In Kubernetes models,
+the allowlist restricts the sources allowed through the application's
+ingress resource and, for load balancer services, the load balancer.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
+	ExposedEndpointIngressAllowKey: {
+		Description: `Exposed endpoint ingress allowlist is a space-separated list of
+<application>:<endpoint>=<cidr>[,<cidr>...] entries. Each entry replaces
+exposed-ingress-allow for the ports opened for the application endpoint;
+ports opened for all endpoints remain subject to exposed-ingress-allow.
+An entry with no CIDRs allows no ingress to the endpoint. In Kubernetes
+models, an application is exposed through a single ingress resource, so
+only the CIDRs allowed for all of its endpoints with an entry are used.`,
+		Type:  environschema.Tstring,
+		Group: environschema.EnvironGroup,
+	},
 	TypeKey: {
 		Description: "Type of model, e.g. local, ec2",
 		Type:        environschema.Tstring,
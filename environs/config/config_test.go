@@ -20,6 +20,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju/osenv"
@@ -638,6 +639,59 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid otlp log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":    true,
+			"logforward-type":       "otlp",
+			"logforward-url":        "https://collector:4318/v1/logs",
+			"logforward-ca-cert":    testing.CACert,
+			"logforward-headers":    `{"X-Scope-OrgID": "tenant 1"}`,
+			"logforward-batch-size": 50,
+		}),
+	}, {
+		about:       "Invalid log forwarding headers",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-headers": "X-Scope-OrgID=tenant",
+		}),
+		err: `invalid logforward-headers: expected a JSON object of header names to values: .*`,
+	}, {
+		about:       "Credentials in log forwarding headers",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-headers": `{"Authorization": "Bearer token"}`,
+		}),
+		err: `invalid logforward-headers: header "Authorization" carries credentials, use logforward-headers-secret instead`,
+	}, {
+		about:       "Valid log forwarding headers secret",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-headers-secret": "secret:9m4e2mr0ui3e8a215n4g",
+		}),
+	}, {
+		about:       "Invalid log forwarding headers secret",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-headers-secret": "my-secret",
+		}),
+		err: `invalid logforward-headers-secret: .*`,
+	}, {
+		about:       "Invalid log forwarding type",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-type": "fluentd",
+		}),
+		err: `logforward-type: expected one of \[syslog otlp loki\], got "fluentd"`,
+	}, {
+		about:       "Missing loki log forwarding url",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-type":    "loki",
+		}),
+		err: `invalid loki forwarding config: empty URL not valid`,
 	}, {
 		about:       "Valid container-inherit-properties",
 		useDefaults: config.UseDefaults,
@@ -701,6 +755,27 @@ var configTests = []configTest{
 			"saas-ingress-allow": "blah",
 		}),
 		err: `cidr "blah" not valid`,
+	}, {
+		about:       "Invalid exposed-ingress-allow cidr",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"exposed-ingress-allow": "blah",
+		}),
+		err: `cidr "blah" not valid`,
+	}, {
+		about:       "Invalid exposed-endpoint-ingress-allow entry",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"exposed-endpoint-ingress-allow": "mysql=10.0.0.0/8",
+		}),
+		err: `application endpoint "mysql", expected <application>:<endpoint> not valid`,
+	}, {
+		about:       "Invalid storage-usage-warning-threshold",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"storage-usage-warning-threshold": 101,
+		}),
+		err: `storage-usage-warning-threshold: must be between 0 and 100`,
 	},
 }
 
@@ -806,6 +881,31 @@ func (test configTest) check(c *gc.C) {
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
 
+	targetCfg, hasTargetCfg := cfg.LogFwdTarget()
+	if v, ok := test.attrs["logforward-type"].(string); ok {
+		if c.Check(hasTargetCfg, jc.IsTrue) {
+			c.Check(string(targetCfg.Type), gc.Equals, v)
+		}
+	}
+	if v, ok := test.attrs["logforward-url"].(string); ok {
+		if c.Check(hasTargetCfg, jc.IsTrue) {
+			c.Check(targetCfg.HTTP.URL, gc.Equals, v)
+		}
+	}
+	if _, ok := test.attrs["logforward-headers"]; ok {
+		if c.Check(hasTargetCfg, jc.IsTrue) {
+			c.Check(targetCfg.HTTP.Headers, gc.DeepEquals, map[string]string{"X-Scope-OrgID": "tenant 1"})
+		}
+	}
+	if v, ok := test.attrs["logforward-headers-secret"].(string); ok {
+		c.Check(cfg.LogFwdHeadersSecret(), gc.Equals, v)
+	}
+	if v, ok := test.attrs["logforward-batch-size"].(int); ok {
+		if c.Check(hasTargetCfg, jc.IsTrue) {
+			c.Check(targetCfg.HTTP.BatchSize, gc.Equals, v)
+		}
+	}
+
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Check(cfg.SSLHostnameVerification(), gc.Equals, v)
 	}
@@ -1363,6 +1463,32 @@ func (s *ConfigSuite) TestApplicationOfferAllowList(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "empty cidrs not valid")
 }
 
+func (s *ConfigSuite) TestExposedIngressAllowList(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ExposedIngressAllow(), gc.DeepEquals, []string{"0.0.0.0/0", "::/0"})
+	c.Assert(cfg.ExposedEndpointIngressAllow(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.ExposedIngressAllowKey:         "",
+		config.ExposedEndpointIngressAllowKey: "mysql:db=10.0.0.0/8,192.168.0.0/16 wordpress:website=0.0.0.0/0",
+	})
+	c.Assert(cfg.ExposedIngressAllow(), gc.HasLen, 0)
+	c.Assert(cfg.ExposedEndpointIngressAllow(), jc.DeepEquals, firewall.EndpointIngressAllowlist{
+		"mysql:db":          {"10.0.0.0/8", "192.168.0.0/16"},
+		"wordpress:website": {"0.0.0.0/0"},
+	})
+}
+
+func (s *ConfigSuite) TestStorageUsageWarningThreshold(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.StorageUsageWarningThreshold(), gc.Equals, config.DefaultStorageUsageWarningThreshold)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.StorageUsageWarningThresholdKey: 0,
+	})
+	c.Assert(cfg.StorageUsageWarningThreshold(), gc.Equals, 0)
+}
+
 func (s *ConfigSuite) TestLoggingOutput(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{})
 	loggingOutput, ok := config.LoggingOutput()
@@ -41,3 +41,13 @@ type InstanceFirewaller interface {
 	// address rules for that port range.
 	IngressRules(ctx context.ProviderCallContext, machineId string) (firewall.IngressRules, error)
 }
+
+// InstanceEgressFirewaller provides instance-level control of outbound
+// traffic. It is implemented by instances of providers that can restrict
+// the destinations an instance sends traffic to.
+type InstanceEgressFirewaller interface {
+	// SetEgressRules restricts the outbound traffic of the instance,
+	// which should have been started with the given machine id, to
+	// the given rules. With no rules, outbound traffic is unrestricted.
+	SetEgressRules(ctx context.ProviderCallContext, machineId string, rules firewall.EgressRules) error
+}
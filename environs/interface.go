@@ -525,6 +525,16 @@ type FirewallFeatureQuerier interface {
 	SupportsRulesWithIPV6CIDRs(ctx context.ProviderCallContext) (bool, error)
 }
 
+// EgressFirewallProvider is implemented by providers whose instances can
+// restrict their outbound traffic to the egress rules of the applications
+// deployed to them; see instances.InstanceEgressFirewaller. Egress rules
+// are rejected for applications in models of other providers.
+type EgressFirewallProvider interface {
+	// SupportsEgressRules returns true if the provider's instances can
+	// enforce egress rules.
+	SupportsEgressRules() bool
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
@@ -118,7 +118,8 @@ func NewAPIConnection(args NewAPIConnectionParams) (_ api.Connection, err error)
 	}()
 
 	// If the account details are set, ensure that the user we've logged in as
-	// matches the user we expected to log in as.
+	// matches the user we expected to log in as. This holds for session
+	// tokens too: a token issued for another user must not be accepted.
 	if args.AccountDetails != nil && st.AuthTag() != nil && args.AccountDetails.User != st.AuthTag().Id() {
 		return nil, errors.Unauthorizedf("attempted login as %q for user %q", st.AuthTag().Id(), args.AccountDetails.User)
 	}
@@ -147,6 +147,25 @@ func (s *NewAPIClientSuite) TestCorrectAuthTag(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *NewAPIClientSuite) TestIncorrectSessionTokenAuthTag(c *gc.C) {
+	store := newClientStore(c, "noconfig")
+	store.UpdateAccount("noconfig", jujuclient.AccountDetails{
+		Type:         jujuclient.OAuth2DeviceFlowAccountDetailsType,
+		User:         "wally@external",
+		SessionToken: "test-session-token",
+	})
+
+	expectState := mockedAPIState(mockedHostPort | mockedModelTag)
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		expectState.authTag = names.NewUserTag("simon@external")
+		return expectState, nil
+	}
+
+	stubStore := jujuclienttesting.WrapClientStore(store)
+	_, err := newAPIConnectionFromNames(c, "noconfig", "admin/admin", stubStore, apiOpen)
+	c.Assert(err, jc.ErrorIs, errors.Unauthorized)
+}
+
 func (s *NewAPIClientSuite) TestIncorrectAdminAuthTag(c *gc.C) {
 	store := newClientStore(c, "noconfig")
 
@@ -0,0 +1,172 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpsink
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// requestTimeout is the timeout of a single push request.
+	requestTimeout = 30 * time.Second
+
+	// retryAttempts is the number of times a batch is sent before
+	// giving up.
+	retryAttempts = 5
+
+	retryDelay    = time.Second
+	retryMaxDelay = 30 * time.Second
+)
+
+// Doer sends an HTTP request and returns the response.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+type encodeFunc func([]logfwd.Record) ([]byte, string, error)
+
+// Client sends log records to an HTTP endpoint.
+type Client struct {
+	url       string
+	headers   map[string]string
+	batchSize int
+	encode    encodeFunc
+	doer      Doer
+	clock     clock.Clock
+}
+
+// Open returns a client that pushes log records to the endpoint
+// described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	doer := &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	}
+	client, err := OpenForDoer(cfg, doer, clock.WallClock)
+	return client, errors.Trace(err)
+}
+
+// OpenForDoer returns a client that pushes log records using the
+// supplied doer.
+func OpenForDoer(cfg RawConfig, doer Doer, clock clock.Clock) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var encode encodeFunc
+	switch cfg.Protocol {
+	case OTLP:
+		encode = encodeOTLP
+	case Loki:
+		encode = encodeLoki
+	}
+
+	return &Client{
+		url:       cfg.URL,
+		headers:   cfg.Headers,
+		batchSize: cfg.batchSize(),
+		encode:    encode,
+		doer:      doer,
+		clock:     clock,
+	}, nil
+}
+
+// Close closes the client's idle connections.
+func (client *Client) Close() error {
+	if closer, ok := client.doer.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Send pushes the records to the remote endpoint, in batches.
+func (client *Client) Send(records []logfwd.Record) error {
+	for len(records) > 0 {
+		n := client.batchSize
+		if n > len(records) {
+			n = len(records)
+		}
+		if err := client.sendBatch(records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (client *Client) sendBatch(records []logfwd.Record) error {
+	body, contentType, err := client.encode(records)
+	if err != nil {
+		return errors.Annotate(err, "encoding log records")
+	}
+
+	err = retry.Call(retry.CallArgs{
+		Func: func() error {
+			return client.push(body, contentType)
+		},
+		IsFatalError: func(err error) bool {
+			return !errors.Is(err, errRetryable)
+		},
+		Attempts:    retryAttempts,
+		Delay:       retryDelay,
+		MaxDelay:    retryMaxDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       client.clock,
+	})
+	if retry.IsAttemptsExceeded(err) {
+		err = retry.LastError(err)
+	}
+	return errors.Annotatef(err, "sending %d log records to %s", len(records), client.url)
+}
+
+// errRetryable marks a failed push that may succeed if retried.
+const errRetryable = errors.ConstError("retryable")
+
+func (client *Client) push(body []byte, contentType string) error {
+	req, err := http.NewRequest(http.MethodPost, client.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range client.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.doer.Do(req)
+	if err != nil {
+		// Connection failures are considered transient.
+		return errors.WithType(err, errRetryable)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("unexpected response %q: %s", resp.Status, bytes.TrimSpace(msg))
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return errors.WithType(err, errRetryable)
+	}
+	return err
+}
@@ -0,0 +1,307 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpsink_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpsink"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	doer  *stubDoer
+	clock *testclock.AutoAdvancingClock
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.doer = &stubDoer{}
+	clock := testclock.NewClock(time.Now())
+	s.clock = &testclock.AutoAdvancingClock{Clock: clock, Advance: clock.Advance}
+}
+
+func (s *ClientSuite) open(c *gc.C, protocol httpsink.Protocol, batchSize int) *httpsink.Client {
+	client, err := httpsink.OpenForDoer(httpsink.RawConfig{
+		Enabled:   true,
+		Protocol:  protocol,
+		URL:       "http://a.b.c:3100/push",
+		Headers:   map[string]string{"X-Scope-OrgID": "juju"},
+		BatchSize: batchSize,
+	}, s.doer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := httpsink.OpenForDoer(httpsink.RawConfig{
+		Enabled:  true,
+		Protocol: httpsink.OTLP,
+	}, s.doer, s.clock)
+	c.Assert(err, gc.ErrorMatches, `empty URL not valid`)
+}
+
+func (s *ClientSuite) TestOpenSendsToServer(c *gc.C) {
+	received := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		received <- req.URL.Path + " " + string(body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client, err := httpsink.Open(httpsink.RawConfig{
+		Enabled:  true,
+		Protocol: httpsink.Loki,
+		URL:      srv.URL + "/loki/api/v1/push",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	err = client.Send([]logfwd.Record{newRecord(10, "spam")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case got := <-received:
+		c.Check(got, jc.HasPrefix, `/loki/api/v1/push {"streams":[`)
+		c.Check(got, jc.Contains, `x/y/spam.go:42 spam`)
+	default:
+		c.Fatalf("no request received")
+	}
+}
+
+func (s *ClientSuite) TestSendOTLP(c *gc.C) {
+	client := s.open(c, httpsink.OTLP, 0)
+
+	err := client.Send([]logfwd.Record{newRecord(10, "spam"), newRecord(11, "eggs")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.doer.requests, gc.HasLen, 1)
+	req := s.doer.requests[0]
+	c.Check(req.method, gc.Equals, http.MethodPost)
+	c.Check(req.url, gc.Equals, "http://a.b.c:3100/push")
+	c.Check(req.header.Get("Content-Type"), gc.Equals, "application/json")
+	c.Check(req.header.Get("X-Scope-OrgID"), gc.Equals, "juju")
+
+	var body struct {
+		ResourceLogs []struct {
+			Resource struct {
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value struct {
+						StringValue string `json:"stringValue"`
+					} `json:"value"`
+				} `json:"attributes"`
+			} `json:"resource"`
+			ScopeLogs []struct {
+				LogRecords []struct {
+					TimeUnixNano   string `json:"timeUnixNano"`
+					SeverityNumber int    `json:"severityNumber"`
+					SeverityText   string `json:"severityText"`
+					Body           struct {
+						StringValue string `json:"stringValue"`
+					} `json:"body"`
+				} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+	err = json.Unmarshal(req.body, &body)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Both records have the same origin, so share a resource.
+	c.Assert(body.ResourceLogs, gc.HasLen, 1)
+	attrs := make(map[string]string)
+	for _, attr := range body.ResourceLogs[0].Resource.Attributes {
+		attrs[attr.Key] = attr.Value.StringValue
+	}
+	c.Check(attrs["service.name"], gc.Equals, "jujud-machine-agent")
+	c.Check(attrs["service.version"], gc.Equals, "1.2.3")
+	c.Check(attrs["juju.controller.uuid"], gc.Equals, "9f484882-2f18-4fd2-967d-db9663db7bea")
+	c.Check(attrs["juju.model.uuid"], gc.Equals, "deadbeef-2f18-4fd2-967d-db9663db7bea")
+	c.Check(attrs["juju.entity.name"], gc.Equals, "99")
+
+	c.Assert(body.ResourceLogs[0].ScopeLogs, gc.HasLen, 1)
+	records := body.ResourceLogs[0].ScopeLogs[0].LogRecords
+	c.Assert(records, gc.HasLen, 2)
+	c.Check(records[0].TimeUnixNano, gc.Equals, "12345000000000")
+	c.Check(records[0].SeverityNumber, gc.Equals, 17)
+	c.Check(records[0].SeverityText, gc.Equals, "ERROR")
+	c.Check(records[0].Body.StringValue, gc.Equals, "spam")
+	c.Check(records[1].Body.StringValue, gc.Equals, "eggs")
+}
+
+func (s *ClientSuite) TestSendLoki(c *gc.C) {
+	client := s.open(c, httpsink.Loki, 0)
+
+	err := client.Send([]logfwd.Record{newRecord(10, "spam")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.doer.requests, gc.HasLen, 1)
+	var body struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][]string        `json:"values"`
+		} `json:"streams"`
+	}
+	err = json.Unmarshal(s.doer.requests[0].body, &body)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(body.Streams, gc.HasLen, 1)
+	c.Check(body.Streams[0].Stream, jc.DeepEquals, map[string]string{
+		"juju_controller_uuid": "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"juju_model_uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"juju_entity_type":     "machine",
+		"juju_entity":          "99",
+		"level":                "error",
+		"module":               "juju.x.y",
+	})
+	c.Check(body.Streams[0].Values, jc.DeepEquals, [][]string{
+		{"12345000000000", "x/y/spam.go:42 spam"},
+	})
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client := s.open(c, httpsink.Loki, 2)
+
+	var records []logfwd.Record
+	for i := 0; i < 5; i++ {
+		records = append(records, newRecord(int64(i), fmt.Sprintf("message %d", i)))
+	}
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.doer.requests, gc.HasLen, 3)
+	for i, expected := range []int{2, 2, 1} {
+		c.Check(strings.Count(string(s.doer.requests[i].body), "message "), gc.Equals, expected)
+	}
+}
+
+func (s *ClientSuite) TestSendRetriesTransientFailures(c *gc.C) {
+	s.doer.statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+	s.doer.errs = []error{nil, nil}
+	client := s.open(c, httpsink.OTLP, 0)
+
+	err := client.Send([]logfwd.Record{newRecord(10, "spam")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.doer.requests, gc.HasLen, 3)
+}
+
+func (s *ClientSuite) TestSendRetriesConnectionFailures(c *gc.C) {
+	s.doer.errs = []error{errors.New("connection refused")}
+	client := s.open(c, httpsink.OTLP, 0)
+
+	err := client.Send([]logfwd.Record{newRecord(10, "spam")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.doer.requests, gc.HasLen, 2)
+}
+
+func (s *ClientSuite) TestSendGivesUp(c *gc.C) {
+	s.doer.statuses = []int{500, 500, 500, 500, 500}
+	s.doer.errs = make([]error, 5)
+	client := s.open(c, httpsink.OTLP, 0)
+
+	err := client.Send([]logfwd.Record{newRecord(10, "spam")})
+	c.Assert(err, gc.ErrorMatches, `sending 1 log records to http://a.b.c:3100/push: unexpected response "500 Internal Server Error": .*`)
+
+	c.Check(s.doer.requests, gc.HasLen, 5)
+}
+
+func (s *ClientSuite) TestSendDoesNotRetryClientErrors(c *gc.C) {
+	s.doer.statuses = []int{http.StatusBadRequest}
+	s.doer.errs = []error{nil}
+	client := s.open(c, httpsink.Loki, 0)
+
+	err := client.Send([]logfwd.Record{newRecord(10, "spam")})
+	c.Assert(err, gc.ErrorMatches, `sending 1 log records to .*: unexpected response "400 Bad Request": .*`)
+
+	c.Check(s.doer.requests, gc.HasLen, 1)
+}
+
+func newRecord(id int64, msg string) logfwd.Record {
+	tag := names.NewMachineTag("99")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	return logfwd.Record{
+		ID:        id,
+		Origin:    logfwd.OriginForMachineAgent(tag, cID, mID, ver),
+		Timestamp: time.Unix(12345, 0),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: msg,
+	}
+}
+
+type request struct {
+	method string
+	url    string
+	header http.Header
+	body   []byte
+}
+
+// stubDoer records the requests it is sent. Each request consumes the
+// next queued error or status; once the queues are empty requests
+// succeed.
+type stubDoer struct {
+	requests []request
+	statuses []int
+	errs     []error
+}
+
+func (d *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	d.requests = append(d.requests, request{
+		method: req.Method,
+		url:    req.URL.String(),
+		header: req.Header,
+		body:   body,
+	})
+
+	status := http.StatusNoContent
+	if len(d.errs) > 0 {
+		err := d.errs[0]
+		d.errs = d.errs[1:]
+		if len(d.statuses) > 0 {
+			status = d.statuses[0]
+			d.statuses = d.statuses[1:]
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader("oops")),
+	}, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpsink
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v3/cert"
+)
+
+// Protocol identifies the wire protocol used to push log records.
+type Protocol string
+
+const (
+	// OTLP is the OpenTelemetry logs protocol, encoded as JSON over HTTP.
+	OTLP Protocol = "otlp"
+
+	// Loki is the Loki push API, encoded as JSON over HTTP.
+	Loki Protocol = "loki"
+)
+
+// DefaultBatchSize is the maximum number of records sent in a single
+// request, if the batch size isn't configured.
+const DefaultBatchSize = 100
+
+// RawConfig holds the raw configuration data for a connection to an
+// HTTP log forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Protocol is the protocol spoken by the endpoint.
+	Protocol Protocol
+
+	// URL is the full URL that log records are pushed to, for example
+	// https://otel-collector:4318/v1/logs or
+	// http://loki:3100/loki/api/v1/push.
+	URL string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If empty,
+	// the system roots are used.
+	CACert string
+
+	// Headers are additional headers sent with every request, such
+	// as an Authorization or X-Scope-OrgID header.
+	Headers map[string]string
+
+	// BatchSize is the maximum number of records sent in a single
+	// request. If zero, DefaultBatchSize is used.
+	BatchSize int
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	switch cfg.Protocol {
+	case OTLP, Loki:
+	default:
+		return errors.NotValidf("protocol %q", cfg.Protocol)
+	}
+
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative batch size %d", cfg.BatchSize)
+	}
+
+	if cfg.CACert != "" {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("URL %q without host", cfg.URL)
+	}
+	return nil
+}
+
+func (cfg RawConfig) batchSize() int {
+	if cfg.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+	return cfg.BatchSize
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
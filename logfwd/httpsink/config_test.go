@@ -0,0 +1,119 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpsink_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpsink"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := httpsink.RawConfig{
+		Enabled:   true,
+		Protocol:  httpsink.OTLP,
+		URL:       "https://otel-collector:4318/v1/logs",
+		CACert:    coretesting.CACert,
+		Headers:   map[string]string{"Authorization": "Bearer token"},
+		BatchSize: 50,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateNotEnabled(c *gc.C) {
+	cfg := httpsink.RawConfig{
+		Protocol: httpsink.Loki,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateBadProtocol(c *gc.C) {
+	cfg := httpsink.RawConfig{
+		Enabled:  true,
+		Protocol: "fluentd",
+		URL:      "http://a.b.c:24224",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `protocol "fluentd" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingURL(c *gc.C) {
+	cfg := httpsink.RawConfig{
+		Enabled:  true,
+		Protocol: httpsink.Loki,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `empty URL not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadURLScheme(c *gc.C) {
+	cfg := httpsink.RawConfig{
+		Enabled:  true,
+		Protocol: httpsink.Loki,
+		URL:      "tcp://loki:3100",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL scheme "tcp" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateURLWithoutHost(c *gc.C) {
+	cfg := httpsink.RawConfig{
+		Enabled:  true,
+		Protocol: httpsink.Loki,
+		URL:      "http:///loki/api/v1/push",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "http:///loki/api/v1/push" without host not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateNegativeBatchSize(c *gc.C) {
+	cfg := httpsink.RawConfig{
+		Enabled:   true,
+		Protocol:  httpsink.OTLP,
+		URL:       "http://otel-collector:4318/v1/logs",
+		BatchSize: -1,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `negative batch size -1 not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := httpsink.RawConfig{
+		Enabled:  true,
+		Protocol: httpsink.OTLP,
+		URL:      "https://otel-collector:4318/v1/logs",
+		CACert:   "spam",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: .*`)
+}
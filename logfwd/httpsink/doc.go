@@ -0,0 +1,8 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package httpsink holds the tools needed to perform log forwarding
+// from Juju to a remote HTTP endpoint, speaking either the OTLP logs
+// protocol or the Loki push API. Records are sent in batches, and
+// failed requests are retried.
+package httpsink
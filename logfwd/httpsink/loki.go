@@ -0,0 +1,97 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpsink
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// The following types model the JSON encoding of the Loki push API.
+// See https://grafana.com/docs/loki/latest/reference/api/#push-log-entries-to-loki.
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// encodeLoki encodes the records as a Loki push request. Records are
+// grouped into streams by their labels.
+func encodeLoki(records []logfwd.Record) ([]byte, string, error) {
+	var (
+		request lokiPushRequest
+		index   = make(map[string]int)
+	)
+	for _, rec := range records {
+		labels := lokiLabels(rec)
+		key := lokiStreamKey(labels)
+
+		i, ok := index[key]
+		if !ok {
+			i = len(request.Streams)
+			index[key] = i
+			request.Streams = append(request.Streams, lokiStream{
+				Stream: labels,
+			})
+		}
+
+		stream := &request.Streams[i]
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+			lokiLine(rec),
+		})
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return data, "application/json", nil
+}
+
+// lokiLabels returns the stream labels for the record. Only values with
+// a bounded cardinality are used as labels.
+func lokiLabels(rec logfwd.Record) map[string]string {
+	labels := map[string]string{
+		"juju_controller_uuid": rec.Origin.ControllerUUID,
+		"juju_model_uuid":      rec.Origin.ModelUUID,
+		"juju_entity_type":     rec.Origin.Type.String(),
+		"juju_entity":          rec.Origin.Name,
+		"level":                strings.ToLower(rec.Level.String()),
+	}
+	if rec.Location.Module != "" {
+		labels["module"] = rec.Location.Module
+	}
+	return labels
+}
+
+// lokiStreamKey returns a key that uniquely identifies the labels.
+func lokiStreamKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k, v := range labels {
+		keys = append(keys, k+"="+strconv.Quote(v))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// lokiLine returns the log line for the record, prefixed with the
+// source location when known.
+func lokiLine(rec logfwd.Record) string {
+	if rec.Location.Filename == "" {
+		return rec.Message
+	}
+	return fmt.Sprintf("%s %s", rec.Location.String(), rec.Message)
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpsink
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// The following types model the JSON encoding of the OTLP logs
+// protocol. Only the fields used by Juju are defined.
+// See https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding.
+
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           otlpAnyValue   `json:"body"`
+	Attributes     []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+func otlpString(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func otlpInt(key string, value int64) otlpKeyValue {
+	v := strconv.FormatInt(value, 10)
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{IntValue: &v}}
+}
+
+// otlpSeverity maps a loggo level onto the OTLP severity number.
+// See https://opentelemetry.io/docs/specs/otel/logs/data-model/#field-severitynumber.
+func otlpSeverity(level loggo.Level) int {
+	switch level {
+	case loggo.TRACE:
+		return 1
+	case loggo.DEBUG:
+		return 5
+	case loggo.INFO:
+		return 9
+	case loggo.WARNING:
+		return 13
+	case loggo.ERROR:
+		return 17
+	case loggo.CRITICAL:
+		return 21
+	}
+	return 0
+}
+
+// encodeOTLP encodes the records as an OTLP logs export request.
+// Records are grouped into resources by their origin.
+func encodeOTLP(records []logfwd.Record) ([]byte, string, error) {
+	var (
+		request otlpLogsRequest
+		index   = make(map[logfwd.Origin]int)
+	)
+	for _, rec := range records {
+		i, ok := index[rec.Origin]
+		if !ok {
+			i = len(request.ResourceLogs)
+			index[rec.Origin] = i
+			request.ResourceLogs = append(request.ResourceLogs, otlpResourceLogs{
+				Resource: otlpResource{
+					Attributes: otlpResourceAttributes(rec.Origin),
+				},
+				ScopeLogs: []otlpScopeLogs{{
+					Scope: otlpScope{
+						Name:    rec.Origin.Software.Name,
+						Version: rec.Origin.Software.Version.String(),
+					},
+				}},
+			})
+		}
+
+		scope := &request.ResourceLogs[i].ScopeLogs[0]
+		scope.LogRecords = append(scope.LogRecords, otlpLogRecordFrom(rec))
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return data, "application/json", nil
+}
+
+func otlpResourceAttributes(origin logfwd.Origin) []otlpKeyValue {
+	attrs := []otlpKeyValue{
+		otlpString("service.name", origin.Software.Name),
+		otlpString("service.version", origin.Software.Version.String()),
+		otlpString("juju.controller.uuid", origin.ControllerUUID),
+		otlpString("juju.model.uuid", origin.ModelUUID),
+		otlpString("juju.entity.type", origin.Type.String()),
+		otlpString("juju.entity.name", origin.Name),
+	}
+	if origin.Hostname != "" {
+		attrs = append(attrs, otlpString("host.name", origin.Hostname))
+	}
+	return attrs
+}
+
+func otlpLogRecordFrom(rec logfwd.Record) otlpLogRecord {
+	body := rec.Message
+	attrs := []otlpKeyValue{
+		otlpInt("juju.record.id", rec.ID),
+	}
+	if rec.Location.Module != "" {
+		attrs = append(attrs, otlpString("code.namespace", rec.Location.Module))
+	}
+	if rec.Location.Filename != "" {
+		attrs = append(attrs, otlpString("code.filepath", rec.Location.Filename))
+		if rec.Location.Line > 0 {
+			attrs = append(attrs, otlpInt("code.lineno", int64(rec.Location.Line)))
+		}
+	}
+	return otlpLogRecord{
+		TimeUnixNano:   strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+		SeverityNumber: otlpSeverity(rec.Level),
+		SeverityText:   rec.Level.String(),
+		Body:           otlpAnyValue{StringValue: &body},
+		Attributes:     attrs,
+	}
+}
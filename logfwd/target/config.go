@@ -0,0 +1,82 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package target
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/httpsink"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// Type identifies the kind of log forwarding target.
+type Type string
+
+const (
+	// Syslog forwards records to a syslog (RFC 5424) host over TLS.
+	Syslog Type = "syslog"
+
+	// OTLP forwards records to an OTLP logs endpoint over HTTP.
+	OTLP Type = Type(httpsink.OTLP)
+
+	// Loki forwards records to a Loki push API endpoint over HTTP.
+	Loki Type = Type(httpsink.Loki)
+)
+
+// RawConfig holds the raw configuration for the log forwarding target.
+// Only the config for the selected type is used.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Type is the kind of target logs are forwarded to. If empty,
+	// Syslog is used.
+	Type Type
+
+	// Syslog holds the config for a syslog target.
+	Syslog syslog.RawConfig
+
+	// HTTP holds the config for an OTLP or Loki target.
+	HTTP httpsink.RawConfig
+}
+
+// TargetType returns the type of the target, defaulting to Syslog.
+func (cfg RawConfig) TargetType() Type {
+	if cfg.Type == "" {
+		return Syslog
+	}
+	return cfg.Type
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	switch cfg.TargetType() {
+	case Syslog:
+		if err := cfg.SyslogConfig().Validate(); err != nil {
+			return errors.Annotate(err, "invalid syslog forwarding config")
+		}
+	case OTLP, Loki:
+		if err := cfg.HTTPConfig().Validate(); err != nil {
+			return errors.Annotatef(err, "invalid %s forwarding config", cfg.Type)
+		}
+	default:
+		return errors.NotValidf("log forwarding type %q", cfg.Type)
+	}
+	return nil
+}
+
+// SyslogConfig returns the syslog config for the target.
+func (cfg RawConfig) SyslogConfig() syslog.RawConfig {
+	result := cfg.Syslog
+	result.Enabled = cfg.Enabled
+	return result
+}
+
+// HTTPConfig returns the HTTP config for the target.
+func (cfg RawConfig) HTTPConfig() httpsink.RawConfig {
+	result := cfg.HTTP
+	result.Enabled = cfg.Enabled
+	result.Protocol = httpsink.Protocol(cfg.TargetType())
+	return result
+}
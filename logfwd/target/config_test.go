@@ -0,0 +1,95 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package target_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpsink"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestTargetTypeDefaultsToSyslog(c *gc.C) {
+	var cfg target.RawConfig
+	c.Check(cfg.TargetType(), gc.Equals, target.Syslog)
+}
+
+func (s *ConfigSuite) TestValidateSyslog(c *gc.C) {
+	cfg := target.RawConfig{
+		Enabled: true,
+		Syslog: syslog.RawConfig{
+			Host:       "a.b.c:9876",
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cfg.SyslogConfig().Enabled, jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestValidateSyslogMissingHost(c *gc.C) {
+	cfg := target.RawConfig{
+		Enabled: true,
+		Type:    target.Syslog,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `invalid syslog forwarding config: Host "" not valid`)
+}
+
+func (s *ConfigSuite) TestValidateHTTP(c *gc.C) {
+	cfg := target.RawConfig{
+		Enabled: true,
+		Type:    target.Loki,
+		HTTP: httpsink.RawConfig{
+			URL: "http://loki:3100/loki/api/v1/push",
+		},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cfg.HTTPConfig(), jc.DeepEquals, httpsink.RawConfig{
+		Enabled:  true,
+		Protocol: httpsink.Loki,
+		URL:      "http://loki:3100/loki/api/v1/push",
+	})
+}
+
+func (s *ConfigSuite) TestValidateHTTPMissingURL(c *gc.C) {
+	cfg := target.RawConfig{
+		Enabled: true,
+		Type:    target.OTLP,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `invalid otlp forwarding config: empty URL not valid`)
+}
+
+func (s *ConfigSuite) TestValidateUnknownType(c *gc.C) {
+	cfg := target.RawConfig{
+		Enabled: true,
+		Type:    "fluentd",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `log forwarding type "fluentd" not valid`)
+}
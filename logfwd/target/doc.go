@@ -0,0 +1,6 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package target describes the remote target that log records are
+// forwarded to, selecting between the supported sink types.
+package target
@@ -26,48 +26,92 @@ func SourcePrecheck(
 	modelPresence ModelPresence, controllerPresence ModelPresence,
 	environscloudspecGetter environsCloudSpecGetter,
 ) error {
+	failures := &precheckFailures{}
+	sourcePrecheck(backend, modelPresence, controllerPresence, environscloudspecGetter, failures)
+	return failures.first()
+}
+
+// SourcePrecheckAll runs the same checks as SourcePrecheck, but carries
+// on after a check fails, returning every failure found. Each machine,
+// application, unit and relation is checked separately.
+func SourcePrecheckAll(
+	backend PrecheckBackend,
+	modelPresence ModelPresence, controllerPresence ModelPresence,
+	environscloudspecGetter environsCloudSpecGetter,
+) []error {
+	failures := &precheckFailures{all: true}
+	sourcePrecheck(backend, modelPresence, controllerPresence, environscloudspecGetter, failures)
+	return failures.errs
+}
+
+func sourcePrecheck(
+	backend PrecheckBackend,
+	modelPresence ModelPresence, controllerPresence ModelPresence,
+	environscloudspecGetter environsCloudSpecGetter,
+	failures *precheckFailures,
+) {
 	ctx := newPrecheckSource(backend, modelPresence, environscloudspecGetter)
-	if err := ctx.checkModel(); err != nil {
-		return errors.Trace(err)
+	if !failures.add(ctx.checkModel()) {
+		return
 	}
-
-	if err := ctx.checkMachines(); err != nil {
-		return errors.Trace(err)
+	if !ctx.checkMachines(failures) {
+		return
 	}
-
-	appUnits, err := ctx.checkApplications()
-	if err != nil {
-		return errors.Trace(err)
+	appUnits, ok := ctx.checkApplications(failures)
+	if !ok {
+		return
 	}
-
-	if err := ctx.checkRelations(appUnits); err != nil {
-		return errors.Trace(err)
+	if !ctx.checkRelations(appUnits, failures) {
+		return
 	}
 
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
-		return errors.Annotate(err, "checking cleanups")
+		if !failures.add(errors.Annotate(err, "checking cleanups")) {
+			return
+		}
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if !failures.add(errors.New("cleanup needed")) {
+			return
+		}
 	}
 
 	// Check the source controller.
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
-		return errors.Trace(err)
+		failures.add(errors.Trace(err))
+		return
 	}
+	controllerFailures := &precheckFailures{all: failures.all}
 	controllerCtx := newPrecheckTarget(controllerBackend, controllerPresence, environscloudspecGetter)
-	if err := controllerCtx.checkController(); err != nil {
-		return errors.Annotate(err, "controller")
+	controllerCtx.checkController(controllerFailures)
+	for _, err := range controllerFailures.errs {
+		failures.add(errors.Annotate(err, "controller"))
 	}
-	return nil
 }
 
 // TargetPrecheck checks the state of the target controller to make
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) error {
+	failures := &precheckFailures{}
+	targetPrecheck(backend, pool, modelInfo, presence, failures)
+	return failures.first()
+}
+
+// TargetPrecheckAll runs the same checks as TargetPrecheck, but carries
+// on after a check fails, returning every failure found.
+func TargetPrecheckAll(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) []error {
+	failures := &precheckFailures{all: true}
+	targetPrecheck(backend, pool, modelInfo, presence, failures)
+	return failures.errs
+}
+
+func targetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence, failures *precheckFailures) {
 	if err := modelInfo.Validate(); err != nil {
-		return errors.Trace(err)
+		// None of the other checks make sense without
+		// valid model details.
+		failures.add(errors.Trace(err))
+		return
 	}
 
 	// This check is necessary because there is a window between the
@@ -78,65 +122,110 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	//
 	// See also https://lpad.tv/1611391
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
-		return errors.Annotate(err, "checking for active migration")
+		if !failures.add(errors.Annotate(err, "checking for active migration")) {
+			return
+		}
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if !failures.add(errors.New("model is being migrated out of target controller")) {
+			return
+		}
+	}
+
+	if !checkTargetVersion(backend, modelInfo, failures) {
+		return
+	}
+
+	controllerCtx := newPrecheckTarget(backend, presence, nil)
+	if !controllerCtx.checkController(failures) {
+		return
 	}
 
+	// Check for conflicts with existing models
+	modelUUIDs, err := backend.AllModelUUIDs()
+	if err != nil {
+		failures.add(errors.Annotate(err, "retrieving models"))
+		return
+	}
+	for _, modelUUID := range modelUUIDs {
+		if !failures.add(checkModelConflict(pool, modelUUID, modelInfo)) {
+			return
+		}
+	}
+}
+
+func checkTargetVersion(backend PrecheckBackend, modelInfo coremigration.ModelInfo, failures *precheckFailures) bool {
 	controllerVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		return failures.add(errors.Annotate(err, "retrieving model version"))
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		if !failures.add(errors.Errorf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion)) {
+			return false
+		}
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		if !failures.add(errors.Errorf("source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion)) {
+			return false
+		}
 	}
 
 	// The MigrateToAllowed check is the same as validating if a model can be
 	// migrated to a controller running a newer Juju version.
 	allowed, minVer, err := upgradevalidation.MigrateToAllowed(modelInfo.AgentVersion, controllerVersion)
 	if err != nil {
-		return errors.Maskf(err, "unknown target controller version %v", controllerVersion)
+		return failures.add(errors.Maskf(err, "unknown target controller version %v", controllerVersion))
 	}
 	if !allowed {
-		return errors.Errorf("model must be upgraded to at least version %s before being migrated to a controller with version %s", minVer, controllerVersion)
+		return failures.add(errors.Errorf("model must be upgraded to at least version %s before being migrated to a controller with version %s", minVer, controllerVersion))
 	}
+	return true
+}
 
-	controllerCtx := newPrecheckTarget(backend, presence, nil)
-	if err := controllerCtx.checkController(); err != nil {
+func checkModelConflict(pool Pool, modelUUID string, modelInfo coremigration.ModelInfo) error {
+	model, release, err := pool.GetModel(modelUUID)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	defer release()
 
-	// Check for conflicts with existing models
-	modelUUIDs, err := backend.AllModelUUIDs()
-	if err != nil {
-		return errors.Annotate(err, "retrieving models")
+	// If the model is importing then it's probably left behind
+	// from a previous migration attempt. It will be removed
+	// before the next import.
+	if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
+		return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
 	}
-	for _, modelUUID := range modelUUIDs {
-		model, release, err := pool.GetModel(modelUUID)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		defer release()
+	if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
+		return errors.Errorf("model named %q already exists", model.Name())
+	}
+	return nil
+}
 
-		// If the model is importing then it's probably left behind
-		// from a previous migration attempt. It will be removed
-		// before the next import.
-		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
-		}
-		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
-		}
+// precheckFailures collects the failures of migration prechecks.
+// Unless all failures are wanted, checking stops at the first.
+type precheckFailures struct {
+	all  bool
+	errs []error
+}
+
+// add records the failure, if err is not nil, and reports whether
+// checking should continue.
+func (f *precheckFailures) add(err error) bool {
+	if err == nil {
+		return true
 	}
+	f.errs = append(f.errs, err)
+	return f.all
+}
 
-	return nil
+func (f *precheckFailures) first() error {
+	if len(f.errs) == 0 {
+		return nil
+	}
+	return f.errs[0]
 }
 
 type precheckTarget struct {
@@ -159,128 +248,155 @@ type precheckContext struct {
 	environscloudspecGetter environsCloudSpecGetter
 }
 
-func (ctx *precheckContext) checkController() error {
+func (ctx *precheckContext) checkController(failures *precheckFailures) bool {
 	model, err := ctx.backend.Model()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model")
+		return failures.add(errors.Annotate(err, "retrieving model"))
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if !failures.add(errors.Errorf("model is %s", model.Life())) {
+			return false
+		}
 	}
 
 	if upgrading, err := ctx.backend.IsUpgrading(); err != nil {
-		return errors.Annotate(err, "checking for upgrades")
+		if !failures.add(errors.Annotate(err, "checking for upgrades")) {
+			return false
+		}
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if !failures.add(errors.New("upgrade in progress")) {
+			return false
+		}
 	}
 
-	return errors.Trace(ctx.checkMachines())
+	return ctx.checkMachines(failures)
 }
 
-func (ctx *precheckContext) checkMachines() error {
+func (ctx *precheckContext) checkMachines(failures *precheckFailures) bool {
 	modelVersion, err := ctx.backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		return failures.add(errors.Annotate(err, "retrieving model version"))
 	}
 
 	machines, err := ctx.backend.AllMachines()
 	if err != nil {
-		return errors.Annotate(err, "retrieving machines")
+		return failures.add(errors.Annotate(err, "retrieving machines"))
 	}
-	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
 	for _, machine := range machines {
-		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+		if !failures.add(ctx.checkMachine(machine, modelVersion)) {
+			return false
 		}
+	}
+	return true
+}
 
-		if statusInfo, err := machine.InstanceStatus(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
-		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
-		}
+func (ctx *precheckContext) checkMachine(machine PrecheckMachine, modelVersion version.Number) error {
+	if machine.Life() != state.Alive {
+		return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+	}
 
-		if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
-		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
-		}
+	if statusInfo, err := machine.InstanceStatus(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
+	} else if statusInfo.Status != status.Running {
+		return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+	}
 
-		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
-		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
-		}
+	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
+	if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
+	} else if statusInfo.Status != status.Started {
+		return newStatusError("machine %s agent not functioning at this time",
+			machine.Id(), statusInfo.Status)
+	}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
-			return errors.Trace(err)
-		}
+	if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
+	} else if rebootAction != state.ShouldDoNothing {
+		return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
 	}
-	return nil
+
+	return errors.Trace(checkAgentTools(modelVersion, machine, "machine "+machine.Id()))
 }
 
-func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, error) {
+// checkApplications checks the applications in the model and their
+// units, returning the units of each application that could be
+// retrieved, and whether checking should continue.
+func (ctx *precheckContext) checkApplications(failures *precheckFailures) (map[string][]PrecheckUnit, bool) {
 	modelVersion, err := ctx.backend.AgentVersion()
 	if err != nil {
-		return nil, errors.Annotate(err, "retrieving model version")
+		return nil, failures.add(errors.Annotate(err, "retrieving model version"))
 	}
 	apps, err := ctx.backend.AllApplications()
 	if err != nil {
-		return nil, errors.Annotate(err, "retrieving applications")
+		return nil, failures.add(errors.Annotate(err, "retrieving applications"))
 	}
 
 	model, err := ctx.backend.Model()
 	if err != nil {
-		return nil, errors.Annotate(err, "retrieving model")
+		return nil, failures.add(errors.Annotate(err, "retrieving model"))
 	}
 	appUnits := make(map[string][]PrecheckUnit, len(apps))
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if !failures.add(errors.Errorf("application %s is %s", app.Name(), app.Life())) {
+				return nil, false
+			}
+			continue
 		}
 		units, err := app.AllUnits()
 		if err != nil {
-			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
-		}
-		err = ctx.checkUnits(app, units, modelVersion, model.Type())
-		if err != nil {
-			return nil, errors.Trace(err)
+			if !failures.add(errors.Annotatef(err, "retrieving units for %s", app.Name())) {
+				return nil, false
+			}
+			continue
 		}
 		appUnits[app.Name()] = units
+		if !ctx.checkUnits(app, units, modelVersion, model.Type(), failures) {
+			return nil, false
+		}
 	}
-	return appUnits, nil
+	return appUnits, true
 }
 
-func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number, modelType state.ModelType) error {
+func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number, modelType state.ModelType, failures *precheckFailures) bool {
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		if !failures.add(errors.Errorf("application %s is below its minimum units threshold", app.Name())) {
+			return false
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
 	if appCharmURL == nil {
-		return errors.Errorf("application charm url is nil")
+		return failures.add(errors.Errorf("application charm url is nil"))
 	}
 
 	for _, unit := range units {
-		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+		if !failures.add(ctx.checkUnit(unit, *appCharmURL, modelVersion, modelType)) {
+			return false
 		}
+	}
+	return true
+}
 
-		if err := ctx.checkUnitAgentStatus(unit); err != nil {
-			return errors.Trace(err)
-		}
+func (ctx *precheckContext) checkUnit(unit PrecheckUnit, appCharmURL string, modelVersion version.Number, modelType state.ModelType) error {
+	if unit.Life() != state.Alive {
+		return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+	}
 
-		if modelType == state.ModelTypeIAAS {
-			if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
-				return errors.Trace(err)
-			}
-		}
+	if err := ctx.checkUnitAgentStatus(unit); err != nil {
+		return errors.Trace(err)
+	}
 
-		unitCharmURL := unit.CharmURL()
-		if unitCharmURL == nil || *appCharmURL != *unitCharmURL {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+	if modelType == state.ModelTypeIAAS {
+		if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+			return errors.Trace(err)
 		}
 	}
+
+	unitCharmURL := unit.CharmURL()
+	if unitCharmURL == nil || appCharmURL != *unitCharmURL {
+		return errors.Errorf("unit %s is upgrading", unit.Name())
+	}
 	return nil
 }
 
@@ -300,57 +416,64 @@ func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	return nil
 }
 
-func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) error {
+func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit, failures *precheckFailures) bool {
 	relations, err := ctx.backend.AllRelations()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model relations")
+		return failures.add(errors.Annotate(err, "retrieving model relations"))
 	}
 	for _, rel := range relations {
-		remoteAppName, crossModel, err := rel.RemoteApplication()
-		if err != nil && !errors.IsNotFound(err) {
-			return errors.Annotatef(err, "checking whether relation %s is cross-model", rel)
+		if !failures.add(checkRelation(rel, appUnits)) {
+			return false
 		}
+	}
+	return true
+}
 
-		checkRelationUnit := func(ru PrecheckRelationUnit) error {
-			valid, err := ru.Valid()
-			if err != nil {
-				return errors.Trace(err)
-			}
-			if !valid {
-				return nil
-			}
-			inScope, err := ru.InScope()
+func checkRelation(rel PrecheckRelation, appUnits map[string][]PrecheckUnit) error {
+	remoteAppName, crossModel, err := rel.RemoteApplication()
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "checking whether relation %s is cross-model", rel)
+	}
+
+	checkRelationUnit := func(ru PrecheckRelationUnit) error {
+		valid, err := ru.Valid()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !valid {
+			return nil
+		}
+		inScope, err := ru.InScope()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !inScope {
+			return errors.Errorf("unit %s hasn't joined relation %q yet", ru.UnitName(), rel)
+		}
+		return nil
+	}
+
+	for _, ep := range rel.Endpoints() {
+		// The endpoint app is either local or cross model.
+		// Handle each one as appropriate.
+		if crossModel && ep.ApplicationName == remoteAppName {
+			remoteUnits, err := rel.AllRemoteUnits(remoteAppName)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if !inScope {
-				return errors.Errorf("unit %s hasn't joined relation %q yet", ru.UnitName(), rel)
+			for _, ru := range remoteUnits {
+				if err := checkRelationUnit(ru); err != nil {
+					return errors.Trace(err)
+				}
 			}
-			return nil
-		}
-
-		for _, ep := range rel.Endpoints() {
-			// The endpoint app is either local or cross model.
-			// Handle each one as appropriate.
-			if crossModel && ep.ApplicationName == remoteAppName {
-				remoteUnits, err := rel.AllRemoteUnits(remoteAppName)
+		} else {
+			for _, unit := range appUnits[ep.ApplicationName] {
+				ru, err := rel.Unit(unit)
 				if err != nil {
 					return errors.Trace(err)
 				}
-				for _, ru := range remoteUnits {
-					if err := checkRelationUnit(ru); err != nil {
-						return errors.Trace(err)
-					}
-				}
-			} else {
-				for _, unit := range appUnits[ep.ApplicationName] {
-					ru, err := rel.Unit(unit)
-					if err != nil {
-						return errors.Trace(err)
-					}
-					if err := checkRelationUnit(ru); err != nil {
-						return errors.Trace(err)
-					}
+				if err := checkRelationUnit(ru); err != nil {
+					return errors.Trace(err)
 				}
 			}
 		}
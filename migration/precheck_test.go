@@ -473,6 +473,74 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `unit remote-mysql/0 hasn't joined relation "foo:db remote-mysql:db" yet`)
 }
 
+func (s *SourcePrecheckSuite) TestAllReportsEveryFailure(c *gc.C) {
+	backend := &fakeBackend{
+		machines: []migration.PrecheckMachine{
+			&fakeMachine{id: "0", life: state.Dying},
+			&fakeMachine{id: "1", status: status.Down},
+		},
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				life: state.Dying,
+			},
+			&fakeApp{
+				name: "bar",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "bar/0", life: state.Dead},
+				},
+			},
+		},
+		cleanupNeeded: true,
+		controllerBackend: &fakeBackend{
+			isUpgrading: true,
+		},
+	}
+	errs := migration.SourcePrecheckAll(
+		backend, allAlivePresence(), allAlivePresence(),
+		func(names.ModelTag) (environscloudspec.CloudSpec, error) {
+			return environscloudspec.CloudSpec{Type: "foo"}, nil
+		},
+	)
+	c.Assert(errorMessages(errs), jc.DeepEquals, []string{
+		"machine 0 is dying",
+		"machine 1 agent not functioning at this time (down)",
+		"application foo is dying",
+		"unit bar/0 is dead",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+}
+
+func (s *SourcePrecheckSuite) TestAllSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	errs := migration.SourcePrecheckAll(
+		backend, allAlivePresence(), allAlivePresence(),
+		func(names.ModelTag) (environscloudspec.CloudSpec, error) {
+			return environscloudspec.CloudSpec{Type: "foo"}, nil
+		},
+	)
+	c.Assert(errs, gc.HasLen, 0)
+}
+
+func (s *SourcePrecheckSuite) TestFirstFailureOnly(c *gc.C) {
+	backend := &fakeBackend{
+		machines: []migration.PrecheckMachine{
+			&fakeMachine{id: "0", life: state.Dying},
+			&fakeMachine{id: "1", status: status.Down},
+		},
+		cleanupNeeded: true,
+	}
+	err := migration.SourcePrecheck(
+		backend, allAlivePresence(), allAlivePresence(),
+		func(names.ModelTag) (environscloudspec.CloudSpec, error) {
+			return environscloudspec.CloudSpec{Type: "foo"}, nil
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "machine 0 is dying")
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -722,6 +790,42 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestAllReportsEveryFailure(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{
+				uuid:      modelUUID,
+				name:      "other",
+				modelType: state.ModelTypeIAAS,
+				owner:     modelOwner,
+			},
+		},
+	}
+	backend := newBackendWithDyingMachine()
+	backend.migrationActive = true
+	backend.models = pool.uuids()
+	errs := migration.TargetPrecheckAll(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(errorMessages(errs), jc.DeepEquals, []string{
+		"model is being migrated out of target controller",
+		"machine 0 is dying",
+		"model with same UUID already exists (model-uuid)",
+	})
+}
+
+func (s *TargetPrecheckSuite) TestAllInvalidModelInfo(c *gc.C) {
+	s.modelInfo.UUID = ""
+	errs := migration.TargetPrecheckAll(newBackendWithDyingMachine(), nil, s.modelInfo, allAlivePresence())
+	c.Assert(errorMessages(errs), jc.DeepEquals, []string{"empty UUID not valid"})
+}
+
+func errorMessages(errs []error) []string {
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -0,0 +1,253 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/client/usermanager"
+	controllerclient "github.com/juju/juju/api/controller/controller"
+	"github.com/juju/juju/api/controller/migrationtarget"
+	"github.com/juju/juju/apiserver/common/cloudspec"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+)
+
+// PrecheckMigration runs the prechecks for migrating the model to the
+// target controller, updating targetInfo as needed from information
+// retrieved from the target. It is used both for the migrations
+// initiated by clients and those started by migration plans.
+func PrecheckMigration(
+	st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo,
+	modelPresence, controllerPresence ModelPresence,
+	leaders map[string]string,
+) error {
+	// Check model and source controller.
+	backend, err := PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return errors.Annotate(err, "creating backend")
+	}
+	if err := SourcePrecheck(
+		backend,
+		modelPresence, controllerPresence,
+		cloudspec.MakeCloudSpecGetterForModel(st),
+	); err != nil {
+		return errors.Annotate(err, "source prechecks failed")
+	}
+
+	// Check target controller.
+	modelInfo, srcUserList, err := MakeModelInfo(st, ctlrSt, leaders)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	targetConn, err := api.Open(TargetToAPIInfo(targetInfo), ControllerDialOpts())
+	if err != nil {
+		return errors.Annotate(err, "connect to target controller")
+	}
+	defer targetConn.Close()
+	dstUserList, err := TargetControllerUsers(targetConn)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = srcUserList.CheckCompatibilityWith(dstUserList); err != nil {
+		return errors.Trace(err)
+	}
+	client := migrationtarget.NewClient(targetConn)
+	if targetInfo.CACert == "" {
+		targetInfo.CACert, err = client.CACert()
+		if err != nil {
+			if !params.IsCodeNotImplemented(err) {
+				return errors.Annotatef(err, "cannot retrieve CA certificate")
+			}
+			// If the call's not implemented, it indicates an earlier version
+			// of the controller, which we can't migrate to.
+			return errors.New("controller API version is too old")
+		}
+	}
+	err = client.Prechecks(modelInfo)
+	return errors.Annotate(err, "target prechecks failed")
+}
+
+// UserList encapsulates information about the users who have been granted
+// access to a model or the users known to a particular controller.
+type UserList struct {
+	identityURL string
+	users       set.Strings
+}
+
+// CheckCompatibilityWith ensures that the set of users granted access to
+// the model being migrated is present in the destination (migration target)
+// controller.
+func (src *UserList) CheckCompatibilityWith(dst UserList) error {
+	srcUsers, dstUsers := src.users, dst.users
+
+	// If external users have access to this model we can only allow the
+	// migration to proceed if:
+	// - the local users from src exist in the dst, and
+	// - both controllers are configured with the same identity provider URL
+	srcExtUsers := filterSet(srcUsers, func(u string) bool {
+		return strings.Contains(u, "@")
+	})
+
+	if srcExtUsers.Size() != 0 {
+		localSrcUsers := srcUsers.Difference(srcExtUsers)
+
+		// In this case external user lookups will most likely not work.
+		// Display an appropriate error message depending on whether
+		// the local users are present in dst or not.
+		if src.identityURL != dst.identityURL {
+			missing := localSrcUsers.Difference(dstUsers)
+			if missing.Size() == 0 {
+				return errors.Errorf(`cannot initiate migration as external users have been granted access to the model
+and the two controllers have different identity provider configurations. To resolve
+this issue you can remove the following users from the current model:
+  - %s`,
+					strings.Join(srcExtUsers.Values(), "\n  - "),
+				)
+			}
+
+			return errors.Errorf(`cannot initiate migration as external users have been granted access to the model
+and the two controllers have different identity provider configurations. To resolve
+this issue you need to remove the following users from the current model:
+  - %s
+
+and add the following users to the destination controller or remove them from
+the current model:
+  - %s`,
+				strings.Join(srcExtUsers.Values(), "\n  - "),
+				strings.Join(localSrcUsers.Difference(dstUsers).Values(), "\n  - "),
+			)
+
+		}
+
+		// External user lookups will work out of the box. We only need
+		// to ensure that the local model users are present in dst
+		srcUsers = localSrcUsers
+	}
+
+	if missing := srcUsers.Difference(dstUsers); missing.Size() != 0 {
+		return errors.Errorf(`cannot initiate migration as the users granted access to the model do not exist
+on the destination controller. To resolve this issue you can add the following
+users to the destination controller or remove them from the current model:
+  - %s`, strings.Join(missing.Values(), "\n  - "))
+	}
+
+	return nil
+}
+
+// MakeModelInfo exports the model for migration, returning it along
+// with the users granted access to it.
+func MakeModelInfo(st, ctlrSt *state.State, leaders map[string]string) (coremigration.ModelInfo, UserList, error) {
+	var empty coremigration.ModelInfo
+	var ul UserList
+
+	model, err := st.Model()
+	if err != nil {
+		return empty, ul, errors.Trace(err)
+	}
+
+	description, err := st.Export(leaders)
+	if err != nil {
+		return empty, ul, errors.Trace(err)
+	}
+
+	users, err := model.Users()
+	if err != nil {
+		return empty, ul, errors.Trace(err)
+	}
+	ul.users = set.NewStrings()
+	for _, u := range users {
+		ul.users.Add(u.UserName)
+	}
+
+	// Retrieve agent version for the model.
+	conf, err := model.ModelConfig()
+	if err != nil {
+		return empty, UserList{}, errors.Trace(err)
+	}
+	agentVersion, _ := conf.AgentVersion()
+
+	// Retrieve agent version for the controller.
+	controllerModel, err := ctlrSt.Model()
+	if err != nil {
+		return empty, UserList{}, errors.Trace(err)
+	}
+	controllerConfig, err := controllerModel.Config()
+	if err != nil {
+		return empty, UserList{}, errors.Trace(err)
+	}
+	controllerVersion, _ := controllerConfig.AgentVersion()
+
+	coreConf, err := ctlrSt.ControllerConfig()
+	if err != nil {
+		return empty, UserList{}, errors.Trace(err)
+	}
+	ul.identityURL = coreConf.IdentityURL()
+	return coremigration.ModelInfo{
+		UUID:                   model.UUID(),
+		Name:                   model.Name(),
+		Owner:                  model.Owner(),
+		AgentVersion:           agentVersion,
+		ControllerAgentVersion: controllerVersion,
+		ModelDescription:       description,
+	}, ul, nil
+}
+
+// TargetControllerUsers returns the users known to the target
+// controller of a migration.
+func TargetControllerUsers(conn api.Connection) (UserList, error) {
+	ul := UserList{}
+
+	userClient := usermanager.NewClient(conn)
+	users, err := userClient.UserInfo(nil, usermanager.AllUsers)
+	if err != nil {
+		return ul, errors.Trace(err)
+	}
+
+	ul.users = set.NewStrings()
+	for _, u := range users {
+		ul.users.Add(u.Username)
+	}
+
+	ctrlClient := controllerclient.NewClient(conn)
+	ul.identityURL, err = ctrlClient.IdentityProviderURL()
+	if err != nil {
+		return ul, errors.Trace(err)
+	}
+
+	return ul, nil
+}
+
+// TargetToAPIInfo returns the information needed to connect to the
+// target controller of a migration.
+func TargetToAPIInfo(ti *coremigration.TargetInfo) *api.Info {
+	info := &api.Info{
+		Addrs:     ti.Addrs,
+		CACert:    ti.CACert,
+		Password:  ti.Password,
+		Macaroons: ti.Macaroons,
+	}
+	// Only local users must be added to the api info.
+	// For external users, the tag needs to be left empty.
+	if ti.AuthTag.IsLocal() {
+		info.Tag = ti.AuthTag
+	}
+	return info
+}
+
+func filterSet(s set.Strings, keep func(string) bool) set.Strings {
+	out := set.NewStrings()
+	for _, v := range s.Values() {
+		if keep(v) {
+			out.Add(v)
+		}
+	}
+
+	return out
+}
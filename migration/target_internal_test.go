@@ -1,7 +1,7 @@
 // Copyright 2019 Canonical Ltd.
 // Licensed under the AGPLv3, see LICENCE file for details.
 
-package controller
+package migration
 
 import (
 	"github.com/juju/collections/set"
@@ -11,48 +11,48 @@ import (
 	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/api"
-	"github.com/juju/juju/core/migration"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/testing"
 )
 
-var _ = gc.Suite(&controllerSuite{})
+var _ = gc.Suite(&targetSuite{})
 
-type controllerSuite struct{}
+type targetSuite struct{}
 
-func (s *controllerSuite) TestUserListCompatibility(c *gc.C) {
+func (s *targetSuite) TestUserListCompatibility(c *gc.C) {
 	extProvider1 := "https://api.jujucharms.com/identity"
 	extProvider2 := "http://candid.provider/identity"
 	specs := []struct {
 		descr    string
-		src, dst userList
+		src, dst UserList
 		expErr   string
 	}{
 		{
 			descr: `all src users present in dst`,
-			src: userList{
+			src: UserList{
 				users: set.NewStrings("foo", "bar"),
 			},
-			dst: userList{
+			dst: UserList{
 				users: set.NewStrings("foo", "bar"),
 			},
 		},
 		{
 			descr: `local src users present in dst, and an external user has been granted access, and src/dst use the same identity provider url`,
-			src: userList{
+			src: UserList{
 				users:       set.NewStrings("foo", "bar@external"),
 				identityURL: extProvider1,
 			},
-			dst: userList{
+			dst: UserList{
 				users:       set.NewStrings("foo"),
 				identityURL: extProvider1,
 			},
 		},
 		{
 			descr: `some local src users not present in dst`,
-			src: userList{
+			src: UserList{
 				users: set.NewStrings("foo", "bar"),
 			},
-			dst: userList{
+			dst: UserList{
 				users: set.NewStrings("bar"),
 			},
 			expErr: `cannot initiate migration as the users granted access to the model do not exist
@@ -62,11 +62,11 @@ users to the destination controller or remove them from the current model:
 		},
 		{
 			descr: `local src users present in dst, and an external user has been granted access, and src/dst use different identity provider URL`,
-			src: userList{
+			src: UserList{
 				users:       set.NewStrings("foo", "bar@external"),
 				identityURL: extProvider1,
 			},
-			dst: userList{
+			dst: UserList{
 				users:       set.NewStrings("foo", "bar@external"),
 				identityURL: extProvider2,
 			},
@@ -77,11 +77,11 @@ this issue you can remove the following users from the current model:
 		},
 		{
 			descr: `not all local src users present in dst, and an external user has been granted access, and src/dst use different identity provider URL`,
-			src: userList{
+			src: UserList{
 				users:       set.NewStrings("foo", "bar@external"),
 				identityURL: extProvider1,
 			},
-			dst: userList{
+			dst: UserList{
 				users:       set.NewStrings("baz", "bar@external"),
 				identityURL: extProvider2,
 			},
@@ -99,7 +99,7 @@ the current model:
 	for specIndex, spec := range specs {
 		c.Logf("test %d: %s", specIndex, spec.descr)
 
-		err := spec.src.checkCompatibilityWith(spec.dst)
+		err := spec.src.CheckCompatibilityWith(spec.dst)
 		if spec.expErr == "" {
 			c.Assert(err, jc.ErrorIsNil)
 		} else {
@@ -109,15 +109,15 @@ the current model:
 	}
 }
 
-func (s *controllerSuite) TestTargetToAPIInfoLocalUser(c *gc.C) {
-	targetInfo := migration.TargetInfo{
+func (s *targetSuite) TestTargetToAPIInfoLocalUser(c *gc.C) {
+	targetInfo := coremigration.TargetInfo{
 		Addrs:     []string{"6.6.6.6"},
 		CACert:    testing.CACert,
 		AuthTag:   names.NewUserTag("fred"),
 		Password:  "sekret",
 		Macaroons: []macaroon.Slice{{}},
 	}
-	apiInfo := targetToAPIInfo(&targetInfo)
+	apiInfo := TargetToAPIInfo(&targetInfo)
 	c.Assert(apiInfo, jc.DeepEquals, &api.Info{
 		Addrs:     targetInfo.Addrs,
 		CACert:    targetInfo.CACert,
@@ -127,15 +127,15 @@ func (s *controllerSuite) TestTargetToAPIInfoLocalUser(c *gc.C) {
 	})
 }
 
-func (s *controllerSuite) TestTargetToAPIInfoExternalUser(c *gc.C) {
-	targetInfo := migration.TargetInfo{
+func (s *targetSuite) TestTargetToAPIInfoExternalUser(c *gc.C) {
+	targetInfo := coremigration.TargetInfo{
 		Addrs:     []string{"6.6.6.6"},
 		CACert:    testing.CACert,
 		AuthTag:   names.NewUserTag("fred@external"),
 		Password:  "sekret",
 		Macaroons: []macaroon.Slice{{}},
 	}
-	apiInfo := targetToAPIInfo(&targetInfo)
+	apiInfo := TargetToAPIInfo(&targetInfo)
 	c.Assert(apiInfo, jc.DeepEquals, &api.Info{
 		Addrs:     targetInfo.Addrs,
 		CACert:    targetInfo.CACert,
@@ -697,6 +697,12 @@ func (*environProvider) Version() int {
 	return 0
 }
 
+// SupportsEgressRules is part of the environs.EgressFirewallProvider
+// interface.
+func (*environProvider) SupportsEgressRules() bool {
+	return true
+}
+
 func (p *environProvider) Open(_ stdcontext.Context, args environs.OpenParams) (environs.Environ, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -1860,6 +1866,7 @@ func (*environ) Provider() environs.EnvironProvider {
 type dummyInstance struct {
 	state        *environState
 	rules        firewall.IngressRules
+	egressRules  firewall.EgressRules
 	id           instance.Id
 	status       string
 	machineId    string
@@ -2052,6 +2059,36 @@ func (inst *dummyInstance) IngressRules(ctx context.ProviderCallContext, machine
 	return
 }
 
+func (inst *dummyInstance) SetEgressRules(ctx context.ProviderCallContext, machineId string, rules firewall.EgressRules) error {
+	defer delay()
+	logger.Infof("setEgressRules %s, %v", machineId, rules)
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for setting egress rules on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("SetEgressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("SetEgressRules"); err != nil {
+		return err
+	}
+	inst.egressRules = append(firewall.EgressRules(nil), rules...)
+	return nil
+}
+
+// EgressRules returns the egress rules last set on the instance.
+func (inst *dummyInstance) EgressRules(ctx context.ProviderCallContext, machineId string) (firewall.EgressRules, error) {
+	defer delay()
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("EgressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	return append(firewall.EgressRules(nil), inst.egressRules...), nil
+}
+
 // providerDelay controls the delay before dummy responds.
 // non empty values in JUJU_DUMMY_DELAY will be parsed as
 // time.Durations into this value.
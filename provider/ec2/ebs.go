@@ -335,6 +335,7 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeResizer = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolumeInput, _ error) {
@@ -558,6 +559,30 @@ func (v *ebsVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volIds
 	return foreachVolume(v.env.ec2Client, ctx, volIds, releaseVolume), nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+// EBS volumes are sized in GiB, so the requested size is rounded up.
+// The volume may be used at its new size as soon as the modification
+// has started; the remaining optimisation happens in the background.
+func (v *ebsVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeResult, error) {
+	results := make([]storage.ResizeResult, len(params))
+	for i, p := range params {
+		size := mibToGib(p.Size)
+		resp, err := v.env.ec2Client.ModifyVolume(ctx, &ec2.ModifyVolumeInput{
+			VolumeId: aws.String(p.VolumeId),
+			Size:     aws.Int32(int32(size)),
+		})
+		if err != nil {
+			results[i].Error = maybeConvertCredentialError(err, ctx)
+			continue
+		}
+		if resp.VolumeModification != nil && resp.VolumeModification.TargetSize != nil {
+			size = uint64(aws.ToInt32(resp.VolumeModification.TargetSize))
+		}
+		results[i].Size = gibToMib(size)
+	}
+	return results, nil
+}
+
 func foreachVolume(client Client, ctx context.ProviderCallContext, volIds []string, f func(Client, context.ProviderCallContext, string) error) []error {
 	var wg sync.WaitGroup
 	wg.Add(len(volIds))
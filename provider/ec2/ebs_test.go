@@ -494,6 +494,34 @@ func (s *ebsSuite) TestDestroyVolumes(c *gc.C) {
 	c.Assert(aws.ToInt32(ec2Vols.Volumes[3].Size), gc.Equals, int32(50))
 }
 
+func (s *ebsSuite) TestResizeVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.setupAttachVolumesTest(c, vs, ec2test.Running)
+	resizer, ok := vs.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	results, err := resizer.ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     15000,
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "vol-42",
+		Size:     15000,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeResult{Size: 15360})
+	c.Assert(results[1].Error, gc.ErrorMatches, ".*vol-42 not found.*")
+
+	ec2Client := ec2.StorageEC2(vs)
+	ec2Vols, err := ec2Client.DescribeVolumes(s.cloudCallCtx, &awsec2.DescribeVolumesInput{
+		VolumeIds: []string{"vol-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(aws.ToInt32(ec2Vols.Volumes[0].Size), gc.Equals, int32(15))
+}
+
 func (s *ebsSuite) TestDestroyVolumesStillAttached(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
@@ -77,6 +77,39 @@ func (srv *Server) DeleteVolume(ctx context.Context, in *ec2.DeleteVolumeInput,
 	return &ec2.DeleteVolumeOutput{}, nil
 }
 
+// ModifyVolume implements ec2.Client.
+func (srv *Server) ModifyVolume(ctx context.Context, in *ec2.ModifyVolumeInput, opts ...func(*ec2.Options)) (*ec2.ModifyVolumeOutput, error) {
+	srv.volumeMutatingCalls.next()
+
+	if err, ok := srv.apiCallErrors["ModifyVolume"]; ok {
+		return nil, err
+	}
+
+	v, err := srv.volume(aws.ToString(in.VolumeId))
+	if err != nil {
+		return nil, err
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	modification := &types.VolumeModification{
+		VolumeId:           v.VolumeId,
+		OriginalSize:       v.Size,
+		TargetSize:         v.Size,
+		ModificationState:  types.VolumeModificationStateModifying,
+		OriginalVolumeType: v.VolumeType,
+		TargetVolumeType:   v.VolumeType,
+	}
+	if in.Size != nil {
+		if aws.ToInt32(in.Size) < aws.ToInt32(v.Size) {
+			return nil, apiError("InvalidParameterValue", "New size cannot be smaller than existing size")
+		}
+		v.Size = in.Size
+		modification.TargetSize = in.Size
+	}
+	return &ec2.ModifyVolumeOutput{VolumeModification: modification}, nil
+}
+
 // DescribeVolumes implements ec2.Client.
 func (srv *Server) DescribeVolumes(ctx context.Context, in *ec2.DescribeVolumesInput, opts ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
 	if err, ok := srv.apiCallErrors["DescribeVolumes"]; ok {
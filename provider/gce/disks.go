@@ -75,6 +75,8 @@ func (g *storageProvider) FilesystemSource(providerConfig *storage.Config) (stor
 	return nil, errors.NotSupportedf("filesystems")
 }
 
+var _ storage.VolumeResizer = (*volumeSource)(nil)
+
 type volumeSource struct {
 	gce       gceConnection
 	envName   string // non-unique, informational only
@@ -425,6 +427,26 @@ func (v *volumeSource) describeOneVolume(ctx context.ProviderCallContext, volNam
 	return desc, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+// Persistent disks are sized in GiB, so the requested size is rounded up.
+func (v *volumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeResult, error) {
+	results := make([]storage.ResizeResult, len(params))
+	for i, p := range params {
+		zone, _, err := parseVolumeId(p.VolumeId)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+			continue
+		}
+		size := mibToGib(p.Size)
+		if err := v.gce.ResizeDisk(zone, p.VolumeId, size); err != nil {
+			results[i].Error = google.HandleCredentialError(errors.Annotatef(err, "cannot resize volume %q", p.VolumeId), ctx)
+			continue
+		}
+		results[i].Size = size * 1024
+	}
+	return results, nil
+}
+
 // TODO(perrito666) These rules are yet to be defined.
 func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	return nil
@@ -195,6 +195,27 @@ func (s *volumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	c.Assert(call[0].ID, gc.Equals, "a--volume-name")
 }
 
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	results, err := s.source.(storage.VolumeResizer).ResizeVolumes(s.CallCtx, []storage.VolumeResizeParams{{
+		VolumeId: "a--volume-name",
+		Size:     15000,
+	}, {
+		VolumeId: "volume-name",
+		Size:     15000,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeResult{Size: 15360})
+	c.Assert(results[1].Error, gc.ErrorMatches, `invalid volume id "volume-name": malformed volume id "volume-name"`)
+
+	called, calls := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].ZoneName, gc.Equals, "a")
+	c.Assert(calls[0].ID, gc.Equals, "a--volume-name")
+	c.Assert(calls[0].SizeGb, gc.Equals, uint64(15))
+}
+
 func (s *volumeSourceSuite) TestReleaseVolumesInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
@@ -66,6 +66,9 @@ type gceConnection interface {
 	// SetDiskLabels sets the labels on a disk, ensuring that the disk's
 	// label fingerprint matches the one supplied.
 	SetDiskLabels(zone, id, labelFingerprint string, labels map[string]string) error
+	// ResizeDisk grows the disk identified by <id> in <zone> to the
+	// given size, in GiB.
+	ResizeDisk(zone, id string, sizeGb uint64) error
 	// AttachDisk will attach the volume identified by <volumeName> into the instance
 	// <instanceId> and return an AttachedDisk representing it or error.
 	AttachDisk(zone, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error)
@@ -98,6 +98,10 @@ type service interface {
 	// label fingerprint matches the one supplied.
 	SetDiskLabels(project, zone, id, labelFingerprint string, labels map[string]string) error
 
+	// ResizeDisk grows the disk identified by id to the given size,
+	// in GiB.
+	ResizeDisk(project, zone, id string, sizeGb int64) error
+
 	// AttachDisk will attach the disk described in attachedDisks (if it exists) into
 	// the instance with id instanceId.
 	AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error
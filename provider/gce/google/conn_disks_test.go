@@ -94,6 +94,18 @@ func (s *connSuite) TestConnectionSetDiskLabels(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].Labels, jc.DeepEquals, labels)
 }
 
+func (s *connSuite) TestConnectionResizeDisk(c *gc.C) {
+	err := s.Conn.ResizeDisk("home-zone", fakeVolName, 15)
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ResizeDisk")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].SizeGb, gc.Equals, int64(15))
+}
+
 func (s *connSuite) TestConnectionAttachDisk(c *gc.C) {
 	_, fakeDisk, err := fakeDiskAndSpec()
 	c.Check(err, jc.ErrorIsNil)
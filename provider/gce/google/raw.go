@@ -293,6 +293,18 @@ func (rc *rawConn) SetDiskLabels(project, zone, id, labelFingerprint string, lab
 	return errors.Trace(err)
 }
 
+func (rc *rawConn) ResizeDisk(project, zone, id string, sizeGb int64) error {
+	ds := rc.Service.Disks
+	call := ds.Resize(project, zone, id, &compute.DisksResizeRequest{
+		SizeGb: sizeGb,
+	})
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not resize disk %q", id)
+	}
+	return errors.Trace(rc.waitOperation(project, op, longRetryStrategy, logOperationErrors))
+}
+
 func (rc *rawConn) AttachDisk(project, zone, instanceId string, disk *compute.AttachedDisk) error {
 	call := rc.Instances.AttachDisk(project, zone, instanceId, disk)
 	_, err := call.Do() // Perhaps return something from the Op
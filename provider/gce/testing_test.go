@@ -499,6 +499,7 @@ type fakeConnCall struct {
 	Value            string
 	LabelFingerprint string
 	Labels           map[string]string
+	SizeGb           uint64
 }
 
 type fakeConn struct {
@@ -673,6 +674,16 @@ func (fc *fakeConn) SetDiskLabels(zone, id, labelFingerprint string, labels map[
 	return fc.err()
 }
 
+func (fc *fakeConn) ResizeDisk(zone, id string, sizeGb uint64) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "ResizeDisk",
+		ZoneName: zone,
+		ID:       id,
+		SizeGb:   sizeGb,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) AttachDisk(zone, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName:   "AttachDisk",
@@ -174,6 +174,34 @@ func (mr *MockServerMockRecorder) CreateVolume(arg0, arg1, arg2 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVolume", reflect.TypeOf((*MockServer)(nil).CreateVolume), arg0, arg1, arg2)
 }
 
+// CreateVolumeFromSnapshot mocks base method.
+func (m *MockServer) CreateVolumeFromSnapshot(arg0, arg1, arg2, arg3 string, arg4 map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVolumeFromSnapshot", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVolumeFromSnapshot indicates an expected call of CreateVolumeFromSnapshot.
+func (mr *MockServerMockRecorder) CreateVolumeFromSnapshot(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVolumeFromSnapshot", reflect.TypeOf((*MockServer)(nil).CreateVolumeFromSnapshot), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateVolumeSnapshot mocks base method.
+func (m *MockServer) CreateVolumeSnapshot(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVolumeSnapshot", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVolumeSnapshot indicates an expected call of CreateVolumeSnapshot.
+func (mr *MockServerMockRecorder) CreateVolumeSnapshot(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVolumeSnapshot", reflect.TypeOf((*MockServer)(nil).CreateVolumeSnapshot), arg0, arg1, arg2)
+}
+
 // DeleteCertificate mocks base method.
 func (m *MockServer) DeleteCertificate(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -216,6 +244,20 @@ func (mr *MockServerMockRecorder) DeleteStoragePoolVolume(arg0, arg1, arg2 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStoragePoolVolume", reflect.TypeOf((*MockServer)(nil).DeleteStoragePoolVolume), arg0, arg1, arg2)
 }
 
+// DeleteVolumeSnapshot mocks base method.
+func (m *MockServer) DeleteVolumeSnapshot(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVolumeSnapshot", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVolumeSnapshot indicates an expected call of DeleteVolumeSnapshot.
+func (mr *MockServerMockRecorder) DeleteVolumeSnapshot(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVolumeSnapshot", reflect.TypeOf((*MockServer)(nil).DeleteVolumeSnapshot), arg0, arg1, arg2)
+}
+
 // EnableHTTPSListener mocks base method.
 func (m *MockServer) EnableHTTPSListener() error {
 	m.ctrl.T.Helper()
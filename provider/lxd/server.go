@@ -71,6 +71,9 @@ type Server interface {
 	GetStoragePoolVolume(pool string, volType string, name string) (*lxdapi.StorageVolume, string, error)
 	GetStoragePoolVolumes(pool string) (volumes []lxdapi.StorageVolume, err error)
 	CreateVolume(pool, name string, config map[string]string) error
+	CreateVolumeSnapshot(pool, volume, snapshot string) error
+	DeleteVolumeSnapshot(pool, volume, snapshot string) error
+	CreateVolumeFromSnapshot(pool, name, volume, snapshot string, config map[string]string) error
 	UpdateStoragePoolVolume(pool string, volType string, name string, volume lxdapi.StorageVolumePut, ETag string) error
 	DeleteStoragePoolVolume(pool string, volType string, name string) (err error)
 	ServerCertificate() string
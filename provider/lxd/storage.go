@@ -235,6 +235,9 @@ type lxdFilesystemSource struct {
 	env *environ
 }
 
+var _ storage.FilesystemSnapshotter = (*lxdFilesystemSource)(nil)
+var _ storage.FilesystemResizer = (*lxdFilesystemSource)(nil)
+
 // CreateFilesystems is specified on the storage.FilesystemSource interface.
 func (s *lxdFilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemParams) (_ []storage.CreateFilesystemsResult, err error) {
 	results := make([]storage.CreateFilesystemsResult, len(args))
@@ -285,7 +288,23 @@ func (s *lxdFilesystemSource) createFilesystem(
 		config["size"] = fmt.Sprintf("%dMiB", arg.Size)
 	}
 
-	if err := s.env.server().CreateVolume(cfg.lxdPool, volumeName, config); err != nil {
+	if arg.SnapshotId != "" {
+		lxdPool, sourceVolumeName, snapshotName, err := parseSnapshotId(arg.SnapshotId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if lxdPool != cfg.lxdPool {
+			return nil, errors.Errorf(
+				"snapshot %q is in LXD storage pool %q, not %q",
+				arg.SnapshotId, lxdPool, cfg.lxdPool,
+			)
+		}
+		if err := s.env.server().CreateVolumeFromSnapshot(
+			cfg.lxdPool, volumeName, sourceVolumeName, snapshotName, config,
+		); err != nil {
+			return nil, errors.Annotate(err, "creating volume from snapshot")
+		}
+	} else if err := s.env.server().CreateVolume(cfg.lxdPool, volumeName, config); err != nil {
 		return nil, errors.Annotate(err, "creating volume")
 	}
 
@@ -317,6 +336,142 @@ func parseFilesystemId(id string) (lxdPool, volumeName string, _ error) {
 	return fields[0], fields[1], nil
 }
 
+// CreateFilesystemSnapshots is specified on the storage.FilesystemSnapshotter
+// interface.
+func (s *lxdFilesystemSource) CreateFilesystemSnapshots(
+	ctx context.ProviderCallContext, args []storage.FilesystemSnapshotParams,
+) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := s.createFilesystemSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of filesystem %v", arg.Filesystem.Id())
+			common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (s *lxdFilesystemSource) createFilesystemSnapshot(arg storage.FilesystemSnapshotParams) (*storage.Snapshot, error) {
+	lxdPool, volumeName, err := parseFilesystemId(arg.FilesystemId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volume, _, err := s.env.server().GetStoragePoolVolume(lxdPool, storagePoolVolumeType, volumeName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var size uint64
+	if sizeString := volume.Config["size"]; sizeString != "" {
+		n, err := units.ParseByteSizeString(sizeString)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing size")
+		}
+		// ParseByteSizeString returns bytes, we want MiB.
+		size = uint64(n / (1024 * 1024))
+	}
+	snapshotName := "snapshot-" + strings.Replace(arg.Id, "/", "-", -1)
+	if err := s.env.server().CreateVolumeSnapshot(lxdPool, volumeName, snapshotName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.Snapshot{
+		Id: arg.Id,
+		SnapshotInfo: storage.SnapshotInfo{
+			SnapshotId: makeSnapshotId(lxdPool, volumeName, snapshotName),
+			Size:       size,
+		},
+	}, nil
+}
+
+// DeleteFilesystemSnapshots is specified on the storage.FilesystemSnapshotter
+// interface. LXD deletes the snapshots of a volume along with the volume,
+// so snapshots that no longer exist are treated as deleted.
+func (s *lxdFilesystemSource) DeleteFilesystemSnapshots(
+	ctx context.ProviderCallContext, snapshotIds []string,
+) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		lxdPool, volumeName, snapshotName, err := parseSnapshotId(snapshotId)
+		if err == nil {
+			err = s.env.server().DeleteVolumeSnapshot(lxdPool, volumeName, snapshotName)
+		}
+		if err != nil {
+			results[i] = errors.Annotatef(err, "deleting snapshot %q", snapshotId)
+			common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		}
+	}
+	return results, nil
+}
+
+// ResizeFilesystems is specified on the storage.FilesystemResizer
+// interface.
+func (s *lxdFilesystemSource) ResizeFilesystems(
+	ctx context.ProviderCallContext, args []storage.FilesystemResizeParams,
+) ([]storage.ResizeResult, error) {
+	results := make([]storage.ResizeResult, len(args))
+	for i, arg := range args {
+		if err := s.resizeFilesystem(arg); err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing filesystem %v", arg.Filesystem.Id())
+			common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+			continue
+		}
+		results[i].Size = arg.Size
+	}
+	return results, nil
+}
+
+func (s *lxdFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) error {
+	cfg, err := newLXDStorageConfig(arg.Attributes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.driver == "dir" {
+		// The "dir" driver does not support sizing volumes.
+		return errors.NotSupportedf("resizing volumes in LXD storage pools with the %q driver", cfg.driver)
+	}
+	lxdPool, volumeName, err := parseFilesystemId(arg.FilesystemId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volume, eTag, err := s.env.server().GetStoragePoolVolume(lxdPool, storagePoolVolumeType, volumeName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	update := volume.Writable()
+	if update.Config == nil {
+		update.Config = make(map[string]string)
+	}
+	update.Config["size"] = fmt.Sprintf("%dMiB", arg.Size)
+	return errors.Trace(s.env.server().UpdateStoragePoolVolume(
+		lxdPool, storagePoolVolumeType, volumeName, update, eTag,
+	))
+}
+
+func makeSnapshotId(lxdPool, volumeName, snapshotName string) string {
+	// LXD names snapshots of a volume <volume-name>/<snapshot-name>.
+	return fmt.Sprintf("%s:%s/%s", lxdPool, volumeName, snapshotName)
+}
+
+// parseSnapshotId parses the given snapshot ID, returning the underlying
+// LXD storage pool name, volume name and snapshot name.
+func parseSnapshotId(id string) (lxdPool, volumeName, snapshotName string, _ error) {
+	lxdPool, name, err := parseFilesystemId(id)
+	if err != nil {
+		return "", "", "", errors.Errorf(
+			"invalid snapshot ID %q; expected ID in format <lxd-pool>:<volume-name>/<snapshot-name>", id,
+		)
+	}
+	fields := strings.SplitN(name, "/", 2)
+	if len(fields) < 2 {
+		return "", "", "", errors.Errorf(
+			"invalid snapshot ID %q; expected ID in format <lxd-pool>:<volume-name>/<snapshot-name>", id,
+		)
+	}
+	return lxdPool, fields[0], fields[1], nil
+}
+
 // TODO (manadart 2018-06-28) Add a test for DestroyController that properly
 // verifies this behaviour.
 func destroyControllerFilesystems(env *environ, controllerUUID string) error {
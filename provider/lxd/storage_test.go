@@ -143,6 +143,152 @@ func (s *storageSuite) TestCreateFilesystems(c *gc.C) {
 	})
 }
 
+func (s *storageSuite) TestCreateFilesystemsFromSnapshot(c *gc.C) {
+	source := s.filesystemSource(c, "source")
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("1"),
+		Provider:   "lxd",
+		Size:       2048,
+		SnapshotId: "radiance:juju-f75cba-filesystem-0/snapshot-3",
+		Attributes: map[string]interface{}{
+			"lxd-pool": "radiance",
+			"driver":   "btrfs",
+		},
+	}, {
+		Tag:        names.NewFilesystemTag("2"),
+		Provider:   "lxd",
+		Size:       1024,
+		SnapshotId: "juju:juju-f75cba-filesystem-0/snapshot-3",
+		Attributes: map[string]interface{}{
+			"lxd-pool": "radiance",
+			"driver":   "btrfs",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Filesystem, jc.DeepEquals, &storage.Filesystem{
+		Tag: names.NewFilesystemTag("1"),
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: "radiance:juju-f75cba-filesystem-1",
+			Size:         2048,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches,
+		`snapshot "juju:juju-f75cba-filesystem-0/snapshot-3" is in LXD storage pool "juju", not "radiance"`)
+
+	s.Stub.CheckCallNames(c, "CreatePool", "CreateVolumeFromSnapshot", "CreatePool")
+	s.Stub.CheckCall(c, 1, "CreateVolumeFromSnapshot",
+		"radiance", "juju-f75cba-filesystem-1", "juju-f75cba-filesystem-0", "snapshot-3",
+		map[string]string{"size": "2048MiB"},
+	)
+}
+
+func (s *storageSuite) TestCreateFilesystemSnapshots(c *gc.C) {
+	source := s.filesystemSource(c, "pool")
+	c.Assert(source, gc.Implements, new(storage.FilesystemSnapshotter))
+	snapshotter := source.(storage.FilesystemSnapshotter)
+
+	s.Client.Volumes = map[string][]api.StorageVolume{
+		"radiance": {{
+			Name: "juju-f75cba-filesystem-0",
+			StorageVolumePut: api.StorageVolumePut{
+				Config: map[string]string{
+					"size": "1GiB",
+				},
+			},
+		}},
+	}
+
+	results, err := snapshotter.CreateFilesystemSnapshots(s.callCtx, []storage.FilesystemSnapshotParams{{
+		Id:           "3",
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "radiance:juju-f75cba-filesystem-0",
+		Provider:     "lxd",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateSnapshotsResult{{
+		Snapshot: &storage.Snapshot{
+			Id: "3",
+			SnapshotInfo: storage.SnapshotInfo{
+				SnapshotId: "radiance:juju-f75cba-filesystem-0/snapshot-3",
+				Size:       1024,
+			},
+		},
+	}})
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"GetStoragePoolVolume", []interface{}{"radiance", "custom", "juju-f75cba-filesystem-0"}},
+		{"CreateVolumeSnapshot", []interface{}{"radiance", "juju-f75cba-filesystem-0", "snapshot-3"}},
+	})
+}
+
+func (s *storageSuite) TestDeleteFilesystemSnapshots(c *gc.C) {
+	source := s.filesystemSource(c, "pool")
+	snapshotter := source.(storage.FilesystemSnapshotter)
+
+	results, err := snapshotter.DeleteFilesystemSnapshots(s.callCtx, []string{
+		"radiance:juju-f75cba-filesystem-0/snapshot-3",
+		"radiance:juju-f75cba-filesystem-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, `deleting snapshot .*: invalid snapshot ID .*`)
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"DeleteVolumeSnapshot", []interface{}{"radiance", "juju-f75cba-filesystem-0", "snapshot-3"}},
+	})
+}
+
+func (s *storageSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.filesystemSource(c, "pool")
+	c.Assert(source, gc.Implements, new(storage.FilesystemResizer))
+	resizer := source.(storage.FilesystemResizer)
+
+	s.Client.Volumes = map[string][]api.StorageVolume{
+		"radiance": {{
+			Name: "juju-f75cba-filesystem-0",
+			StorageVolumePut: api.StorageVolumePut{
+				Config: map[string]string{
+					"size": "1024MiB",
+				},
+			},
+		}},
+	}
+
+	results, err := resizer.ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "radiance:juju-f75cba-filesystem-0",
+		Size:         2048,
+		Provider:     "lxd",
+		Attributes: map[string]interface{}{
+			"driver":   "zfs",
+			"lxd-pool": "radiance",
+		},
+	}, {
+		Filesystem:   names.NewFilesystemTag("1"),
+		FilesystemId: "juju:juju-f75cba-filesystem-1",
+		Size:         2048,
+		Provider:     "lxd",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeResult{Size: 2048})
+	c.Assert(results[1].Error, gc.ErrorMatches,
+		`resizing filesystem 1: resizing volumes in LXD storage pools with the "dir" driver not supported`)
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"GetStoragePoolVolume", []interface{}{"radiance", "custom", "juju-f75cba-filesystem-0"}},
+		{"UpdateStoragePoolVolume", []interface{}{
+			"radiance", "custom", "juju-f75cba-filesystem-0",
+			api.StorageVolumePut{
+				Config: map[string]string{
+					"size": "2048MiB",
+				},
+			},
+			"eTag",
+		}},
+	})
+}
+
 func (s *storageSuite) TestCreateFilesystemsPoolExists(c *gc.C) {
 	s.Stub.SetErrors(errors.New("pool already exists"))
 	source := s.filesystemSource(c, "source")
@@ -568,6 +568,21 @@ func (conn *StubClient) CreateVolume(pool, volume string, config map[string]stri
 	return conn.NextErr()
 }
 
+func (conn *StubClient) CreateVolumeSnapshot(pool, volume, snapshot string) error {
+	conn.AddCall("CreateVolumeSnapshot", pool, volume, snapshot)
+	return conn.NextErr()
+}
+
+func (conn *StubClient) DeleteVolumeSnapshot(pool, volume, snapshot string) error {
+	conn.AddCall("DeleteVolumeSnapshot", pool, volume, snapshot)
+	return conn.NextErr()
+}
+
+func (conn *StubClient) CreateVolumeFromSnapshot(pool, name, volume, snapshot string, config map[string]string) error {
+	conn.AddCall("CreateVolumeFromSnapshot", pool, name, volume, snapshot, config)
+	return conn.NextErr()
+}
+
 func (conn *StubClient) DeleteStoragePoolVolume(pool, volType, volume string) error {
 	conn.AddCall("DeleteStoragePoolVolume", pool, volType, volume)
 	return conn.NextErr()
@@ -4,9 +4,14 @@
 package openstack
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -122,6 +127,7 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 	// TODO (stickupkid): Move this to the ClientFactory.
 	// We shouldn't have another wrapper around an existing client.
 	cinderCl := cinderClient{cinder.Basic(env.volumeURL, client.TenantId(), client.Token)}
+	handleRequest := cinder.SetAuthHeaderFn(client.Token, http.DefaultClient.Do)
 
 	cloudSpec := env.cloudUnlocked
 	if len(cloudSpec.CACertificates) > 0 {
@@ -131,11 +137,13 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 			client.Token,
 			tlsConfig(cloudSpec.CACertificates)),
 		}
+		handleRequest = cinder.AuthHeaderTSLConfigDoRequestFn(client.Token, tlsConfig(cloudSpec.CACertificates))
 	}
 
 	return &openstackStorageAdapter{
 		cinderCl,
 		novaClient{env.novaUnlocked},
+		volumeActionClient{endpoint: env.volumeURL, handleRequest: handleRequest},
 	}, nil
 }
 
@@ -224,6 +232,7 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeResizer = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(
@@ -607,6 +616,52 @@ func (s *cinderVolumeSource) ImportVolume(ctx context.ProviderCallContext, volum
 	return cinderToJujuVolumeInfo(volume), nil
 }
 
+// ResizeVolumes implements storage.VolumeResizer.
+func (s *cinderVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, args []storage.VolumeResizeParams) ([]storage.ResizeResult, error) {
+	results := make([]storage.ResizeResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %q", arg.VolumeId)
+			if denied := common.MaybeHandleCredentialError(IsAuthorisationFailure, err, ctx); denied {
+				// If it is an unauthorised error, no need to continue since we will 100% fail...
+				break
+			}
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (uint64, error) {
+	volume, err := s.storageAdapter.GetVolume(arg.VolumeId)
+	if err != nil {
+		return 0, errors.Annotate(err, "getting volume")
+	}
+	// Sizes in Cinder are in GiB.
+	size := int((arg.Size + 1023) / 1024)
+	if volume.Size >= size {
+		return uint64(volume.Size * 1024), nil
+	}
+	if err := s.storageAdapter.ExtendVolume(arg.VolumeId, size); err != nil {
+		return 0, errors.Trace(err)
+	}
+	volume, err = waitVolume(s.storageAdapter, arg.VolumeId, func(v *cinder.Volume) (bool, error) {
+		switch v.Status {
+		case "extending":
+			return false, nil
+		case "error_extending":
+			return false, errors.New("volume could not be extended")
+		}
+		return v.Size >= size, nil
+	})
+	if err != nil {
+		return 0, errors.Annotate(err, "waiting for volume to be extended")
+	}
+	return uint64(volume.Size * 1024), nil
+}
+
 func waitVolume(
 	storageAdapter OpenstackStorage,
 	volumeId string,
@@ -701,6 +756,7 @@ type OpenstackStorage interface {
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
 	ListVolumeAvailabilityZones() ([]cinder.AvailabilityZone, error)
+	ExtendVolume(volumeId string, size int) error
 }
 
 type endpointResolver interface {
@@ -740,6 +796,7 @@ func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, err
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+	volumeActionClient
 }
 
 type cinderClient struct {
@@ -810,3 +867,53 @@ func (ga *openstackStorageAdapter) DetachVolume(serverId, attachmentId string) e
 	}
 	return nil
 }
+
+// cinderOnlineExtendVersion is the block storage API microversion that
+// allows volumes to be extended while they are attached. Endpoints that
+// predate microversions ignore it.
+const cinderOnlineExtendVersion = "volume 3.42"
+
+// volumeActionClient sends volume actions, which the Cinder client
+// does not support, to the block storage endpoint.
+type volumeActionClient struct {
+	endpoint      *url.URL
+	handleRequest cinder.RequestHandlerFn
+}
+
+// ExtendVolume is part of the OpenstackStorage interface. It grows the
+// volume to the specified size in GiB with the os-extend volume action.
+func (c volumeActionClient) ExtendVolume(volumeId string, size int) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"os-extend": map[string]int{"new_size": size},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	endpoint := *c.endpoint
+	if !strings.HasSuffix(endpoint.Path, "/") {
+		endpoint.Path += "/"
+	}
+	actionURL := endpoint.ResolveReference(&url.URL{Path: fmt.Sprintf("volumes/%s/action", volumeId)})
+	req, err := http.NewRequest("POST", actionURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("OpenStack-API-Version", cinderOnlineExtendVersion)
+	resp, err := c.handleRequest(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		return nil
+	case http.StatusNotFound:
+		return errors.NotFoundf("volume %q", volumeId)
+	case http.StatusUnauthorized:
+		return gooseerrors.NewUnauthorisedf(nil, "", "extending volume %q", volumeId)
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	return errors.Errorf("extending volume %q: invalid status (%d): %s", volumeId, resp.StatusCode, respBody)
+}
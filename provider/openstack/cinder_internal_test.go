@@ -4,8 +4,14 @@
 package openstack
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
 	"github.com/go-goose/goose/v5/client"
 	"github.com/go-goose/goose/v5/identity"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -62,3 +68,50 @@ func (r *testAuthClient) TenantId() string {
 func (r *testAuthClient) EndpointsForRegion(region string) identity.ServiceURLs {
 	return r.regionEndpoints[region]
 }
+
+func (s *cinderInternalSuite) TestExtendVolume(c *gc.C) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/v3/tenant/volumes/vol-1/action")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		c.Check(req.Header.Get("OpenStack-API-Version"), gc.Equals, "volume 3.42")
+		body, err := io.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(string(body), gc.Equals, `{"os-extend":{"new_size":3}}`)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	endpoint, err := url.Parse(srv.URL + "/v3/tenant")
+	c.Assert(err, jc.ErrorIsNil)
+	actions := volumeActionClient{endpoint: endpoint, handleRequest: http.DefaultClient.Do}
+	err = actions.ExtendVolume("vol-1", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.Equals, 1)
+}
+
+func (s *cinderInternalSuite) TestExtendVolumeErrors(c *gc.C) {
+	status := http.StatusNotFound
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("volume is busy"))
+	}))
+	defer srv.Close()
+
+	endpoint, err := url.Parse(srv.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	actions := volumeActionClient{endpoint: endpoint, handleRequest: http.DefaultClient.Do}
+
+	err = actions.ExtendVolume("vol-1", 3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	status = http.StatusUnauthorized
+	err = actions.ExtendVolume("vol-1", 3)
+	c.Assert(err, jc.Satisfies, IsAuthorisationFailure)
+
+	status = http.StatusBadRequest
+	err = actions.ExtendVolume("vol-1", 3)
+	c.Assert(err, gc.ErrorMatches, `extending volume "vol-1": invalid status \(400\): volume is busy`)
+}
@@ -839,6 +839,98 @@ func (s *cinderVolumeSourceSuite) TestImportVolumeInvalidCredential(c *gc.C) {
 	c.Assert(s.invalidCredential, jc.IsTrue)
 }
 
+func (s *cinderVolumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	statuses := []string{"in-use", "extending", "in-use"}
+	sizes := []int{1, 1, 3}
+	mockAdapter := &mockAdapter{
+		getVolume: func(volId string) (*cinder.Volume, error) {
+			c.Assert(statuses, gc.Not(gc.HasLen), 0)
+			volume := &cinder.Volume{ID: volId, Status: statuses[0], Size: sizes[0]}
+			statuses, sizes = statuses[1:], sizes[1:]
+			return volume, nil
+		},
+	}
+	s.PatchValue(openstack.CinderAttempt, utils.AttemptStrategy{Min: 3})
+
+	volSource := openstack.NewCinderVolumeSource(mockAdapter, s.env)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2*1024 + 1,
+		Provider: openstack.CinderProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeResult{{Size: 3 * 1024}})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"ExtendVolume", []interface{}{mockVolId, 3}},
+		{"GetVolume", []interface{}{mockVolId}},
+		{"GetVolume", []interface{}{mockVolId}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesAlreadyLarger(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volId, Status: "in-use", Size: 4}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter, s.env)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2 * 1024,
+		Provider: openstack.CinderProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeResult{{Size: 4 * 1024}})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesFailed(c *gc.C) {
+	statuses := []string{"in-use", "error_extending"}
+	mockAdapter := &mockAdapter{
+		getVolume: func(volId string) (*cinder.Volume, error) {
+			status := statuses[0]
+			statuses = statuses[1:]
+			return &cinder.Volume{ID: volId, Status: status, Size: 1}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter, s.env)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2 * 1024,
+		Provider: openstack.CinderProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume "0": waiting for volume to be extended: volume could not be extended`)
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesInvalidCredential(c *gc.C) {
+	c.Assert(s.invalidCredential, jc.IsFalse)
+	mockAdapter := &mockAdapter{
+		getVolume: func(volId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volId, Status: "in-use", Size: 1}, nil
+		},
+		extendVolume: func(string, int) error {
+			return testUnauthorisedGooseError
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter, s.env)
+	_, err := volSource.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2 * 1024,
+		Provider: openstack.CinderProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.invalidCredential, jc.IsTrue)
+}
+
 type mockAdapter struct {
 	gitjujutesting.Stub
 	getVolume             func(string) (*cinder.Volume, error)
@@ -850,6 +942,7 @@ type mockAdapter struct {
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
 	listAvailabilityZones func() ([]cinder.AvailabilityZone, error)
+	extendVolume          func(string, int) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -927,6 +1020,14 @@ func (ma *mockAdapter) ListVolumeAvailabilityZones() ([]cinder.AvailabilityZone,
 	return nil, gooseerrors.NewNotImplementedf(nil, nil, "ListAvailabilityZones")
 }
 
+func (ma *mockAdapter) ExtendVolume(volumeId string, size int) error {
+	ma.MethodCall(ma, "ExtendVolume", volumeId, size)
+	if ma.extendVolume != nil {
+		return ma.extendVolume(volumeId, size)
+	}
+	return nil
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
@@ -113,6 +113,11 @@ type Firewaller interface {
 
 	// InstanceIngressRules returns the ingress rules applied to the specified  instance.
 	InstanceIngressRules(ctx context.ProviderCallContext, inst instances.Instance, machineID string) (firewall.IngressRules, error)
+
+	// SetInstanceEgressRules restricts the outbound traffic of the specified
+	// instance to the given rules. With no rules, outbound traffic is
+	// unrestricted.
+	SetInstanceEgressRules(ctx context.ProviderCallContext, inst instances.Instance, machineID string, rules firewall.EgressRules) error
 }
 
 type firewallerFactory struct{}
@@ -663,6 +668,160 @@ func (c *neutronFirewaller) InstanceIngressRules(ctx context.ProviderCallContext
 	return rules, err
 }
 
+// allEgress holds the egress rules that allow all outbound traffic, which
+// Neutron adds to every new security group.
+var allEgress = firewall.EgressRules{
+	{DestinationCIDR: firewall.AllNetworksIPV4CIDR},
+	{DestinationCIDR: firewall.AllNetworksIPV6CIDR},
+}
+
+// SetInstanceEgressRules implements Firewaller interface.
+//
+// Security groups allow the union of their rules, so the egress rules are
+// set in the machine's security group, and the allow-all egress rules
+// Neutron adds by default are removed from the model's security group the
+// first time any machine's egress is restricted. Machine security groups
+// keep their default allow-all egress rules until restricted. Restricted
+// machines can always reach DNS and the metadata service; the caller is
+// expected to include the controller API in the rules.
+func (c *neutronFirewaller) SetInstanceEgressRules(ctx context.ProviderCallContext, inst instances.Instance, machineID string, rules firewall.EgressRules) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for setting egress rules on instance",
+			c.environ.Config().FirewallMode())
+	}
+	// For bug 1680787
+	// No security groups exist if the network used to boot the instance has
+	// PortSecurityEnabled set to false.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return nil
+	}
+	if len(rules) == 0 {
+		rules = allEgress
+	} else {
+		var err error
+		if rules, err = rules.WithEssentials(nil); err != nil {
+			return errors.Trace(err)
+		}
+		if c.environ.ecfg().useDefaultSecurityGroup() {
+			return errors.NotSupportedf("restricting egress with use-default-secgroup set")
+		}
+		if err := c.setEgressRulesInGroup(ctx, c.jujuGroupRegexp(), nil); err != nil {
+			handleCredentialError(err, ctx)
+			return errors.Trace(err)
+		}
+	}
+	if err := c.setEgressRulesInGroup(ctx, c.machineGroupRegexp(machineID), rules); err != nil {
+		handleCredentialError(err, ctx)
+		return errors.Trace(err)
+	}
+	logger.Infof("set egress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineID, rules)
+	return nil
+}
+
+// setEgressRulesInGroup replaces the egress rules of the security group
+// matching nameRegExp with the given rules.
+func (c *neutronFirewaller) setEgressRulesInGroup(ctx context.ProviderCallContext, nameRegExp string, rules firewall.EgressRules) error {
+	group, err := c.matchingGroup(ctx, nameRegExp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	wanted := egressRulesToRuleInfo(group.Id, rules)
+	present := make([]bool, len(wanted))
+	for _, existing := range group.Rules {
+		if existing.Direction != "egress" {
+			continue
+		}
+		keep := false
+		for i, info := range wanted {
+			if secGroupMatchesRuleInfo(existing, info) {
+				keep, present[i] = true, true
+			}
+		}
+		if keep {
+			continue
+		}
+		if err := neutronClient.DeleteSecurityGroupRuleV2(existing.Id); err != nil && !gooseerrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	for i, info := range wanted {
+		if present[i] {
+			continue
+		}
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(info); err != nil && !gooseerrors.IsDuplicateValue(err) {
+			return fmt.Errorf("creating egress security group rule for parent group id %q to %q: %w", info.ParentGroupId, info.RemoteIPPrefix, err)
+		}
+	}
+	return nil
+}
+
+// egressRulesToRuleInfo returns the Neutron security group rules for the
+// given egress rules.
+func egressRulesToRuleInfo(groupId string, rules firewall.EgressRules) []neutron.RuleInfoV2 {
+	var result []neutron.RuleInfoV2
+	for _, r := range rules {
+		ruleInfo := neutron.RuleInfoV2{
+			Direction:      "egress",
+			ParentGroupId:  groupId,
+			RemoteIPPrefix: r.DestinationCIDR,
+		}
+		switch addrType, _ := corenetwork.CIDRAddressType(r.DestinationCIDR); addrType {
+		case corenetwork.IPv4Address:
+			ruleInfo.EthernetType = "IPv4"
+		case corenetwork.IPv6Address:
+			ruleInfo.EthernetType = "IPv6"
+		default:
+			// Should never happen; ignore CIDR
+			continue
+		}
+		if !r.AllPorts() {
+			ruleInfo.IPProtocol = r.PortRange.Protocol
+			if ruleInfo.IPProtocol != "icmp" {
+				ruleInfo.PortRangeMin = r.PortRange.FromPort
+				ruleInfo.PortRangeMax = r.PortRange.ToPort
+			}
+		}
+		result = append(result, ruleInfo)
+	}
+	return result
+}
+
+// secGroupMatchesRuleInfo checks if the supplied security group rule has
+// the same direction, destination and ports as ruleInfo.
+func secGroupMatchesRuleInfo(secGroupRule neutron.SecurityGroupRuleV2, ruleInfo neutron.RuleInfoV2) bool {
+	if secGroupRule.Direction != ruleInfo.Direction ||
+		secGroupRule.EthernetType != ruleInfo.EthernetType ||
+		secGroupRule.RemoteGroupID != "" {
+		return false
+	}
+	prefix := secGroupRule.RemoteIPPrefix
+	if prefix == "" {
+		// No prefix means any address of the rule's ethernet type.
+		prefix = firewall.AllNetworksIPV4CIDR
+		if secGroupRule.EthernetType == "IPv6" {
+			prefix = firewall.AllNetworksIPV6CIDR
+		}
+	}
+	if prefix != ruleInfo.RemoteIPPrefix {
+		return false
+	}
+	protocol := ""
+	if secGroupRule.IPProtocol != nil {
+		protocol = *secGroupRule.IPProtocol
+	}
+	portMin, portMax := 0, 0
+	if secGroupRule.PortRangeMin != nil {
+		portMin = *secGroupRule.PortRangeMin
+	}
+	if secGroupRule.PortRangeMax != nil {
+		portMax = *secGroupRule.PortRangeMax
+	}
+	return protocol == ruleInfo.IPProtocol &&
+		portMin == ruleInfo.PortRangeMin &&
+		portMax == ruleInfo.PortRangeMax
+}
+
 // Matching a security group by name only works if each name is unqiue.  Neutron
 // security groups are not required to have unique names.  Juju constructs unique
 // names, but there are frequently multiple matches to 'default'
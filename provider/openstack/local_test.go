@@ -3123,6 +3123,62 @@ func (s *localServerSuite) TestICMPFirewallRules(c *gc.C) {
 	c.Assert(rules[0].SourceCIDRs.Contains("::/0"), jc.IsTrue)
 }
 
+func (s *localServerSuite) TestSetInstanceEgressRules(c *gc.C) {
+	err := bootstrapEnv(c, s.env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	inst, _ := testing.AssertStartInstance(c, s.env, s.callCtx, s.ControllerUUID, "100")
+	firewaller := openstack.GetFirewaller(s.env)
+	err = firewaller.SetInstanceEgressRules(s.callCtx, inst, "100", firewall.EgressRules{
+		{DestinationCIDR: "10.0.0.0/8"},
+		{DestinationCIDR: "0.0.0.0/0", PortRange: network.MustParsePortRange("443/tcp")},
+		{DestinationCIDR: "2001:db8::/32", PortRange: network.MustParsePortRange("53/udp")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// DNS and the metadata service are always allowed.
+	c.Assert(s.egressRules(c, openstack.MachineGroupRegexp(s.env, "100")), jc.SameContents, []string{
+		"IPv4 10.0.0.0/8",
+		"IPv4 0.0.0.0/0 tcp 443-443",
+		"IPv6 2001:db8::/32 udp 53-53",
+		"IPv4 0.0.0.0/0 tcp 53-53",
+		"IPv4 0.0.0.0/0 udp 53-53",
+		"IPv6 ::/0 tcp 53-53",
+		"IPv6 ::/0 udp 53-53",
+		"IPv4 169.254.169.254/32",
+	})
+
+	// Removing the rules allows all outbound traffic again.
+	err = firewaller.SetInstanceEgressRules(s.callCtx, inst, "100", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.egressRules(c, openstack.MachineGroupRegexp(s.env, "100")), jc.SameContents, []string{
+		"IPv4 0.0.0.0/0",
+		"IPv6 ::/0",
+	})
+}
+
+// egressRules returns a summary of the egress rules in the security group
+// matching nameRegExp.
+func (s *localServerSuite) egressRules(c *gc.C, nameRegExp string) []string {
+	group, err := openstack.MatchingGroup(s.env, s.callCtx, nameRegExp)
+	c.Assert(err, jc.ErrorIsNil)
+	var rules []string
+	for _, rule := range group.Rules {
+		if rule.Direction != "egress" {
+			continue
+		}
+		summary := rule.EthernetType + " " + rule.RemoteIPPrefix
+		if rule.IPProtocol != nil && *rule.IPProtocol != "" {
+			summary += " " + *rule.IPProtocol
+		}
+		if rule.PortRangeMin != nil && rule.PortRangeMax != nil {
+			summary += fmt.Sprintf(" %d-%d", *rule.PortRangeMin, *rule.PortRangeMax)
+		}
+		rules = append(rules, summary)
+	}
+	return rules
+}
+
 // TestIPv6RuleCreationForEmptyCIDR is a regression test for lp1709312
 func (s *localServerSuite) TestIPv6RuleCreationForEmptyCIDR(c *gc.C) {
 	err := bootstrapEnv(c, s.env)
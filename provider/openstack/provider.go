@@ -72,8 +72,9 @@ type EnvironProvider struct {
 }
 
 var (
-	_ environs.CloudEnvironProvider = (*EnvironProvider)(nil)
-	_ environs.ProviderSchema       = (*EnvironProvider)(nil)
+	_ environs.CloudEnvironProvider   = (*EnvironProvider)(nil)
+	_ environs.ProviderSchema         = (*EnvironProvider)(nil)
+	_ environs.EgressFirewallProvider = (*EnvironProvider)(nil)
 )
 
 var providerInstance = &EnvironProvider{
@@ -160,6 +161,12 @@ func (EnvironProvider) Version() int {
 	return 0
 }
 
+// SupportsEgressRules is part of the environs.EgressFirewallProvider
+// interface. Egress rules are set in the security groups of instances.
+func (EnvironProvider) SupportsEgressRules() bool {
+	return true
+}
+
 func (p EnvironProvider) Open(ctx stdcontext.Context, args environs.OpenParams) (environs.Environ, error) {
 	logger.Infof("opening model %q", args.Config.Name())
 	uuid := args.Config.UUID()
@@ -539,6 +546,10 @@ func (inst *openstackInstance) IngressRules(ctx context.ProviderCallContext, mac
 	return inst.e.firewaller.InstanceIngressRules(ctx, inst, machineId)
 }
 
+func (inst *openstackInstance) SetEgressRules(ctx context.ProviderCallContext, machineId string, rules firewall.EgressRules) error {
+	return inst.e.firewaller.SetInstanceEgressRules(ctx, inst, machineId, rules)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
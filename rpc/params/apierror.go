@@ -151,6 +151,7 @@ const (
 	CodeModelNotFound             = "model not found"
 	CodeUnauthorized              = "unauthorized access"
 	CodeSessionTokenInvalid       = "session token invalid"
+	CodeDeviceLoginPending        = "device login pending"
 	CodeLoginExpired              = "login expired"
 	CodeNoCreds                   = "no credentials provided"
 	CodeCannotEnterScope          = "cannot enter scope"
@@ -277,6 +278,12 @@ func IsCodeSessionTokenInvalid(err error) bool {
 	return ErrCode(err) == CodeSessionTokenInvalid
 }
 
+// IsCodeDeviceLoginPending returns true if err includes a DeviceLoginPending
+// error code.
+func IsCodeDeviceLoginPending(err error) bool {
+	return ErrCode(err) == CodeDeviceLoginPending
+}
+
 func IsCodeNoCreds(err error) bool {
 	// When we receive this error from an rpc call, rpc.RequestError
 	// is populated with a CodeUnauthorized code and a message that
@@ -20,6 +20,16 @@ type BackupsDownloadArgs struct {
 	ID string `json:"id"`
 }
 
+// BackupsVerifyArgs holds the args for the API Verify method.
+type BackupsVerifyArgs struct {
+	ID string `json:"id"`
+}
+
+// BackupsListResult holds the list of all stored backups.
+type BackupsListResult struct {
+	List []BackupsMetadataResult `json:"list"`
+}
+
 // BackupsMetadataResult holds the metadata for a backup as returned by
 // an API backups method (such as Create).
 type BackupsMetadataResult struct {
@@ -58,3 +68,31 @@ type BackupsMetadataResult struct {
 	// HANodes reflects HA configuration: number of controller nodes in HA.
 	HANodes int64 `json:"ha-nodes"`
 }
+
+// BackupsUploadResult holds the result of uploading a backup archive
+// to the controller.
+type BackupsUploadResult struct {
+	ID string `json:"id"`
+}
+
+// BackupsRestoreArgs holds the args for the API Restore and
+// RestoreStatus methods.
+type BackupsRestoreArgs struct {
+	ID string `json:"id"`
+}
+
+// BackupsRestoreResult holds the result of the API Restore and
+// RestoreStatus methods.
+type BackupsRestoreResult struct {
+	// Metadata holds the metadata of the restored backup archive.
+	Metadata BackupsMetadataResult `json:"metadata"`
+
+	// Steps holds the restore steps that have been started, in order.
+	Steps []string `json:"steps"`
+
+	// Done is true once the restore has finished.
+	Done bool `json:"done"`
+
+	// Error holds the reason the restore failed, if it did.
+	Error *Error `json:"error,omitempty"`
+}
@@ -148,3 +148,58 @@ type DashboardConnectionInfo struct {
 	SSHConnection   *DashboardConnectionSSHTunnel `json:"ssh-connection"`
 	Error           *Error                        `json:"error,omitempty"`
 }
+
+// AuditLogQuery holds the filter used to query the audit log entries
+// stored in the controller database. Empty fields match any entry.
+type AuditLogQuery struct {
+	// User is the name of the user that made the API requests.
+	User string `json:"user,omitempty"`
+
+	// ModelUUID is the UUID of the model the requests were made to.
+	ModelUUID string `json:"model-uuid,omitempty"`
+
+	// Facade and Method select the API requests made.
+	Facade string `json:"facade,omitempty"`
+	Method string `json:"method,omitempty"`
+
+	// From and To limit the entries to those recorded in the range.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+
+	// Limit is the maximum number of entries returned; the most
+	// recent ones are kept.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry is a conversation, or an API request made in one,
+// recorded in the audit log. The request fields are empty for
+// conversations.
+type AuditLogEntry struct {
+	Controller     string          `json:"controller"`
+	ConversationID string          `json:"conversation-id"`
+	ConnectionID   string          `json:"connection-id"`
+	When           time.Time       `json:"when"`
+	Who            string          `json:"who"`
+	What           string          `json:"what,omitempty"`
+	ModelName      string          `json:"model-name"`
+	ModelUUID      string          `json:"model-uuid"`
+	RequestID      uint64          `json:"request-id,omitempty"`
+	Facade         string          `json:"facade,omitempty"`
+	Method         string          `json:"method,omitempty"`
+	Version        int             `json:"version,omitempty"`
+	Args           string          `json:"args,omitempty"`
+	Errors         []AuditLogError `json:"errors,omitempty"`
+}
+
+// AuditLogError holds an error returned in response to an audited API
+// request.
+type AuditLogError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// AuditLogResults holds the result of an audit log query, oldest entry
+// first.
+type AuditLogResults struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
@@ -63,6 +63,79 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationDryRunResults is used to return the result of one or
+// more migration dry runs.
+type MigrationDryRunResults struct {
+	Results []MigrationDryRunResult `json:"results"`
+}
+
+// MigrationDryRunResult is used to return the blockers found by a
+// migration dry run of a single model. An empty list of blockers means
+// that the migration is expected to succeed.
+type MigrationDryRunResult struct {
+	ModelTag string             `json:"model-tag"`
+	Error    *Error             `json:"error,omitempty"`
+	Blockers []MigrationBlocker `json:"blockers,omitempty"`
+}
+
+// MigrationBlocker describes an issue, found by one of the checks run
+// during a migration dry run, that would prevent the migration.
+type MigrationBlocker struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+// CreateMigrationPlanArgs holds the details required to create a plan
+// to migrate a number of models to another controller.
+type CreateMigrationPlanArgs struct {
+	ModelTags   []string            `json:"model-tags"`
+	TargetInfo  MigrationTargetInfo `json:"target-info"`
+	Concurrency int                 `json:"concurrency"`
+	MaxAttempts int                 `json:"max-attempts"`
+	WindowStart *time.Time          `json:"window-start,omitempty"`
+	WindowEnd   *time.Time          `json:"window-end,omitempty"`
+}
+
+// MigrationPlanResults holds the details of a controller's migration
+// plans.
+type MigrationPlanResults struct {
+	Results []MigrationPlan `json:"results"`
+}
+
+// MigrationPlan describes a plan to migrate a number of models to
+// another controller, along with the progress of each model.
+type MigrationPlan struct {
+	Id                    string               `json:"id"`
+	InitiatedBy           string               `json:"initiated-by"`
+	Created               time.Time            `json:"created"`
+	TargetControllerTag   string               `json:"target-controller-tag"`
+	TargetControllerAlias string               `json:"target-controller-alias,omitempty"`
+	Concurrency           int                  `json:"concurrency"`
+	MaxAttempts           int                  `json:"max-attempts"`
+	WindowStart           *time.Time           `json:"window-start,omitempty"`
+	WindowEnd             *time.Time           `json:"window-end,omitempty"`
+	Completed             bool                 `json:"completed"`
+	Cancelled             bool                 `json:"cancelled,omitempty"`
+	Models                []MigrationPlanModel `json:"models"`
+}
+
+// MigrationPlanModel describes the progress of the migration of a
+// single model in a migration plan.
+type MigrationPlanModel struct {
+	ModelTag    string     `json:"model-tag"`
+	ModelName   string     `json:"model-name"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	MigrationId string     `json:"migration-id,omitempty"`
+	Message     string     `json:"message,omitempty"`
+	RetryAfter  *time.Time `json:"retry-after,omitempty"`
+}
+
+// CancelMigrationPlanArgs identifies the migration plan to cancel.
+type CancelMigrationPlanArgs struct {
+	Id string `json:"id"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
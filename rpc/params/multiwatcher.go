@@ -107,6 +107,10 @@ func (d *Delta) UnmarshalJSON(data []byte) error {
 		d.Entity = new(RelationInfo)
 	case "remoteApplication":
 		d.Entity = new(RemoteApplicationUpdate)
+	case "secret":
+		d.Entity = new(SecretInfo)
+	case "storageInstance":
+		d.Entity = new(StorageInstanceInfo)
 	case "unit":
 		d.Entity = new(UnitInfo)
 	default:
@@ -316,10 +320,11 @@ func (i *ActionInfo) EntityId() EntityId {
 // RelationInfo holds the information about a relation that is tracked
 // by multiwatcherStore.
 type RelationInfo struct {
-	ModelUUID string     `json:"model-uuid"`
-	Key       string     `json:"key"`
-	Id        int        `json:"id"`
-	Endpoints []Endpoint `json:"endpoints"`
+	ModelUUID string      `json:"model-uuid"`
+	Key       string      `json:"key"`
+	Id        int         `json:"id"`
+	Endpoints []Endpoint  `json:"endpoints"`
+	Status    *StatusInfo `json:"status,omitempty"`
 }
 
 // NewCharmRelation creates a new local CharmRelation structure from  the
@@ -450,3 +455,49 @@ func (i *BranchInfo) EntityId() EntityId {
 		Id:        i.Id,
 	}
 }
+
+// StorageInstanceInfo holds data about a storage instance
+// that is tracked by multiwatcherStore.
+type StorageInstanceInfo struct {
+	ModelUUID   string                `json:"model-uuid"`
+	Id          string                `json:"id"`
+	Kind        string                `json:"kind"`
+	Owner       string                `json:"owner,omitempty"`
+	StorageName string                `json:"storage-name"`
+	Pool        string                `json:"pool,omitempty"`
+	Life        life.Value            `json:"life"`
+	Attachments map[string]life.Value `json:"attachments,omitempty"`
+}
+
+// EntityId returns a unique identifier for a storage instance
+// across models.
+func (i *StorageInstanceInfo) EntityId() EntityId {
+	return EntityId{
+		Kind:      "storageInstance",
+		ModelUUID: i.ModelUUID,
+		Id:        i.Id,
+	}
+}
+
+// SecretInfo holds data about a secret owned by a model
+// that is tracked by multiwatcherStore.
+type SecretInfo struct {
+	ModelUUID      string         `json:"model-uuid"`
+	Id             string         `json:"id"`
+	Owner          string         `json:"owner"`
+	Label          string         `json:"label,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	LatestRevision int            `json:"latest-revision"`
+	RotatePolicy   string         `json:"rotate-policy,omitempty"`
+	UpdateTime     time.Time      `json:"update-time"`
+	Consumers      map[string]int `json:"consumers,omitempty"`
+}
+
+// EntityId returns a unique identifier for a secret across models.
+func (i *SecretInfo) EntityId() EntityId {
+	return EntityId{
+		Kind:      "secret",
+		ModelUUID: i.ModelUUID,
+		Id:        i.Id,
+	}
+}
@@ -16,4 +16,6 @@ var (
 	_ EntityInfo = (*ActionInfo)(nil)
 	_ EntityInfo = (*ModelUpdate)(nil)
 	_ EntityInfo = (*BranchInfo)(nil)
+	_ EntityInfo = (*StorageInstanceInfo)(nil)
+	_ EntityInfo = (*SecretInfo)(nil)
 )
@@ -599,6 +599,7 @@ type LoginRequest struct {
 	Macaroons     []macaroon.Slice `json:"macaroons"`
 	BakeryVersion bakery.Version   `json:"bakery-version,omitempty"`
 	Token         string           `json:"token,omitempty"`
+	SessionToken  string           `json:"session-token,omitempty"`
 	CLIArgs       string           `json:"cli-args,omitempty"`
 	UserData      string           `json:"user-data"`
 	ClientVersion string           `json:"client-version,omitempty"`
@@ -611,6 +612,27 @@ type LoginRequestCompat struct {
 	Creds        `json:"creds"`
 }
 
+// LoginDeviceResult holds the details a user needs to complete an
+// OAuth 2.0 device login with the controller's identity provider.
+type LoginDeviceResult struct {
+	UserCode        string `json:"user-code"`
+	VerificationURI string `json:"verification-uri"`
+	// Interval is the minimum number of seconds between calls to
+	// GetDeviceSessionToken.
+	Interval int `json:"interval,omitempty"`
+}
+
+// SessionTokenResult holds the session token obtained once a device
+// login has completed.
+type SessionTokenResult struct {
+	SessionToken string `json:"session-token"`
+}
+
+// SessionTokenLoginRequest holds the session token used to log in.
+type SessionTokenLoginRequest struct {
+	SessionToken string `json:"session-token"`
+}
+
 // GetAnnotationsResults holds annotations associated with an entity.
 type GetAnnotationsResults struct {
 	Annotations map[string]string `json:"annotations"`
@@ -1411,6 +1433,67 @@ type BranchTrackArg struct {
 	NumUnits   int      `json:"num-units,omitempty"`
 }
 
+// BranchRolloutArg identifies an in-flight branch and the policy by
+// which it is to be gradually rolled out.
+type BranchRolloutArg struct {
+	BranchName string `json:"branch"`
+
+	// Steps are the percentages of each application's units to be
+	// tracking the branch after each step.
+	Steps []int `json:"steps"`
+
+	// Start, if set, is when the rollout begins.
+	Start *time.Time `json:"start,omitempty"`
+
+	// StepInterval is the minimum time between steps.
+	StepInterval time.Duration `json:"step-interval,omitempty"`
+
+	// WaitForActive indicates that each step waits for the units
+	// tracking the branch to be active.
+	WaitForActive bool `json:"wait-for-active,omitempty"`
+
+	// AutoAbort indicates that the branch is aborted if any unit
+	// tracking it goes into error.
+	AutoAbort bool `json:"auto-abort,omitempty"`
+}
+
+// BranchCharmArg identifies an in-flight branch and the charm that an
+// application is upgraded to under it.
+type BranchCharmArg struct {
+	BranchName      string `json:"branch"`
+	ApplicationName string `json:"application"`
+
+	// CharmURL is the URL of the charm, which must already have been
+	// added to the model.
+	CharmURL    string       `json:"charm-url"`
+	CharmOrigin *CharmOrigin `json:"charm-origin,omitempty"`
+
+	// ResourceIDs are the IDs of pending resources to attach to the
+	// application under the branch, keyed by resource name.
+	ResourceIDs map[string]string `json:"resource-ids,omitempty"`
+}
+
+// BranchResourceArg identifies an in-flight branch and a pending resource
+// to be attached to an application under it.
+type BranchResourceArg struct {
+	BranchName      string `json:"branch"`
+	ApplicationName string `json:"application"`
+	ResourceName    string `json:"resource"`
+	PendingID       string `json:"pending-id"`
+}
+
+// BranchRollout represents the rollout policy and progress of a branch.
+type BranchRollout struct {
+	Steps         []int         `json:"steps"`
+	Start         *time.Time    `json:"start,omitempty"`
+	StepInterval  time.Duration `json:"step-interval,omitempty"`
+	WaitForActive bool          `json:"wait-for-active,omitempty"`
+	AutoAbort     bool          `json:"auto-abort,omitempty"`
+	Status        string        `json:"status"`
+	Step          int           `json:"step"`
+	Message       string        `json:"message,omitempty"`
+}
+
 // GenerationApplication represents changes to an application
 // made under a branch.
 type GenerationApplication struct {
@@ -1431,6 +1514,14 @@ type GenerationApplication struct {
 	// Config changes are the effective new configuration values resulting from
 	// changes made under this branch.
 	ConfigChanges map[string]interface{} `json:"config"`
+
+	// CharmURL is the URL of the charm that the application is upgraded
+	// to under this branch, if it is.
+	CharmURL string `json:"charm-url,omitempty"`
+
+	// Resources are the IDs of the pending resources attached to the
+	// application under this branch, keyed by resource name.
+	Resources map[string]string `json:"resources,omitempty"`
 }
 
 // Generation represents a model generation's details including config changes.
@@ -1456,6 +1547,10 @@ type Generation struct {
 	// Applications holds the collection of application changes
 	// made under this generation.
 	Applications []GenerationApplication `json:"applications"`
+
+	// Rollout holds the rollout policy and progress of the generation,
+	// if it has one.
+	Rollout *BranchRollout `json:"rollout,omitempty"`
 }
 
 // BranchResults transports a collection of generation details.
@@ -6,6 +6,7 @@ package params_test
 import (
 	"encoding/json"
 	stdtesting "testing"
+	"time"
 
 	"github.com/juju/charm/v12"
 	jc "github.com/juju/testing/checkers"
@@ -158,6 +159,46 @@ var marshalTestCases = []struct {
 		},
 	},
 	json: `["relation","change",{"model-uuid": "uuid", "key":"Benji", "id": 4711, "endpoints": [{"application-name":"logging", "relation":{"name":"logging-directory", "role":"requirer", "interface":"logging", "optional":false, "limit":1, "scope":"container"}}, {"application-name":"wordpress", "relation":{"name":"logging-dir", "role":"provider", "interface":"logging", "optional":false, "limit":0, "scope":"container"}}]}]`,
+}, {
+	about: "RelationInfo Delta with status",
+	value: params.Delta{
+		Entity: &params.RelationInfo{
+			ModelUUID: "uuid",
+			Key:       "Benji",
+			Id:        4711,
+			Status: &params.StatusInfo{
+				Current: status.Joined,
+			},
+		},
+	},
+	json: `["relation","change",{"model-uuid": "uuid", "key":"Benji", "id": 4711, "endpoints": null, "status":{"current":"joined","message":"","version":""}}]`,
+}, {
+	about: "StorageInstanceInfo Delta",
+	value: params.Delta{
+		Entity: &params.StorageInstanceInfo{
+			ModelUUID:   "uuid",
+			Id:          "data/0",
+			Kind:        "block",
+			Owner:       "unit-mysql-0",
+			StorageName: "data",
+			Life:        life.Alive,
+			Attachments: map[string]life.Value{"mysql/0": life.Alive},
+		},
+	},
+	json: `["storageInstance","change",{"model-uuid":"uuid","id":"data/0","kind":"block","owner":"unit-mysql-0","storage-name":"data","life":"alive","attachments":{"mysql/0":"alive"}}]`,
+}, {
+	about: "SecretInfo Delta",
+	value: params.Delta{
+		Entity: &params.SecretInfo{
+			ModelUUID:      "uuid",
+			Id:             "cj4v5vm78ohs79o84r4g",
+			Owner:          "application-mysql",
+			LatestRevision: 2,
+			UpdateTime:     time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+			Consumers:      map[string]int{"unit-wordpress-0": 1},
+		},
+	},
+	json: `["secret","change",{"model-uuid":"uuid","id":"cj4v5vm78ohs79o84r4g","owner":"application-mysql","latest-revision":2,"update-time":"2023-05-01T12:00:00Z","consumers":{"unit-wordpress-0":1}}]`,
 }, {
 	about: "AnnotationInfo Delta",
 	value: params.Delta{
@@ -222,6 +222,40 @@ type ChangeSecretBackendArg struct {
 	Content  SecretContentParams `json:"content,omitempty"`
 }
 
+// MigrateSecretsArg holds the args for migrating secret content
+// from one backend to another.
+type MigrateSecretsArg struct {
+	FromBackend string `json:"from-backend"`
+	ToBackend   string `json:"to-backend"`
+	// Limit is the maximum number of revisions to attempt in the
+	// call, whether they are migrated or not; 0 means no limit.
+	Limit int `json:"limit,omitempty"`
+	// After is the cursor returned by the previous call; only the
+	// revisions after it are attempted.
+	After string `json:"after,omitempty"`
+}
+
+// MigrateSecretsResult holds the progress of a secret migration.
+type MigrateSecretsResult struct {
+	// Migrated is the number of revisions migrated by the call.
+	Migrated int `json:"migrated"`
+	// Remaining is the number of revisions still stored in the
+	// source backend.
+	Remaining int `json:"remaining"`
+	// Failed holds the revisions which could not be migrated.
+	Failed []SecretRevisionError `json:"failed,omitempty"`
+	// Next is the cursor to carry on from in the next call; it is
+	// empty once every revision has been attempted.
+	Next string `json:"next,omitempty"`
+}
+
+// SecretRevisionError holds an error for a secret revision.
+type SecretRevisionError struct {
+	URI      string `json:"uri"`
+	Revision int    `json:"revision"`
+	Error    *Error `json:"error"`
+}
+
 // SecretContentResults holds secret value results.
 type SecretContentResults struct {
 	Results []SecretContentResult `json:"results"`
@@ -99,6 +99,9 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     life.Value  `json:"life"`
+
+	// Size is the size of the attached volume or filesystem, in MiB.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -258,6 +261,7 @@ type VolumeParams struct {
 	Provider   string                  `json:"provider"`
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
 }
 
@@ -419,6 +423,7 @@ type FilesystemParams struct {
 	Provider      string                      `json:"provider"`
 	Attributes    map[string]interface{}      `json:"attributes,omitempty"`
 	Tags          map[string]string           `json:"tags,omitempty"`
+	SnapshotId    string                      `json:"snapshot-id,omitempty"`
 	Attachment    *FilesystemAttachmentParams `json:"attachment,omitempty"`
 }
 
@@ -535,6 +540,9 @@ type StorageDetails struct {
 	// Attachments contains a mapping from unit tag to
 	// storage attachment details.
 	Attachments map[string]StorageAttachmentDetails `json:"attachments,omitempty"`
+
+	// Usage contains the last reported usage of the storage, if any.
+	Usage *StorageUsage `json:"usage,omitempty"`
 }
 
 // StorageFilter holds filter terms for listing storage details.
@@ -855,6 +863,11 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// SnapshotId, if non-empty, is the ID of the storage snapshot that
+	// the storage is to be created from. The constraints are ignored,
+	// as the storage takes the pool and size of the snapshot.
+	SnapshotId string `json:"snapshot-id,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
@@ -913,6 +926,10 @@ type ImportStorageParams struct {
 
 	// StorageName is the name of the storage to assign to the entity.
 	StorageName string `json:"storage-name"`
+
+	// Application, if non-empty, is the name of the application to
+	// whose units the imported storage may be attached.
+	Application string `json:"application,omitempty"`
 }
 
 // ImportStorageResults contains the results of importing a collection of
@@ -951,3 +968,225 @@ type AddStorageDetails struct {
 	// of the added storage instances.
 	StorageTags []string `json:"storage-tags"`
 }
+
+// StorageSnapshotDetails holds information about a storage snapshot.
+type StorageSnapshotDetails struct {
+	// Id is the ID of the snapshot.
+	Id string `json:"id"`
+
+	// StorageTag is the tag of the storage instance that the snapshot
+	// was taken of.
+	StorageTag string `json:"storage-tag"`
+
+	// Kind is the kind of the storage instance.
+	Kind StorageKind `json:"kind"`
+
+	// VolumeTag is the tag of the volume that the snapshot was taken
+	// of, if any.
+	VolumeTag string `json:"volume-tag,omitempty"`
+
+	// FilesystemTag is the tag of the filesystem that the snapshot was
+	// taken of, if any.
+	FilesystemTag string `json:"filesystem-tag,omitempty"`
+
+	// MachineTag is the tag of the machine that the snapshot is
+	// scoped to, if any.
+	MachineTag string `json:"machine-tag,omitempty"`
+
+	// Pool is the name of the storage pool of the snapshotted storage.
+	Pool string `json:"pool"`
+
+	// Size is the size of the snapshotted storage, in MiB.
+	Size uint64 `json:"size"`
+
+	// Created is when the snapshot was requested.
+	Created time.Time `json:"created"`
+
+	// SnapshotId is the provider-allocated unique ID of the snapshot,
+	// set once the snapshot has been taken.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+
+	// Message is the reason that taking the snapshot failed, if it did.
+	Message string `json:"message,omitempty"`
+
+	// Life is the life of the snapshot. Dying snapshots are being
+	// deleted.
+	Life life.Value `json:"life,omitempty"`
+}
+
+// StorageSnapshotDetailsResult holds a storage snapshot's details, or
+// an error.
+type StorageSnapshotDetailsResult struct {
+	Result *StorageSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                  `json:"error,omitempty"`
+}
+
+// StorageSnapshotDetailsResults holds a collection of storage snapshot
+// details.
+type StorageSnapshotDetailsResults struct {
+	Results []StorageSnapshotDetailsResult `json:"results"`
+}
+
+// StorageSnapshotParams holds the parameters for taking a snapshot of
+// a volume or filesystem.
+type StorageSnapshotParams struct {
+	// Id is the ID of the storage snapshot.
+	Id string `json:"id"`
+
+	// VolumeTag is the tag of the volume to snapshot, if any.
+	VolumeTag string `json:"volume-tag,omitempty"`
+
+	// VolumeId is the provider-allocated unique ID of the volume.
+	VolumeId string `json:"volume-id,omitempty"`
+
+	// FilesystemTag is the tag of the filesystem to snapshot, if any.
+	FilesystemTag string `json:"filesystem-tag,omitempty"`
+
+	// FilesystemId is the provider-allocated unique ID of the
+	// filesystem.
+	FilesystemId string `json:"filesystem-id,omitempty"`
+
+	// Provider is the storage provider that manages the storage.
+	Provider string `json:"provider"`
+
+	// Attributes are the storage pool attributes.
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// Tags are the resource tags to apply to the snapshot.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Life is the life of the snapshot. A dying snapshot is to be
+	// deleted rather than taken.
+	Life life.Value `json:"life"`
+
+	// SnapshotId is the provider-allocated unique ID of a dying
+	// snapshot that has been taken, and so must be deleted.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+}
+
+// StorageSnapshotParamsResult holds the parameters for taking or
+// deleting a snapshot, or an error. Result is nil if the snapshot no
+// longer needs to be taken.
+type StorageSnapshotParamsResult struct {
+	Result *StorageSnapshotParams `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// StorageSnapshotParamsResults holds a collection of
+// StorageSnapshotParamsResult.
+type StorageSnapshotParamsResults struct {
+	Results []StorageSnapshotParamsResult `json:"results"`
+}
+
+// StorageSnapshotInfo holds the outcome of taking a storage snapshot.
+type StorageSnapshotInfo struct {
+	// Id is the ID of the storage snapshot.
+	Id string `json:"id"`
+
+	// SnapshotId is the provider-allocated unique ID of the snapshot.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+
+	// Size is the size of the snapshot reported by the provider, in MiB.
+	Size uint64 `json:"size,omitempty"`
+
+	// Error is the reason that taking the snapshot failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
+// StorageSnapshotInfos holds a collection of StorageSnapshotInfo.
+type StorageSnapshotInfos struct {
+	Snapshots []StorageSnapshotInfo `json:"snapshots"`
+}
+
+// ResizeStorageArg holds the arguments for resizing a storage instance.
+type ResizeStorageArg struct {
+	// StorageTag is the tag of the storage instance to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the requested size of the storage, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// ResizeStorageArgs holds the arguments for resizing storage instances.
+type ResizeStorageArgs struct {
+	Storage []ResizeStorageArg `json:"storage"`
+}
+
+// StorageResizeParams holds the parameters for growing a volume or
+// filesystem.
+type StorageResizeParams struct {
+	// Tag is the tag of the volume or filesystem to resize.
+	Tag string `json:"tag"`
+
+	// ProviderId is the provider-allocated unique ID of the volume or
+	// filesystem.
+	ProviderId string `json:"provider-id"`
+
+	// Size is the current size of the volume or filesystem, in MiB.
+	Size uint64 `json:"size"`
+
+	// RequestedSize is the size to grow the volume or filesystem to,
+	// in MiB.
+	RequestedSize uint64 `json:"requested-size"`
+
+	// Provider is the storage provider that manages the storage.
+	Provider string `json:"provider"`
+
+	// Attributes are the storage pool attributes.
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// StorageResizeParamsResult holds the parameters for growing a volume
+// or filesystem, or an error. Result is nil if no resize is pending.
+type StorageResizeParamsResult struct {
+	Result *StorageResizeParams `json:"result,omitempty"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// StorageResizeParamsResults holds a collection of
+// StorageResizeParamsResult.
+type StorageResizeParamsResults struct {
+	Results []StorageResizeParamsResult `json:"results"`
+}
+
+// StorageResized holds the outcome of growing a volume or filesystem.
+type StorageResized struct {
+	// Tag is the tag of the volume or filesystem that was resized.
+	Tag string `json:"tag"`
+
+	// Size is the size of the volume or filesystem after the resize
+	// was attempted, in MiB. If the resize failed, this is zero.
+	Size uint64 `json:"size,omitempty"`
+}
+
+// StorageResizedArgs holds a collection of StorageResized.
+type StorageResizedArgs struct {
+	Resized []StorageResized `json:"resized"`
+}
+
+// StorageUsage describes how much of the space and inodes of a storage
+// instance are in use.
+type StorageUsage struct {
+	BytesUsed   uint64    `json:"bytes-used"`
+	BytesTotal  uint64    `json:"bytes-total"`
+	InodesUsed  uint64    `json:"inodes-used"`
+	InodesTotal uint64    `json:"inodes-total"`
+	Updated     time.Time `json:"updated"`
+
+	// Warning is set when the usage is at or above the model's
+	// storage usage warning threshold.
+	Warning string `json:"warning,omitempty"`
+}
+
+// StorageUsageArg holds the usage of a storage instance attached to a
+// unit, as reported by the unit.
+type StorageUsageArg struct {
+	StorageTag string       `json:"storage-tag"`
+	UnitTag    string       `json:"unit-tag"`
+	Usage      StorageUsage `json:"usage"`
+}
+
+// StorageUsageArgs holds the usage of a set of storage instances.
+type StorageUsageArgs struct {
+	Args []StorageUsageArg `json:"args"`
+}
@@ -16,6 +16,13 @@ type UserInfo struct {
 	DateCreated    time.Time  `json:"date-created"`
 	LastConnection *time.Time `json:"last-connection,omitempty"`
 	Disabled       bool       `json:"disabled"`
+
+	// Groups holds the names of the user groups the user is a member of.
+	Groups []string `json:"groups,omitempty"`
+
+	// GroupAccess holds the access the user is granted through
+	// membership of user groups.
+	GroupAccess []UserGroupAccess `json:"group-access,omitempty"`
 }
 
 // UserInfoResult holds the result of a UserInfo call.
@@ -67,3 +74,78 @@ type AddUserResult struct {
 	SecretKey []byte `json:"secret-key,omitempty"`
 	Error     *Error `json:"error,omitempty"`
 }
+
+// UserGroupAccess describes the access a user group has on a controller,
+// model, cloud or application offer.
+type UserGroupAccess struct {
+	Group  string `json:"group"`
+	Target string `json:"target-tag"`
+	Access string `json:"access"`
+}
+
+// UserGroupInfo holds information on a user group.
+type UserGroupInfo struct {
+	Name        string            `json:"name"`
+	Members     []string          `json:"members"`
+	CreatedBy   string            `json:"created-by"`
+	DateCreated time.Time         `json:"date-created"`
+	Access      []UserGroupAccess `json:"access,omitempty"`
+}
+
+// UserGroupInfoResult holds the result of a UserGroups call.
+type UserGroupInfoResult struct {
+	Result *UserGroupInfo `json:"result,omitempty"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// UserGroupInfoResults holds the result of a bulk UserGroups API call.
+type UserGroupInfoResults struct {
+	Results []UserGroupInfoResult `json:"results"`
+}
+
+// UserGroupNames holds the names of user groups. An empty list
+// indicates that all user groups should be returned by UserGroups.
+type UserGroupNames struct {
+	Names []string `json:"names"`
+}
+
+// UserGroupAction is an action that can be performed on user group
+// membership or access.
+type UserGroupAction string
+
+// Actions that can be performed on a user group.
+const (
+	AddUserGroupMembers    UserGroupAction = "add"
+	RemoveUserGroupMembers UserGroupAction = "remove"
+	GrantUserGroupAccess   UserGroupAction = "grant"
+	RevokeUserGroupAccess  UserGroupAction = "revoke"
+)
+
+// ModifyUserGroupMembersRequest holds the parameters for adding users to,
+// and removing users from, user groups.
+type ModifyUserGroupMembersRequest struct {
+	Changes []ModifyUserGroupMembers `json:"changes"`
+}
+
+// ModifyUserGroupMembers holds the parameters for changing the membership
+// of a single user group.
+type ModifyUserGroupMembers struct {
+	Group    string          `json:"group"`
+	Action   UserGroupAction `json:"action"`
+	UserTags []string        `json:"user-tags"`
+}
+
+// ModifyUserGroupAccessRequest holds the parameters for granting access to,
+// and revoking access from, user groups.
+type ModifyUserGroupAccessRequest struct {
+	Changes []ModifyUserGroupAccess `json:"changes"`
+}
+
+// ModifyUserGroupAccess holds the parameters for changing the access of a
+// single user group on a controller, model, cloud or application offer.
+type ModifyUserGroupAccess struct {
+	Group     string          `json:"group"`
+	Action    UserGroupAction `json:"action"`
+	Access    string          `json:"access"`
+	TargetTag string          `json:"target-tag"`
+}
@@ -5,12 +5,14 @@ package all
 
 import (
 	"github.com/juju/juju/secrets/provider"
+	"github.com/juju/juju/secrets/provider/file"
 	"github.com/juju/juju/secrets/provider/juju"
 	"github.com/juju/juju/secrets/provider/kubernetes"
 	"github.com/juju/juju/secrets/provider/vault"
 )
 
 func init() {
+	provider.Register(file.NewProvider())
 	provider.Register(juju.NewProvider())
 	provider.Register(kubernetes.NewProvider())
 	provider.Register(vault.NewProvider())
@@ -0,0 +1,150 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/nacl/secretbox"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+const nonceSize = 24
+
+type fileBackend struct {
+	rootDir  string
+	modelDir string
+	key      *[keySize]byte
+}
+
+func (k fileBackend) contentPath(revisionId string) (string, error) {
+	if revisionId == "" || strings.ContainsAny(revisionId, `/\`) || revisionId == "." || revisionId == ".." {
+		return "", errors.NotValidf("secret revision id %q", revisionId)
+	}
+	return filepath.Join(k.modelDir, revisionId), nil
+}
+
+// GetContent implements SecretsBackend.
+func (k fileBackend) GetContent(_ context.Context, revisionId string) (secrets.SecretValue, error) {
+	path, err := k.contentPath(revisionId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sealed, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("secret revision %q", revisionId)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting secret %q", revisionId)
+	}
+	if len(sealed) < nonceSize {
+		return nil, errors.NotValidf("content of secret %q", revisionId)
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], sealed[:nonceSize])
+	data, ok := secretbox.Open(nil, sealed[nonceSize:], &nonce, k.key)
+	if !ok {
+		return nil, errors.Errorf("cannot decrypt secret %q", revisionId)
+	}
+	val := make(map[string]string)
+	if err := json.Unmarshal(data, &val); err != nil {
+		return nil, errors.Annotatef(err, "decoding secret %q", revisionId)
+	}
+	return secrets.NewSecretValue(val), nil
+}
+
+// DeleteContent implements SecretsBackend.
+func (k fileBackend) DeleteContent(_ context.Context, revisionId string) error {
+	path, err := k.contentPath(revisionId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("secret revision %q", revisionId)
+	}
+	return errors.Annotatef(err, "deleting secret %q", revisionId)
+}
+
+// SaveContent implements SecretsBackend.
+func (k fileBackend) SaveContent(_ context.Context, uri *secrets.URI, revision int, value secrets.SecretValue) (string, error) {
+	revisionId := uri.Name(revision)
+	path, err := k.contentPath(revisionId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	data, err := json.Marshal(value.EncodedValues())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var nonce [nonceSize]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return "", errors.Annotate(err, "generating nonce")
+	}
+	sealed := secretbox.Seal(nonce[:], data, &nonce, k.key)
+
+	// Write to a temporary file first so that readers never
+	// see partially written content.
+	f, err := os.CreateTemp(k.modelDir, "."+revisionId+"-*")
+	if err != nil {
+		return "", errors.Annotatef(err, "saving secret content for %q", revisionId)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.Write(sealed); err != nil {
+		_ = f.Close()
+		return "", errors.Annotatef(err, "saving secret content for %q", revisionId)
+	}
+	if err := f.Close(); err != nil {
+		return "", errors.Annotatef(err, "saving secret content for %q", revisionId)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", errors.Annotatef(err, "saving secret content for %q", revisionId)
+	}
+	return revisionId, nil
+}
+
+// Ping implements SecretsBackend.
+func (k fileBackend) Ping() error {
+	info, err := os.Stat(k.rootDir)
+	if err != nil {
+		return errors.Annotate(err, "backend not reachable")
+	}
+	if !info.IsDir() {
+		return errors.Errorf("backend path %q is not a directory", k.rootDir)
+	}
+	return nil
+}
+
+// agentBackend is used by agents, which have no access to the
+// backend directory. Content is sent to and read from the
+// controller instead.
+type agentBackend struct{}
+
+// GetContent implements SecretsBackend.
+func (agentBackend) GetContent(_ context.Context, revisionId string) (secrets.SecretValue, error) {
+	return nil, errors.NotSupportedf("reading secret %q from a controller served backend", revisionId)
+}
+
+// DeleteContent implements SecretsBackend.
+// The controller deletes the content when the secret revision is removed.
+func (agentBackend) DeleteContent(_ context.Context, _ string) error {
+	return nil
+}
+
+// SaveContent implements SecretsBackend.
+func (agentBackend) SaveContent(_ context.Context, _ *secrets.URI, _ int, _ secrets.SecretValue) (string, error) {
+	return "", errors.NotSupportedf("saving content to a controller served backend")
+}
+
+// Ping implements SecretsBackend.
+func (agentBackend) Ping() error {
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file
+
+import (
+	"encoding/base64"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/controller"
+	coreconfig "github.com/juju/juju/core/config"
+	"github.com/juju/juju/secrets/provider"
+)
+
+const (
+	// PathKey is the directory in which secret content is stored.
+	PathKey = "path"
+
+	// EncryptionKeyKey is the base64 encoded 32 byte key from which
+	// the per model content keys are derived. It is taken from the
+	// secret-backend-encryption-key controller config attribute.
+	EncryptionKeyKey = "encryption-key"
+)
+
+var configSchema = environschema.Fields{
+	PathKey: {
+		Description: "The absolute path of the directory in which to store secrets. " +
+			"For a highly available controller, the directory must be shared by all controller machines.",
+		Type:      environschema.Tstring,
+		Immutable: true,
+		Mandatory: true,
+	},
+}
+
+var configDefaults = schema.Defaults{}
+
+type backendConfig struct {
+	validAttrs map[string]interface{}
+	key        string
+}
+
+func (c *backendConfig) path() string {
+	return c.validAttrs[PathKey].(string)
+}
+
+// ConfigSchema implements SecretBackendProvider.
+func (p fileProvider) ConfigSchema() environschema.Fields {
+	return configSchema
+}
+
+// ConfigDefaults implements SecretBackendProvider.
+func (p fileProvider) ConfigDefaults() schema.Defaults {
+	return configDefaults
+}
+
+// ValidateConfig implements SecretBackendProvider.
+func (p fileProvider) ValidateConfig(oldCfg, newCfg provider.ConfigAttrs) error {
+	if _, ok := newCfg[EncryptionKeyKey]; ok {
+		return errors.NotValidf("%q is taken from controller config %q and cannot be set", EncryptionKeyKey, controller.SecretBackendEncryptionKey)
+	}
+	newValidCfg, err := newConfig(newCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !filepath.IsAbs(newValidCfg.path()) {
+		return errors.NotValidf("relative path %q", newValidCfg.path())
+	}
+
+	if oldCfg == nil {
+		return nil
+	}
+	oldValidCfg, err := newConfig(oldCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for n, field := range configSchema {
+		if !field.Immutable {
+			continue
+		}
+		oldV := oldValidCfg.validAttrs[n]
+		newV := newValidCfg.validAttrs[n]
+		if oldV != newV {
+			return errors.Errorf("cannot change immutable field %q", n)
+		}
+	}
+	return nil
+}
+
+// ControllerSecretConfig implements ControllerSecretConfig.
+// It returns the encryption key held in controller config.
+func (p fileProvider) ControllerSecretConfig(controllerCfg controller.Config) (provider.ConfigAttrs, error) {
+	key := controllerCfg.SecretBackendEncryptionKey()
+	if key == "" {
+		return nil, errors.NotValidf("missing controller config %q", controller.SecretBackendEncryptionKey)
+	}
+	return provider.ConfigAttrs{
+		EncryptionKeyKey: key,
+	}, nil
+}
+
+// newConfig returns the config for the specified attributes. The
+// encryption key is only present in the admin config assembled
+// by the controller, so it is not part of the config schema.
+func newConfig(attrs map[string]interface{}) (*backendConfig, error) {
+	schemaAttrs := make(map[string]interface{}, len(attrs))
+	for k, v := range attrs {
+		if k != EncryptionKeyKey {
+			schemaAttrs[k] = v
+		}
+	}
+	cfg, err := coreconfig.NewConfig(schemaAttrs, configSchema, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	key, _ := attrs[EncryptionKeyKey].(string)
+	return &backendConfig{validAttrs: cfg.Attributes(), key: key}, nil
+}
+
+func decodeKey(encoded string) (*[keySize]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.NotValidf("encryption key encoding")
+	}
+	if len(raw) != keySize {
+		return nil, errors.NotValidf("encryption key length %d (expected %d)", len(raw), keySize)
+	}
+	var key [keySize]byte
+	copy(key[:], raw)
+	return &key, nil
+}
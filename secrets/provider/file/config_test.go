@@ -0,0 +1,93 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file_test
+
+import (
+	"encoding/base64"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/secrets/provider"
+	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/secrets/provider/file"
+)
+
+type configSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&configSuite{})
+
+func (s *configSuite) TestValidateConfig(c *gc.C) {
+	p, err := provider.Provider(file.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	configValidator, ok := p.(provider.ProviderConfig)
+	c.Assert(ok, jc.IsTrue)
+	for i, t := range []struct {
+		cfg    map[string]interface{}
+		oldCfg map[string]interface{}
+		err    string
+	}{{
+		cfg: map[string]interface{}{},
+		err: "path: expected string, got nothing",
+	}, {
+		cfg: map[string]interface{}{"path": "secrets"},
+		err: `relative path "secrets" not valid`,
+	}, {
+		cfg: map[string]interface{}{"path": "/srv/secrets", "encryption-key": testKey},
+		err: `"encryption-key" is taken from controller config "secret-backend-encryption-key" and cannot be set not valid`,
+	}, {
+		cfg:    map[string]interface{}{"path": "/srv/new"},
+		oldCfg: map[string]interface{}{"path": "/srv/old"},
+		err:    `cannot change immutable field "path"`,
+	}, {
+		cfg:    map[string]interface{}{"path": "/srv/secrets"},
+		oldCfg: map[string]interface{}{"path": "/srv/secrets"},
+	}} {
+		c.Logf("test %d", i)
+		err = configValidator.ValidateConfig(t.oldCfg, t.cfg)
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *configSuite) TestConfigDefaults(c *gc.C) {
+	p, err := provider.Provider(file.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	configValidator, ok := p.(provider.ProviderConfig)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(configValidator.ConfigDefaults(), gc.NotNil)
+	_, ok = configValidator.ConfigSchema()["encryption-key"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *configSuite) TestControllerSecretConfig(c *gc.C) {
+	p, err := provider.Provider(file.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	secretConfig, ok := p.(provider.ControllerSecretConfig)
+	c.Assert(ok, jc.IsTrue)
+	key := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	cfg, err := secretConfig.ControllerSecretConfig(controller.Config{
+		"secret-backend-encryption-key": key,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, provider.ConfigAttrs{"encryption-key": key})
+}
+
+func (s *configSuite) TestControllerSecretConfigMissingKey(c *gc.C) {
+	p, err := provider.Provider(file.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	secretConfig, ok := p.(provider.ControllerSecretConfig)
+	c.Assert(ok, jc.IsTrue)
+	_, err = secretConfig.ControllerSecretConfig(controller.Config{})
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+	c.Assert(err, gc.ErrorMatches, `missing controller config "secret-backend-encryption-key" not valid`)
+}
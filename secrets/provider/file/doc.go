@@ -0,0 +1,11 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package file provides a secrets backend which stores secret content
+// in a local directory tree, encrypted with NaCl secretbox.
+//
+// The content key for each model is derived from the
+// secret-backend-encryption-key controller config. Only the
+// controller reads or writes the directory, so for a highly available
+// controller it must be on storage shared by all controller machines.
+package file
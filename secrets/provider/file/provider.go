@@ -0,0 +1,142 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/secrets/provider"
+)
+
+var logger = loggo.GetLogger("juju.secrets.file")
+
+const (
+	// BackendType is the type of the file secrets backend.
+	BackendType = "file"
+
+	keySize = 32
+)
+
+// NewProvider returns a file secrets provider.
+func NewProvider() provider.SecretBackendProvider {
+	return fileProvider{}
+}
+
+type fileProvider struct {
+}
+
+func (p fileProvider) Type() string {
+	return BackendType
+}
+
+func modelPathPrefix(name, modelUUID string) string {
+	if name == "" || modelUUID == "" {
+		return ""
+	}
+	suffix := modelUUID[len(modelUUID)-6:]
+	return name + "-" + suffix
+}
+
+// Initialise creates the directory holding the model's secrets.
+func (p fileProvider) Initialise(cfg *provider.ModelBackendConfig) error {
+	backend, err := p.newBackend(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(backend.modelDir, 0700); err != nil {
+		return errors.Annotatef(err, "creating secrets directory for model %q", cfg.ModelName)
+	}
+	return nil
+}
+
+// CleanupModel deletes all secrets associated with the model.
+func (p fileProvider) CleanupModel(cfg *provider.ModelBackendConfig) error {
+	backend, err := p.newBackend(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("removing secrets directory %q", backend.modelDir)
+	if err := os.RemoveAll(backend.modelDir); err != nil {
+		return errors.Annotatef(err, "removing secrets for model %q", cfg.ModelName)
+	}
+	return nil
+}
+
+// CleanupSecrets is not used; there are no access policies
+// associated with individual secrets.
+func (p fileProvider) CleanupSecrets(cfg *provider.ModelBackendConfig, tag names.Tag, removed provider.SecretRevisions) error {
+	return nil
+}
+
+// ServedByController implements ControllerServed.
+// The backend directory is only reachable from the controller, so
+// agents send and receive secret content via the controller API.
+func (p fileProvider) ServedByController() {}
+
+// RestrictedConfig returns the config needed to create a
+// secrets backend client restricted to manage the specified
+// owned secrets and read shared secrets for the given entity tag.
+// Agents have no direct access to the backend, so the config
+// carries neither the path nor any key; the controller only
+// serves content the agent is permitted to access.
+func (p fileProvider) RestrictedConfig(
+	adminCfg *provider.ModelBackendConfig, sameController, forDrain bool, tag names.Tag, owned provider.SecretRevisions, read provider.SecretRevisions,
+) (*provider.BackendConfig, error) {
+	return &provider.BackendConfig{
+		BackendType: BackendType,
+	}, nil
+}
+
+// NewBackend returns a file backed secrets backend client.
+// A restricted config, as handed to agents, results in a
+// client which defers all content operations to the controller.
+func (p fileProvider) NewBackend(cfg *provider.ModelBackendConfig) (provider.SecretsBackend, error) {
+	if _, ok := cfg.Config[PathKey]; !ok {
+		return agentBackend{}, nil
+	}
+	return p.newBackend(cfg)
+}
+
+func (p fileProvider) newBackend(cfg *provider.ModelBackendConfig) (*fileBackend, error) {
+	validCfg, err := newConfig(cfg.Config)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid file config")
+	}
+	backend := &fileBackend{
+		rootDir:  validCfg.path(),
+		modelDir: filepath.Join(validCfg.path(), modelPathPrefix(cfg.ModelName, cfg.ModelUUID)),
+	}
+	if cfg.ModelUUID == "" {
+		// No model is known when pinging a new backend.
+		return backend, nil
+	}
+	if backend.key, err = modelKey(validCfg, cfg.ModelUUID); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return backend, nil
+}
+
+// modelKey returns the content key for the specified model,
+// derived from the backend's encryption key.
+func modelKey(cfg *backendConfig, modelUUID string) (*[keySize]byte, error) {
+	if cfg.key == "" {
+		return nil, errors.NotValidf("file config missing encryption key")
+	}
+	key, err := decodeKey(cfg.key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	mac := hmac.New(sha256.New, key[:])
+	_, _ = mac.Write([]byte(modelUUID))
+	var result [keySize]byte
+	copy(result[:], mac.Sum(nil))
+	return &result, nil
+}
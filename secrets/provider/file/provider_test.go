@@ -0,0 +1,201 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/secrets/provider/file"
+	coretesting "github.com/juju/juju/testing"
+)
+
+// testKey is a base64 encoded 32 byte key.
+const testKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+type providerSuite struct {
+	testing.IsolationSuite
+
+	dir      string
+	p        provider.SecretBackendProvider
+	adminCfg *provider.ModelBackendConfig
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+
+	var err error
+	s.p, err = provider.Provider(file.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.adminCfg = &provider.ModelBackendConfig{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ModelName:      "fred",
+		BackendConfig: provider.BackendConfig{
+			BackendType: file.BackendType,
+			Config: map[string]interface{}{
+				"path":           s.dir,
+				"encryption-key": testKey,
+			},
+		},
+	}
+}
+
+func (s *providerSuite) modelDir() string {
+	return filepath.Join(s.dir, "fred-06f00d")
+}
+
+func (s *providerSuite) TestInitialise(c *gc.C) {
+	err := s.p.Initialise(s.adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := os.Stat(s.modelDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.IsDir(), jc.IsTrue)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0700))
+}
+
+func (s *providerSuite) TestSaveGetDeleteContent(c *gc.C) {
+	err := s.p.Initialise(s.adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	b, err := s.p.NewBackend(s.adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uri := coresecrets.NewURI()
+	ctx := context.Background()
+	revisionId, err := b.SaveContent(ctx, uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisionId, gc.Equals, uri.ID+"-1")
+
+	// The content is not stored in the clear.
+	data, err := os.ReadFile(filepath.Join(s.modelDir(), revisionId))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.Contains(string(data), "YmFy"), jc.IsFalse)
+
+	val, err := b.GetContent(ctx, revisionId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(val.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+
+	err = b.DeleteContent(ctx, revisionId)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = b.GetContent(ctx, revisionId)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = b.DeleteContent(ctx, revisionId)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *providerSuite) TestGetContentInvalidRevision(c *gc.C) {
+	b, err := s.p.NewBackend(s.adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = b.GetContent(context.Background(), "../escape")
+	c.Assert(err, gc.ErrorMatches, `secret revision id "../escape" not valid`)
+}
+
+func (s *providerSuite) TestServedByController(c *gc.C) {
+	c.Assert(provider.IsServedByController(s.p), jc.IsTrue)
+}
+
+func (s *providerSuite) TestRestrictedConfig(c *gc.C) {
+	owned := provider.SecretRevisions{}
+	owned.Add(coresecrets.NewURI(), "rev-1")
+	cfg, err := s.p.RestrictedConfig(s.adminCfg, true, false, names.NewUnitTag("mariadb/0"), owned, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, &provider.BackendConfig{BackendType: file.BackendType})
+}
+
+func (s *providerSuite) TestAgentBackend(c *gc.C) {
+	err := s.p.Initialise(s.adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	admin, err := s.p.NewBackend(s.adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	uri := coresecrets.NewURI()
+	ctx := context.Background()
+	revisionId, err := admin.SaveContent(ctx, uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.p.RestrictedConfig(s.adminCfg, true, false, names.NewUnitTag("mariadb/0"), nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	agent, err := s.p.NewBackend(&provider.ModelBackendConfig{
+		ControllerUUID: s.adminCfg.ControllerUUID,
+		ModelUUID:      s.adminCfg.ModelUUID,
+		ModelName:      s.adminCfg.ModelName,
+		BackendConfig:  *cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Agents read and save content via the controller.
+	_, err = agent.GetContent(ctx, revisionId)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = agent.SaveContent(ctx, uri, 2, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	// Content is left for the controller to delete.
+	err = agent.DeleteContent(ctx, revisionId)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = admin.GetContent(ctx, revisionId)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestModelKeysDiffer(c *gc.C) {
+	err := s.p.Initialise(s.adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	b, err := s.p.NewBackend(s.adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	uri := coresecrets.NewURI()
+	ctx := context.Background()
+	revisionId, err := b.SaveContent(ctx, uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A config for another model with the same name prefix cannot
+	// decrypt the content.
+	otherCfg := *s.adminCfg
+	otherCfg.ModelUUID = "c0ffee00-0bad-400d-8000-4b1d0d06f00d"
+	other, err := s.p.NewBackend(&otherCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = other.GetContent(ctx, revisionId)
+	c.Assert(err, gc.ErrorMatches, `cannot decrypt secret ".*"`)
+}
+
+func (s *providerSuite) TestCleanupModel(c *gc.C) {
+	err := s.p.Initialise(s.adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.p.CleanupModel(s.adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(s.modelDir())
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *providerSuite) TestPing(c *gc.C) {
+	b, err := s.p.NewBackend(&provider.ModelBackendConfig{
+		BackendConfig: s.adminCfg.BackendConfig,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(b.Ping(), jc.ErrorIsNil)
+
+	b, err = s.p.NewBackend(&provider.ModelBackendConfig{
+		BackendConfig: provider.BackendConfig{
+			BackendType: file.BackendType,
+			Config: map[string]interface{}{
+				"path":           filepath.Join(s.dir, "missing"),
+				"encryption-key": testKey,
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(b.Ping(), gc.ErrorMatches, "backend not reachable: .*")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/secrets"
 )
 
@@ -100,3 +101,30 @@ func HasAuthRefresh(p SecretBackendProvider) bool {
 	_, ok := p.(SupportAuthRefresh)
 	return ok
 }
+
+// ControllerSecretConfig is implemented by providers whose backends
+// need config, such as encryption keys, held in controller config.
+// The config is only added to the admin config used by the controller;
+// it is never shown to users or handed to agents.
+type ControllerSecretConfig interface {
+	// ControllerSecretConfig returns the config to add to the admin
+	// config of the provider's backends. An error satisfying
+	// errors.NotValid is returned if the controller config is missing
+	// what the backends need.
+	ControllerSecretConfig(controllerCfg controller.Config) (ConfigAttrs, error)
+}
+
+// ControllerServed is implemented by providers whose backends can only
+// be reached by the controller. Agents send and receive the content of
+// such backends via the controller API rather than accessing the
+// backend themselves.
+type ControllerServed interface {
+	ServedByController()
+}
+
+// IsServedByController returns true if the content of backends created
+// by the provider is only accessible via the controller.
+func IsServedByController(p SecretBackendProvider) bool {
+	_, ok := p.(ControllerServed)
+	return ok
+}
@@ -2,4 +2,8 @@
 // Licensed under the AGPLv3, see LICENCE file for details.
 
 // Package vault provides the vault secrets backend.
+//
+// Secret content is stored in a KV version 1 secrets engine, mounted
+// per model, so the backend works with any server implementing the
+// HashiCorp Vault KV version 1 API.
 package vault
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"time"
+
 	"github.com/juju/mgo/v3"
 
 	"github.com/juju/juju/state/bakerystorage"
@@ -115,6 +117,10 @@ func allCollections() CollectionSchema {
 		// migration minions.
 		migrationsMinionSyncC: {global: true},
 
+		// This collection holds plans for migrating many models to
+		// another controller, and their progress.
+		migrationPlansC: {global: true},
+
 		// This collection holds user information that's not specific to any
 		// one model.
 		usersC: {
@@ -126,6 +132,35 @@ func allCollections() CollectionSchema {
 			global: true,
 		},
 
+		// This collection holds the groups of users that access can be
+		// granted to.
+		userGroupsC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"members"},
+			}},
+		},
+
+		// This collection holds the audit log entries written by the
+		// database audit log sink of every controller. Entries are
+		// removed by mongo once they pass their expiry time.
+		auditLogC: {
+			global:    true,
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"-when"},
+			}, {
+				Key: []string{"who", "-when"},
+			}, {
+				Key: []string{"model-uuid", "-when"},
+			}, {
+				Key: []string{"facade", "method", "-when"},
+			}, {
+				Key:         []string{"expire-at"},
+				ExpireAfter: time.Second,
+			}},
+		},
+
 		// This collection holds the last time the user connected to the API server.
 		userLastLoginC: {
 			global:    true,
@@ -364,6 +399,12 @@ func allCollections() CollectionSchema {
 				Key: []string{"model-uuid", "owner"},
 			}},
 		},
+		storageSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "storageid"},
+			}},
+		},
+		storageUsageC: {},
 		storageAttachmentsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "storageid"},
@@ -703,6 +744,7 @@ const (
 	minUnitsC                  = "minunits"
 	migrationsActiveC          = "migrations.active"
 	migrationsC                = "migrations"
+	migrationPlansC            = "migrationPlans"
 	migrationsMinionSyncC      = "migrations.minionsync"
 	migrationsStatusC          = "migrations.status"
 	modelUserLastConnectionC   = "modelUserLastConnection"
@@ -733,6 +775,8 @@ const (
 	storageConstraintsC        = "storageconstraints"
 	deviceConstraintsC         = "deviceConstraints"
 	storageInstancesC          = "storageinstances"
+	storageSnapshotsC          = "storagesnapshots"
+	storageUsageC              = "storageusage"
 	subnetsC                   = "subnets"
 	linkLayerDevicesC          = "linklayerdevices"
 	ipAddressesC               = "ip.addresses"
@@ -741,6 +785,8 @@ const (
 	unitsC                     = "units"
 	unitStatesC                = "unitstates"
 	upgradeInfoC               = "upgradeInfo"
+	userGroupsC                = "usergroups"
+	auditLogC                  = "auditlog"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
 	usersC                     = "users"
@@ -4,7 +4,9 @@
 package state
 
 import (
+	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/juju/charm/v12"
@@ -108,6 +110,19 @@ func makeAllWatcherCollectionInfo(collNames []string) map[string]allWatcherState
 		case podSpecsC:
 			collection.docType = reflect.TypeOf(backingPodSpec{})
 			collection.subsidiary = true
+		case offerConnectionsC:
+			collection.docType = reflect.TypeOf(backingOfferConnection{})
+			collection.subsidiary = true
+		case storageInstancesC:
+			collection.docType = reflect.TypeOf(backingStorageInstance{})
+		case storageAttachmentsC:
+			collection.docType = reflect.TypeOf(backingStorageAttachment{})
+			collection.subsidiary = true
+		case secretMetadataC:
+			collection.docType = reflect.TypeOf(backingSecret{})
+		case secretConsumersC:
+			collection.docType = reflect.TypeOf(backingSecretConsumer{})
+			collection.subsidiary = true
 		default:
 			allWatcherLogger.Criticalf("programming error: unknown collection %q", collName)
 		}
@@ -984,6 +999,34 @@ func (b *backingApplicationOffer) mongoID() string {
 	return b.OfferName
 }
 
+type backingOfferConnection offerConnectionDoc
+
+func (b *backingOfferConnection) updated(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`offer connection "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	return errors.Trace(ctx.updateOfferConnections(b.OfferUUID))
+}
+
+func (b *backingOfferConnection) removed(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`offer connection "%s:%s" removed`, ctx.modelUUID, ctx.id)
+	// The removed document can't be read to find the offer it was for,
+	// so refresh all the offers in the model.
+	for _, info := range ctx.store.All() {
+		offerInfo, ok := info.(*multiwatcher.ApplicationOfferInfo)
+		if !ok || offerInfo.ModelUUID != ctx.modelUUID {
+			continue
+		}
+		if err := ctx.updateOfferConnections(offerInfo.OfferUUID); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (b *backingOfferConnection) mongoID() string {
+	allWatcherLogger.Criticalf("programming error: attempting to get mongoID from offer connection document")
+	return ""
+}
+
 type backingAction actionDoc
 
 func (a *backingAction) mongoID() string {
@@ -1038,6 +1081,19 @@ func (r *backingRelation) updated(ctx *allWatcherContext) error {
 		ID:        r.Id,
 		Endpoints: eps,
 	}
+	oldInfo := ctx.store.Get(info.EntityID())
+	if oldInfo == nil {
+		// We're adding the entry for the first time,
+		// so fetch the associated relation status.
+		relationStatus, err := ctx.getStatus(relationGlobalScope(r.Id), "relation")
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "retrieve status for relation %q", r.Key)
+		}
+		info.Status = relationStatus
+	} else {
+		// The entry already exists, so preserve the current status.
+		info.Status = oldInfo.(*multiwatcher.RelationInfo).Status
+	}
 	ctx.store.Update(info)
 	return nil
 }
@@ -1233,6 +1289,13 @@ func (s *backingStatus) updated(ctx *allWatcherContext) error {
 		newInfo := *info
 		newInfo.Status = s.toStatusInfo()
 		info0 = &newInfo
+	case *multiwatcher.RelationInfo:
+		newInfo := *info
+		newInfo.Status = s.toStatusInfo()
+		ctx.store.Update(&newInfo)
+		// The active connection count of an offer depends on the
+		// status of the relations to it.
+		return errors.Trace(ctx.updateOfferConnectionsForRelation(info.Key))
 	case *multiwatcher.MachineInfo:
 		newInfo := *info
 		switch suffix {
@@ -1586,6 +1649,137 @@ func (g *backingGeneration) mongoID() string {
 	return id
 }
 
+type backingStorageInstance storageInstanceDoc
+
+func (s *backingStorageInstance) updated(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`storage instance "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	info := &multiwatcher.StorageInstanceInfo{
+		ModelUUID:   s.ModelUUID,
+		ID:          s.Id,
+		Kind:        s.Kind.String(),
+		Owner:       s.Owner,
+		StorageName: s.StorageName,
+		Pool:        s.Constraints.Pool,
+		Life:        life.Value(s.Life.String()),
+	}
+	// The attachment count on the storage instance changes whenever an
+	// attachment is added or removed, so it is safe to read the
+	// attachments each time.
+	attachments, err := ctx.getStorageAttachments(s.Id)
+	if err != nil {
+		return errors.Annotatef(err, "retrieve attachments for storage %q", s.Id)
+	}
+	info.Attachments = attachments
+	ctx.store.Update(info)
+	return nil
+}
+
+func (s *backingStorageInstance) removed(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`storage instance "%s:%s" removed`, ctx.modelUUID, ctx.id)
+	ctx.removeFromStore(multiwatcher.StorageInstanceKind)
+	return nil
+}
+
+func (s *backingStorageInstance) mongoID() string {
+	return s.Id
+}
+
+type backingStorageAttachment storageAttachmentDoc
+
+func (s *backingStorageAttachment) updated(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`storage attachment "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	return errors.Trace(ctx.updateStorageAttachments(s.StorageInstance))
+}
+
+func (s *backingStorageAttachment) removed(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`storage attachment "%s:%s" removed`, ctx.modelUUID, ctx.id)
+	// The storage attachment id is the unit global key followed
+	// by the storage instance id.
+	parts := strings.SplitN(ctx.id, "#", 3)
+	if len(parts) != 3 {
+		return errors.Errorf("could not parse storage attachment id: %q", ctx.id)
+	}
+	return errors.Trace(ctx.updateStorageAttachments(parts[2]))
+}
+
+func (s *backingStorageAttachment) mongoID() string {
+	allWatcherLogger.Criticalf("programming error: attempting to get mongoID from storage attachment document")
+	return ""
+}
+
+type backingSecret secretMetadataDoc
+
+func (s *backingSecret) updated(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`secret "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	info := &multiwatcher.SecretInfo{
+		ModelUUID:      ctx.modelUUID, // ModelUUID not on secretMetadataDoc
+		ID:             ctx.id,
+		Owner:          s.OwnerTag,
+		Label:          s.Label,
+		Description:    s.Description,
+		LatestRevision: s.LatestRevision,
+		RotatePolicy:   s.RotatePolicy,
+		UpdateTime:     s.UpdateTime,
+	}
+	oldInfo := ctx.store.Get(info.EntityID())
+	if oldInfo == nil {
+		// We're adding the entry for the first time,
+		// so fetch the consumers of the secret.
+		consumers, err := ctx.getSecretConsumers(ctx.id)
+		if err != nil {
+			return errors.Annotatef(err, "retrieve consumers for secret %q", ctx.id)
+		}
+		info.Consumers = consumers
+	} else {
+		// The entry already exists, so preserve the current consumers.
+		info.Consumers = oldInfo.(*multiwatcher.SecretInfo).Consumers
+	}
+	ctx.store.Update(info)
+	return nil
+}
+
+func (s *backingSecret) removed(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`secret "%s:%s" removed`, ctx.modelUUID, ctx.id)
+	ctx.removeFromStore(multiwatcher.SecretKind)
+	return nil
+}
+
+func (s *backingSecret) mongoID() string {
+	_, id, ok := splitDocID(s.DocID)
+	if !ok {
+		allWatcherLogger.Criticalf("secret ID not valid: %v", s.DocID)
+	}
+	return id
+}
+
+type backingSecretConsumer secretConsumerDoc
+
+func (s *backingSecretConsumer) updated(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`secret consumer "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	return errors.Trace(s.updateSecret(ctx))
+}
+
+func (s *backingSecretConsumer) removed(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`secret consumer "%s:%s" removed`, ctx.modelUUID, ctx.id)
+	return errors.Trace(s.updateSecret(ctx))
+}
+
+func (s *backingSecretConsumer) updateSecret(ctx *allWatcherContext) error {
+	// Consumers of local secrets are keyed on the secret id followed
+	// by the consumer tag. Consumers of secrets owned by other models
+	// use the secret URI instead, and those secrets aren't tracked.
+	secretID, _, ok := strings.Cut(ctx.id, "#")
+	if !ok || strings.Contains(secretID, "/") {
+		return nil
+	}
+	return errors.Trace(ctx.updateSecretConsumers(secretID))
+}
+
+func (s *backingSecretConsumer) mongoID() string {
+	allWatcherLogger.Criticalf("programming error: attempting to get mongoID from secret consumer document")
+	return ""
+}
+
 // backingEntityDoc is implemented by the documents in
 // collections that the allWatcherStateBacking watches.
 type backingEntityDoc interface {
@@ -1654,6 +1848,11 @@ func NewAllWatcherBacking(pool *StatePool) (AllWatcherBacking, error) {
 		remoteApplicationsC,
 		statusesC,
 		settingsC,
+		offerConnectionsC,
+		storageInstancesC,
+		storageAttachmentsC,
+		secretMetadataC,
+		secretConsumersC,
 		// And for CAAS we need to watch these...
 		podSpecsC,
 	}
@@ -2261,12 +2460,153 @@ func (ctx *allWatcherContext) entityIDForGlobalKey(key string) (multiwatcher.Ent
 			ModelUUID: ctx.modelUUID,
 			Name:      id,
 		}
+	case "r":
+		// Relations are tracked by their key, but their global
+		// key is based on the relation id.
+		relationID, err := strconv.Atoi(id)
+		if err != nil {
+			return multiwatcher.EntityID{}, "", false
+		}
+		relation, err := ctx.state.Relation(relationID)
+		if err != nil {
+			return multiwatcher.EntityID{}, "", false
+		}
+		result = &multiwatcher.RelationInfo{
+			ModelUUID: ctx.modelUUID,
+			Key:       relation.String(),
+		}
 	default:
 		return multiwatcher.EntityID{}, "", false
 	}
 	return result.EntityID(), suffix, true
 }
 
+// getStorageAttachments returns the life of each attachment of the
+// storage instance with the given id, keyed on the attached unit.
+func (ctx *allWatcherContext) getStorageAttachments(storageID string) (map[string]life.Value, error) {
+	col, closer := ctx.state.db().GetCollection(storageAttachmentsC)
+	defer closer()
+
+	var docs []storageAttachmentDoc
+	if err := col.Find(bson.D{{"storageid", storageID}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot read attachments of storage %q", storageID)
+	}
+	attachments := make(map[string]life.Value, len(docs))
+	for _, doc := range docs {
+		attachments[doc.Unit] = life.Value(doc.Life.String())
+	}
+	return attachments, nil
+}
+
+// updateStorageAttachments refreshes the attachments of the storage
+// instance with the given id, if the storage instance is in the store.
+func (ctx *allWatcherContext) updateStorageAttachments(storageID string) error {
+	info0 := ctx.store.Get(multiwatcher.EntityID{
+		Kind:      multiwatcher.StorageInstanceKind,
+		ModelUUID: ctx.modelUUID,
+		ID:        storageID,
+	})
+	info, ok := info0.(*multiwatcher.StorageInstanceInfo)
+	if !ok {
+		// The storage instance info doesn't exist yet; it will pick
+		// up the attachments when it does.
+		return nil
+	}
+	attachments, err := ctx.getStorageAttachments(storageID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newInfo := *info
+	newInfo.Attachments = attachments
+	ctx.store.Update(&newInfo)
+	return nil
+}
+
+// getSecretConsumers returns the revision of the secret with the given
+// id that each consumer in the model is using, keyed on the consumer tag.
+func (ctx *allWatcherContext) getSecretConsumers(secretID string) (map[string]int, error) {
+	col, closer := ctx.state.db().GetCollection(secretConsumersC)
+	defer closer()
+
+	var docs []secretConsumerDoc
+	query := bson.D{{"_id", bson.D{{"$regex", fmt.Sprintf("^%s:%s#", ctx.modelUUID, secretID)}}}}
+	if err := col.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot read consumers of secret %q", secretID)
+	}
+	consumers := make(map[string]int, len(docs))
+	for _, doc := range docs {
+		consumers[doc.ConsumerTag] = doc.CurrentRevision
+	}
+	return consumers, nil
+}
+
+// updateSecretConsumers refreshes the consumers of the secret with the
+// given id, if the secret is in the store.
+func (ctx *allWatcherContext) updateSecretConsumers(secretID string) error {
+	info0 := ctx.store.Get(multiwatcher.EntityID{
+		Kind:      multiwatcher.SecretKind,
+		ModelUUID: ctx.modelUUID,
+		ID:        secretID,
+	})
+	info, ok := info0.(*multiwatcher.SecretInfo)
+	if !ok {
+		// The secret info doesn't exist yet; it will pick up
+		// the consumers when it does.
+		return nil
+	}
+	consumers, err := ctx.getSecretConsumers(secretID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newInfo := *info
+	newInfo.Consumers = consumers
+	ctx.store.Update(&newInfo)
+	return nil
+}
+
+// updateOfferConnections refreshes the connection counts of the offer
+// with the given UUID, if the offer is in the store.
+func (ctx *allWatcherContext) updateOfferConnections(offerUUID string) error {
+	offer, err := NewApplicationOffers(ctx.state).ApplicationOfferForUUID(offerUUID)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	info0 := ctx.store.Get(multiwatcher.EntityID{
+		Kind:      multiwatcher.ApplicationOfferKind,
+		ModelUUID: ctx.modelUUID,
+		ID:        offer.OfferName,
+	})
+	info, ok := info0.(*multiwatcher.ApplicationOfferInfo)
+	if !ok {
+		// The offer info doesn't exist yet; it will pick up the
+		// connections when it does.
+		return nil
+	}
+	remoteConnection, err := ctx.state.RemoteConnectionStatus(offerUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newInfo := *info
+	newInfo.TotalConnectedCount = remoteConnection.TotalConnectionCount()
+	newInfo.ActiveConnectedCount = remoteConnection.ActiveConnectionCount()
+	ctx.store.Update(&newInfo)
+	return nil
+}
+
+// updateOfferConnectionsForRelation refreshes the connection counts of
+// the offer connected to by the relation with the given key, if any.
+func (ctx *allWatcherContext) updateOfferConnectionsForRelation(relationKey string) error {
+	conn, err := ctx.state.OfferConnectionForRelation(relationKey)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(ctx.updateOfferConnections(conn.OfferUUID()))
+}
+
 func (ctx *allWatcherContext) modelType() (ModelType, error) {
 	if ctx.modelType_ != modelTypeNone {
 		return ctx.modelType_, nil
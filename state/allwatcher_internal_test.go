@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/core/network"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/testing"
@@ -257,6 +258,11 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "logging", Relation: multiwatcher.CharmRelation{Name: "logging-directory", Role: "requirer", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}},
 			{ApplicationName: "wordpress", Relation: multiwatcher.CharmRelation{Name: "logging-dir", Role: "provider", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}}},
+		Status: multiwatcher.StatusInfo{
+			Current: status.Joining,
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 	})
 
 	for i := 0; i < units; i++ {
@@ -432,6 +438,11 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "mysql", Relation: multiwatcher.CharmRelation{Name: "server", Role: "provider", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}},
 			{ApplicationName: "remote-wordpress2", Relation: multiwatcher.CharmRelation{Name: "db", Role: "requirer", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}}},
+		Status: multiwatcher.StatusInfo{
+			Current: status.Joining,
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 	})
 
 	applicationOfferInfo, rel2 := addTestingApplicationOffer(
@@ -444,6 +455,11 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "mysql", Relation: multiwatcher.CharmRelation{Name: "server", Role: "provider", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}},
 			{ApplicationName: "remote-wordpress", Relation: multiwatcher.CharmRelation{Name: "db", Role: "requirer", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}}},
+		Status: multiwatcher.StatusInfo{
+			Current: status.Joining,
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 	})
 	add(&applicationOfferInfo)
 
@@ -921,6 +937,14 @@ func (s *allWatcherStateSuite) TestChangeApplicationOffers(c *gc.C) {
 	testChangeApplicationOffers(c, s.performChangeTestCases)
 }
 
+func (s *allWatcherStateSuite) TestChangeStorageInstances(c *gc.C) {
+	testChangeStorageInstances(c, s.performChangeTestCases)
+}
+
+func (s *allWatcherStateSuite) TestChangeSecrets(c *gc.C) {
+	testChangeSecrets(c, s.performChangeTestCases)
+}
+
 func (s *allWatcherStateSuite) TestChangeGenerations(c *gc.C) {
 	testChangeGenerations(c, s.performChangeTestCases)
 }
@@ -2066,6 +2090,7 @@ func testChangeRelations(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C
 			c.Assert(err, jc.ErrorIsNil)
 			_, err = st.AddRelation(eps...)
 			c.Assert(err, jc.ErrorIsNil)
+			now := st.clock().Now()
 
 			return changeTestCase{
 				about: "relation is added if it's in backing but not in Store",
@@ -2080,6 +2105,51 @@ func testChangeRelations(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C
 						Endpoints: []multiwatcher.Endpoint{
 							{ApplicationName: "logging", Relation: multiwatcher.CharmRelation{Name: "logging-directory", Role: "requirer", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}},
 							{ApplicationName: "wordpress", Relation: multiwatcher.CharmRelation{Name: "logging-dir", Role: "provider", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}}},
+						Status: multiwatcher.StatusInfo{
+							Current: status.Joining,
+							Data:    map[string]interface{}{},
+							Since:   &now,
+						},
+					}}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			AddTestingApplication(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"))
+			AddTestingApplication(c, st, "logging", AddTestingCharm(c, st, "logging"))
+			eps, err := st.InferEndpoints("logging", "wordpress")
+			c.Assert(err, jc.ErrorIsNil)
+			rel, err := st.AddRelation(eps...)
+			c.Assert(err, jc.ErrorIsNil)
+			now := st.clock().Now()
+			err = rel.SetStatus(status.StatusInfo{
+				Status: status.Joined,
+				Since:  &now,
+			})
+			c.Assert(err, jc.ErrorIsNil)
+
+			return changeTestCase{
+				about: "relation status is updated if the relation is in the store",
+				initialContents: []multiwatcher.EntityInfo{&multiwatcher.RelationInfo{
+					ModelUUID: st.ModelUUID(),
+					Key:       "logging:logging-directory wordpress:logging-dir",
+					ID:        rel.Id(),
+					Status: multiwatcher.StatusInfo{
+						Current: status.Joining,
+					},
+				}},
+				change: watcher.Change{
+					C:  "statuses",
+					Id: st.docID(fmt.Sprintf("r#%d", rel.Id())),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.RelationInfo{
+						ModelUUID: st.ModelUUID(),
+						Key:       "logging:logging-directory wordpress:logging-dir",
+						ID:        rel.Id(),
+						Status: multiwatcher.StatusInfo{
+							Current: status.Joined,
+							Data:    map[string]interface{}{},
+							Since:   &now,
+						},
 					}}}
 		},
 	}
@@ -3599,6 +3669,232 @@ func testChangeApplicationOffers(c *gc.C, runChangeTests func(*gc.C, []changeTes
 				expectContents: []multiwatcher.EntityInfo{&applicationOfferInfo},
 			}
 		},
+		func(c *gc.C, st *State) changeTestCase {
+			applicationOfferInfo, _ := addOffer(c, st)
+			initialApplicationOfferInfo := applicationOfferInfo
+			addTestingRemoteApplication(
+				c, st, "remote-wordpress2", "", []charm.Relation{{
+					Name:      "db",
+					Role:      "requirer",
+					Scope:     charm.ScopeGlobal,
+					Interface: "mysql",
+				}}, true,
+			)
+			eps, err := st.InferEndpoints("mysql", "remote-wordpress2")
+			c.Assert(err, jc.ErrorIsNil)
+			rel, err := st.AddRelation(eps...)
+			c.Assert(err, jc.ErrorIsNil)
+			_, err = st.AddOfferConnection(AddOfferConnectionParams{
+				SourceModelUUID: utils.MustNewUUID().String(),
+				RelationId:      rel.Id(),
+				RelationKey:     rel.Tag().Id(),
+				Username:        "fred",
+				OfferUUID:       initialApplicationOfferInfo.OfferUUID,
+			})
+			c.Assert(err, jc.ErrorIsNil)
+
+			applicationOfferInfo.TotalConnectedCount = 2
+			return changeTestCase{
+				about:           "application offer count is updated when an offer connection is added",
+				initialContents: []multiwatcher.EntityInfo{&initialApplicationOfferInfo},
+				change: watcher.Change{
+					C:  "applicationOfferConnections",
+					Id: st.docID(fmt.Sprint(rel.Id())),
+				},
+				expectContents: []multiwatcher.EntityInfo{&applicationOfferInfo},
+			}
+		},
+	}
+	runChangeTests(c, changeTestFuncs)
+}
+
+func testChangeStorageInstances(c *gc.C, runChangeTests func(*gc.C, []changeTestFunc)) {
+	addStorage := func(c *gc.C, st *State) {
+		ch := AddTestingCharm(c, st, "storage-block")
+		app := AddTestingApplicationWithStorage(c, st, "storage-block", ch, map[string]StorageConstraints{
+			"data": {Pool: "loop", Size: 1024, Count: 1},
+		})
+		_, err := app.AddUnit(AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	changeTestFuncs := []changeTestFunc{
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "no storage instance in state, no storage instance in store -> do nothing",
+				change: watcher.Change{
+					C:  "storageinstances",
+					Id: st.docID("data/0"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "storage instance is removed if it's not in backing",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.StorageInstanceInfo{
+						ModelUUID: st.ModelUUID(),
+						ID:        "data/0",
+					},
+				},
+				change: watcher.Change{
+					C:  "storageinstances",
+					Id: st.docID("data/0"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			addStorage(c, st)
+			return changeTestCase{
+				about: "storage instance is added if it's in backing but not in Store",
+				change: watcher.Change{
+					C:  "storageinstances",
+					Id: st.docID("data/0"),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.StorageInstanceInfo{
+						ModelUUID:   st.ModelUUID(),
+						ID:          "data/0",
+						Kind:        "block",
+						Owner:       "unit-storage-block-0",
+						StorageName: "data",
+						Pool:        "loop",
+						Life:        life.Alive,
+						Attachments: map[string]life.Value{"storage-block/0": life.Alive},
+					},
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			addStorage(c, st)
+			return changeTestCase{
+				about: "storage attachments are updated if the storage instance is in the store",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.StorageInstanceInfo{
+						ModelUUID: st.ModelUUID(),
+						ID:        "data/0",
+					},
+				},
+				change: watcher.Change{
+					C:  "storageattachments",
+					Id: st.docID("u#storage-block/0#data/0"),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.StorageInstanceInfo{
+						ModelUUID:   st.ModelUUID(),
+						ID:          "data/0",
+						Attachments: map[string]life.Value{"storage-block/0": life.Alive},
+					},
+				}}
+		},
+	}
+	runChangeTests(c, changeTestFuncs)
+}
+
+// allWatcherLeaderToken is a leadership token that is always valid.
+type allWatcherLeaderToken struct{}
+
+// Check implements leadership.Token.
+func (allWatcherLeaderToken) Check() error {
+	return nil
+}
+
+func testChangeSecrets(c *gc.C, runChangeTests func(*gc.C, []changeTestFunc)) {
+	addSecret := func(c *gc.C, st *State) (*secrets.SecretMetadata, names.Tag) {
+		app := AddTestingApplication(c, st, "mysql", AddTestingCharm(c, st, "mysql"))
+		rotatePolicy := secrets.RotateDaily
+		description := "my secret"
+		label := "foobar"
+		md, err := NewSecrets(st).CreateSecret(secrets.NewURI(), CreateSecretParams{
+			Version: 1,
+			Owner:   app.Tag(),
+			UpdateSecretParams: UpdateSecretParams{
+				LeaderToken:  allWatcherLeaderToken{},
+				RotatePolicy: &rotatePolicy,
+				Description:  &description,
+				Label:        &label,
+				Data:         map[string]string{"foo": "bar"},
+			},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		consumer := AddTestingApplication(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"))
+		return md, consumer.Tag()
+	}
+
+	changeTestFuncs := []changeTestFunc{
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "no secret in state, no secret in store -> do nothing",
+				change: watcher.Change{
+					C:  "secretMetadata",
+					Id: st.docID("cj4v5vm78ohs79o84r4g"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "secret is removed if it's not in backing",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.SecretInfo{
+						ModelUUID: st.ModelUUID(),
+						ID:        "cj4v5vm78ohs79o84r4g",
+					},
+				},
+				change: watcher.Change{
+					C:  "secretMetadata",
+					Id: st.docID("cj4v5vm78ohs79o84r4g"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			md, consumer := addSecret(c, st)
+			err := st.SaveSecretConsumer(md.URI, consumer, &secrets.SecretConsumerMetadata{
+				CurrentRevision: 1,
+				LatestRevision:  1,
+			})
+			c.Assert(err, jc.ErrorIsNil)
+			return changeTestCase{
+				about: "secret is added if it's in backing but not in Store",
+				change: watcher.Change{
+					C:  "secretMetadata",
+					Id: st.docID(md.URI.ID),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.SecretInfo{
+						ModelUUID:      st.ModelUUID(),
+						ID:             md.URI.ID,
+						Owner:          "application-mysql",
+						Label:          "foobar",
+						Description:    "my secret",
+						LatestRevision: 1,
+						RotatePolicy:   "daily",
+						UpdateTime:     md.UpdateTime,
+						Consumers:      map[string]int{"application-wordpress": 1},
+					},
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			md, consumer := addSecret(c, st)
+			err := st.SaveSecretConsumer(md.URI, consumer, &secrets.SecretConsumerMetadata{
+				CurrentRevision: 1,
+				LatestRevision:  1,
+			})
+			c.Assert(err, jc.ErrorIsNil)
+			return changeTestCase{
+				about: "secret consumers are updated if the secret is in the store",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.SecretInfo{
+						ModelUUID: st.ModelUUID(),
+						ID:        md.URI.ID,
+					},
+				},
+				change: watcher.Change{
+					C:  "secretConsumers",
+					Id: st.docID(md.URI.ID + "#" + consumer.String()),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.SecretInfo{
+						ModelUUID: st.ModelUUID(),
+						ID:        md.URI.ID,
+						Consumers: map[string]int{"application-wordpress": 1},
+					},
+				}}
+		},
 	}
 	runChangeTests(c, changeTestFuncs)
 }
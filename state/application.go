@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/core/status"
 	mgoutils "github.com/juju/juju/mongo/utils"
 	stateerrors "github.com/juju/juju/state/errors"
@@ -1288,6 +1289,7 @@ func (a *Application) IsSidecar() (bool, error) {
 func (a *Application) changeCharmOps(
 	ch *Charm,
 	updatedSettings charm.Settings,
+	configDelta settings.ItemChanges,
 	forceUnits bool,
 	updatedStorageConstraints map[string]StorageConstraints,
 ) ([]txn.Op, error) {
@@ -1295,7 +1297,9 @@ func (a *Application) changeCharmOps(
 	var newSettings charm.Settings
 	oldKey, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
 	if err == nil {
-		// Filter the old settings through to get the new settings.
+		// Apply any pending changes to the old settings, then filter
+		// them through to get the new settings.
+		oldKey.applyChanges(configDelta)
 		newSettings = ch.Config().FilterSettings(oldKey.Map())
 		for k, v := range updatedSettings {
 			newSettings[k] = v
@@ -1737,7 +1741,6 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		return errors.Annotate(err, "validating config settings")
 	}
 
-	var newCharmModifiedVersion int
 	acopy := &Application{a.st, a.doc}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		a := acopy
@@ -1746,128 +1749,137 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 				return nil, errors.Trace(err)
 			}
 		}
+		return a.setCharmOps(cfg, updatedSettings, nil)
+	}
 
-		// NOTE: We're explicitly allowing SetCharm to succeed
-		// when the application is Dying, because application/charm
-		// upgrades should still be allowed to apply to dying
-		// applications and units, so that bugs in departed/broken
-		// hooks can be addressed at runtime.
-		if a.Life() == Dead {
-			return nil, stateerrors.ErrDead
-		}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return err
+	}
+	return a.Refresh()
+}
 
-		// Record the current value of charmModifiedVersion, so we can
-		// set the value on the method receiver's in-memory document
-		// structure. We increment the version only when we change the
-		// charm URL.
-		newCharmModifiedVersion = a.doc.CharmModifiedVersion
+// setCharmOps returns the operations to upgrade the application as
+// described by cfg, with the validated config settings. Any configDelta
+// is applied to the application's current settings before they are
+// carried over to a new charm.
+func (a *Application) setCharmOps(
+	cfg SetCharmConfig, updatedSettings charm.Settings, configDelta settings.ItemChanges,
+) ([]txn.Op, error) {
+	// NOTE: We're explicitly allowing SetCharm to succeed
+	// when the application is Dying, because application/charm
+	// upgrades should still be allowed to apply to dying
+	// applications and units, so that bugs in departed/broken
+	// hooks can be addressed at runtime.
+	if a.Life() == Dead {
+		return nil, stateerrors.ErrDead
+	}
 
-		ops := []txn.Op{{
-			C:  applicationsC,
-			Id: a.doc.DocID,
-			Assert: append(notDeadDoc, bson.DocElem{
-				"charmmodifiedversion", a.doc.CharmModifiedVersion,
-			}),
-		}}
+	// Record the current value of charmModifiedVersion, so we can
+	// tell whether the charm upgrade has already incremented it.
+	// We increment the version only when we change the charm URL.
+	newCharmModifiedVersion := a.doc.CharmModifiedVersion
 
-		if *a.doc.CharmURL == cfg.Charm.URL() {
-			updates := bson.D{
-				{"forcecharm", cfg.ForceUnits},
-			}
-			// Charm URL already set; just update the force flag.
-			ops = append(ops, txn.Op{
-				C:      applicationsC,
-				Id:     a.doc.DocID,
-				Assert: txn.DocExists,
-				Update: bson.D{{"$set", updates}},
-			})
-		} else {
-			// Check if the new charm specifies a relation max limit
-			// that cannot be satisfied by the currently established
-			// relation count.
-			quotaErr := a.preUpgradeRelationLimitCheck(cfg.Charm)
-
-			// If the operator specified --force, we still allow
-			// the upgrade to continue with a warning.
-			if errors.IsQuotaLimitExceeded(quotaErr) && cfg.Force {
-				logger.Warningf("%v; allowing upgrade to proceed as the operator specified --force", quotaErr)
-			} else if quotaErr != nil {
-				return nil, errors.Trace(quotaErr)
-			}
+	ops := []txn.Op{{
+		C:  applicationsC,
+		Id: a.doc.DocID,
+		Assert: append(notDeadDoc, bson.DocElem{
+			"charmmodifiedversion", a.doc.CharmModifiedVersion,
+		}),
+	}}
 
-			chng, err := a.changeCharmOps(
-				cfg.Charm,
-				updatedSettings,
-				cfg.ForceUnits,
-				cfg.StorageConstraints,
-			)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			ops = append(ops, chng...)
-			newCharmModifiedVersion++
+	if *a.doc.CharmURL == cfg.Charm.URL() {
+		updates := bson.D{
+			{"forcecharm", cfg.ForceUnits},
 		}
-
-		// Resources can be upgraded independent of a charm upgrade.
-		resourceOps, err := a.resolveResourceOps(cfg.PendingResourceIDs)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		ops = append(ops, resourceOps...)
-		// Only update newCharmModifiedVersion once. It might have been
-		// incremented in charmCharmOps.
-		if len(resourceOps) > 0 && newCharmModifiedVersion == a.doc.CharmModifiedVersion {
-			ops = append(ops, incCharmModifiedVersionOps(a.doc.DocID)...)
-			newCharmModifiedVersion++
-		}
-
-		// Update the charm origin
+		// Charm URL already set; just update the force flag.
 		ops = append(ops, txn.Op{
 			C:      applicationsC,
 			Id:     a.doc.DocID,
 			Assert: txn.DocExists,
-			Update: bson.D{{"$set", bson.D{
-				{"charm-origin", *cfg.CharmOrigin},
-			}}},
+			Update: bson.D{{"$set", updates}},
 		})
-
-		if cfg.RequireNoUnits {
-			if a.UnitCount()+a.GetScale() > 0 {
-				return nil, stateerrors.ErrApplicationShouldNotHaveUnits
-			}
-			ops = append(ops, txn.Op{
-				C:      applicationsC,
-				Id:     a.doc.DocID,
-				Assert: bson.D{{"scale", 0}, {"unitcount", 0}},
-			})
-		}
-
-		// Always update bindings regardless of whether we upgrade to a
-		// new version or stay at the previous version.
-		currentMap, txnRevno, err := readEndpointBindings(a.st, a.globalKey())
-		if err != nil && !errors.IsNotFound(err) {
-			return ops, errors.Trace(err)
-		}
-		b, err := a.bindingsForOps(currentMap)
+	} else {
+		// Check if the new charm specifies a relation max limit
+		// that cannot be satisfied by the currently established
+		// relation count.
+		quotaErr := a.preUpgradeRelationLimitCheck(cfg.Charm)
+
+		// If the operator specified --force, we still allow
+		// the upgrade to continue with a warning.
+		if errors.IsQuotaLimitExceeded(quotaErr) && cfg.Force {
+			logger.Warningf("%v; allowing upgrade to proceed as the operator specified --force", quotaErr)
+		} else if quotaErr != nil {
+			return nil, errors.Trace(quotaErr)
+		}
+
+		chng, err := a.changeCharmOps(
+			cfg.Charm,
+			updatedSettings,
+			configDelta,
+			cfg.ForceUnits,
+			cfg.StorageConstraints,
+		)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		endpointBindingsOps, err := b.updateOps(txnRevno, cfg.EndpointBindings, cfg.Charm.Meta(), cfg.Force)
-		if err == nil {
-			ops = append(ops, endpointBindingsOps...)
-		} else if !errors.IsNotFound(err) && err != jujutxn.ErrNoOperations {
-			// If endpoint bindings do not exist this most likely means the application
-			// itself no longer exists, which will be caught soon enough anyway.
-			// ErrNoOperations on the other hand means there's nothing to update.
-			return nil, errors.Trace(err)
+		ops = append(ops, chng...)
+		newCharmModifiedVersion++
+	}
+
+	// Resources can be upgraded independent of a charm upgrade.
+	resourceOps, err := a.resolveResourceOps(cfg.PendingResourceIDs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, resourceOps...)
+	// Only update newCharmModifiedVersion once. It might have been
+	// incremented in charmCharmOps.
+	if len(resourceOps) > 0 && newCharmModifiedVersion == a.doc.CharmModifiedVersion {
+		ops = append(ops, incCharmModifiedVersionOps(a.doc.DocID)...)
+		newCharmModifiedVersion++
+	}
+
+	// Update the charm origin
+	ops = append(ops, txn.Op{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"charm-origin", *cfg.CharmOrigin},
+		}}},
+	})
+
+	if cfg.RequireNoUnits {
+		if a.UnitCount()+a.GetScale() > 0 {
+			return nil, stateerrors.ErrApplicationShouldNotHaveUnits
 		}
-		return ops, nil
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"scale", 0}, {"unitcount", 0}},
+		})
 	}
 
-	if err := a.st.db().Run(buildTxn); err != nil {
-		return err
+	// Always update bindings regardless of whether we upgrade to a
+	// new version or stay at the previous version.
+	currentMap, txnRevno, err := readEndpointBindings(a.st, a.globalKey())
+	if err != nil && !errors.IsNotFound(err) {
+		return ops, errors.Trace(err)
 	}
-	return a.Refresh()
+	b, err := a.bindingsForOps(currentMap)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpointBindingsOps, err := b.updateOps(txnRevno, cfg.EndpointBindings, cfg.Charm.Meta(), cfg.Force)
+	if err == nil {
+		ops = append(ops, endpointBindingsOps...)
+	} else if !errors.IsNotFound(err) && err != jujutxn.ErrNoOperations {
+		// If endpoint bindings do not exist this most likely means the application
+		// itself no longer exists, which will be caught soon enough anyway.
+		// ErrNoOperations on the other hand means there's nothing to update.
+		return nil, errors.Trace(err)
+	}
+	return ops, nil
 }
 
 // SetDownloadedIDAndHash updates the applications charm origin with ID and
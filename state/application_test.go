@@ -4815,6 +4815,24 @@ func (s *ApplicationSuite) TestUpdateApplicationConfigWithDyingApplication(c *gc
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	w := s.mysql.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, w)
+	wc.AssertOneChange()
+
+	err := s.mysql.UpdateApplicationConfig(config.ConfigAttributes{"title": "value"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Charm config changes aren't reported.
+	err = s.mysql.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *ApplicationSuite) TestDestroyApplicationRemovesConfig(c *gc.C) {
 	err := s.mysql.UpdateApplicationConfig(config.ConfigAttributes{"title": "value"}, nil, sampleApplicationConfigSchema(), nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -5864,7 +5882,7 @@ func (s *CAASApplicationSuite) TestUpsertCAASUnit(c *gc.C) {
 		Pool:       "kubernetes",
 		Persistent: true,
 	}
-	storageTag, err := sb.AddExistingFilesystem(fsInfo, &volumeInfo, "database")
+	storageTag, err := sb.AddExistingFilesystem(fsInfo, &volumeInfo, "database", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(storageTag.Id(), gc.Equals, "database/0")
 
@@ -5956,6 +5974,88 @@ func (s *CAASApplicationSuite) TestUpsertCAASUnit(c *gc.C) {
 	c.Assert(containerInfo.Address().Value, gc.Equals, "1.2.3.4")
 }
 
+func (s *CAASApplicationSuite) TestReplaceStorage(c *gc.C) {
+	registry := &storage.StaticProviderRegistry{
+		Providers: map[storage.ProviderType]storage.Provider{
+			"kubernetes": &dummy.StorageProvider{
+				StorageScope: storage.ScopeEnviron,
+				IsDynamic:    true,
+				IsReleasable: true,
+				SupportsFunc: func(k storage.StorageKind) bool {
+					return k == storage.StorageKindBlock
+				},
+			},
+		},
+	}
+
+	st := s.Factory.MakeCAASModel(c, &factory.ModelParams{
+		CloudName: "caascloud",
+	})
+	s.AddCleanup(func(_ *gc.C) { _ = st.Close() })
+
+	pm := poolmanager.New(state.NewStateSettings(st), registry)
+	_, err := pm.Create("kubernetes", "kubernetes", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.policy = testing.MockPolicy{
+		GetStorageProviderRegistry: func() (storage.ProviderRegistry, error) {
+			return registry, nil
+		},
+	}
+
+	sb, err := state.NewStorageBackend(st)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fsInfo := state.FilesystemInfo{
+		Size: 100,
+		Pool: "kubernetes",
+	}
+	volumeInfo := state.VolumeInfo{
+		VolumeId:   "pv-database-0",
+		Size:       100,
+		Pool:       "kubernetes",
+		Persistent: true,
+	}
+	imported, err := sb.AddExistingFilesystem(fsInfo, &volumeInfo, "database", "cockroachdb")
+	c.Assert(err, jc.ErrorIsNil)
+	volumeInfo.VolumeId = "pv-database-1"
+	importedElsewhere, err := sb.AddExistingFilesystem(fsInfo, &volumeInfo, "database", "mariadb")
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := state.AddTestingCharmForSeries(c, st, "quantal", "cockroachdb")
+	cockroachdb := state.AddTestingApplicationWithStorage(c, st, "cockroachdb", ch, map[string]state.StorageConstraints{
+		"database": {
+			Pool:  "kubernetes",
+			Size:  100,
+			Count: 1,
+		},
+	})
+	unit, err := cockroachdb.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachments, err := sb.UnitStorageAttachments(unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	own := attachments[0].StorageInstance()
+
+	err = sb.ReplaceStorage(own, importedElsewhere, unit.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot replace .* on unit cockroachdb/0: cannot attach storage imported for application mariadb to unit cockroachdb/0`)
+
+	err = sb.ReplaceStorage(own, imported, unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachments, err = sb.UnitStorageAttachments(unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].StorageInstance(), gc.Equals, imported)
+	si, err := sb.StorageInstance(imported)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, unit.Tag())
+	_, err = sb.StorageInstance(own)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func intPtr(val int) *int {
 	return &val
 }
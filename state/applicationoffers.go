@@ -309,6 +309,12 @@ func (op *RemoveOfferOperation) Done(err error) error {
 		removeOps = append(removeOps,
 			removePermissionOp(applicationOfferKey(op.offer.OfferUUID), userGlobalKey(userAccessID(user))))
 	}
+	groupOps, err := op.offerStore.st.removeUserGroupsAccessOps(applicationOfferKey(op.offer.OfferUUID))
+	if err != nil {
+		op.AddError(errors.Errorf("error removing offer permissions: %v", err))
+		return nil
+	}
+	removeOps = append(removeOps, groupOps...)
 	err = op.offerStore.st.db().RunTransaction(removeOps)
 	if err != nil {
 		op.AddError(errors.Errorf("error removing offer permissions: %v", err))
@@ -13,8 +13,16 @@ import (
 	"github.com/juju/juju/core/permission"
 )
 
-// GetOfferAccess gets the access permission for the specified user on an offer.
+// GetOfferAccess gets the access permission for the specified user on an
+// offer, including any access granted to groups the user is a member of.
 func (st *State) GetOfferAccess(offerUUID string, user names.UserTag) (permission.Access, error) {
+	access, err := st.offerDirectAccess(offerUUID, user)
+	return st.withUserGroupsAccess(user, names.NewApplicationOfferTag(offerUUID), access, err)
+}
+
+// offerDirectAccess gets the access permission granted directly to the
+// specified user on an offer.
+func (st *State) offerDirectAccess(offerUUID string, user names.UserTag) (permission.Access, error) {
 	perm, err := st.userPermission(applicationOfferKey(offerUUID), userGlobalKey(userAccessID(user)))
 	if err != nil {
 		return "", errors.Trace(err)
@@ -70,7 +78,7 @@ func (st *State) UpdateOfferAccess(offer names.ApplicationOfferTag, user names.U
 	offerUUID := offer.Id()
 
 	buildTxn := func(int) ([]txn.Op, error) {
-		_, err := st.GetOfferAccess(offerUUID, user)
+		_, err := st.offerDirectAccess(offerUUID, user)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -163,7 +171,7 @@ func (st *State) suspendRevokedRelationsOps(offerUUID, userId string) ([]txn.Op,
 func (st *State) RemoveOfferAccess(offer names.ApplicationOfferTag, user names.UserTag) error {
 	buildTxn := func(int) ([]txn.Op, error) {
 		offerUUID := offer.Id()
-		_, err := st.GetOfferAccess(offerUUID, user)
+		_, err := st.offerDirectAccess(offerUUID, user)
 		if err != nil {
 			return nil, err
 		}
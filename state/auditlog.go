@@ -0,0 +1,314 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+// auditLogConversationCacheSize is the number of conversations an
+// AuditLogWriter remembers, so that their details can be added to the
+// requests made in them without reading them back from the database.
+const auditLogConversationCacheSize = 1000
+
+// auditLogDoc holds a conversation, or an API request made in one,
+// recorded by the database audit log sink. Request documents repeat
+// the details of their conversation so that they can be queried
+// directly.
+type auditLogDoc struct {
+	DocID          string             `bson:"_id"`
+	Controller     string             `bson:"controller"`
+	ConversationID string             `bson:"conversation-id"`
+	ConnectionID   string             `bson:"connection-id"`
+	RequestID      int64              `bson:"request-id,omitempty"`
+	When           time.Time          `bson:"when"`
+	ExpireAt       time.Time          `bson:"expire-at"`
+	Who            string             `bson:"who"`
+	What           string             `bson:"what,omitempty"`
+	ModelName      string             `bson:"model-name"`
+	ModelUUID      string             `bson:"model-uuid"`
+	Facade         string             `bson:"facade,omitempty"`
+	Method         string             `bson:"method,omitempty"`
+	Version        int                `bson:"version,omitempty"`
+	Args           string             `bson:"args,omitempty"`
+	Errors         []auditLogErrorDoc `bson:"errors,omitempty"`
+}
+
+type auditLogErrorDoc struct {
+	Message string `bson:"message"`
+	Code    string `bson:"code,omitempty"`
+}
+
+func auditLogRequestDocID(conversationID string, requestID uint64) string {
+	return fmt.Sprintf("%s:%d", conversationID, requestID)
+}
+
+// AuditLogWriter writes audit log records to the controller database,
+// so that the audit trail of every controller can be queried in one
+// place. It implements auditlog.RecordSender, and is expected to be
+// wrapped by auditlog.NewForwarder.
+type AuditLogWriter struct {
+	coll         *mgo.Collection
+	controllerID string
+	retention    time.Duration
+
+	conversations     map[string]auditLogDoc
+	conversationOrder []string
+}
+
+// NewAuditLogWriter returns a writer that records audit log entries
+// from the given controller in the database. Entries are removed once
+// they are older than the retention period.
+func NewAuditLogWriter(st *State, controllerID string, retention time.Duration) *AuditLogWriter {
+	session := st.MongoSession().Copy()
+	session.SetSafe(&mgo.Safe{
+		W: 1,
+	})
+	return &AuditLogWriter{
+		coll:          session.DB(jujuDB).C(auditLogC),
+		controllerID:  controllerID,
+		retention:     retention,
+		conversations: make(map[string]auditLogDoc),
+	}
+}
+
+// SendRecords implements auditlog.RecordSender. Conversations and
+// requests are inserted, and response errors are added to the request
+// they answer.
+func (w *AuditLogWriter) SendRecords(records []auditlog.Record) error {
+	bulk := w.coll.Bulk()
+	for _, r := range records {
+		switch {
+		case r.Conversation != nil:
+			doc := w.conversationDoc(*r.Conversation)
+			w.remember(doc)
+			bulk.Insert(&doc)
+		case r.Request != nil:
+			doc, err := w.requestDoc(*r.Request)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			bulk.Insert(&doc)
+		case r.Errors != nil:
+			errs := make([]auditLogErrorDoc, len(r.Errors.Errors))
+			for i, e := range r.Errors.Errors {
+				errs[i] = auditLogErrorDoc{Message: e.Message, Code: e.Code}
+			}
+			bulk.Update(
+				bson.D{{"_id", auditLogRequestDocID(r.Errors.ConversationID, r.Errors.RequestID)}},
+				bson.D{{"$set", bson.D{{"errors", errs}}}},
+			)
+		}
+	}
+	_, err := bulk.Run()
+	return errors.Annotatef(err, "writing %d audit log record(s)", len(records))
+}
+
+// Close implements auditlog.RecordSender.
+func (w *AuditLogWriter) Close() error {
+	w.coll.Database.Session.Close()
+	return nil
+}
+
+func (w *AuditLogWriter) conversationDoc(c auditlog.Conversation) auditLogDoc {
+	when := parseAuditLogTime(c.When)
+	return auditLogDoc{
+		DocID:          c.ConversationID,
+		Controller:     w.controllerID,
+		ConversationID: c.ConversationID,
+		ConnectionID:   c.ConnectionID,
+		When:           when,
+		ExpireAt:       when.Add(w.retention),
+		Who:            c.Who,
+		What:           c.What,
+		ModelName:      c.ModelName,
+		ModelUUID:      c.ModelUUID,
+	}
+}
+
+func (w *AuditLogWriter) requestDoc(r auditlog.Request) (auditLogDoc, error) {
+	conversation, err := w.conversation(r.ConversationID)
+	if err != nil {
+		return auditLogDoc{}, errors.Trace(err)
+	}
+	when := parseAuditLogTime(r.When)
+	return auditLogDoc{
+		DocID:          auditLogRequestDocID(r.ConversationID, r.RequestID),
+		Controller:     w.controllerID,
+		ConversationID: r.ConversationID,
+		ConnectionID:   r.ConnectionID,
+		RequestID:      int64(r.RequestID),
+		When:           when,
+		ExpireAt:       when.Add(w.retention),
+		Who:            conversation.Who,
+		What:           conversation.What,
+		ModelName:      conversation.ModelName,
+		ModelUUID:      conversation.ModelUUID,
+		Facade:         r.Facade,
+		Method:         r.Method,
+		Version:        r.Version,
+		Args:           r.Args,
+	}, nil
+}
+
+// conversation returns the conversation with the given ID, reading it
+// from the database if it isn't one of the recently written ones. If
+// the conversation was never written, perhaps because the database
+// sink was enabled part way through it, an empty conversation is
+// returned.
+func (w *AuditLogWriter) conversation(id string) (auditLogDoc, error) {
+	if doc, ok := w.conversations[id]; ok {
+		return doc, nil
+	}
+	var doc auditLogDoc
+	err := w.coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		logger.Debugf("audit log conversation %q not found", id)
+		return auditLogDoc{}, nil
+	} else if err != nil {
+		return auditLogDoc{}, errors.Annotatef(err, "reading audit log conversation %q", id)
+	}
+	w.remember(doc)
+	return doc, nil
+}
+
+func (w *AuditLogWriter) remember(doc auditLogDoc) {
+	if _, ok := w.conversations[doc.DocID]; ok {
+		return
+	}
+	if len(w.conversationOrder) >= auditLogConversationCacheSize {
+		delete(w.conversations, w.conversationOrder[0])
+		w.conversationOrder = w.conversationOrder[1:]
+	}
+	w.conversations[doc.DocID] = doc
+	w.conversationOrder = append(w.conversationOrder, doc.DocID)
+}
+
+func parseAuditLogTime(value string) time.Time {
+	when, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		logger.Warningf("invalid audit log time %q, using the current time", value)
+		return time.Now().UTC()
+	}
+	return when.UTC()
+}
+
+// AuditLogFilter selects the entries returned by QueryAuditLog. Empty
+// fields match any entry.
+type AuditLogFilter struct {
+	// User is the name of the user that made the API requests.
+	User string
+
+	// ModelUUID is the UUID of the model the requests were made to.
+	ModelUUID string
+
+	// Facade and Method select the API requests made. If either is
+	// set, only requests (and not the conversations they are made in)
+	// match, since conversations have neither.
+	Facade string
+	Method string
+
+	// From and To limit the entries to those recorded in the range.
+	From time.Time
+	To   time.Time
+
+	// Limit is the maximum number of entries returned; the most recent
+	// ones are kept.
+	Limit int
+}
+
+// AuditLogEntry is a conversation, or an API request made in one,
+// recorded in the controller database.
+type AuditLogEntry struct {
+	// Controller is the ID of the controller that recorded the entry.
+	Controller string
+
+	ConversationID string
+	ConnectionID   string
+	When           time.Time
+	Who            string
+	What           string
+	ModelName      string
+	ModelUUID      string
+
+	// The remaining fields are only set for API requests.
+	RequestID uint64
+	Facade    string
+	Method    string
+	Version   int
+	Args      string
+	Errors    []auditlog.Error
+}
+
+// QueryAuditLog returns the audit log entries recorded in the database
+// by all controllers that match the filter, oldest first.
+func (st *State) QueryAuditLog(filter AuditLogFilter) ([]AuditLogEntry, error) {
+	coll, closer := st.db().GetRawCollection(auditLogC)
+	defer closer()
+
+	query := bson.D{}
+	if filter.User != "" {
+		query = append(query, bson.DocElem{"who", filter.User})
+	}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	if filter.Facade != "" {
+		query = append(query, bson.DocElem{"facade", filter.Facade})
+	}
+	if filter.Method != "" {
+		query = append(query, bson.DocElem{"method", filter.Method})
+	}
+	when := bson.D{}
+	if !filter.From.IsZero() {
+		when = append(when, bson.DocElem{"$gte", filter.From.UTC()})
+	}
+	if !filter.To.IsZero() {
+		when = append(when, bson.DocElem{"$lte", filter.To.UTC()})
+	}
+	if len(when) > 0 {
+		query = append(query, bson.DocElem{"when", when})
+	}
+
+	q := coll.Find(query).Sort("-when", "-_id")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var docs []auditLogDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "querying audit log")
+	}
+
+	entries := make([]AuditLogEntry, len(docs))
+	for i, doc := range docs {
+		entry := AuditLogEntry{
+			Controller:     doc.Controller,
+			ConversationID: doc.ConversationID,
+			ConnectionID:   doc.ConnectionID,
+			When:           doc.When.UTC(),
+			Who:            doc.Who,
+			What:           doc.What,
+			ModelName:      doc.ModelName,
+			ModelUUID:      doc.ModelUUID,
+			RequestID:      uint64(doc.RequestID),
+			Facade:         doc.Facade,
+			Method:         doc.Method,
+			Version:        doc.Version,
+			Args:           doc.Args,
+		}
+		for _, e := range doc.Errors {
+			entry.Errors = append(entry.Errors, auditlog.Error{Message: e.Message, Code: e.Code})
+		}
+		// Most recent entries were read first.
+		entries[len(docs)-1-i] = entry
+	}
+	return entries, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+)
+
+type AuditLogSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) writeRecords(c *gc.C, controllerID string, records ...auditlog.Record) {
+	writer := state.NewAuditLogWriter(s.State, controllerID, 24*time.Hour)
+	defer writer.Close()
+	err := writer.SendRecords(records)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func conversationRecord(id, who, modelUUID, when string) auditlog.Record {
+	return auditlog.Record{Conversation: &auditlog.Conversation{
+		Who:            who,
+		What:           "juju deploy mysql",
+		When:           when,
+		ModelName:      "default",
+		ModelUUID:      modelUUID,
+		ConversationID: id,
+		ConnectionID:   "1F",
+	}}
+}
+
+func requestRecord(conversationID string, requestID uint64, facade, method, when string) auditlog.Record {
+	return auditlog.Record{Request: &auditlog.Request{
+		ConversationID: conversationID,
+		ConnectionID:   "1F",
+		RequestID:      requestID,
+		When:           when,
+		Facade:         facade,
+		Method:         method,
+		Version:        3,
+	}}
+}
+
+func (s *AuditLogSuite) TestWriteAndQuery(c *gc.C) {
+	s.writeRecords(c, "0",
+		conversationRecord("abc", "bob", "model-a", "2023-05-01T10:00:00Z"),
+		requestRecord("abc", 1, "Application", "Deploy", "2023-05-01T10:00:01Z"),
+		auditlog.Record{Errors: &auditlog.ResponseErrors{
+			ConversationID: "abc",
+			ConnectionID:   "1F",
+			RequestID:      1,
+			When:           "2023-05-01T10:00:02Z",
+			Errors:         []*auditlog.Error{{Message: "no way", Code: "unauthorized access"}},
+		}},
+	)
+
+	entries, err := s.State.QueryAuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []state.AuditLogEntry{{
+		Controller:     "0",
+		ConversationID: "abc",
+		ConnectionID:   "1F",
+		When:           time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC),
+		Who:            "bob",
+		What:           "juju deploy mysql",
+		ModelName:      "default",
+		ModelUUID:      "model-a",
+	}, {
+		Controller:     "0",
+		ConversationID: "abc",
+		ConnectionID:   "1F",
+		When:           time.Date(2023, 5, 1, 10, 0, 1, 0, time.UTC),
+		Who:            "bob",
+		What:           "juju deploy mysql",
+		ModelName:      "default",
+		ModelUUID:      "model-a",
+		RequestID:      1,
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        3,
+		Errors:         []auditlog.Error{{Message: "no way", Code: "unauthorized access"}},
+	}})
+}
+
+func (s *AuditLogSuite) TestRequestInLaterBatch(c *gc.C) {
+	s.writeRecords(c, "0", conversationRecord("abc", "bob", "model-a", "2023-05-01T10:00:00Z"))
+	// A new writer has to read the conversation back from the database.
+	s.writeRecords(c, "0", requestRecord("abc", 1, "Application", "Deploy", "2023-05-01T10:00:01Z"))
+
+	entries, err := s.State.QueryAuditLog(state.AuditLogFilter{Facade: "Application"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Who, gc.Equals, "bob")
+	c.Assert(entries[0].ModelUUID, gc.Equals, "model-a")
+}
+
+func (s *AuditLogSuite) TestQueryFilters(c *gc.C) {
+	s.writeRecords(c, "0",
+		conversationRecord("abc", "bob", "model-a", "2023-05-01T10:00:00Z"),
+		requestRecord("abc", 1, "Application", "Deploy", "2023-05-01T10:00:01Z"),
+		requestRecord("abc", 2, "Application", "SetConfigs", "2023-05-01T10:00:02Z"),
+	)
+	s.writeRecords(c, "1",
+		conversationRecord("def", "mary@external", "model-b", "2023-05-02T10:00:00Z"),
+		requestRecord("def", 1, "Application", "Deploy", "2023-05-02T10:00:01Z"),
+		requestRecord("def", 2, "Client", "AddMachines", "2023-05-02T10:00:02Z"),
+	)
+
+	type summary struct {
+		controller string
+		id         string
+		method     string
+	}
+	query := func(filter state.AuditLogFilter) []summary {
+		entries, err := s.State.QueryAuditLog(filter)
+		c.Assert(err, jc.ErrorIsNil)
+		var result []summary
+		for _, e := range entries {
+			result = append(result, summary{e.Controller, e.ConversationID, e.Method})
+		}
+		return result
+	}
+
+	c.Check(query(state.AuditLogFilter{User: "mary@external"}), jc.DeepEquals, []summary{
+		{"1", "def", ""},
+		{"1", "def", "Deploy"},
+		{"1", "def", "AddMachines"},
+	})
+	c.Check(query(state.AuditLogFilter{ModelUUID: "model-a", Facade: "Application"}), jc.DeepEquals, []summary{
+		{"0", "abc", "Deploy"},
+		{"0", "abc", "SetConfigs"},
+	})
+	c.Check(query(state.AuditLogFilter{Method: "Deploy"}), jc.DeepEquals, []summary{
+		{"0", "abc", "Deploy"},
+		{"1", "def", "Deploy"},
+	})
+	c.Check(query(state.AuditLogFilter{
+		From: time.Date(2023, 5, 1, 10, 0, 2, 0, time.UTC),
+		To:   time.Date(2023, 5, 2, 10, 0, 1, 0, time.UTC),
+	}), jc.DeepEquals, []summary{
+		{"0", "abc", "SetConfigs"},
+		{"1", "def", ""},
+		{"1", "def", "Deploy"},
+	})
+	c.Check(query(state.AuditLogFilter{Limit: 2}), jc.DeepEquals, []summary{
+		{"1", "def", "Deploy"},
+		{"1", "def", "AddMachines"},
+	})
+}
@@ -82,8 +82,8 @@ type ArchiveWorkspace struct {
 	RootDir string
 }
 
-func newArchiveWorkspace() (*ArchiveWorkspace, error) {
-	rootdir, err := os.MkdirTemp("", "juju-backups-")
+func newArchiveWorkspace(parentDir string) (*ArchiveWorkspace, error) {
+	rootdir, err := os.MkdirTemp(parentDir, "juju-backups-")
 	if err != nil {
 		return nil, errors.Annotate(err, "while creating workspace dir")
 	}
@@ -101,7 +101,14 @@ func newArchiveWorkspace() (*ArchiveWorkspace, error) {
 // "temporary" directory. For relatively large archives this could have
 // adverse effects on hosts with little disk space.
 func NewArchiveWorkspaceReader(archive io.Reader) (*ArchiveWorkspace, error) {
-	ws, err := newArchiveWorkspace()
+	return newArchiveWorkspaceReader("", archive)
+}
+
+// newArchiveWorkspaceReader returns a new archive workspace rooted in a
+// new directory under parentDir, populated from the archive. An empty
+// parentDir means the host's temporary directory.
+func newArchiveWorkspaceReader(parentDir string, archive io.Reader) (*ArchiveWorkspace, error) {
+	ws, err := newArchiveWorkspace(parentDir)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
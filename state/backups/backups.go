@@ -24,6 +24,10 @@ const (
 
 	// FilenameTemplate is used with time.Time.Format to generate a filename.
 	FilenameTemplate = FilenamePrefix + "20060102-150405.tar.gz"
+
+	// UploadFilenamePrefix is the prefix used for backup archive files
+	// uploaded to the controller for a restore.
+	UploadFilenamePrefix = FilenamePrefix + "upload-"
 )
 
 var logger = loggo.GetLogger("juju.state.backups")
@@ -52,6 +56,25 @@ type Backups interface {
 
 	// Get returns the metadata and specified archive file.
 	Get(fileName string) (*Metadata, io.ReadCloser, error)
+
+	// Add stores the archive read from the given reader in the backup
+	// directory and returns the ID it was stored under.
+	Add(archive io.Reader) (string, error)
+
+	// Restore restores the controller from the archive with the given
+	// ID. It returns the metadata found in the archive.
+	Restore(id string, args RestoreArgs) (*Metadata, error)
+
+	// List returns the metadata of all stored archive files, oldest
+	// first.
+	List() ([]*Metadata, error)
+
+	// Verify checks the specified archive file against the checksum
+	// recorded in its metadata, and returns the metadata.
+	Verify(fileName string) (*Metadata, error)
+
+	// Remove deletes the specified archive file and its metadata.
+	Remove(fileName string) error
 }
 
 type backups struct {
@@ -169,6 +192,10 @@ func (b *backups) Create(meta *Metadata, dbInfo *DBInfo) (string, error) {
 	if err != nil {
 		return "", errors.Annotate(err, "while updating metadata")
 	}
+	meta.SetStored(meta.Finished)
+	if err := writeMetadataFile(result.filename, meta); err != nil {
+		return "", errors.Annotate(err, "while storing metadata")
+	}
 
 	return result.filename, nil
 }
@@ -211,6 +238,9 @@ func (b *backups) Get(fileName string) (_ *Metadata, _ io.ReadCloser, err error)
 		if err2 := os.Remove(fileName); err2 != nil && !os.IsNotExist(err2) {
 			logger.Errorf("error removing backup archive: %v", err2.Error())
 		}
+		if err2 := os.Remove(metadataFilename(fileName)); err2 != nil && !os.IsNotExist(err2) {
+			logger.Errorf("error removing backup metadata: %v", err2.Error())
+		}
 	}()
 
 	readCloser, err := os.Open(fileName)
@@ -231,3 +261,29 @@ func (b *backups) Get(fileName string) (_ *Metadata, _ io.ReadCloser, err error)
 
 	return meta, readCloser, nil
 }
+
+// Add stores the archive read from the given reader in the backup
+// directory, for a subsequent restore. The returned ID is the name of
+// the archive file within the backup directory.
+func (b *backups) Add(archive io.Reader) (_ string, err error) {
+	destinationDir := b.paths.BackupDir
+	if !filepath.IsAbs(destinationDir) {
+		return "", errors.Errorf("cannot use relative backup destination directory %q", destinationDir)
+	}
+
+	file, err := os.CreateTemp(destinationDir, UploadFilenamePrefix+"*.tar.gz")
+	if err != nil {
+		return "", errors.Annotate(err, "while creating backup archive file")
+	}
+	defer func() {
+		_ = file.Close()
+		if err != nil {
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	if _, err := io.Copy(file, archive); err != nil {
+		return "", errors.Annotate(err, "while storing backup archive")
+	}
+	return filepath.Base(file.Name()), nil
+}
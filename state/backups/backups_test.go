@@ -136,6 +136,11 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	c.Check(meta.Origin.Machine, gc.Equals, "<machine ID>")
 	c.Check(meta.Origin.Hostname, gc.Equals, "<hostname>")
 	c.Check(meta.Notes, gc.Equals, "some notes")
+	c.Check(meta.Stored(), gc.NotNil)
+
+	// The metadata, including the checksum, is stored alongside the archive.
+	_, err = os.Stat(resultFilename + ".json")
+	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *backupsSuite) TestCreateFailToListFiles(c *gc.C) {
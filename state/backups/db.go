@@ -4,6 +4,7 @@
 package backups
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -236,6 +237,138 @@ func (md *mongoDumper) Dump(baseDumpDir string) error {
 	return errors.Trace(err)
 }
 
+const restoreName = "mongorestore"
+
+// DBRestorer is any type that restores a database from a dump dir.
+type DBRestorer interface {
+	// Restore the database from the contents of dumpDir.
+	Restore(dumpDir string) error
+
+	// IsSnap returns true if we are using the juju-db snap.
+	IsSnap() bool
+}
+
+var getMongorestorePath = func() (string, error) {
+	return getMongoToolPath(restoreName, os.Stat, exec.LookPath)
+}
+
+type mongoRestorer struct {
+	*DBInfo
+	// binPath is the path to the restore executable.
+	binPath string
+}
+
+// NewDBRestorer returns a new value with a Restore method for restoring
+// the juju state database from a dump.
+func NewDBRestorer(info *DBInfo) (DBRestorer, error) {
+	mongorestorePath, err := getMongorestorePath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongorestore not available")
+	}
+
+	restorer := mongoRestorer{
+		DBInfo:  info,
+		binPath: mongorestorePath,
+	}
+	return &restorer, nil
+}
+
+func (mr *mongoRestorer) options(dumpDir string) []string {
+	options := []string{
+		"--ssl",
+		"--tlsInsecure",
+		"--authenticationDatabase", "admin",
+		"--host", mr.Address,
+		"--username", mr.Username,
+		"--password", mr.Password,
+		"--drop",
+		"--oplogReplay",
+		"--batchSize", "10",
+		"--dir", dumpDir,
+	}
+	return options
+}
+
+// Restore restores the juju state-related databases from the dump. Any
+// existing collections are dropped before being restored, and the oplog
+// captured with the dump is replayed so that the result is consistent.
+func (mr *mongoRestorer) Restore(dumpDir string) error {
+	logger.Tracef("restoring Mongo database from %q", dumpDir)
+	// The juju-db.mongorestore snap has a private /tmp, so a dump
+	// staged under the snap's view of /tmp must be passed using the
+	// path the snap sees. See mongoDumper.dump.
+	dumpDirArg := dumpDir
+	if mr.IsSnap() && strings.HasPrefix(dumpDirArg, snapTmpDir) {
+		dumpDirArg = strings.TrimPrefix(dumpDirArg, snapTmpDir)
+	}
+	if err := runCommandFn(mr.binPath, mr.options(dumpDirArg)...); err != nil {
+		return errors.Annotate(err, "error restoring databases")
+	}
+	return nil
+}
+
+// IsSnap returns true if we are using the juju-db snap.
+func (mr *mongoRestorer) IsSnap() bool {
+	return filepath.Base(mr.binPath) == snapToolPrefix+restoreName
+}
+
+// controllerApplicationName is the name of the application that
+// bootstrap deploys to run the controller charm.
+const controllerApplicationName = "controller"
+
+// jobManageModel is the stored value of state.JobManageModel.
+const jobManageModel = 2
+
+// freshControllerChecks describe the documents that a freshly
+// bootstrapped controller does not have in the juju database.
+var freshControllerChecks = []struct {
+	collection string
+	query      bson.M
+	what       string
+}{{
+	collection: "models",
+	query:      bson.M{"name": bson.M{"$nin": []string{"controller", "default"}}},
+	what:       "models",
+}, {
+	collection: "applications",
+	query:      bson.M{"name": bson.M{"$ne": controllerApplicationName}},
+	what:       "applications",
+}, {
+	collection: "machines",
+	query:      bson.M{"jobs": bson.M{"$ne": jobManageModel}},
+	what:       "workload machines",
+}}
+
+// CheckFreshController returns an error if the juju database reached
+// through the given session shows that the controller has been used
+// since it was bootstrapped. Restoring a backup replaces the whole
+// database, so anything added since bootstrap would be lost.
+func CheckFreshController(session DBSession) error {
+	db := session.DB("juju")
+	var inUse []string
+	for _, check := range freshControllerChecks {
+		var result struct {
+			N int `bson:"n"`
+		}
+		err := db.Run(bson.D{
+			{"count", check.collection},
+			{"query", check.query},
+		}, &result)
+		if err != nil {
+			return errors.Annotatef(err, "counting %s", check.what)
+		}
+		if result.N > 0 {
+			inUse = append(inUse, fmt.Sprintf("%d %s", result.N, check.what))
+		}
+	}
+	if len(inUse) > 0 {
+		return errors.Errorf(
+			"controller is not freshly bootstrapped (found %s); restore into a new controller",
+			strings.Join(inUse, ", "))
+	}
+	return nil
+}
+
 // stripIgnored removes the ignored DBs from the mongo dump files.
 // This involves deleting DB-specific directories.
 //
@@ -23,6 +23,10 @@ var (
 	AvailableDisk        = &availableDisk
 	TotalDisk            = &totalDisk
 	DirSize              = &dirSize
+	GetDBRestorer        = &getDBRestorer
+	UnpackFilesBundle    = &unpackFilesBundle
+	GetMongorestorePath  = &getMongorestorePath
+	WriteMetadataFile    = writeMetadataFile
 )
 
 // ExposeCreateResult extracts the values in a create() result.
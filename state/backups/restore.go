@@ -0,0 +1,222 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/network"
+)
+
+var (
+	getDBRestorer     = NewDBRestorer
+	unpackFilesBundle = func(ws *ArchiveWorkspace) error {
+		return ws.UnpackFilesBundle(string(os.PathSeparator))
+	}
+)
+
+// RestoreArgs holds the information needed to restore a backup archive
+// onto the controller machine running the restore.
+type RestoreArgs struct {
+	// DBInfo describes the database to restore into.
+	DBInfo *DBInfo
+
+	// Session is a session on the database being restored into. It is
+	// used to check that the controller has not been used since it
+	// was bootstrapped.
+	Session DBSession
+
+	// MachineID is the ID of the controller machine being restored.
+	MachineID string
+
+	// ControllerVersion is the juju version of the controller being
+	// restored. The archive must have been created by a controller
+	// running the same major and minor version.
+	ControllerVersion version.Number
+
+	// Progress, if not nil, is called with a short description of
+	// each restore step as it starts.
+	Progress func(string)
+}
+
+// Validate returns an error if the args are not valid.
+func (args RestoreArgs) Validate() error {
+	if args.DBInfo == nil {
+		return errors.NotValidf("missing DBInfo")
+	}
+	if args.Session == nil {
+		return errors.NotValidf("missing Session")
+	}
+	if !names.IsValidMachine(args.MachineID) {
+		return errors.NotValidf("machine ID %q", args.MachineID)
+	}
+	if args.ControllerVersion == version.Zero {
+		return errors.NotValidf("missing controller version")
+	}
+	return nil
+}
+
+// CheckRestoreCompatible returns an error if a backup archive with the
+// given metadata cannot be restored into a controller running the
+// given version.
+func CheckRestoreCompatible(meta *Metadata, controllerVersion version.Number) error {
+	if meta.FormatVersion != currentFormatVersion {
+		return errors.NotSupportedf("backup format version %d (expected %d)", meta.FormatVersion, currentFormatVersion)
+	}
+	archiveVersion := meta.Origin.Version
+	if archiveVersion.Major != controllerVersion.Major || archiveVersion.Minor != controllerVersion.Minor {
+		return errors.NotSupportedf(
+			"restoring a backup created by juju %s into a controller running juju %s",
+			archiveVersion, controllerVersion,
+		)
+	}
+	return nil
+}
+
+// Restore replaces the controller's database and state-related files
+// with those held in the backup archive with the given ID, as returned
+// by Add. The agent configuration of the restored machine is re-seeded
+// so that it keeps pointing at the controller being restored. Restore
+// refuses to run against a controller that has been used since it was
+// bootstrapped. An archive uploaded for the restore is removed once
+// the restore has succeeded.
+//
+// The controller agent must not be running while the restore runs,
+// and must be restarted afterwards to pick up the restored state.
+func (b *backups) Restore(id string, args RestoreArgs) (*Metadata, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	progress := args.Progress
+	if progress == nil {
+		progress = func(string) {}
+	}
+
+	if id == "" || filepath.Base(id) != id {
+		return nil, errors.NotValidf("backup ID %q", id)
+	}
+	fileName := filepath.Join(b.paths.BackupDir, id)
+	valid, err := isValidFilepath(b.paths.BackupDir, fileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !valid {
+		return nil, errors.NotValidf("backup ID %q", id)
+	}
+
+	progress("checking controller")
+	if err := CheckFreshController(args.Session); err != nil {
+		return nil, errors.Trace(err)
+	}
+	restorer, err := getDBRestorer(args.DBInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "while preparing for DB restore")
+	}
+
+	archive, err := os.Open(fileName)
+	if err != nil {
+		return nil, errors.Annotate(err, "while opening backup archive")
+	}
+	defer func() { _ = archive.Close() }()
+
+	progress("unpacking backup archive")
+	ws, err := newArchiveWorkspaceReader(restoreWorkspaceDir(restorer), archive)
+	if err != nil {
+		return nil, errors.Annotate(err, "while unpacking backup archive")
+	}
+	defer func() {
+		if err := ws.Close(); err != nil {
+			logger.Errorf("error removing restore workspace: %v", err)
+		}
+	}()
+
+	progress("checking backup archive")
+	meta, err := ws.Metadata()
+	if err != nil {
+		return nil, errors.Annotate(err, "while reading backup metadata")
+	}
+	if err := CheckRestoreCompatible(meta, args.ControllerVersion); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Read the current agent config before anything is overwritten,
+	// so that the restored config can be pointed back at this machine.
+	configPath := agent.ConfigPath(b.paths.DataDir, names.NewMachineTag(args.MachineID))
+	current, err := agent.ReadConfig(configPath)
+	if err != nil {
+		return nil, errors.Annotate(err, "while reading current agent config")
+	}
+
+	progress("restoring database")
+	if err := restorer.Restore(ws.DBDumpDir); err != nil {
+		return nil, errors.Annotate(err, "while restoring database")
+	}
+
+	progress("restoring controller files")
+	if err := unpackFilesBundle(ws); err != nil {
+		return nil, errors.Annotate(err, "while restoring controller files")
+	}
+
+	progress("re-seeding agent config")
+	if err := reseedAgentConfig(configPath, current); err != nil {
+		return nil, errors.Annotate(err, "while re-seeding agent config")
+	}
+
+	// Uploaded archives are not subject to the backup retention
+	// policy, so they are removed as soon as they have been used.
+	if strings.HasPrefix(id, UploadFilenamePrefix) {
+		progress("removing uploaded backup archive")
+		if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+			logger.Warningf("cannot remove uploaded backup archive %q: %v", fileName, err)
+		}
+	}
+	return meta, nil
+}
+
+// restoreWorkspaceDir returns the directory under which the archive
+// being restored is unpacked. The juju-db snap has a private /tmp, so
+// when restoring with it the archive is unpacked into the host side of
+// that private directory, where mongorestore can read the dump.
+func restoreWorkspaceDir(restorer DBRestorer) string {
+	if !restorer.IsSnap() {
+		return ""
+	}
+	return filepath.Join(snapTmpDir, os.TempDir())
+}
+
+// reseedAgentConfig updates the restored agent config at the given path
+// so that it uses the API addresses of the current controller machine.
+// The credentials in the restored config are kept, since they match the
+// restored database. If the archive did not contain a config for this
+// machine, the current config is written back unchanged.
+func reseedAgentConfig(configPath string, current agent.ConfigSetterWriter) error {
+	restored, err := agent.ReadConfig(configPath)
+	if os.IsNotExist(errors.Cause(err)) {
+		logger.Warningf("backup archive did not contain %q, keeping current agent config", configPath)
+		return errors.Trace(current.Write())
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	addrs, err := current.APIAddresses()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hostPorts, err := network.ParseProviderHostPorts(addrs...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := restored.SetAPIHostPorts([]network.HostPorts{hostPorts.HostPorts()}); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(restored.Write())
+}
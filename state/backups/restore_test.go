@@ -0,0 +1,340 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type restoreSuite struct {
+	backupstesting.BaseSuite
+
+	paths    *backups.Paths
+	api      backups.Backups
+	dbInfo   *backups.DBInfo
+	session  *countingSession
+	archive  string
+	restored []string
+}
+
+var _ = gc.Suite(&restoreSuite{})
+
+func (s *restoreSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.paths = &backups.Paths{
+		BackupDir: c.MkDir(),
+		DataDir:   c.MkDir(),
+	}
+	s.api = backups.NewBackups(s.paths)
+	s.dbInfo = &backups.DBInfo{Address: "a", Username: "b", Password: "c"}
+	s.session = &countingSession{counts: make(map[string]int)}
+	s.restored = nil
+
+	s.PatchValue(backups.GetDBRestorer, func(*backups.DBInfo) (backups.DBRestorer, error) {
+		return &fakeRestorer{s: s}, nil
+	})
+	s.PatchValue(backups.UnpackFilesBundle, func(*backups.ArchiveWorkspace) error {
+		// Simulate the files bundle holding the config of the original
+		// controller machine.
+		s.writeAgentConfig(c, "old-password", "10.0.0.1:17070")
+		return nil
+	})
+
+	s.writeAgentConfig(c, "new-password", "10.0.0.2:17070")
+	s.archive = s.writeArchive(c, s.Meta)
+}
+
+type fakeRestorer struct {
+	s *restoreSuite
+}
+
+func (r *fakeRestorer) Restore(dumpDir string) error {
+	r.s.restored = append(r.s.restored, dumpDir)
+	return nil
+}
+
+func (r *fakeRestorer) IsSnap() bool {
+	return false
+}
+
+// countingSession answers count commands with the counts it holds,
+// keyed by collection name.
+type countingSession struct {
+	counts map[string]int
+}
+
+func (f *countingSession) DatabaseNames() ([]string, error) {
+	return []string{"juju"}, nil
+}
+
+func (f *countingSession) DB(name string) backups.Database {
+	return f
+}
+
+func (f *countingSession) Run(cmd interface{}, result interface{}) error {
+	cmdInfo, ok := cmd.(bson.D)
+	if !ok || len(cmdInfo) != 2 || cmdInfo[0].Name != "count" {
+		return errors.Errorf("unexpected cmd %#v", cmd)
+	}
+	collection := cmdInfo[0].Value.(string)
+	data, err := bson.Marshal(bson.M{"n": f.counts[collection]})
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, result)
+}
+
+func (s *restoreSuite) writeAgentConfig(c *gc.C, password, apiAddress string) {
+	conf, err := agent.NewAgentConfig(agent.AgentConfigParams{
+		Paths:             agent.Paths{DataDir: s.paths.DataDir},
+		Tag:               names.NewMachineTag("0"),
+		UpgradedToVersion: jujuversion.Current,
+		Password:          password,
+		CACert:            "ca cert",
+		APIAddresses:      []string{apiAddress},
+		Nonce:             "a nonce",
+		Controller:        testing.ControllerTag,
+		Model:             testing.ModelTag,
+		JujuDBSnapChannel: controller.DefaultJujuDBSnapChannel,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.Write(), jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) writeArchive(c *gc.C, meta *backups.Metadata) string {
+	archive, err := backupstesting.NewArchive(meta, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	filename := filepath.Join(s.paths.BackupDir, backups.FilenamePrefix+"restore.tar.gz")
+	err = os.WriteFile(filename, archive.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return filename
+}
+
+func (s *restoreSuite) restoreArgs() backups.RestoreArgs {
+	return backups.RestoreArgs{
+		DBInfo:            s.dbInfo,
+		Session:           s.session,
+		MachineID:         "0",
+		ControllerVersion: jujuversion.Current,
+	}
+}
+
+func (s *restoreSuite) TestRestore(c *gc.C) {
+	var steps []string
+	args := s.restoreArgs()
+	args.Progress = func(step string) {
+		steps = append(steps, step)
+	}
+
+	meta, err := s.api.Restore(filepath.Base(s.archive), args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.Origin.Version, gc.Equals, jujuversion.Current)
+	c.Check(s.restored, gc.HasLen, 1)
+	c.Check(filepath.Base(s.restored[0]), gc.Equals, "dump")
+	c.Check(steps, jc.DeepEquals, []string{
+		"checking controller",
+		"unpacking backup archive",
+		"checking backup archive",
+		"restoring database",
+		"restoring controller files",
+		"re-seeding agent config",
+	})
+
+	conf, err := agent.ReadConfig(agent.ConfigPath(s.paths.DataDir, names.NewMachineTag("0")))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(conf.OldPassword(), gc.Equals, "old-password")
+	addrs, err := conf.APIAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addrs, jc.DeepEquals, []string{"10.0.0.2:17070"})
+}
+
+func (s *restoreSuite) TestRestoreRemovesUploadedArchive(c *gc.C) {
+	uploaded := filepath.Join(s.paths.BackupDir, backups.UploadFilenamePrefix+"1.tar.gz")
+	err := os.Rename(s.archive, uploaded)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var steps []string
+	args := s.restoreArgs()
+	args.Progress = func(step string) {
+		steps = append(steps, step)
+	}
+	_, err = s.api.Restore(filepath.Base(uploaded), args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(steps[len(steps)-1], gc.Equals, "removing uploaded backup archive")
+	_, err = os.Stat(uploaded)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *restoreSuite) TestRestoreKeepsUploadedArchiveOnFailure(c *gc.C) {
+	uploaded := filepath.Join(s.paths.BackupDir, backups.UploadFilenamePrefix+"1.tar.gz")
+	err := os.Rename(s.archive, uploaded)
+	c.Assert(err, jc.ErrorIsNil)
+	s.session.counts["applications"] = 1
+
+	_, err = s.api.Restore(filepath.Base(uploaded), s.restoreArgs())
+	c.Assert(err, gc.NotNil)
+	_, err = os.Stat(uploaded)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) TestRestoreKeepsCreatedArchive(c *gc.C) {
+	_, err := s.api.Restore(filepath.Base(s.archive), s.restoreArgs())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(s.archive)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) TestRestoreStatus(c *gc.C) {
+	_, err := backups.ReadRestoreStatus(s.paths.DataDir)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	err = backups.WriteRestoreStatus(s.paths.DataDir, &backups.RestoreStatus{
+		ID:    "backup-id",
+		Steps: []string{"restoring database"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := backups.ReadRestoreStatus(s.paths.DataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, &backups.RestoreStatus{
+		ID:    "backup-id",
+		Steps: []string{"restoring database"},
+	})
+
+	err = backups.WriteRestoreStatus(s.paths.DataDir, &backups.RestoreStatus{
+		ID:       "backup-id",
+		Steps:    []string{"restoring database"},
+		Done:     true,
+		Error:    "failed!",
+		Metadata: s.Meta,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = backups.ReadRestoreStatus(s.paths.DataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Done, jc.IsTrue)
+	c.Check(status.Error, gc.Equals, "failed!")
+	c.Assert(status.Metadata, gc.NotNil)
+	c.Check(status.Metadata.Origin, jc.DeepEquals, s.Meta.Origin)
+}
+
+func (s *restoreSuite) TestRestoreVersionMismatch(c *gc.C) {
+	s.Meta.Origin.Version = version.MustParse("2.9.42")
+	s.archive = s.writeArchive(c, s.Meta)
+
+	_, err := s.api.Restore(filepath.Base(s.archive), s.restoreArgs())
+	c.Assert(err, gc.ErrorMatches, `restoring a backup created by juju 2.9.42 into a controller running juju .* not supported`)
+	c.Check(s.restored, gc.HasLen, 0)
+}
+
+func (s *restoreSuite) TestRestoreInvalidID(c *gc.C) {
+	_, err := s.api.Restore("/etc/passwd", s.restoreArgs())
+	c.Assert(err, gc.ErrorMatches, `backup ID "/etc/passwd" not valid`)
+
+	_, err = s.api.Restore("passwd", s.restoreArgs())
+	c.Assert(err, gc.ErrorMatches, `backup ID "passwd" not valid`)
+}
+
+func (s *restoreSuite) TestRestoreMissingDBInfo(c *gc.C) {
+	args := s.restoreArgs()
+	args.DBInfo = nil
+	_, err := s.api.Restore(filepath.Base(s.archive), args)
+	c.Assert(err, gc.ErrorMatches, `missing DBInfo not valid`)
+}
+
+func (s *restoreSuite) TestRestoreControllerInUse(c *gc.C) {
+	s.session.counts["applications"] = 2
+	s.session.counts["machines"] = 1
+
+	_, err := s.api.Restore(filepath.Base(s.archive), s.restoreArgs())
+	c.Assert(err, gc.ErrorMatches, `controller is not freshly bootstrapped \(found 2 applications, 1 workload machines\); restore into a new controller`)
+	c.Check(s.restored, gc.HasLen, 0)
+}
+
+func (s *restoreSuite) TestCheckRestoreCompatible(c *gc.C) {
+	meta := backupstesting.NewMetadata()
+	meta.Origin.Version = version.MustParse("4.0.1")
+
+	err := backups.CheckRestoreCompatible(meta, version.MustParse("4.0.3"))
+	c.Check(err, jc.ErrorIsNil)
+
+	err = backups.CheckRestoreCompatible(meta, version.MustParse("4.1.0"))
+	c.Check(err, gc.ErrorMatches, `restoring a backup created by juju 4.0.1 into a controller running juju 4.1.0 not supported`)
+
+	meta.FormatVersion = 0
+	err = backups.CheckRestoreCompatible(meta, version.MustParse("4.0.1"))
+	c.Check(err, gc.ErrorMatches, `backup format version 0 \(expected 1\) not supported`)
+}
+
+func (s *restoreSuite) TestAdd(c *gc.C) {
+	id, err := s.api.Add(strings.NewReader("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Matches, backups.FilenamePrefix+"upload-.*\\.tar\\.gz")
+
+	data, err := os.ReadFile(filepath.Join(s.paths.BackupDir, id))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+}
+
+func (s *restoreSuite) TestDBRestorer(c *gc.C) {
+	s.PatchValue(backups.GetMongorestorePath, func() (string, error) {
+		return "bogusmongorestore", nil
+	})
+	var ran []string
+	s.PatchValue(backups.RunCommand, func(cmd string, args ...string) error {
+		ran = append([]string{cmd}, args...)
+		return nil
+	})
+
+	restorer, err := backups.NewDBRestorer(s.dbInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	err = restorer.Restore("/tmp/dump")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ran, jc.DeepEquals, []string{
+		"bogusmongorestore",
+		"--ssl",
+		"--tlsInsecure",
+		"--authenticationDatabase", "admin",
+		"--host", "a",
+		"--username", "b",
+		"--password", "c",
+		"--drop",
+		"--oplogReplay",
+		"--batchSize", "10",
+		"--dir", "/tmp/dump",
+	})
+}
+
+func (s *restoreSuite) TestDBRestorerSnap(c *gc.C) {
+	s.PatchValue(backups.GetMongorestorePath, func() (string, error) {
+		return "/snap/bin/juju-db.mongorestore", nil
+	})
+	var ran []string
+	s.PatchValue(backups.RunCommand, func(cmd string, args ...string) error {
+		ran = append([]string{cmd}, args...)
+		return nil
+	})
+
+	restorer, err := backups.NewDBRestorer(s.dbInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(restorer.IsSnap(), jc.IsTrue)
+	err = restorer.Restore("/tmp/snap-private-tmp/snap.juju-db/tmp/juju-backups-1/dump")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ran[len(ran)-2:], jc.DeepEquals, []string{"--dir", "/tmp/juju-backups-1/dump"})
+}
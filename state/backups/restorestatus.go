@@ -0,0 +1,108 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v3"
+)
+
+// restoreStatusFilename is the name of the file, in the agent's data
+// directory, recording the progress of the most recent restore.
+const restoreStatusFilename = "restore-status.json"
+
+// RestoreStatus records the progress of a restore. It is kept in a
+// file rather than in the database or in memory, since the restore
+// replaces the database and the controller agent is stopped while
+// the restore runs.
+type RestoreStatus struct {
+	// ID is the ID of the backup archive being restored.
+	ID string
+
+	// Steps holds a short description of each restore step started
+	// so far.
+	Steps []string
+
+	// Done is true once the restore has finished.
+	Done bool
+
+	// Error describes why the restore failed, if it did.
+	Error string
+
+	// Metadata is the metadata of the restored backup, once the
+	// restore has succeeded.
+	Metadata *Metadata
+}
+
+type restoreStatusDoc struct {
+	ID       string          `json:"id"`
+	Steps    []string        `json:"steps,omitempty"`
+	Done     bool            `json:"done"`
+	Error    string          `json:"error,omitempty"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+}
+
+func restoreStatusPath(dataDir string) string {
+	return filepath.Join(dataDir, restoreStatusFilename)
+}
+
+// ReadRestoreStatus returns the status of the most recent restore of
+// the controller with the given agent data directory. It returns a
+// NotFound error if no restore has been started.
+func ReadRestoreStatus(dataDir string) (*RestoreStatus, error) {
+	data, err := os.ReadFile(restoreStatusPath(dataDir))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("restore status")
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var doc restoreStatusDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Annotate(err, "while reading restore status")
+	}
+	status := &RestoreStatus{
+		ID:    doc.ID,
+		Steps: doc.Steps,
+		Done:  doc.Done,
+		Error: doc.Error,
+	}
+	if len(doc.Metadata) > 0 {
+		if status.Metadata, err = NewMetadataJSONReader(bytes.NewReader(doc.Metadata)); err != nil {
+			return nil, errors.Annotate(err, "while reading restored backup metadata")
+		}
+	}
+	return status, nil
+}
+
+// WriteRestoreStatus records the status of a restore of the controller
+// with the given agent data directory, replacing any previous status.
+func WriteRestoreStatus(dataDir string, status *RestoreStatus) error {
+	doc := restoreStatusDoc{
+		ID:    status.ID,
+		Steps: status.Steps,
+		Done:  status.Done,
+		Error: status.Error,
+	}
+	if status.Metadata != nil {
+		buf, err := status.Metadata.AsJSONBuffer()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if doc.Metadata, err = io.ReadAll(buf); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(restoreStatusPath(dataDir), data, 0600))
+}
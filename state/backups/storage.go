@@ -0,0 +1,204 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// archiveSuffix is the suffix of backup archive files.
+	archiveSuffix = ".tar.gz"
+
+	// metadataSuffix is appended to the name of a backup archive to give
+	// the name of the file holding its metadata. Unlike the metadata
+	// stored inside the archive, this includes the archive checksum.
+	metadataSuffix = ".json"
+)
+
+// metadataFilename returns the name of the file holding the metadata
+// for the backup archive with the given name.
+func metadataFilename(fileName string) string {
+	return fileName + metadataSuffix
+}
+
+// writeMetadataFile writes the metadata for the backup archive with the
+// given name alongside the archive.
+func writeMetadataFile(fileName string, meta *Metadata) error {
+	data, err := meta.AsJSONBuffer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	file, err := os.Create(metadataFilename(fileName))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := io.Copy(file, data); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// readMetadataFile reads the metadata stored alongside the backup archive
+// with the given name. It returns a NotFound error if there is none.
+func readMetadataFile(fileName string) (*Metadata, error) {
+	file, err := os.Open(metadataFilename(fileName))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("metadata for backup %q", fileName)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	meta, err := NewMetadataJSONReader(file)
+	if err != nil {
+		return nil, errors.Annotatef(err, "while reading metadata for backup %q", fileName)
+	}
+	meta.SetID(fileName)
+	return meta, nil
+}
+
+// List returns the metadata of all the backup archives stored in the
+// backup directory, oldest first. The ID of each is the archive filename.
+// Archives whose metadata cannot be read are logged and left out.
+func (b *backups) List() ([]*Metadata, error) {
+	var fileNames []string
+	err := filepath.WalkDir(b.paths.BackupDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if strings.HasPrefix(name, FilenamePrefix) && strings.HasSuffix(name, archiveSuffix) {
+			fileNames = append(fileNames, path)
+		}
+		return nil
+	})
+	if os.IsNotExist(errors.Cause(err)) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "while listing backup archives")
+	}
+
+	var result []*Metadata
+	for _, fileName := range fileNames {
+		meta, err := readMetadataFile(fileName)
+		if errors.Is(err, errors.NotFound) {
+			// Archives uploaded for a restore, or created before the
+			// metadata was stored alongside them, only have the metadata
+			// that can be derived from the file itself.
+			meta, err = buildFileMetadata(fileName)
+		}
+		if err != nil {
+			// One unreadable archive must not hide all the others.
+			logger.Warningf("skipping backup %q: %v", fileName, err)
+			continue
+		}
+		result = append(result, meta)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return backupTime(result[i]).Before(backupTime(result[j]))
+	})
+	return result, nil
+}
+
+func buildFileMetadata(fileName string) (*Metadata, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	meta, err := BuildMetadata(file)
+	if err != nil {
+		return nil, errors.Annotatef(err, "while building metadata for backup %q", fileName)
+	}
+	meta.SetID(fileName)
+	return meta, nil
+}
+
+// Verify checks the backup archive with the given filename against the
+// size and checksum recorded in its metadata when it was created. It
+// returns the metadata of the archive.
+func (b *backups) Verify(fileName string) (*Metadata, error) {
+	valid, err := isValidFilepath(b.paths.BackupDir, fileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !valid {
+		return nil, errors.NotValidf("backup file %q", fileName)
+	}
+
+	meta, err := readMetadataFile(fileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if meta.ChecksumFormat() != checksumFormat {
+		return nil, errors.NotSupportedf("checksum format %q", meta.ChecksumFormat())
+	}
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, errors.Annotate(err, "while opening backup archive")
+	}
+	defer func() { _ = file.Close() }()
+
+	hasher := sha1.New()
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return nil, errors.Annotate(err, "while reading backup archive")
+	}
+	if size != meta.Size() {
+		return nil, errors.Errorf("backup archive %q has size %d, expected %d", fileName, size, meta.Size())
+	}
+	checksum := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+	if checksum != meta.Checksum() {
+		return nil, errors.Errorf("backup archive %q has checksum %q, expected %q", fileName, checksum, meta.Checksum())
+	}
+	return meta, nil
+}
+
+// Remove deletes the backup archive with the given filename, together
+// with its metadata.
+func (b *backups) Remove(fileName string) error {
+	valid, err := isValidFilepath(b.paths.BackupDir, fileName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !valid {
+		return errors.NotValidf("backup file %q", fileName)
+	}
+	if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "while removing backup archive")
+	}
+	if err := os.Remove(metadataFilename(fileName)); err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "while removing backup metadata")
+	}
+	return nil
+}
+
+// backupTime returns the time at which the backup was taken, falling
+// back to when it was started if it is not known when it finished.
+func backupTime(meta *Metadata) time.Time {
+	if meta.Finished != nil {
+		return *meta.Finished
+	}
+	return meta.Started
+}
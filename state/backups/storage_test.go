@@ -0,0 +1,163 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type storageSuite struct {
+	backupstesting.BaseSuite
+
+	paths *backups.Paths
+	api   backups.Backups
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.paths = &backups.Paths{
+		BackupDir: c.MkDir(),
+	}
+	s.api = backups.NewBackups(s.paths)
+}
+
+// addBackup writes a backup archive with the given content to the backup
+// directory, together with metadata recording its checksum.
+func (s *storageSuite) addBackup(c *gc.C, name, content string, finished time.Time) string {
+	fileName := filepath.Join(s.paths.BackupDir, name)
+	err := os.WriteFile(fileName, []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sum := sha1.Sum([]byte(content))
+	meta := backups.NewMetadata()
+	err = meta.MarkComplete(int64(len(content)), base64.StdEncoding.EncodeToString(sum[:]))
+	c.Assert(err, jc.ErrorIsNil)
+	meta.Finished = &finished
+	err = backups.WriteMetadataFile(fileName, meta)
+	c.Assert(err, jc.ErrorIsNil)
+	return fileName
+}
+
+func (s *storageSuite) TestList(c *gc.C) {
+	now := time.Now().UTC()
+	newer := s.addBackup(c, backups.FilenamePrefix+"2.tar.gz", "<newer>", now)
+	older := s.addBackup(c, backups.FilenamePrefix+"1.tar.gz", "<older>", now.Add(-time.Hour))
+
+	// Uploaded archives have no stored metadata.
+	uploaded := filepath.Join(s.paths.BackupDir, backups.UploadFilenamePrefix+"1.tar.gz")
+	err := os.WriteFile(uploaded, []byte("<uploaded>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Other files are ignored.
+	err = os.WriteFile(filepath.Join(s.paths.BackupDir, "other.tar.gz"), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	metas, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metas, gc.HasLen, 3)
+	byID := make(map[string]*backups.Metadata)
+	var ids []string
+	for _, meta := range metas {
+		byID[meta.ID()] = meta
+		if meta.ID() != uploaded {
+			ids = append(ids, meta.ID())
+		}
+	}
+	c.Check(ids, jc.DeepEquals, []string{older, newer})
+	c.Check(byID[uploaded].Size(), gc.Equals, int64(len("<uploaded>")))
+	c.Check(byID[newer].FormatVersion, gc.Equals, int64(1))
+}
+
+func (s *storageSuite) TestListSkipsUnreadable(c *gc.C) {
+	good := s.addBackup(c, backups.FilenamePrefix+"1.tar.gz", "<good>", time.Now().UTC())
+	bad := filepath.Join(s.paths.BackupDir, backups.FilenamePrefix+"2.tar.gz")
+	err := os.WriteFile(bad, []byte("<bad>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.WriteFile(bad+".json", []byte("not json"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	metas, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metas, gc.HasLen, 1)
+	c.Check(metas[0].ID(), gc.Equals, good)
+}
+
+func (s *storageSuite) TestListMissingBackupDir(c *gc.C) {
+	s.paths.BackupDir = filepath.Join(c.MkDir(), "missing")
+	metas, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metas, gc.HasLen, 0)
+}
+
+func (s *storageSuite) TestVerify(c *gc.C) {
+	fileName := s.addBackup(c, backups.FilenamePrefix+"1.tar.gz", "<archive>", time.Now())
+
+	meta, err := s.api.Verify(fileName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.ID(), gc.Equals, fileName)
+	c.Check(meta.Size(), gc.Equals, int64(len("<archive>")))
+}
+
+func (s *storageSuite) TestVerifyChecksumMismatch(c *gc.C) {
+	fileName := s.addBackup(c, backups.FilenamePrefix+"1.tar.gz", "<archive>", time.Now())
+	err := os.WriteFile(fileName, []byte("<ARCHIVE>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.api.Verify(fileName)
+	c.Assert(err, gc.ErrorMatches, `backup archive ".*" has checksum ".*", expected ".*"`)
+}
+
+func (s *storageSuite) TestVerifySizeMismatch(c *gc.C) {
+	fileName := s.addBackup(c, backups.FilenamePrefix+"1.tar.gz", "<archive>", time.Now())
+	err := os.WriteFile(fileName, []byte("<truncated"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.api.Verify(fileName)
+	c.Assert(err, gc.ErrorMatches, `backup archive ".*" has size 10, expected 9`)
+}
+
+func (s *storageSuite) TestVerifyMissingMetadata(c *gc.C) {
+	fileName := filepath.Join(s.paths.BackupDir, backups.UploadFilenamePrefix+"1.tar.gz")
+	err := os.WriteFile(fileName, []byte("<uploaded>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.api.Verify(fileName)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *storageSuite) TestVerifyInvalidFilename(c *gc.C) {
+	_, err := s.api.Verify("/etc/passwd")
+	c.Assert(err, gc.ErrorMatches, `backup file "/etc/passwd" not valid`)
+}
+
+func (s *storageSuite) TestRemove(c *gc.C) {
+	fileName := s.addBackup(c, backups.FilenamePrefix+"1.tar.gz", "<archive>", time.Now())
+
+	err := s.api.Remove(fileName)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = os.Stat(fileName)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+	_, err = os.Stat(fileName + ".json")
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *storageSuite) TestRemoveInvalidFilename(c *gc.C) {
+	err := s.api.Remove("/etc/passwd")
+	c.Assert(err, gc.ErrorMatches, `backup file "/etc/passwd" not valid`)
+}
@@ -41,6 +41,9 @@ type FakeBackups struct {
 	InstanceId instance.Id
 	// ArchiveArg holds the backup archive that was passed in.
 	ArchiveArg io.Reader
+	// RestoreHook, if set, is called by Restore after reporting
+	// progress and before returning.
+	RestoreHook func()
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -69,3 +72,44 @@ func (b *FakeBackups) Get(id string) (*backups.Metadata, io.ReadCloser, error) {
 	b.IDArg = id
 	return b.Meta, b.Archive, b.Error
 }
+
+// Add stores the archive and returns its ID.
+func (b *FakeBackups) Add(archive io.Reader) (string, error) {
+	b.Calls = append(b.Calls, "Add")
+	b.ArchiveArg = archive
+	return b.Filename, b.Error
+}
+
+// Restore restores the specified archive and returns its metadata.
+func (b *FakeBackups) Restore(id string, args backups.RestoreArgs) (*backups.Metadata, error) {
+	b.Calls = append(b.Calls, "Restore")
+	b.IDArg = id
+	b.DBInfoArg = args.DBInfo
+	if args.Progress != nil {
+		args.Progress("restoring")
+	}
+	if b.RestoreHook != nil {
+		b.RestoreHook()
+	}
+	return b.Meta, b.Error
+}
+
+// List returns the metadata list.
+func (b *FakeBackups) List() ([]*backups.Metadata, error) {
+	b.Calls = append(b.Calls, "List")
+	return b.MetaList, b.Error
+}
+
+// Verify verifies the specified archive and returns its metadata.
+func (b *FakeBackups) Verify(fileName string) (*backups.Metadata, error) {
+	b.Calls = append(b.Calls, "Verify")
+	b.IDArg = fileName
+	return b.Meta, b.Error
+}
+
+// Remove removes the specified archive.
+func (b *FakeBackups) Remove(fileName string) error {
+	b.Calls = append(b.Calls, "Remove")
+	b.IDArg = fileName
+	return b.Error
+}
@@ -368,10 +368,7 @@ func (st *State) removeCloudOps(name string) ([]txn.Op, error) {
 	}
 	ops = append(ops, credOps...)
 
-	permPattern := bson.M{
-		"_id": bson.M{"$regex": "^" + cloudGlobalKey(name) + "#"},
-	}
-	permOps, err := st.removeInCollectionOps(permissionsC, permPattern)
+	permOps, err := st.removeObjectPermissionsOps(cloudGlobalKey(name))
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/mgo/v3/bson"
 	"github.com/juju/mgo/v3/txn"
@@ -43,8 +44,16 @@ func (st *State) CreateCloudAccess(cloud string, user names.UserTag, access perm
 	return errors.Trace(err)
 }
 
-// GetCloudAccess gets the access permission for the specified user on a cloud.
+// GetCloudAccess gets the access permission for the specified user on a
+// cloud, including any access granted to groups the user is a member of.
 func (st *State) GetCloudAccess(cloud string, user names.UserTag) (permission.Access, error) {
+	access, err := st.cloudDirectAccess(cloud, user)
+	return st.withUserGroupsAccess(user, names.NewCloudTag(cloud), access, err)
+}
+
+// cloudDirectAccess gets the access permission granted directly to the
+// specified user on a cloud.
+func (st *State) cloudDirectAccess(cloud string, user names.UserTag) (permission.Access, error) {
 	perm, err := st.userPermission(cloudGlobalKey(cloud), userGlobalKey(userAccessID(user)))
 	if err != nil {
 		return "", errors.Trace(err)
@@ -72,7 +81,7 @@ func (st *State) UpdateCloudAccess(cloud string, user names.UserTag, access perm
 	}
 
 	buildTxn := func(int) ([]txn.Op, error) {
-		_, err := st.GetCloudAccess(cloud, user)
+		_, err := st.cloudDirectAccess(cloud, user)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -87,7 +96,7 @@ func (st *State) UpdateCloudAccess(cloud string, user names.UserTag, access perm
 // RemoveCloudAccess removes the access permission for a user on a cloud.
 func (st *State) RemoveCloudAccess(cloud string, user names.UserTag) error {
 	buildTxn := func(int) ([]txn.Op, error) {
-		_, err := st.GetCloudAccess(cloud, user)
+		_, err := st.cloudDirectAccess(cloud, user)
 		if err != nil {
 			return nil, err
 		}
@@ -163,15 +172,24 @@ func (st *State) cloudNamesForUser(user names.UserTag) ([]string, error) {
 
 	var doc permissionDoc
 	iter := query.Iter()
-	var cloudNames []string
+	cloudNames := set.NewStrings()
 	for iter.Next(&doc) {
 		cloudName := strings.TrimPrefix(doc.ObjectGlobalKey, "cloud#")
-		cloudNames = append(cloudNames, cloudName)
+		cloudNames.Add(cloudName)
 	}
 	if err := iter.Close(); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return cloudNames, nil
+
+	// Include the clouds the user can see through their groups.
+	groupAccess, err := st.userGroupsCloudAccess(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for cloudName := range groupAccess {
+		cloudNames.Add(cloudName)
+	}
+	return cloudNames.SortedValues(), nil
 }
 
 // fillInCloudUserAccess fills in the Access rights for this user on the clouds (but not other users).
@@ -206,5 +224,22 @@ func (st *State) fillInCloudUserAccess(user names.UserTag, cloudInfo []CloudInfo
 			details.Access = access
 		}
 	}
-	return iter.Close()
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Access granted to the user's groups counts if it is greater.
+	groupAccess, err := st.userGroupsCloudAccess(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for cloudName, access := range groupAccess {
+		cloudIdx, ok := indexByName[cloudName]
+		if !ok {
+			continue
+		}
+		details := &cloudInfo[cloudIdx]
+		details.Access = greaterAccess(names.CloudTagKind, details.Access, access)
+	}
+	return nil
 }
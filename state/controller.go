@@ -150,11 +150,36 @@ func (st *State) checkValidControllerConfig(updateAttrs map[string]interface{},
 				return errors.Annotatef(err, "invalid config %q=%q", k, cVal)
 			}
 		}
+		if k == jujucontroller.SecretBackendEncryptionKey {
+			if err := st.checkSecretBackendEncryptionKeyUnchanged(updateAttrs[k]); err != nil {
+				return errors.Trace(err)
+			}
+		}
 	}
 	for _, r := range removeAttrs {
 		if err := checkUpdateControllerConfig(r); err != nil {
 			return errors.Trace(err)
 		}
+		if r == jujucontroller.SecretBackendEncryptionKey {
+			if err := st.checkSecretBackendEncryptionKeyUnchanged(""); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkSecretBackendEncryptionKeyUnchanged returns an error if the
+// secret backend encryption key has already been set to a different
+// value. Content stored in file secret backends can only be read with
+// the key it was encrypted with.
+func (st *State) checkSecretBackendEncryptionKeyUnchanged(value interface{}) error {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current := cfg.SecretBackendEncryptionKey(); current != "" && current != value {
+		return errors.Errorf("cannot change %q once it is set", jujucontroller.SecretBackendEncryptionKey)
 	}
 	return nil
 }
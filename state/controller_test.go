@@ -38,7 +38,11 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.AllowModelAccessKey,
 		controller.APIPortOpenDelay,
 		controller.AuditLogExcludeMethods,
+		controller.AuditLogHTTPURL,
+		controller.AuditLogSyslogHost,
 		controller.AutocertURLKey,
+		controller.BackupRetentionCount,
+		controller.BackupRetentionPeriod,
 		controller.AutocertDNSNameKey,
 		controller.CAASImageRepo,
 		controller.CAASOperatorImagePath,
@@ -48,6 +52,11 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.IdentityURL,
 		controller.IdentityPublicKey,
 		controller.LoginTokenRefreshURL,
+		controller.OIDCIssuerURL,
+		controller.OIDCClientID,
+		controller.OIDCUsernameClaim,
+		controller.OIDCGroupsClaim,
+		controller.OIDCGroupAccess,
 		controller.JujuDBSnapChannel,
 		controller.JujuHASpace,
 		controller.JujuManagementSpace,
@@ -174,6 +183,32 @@ func (s *ControllerSuite) TestUpdateControllerConfigValidates(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "thing" at position 1`)
 }
 
+func (s *ControllerSuite) TestUpdateControllerConfigSecretBackendEncryptionKeySetOnce(c *gc.C) {
+	key := "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SecretBackendEncryptionKey: key,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Setting the same key again is fine.
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SecretBackendEncryptionKey: key,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SecretBackendEncryptionKey: "ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA=",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot change "secret-backend-encryption-key" once it is set`)
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.SecretBackendEncryptionKey})
+	c.Assert(err, gc.ErrorMatches, `cannot change "secret-backend-encryption-key" once it is set`)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SecretBackendEncryptionKey(), gc.Equals, key)
+}
+
 func (s *ControllerSuite) TestUpdatingUnknownName(c *gc.C) {
 	err := s.State.UpdateControllerConfig(map[string]interface{}{
 		"ana-ng": "majestic",
@@ -64,6 +64,10 @@ type Filesystem interface {
 	// Detachable reports whether or not the filesystem is detachable.
 	Detachable() bool
 
+	// RequestedSize returns the size in MiB that the filesystem is to be
+	// grown to, if a resize has been requested and not yet completed.
+	RequestedSize() (uint64, bool)
+
 	// Releasing reports whether or not the filesystem is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
@@ -115,6 +119,7 @@ type filesystemDoc struct {
 	AttachmentCount int               `bson:"attachmentcount"`
 	Info            *FilesystemInfo   `bson:"info,omitempty"`
 	Params          *FilesystemParams `bson:"params,omitempty"`
+	RequestedSize   uint64            `bson:"requestedsize,omitempty"`
 
 	// HostId is the ID of the host that a non-detachable
 	// volume is initially attached to. We use this to identify
@@ -155,6 +160,11 @@ type FilesystemParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if non-empty, is the provider-allocated unique ID of
+	// the snapshot that the filesystem, or its backing volume, is to be
+	// created from.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // FilesystemInfo describes information about a filesystem.
@@ -252,6 +262,11 @@ func (f *filesystem) Releasing() bool {
 	return f.doc.Releasing
 }
 
+// RequestedSize is required to implement Filesystem.
+func (f *filesystem) RequestedSize() (uint64, bool) {
+	return f.doc.RequestedSize, f.doc.RequestedSize != 0
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (status.StatusInfo, error) {
 	return getStatus(f.mb.db(), filesystemGlobalKey(f.FilesystemTag().Id()), "filesystem")
@@ -537,6 +552,7 @@ func (sb *storageBackend) removeMachineFilesystemsOps(m *Machine) ([]txn.Op, err
 					Assert: txn.DocExists,
 					Remove: true,
 				},
+				removeStorageUsageOp(f.doc.StorageId),
 			)
 		}
 		fsOps, err := removeFilesystemOps(sb, f, f.doc.Releasing, false, nil)
@@ -913,14 +929,17 @@ func removeFilesystemOps(sb *storageBackend, filesystem Filesystem, release, for
 // filesystem into the model. The model will start out with
 // the status "detached". The filesystem and associated backing
 // volume (if any) will be associated with the given storage
-// name, with the allocated storage tag being returned.
+// name, with the allocated storage tag being returned. If an
+// application name is specified, the storage may only be attached
+// to units of that application.
 func (sb *storageBackend) AddExistingFilesystem(
 	info FilesystemInfo,
 	backingVolume *VolumeInfo,
 	storageName string,
+	application string,
 ) (_ names.StorageTag, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add existing filesystem")
-	if err := validateAddExistingFilesystem(sb, info, backingVolume, storageName); err != nil {
+	if err := validateAddExistingFilesystem(sb, info, backingVolume, storageName, application); err != nil {
 		return names.StorageTag{}, errors.Trace(err)
 	}
 	storageId, err := newStorageInstanceId(sb.mb, storageName)
@@ -952,6 +971,7 @@ func (sb *storageBackend) AddExistingFilesystem(
 			Id:          storageId,
 			Kind:        StorageKindFilesystem,
 			StorageName: storageName,
+			Application: application,
 			Constraints: storageInstanceConstraints{
 				Pool: info.Pool,
 				Size: info.Size,
@@ -972,6 +992,7 @@ func validateAddExistingFilesystem(
 	info FilesystemInfo,
 	backingVolume *VolumeInfo,
 	storageName string,
+	application string,
 ) error {
 	if !storage.IsValidPoolName(info.Pool) {
 		return errors.NotValidf("pool name %q", info.Pool)
@@ -979,6 +1000,9 @@ func validateAddExistingFilesystem(
 	if !storageNameRE.MatchString(storageName) {
 		return errors.NotValidf("storage name %q", storageName)
 	}
+	if application != "" && !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
 	if backingVolume == nil {
 		if info.FilesystemId == "" {
 			return errors.NotValidf("empty filesystem ID")
@@ -1101,10 +1125,11 @@ func (sb *storageBackend) addFilesystemOps(params FilesystemParams, hostId strin
 			params.filesystemId = filesystemTag.String()
 		}
 		volumeParams := VolumeParams{
-			params.storage,
-			params.volumeInfo,
-			params.Pool,
-			params.Size,
+			storage:    params.storage,
+			volumeInfo: params.volumeInfo,
+			Pool:       params.Pool,
+			Size:       params.Size,
+			SnapshotId: params.SnapshotId,
 		}
 		volumeOps, volumeTag, err = sb.addVolumeOps(volumeParams, hostId)
 		if err != nil {
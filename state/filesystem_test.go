@@ -1497,7 +1497,7 @@ func (s *FilesystemIAASModelSuite) TestAddExistingFilesystem(c *gc.C) {
 		Size:         123,
 		FilesystemId: "foo",
 	}
-	storageTag, err := s.storageBackend.AddExistingFilesystem(fsInfoIn, nil, "pgdata")
+	storageTag, err := s.storageBackend.AddExistingFilesystem(fsInfoIn, nil, "pgdata", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(storageTag, gc.Equals, names.NewStorageTag("pgdata/0"))
 
@@ -1517,7 +1517,7 @@ func (s *FilesystemIAASModelSuite) TestAddExistingFilesystemEmptyFilesystemId(c
 		Pool: "modelscoped",
 		Size: 123,
 	}
-	_, err := s.storageBackend.AddExistingFilesystem(fsInfoIn, nil, "pgdata")
+	_, err := s.storageBackend.AddExistingFilesystem(fsInfoIn, nil, "pgdata", "")
 	c.Assert(err, gc.ErrorMatches, "cannot add existing filesystem: empty filesystem ID not valid")
 }
 
@@ -1531,7 +1531,7 @@ func (s *FilesystemIAASModelSuite) TestAddExistingFilesystemVolumeBacked(c *gc.C
 		Size:     123,
 		VolumeId: "foo",
 	}
-	storageTag, err := s.storageBackend.AddExistingFilesystem(fsInfoIn, &volInfoIn, "pgdata")
+	storageTag, err := s.storageBackend.AddExistingFilesystem(fsInfoIn, &volInfoIn, "pgdata", "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(storageTag, gc.Equals, names.NewStorageTag("pgdata/0"))
 
@@ -1563,7 +1563,7 @@ func (s *FilesystemIAASModelSuite) TestAddExistingFilesystemVolumeBackedVolumeIn
 		Size:         123,
 		FilesystemId: "foo",
 	}
-	_, err := s.storageBackend.AddExistingFilesystem(fsInfo, nil, "pgdata")
+	_, err := s.storageBackend.AddExistingFilesystem(fsInfo, nil, "pgdata", "")
 	c.Assert(err, gc.ErrorMatches, "cannot add existing filesystem: backing volume info missing")
 }
 
@@ -1578,7 +1578,7 @@ func (s *FilesystemStateSuite) TestAddExistingFilesystemVolumeBackedFilesystemId
 		Size:     123,
 		VolumeId: "foo",
 	}
-	_, err := s.storageBackend.AddExistingFilesystem(fsInfo, &volInfo, "pgdata")
+	_, err := s.storageBackend.AddExistingFilesystem(fsInfo, &volInfo, "pgdata", "")
 	c.Assert(err, gc.ErrorMatches, "cannot add existing filesystem: non-empty filesystem ID with backing volume not valid")
 }
 
@@ -1591,10 +1591,36 @@ func (s *FilesystemStateSuite) TestAddExistingFilesystemVolumeBackedEmptyVolumeI
 		Pool: "modelscoped-block",
 		Size: 123,
 	}
-	_, err := s.storageBackend.AddExistingFilesystem(fsInfo, &volInfo, "pgdata")
+	_, err := s.storageBackend.AddExistingFilesystem(fsInfo, &volInfo, "pgdata", "")
 	c.Assert(err, gc.ErrorMatches, "cannot add existing filesystem: empty backing volume ID not valid")
 }
 
+func (s *FilesystemIAASModelSuite) TestAddExistingFilesystemApplication(c *gc.C) {
+	fsInfo := state.FilesystemInfo{
+		Pool:         "modelscoped",
+		Size:         123,
+		FilesystemId: "foo",
+	}
+	storageTag, err := s.storageBackend.AddExistingFilesystem(fsInfo, nil, "pgdata", "postgresql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.storageBackend.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	app, ok := si.Application()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(app, gc.Equals, names.NewApplicationTag("postgresql"))
+}
+
+func (s *FilesystemIAASModelSuite) TestAddExistingFilesystemInvalidApplication(c *gc.C) {
+	fsInfo := state.FilesystemInfo{
+		Pool:         "modelscoped",
+		Size:         123,
+		FilesystemId: "foo",
+	}
+	_, err := s.storageBackend.AddExistingFilesystem(fsInfo, nil, "pgdata", "Postgres!")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: application name "Postgres!" not valid`)
+}
+
 func (s *FilesystemStateSuite) setupFilesystemAttachment(c *gc.C, pool string) (state.Filesystem, *state.Machine) {
 	machine, err := s.st.AddOneMachine(state.MachineTemplate{
 		Base: state.UbuntuBase("12.10"),
@@ -2910,7 +2910,12 @@ func (e *exporter) addStorage(instance *storageInstance, attachments []names.Uni
 	if !ok {
 		owner = nil
 	}
-	cons := description.StorageInstanceConstraints(instance.doc.Constraints)
+	// The snapshot that the storage was created from is not exported,
+	// as snapshots are not migrated.
+	cons := description.StorageInstanceConstraints{
+		Pool: instance.doc.Constraints.Pool,
+		Size: instance.doc.Constraints.Size,
+	}
 	args := description.StorageArgs{
 		Tag:         instance.StorageTag(),
 		Kind:        instance.Kind().String(),
@@ -108,17 +108,8 @@ func (ctrl *Controller) Import(model description.Model) (_ *Model, _ *State, err
 			}
 		} else if err != nil {
 			return nil, nil, errors.Trace(err)
-		} else {
-			// ensure existing creds match
-			if existingCreds.AuthType != creds.AuthType() {
-				return nil, nil, errors.Errorf("credential auth type mismatch: %q != %q", existingCreds.AuthType, creds.AuthType())
-			}
-			if !credentialAttributesMatch(existingCreds.Attributes, creds.Attributes()) {
-				return nil, nil, errors.Errorf("credential attribute mismatch: %v != %v", existingCreds.Attributes, creds.Attributes())
-			}
-			if existingCreds.Revoked {
-				return nil, nil, errors.Errorf("credential %q is revoked", credID)
-			}
+		} else if err := checkImportedCredential(existingCreds, creds, credID); err != nil {
+			return nil, nil, errors.Trace(err)
 		}
 
 		args.CloudCredential = credTag
@@ -260,6 +251,76 @@ func credentialAttributesMatch(a map[string]string, b map[string]string) bool {
 	return reflect.DeepEqual(a, b)
 }
 
+// checkImportedCredential ensures that the existing cloud credential
+// matches the one used by the imported model.
+func checkImportedCredential(existingCreds Credential, creds description.CloudCredential, credID string) error {
+	if existingCreds.AuthType != creds.AuthType() {
+		return errors.Errorf("credential auth type mismatch: %q != %q", existingCreds.AuthType, creds.AuthType())
+	}
+	if !credentialAttributesMatch(existingCreds.Attributes, creds.Attributes()) {
+		return errors.Errorf("credential attribute mismatch: %v != %v", existingCreds.Attributes, creds.Attributes())
+	}
+	if existingCreds.Revoked {
+		return errors.Errorf("credential %q is revoked", credID)
+	}
+	return nil
+}
+
+// ValidateImport checks, without writing anything to the database,
+// that the model could be imported into the controller. Rather than
+// stopping at the first issue, every issue found is returned.
+func (ctrl *Controller) ValidateImport(model description.Model) ([]error, error) {
+	st, err := ctrl.pool.SystemState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var problems []error
+	if err := model.Validate(); err != nil {
+		problems = append(problems, errors.Annotate(err, "invalid model"))
+	}
+
+	modelUUID := model.Tag().Id()
+	if modelExists, err := st.ModelExists(modelUUID); err != nil {
+		return nil, errors.Trace(err)
+	} else if modelExists {
+		problems = append(problems, errors.AlreadyExistsf("model %s", modelUUID))
+	}
+
+	if model.Type() != "" {
+		if _, err := ParseModelType(model.Type()); err != nil {
+			problems = append(problems, errors.Trace(err))
+		}
+	}
+	if _, err := modelConfig(model.Config()); err != nil {
+		problems = append(problems, errors.Annotate(err, "invalid model config"))
+	}
+
+	modelCloud, err := st.Cloud(model.Cloud())
+	if errors.Is(err, errors.NotFound) {
+		problems = append(problems, errors.NotFoundf("cloud %q", model.Cloud()))
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else if model.CloudRegion() != "" {
+		if _, err := cloud.RegionByName(modelCloud.Regions, model.CloudRegion()); err != nil {
+			problems = append(problems, errors.Annotatef(err, "cloud %q", model.Cloud()))
+		}
+	}
+
+	if creds := model.CloudCredential(); creds != nil {
+		credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+		if !names.IsValidCloudCredential(credID) {
+			problems = append(problems, errors.NotValidf("cloud credential ID %q", credID))
+		} else if existingCreds, err := st.CloudCredential(names.NewCloudCredentialTag(credID)); err == nil {
+			if err := checkImportedCredential(existingCreds, creds, credID); err != nil {
+				problems = append(problems, errors.Trace(err))
+			}
+		} else if !errors.Is(err, errors.NotFound) {
+			return nil, errors.Trace(err)
+		}
+	}
+	return problems, nil
+}
+
 // modelConfig creates a config for the model being imported.
 func modelConfig(attrs map[string]interface{}) (*config.Config, error) {
 	// If the tools version is before 2.9.35, the default-series
@@ -2432,7 +2493,10 @@ func (i *importer) addStorageInstance(storage description.Storage) error {
 
 func (i *importer) storageInstanceConstraints(storage description.Storage) storageInstanceConstraints {
 	if cons, ok := storage.Constraints(); ok {
-		return storageInstanceConstraints(cons)
+		return storageInstanceConstraints{
+			Pool: cons.Pool,
+			Size: cons.Size,
+		}
 	}
 	// Older versions of Juju did not record storage constraints on the
 	// storage instance, so we must do what we do during upgrade steps:
@@ -74,6 +74,45 @@ func (s *MigrationImportSuite) TestExisting(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
 }
 
+func (s *MigrationImportSuite) TestValidateImportExisting(c *gc.C) {
+	out, err := s.State.Export(map[string]string{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	problems, err := s.Controller.ValidateImport(out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0], jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *MigrationImportSuite) TestValidateImportNewModel(c *gc.C) {
+	out, err := s.State.Export(map[string]string{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	in := newModel(out, utils.MustNewUUID().String(), "new")
+	problems, err := s.Controller.ValidateImport(in)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 0)
+
+	// Nothing is written.
+	exists, err := s.State.ModelExists(in.Tag().Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exists, jc.IsFalse)
+}
+
+func (s *MigrationImportSuite) TestValidateImportUnknownRegion(c *gc.C) {
+	out, err := s.State.Export(map[string]string{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	in := &regionModel{
+		Model:  newModel(out, utils.MustNewUUID().String(), "new"),
+		region: "nowhere",
+	}
+	problems, err := s.Controller.ValidateImport(in)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0], gc.ErrorMatches, `cloud ".*": region "nowhere" not found .*`)
+}
+
 func (s *MigrationImportSuite) importModel(
 	c *gc.C, st *state.State, transform ...func(map[string]interface{}),
 ) (*state.Model, *state.State) {
@@ -3532,6 +3571,15 @@ func (s *MigrationImportSuite) TestApplicationWithProvisioningState(c *gc.C) {
 // can use all the other data to validate imports. An owner and name of the
 // model are unique together in a controller.
 // Also, optionally overwrite the return value of certain methods
+type regionModel struct {
+	description.Model
+	region string
+}
+
+func (m *regionModel) CloudRegion() string {
+	return m.region
+}
+
 func newModel(m description.Model, uuid, name string) *mockModel {
 	return &mockModel{Model: m, uuid: uuid, name: name}
 }
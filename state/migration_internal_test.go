@@ -118,6 +118,17 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
+		// User groups are controller wide, and aren't migrated either.
+		userGroupsC,
+		// The audit log is a record of what happened on this
+		// controller.
+		auditLogC,
+		// Storage snapshots are held by the source cloud, and
+		// can't be used by the target controller.
+		storageSnapshotsC,
+		// Storage usage is reported again by the units after
+		// migration.
+		storageUsageC,
 		// Controller users contain extra data about users therefore
 		// are not migrated either.
 		controllerUsersC,
@@ -149,6 +160,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		migrationsStatusC,
 		migrationsActiveC,
 		migrationsMinionSyncC,
+		migrationPlansC,
 
 		// The container ref document is primarily there to keep track
 		// of a particular machine's containers. The migration format
@@ -772,8 +784,9 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
-		"HostId",    // recreated from pool properties
-		"Releasing", // only when dying; can't migrate dying storage
+		"HostId",        // recreated from pool properties
+		"Releasing",     // only when dying; can't migrate dying storage
+		"RequestedSize", // resizes in progress are not migrated
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -787,7 +800,9 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
 		"HardwareId", "WWN", "Size", "Pool", "VolumeId", "Persistent"))
 	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool",
+		"SnapshotId", // snapshots are not migrated
+	))
 }
 
 func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
@@ -815,8 +830,9 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
-		"HostId",    // recreated from pool properties
-		"Releasing", // only when dying; can't migrate dying storage
+		"HostId",        // recreated from pool properties
+		"Releasing",     // only when dying; can't migrate dying storage
+		"RequestedSize", // resizes in progress are not migrated
 	)
 	migrated := set.NewStrings(
 		"FilesystemId",
@@ -831,7 +847,9 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 	s.AssertExportedFields(c, FilesystemInfo{}, set.NewStrings(
 		"Size", "Pool", "FilesystemId"))
 	s.AssertExportedFields(c, FilesystemParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool",
+		"SnapshotId", // snapshots are not migrated
+	))
 }
 
 func (s *MigrationSuite) TestFilesystemAttachmentDocFields(c *gc.C) {
@@ -859,7 +877,8 @@ func (s *MigrationSuite) TestStorageInstanceDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
-		"Releasing", // only when dying; can't migrate dying storage
+		"Releasing",   // only when dying; can't migrate dying storage
+		"Application", // not yet supported by the model description
 	)
 	migrated := set.NewStrings(
 		"Id",
@@ -0,0 +1,491 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+	"github.com/juju/names/v5"
+	jujutxn "github.com/juju/txn/v3"
+
+	"github.com/juju/juju/core/migration"
+)
+
+// This file contains functionality for managing the state documents
+// used by Juju to track plans for migrating many models to another
+// controller.
+
+// MigrationPlanModelStatus describes the progress of the migration of
+// a single model in a migration plan.
+type MigrationPlanModelStatus string
+
+const (
+	// MigrationPlanQueued indicates that the model is waiting to be
+	// migrated, either for the first time or after a failed attempt.
+	MigrationPlanQueued MigrationPlanModelStatus = "queued"
+
+	// MigrationPlanRunning indicates that a migration of the model
+	// is in progress.
+	MigrationPlanRunning MigrationPlanModelStatus = "running"
+
+	// MigrationPlanSucceeded indicates that the model was migrated.
+	MigrationPlanSucceeded MigrationPlanModelStatus = "succeeded"
+
+	// MigrationPlanFailed indicates that every attempt to migrate
+	// the model failed.
+	MigrationPlanFailed MigrationPlanModelStatus = "failed"
+
+	// MigrationPlanCancelled indicates that the plan was cancelled
+	// before the model was migrated.
+	MigrationPlanCancelled MigrationPlanModelStatus = "cancelled"
+)
+
+// IsFinal returns whether no further migrations will be attempted for
+// a model with the status.
+func (s MigrationPlanModelStatus) IsFinal() bool {
+	return s == MigrationPlanSucceeded || s == MigrationPlanFailed || s == MigrationPlanCancelled
+}
+
+// MigrationPlanSpec holds the information required to create a
+// MigrationPlan.
+type MigrationPlanSpec struct {
+	InitiatedBy names.UserTag
+	TargetInfo  migration.TargetInfo
+
+	// ModelUUIDs holds the models to migrate, in the order in which
+	// they are to be migrated.
+	ModelUUIDs []string
+
+	// Concurrency is the maximum number of migrations that may be
+	// running at any one time.
+	Concurrency int
+
+	// MaxAttempts is the number of times the migration of each
+	// model is attempted before it is considered to have failed.
+	MaxAttempts int
+
+	// WindowStart and WindowEnd optionally restrict the time during
+	// which migrations may be started. A zero time is unbounded.
+	WindowStart time.Time
+	WindowEnd   time.Time
+}
+
+// Validate returns an error if the MigrationPlanSpec contains bad
+// data. Nil is returned otherwise.
+func (spec *MigrationPlanSpec) Validate() error {
+	if !names.IsValidUser(spec.InitiatedBy.Id()) {
+		return errors.NotValidf("InitiatedBy")
+	}
+	if len(spec.ModelUUIDs) == 0 {
+		return errors.NotValidf("empty model list")
+	}
+	seen := set.NewStrings()
+	for _, uuid := range spec.ModelUUIDs {
+		if !names.IsValidModel(uuid) {
+			return errors.NotValidf("model UUID %q", uuid)
+		}
+		if seen.Contains(uuid) {
+			return errors.NotValidf("duplicate model %q", uuid)
+		}
+		seen.Add(uuid)
+	}
+	if spec.Concurrency < 1 {
+		return errors.NotValidf("concurrency %d", spec.Concurrency)
+	}
+	if spec.MaxAttempts < 1 {
+		return errors.NotValidf("max attempts %d", spec.MaxAttempts)
+	}
+	if !spec.WindowStart.IsZero() && !spec.WindowEnd.IsZero() && !spec.WindowEnd.After(spec.WindowStart) {
+		return errors.NotValidf("window ending before it starts")
+	}
+	return spec.TargetInfo.Validate()
+}
+
+// MigrationPlanModel describes the progress of the migration of a
+// single model in a migration plan.
+type MigrationPlanModel struct {
+	ModelUUID string
+	Status    MigrationPlanModelStatus
+
+	// ModelName is the name of the model when the plan was created,
+	// kept so that the plan can still be reported once the model
+	// has left the controller.
+	ModelName string
+
+	// Attempts is the number of migrations started for the model.
+	Attempts int
+
+	// MigrationId is the ID of the most recent migration started
+	// for the model.
+	MigrationId string
+
+	// Message holds details of the most recent failure.
+	Message string
+
+	// RetryAfter is the time before which the migration of a queued
+	// model will not be retried, or the zero time if it may be
+	// started at once.
+	RetryAfter time.Time
+}
+
+// MigrationPlan represents a plan to migrate a number of models to
+// another controller.
+type MigrationPlan struct {
+	st  *State
+	doc migrationPlanDoc
+}
+
+// migrationPlanDoc holds a migration plan. These are written into
+// migrationPlansC.
+type migrationPlanDoc struct {
+	Id          string `bson:"_id"`
+	InitiatedBy string `bson:"initiated-by"`
+	Created     int64  `bson:"created"`
+
+	TargetController      string   `bson:"target-controller"`
+	TargetControllerAlias string   `bson:"target-controller-alias"`
+	TargetAddrs           []string `bson:"target-addrs"`
+	TargetCACert          string   `bson:"target-cacert"`
+	TargetAuthTag         string   `bson:"target-entity"`
+	TargetPassword        string   `bson:"target-password,omitempty"`
+	TargetMacaroons       string   `bson:"target-macaroons,omitempty"`
+
+	Concurrency int   `bson:"concurrency"`
+	MaxAttempts int   `bson:"max-attempts"`
+	WindowStart int64 `bson:"window-start,omitempty"`
+	WindowEnd   int64 `bson:"window-end,omitempty"`
+	Cancelled   bool  `bson:"cancelled,omitempty"`
+
+	Models []migrationPlanModelDoc `bson:"models"`
+}
+
+type migrationPlanModelDoc struct {
+	ModelUUID   string `bson:"model-uuid"`
+	ModelName   string `bson:"model-name"`
+	Status      string `bson:"status"`
+	Attempts    int    `bson:"attempts"`
+	MigrationId string `bson:"migration-id,omitempty"`
+	Message     string `bson:"message,omitempty"`
+	RetryAfter  int64  `bson:"retry-after,omitempty"`
+}
+
+// Id returns the unique identifier of the migration plan.
+func (p *MigrationPlan) Id() string {
+	return p.doc.Id
+}
+
+// InitiatedBy returns the name of the user who created the plan.
+func (p *MigrationPlan) InitiatedBy() string {
+	return p.doc.InitiatedBy
+}
+
+// Created returns when the plan was created.
+func (p *MigrationPlan) Created() time.Time {
+	return unixNanoToTime0(p.doc.Created)
+}
+
+// TargetInfo returns the details required to connect to the
+// migration's target controller.
+func (p *MigrationPlan) TargetInfo() (*migration.TargetInfo, error) {
+	authTag, err := names.ParseUserTag(p.doc.TargetAuthTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	macs, err := jsonToMacaroons(p.doc.TargetMacaroons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &migration.TargetInfo{
+		ControllerTag:   names.NewControllerTag(p.doc.TargetController),
+		ControllerAlias: p.doc.TargetControllerAlias,
+		Addrs:           p.doc.TargetAddrs,
+		CACert:          p.doc.TargetCACert,
+		AuthTag:         authTag,
+		Password:        p.doc.TargetPassword,
+		Macaroons:       macs,
+	}, nil
+}
+
+// Concurrency returns the maximum number of migrations that may be
+// running at any one time.
+func (p *MigrationPlan) Concurrency() int {
+	return p.doc.Concurrency
+}
+
+// MaxAttempts returns the number of times the migration of each model
+// is attempted before it is considered to have failed.
+func (p *MigrationPlan) MaxAttempts() int {
+	return p.doc.MaxAttempts
+}
+
+// WindowStart returns the time before which no migrations will be
+// started, or the zero time if there is no such restriction.
+func (p *MigrationPlan) WindowStart() time.Time {
+	return unixNanoToTime0(p.doc.WindowStart)
+}
+
+// WindowEnd returns the time after which no migrations will be
+// started, or the zero time if there is no such restriction.
+func (p *MigrationPlan) WindowEnd() time.Time {
+	return unixNanoToTime0(p.doc.WindowEnd)
+}
+
+// Models returns the progress of each model in the plan, in the order
+// in which they are to be migrated.
+func (p *MigrationPlan) Models() []MigrationPlanModel {
+	models := make([]MigrationPlanModel, len(p.doc.Models))
+	for i, m := range p.doc.Models {
+		models[i] = MigrationPlanModel{
+			ModelUUID:   m.ModelUUID,
+			ModelName:   m.ModelName,
+			Status:      MigrationPlanModelStatus(m.Status),
+			Attempts:    m.Attempts,
+			MigrationId: m.MigrationId,
+			Message:     m.Message,
+			RetryAfter:  unixNanoToTime0(m.RetryAfter),
+		}
+	}
+	return models
+}
+
+// Cancelled returns whether the plan has been cancelled, in which case
+// no further migrations will be started for it.
+func (p *MigrationPlan) Cancelled() bool {
+	return p.doc.Cancelled
+}
+
+// Completed returns whether no further migrations will be started for
+// the plan.
+func (p *MigrationPlan) Completed() bool {
+	for _, m := range p.doc.Models {
+		if !MigrationPlanModelStatus(m.Status).IsFinal() {
+			return false
+		}
+	}
+	return true
+}
+
+// SetModelProgress records the progress of the migration of a model in
+// the plan. If the plan has been cancelled, a model which would be
+// queued again is recorded as cancelled instead.
+func (p *MigrationPlan) SetModelProgress(progress MigrationPlanModel) error {
+	var doc migrationPlanModelDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := p.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		index := -1
+		for i, m := range p.doc.Models {
+			if m.ModelUUID == progress.ModelUUID {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, errors.NotFoundf("model %q in migration plan %s", progress.ModelUUID, p.doc.Id)
+		}
+		doc = migrationPlanModelDoc{
+			ModelUUID:   progress.ModelUUID,
+			ModelName:   p.doc.Models[index].ModelName,
+			Status:      string(progress.Status),
+			Attempts:    progress.Attempts,
+			MigrationId: progress.MigrationId,
+			Message:     progress.Message,
+		}
+		if !progress.RetryAfter.IsZero() {
+			doc.RetryAfter = progress.RetryAfter.UnixNano()
+		}
+		if p.doc.Cancelled && progress.Status == MigrationPlanQueued {
+			doc.Status = string(MigrationPlanCancelled)
+			doc.RetryAfter = 0
+		}
+		field := fmt.Sprintf("models.%d", index)
+		return []txn.Op{{
+			C:  migrationPlansC,
+			Id: p.doc.Id,
+			Assert: bson.D{
+				{field + ".model-uuid", progress.ModelUUID},
+				cancelledAssert(p.doc.Cancelled),
+			},
+			Update: bson.D{{"$set", bson.D{{field, doc}}}},
+		}}, nil
+	}
+	if err := p.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set progress of model %q in migration plan %s", progress.ModelUUID, p.doc.Id)
+	}
+	for i, m := range p.doc.Models {
+		if m.ModelUUID == doc.ModelUUID {
+			p.doc.Models[i] = doc
+		}
+	}
+	return nil
+}
+
+// Cancel stops any further migrations from being started for the
+// plan. Queued models are recorded as cancelled, while migrations
+// already running are left to finish.
+func (p *MigrationPlan) Cancel() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := p.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if p.doc.Cancelled {
+			return nil, jujutxn.ErrNoOperations
+		}
+		assert := bson.D{cancelledAssert(false)}
+		updates := bson.D{{"cancelled", true}}
+		for i, m := range p.doc.Models {
+			if MigrationPlanModelStatus(m.Status) != MigrationPlanQueued {
+				continue
+			}
+			field := fmt.Sprintf("models.%d", i)
+			assert = append(assert, bson.DocElem{field + ".status", m.Status})
+			updates = append(updates,
+				bson.DocElem{field + ".status", string(MigrationPlanCancelled)},
+				bson.DocElem{field + ".retry-after", int64(0)},
+			)
+		}
+		return []txn.Op{{
+			C:      migrationPlansC,
+			Id:     p.doc.Id,
+			Assert: assert,
+			Update: bson.D{{"$set", updates}},
+		}}, nil
+	}
+	if err := p.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot cancel migration plan %s", p.doc.Id)
+	}
+	return errors.Trace(p.Refresh())
+}
+
+func cancelledAssert(cancelled bool) bson.DocElem {
+	if cancelled {
+		return bson.DocElem{"cancelled", true}
+	}
+	return bson.DocElem{"cancelled", bson.D{{"$ne", true}}}
+}
+
+// Refresh updates the contents of the MigrationPlan from the
+// underlying state.
+func (p *MigrationPlan) Refresh() error {
+	plan, err := p.st.MigrationPlan(p.doc.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p.doc = plan.doc
+	return nil
+}
+
+// CreateMigrationPlan records a plan to migrate a number of models to
+// another controller. The models are migrated by the controller as the
+// plan allows.
+func (st *State) CreateMigrationPlan(spec MigrationPlanSpec) (*MigrationPlan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkTargetController(st, spec.TargetInfo.ControllerTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelsColl, closer := st.db().GetCollection(modelsC)
+	defer closer()
+	models := make([]migrationPlanModelDoc, len(spec.ModelUUIDs))
+	for i, uuid := range spec.ModelUUIDs {
+		if uuid == st.ControllerModelUUID() {
+			return nil, errors.New("controllers can't be migrated")
+		}
+		var model modelDoc
+		if err := modelsColl.FindId(uuid).One(&model); err == mgo.ErrNotFound {
+			return nil, errors.NotFoundf("model %q", uuid)
+		} else if err != nil {
+			return nil, errors.Annotate(err, "querying model")
+		}
+		models[i] = migrationPlanModelDoc{
+			ModelUUID: uuid,
+			ModelName: model.Name,
+			Status:    string(MigrationPlanQueued),
+		}
+	}
+	macsJSON, err := macaroonsToJSON(spec.TargetInfo.Macaroons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seq, err := sequence(st, "migrationplan")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	doc := migrationPlanDoc{
+		Id:                    strconv.Itoa(seq),
+		InitiatedBy:           spec.InitiatedBy.Id(),
+		Created:               st.clock().Now().UnixNano(),
+		TargetController:      spec.TargetInfo.ControllerTag.Id(),
+		TargetControllerAlias: spec.TargetInfo.ControllerAlias,
+		TargetAddrs:           spec.TargetInfo.Addrs,
+		TargetCACert:          spec.TargetInfo.CACert,
+		TargetAuthTag:         spec.TargetInfo.AuthTag.String(),
+		TargetPassword:        spec.TargetInfo.Password,
+		TargetMacaroons:       macsJSON,
+		Concurrency:           spec.Concurrency,
+		MaxAttempts:           spec.MaxAttempts,
+		Models:                models,
+	}
+	if !spec.WindowStart.IsZero() {
+		doc.WindowStart = spec.WindowStart.UnixNano()
+	}
+	if !spec.WindowEnd.IsZero() {
+		doc.WindowEnd = spec.WindowEnd.UnixNano()
+	}
+	ops := []txn.Op{{
+		C:      migrationPlansC,
+		Id:     doc.Id,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotate(err, "failed to create migration plan")
+	}
+	return &MigrationPlan{st: st, doc: doc}, nil
+}
+
+// MigrationPlan returns the migration plan with the specified ID.
+func (st *State) MigrationPlan(id string) (*MigrationPlan, error) {
+	plans, closer := st.db().GetCollection(migrationPlansC)
+	defer closer()
+
+	var doc migrationPlanDoc
+	err := plans.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("migration plan %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get migration plan %q", id)
+	}
+	return &MigrationPlan{st: st, doc: doc}, nil
+}
+
+// AllMigrationPlans returns all of the migration plans, oldest first.
+func (st *State) AllMigrationPlans() ([]*MigrationPlan, error) {
+	plans, closer := st.db().GetCollection(migrationPlansC)
+	defer closer()
+
+	var docs []migrationPlanDoc
+	if err := plans.Find(nil).Sort("created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get migration plans")
+	}
+	result := make([]*MigrationPlan, len(docs))
+	for i, doc := range docs {
+		result[i] = &MigrationPlan{st: st, doc: doc}
+	}
+	return result, nil
+}
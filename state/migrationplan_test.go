@@ -0,0 +1,255 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/v3"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+)
+
+type MigrationPlanSuite struct {
+	ConnSuite
+	State2  *state.State
+	State3  *state.State
+	stdSpec state.MigrationPlanSpec
+}
+
+var _ = gc.Suite(new(MigrationPlanSuite))
+
+func (s *MigrationPlanSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	// Create hosted models to migrate.
+	s.State2 = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.State2.Close() })
+	s.State3 = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.State3.Close() })
+
+	s.stdSpec = state.MigrationPlanSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag:   names.NewControllerTag(utils.MustNewUUID().String()),
+			ControllerAlias: "target-controller",
+			Addrs:           []string{"1.2.3.4:5555"},
+			CACert:          "cert",
+			AuthTag:         names.NewUserTag("user"),
+			Password:        "password",
+		},
+		ModelUUIDs:  []string{s.State2.ModelUUID(), s.State3.ModelUUID()},
+		Concurrency: 1,
+		MaxAttempts: 2,
+	}
+}
+
+func (s *MigrationPlanSuite) TestCreate(c *gc.C) {
+	start := time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
+	s.stdSpec.WindowStart = start
+	plan, err := s.State.CreateMigrationPlan(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(plan.InitiatedBy(), gc.Equals, "admin")
+	c.Check(plan.Concurrency(), gc.Equals, 1)
+	c.Check(plan.MaxAttempts(), gc.Equals, 2)
+	c.Check(plan.WindowStart().Equal(start), jc.IsTrue)
+	c.Check(plan.WindowEnd().IsZero(), jc.IsTrue)
+	c.Check(plan.Completed(), jc.IsFalse)
+	info, err := plan.TargetInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*info, jc.DeepEquals, s.stdSpec.TargetInfo)
+	model2, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	model3, err := s.State3.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.Models(), jc.DeepEquals, []state.MigrationPlanModel{{
+		ModelUUID: s.State2.ModelUUID(),
+		ModelName: model2.Name(),
+		Status:    state.MigrationPlanQueued,
+	}, {
+		ModelUUID: s.State3.ModelUUID(),
+		ModelName: model3.Name(),
+		Status:    state.MigrationPlanQueued,
+	}})
+
+	plans, err := s.State.AllMigrationPlans()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plans, gc.HasLen, 1)
+	c.Check(plans[0].Id(), gc.Equals, plan.Id())
+}
+
+func (s *MigrationPlanSuite) TestCreateValidation(c *gc.C) {
+	tests := []struct {
+		label        string
+		tweak        func(*state.MigrationPlanSpec)
+		errorPattern string
+	}{{
+		"no models",
+		func(spec *state.MigrationPlanSpec) { spec.ModelUUIDs = nil },
+		"empty model list not valid",
+	}, {
+		"duplicate model",
+		func(spec *state.MigrationPlanSpec) {
+			spec.ModelUUIDs = []string{s.State2.ModelUUID(), s.State2.ModelUUID()}
+		},
+		`duplicate model ".+" not valid`,
+	}, {
+		"zero concurrency",
+		func(spec *state.MigrationPlanSpec) { spec.Concurrency = 0 },
+		"concurrency 0 not valid",
+	}, {
+		"window ends before start",
+		func(spec *state.MigrationPlanSpec) {
+			spec.WindowStart = time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
+			spec.WindowEnd = spec.WindowStart.Add(-time.Hour)
+		},
+		"window ending before it starts not valid",
+	}, {
+		"controller model",
+		func(spec *state.MigrationPlanSpec) { spec.ModelUUIDs = []string{s.State.ModelUUID()} },
+		"controllers can't be migrated",
+	}, {
+		"missing model",
+		func(spec *state.MigrationPlanSpec) { spec.ModelUUIDs = []string{utils.MustNewUUID().String()} },
+		`model ".+" not found`,
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.label)
+		spec := s.stdSpec
+		test.tweak(&spec)
+		_, err := s.State.CreateMigrationPlan(spec)
+		c.Check(err, gc.ErrorMatches, test.errorPattern)
+	}
+}
+
+func (s *MigrationPlanSuite) TestSetModelProgress(c *gc.C) {
+	plan, err := s.State.CreateMigrationPlan(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model3, err := s.State3.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	progress := state.MigrationPlanModel{
+		ModelUUID:   s.State3.ModelUUID(),
+		ModelName:   model3.Name(),
+		Status:      state.MigrationPlanFailed,
+		Attempts:    2,
+		MigrationId: s.State3.ModelUUID() + ":1",
+		Message:     "boom",
+	}
+	err = plan.SetModelProgress(progress)
+	c.Assert(err, jc.ErrorIsNil)
+
+	plan, err = s.State.MigrationPlan(plan.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.Models()[1], jc.DeepEquals, progress)
+	c.Check(plan.Completed(), jc.IsFalse)
+
+	err = plan.SetModelProgress(state.MigrationPlanModel{
+		ModelUUID: s.State2.ModelUUID(),
+		Status:    state.MigrationPlanSucceeded,
+		Attempts:  1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan.Refresh(), jc.ErrorIsNil)
+	c.Check(plan.Completed(), jc.IsTrue)
+}
+
+func (s *MigrationPlanSuite) TestSetModelProgressRetryAfter(c *gc.C) {
+	plan, err := s.State.CreateMigrationPlan(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	retryAfter := time.Date(2023, 6, 1, 2, 0, 0, 0, time.UTC)
+	err = plan.SetModelProgress(state.MigrationPlanModel{
+		ModelUUID:  s.State2.ModelUUID(),
+		Status:     state.MigrationPlanQueued,
+		Attempts:   1,
+		Message:    "boom",
+		RetryAfter: retryAfter,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	plan, err = s.State.MigrationPlan(plan.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.Models()[0].RetryAfter.Equal(retryAfter), jc.IsTrue)
+	c.Check(plan.Models()[1].RetryAfter.IsZero(), jc.IsTrue)
+}
+
+func (s *MigrationPlanSuite) TestCancel(c *gc.C) {
+	plan, err := s.State.CreateMigrationPlan(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	err = plan.SetModelProgress(state.MigrationPlanModel{
+		ModelUUID:   s.State2.ModelUUID(),
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: s.State2.ModelUUID() + ":0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = plan.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.Cancelled(), jc.IsTrue)
+
+	plan, err = s.State.MigrationPlan(plan.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.Cancelled(), jc.IsTrue)
+	models := plan.Models()
+	c.Check(models[0].Status, gc.Equals, state.MigrationPlanRunning)
+	c.Check(models[1].Status, gc.Equals, state.MigrationPlanCancelled)
+	c.Check(plan.Completed(), jc.IsFalse)
+
+	// Cancelling again is a no-op.
+	err = plan.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MigrationPlanSuite) TestSetModelProgressAfterCancel(c *gc.C) {
+	plan, err := s.State.CreateMigrationPlan(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	err = plan.SetModelProgress(state.MigrationPlanModel{
+		ModelUUID:   s.State2.ModelUUID(),
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: s.State2.ModelUUID() + ":0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Cancel a separate copy of the plan, leaving this one stale.
+	other, err := s.State.MigrationPlan(plan.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other.Cancel(), jc.ErrorIsNil)
+
+	// The aborted migration isn't queued again.
+	err = plan.SetModelProgress(state.MigrationPlanModel{
+		ModelUUID:   s.State2.ModelUUID(),
+		Status:      state.MigrationPlanQueued,
+		Attempts:    1,
+		MigrationId: s.State2.ModelUUID() + ":0",
+		Message:     "aborted",
+		RetryAfter:  time.Now(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.Cancelled(), jc.IsTrue)
+	c.Check(plan.Models()[0].Status, gc.Equals, state.MigrationPlanCancelled)
+	c.Check(plan.Models()[0].RetryAfter.IsZero(), jc.IsTrue)
+	c.Check(plan.Completed(), jc.IsTrue)
+}
+
+func (s *MigrationPlanSuite) TestSetModelProgressUnknownModel(c *gc.C) {
+	plan, err := s.State.CreateMigrationPlan(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = plan.SetModelProgress(state.MigrationPlanModel{ModelUUID: utils.MustNewUUID().String()})
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MigrationPlanSuite) TestMigrationPlanNotFound(c *gc.C) {
+	_, err := s.State.MigrationPlan("42")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
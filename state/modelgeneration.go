@@ -18,6 +18,7 @@ import (
 	"github.com/juju/names/v5"
 	jujutxn "github.com/juju/txn/v3"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/mongo/utils"
 	stateerrors "github.com/juju/juju/state/errors"
@@ -71,7 +72,19 @@ type generationDoc struct {
 	// Config is all changes made to charm configuration under this branch.
 	Config map[string][]itemChange `bson:"charm-config"`
 
-	// TODO (manadart 2019-04-02): CharmURLs, Resources.
+	// Charms holds the charms that applications are upgraded to under
+	// this branch, keyed by application name.
+	Charms map[string]branchCharmDoc `bson:"charms,omitempty"`
+
+	// Resources holds the IDs of pending resources attached to
+	// applications under this branch, keyed by application name and
+	// then resource name.
+	Resources map[string]map[string]string `bson:"resources,omitempty"`
+
+	// CharmChanges counts the charm and resource changes made to each
+	// application under this branch. It is added to the application's
+	// charm modified version for units tracking the branch.
+	CharmChanges map[string]int `bson:"charm-changes,omitempty"`
 
 	// Created is a Unix timestamp indicating when this generation was created.
 	Created int64 `bson:"created"`
@@ -85,6 +98,47 @@ type generationDoc struct {
 
 	// CompletedBy is the user who committed this generation to the model.
 	CompletedBy string `bson:"completed-by"`
+
+	// Rollout, if set, describes the gradual rollout of this generation
+	// to the units of its applications.
+	Rollout *rolloutDoc `bson:"rollout,omitempty"`
+}
+
+// branchCharmDoc represents a charm upgrade made under a generation.
+type branchCharmDoc struct {
+	URL    string      `bson:"url"`
+	Origin CharmOrigin `bson:"origin"`
+}
+
+// rolloutDoc represents the rollout policy and progress of a generation.
+type rolloutDoc struct {
+	Steps         []int  `bson:"steps"`
+	Start         int64  `bson:"start,omitempty"`
+	StepInterval  int64  `bson:"step-interval,omitempty"`
+	WaitForActive bool   `bson:"wait-for-active,omitempty"`
+	AutoAbort     bool   `bson:"auto-abort,omitempty"`
+	Status        string `bson:"status"`
+	Step          int    `bson:"step"`
+	LastStep      int64  `bson:"last-step,omitempty"`
+	Message       string `bson:"message,omitempty"`
+}
+
+// BranchRollout holds the rollout policy of a branch along with the
+// progress made rolling it out.
+type BranchRollout struct {
+	model.RolloutPolicy
+
+	// Status is the progress of the rollout.
+	Status model.RolloutStatus
+
+	// Step is the number of steps taken.
+	Step int
+
+	// LastStep is when the most recent step was taken.
+	LastStep time.Time
+
+	// Message explains the rollout status, if needed.
+	Message string
 }
 
 // Generation represents the state of a model generation.
@@ -155,6 +209,166 @@ func (g *Generation) CompletedBy() string {
 	return g.doc.CompletedBy
 }
 
+// Rollout returns the rollout policy and progress of the generation.
+// False is returned if no rollout has been set for the generation.
+func (g *Generation) Rollout() (BranchRollout, bool) {
+	doc := g.doc.Rollout
+	if doc == nil {
+		return BranchRollout{}, false
+	}
+	rollout := BranchRollout{
+		RolloutPolicy: model.RolloutPolicy{
+			Steps:         doc.Steps,
+			StepInterval:  time.Duration(doc.StepInterval),
+			WaitForActive: doc.WaitForActive,
+			AutoAbort:     doc.AutoAbort,
+		},
+		Status:  model.RolloutStatus(doc.Status),
+		Step:    doc.Step,
+		Message: doc.Message,
+	}
+	if doc.Start > 0 {
+		rollout.Start = time.Unix(doc.Start, 0).UTC()
+	}
+	if doc.LastStep > 0 {
+		rollout.LastStep = time.Unix(doc.LastStep, 0).UTC()
+	}
+	return rollout, true
+}
+
+// SetRollout sets the policy by which the generation is gradually
+// rolled out to the units of its applications. Any progress made under
+// a previous policy is discarded, but units already tracking the branch
+// continue to do so.
+func (g *Generation) SetRollout(policy model.RolloutPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	doc := &rolloutDoc{
+		Steps:         policy.Steps,
+		StepInterval:  int64(policy.StepInterval),
+		WaitForActive: policy.WaitForActive,
+		AutoAbort:     policy.AutoAbort,
+		Status:        string(model.RolloutPending),
+	}
+	if !policy.Start.IsZero() {
+		doc.Start = policy.Start.Unix()
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"completed", 0}},
+			Update: bson.D{{"$set", bson.D{{"rollout", doc}}}},
+		}}, nil
+	}
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	g.doc.Rollout = doc
+	return nil
+}
+
+// SetRolloutProgress records that the given number of rollout steps
+// have been taken, the latest at the input time.
+func (g *Generation) SetRolloutProgress(step int, at time.Time) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if g.doc.Rollout == nil {
+			return nil, errors.NotFoundf("rollout for branch %q", g.doc.Name)
+		}
+		return []txn.Op{{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{
+				{"completed", 0},
+				{"rollout", bson.D{{"$exists", true}}},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"rollout.status", string(model.RolloutRunning)},
+				{"rollout.step", step},
+				{"rollout.last-step", at.Unix()},
+			}}},
+		}}, nil
+	}
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	g.doc.Rollout.Status = string(model.RolloutRunning)
+	g.doc.Rollout.Step = step
+	g.doc.Rollout.LastStep = at.Unix()
+	return nil
+}
+
+// AbortRollout abandons the rollout of the generation. Units tracking
+// the branch are returned to the master generation, and the branch is
+// aborted. The message records why the rollout was abandoned.
+func (g *Generation) AbortRollout(userName, message string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if g.doc.Rollout == nil {
+			return nil, errors.NotFoundf("rollout for branch %q", g.doc.Name)
+		}
+		now, err := g.st.ControllerTimestamp()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unassigned := make(map[string][]string, len(g.doc.AssignedUnits))
+		for appName := range g.doc.AssignedUnits {
+			unassigned[appName] = []string{}
+		}
+		ops, err := g.removePendingResourcesOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"assigned-units", unassigned},
+				{"completed", now.Unix()},
+				{"completed-by", userName},
+				{"rollout.status", string(model.RolloutAborted)},
+				{"rollout.message", message},
+			}}},
+		}), nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// rolloutStatusUpdate returns the update recording the final status of
+// the generation's rollout, if it has one, as the generation completes.
+func (g *Generation) rolloutStatusUpdate(status model.RolloutStatus) bson.D {
+	if g.doc.Rollout == nil {
+		return nil
+	}
+	return bson.D{{"rollout.status", string(status)}}
+}
+
 // AssignApplication indicates that the application with the input name has had
 // changes in this generation.
 func (g *Generation) AssignApplication(appName string) error {
@@ -372,11 +586,201 @@ func (g *Generation) UpdateCharmConfig(appName string, master *Settings, validCh
 	return errors.Trace(g.st.db().Run(buildTxn))
 }
 
+// CharmURL returns the URL of the charm that the application with the
+// input name is upgraded to under this generation.
+// False is returned if the charm is not changed under the generation.
+func (g *Generation) CharmURL(appName string) (string, bool) {
+	ch, ok := g.doc.Charms[appName]
+	return ch.URL, ok
+}
+
+// Resources returns the IDs of the pending resources attached to
+// applications under this generation, keyed by application name and then
+// resource name.
+func (g *Generation) Resources() map[string]map[string]string {
+	result := make(map[string]map[string]string, len(g.doc.Resources))
+	for appName, pendingIDs := range g.doc.Resources {
+		result[appName] = make(map[string]string, len(pendingIDs))
+		for name, pendingID := range pendingIDs {
+			result[appName][name] = pendingID
+		}
+	}
+	return result
+}
+
+// SetCharm records that the application with the input name is upgraded to
+// the input charm under this generation. Units tracking the branch run the
+// new charm; the application itself is upgraded when the branch is
+// committed.
+func (g *Generation) SetCharm(appName string, ch *Charm, origin CharmOrigin) error {
+	app, err := g.st.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := app.validateSetCharmConfig(SetCharmConfig{
+		Charm:       ch,
+		CharmOrigin: &origin,
+	}); err != nil {
+		return errors.Annotatef(err, "cannot upgrade application %q to charm %q", appName, ch.URL())
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      charmsC,
+			Id:     ch.doc.DocID,
+			Assert: txn.DocExists,
+		}}
+		ops = append(ops, g.assignApplicationOps(appName)...)
+		return append(ops, txn.Op{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"completed", 0}},
+			Update: bson.D{
+				{"$set", bson.D{{"charms." + appName, branchCharmDoc{
+					URL:    ch.URL(),
+					Origin: origin,
+				}}}},
+				{"$inc", bson.D{{"charm-changes." + appName, 1}}},
+			},
+		}), nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// AttachResource records that the pending resource with the input ID is
+// used for the named resource of the application under this generation.
+// Units tracking the branch are given the pending resource; it replaces
+// the application's resource when the branch is committed.
+func (g *Generation) AttachResource(appName, resourceName, pendingID string) error {
+	resources := g.st.resources()
+	if _, err := resources.GetPendingResource(appName, resourceName, pendingID); err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := g.assignApplicationOps(appName)
+
+		// Clean up any pending resource previously attached in its place.
+		if oldID, ok := g.doc.Resources[appName][resourceName]; ok && oldID != pendingID {
+			removeOps, err := resources.removePendingAppResourcesOps(appName, map[string]string{resourceName: oldID})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, removeOps...)
+		}
+		return append(ops, txn.Op{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{
+				{"completed", 0},
+				{"txn-revno", g.doc.TxnRevno},
+			},
+			Update: bson.D{
+				{"$set", bson.D{{"resources." + appName + "." + resourceName, pendingID}}},
+				{"$inc", bson.D{{"charm-changes." + appName, 1}}},
+			},
+		}), nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// assignApplicationOps returns the operations to record that the
+// application has changes under the generation, if it isn't already.
+func (g *Generation) assignApplicationOps(appName string) []txn.Op {
+	if _, ok := g.doc.AssignedUnits[appName]; ok {
+		return nil
+	}
+	return assignGenerationAppTxnOps(g.doc.DocId, appName)
+}
+
+// commitCharmTxnOps returns the operations to upgrade the applications
+// whose charm or resources were changed under the generation, along with
+// the names of the applications whose config changes are carried over to
+// their new charm by those operations.
+func (g *Generation) commitCharmTxnOps() ([]txn.Op, set.Strings, error) {
+	appNames := set.NewStrings()
+	for appName := range g.doc.Charms {
+		appNames.Add(appName)
+	}
+	for appName := range g.doc.Resources {
+		appNames.Add(appName)
+	}
+	var ops []txn.Op
+	configured := set.NewStrings()
+	for _, appName := range appNames.SortedValues() {
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		cfg := SetCharmConfig{
+			CharmOrigin:        app.CharmOrigin(),
+			PendingResourceIDs: g.doc.Resources[appName],
+		}
+		if branchCharm, ok := g.doc.Charms[appName]; ok {
+			if cfg.Charm, err = g.st.Charm(branchCharm.URL); err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			origin := branchCharm.Origin
+			cfg.CharmOrigin = &origin
+		} else if cfg.Charm, _, err = app.Charm(); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if err := app.validateSetCharmConfig(cfg); err != nil {
+			return nil, nil, errors.Annotatef(err, "cannot upgrade application %q", appName)
+		}
+
+		// Config changes made under the branch must be applied to the
+		// settings carried over to a new charm, rather than to the
+		// settings of the charm being replaced.
+		var configDelta settings.ItemChanges
+		if cfg.Charm.URL() != *app.doc.CharmURL {
+			configDelta = g.Config()[appName]
+			configured.Add(appName)
+		}
+		appOps, err := app.setCharmOps(cfg, charm.Settings{}, configDelta)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "cannot upgrade application %q", appName)
+		}
+		ops = append(ops, appOps...)
+	}
+	return ops, configured, nil
+}
+
+// removePendingResourcesOps returns the operations to remove the pending
+// resources attached under the generation.
+func (g *Generation) removePendingResourcesOps() ([]txn.Op, error) {
+	resources := g.st.resources()
+	var ops []txn.Op
+	for appName, pendingIDs := range g.doc.Resources {
+		removeOps, err := resources.removePendingAppResourcesOps(appName, pendingIDs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, removeOps...)
+	}
+	return ops, nil
+}
+
 // Commit marks the generation as completed and assigns it the next value from
 // the generation sequence. The new generation ID is returned.
 func (g *Generation) Commit(userName string) (int, error) {
 	var newGenId int
-
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
 			if err := g.Refresh(); err != nil {
@@ -399,21 +803,32 @@ func (g *Generation) Commit(userName string) (int, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		ops, err := g.commitConfigTxnOps()
+
+		// Charm upgrades and resource changes made under the branch are
+		// applied to the applications as the branch is completed, so
+		// that units which were not tracking it pick them up.
+		ops, configured, err := g.commitCharmTxnOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		configOps, err := g.commitConfigTxnOps(configured)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		ops = append(ops, configOps...)
 
 		// Get the new sequence as late as we can.
 		// If assigned is empty, indicating no changes under this branch,
 		// then the generation ID in not incremented.
 		// This effectively means the generation is aborted, not committed.
+		rolloutStatus := model.RolloutAborted
 		if len(assigned) > 0 {
 			id, err := sequenceWithMin(g.st, "generation", 1)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
 			newGenId = id
+			rolloutStatus = model.RolloutCompleted
 		}
 
 		// As a proxy for checking that the generation has not changed,
@@ -424,12 +839,12 @@ func (g *Generation) Commit(userName string) (int, error) {
 			Id:     g.doc.DocId,
 			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
 			Update: bson.D{
-				{"$set", bson.D{
+				{"$set", append(bson.D{
 					{"assigned-units", assigned},
 					{"completed", now.Unix()},
 					{"completed-by", userName},
 					{"generation-id", newGenId},
-				}},
+				}, g.rolloutStatusUpdate(rolloutStatus)...)},
 			},
 		})
 		return ops, nil
@@ -459,11 +874,12 @@ func (g *Generation) assignedWithAllUnits() (map[string][]string, error) {
 // commitConfigTxnOps iterates over all the applications with configuration
 // deltas, determines their effective new settings, then gathers the
 // operations representing the changes so that they can all be applied in a
-// single transaction.
-func (g *Generation) commitConfigTxnOps() ([]txn.Op, error) {
+// single transaction. Applications whose deltas are applied by a charm
+// upgrade are skipped.
+func (g *Generation) commitConfigTxnOps(skip set.Strings) ([]txn.Op, error) {
 	var ops []txn.Op
 	for appName, delta := range g.Config() {
-		if len(delta) == 0 {
+		if len(delta) == 0 || skip.Contains(appName) {
 			continue
 		}
 		app, err := g.st.Application(appName)
@@ -514,10 +930,12 @@ func (g *Generation) Abort(userName string) error {
 			}
 		}
 
-		// Must not have upgraded charm of tracked application.
-		// TODO (hml) 2019-06-26
-		// Implement cannot abort branch where tracked application has
-		// been upgraded.
+		// No units are tracking the branch, so any charm upgraded under it
+		// is not in use. Resources attached under it are discarded.
+		ops, err := g.removePendingResourcesOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 
 		now, err := g.st.ControllerTimestamp()
 		if err != nil {
@@ -526,17 +944,17 @@ func (g *Generation) Abort(userName string) error {
 		// As a proxy for checking that the generation has not changed,
 		// Assert that the txn rev-no has not changed since we materialised
 		// this generation object.
-		ops := []txn.Op{{
+		ops = append(ops, txn.Op{
 			C:      generationsC,
 			Id:     g.doc.DocId,
 			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
 			Update: bson.D{
-				{"$set", bson.D{
+				{"$set", append(bson.D{
 					{"completed", now.Unix()},
 					{"completed-by", userName},
-				}},
+				}, g.rolloutStatusUpdate(model.RolloutAborted)...)},
 			},
-		}}
+		})
 		return ops, nil
 	}
 
@@ -598,10 +1016,16 @@ func (g *Generation) unassignUnitOps(unitName, appName string) []txn.Op {
 	}}
 }
 
-// HasChangesFor returns true when the generation has config changes for
-// the provided application.
+// HasChangesFor returns true when the generation has config, charm or
+// resource changes for the provided application.
 func (g *Generation) HasChangesFor(appName string) bool {
-	_, ok := g.doc.Config[appName]
+	if _, ok := g.doc.Config[appName]; ok {
+		return true
+	}
+	if _, ok := g.doc.Charms[appName]; ok {
+		return true
+	}
+	_, ok := g.doc.Resources[appName]
 	return ok
 }
 
@@ -636,6 +1060,20 @@ func (g *Generation) unassignAppOps(appName string) []txn.Op {
 			},
 		})
 	}
+	if _, ok := g.doc.CharmChanges[appName]; ok {
+		ops = append(ops, txn.Op{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{
+				{"$unset", bson.D{
+					{"charms." + appName, 1},
+					{"resources." + appName, 1},
+					{"charm-changes." + appName, 1},
+				}},
+			},
+		})
+	}
 	return ops
 }
 
@@ -852,6 +1290,56 @@ func (m *Model) unitBranch(unitName string) (*Generation, error) {
 	return nil, nil
 }
 
+// UnitCharmURL returns the URL of the charm that the unit of the
+// application with the input name should run, and whether the upgrade to
+// it is forced. Units tracking a branch under which the application's
+// charm is upgraded run the branch's charm.
+func (a *Application) UnitCharmURL(unitName string) (*string, bool, error) {
+	branch, err := a.unitBranch(unitName)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if branch != nil {
+		if curl, ok := branch.CharmURL(a.doc.Name); ok {
+			return &curl, false, nil
+		}
+	}
+	curl, force := a.CharmURL()
+	return curl, force, nil
+}
+
+// UnitCharmModifiedVersion returns the charm modified version seen by
+// the unit of the application with the input name. It is advanced for
+// units tracking a branch with charm or resource changes for the
+// application, so that they run the upgrade-charm hook.
+func (a *Application) UnitCharmModifiedVersion(unitName string) (int, error) {
+	branch, err := a.unitBranch(unitName)
+	if err != nil {
+		return -1, errors.Trace(err)
+	}
+	version := a.CharmModifiedVersion()
+	if branch != nil {
+		version += branch.doc.CharmChanges[a.doc.Name]
+	}
+	return version, nil
+}
+
+// unitBranch returns the branch tracked by the unit of the application
+// with the input name, or nil if it is not tracking one.
+func (a *Application) unitBranch(unitName string) (*Generation, error) {
+	if appName, err := names.UnitApplication(unitName); err != nil {
+		return nil, errors.Trace(err)
+	} else if appName != a.doc.Name {
+		return nil, errors.NotValidf("unit %q of application %q", unitName, a.doc.Name)
+	}
+	m, err := a.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	branch, err := m.unitBranch(unitName)
+	return branch, errors.Trace(err)
+}
+
 func newGeneration(st *State, doc *generationDoc) *Generation {
 	return &Generation{
 		st:  st,
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 )
 
@@ -284,6 +285,127 @@ func (s *generationSuite) TestCommitAppliesConfigDeltas(c *gc.C) {
 	c.Check(cfg, gc.DeepEquals, charm.Settings(newCfg))
 }
 
+func (s *generationSuite) TestSetCharm(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	oldURL, _ := app.CharmURL()
+	oldVersion := app.CharmModifiedVersion()
+
+	newCh := s.addNewRiakCharm(c)
+	c.Assert(gen.SetCharm("riak", newCh, *app.CharmOrigin()), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	url, ok := gen.CharmURL("riak")
+	c.Check(ok, jc.IsTrue)
+	c.Check(url, gc.Equals, newCh.URL())
+	c.Check(gen.HasChangesFor("riak"), jc.IsTrue)
+
+	// Only the unit tracking the branch runs the new charm.
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	unitURL, force, err := app.UnitCharmURL("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*unitURL, gc.Equals, newCh.URL())
+	c.Check(force, jc.IsFalse)
+	version, err := app.UnitCharmModifiedVersion("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, oldVersion+1)
+
+	unitURL, _, err = app.UnitCharmURL("riak/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*unitURL, gc.Equals, *oldURL)
+	version, err = app.UnitCharmModifiedVersion("riak/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, oldVersion)
+
+	_, _, err = app.UnitCharmURL("mysql/0")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *generationSuite) TestSetCharmCompletedError(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	err = gen.SetCharm("riak", s.addNewRiakCharm(c), *app.CharmOrigin())
+	c.Assert(err, gc.ErrorMatches, "branch was already aborted")
+}
+
+func (s *generationSuite) TestCommitAppliesCharm(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	newCh := s.addNewRiakCharm(c)
+	c.Assert(gen.SetCharm("riak", newCh, *app.CharmOrigin()), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	url, _ := app.CharmURL()
+	c.Check(*url, gc.Equals, newCh.URL())
+}
+
+func (s *generationSuite) TestCommitAppliesCharmAndConfig(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	newCh := s.addNewRiakCharm(c)
+	c.Assert(gen.SetCharm("riak", newCh, *app.CharmOrigin()), jc.ErrorIsNil)
+	newCfg := map[string]interface{}{"http_port": int64(9999)}
+	c.Assert(app.UpdateCharmConfig(newBranchName, newCfg), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The branch config is carried over to the new charm.
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	url, _ := app.CharmURL()
+	c.Check(*url, gc.Equals, newCh.URL())
+	cfg, err := app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.DeepEquals, charm.Settings(newCfg))
+}
+
+func (s *generationSuite) TestCommitAbortedBranchLeavesCharm(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	oldURL, _ := app.CharmURL()
+	c.Assert(gen.SetCharm("riak", s.addNewRiakCharm(c), *app.CharmOrigin()), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	// The charm upgrade is only applied if the branch is committed.
+	defer state.SetBeforeHooks(c, s.State, func() {
+		branch, err := s.Model.Branch(newBranchName)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(branch.Abort(branchCommitter), jc.ErrorIsNil)
+	}).Check()
+
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, gc.ErrorMatches, "branch was already aborted")
+
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	url, _ := app.CharmURL()
+	c.Check(*url, gc.Equals, *oldURL)
+}
+
 func (s *generationSuite) TestAbortSuccess(c *gc.C) {
 	s.setupTestingClock(c)
 
@@ -343,6 +465,103 @@ func (s *generationSuite) TestAbortCommittedBranch(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "branch was already committed")
 }
 
+func (s *generationSuite) TestSetRollout(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.addBranch(c)
+
+	_, ok := gen.Rollout()
+	c.Check(ok, jc.IsFalse)
+
+	start := time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
+	policy := model.RolloutPolicy{
+		Steps:         []int{25, 100},
+		Start:         start,
+		StepInterval:  time.Hour,
+		WaitForActive: true,
+		AutoAbort:     true,
+	}
+	c.Assert(gen.SetRollout(policy), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	rollout, ok := gen.Rollout()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(rollout, jc.DeepEquals, state.BranchRollout{
+		RolloutPolicy: policy,
+		Status:        model.RolloutPending,
+	})
+}
+
+func (s *generationSuite) TestSetRolloutInvalid(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.addBranch(c)
+
+	err := gen.SetRollout(model.RolloutPolicy{Steps: []int{50}})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *generationSuite) TestSetRolloutProgress(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.addBranch(c)
+	c.Assert(gen.SetRollout(model.RolloutPolicy{Steps: []int{50, 100}}), jc.ErrorIsNil)
+
+	at := time.Date(2023, 6, 1, 1, 0, 0, 0, time.UTC)
+	c.Assert(gen.SetRolloutProgress(1, at), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	rollout, ok := gen.Rollout()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(rollout.Status, gc.Equals, model.RolloutRunning)
+	c.Check(rollout.Step, gc.Equals, 1)
+	c.Check(rollout.LastStep, gc.Equals, at)
+}
+
+func (s *generationSuite) TestSetRolloutProgressNoRollout(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.addBranch(c)
+
+	err := gen.SetRolloutProgress(1, time.Now())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *generationSuite) TestCommitCompletesRollout(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.SetRollout(model.RolloutPolicy{Steps: []int{100}}), jc.ErrorIsNil)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, err := gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	rollout, ok := gen.Rollout()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(rollout.Status, gc.Equals, model.RolloutCompleted)
+}
+
+func (s *generationSuite) TestAbortRollout(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.SetRollout(model.RolloutPolicy{Steps: []int{50, 100}}), jc.ErrorIsNil)
+	c.Assert(gen.AssignUnits("riak", 2), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	err := gen.AbortRollout(branchCommitter, "unit riak/0 is in error")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	c.Check(gen.IsCompleted(), jc.IsTrue)
+	c.Check(gen.GenerationId(), gc.Equals, 0)
+	c.Check(gen.AssignedUnits(), jc.DeepEquals, map[string][]string{"riak": {}})
+	rollout, ok := gen.Rollout()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(rollout.Status, gc.Equals, model.RolloutAborted)
+	c.Check(rollout.Message, gc.Equals, "unit riak/0 is in error")
+
+	_, err = s.Model.Branch(newBranchName)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *generationSuite) TestBranchCharmConfigDeltas(c *gc.C) {
 	gen := s.setupAssignAllUnits(c)
 	c.Assert(gen.Config(), gc.HasLen, 0)
@@ -484,6 +703,35 @@ func (s *generationSuite) TestApplicationBranches(c *gc.C) {
 	c.Assert(appBranchesATake2, gc.DeepEquals, appBranchesA)
 }
 
+func (s *generationSuite) TestWatchApplicationBranches(c *gc.C) {
+	s.setupTestingClock(c)
+	branch := s.setupAssignUnits(c)
+
+	w := s.State.WatchApplicationBranches("riak")
+	defer statetesting.AssertStop(c, w)
+
+	// Initial event.
+	wc := statetesting.NewNotifyWatcherC(c, w)
+	wc.AssertOneChange()
+
+	// Branches not tracking the application are ignored.
+	c.Assert(s.Model.AddBranch("banana", newBranchCreator), jc.ErrorIsNil)
+	other, err := s.Model.Branch("banana")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other.Abort(newBranchCreator), jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	c.Assert(branch.AssignApplication("riak"), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	c.Assert(branch.Refresh(), jc.ErrorIsNil)
+	c.Assert(branch.Abort(newBranchCreator), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *generationSuite) TestDestroyCleansupBranches(c *gc.C) {
 	s.setupTestingClock(c)
 
@@ -536,6 +784,14 @@ options:
 	return s.addBranch(c)
 }
 
+func (s *generationSuite) addNewRiakCharm(c *gc.C) *state.Charm {
+	var cfgYAML = `
+options:
+  http_port: {default: 8089, description: HTTP Port, type: int}
+`
+	return s.AddConfigCharm(c, "riak", cfgYAML, 667)
+}
+
 func (s *generationSuite) addBranch(c *gc.C) *state.Generation {
 	c.Assert(s.Model.AddBranch(newBranchName, newBranchCreator), jc.ErrorIsNil)
 	branch, err := s.Model.Branch(newBranchName)
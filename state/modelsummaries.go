@@ -223,9 +223,11 @@ func (p *modelSummaryProcessor) fillInPermissions(permissionIds []string) error
 			continue
 		}
 		details := &p.summaries[modelIdx]
+		// The user's access may come from both direct and group
+		// permissions, so keep the greatest.
 		access := permission.Access(doc.Access)
 		if err := access.Validate(); err == nil {
-			details.Access = access
+			details.Access = greaterAccess(names.ModelTagKind, details.Access, access)
 		}
 	}
 	if err := iter.Close(); err != nil {
@@ -407,10 +409,20 @@ func (p *modelSummaryProcessor) fillInJustUser() error {
 	// TODO(jam): 2017-11-27 ensure that we have appropriate indexes so that users that aren't "admin" and only see a couple
 	// models don't do a COLLSCAN on the table.
 	username := strings.ToLower(p.user.Name())
+	subjectKeys := []string{userGlobalKey(username)}
+	groups, err := p.st.UserGroupsForUser(p.user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, group := range groups {
+		subjectKeys = append(subjectKeys, userGroupGlobalKey(group.Name()))
+	}
 	var permissionIds []string
 	for _, modelUUID := range p.modelUUIDs {
-		permId := permissionID(modelKey(modelUUID), userGlobalKey(username))
-		permissionIds = append(permissionIds, permId)
+		for _, subjectKey := range subjectKeys {
+			permId := permissionID(modelKey(modelUUID), subjectKey)
+			permissionIds = append(permissionIds, permId)
+		}
 	}
 	if err := p.fillInPermissions(permissionIds); err != nil {
 		return errors.Trace(err)
@@ -233,6 +233,12 @@ func (st *State) modelQueryForUser(user names.UserTag, isSuperuser bool) (mongo.
 			closer()
 			return nil, nil, errors.Trace(err)
 		}
+		groupModelUUIDs, err := st.userGroupsModelUUIDs(user)
+		if err != nil {
+			closer()
+			return nil, nil, errors.Trace(err)
+		}
+		modelUUIDs = append(modelUUIDs, groupModelUUIDs...)
 		modelQuery = models.Find(bson.M{
 			"_id":            bson.M{"$in": modelUUIDs},
 			"migration-mode": bson.M{"$ne": MigrationModeImporting},
@@ -311,10 +317,10 @@ func (st *State) ModelUUIDsForUser(user names.UserTag) ([]string, error) {
 			return nil, errors.Trace(err)
 		}
 	} else {
-		// Since there are no groups at this stage, the simplest way to get all
-		// the models that a particular user can see is to look through the
-		// model user collection. A raw collection is required to support
-		// queries across multiple models.
+		// The models that a particular user can see are those in the model
+		// user collection, along with those any of the user's groups have
+		// access to. A raw collection is required to support queries across
+		// multiple models.
 		modelUsers, userCloser := st.db().GetRawCollection(modelUsersC)
 		defer userCloser()
 
@@ -326,6 +332,11 @@ func (st *State) ModelUUIDsForUser(user names.UserTag) ([]string, error) {
 		for _, doc := range userSlice {
 			modelUUIDs = append(modelUUIDs, doc.ObjectUUID)
 		}
+		groupModelUUIDs, err := st.userGroupsModelUUIDs(user)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		modelUUIDs = append(modelUUIDs, groupModelUUIDs...)
 	}
 
 	modelsColl, close := st.db().GetCollection(modelsC)
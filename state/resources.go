@@ -722,7 +722,33 @@ func (st resourcePersistence) UpdatePendingResource(applicationID, pendingID, us
 func (p *resourcePersistence) OpenResource(applicationID, name string) (resources.Resource, io.ReadCloser, error) {
 	rLogger.Tracef("open resource %q of %q", name, applicationID)
 	id := newAppResourceID(applicationID, name)
-	resourceInfo, storagePath, err := p.getResource(id)
+	return p.openResource(applicationID, name, func() (resources.Resource, string, error) {
+		return p.getResource(id)
+	})
+}
+
+// openPendingResource returns metadata about the pending resource, and
+// a reader for the resource.
+func (p *resourcePersistence) openPendingResource(applicationID, name, pendingID string) (resources.Resource, io.ReadCloser, error) {
+	rLogger.Tracef("open resource %q of %q (pending %q)", name, applicationID, pendingID)
+	id := newAppResourceID(applicationID, name)
+	return p.openResource(applicationID, name, func() (resources.Resource, string, error) {
+		doc, err := p.getOnePending(id, pendingID)
+		if err != nil {
+			return resources.Resource{}, "", errors.Trace(err)
+		}
+		stored, err := doc2resource(doc)
+		if err != nil {
+			return resources.Resource{}, "", errors.Trace(err)
+		}
+		return stored.Resource, stored.storagePath, nil
+	})
+}
+
+func (p *resourcePersistence) openResource(
+	applicationID, name string, getResource func() (resources.Resource, string, error),
+) (resources.Resource, io.ReadCloser, error) {
+	resourceInfo, storagePath, err := getResource()
 	if err != nil {
 		if err := p.verifyApplication(applicationID); err != nil {
 			return resources.Resource{}, nil, errors.Trace(err)
@@ -780,7 +806,7 @@ func (p *resourcePersistence) OpenResourceForUniter(unitName, resName string) (r
 	if err != nil {
 		return resources.Resource{}, nil, errors.Trace(err)
 	}
-	resourceInfo, resourceReader, err := p.OpenResource(appName, resName)
+	resourceInfo, resourceReader, err := p.openResourceForUnit(appName, unitName, resName)
 	if err != nil {
 		return resources.Resource{}, nil, errors.Trace(err)
 	}
@@ -806,6 +832,33 @@ func (p *resourcePersistence) OpenResourceForUniter(unitName, resName string) (r
 	return resourceInfo, resourceReader, nil
 }
 
+// openResourceForUnit opens the resource for the unit, which is the
+// resource attached under the branch the unit is tracking, if any, or
+// the application's resource otherwise.
+func (p *resourcePersistence) openResourceForUnit(appName, unitName, resName string) (resources.Resource, io.ReadCloser, error) {
+	m, err := p.st.Model()
+	if err != nil {
+		return resources.Resource{}, nil, errors.Trace(err)
+	}
+	branch, err := m.unitBranch(unitName)
+	if err != nil {
+		return resources.Resource{}, nil, errors.Trace(err)
+	}
+	if branch != nil {
+		if pendingID, ok := branch.doc.Resources[appName][resName]; ok {
+			res, reader, err := p.openPendingResource(appName, resName, pendingID)
+			if err != nil {
+				return resources.Resource{}, nil, errors.Trace(err)
+			}
+			// The unit uses the resource as if it were the
+			// application's, so it is recorded that way.
+			res.PendingID = ""
+			return res, reader, nil
+		}
+	}
+	return p.OpenResource(appName, resName)
+}
+
 // unitSetter records the resource as in use by a unit when the wrapped
 // reader has been fully read.
 type unitSetter struct {
@@ -363,14 +363,11 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 	return errors.Trace(st.db().RunTransaction(ops))
 }
 
-// removeAllModelPermissions removes all direct permissions documents for
-// this model, and all permissions for offers hosted by this model.
+// removeAllModelPermissions removes all the permissions documents granting
+// users and groups access to this model, and to the offers it hosts.
 func (st *State) removeAllModelPermissions() error {
 	var permOps []txn.Op
-	permPattern := bson.M{
-		"_id": bson.M{"$regex": "^" + permissionID(modelKey(st.ModelUUID()), "")},
-	}
-	ops, err := st.removeInCollectionOps(permissionsC, permPattern)
+	ops, err := st.removeObjectPermissionsOps(modelKey(st.ModelUUID()))
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -385,10 +382,7 @@ func (st *State) removeAllModelPermissions() error {
 	}
 
 	for _, offer := range offerDocs {
-		permPattern = bson.M{
-			"_id": bson.M{"$regex": "^" + permissionID(applicationOfferKey(offer.OfferUUID), "")},
-		}
-		ops, err = st.removeInCollectionOps(permissionsC, permPattern)
+		ops, err = st.removeObjectPermissionsOps(applicationOfferKey(offer.OfferUUID))
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -398,6 +392,24 @@ func (st *State) removeAllModelPermissions() error {
 	return errors.Trace(err)
 }
 
+// removeObjectPermissionsOps returns the operations needed to remove the
+// access all users and groups have on the object, so that none of it
+// applies to another object later given the same key.
+func (st *State) removeObjectPermissionsOps(objectGlobalKey string) ([]txn.Op, error) {
+	userPattern := bson.M{
+		"_id": bson.M{"$regex": "^" + permissionID(objectGlobalKey, userGlobalKey(""))},
+	}
+	ops, err := st.removeInCollectionOps(permissionsC, userPattern)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	groupOps, err := st.removeUserGroupsAccessOps(objectGlobalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, groupOps...), nil
+}
+
 // removeAllInCollectionRaw removes all the documents from the given
 // named collection.
 func (st *State) removeAllInCollectionRaw(name string) error {
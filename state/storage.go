@@ -56,6 +56,11 @@ type StorageInstance interface {
 	// Pool returns the name of the storage pool from which the storage
 	// instance has been or will be provisioned.
 	Pool() string
+
+	// Application returns the tag of the application to whose units an
+	// imported storage instance may be attached, and a boolean indicating
+	// whether or not the storage instance is restricted to an application.
+	Application() (names.ApplicationTag, bool)
 }
 
 // StorageAttachment represents the state of a unit's attachment to a storage
@@ -197,6 +202,13 @@ func (s *storageInstance) Pool() string {
 	return s.doc.Constraints.Pool
 }
 
+func (s *storageInstance) Application() (names.ApplicationTag, bool) {
+	if s.doc.Application == "" {
+		return names.ApplicationTag{}, false
+	}
+	return names.NewApplicationTag(s.doc.Application), true
+}
+
 // entityStorageRefcountKey returns a key for refcounting charm storage
 // for a specific entity. Each time a storage instance is created, the
 // named store's refcount is incremented; and decremented when removed.
@@ -215,6 +227,7 @@ type storageInstanceDoc struct {
 	Releasing       bool                       `bson:"releasing,omitempty"`
 	Owner           string                     `bson:"owner,omitempty"`
 	StorageName     string                     `bson:"storagename"`
+	Application     string                     `bson:"application,omitempty"`
 	AttachmentCount int                        `bson:"attachmentcount"`
 	Constraints     storageInstanceConstraints `bson:"constraints"`
 }
@@ -222,8 +235,9 @@ type storageInstanceDoc struct {
 // storageInstanceConstraints contains a subset of StorageConstraints,
 // for a single storage instance.
 type storageInstanceConstraints struct {
-	Pool string `bson:"pool"`
-	Size uint64 `bson:"size"`
+	Pool       string `bson:"pool"`
+	Size       uint64 `bson:"size"`
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 type storageAttachment struct {
@@ -546,6 +560,30 @@ func removeStorageInstanceOps(si *storageInstance, assert bson.D, force bool) ([
 		}
 	}
 
+	// Snapshots are destroyed along with the storage they were taken
+	// of; some providers delete them with the volume anyway.
+	snapshotOps, err := destroyStorageSnapshotsOps(si.sb.mb, si.doc.Id)
+	if err != nil {
+		if !force {
+			return nil, errors.Trace(err)
+		}
+		logger.Warningf("could not get operations to destroy snapshots when removing storage instance %v: %v", si.StorageTag().Id(), err)
+	}
+	ops = append(ops, snapshotOps...)
+	ops = append(ops, removeStorageUsageOp(si.doc.Id))
+
+	storageOps, err := destroyStorageInstanceStorageOps(si, force)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, storageOps...), nil
+}
+
+// destroyStorageInstanceStorageOps returns txn.Ops to destroy the volume
+// and/or filesystem assigned to the storage instance, if any.
+func destroyStorageInstanceStorageOps(si *storageInstance, force bool) ([]txn.Op, error) {
+	var ops []txn.Op
+
 	machineStorageOp := func(c string, id string) txn.Op {
 		return txn.Op{
 			C:      c,
@@ -831,8 +869,9 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				Constraints: storageInstanceConstraints{
-					Pool: cons.Pool,
-					Size: cons.Size,
+					Pool:       cons.Pool,
+					Size:       cons.Size,
+					SnapshotId: cons.snapshotId,
 				},
 			}
 			var hostStorageOps []txn.Op
@@ -1074,6 +1113,131 @@ func (sb *storageBackend) AttachStorage(storage names.StorageTag, unit names.Uni
 	return sb.mb.db().Run(buildTxn)
 }
 
+// ReplaceStorage attaches the unowned storage instance "replacement" to the
+// unit in place of the unit's own storage instance "storage" with the same
+// storage name, which is destroyed. The storage being replaced must not yet
+// have been provisioned for the unit.
+//
+// This is used on CAAS models, when the cloud binds an imported volume to
+// a unit which has already been allocated storage of its own. As one
+// storage instance replaces another, the unit's charm storage count is
+// unchanged.
+func (sb *storageBackend) ReplaceStorage(storage, replacement names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err,
+		"cannot replace %s with %s on %s",
+		names.ReadableString(storage),
+		names.ReadableString(replacement),
+		names.ReadableString(unit),
+	)
+	if sb.modelType != ModelTypeCAAS {
+		return errors.NotSupportedf("replacing storage on %s models", sb.modelType)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := sb.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		newSi, err := sb.storageInstance(replacement)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if owner, ok := newSi.Owner(); ok {
+			if owner == unit && attempt > 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.Errorf(
+				"%s is already owned by %s",
+				names.ReadableString(replacement),
+				names.ReadableString(owner),
+			)
+		}
+		if owner, ok := si.Owner(); !ok || owner != unit {
+			return nil, errors.Errorf("%s is not owned by the unit", names.ReadableString(storage))
+		}
+		if si.StorageName() != newSi.StorageName() {
+			return nil, errors.Errorf(
+				"storage name %q does not match %q",
+				newSi.StorageName(), si.StorageName(),
+			)
+		}
+		if si.Life() != Alive || si.doc.AttachmentCount != 1 {
+			return nil, errors.Errorf("%s is being detached", names.ReadableString(storage))
+		}
+		u, err := sb.unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if u.Life() != Alive {
+			return nil, errors.New("unit not alive")
+		}
+		ch, err := u.charm()
+		if err != nil {
+			return nil, errors.Annotate(err, "getting charm")
+		}
+
+		// The storage being replaced must not have been provisioned
+		// for the unit, or we would be discarding its contents.
+		_, filesystemAttachment, err := sb.storageHostAttachment(si, unit, unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var attachmentOps []txn.Op
+		if filesystemAttachment != nil {
+			if _, err := filesystemAttachment.Info(); err == nil {
+				return nil, errors.Errorf("%s is already provisioned", names.ReadableString(storage))
+			} else if !errors.IsNotProvisioned(err) {
+				return nil, errors.Trace(err)
+			}
+			attachmentOps = append(attachmentOps, txn.Op{
+				C: filesystemAttachmentsC,
+				Id: filesystemAttachmentId(
+					filesystemAttachment.Host().Id(),
+					filesystemAttachment.Filesystem().Id(),
+				),
+				Assert: bson.D{{"info", bson.D{{"$exists", false}}}},
+			})
+		}
+
+		ops, err := sb.attachStorageOps(newSi, unit, u.Base().OS, ch, u)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, attachmentOps...)
+
+		// Remove the replaced storage instance and its attachment
+		// outright. The unit's storage attachment count and storage
+		// refcount are unchanged, as is the count of storage
+		// instances for the storage name.
+		ops = append(ops, txn.Op{
+			C:      storageAttachmentsC,
+			Id:     storageAttachmentId(unit.Id(), storage.Id()),
+			Assert: isAliveDoc,
+			Remove: true,
+		}, txn.Op{
+			C:  storageInstancesC,
+			Id: si.doc.Id,
+			Assert: bson.D{
+				{"life", Alive},
+				{"owner", si.doc.Owner},
+				{"attachmentcount", 1},
+			},
+			Remove: true,
+		}, removeStorageUsageOp(si.doc.Id), txn.Op{
+			C:      unitsC,
+			Id:     u.doc.Name,
+			Assert: isAliveDoc,
+		})
+		storageOps, err := destroyStorageInstanceStorageOps(si, false)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, storageOps...)
+		ops = append(ops, u.assertCharmOps(ch)...)
+		return ops, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 // attachStorageOps returns txn.Ops to attach a storage instance to the
 // specified unit. The caller must ensure that the unit is in a state
 // to attach the storage (i.e. it is Alive, or is being created).
@@ -1113,10 +1277,12 @@ func (sb *storageBackend) attachStorageOps(
 		} else if !errors.IsNotFound(err) {
 			return nil, errors.Trace(err)
 		}
-	} else {
-		// TODO(axw) should we store the application name on the
-		// storage, and restrict attaching to only units of that
-		// application?
+	} else if si.doc.Application != "" && si.doc.Application != unitApplicationName {
+		return nil, errors.Errorf(
+			"cannot attach storage imported for %s to %s",
+			names.ReadableString(names.NewApplicationTag(si.doc.Application)),
+			names.ReadableString(unitTag),
+		)
 	}
 
 	// Check that the unit's charm declares storage with the storage
@@ -1688,6 +1854,12 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// snapshotId, if non-empty, is the provider-allocated unique ID of
+	// the snapshot that the storage instances are to be created from.
+	// It is only set when adding storage from a snapshot, and is never
+	// recorded as a storage constraint.
+	snapshotId string
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -0,0 +1,412 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+	"github.com/juju/names/v5"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/state/watcher"
+)
+
+// ResizeStorage records a request to grow the volume or filesystem of
+// the specified storage instance to the given size, in MiB. The resize
+// is carried out by the storage provisioner responsible for the volume
+// or filesystem, which must already be provisioned. For filesystems
+// backed by a volume, the volume is resized; the charm is responsible
+// for growing the filesystem once the storage-resized hook is run.
+func (sb *storageBackend) ResizeStorage(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize %s", names.ReadableString(tag))
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		ops := []txn.Op{{
+			C:      storageInstancesC,
+			Id:     tag.Id(),
+			Assert: isAliveDoc,
+		}}
+		var resizeOps []txn.Op
+		switch s.Kind() {
+		case StorageKindBlock:
+			v, err := sb.storageInstanceVolume(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			resizeOps, err = v.resizeOps(size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		case StorageKindFilesystem:
+			f, err := sb.storageInstanceFilesystem(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if volumeTag, err := f.Volume(); err == nil {
+				v, err := getVolumeByTag(sb.mb, volumeTag)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				resizeOps, err = v.resizeOps(size)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+			} else if err != ErrNoBackingVolume {
+				return nil, errors.Trace(err)
+			} else {
+				resizeOps, err = f.resizeOps(size)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+		default:
+			return nil, errors.Errorf("invalid storage kind %v", s.Kind())
+		}
+		return append(ops, resizeOps...), nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// resizeOps returns the operations required to request that the volume
+// be grown to the given size.
+func (v *volume) resizeOps(size uint64) ([]txn.Op, error) {
+	if v.doc.Life != Alive {
+		return nil, errors.Errorf("volume %q is not alive", v.doc.Name)
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := validateResize(size, info.Size); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{{
+		C:  volumesC,
+		Id: v.doc.Name,
+		Assert: bson.D{
+			{"life", Alive},
+			{"info.size", info.Size},
+		},
+		Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+	}}, nil
+}
+
+// resizeOps returns the operations required to request that the
+// filesystem be grown to the given size.
+func (f *filesystem) resizeOps(size uint64) ([]txn.Op, error) {
+	if f.doc.Life != Alive {
+		return nil, errors.Errorf("filesystem %q is not alive", f.doc.FilesystemId)
+	}
+	info, err := f.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := validateResize(size, info.Size); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{{
+		C:  filesystemsC,
+		Id: f.doc.FilesystemId,
+		Assert: bson.D{
+			{"life", Alive},
+			{"info.size", info.Size},
+		},
+		Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+	}}, nil
+}
+
+// validateResize checks that storage of the current size, in MiB, may
+// be resized to the requested size. Storage may only be grown.
+func validateResize(size, current uint64) error {
+	if size <= current {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"requested size %dMiB is not larger than the current size %dMiB", size, current,
+		))
+	}
+	return nil
+}
+
+// SetVolumeResized records the outcome of a requested resize of the
+// specified volume, clearing the request. The size is the size of the
+// volume in MiB after the resize was attempted; if it is no larger than
+// the recorded size, only the request is cleared. If the volume backs a
+// filesystem, the filesystem's size is updated to match.
+func (sb *storageBackend) SetVolumeResized(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set resized volume %q", tag.Id())
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		update := bson.D{{"$unset", bson.D{{"requestedsize", nil}}}}
+		if size > info.Size {
+			update = append(update, bson.DocElem{"$set", bson.D{{"info.size", size}}})
+		}
+		ops := []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: bson.D{{"info.size", info.Size}},
+			Update: update,
+		}}
+		if size <= info.Size {
+			return ops, nil
+		}
+
+		f, err := sb.volumeFilesystem(tag)
+		if errors.IsNotFound(err) {
+			return ops, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fsInfo, err := f.Info(); err == nil && fsInfo.Size < size {
+			ops = append(ops, txn.Op{
+				C:      filesystemsC,
+				Id:     f.doc.FilesystemId,
+				Assert: bson.D{{"info.size", fsInfo.Size}},
+				Update: bson.D{{"$set", bson.D{{"info.size", size}}}},
+			})
+		}
+		return ops, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// SetFilesystemResized records the outcome of a requested resize of the
+// specified filesystem, clearing the request. The size is the size of
+// the filesystem in MiB after the resize was attempted; if it is no
+// larger than the recorded size, only the request is cleared.
+func (sb *storageBackend) SetFilesystemResized(tag names.FilesystemTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set resized filesystem %q", tag.Id())
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		f, err := getFilesystemByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := f.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		update := bson.D{{"$unset", bson.D{{"requestedsize", nil}}}}
+		if size > info.Size {
+			update = append(update, bson.DocElem{"$set", bson.D{{"info.size", size}}})
+		}
+		return []txn.Op{{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: bson.D{{"info.size", info.Size}},
+			Update: update,
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to the size or requested size of model-scoped volumes.
+func (sb *storageBackend) WatchModelVolumeResizes() StringsWatcher {
+	return sb.watchModelStorageSizes(volumesC)
+}
+
+// WatchModelFilesystemResizes returns a StringsWatcher that notifies of
+// changes to the size or requested size of model-scoped filesystems.
+func (sb *storageBackend) WatchModelFilesystemResizes() StringsWatcher {
+	return sb.watchModelStorageSizes(filesystemsC)
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to the size or requested size of volumes scoped to the
+// specified machine.
+func (sb *storageBackend) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	return sb.watchMachineStorageSizes(m, volumesC)
+}
+
+// WatchMachineFilesystemResizes returns a StringsWatcher that notifies
+// of changes to the size or requested size of filesystems scoped to the
+// specified machine.
+func (sb *storageBackend) WatchMachineFilesystemResizes(m names.MachineTag) StringsWatcher {
+	return sb.watchMachineStorageSizes(m, filesystemsC)
+}
+
+// watchModelStorageSizes returns a StringsWatcher that notifies of
+// changes to the sizes of the model-scoped volumes or filesystems in the
+// given collection.
+func (sb *storageBackend) watchModelStorageSizes(collection string) StringsWatcher {
+	mb := sb.mb
+	filter := func(id interface{}) bool {
+		k, err := mb.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newStorageSizeWatcher(mb, collection, filter)
+}
+
+// watchMachineStorageSizes returns a StringsWatcher that notifies of
+// changes to the sizes of the volumes or filesystems in the given
+// collection that are scoped to the specified machine.
+func (sb *storageBackend) watchMachineStorageSizes(m names.MachineTag, collection string) StringsWatcher {
+	mb := sb.mb
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := mb.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix) && !strings.Contains(k[len(prefix):], "/")
+	}
+	return newStorageSizeWatcher(mb, collection, filter)
+}
+
+// storageSize holds the fields of a volume or filesystem document that
+// a storageSizeWatcher compares.
+type storageSize struct {
+	DocID string `bson:"_id"`
+	Info  struct {
+		Size uint64 `bson:"size"`
+	} `bson:"info"`
+	RequestedSize uint64 `bson:"requestedsize"`
+}
+
+// storageSizeWatcher notifies about changes to the provisioned size and
+// requested size of volumes or filesystems. The first event returned by
+// the watcher is the set of all matching volumes or filesystems.
+// Subsequent events are generated only when the size or requested size
+// of one changes; other changes, such as to attachments or status, are
+// ignored.
+type storageSizeWatcher struct {
+	commonWatcher
+	collection string
+	filter     func(interface{}) bool
+	known      map[string]storageSize
+	out        chan []string
+}
+
+var _ Watcher = (*storageSizeWatcher)(nil)
+
+func newStorageSizeWatcher(backend modelBackend, collection string, filter func(interface{}) bool) StringsWatcher {
+	w := &storageSizeWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		collection:    collection,
+		filter:        filter,
+		known:         make(map[string]storageSize),
+		out:           make(chan []string),
+	}
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+var storageSizeFields = bson.D{{"_id", 1}, {"info.size", 1}, {"requestedsize", 1}}
+
+func (w *storageSizeWatcher) initial() (set.Strings, error) {
+	ids := set.NewStrings()
+	coll, closer := w.db.GetCollection(w.collection)
+	defer closer()
+
+	var doc storageSize
+	iter := coll.Find(nil).Select(storageSizeFields).Iter()
+	for iter.Next(&doc) {
+		if !w.filter(doc.DocID) {
+			continue
+		}
+		id := w.backend.localID(doc.DocID)
+		w.known[id] = doc
+		ids.Add(id)
+	}
+	return ids, iter.Close()
+}
+
+func (w *storageSizeWatcher) merge(ids set.Strings, change watcher.Change) error {
+	id := w.backend.localID(change.Id.(string))
+	if change.Revno < 0 {
+		delete(w.known, id)
+		ids.Remove(id)
+		return nil
+	}
+	coll, closer := w.db.GetCollection(w.collection)
+	defer closer()
+
+	var doc storageSize
+	if err := coll.FindId(change.Id).Select(storageSizeFields).One(&doc); err == mgo.ErrNotFound {
+		delete(w.known, id)
+		ids.Remove(id)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	known, ok := w.known[id]
+	w.known[id] = doc
+	if !ok || known.Info.Size != doc.Info.Size || known.RequestedSize != doc.RequestedSize {
+		ids.Add(id)
+	}
+	return nil
+}
+
+func (w *storageSizeWatcher) loop() error {
+	ch := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(w.collection, ch, func(id interface{}) bool {
+		return isLocalID(w.backend)(id) && w.filter(id)
+	})
+	defer w.watcher.UnwatchCollection(w.collection, ch)
+	ids, err := w.initial()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-ch:
+			if err := w.merge(ids, change); err != nil {
+				return errors.Trace(err)
+			}
+			if !ids.IsEmpty() {
+				out = w.out
+			}
+		case out <- ids.Values():
+			out = nil
+			ids = set.NewStrings()
+		}
+	}
+}
+
+// Changes returns the event channel for the watcher.
+func (w *storageSizeWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+// WatchVolume returns a watcher for observing changes to a volume.
+func (sb *storageBackend) WatchVolume(tag names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, volumesC, sb.mb.docID(tag.Id()))
+}
+
+// WatchFilesystem returns a watcher for observing changes to a
+// filesystem.
+func (sb *storageBackend) WatchFilesystem(tag names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, filesystemsC, sb.mb.docID(tag.Id()))
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type StorageResizeSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageResizeSuite{})
+
+func (s *StorageResizeSuite) TestResizeStorageUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorage(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: .* not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageShrink(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	volume := s.storageInstanceVolume(c, storageTag)
+	err := s.storageBackend.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{VolumeId: "vol-123", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorage(storageTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: requested size 1024MiB is not larger than the current size 1024MiB`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageVolume(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	volume := s.storageInstanceVolume(c, storageTag)
+	_, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+
+	err := s.storageBackend.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volume.VolumeTag())
+	size, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	err = s.storageBackend.SetVolumeResized(volume.VolumeTag(), 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volume.VolumeTag())
+	_, ok = volume.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(4096))
+}
+
+func (s *StorageResizeSuite) TestSetVolumeResizedFailed(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	err := s.storageBackend.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A failed resize is recorded by reporting the current size,
+	// which clears the request.
+	volume := s.storageInstanceVolume(c, storageTag)
+	err = s.storageBackend.SetVolumeResized(volume.VolumeTag(), 0)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volume.VolumeTag())
+	_, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(0))
+}
+
+func (s *StorageResizeSuite) TestResizeStorageFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "filesystem", "rootfs")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	err = s.storageBackend.SetFilesystemInfo(filesystem.FilesystemTag(), state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Size:         1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem = s.filesystem(c, filesystem.FilesystemTag())
+	size, ok := filesystem.RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	err = s.storageBackend.SetFilesystemResized(filesystem.FilesystemTag(), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem = s.filesystem(c, filesystem.FilesystemTag())
+	_, ok = filesystem.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertFilesystemInfo(c, filesystem.FilesystemTag(), state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "rootfs",
+		Size:         2048,
+	})
+}
+
+func (s *StorageResizeSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	volume := s.storageInstanceVolume(c, storageTag)
+	machine := unitMachine(c, s.st, u)
+
+	w := s.storageBackend.WatchMachineVolumeResizes(machine.MachineTag())
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, w)
+	wc.AssertChange(volume.VolumeTag().Id()) // initial
+	wc.AssertNoChange()
+
+	// Changes to the volume that do not affect its size are ignored.
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	info.HardwareId = "serial-123"
+	err = s.storageBackend.SetVolumeInfo(volume.VolumeTag(), info)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.storageBackend.ResizeStorage(storageTag, info.Size+1024)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(volume.VolumeTag().Id())
+	wc.AssertNoChange()
+
+	err = s.storageBackend.SetVolumeResized(volume.VolumeTag(), info.Size+1024)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(volume.VolumeTag().Id())
+	wc.AssertNoChange()
+}
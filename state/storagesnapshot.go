@@ -0,0 +1,590 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/charm/v12"
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+	"github.com/juju/names/v5"
+	jujutxn "github.com/juju/txn/v3"
+)
+
+// StorageSnapshot describes a point-in-time snapshot of the volume or
+// filesystem of a storage instance. New storage instances may be created
+// from the snapshot. Snapshots are destroyed along with the storage
+// instance they were taken of.
+type StorageSnapshot interface {
+	// Id returns the ID of the snapshot.
+	Id() string
+
+	// Life returns the life of the snapshot. A dying snapshot is
+	// removed once the storage provisioner has deleted it.
+	Life() Life
+
+	// StorageInstance returns the tag of the storage instance that the
+	// snapshot was taken of.
+	StorageInstance() names.StorageTag
+
+	// Kind returns the kind of the storage instance that the snapshot
+	// was taken of.
+	Kind() StorageKind
+
+	// Volume returns the tag of the volume that the snapshot was taken
+	// of. If the snapshot was taken of a filesystem that isn't backed by
+	// a volume, an error satisfying errors.IsNotFound is returned.
+	Volume() (names.VolumeTag, error)
+
+	// Filesystem returns the tag of the filesystem that the snapshot was
+	// taken of. If the snapshot was taken of a volume, an error
+	// satisfying errors.IsNotFound is returned.
+	Filesystem() (names.FilesystemTag, error)
+
+	// Machine returns the tag of the machine that the snapshot is
+	// scoped to, if any. Snapshots of machine-scoped storage are kept
+	// on the machine, and can only be used by units on that machine.
+	Machine() (names.MachineTag, bool)
+
+	// Pool returns the name of the storage pool of the volume or
+	// filesystem that the snapshot was taken of.
+	Pool() string
+
+	// Size returns the size of the volume or filesystem that the
+	// snapshot was taken of, in MiB.
+	Size() uint64
+
+	// Created returns when the snapshot was requested.
+	Created() time.Time
+
+	// Info returns the snapshot's StorageSnapshotInfo, or a NotProvisioned
+	// error if the snapshot has not yet been taken.
+	Info() (StorageSnapshotInfo, error)
+
+	// Message returns the reason that taking the snapshot failed, if it
+	// did.
+	Message() string
+}
+
+// StorageSnapshotInfo describes information about a snapshot that has
+// been taken.
+type StorageSnapshotInfo struct {
+	// SnapshotId is the provider-allocated unique ID of the snapshot.
+	SnapshotId string `bson:"snapshotid"`
+
+	// Size is the size reported by the provider for the snapshot, in
+	// MiB. This may be zero if the provider doesn't know.
+	Size uint64 `bson:"size"`
+}
+
+type storageSnapshot struct {
+	doc storageSnapshotDoc
+}
+
+// storageSnapshotDoc records information about a snapshot of the volume
+// or filesystem of a storage instance.
+type storageSnapshotDoc struct {
+	DocID        string               `bson:"_id"`
+	Id           string               `bson:"id"`
+	ModelUUID    string               `bson:"model-uuid"`
+	Life         Life                 `bson:"life"`
+	StorageId    string               `bson:"storageid"`
+	Kind         StorageKind          `bson:"storagekind"`
+	VolumeId     string               `bson:"volumeid,omitempty"`
+	FilesystemId string               `bson:"filesystemid,omitempty"`
+	Pool         string               `bson:"pool"`
+	Size         uint64               `bson:"size"`
+	HostId       string               `bson:"hostid,omitempty"`
+	Created      time.Time            `bson:"created"`
+	Info         *StorageSnapshotInfo `bson:"info,omitempty"`
+	Message      string               `bson:"message,omitempty"`
+	// Started records that the storage provisioner started taking the
+	// snapshot, so that it is never taken twice.
+	Started bool `bson:"started,omitempty"`
+}
+
+// Id is required to implement StorageSnapshot.
+func (s *storageSnapshot) Id() string {
+	return s.doc.Id
+}
+
+// Life is required to implement StorageSnapshot.
+func (s *storageSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// StorageInstance is required to implement StorageSnapshot.
+func (s *storageSnapshot) StorageInstance() names.StorageTag {
+	return names.NewStorageTag(s.doc.StorageId)
+}
+
+// Kind is required to implement StorageSnapshot.
+func (s *storageSnapshot) Kind() StorageKind {
+	return s.doc.Kind
+}
+
+// Volume is required to implement StorageSnapshot.
+func (s *storageSnapshot) Volume() (names.VolumeTag, error) {
+	if s.doc.VolumeId == "" {
+		return names.VolumeTag{}, errors.NotFoundf("volume for storage snapshot %q", s.doc.Id)
+	}
+	return names.NewVolumeTag(s.doc.VolumeId), nil
+}
+
+// Filesystem is required to implement StorageSnapshot.
+func (s *storageSnapshot) Filesystem() (names.FilesystemTag, error) {
+	if s.doc.FilesystemId == "" {
+		return names.FilesystemTag{}, errors.NotFoundf("filesystem for storage snapshot %q", s.doc.Id)
+	}
+	return names.NewFilesystemTag(s.doc.FilesystemId), nil
+}
+
+// Machine is required to implement StorageSnapshot.
+func (s *storageSnapshot) Machine() (names.MachineTag, bool) {
+	if s.doc.HostId == "" {
+		return names.MachineTag{}, false
+	}
+	return names.NewMachineTag(s.doc.HostId), true
+}
+
+// Pool is required to implement StorageSnapshot.
+func (s *storageSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Size is required to implement StorageSnapshot.
+func (s *storageSnapshot) Size() uint64 {
+	return s.doc.Size
+}
+
+// Created is required to implement StorageSnapshot.
+func (s *storageSnapshot) Created() time.Time {
+	return s.doc.Created.UTC()
+}
+
+// Info is required to implement StorageSnapshot.
+func (s *storageSnapshot) Info() (StorageSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return StorageSnapshotInfo{}, errors.NotProvisionedf("storage snapshot %q", s.doc.Id)
+	}
+	return *s.doc.Info, nil
+}
+
+// Message is required to implement StorageSnapshot.
+func (s *storageSnapshot) Message() string {
+	return s.doc.Message
+}
+
+// newStorageSnapshotId returns a unique ID for a snapshot. Snapshots of
+// machine-scoped storage are given IDs prefixed with the machine ID, so
+// that they can be watched by the machine's storage provisioner.
+func newStorageSnapshotId(mb modelBackend, hostId string) (string, error) {
+	seq, err := sequence(mb, "storagesnapshot")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	id := fmt.Sprint(seq)
+	if hostId != "" {
+		id = hostId + "/" + id
+	}
+	return id, nil
+}
+
+// StorageSnapshot returns the snapshot with the specified ID.
+func (sb *storageBackend) StorageSnapshot(id string) (StorageSnapshot, error) {
+	s, err := sb.storageSnapshot(id)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (sb *storageBackend) storageSnapshot(id string) (*storageSnapshot, error) {
+	coll, cleanup := sb.mb.db().GetCollection(storageSnapshotsC)
+	defer cleanup()
+
+	var doc storageSnapshotDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("storage snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting storage snapshot %q", id)
+	}
+	return &storageSnapshot{doc}, nil
+}
+
+// AllStorageSnapshots returns all snapshots in the model.
+func (sb *storageBackend) AllStorageSnapshots() ([]StorageSnapshot, error) {
+	coll, cleanup := sb.mb.db().GetCollection(storageSnapshotsC)
+	defer cleanup()
+
+	var docs []storageSnapshotDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get storage snapshots")
+	}
+	result := make([]StorageSnapshot, len(docs))
+	for i := range docs {
+		result[i] = &storageSnapshot{docs[i]}
+	}
+	return result, nil
+}
+
+// CreateStorageSnapshot records a request to take a snapshot of the
+// volume or filesystem of the specified storage instance. The snapshot
+// is taken by the storage provisioner responsible for the volume or
+// filesystem, which must already be provisioned. For filesystems backed
+// by a volume, the snapshot is taken of the volume.
+func (sb *storageBackend) CreateStorageSnapshot(tag names.StorageTag) (_ StorageSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot snapshot %s", names.ReadableString(tag))
+
+	s, err := sb.storageInstance(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.Life() != Alive {
+		return nil, errors.New("storage is not alive")
+	}
+	doc := storageSnapshotDoc{
+		StorageId: tag.Id(),
+		Kind:      s.Kind(),
+		Life:      Alive,
+		Created:   sb.mb.clock().Now(),
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     tag.Id(),
+		Assert: isAliveDoc,
+	}}
+	switch s.Kind() {
+	case StorageKindBlock:
+		v, err := sb.storageInstanceVolume(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := doc.setVolume(v); err != nil {
+			return nil, errors.Trace(err)
+		}
+	case StorageKindFilesystem:
+		f, err := sb.storageInstanceFilesystem(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if volumeTag, err := f.Volume(); err == nil {
+			v, err := getVolumeByTag(sb.mb, volumeTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := doc.setVolume(v); err != nil {
+				return nil, errors.Trace(err)
+			}
+		} else if err != ErrNoBackingVolume {
+			return nil, errors.Trace(err)
+		} else {
+			if _, ok := names.FilesystemUnit(f.FilesystemTag()); ok {
+				return nil, errors.NotSupportedf("snapshots of storage scoped to a unit")
+			}
+			info, err := f.Info()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			doc.FilesystemId = f.FilesystemTag().Id()
+			doc.Pool = info.Pool
+			doc.Size = info.Size
+			if machineTag, ok := names.FilesystemMachine(f.FilesystemTag()); ok {
+				doc.HostId = machineTag.Id()
+			}
+		}
+	default:
+		return nil, errors.Errorf("invalid storage kind %v", s.Kind())
+	}
+	if doc.VolumeId != "" {
+		ops = append(ops, txn.Op{
+			C:      volumesC,
+			Id:     doc.VolumeId,
+			Assert: isAliveDoc,
+		})
+	} else {
+		ops = append(ops, txn.Op{
+			C:      filesystemsC,
+			Id:     doc.FilesystemId,
+			Assert: isAliveDoc,
+		})
+	}
+
+	id, err := newStorageSnapshotId(sb.mb, doc.HostId)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate storage snapshot ID")
+	}
+	doc.Id = id
+	ops = append(ops, txn.Op{
+		C:      storageSnapshotsC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	})
+	if err := sb.mb.db().RunTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.New("storage is not alive")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sb.StorageSnapshot(id)
+}
+
+// setVolume records the provisioned volume that the snapshot is taken of.
+func (doc *storageSnapshotDoc) setVolume(v *volume) error {
+	info, err := v.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	doc.VolumeId = v.VolumeTag().Id()
+	doc.Pool = info.Pool
+	doc.Size = info.Size
+	if machineTag, ok := names.VolumeMachine(v.VolumeTag()); ok {
+		doc.HostId = machineTag.Id()
+	}
+	return nil
+}
+
+// StartStorageSnapshot records that the storage provisioner is taking
+// the snapshot. An error satisfying errors.IsAlreadyExists is returned
+// if taking the snapshot was started before, so that a snapshot whose
+// outcome was never recorded, say because the agent restarted while
+// taking it, is not taken again.
+func (sb *storageBackend) StartStorageSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot start storage snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Started || s.doc.Info != nil || s.doc.Message != "" {
+			return nil, errors.AlreadyExistsf("storage snapshot %q", id)
+		}
+		if s.doc.Life != Alive {
+			return nil, errors.New("storage snapshot is not alive")
+		}
+		return []txn.Op{{
+			C:  storageSnapshotsC,
+			Id: id,
+			Assert: bson.D{
+				{"life", Alive},
+				{"started", bson.D{{"$ne", true}}},
+				{"info", bson.D{{"$exists", false}}},
+				{"message", bson.D{{"$exists", false}}},
+			},
+			Update: bson.D{{"$set", bson.D{{"started", true}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// SetStorageSnapshotInfo records the details of a snapshot that has
+// been taken.
+func (sb *storageBackend) SetStorageSnapshotInfo(id string, info StorageSnapshotInfo) error {
+	if info.SnapshotId == "" {
+		return errors.NotValidf("storage snapshot %q with empty snapshot ID", id)
+	}
+	return errors.Annotatef(sb.updateStorageSnapshot(id, bson.D{
+		{"$set", bson.D{{"info", &info}}},
+		{"$unset", bson.D{{"message", nil}}},
+	}), "cannot set info for storage snapshot %q", id)
+}
+
+// SetStorageSnapshotError records that taking a snapshot failed, for
+// the given reason.
+func (sb *storageBackend) SetStorageSnapshotError(id string, message string) error {
+	return errors.Annotatef(sb.updateStorageSnapshot(id, bson.D{
+		{"$set", bson.D{{"message", message}}},
+	}), "cannot set error for storage snapshot %q", id)
+}
+
+// updateStorageSnapshot updates a snapshot that has not yet been taken.
+func (sb *storageBackend) updateStorageSnapshot(id string, update bson.D) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.StorageSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := s.Info(); err == nil {
+			return nil, errors.AlreadyExistsf("info for storage snapshot %q", id)
+		}
+		return []txn.Op{{
+			C:      storageSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"info", bson.D{{"$exists", false}}}},
+			Update: update,
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// DestroyStorageSnapshot ensures that the snapshot will be removed. The
+// snapshot is marked dying, and removed once the storage provisioner has
+// deleted it.
+func (sb *storageBackend) DestroyStorageSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy storage snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{destroyStorageSnapshotOp(id)}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+func destroyStorageSnapshotOp(id string) txn.Op {
+	return txn.Op{
+		C:      storageSnapshotsC,
+		Id:     id,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+	}
+}
+
+// destroyStorageSnapshotsOps returns the txn.Ops to destroy the snapshots
+// of the specified storage instance.
+func destroyStorageSnapshotsOps(mb modelBackend, storageId string) ([]txn.Op, error) {
+	coll, cleanup := mb.db().GetCollection(storageSnapshotsC)
+	defer cleanup()
+
+	var docs []storageSnapshotDoc
+	err := coll.Find(bson.D{
+		{"storageid", storageId},
+		{"life", Alive},
+	}).Select(bson.D{{"id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting snapshots of storage %q", storageId)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = destroyStorageSnapshotOp(doc.Id)
+	}
+	return ops, nil
+}
+
+// RemoveStorageSnapshot removes the dying snapshot with the specified ID,
+// once the storage provisioner has deleted it.
+func (sb *storageBackend) RemoveStorageSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove storage snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life == Alive {
+			return nil, errors.New("storage snapshot is not dying")
+		}
+		return []txn.Op{{
+			C:      storageSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"life", bson.D{{"$ne", Alive}}}},
+			Remove: true,
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// WatchModelStorageSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of snapshots of model-scoped storage.
+func (sb *storageBackend) WatchModelStorageSnapshots() StringsWatcher {
+	return sb.watchModelHostStorage(storageSnapshotsC)
+}
+
+// WatchMachineStorageSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of snapshots of storage scoped to the
+// specified machine.
+func (sb *storageBackend) WatchMachineStorageSnapshots(m names.MachineTag) StringsWatcher {
+	return sb.watchHostStorage(m, storageSnapshotsC)
+}
+
+// AddStorageForUnitFromSnapshot adds a storage instance to the given unit,
+// with its volume or filesystem created from the specified snapshot. The
+// storage is created in the snapshot's pool, and is at least as large as
+// the storage the snapshot was taken of.
+func (sb *storageBackend) AddStorageForUnitFromSnapshot(
+	tag names.UnitTag, storageName string, snapshotId string,
+) (_ []names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add storage from snapshot %q", snapshotId)
+
+	s, err := sb.StorageSnapshot(snapshotId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.Life() != Alive {
+		return nil, errors.New("snapshot is not alive")
+	}
+	info, err := s.Info()
+	if errors.IsNotProvisioned(err) {
+		return nil, errors.New("snapshot has not been taken")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	u, err := sb.unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, err := u.charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	charmStorage, ok := ch.Meta().Storage[storageName]
+	if !ok {
+		return nil, errors.NotFoundf("charm storage %q", storageName)
+	}
+	kind := StorageKindBlock
+	if charmStorage.Type == charm.StorageFilesystem {
+		kind = StorageKindFilesystem
+	}
+	if kind != s.Kind() {
+		return nil, errors.Errorf(
+			"snapshot is of %s storage, charm storage %q is %s storage",
+			s.Kind(), storageName, kind,
+		)
+	}
+	if machineTag, ok := s.Machine(); ok {
+		// Machine-scoped snapshots are only available on the machine.
+		machineId, err := u.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			return nil, errors.Errorf("snapshot is on machine %q, and unit %q is not assigned to a machine", machineTag.Id(), tag.Id())
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if machineId != machineTag.Id() {
+			return nil, errors.Errorf("snapshot is on machine %q, not on machine %q of unit %q", machineTag.Id(), machineId, tag.Id())
+		}
+	}
+
+	size := s.Size()
+	if info.Size > size {
+		size = info.Size
+	}
+	cons := StorageConstraints{
+		Pool:       s.Pool(),
+		Size:       size,
+		Count:      1,
+		snapshotId: info.SnapshotId,
+	}
+	modelOp := &addStorageForUnitOperation{
+		sb:                 sb,
+		u:                  u,
+		storageName:        storageName,
+		storageConstraints: cons,
+	}
+	if err = sb.mb.db().Run(modelOp.Build); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelOp.tags, nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type StorageSnapshotSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageSnapshotSuite{})
+
+func (s *StorageSnapshotSuite) TestCreateStorageSnapshotUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot storage data/0: .* not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *StorageSnapshotSuite) TestCreateStorageSnapshot(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	volume := s.storageInstanceVolume(c, storageTag)
+	err := s.storageBackend.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{VolumeId: "vol-123", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	machine := unitMachine(c, s.st, u)
+
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), jc.HasPrefix, machine.Id()+"/")
+	c.Assert(snapshot.StorageInstance(), gc.Equals, storageTag)
+	c.Assert(snapshot.Kind(), gc.Equals, state.StorageKindBlock)
+	c.Assert(snapshot.Pool(), gc.Equals, "loop-pool")
+	c.Assert(snapshot.Size(), gc.Equals, uint64(1024))
+	volumeTag, err := snapshot.Volume()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeTag, gc.Equals, volume.VolumeTag())
+	_, err = snapshot.Filesystem()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	machineTag, ok := snapshot.Machine()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(machineTag, gc.Equals, machine.MachineTag())
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	snapshots, err := s.storageBackend.AllStorageSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0].Id(), gc.Equals, snapshot.Id())
+}
+
+func (s *StorageSnapshotSuite) TestSetStorageSnapshotInfo(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := state.StorageSnapshotInfo{SnapshotId: "snap-123", Size: 512}
+	err = s.storageBackend.SetStorageSnapshotInfo(snapshot.Id(), info)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.storageBackend.StorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotInfo, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotInfo, jc.DeepEquals, info)
+
+	// Snapshots are taken once; their outcome can't be changed.
+	err = s.storageBackend.SetStorageSnapshotError(snapshot.Id(), "boom")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *StorageSnapshotSuite) TestSetStorageSnapshotError(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.SetStorageSnapshotError(snapshot.Id(), "boom")
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.storageBackend.StorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Message(), gc.Equals, "boom")
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *StorageSnapshotSuite) TestWatchMachineStorageSnapshots(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	machine := unitMachine(c, s.st, u)
+
+	w := s.storageBackend.WatchMachineStorageSnapshots(machine.MachineTag())
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, w)
+	wc.AssertChange() // initial
+	wc.AssertNoChange()
+
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(snapshot.Id())
+	wc.AssertNoChange()
+}
+
+func (s *StorageSnapshotSuite) TestAddStorageForUnitFromSnapshot(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.AddStorageForUnitFromSnapshot(u.UnitTag(), "data", snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot add storage from snapshot ".*": snapshot has not been taken`)
+
+	err = s.storageBackend.SetStorageSnapshotInfo(snapshot.Id(), state.StorageSnapshotInfo{
+		SnapshotId: "snap-123",
+		Size:       2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	tags, err := s.storageBackend.AddStorageForUnitFromSnapshot(u.UnitTag(), "data", snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []names.StorageTag{names.NewStorageTag("data/1")})
+
+	volume := s.storageInstanceVolume(c, tags[0])
+	volumeParams, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(volumeParams.Pool, gc.Equals, "loop-pool")
+	c.Assert(volumeParams.Size, gc.Equals, uint64(2048))
+	c.Assert(volumeParams.SnapshotId, gc.Equals, "snap-123")
+}
+
+func (s *StorageSnapshotSuite) TestAddStorageForUnitFromSnapshotKindMismatch(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetStorageSnapshotInfo(snapshot.Id(), state.StorageSnapshotInfo{SnapshotId: "snap-123"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, u2, _ := s.setupSingleStorageDetachable(c, "filesystem", "rootfs")
+	_, err = s.storageBackend.AddStorageForUnitFromSnapshot(u2.UnitTag(), "data", snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `.*snapshot is of block storage, charm storage "data" is filesystem storage`)
+}
+
+func (s *StorageSnapshotSuite) TestStartStorageSnapshot(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.StartStorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A snapshot is only ever taken once.
+	err = s.storageBackend.StartStorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	err = s.storageBackend.SetStorageSnapshotError(snapshot.Id(), "interrupted")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.StartStorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *StorageSnapshotSuite) TestDestroyAndRemoveStorageSnapshot(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+
+	err = s.storageBackend.RemoveStorageSnapshot(snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot remove storage snapshot ".*": storage snapshot is not dying`)
+
+	err = s.storageBackend.DestroyStorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.DestroyStorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.storageBackend.StorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dying)
+
+	_, err = s.storageBackend.AddStorageForUnitFromSnapshot(u.UnitTag(), "data", snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot add storage from snapshot ".*": snapshot is not alive`)
+
+	err = s.storageBackend.RemoveStorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.StorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.storageBackend.RemoveStorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageSnapshotSuite) TestRemoveStorageInstanceDestroysSnapshots(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	s.provisionStorageVolume(c, u, storageTag)
+	snapshot, err := s.storageBackend.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.DetachStorage(storageTag, u.UnitTag(), false, dontWait)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.DestroyStorageInstance(storageTag, true, false, dontWait)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.RemoveStorageAttachment(storageTag, u.UnitTag(), false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.StorageInstance(storageTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	snapshot, err = s.storageBackend.StorageSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dying)
+}
@@ -0,0 +1,256 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/core/status"
+)
+
+// StorageUsage describes how much of the space and inodes of a storage
+// instance are in use, as last reported by a unit it is attached to.
+type StorageUsage struct {
+	BytesUsed   uint64
+	BytesTotal  uint64
+	InodesUsed  uint64
+	InodesTotal uint64
+
+	// Updated is when the usage was reported.
+	Updated time.Time
+}
+
+// Warning returns a description of the space and inodes in use that
+// are at or above the given percentage threshold, or an empty string
+// if neither is. A threshold of zero or less disables the warning.
+func (u StorageUsage) Warning(threshold int) string {
+	if threshold <= 0 {
+		return ""
+	}
+	var exceeded []string
+	if u.BytesTotal > 0 && u.BytesUsed*100 >= u.BytesTotal*uint64(threshold) {
+		exceeded = append(exceeded, fmt.Sprintf("%d%% of space", u.BytesUsed*100/u.BytesTotal))
+	}
+	if u.InodesTotal > 0 && u.InodesUsed*100 >= u.InodesTotal*uint64(threshold) {
+		exceeded = append(exceeded, fmt.Sprintf("%d%% of inodes", u.InodesUsed*100/u.InodesTotal))
+	}
+	if len(exceeded) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s used, at or above the %d%% warning threshold", strings.Join(exceeded, " and "), threshold)
+}
+
+// ModelStorageUsage is the usage of a storage instance in a model.
+type ModelStorageUsage struct {
+	StorageUsage
+
+	ModelUUID string
+	StorageID string
+}
+
+// storageUsageDoc records the usage of a storage instance. It is
+// removed along with the storage instance.
+type storageUsageDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	StorageId string `bson:"storageid"`
+
+	BytesUsed   uint64 `bson:"bytesused"`
+	BytesTotal  uint64 `bson:"bytestotal"`
+	InodesUsed  uint64 `bson:"inodesused"`
+	InodesTotal uint64 `bson:"inodestotal"`
+	Updated     int64  `bson:"updated"`
+}
+
+func (doc *storageUsageDoc) usage() StorageUsage {
+	return StorageUsage{
+		BytesUsed:   doc.BytesUsed,
+		BytesTotal:  doc.BytesTotal,
+		InodesUsed:  doc.InodesUsed,
+		InodesTotal: doc.InodesTotal,
+		Updated:     time.Unix(0, doc.Updated).UTC(),
+	}
+}
+
+// storageUsageWarningKey is the key in the status data of a filesystem
+// or volume that records that its status message is a usage warning.
+const storageUsageWarningKey = "storage-usage-warning-threshold"
+
+// SetStorageUsage records the usage of the specified storage instance.
+// If no update time is given, the current time is used. If the space or
+// inodes in use are at or above the model's warning threshold, the
+// status message of the storage's filesystem or volume says so; the
+// message is cleared once the usage falls below the threshold again.
+func (sb *storageBackend) SetStorageUsage(tag names.StorageTag, usage StorageUsage) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set usage of %s", names.ReadableString(tag))
+
+	if usage.BytesUsed > usage.BytesTotal {
+		return errors.NotValidf("%d bytes used of %d", usage.BytesUsed, usage.BytesTotal)
+	}
+	if usage.InodesUsed > usage.InodesTotal {
+		return errors.NotValidf("%d inodes used of %d", usage.InodesUsed, usage.InodesTotal)
+	}
+	if usage.Updated.IsZero() {
+		usage.Updated = sb.mb.clock().Now()
+	}
+	doc := storageUsageDoc{
+		DocID:       sb.mb.docID(tag.Id()),
+		ModelUUID:   sb.mb.ModelUUID(),
+		StorageId:   tag.Id(),
+		BytesUsed:   usage.BytesUsed,
+		BytesTotal:  usage.BytesTotal,
+		InodesUsed:  usage.InodesUsed,
+		InodesTotal: usage.InodesTotal,
+		Updated:     usage.Updated.UnixNano(),
+	}
+
+	coll, closer := sb.mb.db().GetCollection(storageUsageC)
+	defer closer()
+
+	var si *storageInstance
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var err error
+		if si, err = sb.storageInstance(tag); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      storageInstancesC,
+			Id:     tag.Id(),
+			Assert: txn.DocExists,
+		}}
+		n, err := coll.FindId(tag.Id()).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n > 0 {
+			return append(ops, txn.Op{
+				C:      storageUsageC,
+				Id:     tag.Id(),
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"bytesused", doc.BytesUsed},
+					{"bytestotal", doc.BytesTotal},
+					{"inodesused", doc.InodesUsed},
+					{"inodestotal", doc.InodesTotal},
+					{"updated", doc.Updated},
+				}}},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      storageUsageC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}), nil
+	}
+	if err := sb.mb.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(sb.setStorageUsageStatus(si, usage))
+}
+
+// setStorageUsageStatus sets or clears the usage warning in the status
+// of the filesystem or volume assigned to the storage instance.
+func (sb *storageBackend) setStorageUsageStatus(si *storageInstance, usage StorageUsage) error {
+	cfg, err := sb.config()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	threshold := cfg.StorageUsageWarningThreshold()
+
+	var entity interface {
+		status.StatusGetter
+		status.StatusSetter
+	}
+	switch si.Kind() {
+	case StorageKindFilesystem:
+		entity, err = sb.StorageInstanceFilesystem(si.StorageTag())
+	case StorageKindBlock:
+		entity, err = sb.StorageInstanceVolume(si.StorageTag())
+	default:
+		return nil
+	}
+	if errors.Is(err, errors.NotFound) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := entity.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, warned := current.Data[storageUsageWarningKey]
+
+	warning := usage.Warning(threshold)
+	switch {
+	case warning != "" && current.Status == status.Attached && current.Message != warning:
+		return entity.SetStatus(status.StatusInfo{
+			Status:  status.Attached,
+			Message: warning,
+			Data:    map[string]interface{}{storageUsageWarningKey: threshold},
+			Since:   &usage.Updated,
+		})
+	case warning == "" && warned:
+		return entity.SetStatus(status.StatusInfo{
+			Status: current.Status,
+			Since:  &usage.Updated,
+		})
+	}
+	return nil
+}
+
+// StorageUsage returns the last reported usage of the specified storage
+// instance. If no usage has been reported, an error satisfying
+// errors.IsNotFound is returned.
+func (sb *storageBackend) StorageUsage(tag names.StorageTag) (StorageUsage, error) {
+	coll, closer := sb.mb.db().GetCollection(storageUsageC)
+	defer closer()
+
+	var doc storageUsageDoc
+	if err := coll.FindId(tag.Id()).One(&doc); err == mgo.ErrNotFound {
+		return StorageUsage{}, errors.NotFoundf("usage of %s", names.ReadableString(tag))
+	} else if err != nil {
+		return StorageUsage{}, errors.Annotatef(err, "cannot get usage of %s", names.ReadableString(tag))
+	}
+	return doc.usage(), nil
+}
+
+// AllStorageUsageForController returns the last reported usage of the
+// storage instances in all models on the controller.
+func (st *State) AllStorageUsageForController() ([]ModelStorageUsage, error) {
+	coll, closer := st.db().GetRawCollection(storageUsageC)
+	defer closer()
+
+	var docs []storageUsageDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get storage usage")
+	}
+	result := make([]ModelStorageUsage, len(docs))
+	for i, doc := range docs {
+		result[i] = ModelStorageUsage{
+			StorageUsage: doc.usage(),
+			ModelUUID:    doc.ModelUUID,
+			StorageID:    doc.StorageId,
+		}
+	}
+	return result, nil
+}
+
+// removeStorageUsageOp returns a txn.Op that removes the usage recorded
+// for the storage instance with the specified ID, if any.
+func removeStorageUsageOp(storageId string) txn.Op {
+	return txn.Op{
+		C:      storageUsageC,
+		Id:     storageId,
+		Remove: true,
+	}
+}
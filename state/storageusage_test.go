@@ -0,0 +1,189 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type StorageUsageSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageUsageSuite{})
+
+func (s *StorageUsageSuite) TestStorageUsageNotReported(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	_, err := s.storageBackend.StorageUsage(storageTag)
+	c.Assert(err, gc.ErrorMatches, `usage of storage data/0 not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StorageUsageSuite) TestSetStorageUsage(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	updated := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	usage := state.StorageUsage{
+		BytesUsed:   1024,
+		BytesTotal:  4096,
+		InodesUsed:  10,
+		InodesTotal: 100,
+		Updated:     updated,
+	}
+	err := s.storageBackend.SetStorageUsage(storageTag, usage)
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := s.storageBackend.StorageUsage(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, usage)
+}
+
+func (s *StorageUsageSuite) TestSetStorageUsageInvalid(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.storageBackend.SetStorageUsage(storageTag, state.StorageUsage{
+		BytesUsed:  2,
+		BytesTotal: 1,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set usage of storage data/0: 2 bytes used of 1 not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *StorageUsageSuite) TestSetStorageUsageNotFound(c *gc.C) {
+	err := s.storageBackend.SetStorageUsage(names.NewStorageTag("data/0"), state.StorageUsage{})
+	c.Assert(err, gc.ErrorMatches, `cannot set usage of storage data/0: storage instance "data/0" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StorageUsageSuite) TestSetStorageUsageUpdates(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.storageBackend.SetStorageUsage(storageTag, state.StorageUsage{
+		BytesUsed:  1,
+		BytesTotal: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	usage := state.StorageUsage{
+		BytesUsed:  2,
+		BytesTotal: 4,
+		Updated:    time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC),
+	}
+	err = s.storageBackend.SetStorageUsage(storageTag, usage)
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := s.storageBackend.StorageUsage(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, usage)
+}
+
+func (s *StorageUsageSuite) TestSetStorageUsageWarningStatus(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	err := filesystem.SetStatus(status.StatusInfo{Status: status.Attached})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.SetStorageUsage(storageTag, state.StorageUsage{
+		BytesUsed:   95,
+		BytesTotal:  100,
+		InodesUsed:  10,
+		InodesTotal: 100,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := filesystem.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, status.Attached)
+	c.Assert(info.Message, gc.Equals, "95% of space used, at or above the 90% warning threshold")
+
+	err = s.storageBackend.SetStorageUsage(storageTag, state.StorageUsage{
+		BytesUsed:   50,
+		BytesTotal:  100,
+		InodesUsed:  10,
+		InodesTotal: 100,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	info, err = filesystem.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, status.Attached)
+	c.Assert(info.Message, gc.Equals, "")
+}
+
+func (s *StorageUsageSuite) TestSetStorageUsageWarningLeavesOtherStatus(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	err := filesystem.SetStatus(status.StatusInfo{Status: status.Error, Message: "boom"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.SetStorageUsage(storageTag, state.StorageUsage{
+		BytesUsed:  95,
+		BytesTotal: 100,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := filesystem.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, status.Error)
+	c.Assert(info.Message, gc.Equals, "boom")
+}
+
+func (s *StorageUsageSuite) TestRemoveStorageInstanceRemovesUsage(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "loop-pool")
+	err := s.storageBackend.SetStorageUsage(storageTag, state.StorageUsage{
+		BytesUsed:  1,
+		BytesTotal: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.DetachStorage(storageTag, u.UnitTag(), false, dontWait)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.DestroyStorageInstance(storageTag, true, false, dontWait)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.RemoveStorageAttachment(storageTag, u.UnitTag(), false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.StorageInstance(storageTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.storageBackend.StorageUsage(storageTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	all, err := s.State.AllStorageUsageForController()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
+
+func (s *StorageUsageSuite) TestAllStorageUsageForController(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	usage := state.StorageUsage{
+		BytesUsed:   1024,
+		BytesTotal:  4096,
+		InodesUsed:  10,
+		InodesTotal: 100,
+		Updated:     time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC),
+	}
+	err := s.storageBackend.SetStorageUsage(storageTag, usage)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllStorageUsageForController()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, []state.ModelStorageUsage{{
+		StorageUsage: usage,
+		ModelUUID:    s.State.ModelUUID(),
+		StorageID:    "data/0",
+	}})
+}
+
+func (s *StorageUsageSuite) TestStorageUsageWarning(c *gc.C) {
+	usage := state.StorageUsage{
+		BytesUsed:   95,
+		BytesTotal:  100,
+		InodesUsed:  91,
+		InodesTotal: 100,
+	}
+	c.Check(usage.Warning(90), gc.Equals, "95% of space and 91% of inodes used, at or above the 90% warning threshold")
+	c.Check(usage.Warning(92), gc.Equals, "95% of space used, at or above the 92% warning threshold")
+	c.Check(usage.Warning(96), gc.Equals, "")
+	c.Check(usage.Warning(0), gc.Equals, "")
+}
@@ -2376,9 +2376,10 @@ func storageParamsForStorageInstance(
 			}
 		} else if errors.IsNotFound(err) {
 			filesystemParams := FilesystemParams{
-				storage: storage.StorageTag(),
-				Pool:    storage.doc.Constraints.Pool,
-				Size:    storage.doc.Constraints.Size,
+				storage:    storage.StorageTag(),
+				Pool:       storage.doc.Constraints.Pool,
+				Size:       storage.doc.Constraints.Size,
+				SnapshotId: storage.doc.Constraints.SnapshotId,
 			}
 			filesystems = append(filesystems, HostFilesystemParams{
 				filesystemParams, filesystemAttachmentParams,
@@ -2421,9 +2422,10 @@ func storageParamsForStorageInstance(
 			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
 		} else if errors.IsNotFound(err) {
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				Pool:    storage.doc.Constraints.Pool,
-				Size:    storage.doc.Constraints.Size,
+				storage:    storage.StorageTag(),
+				Pool:       storage.doc.Constraints.Pool,
+				Size:       storage.doc.Constraints.Size,
+				SnapshotId: storage.doc.Constraints.SnapshotId,
 			}
 			volumes = append(volumes, HostVolumeParams{
 				volumeParams, volumeAttachmentParams,
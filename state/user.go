@@ -276,6 +276,13 @@ func (st *State) RemoveUser(tag names.UserTag) error {
 		// remove the user from the controller
 		ops = append(ops, removeControllerUserOps(st.ControllerUUID(), tag)...)
 
+		// remove the user from any groups
+		groupOps, err := st.removeUserFromGroupsOps(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, groupOps...)
+
 		// new entry in the removal log
 		newRemovalLogEntry := userRemovedLogEntry{
 			RemovedBy:   u.doc.CreatedBy,
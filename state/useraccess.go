@@ -140,12 +140,21 @@ func NewControllerUserAccess(st *State, userDoc userAccessDoc) (permission.UserA
 	return newUserAccess(perm, userDoc, names.NewControllerTag(userDoc.ObjectUUID)), nil
 }
 
-// UserPermission returns the access permission for the passed subject and target.
+// UserPermission returns the access permission for the passed subject and
+// target. This is the greatest of the access granted directly to the user,
+// and the access granted to any of the groups the user is a member of.
 func (st *State) UserPermission(subject names.UserTag, target names.Tag) (permission.Access, error) {
 	if err := st.userMayHaveAccess(subject); err != nil {
 		return "", errors.Trace(err)
 	}
 
+	access, err := st.userDirectPermission(subject, target)
+	return st.withUserGroupsAccess(subject, target, access, err)
+}
+
+// userDirectPermission returns the access permission granted directly to
+// the subject on the target.
+func (st *State) userDirectPermission(subject names.UserTag, target names.Tag) (permission.Access, error) {
 	switch target.Kind() {
 	case names.ModelTagKind, names.ControllerTagKind:
 		access, err := st.UserAccess(subject, target)
@@ -154,9 +163,9 @@ func (st *State) UserPermission(subject names.UserTag, target names.Tag) (permis
 		}
 		return access.Access, nil
 	case names.ApplicationOfferTagKind:
-		return st.GetOfferAccess(target.Id(), subject)
+		return st.offerDirectAccess(target.Id(), subject)
 	case names.CloudTagKind:
-		return st.GetCloudAccess(target.Id(), subject)
+		return st.cloudDirectAccess(target.Id(), subject)
 	default:
 		return "", errors.NotValidf("%q as a target", target.Kind())
 	}
@@ -0,0 +1,575 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+	"github.com/juju/names/v5"
+	jujutxn "github.com/juju/txn/v3"
+
+	"github.com/juju/juju/core/permission"
+)
+
+const userGroupGlobalKeyPrefix = "gr"
+
+// userGroupGlobalKey returns the permission subject key for the group.
+func userGroupGlobalKey(name string) string {
+	return fmt.Sprintf("%s#%s", userGroupGlobalKeyPrefix, strings.ToLower(name))
+}
+
+// userGroupDoc represents a group of users that access can be granted to.
+type userGroupDoc struct {
+	DocID       string    `bson:"_id"`
+	Name        string    `bson:"name"`
+	Members     []string  `bson:"members"`
+	CreatedBy   string    `bson:"created-by"`
+	DateCreated time.Time `bson:"date-created"`
+}
+
+// UserGroup represents a group of users. Access granted to a group on a
+// controller, model, application offer or cloud applies to all its members.
+type UserGroup struct {
+	st  *State
+	doc userGroupDoc
+}
+
+// Name returns the name of the group.
+func (g *UserGroup) Name() string {
+	return g.doc.Name
+}
+
+// Members returns the users in the group, sorted by name.
+func (g *UserGroup) Members() []names.UserTag {
+	members := make([]string, len(g.doc.Members))
+	copy(members, g.doc.Members)
+	sort.Strings(members)
+
+	result := make([]names.UserTag, len(members))
+	for i, member := range members {
+		result[i] = names.NewUserTag(member)
+	}
+	return result
+}
+
+// CreatedBy returns the name of the user that created the group.
+func (g *UserGroup) CreatedBy() string {
+	return g.doc.CreatedBy
+}
+
+// DateCreated returns when the group was created, in UTC.
+func (g *UserGroup) DateCreated() time.Time {
+	return g.doc.DateCreated.UTC()
+}
+
+// Refresh refreshes the contents of the group from the underlying state.
+func (g *UserGroup) Refresh() error {
+	var doc userGroupDoc
+	if err := g.st.getUserGroup(g.doc.Name, &doc); err != nil {
+		return errors.Trace(err)
+	}
+	g.doc = doc
+	return nil
+}
+
+// AddMembers adds the users to the group. Local users must exist. Adding
+// a user that is already a member is not an error.
+func (g *UserGroup) AddMembers(users ...names.UserTag) error {
+	members := make([]string, len(users))
+	for i, user := range users {
+		if user.IsLocal() {
+			if _, err := g.st.User(user); err != nil {
+				return errors.Annotatef(err, "adding %q to group %q", user.Id(), g.doc.Name)
+			}
+		}
+		members[i] = userAccessID(user)
+	}
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     g.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"members", bson.D{{"$each", members}}}}}},
+	}}
+	if err := g.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("group %q", g.doc.Name)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(g.Refresh())
+}
+
+// RemoveMembers removes the users from the group. Removing a user that is
+// not a member is not an error.
+func (g *UserGroup) RemoveMembers(users ...names.UserTag) error {
+	members := make([]string, len(users))
+	for i, user := range users {
+		members[i] = userAccessID(user)
+	}
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     g.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pullAll", bson.D{{"members", members}}}},
+	}}
+	if err := g.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("group %q", g.doc.Name)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(g.Refresh())
+}
+
+// AddUserGroup adds a group of users to the controller.
+func (st *State) AddUserGroup(name, creator string) (*UserGroup, error) {
+	if !names.IsValidUserName(name) {
+		return nil, errors.NotValidf("group name %q", name)
+	}
+	group := &UserGroup{
+		st: st,
+		doc: userGroupDoc{
+			DocID:       strings.ToLower(name),
+			Name:        name,
+			Members:     []string{},
+			CreatedBy:   creator,
+			DateCreated: st.nowToTheSecond(),
+		},
+	}
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     group.doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &group.doc,
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.AlreadyExistsf("group %q", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+func (st *State) getUserGroup(name string, doc *userGroupDoc) error {
+	groups, closer := st.db().GetCollection(userGroupsC)
+	defer closer()
+
+	err := groups.FindId(strings.ToLower(name)).One(doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("group %q", name)
+	}
+	return errors.Annotatef(err, "cannot get group %q", name)
+}
+
+// UserGroup returns the group with the given name.
+func (st *State) UserGroup(name string) (*UserGroup, error) {
+	group := &UserGroup{st: st}
+	if err := st.getUserGroup(name, &group.doc); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// AllUserGroups returns all the groups in the controller, sorted by name.
+func (st *State) AllUserGroups() ([]*UserGroup, error) {
+	return st.userGroups(nil)
+}
+
+// UserGroupsForUser returns the groups the user is a member of, sorted by
+// name.
+func (st *State) UserGroupsForUser(user names.UserTag) ([]*UserGroup, error) {
+	return st.userGroups(bson.D{{"members", userAccessID(user)}})
+}
+
+func (st *State) userGroups(query bson.D) ([]*UserGroup, error) {
+	groups, closer := st.db().GetCollection(userGroupsC)
+	defer closer()
+
+	var docs []userGroupDoc
+	if err := groups.Find(query).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get groups")
+	}
+	result := make([]*UserGroup, len(docs))
+	for i, doc := range docs {
+		result[i] = &UserGroup{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// RemoveUserGroup removes the group, along with all the access granted
+// to it.
+func (st *State) RemoveUserGroup(name string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		group, err := st.UserGroup(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      userGroupsC,
+			Id:     group.doc.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}
+		perms, err := st.userGroupPermissionDocs(bson.D{{"subject-global-key", userGroupGlobalKey(name)}})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, perm := range perms {
+			ops = append(ops, removePermissionOp(perm.ObjectGlobalKey, perm.SubjectGlobalKey))
+		}
+		return ops, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// removeUserFromGroupsOps returns the operations needed to remove the user
+// from all the groups it is a member of.
+func (st *State) removeUserFromGroupsOps(user names.UserTag) ([]txn.Op, error) {
+	groups, err := st.UserGroupsForUser(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(groups))
+	for i, group := range groups {
+		ops[i] = txn.Op{
+			C:      userGroupsC,
+			Id:     group.doc.DocID,
+			Update: bson.D{{"$pull", bson.D{{"members", userAccessID(user)}}}},
+		}
+	}
+	return ops, nil
+}
+
+// UserGroupPermission describes the access granted to a group on a target.
+type UserGroupPermission struct {
+	// Group is the name of the group.
+	Group string
+
+	// Target is the controller, model, application offer or cloud
+	// that access has been granted on.
+	Target names.Tag
+
+	// Access is the access granted to the group.
+	Access permission.Access
+}
+
+// userGroupTargetKey returns the permission object key for the target,
+// after validating the access is appropriate for it.
+func userGroupTargetKey(target names.Tag, access permission.Access) (string, error) {
+	var (
+		key      string
+		validate func(permission.Access) error
+	)
+	switch target.Kind() {
+	case names.ControllerTagKind:
+		key, validate = controllerKey(target.Id()), permission.ValidateControllerAccess
+	case names.ModelTagKind:
+		key, validate = modelKey(target.Id()), permission.ValidateModelAccess
+	case names.ApplicationOfferTagKind:
+		key, validate = applicationOfferKey(target.Id()), permission.ValidateOfferAccess
+	case names.CloudTagKind:
+		key, validate = cloudGlobalKey(target.Id()), permission.ValidateCloudAccess
+	default:
+		return "", errors.NotValidf("%q as a target", target.Kind())
+	}
+	if access != permission.NoAccess {
+		if err := validate(access); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	return key, nil
+}
+
+// userGroupTarget returns the target identified by the permission object
+// key, or false if the key is not a valid target.
+func userGroupTarget(objectGlobalKey string) (names.Tag, bool) {
+	kind, id, ok := strings.Cut(objectGlobalKey, "#")
+	if !ok {
+		return nil, false
+	}
+	switch kind {
+	case controllerGlobalKey:
+		return names.NewControllerTag(id), true
+	case modelGlobalKey:
+		return names.NewModelTag(id), true
+	case applicationOfferGlobalKey:
+		return names.NewApplicationOfferTag(id), true
+	case "cloud":
+		// See cloudGlobalKey.
+		return names.NewCloudTag(id), true
+	}
+	return nil, false
+}
+
+// SetUserGroupAccess sets the access the group has on the target, which
+// is a controller, model, application offer or cloud.
+func (st *State) SetUserGroupAccess(group string, target names.Tag, access permission.Access) error {
+	objectKey, err := userGroupTargetKey(target, access)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if access == permission.NoAccess {
+		return errors.NotValidf("empty access")
+	}
+	if target.Kind() == names.ControllerTagKind && target.Id() != st.ControllerUUID() {
+		return errors.NotValidf("controller %q", target.Id())
+	}
+	subjectKey := userGroupGlobalKey(group)
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		groupDoc := userGroupDoc{}
+		if err := st.getUserGroup(group, &groupDoc); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      userGroupsC,
+			Id:     groupDoc.DocID,
+			Assert: txn.DocExists,
+		}}
+		switch target.Kind() {
+		case names.ModelTagKind:
+			ops = append(ops, txn.Op{
+				C:      modelsC,
+				Id:     target.Id(),
+				Assert: isAliveDoc,
+			})
+		case names.CloudTagKind:
+			ops = append(ops, txn.Op{
+				C:      cloudsC,
+				Id:     target.Id(),
+				Assert: txn.DocExists,
+			})
+		}
+
+		_, err := st.userPermission(objectKey, subjectKey)
+		if errors.Is(err, errors.NotFound) {
+			return append(ops, createPermissionOp(objectKey, subjectKey, access)), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, updatePermissionOp(objectKey, subjectKey, access)), nil
+	}
+	err = st.db().Run(buildTxn)
+	if err == jujutxn.ErrExcessiveContention {
+		if _, err := st.UserGroup(group); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.NotFoundf("%s", names.ReadableString(target))
+	}
+	return errors.Trace(err)
+}
+
+// RemoveUserGroupAccess removes the access the group has on the target.
+func (st *State) RemoveUserGroupAccess(group string, target names.Tag) error {
+	objectKey, err := userGroupTargetKey(target, permission.NoAccess)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{removePermissionOp(objectKey, userGroupGlobalKey(group))}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("access for group %q on %s", group, names.ReadableString(target))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UserGroupAccess returns the access the group has on the target.
+func (st *State) UserGroupAccess(group string, target names.Tag) (permission.Access, error) {
+	objectKey, err := userGroupTargetKey(target, permission.NoAccess)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	perm, err := st.userPermission(objectKey, userGroupGlobalKey(group))
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// UserGroupPermissions returns all the access granted to the group.
+func (st *State) UserGroupPermissions(group string) ([]UserGroupPermission, error) {
+	groupDoc := userGroupDoc{}
+	if err := st.getUserGroup(group, &groupDoc); err != nil {
+		return nil, errors.Trace(err)
+	}
+	perms, err := st.userGroupPermissionDocs(bson.D{{"subject-global-key", userGroupGlobalKey(group)}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []UserGroupPermission
+	for _, perm := range perms {
+		target, ok := userGroupTarget(perm.ObjectGlobalKey)
+		if !ok {
+			continue
+		}
+		result = append(result, UserGroupPermission{
+			Group:  groupDoc.Name,
+			Target: target,
+			Access: stringToAccess(perm.Access),
+		})
+	}
+	return result, nil
+}
+
+// removeUserGroupsAccessOps returns the operations needed to remove the
+// access all groups have on the object.
+func (st *State) removeUserGroupsAccessOps(objectGlobalKey string) ([]txn.Op, error) {
+	perms, err := st.userGroupPermissionDocs(bson.D{
+		{"object-global-key", objectGlobalKey},
+		{"subject-global-key", bson.D{{"$regex", "^" + userGroupGlobalKeyPrefix + "#"}}},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(perms))
+	for i, perm := range perms {
+		ops[i] = removePermissionOp(perm.ObjectGlobalKey, perm.SubjectGlobalKey)
+	}
+	return ops, nil
+}
+
+func (st *State) userGroupPermissionDocs(query bson.D) ([]permissionDoc, error) {
+	permissions, closer := st.db().GetCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	if err := permissions.Find(query).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get group permissions")
+	}
+	return docs, nil
+}
+
+// userGroupsAccess returns the greatest access granted on the target to
+// any of the groups the user is a member of.
+func (st *State) userGroupsAccess(user names.UserTag, target names.Tag) (permission.Access, error) {
+	objectKey, err := userGroupTargetKey(target, permission.NoAccess)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	groups, err := st.UserGroupsForUser(user)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	if len(groups) == 0 {
+		return permission.NoAccess, nil
+	}
+	ids := make([]string, len(groups))
+	for i, group := range groups {
+		ids[i] = permissionID(objectKey, userGroupGlobalKey(group.doc.Name))
+	}
+	perms, err := st.userGroupPermissionDocs(bson.D{{"_id", bson.D{{"$in", ids}}}})
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+
+	access := permission.NoAccess
+	for _, perm := range perms {
+		access = greaterAccess(target.Kind(), access, stringToAccess(perm.Access))
+	}
+	return access, nil
+}
+
+// withUserGroupsAccess combines the access granted directly to the user
+// on the target, as returned with err by a direct lookup, with the
+// greatest access granted to any of the user's groups. A not found error
+// from the direct lookup is only returned if no group has access either.
+func (st *State) withUserGroupsAccess(
+	user names.UserTag, target names.Tag, access permission.Access, err error,
+) (permission.Access, error) {
+	if err != nil && !errors.Is(err, errors.NotFound) {
+		return "", errors.Trace(err)
+	}
+	groupAccess, groupErr := st.userGroupsAccess(user, target)
+	if groupErr != nil {
+		return "", errors.Trace(groupErr)
+	}
+	if groupAccess == permission.NoAccess {
+		// Preserve the not found error when there is no access at all.
+		return access, errors.Trace(err)
+	}
+	return greaterAccess(target.Kind(), access, groupAccess), nil
+}
+
+// userGroupsModelUUIDs returns the uuids of the models that any of the
+// groups the user is a member of have been granted access to.
+func (st *State) userGroupsModelUUIDs(user names.UserTag) ([]string, error) {
+	groups, err := st.UserGroupsForUser(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	subjectKeys := make([]string, len(groups))
+	for i, group := range groups {
+		subjectKeys[i] = userGroupGlobalKey(group.doc.Name)
+	}
+	perms, err := st.userGroupPermissionDocs(bson.D{
+		{"subject-global-key", bson.D{{"$in", subjectKeys}}},
+		{"object-global-key", bson.D{{"$regex", "^" + modelGlobalKey + "#"}}},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	uuids := make([]string, len(perms))
+	for i, perm := range perms {
+		uuids[i] = strings.TrimPrefix(perm.ObjectGlobalKey, modelGlobalKey+"#")
+	}
+	return uuids, nil
+}
+
+// userGroupsCloudAccess returns the greatest access granted on each cloud
+// to any of the groups the user is a member of, keyed by cloud name.
+func (st *State) userGroupsCloudAccess(user names.UserTag) (map[string]permission.Access, error) {
+	groups, err := st.UserGroupsForUser(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	subjectKeys := make([]string, len(groups))
+	for i, group := range groups {
+		subjectKeys[i] = userGroupGlobalKey(group.doc.Name)
+	}
+	perms, err := st.userGroupPermissionDocs(bson.D{
+		{"subject-global-key", bson.D{{"$in", subjectKeys}}},
+		{"object-global-key", bson.D{{"$regex", "^" + cloudGlobalKey("")}}},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]permission.Access)
+	for _, perm := range perms {
+		cloudName := strings.TrimPrefix(perm.ObjectGlobalKey, cloudGlobalKey(""))
+		result[cloudName] = greaterAccess(names.CloudTagKind, result[cloudName], stringToAccess(perm.Access))
+	}
+	return result, nil
+}
+
+// greaterAccess returns the greater of the two access levels, as ordered
+// for the kind of target.
+func greaterAccess(kind string, a, b permission.Access) permission.Access {
+	var greaterOrEqual bool
+	switch kind {
+	case names.ControllerTagKind:
+		greaterOrEqual = a.EqualOrGreaterControllerAccessThan(b)
+	case names.ModelTagKind:
+		greaterOrEqual = a.EqualOrGreaterModelAccessThan(b)
+	case names.ApplicationOfferTagKind:
+		greaterOrEqual = a.EqualOrGreaterOfferAccessThan(b)
+	case names.CloudTagKind:
+		greaterOrEqual = a.EqualOrGreaterCloudAccessThan(b)
+	}
+	if greaterOrEqual || b == permission.NoAccess {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,383 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UserGroupSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UserGroupSuite{})
+
+func (s *UserGroupSuite) makeGroup(c *gc.C, name string, members ...names.UserTag) *state.UserGroup {
+	group, err := s.State.AddUserGroup(name, "test-admin")
+	c.Assert(err, jc.ErrorIsNil)
+	if len(members) > 0 {
+		err = group.AddMembers(members...)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return group
+}
+
+func (s *UserGroupSuite) TestAddUserGroup(c *gc.C) {
+	group, err := s.State.AddUserGroup("Engineers", "test-admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "Engineers")
+	c.Assert(group.CreatedBy(), gc.Equals, "test-admin")
+	c.Assert(group.Members(), gc.HasLen, 0)
+
+	group, err = s.State.UserGroup("engineers")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "Engineers")
+}
+
+func (s *UserGroupSuite) TestAddUserGroupAlreadyExists(c *gc.C) {
+	s.makeGroup(c, "engineers")
+	_, err := s.State.AddUserGroup("Engineers", "test-admin")
+	c.Assert(err, jc.ErrorIs, errors.AlreadyExists)
+}
+
+func (s *UserGroupSuite) TestAddUserGroupInvalidName(c *gc.C) {
+	_, err := s.State.AddUserGroup("bad@group", "test-admin")
+	c.Assert(err, gc.ErrorMatches, `group name "bad@group" not valid`)
+}
+
+func (s *UserGroupSuite) TestUserGroupNotFound(c *gc.C) {
+	_, err := s.State.UserGroup("missing")
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *UserGroupSuite) TestAllUserGroups(c *gc.C) {
+	s.makeGroup(c, "testers")
+	s.makeGroup(c, "engineers")
+
+	groups, err := s.State.AllUserGroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 2)
+	c.Assert(groups[0].Name(), gc.Equals, "engineers")
+	c.Assert(groups[1].Name(), gc.Equals, "testers")
+}
+
+func (s *UserGroupSuite) TestMembers(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	mary := names.NewUserTag("mary@external")
+	group := s.makeGroup(c, "engineers", mary, bob, bob)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{bob, mary})
+
+	groups, err := s.State.UserGroupsForUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 1)
+	c.Assert(groups[0].Name(), gc.Equals, "engineers")
+
+	err = group.RemoveMembers(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{mary})
+
+	groups, err = s.State.UserGroupsForUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 0)
+}
+
+func (s *UserGroupSuite) TestAddMembersUnknownLocalUser(c *gc.C) {
+	group := s.makeGroup(c, "engineers")
+	err := group.AddMembers(names.NewUserTag("nobody"))
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+	c.Assert(group.Members(), gc.HasLen, 0)
+}
+
+func (s *UserGroupSuite) TestRemoveUserLeavesGroups(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	group := s.makeGroup(c, "engineers", bob)
+
+	err := s.State.RemoveUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = group.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), gc.HasLen, 0)
+}
+
+func (s *UserGroupSuite) TestGroupModelAccess(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	modelTag := s.Model.ModelTag()
+
+	_, err := s.State.UserPermission(bob, modelTag)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	s.makeGroup(c, "engineers", bob)
+	s.makeGroup(c, "admins", bob)
+	err = s.State.SetUserGroupAccess("engineers", modelTag, permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("admins", modelTag, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserPermission(bob, modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	uuids, err := s.State.ModelUUIDsForUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuids, jc.DeepEquals, []string{modelTag.Id()})
+
+	summaries, err := s.State.ModelSummariesForUser(bob, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(summaries, gc.HasLen, 1)
+	c.Assert(summaries[0].Access, gc.Equals, permission.WriteAccess)
+}
+
+func (s *UserGroupSuite) TestGroupAccessUnionWithUserAccess(c *gc.C) {
+	bob := s.Factory.MakeModelUser(c, &factory.ModelUserParams{
+		User:   "bob",
+		Access: permission.AdminAccess,
+	}).UserTag
+	modelTag := s.Model.ModelTag()
+
+	s.makeGroup(c, "engineers", bob)
+	err := s.State.SetUserGroupAccess("engineers", modelTag, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserPermission(bob, modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+}
+
+func (s *UserGroupSuite) TestGroupControllerAccess(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	s.makeGroup(c, "admins", bob)
+
+	err := s.State.SetUserGroupAccess("admins", s.State.ControllerTag(), permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserPermission(bob, s.State.ControllerTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.SuperuserAccess)
+}
+
+func (s *UserGroupSuite) TestGroupCloudAccess(c *gc.C) {
+	err := s.State.AddCloud(cloud.Cloud{
+		Name:      "fluffy",
+		Type:      "dummy",
+		AuthTypes: []cloud.AuthType{cloud.UserPassAuthType},
+	}, "test-admin")
+	c.Assert(err, jc.ErrorIsNil)
+	bob := names.NewUserTag("bob@external")
+	s.makeGroup(c, "engineers", bob)
+
+	err = s.State.SetUserGroupAccess("engineers", names.NewCloudTag("fluffy"), permission.AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserPermission(bob, names.NewCloudTag("fluffy"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AddModelAccess)
+
+	access, err = s.State.GetCloudAccess("fluffy", bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AddModelAccess)
+
+	clouds, err := s.State.CloudsForUser(bob, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clouds, gc.HasLen, 1)
+	c.Assert(clouds[0].Name, gc.Equals, "fluffy")
+	c.Assert(clouds[0].Access, gc.Equals, permission.AddModelAccess)
+
+	// Group access is not reported as user access.
+	users, err := s.State.GetCloudUsers("fluffy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, jc.DeepEquals, map[string]permission.Access{
+		"test-admin": permission.AdminAccess,
+	})
+}
+
+func (s *UserGroupSuite) TestGroupCloudAccessUnionWithUserAccess(c *gc.C) {
+	err := s.State.AddCloud(cloud.Cloud{
+		Name:      "fluffy",
+		Type:      "dummy",
+		AuthTypes: []cloud.AuthType{cloud.UserPassAuthType},
+	}, "test-admin")
+	c.Assert(err, jc.ErrorIsNil)
+	bob := names.NewUserTag("bob@external")
+	err = s.State.CreateCloudAccess("fluffy", bob, permission.AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.makeGroup(c, "engineers", bob)
+	err = s.State.SetUserGroupAccess("engineers", names.NewCloudTag("fluffy"), permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	clouds, err := s.State.CloudsForUser(bob, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clouds, gc.HasLen, 1)
+	c.Assert(clouds[0].Access, gc.Equals, permission.AdminAccess)
+
+	// Removing the user's own access leaves the group's.
+	err = s.State.RemoveCloudAccess("fluffy", bob)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.GetCloudAccess("fluffy", bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+}
+
+func (s *UserGroupSuite) TestGroupOfferAccess(c *gc.C) {
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	offer, err := state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "someoffer",
+		ApplicationName: "mysql",
+		Owner:           "test-admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	offerTag := names.NewApplicationOfferTag(offer.OfferUUID)
+
+	_, err = s.State.GetOfferAccess(offer.OfferUUID, bob)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	s.makeGroup(c, "consumers", bob)
+	err = s.State.SetUserGroupAccess("consumers", offerTag, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.GetOfferAccess(offer.OfferUUID, bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ConsumeAccess)
+
+	// Direct access is combined with the group's.
+	err = s.State.CreateOfferAccess(offerTag, bob, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.GetOfferAccess(offer.OfferUUID, bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ConsumeAccess)
+
+	// Group access is not reported as user access.
+	users, err := s.State.GetOfferUsers(offer.OfferUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users["bob"], gc.Equals, permission.ReadAccess)
+}
+
+func (s *UserGroupSuite) TestSetUserGroupAccessInvalid(c *gc.C) {
+	s.makeGroup(c, "engineers")
+
+	err := s.State.SetUserGroupAccess("engineers", s.Model.ModelTag(), permission.SuperuserAccess)
+	c.Assert(err, gc.ErrorMatches, `"superuser" model access not valid`)
+
+	err = s.State.SetUserGroupAccess("engineers", names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	err = s.State.SetUserGroupAccess("missing", s.Model.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *UserGroupSuite) TestUserGroupPermissions(c *gc.C) {
+	s.makeGroup(c, "engineers")
+	modelTag := s.Model.ModelTag()
+	err := s.State.SetUserGroupAccess("engineers", modelTag, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("engineers", modelTag, permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("engineers", s.State.ControllerTag(), permission.LoginAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserGroupAccess("engineers", modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	perms, err := s.State.UserGroupPermissions("engineers")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perms, jc.SameContents, []state.UserGroupPermission{{
+		Group:  "engineers",
+		Target: s.State.ControllerTag(),
+		Access: permission.LoginAccess,
+	}, {
+		Group:  "engineers",
+		Target: modelTag,
+		Access: permission.WriteAccess,
+	}})
+
+	err = s.State.RemoveUserGroupAccess("engineers", modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserGroupAccess("engineers", modelTag)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	err = s.State.RemoveUserGroupAccess("engineers", modelTag)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *UserGroupSuite) TestRemoveUserGroup(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	s.makeGroup(c, "engineers", bob)
+	modelTag := s.Model.ModelTag()
+	err := s.State.SetUserGroupAccess("engineers", modelTag, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveUserGroup("engineers")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.UserGroup("engineers")
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+	_, err = s.State.UserPermission(bob, modelTag)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	// Recreating the group does not restore its access.
+	s.makeGroup(c, "engineers", bob)
+	_, err = s.State.UserPermission(bob, modelTag)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *UserGroupSuite) TestRemoveModelRemovesGroupAccess(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	s.makeGroup(c, "engineers", bob)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUserGroupAccess("engineers", model.ModelTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = model.Destroy(state.DestroyModelParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.RemoveDyingModel()
+	c.Assert(err, jc.ErrorIsNil)
+
+	perms, err := s.State.UserGroupPermissions("engineers")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perms, gc.HasLen, 0)
+	_, err = s.State.UserGroupAccess("engineers", model.ModelTag())
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *UserGroupSuite) TestRemoveCloudRemovesGroupAccess(c *gc.C) {
+	fluffy := cloud.Cloud{
+		Name:      "fluffy",
+		Type:      "dummy",
+		AuthTypes: []cloud.AuthType{cloud.UserPassAuthType},
+	}
+	err := s.State.AddCloud(fluffy, "test-admin")
+	c.Assert(err, jc.ErrorIsNil)
+	bob := names.NewUserTag("bob@external")
+	s.makeGroup(c, "engineers", bob)
+	err = s.State.SetUserGroupAccess("engineers", names.NewCloudTag("fluffy"), permission.AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveCloud("fluffy")
+	c.Assert(err, jc.ErrorIsNil)
+
+	perms, err := s.State.UserGroupPermissions("engineers")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(perms, gc.HasLen, 0)
+
+	// A cloud added later with the same name is not accessible to the
+	// group.
+	err = s.State.AddCloud(fluffy, "test-admin")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserPermission(bob, names.NewCloudTag("fluffy"))
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
@@ -53,13 +53,14 @@ func (st *State) userPermission(objectGlobalKey, subjectGlobalKey string) (*user
 	return result, nil
 }
 
-// usersPermissions returns all permissions for a given object.
+// usersPermissions returns all user permissions for a given object.
 func (st *State) usersPermissions(objectGlobalKey string) ([]*userPermission, error) {
 	permissions, closer := st.db().GetCollection(permissionsC)
 	defer closer()
 
 	var matchingPermissions []permissionDoc
-	findExpr := fmt.Sprintf("^%s#.*$", objectGlobalKey)
+	// Only match permissions granted to users, not groups.
+	findExpr := fmt.Sprintf("^%s#%s#.*$", objectGlobalKey, userGlobalKeyPrefix)
 	if err := permissions.Find(
 		bson.D{{"_id", bson.D{{"$regex", findExpr}}}},
 	).All(&matchingPermissions); err != nil {
@@ -49,6 +49,10 @@ type Volume interface {
 	// Detachable reports whether or not the volume is detachable.
 	Detachable() bool
 
+	// RequestedSize returns the size in MiB that the volume is to be
+	// grown to, if a resize has been requested and not yet completed.
+	RequestedSize() (uint64, bool)
+
 	// Releasing reports whether or not the volume is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
@@ -126,6 +130,7 @@ type volumeDoc struct {
 	AttachmentCount int           `bson:"attachmentcount"`
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
+	RequestedSize   uint64        `bson:"requestedsize,omitempty"`
 
 	// HostId is the ID of the host that a non-detachable
 	// volume is initially attached to. We use this to identify
@@ -176,6 +181,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if non-empty, is the provider-allocated unique ID of
+	// the snapshot that the volume is to be created from.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -278,6 +287,11 @@ func (v *volume) Releasing() bool {
 	return v.doc.Releasing
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize != 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return getStatus(v.mb.db(), volumeGlobalKey(v.VolumeTag().Id()), "volume")
@@ -636,6 +650,7 @@ func (sb *storageBackend) removeMachineVolumesOps(m *Machine) ([]txn.Op, error)
 					Assert: txn.DocExists,
 					Remove: true,
 				},
+				removeStorageUsageOp(v.doc.StorageId),
 			)
 		}
 		ops = append(ops, sb.removeVolumeOps(v.VolumeTag())...)
@@ -1986,6 +1986,13 @@ func (a *Application) Watch() NotifyWatcher {
 	return newEntityWatcher(a.st, applicationsC, a.doc.DocID)
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to an
+// application's config settings, as opposed to its charm config.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	docId := a.st.docID(a.applicationConfigKey())
+	return newEntityWatcher(a.st, settingsC, docId)
+}
+
 // WatchLeaderSettings returns a watcher for observing changed to an application's
 // leader settings.
 func (a *Application) WatchLeaderSettings() NotifyWatcher {
@@ -3605,6 +3612,132 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 	return newNotifyCollWatcher(st, machineRemovalsC, isLocalID(st))
 }
 
+// WatchBranches returns a NotifyWatcher which triggers whenever any
+// of the model's branches changes.
+func (st *State) WatchBranches() NotifyWatcher {
+	return newNotifyCollWatcher(st, generationsC, isLocalID(st))
+}
+
+// WatchApplicationBranches returns a NotifyWatcher which triggers
+// whenever a branch tracking the application changes, including when
+// such a branch is removed or stops tracking it.
+func (st *State) WatchApplicationBranches(appName string) NotifyWatcher {
+	return newApplicationBranchesWatcher(st, appName)
+}
+
+// applicationBranchesWatcher implements NotifyWatcher, triggering when
+// a change is seen to one of the model's branches which tracks, or
+// was tracking, a specific application.
+type applicationBranchesWatcher struct {
+	commonWatcher
+	appName  string
+	tracking set.Strings
+	sink     chan struct{}
+}
+
+func newApplicationBranchesWatcher(backend modelBackend, appName string) NotifyWatcher {
+	w := &applicationBranchesWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		appName:       appName,
+		tracking:      set.NewStrings(),
+		sink:          make(chan struct{}),
+	}
+	w.tomb.Go(func() error {
+		defer close(w.sink)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *applicationBranchesWatcher) Changes() <-chan struct{} {
+	return w.sink
+}
+
+func (w *applicationBranchesWatcher) loop() error {
+	in := make(chan watcher.Change)
+
+	w.watcher.WatchCollectionWithFilter(generationsC, in, isLocalID(w.backend))
+	defer w.watcher.UnwatchCollection(generationsC, in)
+
+	if err := w.initial(); err != nil {
+		return errors.Trace(err)
+	}
+	out := w.sink // out set so that initial event is sent.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-in:
+			ids, ok := collect(change, in, w.tomb.Dying())
+			if !ok {
+				return tomb.ErrDying
+			}
+			changed, err := w.merge(ids)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if changed {
+				out = w.sink
+			}
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// initial records the branches which currently track the application.
+func (w *applicationBranchesWatcher) initial() error {
+	col, closer := w.db.GetCollection(generationsC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	query := bson.D{{"assigned-units." + w.appName, bson.D{{"$exists", true}}}}
+	if err := col.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	for _, doc := range docs {
+		w.tracking.Add(doc.DocID)
+	}
+	return nil
+}
+
+// merge updates the branches known to track the application from the
+// changed branch ids, reporting whether any of the changes concern it.
+func (w *applicationBranchesWatcher) merge(ids map[interface{}]bool) (bool, error) {
+	col, closer := w.db.GetCollection(generationsC)
+	defer closer()
+
+	changed := false
+	for id, exists := range ids {
+		docID, ok := id.(string)
+		if !ok {
+			return false, errors.Errorf("id is not of type string, got %T", id)
+		}
+		wasTracking := w.tracking.Contains(docID)
+		w.tracking.Remove(docID)
+		if exists {
+			var doc struct {
+				AssignedUnits map[string][]string `bson:"assigned-units"`
+			}
+			err := col.FindId(docID).Select(bson.D{{"assigned-units", 1}}).One(&doc)
+			if err != nil && err != mgo.ErrNotFound {
+				return false, errors.Trace(err)
+			}
+			if _, ok := doc.AssignedUnits[w.appName]; ok {
+				w.tracking.Add(docID)
+				changed = true
+			}
+		}
+		changed = changed || wasTracking
+	}
+	return changed, nil
+}
+
 // notifyCollWatcher implements NotifyWatcher, triggering when a
 // change is seen in a specific collection matching the provided
 // filter function.
@@ -220,6 +220,54 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeSnapshotter provides an interface for taking snapshots of
+// volumes. A VolumeSource that implements VolumeSnapshotter must also
+// create volumes from its snapshots, when VolumeParams.SnapshotId is
+// specified.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots takes snapshots of the volumes with
+	// the specified parameters.
+	CreateVolumeSnapshots(ctx context.ProviderCallContext, params []VolumeSnapshotParams) ([]CreateSnapshotsResult, error)
+
+	// DeleteVolumeSnapshots deletes the volume snapshots with the
+	// specified provider snapshot IDs. Snapshots that no longer exist,
+	// say because they were deleted along with their volume, are
+	// treated as deleted.
+	DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error)
+}
+
+// FilesystemSnapshotter provides an interface for taking snapshots of
+// filesystems. A FilesystemSource that implements FilesystemSnapshotter
+// must also create filesystems from its snapshots, when
+// FilesystemParams.SnapshotId is specified.
+type FilesystemSnapshotter interface {
+	// CreateFilesystemSnapshots takes snapshots of the filesystems
+	// with the specified parameters.
+	CreateFilesystemSnapshots(ctx context.ProviderCallContext, params []FilesystemSnapshotParams) ([]CreateSnapshotsResult, error)
+
+	// DeleteFilesystemSnapshots deletes the filesystem snapshots with
+	// the specified provider snapshot IDs. Snapshots that no longer
+	// exist, say because they were deleted along with their filesystem,
+	// are treated as deleted.
+	DeleteFilesystemSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error)
+}
+
+// VolumeResizer provides an interface for growing provisioned volumes
+// while they are attached and in use.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters
+	// to at least their requested sizes.
+	ResizeVolumes(ctx context.ProviderCallContext, params []VolumeResizeParams) ([]ResizeResult, error)
+}
+
+// FilesystemResizer provides an interface for growing provisioned
+// filesystems while they are attached and in use.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters to at least their requested sizes.
+	ResizeFilesystems(ctx context.ProviderCallContext, params []FilesystemResizeParams) ([]ResizeResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -243,6 +291,11 @@ type VolumeParams struct {
 	// storage provider supports tags.
 	ResourceTags map[string]string
 
+	// SnapshotId, if non-empty, is the provider ID of the snapshot that
+	// the volume is to be created from. SnapshotId is only specified for
+	// volume sources that implement VolumeSnapshotter.
+	SnapshotId string
+
 	// Attachment identifies the machine that the volume should be attached
 	// to initially, or nil if the volume should not be attached to any
 	// machine. Some providers, such as MAAS, do not support dynamic
@@ -319,6 +372,12 @@ type FilesystemParams struct {
 	// storage provider supports tags.
 	ResourceTags map[string]string
 
+	// SnapshotId, if non-empty, is the provider ID of the snapshot that
+	// the filesystem is to be created from. For filesystems backed by a
+	// volume, the backing volume is created from the snapshot, and the
+	// filesystem on it is kept as is.
+	SnapshotId string
+
 	// Attachment identifies the machine that the filesystem should be attached
 	// to initially, or nil if the filesystem should not be attached to any
 	// machine.
@@ -343,6 +402,112 @@ type FilesystemAttachmentParams struct {
 	Path string
 }
 
+// VolumeSnapshotParams is a set of parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju for the requested snapshot.
+	Id string
+
+	// Volume is the tag of the volume to take a snapshot of.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Provider is the name of the storage provider that created the volume.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the volume was created from.
+	Attributes map[string]interface{}
+
+	// ResourceTags is a set of tags to set on the created snapshot, if
+	// the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// FilesystemSnapshotParams is a set of parameters for taking a snapshot
+// of a filesystem.
+type FilesystemSnapshotParams struct {
+	// Id is the unique ID assigned by Juju for the requested snapshot.
+	Id string
+
+	// Filesystem is the tag of the filesystem to take a snapshot of.
+	Filesystem names.FilesystemTag
+
+	// FilesystemId is the unique provider-supplied ID for the filesystem.
+	FilesystemId string
+
+	// Provider is the name of the storage provider that created the
+	// filesystem.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the filesystem was created from.
+	Attributes map[string]interface{}
+
+	// ResourceTags is a set of tags to set on the created snapshot, if
+	// the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Volume is the tag of the volume to grow.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Size is the requested size of the volume in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that created the volume.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the volume was created from.
+	Attributes map[string]interface{}
+}
+
+// FilesystemResizeParams is a set of parameters for growing a
+// filesystem.
+type FilesystemResizeParams struct {
+	// Filesystem is the tag of the filesystem to grow.
+	Filesystem names.FilesystemTag
+
+	// FilesystemId is the unique provider-supplied ID for the filesystem.
+	FilesystemId string
+
+	// Size is the requested size of the filesystem in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that created the
+	// filesystem.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the filesystem was created from.
+	Attributes map[string]interface{}
+}
+
+// ResizeResult contains the result of a ResizeVolumes or
+// ResizeFilesystems call for one volume or filesystem. Size is the
+// new size in MiB, which may be larger than requested, and should
+// only be used if Error is nil.
+type ResizeResult struct {
+	Size  uint64
+	Error error
+}
+
+// CreateSnapshotsResult contains the result of a CreateVolumeSnapshots
+// or CreateFilesystemSnapshots call for one snapshot. Snapshot should
+// only be used if Error is nil.
+type CreateSnapshotsResult struct {
+	Snapshot *Snapshot
+	Error    error
+}
+
 // CreateVolumesResult contains the result of a VolumeSource.CreateVolumes call
 // for one volume. Volume and VolumeAttachment should only be used if Error is
 // nil.
@@ -27,6 +27,12 @@ type KubernetesFilesystemParams struct {
 	// Attachment identifies the mount point the filesystem should be
 	// mounted at.
 	Attachment *KubernetesFilesystemAttachmentParams
+
+	// ImportedVolumeIds holds the IDs of volumes imported for the
+	// application that have not yet been attached, and which should
+	// be bound to the filesystems of new units in preference to
+	// creating new volumes.
+	ImportedVolumeIds []string
 }
 
 // KubernetesFilesystemAttachmentParams is a set of parameters for filesystem attachment
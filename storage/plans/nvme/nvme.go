@@ -0,0 +1,442 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package nvme
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/retry"
+	"github.com/juju/utils/v3/exec"
+
+	"github.com/juju/juju/core/paths"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plans/common"
+)
+
+var logger = loggo.GetLogger("juju.storage.plans.nvme")
+
+const (
+	// defaultPort is the IANA assigned port for NVMe over TCP.
+	defaultPort = 4420
+
+	// transportTCP is the only NVMe over Fabrics transport supported.
+	transportTCP = "tcp"
+)
+
+var (
+	sysfsNVMeSubsystem = "/sys/class/nvme-subsystem"
+
+	// hostNQNFile holds the NQN nvme-cli identifies the host with when
+	// none is given.
+	hostNQNFile = "/etc/nvme/hostnqn"
+
+	// attachmentsDir records the namespaces attached from each target,
+	// so that a target is only disconnected once none of them are used.
+	attachmentsDir = filepath.Join(paths.NixDataDir, "storage", "nvme")
+
+	controllerRE = regexp.MustCompile(`^nvme\d+$`)
+	namespaceRE  = regexp.MustCompile(`^nvme\d+n\d+$`)
+)
+
+type nvmePlan struct{}
+
+// NewNVMePlan returns a plan that attaches volumes exported as NVMe over
+// TCP targets, using nvme-cli.
+func NewNVMePlan() common.Plan {
+	return &nvmePlan{}
+}
+
+func (n *nvmePlan) AttachVolume(volumeInfo map[string]string) (storage.BlockDevice, error) {
+	plan, err := newNVMeInfo(volumeInfo)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Trace(err)
+	}
+	return plan.attach()
+}
+
+func (n *nvmePlan) DetachVolume(volumeInfo map[string]string) error {
+	plan, err := newNVMeInfo(volumeInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return plan.detach()
+}
+
+type nvmeConnectionInfo struct {
+	nqn          string
+	address      string
+	port         int
+	nsid         string
+	hostNQN      string
+	dhchapSecret string
+}
+
+// runCommand runs the command in params directly rather than through a
+// shell, so that none of its arguments are interpreted.
+var runCommand = func(params []string) (*exec.ExecResponse, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := osexec.Command(params[0], params[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	resp := &exec.ExecResponse{
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+	}
+	if cmd.ProcessState != nil {
+		resp.Code = cmd.ProcessState.ExitCode()
+	}
+	return resp, err
+}
+
+func newNVMeInfo(info map[string]string) (*nvmeConnectionInfo, error) {
+	var nqn, address string
+	var ok bool
+	if nqn, ok = info["nqn"]; !ok {
+		return nil, errors.Errorf("missing required field: nqn")
+	}
+	if address, ok = info["address"]; !ok {
+		return nil, errors.Errorf("missing required field: address")
+	}
+	if transport, ok := info["transport"]; ok && transport != transportTCP {
+		return nil, errors.NotSupportedf("NVMe transport %q", transport)
+	}
+	port := defaultPort
+	if value, ok := info["port"]; ok {
+		var err error
+		if port, err = strconv.Atoi(value); err != nil {
+			return nil, errors.Errorf("invalid port: %v", value)
+		}
+	}
+	plan := &nvmeConnectionInfo{
+		nqn:          nqn,
+		address:      address,
+		port:         port,
+		nsid:         info["nsid"],
+		hostNQN:      info["host-nqn"],
+		dhchapSecret: info["dhchap-secret"],
+	}
+	return plan, nil
+}
+
+// subsystemPath returns the sysfs folder of the NVMe subsystem with the
+// connection's NQN.
+func (n *nvmeConnectionInfo) subsystemPath() (string, error) {
+	items, err := os.ReadDir(sysfsNVMeSubsystem)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	for _, val := range items {
+		subsystemPath := filepath.Join(sysfsNVMeSubsystem, val.Name())
+		subsysnqn, err := os.ReadFile(filepath.Join(subsystemPath, "subsysnqn"))
+		if err != nil {
+			logger.Tracef("failed to read NQN of subsystem %s: %s", val.Name(), err)
+			continue
+		}
+		if strings.TrimSpace(string(subsysnqn)) == n.nqn {
+			return subsystemPath, nil
+		}
+	}
+	return "", errors.NotFoundf("subsystem for nqn %s", n.nqn)
+}
+
+// deviceName returns the name of the block device of the connection's
+// namespace. With native NVMe multipathing, namespaces are found in the
+// subsystem folder, otherwise in the folders of its controllers.
+func (n *nvmeConnectionInfo) deviceName() (string, error) {
+	subsystemPath, err := n.subsystemPath()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	items, err := os.ReadDir(subsystemPath)
+	if err != nil {
+		return "", err
+	}
+	var namespaces []string
+	for _, val := range items {
+		switch {
+		case namespaceRE.MatchString(val.Name()):
+			namespaces = append(namespaces, filepath.Join(subsystemPath, val.Name()))
+		case controllerRE.MatchString(val.Name()):
+			controllerItems, err := os.ReadDir(filepath.Join(subsystemPath, val.Name()))
+			if err != nil {
+				logger.Tracef("failed to read controller %s: %s", val.Name(), err)
+				continue
+			}
+			for _, item := range controllerItems {
+				if namespaceRE.MatchString(item.Name()) {
+					namespaces = append(namespaces, filepath.Join(subsystemPath, val.Name(), item.Name()))
+				}
+			}
+		}
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		if n.nsid != "" {
+			nsid, err := os.ReadFile(filepath.Join(namespace, "nsid"))
+			if err != nil || strings.TrimSpace(string(nsid)) != n.nsid {
+				continue
+			}
+		}
+		return filepath.Base(namespace), nil
+	}
+	return "", errors.NotFoundf("device for nqn %s", n.nqn)
+}
+
+// nvmeConfigHost, nvmeConfigSubsystem and nvmeConfigPort make up the
+// JSON config file read by nvme-cli.
+type nvmeConfigHost struct {
+	HostNQN    string                `json:"hostnqn"`
+	Subsystems []nvmeConfigSubsystem `json:"subsystems"`
+}
+
+type nvmeConfigSubsystem struct {
+	NQN   string           `json:"nqn"`
+	Ports []nvmeConfigPort `json:"ports"`
+}
+
+type nvmeConfigPort struct {
+	Transport string `json:"transport"`
+	Address   string `json:"traddr"`
+	Port      string `json:"trsvcid"`
+	DHCHAPKey string `json:"dhchap_key"`
+}
+
+// writeConfig writes a config file holding the DH-HMAC-CHAP secret used
+// to authenticate with the target, so that the secret isn't passed on
+// the command line where any user can read it. The file is only
+// readable by its owner and is removed by the caller once connected.
+func (n *nvmeConnectionInfo) writeConfig() (string, error) {
+	hostNQN := n.hostNQN
+	if hostNQN == "" {
+		data, err := os.ReadFile(hostNQNFile)
+		if err != nil {
+			return "", errors.Annotate(err, "reading host NQN")
+		}
+		hostNQN = strings.TrimSpace(string(data))
+	}
+	data, err := json.Marshal([]nvmeConfigHost{{
+		HostNQN: hostNQN,
+		Subsystems: []nvmeConfigSubsystem{{
+			NQN: n.nqn,
+			Ports: []nvmeConfigPort{{
+				Transport: transportTCP,
+				Address:   n.address,
+				Port:      strconv.Itoa(n.port),
+				DHCHAPKey: n.dhchapSecret,
+			}},
+		}},
+	}})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	f, err := os.CreateTemp("", "juju-nvme-*.json")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.Write(data); err != nil {
+		_ = os.Remove(f.Name())
+		return "", errors.Trace(err)
+	}
+	return f.Name(), nil
+}
+
+func (n *nvmeConnectionInfo) connect() error {
+	// nvme-cli needs the transport module loaded to reach the target.
+	result, err := runCommand([]string{"modprobe", "nvme-tcp"})
+	if err != nil {
+		return errors.Annotatef(err, "failed to load nvme-tcp module: %s", result.Stderr)
+	}
+	connectCmd := []string{
+		"nvme", "connect",
+		"-t", transportTCP,
+		"-a", n.address,
+		"-s", strconv.Itoa(n.port),
+		"-n", n.nqn,
+	}
+	if n.hostNQN != "" {
+		connectCmd = append(connectCmd, "--hostnqn", n.hostNQN)
+	}
+	if n.dhchapSecret != "" {
+		configPath, err := n.writeConfig()
+		if err != nil {
+			return errors.Annotate(err, "writing nvme config")
+		}
+		defer func() { _ = os.Remove(configPath) }()
+		connectCmd = append(connectCmd, "--config", configPath)
+	}
+	result, err = runCommand(connectCmd)
+	if err != nil {
+		return errors.Annotatef(err, "nvme failed to connect to target: %s", result.Stderr)
+	}
+	return nil
+}
+
+func (n *nvmeConnectionInfo) disconnect() error {
+	disconnectCmd := []string{
+		"nvme", "disconnect",
+		"-n", n.nqn,
+	}
+	result, err := runCommand(disconnectCmd)
+	if err != nil {
+		return errors.Annotatef(err, "nvme failed to disconnect from target: %s", result.Stderr)
+	}
+	return nil
+}
+
+func (n *nvmeConnectionInfo) attach() (storage.BlockDevice, error) {
+	// A target that's already connected, say by an earlier attempt that
+	// failed to record the block device, isn't connected again.
+	if _, err := n.subsystemPath(); errors.IsNotFound(err) {
+		if err := n.connect(); err != nil {
+			return storage.BlockDevice{}, errors.Trace(err)
+		}
+	} else if err != nil {
+		return storage.BlockDevice{}, errors.Trace(err)
+	}
+	// Wait for device to show up
+	var devName string
+	err := retry.Call(retry.CallArgs{
+		Func: func() error {
+			var err error
+			devName, err = n.deviceName()
+			return err
+		},
+		Attempts: 20,
+		Delay:    time.Second,
+		Clock:    clock.WallClock,
+	})
+	if err != nil {
+		return storage.BlockDevice{}, errors.Trace(retry.LastError(err))
+	}
+	blockDevice, err := getHardwareInfo(devName)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Trace(err)
+	}
+	if err := n.recordAttachment(); err != nil {
+		return storage.BlockDevice{}, errors.Annotate(err, "recording attachment")
+	}
+	return blockDevice, nil
+}
+
+// attachmentPath returns the path of the file recording that the
+// connection's namespace is attached.
+func (n *nvmeConnectionInfo) attachmentPath() string {
+	nsid := n.nsid
+	if nsid == "" {
+		nsid = "default"
+	}
+	return filepath.Join(attachmentsDir, url.PathEscape(n.nqn), nsid)
+}
+
+func (n *nvmeConnectionInfo) recordAttachment() error {
+	path := n.attachmentPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.WriteFile(path, nil, 0600))
+}
+
+// removeAttachment removes the record of the connection's namespace
+// being attached. It returns true if other namespaces of the same
+// target are still attached.
+func (n *nvmeConnectionInfo) removeAttachment() (bool, error) {
+	path := n.attachmentPath()
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return false, errors.Trace(err)
+	}
+	items, err := os.ReadDir(filepath.Dir(path))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(items) > 0 {
+		return true, nil
+	}
+	return false, errors.Trace(os.Remove(filepath.Dir(path)))
+}
+
+func (n *nvmeConnectionInfo) detach() error {
+	// Disconnecting removes all of the target's namespaces, so it's left
+	// connected while any others are attached.
+	inUse, err := n.removeAttachment()
+	if err != nil {
+		return errors.Annotate(err, "removing attachment record")
+	}
+	if inUse {
+		logger.Debugf("not disconnecting from %s, other namespaces are attached", n.nqn)
+		return nil
+	}
+	if _, err := n.subsystemPath(); errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(n.disconnect())
+}
+
+func getHardwareInfo(name string) (storage.BlockDevice, error) {
+	cmd := []string{
+		"udevadm", "info",
+		"-q", "property",
+		"--name", name,
+	}
+
+	result, err := runCommand(cmd)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Annotatef(err, "error running udevadm")
+	}
+	blockDevice := storage.BlockDevice{
+		DeviceName: name,
+	}
+	var busId, serialId string
+	s := bufio.NewScanner(bytes.NewReader(result.Stdout))
+	for s.Scan() {
+		fields := strings.SplitN(s.Text(), "=", 2)
+		if len(fields) != 2 {
+			logger.Tracef("failed to parse line %s", s.Text())
+			continue
+		}
+
+		key := fields[0]
+		value := fields[1]
+		switch key {
+		case "ID_WWN":
+			blockDevice.WWN = value
+		case "DEVLINKS":
+			blockDevice.DeviceLinks = strings.Split(value, " ")
+		case "ID_BUS":
+			busId = value
+		case "ID_SERIAL":
+			serialId = value
+		}
+	}
+	// Older versions of udev don't set ID_BUS for NVMe devices. This
+	// matches the hardware id the diskmanager records for the device.
+	if busId == "" {
+		busId = "nvme"
+	}
+	if serialId != "" {
+		blockDevice.HardwareId = fmt.Sprintf("%s-%s", busId, serialId)
+		blockDevice.SerialId = serialId
+	}
+	return blockDevice, nil
+}
@@ -0,0 +1,286 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package nvme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/v3/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+)
+
+const testNQN = "nqn.2023-01.io.juju:volume-0"
+
+type nvmeSuite struct {
+	testing.IsolationSuite
+
+	sysfs       string
+	attachments string
+	commands    []string
+	// config holds the content of the config file passed to nvme
+	// connect, which is removed once connected.
+	config string
+	// connect is called when the fake nvme connect command runs.
+	connect func()
+}
+
+var _ = gc.Suite(&nvmeSuite{})
+
+func (s *nvmeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.sysfs = c.MkDir()
+	s.attachments = c.MkDir()
+	s.commands = nil
+	s.config = ""
+	s.connect = func() {}
+	s.PatchValue(&sysfsNVMeSubsystem, s.sysfs)
+	s.PatchValue(&attachmentsDir, s.attachments)
+	s.PatchValue(&runCommand, func(params []string) (*exec.ExecResponse, error) {
+		cmd := strings.Join(params, " ")
+		s.commands = append(s.commands, cmd)
+		result := &exec.ExecResponse{}
+		switch {
+		case strings.HasPrefix(cmd, "nvme connect"):
+			for i, param := range params {
+				if param == "--config" {
+					data, err := os.ReadFile(params[i+1])
+					c.Assert(err, jc.ErrorIsNil)
+					s.config = string(data)
+					info, err := os.Stat(params[i+1])
+					c.Assert(err, jc.ErrorIsNil)
+					c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+				}
+			}
+			s.connect()
+		case strings.HasPrefix(cmd, "udevadm"):
+			result.Stdout = []byte(`DEVNAME=/dev/nvme1n1
+DEVLINKS=/dev/disk/by-id/nvme-Linux_0b7a9a3f
+ID_SERIAL=Linux_0b7a9a3f
+ID_WWN=uuid.0b7a9a3f
+`)
+		}
+		return result, nil
+	})
+}
+
+// addSubsystem adds a subsystem for the NQN with the given namespace
+// paths, each relative to the subsystem folder, to the fake sysfs.
+func (s *nvmeSuite) addSubsystem(c *gc.C, name, nqn string, namespaces map[string]string) {
+	subsystemPath := filepath.Join(s.sysfs, name)
+	err := os.MkdirAll(subsystemPath, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.WriteFile(filepath.Join(subsystemPath, "subsysnqn"), []byte(nqn+"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	for namespace, nsid := range namespaces {
+		namespacePath := filepath.Join(subsystemPath, namespace)
+		err := os.MkdirAll(namespacePath, 0755)
+		c.Assert(err, jc.ErrorIsNil)
+		err = os.WriteFile(filepath.Join(namespacePath, "nsid"), []byte(nsid+"\n"), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *nvmeSuite) TestNewNVMeInfo(c *gc.C) {
+	info, err := newNVMeInfo(map[string]string{
+		"nqn":     testNQN,
+		"address": "10.0.0.1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &nvmeConnectionInfo{
+		nqn:     testNQN,
+		address: "10.0.0.1",
+		port:    4420,
+	})
+
+	for _, test := range []struct {
+		info map[string]string
+		err  string
+	}{{
+		info: map[string]string{"address": "10.0.0.1"},
+		err:  "missing required field: nqn",
+	}, {
+		info: map[string]string{"nqn": testNQN},
+		err:  "missing required field: address",
+	}, {
+		info: map[string]string{"nqn": testNQN, "address": "10.0.0.1", "port": "nvme"},
+		err:  "invalid port: nvme",
+	}, {
+		info: map[string]string{"nqn": testNQN, "address": "10.0.0.1", "transport": "rdma"},
+		err:  `NVMe transport "rdma" not supported`,
+	}} {
+		_, err := newNVMeInfo(test.info)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *nvmeSuite) TestAttachVolume(c *gc.C) {
+	s.addSubsystem(c, "nvme-subsys0", "nqn.2014.08.org.nvmexpress:local", map[string]string{"nvme0n1": "1"})
+	s.connect = func() {
+		s.addSubsystem(c, "nvme-subsys1", testNQN, map[string]string{"nvme1n1": "1"})
+	}
+
+	device, err := NewNVMePlan().AttachVolume(map[string]string{
+		"nqn":           testNQN,
+		"address":       "10.0.0.1",
+		"port":          "4421",
+		"host-nqn":      "nqn.2014-08.org.nvmexpress:uuid:1234",
+		"dhchap-secret": "DHHC-1:00:secret:",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(device, jc.DeepEquals, storage.BlockDevice{
+		DeviceName:  "nvme1n1",
+		DeviceLinks: []string{"/dev/disk/by-id/nvme-Linux_0b7a9a3f"},
+		HardwareId:  "nvme-Linux_0b7a9a3f",
+		SerialId:    "Linux_0b7a9a3f",
+		WWN:         "uuid.0b7a9a3f",
+	})
+	c.Assert(s.commands, gc.HasLen, 3)
+	c.Assert(s.commands[0], gc.Equals, "modprobe nvme-tcp")
+	// The secret is passed in a config file, not on the command line.
+	c.Assert(s.commands[1], gc.Matches, "nvme connect -t tcp -a 10.0.0.1 -s 4421 -n "+testNQN+
+		" --hostnqn nqn.2014-08.org.nvmexpress:uuid:1234 --config .*/juju-nvme-.*\\.json")
+	c.Assert(s.commands[2], gc.Equals, "udevadm info -q property --name nvme1n1")
+	c.Assert(s.config, jc.JSONEquals, []interface{}{map[string]interface{}{
+		"hostnqn": "nqn.2014-08.org.nvmexpress:uuid:1234",
+		"subsystems": []interface{}{map[string]interface{}{
+			"nqn": testNQN,
+			"ports": []interface{}{map[string]interface{}{
+				"transport":  "tcp",
+				"traddr":     "10.0.0.1",
+				"trsvcid":    "4421",
+				"dhchap_key": "DHHC-1:00:secret:",
+			}},
+		}},
+	}})
+	configPath := strings.Fields(s.commands[1])[len(strings.Fields(s.commands[1]))-1]
+	_, err = os.Stat(configPath)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *nvmeSuite) TestAttachVolumeSecretDefaultHostNQN(c *gc.C) {
+	hostNQNPath := filepath.Join(c.MkDir(), "hostnqn")
+	err := os.WriteFile(hostNQNPath, []byte("nqn.2014-08.org.nvmexpress:uuid:5678\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&hostNQNFile, hostNQNPath)
+	s.connect = func() {
+		s.addSubsystem(c, "nvme-subsys1", testNQN, map[string]string{"nvme1n1": "1"})
+	}
+
+	_, err = NewNVMePlan().AttachVolume(map[string]string{
+		"nqn":           testNQN,
+		"address":       "10.0.0.1",
+		"dhchap-secret": "DHHC-1:00:secret:",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.config, gc.Matches, `.*"hostnqn":"nqn.2014-08.org.nvmexpress:uuid:5678".*`)
+	for _, cmd := range s.commands {
+		c.Assert(cmd, gc.Not(gc.Matches), ".*DHHC-1.*")
+	}
+}
+
+func (s *nvmeSuite) TestAttachVolumeAlreadyConnected(c *gc.C) {
+	// Without native multipathing, namespaces are found in the
+	// subsystem's controller folders.
+	s.addSubsystem(c, "nvme-subsys1", testNQN, map[string]string{
+		"nvme1/nvme1n1": "1",
+		"nvme1/nvme1n2": "2",
+	})
+
+	device, err := NewNVMePlan().AttachVolume(map[string]string{
+		"nqn":     testNQN,
+		"address": "10.0.0.1",
+		"nsid":    "2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(device.DeviceName, gc.Equals, "nvme1n2")
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"udevadm info -q property --name nvme1n2",
+	})
+}
+
+func (s *nvmeSuite) TestAttachVolumeConnectFails(c *gc.C) {
+	s.PatchValue(&runCommand, func(params []string) (*exec.ExecResponse, error) {
+		if params[0] == "nvme" {
+			return &exec.ExecResponse{Stderr: []byte("could not add new controller")}, errors.New("exit status 1")
+		}
+		return &exec.ExecResponse{}, nil
+	})
+	_, err := NewNVMePlan().AttachVolume(map[string]string{
+		"nqn":     testNQN,
+		"address": "10.0.0.1",
+	})
+	c.Assert(err, gc.ErrorMatches, "nvme failed to connect to target: could not add new controller: exit status 1")
+}
+
+func (s *nvmeSuite) TestDetachVolume(c *gc.C) {
+	s.addSubsystem(c, "nvme-subsys1", testNQN, map[string]string{"nvme1n1": "1"})
+	volumeInfo := map[string]string{
+		"nqn":     testNQN,
+		"address": "10.0.0.1",
+	}
+	_, err := NewNVMePlan().AttachVolume(volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands = nil
+
+	err = NewNVMePlan().DetachVolume(volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"nvme disconnect -n " + testNQN,
+	})
+	items, err := os.ReadDir(s.attachments)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(items, gc.HasLen, 0)
+}
+
+func (s *nvmeSuite) TestDetachVolumeOtherNamespaceAttached(c *gc.C) {
+	s.addSubsystem(c, "nvme-subsys1", testNQN, map[string]string{
+		"nvme1n1": "1",
+		"nvme1n2": "2",
+	})
+	plan := NewNVMePlan()
+	for _, nsid := range []string{"1", "2"} {
+		_, err := plan.AttachVolume(map[string]string{
+			"nqn":     testNQN,
+			"address": "10.0.0.1",
+			"nsid":    nsid,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.commands = nil
+
+	// Namespace 2 is still attached, so the target stays connected.
+	err := plan.DetachVolume(map[string]string{
+		"nqn":     testNQN,
+		"address": "10.0.0.1",
+		"nsid":    "1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, gc.HasLen, 0)
+
+	err = plan.DetachVolume(map[string]string{
+		"nqn":     testNQN,
+		"address": "10.0.0.1",
+		"nsid":    "2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"nvme disconnect -n " + testNQN,
+	})
+}
+
+func (s *nvmeSuite) TestDetachVolumeNotConnected(c *gc.C) {
+	err := NewNVMePlan().DetachVolume(map[string]string{
+		"nqn":     testNQN,
+		"address": "10.0.0.1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, gc.HasLen, 0)
+}
@@ -10,11 +10,13 @@ import (
 	"github.com/juju/juju/storage/plans/common"
 	"github.com/juju/juju/storage/plans/iscsi"
 	"github.com/juju/juju/storage/plans/local"
+	"github.com/juju/juju/storage/plans/nvme"
 )
 
 var registry = map[storage.DeviceType]common.Plan{
 	storage.DeviceTypeLocal: local.NewLocalPlan(),
 	storage.DeviceTypeISCSI: iscsi.NewiSCSIPlan(),
+	storage.DeviceTypeNVMe:  nvme.NewNVMePlan(),
 }
 
 func PlanByType(name storage.DeviceType) (common.Plan, error) {
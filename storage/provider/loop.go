@@ -107,7 +107,10 @@ type loopVolumeSource struct {
 	storageDir string
 }
 
-var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var (
+	_ storage.VolumeSource      = (*loopVolumeSource)(nil)
+	_ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
+)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -128,6 +131,15 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	if params.SnapshotId != "" {
+		// The snapshot is copied before allocating the block file,
+		// which then grows the copy to the requested size if the
+		// snapshot is smaller.
+		snapshotFilePath := lvs.snapshotFilePath(params.SnapshotId)
+		if err := copyBlockFile(lvs.run, snapshotFilePath, loopFilePath); err != nil {
+			return storage.Volume{}, errors.Annotatef(err, "could not create block file from snapshot %q", params.SnapshotId)
+		}
+	}
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
@@ -144,6 +156,121 @@ func (lvs *loopVolumeSource) volumeFilePath(tag names.VolumeTag) string {
 	return filepath.Join(lvs.storageDir, tag.String())
 }
 
+// snapshotFilePath returns the path of the file holding the snapshot with
+// the specified ID. Snapshots are kept apart from the volume files, and
+// are removed when the snapshot is deleted.
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) string {
+	return filepath.Join(lvs.storageDir, "snapshots", snapshotId)
+}
+
+// CreateVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, args []storage.VolumeSnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createVolumeSnapshot(arg storage.VolumeSnapshotParams) (*storage.Snapshot, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Volume)
+	fi, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading loop backing file")
+	}
+	snapshotId := "snapshot-" + strings.Replace(arg.Id, "/", "-", -1)
+	snapshotFilePath := lvs.snapshotFilePath(snapshotId)
+	if err := ensureDir(lvs.dirFuncs, filepath.Dir(snapshotFilePath)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	thaw, err := freezeLoopFilesystems(lvs.run, loopFilePath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	err = copyBlockFile(lvs.run, loopFilePath, snapshotFilePath)
+	thaw()
+	if err != nil {
+		_ = os.Remove(snapshotFilePath)
+		return nil, errors.Trace(err)
+	}
+	return &storage.Snapshot{
+		Id: arg.Id,
+		SnapshotInfo: storage.SnapshotInfo{
+			SnapshotId: snapshotId,
+			Size:       uint64(fi.Size() / (1024 * 1024)),
+		},
+	}, nil
+}
+
+// freezeLoopFilesystems freezes the filesystems mounted from the loop
+// devices attached to the specified file, so that the file isn't written
+// to while it's copied. Freezing a filesystem flushes its pending writes
+// first. The returned func thaws the filesystems again. An attached loop
+// device without a mounted filesystem may be written to at any time, so
+// the file can't be copied consistently and an error is returned.
+func freezeLoopFilesystems(run runCommandFunc, filePath string) (thaw func(), _ error) {
+	var frozen []string
+	thaw = func() {
+		for _, mountPoint := range frozen {
+			if _, err := run("fsfreeze", "-u", mountPoint); err != nil {
+				logger.Errorf("failed to thaw filesystem at %q: %v", mountPoint, err)
+			}
+		}
+	}
+	deviceNames, err := associatedLoopDevices(run, filePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		stdout, err := run("lsblk", "-n", "-o", "MOUNTPOINT", path.Join("/dev", deviceName))
+		if err != nil {
+			thaw()
+			return nil, errors.Annotatef(err, "getting mount point of loop device %q", deviceName)
+		}
+		mountPoint := strings.TrimSpace(stdout)
+		if mountPoint == "" {
+			thaw()
+			return nil, errors.Errorf(
+				"volume is attached to loop device %q without a mounted filesystem, and may be written to while being copied",
+				deviceName,
+			)
+		}
+		if _, err := run("fsfreeze", "-f", mountPoint); err != nil {
+			thaw()
+			return nil, errors.Annotatef(err, "freezing filesystem at %q", mountPoint)
+		}
+		frozen = append(frozen, mountPoint)
+	}
+	return thaw, nil
+}
+
+// DeleteVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := lvs.deleteVolumeSnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "deleting snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) deleteVolumeSnapshot(snapshotId string) error {
+	if !strings.HasPrefix(snapshotId, "snapshot-") || filepath.Base(snapshotId) != snapshotId {
+		return errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	err := os.Remove(lvs.snapshotFilePath(snapshotId))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing snapshot file")
+	}
+	return nil
+}
+
 // ListVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
 	// TODO(axw) implement this when we need it.
@@ -263,6 +390,18 @@ func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) erro
 	return nil
 }
 
+// copyBlockFile copies the loop backing file at the source path to the
+// destination path, keeping holes in the file so that sparse files stay
+// sparse. Filesystems that support it share the copy's data blocks with
+// the source until either is written to.
+func copyBlockFile(run runCommandFunc, sourcePath, destPath string) error {
+	_, err := run("cp", "--sparse=always", "--reflink=auto", sourcePath, destPath)
+	if err != nil {
+		return errors.Annotatef(err, "copying loop backing file %q", sourcePath)
+	}
+	return nil
+}
+
 // attachLoopDevice attaches a loop device to the file with the
 // specified path, and returns the loop device's name (e.g. "loop0").
 // losetup will create additional loop devices as necessary.
@@ -127,6 +127,136 @@ func (s *loopSuite) TestCreateVolumesNoAttachment(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	s.commands.expect("cp", "--sparse=always", "--reflink=auto",
+		filepath.Join(s.storageDir, "snapshots", "snapshot-1-0"),
+		filepath.Join(s.storageDir, "volume-1-1"),
+	)
+	s.commands.expect("fallocate", "-l", "4MiB", filepath.Join(s.storageDir, "volume-1-1"))
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("1/1"),
+		Size:       4,
+		SnapshotId: "snapshot-1-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("1/1"),
+		storage.VolumeInfo{
+			VolumeId: "volume-1-1",
+			Size:     4,
+		},
+	})
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	source, dirFuncs := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-1-0")
+	err := os.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Truncate(fileName, 2*1024*1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.commands.expect("losetup", "-j", fileName)
+	s.commands.expect("cp", "--sparse=always", "--reflink=auto",
+		fileName, filepath.Join(s.storageDir, "snapshots", "snapshot-1-3"),
+	)
+	results, err := source.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.callCtx, []storage.VolumeSnapshotParams{{
+		Id:       "1/3",
+		Volume:   names.NewVolumeTag("1/0"),
+		VolumeId: "volume-1-0",
+	}, {
+		Id:       "1/4",
+		Volume:   names.NewVolumeTag("1/1"),
+		VolumeId: "volume-1-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Snapshot, jc.DeepEquals, &storage.Snapshot{
+		Id: "1/3",
+		SnapshotInfo: storage.SnapshotInfo{
+			SnapshotId: "snapshot-1-3",
+			Size:       2,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, "creating snapshot of volume 1/1: reading loop backing file: .*")
+	c.Assert(dirFuncs.Dirs.Contains(filepath.Join(s.storageDir, "snapshots")), jc.IsTrue)
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshotsFreezesMountedFilesystem(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-1-0")
+	err := os.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	cmd = s.commands.expect("lsblk", "-n", "-o", "MOUNTPOINT", "/dev/loop0")
+	cmd.respond("/var/lib/juju/storage/data/0\n", nil)
+	s.commands.expect("fsfreeze", "-f", "/var/lib/juju/storage/data/0")
+	s.commands.expect("cp", "--sparse=always", "--reflink=auto",
+		fileName, filepath.Join(s.storageDir, "snapshots", "snapshot-1-3"),
+	)
+	s.commands.expect("fsfreeze", "-u", "/var/lib/juju/storage/data/0")
+
+	results, err := source.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.callCtx, []storage.VolumeSnapshotParams{{
+		Id:       "1/3",
+		Volume:   names.NewVolumeTag("1/0"),
+		VolumeId: "volume-1-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshotsAttachedNotMounted(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-1-0")
+	err := os.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	cmd = s.commands.expect("lsblk", "-n", "-o", "MOUNTPOINT", "/dev/loop0")
+	cmd.respond("\n", nil)
+
+	results, err := source.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.callCtx, []storage.VolumeSnapshotParams{{
+		Id:       "1/3",
+		Volume:   names.NewVolumeTag("1/0"),
+		VolumeId: "volume-1-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `.*volume is attached to loop device "loop0" without a mounted filesystem.*`)
+}
+
+func (s *loopSuite) TestDeleteVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.MkdirAll(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(snapshotsDir, "snapshot-1-3")
+	err = os.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs, err := source.(storage.VolumeSnapshotter).DeleteVolumeSnapshots(s.callCtx, []string{
+		"snapshot-1-3", "snapshot-1-4", "../volume-1-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	// Snapshots already deleted are ignored.
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `.* invalid loop snapshot ID "\.\./volume-1-0"`)
+
+	_, err = os.Stat(fileName)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
 func (s *loopSuite) TestDestroyVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
@@ -93,6 +93,18 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 		return nil, errors.Trace(err)
 	}
 	devicePath := devicePath(blockDevice)
+	if arg.SnapshotId != "" {
+		// The backing volume was created from a snapshot, and so
+		// already holds the filesystem.
+		return &storage.Filesystem{
+			arg.Tag,
+			arg.Volume,
+			storage.FilesystemInfo{
+				arg.Tag.String(),
+				blockDevice.Size,
+			},
+		}, nil
+	}
 	if isDiskDevice(devicePath) {
 		if err := destroyPartitions(s.run, devicePath); err != nil {
 			return nil, errors.Trace(err)
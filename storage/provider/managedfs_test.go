@@ -111,6 +111,33 @@ func (s *managedfsSuite) TestCreateFilesystems(c *gc.C) {
 	}})
 }
 
+func (s *managedfsSuite) TestCreateFilesystemsFromSnapshot(c *gc.C) {
+	source := s.initSource(c)
+	// The filesystem is kept as is, so no commands are run.
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       2,
+	}
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("0/0"),
+		Volume:     names.NewVolumeTag("0"),
+		Size:       2,
+		SnapshotId: "snapshot-0-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			names.NewFilesystemTag("0/0"),
+			names.NewVolumeTag("0"),
+			storage.FilesystemInfo{
+				FilesystemId: "filesystem-0-0",
+				Size:         2,
+			},
+		},
+	}})
+}
+
 func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	source := s.initSource(c)
 	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
@@ -0,0 +1,22 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+// Snapshot identifies and describes a snapshot of a volume or filesystem.
+type Snapshot struct {
+	// Id is the unique ID assigned by Juju to the snapshot.
+	Id string
+
+	SnapshotInfo
+}
+
+// SnapshotInfo describes a snapshot of a volume or filesystem.
+type SnapshotInfo struct {
+	// SnapshotId is the unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size of the volume or filesystem that the snapshot
+	// was taken of, in MiB.
+	Size uint64
+}
@@ -10,6 +10,7 @@ type DeviceType string
 var (
 	DeviceTypeLocal DeviceType = "local"
 	DeviceTypeISCSI DeviceType = "iscsi"
+	DeviceTypeNVMe  DeviceType = "nvme"
 )
 
 // Volume identifies and describes a volume (disk, logical volume, etc.)
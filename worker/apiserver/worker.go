@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
@@ -18,6 +19,8 @@ import (
 	"github.com/juju/juju/apiserver/apiserverhttp"
 	"github.com/juju/juju/apiserver/authentication/jwt"
 	"github.com/juju/juju/apiserver/authentication/macaroon"
+	"github.com/juju/juju/apiserver/authentication/oidc"
+	"github.com/juju/juju/apiserver/stateauthenticator"
 	jujucontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/cache"
@@ -26,6 +29,7 @@ import (
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/common"
 	"github.com/juju/juju/worker/syslogger"
 )
 
@@ -152,6 +156,10 @@ func NewWorker(config Config) (worker.Worker, error) {
 	if err != nil {
 		return nil, fmt.Errorf("gathering authenticators for apiserver: %w", err)
 	}
+	oidcAuthenticator, err := gatherOIDCAuthenticator(controllerConfig, systemState, config.Clock)
+	if err != nil {
+		return nil, fmt.Errorf("gathering authenticators for apiserver: %w", err)
+	}
 
 	serverConfig := apiserver.ServerConfig{
 		StatePool:                     config.StatePool,
@@ -166,6 +174,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		Mux:                           config.Mux,
 		LocalMacaroonAuthenticator:    config.LocalMacaroonAuthenticator,
 		JWTAuthenticator:              jwtAuthenticator,
+		OIDCAuthenticator:             oidcAuthenticator,
 		UpgradeComplete:               config.UpgradeComplete,
 		PublicDNSName:                 controllerConfig.AutocertDNSName(),
 		AllowModelAccess:              controllerConfig.AllowModelAccess(),
@@ -180,7 +189,19 @@ func NewWorker(config Config) (worker.Worker, error) {
 		CharmhubHTTPClient:            config.CharmhubHTTPClient,
 		DBGetter:                      config.DBGetter,
 	}
-	return config.NewServer(serverConfig)
+	server, err := config.NewServer(serverConfig)
+	if err != nil {
+		if oidcAuthenticator != nil {
+			oidcAuthenticator.Close()
+		}
+		return nil, err
+	}
+	if oidcAuthenticator == nil {
+		return server, nil
+	}
+	// The OpenID Connect authenticator refreshes the issuer's keys in
+	// the background until it is closed.
+	return common.NewCleanupWorker(server, oidcAuthenticator.Close), nil
 }
 
 // gatherJWTAuthenticator is responsible for building up the jwt authenticator
@@ -197,6 +218,35 @@ func gatherJWTAuthenticator(controllerConfig jujucontroller.Config) (jwt.Authent
 	return jwtAuthenticator, nil
 }
 
+// oidcRequestTimeout is the timeout for requests made to an OpenID Connect
+// issuer. When completing a device login, it applies to each poll of the
+// issuer rather than the login as a whole.
+const oidcRequestTimeout = 30 * time.Second
+
+// gatherOIDCAuthenticator is responsible for building up the OpenID Connect
+// authenticator if this controller has been provisioned to trust an issuer.
+// The issuer is discovered when the authenticator is first used, so an
+// unreachable issuer doesn't stop the API server from starting. Access
+// granted within Juju applies to the issuer's users as well as the
+// access granted to their issuer groups.
+func gatherOIDCAuthenticator(controllerConfig jujucontroller.Config, systemState *state.State, clk clock.Clock) (oidc.Authenticator, error) {
+	if controllerConfig.OIDCIssuerURL() == "" {
+		return nil, nil
+	}
+	oidcConfig, err := oidc.NewConfig(controllerConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	oidcConfig.HTTPClient = &http.Client{Timeout: oidcRequestTimeout}
+	oidcConfig.Clock = clk
+	oidcConfig.JujuAccess = &stateauthenticator.PermissionDelegator{State: systemState}
+	oidcAuthenticator, err := oidc.NewAuthenticator(oidcConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return oidcAuthenticator, nil
+}
+
 func newServerShim(config apiserver.ServerConfig) (worker.Worker, error) {
 	return apiserver.NewServer(config)
 }
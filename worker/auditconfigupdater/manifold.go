@@ -10,6 +10,7 @@ import (
 
 	jujuagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/common"
 	workerstate "github.com/juju/juju/worker/state"
 )
@@ -68,7 +69,9 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
-	logDir := agent.CurrentConfig().LogDir()
+	agentConfig := agent.CurrentConfig()
+	logDir := agentConfig.LogDir()
+	controllerID := agentConfig.Tag().Id()
 
 	st, err := statePool.SystemState()
 	if err != nil {
@@ -76,9 +79,22 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
-	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
-	}
+	sinks := newSinkCache(func(name string, cfg auditlog.Config) auditlog.AuditLog {
+		switch name {
+		case auditlog.FileSink:
+			return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		case auditlog.SyslogSink:
+			return auditlog.NewSyslog(cfg.SyslogHost)
+		case auditlog.HTTPSink:
+			return auditlog.NewHTTP(cfg.HTTPURL)
+		case auditlog.DatabaseSink:
+			writer := state.NewAuditLogWriter(st, controllerID, cfg.DatabaseRetention)
+			return auditlog.NewForwarder(name, writer)
+		}
+		logger.Warningf("ignoring unknown audit log sink %q", name)
+		return nil
+	})
+	logFactory := sinks.target
 	auditConfig, err := initialConfig(st)
 	if err != nil {
 		_ = stTracker.Done()
@@ -90,10 +106,14 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 
 	w, err := config.NewWorker(st, auditConfig, logFactory)
 	if err != nil {
+		sinks.closeAll()
 		_ = stTracker.Done()
 		return nil, errors.Trace(err)
 	}
-	return common.NewCleanupWorker(w, func() { _ = stTracker.Done() }), nil
+	return common.NewCleanupWorker(w, func() {
+		sinks.closeAll()
+		_ = stTracker.Done()
+	}), nil
 }
 
 type withCurrentConfig interface {
@@ -121,12 +141,5 @@ func initialConfig(source ConfigSource) (auditlog.Config, error) {
 	if err != nil {
 		return auditlog.Config{}, errors.Trace(err)
 	}
-	result := auditlog.Config{
-		Enabled:        cfg.AuditingEnabled(),
-		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
-		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
-		MaxBackups:     cfg.AuditLogMaxBackups(),
-		ExcludeMethods: cfg.AuditLogExcludeMethods(),
-	}
-	return result, nil
+	return auditConfig(cfg), nil
 }
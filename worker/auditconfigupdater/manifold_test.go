@@ -6,6 +6,7 @@ package auditconfigupdater_test
 import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/names/v5"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v3"
@@ -15,6 +16,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -121,11 +123,13 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 
 	auditConfig.Target = nil
 	c.Assert(auditConfig, gc.DeepEquals, auditlog.Config{
-		Enabled:        true,
-		CaptureAPIArgs: true,
-		ExcludeMethods: set.NewStrings("This.Method"),
-		MaxSizeMB:      10,
-		MaxBackups:     10,
+		Enabled:           true,
+		CaptureAPIArgs:    true,
+		ExcludeMethods:    set.NewStrings("This.Method"),
+		MaxSizeMB:         10,
+		MaxBackups:        10,
+		Sinks:             []string{"file"},
+		DatabaseRetention: controller.DefaultAuditLogDatabaseRetention,
 	})
 
 	c.Assert(args[2], gc.NotNil)
@@ -208,6 +212,10 @@ func (c *mockAgentConfig) LogDir() string {
 	return c.logDir
 }
 
+func (c *mockAgentConfig) Tag() names.Tag {
+	return names.NewMachineTag("0")
+}
+
 type stubStateTracker struct {
 	testing.Stub
 	pool *state.StatePool
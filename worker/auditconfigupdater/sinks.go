@@ -0,0 +1,104 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditconfigupdater
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+var logger = loggo.GetLogger("juju.worker.auditconfigupdater")
+
+// SinkFactory makes the audit log sink with the given name (see
+// auditlog.FileSink and friends) for the config.
+type SinkFactory func(name string, cfg auditlog.Config) auditlog.AuditLog
+
+// sinkCache makes audit log targets that write to each of the sinks
+// named in a config. Sinks are reused by later targets for as long as
+// their configuration is unchanged, and closed once they are no longer
+// used. The file sink is never closed, to avoid leaking file handles
+// when API connections made with an earlier target write to it.
+type sinkCache struct {
+	mu      sync.Mutex
+	newSink SinkFactory
+	sinks   map[string]auditlog.AuditLog
+}
+
+func newSinkCache(newSink SinkFactory) *sinkCache {
+	return &sinkCache{
+		newSink: newSink,
+		sinks:   make(map[string]auditlog.AuditLog),
+	}
+}
+
+// target returns an AuditLog that writes to all of the sinks named in
+// the config. It is an AuditLogFactory.
+func (c *sinkCache) target(cfg auditlog.Config) auditlog.AuditLog {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := cfg.Sinks
+	if len(names) == 0 {
+		names = []string{auditlog.FileSink}
+	}
+	sinks := make(map[string]auditlog.AuditLog)
+	var logs []auditlog.AuditLog
+	for _, name := range names {
+		key := sinkKey(name, cfg)
+		if _, ok := sinks[key]; ok {
+			continue
+		}
+		sink, ok := c.sinks[key]
+		if !ok {
+			if sink = c.newSink(name, cfg); sink == nil {
+				continue
+			}
+		}
+		sinks[key] = sink
+		logs = append(logs, sink)
+	}
+	for key, sink := range c.sinks {
+		if _, ok := sinks[key]; ok {
+			continue
+		}
+		if key == auditlog.FileSink {
+			sinks[key] = sink
+			continue
+		}
+		if err := sink.Close(); err != nil {
+			logger.Warningf("closing audit log sink %s: %v", key, err)
+		}
+	}
+	c.sinks = sinks
+	return auditlog.NewMultiLog(logs...)
+}
+
+// closeAll closes all of the sinks made by the cache.
+func (c *sinkCache) closeAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, sink := range c.sinks {
+		if err := sink.Close(); err != nil {
+			logger.Warningf("closing audit log sink %s: %v", key, err)
+		}
+	}
+	c.sinks = make(map[string]auditlog.AuditLog)
+}
+
+// sinkKey identifies a sink and the configuration it was made with.
+func sinkKey(name string, cfg auditlog.Config) string {
+	switch name {
+	case auditlog.SyslogSink:
+		return fmt.Sprintf("%s %s", name, cfg.SyslogHost)
+	case auditlog.HTTPSink:
+		return fmt.Sprintf("%s %s", name, cfg.HTTPURL)
+	case auditlog.DatabaseSink:
+		return fmt.Sprintf("%s %s", name, cfg.DatabaseRetention)
+	}
+	return name
+}
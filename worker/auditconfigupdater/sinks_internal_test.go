@@ -0,0 +1,83 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditconfigupdater
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/auditlog"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type sinkCacheSuite struct {
+	jujutesting.BaseSuite
+
+	made map[string]*closeRecordingLog
+}
+
+var _ = gc.Suite(&sinkCacheSuite{})
+
+func (s *sinkCacheSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.made = make(map[string]*closeRecordingLog)
+}
+
+func (s *sinkCacheSuite) newSink(name string, cfg auditlog.Config) auditlog.AuditLog {
+	sink := &closeRecordingLog{}
+	s.made[sinkKey(name, cfg)] = sink
+	return sink
+}
+
+func (s *sinkCacheSuite) TestTargetReusesSinks(c *gc.C) {
+	cache := newSinkCache(s.newSink)
+	cfg := auditlog.Config{
+		Sinks:      []string{"file", "syslog"},
+		SyslogHost: "syslog.example.com:601",
+	}
+	cache.target(cfg)
+	c.Assert(s.made, gc.HasLen, 2)
+	file := s.made["file"]
+	syslog := s.made["syslog syslog.example.com:601"]
+
+	// Changing the syslog host replaces the syslog sink, and closes
+	// the old one.
+	cfg.SyslogHost = "syslog.example.com:602"
+	cfg.Sinks = append(cfg.Sinks, "http")
+	cfg.HTTPURL = "https://audit.example.com"
+	cache.target(cfg)
+	c.Assert(s.made, gc.HasLen, 4)
+	c.Assert(s.made["file"], gc.Equals, file)
+	c.Assert(syslog.closed, jc.IsTrue)
+	c.Assert(file.closed, jc.IsFalse)
+
+	// The file sink is kept open even when it's no longer used.
+	cfg.Sinks = []string{"http"}
+	target := cache.target(cfg)
+	c.Assert(target, gc.Equals, auditlog.AuditLog(s.made["http https://audit.example.com"]))
+	c.Assert(file.closed, jc.IsFalse)
+	c.Assert(s.made["syslog syslog.example.com:602"].closed, jc.IsTrue)
+
+	cache.closeAll()
+	c.Assert(file.closed, jc.IsTrue)
+	c.Assert(s.made["http https://audit.example.com"].closed, jc.IsTrue)
+}
+
+func (s *sinkCacheSuite) TestTargetDefaultsToFile(c *gc.C) {
+	cache := newSinkCache(s.newSink)
+	target := cache.target(auditlog.Config{})
+	c.Assert(s.made, gc.HasLen, 1)
+	c.Assert(target, gc.Equals, auditlog.AuditLog(s.made["file"]))
+}
+
+type closeRecordingLog struct {
+	apitesting.FakeAuditLog
+	closed bool
+}
+
+func (l *closeRecordingLog) Close() error {
+	l.closed = true
+	return nil
+}
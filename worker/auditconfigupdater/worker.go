@@ -30,9 +30,10 @@ type AuditLogFactory func(auditlog.Config) auditlog.AuditLog
 // New returns a worker that will keep an up-to-date audit log config.
 func New(source ConfigSource, initial auditlog.Config, logFactory AuditLogFactory) (worker.Worker, error) {
 	u := &updater{
-		source:     source,
-		current:    initial,
-		logFactory: logFactory,
+		source:       source,
+		current:      initial,
+		targetConfig: initial,
+		logFactory:   logFactory,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -50,6 +51,9 @@ type updater struct {
 	source     ConfigSource
 	current    auditlog.Config
 	logFactory AuditLogFactory
+
+	// targetConfig is the config the current target was made for.
+	targetConfig auditlog.Config
 }
 
 // Kill is part of the worker.Worker interface.
@@ -89,15 +93,12 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 	if err != nil {
 		return auditlog.Config{}, errors.Trace(err)
 	}
-	result := auditlog.Config{
-		Enabled:        cfg.AuditingEnabled(),
-		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
-		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
-		MaxBackups:     cfg.AuditLogMaxBackups(),
-		ExcludeMethods: cfg.AuditLogExcludeMethods(),
-	}
-	if result.Enabled && u.current.Target == nil {
+	result := auditConfig(cfg)
+	if result.Enabled && (u.current.Target == nil || result.SinksChanged(u.targetConfig)) {
+		// The factory reuses any sinks that are unchanged, so the
+		// audit log file is never reopened.
 		result.Target = u.logFactory(result)
+		u.targetConfig = result
 	} else {
 		// Keep the existing target to avoid file handle leaks from
 		// disabling and enabling auditing - we'll still stop logging
@@ -113,6 +114,22 @@ func (u *updater) update(newConfig auditlog.Config) {
 	u.current = newConfig
 }
 
+// auditConfig returns the audit log config held in the controller
+// config, without a target.
+func auditConfig(cfg controller.Config) auditlog.Config {
+	return auditlog.Config{
+		Enabled:           cfg.AuditingEnabled(),
+		CaptureAPIArgs:    cfg.AuditLogCaptureArgs(),
+		MaxSizeMB:         cfg.AuditLogMaxSizeMB(),
+		MaxBackups:        cfg.AuditLogMaxBackups(),
+		ExcludeMethods:    cfg.AuditLogExcludeMethods(),
+		Sinks:             cfg.AuditLogSinks(),
+		SyslogHost:        cfg.AuditLogSyslogHost(),
+		HTTPURL:           cfg.AuditLogHTTPURL(),
+		DatabaseRetention: cfg.AuditLogDatabaseRetention(),
+	}
+}
+
 // CurrentConfig returns the updater's up-to-date audit config.
 func (u *updater) CurrentConfig() auditlog.Config {
 	u.mu.Lock()
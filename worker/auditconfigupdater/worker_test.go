@@ -188,6 +188,56 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestChangingSinks(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	initial := auditlog.Config{
+		Enabled: true,
+		Sinks:   []string{"file"},
+		Target:  &apitesting.FakeAuditLog{},
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return &newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-sinks"] = []interface{}{"file", "syslog"}
+	cfg["audit-log-syslog-host"] = "syslog.example.com:601"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Target == &newTarget
+	})
+	c.Assert(newConfig.Sinks, jc.DeepEquals, []string{"file", "syslog"})
+	c.Assert(newConfig.SyslogHost, gc.Equals, "syslog.example.com:601")
+	c.Assert(calls, gc.HasLen, 1)
+
+	// Changing other settings keeps the target.
+	cfg = makeControllerConfig(true, true)
+	cfg["audit-log-sinks"] = []interface{}{"syslog", "file"}
+	cfg["audit-log-syslog-host"] = "syslog.example.com:601"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig = waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.CaptureAPIArgs
+	})
+	c.Assert(newConfig.Target, gc.Equals, auditlog.AuditLog(&newTarget))
+	c.Assert(calls, gc.HasLen, 1)
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
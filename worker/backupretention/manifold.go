@@ -0,0 +1,117 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupretention
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/dependency"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/worker/common"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// DefaultInterval is how often stored backups are checked against
+// the retention policy.
+const DefaultInterval = time.Hour
+
+// ManifoldConfig holds the information needed to run a backup
+// retention worker in a dependency.Engine.
+type ManifoldConfig struct {
+	StateName string
+	Clock     clock.Clock
+	Logger    Logger
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold to run a backup retention
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	st, err := statePool.SystemState()
+	if err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+
+	w, err := config.NewWorker(Config{
+		Backend:    stateBackend{st: st},
+		NewBackups: backups.NewBackups,
+		Clock:      config.Clock,
+		Logger:     config.Logger,
+		Interval:   DefaultInterval,
+	})
+	if err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, func() { _ = stTracker.Done() }), nil
+}
+
+// stateBackend implements Backend using the controller model's state.
+type stateBackend struct {
+	st *state.State
+}
+
+// ControllerConfig is part of the Backend interface.
+func (b stateBackend) ControllerConfig() (controller.Config, error) {
+	return b.st.ControllerConfig()
+}
+
+// BackupDir is part of the Backend interface.
+func (b stateBackend) BackupDir() (string, error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	modelConfig, err := model.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return backups.BackupDirToUse(modelConfig.BackupDir()), nil
+}
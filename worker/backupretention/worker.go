@@ -0,0 +1,182 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupretention
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Backend provides the controller configuration and backup
+// directory needed to apply the retention policy.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	BackupDir() (string, error)
+}
+
+// Config holds the configuration and dependencies for the worker.
+type Config struct {
+	Backend    Backend
+	NewBackups func(*backups.Paths) backups.Backups
+	Clock      clock.Clock
+	Logger     Logger
+	Interval   time.Duration
+}
+
+// Validate returns an error if the config cannot be expected
+// to drive a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.NewBackups == nil {
+		return errors.NotValidf("nil NewBackups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically removes stored backups
+// which fall outside the controller's backup retention policy.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &retentionWorker{
+		config: config,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type retentionWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *retentionWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *retentionWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *retentionWorker) loop() error {
+	// Prune once on startup, then on every interval.
+	var timeout <-chan time.Time
+	for {
+		if err := w.prune(); err != nil {
+			return errors.Trace(err)
+		}
+		timeout = w.config.Clock.After(w.config.Interval)
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timeout:
+		}
+	}
+}
+
+func (w *retentionWorker) prune() error {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "getting controller config")
+	}
+	count := cfg.BackupRetentionCount()
+	period := cfg.BackupRetentionPeriod()
+	if count == 0 && period == 0 {
+		return nil
+	}
+
+	backupDir, err := w.config.Backend.BackupDir()
+	if err != nil {
+		return errors.Annotate(err, "getting backup directory")
+	}
+	b := w.config.NewBackups(&backups.Paths{BackupDir: backupDir})
+	metaList, err := b.List()
+	if err != nil {
+		return errors.Annotate(err, "listing backups")
+	}
+
+	now := w.config.Clock.Now()
+	for _, meta := range Expired(metaList, count, period, now) {
+		w.config.Logger.Infof("removing backup %q outside retention policy", meta.ID())
+		if err := b.Remove(meta.ID()); err != nil {
+			// Keep going; a later pass will try again.
+			w.config.Logger.Warningf("cannot remove backup %q: %v", meta.ID(), err)
+		}
+	}
+	return nil
+}
+
+// Expired returns the backups in metaList which should be removed
+// to satisfy the retention policy: only the newest count backups
+// are kept (if count is positive), and backups older than period
+// are removed (if period is positive). Archives uploaded for a
+// restore are not subject to the policy.
+func Expired(metaList []*backups.Metadata, count int, period time.Duration, now time.Time) []*backups.Metadata {
+	sorted := make([]*backups.Metadata, 0, len(metaList))
+	for _, meta := range metaList {
+		if strings.HasPrefix(filepath.Base(meta.ID()), backups.UploadFilenamePrefix) {
+			continue
+		}
+		sorted = append(sorted, meta)
+	}
+	// Newest first.
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return backupTime(sorted[i]).After(backupTime(sorted[j]))
+	})
+
+	var expired []*backups.Metadata
+	for i, meta := range sorted {
+		if count > 0 && i >= count {
+			expired = append(expired, meta)
+			continue
+		}
+		if period > 0 && backupTime(meta).Before(now.Add(-period)) {
+			expired = append(expired, meta)
+		}
+	}
+	return expired
+}
+
+func backupTime(meta *backups.Metadata) time.Time {
+	if meta.Finished != nil {
+		return *meta.Finished
+	}
+	return meta.Started
+}
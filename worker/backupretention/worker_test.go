@@ -0,0 +1,248 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupretention_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupretention"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	backend *fakeBackend
+	backups *fakeBackups
+	config  backupretention.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{
+		config: controller.Config{
+			controller.BackupRetentionCount: 2,
+		},
+		backupDir: "/var/lib/juju/backups",
+	}
+	s.backups = &fakeBackups{removed: make(chan string, 10)}
+	s.config = backupretention.Config{
+		Backend: s.backend,
+		NewBackups: func(paths *backups.Paths) backups.Backups {
+			s.backups.paths = paths
+			return s.backups
+		},
+		Clock:    s.clock,
+		Logger:   loggo.GetLogger("test"),
+		Interval: time.Hour,
+	}
+}
+
+func (s *workerSuite) newMetadata(id string, age time.Duration) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	finished := s.clock.Now().Add(-age)
+	meta.Started = finished.Add(-time.Minute)
+	meta.Finished = &finished
+	return meta
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		f      func(*backupretention.Config)
+		expect string
+	}{{
+		func(cfg *backupretention.Config) { cfg.Backend = nil },
+		"nil Backend not valid",
+	}, {
+		func(cfg *backupretention.Config) { cfg.NewBackups = nil },
+		"nil NewBackups not valid",
+	}, {
+		func(cfg *backupretention.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *backupretention.Config) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *backupretention.Config) { cfg.Interval = 0 },
+		"non-positive Interval not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.expect)
+		config := s.config
+		test.f(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *workerSuite) TestRemovesBeyondCount(c *gc.C) {
+	s.backups.list = []*backups.Metadata{
+		s.newMetadata("oldest", 3*time.Hour),
+		s.newMetadata("newest", time.Hour),
+		s.newMetadata("middle", 2*time.Hour),
+	}
+	w, err := backupretention.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertRemoved(c, "oldest")
+	c.Assert(s.backups.paths.BackupDir, gc.Equals, "/var/lib/juju/backups")
+}
+
+func (s *workerSuite) TestRemovesOnInterval(c *gc.C) {
+	s.backend.config = controller.Config{
+		controller.BackupRetentionPeriod: "24h",
+	}
+	w, err := backupretention.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoRemoval(c)
+
+	s.backups.setList(s.newMetadata("stale", 25*time.Hour))
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRemoved(c, "stale")
+}
+
+func (s *workerSuite) TestNoPolicy(c *gc.C) {
+	s.backend.config = controller.Config{}
+	s.backups.list = []*backups.Metadata{
+		s.newMetadata("old", 1000*time.Hour),
+	}
+	w, err := backupretention.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoRemoval(c)
+	c.Assert(s.backups.paths, gc.IsNil)
+}
+
+func (s *workerSuite) TestControllerConfigError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	w, err := backupretention.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting controller config: boom")
+}
+
+func (s *workerSuite) TestExpired(c *gc.C) {
+	b1 := s.newMetadata("b1", 50*time.Hour)
+	b2 := s.newMetadata("b2", 30*time.Hour)
+	b3 := s.newMetadata("b3", 10*time.Hour)
+	b4 := s.newMetadata("b4", time.Hour)
+	upload := s.newMetadata("/var/lib/juju/backups/"+backups.UploadFilenamePrefix+"1.tar.gz", 100*time.Hour)
+	all := []*backups.Metadata{b3, upload, b1, b4, b2}
+	now := s.clock.Now()
+
+	tests := []struct {
+		about  string
+		count  int
+		period time.Duration
+		expect []*backups.Metadata
+	}{{
+		about: "no policy",
+	}, {
+		about:  "count only",
+		count:  3,
+		expect: []*backups.Metadata{b1},
+	}, {
+		about:  "period only",
+		period: 24 * time.Hour,
+		expect: []*backups.Metadata{b2, b1},
+	}, {
+		about:  "count and period",
+		count:  1,
+		period: 40 * time.Hour,
+		expect: []*backups.Metadata{b3, b2, b1},
+	}, {
+		about:  "count larger than list",
+		count:  10,
+		period: 100 * time.Hour,
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.about)
+		expired := backupretention.Expired(all, test.count, test.period, now)
+		c.Check(expired, jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *workerSuite) assertRemoved(c *gc.C, id string) {
+	select {
+	case removed := <-s.backups.removed:
+		c.Assert(removed, gc.Equals, id)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %q to be removed", id)
+	}
+	s.assertNoRemoval(c)
+}
+
+func (s *workerSuite) assertNoRemoval(c *gc.C) {
+	select {
+	case removed := <-s.backups.removed:
+		c.Fatalf("unexpected removal of %q", removed)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeBackend struct {
+	config    controller.Config
+	backupDir string
+	err       error
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	return b.config, b.err
+}
+
+func (b *fakeBackend) BackupDir() (string, error) {
+	return b.backupDir, nil
+}
+
+type fakeBackups struct {
+	backups.Backups
+
+	mu      sync.Mutex
+	paths   *backups.Paths
+	list    []*backups.Metadata
+	removed chan string
+}
+
+func (b *fakeBackups) setList(list ...*backups.Metadata) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.list = list
+}
+
+func (b *fakeBackups) List() ([]*backups.Metadata, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.list, nil
+}
+
+func (b *fakeBackups) Remove(id string) error {
+	b.removed <- id
+	return nil
+}
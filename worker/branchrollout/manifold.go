@@ -0,0 +1,168 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/dependency"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/common"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+const (
+	// DefaultStatusInterval is how often the units of a branch are
+	// checked while its rollout depends on their status.
+	DefaultStatusInterval = 30 * time.Second
+
+	// DefaultErrorDelay is how long to wait before restarting the
+	// rollouts of a model after they fail.
+	DefaultErrorDelay = time.Minute
+)
+
+// ManifoldConfig holds the information needed to run a branch rollout
+// worker in a dependency.Engine.
+type ManifoldConfig struct {
+	StateName string
+	Clock     clock.Clock
+	Logger    Logger
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold to run a branch rollout
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	systemState, err := statePool.SystemState()
+	if err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+
+	w, err := config.NewWorker(Config{
+		ModelWatcher:   systemState,
+		Controller:     stateController{pool: statePool},
+		Clock:          config.Clock,
+		Logger:         config.Logger,
+		StatusInterval: DefaultStatusInterval,
+		ErrorDelay:     DefaultErrorDelay,
+	})
+	if err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, func() { _ = stTracker.Done() }), nil
+}
+
+// stateController implements Controller using the state pool.
+type stateController struct {
+	pool *state.StatePool
+}
+
+// Model is part of the Controller interface.
+func (c stateController) Model(modelUUID string) (Model, func(), error) {
+	st, err := c.pool.Get(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		st.Release()
+		return nil, nil, errors.Trace(err)
+	}
+	return stateModel{st: st.State, model: model}, func() { st.Release() }, nil
+}
+
+// stateModel implements Model using a model's state.
+type stateModel struct {
+	st    *state.State
+	model *state.Model
+}
+
+// Life is part of the Model interface.
+func (m stateModel) Life() state.Life {
+	return m.model.Life()
+}
+
+// WatchBranches is part of the Model interface.
+func (m stateModel) WatchBranches() state.NotifyWatcher {
+	return m.st.WatchBranches()
+}
+
+// Branches is part of the Model interface.
+func (m stateModel) Branches() ([]Branch, error) {
+	branches, err := m.st.Branches()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Branch, len(branches))
+	for i, branch := range branches {
+		result[i] = branch
+	}
+	return result, nil
+}
+
+// UnitStatuses is part of the Model interface.
+func (m stateModel) UnitStatuses(appName string) (map[string]status.Status, error) {
+	app, err := m.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]status.Status, len(units))
+	for _, unit := range units {
+		info, err := unit.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[unit.Name()] = info.Status
+	}
+	return result, nil
+}
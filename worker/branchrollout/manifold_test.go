@@ -0,0 +1,83 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/dependency"
+	dt "github.com/juju/worker/v3/dependency/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/branchrollout"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+
+	config branchrollout.ManifoldConfig
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = branchrollout.ManifoldConfig{
+		StateName: "state",
+		Clock:     testclock.NewClock(time.Time{}),
+		Logger:    loggo.GetLogger("test"),
+		NewWorker: func(branchrollout.Config) (worker.Worker, error) {
+			return nil, errors.New("unexpected call")
+		},
+	}
+}
+
+func (s *manifoldSuite) TestInputs(c *gc.C) {
+	manifold := branchrollout.Manifold(s.config)
+	c.Assert(manifold.Inputs, jc.SameContents, []string{"state"})
+}
+
+func (s *manifoldSuite) TestValidate(c *gc.C) {
+	c.Assert(s.config.Validate(), jc.ErrorIsNil)
+
+	tests := []struct {
+		f      func(*branchrollout.ManifoldConfig)
+		expect string
+	}{{
+		func(cfg *branchrollout.ManifoldConfig) { cfg.StateName = "" },
+		"empty StateName not valid",
+	}, {
+		func(cfg *branchrollout.ManifoldConfig) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *branchrollout.ManifoldConfig) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *branchrollout.ManifoldConfig) { cfg.NewWorker = nil },
+		"nil NewWorker not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.expect)
+		config := s.config
+		test.f(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *manifoldSuite) TestMissingState(c *gc.C) {
+	manifold := branchrollout.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"state": dependency.ErrMissing,
+	})
+	_, err := manifold.Start(context)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// modelWorker takes the steps of the rollouts of a single model's
+// branches as they fall due, reacting to changes to the branches.
+type modelWorker struct {
+	catacomb  catacomb.Catacomb
+	config    Config
+	modelUUID string
+	model     Model
+}
+
+func newModelWorker(modelUUID string, model Model, release func(), config Config) (*modelWorker, error) {
+	w := &modelWorker{
+		config:    config,
+		modelUUID: modelUUID,
+		model:     model,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: func() error {
+			defer release()
+			return w.loop()
+		},
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill satisfies the Worker interface.
+func (w *modelWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait satisfies the Worker interface.
+func (w *modelWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *modelWorker) loop() error {
+	watcher := w.model.WatchBranches()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	// The timer fires when the next rollout step falls due, or units
+	// need to be checked again; it is nil when nothing is pending.
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("branch watcher closed")
+			}
+		case <-timer:
+		}
+
+		next, err := w.advanceBranches()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		timer = nil
+		if !next.IsZero() {
+			timer = w.config.Clock.After(next.Sub(w.config.Clock.Now()))
+		}
+	}
+}
+
+// advanceBranches takes any rollout steps which are due, returning the
+// time at which the branches next need to be checked, or the zero time
+// if only a change to the branches can make progress. A failure to
+// advance one branch is logged and retried later, without holding up
+// the model's other branches.
+func (w *modelWorker) advanceBranches() (time.Time, error) {
+	branches, err := w.model.Branches()
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "getting branches")
+	}
+	now := w.config.Clock.Now()
+	var next time.Time
+	for _, branch := range branches {
+		rollout, ok := branch.Rollout()
+		if !ok || rollout.Status.IsFinal() {
+			continue
+		}
+		due, err := w.advanceBranch(branch, rollout, now)
+		if err != nil {
+			w.config.Logger.Errorf("cannot advance rollout of branch %q in model %q: %v",
+				branch.BranchName(), w.modelUUID, err)
+			due = now.Add(w.config.StatusInterval)
+		}
+		if !due.IsZero() && (next.IsZero() || due.Before(next)) {
+			next = due
+		}
+	}
+	return next, nil
+}
+
+// advanceBranch takes the branch's next rollout step if it is due, or
+// commits or aborts the branch as its rollout policy directs. It
+// returns when the branch next needs to be checked.
+func (w *modelWorker) advanceBranch(branch Branch, rollout state.BranchRollout, now time.Time) (time.Time, error) {
+	if !rollout.Start.IsZero() && now.Before(rollout.Start) {
+		return rollout.Start, nil
+	}
+
+	assigned := branch.AssignedUnits()
+	statuses := make(map[string]map[string]status.Status, len(assigned))
+	for appName := range assigned {
+		appStatuses, err := w.model.UnitStatuses(appName)
+		if err != nil {
+			return time.Time{}, errors.Annotatef(err, "getting status of %q units", appName)
+		}
+		statuses[appName] = appStatuses
+	}
+
+	// Units are only checked while they can hold up or abort the
+	// rollout.
+	var recheck time.Time
+	tracking, trackingStatuses := trackingUnits(assigned, statuses)
+	if rollout.AutoAbort {
+		for _, unitName := range tracking {
+			if trackingStatuses[unitName] != status.Error {
+				continue
+			}
+			message := fmt.Sprintf("unit %s went into error", unitName)
+			w.config.Logger.Infof("aborting rollout of branch %q: %s", branch.BranchName(), message)
+			return time.Time{}, errors.Annotate(branch.AbortRollout(branch.CreatedBy(), message), "aborting")
+		}
+		recheck = now.Add(w.config.StatusInterval)
+	}
+
+	if rollout.Step > 0 {
+		// Give the last step time to settle before taking the next.
+		if settled := rollout.LastStep.Add(rollout.StepInterval); now.Before(settled) {
+			return earliest(settled, recheck), nil
+		}
+		if rollout.WaitForActive {
+			for _, unitName := range tracking {
+				if trackingStatuses[unitName] != status.Active {
+					w.config.Logger.Debugf("rollout of branch %q waiting for unit %s", branch.BranchName(), unitName)
+					return now.Add(w.config.StatusInterval), nil
+				}
+			}
+		}
+	}
+
+	if rollout.Step >= len(rollout.Steps) {
+		genId, err := branch.Commit(branch.CreatedBy())
+		if err != nil {
+			return time.Time{}, errors.Annotate(err, "committing")
+		}
+		w.config.Logger.Infof("rollout of branch %q completed at generation %d", branch.BranchName(), genId)
+		return time.Time{}, nil
+	}
+
+	percent := rollout.Steps[rollout.Step]
+	for appName, unitNames := range assigned {
+		have := 0
+		for _, unitName := range unitNames {
+			if _, ok := statuses[appName][unitName]; ok {
+				have++
+			}
+		}
+		want := (percent*len(statuses[appName]) + 99) / 100
+		if want <= have {
+			continue
+		}
+		if err := branch.AssignUnits(appName, want-have); err != nil {
+			return time.Time{}, errors.Annotatef(err, "assigning units of %q", appName)
+		}
+	}
+	if err := branch.SetRolloutProgress(rollout.Step+1, now); err != nil {
+		return time.Time{}, errors.Annotate(err, "recording progress")
+	}
+	w.config.Logger.Infof("rollout of branch %q reached %d%%", branch.BranchName(), percent)
+	return earliest(now.Add(rollout.StepInterval), recheck), nil
+}
+
+// earliest returns the earlier of the two times, ignoring either
+// which is zero.
+func earliest(t1, t2 time.Time) time.Time {
+	if t1.IsZero() || (!t2.IsZero() && t2.Before(t1)) {
+		return t2
+	}
+	return t1
+}
+
+// trackingUnits returns the names of the units tracking the branch which
+// still exist, in sorted order, along with their statuses.
+func trackingUnits(
+	assigned map[string][]string, statuses map[string]map[string]status.Status,
+) ([]string, map[string]status.Status) {
+	var unitNames []string
+	unitStatuses := make(map[string]status.Status)
+	for appName, assignedNames := range assigned {
+		for _, unitName := range assignedNames {
+			if unitStatus, ok := statuses[appName][unitName]; ok {
+				unitNames = append(unitNames, unitName)
+				unitStatuses[unitName] = unitStatus
+			}
+		}
+	}
+	sort.Strings(unitNames)
+	return unitNames, unitStatuses
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/branchrollout"
+)
+
+// modelWorkerSuite tests the rollout of the branches of a single
+// model.
+type modelWorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	model  *fakeModel
+	branch *fakeBranch
+	calls  chan string
+	config branchrollout.Config
+}
+
+var _ = gc.Suite(&modelWorkerSuite{})
+
+func (s *modelWorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.calls = make(chan string, 10)
+	s.branch = s.newBranch("new-branch")
+	controller := &fakeController{
+		models:  make(map[string]*fakeModel),
+		watched: make(chan string, 10),
+	}
+	s.model = controller.addModel("model-uuid")
+	s.model.branches = []branchrollout.Branch{s.branch}
+	for _, unitName := range []string{"redis/0", "redis/1", "redis/2", "redis/3"} {
+		s.model.statuses[unitName] = status.Active
+	}
+	s.config = branchrollout.Config{
+		ModelWatcher:   &fakeModelWatcher{changes: make(chan []string, 1)},
+		Controller:     controller,
+		Clock:          s.clock,
+		Logger:         loggo.GetLogger("test"),
+		StatusInterval: 10 * time.Second,
+		ErrorDelay:     5 * time.Minute,
+	}
+}
+
+func (s *modelWorkerSuite) newBranch(name string) *fakeBranch {
+	return &fakeBranch{
+		name: name,
+		rollout: state.BranchRollout{
+			RolloutPolicy: model.RolloutPolicy{
+				Steps:        []int{25, 50, 100},
+				StepInterval: time.Minute,
+			},
+			Status: model.RolloutPending,
+		},
+		assigned: map[string][]string{"redis": {}},
+		calls:    s.calls,
+	}
+}
+
+func (s *modelWorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := branchrollout.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.config.ModelWatcher.(*fakeModelWatcher).changes <- []string{"model-uuid"}
+	return w
+}
+
+func (s *modelWorkerSuite) TestRollsOutStepByStep(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertCalls(c, "new-branch: assign redis 1", "new-branch: progress 1")
+
+	// The step interval is honoured between steps.
+	s.advance(c, 30*time.Second)
+	s.assertCalls(c)
+	s.advance(c, 30*time.Second)
+	s.assertCalls(c, "new-branch: assign redis 1", "new-branch: progress 2")
+
+	s.advance(c, time.Minute)
+	s.assertCalls(c, "new-branch: assign redis 2", "new-branch: progress 3")
+
+	s.advance(c, time.Minute)
+	s.assertCalls(c, "new-branch: commit admin")
+}
+
+func (s *modelWorkerSuite) TestReactsToBranchChanges(c *gc.C) {
+	s.branch.rollout = state.BranchRollout{}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertCalls(c)
+
+	// A rollout policy set on the branch is picked up without waiting.
+	s.branch.mu.Lock()
+	s.branch.rollout = s.newBranch("").rollout
+	s.branch.mu.Unlock()
+	s.model.branchWatcher().(*fakeNotifyWatcher).changes <- struct{}{}
+	s.assertCalls(c, "new-branch: assign redis 1", "new-branch: progress 1")
+}
+
+func (s *modelWorkerSuite) TestWaitsForStart(c *gc.C) {
+	s.branch.rollout.Start = s.clock.Now().Add(90 * time.Second)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertCalls(c)
+	s.advance(c, time.Minute)
+	s.assertCalls(c)
+
+	s.advance(c, 30*time.Second)
+	s.assertCalls(c, "new-branch: assign redis 1", "new-branch: progress 1")
+}
+
+func (s *modelWorkerSuite) TestWaitsForActive(c *gc.C) {
+	s.branch.rollout.WaitForActive = true
+	s.branch.rollout.Status = model.RolloutRunning
+	s.branch.rollout.Step = 1
+	s.branch.rollout.LastStep = s.clock.Now().Add(-time.Hour)
+	s.branch.assigned["redis"] = []string{"redis/0"}
+	s.model.setStatus("redis/0", status.Maintenance)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertCalls(c)
+
+	s.model.setStatus("redis/0", status.Active)
+	s.advance(c, 10*time.Second)
+	s.assertCalls(c, "new-branch: assign redis 1", "new-branch: progress 2")
+}
+
+func (s *modelWorkerSuite) TestAutoAbort(c *gc.C) {
+	s.branch.rollout.AutoAbort = true
+	s.branch.rollout.Status = model.RolloutRunning
+	s.branch.rollout.Step = 1
+	s.branch.rollout.LastStep = s.clock.Now()
+	s.branch.assigned["redis"] = []string{"redis/0"}
+	s.model.setStatus("redis/0", status.Error)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertCalls(c, "new-branch: abort admin: unit redis/0 went into error")
+}
+
+func (s *modelWorkerSuite) TestAutoAbortBetweenSteps(c *gc.C) {
+	s.branch.rollout.AutoAbort = true
+	s.branch.rollout.StepInterval = time.Hour
+	s.branch.rollout.Status = model.RolloutRunning
+	s.branch.rollout.Step = 1
+	s.branch.rollout.LastStep = s.clock.Now()
+	s.branch.assigned["redis"] = []string{"redis/0"}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertCalls(c)
+
+	// The units are checked well before the next step is due.
+	s.model.setStatus("redis/0", status.Error)
+	s.advance(c, 10*time.Second)
+	s.assertCalls(c, "new-branch: abort admin: unit redis/0 went into error")
+}
+
+func (s *modelWorkerSuite) TestErrorIgnoredWithoutAutoAbort(c *gc.C) {
+	s.model.setStatus("redis/0", status.Error)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertCalls(c, "new-branch: assign redis 1", "new-branch: progress 1")
+}
+
+func (s *modelWorkerSuite) TestSkipsBranchesWithoutRollout(c *gc.C) {
+	s.model.branches = []branchrollout.Branch{&fakeBranch{name: "plain", calls: s.calls}}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertCalls(c)
+}
+
+func (s *modelWorkerSuite) TestBranchFailureDoesNotStopOthers(c *gc.C) {
+	broken := s.newBranch("broken")
+	broken.assignErr = errors.New("boom")
+	s.model.branches = []branchrollout.Branch{broken, s.branch}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertCalls(c,
+		"broken: assign failed",
+		"new-branch: assign redis 1", "new-branch: progress 1",
+	)
+
+	// The failed branch is retried after the status interval.
+	s.advance(c, 10*time.Second)
+	s.assertCalls(c, "broken: assign failed")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *modelWorkerSuite) advance(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelWorkerSuite) assertCalls(c *gc.C, expected ...string) {
+	for _, expect := range expected {
+		select {
+		case call := <-s.calls:
+			c.Assert(call, gc.Equals, expect)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %q", expect)
+		}
+	}
+	select {
+	case call := <-s.calls:
+		c.Fatalf("unexpected call %q", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// ModelWatcher provides the means to watch for the addition and
+// removal of models.
+type ModelWatcher interface {
+	WatchModels() state.StringsWatcher
+}
+
+// Controller provides the controller's models by UUID. Once a model
+// is no longer required, the returned function must be called to
+// dispose of it.
+type Controller interface {
+	Model(modelUUID string) (Model, func(), error)
+}
+
+// Model provides the in-flight branches of a model, and the status of
+// the units they are rolled out to.
+type Model interface {
+	// Life returns the life of the model.
+	Life() state.Life
+
+	// WatchBranches returns a watcher which notifies of changes to
+	// the model's branches.
+	WatchBranches() state.NotifyWatcher
+
+	// Branches returns the model's in-flight branches.
+	Branches() ([]Branch, error)
+
+	// UnitStatuses returns the status of each of the application's
+	// units, keyed by unit name.
+	UnitStatuses(appName string) (map[string]status.Status, error)
+}
+
+// Branch describes the methods of a model branch used by the worker.
+type Branch interface {
+	BranchName() string
+	CreatedBy() string
+	Rollout() (state.BranchRollout, bool)
+	AssignedUnits() map[string][]string
+	AssignUnits(appName string, numUnits int) error
+	SetRolloutProgress(step int, at time.Time) error
+	AbortRollout(userName, message string) error
+	Commit(userName string) (int, error)
+}
+
+// Config holds the dependencies and configuration necessary to run a
+// branch rollout worker.
+type Config struct {
+	ModelWatcher ModelWatcher
+	Controller   Controller
+	Clock        clock.Clock
+	Logger       Logger
+
+	// StatusInterval is how often the units of a branch are checked
+	// while its rollout waits for them to become active, or watches
+	// for them going into error.
+	StatusInterval time.Duration
+
+	// ErrorDelay is how long to wait before restarting the rollouts
+	// of a model after they fail.
+	ErrorDelay time.Duration
+}
+
+// Validate returns an error if config cannot be expected to drive
+// a functional branch rollout worker.
+func (config Config) Validate() error {
+	if config.ModelWatcher == nil {
+		return errors.NotValidf("nil ModelWatcher")
+	}
+	if config.Controller == nil {
+		return errors.NotValidf("nil Controller")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.StatusInterval <= 0 {
+		return errors.NotValidf("non-positive StatusInterval")
+	}
+	if config.ErrorDelay <= 0 {
+		return errors.NotValidf("non-positive ErrorDelay")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which runs a rollout worker for each of
+// the controller's live models. A failure in one model's rollouts is
+// logged and the model's worker restarted, without affecting the
+// rollouts of other models.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &rolloutManager{
+		config: config,
+		runner: worker.NewRunner(worker.RunnerParams{
+			IsFatal:      func(error) bool { return false },
+			RestartDelay: config.ErrorDelay,
+			Clock:        config.Clock,
+			Logger:       config.Logger,
+		}),
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{w.runner},
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type rolloutManager struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	runner   *worker.Runner
+}
+
+// Kill satisfies the Worker interface.
+func (w *rolloutManager) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait satisfies the Worker interface.
+func (w *rolloutManager) Wait() error {
+	return w.catacomb.Wait()
+}
+
+// Report shows up in the dependency engine report.
+func (w *rolloutManager) Report() map[string]interface{} {
+	return w.runner.Report()
+}
+
+func (w *rolloutManager) loop() error {
+	watcher := w.config.ModelWatcher.WatchModels()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case uuids, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("model watcher closed")
+			}
+			for _, modelUUID := range uuids {
+				if err := w.modelChanged(modelUUID); err != nil {
+					w.config.Logger.Errorf("cannot manage rollouts for model %q: %v", modelUUID, err)
+				}
+			}
+		}
+	}
+}
+
+// modelChanged starts a rollout worker for the model if it is alive,
+// and stops any worker it has otherwise.
+func (w *rolloutManager) modelChanged(modelUUID string) error {
+	model, release, err := w.config.Controller.Model(modelUUID)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	alive := err == nil && model.Life() == state.Alive
+	if err == nil {
+		release()
+	}
+
+	if !alive {
+		err := w.runner.StopAndRemoveWorker(modelUUID, w.catacomb.Dying())
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	err = w.runner.StartWorker(modelUUID, func() (worker.Worker, error) {
+		return w.startModelWorker(modelUUID)
+	})
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (w *rolloutManager) startModelWorker(modelUUID string) (worker.Worker, error) {
+	model, release, err := w.config.Controller.Model(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	mw, err := newModelWorker(modelUUID, model, release, w.config)
+	if err != nil {
+		release()
+		return nil, errors.Trace(err)
+	}
+	return mw, nil
+}
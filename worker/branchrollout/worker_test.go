@@ -0,0 +1,409 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/branchrollout"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock        *testclock.Clock
+	modelWatcher *fakeModelWatcher
+	controller   *fakeController
+	config       branchrollout.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.modelWatcher = &fakeModelWatcher{changes: make(chan []string)}
+	s.controller = &fakeController{
+		models:  make(map[string]*fakeModel),
+		watched: make(chan string, 10),
+	}
+	s.config = branchrollout.Config{
+		ModelWatcher:   s.modelWatcher,
+		Controller:     s.controller,
+		Clock:          s.clock,
+		Logger:         loggo.GetLogger("test"),
+		StatusInterval: time.Minute,
+		ErrorDelay:     5 * time.Minute,
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		f      func(*branchrollout.Config)
+		expect string
+	}{{
+		func(cfg *branchrollout.Config) { cfg.ModelWatcher = nil },
+		"nil ModelWatcher not valid",
+	}, {
+		func(cfg *branchrollout.Config) { cfg.Controller = nil },
+		"nil Controller not valid",
+	}, {
+		func(cfg *branchrollout.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *branchrollout.Config) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *branchrollout.Config) { cfg.StatusInterval = 0 },
+		"non-positive StatusInterval not valid",
+	}, {
+		func(cfg *branchrollout.Config) { cfg.ErrorDelay = 0 },
+		"non-positive ErrorDelay not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.expect)
+		config := s.config
+		test.f(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *workerSuite) TestStartsModelWorkers(c *gc.C) {
+	s.controller.addModel("uuid-1")
+	s.controller.addModel("uuid-2")
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendModelChange(c, "uuid-1", "uuid-2", "uuid-3")
+	s.assertWatched(c, "uuid-1", "uuid-2")
+
+	// A model worker is only started once.
+	s.sendModelChange(c, "uuid-1")
+	s.assertWatched(c)
+}
+
+func (s *workerSuite) TestStopsModelWorkerForDyingModel(c *gc.C) {
+	m := s.controller.addModel("uuid-1")
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendModelChange(c, "uuid-1")
+	s.assertWatched(c, "uuid-1")
+
+	m.setLife(state.Dying)
+	s.sendModelChange(c, "uuid-1")
+	workertest.CheckKilled(c, m.branchWatcher())
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if s.controller.unreleased() == 0 {
+			break
+		}
+	}
+	c.Check(s.controller.unreleased(), gc.Equals, 0)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *workerSuite) TestModelErrorRestartsModelWorker(c *gc.C) {
+	m := s.controller.addModel("uuid-1")
+	m.setBranchesErr(errors.New("boom"))
+	other := s.controller.addModel("uuid-2")
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendModelChange(c, "uuid-1", "uuid-2")
+	s.assertWatched(c, "uuid-1", "uuid-2")
+	workertest.CheckKilled(c, m.branchWatcher())
+
+	// The other model's worker is unaffected, and the failed one is
+	// restarted after a delay.
+	workertest.CheckAlive(c, other.branchWatcher())
+	m.setBranchesErr(nil)
+	err := s.clock.WaitAdvance(5*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertWatched(c, "uuid-1")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := branchrollout.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *workerSuite) sendModelChange(c *gc.C, uuids ...string) {
+	select {
+	case s.modelWatcher.changes <- uuids:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending model change")
+	}
+}
+
+func (s *workerSuite) assertWatched(c *gc.C, expected ...string) {
+	var watched []string
+	for range expected {
+		select {
+		case modelUUID := <-s.controller.watched:
+			watched = append(watched, modelUUID)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for branches to be watched")
+		}
+	}
+	c.Check(watched, jc.SameContents, expected)
+	select {
+	case modelUUID := <-s.controller.watched:
+		c.Fatalf("unexpected watch of model %q", modelUUID)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeModelWatcher struct {
+	changes chan []string
+}
+
+func (w *fakeModelWatcher) WatchModels() state.StringsWatcher {
+	return &fakeStringsWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: w.changes,
+	}
+}
+
+type fakeStringsWatcher struct {
+	worker.Worker
+	changes chan []string
+}
+
+func (w *fakeStringsWatcher) Err() error {
+	return w.Wait()
+}
+
+func (w *fakeStringsWatcher) Stop() error {
+	return worker.Stop(w)
+}
+
+func (w *fakeStringsWatcher) Changes() <-chan []string {
+	return w.changes
+}
+
+type fakeNotifyWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+func (w *fakeNotifyWatcher) Err() error {
+	return w.Wait()
+}
+
+func (w *fakeNotifyWatcher) Stop() error {
+	return worker.Stop(w)
+}
+
+func (w *fakeNotifyWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+type fakeController struct {
+	mu       sync.Mutex
+	models   map[string]*fakeModel
+	acquired int
+	watched  chan string
+}
+
+func (c *fakeController) addModel(modelUUID string) *fakeModel {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m := &fakeModel{
+		uuid:     modelUUID,
+		life:     state.Alive,
+		statuses: make(map[string]status.Status),
+		watched:  c.watched,
+	}
+	c.models[modelUUID] = m
+	return m
+}
+
+func (c *fakeController) unreleased() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.acquired
+}
+
+func (c *fakeController) Model(modelUUID string) (branchrollout.Model, func(), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m, ok := c.models[modelUUID]
+	if !ok {
+		return nil, nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	c.acquired++
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			c.mu.Lock()
+			c.acquired--
+			c.mu.Unlock()
+		})
+	}
+	return m, release, nil
+}
+
+type fakeModel struct {
+	mu          sync.Mutex
+	uuid        string
+	life        state.Life
+	branches    []branchrollout.Branch
+	branchesErr error
+	statuses    map[string]status.Status
+	watcher     *fakeNotifyWatcher
+	watched     chan<- string
+}
+
+func (m *fakeModel) setLife(life state.Life) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.life = life
+}
+
+func (m *fakeModel) setBranchesErr(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.branchesErr = err
+}
+
+func (m *fakeModel) setStatus(unitName string, unitStatus status.Status) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[unitName] = unitStatus
+}
+
+func (m *fakeModel) branchWatcher() worker.Worker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.watcher
+}
+
+func (m *fakeModel) Life() state.Life {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.life
+}
+
+func (m *fakeModel) WatchBranches() state.NotifyWatcher {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.watcher = &fakeNotifyWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: make(chan struct{}, 1),
+	}
+	m.watcher.changes <- struct{}{}
+	m.watched <- m.uuid
+	return m.watcher
+}
+
+func (m *fakeModel) Branches() ([]branchrollout.Branch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.branches, m.branchesErr
+}
+
+func (m *fakeModel) UnitStatuses(appName string) (map[string]status.Status, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make(map[string]status.Status)
+	for unitName, unitStatus := range m.statuses {
+		result[unitName] = unitStatus
+	}
+	return result, nil
+}
+
+type fakeBranch struct {
+	mu        sync.Mutex
+	name      string
+	rollout   state.BranchRollout
+	assigned  map[string][]string
+	assignErr error
+	calls     chan string
+}
+
+func (b *fakeBranch) BranchName() string {
+	return b.name
+}
+
+func (b *fakeBranch) CreatedBy() string {
+	return "admin"
+}
+
+func (b *fakeBranch) Rollout() (state.BranchRollout, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rollout, b.rollout.Steps != nil
+}
+
+func (b *fakeBranch) AssignedUnits() map[string][]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	result := make(map[string][]string)
+	for appName, unitNames := range b.assigned {
+		result[appName] = append([]string{}, unitNames...)
+	}
+	return result
+}
+
+func (b *fakeBranch) AssignUnits(appName string, numUnits int) error {
+	b.mu.Lock()
+	if b.assignErr != nil {
+		b.mu.Unlock()
+		b.calls <- fmt.Sprintf("%s: assign failed", b.name)
+		return b.assignErr
+	}
+	for i := 0; i < numUnits; i++ {
+		unitName := fmt.Sprintf("%s/%d", appName, len(b.assigned[appName]))
+		b.assigned[appName] = append(b.assigned[appName], unitName)
+	}
+	b.mu.Unlock()
+	b.calls <- fmt.Sprintf("%s: assign %s %d", b.name, appName, numUnits)
+	return nil
+}
+
+func (b *fakeBranch) SetRolloutProgress(step int, at time.Time) error {
+	b.mu.Lock()
+	b.rollout.Status = model.RolloutRunning
+	b.rollout.Step = step
+	b.rollout.LastStep = at
+	b.mu.Unlock()
+	b.calls <- fmt.Sprintf("%s: progress %d", b.name, step)
+	return nil
+}
+
+func (b *fakeBranch) AbortRollout(userName, message string) error {
+	b.mu.Lock()
+	b.rollout.Status = model.RolloutAborted
+	b.rollout.Message = message
+	b.mu.Unlock()
+	b.calls <- fmt.Sprintf("%s: abort %s: %s", b.name, userName, message)
+	return nil
+}
+
+func (b *fakeBranch) Commit(userName string) (int, error) {
+	b.mu.Lock()
+	b.rollout.Status = model.RolloutCompleted
+	b.mu.Unlock()
+	b.calls <- fmt.Sprintf("%s: commit %s", b.name, userName)
+	return 1, nil
+}
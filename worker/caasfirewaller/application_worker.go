@@ -12,6 +12,9 @@ import (
 	"github.com/juju/worker/v3"
 	"github.com/juju/worker/v3/catacomb"
 
+	"github.com/juju/juju/core/config"
+	"github.com/juju/juju/core/network/firewall"
+	environsconfig "github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/tags"
 )
 
@@ -24,9 +27,12 @@ type applicationWorker struct {
 	serviceExposer    ServiceExposer
 	lifeGetter        LifeGetter
 	charmGetter       CharmGetter
+	modelConfig       ModelConfigWatcher
 
 	initial           bool
 	previouslyExposed bool
+	previousIngress   []string
+	previousEgress    firewall.EgressRules
 
 	logger Logger
 }
@@ -39,6 +45,7 @@ func newApplicationWorker(
 	applicationExposer ServiceExposer,
 	lifeGetter LifeGetter,
 	charmGetter CharmGetter,
+	modelConfig ModelConfigWatcher,
 	logger Logger,
 ) (worker.Worker, error) {
 	w := &applicationWorker{
@@ -49,6 +56,7 @@ func newApplicationWorker(
 		serviceExposer:    applicationExposer,
 		lifeGetter:        lifeGetter,
 		charmGetter:       charmGetter,
+		modelConfig:       modelConfig,
 		initial:           true,
 		logger:            logger,
 	}
@@ -86,11 +94,29 @@ func (w *applicationWorker) loop() (err error) {
 	if err := w.catacomb.Add(appWatcher); err != nil {
 		return errors.Trace(err)
 	}
+	// The model config holds the exposed ingress allowlists.
+	modelConfigWatcher, err := w.modelConfig.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
 
 	for {
 		select {
 		case <-w.catacomb.Dying():
 			return w.catacomb.ErrDying()
+		case _, ok := <-modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := w.processApplicationChange(); err != nil {
+				if strings.Contains(err.Error(), "unexpected EOF") {
+					return nil
+				}
+				return errors.Trace(err)
+			}
 		case _, ok := <-appWatcher.Changes():
 			if !ok {
 				return errors.New("application watcher closed")
@@ -147,28 +173,80 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if !w.initial && exposed == w.previouslyExposed {
+	appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.processEgressChange(appConfig); err != nil {
+		return errors.Trace(err)
+	}
+	modelConfig, err := w.modelConfig.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ingress := allowedIngressCIDRs(w.application, modelConfig)
+	if !w.initial && exposed == w.previouslyExposed &&
+		(!exposed || strings.Join(ingress, ",") == strings.Join(w.previousIngress, ",")) {
 		return nil
 	}
 
 	w.initial = false
 	w.previouslyExposed = exposed
-	if exposed {
-		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
-		if err != nil {
-			return errors.Trace(err)
-		}
+	w.previousIngress = ingress
+	if exposed && len(ingress) > 0 {
 		resourceTags := tags.ResourceTags(
 			names.NewModelTag(w.modelUUID),
 			names.NewControllerTag(w.controllerUUID),
 		)
-		if err := w.serviceExposer.ExposeService(w.application, resourceTags, appConfig); err != nil {
+		if err := w.serviceExposer.ExposeService(w.application, resourceTags, appConfig, ingress); err != nil {
 			return errors.Trace(err)
 		}
 		return nil
 	}
+	if exposed {
+		w.logger.Warningf("exposed application %q is not reachable: no ingress is allowed by the model's exposed ingress allowlists", w.application)
+	}
 	if err := w.serviceExposer.UnexposeService(w.application); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
 }
+
+// allowedIngressCIDRs returns the CIDRs allowed to reach the application
+// once it is exposed, according to the model's exposed ingress
+// allowlists. The application is exposed through a single ingress
+// resource serving all of its endpoints, so when any of its endpoints
+// have their own allowlist, only the CIDRs allowed by all of them are
+// used in place of the model wide allowlist.
+func allowedIngressCIDRs(application string, cfg *environsconfig.Config) []string {
+	allowed := cfg.ExposedIngressAllow()
+	found := false
+	for key, endpointAllowed := range cfg.ExposedEndpointIngressAllow() {
+		if app, _, _ := strings.Cut(key, ":"); app != application {
+			continue
+		}
+		if !found {
+			allowed, found = endpointAllowed, true
+			continue
+		}
+		allowed = firewall.IntersectCIDRs(allowed, endpointAllowed)
+	}
+	return allowed
+}
+
+// processEgressChange restricts the outbound traffic of the application
+// to the egress rules in its config, if they changed.
+func (w *applicationWorker) processEgressChange(appConfig config.ConfigAttributes) error {
+	rules, err := firewall.ParseEgressRules(appConfig.GetString(firewall.EgressAllowKey, ""))
+	if err != nil {
+		return errors.Annotatef(err, "egress rules for application %q", w.application)
+	}
+	if !w.initial && rules.EqualTo(w.previousEgress) {
+		return nil
+	}
+	if err := w.serviceExposer.EnsureEgressPolicy(w.application, rules); err != nil {
+		return errors.Trace(err)
+	}
+	w.previousEgress = rules
+	return nil
+}
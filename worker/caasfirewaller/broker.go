@@ -3,9 +3,13 @@
 
 package caasfirewaller
 
-import "github.com/juju/juju/core/config"
+import (
+	"github.com/juju/juju/core/config"
+	"github.com/juju/juju/core/network/firewall"
+)
 
 type ServiceExposer interface {
-	ExposeService(appName string, resourceTags map[string]string, config config.ConfigAttributes) error
+	ExposeService(appName string, resourceTags map[string]string, config config.ConfigAttributes, ingressCIDRs []string) error
 	UnexposeService(appName string) error
+	EnsureEgressPolicy(appName string, rules firewall.EgressRules) error
 }
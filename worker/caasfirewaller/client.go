@@ -8,6 +8,7 @@ import (
 	"github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
+	environsconfig "github.com/juju/juju/environs/config"
 )
 
 // Client provides an interface for interacting with the
@@ -17,6 +18,7 @@ type Client interface {
 	ApplicationGetter
 	LifeGetter
 	CharmGetter
+	ModelConfigWatcher
 }
 
 // ApplicationGetter provides an interface for
@@ -39,3 +41,11 @@ type LifeGetter interface {
 type CharmGetter interface {
 	ApplicationCharmInfo(string) (*charms.CharmInfo, error)
 }
+
+// ModelConfigWatcher provides an interface for watching and
+// fetching the model config, which holds the model's exposed
+// ingress allowlists.
+type ModelConfigWatcher interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*environsconfig.Config, error)
+}
@@ -93,6 +93,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		ApplicationGetter: client,
 		LifeGetter:        client,
 		CharmGetter:       client,
+		ModelConfig:       client,
 		ServiceExposer:    broker,
 		Logger:            config.Logger,
 	})
@@ -156,6 +156,7 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 		ServiceExposer:    &s.broker,
 		LifeGetter:        &s.client,
 		CharmGetter:       &s.client,
+		ModelConfig:       &s.client,
 		Logger:            loggo.GetLogger("test"),
 	})
 }
@@ -12,8 +12,10 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/core/watcher/watchertest"
+	environsconfig "github.com/juju/juju/environs/config"
 	"github.com/juju/juju/worker/caasfirewaller"
 )
 
@@ -35,8 +37,8 @@ type mockServiceExposer struct {
 	unexposed chan<- struct{}
 }
 
-func (m *mockServiceExposer) ExposeService(appName string, resourceTags map[string]string, config config.ConfigAttributes) error {
-	m.MethodCall(m, "ExposeService", appName, resourceTags, config)
+func (m *mockServiceExposer) ExposeService(appName string, resourceTags map[string]string, config config.ConfigAttributes, ingressCIDRs []string) error {
+	m.MethodCall(m, "ExposeService", appName, resourceTags, config, ingressCIDRs)
 	m.exposed <- struct{}{}
 	return m.NextErr()
 }
@@ -47,11 +49,17 @@ func (m *mockServiceExposer) UnexposeService(appName string) error {
 	return m.NextErr()
 }
 
+func (m *mockServiceExposer) EnsureEgressPolicy(appName string, rules firewall.EgressRules) error {
+	m.MethodCall(m, "EnsureEgressPolicy", appName, rules)
+	return m.NextErr()
+}
+
 type mockApplicationGetter struct {
 	testing.Stub
 	allWatcher *watchertest.MockStringsWatcher
 	appWatcher *watchertest.MockNotifyWatcher
 	exposed    bool
+	egress     string
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -80,7 +88,32 @@ func (m *mockApplicationGetter) IsExposed(appName string) (bool, error) {
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (config.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig", appName)
-	return config.ConfigAttributes{"juju-external-hostname": "exthost"}, a.NextErr()
+	return config.ConfigAttributes{
+		"juju-external-hostname": "exthost",
+		firewall.EgressAllowKey:  a.egress,
+	}, a.NextErr()
+}
+
+type mockModelConfigWatcher struct {
+	testing.Stub
+	changes chan struct{}
+	config  *environsconfig.Config
+}
+
+func (m *mockModelConfigWatcher) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchForModelConfigChanges")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return watchertest.NewMockNotifyWatcher(m.changes), nil
+}
+
+func (m *mockModelConfigWatcher) ModelConfig() (*environsconfig.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.config, nil
 }
 
 type mockLifeGetter struct {
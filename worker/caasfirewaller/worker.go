@@ -25,6 +25,7 @@ type Config struct {
 	ApplicationGetter ApplicationGetter
 	LifeGetter        LifeGetter
 	CharmGetter       CharmGetter
+	ModelConfig       ModelConfigWatcher
 	ServiceExposer    ServiceExposer
 	Logger            Logger
 }
@@ -46,6 +47,9 @@ func (config Config) Validate() error {
 	if config.CharmGetter == nil {
 		return errors.NotValidf("missing CharmGetter")
 	}
+	if config.ModelConfig == nil {
+		return errors.NotValidf("missing ModelConfig")
+	}
 	if config.ServiceExposer == nil {
 		return errors.NotValidf("missing ServiceExposer")
 	}
@@ -141,6 +145,7 @@ func (p *firewaller) loop() error {
 					p.config.ServiceExposer,
 					p.config.LifeGetter,
 					p.config.CharmGetter,
+					p.config.ModelConfig,
 					logger,
 				)
 				if err != nil {
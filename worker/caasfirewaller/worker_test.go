@@ -19,7 +19,10 @@ import (
 	"github.com/juju/juju/api/common/charms"
 	"github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/watcher/watchertest"
+	environsconfig "github.com/juju/juju/environs/config"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/caasfirewaller"
 )
@@ -32,9 +35,11 @@ type WorkerSuite struct {
 	serviceExposer    mockServiceExposer
 	lifeGetter        mockLifeGetter
 	charmGetter       mockCharmGetter
+	modelConfig       mockModelConfigWatcher
 
 	applicationChanges chan []string
 	appExposedChange   chan struct{}
+	modelConfigChange  chan struct{}
 	serviceExposed     chan struct{}
 	serviceUnexposed   chan struct{}
 }
@@ -46,6 +51,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationChanges = make(chan []string)
 	s.appExposedChange = make(chan struct{})
+	s.modelConfigChange = make(chan struct{})
 	s.serviceExposed = make(chan struct{})
 	s.serviceUnexposed = make(chan struct{})
 
@@ -64,6 +70,10 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 			Meta:     &charm.Meta{},
 		},
 	}
+	s.modelConfig = mockModelConfigWatcher{
+		changes: s.modelConfigChange,
+		config:  s.newModelConfig(c, nil),
+	}
 	s.serviceExposer = mockServiceExposer{
 		exposed:   s.serviceExposed,
 		unexposed: s.serviceUnexposed,
@@ -76,10 +86,33 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		ServiceExposer:    &s.serviceExposer,
 		LifeGetter:        &s.lifeGetter,
 		CharmGetter:       &s.charmGetter,
+		ModelConfig:       &s.modelConfig,
 		Logger:            loggo.GetLogger("test"),
 	}
 }
 
+func (s *WorkerSuite) newModelConfig(c *gc.C, attrs coretesting.Attrs) *environsconfig.Config {
+	cfg, err := environsconfig.New(environsconfig.UseDefaults, coretesting.FakeConfig().Merge(attrs))
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *WorkerSuite) sendModelConfigChange(c *gc.C) {
+	select {
+	case s.modelConfigChange <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending model config change")
+	}
+}
+
+func (s *WorkerSuite) waitExposed(c *gc.C) {
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+}
+
 func (s *WorkerSuite) sendApplicationExposedChange(c *gc.C) {
 	select {
 	case s.appExposedChange <- struct{}{}:
@@ -113,6 +146,10 @@ func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 		config.CharmGetter = nil
 	}, `missing CharmGetter not valid`)
 
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.ModelConfig = nil
+	}, `missing ModelConfig not valid`)
+
 	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
 		config.Logger = nil
 	}, `missing Logger not valid`)
@@ -171,12 +208,117 @@ func (s *WorkerSuite) TestExposedChange(c *gc.C) {
 	case <-time.After(coretesting.LongWait):
 		c.Fatal("timed out waiting for service to be exposed")
 	}
-	s.serviceExposer.CheckCallNames(c, "UnexposeService", "ExposeService")
+	s.serviceExposer.CheckCallNames(c, "EnsureEgressPolicy", "UnexposeService", "ExposeService")
+	s.serviceExposer.CheckCall(c, 2, "ExposeService", "gitlab",
+		map[string]string{
+			"juju-controller-uuid": coretesting.ControllerTag.Id(),
+			"juju-model-uuid":      coretesting.ModelTag.Id()},
+		config.ConfigAttributes{"juju-external-hostname": "exthost", "egress-allow": ""},
+		[]string{"0.0.0.0/0", "::/0"})
+}
+
+func (s *WorkerSuite) TestExposedIngressAllowChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendApplicationChange(c, "gitlab")
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	s.waitExposed(c)
+
+	s.modelConfig.config = s.newModelConfig(c, coretesting.Attrs{
+		environsconfig.ExposedIngressAllowKey: "10.0.0.0/8",
+	})
+	s.sendModelConfigChange(c)
+	s.waitExposed(c)
+
+	// Unchanged allowlists aren't applied again.
+	s.sendModelConfigChange(c)
+	select {
+	case <-s.serviceExposed:
+		c.Fatal("service exposed unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.serviceExposer.CheckCallNames(c, "EnsureEgressPolicy", "ExposeService", "ExposeService")
+	s.serviceExposer.CheckCall(c, 2, "ExposeService", "gitlab",
+		map[string]string{
+			"juju-controller-uuid": coretesting.ControllerTag.Id(),
+			"juju-model-uuid":      coretesting.ModelTag.Id()},
+		config.ConfigAttributes{"juju-external-hostname": "exthost", "egress-allow": ""},
+		[]string{"10.0.0.0/8"})
+}
+
+func (s *WorkerSuite) TestExposedEndpointIngressAllow(c *gc.C) {
+	s.modelConfig.config = s.newModelConfig(c, coretesting.Attrs{
+		environsconfig.ExposedIngressAllowKey:         "10.0.0.0/8",
+		environsconfig.ExposedEndpointIngressAllowKey: "gitlab:http=10.0.0.0/8,192.168.0.0/16 gitlab:ssh=10.1.0.0/16 mysql:db=",
+	})
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendApplicationChange(c, "gitlab")
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	s.waitExposed(c)
+	// The ingress serves all the application's endpoints, so only the
+	// CIDRs allowed for every endpoint with an allowlist are used.
 	s.serviceExposer.CheckCall(c, 1, "ExposeService", "gitlab",
 		map[string]string{
 			"juju-controller-uuid": coretesting.ControllerTag.Id(),
 			"juju-model-uuid":      coretesting.ModelTag.Id()},
-		config.ConfigAttributes{"juju-external-hostname": "exthost"})
+		config.ConfigAttributes{"juju-external-hostname": "exthost", "egress-allow": ""},
+		[]string{"10.1.0.0/16"})
+}
+
+func (s *WorkerSuite) TestExposedNoIngressAllowed(c *gc.C) {
+	s.modelConfig.config = s.newModelConfig(c, coretesting.Attrs{
+		environsconfig.ExposedEndpointIngressAllowKey: "gitlab:http=",
+	})
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendApplicationChange(c, "gitlab")
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+	s.serviceExposer.CheckCallNames(c, "EnsureEgressPolicy", "UnexposeService")
+}
+
+func (s *WorkerSuite) TestEgressChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendApplicationChange(c, "gitlab")
+
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+
+	s.applicationGetter.egress = "10.0.0.0/8 0.0.0.0/0:443"
+	s.sendApplicationExposedChange(c)
+	// Unchanged egress rules aren't set again. Each change is only
+	// received once the one before it has been processed.
+	s.sendApplicationExposedChange(c)
+	s.sendApplicationExposedChange(c)
+	s.serviceExposer.CheckCallNames(c, "EnsureEgressPolicy", "UnexposeService", "EnsureEgressPolicy")
+	s.serviceExposer.CheckCall(c, 2, "EnsureEgressPolicy", "gitlab", firewall.EgressRules{
+		{DestinationCIDR: "0.0.0.0/0", PortRange: network.MustParsePortRange("443/tcp")},
+		{DestinationCIDR: "10.0.0.0/8"},
+	})
 }
 
 func (s *WorkerSuite) TestUnexposedChange(c *gc.C) {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -34,6 +35,7 @@ type applicationWorker struct {
 
 	initial           bool
 	previouslyExposed bool
+	previousEgress    firewall.EgressRules
 
 	currentPorts network.GroupedPortRanges
 
@@ -211,6 +213,9 @@ func (w *applicationWorker) onApplicationChanged() (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := w.onEgressChanged(); err != nil {
+		return errors.Trace(err)
+	}
 	if !w.initial && exposed == w.previouslyExposed {
 		return nil
 	}
@@ -223,6 +228,27 @@ func (w *applicationWorker) onApplicationChanged() (err error) {
 	return errors.Trace(unExposeService(w.serviceUpdater))
 }
 
+// onEgressChanged restricts the outbound traffic of the application to
+// the egress rules in its config, if they changed.
+func (w *applicationWorker) onEgressChanged() error {
+	appConfig, err := w.firewallerAPI.ApplicationConfig(w.appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rules, err := firewall.ParseEgressRules(appConfig.GetString(firewall.EgressAllowKey, ""))
+	if err != nil {
+		return errors.Annotatef(err, "egress rules for application %q", w.appName)
+	}
+	if !w.initial && rules.EqualTo(w.previousEgress) {
+		return nil
+	}
+	if err := w.broker.EnsureEgressPolicy(w.appName, rules); err != nil {
+		return errors.Trace(err)
+	}
+	w.previousEgress = rules
+	return nil
+}
+
 func exposeService(app ServiceUpdater) error {
 	// TODO(sidecar): implement expose once it's modelled.
 	// app.UpdateService()
@@ -15,7 +15,9 @@ import (
 
 	"github.com/juju/juju/caas"
 	caasmocks "github.com/juju/juju/caas/mocks"
+	"github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/testing"
@@ -150,9 +152,16 @@ func (s *appWorkerSuite) TestWorker(c *gc.C) {
 			},
 		}, false).Return(nil),
 
-		s.firewallerAPI.EXPECT().IsExposed(s.appName).DoAndReturn(func(_ string) (bool, error) {
+		s.firewallerAPI.EXPECT().IsExposed(s.appName).Return(false, nil),
+		s.firewallerAPI.EXPECT().ApplicationConfig(s.appName).Return(config.ConfigAttributes{
+			"egress-allow": "10.0.0.0/8 0.0.0.0/0:443",
+		}, nil),
+		s.broker.EXPECT().EnsureEgressPolicy(s.appName, firewall.EgressRules{
+			{DestinationCIDR: "0.0.0.0/0", PortRange: network.MustParsePortRange("443/tcp")},
+			{DestinationCIDR: "10.0.0.0/8"},
+		}).DoAndReturn(func(string, firewall.EgressRules) error {
 			close(done)
-			return false, nil
+			return nil
 		}),
 	)
 
@@ -5,11 +5,13 @@ package caasfirewallersidecar
 
 import (
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/network/firewall"
 )
 
 // CAASBroker exposes CAAS broker functionality to a worker.
 type CAASBroker interface {
 	Application(string, caas.DeploymentType) caas.Application
+	EnsureEgressPolicy(appName string, rules firewall.EgressRules) error
 }
 
 // PortMutator exposes CAAS application functionality to a worker.
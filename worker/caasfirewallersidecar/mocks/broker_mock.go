@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	caas "github.com/juju/juju/caas"
+	firewall "github.com/juju/juju/core/network/firewall"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -53,6 +54,20 @@ func (mr *MockCAASBrokerMockRecorder) Application(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Application", reflect.TypeOf((*MockCAASBroker)(nil).Application), arg0, arg1)
 }
 
+// EnsureEgressPolicy mocks base method.
+func (m *MockCAASBroker) EnsureEgressPolicy(arg0 string, arg1 firewall.EgressRules) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureEgressPolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureEgressPolicy indicates an expected call of EnsureEgressPolicy.
+func (mr *MockCAASBrokerMockRecorder) EnsureEgressPolicy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureEgressPolicy", reflect.TypeOf((*MockCAASBroker)(nil).EnsureEgressPolicy), arg0, arg1)
+}
+
 // MockPortMutator is a mock of PortMutator interface.
 type MockPortMutator struct {
 	ctrl     *gomock.Controller
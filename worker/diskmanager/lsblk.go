@@ -228,6 +228,11 @@ func addHardwareInfo(dev *storage.BlockDevice) error {
 	if wwnWithExtension != "" {
 		dev.WWN = wwnWithExtension
 	}
+	if idBus == "" && strings.HasPrefix(dev.DeviceName, "nvme") {
+		// Older versions of udev don't set ID_BUS for NVMe devices;
+		// the symlink in /dev/disk/by-id is prefixed with "nvme".
+		idBus = "nvme"
+	}
 	if idBus != "" && idSerial != "" {
 		// ID_BUS will be something like "scsi" or "ata";
 		// ID_SERIAL will be something like ${MODEL}_${SERIALNO};
@@ -111,6 +111,15 @@ ID_SERIAL=0980978987987
 `, "sda", storage.BlockDevice{HardwareId: "ata-0980978987987", SerialId: "0980978987987"})
 }
 
+func (s *ListBlockDevicesSuite) TestListBlockDevicesNVMeHardwareId(c *gc.C) {
+	// If ID_BUS isn't set for an NVMe device, we should
+	// still get a HardwareId value.
+	s.testListBlockDevicesExtended(c, `
+DEVPATH=/devices/virtual/nvme-fabrics/ctl/nvme1/nvme1n1
+ID_SERIAL=Linux_0b7a9a3f4e1c2d5b
+`, "nvme1n1", storage.BlockDevice{HardwareId: "nvme-Linux_0b7a9a3f4e1c2d5b", SerialId: "Linux_0b7a9a3f4e1c2d5b"})
+}
+
 func (s *ListBlockDevicesSuite) TestListBlockDevicesSerialId(c *gc.C) {
 	// If ID_SERIAL is found, then we should get
 	// a SerialId value.
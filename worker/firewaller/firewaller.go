@@ -7,6 +7,7 @@ import (
 	stdcontext "context"
 	"io"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/EvilSuperstars/go-cidrman"
@@ -45,6 +46,7 @@ type FirewallerAPI interface {
 	WatchModelFirewallRules() (watcher.NotifyWatcher, error)
 	ModelFirewallRules() (firewall.IngressRules, error)
 	ModelConfig() (*config.Config, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
 	Machine(tag names.MachineTag) (*firewaller.Machine, error)
 	Unit(tag names.UnitTag) (*firewaller.Unit, error)
 	Relation(tag names.RelationTag) (*firewaller.Relation, error)
@@ -163,6 +165,7 @@ type Firewaller struct {
 	portsWatcher         watcher.StringsWatcher
 	subnetWatcher        watcher.StringsWatcher
 	modelFirewallWatcher watcher.NotifyWatcher
+	modelConfigWatcher   watcher.NotifyWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -172,6 +175,15 @@ type Firewaller struct {
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
 
+	// The CIDRs allowed to reach the ports opened by exposed
+	// applications, from the model config.
+	exposedIngressAllow  []string
+	endpointIngressAllow firewall.EndpointIngressAllowlist
+
+	// Fires when egress rules need to be set on machines that weren't
+	// provisioned when their rules were last flushed.
+	egressRetry <-chan time.Time
+
 	// Set to true if the environment supports ingress rules containing
 	// IPV6 CIDRs.
 	envIPV6CIDRSupport bool
@@ -304,10 +316,25 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	fw.modelConfigWatcher, err = fw.firewallerApi.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Annotatef(err, "failed to start model config watcher")
+	}
+	if err := fw.catacomb.Add(fw.modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := fw.readExposedIngressAllow(); err != nil {
+		return errors.Trace(err)
+	}
+
 	fw.logger.Debugf("started watching opened port ranges for the model")
 	return nil
 }
 
+// egressRetryDelay is how long to wait before trying again to set the
+// egress rules of machines that weren't provisioned.
+const egressRetryDelay = 10 * time.Second
+
 func (fw *Firewaller) loop() error {
 	if err := fw.setUp(); err != nil {
 		return errors.Trace(err)
@@ -337,6 +364,13 @@ func (fw *Firewaller) loop() error {
 			} else {
 				ensureModelFirewalls = nil
 			}
+		case <-fw.egressRetry:
+			fw.egressRetry = nil
+			for _, machined := range fw.machineds {
+				if err := fw.flushInstanceEgress(machined); err != nil {
+					return errors.Annotate(err, "cannot set egress rules")
+				}
+			}
 		case _, ok := <-modelFirewallChanges:
 			if !ok {
 				return errors.New("model config watcher closed")
@@ -399,6 +433,14 @@ func (fw *Firewaller) loop() error {
 			if err := fw.subnetsChanged(); err != nil {
 				return errors.Trace(err)
 			}
+		case _, ok := <-fw.modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+
+			if err := fw.exposedIngressAllowChanged(); err != nil {
+				return errors.Trace(err)
+			}
 		case change := <-fw.localRelationsChange:
 			// We have a notification that the remote (consuming) model
 			// has changed egress networks so need to update the local
@@ -413,6 +455,7 @@ func (fw *Firewaller) loop() error {
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
 			change.applicationd.exposedEndpoints = change.exposedEndpoints
+			change.applicationd.egressRules = change.egressRules
 			var unitds []*unitData
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -462,6 +505,43 @@ func (fw *Firewaller) subnetsChanged() error {
 	return nil
 }
 
+// exposedIngressAllowChanged updates the ingress rules of the exposed
+// applications if the model's exposed ingress allowlists have changed.
+func (fw *Firewaller) exposedIngressAllowChanged() error {
+	changed, err := fw.readExposedIngressAllow()
+	if err != nil || !changed {
+		return errors.Trace(err)
+	}
+
+	var unitds []*unitData
+	for _, appd := range fw.applicationids {
+		if !appd.exposed {
+			continue
+		}
+		for _, unitd := range appd.unitds {
+			unitds = append(unitds, unitd)
+		}
+	}
+	return errors.Trace(fw.flushUnits(unitds))
+}
+
+// readExposedIngressAllow reads the CIDRs allowed to reach the ports
+// opened by exposed applications from the model config, and reports
+// whether they have changed.
+func (fw *Firewaller) readExposedIngressAllow() (bool, error) {
+	cfg, err := fw.firewallerApi.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	allow := cfg.ExposedIngressAllow()
+	endpointAllow := cfg.ExposedEndpointIngressAllow()
+	changed := strings.Join(allow, ",") != strings.Join(fw.exposedIngressAllow, ",") ||
+		endpointAllow.String() != fw.endpointIngressAllow.String()
+	fw.exposedIngressAllow = allow
+	fw.endpointIngressAllow = endpointAllow
+	return changed, nil
+}
+
 func (fw *Firewaller) relationIngressChanged(change *remoteRelationNetworkChange) error {
 	fw.logger.Debugf("process remote relation ingress change for %v", change.relationTag)
 	relData, ok := fw.relationIngress[change.relationTag]
@@ -606,11 +686,16 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	egressRules, err := applicationEgressRules(app)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	applicationd := &applicationData{
 		fw:               fw,
 		application:      app,
 		exposed:          exposed,
 		exposedEndpoints: exposedEndpoints,
+		egressRules:      egressRules,
 		unitds:           make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
@@ -618,7 +703,7 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed, exposedEndpoints)
+			return applicationd.watchLoop(exposed, exposedEndpoints, egressRules)
 		},
 	})
 	if err != nil {
@@ -847,7 +932,9 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 	return nil
 }
 
-// flushMachine opens and closes ports for the passed machine.
+// flushMachine opens and closes ports for the passed machine, and in
+// instance mode, sets the machine's egress rules. Egress rules aren't
+// supported in global mode.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
 	want, err := fw.gatherIngressRules(machined)
 	if err != nil {
@@ -858,7 +945,34 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 	if fw.globalMode {
 		return fw.flushGlobalPorts(toOpen, toClose)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	if err := fw.flushInstancePorts(machined, toOpen, toClose); err != nil {
+		return errors.Trace(err)
+	}
+	return fw.flushInstanceEgress(machined)
+}
+
+// gatherEgressRules returns the egress rules for the specified machine,
+// which are the union of the egress rules of the applications of its
+// units. If any of those applications has no egress rules, the machine's
+// outbound traffic is unrestricted and nil is returned.
+func (fw *Firewaller) gatherEgressRules(machined *machineData) firewall.EgressRules {
+	var want firewall.EgressRules
+	for _, unitd := range machined.unitds {
+		rules := unitd.applicationd.egressRules
+		if len(rules) == 0 {
+			return nil
+		}
+		for _, rule := range rules {
+			// See gatherIngressRules for why IPV6 CIDRs are filtered out.
+			if !fw.envIPV6CIDRSupport {
+				if addrType, _ := network.CIDRAddressType(rule.DestinationCIDR); addrType == network.IPv6Address {
+					continue
+				}
+			}
+			want = append(want, rule)
+		}
+	}
+	return want.UniqueRules()
 }
 
 // gatherIngressRules returns the ingress rules to open and close
@@ -939,6 +1053,7 @@ func (fw *Firewaller) ingressRulesForExposedMachineUnit(machine *machineData, un
 	openUnitPortRanges network.GroupedPortRanges) firewall.IngressRules {
 	var (
 		exposedEndpoints = unit.applicationd.exposedEndpoints
+		appName          = unit.applicationd.application.Name()
 		rules            firewall.IngressRules
 	)
 
@@ -976,12 +1091,18 @@ func (fw *Firewaller) ingressRulesForExposedMachineUnit(machine *machineData, un
 		// If this is a named (i.e. not the wildcard) endpoint, look up
 		// the port ranges opened for *all* endpoints as well as for
 		// that endpoint name specifically, and create ingress rules.
+		// The ports opened for all endpoints are restricted by the
+		// model wide allowlist rather than the endpoint's one.
 		if exposedEndpoint != "" {
-			for _, portRange := range openUnitPortRanges[exposedEndpoint] { // ports opened for this endpoint
-				rules = append(rules, firewall.NewIngressRule(portRange, srcCIDRs.Values()...))
+			if allowedCIDRs := fw.allowedIngressCIDRs(appName, exposedEndpoint, srcCIDRs); len(allowedCIDRs) > 0 {
+				for _, portRange := range openUnitPortRanges[exposedEndpoint] { // ports opened for this endpoint
+					rules = append(rules, firewall.NewIngressRule(portRange, allowedCIDRs...))
+				}
 			}
-			for _, portRange := range openUnitPortRanges[""] { // ports opened for ALL endpoints
-				rules = append(rules, firewall.NewIngressRule(portRange, srcCIDRs.Values()...))
+			if allowedCIDRs := fw.allowedIngressCIDRs(appName, "", srcCIDRs); len(allowedCIDRs) > 0 {
+				for _, portRange := range openUnitPortRanges[""] { // ports opened for ALL endpoints
+					rules = append(rules, firewall.NewIngressRule(portRange, allowedCIDRs...))
+				}
 			}
 			continue
 		}
@@ -996,8 +1117,12 @@ func (fw *Firewaller) ingressRulesForExposedMachineUnit(machine *machineData, un
 				continue
 			}
 
+			allowedCIDRs := fw.allowedIngressCIDRs(appName, endpointName, srcCIDRs)
+			if len(allowedCIDRs) == 0 {
+				continue // all ingress is denied by the model's allowlist
+			}
 			for _, portRange := range portRanges {
-				rules = append(rules, firewall.NewIngressRule(portRange, srcCIDRs.Values()...))
+				rules = append(rules, firewall.NewIngressRule(portRange, allowedCIDRs...))
 			}
 		}
 	}
@@ -1005,6 +1130,20 @@ func (fw *Firewaller) ingressRulesForExposedMachineUnit(machine *machineData, un
 	return rules
 }
 
+// allowedIngressCIDRs restricts the CIDRs an application endpoint is
+// exposed to by the model's exposed ingress allowlists. The allowlist
+// for the endpoint is used if there is one; the ports opened for all
+// endpoints (endpoint "") are restricted by the model wide allowlist.
+func (fw *Firewaller) allowedIngressCIDRs(application, endpoint string, cidrs set.Strings) []string {
+	allowed := fw.exposedIngressAllow
+	if endpoint != "" {
+		if endpointAllowed, ok := fw.endpointIngressAllow[firewall.EndpointKey(application, endpoint)]; ok {
+			allowed = endpointAllowed
+		}
+	}
+	return firewall.IntersectCIDRs(cidrs.Values(), allowed)
+}
+
 // TODO(wallyworld) - consider making this configurable.
 const maxAllowedCIDRS = 20
 
@@ -1190,6 +1329,64 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	return nil
 }
 
+// flushInstanceEgress sets the egress rules of the machine's instance, if
+// they changed since they were last set.
+func (fw *Firewaller) flushInstanceEgress(machined *machineData) (err error) {
+	defer func() {
+		if params.IsCodeNotFound(err) {
+			err = nil
+		}
+	}()
+
+	want := fw.gatherEgressRules(machined)
+	if machined.egressApplied && want.EqualTo(machined.egressRules) {
+		return nil
+	}
+	fw.logger.Debugf("flush instance egress: %v", want)
+	m, err := machined.machine()
+	if err != nil {
+		return err
+	}
+	machineId := machined.tag.Id()
+	instanceId, err := m.InstanceId()
+	if errors.IsNotProvisioned(err) {
+		// An instance starts with its outbound traffic unrestricted, so
+		// only come back to it once provisioned if there's a restriction
+		// to set.
+		if len(want) > 0 && fw.egressRetry == nil {
+			fw.egressRetry = fw.clk.After(egressRetryDelay)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ctx := stdcontext.Background()
+	envInstances, err := fw.environInstances.Instances(fw.cloudCallContextFunc(ctx), []instance.Id{instanceId})
+	if err != nil {
+		return err
+	}
+	machined.egressRules, machined.egressApplied = want, true
+	fwInstance, ok := envInstances[0].(instances.InstanceEgressFirewaller)
+	if !ok {
+		if len(want) > 0 {
+			fw.logger.Infof("egress rules %v not enforced on %q: instance of type %T doesn't support them",
+				want, machined.tag, envInstances[0])
+		}
+		return nil
+	}
+	err = fwInstance.SetEgressRules(fw.cloudCallContextFunc(ctx), machineId, want)
+	if errors.IsNotSupported(err) {
+		fw.logger.Warningf("egress rules %v not enforced on %q: %v", want, machined.tag, err)
+		return nil
+	} else if err != nil {
+		machined.egressApplied = false
+		return err
+	}
+	fw.logger.Infof("set egress rules %v on %q", want, machined.tag)
+	return nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -1286,6 +1483,10 @@ type machineData struct {
 	ingressRules firewall.IngressRules
 	// ports defined by units on this machine
 	openedPortRangesByEndpoint map[names.UnitTag]network.GroupedPortRanges
+	// the egress rules last set on the machine's instance, and whether
+	// they have been set at all
+	egressRules   firewall.EgressRules
+	egressApplied bool
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
@@ -1333,25 +1534,42 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and egress rules for one
+// specific application.
 type exposedChange struct {
 	applicationd     *applicationData
 	exposed          bool
 	exposedEndpoints map[string]params.ExposedEndpoint
+	egressRules      firewall.EgressRules
 }
 
-// applicationData holds application details and watches exposure changes.
+// applicationData holds application details and watches exposure and
+// egress rule changes.
 type applicationData struct {
 	catacomb         catacomb.Catacomb
 	fw               *Firewaller
 	application      *firewaller.Application
 	exposed          bool
 	exposedEndpoints map[string]params.ExposedEndpoint
+	egressRules      firewall.EgressRules
 	unitds           map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(curExposed bool, curExposedEndpoints map[string]params.ExposedEndpoint) error {
+// applicationEgressRules returns the egress rules of the application,
+// treating a controller that doesn't support them as having none.
+func applicationEgressRules(app *firewaller.Application) (firewall.EgressRules, error) {
+	rules, err := app.EgressRules()
+	if errors.IsNotSupported(err) {
+		return nil, nil
+	}
+	return rules, errors.Trace(err)
+}
+
+// watchLoop watches the application's exposed flag and egress rules for
+// changes.
+func (ad *applicationData) watchLoop(
+	curExposed bool, curExposedEndpoints map[string]params.ExposedEndpoint, curEgressRules firewall.EgressRules,
+) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1378,19 +1596,28 @@ func (ad *applicationData) watchLoop(curExposed bool, curExposedEndpoints map[st
 				}
 				return errors.Trace(err)
 			}
-			if curExposed == newExposed && equalExposedEndpoints(curExposedEndpoints, newExposedEndpoints) {
-				ad.fw.logger.Tracef("application(%q) expose settings unchanged: exposed: %v, exposedEndpoints: %v",
-					ad.application.Name(), curExposed, curExposedEndpoints)
+			newEgressRules, err := applicationEgressRules(ad.application)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					ad.fw.logger.Debugf("application(%q).EgressRules() returned NotFound: %v", ad.application.Name(), err)
+					return nil
+				}
+				return errors.Trace(err)
+			}
+			if curExposed == newExposed && equalExposedEndpoints(curExposedEndpoints, newExposedEndpoints) &&
+				curEgressRules.EqualTo(newEgressRules) {
+				ad.fw.logger.Tracef("application(%q) expose settings unchanged: exposed: %v, exposedEndpoints: %v, egressRules: %v",
+					ad.application.Name(), curExposed, curExposedEndpoints, curEgressRules)
 				continue
 			}
-			ad.fw.logger.Tracef("application(%q) expose settings changed: exposed: %v, exposedEndpoints: %v",
-				ad.application.Name(), newExposed, newExposedEndpoints)
+			ad.fw.logger.Tracef("application(%q) expose settings changed: exposed: %v, exposedEndpoints: %v, egressRules: %v",
+				ad.application.Name(), newExposed, newExposedEndpoints, newEgressRules)
 
-			curExposed, curExposedEndpoints = newExposed, newExposedEndpoints
+			curExposed, curExposedEndpoints, curEgressRules = newExposed, newExposedEndpoints, newEgressRules
 			select {
 			case <-ad.catacomb.Dying():
 				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, newExposed, newExposedEndpoints}:
+			case ad.fw.exposedChange <- &exposedChange{ad, newExposed, newExposedEndpoints, newEgressRules}:
 			}
 		}
 	}
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/utils/v3"
 	"github.com/juju/worker/v3"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/agent/credentialvalidator"
@@ -29,6 +30,8 @@ import (
 	apifirewaller "github.com/juju/juju/api/controller/firewaller"
 	"github.com/juju/juju/api/controller/remoterelations"
 	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/apiserver/common"
+	coreconfig "github.com/juju/juju/core/config"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
@@ -138,6 +141,34 @@ func (s *firewallerBaseSuite) assertIngressRules(c *gc.C, inst instances.Instanc
 	}
 }
 
+// assertEgressRules retrieves the egress rules set on the instance and
+// compares them to the expected.
+func (s *firewallerBaseSuite) assertEgressRules(c *gc.C, inst instances.Instance, machineId string,
+	expected firewall.EgressRules) {
+	fwInst, ok := inst.(interface {
+		EgressRules(context.ProviderCallContext, string) (firewall.EgressRules, error)
+	})
+	c.Assert(ok, gc.Equals, true)
+
+	start := time.Now()
+	for {
+		time.Sleep(coretesting.ShortWait)
+
+		got, err := fwInst.EgressRules(s.callCtx, machineId)
+		if err != nil {
+			c.Fatal(err)
+		}
+		if got.EqualTo(expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
 // assertEnvironPorts retrieves the open ports of environment and compares them
 // to the expected.
 func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected firewall.IngressRules) {
@@ -475,6 +506,40 @@ func (s *InstanceModeSuite) TestMultipleUnits(c *gc.C) {
 	s.assertIngressRules(c, inst2, m2.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestEgressRules(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	_, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	setEgress := func(value string) {
+		err := app.UpdateApplicationConfig(coreconfig.ConfigAttributes{
+			firewall.EgressAllowKey: value,
+		}, nil, environschema.Fields{
+			firewall.EgressAllowKey: {Type: environschema.Tstring},
+		}, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// The essential rules for reaching DNS, the metadata service and the
+	// controller API are always added.
+	controllerAddrs, _, err := common.StateControllerInfo(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	expected, err := firewall.EgressRules{
+		{DestinationCIDR: "0.0.0.0/0", PortRange: network.MustParsePortRange("443/tcp")},
+		{DestinationCIDR: "10.0.0.0/8"},
+	}.WithEssentials(controllerAddrs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	setEgress("10.0.0.0/8 0.0.0.0/0:443")
+	s.assertEgressRules(c, inst, m.Id(), expected)
+
+	setEgress("")
+	s.assertEgressRules(c, inst, m.Id(), nil)
+}
+
 func (s *InstanceModeSuite) TestStartWithState(c *gc.C) {
 	app := s.AddTestingApplication(c, "wordpress", s.charm)
 	err := app.MergeExposeSettings(map[string]state.ExposedEndpoint{
@@ -1567,6 +1632,110 @@ func (s *InstanceModeSuite) TestExposedApplicationWithExposedEndpoints(c *gc.C)
 	})
 }
 
+func (s *InstanceModeSuite) TestExposedApplicationRestrictedByIngressAllowlist(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	mustOpenPortRanges(c, s.State, u, allEndpoints, []network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+	})
+	mustOpenPortRanges(c, s.State, u, "url", []network.PortRange{
+		network.MustParsePortRange("1337/tcp"),
+	})
+
+	err := app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		allEndpoints: {
+			ExposeToCIDRs: []string{firewall.AllNetworksIPV4CIDR},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR),
+		firewall.NewIngressRule(network.MustParsePortRange("1337/tcp"), firewall.AllNetworksIPV4CIDR),
+	})
+
+	// Restrict ingress to all exposed ports, and replace the model
+	// wide allowlist for the "url" endpoint.
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.UpdateModelConfig(map[string]interface{}{
+		config.ExposedIngressAllowKey:         "10.0.0.0/8,192.168.0.0/16",
+		config.ExposedEndpointIngressAllowKey: "wordpress:url=10.1.0.0/16",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8", "192.168.0.0/16"),
+		firewall.NewIngressRule(network.MustParsePortRange("1337/tcp"), "10.1.0.0/16"),
+	})
+
+	// An endpoint allowlist with no CIDRs denies all ingress.
+	err = model.UpdateModelConfig(map[string]interface{}{
+		config.ExposedEndpointIngressAllowKey: "wordpress:url=",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8", "192.168.0.0/16"),
+	})
+}
+
+func (s *InstanceModeSuite) TestExposedEndpointAllowlistDoesNotApplyToAllEndpointPorts(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	mustOpenPortRanges(c, s.State, u, allEndpoints, []network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+	})
+	mustOpenPortRanges(c, s.State, u, "url", []network.PortRange{
+		network.MustParsePortRange("1337/tcp"),
+	})
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.UpdateModelConfig(map[string]interface{}{
+		config.ExposedIngressAllowKey:         "10.0.0.0/8",
+		config.ExposedEndpointIngressAllowKey: "wordpress:url=10.1.0.0/16",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the "url" endpoint is exposed, but the ports opened for all
+	// endpoints are still restricted by the model wide allowlist.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {
+			ExposeToCIDRs: []string{firewall.AllNetworksIPV4CIDR},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		firewall.NewIngressRule(network.MustParsePortRange("1337/tcp"), "10.1.0.0/16"),
+	})
+
+	// Denying all ingress to the endpoint leaves the ports opened for
+	// all endpoints.
+	err = model.UpdateModelConfig(map[string]interface{}{
+		config.ExposedEndpointIngressAllowKey: "wordpress:url=",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+	})
+}
+
 func (s *InstanceModeSuite) TestExposedApplicationWithExposedEndpointsWhenSpaceTopologyChanges(c *gc.C) {
 	// Create two spaces and add a subnet to each one
 	sp1, err := s.State.AddSpace("space1", network.Id("sp-1"), nil, false)
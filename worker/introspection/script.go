@@ -156,6 +156,11 @@ juju_presence_report () {
   juju_agent presence
 }
 
+juju_slow_queries () {
+  local top=${1:-10}
+  juju_agent "slowqueries?top=$top"
+}
+
 juju_statetracker_report () {
   juju_agent debug/pprof/juju/state/tracker?debug=1
 }
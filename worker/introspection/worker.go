@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"runtime"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -42,6 +43,13 @@ type Reporter interface {
 	IntrospectionReport() string
 }
 
+// SlowQueryReporter provides a report of the slowest database statements
+// seen by the agent.
+type SlowQueryReporter interface {
+	// SlowQueryReport returns a report of the top n slow statements.
+	SlowQueryReport(n int) string
+}
+
 // Clock represents the ability to wait for a bit.
 type Clock interface {
 	Now() time.Time
@@ -70,6 +78,7 @@ type Config struct {
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	Presence           presence.Recorder
+	SlowQueries        SlowQueryReporter
 	Clock              Clock
 	LocalHub           SimpleHub
 	CentralHub         StructuredHub
@@ -99,6 +108,7 @@ type socketListener struct {
 	machineLock        machinelock.Lock
 	prometheusGatherer prometheus.Gatherer
 	presence           presence.Recorder
+	slowQueries        SlowQueryReporter
 	clock              Clock
 	localHub           SimpleHub
 	centralHub         StructuredHub
@@ -132,6 +142,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		machineLock:        config.MachineLock,
 		prometheusGatherer: config.PrometheusGatherer,
 		presence:           config.Presence,
+		slowQueries:        config.SlowQueries,
 		clock:              config.Clock,
 		localHub:           config.LocalHub,
 		centralHub:         config.CentralHub,
@@ -216,6 +227,11 @@ func (w *socketListener) RegisterHTTPHandlers(
 	} else {
 		handle("/presence", notSupportedHandler{"Presence"})
 	}
+	if w.slowQueries != nil {
+		handle("/slowqueries", slowQueriesHandler{w.slowQueries})
+	} else {
+		handle("/slowqueries", notSupportedHandler{"Slow Queries"})
+	}
 	if w.localHub != nil {
 		handle("/units", unitsHandler{w.clock, w.localHub, w.done})
 	} else {
@@ -306,6 +322,32 @@ func (h introspectionReporterHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 	fmt.Fprint(w, h.reporter.IntrospectionReport())
 }
 
+// defaultSlowQueries is the number of statements reported by the slow
+// queries handler, if the request doesn't specify the top value.
+const defaultSlowQueries = 10
+
+type slowQueriesHandler struct {
+	reporter SlowQueryReporter
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h slowQueriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	top := defaultSlowQueries
+	if v := r.URL.Query().Get("top"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, fmt.Sprintf("top value %q not valid", v), http.StatusBadRequest)
+			return
+		}
+		top = n
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	fmt.Fprint(w, "Slow Query Report:\n\n")
+	fmt.Fprint(w, h.reporter.SlowQueryReport(top))
+}
+
 type presenceHandler struct {
 	presence presence.Recorder
 }
@@ -77,6 +77,7 @@ type introspectionSuite struct {
 	reporter   introspection.DepEngineReporter
 	gatherer   prometheus.Gatherer
 	recorder   presence.Recorder
+	queries    introspection.SlowQueryReporter
 	localHub   *pubsub.SimpleHub
 	centralHub introspection.StructuredHub
 	clock      *testclock.Clock
@@ -92,6 +93,7 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 	s.reporter = nil
 	s.worker = nil
 	s.recorder = nil
+	s.queries = nil
 	s.gatherer = newPrometheusGatherer()
 	s.localHub = pubsub.NewSimpleHub(&pubsub.SimpleHubConfig{Logger: loggo.GetLogger("test.localhub")})
 	s.centralHub = pubsub.NewStructuredHub(&pubsub.StructuredHubConfig{Logger: loggo.GetLogger("test.centralhub")})
@@ -106,6 +108,7 @@ func (s *introspectionSuite) startWorker(c *gc.C) {
 		DepEngine:          s.reporter,
 		PrometheusGatherer: s.gatherer,
 		Presence:           s.recorder,
+		SlowQueries:        s.queries,
 		Clock:              s.clock,
 		LocalHub:           s.localHub,
 		CentralHub:         s.centralHub,
@@ -263,6 +266,41 @@ agent-1  server  42       alive
 `[1:])
 }
 
+func (s *introspectionSuite) TestMissingSlowQueryReporter(c *gc.C) {
+	response := s.call(c, "/slowqueries")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusNotFound)
+	s.assertBody(c, response, `"Slow Queries" introspection not supported`)
+}
+
+func (s *introspectionSuite) TestSlowQueryReporter(c *gc.C) {
+	// We need to make sure the existing worker is shut down
+	// so we can connect to the socket.
+	workertest.CheckKill(c, s.worker)
+	s.queries = slowQueryReporter{}
+	s.startWorker(c)
+
+	response := s.call(c, "/slowqueries")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
+	s.assertBody(c, response, `
+Slow Query Report:
+
+top 10 slow queries`[1:])
+
+	response = s.call(c, "/slowqueries?top=3")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
+	s.assertBodyContains(c, response, "top 3 slow queries")
+}
+
+func (s *introspectionSuite) TestSlowQueryReporterInvalidTop(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.queries = slowQueryReporter{}
+	s.startWorker(c)
+
+	response := s.call(c, "/slowqueries?top=many")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusBadRequest)
+	s.assertBody(c, response, `top value "many" not valid`)
+}
+
 func (s *introspectionSuite) TestPrometheusMetrics(c *gc.C) {
 	response := s.call(c, "/metrics")
 	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
@@ -381,6 +419,12 @@ func (r *reporter) Report() map[string]interface{} {
 	return r.values
 }
 
+type slowQueryReporter struct{}
+
+func (slowQueryReporter) SlowQueryReport(n int) string {
+	return fmt.Sprintf("top %d slow queries\n", n)
+}
+
 func newPrometheusGatherer() prometheus.Gatherer {
 	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "tau", Help: "Tau."})
 	counter.Add(6.283185)
@@ -81,7 +81,7 @@ type OpenLogForwarderArgs struct {
 	Logger Logger
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -150,7 +150,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		lf.args.Logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		lf.args.Logger.Infof("log forward enabled, starting to stream logs")
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -235,7 +235,7 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
 	"github.com/juju/juju/rpc/params"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
@@ -85,8 +86,8 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		OpenSink: func(cfg *target.RawConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.Syslog.Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -249,13 +250,15 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
+func (c *mockLogForwardConfig) LogForwardConfig() (*target.RawConfig, bool, error) {
+	return &target.RawConfig{
+		Enabled: c.enabled,
+		Syslog: syslog.RawConfig{
+			Host:       c.host,
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
 	}, true, nil
 }
 
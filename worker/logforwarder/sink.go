@@ -5,7 +5,7 @@ package logforwarder
 
 import (
 	"github.com/juju/juju/core/watcher"
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
 )
 
 // LogForwardConfig provides access to the log forwarding config for a model.
@@ -15,7 +15,7 @@ type LogForwardConfig interface {
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
 	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	LogForwardConfig() (*target.RawConfig, bool, error)
 }
 
 type LogSinkSpec struct {
@@ -27,7 +27,7 @@ type LogSinkSpec struct {
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg *target.RawConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
@@ -0,0 +1,27 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/httpsink"
+	"github.com/juju/juju/logfwd/target"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenHTTP returns a sink used to receive log messages to be forwarded
+// to an OTLP or Loki compatible HTTP endpoint.
+func OpenHTTP(cfg *target.RawConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := httpsink.Open(cfg.HTTPConfig())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
@@ -0,0 +1,23 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/target"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// Open returns a sink used to receive log messages to be forwarded,
+// using the sink implementation for the configured target type.
+func Open(cfg *target.RawConfig) (*logforwarder.LogSink, error) {
+	switch cfg.TargetType() {
+	case target.Syslog:
+		return OpenSyslog(cfg)
+	case target.OTLP, target.Loki:
+		return OpenHTTP(cfg)
+	}
+	return nil, errors.NotValidf("log forward target type %q", cfg.Type)
+}
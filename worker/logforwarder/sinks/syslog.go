@@ -8,15 +8,17 @@ import (
 
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
 	"github.com/juju/juju/worker/logforwarder"
 )
 
-// OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+// OpenSyslog returns a sink used to receive log messages to be forwarded
+// to a syslog server.
+func OpenSyslog(cfg *target.RawConfig) (*logforwarder.LogSink, error) {
 	if !cfg.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
-	client, err := syslog.Open(*cfg)
+	client, err := syslog.Open(cfg.SyslogConfig())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -0,0 +1,195 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migrationplanner
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/dependency"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/common"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// DefaultInterval is how often migration plans are checked for
+// migrations to start or follow up.
+const DefaultInterval = 30 * time.Second
+
+const (
+	// DefaultRetryDelay is how long to wait before retrying a model's
+	// migration after its first failure.
+	DefaultRetryDelay = 5 * time.Minute
+
+	// DefaultMaxRetryDelay is the longest wait between attempts to
+	// migrate a model.
+	DefaultMaxRetryDelay = time.Hour
+)
+
+// PrecheckMigrationFunc runs the prechecks for migrating the model of
+// st to the target controller, updating targetInfo with details
+// retrieved from the target as needed. The leaders map holds the
+// leader unit of each of the model's applications.
+type PrecheckMigrationFunc func(
+	st, ctlrSt *state.State, targetInfo *migration.TargetInfo, leaders map[string]string,
+) error
+
+// ManifoldConfig holds the information needed to run a migration
+// planner worker in a dependency.Engine.
+type ManifoldConfig struct {
+	StateName         string
+	LeaseManagerName  string
+	Clock             clock.Clock
+	Logger            Logger
+	PrecheckMigration PrecheckMigrationFunc
+	NewWorker         func(Config) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.LeaseManagerName == "" {
+		return errors.NotValidf("empty LeaseManagerName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.PrecheckMigration == nil {
+		return errors.NotValidf("nil PrecheckMigration")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold to run a migration planner
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.StateName,
+			config.LeaseManagerName,
+		},
+		Start: config.start,
+	}
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var leaseManager lease.Manager
+	if err := context.Get(config.LeaseManagerName, &leaseManager); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	st, err := statePool.SystemState()
+	if err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+
+	w, err := config.NewWorker(Config{
+		Backend: stateBackend{
+			pool:         statePool,
+			st:           st,
+			leaseManager: leaseManager,
+			precheck:     config.PrecheckMigration,
+		},
+		Clock:         config.Clock,
+		Logger:        config.Logger,
+		Interval:      DefaultInterval,
+		RetryDelay:    DefaultRetryDelay,
+		MaxRetryDelay: DefaultMaxRetryDelay,
+	})
+	if err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, func() { _ = stTracker.Done() }), nil
+}
+
+// stateBackend implements Backend using the controller's state.
+type stateBackend struct {
+	pool         *state.StatePool
+	st           *state.State
+	leaseManager lease.Manager
+	precheck     PrecheckMigrationFunc
+}
+
+// MigrationPlans is part of the Backend interface.
+func (b stateBackend) MigrationPlans() ([]MigrationPlan, error) {
+	plans, err := b.st.AllMigrationPlans()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]MigrationPlan, len(plans))
+	for i, plan := range plans {
+		result[i] = plan
+	}
+	return result, nil
+}
+
+// MigrationStatus is part of the Backend interface.
+func (b stateBackend) MigrationStatus(migrationId string) (migration.Phase, string, error) {
+	mig, err := b.st.Migration(migrationId)
+	if err != nil {
+		return migration.UNKNOWN, "", errors.Trace(err)
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return migration.UNKNOWN, "", errors.Trace(err)
+	}
+	return phase, mig.StatusMessage(), nil
+}
+
+// StartMigration is part of the Backend interface.
+func (b stateBackend) StartMigration(modelUUID string, spec state.MigrationSpec) (string, error) {
+	st, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer st.Release()
+
+	// Run the same checks as a migration initiated through the API,
+	// so that a model which cannot be migrated is not exported.
+	reader, err := b.leaseManager.Reader(lease.ApplicationLeadershipNamespace, modelUUID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	leaders, err := reader.Leases()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := b.precheck(st.State, b.st, &spec.TargetInfo, leaders); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	mig, err := st.CreateMigration(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return mig.Id(), nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migrationplanner_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/dependency"
+	dt "github.com/juju/worker/v3/dependency/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/migrationplanner"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+
+	config migrationplanner.ManifoldConfig
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = migrationplanner.ManifoldConfig{
+		StateName:        "state",
+		LeaseManagerName: "lease-manager",
+		Clock:            testclock.NewClock(time.Time{}),
+		Logger:           loggo.GetLogger("test"),
+		PrecheckMigration: func(*state.State, *state.State, *migration.TargetInfo, map[string]string) error {
+			return errors.New("unexpected call")
+		},
+		NewWorker: func(migrationplanner.Config) (worker.Worker, error) {
+			return nil, errors.New("unexpected call")
+		},
+	}
+}
+
+func (s *manifoldSuite) TestInputs(c *gc.C) {
+	manifold := migrationplanner.Manifold(s.config)
+	c.Assert(manifold.Inputs, jc.SameContents, []string{"state", "lease-manager"})
+}
+
+func (s *manifoldSuite) TestValidate(c *gc.C) {
+	c.Assert(s.config.Validate(), jc.ErrorIsNil)
+
+	tests := []struct {
+		f      func(*migrationplanner.ManifoldConfig)
+		expect string
+	}{{
+		func(cfg *migrationplanner.ManifoldConfig) { cfg.StateName = "" },
+		"empty StateName not valid",
+	}, {
+		func(cfg *migrationplanner.ManifoldConfig) { cfg.LeaseManagerName = "" },
+		"empty LeaseManagerName not valid",
+	}, {
+		func(cfg *migrationplanner.ManifoldConfig) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *migrationplanner.ManifoldConfig) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *migrationplanner.ManifoldConfig) { cfg.PrecheckMigration = nil },
+		"nil PrecheckMigration not valid",
+	}, {
+		func(cfg *migrationplanner.ManifoldConfig) { cfg.NewWorker = nil },
+		"nil NewWorker not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.expect)
+		config := s.config
+		test.f(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *manifoldSuite) TestMissingState(c *gc.C) {
+	manifold := migrationplanner.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"state":         dependency.ErrMissing,
+		"lease-manager": &fakeLeaseManager{},
+	})
+	_, err := manifold.Start(context)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (s *manifoldSuite) TestMissingLeaseManager(c *gc.C) {
+	manifold := migrationplanner.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"lease-manager": dependency.ErrMissing,
+	})
+	_, err := manifold.Start(context)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+type fakeLeaseManager struct {
+	lease.Manager
+}
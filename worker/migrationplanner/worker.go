@@ -0,0 +1,311 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migrationplanner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/retry"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// MigrationPlan describes the methods of a migration plan used by the
+// worker.
+type MigrationPlan interface {
+	Id() string
+	InitiatedBy() string
+	TargetInfo() (*migration.TargetInfo, error)
+	Concurrency() int
+	MaxAttempts() int
+	WindowStart() time.Time
+	WindowEnd() time.Time
+	Models() []state.MigrationPlanModel
+	Completed() bool
+	Cancelled() bool
+	SetModelProgress(state.MigrationPlanModel) error
+}
+
+// Backend provides the migration plans and the means to start and
+// follow the migrations they call for.
+type Backend interface {
+	// MigrationPlans returns all of the controller's migration plans.
+	MigrationPlans() ([]MigrationPlan, error)
+
+	// MigrationStatus returns the phase and status message of the
+	// migration with the given ID.
+	MigrationStatus(migrationId string) (migration.Phase, string, error)
+
+	// StartMigration checks that the model with the given UUID can be
+	// migrated and starts migrating it, returning the ID of the new
+	// migration.
+	StartMigration(modelUUID string, spec state.MigrationSpec) (string, error)
+}
+
+// Config holds the configuration and dependencies for the worker.
+type Config struct {
+	Backend  Backend
+	Clock    clock.Clock
+	Logger   Logger
+	Interval time.Duration
+
+	// RetryDelay is how long to wait before retrying a model's
+	// migration after its first failure. The delay doubles with each
+	// further failure, up to MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// Validate returns an error if the config cannot be expected
+// to drive a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.MaxRetryDelay < config.RetryDelay {
+		return errors.NotValidf("MaxRetryDelay less than RetryDelay")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically works through the
+// controller's migration plans, starting migrations as each plan's
+// concurrency limit and time window allow, and retrying those which
+// fail after an increasing delay.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &plannerWorker{
+		config: config,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type plannerWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *plannerWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *plannerWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *plannerWorker) loop() error {
+	// Check once on startup, then on every interval.
+	var timeout <-chan time.Time
+	for {
+		if err := w.advance(); err != nil {
+			return errors.Trace(err)
+		}
+		timeout = w.config.Clock.After(w.config.Interval)
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timeout:
+		}
+	}
+}
+
+// advance takes the next steps of every incomplete plan. A failure to
+// advance one plan is logged and retried at the next interval, without
+// holding up the other plans.
+func (w *plannerWorker) advance() error {
+	plans, err := w.config.Backend.MigrationPlans()
+	if err != nil {
+		return errors.Annotate(err, "getting migration plans")
+	}
+	for _, plan := range plans {
+		if plan.Completed() {
+			continue
+		}
+		if err := w.advancePlan(plan); err != nil {
+			w.config.Logger.Errorf("cannot advance migration plan %s: %v", plan.Id(), err)
+		}
+	}
+	return nil
+}
+
+func (w *plannerWorker) advancePlan(plan MigrationPlan) error {
+	models := plan.Models()
+
+	// Catch up with the migrations already started.
+	running := 0
+	for i, model := range models {
+		if model.Status != state.MigrationPlanRunning {
+			continue
+		}
+		progress, err := w.checkMigration(plan, model)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if progress.Status == state.MigrationPlanRunning {
+			running++
+		}
+		models[i] = progress
+	}
+	if plan.Cancelled() {
+		// Queued models were cancelled with the plan; only the
+		// migrations already running are followed.
+		return nil
+	}
+
+	now := w.config.Clock.Now()
+	if end := plan.WindowEnd(); !end.IsZero() && !now.Before(end) {
+		// No more migrations will be started, so give up on any
+		// models still waiting.
+		for _, model := range models {
+			if model.Status != state.MigrationPlanQueued {
+				continue
+			}
+			model.Status = state.MigrationPlanFailed
+			model.RetryAfter = time.Time{}
+			if model.Message == "" {
+				model.Message = "migration window closed"
+			} else {
+				model.Message = fmt.Sprintf("migration window closed (%s)", model.Message)
+			}
+			if err := w.setProgress(plan, model); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
+	if start := plan.WindowStart(); !start.IsZero() && now.Before(start) {
+		return nil
+	}
+
+	var spec *state.MigrationSpec
+	for _, model := range models {
+		if running >= plan.Concurrency() {
+			break
+		}
+		if model.Status != state.MigrationPlanQueued || now.Before(model.RetryAfter) {
+			continue
+		}
+		if spec == nil {
+			targetInfo, err := plan.TargetInfo()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			spec = &state.MigrationSpec{
+				InitiatedBy: names.NewUserTag(plan.InitiatedBy()),
+				TargetInfo:  *targetInfo,
+			}
+		}
+
+		model.Attempts++
+		migrationId, err := w.config.Backend.StartMigration(model.ModelUUID, *spec)
+		switch {
+		case errors.IsNotFound(err):
+			model.Status = state.MigrationPlanFailed
+			model.Message = err.Error()
+		case err != nil:
+			w.config.Logger.Warningf("cannot start migration of model %q: %v", model.ModelUUID, err)
+			model.Message = err.Error()
+			w.retryOrFail(plan, &model)
+		default:
+			w.config.Logger.Infof("started migration %q for plan %s", migrationId, plan.Id())
+			model.Status = state.MigrationPlanRunning
+			model.MigrationId = migrationId
+			model.RetryAfter = time.Time{}
+			running++
+		}
+		if err := w.setProgress(plan, model); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// checkMigration records the outcome of the model's most recent
+// migration, if it has finished, and returns the model's progress.
+func (w *plannerWorker) checkMigration(plan MigrationPlan, model state.MigrationPlanModel) (state.MigrationPlanModel, error) {
+	phase, message, err := w.config.Backend.MigrationStatus(model.MigrationId)
+	if errors.IsNotFound(err) {
+		phase, message = migration.ABORTDONE, "migration not found"
+	} else if err != nil {
+		return model, errors.Trace(err)
+	}
+
+	switch phase {
+	case migration.DONE:
+		w.config.Logger.Infof("migration %q for plan %s succeeded", model.MigrationId, plan.Id())
+		model.Status = state.MigrationPlanSucceeded
+		model.Message = ""
+	case migration.ABORTDONE:
+		w.config.Logger.Infof("migration %q for plan %s aborted: %s", model.MigrationId, plan.Id(), message)
+		model.Message = message
+		if plan.Cancelled() {
+			model.Status = state.MigrationPlanCancelled
+		} else {
+			model.Status = state.MigrationPlanQueued
+			w.retryOrFail(plan, &model)
+		}
+	default:
+		return model, nil
+	}
+	return model, errors.Trace(w.setProgress(plan, model))
+}
+
+// retryOrFail marks the model as failed if it has used up its
+// attempts, or otherwise holds off its next attempt for a delay which
+// grows with each failure.
+func (w *plannerWorker) retryOrFail(plan MigrationPlan, model *state.MigrationPlanModel) {
+	if model.Attempts >= plan.MaxAttempts() {
+		model.Status = state.MigrationPlanFailed
+		model.RetryAfter = time.Time{}
+		return
+	}
+	backoff := retry.ExpBackoff(w.config.RetryDelay, w.config.MaxRetryDelay, 2, false)
+	delay := backoff(0, model.Attempts-1)
+	model.RetryAfter = w.config.Clock.Now().Add(delay)
+	w.config.Logger.Debugf("retrying migration of model %q in %s", model.ModelUUID, delay)
+}
+
+func (w *plannerWorker) setProgress(plan MigrationPlan, model state.MigrationPlanModel) error {
+	if err := plan.SetModelProgress(model); err != nil {
+		return errors.Annotatef(err, "recording progress of model %q", model.ModelUUID)
+	}
+	return nil
+}
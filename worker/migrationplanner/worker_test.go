@@ -0,0 +1,560 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migrationplanner_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/migrationplanner"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	backend *fakeBackend
+	plan    *fakePlan
+	config  migrationplanner.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.plan = &fakePlan{
+		concurrency: 2,
+		maxAttempts: 2,
+		models: []state.MigrationPlanModel{
+			{ModelUUID: "uuid-1", Status: state.MigrationPlanQueued},
+			{ModelUUID: "uuid-2", Status: state.MigrationPlanQueued},
+			{ModelUUID: "uuid-3", Status: state.MigrationPlanQueued},
+		},
+		progress: make(chan state.MigrationPlanModel, 10),
+	}
+	s.backend = &fakeBackend{
+		plans:    []migrationplanner.MigrationPlan{s.plan},
+		phases:   make(map[string]migration.Phase),
+		startErr: make(map[string]error),
+		started:  make(map[string]int),
+	}
+	s.config = migrationplanner.Config{
+		Backend:       s.backend,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+		Interval:      time.Minute,
+		RetryDelay:    5 * time.Minute,
+		MaxRetryDelay: time.Hour,
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		f      func(*migrationplanner.Config)
+		expect string
+	}{{
+		func(cfg *migrationplanner.Config) { cfg.Backend = nil },
+		"nil Backend not valid",
+	}, {
+		func(cfg *migrationplanner.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *migrationplanner.Config) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *migrationplanner.Config) { cfg.Interval = 0 },
+		"non-positive Interval not valid",
+	}, {
+		func(cfg *migrationplanner.Config) { cfg.RetryDelay = 0 },
+		"non-positive RetryDelay not valid",
+	}, {
+		func(cfg *migrationplanner.Config) { cfg.MaxRetryDelay = time.Minute },
+		"MaxRetryDelay less than RetryDelay not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.expect)
+		config := s.config
+		test.f(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *workerSuite) TestStartsUpToConcurrency(c *gc.C) {
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-1:0",
+	}, state.MigrationPlanModel{
+		ModelUUID:   "uuid-2",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-2:0",
+	})
+	c.Assert(s.backend.specs, gc.HasLen, 2)
+	c.Check(s.backend.specs[0], jc.DeepEquals, state.MigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo:  migration.TargetInfo{ControllerAlias: "target"},
+	})
+
+	// The third model waits for a migration to finish.
+	s.backend.setPhase("uuid-1:0", migration.DONE)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanSucceeded,
+		Attempts:    1,
+		MigrationId: "uuid-1:0",
+	}, state.MigrationPlanModel{
+		ModelUUID:   "uuid-3",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-3:0",
+	})
+}
+
+func (s *workerSuite) TestRetriesAbortedMigration(c *gc.C) {
+	s.plan.models = []state.MigrationPlanModel{{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-1:0",
+	}}
+	s.backend.phases["uuid-1:0"] = migration.ABORTDONE
+	s.backend.started["uuid-1"] = 1
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanQueued,
+		Attempts:    1,
+		MigrationId: "uuid-1:0",
+		Message:     "aborted",
+		RetryAfter:  s.clock.Now().Add(5 * time.Minute),
+	})
+
+	// The migration is only retried once the delay has passed.
+	err = s.clock.WaitAdvance(4*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoProgress(c)
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    2,
+		MigrationId: "uuid-1:1",
+		Message:     "aborted",
+	})
+}
+
+func (s *workerSuite) TestRetryDelayGrows(c *gc.C) {
+	s.plan.maxAttempts = 10
+	s.plan.models = []state.MigrationPlanModel{{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    3,
+		MigrationId: "uuid-1:2",
+	}, {
+		ModelUUID:   "uuid-2",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    8,
+		MigrationId: "uuid-2:7",
+	}}
+	s.backend.phases["uuid-1:2"] = migration.ABORTDONE
+	s.backend.phases["uuid-2:7"] = migration.ABORTDONE
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanQueued,
+		Attempts:    3,
+		MigrationId: "uuid-1:2",
+		Message:     "aborted",
+		RetryAfter:  s.clock.Now().Add(20 * time.Minute),
+	}, state.MigrationPlanModel{
+		ModelUUID:   "uuid-2",
+		Status:      state.MigrationPlanQueued,
+		Attempts:    8,
+		MigrationId: "uuid-2:7",
+		Message:     "aborted",
+		RetryAfter:  s.clock.Now().Add(time.Hour),
+	})
+}
+
+func (s *workerSuite) TestFailsAfterMaxAttempts(c *gc.C) {
+	s.plan.models = []state.MigrationPlanModel{{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    2,
+		MigrationId: "uuid-1:1",
+	}}
+	s.backend.phases["uuid-1:1"] = migration.ABORTDONE
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanFailed,
+		Attempts:    2,
+		MigrationId: "uuid-1:1",
+		Message:     "aborted",
+	})
+}
+
+func (s *workerSuite) TestStartMigrationErrors(c *gc.C) {
+	s.plan.maxAttempts = 1
+	s.backend.startErr["uuid-1"] = errors.NotFoundf("model %q", "uuid-1")
+	s.backend.startErr["uuid-2"] = errors.New("migration already in progress")
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID: "uuid-1",
+		Status:    state.MigrationPlanFailed,
+		Attempts:  1,
+		Message:   `model "uuid-1" not found`,
+	}, state.MigrationPlanModel{
+		ModelUUID: "uuid-2",
+		Status:    state.MigrationPlanFailed,
+		Attempts:  1,
+		Message:   "migration already in progress",
+	}, state.MigrationPlanModel{
+		ModelUUID:   "uuid-3",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-3:0",
+	})
+}
+
+func (s *workerSuite) TestStartMigrationErrorRetried(c *gc.C) {
+	s.plan.models = s.plan.models[:1]
+	s.backend.startErr["uuid-1"] = errors.New("source prechecks failed: boom")
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:  "uuid-1",
+		Status:     state.MigrationPlanQueued,
+		Attempts:   1,
+		Message:    "source prechecks failed: boom",
+		RetryAfter: s.clock.Now().Add(5 * time.Minute),
+	})
+	c.Check(s.backend.specs, gc.HasLen, 0)
+}
+
+func (s *workerSuite) TestCancelledPlan(c *gc.C) {
+	s.plan.cancelled = true
+	s.plan.models = []state.MigrationPlanModel{{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-1:0",
+	}, {
+		ModelUUID:   "uuid-2",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-2:0",
+	}, {
+		ModelUUID: "uuid-3",
+		Status:    state.MigrationPlanCancelled,
+	}}
+	s.backend.phases["uuid-1:0"] = migration.DONE
+	s.backend.phases["uuid-2:0"] = migration.ABORTDONE
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// Running migrations are followed, but aborted ones are not
+	// retried and no new ones are started.
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanSucceeded,
+		Attempts:    1,
+		MigrationId: "uuid-1:0",
+	}, state.MigrationPlanModel{
+		ModelUUID:   "uuid-2",
+		Status:      state.MigrationPlanCancelled,
+		Attempts:    1,
+		MigrationId: "uuid-2:0",
+		Message:     "aborted",
+	})
+	c.Check(s.backend.specs, gc.HasLen, 0)
+}
+
+func (s *workerSuite) TestWaitsForWindowStart(c *gc.C) {
+	s.plan.models = s.plan.models[:1]
+	s.plan.windowStart = s.clock.Now().Add(90 * time.Second)
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoProgress(c)
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-1:0",
+	})
+}
+
+func (s *workerSuite) TestWindowClosed(c *gc.C) {
+	s.plan.models = []state.MigrationPlanModel{{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-1:0",
+	}, {
+		ModelUUID: "uuid-2",
+		Status:    state.MigrationPlanQueued,
+		Attempts:  1,
+		Message:   "boom",
+	}}
+	s.backend.phases["uuid-1:0"] = migration.IMPORT
+	s.plan.windowEnd = s.clock.Now()
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID: "uuid-2",
+		Status:    state.MigrationPlanFailed,
+		Attempts:  1,
+		Message:   "migration window closed (boom)",
+	})
+}
+
+func (s *workerSuite) TestMigrationPlansError(c *gc.C) {
+	s.backend.plansErr = errors.New("boom")
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting migration plans: boom")
+}
+
+func (s *workerSuite) TestPlanErrorDoesNotStopOthers(c *gc.C) {
+	broken := &fakePlan{
+		id:          "0",
+		targetErr:   errors.New("boom"),
+		concurrency: 1,
+		maxAttempts: 1,
+		models: []state.MigrationPlanModel{
+			{ModelUUID: "uuid-0", Status: state.MigrationPlanQueued},
+		},
+		progress: s.plan.progress,
+	}
+	s.backend.plans = []migrationplanner.MigrationPlan{broken, s.plan}
+	w, err := migrationplanner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-1:0",
+	}, state.MigrationPlanModel{
+		ModelUUID:   "uuid-2",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-2:0",
+	})
+
+	// The broken plan is retried at the next interval, and the other
+	// plan keeps advancing.
+	s.backend.setPhase("uuid-1:0", migration.DONE)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertProgress(c, state.MigrationPlanModel{
+		ModelUUID:   "uuid-1",
+		Status:      state.MigrationPlanSucceeded,
+		Attempts:    1,
+		MigrationId: "uuid-1:0",
+	}, state.MigrationPlanModel{
+		ModelUUID:   "uuid-3",
+		Status:      state.MigrationPlanRunning,
+		Attempts:    1,
+		MigrationId: "uuid-3:0",
+	})
+	workertest.CheckAlive(c, w)
+	c.Check(broken.Models()[0].Status, gc.Equals, state.MigrationPlanQueued)
+}
+
+func (s *workerSuite) assertProgress(c *gc.C, expected ...state.MigrationPlanModel) {
+	for _, expect := range expected {
+		select {
+		case progress := <-s.plan.progress:
+			c.Assert(progress, jc.DeepEquals, expect)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for progress of %q", expect.ModelUUID)
+		}
+	}
+	s.assertNoProgress(c)
+}
+
+func (s *workerSuite) assertNoProgress(c *gc.C) {
+	select {
+	case progress := <-s.plan.progress:
+		c.Fatalf("unexpected progress %#v", progress)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeBackend struct {
+	mu       sync.Mutex
+	plans    []migrationplanner.MigrationPlan
+	plansErr error
+	phases   map[string]migration.Phase
+	startErr map[string]error
+	started  map[string]int
+	specs    []state.MigrationSpec
+}
+
+func (b *fakeBackend) setPhase(migrationId string, phase migration.Phase) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.phases[migrationId] = phase
+}
+
+func (b *fakeBackend) MigrationPlans() ([]migrationplanner.MigrationPlan, error) {
+	return b.plans, b.plansErr
+}
+
+func (b *fakeBackend) MigrationStatus(migrationId string) (migration.Phase, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	phase, ok := b.phases[migrationId]
+	if !ok {
+		return migration.QUIESCE, "", nil
+	}
+	return phase, "aborted", nil
+}
+
+func (b *fakeBackend) StartMigration(modelUUID string, spec state.MigrationSpec) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.startErr[modelUUID]; err != nil {
+		return "", err
+	}
+	b.specs = append(b.specs, spec)
+	id := fmt.Sprintf("%s:%d", modelUUID, b.started[modelUUID])
+	b.started[modelUUID]++
+	return id, nil
+}
+
+type fakePlan struct {
+	mu          sync.Mutex
+	id          string
+	targetErr   error
+	concurrency int
+	maxAttempts int
+	windowStart time.Time
+	windowEnd   time.Time
+	cancelled   bool
+	models      []state.MigrationPlanModel
+	progress    chan state.MigrationPlanModel
+}
+
+func (p *fakePlan) Id() string {
+	if p.id == "" {
+		return "1"
+	}
+	return p.id
+}
+
+func (p *fakePlan) InitiatedBy() string {
+	return "admin"
+}
+
+func (p *fakePlan) TargetInfo() (*migration.TargetInfo, error) {
+	if p.targetErr != nil {
+		return nil, p.targetErr
+	}
+	return &migration.TargetInfo{ControllerAlias: "target"}, nil
+}
+
+func (p *fakePlan) Concurrency() int {
+	return p.concurrency
+}
+
+func (p *fakePlan) MaxAttempts() int {
+	return p.maxAttempts
+}
+
+func (p *fakePlan) WindowStart() time.Time {
+	return p.windowStart
+}
+
+func (p *fakePlan) WindowEnd() time.Time {
+	return p.windowEnd
+}
+
+func (p *fakePlan) Models() []state.MigrationPlanModel {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	models := make([]state.MigrationPlanModel, len(p.models))
+	copy(models, p.models)
+	return models
+}
+
+func (p *fakePlan) Completed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, m := range p.models {
+		if !m.Status.IsFinal() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *fakePlan) Cancelled() bool {
+	return p.cancelled
+}
+
+func (p *fakePlan) SetModelProgress(progress state.MigrationPlanModel) error {
+	p.mu.Lock()
+	for i, m := range p.models {
+		if m.ModelUUID == progress.ModelUUID {
+			p.models[i] = progress
+		}
+	}
+	p.mu.Unlock()
+	p.progress <- progress
+	return nil
+}
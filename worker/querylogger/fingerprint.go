@@ -0,0 +1,69 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package querylogger
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// numberRegexp matches numeric literals that are not part of an
+	// identifier or a positional placeholder (e.g. $1).
+	numberRegexp = regexp.MustCompile(`(^|[^\w$.])[-+]?\d+(?:\.\d+)?(?:[eE][-+]?\d+)?\b`)
+
+	// listRegexp matches a list of placeholders, so that statements that
+	// only differ by the number of values in an IN clause, or a multi-row
+	// insert, share a fingerprint.
+	listRegexp   = regexp.MustCompile(`\?(?:\s*,\s*\?)+`)
+	valuesRegexp = regexp.MustCompile(`\(\?\)(?:\s*,\s*\(\?\))+`)
+
+	whitespaceRegexp = regexp.MustCompile(`\s+`)
+)
+
+// Fingerprint returns a normalised form of the statement, with all the
+// literal values replaced by placeholders. Statements that only differ by
+// the values they use share the same fingerprint.
+func Fingerprint(stmt string) string {
+	stmt = replaceStrings(stmt)
+	stmt = numberRegexp.ReplaceAllString(stmt, "${1}?")
+	stmt = whitespaceRegexp.ReplaceAllString(stmt, " ")
+	stmt = listRegexp.ReplaceAllString(stmt, "?")
+	stmt = valuesRegexp.ReplaceAllString(stmt, "(?)")
+	return strings.TrimRight(strings.TrimSpace(stmt), ";")
+}
+
+// replaceStrings replaces all single quoted string literals with a
+// placeholder. Quotes escaped by doubling them inside a literal are
+// handled.
+func replaceStrings(stmt string) string {
+	var (
+		b       strings.Builder
+		literal bool
+	)
+	b.Grow(len(stmt))
+	for i := 0; i < len(stmt); i++ {
+		ch := stmt[i]
+		if ch != '\'' {
+			if !literal {
+				b.WriteByte(ch)
+			}
+			continue
+		}
+
+		if !literal {
+			literal = true
+			b.WriteByte('?')
+			continue
+		}
+
+		// An escaped quote inside a literal.
+		if i+1 < len(stmt) && stmt[i+1] == '\'' {
+			i++
+			continue
+		}
+		literal = false
+	}
+	return b.String()
+}
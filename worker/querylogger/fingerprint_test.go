@@ -0,0 +1,47 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package querylogger
+
+import (
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+)
+
+type fingerprintSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&fingerprintSuite{})
+
+func (s *fingerprintSuite) TestFingerprint(c *gc.C) {
+	tests := []struct {
+		stmt     string
+		expected string
+	}{{
+		stmt:     "SELECT * FROM foo",
+		expected: "SELECT * FROM foo",
+	}, {
+		stmt:     "SELECT * FROM foo WHERE id = 42;",
+		expected: "SELECT * FROM foo WHERE id = ?",
+	}, {
+		stmt:     "SELECT * FROM foo WHERE name = 'it''s' AND value > -1.5e3",
+		expected: "SELECT * FROM foo WHERE name = ? AND value > ?",
+	}, {
+		stmt:     "SELECT * FROM table1 WHERE uuid IN ('a', 'b', 'c')",
+		expected: "SELECT * FROM table1 WHERE uuid IN (?)",
+	}, {
+		stmt:     "SELECT * FROM foo WHERE uuid IN (?, ?)",
+		expected: "SELECT * FROM foo WHERE uuid IN (?)",
+	}, {
+		stmt:     "INSERT INTO foo (a, b) VALUES (1, 'x'), (2, 'y')",
+		expected: "INSERT INTO foo (a, b) VALUES (?)",
+	}, {
+		stmt:     "SELECT *\n\tFROM foo\n\tWHERE id = $1",
+		expected: "SELECT * FROM foo WHERE id = $1",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %q", i, test.stmt)
+		c.Check(Fingerprint(test.stmt), gc.Equals, test.expected)
+	}
+}
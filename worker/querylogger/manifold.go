@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/worker/v3"
 	"github.com/juju/worker/v3/dependency"
+	"github.com/prometheus/client_golang/prometheus"
 
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/worker/common"
@@ -22,6 +23,12 @@ type Logger interface {
 	Errorf(string, ...interface{})
 }
 
+// Reporter reports on the slow queries recorded by the query logger.
+type Reporter interface {
+	// SlowQueryReport returns a report of the top n slow statements.
+	SlowQueryReport(n int) string
+}
+
 // ManifoldConfig contains:
 // - The names of other manifolds on which the DB accessor depends.
 // - Other dependencies from ManifoldsConfig required by the worker.
@@ -29,6 +36,15 @@ type ManifoldConfig struct {
 	LogDir string
 	Clock  clock.Clock
 	Logger Logger
+
+	PrometheusRegisterer prometheus.Registerer
+	NewMetricsCollector  func() *Collector
+
+	// SetReporter is called with the slow query reporter when the worker
+	// is started, and with nil when it is stopped, so that the report can
+	// be exposed by the introspection worker running outside of the
+	// dependency engine.
+	SetReporter func(Reporter)
 }
 
 func (cfg ManifoldConfig) Validate() error {
@@ -41,6 +57,15 @@ func (cfg ManifoldConfig) Validate() error {
 	if cfg.Logger == nil {
 		return errors.NotValidf("nil Logger")
 	}
+	if cfg.PrometheusRegisterer == nil {
+		return errors.NotValidf("nil PrometheusRegisterer")
+	}
+	if cfg.NewMetricsCollector == nil {
+		return errors.NotValidf("nil NewMetricsCollector")
+	}
+	if cfg.SetReporter == nil {
+		return errors.NotValidf("nil SetReporter")
+	}
 	return nil
 }
 
@@ -55,6 +80,12 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, errors.Trace(err)
 			}
 
+			// Register the metrics collector against the prometheus register.
+			metricsCollector := config.NewMetricsCollector()
+			if err := config.PrometheusRegisterer.Register(metricsCollector); err != nil {
+				return nil, errors.Trace(err)
+			}
+
 			cfg := &WorkerConfig{
 				LogDir: config.LogDir,
 				Clock:  config.Clock,
@@ -64,13 +95,21 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 					// include the slow query logger.
 					return debug.Stack()
 				},
+				Metrics: metricsCollector,
 			}
 
 			w, err := newWorker(cfg)
 			if err != nil {
+				config.PrometheusRegisterer.Unregister(metricsCollector)
 				return nil, errors.Trace(err)
 			}
-			return w, nil
+			config.SetReporter(w)
+			return common.NewCleanupWorker(w, func() {
+				config.SetReporter(nil)
+				// Clean up the metrics for the worker, so the next time a
+				// worker is created we can safely register the metrics again.
+				config.PrometheusRegisterer.Unregister(metricsCollector)
+			}), nil
 		},
 	}
 }
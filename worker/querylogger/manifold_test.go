@@ -6,6 +6,7 @@ package querylogger
 import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 )
 
@@ -30,12 +31,27 @@ func (s *manifoldSuite) TestValidateConfig(c *gc.C) {
 	cfg = s.getConfig()
 	cfg.Logger = nil
 	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
+
+	cfg = s.getConfig()
+	cfg.PrometheusRegisterer = nil
+	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
+
+	cfg = s.getConfig()
+	cfg.NewMetricsCollector = nil
+	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
+
+	cfg = s.getConfig()
+	cfg.SetReporter = nil
+	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
 }
 
 func (s *manifoldSuite) getConfig() ManifoldConfig {
 	return ManifoldConfig{
-		LogDir: "log dir",
-		Clock:  s.clock,
-		Logger: s.logger,
+		LogDir:               "log dir",
+		Clock:                s.clock,
+		Logger:               s.logger,
+		PrometheusRegisterer: prometheus.NewRegistry(),
+		NewMetricsCollector:  NewMetricsCollector,
+		SetReporter:          func(Reporter) {},
 	}
 }
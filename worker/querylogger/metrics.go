@@ -0,0 +1,39 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package querylogger
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	querylogMetricsNamespace   = "juju"
+	querylogSubsystemNamespace = "db"
+)
+
+// Collector defines a prometheus collector for the slow query logger.
+type Collector struct {
+	SlowQueryDuration *prometheus.HistogramVec
+}
+
+// NewMetricsCollector returns a new Collector.
+func NewMetricsCollector() *Collector {
+	return &Collector{
+		SlowQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: querylogMetricsNamespace,
+			Subsystem: querylogSubsystemNamespace,
+			Name:      "slow_query_duration_seconds",
+			Help:      "Time spent in slow db queries, keyed by statement fingerprint.",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"fingerprint"}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.SlowQueryDuration.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.SlowQueryDuration.Collect(ch)
+}
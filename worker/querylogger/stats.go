@@ -0,0 +1,165 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package querylogger
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	// maxStatements is the maximum number of distinct statement
+	// fingerprints that are tracked. Any statement seen once the limit
+	// has been reached is grouped under otherFingerprint.
+	maxStatements = 500
+
+	// maxSamples is the number of most recent durations kept per
+	// statement for calculating percentiles.
+	maxSamples = 1000
+
+	otherFingerprint = "other"
+)
+
+// SlowQuery holds the aggregated statistics for a slow statement.
+type SlowQuery struct {
+	Fingerprint string
+	Count       int
+	Total       time.Duration
+	Max         time.Duration
+	P95         time.Duration
+}
+
+type statementStats struct {
+	count   int
+	total   time.Duration
+	max     time.Duration
+	samples []time.Duration
+	next    int
+}
+
+func (s *statementStats) record(d time.Duration) {
+	s.count++
+	s.total += d
+	if d > s.max {
+		s.max = d
+	}
+	// Keep a ring buffer of the most recent samples.
+	if len(s.samples) < maxSamples {
+		s.samples = append(s.samples, d)
+		return
+	}
+	s.samples[s.next] = d
+	s.next = (s.next + 1) % maxSamples
+}
+
+func (s *statementStats) percentile(p float64) time.Duration {
+	if len(s.samples) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(s.samples))
+	copy(sorted, s.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	// Use the nearest rank method.
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	} else if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// statistics aggregates slow queries by their statement fingerprint.
+type statistics struct {
+	mutex      sync.Mutex
+	statements map[string]*statementStats
+}
+
+func newStatistics() *statistics {
+	return &statistics{
+		statements: make(map[string]*statementStats),
+	}
+}
+
+// record adds the duration of a slow statement to the statistics,
+// returning the fingerprint it was recorded against.
+func (s *statistics) record(fingerprint string, d time.Duration) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats, ok := s.statements[fingerprint]
+	if !ok {
+		if len(s.statements) >= maxStatements {
+			fingerprint = otherFingerprint
+			if stats, ok = s.statements[fingerprint]; !ok {
+				stats = &statementStats{}
+				s.statements[fingerprint] = stats
+			}
+		} else {
+			stats = &statementStats{}
+			s.statements[fingerprint] = stats
+		}
+	}
+	stats.record(d)
+	return fingerprint
+}
+
+// top returns the n statements that have been recorded the most,
+// ordered by count and then by the 95th percentile duration.
+// If n is less than or equal to zero, all the statements are returned.
+func (s *statistics) top(n int) []SlowQuery {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	results := make([]SlowQuery, 0, len(s.statements))
+	for fingerprint, stats := range s.statements {
+		results = append(results, SlowQuery{
+			Fingerprint: fingerprint,
+			Count:       stats.count,
+			Total:       stats.total,
+			Max:         stats.max,
+			P95:         stats.percentile(0.95),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+		if results[i].P95 != results[j].P95 {
+			return results[i].P95 > results[j].P95
+		}
+		return results[i].Fingerprint < results[j].Fingerprint
+	})
+	if n > 0 && len(results) > n {
+		results = results[:n]
+	}
+	return results
+}
+
+// formatSlowQueries writes the slow queries out as a table.
+func formatSlowQueries(queries []SlowQuery) string {
+	if len(queries) == 0 {
+		return "no slow queries recorded\n"
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', 0)
+	fmt.Fprintln(w, "COUNT\tP95\tMAX\tTOTAL\tSTATEMENT")
+	for _, q := range queries {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+			q.Count,
+			q.P95.Round(time.Millisecond),
+			q.Max.Round(time.Millisecond),
+			q.Total.Round(time.Millisecond),
+			q.Fingerprint,
+		)
+	}
+	_ = w.Flush()
+	return buf.String()
+}
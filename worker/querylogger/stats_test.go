@@ -0,0 +1,66 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package querylogger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type statisticsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&statisticsSuite{})
+
+func (s *statisticsSuite) TestTop(c *gc.C) {
+	stats := newStatistics()
+	for i := 1; i <= 100; i++ {
+		stats.record("a", time.Duration(i)*time.Millisecond)
+	}
+	stats.record("b", time.Second)
+	stats.record("c", 2*time.Second)
+
+	c.Check(stats.top(2), jc.DeepEquals, []SlowQuery{{
+		Fingerprint: "a",
+		Count:       100,
+		Total:       5050 * time.Millisecond,
+		Max:         100 * time.Millisecond,
+		P95:         95 * time.Millisecond,
+	}, {
+		Fingerprint: "c",
+		Count:       1,
+		Total:       2 * time.Second,
+		Max:         2 * time.Second,
+		P95:         2 * time.Second,
+	}})
+	c.Check(stats.top(0), gc.HasLen, 3)
+}
+
+func (s *statisticsSuite) TestSamplesAreBounded(c *gc.C) {
+	stats := newStatistics()
+	for i := 0; i < maxSamples*2; i++ {
+		stats.record("a", time.Second)
+	}
+	c.Check(stats.statements["a"].samples, gc.HasLen, maxSamples)
+	c.Check(stats.statements["a"].count, gc.Equals, maxSamples*2)
+}
+
+func (s *statisticsSuite) TestStatementsAreBounded(c *gc.C) {
+	stats := newStatistics()
+	for i := 0; i < maxStatements; i++ {
+		c.Check(stats.record(fmt.Sprintf("stmt %d", i), time.Second), gc.Equals, fmt.Sprintf("stmt %d", i))
+	}
+	c.Check(stats.record("one too many", time.Second), gc.Equals, otherFingerprint)
+	c.Check(stats.record("stmt 0", time.Second), gc.Equals, "stmt 0")
+	c.Check(stats.statements, gc.HasLen, maxStatements+1)
+}
+
+func (s *statisticsSuite) TestFormatSlowQueriesEmpty(c *gc.C) {
+	c.Check(formatSlowQueries(nil), gc.Equals, "no slow queries recorded\n")
+}
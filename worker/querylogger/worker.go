@@ -27,6 +27,7 @@ type WorkerConfig struct {
 	Clock         clock.Clock
 	Logger        Logger
 	StackGatherer func() []byte
+	Metrics       *Collector
 }
 
 // Validate ensures that the config values are valid.
@@ -43,6 +44,9 @@ func (c *WorkerConfig) Validate() error {
 	if c.StackGatherer == nil {
 		return errors.NotValidf("missing StackGatherer")
 	}
+	if c.Metrics == nil {
+		return errors.NotValidf("missing Metrics")
+	}
 	return nil
 }
 
@@ -54,6 +58,8 @@ type loggerWorker struct {
 	clock         clock.Clock
 	logger        Logger
 	stackGatherer func() []byte
+	metrics       *Collector
+	stats         *statistics
 
 	logDir string
 	logs   chan payload
@@ -72,6 +78,8 @@ func newWorker(cfg *WorkerConfig) (*loggerWorker, error) {
 		clock:         cfg.Clock,
 		logger:        cfg.Logger,
 		stackGatherer: cfg.StackGatherer,
+		metrics:       cfg.Metrics,
+		stats:         newStatistics(),
 
 		logs: make(chan payload),
 	}
@@ -81,6 +89,11 @@ func newWorker(cfg *WorkerConfig) (*loggerWorker, error) {
 
 // RecordSlowQuery the slow query, with the given arguments.
 func (l *loggerWorker) RecordSlowQuery(msg, stmt string, args []any, duration float64) {
+	// Aggregate the statement by its fingerprint, so that the same
+	// statement with different values is reported together.
+	fingerprint := l.stats.record(Fingerprint(stmt), time.Duration(duration*float64(time.Second)))
+	l.metrics.SlowQueryDuration.WithLabelValues(fingerprint).Observe(duration)
+
 	// Record the stack.
 	// TODO (stickupkid): Prune the stack to remove the first few frames.
 	stack := l.stackGatherer()
@@ -116,6 +129,18 @@ func (l *loggerWorker) RecordSlowQuery(msg, stmt string, args []any, duration fl
 	l.logger.Warningf("slow query: "+msg, args...)
 }
 
+// SlowQueries returns the top n slow statements recorded by the worker.
+// If n is less than or equal to zero, all the statements are returned.
+func (l *loggerWorker) SlowQueries(n int) []SlowQuery {
+	return l.stats.top(n)
+}
+
+// SlowQueryReport returns a report of the top n slow statements, with
+// their counts and 95th percentile durations.
+func (l *loggerWorker) SlowQueryReport(n int) string {
+	return formatSlowQueries(l.SlowQueries(n))
+}
+
 // Kill is part of the worker.Worker interface.
 func (w *loggerWorker) Kill() {
 	w.tomb.Kill(nil)
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v3/workertest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/mock/gomock"
 	gc "gopkg.in/check.v1"
 )
@@ -101,6 +102,55 @@ dummy stack
 	workertest.CleanKill(c, w)
 }
 
+func (s *loggerSuite) TestLoggerSlowQueries(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	dir := c.MkDir()
+
+	ch := make(chan time.Time)
+	s.timer.EXPECT().Chan().Return(ch).AnyTimes()
+	s.logger.EXPECT().Warningf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	w := s.newWorker(c, dir)
+	defer workertest.DirtyKill(c, w)
+
+	for i := 0; i < 3; i++ {
+		stmt := fmt.Sprintf("SELECT * FROM foo WHERE id = %d", i)
+		w.RecordSlowQuery("hello", stmt, nil, float64(i+1))
+	}
+	w.RecordSlowQuery("hello", "SELECT * FROM bar WHERE name = 'x'", nil, 0.5)
+
+	select {
+	case ch <- time.Now():
+	case <-time.After(testing.ShortWait):
+		c.Fatal("timed out waiting for log to be written")
+	}
+
+	c.Check(w.SlowQueries(0), jc.DeepEquals, []SlowQuery{{
+		Fingerprint: "SELECT * FROM foo WHERE id = ?",
+		Count:       3,
+		Total:       6 * time.Second,
+		Max:         3 * time.Second,
+		P95:         3 * time.Second,
+	}, {
+		Fingerprint: "SELECT * FROM bar WHERE name = ?",
+		Count:       1,
+		Total:       500 * time.Millisecond,
+		Max:         500 * time.Millisecond,
+		P95:         500 * time.Millisecond,
+	}})
+
+	c.Check(w.SlowQueryReport(1), gc.Equals, `
+COUNT  P95  MAX  TOTAL  STATEMENT
+3      3s   3s   6s     SELECT * FROM foo WHERE id = ?
+`[1:])
+
+	count := testutil.CollectAndCount(w.metrics, "juju_db_slow_query_duration_seconds")
+	c.Check(count, gc.Equals, 2)
+
+	workertest.CleanKill(c, w)
+}
+
 func (s *loggerSuite) expectLogResult(c *gc.C, dir string, match string) {
 	data, err := os.ReadFile(filepath.Join(dir, filename))
 	c.Assert(err, jc.ErrorIsNil)
@@ -130,6 +180,7 @@ func (s *loggerSuite) newWorker(c *gc.C, dir string) *loggerWorker {
 		StackGatherer: func() []byte {
 			return []byte("dummy stack")
 		},
+		Metrics: NewMetricsCollector(),
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -28,6 +28,8 @@ type Config struct {
 	Applications         ApplicationWatcher
 	Volumes              VolumeAccessor
 	Filesystems          FilesystemAccessor
+	Snapshots            SnapshotAccessor
+	Resizes              ResizeAccessor
 	Life                 LifecycleManager
 	Registry             storage.ProviderRegistry
 	Machines             MachineAccessor
@@ -492,6 +492,7 @@ func filesystemParamsFromParams(in params.FilesystemParams) (storage.FilesystemP
 		Provider:     providerType,
 		Attributes:   in.Attributes,
 		ResourceTags: in.Tags,
+		SnapshotId:   in.SnapshotId,
 	}, nil
 }
 
@@ -421,8 +421,16 @@ func validateFilesystemParams(
 ) ([]storage.FilesystemParams, []error) {
 	valid := make([]storage.FilesystemParams, 0, len(filesystemParams))
 	results := make([]error, len(filesystemParams))
+	_, canSnapshot := filesystemSource.(storage.FilesystemSnapshotter)
 	for i, params := range filesystemParams {
-		err := filesystemSource.ValidateFilesystemParams(params)
+		var err error
+		if params.SnapshotId != "" && params.Volume == (names.VolumeTag{}) && !canSnapshot {
+			// Volume-backed filesystems are created from a snapshot
+			// of their volume, so only check other filesystems.
+			err = errors.NotSupportedf("creating filesystems from snapshots")
+		} else {
+			err = filesystemSource.ValidateFilesystemParams(params)
+		}
 		if err == nil {
 			valid = append(valid, params)
 		}
@@ -58,6 +58,8 @@ func (config MachineManifoldConfig) newWorker(a agent.Agent, apiCaller base.APIC
 		StorageDir:           storageDir,
 		Volumes:              api,
 		Filesystems:          api,
+		Snapshots:            api,
+		Resizes:              api,
 		Life:                 api,
 		Registry:             provider.CommonStorageProviders(),
 		Machines:             api,
@@ -144,6 +144,7 @@ type mockVolumeAccessor struct {
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	snapshotIds            map[string]string
 
 	setVolumeInfo               func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo     func([]params.VolumeAttachment) ([]params.ErrorResult, error)
@@ -232,6 +233,7 @@ func (v *mockVolumeAccessor) VolumeParams(volumes []names.VolumeTag) ([]params.V
 			Tags: map[string]string{
 				"very": "fancy",
 			},
+			SnapshotId: v.snapshotIds[tag.String()],
 		}
 		if tag.Id() != noAttachmentVolumeId {
 			volumeParams.Attachment = &params.VolumeAttachmentParams{
@@ -327,6 +329,7 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		snapshotIds:            make(map[string]string),
 	}
 }
 
@@ -476,6 +479,73 @@ func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	}
 }
 
+type mockSnapshotAccessor struct {
+	snapshotsWatcher       *mockStringsWatcher
+	storageSnapshotParams  func([]string) ([]params.StorageSnapshotParamsResult, error)
+	startStorageSnapshots  func([]string) ([]params.ErrorResult, error)
+	removeStorageSnapshots func([]string) ([]params.ErrorResult, error)
+	setStorageSnapshotInfo func([]params.StorageSnapshotInfo) ([]params.ErrorResult, error)
+}
+
+func (m *mockSnapshotAccessor) WatchStorageSnapshots(names.Tag) (watcher.StringsWatcher, error) {
+	return m.snapshotsWatcher, nil
+}
+
+func (m *mockSnapshotAccessor) StorageSnapshotParams(ids []string) ([]params.StorageSnapshotParamsResult, error) {
+	return m.storageSnapshotParams(ids)
+}
+
+func (m *mockSnapshotAccessor) StartStorageSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if m.startStorageSnapshots != nil {
+		return m.startStorageSnapshots(ids)
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
+
+func (m *mockSnapshotAccessor) RemoveStorageSnapshots(ids []string) ([]params.ErrorResult, error) {
+	return m.removeStorageSnapshots(ids)
+}
+
+func (m *mockSnapshotAccessor) SetStorageSnapshotInfo(snapshots []params.StorageSnapshotInfo) ([]params.ErrorResult, error) {
+	return m.setStorageSnapshotInfo(snapshots)
+}
+
+func newMockSnapshotAccessor() *mockSnapshotAccessor {
+	return &mockSnapshotAccessor{
+		snapshotsWatcher: newMockStringsWatcher(),
+	}
+}
+
+type mockResizeAccessor struct {
+	volumesWatcher      *mockStringsWatcher
+	filesystemsWatcher  *mockStringsWatcher
+	storageResizeParams func([]names.Tag) ([]params.StorageResizeParamsResult, error)
+	setStorageResized   func([]params.StorageResized) ([]params.ErrorResult, error)
+}
+
+func (m *mockResizeAccessor) WatchVolumeResizes(names.Tag) (watcher.StringsWatcher, error) {
+	return m.volumesWatcher, nil
+}
+
+func (m *mockResizeAccessor) WatchFilesystemResizes(names.Tag) (watcher.StringsWatcher, error) {
+	return m.filesystemsWatcher, nil
+}
+
+func (m *mockResizeAccessor) StorageResizeParams(tags []names.Tag) ([]params.StorageResizeParamsResult, error) {
+	return m.storageResizeParams(tags)
+}
+
+func (m *mockResizeAccessor) SetStorageResized(resized []params.StorageResized) ([]params.ErrorResult, error) {
+	return m.setStorageResized(resized)
+}
+
+func newMockResizeAccessor() *mockResizeAccessor {
+	return &mockResizeAccessor{
+		volumesWatcher:     newMockStringsWatcher(),
+		filesystemsWatcher: newMockStringsWatcher(),
+	}
+}
+
 type mockLifecycleManager struct {
 	err               *params.Error
 	life              func([]names.Tag) ([]params.LifeResult, error)
@@ -561,6 +631,9 @@ type dummyProvider struct {
 	releaseFilesystemsFunc       func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
+	createVolumeSnapshotsFunc    func([]storage.VolumeSnapshotParams) ([]storage.CreateSnapshotsResult, error)
+	deleteVolumeSnapshotsFunc    func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeResult, error)
 }
 
 type dummyVolumeSource struct {
@@ -626,6 +699,44 @@ func (s *dummyVolumeSource) CreateVolumes(ctx context.ProviderCallContext, param
 	return results, nil
 }
 
+// CreateVolumeSnapshots takes snapshots of volumes.
+func (s *dummyVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	if s.provider != nil && s.provider.createVolumeSnapshotsFunc != nil {
+		return s.provider.createVolumeSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].Snapshot = &storage.Snapshot{
+			Id: p.Id,
+			SnapshotInfo: storage.SnapshotInfo{
+				SnapshotId: "snapshot-" + p.VolumeId,
+				Size:       1024,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DeleteVolumeSnapshots deletes snapshots of volumes.
+func (s *dummyVolumeSource) DeleteVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	if s.provider != nil && s.provider.deleteVolumeSnapshotsFunc != nil {
+		return s.provider.deleteVolumeSnapshotsFunc(snapshotIds)
+	}
+	return make([]error, len(snapshotIds)), nil
+}
+
+// ResizeVolumes grows volumes.
+func (s *dummyVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeResult, error) {
+	if s.provider != nil && s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeResult, len(params))
+	for i, p := range params {
+		results[i].Size = p.Size
+	}
+	return results, nil
+}
+
 // DestroyVolumes destroys volumes.
 func (s *dummyVolumeSource) DestroyVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
 	if s.provider.destroyVolumesFunc != nil {
@@ -0,0 +1,216 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	stdcontext "context"
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/storage"
+)
+
+// volumeResizesChanged is called when volumes have changed, which
+// includes requests to resize them.
+func volumeResizesChanged(ctx *context, ids []string) error {
+	tags := make([]names.Tag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewVolumeTag(id)
+	}
+	return storageResizesChanged(ctx, tags)
+}
+
+// filesystemResizesChanged is called when filesystems have changed,
+// which includes requests to resize them.
+func filesystemResizesChanged(ctx *context, ids []string) error {
+	tags := make([]names.Tag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewFilesystemTag(id)
+	}
+	return storageResizesChanged(ctx, tags)
+}
+
+// storageResizesChanged resizes any of the specified volumes or
+// filesystems that have a pending resize request. Resizes are not
+// retried; a failure is reported by setting the status of the volume or
+// filesystem, and the request is cleared.
+func storageResizesChanged(ctx *context, tags []names.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	paramsResults, err := ctx.config.Resizes.StorageResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting storage resize params")
+	}
+	var volumeParams []storage.VolumeResizeParams
+	var filesystemParams []storage.FilesystemResizeParams
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) || params.IsCodeNotProvisioned(result.Error) {
+				// The volume or filesystem has been removed, or is not
+				// yet provisioned; neither can have a pending resize.
+				continue
+			}
+			return errors.Annotatef(result.Error, "getting resize parameters for %s", names.ReadableString(tags[i]))
+		}
+		if result.Result == nil {
+			// There is no pending resize.
+			continue
+		}
+		in := result.Result
+		switch tag := tags[i].(type) {
+		case names.VolumeTag:
+			volumeParams = append(volumeParams, storage.VolumeResizeParams{
+				Volume:     tag,
+				VolumeId:   in.ProviderId,
+				Size:       in.RequestedSize,
+				Provider:   storage.ProviderType(in.Provider),
+				Attributes: in.Attributes,
+			})
+		case names.FilesystemTag:
+			filesystemParams = append(filesystemParams, storage.FilesystemResizeParams{
+				Filesystem:   tag,
+				FilesystemId: in.ProviderId,
+				Size:         in.RequestedSize,
+				Provider:     storage.ProviderType(in.Provider),
+				Attributes:   in.Attributes,
+			})
+		}
+	}
+
+	var resized []params.StorageResized
+	var statuses []params.EntityStatusArgs
+	for _, arg := range volumeParams {
+		result := resizeVolume(ctx, arg)
+		resized = append(resized, params.StorageResized{Tag: arg.Volume.String(), Size: result.Size})
+		if result.Error == nil {
+			if volume, ok := ctx.volumes[arg.Volume]; ok {
+				volume.Size = result.Size
+				ctx.volumes[arg.Volume] = volume
+			}
+		}
+		statuses = append(statuses, resizeStatus(ctx, arg.Volume, arg.Size, result.Error))
+	}
+	for _, arg := range filesystemParams {
+		result := resizeFilesystem(ctx, arg)
+		resized = append(resized, params.StorageResized{Tag: arg.Filesystem.String(), Size: result.Size})
+		if result.Error == nil {
+			if filesystem, ok := ctx.filesystems[arg.Filesystem]; ok {
+				filesystem.Size = result.Size
+				ctx.filesystems[arg.Filesystem] = filesystem
+			}
+		}
+		statuses = append(statuses, resizeStatus(ctx, arg.Filesystem, arg.Size, result.Error))
+	}
+	if len(resized) == 0 {
+		return nil
+	}
+
+	errorResults, err := ctx.config.Resizes.SetStorageResized(resized)
+	if err != nil {
+		return errors.Annotate(err, "publishing resized storage to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "publishing resized %s to state", resized[i].Tag)
+		}
+	}
+	setStatus(ctx, statuses)
+	return nil
+}
+
+// resizeVolume grows a volume, returning the outcome. A failed resize
+// is reported with a zero size.
+func resizeVolume(ctx *context, arg storage.VolumeResizeParams) storage.ResizeResult {
+	sourceName := string(arg.Provider)
+	source, err := volumeSource(ctx.config.StorageDir, sourceName, arg.Provider, ctx.config.Registry)
+	if err != nil {
+		return storage.ResizeResult{Error: errors.Trace(err)}
+	}
+	resizer, ok := source.(storage.VolumeResizer)
+	if !ok {
+		return storage.ResizeResult{Error: errors.NotSupportedf("resizing %q volumes", sourceName)}
+	}
+	ctx.config.Logger.Debugf("resizing %s to %dMiB", names.ReadableString(arg.Volume), arg.Size)
+	results, err := resizer.ResizeVolumes(
+		ctx.config.CloudCallContextFunc(stdcontext.Background()), []storage.VolumeResizeParams{arg},
+	)
+	return resizeResult(ctx, arg.Volume, results, err)
+}
+
+// resizeFilesystem grows a filesystem, returning the outcome. A failed
+// resize is reported with a zero size.
+func resizeFilesystem(ctx *context, arg storage.FilesystemResizeParams) storage.ResizeResult {
+	sourceName := string(arg.Provider)
+	source, err := filesystemSource(ctx.config.StorageDir, sourceName, arg.Provider, ctx.config.Registry)
+	if err != nil {
+		return storage.ResizeResult{Error: errors.Trace(err)}
+	}
+	resizer, ok := source.(storage.FilesystemResizer)
+	if !ok {
+		return storage.ResizeResult{Error: errors.NotSupportedf("resizing %q filesystems", sourceName)}
+	}
+	ctx.config.Logger.Debugf("resizing %s to %dMiB", names.ReadableString(arg.Filesystem), arg.Size)
+	results, err := resizer.ResizeFilesystems(
+		ctx.config.CloudCallContextFunc(stdcontext.Background()), []storage.FilesystemResizeParams{arg},
+	)
+	return resizeResult(ctx, arg.Filesystem, results, err)
+}
+
+// resizeResult returns the single result of resizing a volume or
+// filesystem, logging any failure.
+func resizeResult(ctx *context, tag names.Tag, results []storage.ResizeResult, err error) storage.ResizeResult {
+	var result storage.ResizeResult
+	switch {
+	case err != nil:
+		result.Error = err
+	case len(results) != 1:
+		result.Error = errors.Errorf("expected 1 resize result, got %d", len(results))
+	default:
+		result = results[0]
+	}
+	if result.Error != nil {
+		result.Size = 0
+		ctx.config.Logger.Warningf("failed to resize %s: %v", names.ReadableString(tag), result.Error)
+	}
+	return result
+}
+
+// resizeStatus returns the status of a volume or filesystem after an
+// attempt to resize it to the given size.
+func resizeStatus(ctx *context, tag names.Tag, size uint64, err error) params.EntityStatusArgs {
+	if err != nil {
+		return params.EntityStatusArgs{
+			Tag:    tag.String(),
+			Status: status.Error.String(),
+			Info:   fmt.Sprintf("resizing to %dMiB: %v", size, err),
+		}
+	}
+	attached := false
+	switch tag := tag.(type) {
+	case names.VolumeTag:
+		for id := range ctx.volumeAttachments {
+			if id.AttachmentTag == tag.String() {
+				attached = true
+				break
+			}
+		}
+	case names.FilesystemTag:
+		for id := range ctx.filesystemAttachments {
+			if id.AttachmentTag == tag.String() {
+				attached = true
+				break
+			}
+		}
+	}
+	entityStatus := status.Detached
+	if attached {
+		entityStatus = status.Attached
+	}
+	return params.EntityStatusArgs{Tag: tag.String(), Status: entityStatus.String()}
+}
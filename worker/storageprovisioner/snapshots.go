@@ -0,0 +1,341 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	stdcontext "context"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/storage"
+)
+
+// storageSnapshotsChanged is called when storage snapshots have been
+// requested or destroyed. Snapshots are point-in-time, so they are taken
+// immediately and never retried; a failure is recorded against the
+// snapshot. A snapshot that was started but not recorded, because the
+// worker was interrupted, is marked failed rather than taken again.
+func storageSnapshotsChanged(ctx *context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	paramsResults, err := ctx.config.Snapshots.StorageSnapshotParams(ids)
+	if err != nil {
+		return errors.Annotate(err, "getting storage snapshot params")
+	}
+	var alive, dying []params.StorageSnapshotParams
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The snapshot has already been removed.
+				continue
+			}
+			return errors.Annotatef(result.Error, "getting parameters for storage snapshot %q", ids[i])
+		}
+		if result.Result == nil {
+			// The snapshot has already been taken.
+			continue
+		}
+		if life.IsAlive(result.Result.Life) {
+			alive = append(alive, *result.Result)
+		} else {
+			dying = append(dying, *result.Result)
+		}
+	}
+	if err := removeStorageSnapshots(ctx, dying); err != nil {
+		return errors.Trace(err)
+	}
+	return createStorageSnapshots(ctx, alive)
+}
+
+// createStorageSnapshots takes the requested storage snapshots and
+// records the outcome of each.
+func createStorageSnapshots(ctx *context, snapshots []params.StorageSnapshotParams) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	ids := make([]string, len(snapshots))
+	for i, in := range snapshots {
+		ids[i] = in.Id
+	}
+	startResults, err := ctx.config.Snapshots.StartStorageSnapshots(ids)
+	if err != nil {
+		return errors.Annotate(err, "starting storage snapshots")
+	}
+
+	var infos []params.StorageSnapshotInfo
+	var volumeParams []storage.VolumeSnapshotParams
+	var filesystemParams []storage.FilesystemSnapshotParams
+	for i, in := range snapshots {
+		if err := startResults[i].Error; err != nil {
+			if !params.IsCodeAlreadyExists(err) {
+				return errors.Annotatef(err, "starting storage snapshot %q", in.Id)
+			}
+			ctx.config.Logger.Warningf("storage snapshot %q was interrupted while being taken", in.Id)
+			infos = append(infos, params.StorageSnapshotInfo{
+				Id:    in.Id,
+				Error: "interrupted while being taken",
+			})
+			continue
+		}
+		if in.VolumeTag != "" {
+			volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			volumeParams = append(volumeParams, storage.VolumeSnapshotParams{
+				Id:           in.Id,
+				Volume:       volumeTag,
+				VolumeId:     in.VolumeId,
+				Provider:     storage.ProviderType(in.Provider),
+				Attributes:   in.Attributes,
+				ResourceTags: in.Tags,
+			})
+			continue
+		}
+		filesystemTag, err := names.ParseFilesystemTag(in.FilesystemTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filesystemParams = append(filesystemParams, storage.FilesystemSnapshotParams{
+			Id:           in.Id,
+			Filesystem:   filesystemTag,
+			FilesystemId: in.FilesystemId,
+			Provider:     storage.ProviderType(in.Provider),
+			Attributes:   in.Attributes,
+			ResourceTags: in.Tags,
+		})
+	}
+
+	volumeInfos, err := createVolumeSnapshots(ctx, volumeParams)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	infos = append(infos, volumeInfos...)
+	filesystemInfos, err := createFilesystemSnapshots(ctx, filesystemParams)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	infos = append(infos, filesystemInfos...)
+	if len(infos) == 0 {
+		return nil
+	}
+
+	errorResults, err := ctx.config.Snapshots.SetStorageSnapshotInfo(infos)
+	if err != nil {
+		return errors.Annotate(err, "publishing storage snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "publishing storage snapshot %q to state", infos[i].Id)
+		}
+	}
+	return nil
+}
+
+// removeStorageSnapshots deletes the provider snapshots of dying
+// storage snapshots, and then removes the snapshots from state. A
+// snapshot that could not be deleted is left dying, and is deleted
+// again the next time it changes or the worker restarts.
+func removeStorageSnapshots(ctx *context, snapshots []params.StorageSnapshotParams) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	volumeSnapshots := make(map[storage.ProviderType][]params.StorageSnapshotParams)
+	filesystemSnapshots := make(map[storage.ProviderType][]params.StorageSnapshotParams)
+	var remove []string
+	for _, in := range snapshots {
+		switch {
+		case in.SnapshotId == "":
+			// The snapshot was never taken, so there is nothing to delete.
+			remove = append(remove, in.Id)
+		case in.VolumeTag != "":
+			providerType := storage.ProviderType(in.Provider)
+			volumeSnapshots[providerType] = append(volumeSnapshots[providerType], in)
+		default:
+			providerType := storage.ProviderType(in.Provider)
+			filesystemSnapshots[providerType] = append(filesystemSnapshots[providerType], in)
+		}
+	}
+	for providerType, snapshots := range volumeSnapshots {
+		sourceName := string(providerType)
+		var snapshotter storage.VolumeSnapshotter
+		source, err := volumeSource(ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry)
+		if err == nil {
+			var ok bool
+			if snapshotter, ok = source.(storage.VolumeSnapshotter); !ok {
+				err = errors.NotSupportedf("snapshots of %q volumes", sourceName)
+			}
+		}
+		var results []error
+		if err == nil {
+			ctx.config.Logger.Debugf("deleting volume snapshots: %v", snapshots)
+			results, err = snapshotter.DeleteVolumeSnapshots(
+				ctx.config.CloudCallContextFunc(stdcontext.Background()), snapshotIds(snapshots),
+			)
+		}
+		remove = append(remove, deletedSnapshots(ctx, snapshots, results, err)...)
+	}
+	for providerType, snapshots := range filesystemSnapshots {
+		sourceName := string(providerType)
+		var snapshotter storage.FilesystemSnapshotter
+		source, err := filesystemSource(ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry)
+		if err == nil {
+			var ok bool
+			if snapshotter, ok = source.(storage.FilesystemSnapshotter); !ok {
+				err = errors.NotSupportedf("snapshots of %q filesystems", sourceName)
+			}
+		}
+		var results []error
+		if err == nil {
+			ctx.config.Logger.Debugf("deleting filesystem snapshots: %v", snapshots)
+			results, err = snapshotter.DeleteFilesystemSnapshots(
+				ctx.config.CloudCallContextFunc(stdcontext.Background()), snapshotIds(snapshots),
+			)
+		}
+		remove = append(remove, deletedSnapshots(ctx, snapshots, results, err)...)
+	}
+	if len(remove) == 0 {
+		return nil
+	}
+
+	errorResults, err := ctx.config.Snapshots.RemoveStorageSnapshots(remove)
+	if err != nil {
+		return errors.Annotate(err, "removing storage snapshots from state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "removing storage snapshot %q from state", remove[i])
+		}
+	}
+	return nil
+}
+
+// snapshotIds returns the provider-allocated IDs of the snapshots.
+func snapshotIds(snapshots []params.StorageSnapshotParams) []string {
+	ids := make([]string, len(snapshots))
+	for i, in := range snapshots {
+		ids[i] = in.SnapshotId
+	}
+	return ids
+}
+
+// deletedSnapshots returns the IDs of the snapshots that were deleted,
+// logging those that could not be.
+func deletedSnapshots(ctx *context, snapshots []params.StorageSnapshotParams, results []error, err error) []string {
+	var deleted []string
+	for i, in := range snapshots {
+		deleteErr := err
+		if deleteErr == nil && i >= len(results) {
+			deleteErr = errors.New("missing snapshot result")
+		} else if deleteErr == nil {
+			deleteErr = results[i]
+		}
+		if deleteErr != nil {
+			ctx.config.Logger.Warningf("failed to delete storage snapshot %q: %v", in.Id, deleteErr)
+			continue
+		}
+		deleted = append(deleted, in.Id)
+	}
+	return deleted
+}
+
+// createVolumeSnapshots takes snapshots of volumes, returning the
+// outcome of each.
+func createVolumeSnapshots(ctx *context, args []storage.VolumeSnapshotParams) ([]params.StorageSnapshotInfo, error) {
+	bySource := make(map[storage.ProviderType][]storage.VolumeSnapshotParams)
+	for _, arg := range args {
+		bySource[arg.Provider] = append(bySource[arg.Provider], arg)
+	}
+	var infos []params.StorageSnapshotInfo
+	for providerType, args := range bySource {
+		sourceName := string(providerType)
+		var snapshotter storage.VolumeSnapshotter
+		source, err := volumeSource(ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry)
+		if err == nil {
+			var ok bool
+			if snapshotter, ok = source.(storage.VolumeSnapshotter); !ok {
+				err = errors.NotSupportedf("snapshots of %q volumes", sourceName)
+			}
+		}
+		var results []storage.CreateSnapshotsResult
+		if err == nil {
+			ctx.config.Logger.Debugf("creating volume snapshots: %v", args)
+			results, err = snapshotter.CreateVolumeSnapshots(
+				ctx.config.CloudCallContextFunc(stdcontext.Background()), args,
+			)
+		}
+		for i, arg := range args {
+			info := params.StorageSnapshotInfo{Id: arg.Id}
+			setSnapshotInfo(&info, results, i, err)
+			if info.Error != "" {
+				ctx.config.Logger.Warningf(
+					"failed to snapshot %s: %v", names.ReadableString(arg.Volume), info.Error,
+				)
+			}
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+// createFilesystemSnapshots takes snapshots of filesystems, returning
+// the outcome of each.
+func createFilesystemSnapshots(ctx *context, args []storage.FilesystemSnapshotParams) ([]params.StorageSnapshotInfo, error) {
+	bySource := make(map[storage.ProviderType][]storage.FilesystemSnapshotParams)
+	for _, arg := range args {
+		bySource[arg.Provider] = append(bySource[arg.Provider], arg)
+	}
+	var infos []params.StorageSnapshotInfo
+	for providerType, args := range bySource {
+		sourceName := string(providerType)
+		var snapshotter storage.FilesystemSnapshotter
+		source, err := filesystemSource(ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry)
+		if err == nil {
+			var ok bool
+			if snapshotter, ok = source.(storage.FilesystemSnapshotter); !ok {
+				err = errors.NotSupportedf("snapshots of %q filesystems", sourceName)
+			}
+		}
+		var results []storage.CreateSnapshotsResult
+		if err == nil {
+			ctx.config.Logger.Debugf("creating filesystem snapshots: %v", args)
+			results, err = snapshotter.CreateFilesystemSnapshots(
+				ctx.config.CloudCallContextFunc(stdcontext.Background()), args,
+			)
+		}
+		for i, arg := range args {
+			info := params.StorageSnapshotInfo{Id: arg.Id}
+			setSnapshotInfo(&info, results, i, err)
+			if info.Error != "" {
+				ctx.config.Logger.Warningf(
+					"failed to snapshot %s: %v", names.ReadableString(arg.Filesystem), info.Error,
+				)
+			}
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+// setSnapshotInfo records the i'th result of taking snapshots, or the
+// error that prevented all of them from being taken.
+func setSnapshotInfo(info *params.StorageSnapshotInfo, results []storage.CreateSnapshotsResult, i int, err error) {
+	switch {
+	case err != nil:
+		info.Error = err.Error()
+	case i >= len(results):
+		info.Error = "missing snapshot result"
+	case results[i].Error != nil:
+		info.Error = results[i].Error.Error()
+	case results[i].Snapshot == nil:
+		info.Error = "missing snapshot result"
+	default:
+		info.SnapshotId = results[i].Snapshot.SnapshotId
+		info.Size = results[i].Snapshot.Size
+	}
+}
@@ -128,6 +128,52 @@ type FilesystemAccessor interface {
 	SetFilesystemAttachmentInfo([]params.FilesystemAttachment) ([]params.ErrorResult, error)
 }
 
+// SnapshotAccessor defines an interface used to allow a storage provisioner
+// worker to take and delete storage snapshots.
+type SnapshotAccessor interface {
+	// WatchStorageSnapshots watches for changes to the lifecycles of
+	// storage snapshots that this storage provisioner is responsible for.
+	WatchStorageSnapshots(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// StorageSnapshotParams returns the parameters for taking or
+	// deleting the storage snapshots with the specified IDs.
+	StorageSnapshotParams([]string) ([]params.StorageSnapshotParamsResult, error)
+
+	// StartStorageSnapshots records that the storage snapshots with the
+	// specified IDs are being taken.
+	StartStorageSnapshots([]string) ([]params.ErrorResult, error)
+
+	// RemoveStorageSnapshots removes the dying storage snapshots with
+	// the specified IDs.
+	RemoveStorageSnapshots([]string) ([]params.ErrorResult, error)
+
+	// SetStorageSnapshotInfo records the outcome of taking storage
+	// snapshots.
+	SetStorageSnapshotInfo([]params.StorageSnapshotInfo) ([]params.ErrorResult, error)
+}
+
+// ResizeAccessor defines an interface used to allow a storage provisioner
+// worker to resize volumes and filesystems.
+type ResizeAccessor interface {
+	// WatchVolumeResizes watches for changes to volumes, including
+	// requests to resize them, that this storage provisioner is
+	// responsible for.
+	WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// WatchFilesystemResizes watches for changes to filesystems,
+	// including requests to resize them, that this storage provisioner
+	// is responsible for.
+	WatchFilesystemResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// StorageResizeParams returns the parameters for resizing the
+	// volumes or filesystems with the specified tags.
+	StorageResizeParams([]names.Tag) ([]params.StorageResizeParamsResult, error)
+
+	// SetStorageResized records the outcome of resizing volumes or
+	// filesystems.
+	SetStorageResized([]params.StorageResized) ([]params.ErrorResult, error)
+}
+
 // MachineAccessor defines an interface used to allow a storage provisioner
 // worker to perform machine related operations.
 type MachineAccessor interface {
@@ -210,6 +256,9 @@ func (w *storageProvisioner) loop() error {
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		volumeAttachmentPlansChanges watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
+		storageSnapshotsChanges      watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		filesystemResizesChanges     watcher.StringsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
 	machineChanges := make(chan names.MachineTag)
@@ -303,6 +352,49 @@ func (w *storageProvisioner) loop() error {
 	}
 	filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
 
+	// Storage snapshots are optional, and not supported by older
+	// controllers or for storage scoped to units.
+	if w.config.Snapshots != nil && !ctx.isApplicationKind() {
+		storageSnapshotsWatcher, err := w.config.Snapshots.WatchStorageSnapshots(w.config.Scope)
+		if errors.IsNotSupported(err) {
+			w.config.Logger.Debugf("not taking storage snapshots: %v", err)
+		} else if err != nil {
+			return errors.Annotate(err, "watching storage snapshots")
+		} else {
+			if err := w.catacomb.Add(storageSnapshotsWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			storageSnapshotsChanges = storageSnapshotsWatcher.Changes()
+		}
+	}
+
+	// Storage resizes are optional, and not supported by older
+	// controllers. Storage scoped to units is always volume-backed,
+	// so only volume resizes are watched for it.
+	if w.config.Resizes != nil {
+		volumeResizesWatcher, err := w.config.Resizes.WatchVolumeResizes(w.config.Scope)
+		if errors.IsNotSupported(err) {
+			w.config.Logger.Debugf("not resizing storage: %v", err)
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		} else {
+			if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			volumeResizesChanges = volumeResizesWatcher.Changes()
+		}
+	}
+	if volumeResizesChanges != nil && !ctx.isApplicationKind() {
+		filesystemResizesWatcher, err := w.config.Resizes.WatchFilesystemResizes(w.config.Scope)
+		if err != nil {
+			return errors.Annotate(err, "watching filesystem resizes")
+		}
+		if err := w.catacomb.Add(filesystemResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		filesystemResizesChanges = filesystemResizesWatcher.Changes()
+	}
+
 	for {
 
 		// Check if block devices need to be refreshed.
@@ -364,6 +456,27 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-storageSnapshotsChanges:
+			if !ok {
+				return errors.New("storage snapshots watcher closed")
+			}
+			if err := storageSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-filesystemResizesChanges:
+			if !ok {
+				return errors.New("filesystem resizes watcher closed")
+			}
+			if err := filesystemResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-machineBlockDevicesChanges:
 			if !ok {
 				return errors.New("machine block devices watcher closed")
@@ -2191,6 +2191,43 @@ func (s *caasStorageProvisionerSuite) TestDetachVolumes(c *gc.C) {
 	waitChannel(c, detached, "waiting for volume to be detached")
 }
 
+func (s *caasStorageProvisionerSuite) TestStorageResized(c *gc.C) {
+	resizeAccessor := newMockResizeAccessor()
+	resizeAccessor.storageResizeParams = func(tags []names.Tag) ([]params.StorageResizeParamsResult, error) {
+		c.Assert(tags, jc.DeepEquals, []names.Tag{names.NewVolumeTag("1")})
+		return []params.StorageResizeParamsResult{{
+			Result: &params.StorageResizeParams{
+				Tag:           "volume-1",
+				ProviderId:    "pv-1",
+				Size:          1024,
+				RequestedSize: 2048,
+				Provider:      "dummy",
+			},
+		}}, nil
+	}
+	resized := make(chan interface{})
+	resizeAccessor.setStorageResized = func(args []params.StorageResized) ([]params.ErrorResult, error) {
+		defer close(resized)
+		c.Assert(args, jc.DeepEquals, []params.StorageResized{{
+			Tag:  "volume-1",
+			Size: 2048,
+		}})
+		return make([]params.ErrorResult, len(args)), nil
+	}
+
+	args := &workerArgs{
+		scope:    names.NewApplicationTag("mariadb"),
+		resizes:  resizeAccessor,
+		registry: s.registry,
+	}
+	w := newStorageProvisioner(c, args)
+	defer func() { c.Assert(w.Wait(), gc.IsNil) }()
+	defer w.Kill()
+
+	resizeAccessor.volumesWatcher.changes <- []string{"1"}
+	waitChannel(c, resized, "waiting for resized storage to be set")
+}
+
 func (s *caasStorageProvisionerSuite) TestRemoveVolumes(c *gc.C) {
 	volumeAccessor := newMockVolumeAccessor()
 
@@ -2289,6 +2326,302 @@ func (s *caasStorageProvisionerSuite) TestRemoveFilesystems(c *gc.C) {
 	waitChannel(c, removed, "waiting for filesystem to be removed")
 }
 
+func (s *storageProvisionerSuite) TestStorageSnapshotsTaken(c *gc.C) {
+	snapshotAccessor := newMockSnapshotAccessor()
+	snapshotAccessor.storageSnapshotParams = func(ids []string) ([]params.StorageSnapshotParamsResult, error) {
+		c.Assert(ids, jc.DeepEquals, []string{"1", "2", "3"})
+		return []params.StorageSnapshotParamsResult{{
+			Result: &params.StorageSnapshotParams{
+				Id:        "1",
+				VolumeTag: "volume-1",
+				VolumeId:  "vol-1",
+				Provider:  "dummy",
+				Life:      life.Alive,
+			},
+		}, {
+			// Already taken.
+		}, {
+			Result: &params.StorageSnapshotParams{
+				Id:            "3",
+				FilesystemTag: "filesystem-3",
+				FilesystemId:  "fs-3",
+				Provider:      "dummy",
+				Life:          life.Alive,
+			},
+		}}, nil
+	}
+	infoSet := make(chan interface{})
+	snapshotAccessor.setStorageSnapshotInfo = func(snapshots []params.StorageSnapshotInfo) ([]params.ErrorResult, error) {
+		defer close(infoSet)
+		c.Assert(snapshots, jc.SameContents, []params.StorageSnapshotInfo{{
+			Id:         "1",
+			SnapshotId: "snapshot-vol-1",
+			Size:       1024,
+		}, {
+			Id:    "3",
+			Error: `snapshots of "dummy" filesystems not supported`,
+		}})
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	args := &workerArgs{snapshots: snapshotAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	snapshotAccessor.snapshotsWatcher.changes <- []string{"1", "2", "3"}
+	waitChannel(c, infoSet, "waiting for storage snapshot info to be set")
+}
+
+func (s *storageProvisionerSuite) TestStorageSnapshotFailed(c *gc.C) {
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+		return []storage.CreateSnapshotsResult{{Error: errors.New("no space left")}}, nil
+	}
+	snapshotAccessor := newMockSnapshotAccessor()
+	snapshotAccessor.storageSnapshotParams = func(ids []string) ([]params.StorageSnapshotParamsResult, error) {
+		return []params.StorageSnapshotParamsResult{{
+			Result: &params.StorageSnapshotParams{
+				Id:        "1",
+				VolumeTag: "volume-1",
+				VolumeId:  "vol-1",
+				Provider:  "dummy",
+				Life:      life.Alive,
+			},
+		}}, nil
+	}
+	infoSet := make(chan interface{})
+	snapshotAccessor.setStorageSnapshotInfo = func(snapshots []params.StorageSnapshotInfo) ([]params.ErrorResult, error) {
+		defer close(infoSet)
+		c.Assert(snapshots, jc.DeepEquals, []params.StorageSnapshotInfo{{
+			Id:    "1",
+			Error: "no space left",
+		}})
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	args := &workerArgs{snapshots: snapshotAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	snapshotAccessor.snapshotsWatcher.changes <- []string{"1"}
+	waitChannel(c, infoSet, "waiting for storage snapshot info to be set")
+}
+
+func (s *storageProvisionerSuite) TestStorageSnapshotInterrupted(c *gc.C) {
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+		c.Fatalf("interrupted snapshot taken again")
+		return nil, nil
+	}
+	snapshotAccessor := newMockSnapshotAccessor()
+	snapshotAccessor.storageSnapshotParams = func(ids []string) ([]params.StorageSnapshotParamsResult, error) {
+		return []params.StorageSnapshotParamsResult{{
+			Result: &params.StorageSnapshotParams{
+				Id:        "1",
+				VolumeTag: "volume-1",
+				VolumeId:  "vol-1",
+				Provider:  "dummy",
+				Life:      life.Alive,
+			},
+		}}, nil
+	}
+	snapshotAccessor.startStorageSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		c.Assert(ids, jc.DeepEquals, []string{"1"})
+		return []params.ErrorResult{{
+			Error: &params.Error{Code: params.CodeAlreadyExists, Message: "already started"},
+		}}, nil
+	}
+	infoSet := make(chan interface{})
+	snapshotAccessor.setStorageSnapshotInfo = func(snapshots []params.StorageSnapshotInfo) ([]params.ErrorResult, error) {
+		defer close(infoSet)
+		c.Assert(snapshots, jc.DeepEquals, []params.StorageSnapshotInfo{{
+			Id:    "1",
+			Error: "interrupted while being taken",
+		}})
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	args := &workerArgs{snapshots: snapshotAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	snapshotAccessor.snapshotsWatcher.changes <- []string{"1"}
+	waitChannel(c, infoSet, "waiting for storage snapshot info to be set")
+}
+
+func (s *storageProvisionerSuite) TestStorageSnapshotsRemoved(c *gc.C) {
+	s.provider.deleteVolumeSnapshotsFunc = func(snapshotIds []string) ([]error, error) {
+		c.Assert(snapshotIds, jc.DeepEquals, []string{"snapshot-vol-1", "snapshot-vol-2"})
+		return []error{nil, errors.New("busy")}, nil
+	}
+	snapshotAccessor := newMockSnapshotAccessor()
+	snapshotAccessor.storageSnapshotParams = func(ids []string) ([]params.StorageSnapshotParamsResult, error) {
+		c.Assert(ids, jc.DeepEquals, []string{"1", "2", "3", "4"})
+		return []params.StorageSnapshotParamsResult{{
+			Result: &params.StorageSnapshotParams{
+				Id:         "1",
+				VolumeTag:  "volume-1",
+				Provider:   "dummy",
+				Life:       life.Dying,
+				SnapshotId: "snapshot-vol-1",
+			},
+		}, {
+			Result: &params.StorageSnapshotParams{
+				Id:         "2",
+				VolumeTag:  "volume-2",
+				Provider:   "dummy",
+				Life:       life.Dying,
+				SnapshotId: "snapshot-vol-2",
+			},
+		}, {
+			// Never taken.
+			Result: &params.StorageSnapshotParams{
+				Id:        "3",
+				VolumeTag: "volume-3",
+				Provider:  "dummy",
+				Life:      life.Dying,
+			},
+		}, {
+			// Already removed.
+			Error: &params.Error{Code: params.CodeNotFound, Message: "not found"},
+		}}, nil
+	}
+	snapshotAccessor.startStorageSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		c.Fatalf("dying snapshots started")
+		return nil, nil
+	}
+	removed := make(chan interface{})
+	snapshotAccessor.removeStorageSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		defer close(removed)
+		c.Assert(ids, jc.SameContents, []string{"1", "3"})
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	args := &workerArgs{snapshots: snapshotAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	snapshotAccessor.snapshotsWatcher.changes <- []string{"1", "2", "3", "4"}
+	waitChannel(c, removed, "waiting for storage snapshots to be removed")
+}
+
+func (s *storageProvisionerSuite) TestStorageResized(c *gc.C) {
+	resizeAccessor := newMockResizeAccessor()
+	resizeAccessor.storageResizeParams = func(tags []names.Tag) ([]params.StorageResizeParamsResult, error) {
+		c.Assert(tags, jc.DeepEquals, []names.Tag{names.NewVolumeTag("1"), names.NewVolumeTag("2")})
+		return []params.StorageResizeParamsResult{{
+			Result: &params.StorageResizeParams{
+				Tag:           "volume-1",
+				ProviderId:    "vol-1",
+				Size:          1024,
+				RequestedSize: 2048,
+				Provider:      "dummy",
+			},
+		}, {
+			// No pending resize.
+		}}, nil
+	}
+	resized := make(chan interface{})
+	resizeAccessor.setStorageResized = func(args []params.StorageResized) ([]params.ErrorResult, error) {
+		defer close(resized)
+		c.Assert(args, jc.DeepEquals, []params.StorageResized{{
+			Tag:  "volume-1",
+			Size: 2048,
+		}})
+		return make([]params.ErrorResult, len(args)), nil
+	}
+	statusSetter := &mockStatusSetter{}
+
+	args := &workerArgs{resizes: resizeAccessor, statusSetter: statusSetter, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	resizeAccessor.volumesWatcher.changes <- []string{"1", "2"}
+	waitChannel(c, resized, "waiting for resized storage to be set")
+	worker.Kill()
+	c.Assert(worker.Wait(), gc.IsNil)
+	c.Assert(statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "volume-1",
+		Status: "detached",
+	}})
+}
+
+func (s *storageProvisionerSuite) TestStorageResizeFailed(c *gc.C) {
+	resizeAccessor := newMockResizeAccessor()
+	resizeAccessor.storageResizeParams = func(tags []names.Tag) ([]params.StorageResizeParamsResult, error) {
+		return []params.StorageResizeParamsResult{{
+			Result: &params.StorageResizeParams{
+				Tag:           "filesystem-1",
+				ProviderId:    "fs-1",
+				Size:          1024,
+				RequestedSize: 2048,
+				Provider:      "dummy",
+			},
+		}}, nil
+	}
+	resizeAccessor.setStorageResized = func(args []params.StorageResized) ([]params.ErrorResult, error) {
+		c.Assert(args, jc.DeepEquals, []params.StorageResized{{
+			Tag: "filesystem-1",
+		}})
+		return make([]params.ErrorResult, len(args)), nil
+	}
+	statusSet := make(chan interface{})
+	statusSetter := &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			defer close(statusSet)
+			c.Assert(args, jc.DeepEquals, []params.EntityStatusArgs{{
+				Tag:    "filesystem-1",
+				Status: "error",
+				Info:   `resizing to 2048MiB: resizing "dummy" filesystems not supported`,
+			}})
+			return nil
+		},
+	}
+
+	args := &workerArgs{resizes: resizeAccessor, statusSetter: statusSetter, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	resizeAccessor.filesystemsWatcher.changes <- []string{"1"}
+	waitChannel(c, statusSet, "waiting for filesystem status to be set")
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeFromSnapshotNotSupported(c *gc.C) {
+	s.provider.volumeSourceFunc = func(*storage.Config) (storage.VolumeSource, error) {
+		return &nonSnapshottingVolumeSource{&dummyVolumeSource{provider: s.provider}}, nil
+	}
+	statusSet := make(chan interface{})
+	statusSetter := &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			defer close(statusSet)
+			c.Assert(args, jc.DeepEquals, []params.EntityStatusArgs{{
+				Tag:    "volume-1",
+				Status: "error",
+				Info:   "creating volumes from snapshots not supported",
+			}})
+			return nil
+		},
+	}
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-1"] = "already-provisioned-1"
+	volumeAccessor.snapshotIds["volume-1"] = "snapshot-1"
+
+	args := &workerArgs{volumes: volumeAccessor, statusSetter: statusSetter, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	waitChannel(c, statusSet, "waiting for volume status to be set")
+}
+
+// nonSnapshottingVolumeSource is a volume source that can't take
+// snapshots.
+type nonSnapshottingVolumeSource struct {
+	storage.VolumeSource
+}
+
 func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 	if args == nil {
 		args = &workerArgs{}
@@ -2319,17 +2652,28 @@ func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 	if args.statusSetter == nil {
 		args.statusSetter = &mockStatusSetter{}
 	}
+	var snapshots storageprovisioner.SnapshotAccessor
+	if args.snapshots != nil {
+		snapshots = args.snapshots
+	}
+	var resizes storageprovisioner.ResizeAccessor
+	if args.resizes != nil {
+		resizes = args.resizes
+	}
 	worker, err := storageprovisioner.NewStorageProvisioner(storageprovisioner.Config{
-		Scope:       args.scope,
-		StorageDir:  storageDir,
-		Volumes:     args.volumes,
-		Filesystems: args.filesystems,
-		Life:        args.life,
-		Registry:    args.registry,
-		Machines:    args.machines,
-		Status:      args.statusSetter,
-		Clock:       args.clock,
-		Logger:      loggo.GetLogger("test"),
+		Scope:        args.scope,
+		StorageDir:   storageDir,
+		Volumes:      args.volumes,
+		Filesystems:  args.filesystems,
+		Snapshots:    snapshots,
+		Resizes:      resizes,
+		Life:         args.life,
+		Registry:     args.registry,
+		Machines:     args.machines,
+		Applications: newMockApplicationsWatcher(nil),
+		Status:       args.statusSetter,
+		Clock:        args.clock,
+		Logger:       loggo.GetLogger("test"),
 		CloudCallContextFunc: func(_ stdcontext.Context) context.ProviderCallContext {
 			return context.NewEmptyCloudCallContext()
 		},
@@ -2342,6 +2686,8 @@ type workerArgs struct {
 	scope        names.Tag
 	volumes      *mockVolumeAccessor
 	filesystems  *mockFilesystemAccessor
+	snapshots    *mockSnapshotAccessor
+	resizes      *mockResizeAccessor
 	life         *mockLifecycleManager
 	registry     storage.ProviderRegistry
 	machines     *mockMachineAccessor
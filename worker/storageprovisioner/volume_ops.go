@@ -470,8 +470,14 @@ func validateVolumeParams(
 ) ([]storage.VolumeParams, []error) {
 	valid := make([]storage.VolumeParams, 0, len(volumeParams))
 	results := make([]error, len(volumeParams))
+	_, canSnapshot := volumeSource.(storage.VolumeSnapshotter)
 	for i, params := range volumeParams {
-		err := volumeSource.ValidateVolumeParams(params)
+		var err error
+		if params.SnapshotId != "" && !canSnapshot {
+			err = errors.NotSupportedf("creating volumes from snapshots")
+		} else {
+			err = volumeSource.ValidateVolumeParams(params)
+		}
 		if err == nil {
 			valid = append(valid, params)
 		}
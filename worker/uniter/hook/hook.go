@@ -70,6 +70,17 @@ type Info struct {
 	SecretLabel string `yaml:"secret-label,omitempty"`
 }
 
+// StorageResized is run when the volume or filesystem backing a storage
+// instance attached to the unit has grown, so that the charm can grow
+// any filesystem it manages on it.
+const StorageResized hooks.Kind = "storage-resized"
+
+// IsStorage returns whether the kind represents a storage hook,
+// including those not defined by the charm package.
+func IsStorage(kind hooks.Kind) bool {
+	return kind.IsStorage() || kind == StorageResized
+}
+
 // SecretHookRequiresRevision returns true if the hook context needs a secret revision.
 func SecretHookRequiresRevision(kind hooks.Kind) bool {
 	return kind == hooks.SecretRemove || kind == hooks.SecretExpired
@@ -127,7 +138,7 @@ func (hi Info) Validate() error {
 		return nil
 	case hooks.Action:
 		return errors.Errorf("hooks.Kind Action is deprecated")
-	case hooks.StorageAttached, hooks.StorageDetaching:
+	case hooks.StorageAttached, hooks.StorageDetaching, StorageResized:
 		if !names.IsValidStorage(hi.StorageId) {
 			return errors.Errorf("invalid storage ID %q", hi.StorageId)
 		}
@@ -37,7 +37,7 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 		if err != nil {
 			return "", err
 		}
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		if err := opc.u.storage.ValidateHook(hi); err != nil {
 			return "", err
 		}
@@ -73,7 +73,7 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 	case hi.Kind.IsWorkload():
 	case hi.Kind.IsRelation():
 		return opc.u.relationStateTracker.CommitHook(hi)
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		return opc.u.storage.CommitHook(hi)
 	case hi.Kind.IsSecret():
 		return opc.u.secretsTracker.CommitHook(hi)
@@ -49,7 +49,7 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; unit: %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
 	case rh.info.Kind.IsSecret():
 		if rh.info.SecretRevision == 0 || !hook.SecretHookRequiresRevision(rh.info.Kind) {
@@ -160,4 +160,5 @@ type StorageSnapshot struct {
 	Life     life.Value
 	Attached bool
 	Location string
+	Size     uint64
 }
@@ -277,7 +277,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
-	if hookInfo.Kind.IsStorage() {
+	if hook.IsStorage(hookInfo.Kind) {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		storageName, err := names.StorageName(hookInfo.StorageId)
 		if err != nil {
@@ -47,6 +47,11 @@ type Attachments struct {
 
 	stateOps *stateOps
 
+	// observed records, for each attached storage instance, the
+	// latest size reported by the controller. The size last reported
+	// to the charm is persisted in storageState.
+	observed map[names.StorageTag]uint64
+
 	// TODO: hml
 	// Can this be a names.Set?
 	storageState *State
@@ -65,6 +70,7 @@ func NewAttachments(
 		abort:    abort,
 		stateOps: NewStateOps(rw),
 		pending:  names.NewSet(),
+		observed: make(map[names.StorageTag]uint64),
 	}
 	if err := a.init(); err != nil {
 		return nil, err
@@ -106,6 +112,9 @@ func (a *Attachments) init() error {
 			continue
 		}
 		newStateStorage.Attach(storageTag.Id())
+		if size, ok := existingStorageState.Size(storageTag.Id()); ok {
+			newStateStorage.SetSize(storageTag.Id(), size)
+		}
 	}
 	a.storageState = newStateStorage
 	if a.storageState.Empty() {
@@ -141,9 +150,10 @@ func (a *Attachments) ValidateHook(hi hook.Info) error {
 // CommitHook persists the State change encoded in the supplied storage
 // hook, or returns an error if the hook is invalid given current State.
 func (a *Attachments) CommitHook(hi hook.Info) error {
-	if !hi.Kind.IsStorage() {
+	if !hook.IsStorage(hi.Kind) {
 		return errors.Errorf("not a storage hook: %#v", hi)
 	}
+	storageTag := names.NewStorageTag(hi.StorageId)
 	if hi.Kind == hooks.StorageDetaching {
 		err := a.storageState.Detach(hi.StorageId)
 		if err != nil {
@@ -151,12 +161,14 @@ func (a *Attachments) CommitHook(hi hook.Info) error {
 		}
 	} else {
 		a.storageState.Attach(hi.StorageId)
+		if size, ok := a.observed[storageTag]; ok {
+			a.storageState.SetSize(hi.StorageId, size)
+		}
 	}
 	if err := a.stateOps.Write(a.storageState); err != nil {
 		return err
 	}
 
-	storageTag := names.NewStorageTag(hi.StorageId)
 	switch hi.Kind {
 	case hooks.StorageAttached:
 		a.pending.Remove(storageTag)
@@ -173,5 +185,21 @@ func (a *Attachments) removeStorageAttachment(tag names.StorageTag) error {
 		return errors.Annotate(err, "removing storage attachment")
 	}
 	a.pending.Remove(tag)
+	delete(a.observed, tag)
 	return nil
 }
+
+// resized records the latest observed size of the attached storage
+// with the specified tag, and reports whether it has grown beyond the
+// size last reported to the charm. Storage attached by an agent that
+// did not record sizes has no known size; its first observed size is
+// taken as the size known to the charm.
+func (a *Attachments) resized(tag names.StorageTag, size uint64) bool {
+	a.observed[tag] = size
+	known, ok := a.storageState.Size(tag.Id())
+	if !ok {
+		a.storageState.SetSize(tag.Id(), size)
+		return false
+	}
+	return size > known
+}
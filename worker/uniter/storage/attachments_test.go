@@ -321,6 +321,7 @@ func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 				Life:     life.Alive,
 				Location: "/dev/sdb",
 				Attached: true,
+				Size:     1024,
 			},
 		},
 	}, &mockOperations{})
@@ -328,6 +329,7 @@ func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 	c.Assert(att.Pending(), gc.Equals, 1)
 
 	s.storSt.Attach(storageTag.Id())
+	s.storSt.SetSize(storageTag.Id(), 1024)
 	s.expectSetState(c, "")
 	err = att.CommitHook(hook.Info{
 		Kind:      hooks.StorageAttached,
@@ -347,6 +349,111 @@ func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 	c.Assert(removed, jc.IsTrue)
 }
 
+func (s *attachmentsSuite) TestAttachmentsStorageResized(c *gc.C) {
+	defer s.mockStateOpsSuite.setupMocks(c).Finish()
+
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+	storageTag := names.NewStorageTag("data/0")
+
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return []params.StorageAttachmentId{{
+				StorageTag: storageTag.String(),
+				UnitTag:    unitTag.String(),
+			}}, nil
+		},
+	}
+
+	s.storSt.Attach(storageTag.Id())
+	s.expectState(c)
+	s.expectSetState(c, "")
+	att, err := storage.NewAttachments(st, unitTag, s.mockStateOps, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(loggo.GetLogger("test"), att, s.modelType)
+
+	localState := resolver.LocalState{State: operation.State{
+		Kind: operation.Continue,
+	}}
+	snapshot := func(size uint64) remotestate.Snapshot {
+		return remotestate.Snapshot{
+			Life: life.Alive,
+			Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+				storageTag: {
+					Kind:     params.StorageKindBlock,
+					Life:     life.Alive,
+					Location: "/dev/sdb",
+					Attached: true,
+					Size:     size,
+				},
+			},
+		}
+	}
+
+	// The first observed size is taken to be known to the charm.
+	_, err = r.NextOp(localState, snapshot(1024), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	op, err := r.NextOp(localState, snapshot(2048), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+
+	s.storSt.SetSize(storageTag.Id(), 2048)
+	s.expectSetState(c, "")
+	err = att.CommitHook(hook.Info{
+		Kind:      hook.StorageResized,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = r.NextOp(localState, snapshot(2048), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *attachmentsSuite) TestAttachmentsStorageResizedWhileStopped(c *gc.C) {
+	defer s.mockStateOpsSuite.setupMocks(c).Finish()
+
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+	storageTag := names.NewStorageTag("data/0")
+
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return []params.StorageAttachmentId{{
+				StorageTag: storageTag.String(),
+				UnitTag:    unitTag.String(),
+			}}, nil
+		},
+	}
+
+	// The charm was last told the storage was 1024 MiB, before the
+	// agent was restarted.
+	s.storSt.Attach(storageTag.Id())
+	s.storSt.SetSize(storageTag.Id(), 1024)
+	s.expectState(c)
+	s.expectSetState(c, "")
+	att, err := storage.NewAttachments(st, unitTag, s.mockStateOps, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(loggo.GetLogger("test"), att, s.modelType)
+
+	localState := resolver.LocalState{State: operation.State{
+		Kind: operation.Continue,
+	}}
+	op, err := r.NextOp(localState, remotestate.Snapshot{
+		Life: life.Alive,
+		Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+			storageTag: {
+				Kind:     params.StorageKindBlock,
+				Life:     life.Alive,
+				Location: "/dev/sdb",
+				Attached: true,
+				Size:     2048,
+			},
+		},
+	}, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+}
+
 func (s *attachmentsSuite) TestAttachmentsSetDying(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
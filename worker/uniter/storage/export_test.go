@@ -6,3 +6,12 @@ package storage
 func Storage(st *State) map[string]bool {
 	return st.storage
 }
+
+func Sizes(st *State) map[string]uint64 {
+	return st.sizes
+}
+
+// StateDoc returns the serialized form of the State.
+func StateDoc(st *State) interface{} {
+	return stateDoc{Attached: st.storage, Sizes: st.sizes}
+}
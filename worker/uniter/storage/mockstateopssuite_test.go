@@ -30,7 +30,7 @@ func (s *mockStateOpsSuite) setupMocks(c *gc.C) *gomock.Controller {
 }
 
 func (s *mockStateOpsSuite) expectSetState(c *gc.C, errStr string) {
-	data, err := yaml.Marshal(storage.Storage(s.storSt))
+	data, err := yaml.Marshal(storage.StateDoc(s.storSt))
 	c.Assert(err, jc.ErrorIsNil)
 	strStorageState := string(data)
 	if errStr != "" {
@@ -48,7 +48,7 @@ func (s *mockStateOpsSuite) expectSetStateEmpty(c *gc.C) {
 }
 
 func (s *mockStateOpsSuite) expectState(c *check.C) {
-	data, err := yaml.Marshal(storage.Storage(s.storSt))
+	data, err := yaml.Marshal(storage.StateDoc(s.storSt))
 	c.Assert(err, checkers.ErrorIsNil)
 	strStorageState := string(data)
 
@@ -184,7 +184,11 @@ func (s *storageResolver) nextHookOp(
 		attached, ok := s.storage.storageState.Attached(tag.Id())
 		if ok && attached {
 			// Once the storage is attached, we only care about
-			// lifecycle State changes.
+			// lifecycle State changes, and the storage growing.
+			if snap.Attached && s.storage.resized(tag, snap.Size) {
+				hookInfo.Kind = hook.StorageResized
+				break
+			}
 			return nil, resolver.ErrNoOperation
 		}
 		// The storage-attached hook has not been committed, so add the
@@ -198,6 +202,7 @@ func (s *storageResolver) nextHookOp(
 		}
 		// The storage is alive, but we haven't previously run the
 		// "storage-attached" hook. Do so now.
+		s.storage.observed[tag] = snap.Size
 		hookInfo.Kind = hooks.StorageAttached
 	case life.Dying:
 		attached, ok := s.storage.storageState.Attached(tag.Id())
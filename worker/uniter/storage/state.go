@@ -18,6 +18,11 @@ type State struct {
 	// key is the storage tag id, the value is attached
 	// or not.
 	storage map[string]bool
+
+	// sizes records, for each attached storage instance, the size
+	// last reported to the charm by a storage hook. The key is the
+	// storage tag id.
+	sizes map[string]uint64
 }
 
 func (s *State) Detach(storageID string) error {
@@ -25,6 +30,7 @@ func (s *State) Detach(storageID string) error {
 		return errors.NotFoundf("storage %q", storageID)
 	}
 	s.storage[storageID] = false
+	delete(s.sizes, storageID)
 	return nil
 }
 
@@ -37,12 +43,27 @@ func (s *State) Attached(storageID string) (bool, bool) {
 	return attached, ok
 }
 
+// Size returns the size of the storage last reported to the charm,
+// and whether it is known.
+func (s *State) Size(storageID string) (uint64, bool) {
+	size, ok := s.sizes[storageID]
+	return size, ok
+}
+
+// SetSize records the size of the storage reported to the charm.
+func (s *State) SetSize(storageID string, size uint64) {
+	s.sizes[storageID] = size
+}
+
 func (s *State) Empty() bool {
 	return len(s.storage) == 0
 }
 
 func NewState() *State {
-	return &State{storage: make(map[string]bool)}
+	return &State{
+		storage: make(map[string]bool),
+		sizes:   make(map[string]uint64),
+	}
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -58,7 +79,7 @@ func (s *State) ValidateHook(hi hook.Info) (err error) {
 		if attached {
 			return errors.New("storage already attached")
 		}
-	case hooks.StorageDetaching:
+	case hooks.StorageDetaching, hook.StorageResized:
 		if !attached {
 			return errors.New("storage not attached")
 		}
@@ -83,10 +104,17 @@ func NewStateOps(rw UnitStateReadWriter) *stateOps {
 	return &stateOps{unitStateRW: rw}
 }
 
+// stateDoc is the serialized form of State. Storage State written by
+// older agents is a bare map of storage attachments; storage tag ids
+// always contain a "/", so they never clash with the stateDoc keys.
+type stateDoc struct {
+	Attached map[string]bool   `yaml:"attached"`
+	Sizes    map[string]uint64 `yaml:"sizes,omitempty"`
+}
+
 // Read reads a storage State from the controller. If the saved State
 // does not exist it returns NotFound and a new state.
 func (f *stateOps) Read() (*State, error) {
-	var stor map[string]bool
 	unitState, err := f.unitStateRW.State()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -94,10 +122,28 @@ func (f *stateOps) Read() (*State, error) {
 	if unitState.StorageState == "" {
 		return NewState(), errors.NotFoundf("storage State")
 	}
-	if err = yaml.Unmarshal([]byte(unitState.StorageState), &stor); err != nil {
+	var raw map[string]interface{}
+	if err = yaml.Unmarshal([]byte(unitState.StorageState), &raw); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &State{storage: stor}, nil
+	st := NewState()
+	if _, ok := raw["attached"]; !ok {
+		if err = yaml.Unmarshal([]byte(unitState.StorageState), &st.storage); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return st, nil
+	}
+	var doc stateDoc
+	if err = yaml.Unmarshal([]byte(unitState.StorageState), &doc); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for id, attached := range doc.Attached {
+		st.storage[id] = attached
+	}
+	for id, size := range doc.Sizes {
+		st.sizes[id] = size
+	}
+	return st, nil
 }
 
 // Write stores the supplied State storage map on the controller.  If
@@ -108,7 +154,7 @@ func (f *stateOps) Write(st *State) error {
 	}
 	var str string
 	if len(st.storage) > 0 {
-		data, err := yaml.Marshal(st.storage)
+		data, err := yaml.Marshal(stateDoc{Attached: st.storage, Sizes: st.sizes})
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/storage"
 )
@@ -58,6 +59,25 @@ func (s *stateSuite) TestDetachErr(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *stateSuite) TestSize(c *gc.C) {
+	s.st.Attach(s.tag1.Id())
+	_, found := s.st.Size(s.tag1.Id())
+	c.Assert(found, jc.IsFalse)
+	s.st.SetSize(s.tag1.Id(), 1024)
+	size, found := s.st.Size(s.tag1.Id())
+	c.Assert(found, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(1024))
+}
+
+func (s *stateSuite) TestDetachForgetsSize(c *gc.C) {
+	s.st.Attach(s.tag1.Id())
+	s.st.SetSize(s.tag1.Id(), 1024)
+	err := s.st.Detach(s.tag1.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, found := s.st.Size(s.tag1.Id())
+	c.Assert(found, jc.IsFalse)
+}
+
 func (s *stateSuite) TestEmpty(c *gc.C) {
 	c.Assert(s.st.Empty(), jc.IsTrue)
 }
@@ -85,6 +105,19 @@ func (s *stateSuite) TestValidateHookStorageDetachingError(c *gc.C) {
 
 }
 
+func (s *stateSuite) TestValidateHookStorageResized(c *gc.C) {
+	s.st.Attach(s.tag1.Id())
+	hi := hook.Info{Kind: hook.StorageResized, StorageId: s.tag1.Id()}
+	err := s.st.ValidateHook(hi)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *stateSuite) TestValidateHookStorageResizedError(c *gc.C) {
+	hi := hook.Info{Kind: hook.StorageResized, StorageId: s.tag1.Id()}
+	err := s.st.ValidateHook(hi)
+	c.Assert(err, gc.ErrorMatches, `inappropriate "storage-resized" hook for storage "test/1": storage not attached`)
+}
+
 func (s *stateSuite) TestValidateHookStorageAttached(c *gc.C) {
 	hi := hook.Info{Kind: hooks.StorageAttached, StorageId: s.tag1.Id()}
 	err := s.st.ValidateHook(hi)
@@ -131,6 +164,29 @@ func (s *stateOpsSuite) TestRead(c *gc.C) {
 	c.Assert(storage.Storage(obtainedSt), gc.DeepEquals, storage.Storage(s.storSt))
 }
 
+func (s *stateOpsSuite) TestReadSizes(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.storSt.SetSize(s.tag1.Id(), 1024)
+	s.expectState(c)
+	ops := storage.NewStateOps(s.mockStateOps)
+	obtainedSt, err := ops.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.Storage(obtainedSt), gc.DeepEquals, storage.Storage(s.storSt))
+	c.Assert(storage.Sizes(obtainedSt), gc.DeepEquals, map[string]uint64{"test/1": 1024})
+}
+
+func (s *stateOpsSuite) TestReadLegacy(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.mockStateOps.EXPECT().State().Return(params.UnitStateResult{
+		StorageState: "test/1: true\ntest/2: false\ntest/3: true\n",
+	}, nil)
+	ops := storage.NewStateOps(s.mockStateOps)
+	obtainedSt, err := ops.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.Storage(obtainedSt), gc.DeepEquals, storage.Storage(s.storSt))
+	c.Assert(storage.Sizes(obtainedSt), gc.HasLen, 0)
+}
+
 func (s *stateOpsSuite) TestReadNotFound(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 	s.expectStateNotFound()
@@ -0,0 +1,158 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/worker/v3"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+// StorageUsageAccessor describes the facade methods used to report the
+// usage of the storage attached to a unit.
+type StorageUsageAccessor interface {
+	UnitStorageAttachments(names.UnitTag) ([]params.StorageAttachmentId, error)
+	StorageAttachment(names.StorageTag, names.UnitTag) (params.StorageAttachment, error)
+	SetStorageUsage(names.UnitTag, map[names.StorageTag]params.StorageUsage) error
+}
+
+// StatFSFunc returns the usage of the filesystem mounted at the given
+// path.
+type StatFSFunc func(path string) (params.StorageUsage, error)
+
+// MountPointFunc returns the path at which the block device with the
+// given path is mounted. If it is not mounted, an error satisfying
+// errors.IsNotFound is returned.
+type MountPointFunc func(devicePath string) (string, error)
+
+const (
+	storageUsageReportInterval = 5 * time.Minute
+)
+
+type storageUsageReporter struct {
+	logger  Logger
+	clock   clock.Clock
+	tomb    tomb.Tomb
+	st      StorageUsageAccessor
+	unitTag names.UnitTag
+	statfs  StatFSFunc
+
+	mountPoint MountPointFunc
+}
+
+// NewStorageUsageReporter starts a worker that periodically reports the
+// usage of the storage attached to the unit. Filesystems are reported
+// as mounted; block devices are reported if the charm has mounted a
+// filesystem on them.
+func NewStorageUsageReporter(
+	logger Logger,
+	clock clock.Clock,
+	st StorageUsageAccessor,
+	unitTag names.UnitTag,
+	statfs StatFSFunc,
+	mountPoint MountPointFunc,
+) worker.Worker {
+	if statfs == nil {
+		statfs = statFS
+	}
+	if mountPoint == nil {
+		mountPoint = blockDeviceMountPoint
+	}
+	r := &storageUsageReporter{
+		logger:     logger,
+		clock:      clock,
+		st:         st,
+		unitTag:    unitTag,
+		statfs:     statfs,
+		mountPoint: mountPoint,
+	}
+	r.tomb.Go(r.run)
+	return r
+}
+
+// Kill is part of the worker.Worker interface.
+func (r *storageUsageReporter) Kill() {
+	r.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (r *storageUsageReporter) Wait() error {
+	return r.tomb.Wait()
+}
+
+func (r *storageUsageReporter) run() error {
+	timer := r.clock.NewTimer(storageUsageReportInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-r.tomb.Dying():
+			return tomb.ErrDying
+		case <-timer.Chan():
+			err := r.report()
+			if errors.Is(err, errors.NotImplemented) {
+				// The controller is too old to record storage usage.
+				r.logger.Debugf("not reporting storage usage: %v", err)
+				return nil
+			} else if err != nil {
+				r.logger.Warningf("cannot report storage usage: %v", err)
+			}
+			timer.Reset(storageUsageReportInterval)
+		}
+	}
+}
+
+func (r *storageUsageReporter) report() error {
+	ids, err := r.st.UnitStorageAttachments(r.unitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	usage := make(map[names.StorageTag]params.StorageUsage)
+	for _, id := range ids {
+		storageTag, err := names.ParseStorageTag(id.StorageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		attachment, err := r.st.StorageAttachment(storageTag, r.unitTag)
+		if params.IsCodeNotProvisioned(err) || params.IsCodeNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if attachment.Life != life.Alive || attachment.Location == "" {
+			continue
+		}
+		path := attachment.Location
+		switch attachment.Kind {
+		case params.StorageKindFilesystem:
+		case params.StorageKindBlock:
+			// The usage of a block device is only known if
+			// the charm has made a filesystem on it.
+			path, err = r.mountPoint(attachment.Location)
+			if err != nil {
+				r.logger.Debugf("cannot get usage of %s on %q: %v", storageTag.Id(), attachment.Location, err)
+				continue
+			}
+		default:
+			continue
+		}
+		u, err := r.statfs(path)
+		if err != nil {
+			r.logger.Debugf("cannot get usage of %s at %q: %v", storageTag.Id(), path, err)
+			continue
+		}
+		u.Updated = r.clock.Now()
+		usage[storageTag] = u
+	}
+	if len(usage) == 0 {
+		return nil
+	}
+	return r.st.SetStorageUsage(r.unitTag, usage)
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/juju/errors"
+	"github.com/moby/sys/mountinfo"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// statFS returns the usage of the filesystem mounted at the given path.
+func statFS(path string) (params.StorageUsage, error) {
+	// Note: do not use golang.org/x/sys/unix for this; see lp:1632541.
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return params.StorageUsage{}, errors.Trace(err)
+	}
+	blockSize := uint64(st.Bsize)
+	return params.StorageUsage{
+		BytesUsed:   (st.Blocks - st.Bfree) * blockSize,
+		BytesTotal:  st.Blocks * blockSize,
+		InodesUsed:  st.Files - st.Ffree,
+		InodesTotal: st.Files,
+	}, nil
+}
+
+// blockDeviceMountPoint returns the path at which the block device is
+// mounted. If the device is not mounted, an error satisfying
+// errors.IsNotFound is returned.
+func blockDeviceMountPoint(devicePath string) (string, error) {
+	device, err := filepath.EvalSymlinks(devicePath)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	mounts, err := mountinfo.GetMounts(func(info *mountinfo.Info) (skip, stop bool) {
+		if !strings.HasPrefix(info.Source, "/") {
+			return true, false
+		}
+		source, err := filepath.EvalSymlinks(info.Source)
+		return err != nil || source != device, false
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(mounts) == 0 {
+		return "", errors.NotFoundf("mount point of %q", devicePath)
+	}
+	return mounts[0].Mountpoint, nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+//go:build !linux
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// statFS is not supported on this platform.
+func statFS(path string) (params.StorageUsage, error) {
+	return params.StorageUsage{}, errors.NotSupportedf("storage usage")
+}
+
+// blockDeviceMountPoint is not supported on this platform.
+func blockDeviceMountPoint(devicePath string) (string, error) {
+	return "", errors.NotSupportedf("block device mount points")
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter"
+)
+
+type storageUsageReporterSuite struct{}
+
+var _ = gc.Suite(&storageUsageReporterSuite{})
+
+type fakeStorageUsageAccessor struct {
+	attachments map[string]params.StorageAttachment
+	reported    chan map[names.StorageTag]params.StorageUsage
+	err         error
+}
+
+func (f *fakeStorageUsageAccessor) UnitStorageAttachments(unitTag names.UnitTag) ([]params.StorageAttachmentId, error) {
+	var ids []params.StorageAttachmentId
+	for storageTag := range f.attachments {
+		ids = append(ids, params.StorageAttachmentId{StorageTag: storageTag, UnitTag: unitTag.String()})
+	}
+	return ids, nil
+}
+
+func (f *fakeStorageUsageAccessor) StorageAttachment(storageTag names.StorageTag, _ names.UnitTag) (params.StorageAttachment, error) {
+	return f.attachments[storageTag.String()], nil
+}
+
+func (f *fakeStorageUsageAccessor) SetStorageUsage(_ names.UnitTag, usage map[names.StorageTag]params.StorageUsage) error {
+	f.reported <- usage
+	return f.err
+}
+
+func (s *storageUsageReporterSuite) TestReport(c *gc.C) {
+	st := &fakeStorageUsageAccessor{
+		attachments: map[string]params.StorageAttachment{
+			"storage-data-0": {
+				Kind:     params.StorageKindFilesystem,
+				Life:     life.Alive,
+				Location: "/srv/data",
+			},
+			"storage-logs-0": {
+				Kind:     params.StorageKindFilesystem,
+				Life:     life.Dying,
+				Location: "/srv/logs",
+			},
+			"storage-disks-0": {
+				Kind:     params.StorageKindBlock,
+				Life:     life.Alive,
+				Location: "/dev/sdb",
+			},
+			"storage-disks-1": {
+				Kind:     params.StorageKindBlock,
+				Life:     life.Alive,
+				Location: "/dev/sdc",
+			},
+		},
+		reported: make(chan map[names.StorageTag]params.StorageUsage, 1),
+	}
+	statfs := func(path string) (params.StorageUsage, error) {
+		switch path {
+		case "/srv/data":
+			return params.StorageUsage{
+				BytesUsed:   1024,
+				BytesTotal:  4096,
+				InodesUsed:  10,
+				InodesTotal: 100,
+			}, nil
+		case "/mnt/disks":
+			return params.StorageUsage{
+				BytesUsed:   2048,
+				BytesTotal:  8192,
+				InodesUsed:  20,
+				InodesTotal: 200,
+			}, nil
+		}
+		c.Errorf("unexpected path %q", path)
+		return params.StorageUsage{}, errors.NotFoundf("path %q", path)
+	}
+	mountPoint := func(devicePath string) (string, error) {
+		if devicePath == "/dev/sdb" {
+			return "/mnt/disks", nil
+		}
+		return "", errors.NotFoundf("mount point of %q", devicePath)
+	}
+	now := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	clock := testclock.NewClock(now)
+	w := uniter.NewStorageUsageReporter(loggo.GetLogger("test"), clock, st, names.NewUnitTag("mysql/0"), statfs, mountPoint)
+	defer workertest.CleanKill(c, w)
+
+	err := clock.WaitAdvance(5*time.Minute, testing.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case usage := <-st.reported:
+		c.Assert(usage, jc.DeepEquals, map[names.StorageTag]params.StorageUsage{
+			names.NewStorageTag("data/0"): {
+				BytesUsed:   1024,
+				BytesTotal:  4096,
+				InodesUsed:  10,
+				InodesTotal: 100,
+				Updated:     now.Add(5 * time.Minute),
+			},
+			names.NewStorageTag("disks/0"): {
+				BytesUsed:   2048,
+				BytesTotal:  8192,
+				InodesUsed:  20,
+				InodesTotal: 200,
+				Updated:     now.Add(5 * time.Minute),
+			},
+		})
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for storage usage")
+	}
+}
+
+func (s *storageUsageReporterSuite) TestNotImplementedStopsReporting(c *gc.C) {
+	st := &fakeStorageUsageAccessor{
+		attachments: map[string]params.StorageAttachment{
+			"storage-data-0": {
+				Kind:     params.StorageKindFilesystem,
+				Life:     life.Alive,
+				Location: "/srv/data",
+			},
+		},
+		reported: make(chan map[names.StorageTag]params.StorageUsage, 1),
+		err:      errors.NotImplementedf("SetStorageUsage() (need V20+)"),
+	}
+	statfs := func(path string) (params.StorageUsage, error) {
+		return params.StorageUsage{}, nil
+	}
+	clock := testclock.NewClock(time.Time{})
+	w := uniter.NewStorageUsageReporter(loggo.GetLogger("test"), clock, st, names.NewUnitTag("mysql/0"), statfs, nil)
+
+	err := clock.WaitAdvance(5*time.Minute, testing.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, jc.ErrorIsNil)
+}
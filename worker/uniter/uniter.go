@@ -998,6 +998,11 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		}
 	}
 
+	usageReporter := NewStorageUsageReporter(u.logger.Child("storageusage"), u.clock, u.st, unitTag, nil, nil)
+	if err := u.catacomb.Add(usageReporter); err != nil {
+		return errors.Trace(err)
+	}
+
 	return nil
 }
 